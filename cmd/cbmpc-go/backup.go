@@ -0,0 +1,258 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	stdrsa "crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/rsa"
+)
+
+const backupVersion = 1
+
+// backupFile holds a key share file encrypted to a backup KEM public key:
+// the KEM shared secret keys AES-256-GCM over the whole key file, and the
+// public fields are bound as associated data.
+type backupFile struct {
+	Version    int    `json:"version"`
+	KEM        string `json:"kem"`
+	KEMBits    int    `json:"kem_bits"`
+	Party      string `json:"party"`
+	Curve      string `json:"curve"`
+	PublicKey  []byte `json:"public_key"`
+	KEMCipher  []byte `json:"kem_ciphertext"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func (b *backupFile) aad() []byte {
+	h := sha256.New()
+	fmt.Fprintf(h, "cbmpc-go backup v%d\x00%s-%d\x00%s\x00%s\x00", b.Version, b.KEM, b.KEMBits, b.Party, b.Curve)
+	h.Write(b.PublicKey)
+	return h.Sum(nil)
+}
+
+func backupAEAD(ss []byte) (cipher.AEAD, error) {
+	key := sha256.Sum256(append([]byte("cbmpc-go backup key\x00"), ss...))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealBackup encrypts the key file to ek.
+func sealBackup(k kem.KEM, kemName string, kemBits int, ek []byte, key *keyFile) (*backupFile, error) {
+	plain, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	defer cbmpc.ZeroizeBytes(plain)
+
+	var rho [32]byte
+	if _, err := rand.Read(rho[:]); err != nil {
+		return nil, err
+	}
+	kct, ss, err := k.Encapsulate(ek, rho)
+	cbmpc.ZeroizeBytes(rho[:])
+	if err != nil {
+		return nil, fmt.Errorf("encapsulate: %w", err)
+	}
+	defer cbmpc.ZeroizeBytes(ss)
+	aead, err := backupAEAD(ss)
+	if err != nil {
+		return nil, err
+	}
+	b := &backupFile{
+		Version:   backupVersion,
+		KEM:       kemName,
+		KEMBits:   kemBits,
+		Party:     key.Party,
+		Curve:     key.Curve,
+		PublicKey: key.PublicKey,
+		KEMCipher: kct,
+		Nonce:     make([]byte, aead.NonceSize()),
+	}
+	if _, err := rand.Read(b.Nonce); err != nil {
+		return nil, err
+	}
+	b.Ciphertext = aead.Seal(nil, b.Nonce, plain, b.aad())
+	return b, nil
+}
+
+// openBackup decrypts a backup with the KEM private key handle dk.
+func openBackup(k kem.KEM, dk any, b *backupFile) (*keyFile, error) {
+	if b.Version != backupVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", b.Version)
+	}
+	ss, err := k.Decapsulate(dk, b.KEMCipher)
+	if err != nil {
+		return nil, fmt.Errorf("decapsulate: %w", err)
+	}
+	defer cbmpc.ZeroizeBytes(ss)
+	aead, err := backupAEAD(ss)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.Nonce) != aead.NonceSize() {
+		return nil, errors.New("malformed backup nonce")
+	}
+	plain, err := aead.Open(nil, b.Nonce, b.Ciphertext, b.aad())
+	if err != nil {
+		return nil, errors.New("backup does not decrypt with this key or was modified")
+	}
+	defer cbmpc.ZeroizeBytes(plain)
+	key, err := parseKeyFile(plain)
+	if err != nil {
+		return nil, err
+	}
+	if key.Party != b.Party || key.Curve != b.Curve || string(key.PublicKey) != string(b.PublicKey) {
+		return nil, errors.New("backup header does not match the enclosed key")
+	}
+	return key, nil
+}
+
+// rsaBits returns the modulus size of a PKIX RSA public key.
+func rsaBits(ek []byte) (int, error) {
+	pub, err := x509.ParsePKIXPublicKey(ek)
+	if err != nil {
+		return 0, fmt.Errorf("parse backup public key: %w", err)
+	}
+	rpub, ok := pub.(*stdrsa.PublicKey)
+	if !ok {
+		return 0, errors.New("backup public key is not an RSA key")
+	}
+	return rpub.Size() * 8, nil
+}
+
+func runBackupKeygen(args []string) error {
+	fs := flag.NewFlagSet("backup-keygen", flag.ContinueOnError)
+	bits := fs.Int("bits", 3072, "RSA modulus size: 2048, 3072 or 4096")
+	out := fs.String("out", "", "path prefix; writes <out>.ek (public) and <out>.dk (secret)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return errors.New("-out is required")
+	}
+	k, err := rsa.New(*bits)
+	if err != nil {
+		return err
+	}
+	dk, ek, err := k.Generate()
+	if err != nil {
+		return err
+	}
+	defer cbmpc.ZeroizeBytes(dk)
+	if err := writeSecretFile(*out+".dk", dk); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Clean(*out+".ek"), ek, 0o644); err != nil { // #nosec G306 -- public key
+		return err
+	}
+	fmt.Printf("wrote %s.ek and %s.dk; keep %s.dk offline\n", *out, *out, *out)
+	return nil
+}
+
+func runBackup(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	keyPath := fs.String("key", "", "path of the key share file")
+	ekPath := fs.String("ek", "", "path of the backup public key from backup-keygen")
+	out := fs.String("out", "", "path of the backup file to create")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *keyPath == "" || *ekPath == "" || *out == "" {
+		return errors.New("-key, -ek and -out are required")
+	}
+	key, err := readKeyFile(*keyPath)
+	if err != nil {
+		return err
+	}
+	defer cbmpc.ZeroizeBytes(key.Share)
+	ek, err := os.ReadFile(filepath.Clean(*ekPath))
+	if err != nil {
+		return err
+	}
+	if err := kem.ValidateEK(ek); err != nil {
+		return fmt.Errorf("backup public key: %w", err)
+	}
+	bits, err := rsaBits(ek)
+	if err != nil {
+		return err
+	}
+	k, err := rsa.New(bits)
+	if err != nil {
+		return err
+	}
+	b, err := sealBackup(k, "rsa-oaep", bits, ek, key)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	// The backup is encrypted, but there is no reason to make it world-readable.
+	return writeSecretFile(*out, append(data, '\n'))
+}
+
+func runRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	backupPath := fs.String("backup", "", "path of the backup file")
+	dkPath := fs.String("dk", "", "path of the backup private key from backup-keygen")
+	out := fs.String("out", "", "path of the key share file to create")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *backupPath == "" || *dkPath == "" || *out == "" {
+		return errors.New("-backup, -dk and -out are required")
+	}
+	data, err := os.ReadFile(filepath.Clean(*backupPath))
+	if err != nil {
+		return err
+	}
+	var b backupFile
+	if err := json.Unmarshal(data, &b); err != nil {
+		return fmt.Errorf("parse backup: %w", err)
+	}
+	if b.KEM != "rsa-oaep" {
+		return fmt.Errorf("unsupported backup KEM %q", b.KEM)
+	}
+	dkRef, err := os.ReadFile(filepath.Clean(*dkPath))
+	if err != nil {
+		return err
+	}
+	defer cbmpc.ZeroizeBytes(dkRef)
+	k, err := rsa.New(b.KEMBits)
+	if err != nil {
+		return err
+	}
+	dk, err := k.NewPrivateKeyHandle(dkRef)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = k.FreePrivateKeyHandle(dk) }()
+
+	key, err := openBackup(k, dk, &b)
+	if err != nil {
+		return err
+	}
+	defer cbmpc.ZeroizeBytes(key.Share)
+	if err := writeKeyFile(*out, key); err != nil {
+		return err
+	}
+	fmt.Printf("restored key share of %s to %s\n", key.Party, *out)
+	return nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/bench"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/config"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/tlsnet"
+)
+
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	ops := fs.String("op", "dkg,sign,sign-batch", "comma-separated operations, or \"all\"")
+	curves := fs.String("curve", "secp256k1", "comma-separated curves (secp256k1, p256, p384, p521, ed25519)")
+	n := fs.Int("n", 20, "timed runs per operation")
+	warmup := fs.Int("warmup", 2, "untimed runs before the timed ones")
+	batch := fs.Int("batch", 16, "messages per sign-batch run")
+	kemBits := fs.Int("kem-bits", 2048, "RSA modulus size for PVE operations")
+	transport := fs.String("transport", "mocknet", "transport between the two parties: mocknet or tls (mTLS over loopback)")
+	timeout := fs.Duration("timeout", 10*time.Minute, "overall timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var opList []bench.Operation
+	if *ops == "all" {
+		opList = bench.Operations
+	} else {
+		for _, s := range strings.Split(*ops, ",") {
+			opList = append(opList, bench.Operation(strings.TrimSpace(s)))
+		}
+	}
+	var curveList []cbmpc.Curve
+	for _, s := range strings.Split(*curves, ",") {
+		c := (&config.Config{Curve: strings.TrimSpace(s)}).CurveID()
+		if c == cbmpc.CurveUnknown {
+			return fmt.Errorf("unknown curve %q", s)
+		}
+		curveList = append(curveList, c)
+	}
+	var connect bench.Connector
+	switch *transport {
+	case "mocknet":
+	case "tls":
+		dir, err := os.MkdirTemp("", "cbmpc-bench-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+		if err := tlsnet.GenerateCertificates(benchNames, dir, tlsnet.CertOptions{KeyBits: 2048, IncludeLocalhost: true}); err != nil {
+			return err
+		}
+		connect = loopbackTLS(dir)
+	default:
+		return fmt.Errorf("unknown transport %q", *transport)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "op\tcurve\tn\tp50\tp90\tp99\tmean\titems/s\t")
+	for _, c := range curveList {
+		for _, op := range opList {
+			res, err := bench.Run(ctx, bench.Config{
+				Op:         op,
+				Curve:      c,
+				Iterations: *n,
+				Warmup:     *warmup,
+				BatchSize:  *batch,
+				KEMBits:    *kemBits,
+				Connect:    connect,
+			})
+			if err != nil {
+				_ = w.Flush()
+				return fmt.Errorf("%s %s: %w", op, c, err)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%.1f\t\n", res.Op, res.Curve, len(res.Samples),
+				ms(res.Percentile(50)), ms(res.Percentile(90)), ms(res.Percentile(99)), ms(res.Mean()), res.Throughput())
+		}
+	}
+	return w.Flush()
+}
+
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
+
+var benchNames = []string{"bench-p1", "bench-p2"}
+
+// loopbackTLS connects the two parties with tlsnet over 127.0.0.1 using the
+// certificates generated in dir.
+func loopbackTLS(dir string) bench.Connector {
+	return func(context.Context) ([2]cbmpc.Transport, io.Closer, error) {
+		roots, err := config.LoadCertPool(filepath.Join(dir, "rootCA.pem"))
+		if err != nil {
+			return [2]cbmpc.Transport{}, nil, err
+		}
+		addrs := make([]string, len(benchNames))
+		for i := range addrs {
+			if addrs[i], err = freeAddr(); err != nil {
+				return [2]cbmpc.Transport{}, nil, err
+			}
+		}
+		var (
+			wg   sync.WaitGroup
+			ts   [2]*tlsnet.Transport
+			errs [2]error
+		)
+		for i, name := range benchNames {
+			wg.Add(1)
+			go func(i int, name string) {
+				defer wg.Done()
+				cert, err := config.LoadKeyPair(filepath.Join(dir, name+"-cert.pem"), filepath.Join(dir, name+"-key.pem"))
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				ts[i], errs[i] = tlsnet.New(tlsnet.Config{
+					Self:        i,
+					Names:       benchNames,
+					Addresses:   addrs,
+					Certificate: cert,
+					RootCAs:     roots,
+				})
+			}(i, name)
+		}
+		wg.Wait()
+		var cs closers
+		for _, t := range ts {
+			if t != nil {
+				cs = append(cs, t)
+			}
+		}
+		if err := errors.Join(errs[:]...); err != nil {
+			_ = cs.Close()
+			return [2]cbmpc.Transport{}, nil, err
+		}
+		return [2]cbmpc.Transport{ts[0], ts[1]}, cs, nil
+	}
+}
+
+// freeAddr returns a loopback address with a port that was free a moment ago.
+func freeAddr() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer ln.Close()
+	return ln.Addr().String(), nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/config"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/discovery"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/tlsnet"
+)
+
+// clusterFlags are shared by the subcommands that run a protocol.
+type clusterFlags struct {
+	config  string
+	self    string
+	timeout time.Duration
+}
+
+func (c *clusterFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.config, "config", "", "path to the cluster configuration (JSON or YAML)")
+	fs.StringVar(&c.self, "self", "", "name of this party; overrides self in the configuration")
+	fs.DurationVar(&c.timeout, "timeout", 0, "overall protocol timeout; defaults to timeouts.job in the configuration")
+}
+
+// cluster is a loaded configuration with this party's position in it.
+type cluster struct {
+	cfg    *config.Config
+	self   cbmpc.RoleID
+	names  []string
+	scheme string
+}
+
+func (c *clusterFlags) load() (*cluster, error) {
+	if c.config == "" {
+		return nil, errors.New("-config is required")
+	}
+	cfg, err := config.Load(c.config)
+	if err != nil {
+		return nil, err
+	}
+	if c.self != "" {
+		cfg.Self = c.self
+	}
+	if cfg.Self == "" {
+		return nil, errors.New("-self is required when the configuration does not name this party")
+	}
+	self, ok := cfg.Role(cfg.Self)
+	if !ok {
+		return nil, fmt.Errorf("party %q is not in the configuration", cfg.Self)
+	}
+	if cfg.Transport.Kind != config.TransportTLS {
+		return nil, fmt.Errorf("transport %q cannot connect separate processes; use %q", cfg.Transport.Kind, config.TransportTLS)
+	}
+	if cfg.Threshold != 0 && cfg.Threshold != len(cfg.Parties) {
+		return nil, fmt.Errorf("threshold %d-of-%d keys are not supported; set threshold to 0 for n-of-n", cfg.Threshold, len(cfg.Parties))
+	}
+	if c.timeout == 0 {
+		c.timeout = time.Duration(cfg.Timeouts.Job)
+	}
+	scheme := schemeECDSA
+	if cfg.CurveID() == curve.Ed25519 {
+		scheme = schemeEdDSA
+	}
+	return &cluster{cfg: cfg, self: self, names: cfg.Names(), scheme: scheme}, nil
+}
+
+// checkKey rejects a key share that belongs to a different cluster or party.
+func (c *cluster) checkKey(k *keyFile) error {
+	switch {
+	case k.Curve != c.cfg.Curve:
+		return fmt.Errorf("key is on curve %q, configuration uses %q", k.Curve, c.cfg.Curve)
+	case !slices.Equal(k.Parties, c.names):
+		return fmt.Errorf("key was generated by parties %v, configuration lists %v", k.Parties, c.names)
+	case k.Party != c.cfg.Self:
+		return fmt.Errorf("key belongs to %q, not %q", k.Party, c.cfg.Self)
+	}
+	return nil
+}
+
+// connect opens the mTLS transport to every other party and creates the job.
+// It blocks until every party is reachable. The returned closer releases the
+// job and the transport.
+func (c *cluster) connect(ctx context.Context) (*cbmpc.JobMP, io.Closer, error) {
+	cert, roots, err := c.cfg.TLS()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load certificates: %w", err)
+	}
+	resolver, err := discovery.FromConfig(c.cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	addrs, err := discovery.ResolveAll(ctx, resolver, c.names)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve parties: %w", err)
+	}
+	t, err := tlsnet.New(tlsnet.Config{
+		Self:        int(c.self),
+		Names:       c.names,
+		Addresses:   addrs,
+		Certificate: cert,
+		RootCAs:     roots,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect: %w", err)
+	}
+	job, err := cbmpc.NewJobMPWithHandshake(ctx, t, c.self, c.names, nil)
+	if err != nil {
+		_ = t.Close()
+		return nil, nil, err
+	}
+	return job, closers{job, t}, nil
+}
+
+type closers []io.Closer
+
+func (cs closers) Close() error {
+	var errs []error
+	for _, c := range cs {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// check is one step of the doctor report. run returns a short detail line
+// on success.
+type check struct {
+	name string
+	run  func(ctx context.Context) (string, error)
+}
+
+var doctorChecks = []check{
+	{"native library", checkNative},
+	{"curves", checkCurves},
+	{"self-test", checkSelfTest},
+}
+
+func runDoctor(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", 30*time.Second, "timeout for the loopback self-test")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	return doctor(ctx, os.Stdout)
+}
+
+// doctor prints the build versions and runs every check, continuing past
+// failures so one run shows everything that is wrong.
+func doctor(ctx context.Context, w io.Writer) error {
+	fmt.Fprintf(w, "wrapper:    %s\n", cbmpc.WrapperVersion())
+	fmt.Fprintf(w, "upstream:   %s\n", cbmpc.UpstreamVersion())
+	fmt.Fprintf(w, "go:         %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintln(w)
+
+	failed := 0
+	for _, c := range doctorChecks {
+		detail, err := c.run(ctx)
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "FAIL  %-15s %v\n", c.name, err)
+			continue
+		}
+		fmt.Fprintf(w, "ok    %-15s %s\n", c.name, detail)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(doctorChecks))
+	}
+	return nil
+}
+
+// checkNative asks the native library for a curve generator, which fails when
+// the bindings were not linked in or the shared library cannot be loaded.
+func checkNative(context.Context) (string, error) {
+	g, err := curve.Generator(curve.P256)
+	if err != nil {
+		return "", fmt.Errorf("native bindings unavailable (built with CGO_ENABLED=1?): %w", err)
+	}
+	g.Free()
+	return "loaded", nil
+}
+
+var doctorCurves = []curve.Curve{curve.P256, curve.P384, curve.P521, curve.Secp256k1, curve.Ed25519}
+
+// checkCurves exercises OpenSSL through each supported curve: it draws a
+// random scalar, multiplies the generator and round-trips the point encoding.
+func checkCurves(context.Context) (string, error) {
+	for _, c := range doctorCurves {
+		if err := checkCurve(c); err != nil {
+			return "", fmt.Errorf("%s: %w", c, err)
+		}
+	}
+	return fmt.Sprintf("%d curves", len(doctorCurves)), nil
+}
+
+func checkCurve(c curve.Curve) error {
+	k, err := curve.RandomScalar(c)
+	if err != nil {
+		return err
+	}
+	defer k.Free()
+	p, err := curve.MulGenerator(c, k)
+	if err != nil {
+		return err
+	}
+	defer p.Free()
+	enc, err := p.Bytes()
+	if err != nil {
+		return err
+	}
+	q, err := curve.NewPointFromBytes(c, enc)
+	if err != nil {
+		return err
+	}
+	defer q.Free()
+	dec, err := q.Bytes()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(enc, dec) {
+		return errors.New("point encoding does not round-trip")
+	}
+	return nil
+}
+
+// checkSelfTest runs a 2-party P-256 DKG and signature over mocknet and
+// verifies the signature with crypto/ecdsa.
+func checkSelfTest(ctx context.Context) (string, error) {
+	start := time.Now()
+	net := mocknet.New()
+	names := [2]string{"doctor-p1", "doctor-p2"}
+	digest := sha256.Sum256([]byte("cbmpc-go doctor"))
+
+	var (
+		wg   sync.WaitGroup
+		pubs [2][]byte
+		sigs [2][]byte
+		errs [2]error
+	)
+	for i := range 2 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pubs[i], sigs[i], errs[i] = selfTestParty(ctx, net, i, names, digest[:])
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return "", err
+		}
+	}
+	if !bytes.Equal(pubs[0], pubs[1]) {
+		return "", errors.New("parties derived different public keys")
+	}
+
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), pubs[0])
+	if x == nil {
+		return "", errors.New("public key is not a compressed P-256 point")
+	}
+	pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+	if !ecdsa.VerifyASN1(pub, digest[:], sigs[0]) {
+		return "", errors.New("signature does not verify")
+	}
+	return fmt.Sprintf("P-256 dkg+sign in %s", time.Since(start).Round(time.Millisecond)), nil
+}
+
+func selfTestParty(ctx context.Context, net *mocknet.Net, i int, names [2]string, digest []byte) ([]byte, []byte, error) {
+	role := cbmpc.RoleP1
+	if i == 1 {
+		role = cbmpc.RoleP2
+	}
+	job, err := cbmpc.NewJob2PWithContext(ctx, net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), role, names)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer job.Close()
+
+	dkg, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
+	if err != nil {
+		return nil, nil, fmt.Errorf("dkg: %w", err)
+	}
+	defer dkg.Key.Close()
+	pub, err := dkg.Key.PublicKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := ecdsa2p.Sign(ctx, job, &ecdsa2p.SignParams{Key: dkg.Key, Message: digest})
+	if err != nil {
+		return nil, nil, fmt.Errorf("sign: %w", err)
+	}
+	return pub, res.Signature, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+func runInspect(args []string) error {
+	fs := flag.NewFlagSet("inspect-key", flag.ContinueOnError)
+	keyPath := fs.String("key", "", "path of the key share file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *keyPath == "" {
+		return errors.New("-key is required")
+	}
+	k, err := readKeyFile(*keyPath)
+	if err != nil {
+		return err
+	}
+	defer cbmpc.ZeroizeBytes(k.Share)
+
+	fmt.Printf("scheme:     %s\n", k.Scheme)
+	fmt.Printf("curve:      %s\n", k.Curve)
+	fmt.Printf("party:      %s\n", k.Party)
+	fmt.Printf("parties:    %s\n", strings.Join(k.Parties, ", "))
+	fmt.Printf("public key: %x\n", k.PublicKey)
+	if len(k.SessionID) > 0 {
+		fmt.Printf("session id: %x\n", k.SessionID)
+	}
+	fmt.Printf("share:      %d bytes\n", len(k.Share))
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const keyFileVersion = 1
+
+// Signature schemes.
+const (
+	schemeECDSA = "ecdsa"
+	schemeEdDSA = "eddsa"
+)
+
+// keyFile is the on-disk form of one party's key share. Share holds the
+// serialized native key and is secret; the other fields are public.
+type keyFile struct {
+	Version   int      `json:"version"`
+	Scheme    string   `json:"scheme"`
+	Curve     string   `json:"curve"`
+	Party     string   `json:"party"`
+	Parties   []string `json:"parties"`
+	PublicKey []byte   `json:"public_key"`
+	SessionID []byte   `json:"session_id,omitempty"`
+	Share     []byte   `json:"share"`
+}
+
+func (k *keyFile) validate() error {
+	switch {
+	case k.Version != keyFileVersion:
+		return fmt.Errorf("unsupported key file version %d", k.Version)
+	case k.Scheme != schemeECDSA && k.Scheme != schemeEdDSA:
+		return fmt.Errorf("unknown scheme %q", k.Scheme)
+	case k.Party == "" || len(k.Parties) < 2:
+		return errors.New("key file lacks party information")
+	case len(k.Share) == 0:
+		return errors.New("key file has no share")
+	}
+	return nil
+}
+
+func parseKeyFile(data []byte) (*keyFile, error) {
+	var k keyFile
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, fmt.Errorf("parse key file: %w", err)
+	}
+	if err := k.validate(); err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func readKeyFile(path string) (*keyFile, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return parseKeyFile(data)
+}
+
+// writeSecretFile writes data readable by the owner only, refusing to replace
+// an existing file so a ceremony never overwrites a share by accident.
+func writeSecretFile(path string, data []byte) error {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeKeyFile(path string, k *keyFile) error {
+	data, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeSecretFile(path, append(data, '\n'))
+}
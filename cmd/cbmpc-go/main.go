@@ -0,0 +1,75 @@
+// Command cbmpc-go runs MPC key ceremonies from the command line.
+//
+// Every party runs the same subcommand with the shared cluster configuration
+// (see pkg/cbmpc/config) and its own -self name:
+//
+//	cbmpc-go dkg         -config cluster.yaml -self alice -out alice.key
+//	cbmpc-go sign        -config cluster.yaml -self alice -key alice.key -message "hello" -receiver alice
+//	cbmpc-go refresh     -config cluster.yaml -self alice -key alice.key -out alice.key.new
+//	cbmpc-go backup-keygen -bits 3072 -out backup
+//	cbmpc-go backup      -key alice.key -ek backup.ek -out alice.backup
+//	cbmpc-go restore     -backup alice.backup -dk backup.dk -out alice.key
+//	cbmpc-go inspect-key -key alice.key
+//	cbmpc-go doctor
+//	cbmpc-go bench       -op all -curve secp256k1,ed25519 -transport tls
+//
+// The curve selects the scheme: ECDSA for secp256k1 and the NIST curves,
+// EdDSA for ed25519. Keys are n-of-n; the configured threshold must be zero.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"dkg", "run distributed key generation and write this party's key share", runDKG},
+	{"sign", "sign a message with every party's key share", runSign},
+	{"refresh", "refresh the key shares, keeping the public key", runRefresh},
+	{"backup-keygen", "generate an RSA key pair for encrypting backups", runBackupKeygen},
+	{"backup", "encrypt a key share to a backup public key", runBackup},
+	{"restore", "decrypt a backup into a key share file", runRestore},
+	{"inspect-key", "print the public metadata of a key share file", runInspect},
+	{"doctor", "check the native library and run a local DKG and sign self-test", runDoctor},
+	{"bench", "measure DKG, signing and PVE latency and throughput", runBench},
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "cbmpc-go: %v\n", err)
+		}
+		os.Exit(2)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		usage()
+		return flag.ErrHelp
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:])
+		}
+	}
+	usage()
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: cbmpc-go <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-14s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun cbmpc-go <command> -h for the flags of a command.")
+}
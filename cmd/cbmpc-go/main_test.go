@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// fakeKEM "encapsulates" by XORing the shared secret with a fixed pad.
+type fakeKEM struct{}
+
+var pad = bytes.Repeat([]byte{0x5a}, 32)
+
+func xor(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[i] = b[i] ^ pad[i%len(pad)]
+	}
+	return out
+}
+
+func (fakeKEM) Encapsulate(_ []byte, rho [32]byte) ([]byte, []byte, error) {
+	return xor(rho[:]), append([]byte(nil), rho[:]...), nil
+}
+
+func (fakeKEM) Decapsulate(_ any, ct []byte) ([]byte, error) { return xor(ct), nil }
+
+func (fakeKEM) DerivePub([]byte) ([]byte, error) { return nil, nil }
+
+func testKey() *keyFile {
+	return &keyFile{
+		Version:   keyFileVersion,
+		Scheme:    schemeECDSA,
+		Curve:     "secp256k1",
+		Party:     "alice",
+		Parties:   []string{"alice", "bob"},
+		PublicKey: []byte{0x02, 0x01},
+		Share:     []byte("share"),
+	}
+}
+
+func TestKeyFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alice.key")
+	if err := writeKeyFile(path, testKey()); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeKeyFile(path, testKey()); err == nil {
+		t.Fatal("writeKeyFile overwrote an existing file")
+	}
+	got, err := readKeyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Party != "alice" || !bytes.Equal(got.Share, []byte("share")) {
+		t.Fatalf("read back %+v", got)
+	}
+
+	bad := testKey()
+	bad.Scheme = "rsa"
+	if err := bad.validate(); err == nil {
+		t.Fatal("expected unknown scheme to be rejected")
+	}
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	b, err := sealBackup(fakeKEM{}, "fake", 2048, nil, testKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b.Ciphertext, []byte("share")) {
+		t.Fatal("share stored in the clear")
+	}
+	key, err := openBackup(fakeKEM{}, nil, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(key.Share, []byte("share")) {
+		t.Fatalf("restored share %q", key.Share)
+	}
+
+	// The public header is authenticated.
+	b.Party = "bob"
+	if _, err := openBackup(fakeKEM{}, nil, b); err == nil {
+		t.Fatal("modified backup header accepted")
+	}
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	if err := run(nil); !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("run() = %v", err)
+	}
+	if err := run([]string{"frobnicate"}); err == nil {
+		t.Fatal("expected error for unknown command")
+	}
+	if err := run([]string{"dkg"}); err == nil {
+		t.Fatal("expected error for missing flags")
+	}
+	if err := run([]string{"bench", "-curve", "p224"}); err == nil {
+		t.Fatal("expected error for unknown curve")
+	}
+	if err := run([]string{"bench", "-transport", "carrier-pigeon"}); err == nil {
+		t.Fatal("expected error for unknown transport")
+	}
+}
+
+func TestDoctorReportsVersions(t *testing.T) {
+	var out bytes.Buffer
+	err := doctor(t.Context(), &out)
+	if !strings.Contains(out.String(), cbmpc.WrapperVersion()) {
+		t.Fatalf("doctor output lacks the wrapper version:\n%s", out.String())
+	}
+	for _, c := range doctorChecks {
+		if !strings.Contains(out.String(), c.name) {
+			t.Fatalf("doctor output lacks check %q:\n%s", c.name, out.String())
+		}
+	}
+	if err != nil {
+		t.Logf("doctor: %v\n%s", err, out.String())
+	}
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorrmp"
+)
+
+// share is a key share produced by a protocol run.
+type share struct {
+	bytes     []byte
+	publicKey []byte
+	sessionID []byte
+}
+
+func ecdsaShare(k *ecdsamp.Key, sid cbmpc.SessionID) (*share, error) {
+	defer k.Close()
+	b, err := k.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return &share{bytes: b, publicKey: pub, sessionID: sid.Bytes()}, nil
+}
+
+func eddsaShare(k *schnorrmp.Key, sid cbmpc.SessionID) (*share, error) {
+	defer k.Close()
+	b, err := k.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return &share{bytes: b, publicKey: pub, sessionID: sid.Bytes()}, nil
+}
+
+func dkg(ctx context.Context, job *cbmpc.JobMP, c *cluster) (*share, error) {
+	if c.scheme == schemeEdDSA {
+		res, err := schnorrmp.DKG(ctx, job, &schnorrmp.DKGParams{Curve: c.cfg.CurveID()})
+		if err != nil {
+			return nil, err
+		}
+		return eddsaShare(res.Key, res.SessionID)
+	}
+	res, err := ecdsamp.DKG(ctx, job, &ecdsamp.DKGParams{Curve: c.cfg.CurveID()})
+	if err != nil {
+		return nil, err
+	}
+	return ecdsaShare(res.Key, res.SessionID)
+}
+
+func refresh(ctx context.Context, job *cbmpc.JobMP, k *keyFile) (*share, error) {
+	sid := cbmpc.NewSessionID(k.SessionID)
+	if k.Scheme == schemeEdDSA {
+		key, err := schnorrmp.LoadKey(k.Share)
+		if err != nil {
+			return nil, err
+		}
+		defer key.Close()
+		res, err := schnorrmp.Refresh(ctx, job, &schnorrmp.RefreshParams{SessionID: sid, Key: key})
+		if err != nil {
+			return nil, err
+		}
+		return eddsaShare(res.NewKey, res.SessionID)
+	}
+	key, err := ecdsamp.LoadKey(k.Share)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Close()
+	res, err := ecdsamp.Refresh(ctx, job, &ecdsamp.RefreshParams{SessionID: sid, Key: key})
+	if err != nil {
+		return nil, err
+	}
+	return ecdsaShare(res.NewKey, res.SessionID)
+}
+
+func sign(ctx context.Context, job *cbmpc.JobMP, k *keyFile, msg []byte, receiver int) ([]byte, error) {
+	if k.Scheme == schemeEdDSA {
+		key, err := schnorrmp.LoadKey(k.Share)
+		if err != nil {
+			return nil, err
+		}
+		defer key.Close()
+		res, err := schnorrmp.Sign(ctx, job, &schnorrmp.SignParams{
+			Key:         key,
+			Message:     msg,
+			SigReceiver: receiver,
+			Variant:     schnorrmp.VariantEdDSA,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.Signature, nil
+	}
+	key, err := ecdsamp.LoadKey(k.Share)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Close()
+	res, err := ecdsamp.Sign(ctx, job, &ecdsamp.SignParams{Key: key, Message: msg, SigReceiver: receiver})
+	if err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+func newKeyFile(c *cluster, s *share) *keyFile {
+	return &keyFile{
+		Version:   keyFileVersion,
+		Scheme:    c.scheme,
+		Curve:     c.cfg.Curve,
+		Party:     c.cfg.Self,
+		Parties:   c.names,
+		PublicKey: s.publicKey,
+		SessionID: s.sessionID,
+		Share:     s.bytes,
+	}
+}
+
+func runDKG(args []string) error {
+	fs := flag.NewFlagSet("dkg", flag.ContinueOnError)
+	var cf clusterFlags
+	cf.register(fs)
+	out := fs.String("out", "", "path of the key share file to create")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return errors.New("-out is required")
+	}
+	c, err := cf.load()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(*out); err == nil {
+		return fmt.Errorf("%s already exists", *out)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cf.timeout)
+	defer cancel()
+	job, closer, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	s, err := dkg(ctx, job, c)
+	if err != nil {
+		return fmt.Errorf("dkg: %w", err)
+	}
+	defer cbmpc.ZeroizeBytes(s.bytes)
+	if err := writeKeyFile(*out, newKeyFile(c, s)); err != nil {
+		return err
+	}
+	fmt.Printf("public key: %x\n", s.publicKey)
+	return nil
+}
+
+func runRefresh(args []string) error {
+	fs := flag.NewFlagSet("refresh", flag.ContinueOnError)
+	var cf clusterFlags
+	cf.register(fs)
+	keyPath := fs.String("key", "", "path of the current key share file")
+	out := fs.String("out", "", "path of the refreshed key share file to create")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *keyPath == "" || *out == "" {
+		return errors.New("-key and -out are required")
+	}
+	c, err := cf.load()
+	if err != nil {
+		return err
+	}
+	k, err := readKeyFile(*keyPath)
+	if err != nil {
+		return err
+	}
+	defer cbmpc.ZeroizeBytes(k.Share)
+	if err := c.checkKey(k); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cf.timeout)
+	defer cancel()
+	job, closer, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	s, err := refresh(ctx, job, k)
+	if err != nil {
+		return fmt.Errorf("refresh: %w", err)
+	}
+	defer cbmpc.ZeroizeBytes(s.bytes)
+	if string(s.publicKey) != string(k.PublicKey) {
+		return errors.New("refresh changed the public key; keeping the old share")
+	}
+	if err := writeKeyFile(*out, newKeyFile(c, s)); err != nil {
+		return err
+	}
+	fmt.Printf("refreshed key written to %s; delete %s once every party has refreshed\n", *out, *keyPath)
+	return nil
+}
+
+func runSign(args []string) error {
+	fs := flag.NewFlagSet("sign", flag.ContinueOnError)
+	var cf clusterFlags
+	cf.register(fs)
+	keyPath := fs.String("key", "", "path of the key share file")
+	message := fs.String("message", "", "message to sign; ECDSA signs its SHA-256 digest")
+	hash := fs.String("hash", "", "hex digest to sign as-is (ECDSA only)")
+	receiver := fs.String("receiver", "", "name of the party that receives the signature (default: first party)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *keyPath == "" {
+		return errors.New("-key is required")
+	}
+	if (*message == "") == (*hash == "") {
+		return errors.New("exactly one of -message and -hash is required")
+	}
+	c, err := cf.load()
+	if err != nil {
+		return err
+	}
+	k, err := readKeyFile(*keyPath)
+	if err != nil {
+		return err
+	}
+	defer cbmpc.ZeroizeBytes(k.Share)
+	if err := c.checkKey(k); err != nil {
+		return err
+	}
+
+	var msg []byte
+	switch {
+	case *hash != "" && k.Scheme == schemeEdDSA:
+		return errors.New("-hash is not supported for EdDSA, which signs the message itself")
+	case *hash != "":
+		if msg, err = hex.DecodeString(*hash); err != nil {
+			return fmt.Errorf("-hash: %w", err)
+		}
+	case k.Scheme == schemeEdDSA:
+		msg = []byte(*message)
+	default:
+		sum := sha256.Sum256([]byte(*message))
+		msg = sum[:]
+	}
+	recv := cbmpc.RoleID(0)
+	if *receiver != "" {
+		var ok bool
+		if recv, ok = c.cfg.Role(*receiver); !ok {
+			return fmt.Errorf("receiver %q is not in the configuration", *receiver)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cf.timeout)
+	defer cancel()
+	job, closer, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	sig, err := sign(ctx, job, k, msg, int(recv))
+	if err != nil {
+		return fmt.Errorf("sign: %w", err)
+	}
+	if recv == c.self {
+		fmt.Printf("signature: %x\n", sig)
+	} else {
+		fmt.Printf("signed; signature delivered to %s\n", c.names[recv])
+	}
+	return nil
+}
@@ -8,10 +8,10 @@ import (
 	"math"
 	"time"
 
-	"github.com/coinbase/cb-mpc-go/examples/common"
-	"github.com/coinbase/cb-mpc-go/examples/tlsnet"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/agreerandom"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/config"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/tlsnet"
 )
 
 func main() {
@@ -27,36 +27,26 @@ func main() {
 		log.Fatal("--self flag is required")
 	}
 
-	cfg, err := common.LoadConfig(*configPath)
+	cfg, err := config.Load(*configPath)
 	if err != nil {
 		log.Fatalf("load config: %v", err)
 	}
-	if err := common.ValidateConfig(cfg); err != nil {
-		log.Fatalf("invalid config: %v", err)
-	}
+	cfg.Self = *selfName
 
-	names := make([]string, len(cfg.Parties))
+	self, err := cfg.SelfRole()
+	if err != nil {
+		log.Fatal(err)
+	}
+	selfIndex := int(self)
+	names := cfg.Names()
 	addresses := make([]string, len(cfg.Parties))
-	selfIndex := -1
 	for i, p := range cfg.Parties {
-		names[i] = p.Name
 		addresses[i] = p.Address
-		if p.Name == *selfName {
-			selfIndex = i
-		}
-	}
-	if selfIndex < 0 {
-		log.Fatalf("self name %q not present in config", *selfName)
-	}
-
-	cert, err := common.LoadKeyPair(cfg.Parties[selfIndex].Cert, cfg.Parties[selfIndex].Key)
-	if err != nil {
-		log.Fatalf("load certificate: %v", err)
 	}
 
-	caPool, err := common.LoadCertPool(cfg.CACert)
+	cert, caPool, err := cfg.TLS()
 	if err != nil {
-		log.Fatalf("load CA: %v", err)
+		log.Fatalf("load certificates: %v", err)
 	}
 
 	transport, err := tlsnet.New(tlsnet.Config{
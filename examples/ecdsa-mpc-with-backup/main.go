@@ -5,18 +5,18 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"flag"
-	"fmt"
 	"log"
 	"os"
 	"time"
 
-	"github.com/coinbase/cb-mpc-go/examples/common"
-	"github.com/coinbase/cb-mpc-go/examples/tlsnet"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
-	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/backup"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/config"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/rsa"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/tlsnet"
 )
 
 func main() {
@@ -33,42 +33,31 @@ func main() {
 	}
 
 	// Load configuration
-	cfg, err := common.LoadConfig(*configPath)
+	cfg, err := config.Load(*configPath)
 	if err != nil {
 		log.Fatalf("load config: %v", err)
 	}
-	if err := common.ValidateConfig(cfg); err != nil {
-		log.Fatalf("invalid config: %v", err)
-	}
+	cfg.Self = *selfName
 	if len(cfg.Parties) != 4 {
 		log.Fatalf("multi-party demo requires exactly four parties (got %d)", len(cfg.Parties))
 	}
 
 	// Extract party names and addresses
-	n := len(cfg.Parties)
-	names := make([]string, n)
-	addresses := make([]string, n)
-	selfIndex := -1
+	self, err := cfg.SelfRole()
+	if err != nil {
+		log.Fatal(err)
+	}
+	selfIndex := int(self)
+	names := cfg.Names()
+	addresses := make([]string, len(cfg.Parties))
 	for i, p := range cfg.Parties {
-		names[i] = p.Name
 		addresses[i] = p.Address
-		if p.Name == *selfName {
-			selfIndex = i
-		}
-	}
-	if selfIndex < 0 {
-		log.Fatalf("self name %q not present in config", *selfName)
 	}
 
 	// Load TLS certificates
-	cert, err := common.LoadKeyPair(cfg.Parties[selfIndex].Cert, cfg.Parties[selfIndex].Key)
-	if err != nil {
-		log.Fatalf("load certificate: %v", err)
-	}
-
-	caPool, err := common.LoadCertPool(cfg.CACert)
+	cert, caPool, err := cfg.TLS()
 	if err != nil {
-		log.Fatalf("load CA: %v", err)
+		log.Fatalf("load certificates: %v", err)
 	}
 
 	// Setup mTLS transport
@@ -142,7 +131,7 @@ func main() {
 		log.Printf("[%s] ✓ Signing completed (signature sent to %s)", names[selfIndex], names[sigReceiver])
 	}
 
-	// Step 3: Backup key using PVE (Publicly Verifiable Encryption)
+	// Step 3: Backup key share to recovery custodians using PVE-AC
 	log.Printf("[%s] Step 3: Creating PVE backup of key share...", names[selfIndex])
 
 	// Create deterministic RSA-OAEP KEM instance for PVE backups
@@ -151,37 +140,35 @@ func main() {
 		log.Fatalf("create KEM: %v", err)
 	}
 
-	// Generate KEM key pair for backup encryption
-	skRef, ek, err := kemInstance.Generate()
-	if err != nil {
-		log.Fatalf("KEM generate: %v", err)
-	}
-	// Create a handle for the private key for decapsulation
-	dkHandle, err := kemInstance.NewPrivateKeyHandle(skRef)
+	// Any 2 of 3 recovery custodians can restore the share. In production each
+	// custodian generates its key pair on its own machine and publishes only
+	// the encryption key; the demo generates all three locally.
+	policyAC, err := ac.Compile(ac.Threshold(2, ac.Leaf("recovery1"), ac.Leaf("recovery2"), ac.Leaf("recovery3")))
 	if err != nil {
-		log.Fatalf("KEM NewPrivateKeyHandle: %v", err)
+		log.Fatalf("compile backup policy: %v", err)
 	}
-	defer func() {
-		_ = kemInstance.FreePrivateKeyHandle(dkHandle)
-	}()
-	log.Printf("[%s]   Backup Encryption Key generated", names[selfIndex])
-
-	// Get key bytes to derive a fixed-size backup secret
-	keyBytes, err := dkgResult.Key.Bytes()
+	paths, err := policyAC.LeafPaths()
 	if err != nil {
-		log.Fatalf("get key bytes: %v", err)
+		log.Fatalf("list custodians: %v", err)
 	}
-	defer cbmpc.ZeroizeBytes(keyBytes)
-
-	// Derive a 32-byte secret from the key bytes (for demo backup)
-	keyDigest := sha256.Sum256(keyBytes)
-
-	// Convert digest to scalar for PVE encryption
-	keyScalar, err := curve.NewScalarFromBytes(keyDigest[:])
-	if err != nil {
-		log.Fatalf("create scalar: %v", err)
+	policy := backup.Policy{AC: policyAC, PathToEK: make(map[string][]byte)}
+	custodianDKs := make(map[string]any)
+	for _, path := range paths {
+		skRef, ek, err := kemInstance.Generate()
+		if err != nil {
+			log.Fatalf("KEM generate: %v", err)
+		}
+		dkHandle, err := kemInstance.NewPrivateKeyHandle(skRef)
+		if err != nil {
+			log.Fatalf("KEM NewPrivateKeyHandle: %v", err)
+		}
+		defer func() {
+			_ = kemInstance.FreePrivateKeyHandle(dkHandle)
+		}()
+		policy.PathToEK[path] = ek
+		custodianDKs[path] = dkHandle
 	}
-	defer keyScalar.Free()
+	log.Printf("[%s]   Recovery custodians: %v (any 2)", names[selfIndex], paths)
 
 	// Create PVE instance
 	pveInstance, err := pve.New(kemInstance)
@@ -189,63 +176,68 @@ func main() {
 		log.Fatalf("create PVE: %v", err)
 	}
 
-	// Encrypt key using PVE
-	backupLabel := []byte(fmt.Sprintf("backup-%s-%d", names[selfIndex], time.Now().Unix()))
-	encResult, err := pveInstance.Encrypt(ctx, &pve.EncryptParams{
-		EK:    ek,
-		Label: backupLabel,
-		Curve: cbmpc.CurveP256,
-		X:     keyScalar,
-	})
-	if err != nil {
-		log.Fatalf("PVE encrypt: %v", err)
+	backupParams := &backup.Params{
+		PVE:     pveInstance,
+		Key:     dkgResult.Key,
+		Parties: names,
+		Policy:  policy,
 	}
-	log.Printf("[%s] ✓ Key backed up with PVE", names[selfIndex])
-	log.Printf("[%s]   Backup Label: %s", names[selfIndex], string(backupLabel))
-	log.Printf("[%s]   Ciphertext size: %d bytes", names[selfIndex], len(encResult.Ciphertext))
-
-	// Step 4: Verify the PVE backup
-	log.Printf("[%s] Step 4: Verifying PVE backup...", names[selfIndex])
-
-	// Extract Q point from ciphertext
-	ctQPoint, err := encResult.Ciphertext.Q()
+	if os.Getenv("SAVE_BACKUP") == "1" {
+		backupParams.Sink = backup.DirSink(".")
+	}
+	bundle, err := backup.Backup(ctx, backupParams)
 	if err != nil {
-		log.Fatalf("extract Q from ciphertext: %v", err)
+		log.Fatalf("backup: %v", err)
+	}
+	log.Printf("[%s] ✓ Key share backed up with PVE-AC", names[selfIndex])
+	log.Printf("[%s]   Bundle: %s", names[selfIndex], bundle.Name())
+	log.Printf("[%s]   Ciphertext size: %d bytes", names[selfIndex], len(bundle.Ciphertext))
+	if backupParams.Sink != nil {
+		log.Printf("[%s]   Backup saved to: %s", names[selfIndex], bundle.Name())
 	}
-	defer ctQPoint.Free()
 
-	// Verify the ciphertext
-	err = pveInstance.Verify(ctx, &pve.VerifyParams{
-		EK:         ek,
-		Ciphertext: encResult.Ciphertext,
-		Q:          ctQPoint,
-		Label:      backupLabel,
-	})
-	if err != nil {
+	// Step 4: Verify the PVE backup against the policy
+	log.Printf("[%s] Step 4: Verifying PVE backup...", names[selfIndex])
+	if err := backup.Verify(ctx, pveInstance, bundle, policy); err != nil {
 		log.Fatalf("PVE verify failed: %v", err)
 	}
 	log.Printf("[%s] ✓ PVE backup verified successfully", names[selfIndex])
 
-	// Step 5: Demonstrate recovery by decrypting the backup
+	// Step 5: Demonstrate recovery with two of the three custodians
 	log.Printf("[%s] Step 5: Demonstrating key recovery from backup...", names[selfIndex])
-
-	decResult, err := pveInstance.Decrypt(ctx, &pve.DecryptParams{
-		DK:         dkHandle,
-		EK:         ek,
-		Ciphertext: encResult.Ciphertext,
-		Label:      backupLabel,
-		Curve:      cbmpc.CurveP256,
-	})
+	coordinator, err := backup.NewCoordinator(pveInstance, bundle)
 	if err != nil {
-		log.Fatalf("PVE decrypt: %v", err)
+		log.Fatalf("restore coordinator: %v", err)
+	}
+	for _, path := range paths[:2] {
+		share, err := backup.DecryptShare(ctx, pveInstance, bundle, path, custodianDKs[path])
+		if err != nil {
+			log.Fatalf("custodian %s decrypt: %v", path, err)
+		}
+		if err := coordinator.Add(path, share); err != nil {
+			log.Fatalf("custodian %s share: %v", path, err)
+		}
 	}
-	defer decResult.X.Free()
+	restoredBytes, err := backup.Restore(ctx, bundle, coordinator)
+	if err != nil {
+		log.Fatalf("restore: %v", err)
+	}
+	restoredKey, err := ecdsamp.LoadKey(restoredBytes.Bytes())
+	restoredBytes.Destroy()
+	if err != nil {
+		log.Fatalf("load restored key: %v", err)
+	}
+	defer restoredKey.Close()
 
-	// Verify recovered scalar matches original
-	if !keyScalar.Equal(decResult.X) {
-		log.Fatal("recovered backup secret does not match original")
+	// Verify recovered share belongs to the same key
+	restoredPubKey, err := restoredKey.PublicKey()
+	if err != nil {
+		log.Fatalf("extract restored public key: %v", err)
+	}
+	if hex.EncodeToString(restoredPubKey) != hex.EncodeToString(pubKeyBytes) {
+		log.Fatal("restored key share does not match original public key")
 	}
-	log.Printf("[%s] ✓ Key recovered successfully and verified to match original", names[selfIndex])
+	log.Printf("[%s] ✓ Key share recovered successfully and verified to match original", names[selfIndex])
 
 	// Step 6: Demonstrate key refresh
 	log.Printf("[%s] Step 6: Refreshing key shares...", names[selfIndex])
@@ -288,15 +280,4 @@ func main() {
 	log.Printf("[%s] ✓ Publicly verifiable encryption for backups", names[selfIndex])
 	log.Printf("[%s] ✓ Secure key recovery", names[selfIndex])
 	log.Printf("[%s] ✓ Proactive security via key refresh", names[selfIndex])
-
-	// Write backup to file (optional)
-	if os.Getenv("SAVE_BACKUP") == "1" {
-		backupFile := fmt.Sprintf("backup-%s.hex", names[selfIndex])
-		err = os.WriteFile(backupFile, []byte(hex.EncodeToString(encResult.Ciphertext)), 0600)
-		if err != nil {
-			log.Printf("[%s] Warning: could not save backup: %v", names[selfIndex], err)
-		} else {
-			log.Printf("[%s] Backup saved to: %s", names[selfIndex], backupFile)
-		}
-	}
 }
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/agreerandom"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/protoflow"
+)
+
+func main() {
+	var (
+		format  = flag.String("format", "json", "output format: json or mermaid")
+		parties = flag.Int("parties", 3, "number of parties for multi-party protocols")
+		sizes   = flag.Bool("sizes", false, "include message sizes (noisy across runs)")
+		timeout = flag.Duration("timeout", time.Minute, "overall timeout")
+	)
+	flag.Parse()
+
+	if *parties < 2 {
+		log.Fatal("--parties must be at least 2")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	flows, err := collect(ctx, *parties)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !*sizes {
+		for i, f := range flows {
+			flows[i] = f.WithoutSizes()
+		}
+	}
+
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(flows); err != nil {
+			log.Fatalf("encode: %v", err)
+		}
+	case "mermaid":
+		for _, f := range flows {
+			fmt.Printf("```mermaid\n%s```\n\n", f.Mermaid(nil))
+		}
+	default:
+		log.Fatalf("unknown format %q", *format)
+	}
+}
+
+func collect(ctx context.Context, n int) ([]*protoflow.Flow, error) {
+	var flows []*protoflow.Flow
+
+	// Two-party protocols.
+	net := mocknet.New()
+	recs2 := []*protoflow.Recorder{
+		protoflow.NewRecorder(net.Ep2P(0, 1), 0),
+		protoflow.NewRecorder(net.Ep2P(1, 0), 1),
+	}
+	jobs2 := make([]*cbmpc.Job2P, 2)
+	for i, rec := range recs2 {
+		job, err := cbmpc.NewJob2PWithContext(ctx, rec, cbmpc.Role(i), [2]string{"p1", "p2"})
+		if err != nil {
+			return nil, fmt.Errorf("NewJob2P: %w", err)
+		}
+		defer job.Close()
+		jobs2[i] = job
+	}
+
+	keys2 := make([]*ecdsa2p.Key, 2)
+	steps2 := []struct {
+		name string
+		run  func(i int, job *cbmpc.Job2P) error
+	}{
+		{"agreerandom.AgreeRandom", func(_ int, job *cbmpc.Job2P) error {
+			_, err := agreerandom.AgreeRandom(ctx, job, 256)
+			return err
+		}},
+		{"ecdsa2p.DKG", func(i int, job *cbmpc.Job2P) error {
+			res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveSecp256k1})
+			if err != nil {
+				return err
+			}
+			keys2[i] = res.Key
+			return nil
+		}},
+		{"ecdsa2p.Sign", func(i int, job *cbmpc.Job2P) error {
+			_, err := ecdsa2p.Sign(ctx, job, &ecdsa2p.SignParams{Key: keys2[i], Message: digest()})
+			return err
+		}},
+	}
+	for _, step := range steps2 {
+		f, err := record(step.name, recs2, func(i int) error { return step.run(i, jobs2[i]) })
+		if err != nil {
+			return nil, err
+		}
+		flows = append(flows, f)
+	}
+	for _, k := range keys2 {
+		if k != nil {
+			_ = k.Close()
+		}
+	}
+
+	// Multi-party protocols.
+	netMP := mocknet.New()
+	roles := make([]cbmpc.RoleID, n)
+	names := make([]string, n)
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+		names[i] = fmt.Sprintf("p%d", i)
+	}
+	recsMP := make([]*protoflow.Recorder, n)
+	jobsMP := make([]*cbmpc.JobMP, n)
+	for i, role := range roles {
+		recsMP[i] = protoflow.NewRecorder(netMP.EpMP(role, roles), role)
+		job, err := cbmpc.NewJobMPWithContext(ctx, recsMP[i], role, names)
+		if err != nil {
+			return nil, fmt.Errorf("NewJobMP: %w", err)
+		}
+		defer job.Close()
+		jobsMP[i] = job
+	}
+
+	keysMP := make([]*ecdsamp.Key, n)
+	stepsMP := []struct {
+		name string
+		run  func(i int, job *cbmpc.JobMP) error
+	}{
+		{"agreerandom.MultiAgreeRandom", func(_ int, job *cbmpc.JobMP) error {
+			_, err := agreerandom.MultiAgreeRandom(ctx, job, 256)
+			return err
+		}},
+		{"ecdsamp.DKG", func(i int, job *cbmpc.JobMP) error {
+			res, err := ecdsamp.DKG(ctx, job, &ecdsamp.DKGParams{Curve: cbmpc.CurveSecp256k1})
+			if err != nil {
+				return err
+			}
+			keysMP[i] = res.Key
+			return nil
+		}},
+		{"ecdsamp.Sign", func(i int, job *cbmpc.JobMP) error {
+			_, err := ecdsamp.Sign(ctx, job, &ecdsamp.SignParams{Key: keysMP[i], Message: digest(), SigReceiver: 0})
+			return err
+		}},
+	}
+	for _, step := range stepsMP {
+		f, err := record(step.name, recsMP, func(i int) error { return step.run(i, jobsMP[i]) })
+		if err != nil {
+			return nil, err
+		}
+		flows = append(flows, f)
+	}
+	for _, k := range keysMP {
+		if k != nil {
+			_ = k.Close()
+		}
+	}
+
+	return flows, nil
+}
+
+// record runs one protocol on every party concurrently and builds its flow.
+func record(name string, recs []*protoflow.Recorder, run func(i int) error) (*protoflow.Flow, error) {
+	for _, rec := range recs {
+		rec.Reset()
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(recs))
+	for i := range recs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = run(i)
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("%s (party %d): %w", name, i, err)
+		}
+	}
+	return protoflow.Build(name, recs...)
+}
+
+func digest() []byte {
+	h := sha256.Sum256([]byte("protocol-flows"))
+	return h[:]
+}
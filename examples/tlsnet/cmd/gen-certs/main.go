@@ -5,7 +5,7 @@ import (
 	"log"
 	"strings"
 
-	"github.com/coinbase/cb-mpc-go/examples/tlsnet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/tlsnet"
 )
 
 func main() {
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	mpcx509 "github.com/coinbase/cb-mpc-go/pkg/cbmpc/integrations/x509"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+func main() {
+	var (
+		cn      = flag.String("cn", "MPC Root CA", "common name of the CA")
+		host    = flag.String("host", "service.example", "DNS name of the leaf certificate")
+		timeout = flag.Duration("timeout", time.Minute, "overall timeout")
+	)
+	flag.Parse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	// The service generates its own key and CSR; the CA never sees its key.
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		log.Fatal(err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: *host},
+		DNSNames: []string{*host},
+	}, leafKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	caDER, leafDER, err := runCA(ctx, *cn, csrDER)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		log.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(leafDER)
+	if err != nil {
+		log.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	if _, err := leaf.Verify(x509.VerifyOptions{DNSName: *host, Roots: roots}); err != nil {
+		log.Fatalf("verify chain: %v", err)
+	}
+	log.Printf("issued %q by %q; chain verified", leaf.Subject.CommonName, caCert.Subject.CommonName)
+
+	for _, der := range [][]byte{caDER, leafDER} {
+		if err := pem.Encode(os.Stdout, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// runCA runs both CA parties in-process: a 2-party ECDSA DKG, a self-signed
+// CA certificate, and a leaf certificate for the CSR. In a deployment each
+// party runs in its own process, checks the CSR and builds the same templates
+// independently. It returns the certificates received by P1.
+func runCA(ctx context.Context, cn string, csrDER []byte) (caDER, leafDER []byte, err error) {
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, nil, fmt.Errorf("csr: %w", err)
+	}
+
+	// Fixed inputs shared by both parties, so they sign identical certificates.
+	now := time.Now().Truncate(time.Second)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             now,
+		NotAfter:              now.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	leafTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		NotBefore:    now,
+		NotAfter:     now.AddDate(0, 3, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	net := mocknet.New()
+	names := [2]string{"ca-p1", "ca-p2"}
+	var signers [2]*mpcx509.ECDSA2PSigner
+	for i := range signers {
+		role := cbmpc.Role(i)
+		job, err := cbmpc.NewJob2PWithContext(ctx, net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), role, names)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer job.Close()
+		signers[i] = &mpcx509.ECDSA2PSigner{Job: job}
+	}
+
+	var outs [2][]byte
+	err = both(func(i int) error {
+		res, err := ecdsa2p.DKG(ctx, signers[i].Job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
+		if err != nil {
+			return fmt.Errorf("dkg: %w", err)
+		}
+		signers[i].Key = res.Key
+		return nil
+	})
+	defer func() {
+		for _, s := range signers {
+			if s.Key != nil {
+				_ = s.Key.Close()
+			}
+		}
+	}()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = both(func(i int) error {
+		pub, err := signers[i].Public()
+		if err != nil {
+			return err
+		}
+		outs[i], err = mpcx509.CreateCertificate(ctx, signers[i], caTmpl, caTmpl, pub)
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("ca certificate: %w", err)
+	}
+	caDER = outs[0]
+
+	// P1 publishes the CA certificate; both parties issue under it, since
+	// fields crypto/x509 filled in (the subject key ID) go into the leaf.
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = both(func(i int) error {
+		var err error
+		outs[i], err = mpcx509.CreateCertificate(ctx, signers[i], leafTmpl, caCert, csr.PublicKey)
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("leaf certificate: %w", err)
+	}
+	return caDER, outs[0], nil
+}
+
+// both runs fn for P1 and P2 concurrently.
+func both(fn func(i int) error) error {
+	var wg sync.WaitGroup
+	var errs [2]error
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("party %d: %w", i, err)
+		}
+	}
+	return nil
+}
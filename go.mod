@@ -4,7 +4,9 @@ go 1.25.2
 
 require (
 	github.com/btcsuite/btcd/btcec/v2 v2.3.5
+	golang.org/x/crypto v0.54.0
 	golang.org/x/tools v0.31.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -13,4 +15,5 @@ require (
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.0 // indirect
 	golang.org/x/mod v0.24.0 // indirect
 	golang.org/x/sync v0.15.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
 )
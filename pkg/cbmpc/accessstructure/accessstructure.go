@@ -164,6 +164,13 @@ func buildNode(e Expr) (backend.ACNode, error) {
 		// Build Threshold node (this takes ownership of children, so we don't free them)
 		return backend.ACThreshold(expr.k, nodes)
 
+	case weightedExpr:
+		expanded, err := expr.expand()
+		if err != nil {
+			return nil, err
+		}
+		return buildNode(expanded)
+
 	default:
 		return nil, errors.New("unknown expression type")
 	}
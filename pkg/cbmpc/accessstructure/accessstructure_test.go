@@ -183,3 +183,50 @@ func TestAccessStructureSingleLeaf(t *testing.T) {
 	// The leaf path is "/" or empty since root has no name
 	// We accept this as correct behavior for a single-leaf access structure
 }
+
+func TestAccessStructureQuorums(t *testing.T) {
+	// 2-of-3 threshold: every pair is a minimal quorum.
+	structure, err := Compile(Threshold(2, Leaf("alice"), Leaf("bob"), Leaf("charlie")))
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	quorums := structure.MinimalQuorums()
+	if len(quorums) != 3 {
+		t.Fatalf("expected 3 minimal quorums, got %v", quorums)
+	}
+	for _, q := range quorums {
+		if len(q) != 2 {
+			t.Fatalf("expected quorums of size 2, got %v", q)
+		}
+		if !structure.IsSatisfiedBy(q) {
+			t.Fatalf("minimal quorum %v does not satisfy the structure", q)
+		}
+		if structure.IsSatisfiedBy(q[:1]) {
+			t.Fatalf("single party %v must not satisfy the structure", q[:1])
+		}
+	}
+}
+
+func TestAccessStructureWeighted(t *testing.T) {
+	// alice carries two votes; any 2 votes satisfy the policy.
+	structure, err := Compile(Weighted(2, map[Expr]int{
+		Leaf("alice"): 2,
+		Leaf("bob"):   1,
+		Leaf("carol"): 1,
+	}))
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	quorums := structure.MinimalQuorums()
+	aliceAlone := false
+	for _, q := range quorums {
+		if len(q) == 2 && strings.Contains(q[0], WeightedLeafName("alice", 1)) && strings.Contains(q[1], WeightedLeafName("alice", 2)) {
+			aliceAlone = true
+		}
+	}
+	if !aliceAlone {
+		t.Fatalf("expected alice's two virtual leaves to form a quorum, got %v", quorums)
+	}
+}
@@ -7,11 +7,16 @@
 //
 // # Building Access Structures
 //
-// The package provides four expression types:
+// The package provides five expression types:
 //   - Leaf(name): A party identified by name
 //   - And(children...): Requires ALL children to satisfy the policy
 //   - Or(children...): Requires ANY child to satisfy the policy
 //   - Threshold(k, children...): Requires k of n children to satisfy the policy
+//   - Weighted(k, weights): Requires the weights of satisfied children to sum
+//     to at least k; compiled to a Threshold gate over virtual leaves
+//
+// A leaf of weight w in a Weighted gate compiles to w virtual leaves named
+// WeightedLeafName(name, 1..w), each of which needs its own PVE-AC key entry.
 //
 // # Compilation
 //
@@ -62,11 +67,16 @@
 // Paths are hierarchical strings like "alice", "or1/bob", "or1/threshold2/charlie".
 // The caller is responsible for using consistent names across operations.
 //
-// # Debugging
+// # Other Operations
 //
-// The String() method returns a summary of the access structure:
+//   - FromJSON, FromYAML, ToJSON: Policy documents kept in configuration
+//   - LeafPaths, CheckPathToEK: The exact paths of a compiled structure, and a
+//     check of a PVE-AC PathToEK map against them
+//   - IsSatisfiedBy, MinimalQuorums: Which sets of parties meet the policy
+//   - String: A summary such as "AC with 3 leaves: [/alice /bob /charlie]"
 //
-//	str, _ := structure.String()  // e.g., "AC with 3 leaves: [/alice /bob /charlie]"
+// README.md shows weighted gates, policy documents, path checks and quorum
+// selection with examples.
 //
 // See cb-mpc/src/cbmpc/crypto/secret_sharing.h for access structure implementation.
 package accessstructure
@@ -0,0 +1,96 @@
+package accessstructure
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+// LeafPaths returns the full path of every leaf in the structure, in the
+// order they appear. These are the keys PVE-AC expects in PathToEK.
+func (s AccessStructure) LeafPaths() ([]string, error) {
+	if len(s) == 0 {
+		return nil, errors.New("empty AccessStructure")
+	}
+	paths, err := backend.ACListLeafPaths(s)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	return paths, nil
+}
+
+// PathMismatchError reports the difference between the structure's leaf
+// paths and a set of paths supplied by the caller. Both lists are sorted.
+type PathMismatchError struct {
+	Missing []string // Leaf paths with no entry
+	Extra   []string // Entries that are not leaf paths
+}
+
+func (e *PathMismatchError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Extra) > 0 {
+		parts = append(parts, "unknown "+strings.Join(e.Extra, ", "))
+	}
+	return "leaf paths do not match access structure: " + strings.Join(parts, "; ")
+}
+
+// CheckPaths returns a *PathMismatchError unless paths contains every leaf
+// path of the structure and nothing else.
+func (s AccessStructure) CheckPaths(paths []string) error {
+	leaves, err := s.LeafPaths()
+	if err != nil {
+		return err
+	}
+	if e := comparePaths(leaves, paths); e != nil {
+		return e
+	}
+	return nil
+}
+
+// CheckPathToEK is CheckPaths over the keys of a PVE-AC PathToEK map. Use it
+// before ACEncrypt or ACVerify to get the offending paths instead of a native
+// error.
+func (s AccessStructure) CheckPathToEK(pathToEK map[string][]byte) error {
+	paths := make([]string, 0, len(pathToEK))
+	for p := range pathToEK {
+		paths = append(paths, p)
+	}
+	return s.CheckPaths(paths)
+}
+
+// comparePaths returns nil if got is exactly the set of leaves.
+func comparePaths(leaves, got []string) *PathMismatchError {
+	want := make(map[string]bool, len(leaves))
+	for _, p := range leaves {
+		want[p] = true
+	}
+	seen := make(map[string]bool, len(got))
+	e := &PathMismatchError{}
+	for _, p := range got {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		if !want[p] {
+			e.Extra = append(e.Extra, p)
+		}
+	}
+	for _, p := range leaves {
+		if !seen[p] {
+			e.Missing = append(e.Missing, p)
+		}
+	}
+	if len(e.Missing) == 0 && len(e.Extra) == 0 {
+		return nil
+	}
+	sort.Strings(e.Missing)
+	sort.Strings(e.Extra)
+	return e
+}
+
@@ -0,0 +1,34 @@
+package accessstructure
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComparePaths(t *testing.T) {
+	leaves := []string{"/alice", "/or1/bob", "/or1/th1/charlie"}
+
+	if e := comparePaths(leaves, []string{"/or1/th1/charlie", "/alice", "/or1/bob"}); e != nil {
+		t.Fatalf("exact match reported %v", e)
+	}
+
+	e := comparePaths(leaves, []string{"/alice", "/bob", "/zed", "/alice"})
+	if e == nil {
+		t.Fatal("expected mismatch")
+	}
+	if want := []string{"/or1/bob", "/or1/th1/charlie"}; !reflect.DeepEqual(e.Missing, want) {
+		t.Errorf("Missing = %v, want %v", e.Missing, want)
+	}
+	if want := []string{"/bob", "/zed"}; !reflect.DeepEqual(e.Extra, want) {
+		t.Errorf("Extra = %v, want %v", e.Extra, want)
+	}
+	msg := e.Error()
+	if !strings.Contains(msg, "missing /or1/bob, /or1/th1/charlie") || !strings.Contains(msg, "unknown /bob, /zed") {
+		t.Errorf("unexpected message %q", msg)
+	}
+
+	if e := comparePaths(leaves, nil); e == nil || len(e.Missing) != 3 || e.Extra != nil {
+		t.Errorf("empty input: %+v", e)
+	}
+}
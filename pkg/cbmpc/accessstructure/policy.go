@@ -0,0 +1,213 @@
+package accessstructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Node types used in declarative policy documents.
+const (
+	nodeLeaf      = "leaf"
+	nodeAnd       = "and"
+	nodeOr        = "or"
+	nodeThreshold = "threshold"
+	nodeWeighted  = "weighted"
+)
+
+// policyNode is the wire form of an Expr in JSON and YAML policy documents:
+//
+//	{"type": "threshold", "k": 2, "children": [
+//	    {"type": "leaf", "name": "alice"},
+//	    {"type": "leaf", "name": "bob"},
+//	    {"type": "leaf", "name": "charlie"}
+//	]}
+//
+// Children of a weighted gate carry a weight (default 1):
+//
+//	{"type": "weighted", "k": 3, "children": [
+//	    {"type": "leaf", "name": "alice", "weight": 2},
+//	    {"type": "leaf", "name": "bob"}
+//	]}
+type policyNode struct {
+	Type     string       `json:"type" yaml:"type"`
+	Name     string       `json:"name,omitempty" yaml:"name,omitempty"`
+	K        int          `json:"k,omitempty" yaml:"k,omitempty"`
+	Weight   int          `json:"weight,omitempty" yaml:"weight,omitempty"`
+	Children []policyNode `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// FromJSON parses a declarative policy document into an expression tree.
+// Unknown fields are rejected so typos in config management fail loudly.
+// The result can be passed to Compile.
+func FromJSON(data []byte) (Expr, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var n policyNode
+	if err := dec.Decode(&n); err != nil {
+		return nil, fmt.Errorf("parse policy: %w", err)
+	}
+	if dec.More() {
+		return nil, errors.New("parse policy: trailing data after document")
+	}
+	return n.toExpr("")
+}
+
+// FromYAML parses a declarative policy document in YAML form. The schema is
+// identical to FromJSON.
+func FromYAML(data []byte) (Expr, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var n policyNode
+	if err := dec.Decode(&n); err != nil {
+		return nil, fmt.Errorf("parse policy: %w", err)
+	}
+	return n.toExpr("")
+}
+
+// ToJSON renders an expression tree as a policy document accepted by FromJSON.
+func ToJSON(e Expr) ([]byte, error) {
+	n, err := fromExpr(e)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(n, "", "  ")
+}
+
+// toExpr converts the wire form into an Expr, validating structure as it
+// goes. at identifies the node in error messages.
+func (n policyNode) toExpr(at string) (Expr, error) {
+	if at == "" {
+		at = "root"
+	}
+	if n.Weight != 0 {
+		return nil, fmt.Errorf("%s: weight is only allowed on children of a weighted gate", at)
+	}
+	switch n.Type {
+	case nodeLeaf:
+		if n.Name == "" {
+			return nil, fmt.Errorf("%s: leaf requires a name", at)
+		}
+		if len(n.Children) != 0 || n.K != 0 {
+			return nil, fmt.Errorf("%s: leaf %q must not have children or k", at, n.Name)
+		}
+		return Leaf(n.Name), nil
+
+	case nodeAnd, nodeOr, nodeThreshold:
+		if n.Name != "" {
+			return nil, fmt.Errorf("%s: %s gate must not have a name", at, n.Type)
+		}
+		if len(n.Children) == 0 {
+			return nil, fmt.Errorf("%s: %s gate requires at least one child", at, n.Type)
+		}
+		children := make([]Expr, len(n.Children))
+		for i, c := range n.Children {
+			child, err := c.toExpr(fmt.Sprintf("%s.children[%d]", at, i))
+			if err != nil {
+				return nil, err
+			}
+			children[i] = child
+		}
+		switch n.Type {
+		case nodeAnd:
+			if n.K != 0 {
+				return nil, fmt.Errorf("%s: and gate must not have k", at)
+			}
+			return And(children...), nil
+		case nodeOr:
+			if n.K != 0 {
+				return nil, fmt.Errorf("%s: or gate must not have k", at)
+			}
+			return Or(children...), nil
+		default:
+			if n.K <= 0 || n.K > len(children) {
+				return nil, fmt.Errorf("%s: threshold k must be in [1, %d] (got %d)", at, len(children), n.K)
+			}
+			return Threshold(n.K, children...), nil
+		}
+
+	case nodeWeighted:
+		if n.Name != "" {
+			return nil, fmt.Errorf("%s: weighted gate must not have a name", at)
+		}
+		if len(n.Children) == 0 {
+			return nil, fmt.Errorf("%s: weighted gate requires at least one child", at)
+		}
+		w := weightedExpr{k: n.K, children: make([]weightedChild, len(n.Children))}
+		total := 0
+		for i, c := range n.Children {
+			weight := c.Weight
+			if weight == 0 {
+				weight = 1
+			}
+			if weight < 0 {
+				return nil, fmt.Errorf("%s.children[%d]: weight must be positive (got %d)", at, i, weight)
+			}
+			c.Weight = 0
+			child, err := c.toExpr(fmt.Sprintf("%s.children[%d]", at, i))
+			if err != nil {
+				return nil, err
+			}
+			w.children[i] = weightedChild{expr: child, weight: weight}
+			total += weight
+		}
+		if n.K <= 0 || n.K > total {
+			return nil, fmt.Errorf("%s: weighted k must be in [1, %d] (got %d)", at, total, n.K)
+		}
+		return w, nil
+
+	case "":
+		return nil, fmt.Errorf("%s: missing node type", at)
+	default:
+		return nil, fmt.Errorf("%s: unknown node type %q", at, n.Type)
+	}
+}
+
+// fromExpr converts an Expr into its wire form.
+func fromExpr(e Expr) (policyNode, error) {
+	switch expr := e.(type) {
+	case leaf:
+		return policyNode{Type: nodeLeaf, Name: expr.name}, nil
+	case andExpr:
+		children, err := fromExprs(expr.children)
+		return policyNode{Type: nodeAnd, Children: children}, err
+	case orExpr:
+		children, err := fromExprs(expr.children)
+		return policyNode{Type: nodeOr, Children: children}, err
+	case thresholdExpr:
+		children, err := fromExprs(expr.children)
+		return policyNode{Type: nodeThreshold, K: expr.k, Children: children}, err
+	case weightedExpr:
+		children := make([]policyNode, len(expr.children))
+		for i, c := range expr.children {
+			n, err := fromExpr(c.expr)
+			if err != nil {
+				return policyNode{}, err
+			}
+			if c.weight != 1 {
+				n.Weight = c.weight
+			}
+			children[i] = n
+		}
+		return policyNode{Type: nodeWeighted, K: expr.k, Children: children}, nil
+	case nil:
+		return policyNode{}, errors.New("nil expression")
+	default:
+		return policyNode{}, errors.New("unknown expression type")
+	}
+}
+
+func fromExprs(es []Expr) ([]policyNode, error) {
+	out := make([]policyNode, len(es))
+	for i, e := range es {
+		n, err := fromExpr(e)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = n
+	}
+	return out, nil
+}
@@ -0,0 +1,122 @@
+package accessstructure
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFromJSON(t *testing.T) {
+	doc := `{
+		"type": "and",
+		"children": [
+			{"type": "leaf", "name": "alice"},
+			{"type": "threshold", "k": 2, "children": [
+				{"type": "leaf", "name": "bob"},
+				{"type": "leaf", "name": "charlie"},
+				{"type": "leaf", "name": "dave"}
+			]}
+		]
+	}`
+
+	got, err := FromJSON([]byte(doc))
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	want := And(
+		Leaf("alice"),
+		Threshold(2, Leaf("bob"), Leaf("charlie"), Leaf("dave")),
+	)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FromJSON mismatch:\n got  %#v\n want %#v", got, want)
+	}
+}
+
+func TestFromYAML(t *testing.T) {
+	doc := `
+type: or
+children:
+  - type: leaf
+    name: alice
+  - type: and
+    children:
+      - type: leaf
+        name: bob
+      - type: leaf
+        name: charlie
+`
+	got, err := FromYAML([]byte(doc))
+	if err != nil {
+		t.Fatalf("FromYAML failed: %v", err)
+	}
+	want := Or(Leaf("alice"), And(Leaf("bob"), Leaf("charlie")))
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FromYAML mismatch:\n got  %#v\n want %#v", got, want)
+	}
+}
+
+func TestPolicyRoundTrip(t *testing.T) {
+	expr := And(
+		Leaf("alice"),
+		Or(
+			Leaf("bob"),
+			Threshold(2, Leaf("charlie"), Leaf("dave"), Leaf("eve")),
+		),
+	)
+
+	data, err := ToJSON(expr)
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	back, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	if !reflect.DeepEqual(back, expr) {
+		t.Fatalf("round trip mismatch:\n got  %#v\n want %#v", back, expr)
+	}
+}
+
+func TestFromJSONInvalid(t *testing.T) {
+	cases := map[string]struct {
+		doc  string
+		want string
+	}{
+		"malformed":         {`{"type":`, "parse policy"},
+		"unknown field":     {`{"type": "leaf", "name": "a", "votes": 2}`, "unknown field"},
+		"trailing data":     {`{"type": "leaf", "name": "a"} {}`, "trailing data"},
+		"missing type":      {`{"name": "a"}`, "missing node type"},
+		"unknown type":      {`{"type": "xor", "children": [{"type": "leaf", "name": "a"}]}`, "unknown node type"},
+		"leaf without name": {`{"type": "leaf"}`, "requires a name"},
+		"leaf with child":   {`{"type": "leaf", "name": "a", "children": [{"type": "leaf", "name": "b"}]}`, "must not have children"},
+		"empty gate":        {`{"type": "and"}`, "at least one child"},
+		"named gate":        {`{"type": "or", "name": "x", "children": [{"type": "leaf", "name": "a"}]}`, "must not have a name"},
+		"and with k":        {`{"type": "and", "k": 1, "children": [{"type": "leaf", "name": "a"}]}`, "must not have k"},
+		"threshold k zero":  {`{"type": "threshold", "children": [{"type": "leaf", "name": "a"}]}`, "threshold k"},
+		"threshold k big":   {`{"type": "threshold", "k": 3, "children": [{"type": "leaf", "name": "a"}, {"type": "leaf", "name": "b"}]}`, "threshold k"},
+		"nested error path": {`{"type": "and", "children": [{"type": "leaf", "name": "a"}, {"type": "leaf"}]}`, "root.children[1]"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := FromJSON([]byte(tc.doc))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("error %q does not mention %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromYAMLUnknownField(t *testing.T) {
+	if _, err := FromYAML([]byte("type: leaf\nname: alice\nvotes: 2\n")); err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+}
+
+func TestToJSONNil(t *testing.T) {
+	if _, err := ToJSON(nil); err == nil {
+		t.Fatal("expected error for nil expression")
+	}
+}
@@ -0,0 +1,236 @@
+package accessstructure
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+// IsSatisfiedBy reports whether the parties at the given leaf paths together
+// satisfy the policy. Paths are the full leaf paths used as PathToEK keys in
+// PVE-AC; unknown paths are ignored. An invalid structure is never satisfied.
+func (s AccessStructure) IsSatisfiedBy(paths []string) bool {
+	root, err := s.tree()
+	if err != nil {
+		return false
+	}
+	have := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		have[p] = true
+	}
+	return root.satisfied(have)
+}
+
+// MinimalQuorums returns every minimal set of leaf paths that satisfies the
+// policy: removing any path from a returned set breaks the policy. Each set is
+// sorted, and sets are ordered by size and then lexicographically. The number
+// of quorums grows combinatorially with threshold gates, so this is intended
+// for planning over committee-sized structures. It returns nil for an invalid
+// structure.
+func (s AccessStructure) MinimalQuorums() [][]string {
+	root, err := s.tree()
+	if err != nil {
+		return nil
+	}
+	quorums := minimize(root.quorums())
+	sort.Slice(quorums, func(i, j int) bool {
+		if len(quorums[i]) != len(quorums[j]) {
+			return len(quorums[i]) < len(quorums[j])
+		}
+		return strings.Join(quorums[i], "\x00") < strings.Join(quorums[j], "\x00")
+	})
+	return quorums
+}
+
+// acNode is the Go view of a compiled access structure node.
+type acNode struct {
+	path     string
+	kind     int
+	k        int
+	children []*acNode
+}
+
+func (s AccessStructure) tree() (*acNode, error) {
+	if len(s) == 0 {
+		return nil, errors.New("empty AccessStructure")
+	}
+	infos, err := backend.ACDescribe(s)
+	if err != nil {
+		return nil, err
+	}
+	return buildTree(infos)
+}
+
+// buildTree rebuilds a tree from its pre-order description.
+func buildTree(infos []backend.ACNodeInfo) (*acNode, error) {
+	pos := 0
+	var build func() (*acNode, error)
+	build = func() (*acNode, error) {
+		if pos >= len(infos) {
+			return nil, errors.New("truncated access structure description")
+		}
+		info := infos[pos]
+		pos++
+		n := &acNode{path: info.Path, kind: info.Kind, k: info.Threshold}
+		switch info.Kind {
+		case backend.ACKindLeaf:
+			if info.Children != 0 {
+				return nil, fmt.Errorf("leaf %q has children", info.Path)
+			}
+			return n, nil
+		case backend.ACKindAnd, backend.ACKindOr, backend.ACKindThreshold:
+		default:
+			return nil, fmt.Errorf("node %q has unknown kind %d", info.Path, info.Kind)
+		}
+		if info.Children == 0 {
+			return nil, fmt.Errorf("gate %q has no children", info.Path)
+		}
+		if info.Kind == backend.ACKindThreshold && (info.Threshold <= 0 || info.Threshold > info.Children) {
+			return nil, fmt.Errorf("gate %q has invalid threshold %d of %d", info.Path, info.Threshold, info.Children)
+		}
+		for i := 0; i < info.Children; i++ {
+			child, err := build()
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, child)
+		}
+		return n, nil
+	}
+
+	root, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if pos != len(infos) {
+		return nil, errors.New("trailing nodes in access structure description")
+	}
+	return root, nil
+}
+
+// need returns how many children must be satisfied for a gate.
+func (n *acNode) need() int {
+	switch n.kind {
+	case backend.ACKindAnd:
+		return len(n.children)
+	case backend.ACKindOr:
+		return 1
+	default:
+		return n.k
+	}
+}
+
+func (n *acNode) satisfied(have map[string]bool) bool {
+	if n.kind == backend.ACKindLeaf {
+		return have[n.path]
+	}
+	count := 0
+	for _, c := range n.children {
+		if c.satisfied(have) {
+			count++
+		}
+	}
+	return count >= n.need()
+}
+
+// quorums returns the minimal quorums of the subtree rooted at n.
+func (n *acNode) quorums() [][]string {
+	if n.kind == backend.ACKindLeaf {
+		return [][]string{{n.path}}
+	}
+	children := make([][][]string, len(n.children))
+	for i, c := range n.children {
+		children[i] = c.quorums()
+	}
+
+	var out [][]string
+	chosen := make([]int, 0, n.need())
+	var pick func(start int)
+	pick = func(start int) {
+		if len(chosen) == n.need() {
+			acc := [][]string{nil}
+			for _, i := range chosen {
+				acc = product(acc, children[i])
+			}
+			out = append(out, acc...)
+			return
+		}
+		for i := start; i <= len(children)-(n.need()-len(chosen)); i++ {
+			chosen = append(chosen, i)
+			pick(i + 1)
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+	pick(0)
+	return minimize(out)
+}
+
+// product returns the pairwise unions of a and b.
+func product(a, b [][]string) [][]string {
+	out := make([][]string, 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			out = append(out, union(x, y))
+		}
+	}
+	return out
+}
+
+// union merges two sorted, duplicate-free path sets.
+func union(a, b []string) []string {
+	out := make([]string, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			out = append(out, a[i])
+			i++
+		case a[i] > b[j]:
+			out = append(out, b[j])
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	out = append(out, a[i:]...)
+	return append(out, b[j:]...)
+}
+
+// minimize drops duplicate sets and sets that contain another set.
+func minimize(sets [][]string) [][]string {
+	sort.SliceStable(sets, func(i, j int) bool { return len(sets[i]) < len(sets[j]) })
+	var out [][]string
+	for _, s := range sets {
+		redundant := false
+		for _, kept := range out {
+			if subset(kept, s) {
+				redundant = true
+				break
+			}
+		}
+		if !redundant {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// subset reports whether sorted set a is contained in sorted set b.
+func subset(a, b []string) bool {
+	j := 0
+	for _, x := range a {
+		for j < len(b) && b[j] < x {
+			j++
+		}
+		if j == len(b) || b[j] != x {
+			return false
+		}
+		j++
+	}
+	return true
+}
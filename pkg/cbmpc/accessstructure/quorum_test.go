@@ -0,0 +1,111 @@
+package accessstructure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+// alice AND (bob OR 2-of-3(charlie, dave, eve)), in pre-order.
+var nestedInfos = []backend.ACNodeInfo{
+	{Path: "", Kind: backend.ACKindAnd, Children: 2},
+	{Path: "/alice", Kind: backend.ACKindLeaf},
+	{Path: "/or1", Kind: backend.ACKindOr, Children: 2},
+	{Path: "/or1/bob", Kind: backend.ACKindLeaf},
+	{Path: "/or1/th1", Kind: backend.ACKindThreshold, Threshold: 2, Children: 3},
+	{Path: "/or1/th1/charlie", Kind: backend.ACKindLeaf},
+	{Path: "/or1/th1/dave", Kind: backend.ACKindLeaf},
+	{Path: "/or1/th1/eve", Kind: backend.ACKindLeaf},
+}
+
+func TestTreeSatisfied(t *testing.T) {
+	root, err := buildTree(nestedInfos)
+	if err != nil {
+		t.Fatalf("buildTree failed: %v", err)
+	}
+
+	cases := []struct {
+		paths []string
+		want  bool
+	}{
+		{[]string{"/alice", "/or1/bob"}, true},
+		{[]string{"/alice", "/or1/th1/charlie", "/or1/th1/eve"}, true},
+		{[]string{"/alice", "/or1/th1/charlie"}, false},
+		{[]string{"/or1/bob", "/or1/th1/charlie", "/or1/th1/dave"}, false},
+		{[]string{"/alice", "bob"}, false},
+		{nil, false},
+	}
+	for _, tc := range cases {
+		have := make(map[string]bool)
+		for _, p := range tc.paths {
+			have[p] = true
+		}
+		if got := root.satisfied(have); got != tc.want {
+			t.Errorf("satisfied(%v) = %v, want %v", tc.paths, got, tc.want)
+		}
+	}
+}
+
+func TestTreeQuorums(t *testing.T) {
+	root, err := buildTree(nestedInfos)
+	if err != nil {
+		t.Fatalf("buildTree failed: %v", err)
+	}
+	got := minimize(root.quorums())
+	want := [][]string{
+		{"/alice", "/or1/bob"},
+		{"/alice", "/or1/th1/charlie", "/or1/th1/dave"},
+		{"/alice", "/or1/th1/charlie", "/or1/th1/eve"},
+		{"/alice", "/or1/th1/dave", "/or1/th1/eve"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("quorums mismatch:\n got  %v\n want %v", got, want)
+	}
+}
+
+func TestTreeQuorumsMinimized(t *testing.T) {
+	// a OR (a AND b): the AND branch is never minimal.
+	root, err := buildTree([]backend.ACNodeInfo{
+		{Path: "", Kind: backend.ACKindOr, Children: 2},
+		{Path: "/a", Kind: backend.ACKindLeaf},
+		{Path: "/and1", Kind: backend.ACKindAnd, Children: 2},
+		{Path: "/a", Kind: backend.ACKindLeaf},
+		{Path: "/and1/b", Kind: backend.ACKindLeaf},
+	})
+	if err != nil {
+		t.Fatalf("buildTree failed: %v", err)
+	}
+	got := minimize(root.quorums())
+	if want := [][]string{{"/a"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("quorums mismatch: got %v, want %v", got, want)
+	}
+}
+
+func TestBuildTreeInvalid(t *testing.T) {
+	cases := map[string][]backend.ACNodeInfo{
+		"empty":     nil,
+		"truncated": {{Kind: backend.ACKindAnd, Children: 2}, {Path: "/a", Kind: backend.ACKindLeaf}},
+		"trailing":  {{Path: "/a", Kind: backend.ACKindLeaf}, {Path: "/b", Kind: backend.ACKindLeaf}},
+		"bad kind":  {{Kind: 9}},
+		"bad k":     {{Kind: backend.ACKindThreshold, Threshold: 3, Children: 2}, {Path: "/a", Kind: backend.ACKindLeaf}, {Path: "/b", Kind: backend.ACKindLeaf}},
+		"leaf kids": {{Path: "/a", Kind: backend.ACKindLeaf, Children: 1}, {Path: "/b", Kind: backend.ACKindLeaf}},
+	}
+	for name, infos := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := buildTree(infos); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestInvalidStructure(t *testing.T) {
+	var empty AccessStructure
+	if empty.IsSatisfiedBy([]string{"/alice"}) {
+		t.Fatal("empty structure must not be satisfied")
+	}
+	if q := empty.MinimalQuorums(); q != nil {
+		t.Fatalf("expected nil quorums, got %v", q)
+	}
+}
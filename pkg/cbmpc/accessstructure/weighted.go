@@ -0,0 +1,97 @@
+package accessstructure
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// weightedChild is one input of a weighted-threshold gate.
+type weightedChild struct {
+	expr   Expr
+	weight int
+}
+
+// weightedExpr is a weighted-threshold gate requiring the satisfied children's
+// weights to sum to at least k. It is expanded into a plain threshold gate at
+// Compile time.
+type weightedExpr struct {
+	k        int
+	children []weightedChild
+}
+
+func (weightedExpr) isExpr() {}
+
+// Weighted creates a weighted-threshold gate: the policy is satisfied when the
+// weights of the satisfied children sum to at least k.
+//
+// The gate compiles to a Threshold(k, ...) gate in which a leaf of weight w is
+// replaced by w virtual leaves named WeightedLeafName(name, 1..w); leaves of
+// weight 1 keep their name. A party with weight w therefore holds w shares and
+// needs a PVE-AC key entry for each virtual leaf path. Only leaves may carry a
+// weight above 1, since replicating a subtree would duplicate its party names.
+//
+// Map keys are typically Leaf values; And, Or and Threshold expressions are not
+// comparable and cannot be used as map keys. Children are ordered
+// deterministically, so the same map always compiles to the same structure.
+func Weighted(k int, weights map[Expr]int) Expr {
+	w := weightedExpr{k: k, children: make([]weightedChild, 0, len(weights))}
+	for e, weight := range weights {
+		w.children = append(w.children, weightedChild{expr: e, weight: weight})
+	}
+	sort.Slice(w.children, func(i, j int) bool {
+		return exprSortKey(w.children[i].expr) < exprSortKey(w.children[j].expr)
+	})
+	return w
+}
+
+// WeightedLeafName returns the name of the i-th (1-based) virtual leaf that a
+// weighted party is expanded into.
+func WeightedLeafName(name string, i int) string {
+	return fmt.Sprintf("%s#%d", name, i)
+}
+
+// expand lowers the gate into an equivalent threshold gate.
+func (w weightedExpr) expand() (Expr, error) {
+	if len(w.children) == 0 {
+		return nil, errors.New("Weighted gate requires at least one child")
+	}
+	total := 0
+	var children []Expr
+	for _, c := range w.children {
+		if c.expr == nil {
+			return nil, errors.New("Weighted gate has a nil child")
+		}
+		if c.weight <= 0 {
+			return nil, fmt.Errorf("weight must be positive, got %d", c.weight)
+		}
+		total += c.weight
+		if c.weight == 1 {
+			children = append(children, c.expr)
+			continue
+		}
+		l, ok := c.expr.(leaf)
+		if !ok {
+			return nil, errors.New("only leaves may have a weight above 1")
+		}
+		for i := 1; i <= c.weight; i++ {
+			children = append(children, Leaf(WeightedLeafName(l.name, i)))
+		}
+	}
+	if w.k <= 0 {
+		return nil, fmt.Errorf("weighted threshold k must be positive, got %d", w.k)
+	}
+	if w.k > total {
+		return nil, fmt.Errorf("weighted threshold k (%d) cannot exceed total weight (%d)", w.k, total)
+	}
+	return Threshold(w.k, children...), nil
+}
+
+// exprSortKey returns a canonical string for ordering expressions.
+func exprSortKey(e Expr) string {
+	data, err := ToJSON(e)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,113 @@
+package accessstructure
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWeightedExpand(t *testing.T) {
+	w := Weighted(3, map[Expr]int{
+		Leaf("alice"): 2,
+		Leaf("bob"):   1,
+		Leaf("carol"): 1,
+	})
+
+	got, err := w.(weightedExpr).expand()
+	if err != nil {
+		t.Fatalf("expand failed: %v", err)
+	}
+	want := Threshold(3,
+		Leaf("alice#1"), Leaf("alice#2"),
+		Leaf("bob"),
+		Leaf("carol"),
+	)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expand mismatch:\n got  %#v\n want %#v", got, want)
+	}
+}
+
+func TestWeightedDeterministic(t *testing.T) {
+	weights := map[Expr]int{Leaf("d"): 1, Leaf("a"): 3, Leaf("c"): 2, Leaf("b"): 1}
+	first, err := ToJSON(Weighted(4, weights))
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		again, _ := ToJSON(Weighted(4, weights))
+		if string(again) != string(first) {
+			t.Fatalf("Weighted ordering is not deterministic:\n%s\n%s", first, again)
+		}
+	}
+}
+
+func TestWeightedExpandInvalid(t *testing.T) {
+	cases := map[string]Expr{
+		"empty":          Weighted(1, nil),
+		"zero weight":    Weighted(1, map[Expr]int{Leaf("a"): 0}),
+		"k zero":         Weighted(0, map[Expr]int{Leaf("a"): 1}),
+		"k above total":  Weighted(4, map[Expr]int{Leaf("a"): 2, Leaf("b"): 1}),
+		"weighted gate":  weightedExpr{k: 2, children: []weightedChild{{Leaf("a"), 1}, {Or(Leaf("b"), Leaf("c")), 2}}},
+		"nil child expr": Weighted(1, map[Expr]int{nil: 1}),
+	}
+	for name, e := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := e.(weightedExpr).expand(); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestWeightedPolicyRoundTrip(t *testing.T) {
+	doc := `{"type": "weighted", "k": 3, "children": [
+		{"type": "leaf", "name": "alice", "weight": 2},
+		{"type": "leaf", "name": "bob"},
+		{"type": "and", "children": [
+			{"type": "leaf", "name": "carol"},
+			{"type": "leaf", "name": "dave"}
+		]}
+	]}`
+	e, err := FromJSON([]byte(doc))
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	expanded, err := e.(weightedExpr).expand()
+	if err != nil {
+		t.Fatalf("expand failed: %v", err)
+	}
+	if th, ok := expanded.(thresholdExpr); !ok || th.k != 3 || len(th.children) != 4 {
+		t.Fatalf("unexpected expansion %#v", expanded)
+	}
+
+	data, err := ToJSON(e)
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	back, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("FromJSON(ToJSON) failed: %v", err)
+	}
+	if !reflect.DeepEqual(back, e) {
+		t.Fatalf("round trip mismatch:\n got  %#v\n want %#v", back, e)
+	}
+}
+
+func TestWeightedPolicyInvalid(t *testing.T) {
+	cases := map[string]struct {
+		doc  string
+		want string
+	}{
+		"weight outside weighted": {`{"type": "or", "children": [{"type": "leaf", "name": "a", "weight": 2}]}`, "only allowed"},
+		"negative weight":         {`{"type": "weighted", "k": 1, "children": [{"type": "leaf", "name": "a", "weight": -1}]}`, "must be positive"},
+		"k above total":           {`{"type": "weighted", "k": 3, "children": [{"type": "leaf", "name": "a", "weight": 2}]}`, "weighted k"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := FromJSON([]byte(tc.doc))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error mentioning %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -11,15 +11,16 @@ import (
 
 // AgreeRandom is a Go wrapper for coinbase::mpc::agree_random.
 // See cb-mpc/src/cbmpc/protocol/agree_random.h for protocol details.
-func AgreeRandom(_ context.Context, j *cbmpc.Job2P, bitlen int) ([]byte, error) {
+func AgreeRandom(ctx context.Context, j *cbmpc.Job2P, bitlen int) ([]byte, error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	out, err := backend.AgreeRandom2P(ptr, bitlen)
 	if err != nil {
@@ -31,15 +32,16 @@ func AgreeRandom(_ context.Context, j *cbmpc.Job2P, bitlen int) ([]byte, error)
 
 // MultiAgreeRandom is a Go wrapper for coinbase::mpc::multi_agree_random.
 // See cb-mpc/src/cbmpc/protocol/agree_random.h for protocol details.
-func MultiAgreeRandom(_ context.Context, j *cbmpc.JobMP, bitlen int) ([]byte, error) {
+func MultiAgreeRandom(ctx context.Context, j *cbmpc.JobMP, bitlen int) ([]byte, error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	out, err := backend.AgreeRandomMP(ptr, bitlen)
 	if err != nil {
@@ -51,15 +53,16 @@ func MultiAgreeRandom(_ context.Context, j *cbmpc.JobMP, bitlen int) ([]byte, er
 
 // WeakMultiAgreeRandom is a Go wrapper for coinbase::mpc::weak_multi_agree_random.
 // See cb-mpc/src/cbmpc/protocol/agree_random.h for protocol details.
-func WeakMultiAgreeRandom(_ context.Context, j *cbmpc.JobMP, bitlen int) ([]byte, error) {
+func WeakMultiAgreeRandom(ctx context.Context, j *cbmpc.JobMP, bitlen int) ([]byte, error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	out, err := backend.WeakMultiAgreeRandom(ptr, bitlen)
 	if err != nil {
@@ -72,15 +75,16 @@ func WeakMultiAgreeRandom(_ context.Context, j *cbmpc.JobMP, bitlen int) ([]byte
 // MultiPairwiseAgreeRandom is a Go wrapper for coinbase::mpc::multi_pairwise_agree_random.
 // Returns a slice of []byte corresponding to the C++ std::vector<buf_t> output.
 // See cb-mpc/src/cbmpc/protocol/agree_random.h for protocol details.
-func MultiPairwiseAgreeRandom(_ context.Context, j *cbmpc.JobMP, bitlen int) ([][]byte, error) {
+func MultiPairwiseAgreeRandom(ctx context.Context, j *cbmpc.JobMP, bitlen int) ([][]byte, error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	out, err := backend.MultiPairwiseAgreeRandom(ptr, bitlen)
 	if err != nil {
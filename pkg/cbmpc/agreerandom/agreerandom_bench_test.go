@@ -0,0 +1,66 @@
+//go:build cgo && !windows
+
+package agreerandom_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/agreerandom"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// newMPJobs returns n jobs wired together over a fresh mocknet.
+func newMPJobs(tb testing.TB, ctx context.Context, n int) []*cbmpc.JobMP {
+	tb.Helper()
+	net := mocknet.New()
+	roles := make([]cbmpc.RoleID, n)
+	names := make([]string, n)
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+		names[i] = fmt.Sprintf("party%d", i)
+	}
+	jobs := make([]*cbmpc.JobMP, n)
+	for i, self := range roles {
+		job, err := cbmpc.NewJobMPWithContext(ctx, net.EpMP(self, roles), self, names)
+		if err != nil {
+			tb.Fatalf("NewJobMP role %d: %v", self, err)
+		}
+		jobs[i] = job
+		tb.Cleanup(func() { _ = job.Close() })
+	}
+	return jobs
+}
+
+func BenchmarkMultiAgreeRandom(b *testing.B) {
+	for _, n := range []int{3, 64, 128} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			defer cancel()
+			jobs := newMPJobs(b, ctx, n)
+
+			errs := make([]error, n)
+			b.ResetTimer()
+			for iter := 0; iter < b.N; iter++ {
+				var wg sync.WaitGroup
+				for i, job := range jobs {
+					wg.Add(1)
+					go func(i int, job *cbmpc.JobMP) {
+						defer wg.Done()
+						_, errs[i] = agreerandom.MultiAgreeRandom(ctx, job, 256)
+					}(i, job)
+				}
+				wg.Wait()
+				for i, err := range errs {
+					if err != nil {
+						b.Fatalf("MultiAgreeRandom role %d: %v", i, err)
+					}
+				}
+			}
+		})
+	}
+}
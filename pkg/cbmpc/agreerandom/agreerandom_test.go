@@ -4,6 +4,7 @@ package agreerandom_test
 
 import (
 	"context"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -75,6 +76,61 @@ func TestAgreeRandom2PNative(t *testing.T) {
 	}
 }
 
+func TestAgreeUniform2PNative(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	p1 := net.Ep2P(cbmpc.RoleID(cbmpc.RoleP1), cbmpc.RoleID(cbmpc.RoleP2))
+	p2 := net.Ep2P(cbmpc.RoleID(cbmpc.RoleP2), cbmpc.RoleID(cbmpc.RoleP1))
+	names := [2]string{"p1", "p2"}
+
+	job1, err := cbmpc.NewJob2P(p1, cbmpc.RoleP1, names)
+	if err != nil {
+		t.Fatalf("NewJob2P p1: %v", err)
+	}
+	defer func() {
+		_ = job1.Close()
+	}()
+	job2, err := cbmpc.NewJob2P(p2, cbmpc.RoleP2, names)
+	if err != nil {
+		t.Fatalf("NewJob2P p2: %v", err)
+	}
+	defer func() {
+		_ = job2.Close()
+	}()
+
+	// Just above a power of two, so about half of all draws are rejected
+	max := big.NewInt(1025)
+	for i := 0; i < 5; i++ {
+		var (
+			wg         sync.WaitGroup
+			v1, v2     *big.Int
+			err1, err2 error
+		)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			v1, err1 = agreerandom.AgreeUniform(ctx, job1, max)
+		}()
+		go func() {
+			defer wg.Done()
+			v2, err2 = agreerandom.AgreeUniform(ctx, job2, max)
+		}()
+		wg.Wait()
+
+		if err1 != nil || err2 != nil {
+			t.Fatalf("AgreeUniform: p1 %v, p2 %v", err1, err2)
+		}
+		if v1.Cmp(v2) != 0 {
+			t.Fatalf("party outputs differ: %v and %v", v1, v2)
+		}
+		if v1.Sign() < 0 || v1.Cmp(max) >= 0 {
+			t.Fatalf("output %v out of range", v1)
+		}
+	}
+}
+
 func TestMultiAgreeRandomNative(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
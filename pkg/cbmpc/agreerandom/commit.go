@@ -0,0 +1,219 @@
+package agreerandom
+
+import (
+	"bytes"
+	"context"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// ErrBadReveal is returned when a party's reveal does not open its
+// commitment. The error names the party.
+var ErrBadReveal = errors.New("reveal does not match commitment")
+
+// CommitParams configures CommitRandom.
+type CommitParams struct {
+	// Transport connects this party to every other party.
+	Transport cbmpc.Transport
+	// Self is this party's role. It must appear in Parties.
+	Self cbmpc.RoleID
+	// Parties lists every participant, including Self.
+	Parties []cbmpc.RoleID
+	// Bitlen is the output length in bits (>= 8, multiple of 8).
+	Bitlen int
+	// SessionID must be unique per run and identical across parties.
+	SessionID []byte
+}
+
+// Commitment is a commit-reveal random agreement between its commit round,
+// run by CommitRandom, and its reveal round, run by RevealRandom.
+type Commitment struct {
+	t         cbmpc.Transport
+	self      cbmpc.RoleID
+	parties   []cbmpc.RoleID
+	peers     []cbmpc.RoleID
+	bitlen    int
+	sessionID []byte
+	sid       [32]byte
+	nonce     []byte
+	share     []byte
+	commits   map[cbmpc.RoleID][]byte
+	revealed  bool
+}
+
+// Transcript records a commit-reveal run, so that auditors can recompute its
+// output with VerifyTranscript.
+type Transcript struct {
+	SessionID []byte         `json:"session_id"`
+	Bitlen    int            `json:"bitlen"`
+	Parties   []cbmpc.RoleID `json:"parties"`
+	// Commitments and Reveals hold every party's commitment and its opening,
+	// the 32-byte nonce followed by the 32-byte share.
+	Commitments map[cbmpc.RoleID][]byte `json:"commitments"`
+	Reveals     map[cbmpc.RoleID][]byte `json:"reveals"`
+}
+
+const (
+	commitRoundCommit byte = 3
+	commitRoundReveal byte = 4
+)
+
+// CommitRandom runs the commit round of a commit-reveal random agreement: it
+// commits to 32 random bytes, sends the commitment to every party and waits
+// for theirs. The caller may then run other protocol traffic before calling
+// RevealRandom, which completes the agreement.
+//
+// Unlike TolerantMultiAgreeRandom, every party must take part in both rounds.
+// A party that withholds its reveal makes the run fail rather than dropping
+// out, so it can abort the run but not choose the output.
+func CommitRandom(ctx context.Context, params *CommitParams) (*Commitment, error) {
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.Transport == nil {
+		return nil, cbmpc.ErrNilTransport
+	}
+	if params.Bitlen < 8 || params.Bitlen%8 != 0 {
+		return nil, cbmpc.ErrInvalidBits
+	}
+	if len(params.SessionID) == 0 {
+		return nil, errors.New("empty session ID")
+	}
+	peers, err := tolerantPeers(params.Self, params.Parties)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Commitment{
+		t:         params.Transport,
+		self:      params.Self,
+		parties:   tolerantSorted(params.Parties),
+		peers:     peers,
+		bitlen:    params.Bitlen,
+		sessionID: append([]byte(nil), params.SessionID...),
+		sid:       sha256.Sum256(params.SessionID),
+		nonce:     make([]byte, tolerantShareSize),
+		share:     make([]byte, tolerantShareSize),
+	}
+	if _, err := rand.Read(c.share); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(c.nonce); err != nil {
+		return nil, err
+	}
+
+	own := commitHash(c.sid[:], c.self, c.nonce, c.share)
+	c.commits, err = c.round(ctx, commitRoundCommit, own, sha256.Size)
+	if err != nil {
+		return nil, err
+	}
+	c.commits[c.self] = own
+	return c, nil
+}
+
+// Commitments returns every party's commitment, including this party's.
+func (c *Commitment) Commitments() map[cbmpc.RoleID][]byte {
+	out := make(map[cbmpc.RoleID][]byte, len(c.commits))
+	for role, v := range c.commits {
+		out[role] = append([]byte(nil), v...)
+	}
+	return out
+}
+
+// RevealRandom runs the reveal round: it sends this party's opening, checks
+// every other party's opening against its commitment, and returns the agreed
+// value with the transcript of the run. A reveal that does not open its
+// commitment fails the run with an error wrapping ErrBadReveal. RevealRandom
+// may be called only once.
+func (c *Commitment) RevealRandom(ctx context.Context) ([]byte, *Transcript, error) {
+	if c.revealed {
+		return nil, nil, errors.New("commitment already revealed")
+	}
+	c.revealed = true
+
+	own := append(append([]byte(nil), c.nonce...), c.share...)
+	reveals, err := c.round(ctx, commitRoundReveal, own, 2*tolerantShareSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	reveals[c.self] = own
+
+	tr := &Transcript{
+		SessionID:   c.sessionID,
+		Bitlen:      c.bitlen,
+		Parties:     c.parties,
+		Commitments: c.Commitments(),
+		Reveals:     reveals,
+	}
+	value, err := VerifyTranscript(tr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, tr, nil
+}
+
+// VerifyTranscript checks that every reveal in t opens its party's
+// commitment and returns the agreed value.
+func VerifyTranscript(t *Transcript) ([]byte, error) {
+	if t == nil {
+		return nil, errors.New("nil transcript")
+	}
+	if t.Bitlen < 8 || t.Bitlen%8 != 0 {
+		return nil, cbmpc.ErrInvalidBits
+	}
+	sid := sha256.Sum256(t.SessionID)
+	secret := make([]byte, 0, len(t.Parties)*(4+tolerantShareSize))
+	for _, role := range tolerantSorted(t.Parties) {
+		commit, reveal := t.Commitments[role], t.Reveals[role]
+		if len(reveal) != 2*tolerantShareSize {
+			return nil, fmt.Errorf("%w: party %d", ErrBadReveal, role)
+		}
+		nonce, share := reveal[:tolerantShareSize], reveal[tolerantShareSize:]
+		if !bytes.Equal(commitHash(sid[:], role, nonce, share), commit) {
+			return nil, fmt.Errorf("%w: party %d", ErrBadReveal, role)
+		}
+		secret = binary.BigEndian.AppendUint32(secret, uint32(role))
+		secret = append(secret, share...)
+	}
+	return hkdf.Key(sha256.New, secret, sid[:], "cbmpc/agreerandom/commit/output", t.Bitlen/8)
+}
+
+// round sends payload to every peer and receives theirs. Every peer must
+// answer with a well-formed frame.
+func (c *Commitment) round(ctx context.Context, round byte, payload []byte, size int) (map[cbmpc.RoleID][]byte, error) {
+	msg := tolerantFrame(round, c.sid[:], payload)
+	for _, p := range c.peers {
+		if err := c.t.Send(ctx, p, msg); err != nil {
+			return nil, err
+		}
+	}
+	got, err := c.t.ReceiveAll(ctx, c.peers)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[cbmpc.RoleID][]byte, len(c.peers)+1)
+	for _, p := range c.peers {
+		m := got[p]
+		if len(m) != 2+len(c.sid)+size || m[0] != tolerantVersion || m[1] != round || !bytes.Equal(m[2:2+len(c.sid)], c.sid[:]) {
+			return nil, fmt.Errorf("malformed round %d message from party %d", round-commitRoundCommit+1, p)
+		}
+		out[p] = m[2+len(c.sid):]
+	}
+	return out, nil
+}
+
+func commitHash(sid []byte, role cbmpc.RoleID, nonce, share []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte("cbmpc/agreerandom/commit/commit"))
+	h.Write(sid)
+	_ = binary.Write(h, binary.BigEndian, uint32(role))
+	h.Write(nonce)
+	h.Write(share)
+	return h.Sum(nil)
+}
@@ -0,0 +1,181 @@
+package agreerandom_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/agreerandom"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+type commitOutcome struct {
+	value []byte
+	tr    *agreerandom.Transcript
+	err   error
+}
+
+// runCommitReveal runs CommitRandom and RevealRandom for n parties,
+// exchanging an unrelated message between the two rounds. wrap, if set,
+// wraps each party's transport.
+func runCommitReveal(t *testing.T, n int, wrap func(self cbmpc.RoleID, ep cbmpc.Transport) cbmpc.Transport) map[cbmpc.RoleID]commitOutcome {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	roles := make([]cbmpc.RoleID, n)
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		out = make(map[cbmpc.RoleID]commitOutcome)
+	)
+	for _, self := range roles {
+		var ep cbmpc.Transport = net.EpMP(self, roles)
+		if wrap != nil {
+			ep = wrap(self, ep)
+		}
+		wg.Add(1)
+		go func(self cbmpc.RoleID) {
+			defer wg.Done()
+			var o commitOutcome
+			defer func() {
+				mu.Lock()
+				out[self] = o
+				mu.Unlock()
+			}()
+
+			c, err := agreerandom.CommitRandom(ctx, &agreerandom.CommitParams{
+				Transport: ep,
+				Self:      self,
+				Parties:   roles,
+				Bitlen:    256,
+				SessionID: []byte("commit-test"),
+			})
+			if err != nil {
+				o.err = err
+				return
+			}
+			if len(c.Commitments()) != n {
+				o.err = errors.New("missing commitments")
+				return
+			}
+
+			// Other protocol traffic between the rounds
+			next := (self + 1) % cbmpc.RoleID(n)
+			prev := (self + cbmpc.RoleID(n) - 1) % cbmpc.RoleID(n)
+			if o.err = ep.Send(ctx, next, []byte("hello")); o.err != nil {
+				return
+			}
+			if _, o.err = ep.Receive(ctx, prev); o.err != nil {
+				return
+			}
+
+			o.value, o.tr, o.err = c.RevealRandom(ctx)
+			if o.err == nil {
+				if _, _, err := c.RevealRandom(ctx); err == nil {
+					o.err = errors.New("second RevealRandom succeeded")
+				}
+			}
+		}(self)
+	}
+	wg.Wait()
+	return out
+}
+
+func TestCommitReveal(t *testing.T) {
+	out := runCommitReveal(t, 3, nil)
+
+	var ref []byte
+	for role, o := range out {
+		if o.err != nil {
+			t.Fatalf("role %d: %v", role, o.err)
+		}
+		if len(o.value) != 32 {
+			t.Fatalf("role %d: expected 32 bytes, got %d", role, len(o.value))
+		}
+		if ref == nil {
+			ref = o.value
+		} else if !bytes.Equal(ref, o.value) {
+			t.Fatalf("role %d disagrees on the output", role)
+		}
+
+		got, err := agreerandom.VerifyTranscript(o.tr)
+		if err != nil {
+			t.Fatalf("role %d: VerifyTranscript failed: %v", role, err)
+		}
+		if !bytes.Equal(got, o.value) {
+			t.Fatalf("role %d: transcript yields a different value", role)
+		}
+	}
+
+	// Swapping one party's share breaks its commitment
+	tr := out[0].tr
+	tr.Reveals[1][len(tr.Reveals[1])-1] ^= 1
+	if _, err := agreerandom.VerifyTranscript(tr); !errors.Is(err, agreerandom.ErrBadReveal) {
+		t.Fatalf("VerifyTranscript error = %v, want ErrBadReveal", err)
+	}
+}
+
+// TestCommitRevealBadReveal tests that a reveal that does not match its
+// commitment fails the run for the party that receives it.
+func TestCommitRevealBadReveal(t *testing.T) {
+	out := runCommitReveal(t, 3, func(self cbmpc.RoleID, ep cbmpc.Transport) cbmpc.Transport {
+		if self != 2 {
+			return ep
+		}
+		// Round 0 is the commitment, round 1 the unrelated message.
+		return mocknet.NewAdversary(ep).Tamper(0, 2, func(msg []byte) []byte {
+			msg[len(msg)-1] ^= 1
+			return msg
+		})
+	})
+
+	if err := out[0].err; !errors.Is(err, agreerandom.ErrBadReveal) {
+		t.Fatalf("role 0: error = %v, want ErrBadReveal", err)
+	}
+	if o := out[1]; o.err != nil {
+		t.Fatalf("role 1: %v", o.err)
+	}
+}
+
+func TestCommitRandomInvalidParams(t *testing.T) {
+	ep := mocknet.New().EpMP(0, []cbmpc.RoleID{0, 1})
+	valid := func() *agreerandom.CommitParams {
+		return &agreerandom.CommitParams{
+			Transport: ep,
+			Self:      0,
+			Parties:   []cbmpc.RoleID{0, 1},
+			Bitlen:    128,
+			SessionID: []byte("sid"),
+		}
+	}
+
+	cases := map[string]func(p *agreerandom.CommitParams){
+		"nil transport":   func(p *agreerandom.CommitParams) { p.Transport = nil },
+		"bad bitlen":      func(p *agreerandom.CommitParams) { p.Bitlen = 12 },
+		"empty session":   func(p *agreerandom.CommitParams) { p.SessionID = nil },
+		"self not in set": func(p *agreerandom.CommitParams) { p.Self = 7 },
+		"duplicate role":  func(p *agreerandom.CommitParams) { p.Parties = []cbmpc.RoleID{0, 0} },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := valid()
+			mutate(p)
+			if _, err := agreerandom.CommitRandom(context.Background(), p); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+	if _, err := agreerandom.CommitRandom(context.Background(), nil); err == nil {
+		t.Fatal("expected error for nil params")
+	}
+}
@@ -11,6 +11,11 @@
 //   - MultiAgreeRandom: Multi-party random agreement (fully secure)
 //   - WeakMultiAgreeRandom: Multi-party random agreement (faster, weaker security)
 //   - MultiPairwiseAgreeRandom: Multi-party pairwise random agreement (fully secure)
+//   - TolerantMultiAgreeRandom: Multi-party commit-reveal that tolerates up to
+//     MaxFaults unresponsive parties (biasable; see BiasableRandom)
+//   - CommitRandom and RevealRandom: Multi-party commit-reveal in two calls,
+//     with an auditable Transcript
+//   - AgreeUniform and MultiAgreeUniform: Unbiased integers in [0, max)
 //
 // # Usage
 //
@@ -20,8 +25,53 @@
 //	// Multi-party example
 //	random, err := agreerandom.MultiAgreeRandom(ctx, jobMP, 256)
 //
+//	// Uniform integer in [0, max), without modulo bias
+//	n, err := agreerandom.AgreeUniform(ctx, job2P, max)
+//
 //	// Pairwise random values (n parties generate n pairwise randoms)
 //	randoms, err := agreerandom.MultiPairwiseAgreeRandom(ctx, jobMP, 256)
 //
+// # Tolerating Unresponsive Parties
+//
+// The native protocols abort if any party stops responding. For telemetry or
+// beacon use cases where liveness matters more, TolerantMultiAgreeRandom runs a
+// Go commit-reveal protocol directly over a cbmpc.Transport and drops parties
+// that miss a round. Its output is a BiasableRandom: a party that withholds its
+// reveal can choose between outputs, so the value must not be used for keys,
+// nonces or anything security-critical.
+//
+//	res, err := agreerandom.TolerantMultiAgreeRandom(ctx, &agreerandom.TolerantParams{
+//	    Transport: transport,
+//	    Self:      self,
+//	    Parties:   roles,
+//	    MaxFaults: 1,
+//	    Bitlen:    256,
+//	    SessionID: sid,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	beacon := res.UnsafeBytes()
+//
+// # Two-Phase Commit-Reveal
+//
+// The native protocols run both rounds in one call. To interleave the commit
+// round with other protocol traffic, or to keep a record of every
+// contribution, run a Go commit-reveal protocol in two steps. Every party must
+// take part in both rounds; a reveal that does not open its commitment fails
+// with ErrBadReveal, naming the party:
+//
+//	c, err := agreerandom.CommitRandom(ctx, &agreerandom.CommitParams{
+//	    Transport: transport,
+//	    Self:      self,
+//	    Parties:   roles,
+//	    Bitlen:    256,
+//	    SessionID: sid,
+//	})
+//	// ... other protocol messages; c.Commitments() is available here
+//	value, transcript, err := c.RevealRandom(ctx)
+//
+// Auditors recompute the value from the transcript with VerifyTranscript.
+//
 // See cb-mpc/src/cbmpc/protocol/agree_random.h for protocol implementation details.
 package agreerandom
@@ -0,0 +1,262 @@
+package agreerandom
+
+import (
+	"bytes"
+	"context"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// ErrTooManyFaults is returned when fewer than len(Parties)-MaxFaults parties
+// contributed to a tolerant agree-random run.
+var ErrTooManyFaults = errors.New("too many unresponsive parties")
+
+// TolerantParams configures TolerantMultiAgreeRandom.
+type TolerantParams struct {
+	// Transport connects this party to every other party. Receive and
+	// ReceiveAll must honour context cancellation, which is how unresponsive
+	// peers are timed out.
+	Transport cbmpc.Transport
+	// Self is this party's role. It must appear in Parties.
+	Self cbmpc.RoleID
+	// Parties lists every participant, including Self.
+	Parties []cbmpc.RoleID
+	// MaxFaults is the number of parties that may fail to respond before the
+	// run is aborted with ErrTooManyFaults.
+	MaxFaults int
+	// Bitlen is the output length in bits (>= 8, multiple of 8).
+	Bitlen int
+	// SessionID must be unique per run and identical across parties. It
+	// domain-separates the output and lets late messages from earlier runs be
+	// discarded.
+	SessionID []byte
+	// RoundTimeout bounds how long each of the two rounds waits for peers.
+	// Zero selects 5 seconds.
+	RoundTimeout time.Duration
+}
+
+// BiasableRandom is the output of TolerantMultiAgreeRandom.
+//
+// Unlike MultiAgreeRandom, the value is NOT guaranteed to be uniformly random
+// against an active adversary: a party can wait to see every other reveal and
+// then choose whether to withhold its own, selecting between two outputs.
+// Parties that deliver messages to only some peers can also make honest
+// parties disagree on the value; compare Contributors to detect this. Use it
+// only where liveness matters more than unbiasability, such as telemetry
+// sampling or non-critical beacons; never for key material or nonces.
+type BiasableRandom struct {
+	value []byte
+	// Contributors are the roles whose randomness was included, in order.
+	Contributors []cbmpc.RoleID
+	// Missing are the roles that did not commit, did not reveal, or revealed a
+	// value that did not match their commitment.
+	Missing []cbmpc.RoleID
+}
+
+// UnsafeBytes returns a copy of the agreed value. The name is a reminder that
+// the value may be biased; see BiasableRandom.
+func (r *BiasableRandom) UnsafeBytes() []byte {
+	if r == nil {
+		return nil
+	}
+	return append([]byte(nil), r.value...)
+}
+
+const (
+	tolerantVersion     byte = 1
+	tolerantRoundCommit byte = 1
+	tolerantRoundReveal byte = 2
+
+	tolerantDefaultTimeout = 5 * time.Second
+	tolerantShareSize      = 32
+)
+
+// TolerantMultiAgreeRandom runs a commit-reveal random agreement directly over
+// a transport and tolerates up to MaxFaults parties that never respond.
+//
+// Each party commits to 32 random bytes, then reveals them; the output is
+// derived with HKDF-SHA256 from the session ID and every valid reveal. Parties
+// that miss either round are dropped and reported in Missing. See
+// BiasableRandom for the security caveats of dropping parties.
+func TolerantMultiAgreeRandom(ctx context.Context, params *TolerantParams) (*BiasableRandom, error) {
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.Transport == nil {
+		return nil, cbmpc.ErrNilTransport
+	}
+	if params.Bitlen < 8 || params.Bitlen%8 != 0 {
+		return nil, cbmpc.ErrInvalidBits
+	}
+	if len(params.SessionID) == 0 {
+		return nil, errors.New("empty session ID")
+	}
+	peers, err := tolerantPeers(params.Self, params.Parties)
+	if err != nil {
+		return nil, err
+	}
+	if params.MaxFaults < 0 || params.MaxFaults >= len(params.Parties) {
+		return nil, fmt.Errorf("max faults must be in [0, %d) (got %d)", len(params.Parties), params.MaxFaults)
+	}
+	timeout := params.RoundTimeout
+	if timeout == 0 {
+		timeout = tolerantDefaultTimeout
+	}
+	if timeout < 0 {
+		return nil, fmt.Errorf("round timeout must be >= 0 (got %v)", timeout)
+	}
+
+	sid := sha256.Sum256(params.SessionID)
+	share := make([]byte, tolerantShareSize)
+	nonce := make([]byte, tolerantShareSize)
+	if _, err := rand.Read(share); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	needed := len(params.Parties) - params.MaxFaults
+
+	// Round 1: commitments.
+	myCommit := tolerantCommit(sid[:], params.Self, nonce, share)
+	commits := tolerantRound(ctx, params.Transport, peers, timeout,
+		tolerantFrame(tolerantRoundCommit, sid[:], myCommit), tolerantRoundCommit, sid[:], sha256.Size)
+	if len(commits)+1 < needed {
+		return nil, fmt.Errorf("%w: %d of %d parties committed, need %d", ErrTooManyFaults, len(commits)+1, len(params.Parties), needed)
+	}
+
+	// Round 2: reveals.
+	reveal := append(append([]byte(nil), nonce...), share...)
+	reveals := tolerantRound(ctx, params.Transport, peers, timeout,
+		tolerantFrame(tolerantRoundReveal, sid[:], reveal), tolerantRoundReveal, sid[:], 2*tolerantShareSize)
+
+	shares := map[cbmpc.RoleID][]byte{params.Self: share}
+	for role, c := range commits {
+		r, ok := reveals[role]
+		if !ok {
+			continue
+		}
+		if !bytes.Equal(tolerantCommit(sid[:], role, r[:tolerantShareSize], r[tolerantShareSize:]), c) {
+			continue
+		}
+		shares[role] = r[tolerantShareSize:]
+	}
+	if len(shares) < needed {
+		return nil, fmt.Errorf("%w: %d of %d parties revealed, need %d", ErrTooManyFaults, len(shares), len(params.Parties), needed)
+	}
+
+	out := &BiasableRandom{}
+	secret := make([]byte, 0, len(shares)*(4+tolerantShareSize))
+	for _, role := range tolerantSorted(params.Parties) {
+		s, ok := shares[role]
+		if !ok {
+			out.Missing = append(out.Missing, role)
+			continue
+		}
+		out.Contributors = append(out.Contributors, role)
+		secret = binary.BigEndian.AppendUint32(secret, uint32(role))
+		secret = append(secret, s...)
+	}
+	value, err := hkdf.Key(sha256.New, secret, sid[:], "cbmpc/agreerandom/tolerant/output", params.Bitlen/8)
+	if err != nil {
+		return nil, err
+	}
+	out.value = value
+	return out, nil
+}
+
+func tolerantPeers(self cbmpc.RoleID, parties []cbmpc.RoleID) ([]cbmpc.RoleID, error) {
+	if len(parties) < 2 {
+		return nil, fmt.Errorf("%w: need at least 2 parties (got %d)", cbmpc.ErrBadPeers, len(parties))
+	}
+	seen := make(map[cbmpc.RoleID]bool, len(parties))
+	peers := make([]cbmpc.RoleID, 0, len(parties)-1)
+	for _, p := range parties {
+		if seen[p] {
+			return nil, fmt.Errorf("%w: duplicate role %d", cbmpc.ErrBadPeers, p)
+		}
+		seen[p] = true
+		if p != self {
+			peers = append(peers, p)
+		}
+	}
+	if !seen[self] {
+		return nil, fmt.Errorf("%w: self role %d not in parties", cbmpc.ErrBadPeers, self)
+	}
+	return peers, nil
+}
+
+func tolerantSorted(roles []cbmpc.RoleID) []cbmpc.RoleID {
+	out := append([]cbmpc.RoleID(nil), roles...)
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func tolerantCommit(sid []byte, role cbmpc.RoleID, nonce, share []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte("cbmpc/agreerandom/tolerant/commit"))
+	h.Write(sid)
+	_ = binary.Write(h, binary.BigEndian, uint32(role))
+	h.Write(nonce)
+	h.Write(share)
+	return h.Sum(nil)
+}
+
+// tolerantFrame prefixes payload with a version, round tag and session digest.
+func tolerantFrame(round byte, sid, payload []byte) []byte {
+	msg := make([]byte, 0, 2+len(sid)+len(payload))
+	msg = append(msg, tolerantVersion, round)
+	msg = append(msg, sid...)
+	return append(msg, payload...)
+}
+
+// tolerantRound sends msg to every peer and collects well-formed replies for
+// the given round until every peer answered or the round times out. Peers
+// that fail to send or answer are simply absent from the result.
+func tolerantRound(ctx context.Context, t cbmpc.Transport, peers []cbmpc.RoleID, timeout time.Duration, msg []byte, round byte, sid []byte, size int) map[cbmpc.RoleID][]byte {
+	roundCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, p := range peers {
+		// A failed send shows up as a missing party on the other side.
+		_ = t.Send(roundCtx, p, msg)
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		out = make(map[cbmpc.RoleID][]byte, len(peers))
+	)
+	for _, p := range peers {
+		wg.Add(1)
+		go func(p cbmpc.RoleID) {
+			defer wg.Done()
+			for {
+				got, err := t.Receive(roundCtx, p)
+				if err != nil {
+					return
+				}
+				// Skip stale or foreign frames, e.g. late messages from an
+				// earlier run that timed out.
+				if len(got) != 2+len(sid)+size || got[0] != tolerantVersion || got[1] != round || !bytes.Equal(got[2:2+len(sid)], sid) {
+					continue
+				}
+				mu.Lock()
+				out[p] = got[2+len(sid):]
+				mu.Unlock()
+				return
+			}
+		}(p)
+	}
+	wg.Wait()
+	return out
+}
@@ -0,0 +1,153 @@
+package agreerandom_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/agreerandom"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+type tolerantOutcome struct {
+	res *agreerandom.BiasableRandom
+	err error
+}
+
+// runTolerant runs the tolerant protocol for every role in parties except
+// those in silent, which never participate.
+func runTolerant(t *testing.T, n, maxFaults int, silent map[cbmpc.RoleID]bool) map[cbmpc.RoleID]tolerantOutcome {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	roles := make([]cbmpc.RoleID, n)
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		out = make(map[cbmpc.RoleID]tolerantOutcome)
+	)
+	for _, self := range roles {
+		if silent[self] {
+			continue
+		}
+		ep := net.EpMP(self, roles)
+		wg.Add(1)
+		go func(self cbmpc.RoleID) {
+			defer wg.Done()
+			res, err := agreerandom.TolerantMultiAgreeRandom(ctx, &agreerandom.TolerantParams{
+				Transport:    ep,
+				Self:         self,
+				Parties:      roles,
+				MaxFaults:    maxFaults,
+				Bitlen:       256,
+				SessionID:    []byte("tolerant-test"),
+				RoundTimeout: 200 * time.Millisecond,
+			})
+			mu.Lock()
+			out[self] = tolerantOutcome{res: res, err: err}
+			mu.Unlock()
+		}(self)
+	}
+	wg.Wait()
+	return out
+}
+
+func TestTolerantAllParties(t *testing.T) {
+	out := runTolerant(t, 4, 1, nil)
+
+	var ref []byte
+	for role, o := range out {
+		if o.err != nil {
+			t.Fatalf("role %d: %v", role, o.err)
+		}
+		if len(o.res.Missing) != 0 || len(o.res.Contributors) != 4 {
+			t.Fatalf("role %d: unexpected contributors %v missing %v", role, o.res.Contributors, o.res.Missing)
+		}
+		v := o.res.UnsafeBytes()
+		if len(v) != 32 {
+			t.Fatalf("role %d: expected 32 bytes, got %d", role, len(v))
+		}
+		if ref == nil {
+			ref = v
+		} else if !bytes.Equal(ref, v) {
+			t.Fatalf("role %d disagrees on the output", role)
+		}
+	}
+}
+
+func TestTolerantSilentParty(t *testing.T) {
+	out := runTolerant(t, 4, 1, map[cbmpc.RoleID]bool{2: true})
+
+	var ref []byte
+	for role, o := range out {
+		if o.err != nil {
+			t.Fatalf("role %d: %v", role, o.err)
+		}
+		if len(o.res.Missing) != 1 || o.res.Missing[0] != 2 {
+			t.Fatalf("role %d: expected role 2 missing, got %v", role, o.res.Missing)
+		}
+		v := o.res.UnsafeBytes()
+		if ref == nil {
+			ref = v
+		} else if !bytes.Equal(ref, v) {
+			t.Fatalf("role %d disagrees on the output", role)
+		}
+	}
+}
+
+func TestTolerantTooManyFaults(t *testing.T) {
+	out := runTolerant(t, 4, 1, map[cbmpc.RoleID]bool{1: true, 3: true})
+	for role, o := range out {
+		if !errors.Is(o.err, agreerandom.ErrTooManyFaults) {
+			t.Fatalf("role %d: expected ErrTooManyFaults, got %v", role, o.err)
+		}
+	}
+}
+
+func TestTolerantInvalidParams(t *testing.T) {
+	ep := mocknet.New().EpMP(0, []cbmpc.RoleID{0, 1, 2})
+	valid := func() *agreerandom.TolerantParams {
+		return &agreerandom.TolerantParams{
+			Transport: ep,
+			Self:      0,
+			Parties:   []cbmpc.RoleID{0, 1, 2},
+			MaxFaults: 1,
+			Bitlen:    128,
+			SessionID: []byte("sid"),
+		}
+	}
+
+	cases := map[string]func(p *agreerandom.TolerantParams){
+		"nil transport":     func(p *agreerandom.TolerantParams) { p.Transport = nil },
+		"bad bitlen":        func(p *agreerandom.TolerantParams) { p.Bitlen = 12 },
+		"empty session":     func(p *agreerandom.TolerantParams) { p.SessionID = nil },
+		"self not in set":   func(p *agreerandom.TolerantParams) { p.Self = 7 },
+		"duplicate role":    func(p *agreerandom.TolerantParams) { p.Parties = []cbmpc.RoleID{0, 1, 1} },
+		"too many faults":   func(p *agreerandom.TolerantParams) { p.MaxFaults = 3 },
+		"negative faults":   func(p *agreerandom.TolerantParams) { p.MaxFaults = -1 },
+		"negative timeout":  func(p *agreerandom.TolerantParams) { p.RoundTimeout = -time.Second },
+		"single party only": func(p *agreerandom.TolerantParams) { p.Parties = []cbmpc.RoleID{0} },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := valid()
+			mutate(p)
+			if _, err := agreerandom.TolerantMultiAgreeRandom(context.Background(), p); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+	if _, err := agreerandom.TolerantMultiAgreeRandom(context.Background(), nil); err == nil {
+		t.Fatal("expected error for nil params")
+	}
+}
@@ -0,0 +1,64 @@
+package agreerandom
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// maxUniformDraws bounds the rejection-sampling loop of AgreeUniform. Each
+// draw is accepted with probability above 1/2, so 128 rejections in a row
+// happen with probability below 2^-128.
+const maxUniformDraws = 128
+
+// AgreeUniform runs AgreeRandom until it yields an integer below max and
+// returns that integer, uniformly distributed in [0, max). max must be
+// positive.
+//
+// Reducing raw AgreeRandom output modulo max biases the result towards small
+// values unless max is a power of two; AgreeUniform instead draws
+// max.BitLen() bits at a time and rejects values that are too large. Both
+// parties see the same draws and so stop after the same number of runs.
+func AgreeUniform(ctx context.Context, j *cbmpc.Job2P, max *big.Int) (*big.Int, error) {
+	return uniform(max, func(bitlen int) ([]byte, error) {
+		return AgreeRandom(ctx, j, bitlen)
+	})
+}
+
+// MultiAgreeUniform is like AgreeUniform for MultiAgreeRandom.
+func MultiAgreeUniform(ctx context.Context, j *cbmpc.JobMP, max *big.Int) (*big.Int, error) {
+	return uniform(max, func(bitlen int) ([]byte, error) {
+		return MultiAgreeRandom(ctx, j, bitlen)
+	})
+}
+
+// uniform rejection-samples an integer in [0, max) from draws of random
+// bytes.
+func uniform(max *big.Int, draw func(bitlen int) ([]byte, error)) (*big.Int, error) {
+	if max == nil || max.Sign() <= 0 {
+		return nil, errors.New("max must be positive")
+	}
+	// Values are in [0, max-1], which has bits significant bits.
+	bits := new(big.Int).Sub(max, big.NewInt(1)).BitLen()
+	if bits == 0 {
+		return new(big.Int), nil
+	}
+	bitlen := (bits + 7) / 8 * 8
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bits)), big.NewInt(1))
+
+	for range maxUniformDraws {
+		buf, err := draw(bitlen)
+		if err != nil {
+			return nil, err
+		}
+		v := new(big.Int).SetBytes(buf)
+		cbmpc.ZeroizeBytes(buf)
+		v.And(v, mask)
+		if v.Cmp(max) < 0 {
+			return v, nil
+		}
+	}
+	return nil, errors.New("agree uniform: too many rejected draws")
+}
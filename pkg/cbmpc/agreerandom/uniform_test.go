@@ -0,0 +1,82 @@
+package agreerandom
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestUniformRange(t *testing.T) {
+	for _, m := range []int64{1, 2, 3, 5, 6, 255, 256, 257, 1000} {
+		max := big.NewInt(m)
+		counts := make([]int, m)
+		for range 2000 {
+			v, err := uniform(max, func(bitlen int) ([]byte, error) {
+				buf := make([]byte, bitlen/8)
+				_, err := rand.Read(buf)
+				return buf, err
+			})
+			if err != nil {
+				t.Fatalf("max %d: %v", m, err)
+			}
+			if v.Sign() < 0 || v.Cmp(max) >= 0 {
+				t.Fatalf("max %d: value %v out of range", m, v)
+			}
+			counts[v.Int64()]++
+		}
+		// Small ranges must hit every value.
+		if m <= 6 {
+			for i, c := range counts {
+				if c == 0 {
+					t.Errorf("max %d: value %d never drawn", m, i)
+				}
+			}
+		}
+	}
+}
+
+// TestUniformRejects tests that out-of-range draws are rejected rather than
+// reduced.
+func TestUniformRejects(t *testing.T) {
+	// max = 5 needs 3 bits; 7, 6 and 5 must be rejected.
+	draws := [][]byte{{0x07}, {0xfe}, {0x05}, {0x0c}}
+	var n int
+	v, err := uniform(big.NewInt(5), func(bitlen int) ([]byte, error) {
+		if bitlen != 8 {
+			t.Fatalf("bitlen = %d, want 8", bitlen)
+		}
+		n++
+		return append([]byte(nil), draws[n-1]...), nil
+	})
+	if err != nil {
+		t.Fatalf("uniform failed: %v", err)
+	}
+	// 0x0c masks to 4.
+	if v.Int64() != 4 || n != 4 {
+		t.Fatalf("got %v after %d draws, want 4 after 4", v, n)
+	}
+}
+
+func TestUniformErrors(t *testing.T) {
+	never := func(int) ([]byte, error) {
+		t.Fatal("unexpected draw")
+		return nil, nil
+	}
+	for _, max := range []*big.Int{nil, big.NewInt(0), big.NewInt(-3)} {
+		if _, err := uniform(max, never); err == nil {
+			t.Errorf("max %v: expected error", max)
+		}
+	}
+	if v, err := uniform(big.NewInt(1), never); err != nil || v.Sign() != 0 {
+		t.Errorf("max 1: got %v, %v; want 0", v, err)
+	}
+
+	errDraw := errors.New("draw failed")
+	if _, err := uniform(big.NewInt(10), func(int) ([]byte, error) { return nil, errDraw }); !errors.Is(err, errDraw) {
+		t.Errorf("error = %v, want %v", err, errDraw)
+	}
+	if _, err := uniform(big.NewInt(5), func(int) ([]byte, error) { return []byte{7}, nil }); err == nil {
+		t.Error("expected error after only rejected draws")
+	}
+}
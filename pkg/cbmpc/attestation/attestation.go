@@ -0,0 +1,223 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// Version is the report format version.
+const Version = 1
+
+var (
+	// ErrBadSignature is returned when a report is not signed by the
+	// expected identity key.
+	ErrBadSignature = errors.New("attestation: bad signature")
+	// ErrMismatch is returned by VerifyAll when reports disagree about the
+	// key or the protocol run.
+	ErrMismatch = errors.New("attestation: reports do not match")
+)
+
+// Software records the versions that produced a key.
+type Software struct {
+	Wrapper  string `json:"wrapper"`
+	Upstream string `json:"upstream"`
+	Go       string `json:"go"`
+}
+
+// Channel holds the digests of the messages the party exchanged with one
+// peer during the DKG, as recorded by a Recorder.
+type Channel struct {
+	Peer     string `json:"peer"`
+	Sent     string `json:"sent"`
+	Received string `json:"received"`
+}
+
+// Report is one party's signed statement of how a key was created.
+// Signature is the Ed25519 signature by Identity over the report's JSON
+// encoding with Signature empty.
+type Report struct {
+	Version     int               `json:"version"`
+	Protocol    string            `json:"protocol"`
+	Curve       cbmpc.Curve       `json:"curve"`
+	PublicKey   []byte            `json:"public_key"`
+	Fingerprint string            `json:"fingerprint"`
+	Party       string            `json:"party"`
+	Parties     []string          `json:"parties"`
+	SessionID   []byte            `json:"session_id,omitempty"`
+	Software    Software          `json:"software"`
+	Transcript  []Channel         `json:"transcript,omitempty"`
+	Created     time.Time         `json:"created"`
+	Identity    ed25519.PublicKey `json:"identity"`
+	Signature   []byte            `json:"signature"`
+}
+
+// Params describes a completed DKG.
+type Params struct {
+	// Protocol names the DKG, e.g. "ecdsamp.DKG".
+	Protocol  string
+	Curve     cbmpc.Curve
+	PublicKey []byte
+	// Self is the local party's role; Parties are the job's party names in
+	// role order.
+	Self    cbmpc.RoleID
+	Parties []string
+	// SessionID is the DKG result's session ID, for protocols that have one.
+	SessionID cbmpc.SessionID
+	// Recorder is the transport the DKG job ran over. Optional; without it
+	// the report has no transcript digests.
+	Recorder *Recorder
+	// Identity is the party's long-term signing key.
+	Identity ed25519.PrivateKey
+}
+
+// New builds the local party's report for the DKG described by p and signs
+// it with p.Identity.
+func New(p *Params) (*Report, error) {
+	if p == nil {
+		return nil, errors.New("attestation: nil params")
+	}
+	if p.Protocol == "" {
+		return nil, errors.New("attestation: empty protocol")
+	}
+	if len(p.PublicKey) == 0 {
+		return nil, errors.New("attestation: empty public key")
+	}
+	if int(p.Self) < 0 || int(p.Self) >= len(p.Parties) {
+		return nil, fmt.Errorf("attestation: role %d out of range for %d parties", p.Self, len(p.Parties))
+	}
+	if len(p.Identity) != ed25519.PrivateKeySize {
+		return nil, errors.New("attestation: invalid identity key")
+	}
+	r := &Report{
+		Version:     Version,
+		Protocol:    p.Protocol,
+		Curve:       p.Curve,
+		PublicKey:   bytes.Clone(p.PublicKey),
+		Fingerprint: cbmpc.KeyFingerprint(p.Curve, p.PublicKey),
+		Party:       p.Parties[p.Self],
+		Parties:     slices.Clone(p.Parties),
+		SessionID:   p.SessionID.Bytes(),
+		Software: Software{
+			Wrapper:  cbmpc.WrapperVersion(),
+			Upstream: cbmpc.UpstreamVersion(),
+			Go:       runtime.Version(),
+		},
+		Created:  time.Now().UTC(),
+		Identity: p.Identity.Public().(ed25519.PublicKey),
+	}
+	if p.Recorder != nil {
+		r.Transcript = p.Recorder.channels(p.Parties)
+	}
+	msg, err := r.signedBytes()
+	if err != nil {
+		return nil, err
+	}
+	r.Signature = ed25519.Sign(p.Identity, msg)
+	return r, nil
+}
+
+// Parse decodes a report. It does not verify it.
+func Parse(data []byte) (*Report, error) {
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("attestation: %w", err)
+	}
+	return &r, nil
+}
+
+// Marshal encodes the report as JSON.
+func (r *Report) Marshal() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *Report) signedBytes() ([]byte, error) {
+	c := *r
+	c.Signature = nil
+	return json.Marshal(c)
+}
+
+// Verify checks that r is signed by identity, the party's known identity key,
+// and that its fingerprint matches its public key.
+func (r *Report) Verify(identity ed25519.PublicKey) error {
+	if r.Version != Version {
+		return fmt.Errorf("attestation: version %d, this library reads %d", r.Version, Version)
+	}
+	if len(identity) != ed25519.PublicKeySize || !identity.Equal(r.Identity) {
+		return fmt.Errorf("%w: report of %q is not signed by the expected key", ErrBadSignature, r.Party)
+	}
+	msg, err := r.signedBytes()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(identity, msg, r.Signature) {
+		return fmt.Errorf("%w: report of %q", ErrBadSignature, r.Party)
+	}
+	if r.Fingerprint != cbmpc.KeyFingerprint(r.Curve, r.PublicKey) {
+		return fmt.Errorf("attestation: report of %q: fingerprint does not match the public key", r.Party)
+	}
+	return nil
+}
+
+// VerifyAll checks the reports of every party of one key. Each report must
+// verify against the identity key of its party, and all must agree on the
+// protocol, key, parties and session ID. Where both parties of a pair
+// recorded transcript digests, what each sent must be what the other
+// received.
+//
+// The software versions are not compared: parties may run different builds.
+func VerifyAll(reports []*Report, identities map[string]ed25519.PublicKey) error {
+	if len(reports) == 0 {
+		return errors.New("attestation: no reports")
+	}
+	first := reports[0]
+	byParty := make(map[string]*Report, len(reports))
+	for _, r := range reports {
+		if err := r.Verify(identities[r.Party]); err != nil {
+			return err
+		}
+		switch {
+		case r.Protocol != first.Protocol || r.Curve != first.Curve || !bytes.Equal(r.PublicKey, first.PublicKey):
+			return fmt.Errorf("%w: %q and %q attest to different keys", ErrMismatch, first.Party, r.Party)
+		case !slices.Equal(r.Parties, first.Parties):
+			return fmt.Errorf("%w: %q lists parties %v, not %v", ErrMismatch, r.Party, r.Parties, first.Parties)
+		case !bytes.Equal(r.SessionID, first.SessionID):
+			return fmt.Errorf("%w: %q and %q report different sessions", ErrMismatch, first.Party, r.Party)
+		case byParty[r.Party] != nil:
+			return fmt.Errorf("%w: two reports for %q", ErrMismatch, r.Party)
+		}
+		byParty[r.Party] = r
+	}
+	for _, name := range first.Parties {
+		if byParty[name] == nil {
+			return fmt.Errorf("%w: no report for %q", ErrMismatch, name)
+		}
+	}
+
+	for _, r := range reports {
+		for _, c := range r.Transcript {
+			peer := byParty[c.Peer]
+			if peer == nil {
+				return fmt.Errorf("%w: %q recorded messages with unknown party %q", ErrMismatch, r.Party, c.Peer)
+			}
+			i := slices.IndexFunc(peer.Transcript, func(pc Channel) bool { return pc.Peer == r.Party })
+			if i < 0 {
+				if len(peer.Transcript) > 0 {
+					return fmt.Errorf("%w: %q recorded no messages with %q", ErrMismatch, peer.Party, r.Party)
+				}
+				continue
+			}
+			if c.Sent != peer.Transcript[i].Received {
+				return fmt.Errorf("%w: %q received other messages than %q sent", ErrMismatch, peer.Party, r.Party)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package attestation_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/attestation"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+var parties = []string{"alice", "bob"}
+
+// exchange runs a two-message exchange between roles 0 and 1 over recorders
+// and returns them.
+func exchange(t *testing.T, net *mocknet.Net) [2]*attestation.Recorder {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var recs [2]*attestation.Recorder
+	for i := range recs {
+		rec, err := attestation.NewRecorder(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs[i] = rec
+	}
+	errs := make(chan error, 1)
+	go func() {
+		msg, err := recs[1].Receive(ctx, 0)
+		if err == nil {
+			err = recs[1].Send(ctx, 0, append(msg, '!'))
+		}
+		errs <- err
+	}()
+	if err := recs[0].Send(ctx, 1, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recs[0].Receive(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	return recs
+}
+
+func reports(t *testing.T, recs [2]*attestation.Recorder) ([]*attestation.Report, map[string]ed25519.PublicKey) {
+	t.Helper()
+	ids := make(map[string]ed25519.PublicKey)
+	var out []*attestation.Report
+	for i, rec := range recs {
+		pub, priv, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[parties[i]] = pub
+		r, err := attestation.New(&attestation.Params{
+			Protocol:  "ecdsa2p.DKG",
+			Curve:     cbmpc.CurveSecp256k1,
+			PublicKey: []byte{0x02, 1, 2, 3},
+			Self:      cbmpc.RoleID(i),
+			Parties:   parties,
+			SessionID: cbmpc.NewSessionID([]byte("sid")),
+			Recorder:  rec,
+			Identity:  priv,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, r)
+	}
+	return out, ids
+}
+
+func TestVerifyAll(t *testing.T) {
+	rs, ids := reports(t, exchange(t, mocknet.New()))
+	if err := attestation.VerifyAll(rs, ids); err != nil {
+		t.Fatalf("VerifyAll: %v", err)
+	}
+	if rs[0].Party != "alice" || len(rs[0].Transcript) != 1 || rs[0].Transcript[0].Peer != "bob" {
+		t.Errorf("report = %+v", rs[0])
+	}
+	if rs[0].Software.Wrapper != cbmpc.WrapperVersion() {
+		t.Errorf("wrapper version = %q", rs[0].Software.Wrapper)
+	}
+
+	data, err := rs[1].Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := attestation.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := parsed.Verify(ids["bob"]); err != nil {
+		t.Errorf("Verify parsed: %v", err)
+	}
+}
+
+func TestVerifyAllRejects(t *testing.T) {
+	t.Run("signature", func(t *testing.T) {
+		rs, ids := reports(t, exchange(t, mocknet.New()))
+		rs[1].PublicKey = []byte{0x03, 1, 2, 3}
+		if err := attestation.VerifyAll(rs, ids); !errors.Is(err, attestation.ErrBadSignature) {
+			t.Errorf("err = %v, want ErrBadSignature", err)
+		}
+	})
+	t.Run("identity", func(t *testing.T) {
+		rs, ids := reports(t, exchange(t, mocknet.New()))
+		ids["alice"], ids["bob"] = ids["bob"], ids["alice"]
+		if err := attestation.VerifyAll(rs, ids); !errors.Is(err, attestation.ErrBadSignature) {
+			t.Errorf("err = %v, want ErrBadSignature", err)
+		}
+	})
+	t.Run("missing party", func(t *testing.T) {
+		rs, ids := reports(t, exchange(t, mocknet.New()))
+		if err := attestation.VerifyAll(rs[:1], ids); !errors.Is(err, attestation.ErrMismatch) {
+			t.Errorf("err = %v, want ErrMismatch", err)
+		}
+	})
+	t.Run("transcript", func(t *testing.T) {
+		// Bob's report comes from a different run than Alice's.
+		a, _ := reports(t, exchange(t, mocknet.New()))
+		recs := exchange(t, mocknet.New())
+		if err := recs[1].Send(context.Background(), 0, []byte("extra")); err != nil {
+			t.Fatal(err)
+		}
+		b, ids := reports(t, recs)
+		ids["alice"] = a[0].Identity
+		if err := attestation.VerifyAll([]*attestation.Report{a[0], b[1]}, ids); !errors.Is(err, attestation.ErrMismatch) {
+			t.Errorf("err = %v, want ErrMismatch", err)
+		}
+	})
+}
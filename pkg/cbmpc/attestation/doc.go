@@ -0,0 +1,39 @@
+// Package attestation produces signed reports of how a key was created, for
+// compliance reviews of key ceremonies.
+//
+// After a DKG, each party builds a Report with the key's public key, curve
+// and fingerprint, the participants, the DKG's session ID, the wrapper,
+// cb-mpc and Go versions, and digests of the DKG messages it exchanged with
+// each peer. The report is JSON and signed with the party's Ed25519 identity
+// key:
+//
+//	rec, err := attestation.NewRecorder(transport)
+//	if err != nil {
+//	    return err
+//	}
+//	job, err := cbmpc.NewJobMPWithContext(ctx, rec, self, names)
+//	// ...
+//	res, err := ecdsamp.DKG(ctx, job, &ecdsamp.DKGParams{Curve: cbmpc.CurveSecp256k1})
+//	// ...
+//	pub, _ := res.Key.PublicKey()
+//	report, err := attestation.New(&attestation.Params{
+//	    Protocol:  "ecdsamp.DKG",
+//	    Curve:     cbmpc.CurveSecp256k1,
+//	    PublicKey: pub,
+//	    Self:      self,
+//	    Parties:   names,
+//	    SessionID: res.SessionID,
+//	    Recorder:  rec,
+//	    Identity:  identityKey,
+//	})
+//
+// A verifier collects the reports of all parties and checks them against the
+// parties' known identity keys with VerifyAll. Besides the signatures, it
+// checks that every party attests to the same key and session, and that the
+// messages each party says it sent to a peer are the ones the peer says it
+// received, so a report cannot describe a run that did not happen between
+// these parties.
+//
+// The Recorder keeps only running digests, never messages. Use a fresh
+// Recorder, or at least a fresh job over it, for each DKG being attested.
+package attestation
@@ -0,0 +1,121 @@
+package attestation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"sort"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// Recorder is a cbmpc.Transport that hashes every message the local party
+// sends and receives, one running SHA-256 per peer and direction. Wrap the
+// DKG job's transport in it and pass it to New as Params.Recorder.
+//
+// Only digests are kept. The digest of what one party sent to a peer equals
+// the digest of what the peer received from it, which lets VerifyAll check
+// that the parties saw the same protocol run.
+type Recorder struct {
+	inner cbmpc.Transport
+
+	mu       sync.Mutex
+	sent     map[cbmpc.RoleID]hash.Hash
+	received map[cbmpc.RoleID]hash.Hash
+}
+
+// NewRecorder returns a Recorder that forwards to t.
+func NewRecorder(t cbmpc.Transport) (*Recorder, error) {
+	if t == nil {
+		return nil, cbmpc.ErrNilTransport
+	}
+	return &Recorder{
+		inner:    t,
+		sent:     make(map[cbmpc.RoleID]hash.Hash),
+		received: make(map[cbmpc.RoleID]hash.Hash),
+	}, nil
+}
+
+// Send forwards to the wrapped transport and hashes the message on success.
+func (r *Recorder) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	if err := r.inner.Send(ctx, to, msg); err != nil {
+		return err
+	}
+	r.record(r.sent, to, msg)
+	return nil
+}
+
+// Receive forwards to the wrapped transport and hashes the message on success.
+func (r *Recorder) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
+	msg, err := r.inner.Receive(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	r.record(r.received, from, msg)
+	return msg, nil
+}
+
+// ReceiveAll forwards to the wrapped transport and hashes each message into
+// its sender's digest.
+func (r *Recorder) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	msgs, err := r.inner.ReceiveAll(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	for role, msg := range msgs {
+		r.record(r.received, role, msg)
+	}
+	return msgs, nil
+}
+
+func (r *Recorder) record(m map[cbmpc.RoleID]hash.Hash, peer cbmpc.RoleID, msg []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := m[peer]
+	if !ok {
+		h = sha256.New()
+		m[peer] = h
+	}
+	_ = binary.Write(h, binary.BigEndian, uint64(len(msg)))
+	h.Write(msg)
+}
+
+// channels returns the digests recorded so far, one Channel per peer in role
+// order, naming peers by their index in parties.
+func (r *Recorder) channels(parties []string) []Channel {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	peers := make(map[cbmpc.RoleID]bool)
+	for role := range r.sent {
+		peers[role] = true
+	}
+	for role := range r.received {
+		peers[role] = true
+	}
+	roles := make([]cbmpc.RoleID, 0, len(peers))
+	for role := range peers {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i] < roles[j] })
+
+	out := make([]Channel, 0, len(roles))
+	for _, role := range roles {
+		c := Channel{Sent: digest(r.sent[role]), Received: digest(r.received[role])}
+		if int(role) < len(parties) {
+			c.Peer = parties[role]
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// digest returns the hex sum of h, or the sum of no messages for a nil h.
+func digest(h hash.Hash) string {
+	if h == nil {
+		h = sha256.New()
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,293 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/journal"
+)
+
+// ResultOK is the Result of a record whose operation succeeded. Failed
+// operations record their journal error class, or ResultPolicyRejected.
+const (
+	ResultOK             = "ok"
+	ResultPolicyRejected = "policy_rejected"
+)
+
+// genesis is the Prev of the first record of a chain.
+var genesis = strings.Repeat("0", 64)
+
+// Record is one audited operation. Hash is the hex SHA-256 of the record's
+// JSON encoding with Hash empty, and Prev is the Hash of the record before it.
+type Record struct {
+	Seq      uint64        `json:"seq"`
+	Time     time.Time     `json:"time"`
+	Protocol string        `json:"protocol"`
+	Self     cbmpc.RoleID  `json:"self"`
+	Parties  []string      `json:"parties"`
+	Curve    string        `json:"curve,omitempty"`
+	Key      string        `json:"key,omitempty"`      // cbmpc.KeyFingerprint of the key
+	Messages []string      `json:"messages,omitempty"` // hex SHA-256 of each signed message
+	Result   string        `json:"result"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Prev     string        `json:"prev"`
+	Hash     string        `json:"hash"`
+}
+
+func (r *Record) digest() (string, error) {
+	c := *r
+	c.Hash = ""
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Fingerprint returns the hex SHA-256 of a public key's encoding.
+//
+// Deprecated: Records hold cbmpc.KeyFingerprint, which also covers the curve
+// and matches the key types' Fingerprint methods. Records written before
+// that change hold this value.
+func Fingerprint(publicKey []byte) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:])
+}
+
+// Sink stores encoded records. Append receives one JSON record per call, in
+// chain order, and must not return until the record is stored.
+type Sink interface {
+	Append(record []byte) error
+}
+
+type writerSink struct{ w io.Writer }
+
+func (s writerSink) Append(record []byte) error {
+	_, err := s.w.Write(append(record, '\n'))
+	return err
+}
+
+// WriterSink writes records to w as JSON lines, without syncing.
+func WriterSink(w io.Writer) Sink { return writerSink{w: w} }
+
+// Log appends hash-chained records to a Sink. A Log is safe for concurrent
+// use by many jobs.
+type Log struct {
+	mu     sync.Mutex
+	sink   Sink
+	file   *os.File // set by OpenFile
+	seq    uint64
+	prev   string
+	err    error
+	closed bool
+}
+
+// New returns a Log that starts a new chain in sink.
+func New(sink Sink) (*Log, error) {
+	if sink == nil {
+		return nil, errors.New("nil sink")
+	}
+	return &Log{sink: sink, prev: genesis}, nil
+}
+
+// Resume returns a Log that continues the chain ending in last, for example
+// after reading the records already in a sink at startup.
+func Resume(sink Sink, last *Record) (*Log, error) {
+	l, err := New(sink)
+	if err != nil || last == nil {
+		return l, err
+	}
+	l.seq, l.prev = last.Seq+1, last.Hash
+	return l, nil
+}
+
+// OpenFile opens or creates the audit file at path. Existing records are
+// verified and the chain continues after the last one; a file that fails
+// verification is not appended to. Every record is synced to disk before the
+// operation that produced it returns.
+func OpenFile(path string) (*Log, error) {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_RDWR|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	records, err := Read(f)
+	if err == nil {
+		err = Verify(records)
+	}
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("audit log %s: %w", path, err)
+	}
+	var last *Record
+	if len(records) > 0 {
+		last = &records[len(records)-1]
+	}
+	l, _ := Resume(WriterSink(f), last)
+	l.file = f
+	return l, nil
+}
+
+// Close closes the file opened by OpenFile. Later records fail.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	if l.file != nil {
+		return l.file.Close()
+	}
+	return nil
+}
+
+// Hook returns the operation hook that records every operation of the jobs
+// it is added to. Hooks cannot fail an operation that already ran, so write
+// errors are kept and reported by Err.
+func (l *Log) Hook() cbmpc.OperationHook {
+	return func(_ context.Context, op *cbmpc.Operation) {
+		_, _ = l.Record(op)
+	}
+}
+
+// Err returns the first error from writing a record, if any. Records after a
+// failed write are not written, since the chain would have a gap.
+func (l *Log) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// Record appends a record of op and returns it.
+func (l *Log) Record(op *cbmpc.Operation) (*Record, error) {
+	if op == nil {
+		return nil, errors.New("nil operation")
+	}
+	r := &Record{
+		Time:     op.Start.UTC(),
+		Protocol: op.Protocol,
+		Self:     op.Self,
+		Parties:  op.Parties,
+		Result:   ResultOK,
+		Duration: op.Duration,
+	}
+	if op.Curve != cbmpc.CurveUnknown {
+		r.Curve = op.Curve.String()
+	}
+	r.Key = op.KeyFingerprint()
+	for _, m := range op.Messages {
+		sum := sha256.Sum256(m)
+		r.Messages = append(r.Messages, hex.EncodeToString(sum[:]))
+	}
+	if op.Err != nil {
+		r.Result = journal.ClassifyError(op.Err)
+		if errors.Is(op.Err, cbmpc.ErrPolicyRejected) {
+			r.Result = ResultPolicyRejected
+		}
+		r.Error = op.Err.Error()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, errors.New("audit log closed")
+	}
+	if l.err != nil {
+		return nil, l.err
+	}
+	r.Seq, r.Prev = l.seq, l.prev
+	hash, err := r.digest()
+	if err != nil {
+		return nil, err
+	}
+	r.Hash = hash
+	line, err := json.Marshal(r)
+	if err == nil {
+		err = l.sink.Append(line)
+	}
+	if err == nil && l.file != nil {
+		err = l.file.Sync()
+	}
+	if err != nil {
+		l.err = fmt.Errorf("write audit record %d: %w", r.Seq, err)
+		return nil, l.err
+	}
+	l.seq++
+	l.prev = hash
+	return r, nil
+}
+
+// Read decodes the JSON-line records written by WriterSink or OpenFile.
+func Read(r io.Reader) ([]Record, error) {
+	br := bufio.NewReader(r)
+	var records []Record
+	for n := 1; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) && len(line) == 0 {
+			return records, nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return records, err
+		}
+		var rec Record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return records, fmt.Errorf("%w: line %d: %v", ErrTampered, n, err)
+		}
+		records = append(records, rec)
+	}
+}
+
+// ErrTampered is wrapped by every TamperError.
+var ErrTampered = errors.New("audit log tampered")
+
+// TamperError reports the first record at which a chain fails to verify.
+type TamperError struct {
+	Seq    uint64
+	Reason string
+}
+
+func (e *TamperError) Error() string {
+	return fmt.Sprintf("%v: record %d: %s", ErrTampered, e.Seq, e.Reason)
+}
+
+func (e *TamperError) Unwrap() error { return ErrTampered }
+
+// Verify checks that records form an unbroken chain from the start of a log:
+// sequence numbers count up from zero, every Hash matches its record, and
+// every Prev matches the Hash before it. It returns a *TamperError for the
+// first record that does not.
+func Verify(records []Record) error {
+	prev := genesis
+	for i := range records {
+		r := &records[i]
+		switch {
+		case r.Seq != uint64(i):
+			return &TamperError{Seq: uint64(i), Reason: fmt.Sprintf("sequence number %d", r.Seq)}
+		case r.Prev != prev:
+			return &TamperError{Seq: r.Seq, Reason: "previous hash does not match"}
+		}
+		hash, err := r.digest()
+		if err != nil {
+			return err
+		}
+		if hash != r.Hash {
+			return &TamperError{Seq: r.Seq, Reason: "record hash does not match"}
+		}
+		prev = r.Hash
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/audit"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+func signOp(msg string, err error) *cbmpc.Operation {
+	return &cbmpc.Operation{
+		Protocol:  "ecdsa2p.Sign",
+		Self:      0,
+		Parties:   []string{"p1", "p2"},
+		Curve:     cbmpc.CurveSecp256k1,
+		PublicKey: []byte{2, 7, 7},
+		Messages:  [][]byte{[]byte(msg)},
+		Start:     time.Now(),
+		Duration:  3 * time.Millisecond,
+		Err:       err,
+	}
+}
+
+func TestRecordAndVerify(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := audit.New(audit.WriterSink(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook := log.Hook()
+	hook(context.Background(), signOp("a", nil))
+	hook(context.Background(), signOp("b", &cbmpc.PolicyError{Party: 1, Reason: "not allowed"}))
+	hook(context.Background(), signOp("c", context.DeadlineExceeded))
+	if err := log.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := audit.Read(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := audit.Verify(records); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+	want := []string{audit.ResultOK, audit.ResultPolicyRejected, "timeout"}
+	for i, r := range records {
+		if r.Result != want[i] {
+			t.Errorf("record %d result %q, want %q", i, r.Result, want[i])
+		}
+	}
+	r := records[0]
+	sum := sha256.Sum256([]byte("a"))
+	if r.Key != cbmpc.KeyFingerprint(cbmpc.CurveSecp256k1, []byte{2, 7, 7}) || len(r.Messages) != 1 || r.Messages[0] != hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected record %+v", r)
+	}
+	if r.Curve != "secp256k1" || r.Duration != 3*time.Millisecond || len(r.Parties) != 2 {
+		t.Fatalf("unexpected record %+v", r)
+	}
+	if strings.Contains(buf.String(), `"a"`) {
+		t.Fatal("raw message written to the log")
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	var buf bytes.Buffer
+	log, _ := audit.New(audit.WriterSink(&buf))
+	for _, m := range []string{"a", "b", "c"} {
+		if _, err := log.Record(signOp(m, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	records, err := audit.Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edited := append([]audit.Record{}, records...)
+	edited[1].Result = "protocol"
+	removed := append([]audit.Record{records[0]}, records[2])
+	swapped := []audit.Record{records[0], records[2], records[1]}
+	for name, rs := range map[string][]audit.Record{"edited": edited, "removed": removed, "swapped": swapped} {
+		var te *audit.TamperError
+		if err := audit.Verify(rs); !errors.As(err, &te) || te.Seq != 1 {
+			t.Errorf("%s: Verify = %v, want tampering at record 1", name, err)
+		}
+	}
+}
+
+func TestOpenFileResumesChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	for i := 0; i < 2; i++ {
+		log, err := audit.OpenFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := log.Record(signOp("m", nil)); err != nil {
+			t.Fatal(err)
+		}
+		if err := log.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := audit.Read(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || audit.Verify(records) != nil {
+		t.Fatalf("chain not resumed: %+v", records)
+	}
+
+	tampered := bytes.Replace(data, []byte(`"result":"ok"`), []byte(`"result":"xx"`), 1)
+	if err := os.WriteFile(path, tampered, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := audit.OpenFile(path); !errors.Is(err, audit.ErrTampered) {
+		t.Fatalf("OpenFile on tampered log = %v, want ErrTampered", err)
+	}
+}
+
+func TestAuditJobOperations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	names := [2]string{"p1", "p2"}
+	roles := [2]cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2}
+	bufs := [2]*bytes.Buffer{{}, {}}
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = func() error {
+				log, err := audit.New(audit.WriterSink(bufs[i]))
+				if err != nil {
+					return err
+				}
+				job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), roles[i], names)
+				if err != nil {
+					return err
+				}
+				defer job.Close()
+				job.AddOperationHook(log.Hook())
+				res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
+				if err != nil {
+					return err
+				}
+				defer res.Key.Close()
+				msg := sha256.Sum256([]byte("audited"))
+				if _, err := ecdsa2p.Sign(ctx, job, &ecdsa2p.SignParams{Key: res.Key, Message: msg[:]}); err != nil {
+					return err
+				}
+				return log.Err()
+			}()
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d: %v", i, err)
+		}
+	}
+	for i, buf := range bufs {
+		records, err := audit.Read(buf)
+		if err != nil || audit.Verify(records) != nil {
+			t.Fatalf("party %d: bad log: %v", i, err)
+		}
+		if len(records) != 2 || records[0].Protocol != "ecdsa2p.DKG" || records[1].Protocol != "ecdsa2p.Sign" {
+			t.Fatalf("party %d: unexpected records %+v", i, records)
+		}
+		if records[0].Key == "" || records[0].Key != records[1].Key {
+			t.Fatalf("party %d: DKG and Sign key fingerprints differ", i)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Package audit keeps a tamper-evident, append-only record of every key
+// generation, refresh, import, export and signing operation a party takes
+// part in.
+//
+// A Log is attached to jobs as an operation hook. Each protocol invocation on
+// the job appends one Record with the protocol, the local party and its
+// peers' names, the key's cbmpc.KeyFingerprint, the SHA-256 of every signed
+// message, the outcome and the duration:
+//
+//	log, err := audit.OpenFile("/var/lib/mpc/audit.jsonl")
+//	if err != nil {
+//	    return err
+//	}
+//	defer log.Close()
+//
+//	job, err := cbmpc.NewJobMP(transport, self, names)
+//	// ...
+//	job.AddOperationHook(log.Hook())
+//	res, err := ecdsamp.Sign(ctx, job, params)
+//	if err := log.Err(); err != nil {
+//	    // the record for this signature could not be written
+//	}
+//
+// Records are chained: each carries the hash of the previous one, and its own
+// hash covers every field. Editing, removing or reordering records breaks the
+// chain, which Verify detects. The chain does not stop an attacker who can
+// rewrite the whole file from the tampered record onward; ship records to a
+// write-once store, or keep the latest Hash elsewhere, to detect that too.
+//
+// Signed messages and keys are never written, only their digests. Sinks other
+// than files, such as a remote log service, implement Sink.
+package audit
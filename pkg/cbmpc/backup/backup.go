@@ -0,0 +1,422 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorrmp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
+
+// Version is the bundle format version written by Backup.
+const Version = 1
+
+const labelTag = "cbmpc-backup/v1"
+
+var (
+	// ErrUnsupportedKey is returned for key types Backup cannot back up.
+	ErrUnsupportedKey = errors.New("backup: unsupported key type")
+	// ErrPolicyMismatch is returned by Verify when a bundle was encrypted to
+	// a different access structure or different encryption keys than the
+	// policy it is checked against.
+	ErrPolicyMismatch = errors.New("backup: bundle does not match policy")
+	// ErrMalformed is returned for bundles that cannot be decoded or whose
+	// manifest is inconsistent.
+	ErrMalformed = errors.New("backup: malformed bundle")
+)
+
+// Key is a multi-party key share to back up: an *ecdsamp.Key or a
+// *schnorrmp.Key.
+type Key interface {
+	ProtectedBytes() (*secmem.Buffer, error)
+}
+
+// Policy says who can restore a backup: the access structure, and the
+// encryption key of every leaf, keyed by leaf path.
+type Policy struct {
+	AC       ac.AccessStructure
+	PathToEK map[string][]byte
+}
+
+func (p Policy) check() error {
+	if len(p.AC) == 0 {
+		return errors.New("backup: empty access structure")
+	}
+	return p.AC.CheckPathToEK(p.PathToEK)
+}
+
+// Manifest is the public metadata of a backup. Everything in it is needed to
+// verify the ciphertext and to rebuild the key share once it is restored;
+// none of it is secret.
+type Manifest struct {
+	Version  int         `json:"version"`
+	Protocol string      `json:"protocol"` // "ecdsamp" or "schnorrmp"
+	Curve    cbmpc.Curve `json:"curve"`
+
+	// Party is the name of the party whose share is backed up, and Parties
+	// the names of all parties of the key in the order of PublicShares.
+	Party        string   `json:"party"`
+	Parties      []string `json:"parties"`
+	PublicKey    []byte   `json:"public_key"`
+	PublicShares [][]byte `json:"public_shares"`
+	Fingerprint  string   `json:"fingerprint"`
+
+	// Quorum and KeyCreated are carried over to the restored key share.
+	Quorum     int       `json:"quorum,omitempty"`
+	KeyCreated time.Time `json:"key_created,omitzero"`
+
+	AccessStructure []byte            `json:"access_structure"`
+	PathToEK        map[string][]byte `json:"path_to_ek"`
+
+	// CiphertextDigest is the SHA-256 of the bundle's ciphertext.
+	CiphertextDigest []byte    `json:"ciphertext_digest"`
+	Created          time.Time `json:"created"`
+}
+
+// Bundle is a verifiable backup of one party's key share: a PVE-AC
+// ciphertext of the share, whose embedded proofs let anyone check it against
+// the party's public share, and the manifest describing it.
+type Bundle struct {
+	Manifest Manifest `json:"manifest"`
+	// Ciphertext is the PVE-AC ciphertext in a pve.Marshal envelope.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Name returns the name Backup stores the bundle under: the key fingerprint
+// and the party name.
+func (b *Bundle) Name() string {
+	return b.Manifest.Fingerprint + "-" + url.PathEscape(b.Manifest.Party) + ".json"
+}
+
+// Marshal encodes the bundle as JSON.
+func (b *Bundle) Marshal() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+// Parse decodes a bundle written by Marshal and checks that its manifest is
+// self-consistent. It does not verify the ciphertext; use Verify.
+func Parse(data []byte) (*Bundle, error) {
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Load reads the bundle stored under name in sink.
+func Load(ctx context.Context, sink Sink, name string) (*Bundle, error) {
+	if sink == nil {
+		return nil, errors.New("backup: nil sink")
+	}
+	data, err := sink.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+func (b *Bundle) check() error {
+	m := &b.Manifest
+	if m.Version != Version {
+		return fmt.Errorf("%w: version %d, this library reads %d", ErrMalformed, m.Version, Version)
+	}
+	if _, err := protocolID(m.Protocol); err != nil {
+		return err
+	}
+	if len(m.Parties) != len(m.PublicShares) {
+		return fmt.Errorf("%w: %d parties, %d public shares", ErrMalformed, len(m.Parties), len(m.PublicShares))
+	}
+	if !slices.Contains(m.Parties, m.Party) {
+		return fmt.Errorf("%w: party %q is not one of the key's parties", ErrMalformed, m.Party)
+	}
+	if got := cbmpc.KeyFingerprint(m.Curve, m.PublicKey); got != m.Fingerprint {
+		return fmt.Errorf("%w: fingerprint does not match the public key", ErrMalformed)
+	}
+	if sum := sha256.Sum256(b.Ciphertext); !bytes.Equal(sum[:], m.CiphertextDigest) {
+		return fmt.Errorf("%w: ciphertext digest mismatch", ErrMalformed)
+	}
+	return nil
+}
+
+// publicShare returns the backed-up party's public share.
+func (m *Manifest) publicShare() []byte {
+	return m.PublicShares[slices.Index(m.Parties, m.Party)]
+}
+
+// policy returns the policy recorded in the manifest.
+func (m *Manifest) policy() Policy {
+	return Policy{AC: ac.AccessStructure(m.AccessStructure), PathToEK: m.PathToEK}
+}
+
+// label binds the ciphertext to the key and party it backs up, so a
+// ciphertext cannot be passed off as another party's backup.
+func (m *Manifest) label() []byte {
+	var b []byte
+	for _, f := range [][]byte{
+		[]byte(labelTag), []byte(m.Protocol), []byte(m.Curve.String()),
+		[]byte(m.Party), m.PublicKey, m.publicShare(),
+	} {
+		b = binary.BigEndian.AppendUint32(b, uint32(len(f)))
+		b = append(b, f...)
+	}
+	return b
+}
+
+func protocolID(name string) (uint8, error) {
+	switch name {
+	case "ecdsamp":
+		return envelope.KeyECDSAMP, nil
+	case "schnorrmp":
+		return envelope.KeySchnorrMP, nil
+	default:
+		return 0, fmt.Errorf("%w: protocol %q", ErrUnsupportedKey, name)
+	}
+}
+
+// Params contains parameters for Backup.
+type Params struct {
+	// PVE is the instance whose KEM the policy's encryption keys belong to.
+	PVE *pve.PVE
+
+	// Key is the share to back up. It is not modified.
+	Key Key
+
+	// Parties are the names of every party of the key. Their public shares
+	// are recorded so Restore can rebuild the key share. For threshold keys
+	// list them in the order of the access structure's leaves, which fixes
+	// each party's share index; VerifyBundle relies on it.
+	Parties []string
+
+	// Policy is the access structure and encryption keys that can restore
+	// the share.
+	Policy Policy
+
+	// Sink, if set, receives the encoded bundle under Bundle.Name.
+	Sink Sink
+}
+
+// Backup encrypts the share of params.Key to params.Policy with PVE-AC,
+// checks the result with Verify and, if params.Sink is set, stores it.
+func Backup(ctx context.Context, params *Params) (*Bundle, error) {
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.PVE == nil {
+		return nil, errors.New("backup: nil PVE")
+	}
+	if err := params.Policy.check(); err != nil {
+		return nil, err
+	}
+
+	var protocol uint8
+	switch params.Key.(type) {
+	case *ecdsamp.Key:
+		protocol = envelope.KeyECDSAMP
+	case *schnorrmp.Key:
+		protocol = envelope.KeySchnorrMP
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, params.Key)
+	}
+	data, err := params.Key.ProtectedBytes()
+	if err != nil {
+		return nil, err
+	}
+	defer data.Destroy()
+	meta, native, err := envelope.UnwrapKey(protocol, data.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	ckey, err := backend.ECDSAMPKeyDeserialize(native)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	defer backend.ECDSAMPKeyFree(ckey)
+
+	m, err := newManifest(ckey, params.Parties)
+	if err != nil {
+		return nil, err
+	}
+	m.Protocol = envelope.KeyProtocolName(protocol)
+	m.Quorum = meta.Quorum
+	m.KeyCreated = meta.Created
+	m.AccessStructure = bytes.Clone(params.Policy.AC)
+	m.PathToEK = maps.Clone(params.Policy.PathToEK)
+	m.Created = time.Now().UTC()
+
+	x, err := backend.ECDSAMPKeyGetXShare(ckey)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	defer cbmpc.ZeroizeBytes(x)
+	if err := checkShare(m.Curve, x, m.publicShare()); err != nil {
+		return nil, err
+	}
+
+	enc, err := params.PVE.ACEncrypt(ctx, &pve.ACEncryptParams{
+		AC:       params.Policy.AC,
+		PathToEK: params.Policy.PathToEK,
+		Label:    m.label(),
+		Curve:    m.Curve,
+		Scalars:  [][]byte{x},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ct, err := pve.Marshal(pve.CiphertextAC, m.Curve, enc.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(ct)
+	m.CiphertextDigest = sum[:]
+	b := &Bundle{Manifest: *m, Ciphertext: ct}
+
+	if err := Verify(ctx, params.PVE, b, params.Policy); err != nil {
+		return nil, fmt.Errorf("backup: fresh bundle does not verify: %w", err)
+	}
+	if params.Sink != nil {
+		encoded, err := b.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		if err := params.Sink.Put(ctx, b.Name(), encoded); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// newManifest records the public parts of ckey: its curve, public key, and
+// the public share of every party in parties.
+func newManifest(ckey backend.ECDSAMPKey, parties []string) (*Manifest, error) {
+	c, err := backend.ECDSAMPKeyGetCurve(ckey)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	self, err := backend.ECDSAMPKeyGetPartyName(ckey)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	if !slices.Contains(parties, self) {
+		return nil, fmt.Errorf("backup: key party %q is not in Parties", self)
+	}
+	pub, err := backend.ECDSAMPKeyGetPublicKey(ckey)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	m := &Manifest{
+		Version:     Version,
+		Curve:       cbmpc.Curve(c),
+		Party:       self,
+		Parties:     slices.Clone(parties),
+		PublicKey:   pub,
+		Fingerprint: cbmpc.KeyFingerprint(cbmpc.Curve(c), pub),
+	}
+	for _, name := range parties {
+		q, err := backend.ECDSAMPKeyGetPublicShare(ckey, name)
+		if err != nil {
+			return nil, fmt.Errorf("backup: public share of %q: %w", name, cbmpc.RemapError(err))
+		}
+		m.PublicShares = append(m.PublicShares, q)
+	}
+	return m, nil
+}
+
+// checkShare reports whether x is the discrete log of the public share q.
+func checkShare(c cbmpc.Curve, x, q []byte) error {
+	s, err := curve.NewScalarFromBytes(x)
+	if err != nil {
+		return err
+	}
+	defer s.Free()
+	got, err := curve.MulGenerator(c, s)
+	if err != nil {
+		return err
+	}
+	defer got.Free()
+	want, err := curve.NewPointFromBytes(c, q)
+	if err != nil {
+		return err
+	}
+	defer want.Free()
+	gb, err := got.Bytes()
+	if err != nil {
+		return err
+	}
+	wb, err := want.Bytes()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(gb, wb) {
+		return errors.New("backup: key share does not match its public share")
+	}
+	return nil
+}
+
+// Verify checks that b was encrypted to policy and that its ciphertext
+// encrypts the discrete log of the party's public share under the label
+// derived from the manifest. It needs no private keys, so the custodians
+// named in the policy, or anyone else, can check a backup before the key it
+// protects is relied on.
+func Verify(ctx context.Context, p *pve.PVE, b *Bundle, policy Policy) error {
+	if p == nil {
+		return errors.New("backup: nil PVE")
+	}
+	if b == nil {
+		return errors.New("backup: nil bundle")
+	}
+	if err := b.check(); err != nil {
+		return err
+	}
+	if err := policy.check(); err != nil {
+		return err
+	}
+	m := &b.Manifest
+	if !bytes.Equal(m.AccessStructure, policy.AC) {
+		return fmt.Errorf("%w: access structure differs", ErrPolicyMismatch)
+	}
+	if !maps.EqualFunc(m.PathToEK, policy.PathToEK, bytes.Equal) {
+		return fmt.Errorf("%w: encryption keys differ", ErrPolicyMismatch)
+	}
+	return verifyCiphertext(ctx, p, b, policy)
+}
+
+// verifyCiphertext checks the PVE-AC proofs of b's ciphertext against the
+// party's public share.
+func verifyCiphertext(ctx context.Context, p *pve.PVE, b *Bundle, policy Policy) error {
+	m := &b.Manifest
+	ct, err := pve.Unmarshal(b.Ciphertext, pve.CiphertextAC, m.Curve)
+	if err != nil {
+		return err
+	}
+	q, err := curve.NewPointFromBytes(m.Curve, m.publicShare())
+	if err != nil {
+		return fmt.Errorf("%w: public share: %v", ErrMalformed, err)
+	}
+	defer q.Free()
+	return p.ACVerify(ctx, &pve.ACVerifyParams{
+		AC:         policy.AC,
+		PathToEK:   policy.PathToEK,
+		Ciphertext: pve.ACCiphertext(ct),
+		QPoints:    []*cbmpc.CurvePoint{q},
+		Label:      m.label(),
+	})
+}
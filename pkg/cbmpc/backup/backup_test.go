@@ -0,0 +1,165 @@
+package backup_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/backup"
+)
+
+func TestDirSink(t *testing.T) {
+	ctx := context.Background()
+	sink := backup.DirSink(t.TempDir())
+
+	if err := sink.Put(ctx, "a.json", []byte("one")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := sink.Put(ctx, "a.json", []byte("two")); err != nil {
+		t.Fatalf("Put overwrite: %v", err)
+	}
+	got, err := sink.Get(ctx, "a.json")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(got) != "two" {
+		t.Errorf("Get = %q, want %q", got, "two")
+	}
+
+	if _, err := sink.Get(ctx, "missing.json"); !errors.Is(err, backup.ErrNotFound) {
+		t.Errorf("Get missing: err = %v, want ErrNotFound", err)
+	}
+	for _, name := range []string{"", ".", "..", "../x", "a/b", `a\b`} {
+		if err := sink.Put(ctx, name, []byte("x")); err == nil {
+			t.Errorf("Put(%q) succeeded", name)
+		}
+	}
+}
+
+// testBundle returns a bundle with a consistent manifest and a dummy
+// ciphertext, enough for Parse.
+func testBundle() *backup.Bundle {
+	pub := []byte{0x02, 1, 2, 3}
+	ct := []byte("ciphertext")
+	sum := sha256.Sum256(ct)
+	return &backup.Bundle{
+		Manifest: backup.Manifest{
+			Version:          backup.Version,
+			Protocol:         "ecdsamp",
+			Curve:            cbmpc.CurveSecp256k1,
+			Party:            "b",
+			Parties:          []string{"a", "b"},
+			PublicKey:        pub,
+			PublicShares:     [][]byte{{0x02, 4}, {0x03, 5}},
+			Fingerprint:      cbmpc.KeyFingerprint(cbmpc.CurveSecp256k1, pub),
+			AccessStructure:  []byte{1},
+			PathToEK:         map[string][]byte{"/x": {1}},
+			CiphertextDigest: sum[:],
+		},
+		Ciphertext: ct,
+	}
+}
+
+func TestParse(t *testing.T) {
+	data, err := testBundle().Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	b, err := backup.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if b.Manifest.Party != "b" || string(b.Ciphertext) != "ciphertext" {
+		t.Errorf("Parse = %+v", b)
+	}
+	if want := b.Manifest.Fingerprint + "-b.json"; b.Name() != want {
+		t.Errorf("Name = %q, want %q", b.Name(), want)
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*backup.Bundle)
+		want   error
+	}{
+		{"version", func(b *backup.Bundle) { b.Manifest.Version = 2 }, backup.ErrMalformed},
+		{"protocol", func(b *backup.Bundle) { b.Manifest.Protocol = "ecdsa2p" }, backup.ErrUnsupportedKey},
+		{"party", func(b *backup.Bundle) { b.Manifest.Party = "c" }, backup.ErrMalformed},
+		{"shares", func(b *backup.Bundle) { b.Manifest.PublicShares = b.Manifest.PublicShares[:1] }, backup.ErrMalformed},
+		{"fingerprint", func(b *backup.Bundle) { b.Manifest.PublicKey = []byte{0x03, 1, 2, 3} }, backup.ErrMalformed},
+		{"ciphertext", func(b *backup.Bundle) { b.Ciphertext = []byte("other") }, backup.ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := testBundle()
+			tt.mutate(b)
+			data, err := b.Marshal()
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if _, err := backup.Parse(data); !errors.Is(err, tt.want) {
+				t.Errorf("Parse: err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if _, err := backup.Parse([]byte("{")); !errors.Is(err, backup.ErrMalformed) {
+		t.Errorf("Parse truncated: err = %v, want ErrMalformed", err)
+	}
+}
+
+func TestParseFullRejects(t *testing.T) {
+	ct := []byte("ciphertext")
+	sum := sha256.Sum256(ct)
+	pub := []byte{0x02, 1, 2, 3}
+	valid := func() *backup.FullBundle {
+		return &backup.FullBundle{
+			Manifest: backup.FullManifest{
+				Version:          backup.Version,
+				Protocol:         "ecdsa2p",
+				Curve:            cbmpc.CurveP256,
+				Role:             "p1",
+				PublicKey:        pub,
+				Fingerprint:      cbmpc.KeyFingerprint(cbmpc.CurveP256, pub),
+				EK:               []byte{1},
+				Size:             31,
+				CiphertextDigest: sum[:],
+			},
+			Points:     [][]byte{{1}, {2}, {3}},
+			Ciphertext: ct,
+		}
+	}
+	data, err := valid().Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if _, err := backup.ParseFull(data); err != nil {
+		t.Fatalf("ParseFull: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*backup.FullBundle)
+		want   error
+	}{
+		{"protocol", func(b *backup.FullBundle) { b.Manifest.Protocol = "rsa" }, backup.ErrUnsupportedKey},
+		{"points", func(b *backup.FullBundle) { b.Points = b.Points[:2] }, backup.ErrMalformed},
+		{"size", func(b *backup.FullBundle) { b.Manifest.Size = 0 }, backup.ErrMalformed},
+		{"ciphertext", func(b *backup.FullBundle) { b.Ciphertext = []byte("other") }, backup.ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := valid()
+			tt.mutate(b)
+			data, err := b.Marshal()
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if _, err := backup.ParseFull(data); !errors.Is(err, tt.want) {
+				t.Errorf("ParseFull: err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Package backup makes verifiable backups of multi-party key shares and
+// restores them under an access-structure policy.
+//
+// A backup encrypts one party's private share with PVE-AC (see the pve
+// package) to a policy: an access structure over custodians and each
+// custodian's KEM encryption key. The resulting Bundle carries the
+// ciphertext, whose proofs tie it to the party's public share, and a JSON
+// Manifest with everything needed to check the ciphertext and rebuild the key
+// share: the key's parties, public key and public shares, and the policy.
+// The ciphertext label is derived from the manifest, so a bundle cannot be
+// relabeled as another party's or another key's backup.
+//
+// # Key Operations
+//
+//   - Backup: Encrypts a key share to a policy, verifies it and stores it
+//   - Verify: Checks a bundle against the policy it should be encrypted to
+//   - VerifyBundle: Checks a bundle against the wallet public key, for auditors
+//   - DecryptShare: Produces one custodian's decryption share
+//   - NewCoordinator, Restore: Collect shares and rebuild the key share
+//   - BackupFull, VerifyFull, RestoreFull: Whole-share backups to one key
+//   - LoadKeyShare: Loads a restored share as a key handle
+//
+// Backup encrypts only the private share, a single scalar, and rebuilds the
+// rest of the key from the manifest, which works for multi-party keys only.
+// BackupFull instead splits the whole serialized share into chunks and batch
+// encrypts them, so it also covers 2-party keys, whose Paillier keys cannot be
+// rebuilt, but it is larger and restores with a single decryption key.
+//
+// Bundles are stored in a Sink by name; DirSink keeps them as files.
+//
+// # Usage Example
+//
+//	// Each party backs up its own share after DKG.
+//	b, err := backup.Backup(ctx, &backup.Params{
+//	    PVE:     pveInstance,
+//	    Key:     dkgResult.Key,
+//	    Parties: names,
+//	    Policy:  backup.Policy{AC: custodians, PathToEK: custodianEKs},
+//	    Sink:    backup.DirSink("/var/lib/backups"),
+//	})
+//
+//	// Restore: every available custodian decrypts its share locally...
+//	share, err := backup.DecryptShare(ctx, pveInstance, b, "/alice", aliceDK)
+//
+//	// ...and a coordinator combines a quorum of them.
+//	c, err := backup.NewCoordinator(pveInstance, b)
+//	err = c.Add("/alice", share) // and the others
+//	buf, err := backup.Restore(ctx, b, c)
+//	defer buf.Destroy()
+//	key, err := ecdsamp.LoadKey(buf.Bytes())
+//
+// # Security Considerations
+//
+//   - Verify a bundle against the policy you expect before relying on it.
+//     The policy is recorded in the manifest, but an attacker who can write
+//     to the sink can replace both; Verify compares them to the policy given.
+//   - Custodians should decrypt on their own machines and hand over only
+//     their decryption shares. The coordinator never needs a decryption key,
+//     but whoever runs Restore learns the key share.
+//   - A backup restores the share as it was when backed up. Refresh changes
+//     every share, so back up again after each refresh and retire the old
+//     bundles.
+//   - Backup supports only ecdsamp and schnorrmp keys: a 2-party share
+//     cannot be rebuilt from the private share alone. Use BackupFull for
+//     2-party keys, or migrate them with the migrate package first.
+//   - A full backup publishes one point per chunk. Each chunk is salted with
+//     16 random bytes so that chunks of mostly public key-file bytes cannot be
+//     recovered from their points by brute force.
+package backup
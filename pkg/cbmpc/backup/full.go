@@ -0,0 +1,429 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorr2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorrmp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
+
+// A full backup splits the serialized key share into chunks and encrypts
+// each as one scalar salt || chunk. The scalars stay below 2^248, under the
+// order of every supported curve. The random salt keeps a chunk of mostly
+// known bytes from being recovered from its public point by brute force.
+const (
+	saltSize  = 16
+	chunkSize = 15
+)
+
+const fullLabelTag = "cbmpc-backup-full/v1"
+
+// FullKey is a key share of any protocol: an *ecdsa2p.Key, *ecdsamp.Key,
+// *schnorr2p.Key or *schnorrmp.Key.
+type FullKey interface {
+	ProtectedBytes() (*secmem.Buffer, error)
+	PublicKey() ([]byte, error)
+	Curve() (cbmpc.Curve, error)
+	Close() error
+}
+
+// FullManifest is the public metadata of a full backup.
+type FullManifest struct {
+	Version     int         `json:"version"`
+	Protocol    string      `json:"protocol"`
+	Curve       cbmpc.Curve `json:"curve"`
+	Role        string      `json:"role"` // "p1"/"p2" or the party name
+	PublicKey   []byte      `json:"public_key"`
+	Fingerprint string      `json:"fingerprint"`
+
+	// EK is the encryption key the share is encrypted to.
+	EK []byte `json:"ek"`
+
+	// Size is the length of the serialized key share.
+	Size int `json:"size"`
+
+	CiphertextDigest []byte    `json:"ciphertext_digest"`
+	Created          time.Time `json:"created"`
+}
+
+// FullBundle is a verifiable backup of a whole serialized key share, batch
+// PVE encrypted to a single encryption key. Points holds the public point of
+// every encrypted scalar, which VerifyFull checks the ciphertext against.
+type FullBundle struct {
+	Manifest FullManifest `json:"manifest"`
+	Points   [][]byte     `json:"points"`
+	// Ciphertext is the batch PVE ciphertext in a pve.Marshal envelope.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Name returns the name BackupFull stores the bundle under.
+func (b *FullBundle) Name() string {
+	return b.Manifest.Fingerprint + "-" + url.PathEscape(b.Manifest.Role) + ".full.json"
+}
+
+// Marshal encodes the bundle as JSON.
+func (b *FullBundle) Marshal() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+// ParseFull decodes a bundle written by FullBundle.Marshal and checks that
+// it is self-consistent. It does not verify the ciphertext.
+func ParseFull(data []byte) (*FullBundle, error) {
+	var b FullBundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// LoadFull reads the full bundle stored under name in sink.
+func LoadFull(ctx context.Context, sink Sink, name string) (*FullBundle, error) {
+	if sink == nil {
+		return nil, errors.New("backup: nil sink")
+	}
+	data, err := sink.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFull(data)
+}
+
+func (b *FullBundle) check() error {
+	m := &b.Manifest
+	if m.Version != Version {
+		return fmt.Errorf("%w: version %d, this library reads %d", ErrMalformed, m.Version, Version)
+	}
+	if _, err := fullProtocolID(m.Protocol); err != nil {
+		return err
+	}
+	if m.Size <= 0 || len(b.Points) != chunks(m.Size) {
+		return fmt.Errorf("%w: %d points for a %d-byte share", ErrMalformed, len(b.Points), m.Size)
+	}
+	if got := cbmpc.KeyFingerprint(m.Curve, m.PublicKey); got != m.Fingerprint {
+		return fmt.Errorf("%w: fingerprint does not match the public key", ErrMalformed)
+	}
+	if sum := sha256.Sum256(b.Ciphertext); !bytes.Equal(sum[:], m.CiphertextDigest) {
+		return fmt.Errorf("%w: ciphertext digest mismatch", ErrMalformed)
+	}
+	return nil
+}
+
+// label binds the ciphertext to the key share it backs up.
+func (m *FullManifest) label() []byte {
+	var b []byte
+	for _, f := range [][]byte{
+		[]byte(fullLabelTag), []byte(m.Protocol), []byte(m.Curve.String()),
+		[]byte(m.Role), m.PublicKey, binary.BigEndian.AppendUint64(nil, uint64(m.Size)),
+	} {
+		b = binary.BigEndian.AppendUint32(b, uint32(len(f)))
+		b = append(b, f...)
+	}
+	return b
+}
+
+func fullProtocolID(name string) (uint8, error) {
+	switch name {
+	case "ecdsa2p":
+		return envelope.KeyECDSA2P, nil
+	case "schnorr2p":
+		return envelope.KeySchnorr2P, nil
+	default:
+		return protocolID(name)
+	}
+}
+
+func chunks(size int) int { return (size + chunkSize - 1) / chunkSize }
+
+// FullParams contains parameters for BackupFull.
+type FullParams struct {
+	// PVE is the instance whose KEM EK belongs to.
+	PVE *pve.PVE
+
+	// Key is the share to back up. It is not modified.
+	Key FullKey
+
+	// EK is the encryption key of whoever can restore the share.
+	EK []byte
+
+	// Sink, if set, receives the encoded bundle under FullBundle.Name.
+	Sink Sink
+}
+
+// BackupFull encrypts the whole serialized share of params.Key to params.EK
+// with batch PVE, checks the result with VerifyFull and, if params.Sink is
+// set, stores it. Unlike Backup it works for every protocol, including
+// 2-party keys, at the cost of a larger bundle and a single decryption key.
+func BackupFull(ctx context.Context, params *FullParams) (*FullBundle, error) {
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.PVE == nil {
+		return nil, errors.New("backup: nil PVE")
+	}
+	if len(params.EK) == 0 {
+		return nil, errors.New("backup: empty encryption key")
+	}
+
+	var protocol uint8
+	switch params.Key.(type) {
+	case *ecdsa2p.Key:
+		protocol = envelope.KeyECDSA2P
+	case *ecdsamp.Key:
+		protocol = envelope.KeyECDSAMP
+	case *schnorr2p.Key:
+		protocol = envelope.KeySchnorr2P
+	case *schnorrmp.Key:
+		protocol = envelope.KeySchnorrMP
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, params.Key)
+	}
+	c, err := params.Key.Curve()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := params.Key.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	data, err := params.Key.ProtectedBytes()
+	if err != nil {
+		return nil, err
+	}
+	defer data.Destroy()
+	meta, _, err := envelope.DecodeKey(protocol, data.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	m := FullManifest{
+		Version:     Version,
+		Protocol:    envelope.KeyProtocolName(protocol),
+		Curve:       c,
+		Role:        meta.Role,
+		PublicKey:   pub,
+		Fingerprint: cbmpc.KeyFingerprint(c, pub),
+		EK:          bytes.Clone(params.EK),
+		Size:        data.Len(),
+		Created:     time.Now().UTC(),
+	}
+	scalars, err := toScalars(data.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	defer freeScalars(scalars)
+
+	b := &FullBundle{Manifest: m}
+	for _, s := range scalars {
+		q, err := curve.MulGenerator(c, s)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := q.Bytes()
+		q.Free()
+		if err != nil {
+			return nil, err
+		}
+		b.Points = append(b.Points, raw)
+	}
+	enc, err := params.PVE.BatchEncrypt(ctx, &pve.BatchEncryptParams{
+		EK:      params.EK,
+		Label:   m.label(),
+		Curve:   c,
+		Scalars: scalars,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if b.Ciphertext, err = pve.Marshal(pve.CiphertextBatch, c, enc.Ciphertext); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(b.Ciphertext)
+	b.Manifest.CiphertextDigest = sum[:]
+
+	if err := VerifyFull(ctx, params.PVE, b, params.EK); err != nil {
+		return nil, fmt.Errorf("backup: fresh bundle does not verify: %w", err)
+	}
+	if params.Sink != nil {
+		encoded, err := b.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		if err := params.Sink.Put(ctx, b.Name(), encoded); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// toScalars splits data into salted chunk scalars.
+func toScalars(data []byte) ([]*curve.Scalar, error) {
+	scalars := make([]*curve.Scalar, 0, chunks(len(data)))
+	buf := make([]byte, saltSize+chunkSize)
+	defer cbmpc.ZeroizeBytes(buf)
+	for off := 0; off < len(data); off += chunkSize {
+		clear(buf)
+		if _, err := rand.Read(buf[:saltSize]); err != nil {
+			freeScalars(scalars)
+			return nil, err
+		}
+		copy(buf[saltSize:], data[off:min(off+chunkSize, len(data))])
+		s, err := curve.NewScalarFromBytes(buf)
+		if err != nil {
+			freeScalars(scalars)
+			return nil, err
+		}
+		scalars = append(scalars, s)
+	}
+	return scalars, nil
+}
+
+// fromScalars reassembles size bytes from the chunk scalars.
+func fromScalars(c cbmpc.Curve, scalars []*curve.Scalar, size int) ([]byte, error) {
+	if len(scalars) != chunks(size) {
+		return nil, fmt.Errorf("%w: %d scalars for a %d-byte share", ErrMalformed, len(scalars), size)
+	}
+	out := make([]byte, 0, len(scalars)*chunkSize)
+	for _, s := range scalars {
+		raw := s.BytesPadded(c)
+		if len(raw) < chunkSize {
+			cbmpc.ZeroizeBytes(raw)
+			cbmpc.ZeroizeBytes(out)
+			return nil, fmt.Errorf("%w: short scalar", ErrMalformed)
+		}
+		out = append(out, raw[len(raw)-chunkSize:]...)
+		cbmpc.ZeroizeBytes(raw)
+	}
+	cbmpc.ZeroizeBytes(out[size:])
+	return out[:size], nil
+}
+
+func freeScalars(scalars []*curve.Scalar) {
+	for _, s := range scalars {
+		s.Free()
+	}
+}
+
+// VerifyFull checks that b was encrypted to ek and that its ciphertext
+// encrypts the discrete logs of its points under the label derived from the
+// manifest. It needs no decryption key.
+func VerifyFull(ctx context.Context, p *pve.PVE, b *FullBundle, ek []byte) error {
+	if p == nil {
+		return errors.New("backup: nil PVE")
+	}
+	if b == nil {
+		return errors.New("backup: nil bundle")
+	}
+	if err := b.check(); err != nil {
+		return err
+	}
+	m := &b.Manifest
+	if !bytes.Equal(m.EK, ek) {
+		return fmt.Errorf("%w: encryption key differs", ErrPolicyMismatch)
+	}
+	ct, err := pve.Unmarshal(b.Ciphertext, pve.CiphertextBatch, m.Curve)
+	if err != nil {
+		return err
+	}
+	points := make([]*cbmpc.CurvePoint, 0, len(b.Points))
+	defer func() {
+		for _, q := range points {
+			q.Free()
+		}
+	}()
+	for i, raw := range b.Points {
+		q, err := curve.NewPointFromBytes(m.Curve, raw)
+		if err != nil {
+			return fmt.Errorf("%w: point %d: %v", ErrMalformed, i, err)
+		}
+		points = append(points, q)
+	}
+	return p.BatchVerify(ctx, &pve.BatchVerifyParams{
+		EK:         ek,
+		Ciphertext: pve.BatchCiphertext(ct),
+		Points:     points,
+		Label:      m.label(),
+	})
+}
+
+// RestoreFull decrypts b with dk, the decryption key for the manifest's EK,
+// and returns the serialized key share exactly as Key.ProtectedBytes wrote
+// it. LoadKeyShare turns it back into a key handle. Destroy the buffer when
+// done.
+func RestoreFull(ctx context.Context, p *pve.PVE, b *FullBundle, dk any) (*secmem.Buffer, error) {
+	if p == nil {
+		return nil, errors.New("backup: nil PVE")
+	}
+	if b == nil {
+		return nil, errors.New("backup: nil bundle")
+	}
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+	m := &b.Manifest
+	ct, err := pve.Unmarshal(b.Ciphertext, pve.CiphertextBatch, m.Curve)
+	if err != nil {
+		return nil, err
+	}
+	res, err := p.BatchDecrypt(ctx, &pve.BatchDecryptParams{
+		DK:         dk,
+		EK:         m.EK,
+		Ciphertext: pve.BatchCiphertext(ct),
+		Label:      m.label(),
+		Curve:      m.Curve,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer freeScalars(res.Scalars)
+	data, err := fromScalars(m.Curve, res.Scalars, m.Size)
+	if err != nil {
+		return nil, err
+	}
+	return secmem.Move(data)
+}
+
+// LoadKeyShare loads a key share restored by Restore or RestoreFull as the
+// key type of protocol, a Manifest or FullManifest Protocol: an
+// *ecdsa2p.Key, *ecdsamp.Key, *schnorr2p.Key or *schnorrmp.Key. The caller
+// must Close it.
+func LoadKeyShare(protocol string, data []byte) (FullKey, error) {
+	var (
+		key FullKey
+		err error
+	)
+	switch protocol {
+	case "ecdsa2p":
+		key, err = ecdsa2p.LoadKey(data)
+	case "ecdsamp":
+		key, err = ecdsamp.LoadKey(data)
+	case "schnorr2p":
+		key, err = schnorr2p.LoadKey(data)
+	case "schnorrmp":
+		key, err = schnorrmp.LoadKey(data)
+	default:
+		return nil, fmt.Errorf("%w: protocol %q", ErrUnsupportedKey, protocol)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
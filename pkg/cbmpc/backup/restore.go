@@ -0,0 +1,141 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve/restore"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
+
+// DecryptShare is run by the custodian at path, on its own machine, with its
+// decryption key dk. It returns the custodian's decryption share, which it
+// hands to whoever runs the Coordinator.
+func DecryptShare(ctx context.Context, p *pve.PVE, b *Bundle, path string, dk any) ([]byte, error) {
+	if p == nil {
+		return nil, errors.New("backup: nil PVE")
+	}
+	if b == nil {
+		return nil, errors.New("backup: nil bundle")
+	}
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+	ct, err := pve.Unmarshal(b.Ciphertext, pve.CiphertextAC, b.Manifest.Curve)
+	if err != nil {
+		return nil, err
+	}
+	res, err := p.ACPartyDecryptRow(ctx, &pve.ACPartyDecryptRowParams{
+		AC:         b.Manifest.AccessStructure,
+		Path:       strings.TrimPrefix(path, "/"),
+		DK:         dk,
+		Ciphertext: pve.ACCiphertext(ct),
+		Label:      b.Manifest.label(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Share, nil
+}
+
+// NewCoordinator returns a restore.Coordinator for b that collects the
+// custodians' decryption shares. Pass it to Restore once Satisfied reports a
+// quorum. The coordinator checks the restored share against the party's
+// public share, so it can name custodians whose shares are bad.
+func NewCoordinator(p *pve.PVE, b *Bundle) (*restore.Coordinator, error) {
+	if b == nil {
+		return nil, errors.New("backup: nil bundle")
+	}
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+	m := &b.Manifest
+	ct, err := pve.Unmarshal(b.Ciphertext, pve.CiphertextAC, m.Curve)
+	if err != nil {
+		return nil, err
+	}
+	q, err := curve.NewPointFromBytes(m.Curve, m.publicShare())
+	if err != nil {
+		return nil, fmt.Errorf("%w: public share: %v", ErrMalformed, err)
+	}
+	policy := m.policy()
+	return restore.New(restore.Config{
+		PVE:        p,
+		AC:         policy.AC,
+		Ciphertext: pve.ACCiphertext(ct),
+		Label:      m.label(),
+		Curve:      m.Curve,
+		PathToEK:   policy.PathToEK,
+		QPoints:    []*cbmpc.CurvePoint{q},
+	})
+}
+
+// Restore aggregates the shares collected by c, a Coordinator from
+// NewCoordinator for the same bundle, and rebuilds the backed-up key share.
+// It returns the share serialized as by Key.ProtectedBytes; load it with
+// ecdsamp.LoadKey or schnorrmp.LoadKey according to Manifest.Protocol, and
+// Destroy the buffer afterwards. Errors from the coordinator, including
+// *restore.PartyError values, are returned unchanged.
+func Restore(ctx context.Context, b *Bundle, c *restore.Coordinator) (*secmem.Buffer, error) {
+	if b == nil {
+		return nil, errors.New("backup: nil bundle")
+	}
+	if c == nil {
+		return nil, errors.New("backup: nil coordinator")
+	}
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+	m := &b.Manifest
+	protocol, err := protocolID(m.Protocol)
+	if err != nil {
+		return nil, err
+	}
+	scalars, err := c.Restore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, s := range scalars {
+			s.Free()
+		}
+	}()
+	if len(scalars) != 1 {
+		return nil, fmt.Errorf("%w: restored %d scalars, want 1", ErrMalformed, len(scalars))
+	}
+
+	x := scalars[0].BytesPadded(m.Curve)
+	defer cbmpc.ZeroizeBytes(x)
+	nid, err := backend.CurveToNID(backend.Curve(m.Curve))
+	if err != nil {
+		return nil, err
+	}
+	ckey, err := backend.ECDSAMPKeyNew(nid, m.Party, x, m.PublicKey, m.Parties, m.PublicShares)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	defer backend.ECDSAMPKeyFree(ckey)
+	native, err := backend.ECDSAMPKeySerialize(ckey)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	defer cbmpc.ZeroizeBytes(native)
+	data, err := envelope.EncodeKey(envelope.KeyMeta{
+		Protocol: protocol,
+		Curve:    uint8(m.Curve),
+		Role:     m.Party,
+		Created:  m.KeyCreated,
+		Quorum:   m.Quorum,
+	}, native)
+	if err != nil {
+		return nil, err
+	}
+	return secmem.Move(data)
+}
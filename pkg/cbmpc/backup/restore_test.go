@@ -0,0 +1,375 @@
+//go:build cgo && !windows
+
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/backup"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/testkem"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+)
+
+// dkg runs ecdsamp.DKG for names and returns every party's key.
+func dkg(t *testing.T, ctx context.Context, names []string) []*ecdsamp.Key {
+	t.Helper()
+	net := mocknet.New()
+	roles := make([]cbmpc.RoleID, len(names))
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+	}
+	keys := make([]*ecdsamp.Key, len(names))
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJobMP(net.EpMP(roles[i], roles), roles[i], names)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsamp.DKG(ctx, job, &ecdsamp.DKGParams{Curve: cbmpc.CurveSecp256k1})
+			if err == nil {
+				keys[i] = res.Key
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d DKG failed: %v", i, err)
+		}
+	}
+	t.Cleanup(func() {
+		for _, k := range keys {
+			_ = k.Close()
+		}
+	})
+	return keys
+}
+
+// custodians returns a 2-of-3 policy and each custodian's decryption key.
+func custodians(t *testing.T, kem *testkem.ToyRSAKEM) (backup.Policy, map[string]any) {
+	t.Helper()
+	structure, err := ac.Compile(ac.Threshold(2, ac.Leaf("alice"), ac.Leaf("bob"), ac.Leaf("carol")))
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	paths, err := structure.LeafPaths()
+	if err != nil {
+		t.Fatalf("LeafPaths: %v", err)
+	}
+	policy := backup.Policy{AC: structure, PathToEK: make(map[string][]byte)}
+	dks := make(map[string]any)
+	for _, path := range paths {
+		sk, ek, err := kem.Generate()
+		if err != nil {
+			t.Fatalf("Generate: %v", err)
+		}
+		dk, err := kem.NewPrivateKeyHandle(sk)
+		if err != nil {
+			t.Fatalf("NewPrivateKeyHandle: %v", err)
+		}
+		t.Cleanup(func() { kem.FreePrivateKeyHandle(dk) })
+		policy.PathToEK[path] = ek
+		dks[path] = dk
+	}
+	return policy, dks
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	names := []string{"p0", "p1", "p2"}
+	keys := dkg(t, ctx, names)
+	kem := testkem.NewToyRSAKEM(2048)
+	pveInstance, err := pve.New(kem)
+	if err != nil {
+		t.Fatalf("pve.New: %v", err)
+	}
+	policy, dks := custodians(t, kem)
+	sink := backup.DirSink(t.TempDir())
+
+	b, err := backup.Backup(ctx, &backup.Params{
+		PVE: pveInstance, Key: keys[1], Parties: names, Policy: policy, Sink: sink,
+	})
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	if b.Manifest.Party != "p1" || b.Manifest.Protocol != "ecdsamp" {
+		t.Errorf("manifest = %+v", b.Manifest)
+	}
+
+	loaded, err := backup.Load(ctx, sink, b.Name())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := backup.Verify(ctx, pveInstance, loaded, policy); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	pub, err := keys[1].PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey: %v", err)
+	}
+	if err := backup.VerifyBundle(ctx, pveInstance, loaded, pub); err != nil {
+		t.Fatalf("VerifyBundle: %v", err)
+	}
+
+	c, err := backup.NewCoordinator(pveInstance, loaded)
+	if err != nil {
+		t.Fatalf("NewCoordinator: %v", err)
+	}
+	for _, path := range []string{"/alice", "/carol"} {
+		share, err := backup.DecryptShare(ctx, pveInstance, loaded, path, dks[path])
+		if err != nil {
+			t.Fatalf("DecryptShare(%s): %v", path, err)
+		}
+		if err := c.Add(path, share); err != nil {
+			t.Fatalf("Add(%s): %v", path, err)
+		}
+	}
+	buf, err := backup.Restore(ctx, loaded, c)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	defer buf.Destroy()
+	restored, err := ecdsamp.LoadKey(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadKey: %v", err)
+	}
+	defer restored.Close()
+
+	want, err := keys[1].Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	got, err := restored.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("restored key share differs from the original")
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	names := []string{"p0", "p1"}
+	keys := dkg(t, ctx, names)
+	kem := testkem.NewToyRSAKEM(2048)
+	pveInstance, err := pve.New(kem)
+	if err != nil {
+		t.Fatalf("pve.New: %v", err)
+	}
+	policy, _ := custodians(t, kem)
+	other, _ := custodians(t, kem)
+
+	b, err := backup.Backup(ctx, &backup.Params{
+		PVE: pveInstance, Key: keys[0], Parties: names, Policy: policy,
+	})
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	if err := backup.Verify(ctx, pveInstance, b, other); !errors.Is(err, backup.ErrPolicyMismatch) {
+		t.Errorf("Verify with another policy: err = %v, want ErrPolicyMismatch", err)
+	}
+
+	// Claiming the ciphertext is p1's backup changes the label and the
+	// public share it must match.
+	b.Manifest.Party = "p1"
+	if err := backup.Verify(ctx, pveInstance, b, policy); err == nil {
+		t.Error("Verify accepted a bundle relabeled to another party")
+	}
+
+	if _, err := backup.Backup(ctx, &backup.Params{
+		PVE: pveInstance, Key: keys[0], Parties: []string{"p1"}, Policy: policy,
+	}); err == nil {
+		t.Error("Backup accepted Parties without the key's party")
+	}
+}
+
+func TestVerifyBundleRejects(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	names := []string{"p0", "p1", "p2"}
+	keys := dkg(t, ctx, names)
+	otherKeys := dkg(t, ctx, names)
+	kem := testkem.NewToyRSAKEM(2048)
+	pveInstance, err := pve.New(kem)
+	if err != nil {
+		t.Fatalf("pve.New: %v", err)
+	}
+	policy, _ := custodians(t, kem)
+	pub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey: %v", err)
+	}
+	otherPub, err := otherKeys[0].PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey: %v", err)
+	}
+
+	newBundle := func() *backup.Bundle {
+		b, err := backup.Backup(ctx, &backup.Params{
+			PVE: pveInstance, Key: keys[0], Parties: names, Policy: policy,
+		})
+		if err != nil {
+			t.Fatalf("Backup: %v", err)
+		}
+		return b
+	}
+
+	b := newBundle()
+	if err := backup.VerifyBundle(ctx, pveInstance, b, otherPub); !errors.Is(err, backup.ErrPublicKeyMismatch) {
+		t.Errorf("VerifyBundle with another key: err = %v, want ErrPublicKeyMismatch", err)
+	}
+
+	// Replacing another party's public share breaks the sum.
+	b.Manifest.PublicShares[2] = b.Manifest.PublicShares[1]
+	if err := backup.VerifyBundle(ctx, pveInstance, b, pub); !errors.Is(err, backup.ErrPublicKeyMismatch) {
+		t.Errorf("VerifyBundle with a forged public share: err = %v, want ErrPublicKeyMismatch", err)
+	}
+
+	// Moving the ciphertext to the other key's manifest keeps the public
+	// shares consistent but not the ciphertext's proof.
+	other, err := backup.Backup(ctx, &backup.Params{
+		PVE: pveInstance, Key: otherKeys[0], Parties: names, Policy: policy,
+	})
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	other.Ciphertext = newBundle().Ciphertext
+	sum := sha256.Sum256(other.Ciphertext)
+	other.Manifest.CiphertextDigest = sum[:]
+	b = other
+	if err := backup.VerifyBundle(ctx, pveInstance, b, otherPub); err == nil {
+		t.Error("VerifyBundle accepted a bundle moved to another key")
+	}
+}
+
+// dkg2p runs ecdsa2p.DKG and returns both key shares.
+func dkg2p(t *testing.T, ctx context.Context) [2]*ecdsa2p.Key {
+	t.Helper()
+	net := mocknet.New()
+	var (
+		wg   sync.WaitGroup
+		keys [2]*ecdsa2p.Key
+		errs [2]error
+	)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(party int) {
+			defer wg.Done()
+			role := cbmpc.RoleP1
+			if party == 1 {
+				role = cbmpc.RoleP2
+			}
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(party), cbmpc.RoleID(1-party)), role, [2]string{"p1", "p2"})
+			if err != nil {
+				errs[party] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
+			if err == nil {
+				keys[party] = res.Key
+			}
+			errs[party] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d DKG failed: %v", i, err)
+		}
+	}
+	t.Cleanup(func() {
+		_ = keys[0].Close()
+		_ = keys[1].Close()
+	})
+	return keys
+}
+
+func TestBackupFull(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	keys := dkg2p(t, ctx)
+	kem := testkem.NewToyRSAKEM(2048)
+	pveInstance, err := pve.New(kem)
+	if err != nil {
+		t.Fatalf("pve.New: %v", err)
+	}
+	sk, ek, err := kem.Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	dk, err := kem.NewPrivateKeyHandle(sk)
+	if err != nil {
+		t.Fatalf("NewPrivateKeyHandle: %v", err)
+	}
+	defer kem.FreePrivateKeyHandle(dk)
+	_, otherEK, err := kem.Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	sink := backup.DirSink(t.TempDir())
+
+	b, err := backup.BackupFull(ctx, &backup.FullParams{PVE: pveInstance, Key: keys[1], EK: ek, Sink: sink})
+	if err != nil {
+		t.Fatalf("BackupFull: %v", err)
+	}
+	if b.Manifest.Protocol != "ecdsa2p" || b.Manifest.Role != "p2" {
+		t.Errorf("manifest = %+v", b.Manifest)
+	}
+	loaded, err := backup.LoadFull(ctx, sink, b.Name())
+	if err != nil {
+		t.Fatalf("LoadFull: %v", err)
+	}
+	if err := backup.VerifyFull(ctx, pveInstance, loaded, ek); err != nil {
+		t.Fatalf("VerifyFull: %v", err)
+	}
+	if err := backup.VerifyFull(ctx, pveInstance, loaded, otherEK); !errors.Is(err, backup.ErrPolicyMismatch) {
+		t.Errorf("VerifyFull with another key: err = %v, want ErrPolicyMismatch", err)
+	}
+
+	buf, err := backup.RestoreFull(ctx, pveInstance, loaded, dk)
+	if err != nil {
+		t.Fatalf("RestoreFull: %v", err)
+	}
+	defer buf.Destroy()
+	want, err := keys[1].Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatal("restored key share differs from the original")
+	}
+	restored, err := backup.LoadKeyShare(loaded.Manifest.Protocol, buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadKeyShare: %v", err)
+	}
+	defer restored.Close()
+	if _, ok := restored.(*ecdsa2p.Key); !ok {
+		t.Errorf("LoadKeyShare returned %T, want *ecdsa2p.Key", restored)
+	}
+}
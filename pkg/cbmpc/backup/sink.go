@@ -0,0 +1,82 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound is returned by Sink.Get when no bundle is stored under a name.
+var ErrNotFound = errors.New("backup: bundle not found")
+
+// Sink stores encoded bundles by name. Backup writes to it and Load reads
+// from it; implementations for object stores or vaults only need these two
+// calls. Get must return an error wrapping ErrNotFound for unknown names.
+type Sink interface {
+	Put(ctx context.Context, name string, data []byte) error
+	Get(ctx context.Context, name string) ([]byte, error)
+}
+
+type dirSink struct{ dir string }
+
+// DirSink returns a Sink that stores each bundle as a file in dir. Files are
+// written to a temporary name, synced and renamed, so a crash never leaves a
+// truncated bundle behind.
+func DirSink(dir string) Sink { return dirSink{dir: dir} }
+
+func (s dirSink) path(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("backup: invalid bundle name %q", name)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+func (s dirSink) Put(ctx context.Context, name string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.dir, ".tmp-"+name+"-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, 0o600)
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func (s dirSink) Get(ctx context.Context, name string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- name is checked to stay in dir
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return data, err
+}
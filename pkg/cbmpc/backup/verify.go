@@ -0,0 +1,172 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secretsharing"
+)
+
+// ErrPublicKeyMismatch is returned by VerifyBundle when a bundle is not a
+// backup of a share of the expected public key.
+var ErrPublicKeyMismatch = errors.New("backup: bundle does not match the public key")
+
+// VerifyBundle checks b for an auditor that knows only the wallet's public
+// key: that the bundle is for expectedPublicKey, that the public shares in
+// its manifest combine to that key, and that the ciphertext's proofs show it
+// encrypts the discrete log of the party's public share to the policy in the
+// manifest. It needs no decryption keys, so an audit service can run it on
+// every stored bundle on a schedule.
+//
+// Public shares of additive keys must add up to the public key; those of
+// threshold keys, whose manifest records a Quorum, must interpolate to it in
+// the order of Parties. Keys from ecdsamp.ThresholdDKG record no quorum and
+// cannot be checked this way. The public shares come from the bundle itself,
+// so compare them across the bundles of all parties of a key to rule out a
+// forged set; Verify additionally checks the policy.
+func VerifyBundle(ctx context.Context, p *pve.PVE, b *Bundle, expectedPublicKey []byte) error {
+	if p == nil {
+		return errors.New("backup: nil PVE")
+	}
+	if b == nil {
+		return errors.New("backup: nil bundle")
+	}
+	if err := b.check(); err != nil {
+		return err
+	}
+	m := &b.Manifest
+	want, err := curve.NewPointFromBytes(m.Curve, expectedPublicKey)
+	if err != nil {
+		return fmt.Errorf("backup: expected public key: %w", err)
+	}
+	defer want.Free()
+	if ok, err := hasEncoding(want, m.PublicKey); err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("%w: bundle is for %s", ErrPublicKeyMismatch, m.Fingerprint)
+	}
+	if err := checkPublicShares(m, want); err != nil {
+		return err
+	}
+	return verifyCiphertext(ctx, p, b, m.policy())
+}
+
+// checkPublicShares reports whether the manifest's public shares combine to
+// the public key q.
+func checkPublicShares(m *Manifest, q *curve.Point) error {
+	if m.Quorum == 0 {
+		ok, err := combinesTo(m, nil, q)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: public shares do not add up to the public key", ErrPublicKeyMismatch)
+		}
+		return nil
+	}
+
+	// Shares 1..t-1 and any one other share determine the polynomial, so
+	// every share is on it iff each such set interpolates to q.
+	t := m.Quorum
+	if t > len(m.PublicShares) {
+		return fmt.Errorf("%w: quorum %d exceeds %d parties", ErrMalformed, t, len(m.PublicShares))
+	}
+	indices := make([]int, t)
+	for i := range t - 1 {
+		indices[i] = i + 1
+	}
+	for j := t; j <= len(m.PublicShares); j++ {
+		indices[t-1] = j
+		ok, err := combinesTo(m, indices, q)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: public share of %q is not on the key's sharing polynomial", ErrPublicKeyMismatch, m.Parties[j-1])
+		}
+	}
+	return nil
+}
+
+// combinesTo reports whether the public shares at the 1-based indices,
+// weighted by their Lagrange coefficients, add up to q. Nil indices means
+// every share with weight one.
+func combinesTo(m *Manifest, indices []int, q *curve.Point) (bool, error) {
+	if indices == nil {
+		indices = make([]int, len(m.PublicShares))
+		for i := range indices {
+			indices[i] = i + 1
+		}
+	} else if len(indices) == 0 {
+		return false, nil
+	}
+	weighted := m.Quorum != 0
+
+	var sum *curve.Point
+	defer func() {
+		if sum != nil {
+			sum.Free()
+		}
+	}()
+	for _, idx := range indices {
+		p, err := curve.NewPointFromBytes(m.Curve, m.PublicShares[idx-1])
+		if err != nil {
+			return false, fmt.Errorf("%w: public share of %q: %v", ErrMalformed, m.Parties[idx-1], err)
+		}
+		if weighted {
+			lambda, err := secretsharing.LagrangeCoefficient(m.Curve, indices, idx)
+			if err != nil {
+				p.Free()
+				return false, err
+			}
+			wp, err := p.Mul(lambda)
+			lambda.Free()
+			p.Free()
+			if err != nil {
+				return false, err
+			}
+			p = wp
+		}
+		if sum == nil {
+			sum = p
+			continue
+		}
+		next, err := sum.Add(p)
+		p.Free()
+		if err != nil {
+			return false, err
+		}
+		sum.Free()
+		sum = next
+	}
+	if sum == nil {
+		return false, nil
+	}
+	got, err := sum.Bytes()
+	if err != nil {
+		return false, err
+	}
+	return hasEncoding(q, got)
+}
+
+// hasEncoding reports whether p and the encoded point raw are the same point.
+func hasEncoding(p *curve.Point, raw []byte) (bool, error) {
+	other, err := curve.NewPointFromBytes(p.Curve(), raw)
+	if err != nil {
+		return false, err
+	}
+	defer other.Free()
+	a, err := p.Bytes()
+	if err != nil {
+		return false, err
+	}
+	b, err := other.Bytes()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(a, b), nil
+}
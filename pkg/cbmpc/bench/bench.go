@@ -0,0 +1,434 @@
+package bench
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/rsa"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorr2p"
+)
+
+// Operation names a measured operation.
+type Operation string
+
+// Measured operations.
+const (
+	OpDKG        Operation = "dkg"         // 2-party key generation
+	OpSign       Operation = "sign"        // 2-party signature over one message
+	OpSignBatch  Operation = "sign-batch"  // 2-party signature over BatchSize messages
+	OpPVEEncrypt Operation = "pve-encrypt" // PVE encryption of one scalar
+	OpPVEVerify  Operation = "pve-verify"  // PVE ciphertext verification
+	OpPVEDecrypt Operation = "pve-decrypt" // PVE decryption of one scalar
+)
+
+// Operations lists every operation in the order the CLI reports them.
+var Operations = []Operation{OpDKG, OpSign, OpSignBatch, OpPVEEncrypt, OpPVEVerify, OpPVEDecrypt}
+
+// Connector returns the transports of party P1 and P2, connected to each
+// other. The closer, which may be nil, is closed when the run ends.
+type Connector func(ctx context.Context) ([2]cbmpc.Transport, io.Closer, error)
+
+// Config configures a Run.
+type Config struct {
+	Op    Operation
+	Curve cbmpc.Curve
+	// Iterations is the number of timed runs. Zero means 10.
+	Iterations int
+	// Warmup is the number of untimed runs before the timed ones.
+	Warmup int
+	// BatchSize is the number of messages signed per OpSignBatch run. Zero
+	// means 16.
+	BatchSize int
+	// KEMBits is the RSA modulus size of the PVE encryption key. Zero means
+	// 2048.
+	KEMBits int
+	// Connect creates the transports. Nil means mocknet.
+	Connect Connector
+}
+
+func (c *Config) defaults() error {
+	if !slices.Contains(Operations, c.Op) {
+		return fmt.Errorf("unknown operation %q", c.Op)
+	}
+	if c.Curve == cbmpc.CurveUnknown {
+		return errors.New("curve is required")
+	}
+	if c.Iterations < 0 || c.Warmup < 0 || c.BatchSize < 0 {
+		return errors.New("iterations, warmup and batch size must not be negative")
+	}
+	if c.Iterations == 0 {
+		c.Iterations = 10
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = 16
+	}
+	if c.KEMBits == 0 {
+		c.KEMBits = 2048
+	}
+	return nil
+}
+
+// Result holds the timings of a Run.
+type Result struct {
+	Op    Operation
+	Curve cbmpc.Curve
+	// Items is the number of items processed per run: BatchSize for
+	// OpSignBatch and 1 otherwise.
+	Items int
+	// Samples are the per-run latencies in ascending order.
+	Samples []time.Duration
+	// Elapsed is the wall-clock time of all timed runs.
+	Elapsed time.Duration
+}
+
+// Percentile returns the p-th percentile latency (0 < p <= 100) using the
+// nearest-rank method.
+func (r *Result) Percentile(p float64) time.Duration {
+	if len(r.Samples) == 0 || p <= 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p * float64(len(r.Samples)) / 100))
+	return r.Samples[min(rank, len(r.Samples))-1]
+}
+
+// Mean returns the mean latency.
+func (r *Result) Mean() time.Duration {
+	if len(r.Samples) == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, s := range r.Samples {
+		sum += s
+	}
+	return sum / time.Duration(len(r.Samples))
+}
+
+// Throughput returns items processed per second.
+func (r *Result) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Items*len(r.Samples)) / r.Elapsed.Seconds()
+}
+
+func (r *Result) String() string {
+	return fmt.Sprintf("%s %s n=%d p50=%s p90=%s p99=%s %.1f op/s",
+		r.Op, r.Curve, len(r.Samples),
+		r.Percentile(50).Round(time.Microsecond),
+		r.Percentile(90).Round(time.Microsecond),
+		r.Percentile(99).Round(time.Microsecond),
+		r.Throughput())
+}
+
+// runner performs one run of the operation.
+type runner interface {
+	run(ctx context.Context, i int) error
+	close()
+}
+
+// Run measures cfg.Op. Setup, such as the DKG preceding OpSign, is not timed.
+func Run(ctx context.Context, cfg Config) (*Result, error) {
+	if err := cfg.defaults(); err != nil {
+		return nil, err
+	}
+	r, err := newRunner(ctx, &cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer r.close()
+
+	for i := 0; i < cfg.Warmup; i++ {
+		if err := r.run(ctx, i); err != nil {
+			return nil, fmt.Errorf("warmup: %w", err)
+		}
+	}
+	res := &Result{Op: cfg.Op, Curve: cfg.Curve, Items: 1, Samples: make([]time.Duration, 0, cfg.Iterations)}
+	if cfg.Op == OpSignBatch {
+		res.Items = cfg.BatchSize
+	}
+	start := time.Now()
+	for i := 0; i < cfg.Iterations; i++ {
+		t := time.Now()
+		if err := r.run(ctx, cfg.Warmup+i); err != nil {
+			return nil, err
+		}
+		res.Samples = append(res.Samples, time.Since(t))
+	}
+	res.Elapsed = time.Since(start)
+	slices.Sort(res.Samples)
+	return res, nil
+}
+
+func newRunner(ctx context.Context, cfg *Config) (runner, error) {
+	switch cfg.Op {
+	case OpPVEEncrypt, OpPVEVerify, OpPVEDecrypt:
+		return newPVERunner(ctx, cfg)
+	}
+	p, err := connect(ctx, cfg.Connect)
+	if err != nil {
+		return nil, err
+	}
+	r := &protocolRunner{pair: p, cfg: cfg}
+	if cfg.Op != OpDKG {
+		if err := r.dkg(ctx); err != nil {
+			r.close()
+			return nil, fmt.Errorf("setup: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// pair is the two jobs of an in-process 2-party run.
+type pair struct {
+	jobs   [2]*cbmpc.Job2P
+	closer io.Closer
+}
+
+func mocknetConnector(context.Context) ([2]cbmpc.Transport, io.Closer, error) {
+	net := mocknet.New()
+	return [2]cbmpc.Transport{
+		net.Ep2P(cbmpc.RoleID(cbmpc.RoleP1), cbmpc.RoleID(cbmpc.RoleP2)),
+		net.Ep2P(cbmpc.RoleID(cbmpc.RoleP2), cbmpc.RoleID(cbmpc.RoleP1)),
+	}, nil, nil
+}
+
+func connect(ctx context.Context, c Connector) (*pair, error) {
+	if c == nil {
+		c = mocknetConnector
+	}
+	ts, closer, err := c(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	p := &pair{closer: closer}
+	names := [2]string{"bench-p1", "bench-p2"}
+	for i, role := range []cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2} {
+		if p.jobs[i], err = cbmpc.NewJob2PWithContext(ctx, ts[i], role, names); err != nil {
+			p.close()
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (p *pair) close() {
+	for _, j := range p.jobs {
+		if j != nil {
+			_ = j.Close()
+		}
+	}
+	if p.closer != nil {
+		_ = p.closer.Close()
+	}
+}
+
+// both runs f for both parties concurrently and returns the first error.
+func (p *pair) both(ctx context.Context, f func(ctx context.Context, party int, job *cbmpc.Job2P) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg   sync.WaitGroup
+		errs [2]error
+	)
+	for i := range p.jobs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if errs[i] = f(ctx, i, p.jobs[i]); errs[i] != nil {
+				cancel() // unblock the peer
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protocolRunner measures the 2-party operations. ECDSA curves use ecdsa2p
+// and Ed25519 uses schnorr2p.
+type protocolRunner struct {
+	*pair
+	cfg         *Config
+	ecdsaKeys   [2]*ecdsa2p.Key
+	schnorrKeys [2]*schnorr2p.Key
+}
+
+func (r *protocolRunner) eddsa() bool { return r.cfg.Curve == cbmpc.CurveEd25519 }
+
+// dkg generates the keys used by the signing operations.
+func (r *protocolRunner) dkg(ctx context.Context) error {
+	return r.both(ctx, func(ctx context.Context, i int, job *cbmpc.Job2P) error {
+		if r.eddsa() {
+			res, err := schnorr2p.DKG(ctx, job, &schnorr2p.DKGParams{Curve: r.cfg.Curve})
+			if err != nil {
+				return err
+			}
+			r.schnorrKeys[i] = res.Key
+			return nil
+		}
+		res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: r.cfg.Curve})
+		if err != nil {
+			return err
+		}
+		r.ecdsaKeys[i] = res.Key
+		return nil
+	})
+}
+
+// message returns a distinct digest for run n.
+func message(n int) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(n))
+	sum := sha256.Sum256(b[:])
+	return sum[:]
+}
+
+func (r *protocolRunner) run(ctx context.Context, n int) error {
+	switch r.cfg.Op {
+	case OpDKG:
+		return r.both(ctx, func(ctx context.Context, _ int, job *cbmpc.Job2P) error {
+			if r.eddsa() {
+				res, err := schnorr2p.DKG(ctx, job, &schnorr2p.DKGParams{Curve: r.cfg.Curve})
+				if err != nil {
+					return err
+				}
+				return res.Key.Close()
+			}
+			res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: r.cfg.Curve})
+			if err != nil {
+				return err
+			}
+			return res.Key.Close()
+		})
+	case OpSign:
+		msg := message(n)
+		return r.both(ctx, func(ctx context.Context, i int, job *cbmpc.Job2P) error {
+			if r.eddsa() {
+				_, err := schnorr2p.Sign(ctx, job, &schnorr2p.SignParams{Key: r.schnorrKeys[i], Message: msg, Variant: schnorr2p.VariantEdDSA})
+				return err
+			}
+			_, err := ecdsa2p.Sign(ctx, job, &ecdsa2p.SignParams{Key: r.ecdsaKeys[i], Message: msg})
+			return err
+		})
+	default: // OpSignBatch
+		msgs := make([][]byte, r.cfg.BatchSize)
+		for k := range msgs {
+			msgs[k] = message(n*r.cfg.BatchSize + k)
+		}
+		return r.both(ctx, func(ctx context.Context, i int, job *cbmpc.Job2P) error {
+			if r.eddsa() {
+				_, err := schnorr2p.SignBatch(ctx, job, &schnorr2p.SignBatchParams{Key: r.schnorrKeys[i], Messages: msgs, Variant: schnorr2p.VariantEdDSA})
+				return err
+			}
+			_, err := ecdsa2p.SignBatch(ctx, job, &ecdsa2p.SignBatchParams{Key: r.ecdsaKeys[i], Messages: msgs})
+			return err
+		})
+	}
+}
+
+func (r *protocolRunner) close() {
+	for i := range 2 {
+		if r.ecdsaKeys[i] != nil {
+			_ = r.ecdsaKeys[i].Close()
+		}
+		if r.schnorrKeys[i] != nil {
+			_ = r.schnorrKeys[i].Close()
+		}
+	}
+	r.pair.close()
+}
+
+var pveLabel = []byte("cbmpc-bench")
+
+// pveRunner measures PVE with an RSA KEM. Verify and decrypt reuse one
+// ciphertext produced during setup.
+type pveRunner struct {
+	cfg *Config
+	kem *rsa.KEM
+	pve *pve.PVE
+	ek  []byte
+	dk  any
+	x   *curve.Scalar
+	ct  pve.Ciphertext
+	q   *cbmpc.CurvePoint
+}
+
+func newPVERunner(ctx context.Context, cfg *Config) (*pveRunner, error) {
+	k, err := rsa.New(cfg.KEMBits)
+	if err != nil {
+		return nil, err
+	}
+	p, err := pve.New(k)
+	if err != nil {
+		return nil, err
+	}
+	dkRef, ek, err := k.Generate()
+	if err != nil {
+		return nil, err
+	}
+	defer cbmpc.ZeroizeBytes(dkRef)
+	dk, err := k.NewPrivateKeyHandle(dkRef)
+	if err != nil {
+		return nil, err
+	}
+	r := &pveRunner{cfg: cfg, kem: k, pve: p, ek: ek, dk: dk}
+	if r.x, err = curve.RandomScalar(cfg.Curve); err != nil {
+		r.close()
+		return nil, err
+	}
+	res, err := p.Encrypt(ctx, &pve.EncryptParams{EK: ek, Label: pveLabel, Curve: cfg.Curve, X: r.x})
+	if err != nil {
+		r.close()
+		return nil, fmt.Errorf("setup: %w", err)
+	}
+	r.ct = res.Ciphertext
+	if r.q, err = r.ct.Q(); err != nil {
+		r.close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *pveRunner) run(ctx context.Context, _ int) error {
+	switch r.cfg.Op {
+	case OpPVEEncrypt:
+		_, err := r.pve.Encrypt(ctx, &pve.EncryptParams{EK: r.ek, Label: pveLabel, Curve: r.cfg.Curve, X: r.x})
+		return err
+	case OpPVEVerify:
+		return r.pve.Verify(ctx, &pve.VerifyParams{EK: r.ek, Ciphertext: r.ct, Q: r.q, Label: pveLabel})
+	default: // OpPVEDecrypt
+		res, err := r.pve.Decrypt(ctx, &pve.DecryptParams{DK: r.dk, EK: r.ek, Ciphertext: r.ct, Label: pveLabel, Curve: r.cfg.Curve})
+		if err != nil {
+			return err
+		}
+		res.X.Free()
+		return nil
+	}
+}
+
+func (r *pveRunner) close() {
+	if r.q != nil {
+		r.q.Free()
+	}
+	if r.x != nil {
+		r.x.Free()
+	}
+	_ = r.kem.FreePrivateKeyHandle(r.dk)
+}
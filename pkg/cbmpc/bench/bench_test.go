@@ -0,0 +1,71 @@
+package bench_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/bench"
+)
+
+func TestResultStatistics(t *testing.T) {
+	r := &bench.Result{Items: 4, Elapsed: 2 * time.Second}
+	for i := 1; i <= 10; i++ {
+		r.Samples = append(r.Samples, time.Duration(i)*time.Millisecond)
+	}
+	cases := map[float64]time.Duration{
+		50:  5 * time.Millisecond,
+		90:  9 * time.Millisecond,
+		99:  10 * time.Millisecond,
+		100: 10 * time.Millisecond,
+		1:   1 * time.Millisecond,
+	}
+	for p, want := range cases {
+		if got := r.Percentile(p); got != want {
+			t.Errorf("Percentile(%v) = %v, want %v", p, got, want)
+		}
+	}
+	if got := r.Mean(); got != 5500*time.Microsecond {
+		t.Errorf("Mean() = %v", got)
+	}
+	if got := r.Throughput(); got != 20 {
+		t.Errorf("Throughput() = %v, want 20", got)
+	}
+
+	var empty bench.Result
+	if empty.Percentile(50) != 0 || empty.Mean() != 0 || empty.Throughput() != 0 {
+		t.Error("empty result should report zeros")
+	}
+}
+
+func TestRunRejectsBadConfig(t *testing.T) {
+	ctx := context.Background()
+	if _, err := bench.Run(ctx, bench.Config{Op: "keygen", Curve: cbmpc.CurveP256}); err == nil {
+		t.Error("expected unknown operation to be rejected")
+	}
+	if _, err := bench.Run(ctx, bench.Config{Op: bench.OpSign}); err == nil {
+		t.Error("expected missing curve to be rejected")
+	}
+	if _, err := bench.Run(ctx, bench.Config{Op: bench.OpSign, Curve: cbmpc.CurveP256, Iterations: -1}); err == nil {
+		t.Error("expected negative iterations to be rejected")
+	}
+}
+
+func TestRunMocknet(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	for _, curve := range []cbmpc.Curve{cbmpc.CurveSecp256k1, cbmpc.CurveEd25519} {
+		for _, op := range []bench.Operation{bench.OpSign, bench.OpSignBatch, bench.OpPVEDecrypt} {
+			res, err := bench.Run(ctx, bench.Config{Op: op, Curve: curve, Iterations: 3, Warmup: 1, BatchSize: 4})
+			if err != nil {
+				t.Fatalf("%s %s: %v", op, curve, err)
+			}
+			if len(res.Samples) != 3 {
+				t.Fatalf("%s %s: %d samples, want 3", op, curve, len(res.Samples))
+			}
+			t.Log(res)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Package bench measures protocol latency and throughput for capacity
+// planning.
+//
+// Run executes one operation repeatedly between two in-process parties and
+// reports per-run latencies with percentiles:
+//
+//	res, err := bench.Run(ctx, bench.Config{
+//	    Op:         bench.OpSign,
+//	    Curve:      cbmpc.CurveSecp256k1,
+//	    Iterations: 100,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(res) // sign secp256k1 n=100 p50=... p90=... p99=... 312.4 op/s
+//
+// Two-party operations run over mocknet by default, which isolates the cost
+// of the cryptography. Set Config.Connect to measure a real transport, for
+// example two tlsnet endpoints on loopback; the cost of connecting is not
+// included in the timings. PVE operations are local to one party and ignore
+// the transport.
+//
+// ECDSA curves (P-256, P-384, P-521, secp256k1) are measured with ecdsa2p and
+// Ed25519 with schnorr2p, so results can be compared across curves.
+package bench
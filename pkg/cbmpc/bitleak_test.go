@@ -0,0 +1,36 @@
+package cbmpc_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+type closer struct{}
+
+func (closer) Close() error { return nil }
+
+func TestBitLeakError(t *testing.T) {
+	var err error = &cbmpc.BitLeakError{Protocol: "ecdsa2p.SignWithGlobalAbort", Fingerprint: "fp", MustRefresh: true}
+	if !errors.Is(err, cbmpc.ErrBitLeak) {
+		t.Fatal("BitLeakError does not match ErrBitLeak")
+	}
+	var ble *cbmpc.BitLeakError
+	if !errors.As(err, &ble) || !ble.MustRefresh {
+		t.Fatalf("errors.As = %+v", ble)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "ecdsa2p.SignWithGlobalAbort") || !strings.Contains(msg, "fp") {
+		t.Fatalf("Error() = %q, want protocol and fingerprint", msg)
+	}
+
+	refreshed := &cbmpc.BitLeakError{Protocol: "ecdsamp.SignWithGlobalAbort", RefreshedKey: closer{}}
+	if !strings.Contains(refreshed.Error(), "key refreshed") {
+		t.Fatalf("Error() = %q, want refresh noted", refreshed.Error())
+	}
+	failed := &cbmpc.BitLeakError{Protocol: "ecdsamp.SignWithGlobalAbort", MustRefresh: true, RefreshErr: errors.New("peer gone")}
+	if !strings.Contains(failed.Error(), "refresh failed: peer gone") {
+		t.Fatalf("Error() = %q, want refresh error", failed.Error())
+	}
+}
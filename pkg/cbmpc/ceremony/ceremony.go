@@ -0,0 +1,320 @@
+package ceremony
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/backup"
+)
+
+// Version is the checkpoint and transcript format version.
+const Version = 1
+
+// Names of the usual ceremony steps, in the order they are usually run. Steps
+// may use any name; these only keep transcripts of different deployments
+// comparable.
+const (
+	StepDKG      = "dkg"
+	StepBackup   = "backup"
+	StepVerify   = "verify"
+	StepTestSign = "test-sign"
+	StepReport   = "report"
+)
+
+// ErrMismatch is returned when a checkpoint or transcript belongs to another
+// ceremony, party or list of steps, and by Compare when parties disagree.
+var ErrMismatch = errors.New("ceremony: mismatch")
+
+// Step is one stage of a ceremony. Run does the work, keeping whatever later
+// steps need in the State and recording its results for the transcript. A
+// step that returns an error leaves no trace in the State and is run again on
+// the next Run.
+type Step struct {
+	Name string
+	Run  func(ctx context.Context, s *State) error
+}
+
+// StepError reports the step at which Run stopped.
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string { return fmt.Sprintf("ceremony: step %s: %v", e.Step, e.Err) }
+
+func (e *StepError) Unwrap() error { return e.Err }
+
+// Config describes the local party's part in a ceremony.
+type Config struct {
+	// ID names the ceremony and is the same at every party.
+	ID string
+	// Party is the local party's name; it must be one of Parties.
+	Party   string
+	Parties []string
+	Steps   []Step
+	// Store keeps the checkpoint and, once every step is done, the signed
+	// transcript. The checkpoint holds the State, which may include key
+	// shares, so protect it like a key store.
+	Store backup.Sink
+	// Identity signs the transcript.
+	Identity ed25519.PrivateKey
+}
+
+// Ceremony runs the steps of one party in order, saving a checkpoint after
+// each, so that a restarted process picks up at the first step not done.
+type Ceremony struct {
+	cfg Config
+	cp  checkpoint
+}
+
+type checkpoint struct {
+	Version int               `json:"version"`
+	ID      string            `json:"id"`
+	Party   string            `json:"party"`
+	Steps   []string          `json:"steps"`
+	Entries []Entry           `json:"entries"`
+	Values  map[string][]byte `json:"values,omitempty"`
+}
+
+// New returns the ceremony described by cfg. If cfg.Store holds a checkpoint
+// for it, the ceremony resumes from there; the checkpoint must be for the same
+// ID, party and steps.
+func New(ctx context.Context, cfg Config) (*Ceremony, error) {
+	if cfg.ID == "" {
+		return nil, errors.New("ceremony: empty ID")
+	}
+	if !slices.Contains(cfg.Parties, cfg.Party) {
+		return nil, fmt.Errorf("ceremony: party %q is not one of the parties", cfg.Party)
+	}
+	if cfg.Store == nil {
+		return nil, errors.New("ceremony: nil store")
+	}
+	if len(cfg.Identity) != ed25519.PrivateKeySize {
+		return nil, errors.New("ceremony: invalid identity key")
+	}
+	if len(cfg.Steps) == 0 {
+		return nil, errors.New("ceremony: no steps")
+	}
+	names := make([]string, len(cfg.Steps))
+	for i, s := range cfg.Steps {
+		if s.Name == "" || s.Run == nil {
+			return nil, fmt.Errorf("ceremony: step %d has no name or Run", i)
+		}
+		if slices.Contains(names[:i], s.Name) {
+			return nil, fmt.Errorf("ceremony: duplicate step %q", s.Name)
+		}
+		names[i] = s.Name
+	}
+
+	c := &Ceremony{cfg: cfg, cp: checkpoint{Version: Version, ID: cfg.ID, Party: cfg.Party, Steps: names}}
+	data, err := cfg.Store.Get(ctx, c.checkpointName())
+	switch {
+	case errors.Is(err, backup.ErrNotFound):
+		return c, nil
+	case err != nil:
+		return nil, fmt.Errorf("ceremony: load checkpoint: %w", err)
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("ceremony: checkpoint: %w", err)
+	}
+	switch {
+	case cp.Version != Version:
+		return nil, fmt.Errorf("ceremony: checkpoint version %d, this library reads %d", cp.Version, Version)
+	case cp.ID != cfg.ID || cp.Party != cfg.Party || !slices.Equal(cp.Steps, names):
+		return nil, fmt.Errorf("%w: checkpoint is for ceremony %q, party %q, steps %v", ErrMismatch, cp.ID, cp.Party, cp.Steps)
+	case len(cp.Entries) > len(names):
+		return nil, fmt.Errorf("ceremony: checkpoint has %d entries for %d steps", len(cp.Entries), len(names))
+	}
+	if err := verifyChain(cp.Entries, names); err != nil {
+		return nil, err
+	}
+	c.cp = cp
+	return c, nil
+}
+
+func (c *Ceremony) checkpointName() string {
+	return url.PathEscape(c.cfg.ID) + "-" + url.PathEscape(c.cfg.Party) + ".checkpoint.json"
+}
+
+// TranscriptName returns the name under which Run stores the transcript.
+func (c *Ceremony) TranscriptName() string {
+	return url.PathEscape(c.cfg.ID) + "-" + url.PathEscape(c.cfg.Party) + ".transcript.json"
+}
+
+// Next returns the name of the next step to run, or "" when all are done.
+func (c *Ceremony) Next() string {
+	if n := len(c.cp.Entries); n < len(c.cp.Steps) {
+		return c.cp.Steps[n]
+	}
+	return ""
+}
+
+// Done reports whether every step has completed.
+func (c *Ceremony) Done() bool { return c.Next() == "" }
+
+// Run runs the remaining steps in order, checkpointing after each. It stops at
+// the first failing step and returns a *StepError; calling Run again, in this
+// process or after a restart, retries that step. Once every step is done it
+// signs the transcript, stores it and returns it.
+func (c *Ceremony) Run(ctx context.Context) (*Transcript, error) {
+	for !c.Done() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		step := c.cfg.Steps[len(c.cp.Entries)]
+		s := &State{values: maps.Clone(c.cp.Values), entries: c.cp.Entries}
+		if s.values == nil {
+			s.values = make(map[string][]byte)
+		}
+		started := time.Now().UTC()
+		if err := step.Run(ctx, s); err != nil {
+			return nil, &StepError{Step: step.Name, Err: err}
+		}
+		e := Entry{
+			Step:     step.Name,
+			Started:  started,
+			Finished: time.Now().UTC(),
+			Outputs:  s.outputs,
+			Shared:   s.shared,
+			Prev:     genesis,
+		}
+		if n := len(c.cp.Entries); n > 0 {
+			e.Prev = c.cp.Entries[n-1].Hash
+		}
+		hash, err := e.digest()
+		if err != nil {
+			return nil, err
+		}
+		e.Hash = hash
+
+		next := c.cp
+		next.Entries = append(slices.Clip(c.cp.Entries), e)
+		next.Values = s.values
+		if err := c.save(ctx, &next); err != nil {
+			return nil, &StepError{Step: step.Name, Err: err}
+		}
+		c.cp = next
+	}
+	return c.finish(ctx)
+}
+
+// Rewind discards the checkpoint from step onward, so the next Run starts
+// there. Protocol steps such as a DKG need every party to run them together:
+// when one party has checkpointed a step that another must repeat, both
+// rewind to it. Values set by the discarded steps are kept until overwritten.
+func (c *Ceremony) Rewind(ctx context.Context, step string) error {
+	i := slices.Index(c.cp.Steps, step)
+	if i < 0 {
+		return fmt.Errorf("ceremony: unknown step %q", step)
+	}
+	if i >= len(c.cp.Entries) {
+		return nil
+	}
+	next := c.cp
+	next.Entries = slices.Clone(c.cp.Entries[:i])
+	if err := c.save(ctx, &next); err != nil {
+		return err
+	}
+	c.cp = next
+	return nil
+}
+
+// Transcript returns the entries of the steps completed so far, unsigned.
+func (c *Ceremony) Transcript() *Transcript {
+	return &Transcript{
+		Version: Version,
+		ID:      c.cp.ID,
+		Party:   c.cp.Party,
+		Parties: slices.Clone(c.cfg.Parties),
+		Steps:   slices.Clone(c.cp.Steps),
+		Entries: slices.Clone(c.cp.Entries),
+	}
+}
+
+func (c *Ceremony) save(ctx context.Context, cp *checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	if err := c.cfg.Store.Put(ctx, c.checkpointName(), data); err != nil {
+		return fmt.Errorf("ceremony: save checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (c *Ceremony) finish(ctx context.Context) (*Transcript, error) {
+	t := c.Transcript()
+	if err := t.sign(c.cfg.Identity); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.cfg.Store.Put(ctx, c.TranscriptName(), data); err != nil {
+		return nil, fmt.Errorf("ceremony: store transcript: %w", err)
+	}
+	return t, nil
+}
+
+// State is what steps share. Values persist across steps and restarts in the
+// checkpoint and never reach the transcript; outputs are recorded in the
+// transcript entry of the step that sets them.
+type State struct {
+	values  map[string][]byte
+	entries []Entry
+	outputs map[string]string
+	shared  map[string]string
+}
+
+// Get returns the value stored under key by an earlier step.
+func (s *State) Get(key string) ([]byte, bool) {
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// Put stores a value for later steps, for example a serialized key share.
+func (s *State) Put(key string, value []byte) {
+	s.values[key] = slices.Clone(value)
+}
+
+// Record adds a party-specific output to the step's transcript entry, such
+// as the name of this party's backup bundle.
+func (s *State) Record(key, value string) {
+	if s.outputs == nil {
+		s.outputs = make(map[string]string)
+	}
+	s.outputs[key] = value
+}
+
+// RecordShared adds an output every party must agree on, such as the public
+// key fingerprint. Compare checks these across the parties' transcripts.
+func (s *State) RecordShared(key, value string) {
+	if s.shared == nil {
+		s.shared = make(map[string]string)
+	}
+	s.shared[key] = value
+}
+
+// Output returns an output recorded by an earlier step, shared or not.
+func (s *State) Output(step, key string) (string, bool) {
+	for _, e := range s.entries {
+		if e.Step != step {
+			continue
+		}
+		if v, ok := e.Shared[key]; ok {
+			return v, true
+		}
+		v, ok := e.Outputs[key]
+		return v, ok
+	}
+	return "", false
+}
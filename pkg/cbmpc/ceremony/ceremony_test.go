@@ -0,0 +1,203 @@
+package ceremony_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/backup"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ceremony"
+)
+
+func identity(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv
+}
+
+// steps returns a DKG step that stores a share and records the shared
+// fingerprint, and a backup step that fails while *fail is set.
+func steps(party string, fail *bool, runs map[string]int) []ceremony.Step {
+	return []ceremony.Step{
+		{Name: ceremony.StepDKG, Run: func(_ context.Context, s *ceremony.State) error {
+			runs[ceremony.StepDKG]++
+			s.Put("share", []byte("share-of-"+party))
+			s.RecordShared("fingerprint", "fp")
+			return nil
+		}},
+		{Name: ceremony.StepBackup, Run: func(_ context.Context, s *ceremony.State) error {
+			runs[ceremony.StepBackup]++
+			share, ok := s.Get("share")
+			if !ok || string(share) != "share-of-"+party {
+				return errors.New("share missing")
+			}
+			if fp, _ := s.Output(ceremony.StepDKG, "fingerprint"); fp != "fp" {
+				return errors.New("fingerprint missing")
+			}
+			if *fail {
+				return errors.New("sink unavailable")
+			}
+			s.Record("bundle", "fp-"+party+".json")
+			return nil
+		}},
+	}
+}
+
+func TestRunResume(t *testing.T) {
+	ctx := context.Background()
+	store := backup.DirSink(t.TempDir())
+	id := identity(t)
+	fail := true
+	runs := map[string]int{}
+	cfg := ceremony.Config{
+		ID:       "c1",
+		Party:    "alice",
+		Parties:  []string{"alice", "bob"},
+		Steps:    steps("alice", &fail, runs),
+		Store:    store,
+		Identity: id,
+	}
+
+	c, err := ceremony.New(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Run(ctx)
+	var se *ceremony.StepError
+	if !errors.As(err, &se) || se.Step != ceremony.StepBackup {
+		t.Fatalf("Run: err = %v, want StepError at backup", err)
+	}
+
+	// A restarted process picks up at the failed step.
+	fail = false
+	c, err = ceremony.New(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Next() != ceremony.StepBackup {
+		t.Fatalf("Next = %q, want %q", c.Next(), ceremony.StepBackup)
+	}
+	tr, err := c.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if runs[ceremony.StepDKG] != 1 || runs[ceremony.StepBackup] != 2 {
+		t.Errorf("runs = %v, want dkg once and backup twice", runs)
+	}
+	if err := tr.Verify(id.Public().(ed25519.PublicKey)); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got := tr.Entries[1].Outputs["bundle"]; got != "fp-alice.json" {
+		t.Errorf("bundle output = %q", got)
+	}
+
+	data, err := store.Get(ctx, c.TranscriptName())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := ceremony.ParseTranscript(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stored.Verify(id.Public().(ed25519.PublicKey)); err != nil {
+		t.Fatalf("Verify stored: %v", err)
+	}
+
+	// Rewinding reruns the DKG.
+	if err := c.Rewind(ctx, ceremony.StepDKG); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if runs[ceremony.StepDKG] != 2 {
+		t.Errorf("dkg ran %d times after Rewind, want 2", runs[ceremony.StepDKG])
+	}
+
+	cfg.Steps = cfg.Steps[:1]
+	if _, err := ceremony.New(ctx, cfg); !errors.Is(err, ceremony.ErrMismatch) {
+		t.Errorf("New with other steps: err = %v, want ErrMismatch", err)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	ctx := context.Background()
+	id := identity(t)
+	fail := false
+	c, err := ceremony.New(ctx, ceremony.Config{
+		ID:       "c1",
+		Party:    "alice",
+		Parties:  []string{"alice"},
+		Steps:    steps("alice", &fail, map[string]int{}),
+		Store:    backup.DirSink(t.TempDir()),
+		Identity: id,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := c.Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := id.Public().(ed25519.PublicKey)
+
+	tampered := *tr
+	tampered.Entries = append([]ceremony.Entry(nil), tr.Entries...)
+	tampered.Entries[0].Shared = map[string]string{"fingerprint": "other"}
+	if err := tampered.Verify(pub); !errors.Is(err, ceremony.ErrBadTranscript) {
+		t.Errorf("tampered entry: err = %v, want ErrBadTranscript", err)
+	}
+	resigned := *tr
+	resigned.ID = "c2"
+	if err := resigned.Verify(pub); !errors.Is(err, ceremony.ErrBadTranscript) {
+		t.Errorf("changed ID: err = %v, want ErrBadTranscript", err)
+	}
+	if err := tr.Verify(identity(t).Public().(ed25519.PublicKey)); !errors.Is(err, ceremony.ErrBadTranscript) {
+		t.Errorf("other key: err = %v, want ErrBadTranscript", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ctx := context.Background()
+	parties := []string{"alice", "bob"}
+	run := func(party string, fp string) *ceremony.Transcript {
+		t.Helper()
+		c, err := ceremony.New(ctx, ceremony.Config{
+			ID:      "c1",
+			Party:   party,
+			Parties: parties,
+			Steps: []ceremony.Step{{Name: ceremony.StepDKG, Run: func(_ context.Context, s *ceremony.State) error {
+				s.RecordShared("fingerprint", fp)
+				s.Record("party", party)
+				return nil
+			}}},
+			Store:    backup.DirSink(t.TempDir()),
+			Identity: identity(t),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr, err := c.Run(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tr
+	}
+	alice, bob := run("alice", "fp"), run("bob", "fp")
+	if err := ceremony.Compare(alice, bob); err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+	if err := ceremony.Compare(alice, run("bob", "other")); !errors.Is(err, ceremony.ErrMismatch) {
+		t.Errorf("different fingerprint: err = %v, want ErrMismatch", err)
+	}
+	if err := ceremony.Compare(alice, alice); !errors.Is(err, ceremony.ErrMismatch) {
+		t.Errorf("duplicate party: err = %v, want ErrMismatch", err)
+	}
+	if err := ceremony.Compare(alice); !errors.Is(err, ceremony.ErrMismatch) {
+		t.Errorf("missing party: err = %v, want ErrMismatch", err)
+	}
+}
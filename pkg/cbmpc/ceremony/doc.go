@@ -0,0 +1,69 @@
+// Package ceremony runs key ceremonies: the fixed sequence of DKG, backup,
+// verification, test signature and report that every custodian wraps around
+// the protocols when it brings a new key into service.
+//
+// Each party runs its own Ceremony over the same list of Steps. A step is a
+// function that does one stage of the work, typically running a protocol over
+// a job shared with the other parties, and then records its results. After
+// each step the ceremony saves a checkpoint to a Store, so a party that
+// crashes or is stopped resumes at the first step it had not finished:
+//
+//	c, err := ceremony.New(ctx, ceremony.Config{
+//	    ID:       "wallet-2026-10",
+//	    Party:    "alice",
+//	    Parties:  []string{"alice", "bob", "carol"},
+//	    Identity: aliceIdentity, // ed25519.PrivateKey
+//	    Store:    backup.DirSink("/var/lib/mpc/ceremony"),
+//	    Steps: []ceremony.Step{
+//	        {Name: ceremony.StepDKG, Run: func(ctx context.Context, s *ceremony.State) error {
+//	            res, err := ecdsamp.DKG(ctx, job, &ecdsamp.DKGParams{Curve: cbmpc.CurveSecp256k1})
+//	            if err != nil {
+//	                return err
+//	            }
+//	            defer res.Key.Close()
+//	            share, err := res.Key.Bytes()
+//	            if err != nil {
+//	                return err
+//	            }
+//	            fp, err := res.Key.Fingerprint()
+//	            if err != nil {
+//	                return err
+//	            }
+//	            s.Put("share", share)
+//	            s.RecordShared("fingerprint", fp)
+//	            return nil
+//	        }},
+//	        {Name: ceremony.StepBackup, Run: backupStep},
+//	        // ... verify, test-sign, report
+//	    },
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	transcript, err := c.Run(ctx)
+//
+// # State and Transcript
+//
+// Steps pass data forward through the State. Values stored with Put are kept
+// only in the checkpoint, never in the transcript. Outputs recorded with
+// Record and RecordShared go into the step's transcript entry; shared outputs,
+// such as the public key fingerprint, must be the same at every party.
+//
+// When every step is done, Run signs the transcript with the party's Ed25519
+// identity key and stores it next to the checkpoint. Entries are hash-chained
+// like the records of package audit. Verify checks a transcript's chain and
+// signature, and Compare checks that the transcripts of all parties describe
+// the same ceremony with the same shared outputs. The report step usually
+// builds an attestation.Report of the DKG and records its digest.
+//
+// # Resuming
+//
+// A step that fails leaves the checkpoint as it was, and the next Run retries
+// it. Protocol steps must be retried by every party at once: if one party
+// checkpointed a DKG that another did not finish, the first must Rewind to
+// the DKG so that both run it again. Next reports each party's position.
+//
+// The checkpoint holds every value steps Put, which usually includes key
+// shares. Use a Store that is protected like a key store, and retire the
+// checkpoint once the shares are in their long-term storage.
+package ceremony
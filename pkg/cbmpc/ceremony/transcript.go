@@ -0,0 +1,168 @@
+package ceremony
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// genesis is the Prev of the first entry of a transcript.
+var genesis = strings.Repeat("0", 64)
+
+// ErrBadTranscript is returned when a transcript's chain or signature does
+// not verify.
+var ErrBadTranscript = errors.New("ceremony: invalid transcript")
+
+// Entry records one completed step. Hash is the hex SHA-256 of the entry's
+// JSON encoding with Hash empty, and Prev is the Hash of the entry before it.
+type Entry struct {
+	Step     string            `json:"step"`
+	Started  time.Time         `json:"started"`
+	Finished time.Time         `json:"finished"`
+	Outputs  map[string]string `json:"outputs,omitempty"`
+	Shared   map[string]string `json:"shared,omitempty"`
+	Prev     string            `json:"prev"`
+	Hash     string            `json:"hash"`
+}
+
+func (e *Entry) digest() (string, error) {
+	c := *e
+	c.Hash = ""
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Transcript is one party's record of a ceremony. Signature is the Ed25519
+// signature by PublicKey over the transcript's JSON encoding with Signature
+// empty.
+type Transcript struct {
+	Version   int               `json:"version"`
+	ID        string            `json:"id"`
+	Party     string            `json:"party"`
+	Parties   []string          `json:"parties"`
+	Steps     []string          `json:"steps"`
+	Entries   []Entry           `json:"entries"`
+	PublicKey ed25519.PublicKey `json:"public_key,omitempty"`
+	Signature []byte            `json:"signature,omitempty"`
+}
+
+// ParseTranscript decodes a transcript stored by Run. It does not verify it.
+func ParseTranscript(data []byte) (*Transcript, error) {
+	var t Transcript
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadTranscript, err)
+	}
+	return &t, nil
+}
+
+func (t *Transcript) signedBytes() ([]byte, error) {
+	c := *t
+	c.Signature = nil
+	return json.Marshal(c)
+}
+
+func (t *Transcript) sign(key ed25519.PrivateKey) error {
+	t.PublicKey = key.Public().(ed25519.PublicKey)
+	t.Signature = nil
+	msg, err := t.signedBytes()
+	if err != nil {
+		return err
+	}
+	t.Signature = ed25519.Sign(key, msg)
+	return nil
+}
+
+// Verify checks that t is complete, that its entries form an unbroken chain
+// over its steps and that it is signed by pub, the party's identity key.
+func (t *Transcript) Verify(pub ed25519.PublicKey) error {
+	if t.Version != Version {
+		return fmt.Errorf("%w: version %d", ErrBadTranscript, t.Version)
+	}
+	if len(t.Entries) != len(t.Steps) {
+		return fmt.Errorf("%w: %d of %d steps recorded", ErrBadTranscript, len(t.Entries), len(t.Steps))
+	}
+	if err := verifyChain(t.Entries, t.Steps); err != nil {
+		return err
+	}
+	if len(pub) != ed25519.PublicKeySize || !pub.Equal(t.PublicKey) {
+		return fmt.Errorf("%w: not signed by the expected key", ErrBadTranscript)
+	}
+	msg, err := t.signedBytes()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, msg, t.Signature) {
+		return fmt.Errorf("%w: bad signature", ErrBadTranscript)
+	}
+	return nil
+}
+
+// verifyChain checks that entries are hash-chained and record steps in order.
+func verifyChain(entries []Entry, steps []string) error {
+	prev := genesis
+	for i := range entries {
+		e := &entries[i]
+		switch {
+		case i >= len(steps) || e.Step != steps[i]:
+			return fmt.Errorf("%w: entry %d is for step %q", ErrBadTranscript, i, e.Step)
+		case e.Prev != prev:
+			return fmt.Errorf("%w: entry %d: previous hash does not match", ErrBadTranscript, i)
+		}
+		hash, err := e.digest()
+		if err != nil {
+			return err
+		}
+		if hash != e.Hash {
+			return fmt.Errorf("%w: entry %d: hash does not match", ErrBadTranscript, i)
+		}
+		prev = e.Hash
+	}
+	return nil
+}
+
+// Compare checks that the transcripts of different parties describe the same
+// ceremony: the same ID, parties and steps, one transcript per party, and the
+// same shared outputs at every step. Verify each transcript first; Compare
+// does not check signatures.
+func Compare(ts ...*Transcript) error {
+	if len(ts) == 0 {
+		return errors.New("ceremony: no transcripts")
+	}
+	first := ts[0]
+	seen := make(map[string]bool, len(ts))
+	for _, t := range ts {
+		switch {
+		case t.ID != first.ID:
+			return fmt.Errorf("%w: ceremonies %q and %q", ErrMismatch, first.ID, t.ID)
+		case !slices.Equal(t.Parties, first.Parties):
+			return fmt.Errorf("%w: party %q lists parties %v, not %v", ErrMismatch, t.Party, t.Parties, first.Parties)
+		case !slices.Equal(t.Steps, first.Steps) || len(t.Entries) != len(first.Entries):
+			return fmt.Errorf("%w: party %q ran other steps", ErrMismatch, t.Party)
+		case !slices.Contains(first.Parties, t.Party):
+			return fmt.Errorf("%w: %q is not one of the parties", ErrMismatch, t.Party)
+		case seen[t.Party]:
+			return fmt.Errorf("%w: two transcripts for party %q", ErrMismatch, t.Party)
+		}
+		seen[t.Party] = true
+		for i := range t.Entries {
+			if !maps.Equal(t.Entries[i].Shared, first.Entries[i].Shared) {
+				return fmt.Errorf("%w: parties %q and %q disagree at step %s", ErrMismatch, first.Party, t.Party, t.Steps[i])
+			}
+		}
+	}
+	if len(seen) != len(first.Parties) {
+		return fmt.Errorf("%w: %d of %d parties", ErrMismatch, len(seen), len(first.Parties))
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+// Package commit provides hash and Pedersen commitments for building custom
+// flows around the ZK proofs.
+//
+// # Hash Commitments
+//
+// Commit binds a message to a session ID and the committing party with a
+// random nonce, using the native commitment the protocols themselves use;
+// Open checks an opening and OpenBatch checks many at once:
+//
+//	com, nonce, err := commit.Commit(sid, "alice", msg)
+//	// ... later, after the reveal
+//	err = commit.Open(sid, "alice", com, msg, nonce)
+//
+// # Pedersen Commitments
+//
+// A Pedersen commits to scalars as m*G + r*H over P-256, P-384, P-521 or
+// secp256k1, with the curve arithmetic done by the native library. H is
+// hashed to the curve with curve.HashToPoint, so its discrete logarithm is
+// unknown:
+//
+//	ped, err := commit.NewPedersen(curve.Secp256k1)
+//	defer ped.Free()
+//	com, r, err := ped.Commit(m)
+//	err = ped.Open(com, m, r)
+//
+// OpenBatch checks many Pedersen openings with a single random linear
+// combination. Both batch functions wrap ErrMismatch with the index of the
+// first bad opening.
+package commit
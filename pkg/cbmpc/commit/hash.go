@@ -0,0 +1,63 @@
+package commit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+// NonceSize is the length of the random opening of a hash commitment.
+const NonceSize = 32
+
+var (
+	// ErrMismatch indicates a commitment that does not open to the claimed
+	// message. Batch verification wraps it with the index of the first bad
+	// opening.
+	ErrMismatch = errors.New("commitment does not match opening")
+	// ErrUnsupportedCurve indicates a curve without Pedersen commitments.
+	ErrUnsupportedCurve = errors.New("unsupported curve for Pedersen commitments")
+)
+
+// Commit returns a hash commitment to msg and the nonce that opens it. The
+// commitment is the native library's commitment_t, bound to sessionID and to
+// the committing party, whose pid is derived from party as the native
+// protocols derive it from party names, so it interoperates with the
+// commitments those protocols exchange. It is hiding as long as the nonce
+// stays secret and binding as long as the hash is collision resistant.
+func Commit(sessionID []byte, party string, msg []byte) (com, nonce []byte, err error) {
+	return backend.Commit(sessionID, party, msg)
+}
+
+// Open checks that com, made by Commit with sessionID and party, opens to
+// msg with nonce.
+func Open(sessionID []byte, party string, com, msg, nonce []byte) error {
+	if len(nonce) != NonceSize {
+		return fmt.Errorf("%w: nonce must be %d bytes", ErrMismatch, NonceSize)
+	}
+	err := backend.CommitOpen(sessionID, party, com, msg, nonce)
+	if errors.Is(err, backend.ErrCommitMismatch) {
+		return ErrMismatch
+	}
+	return err
+}
+
+// Opening is a hash commitment with the party that made it and its claimed
+// message and nonce.
+type Opening struct {
+	Party      string
+	Commitment []byte
+	Message    []byte
+	Nonce      []byte
+}
+
+// OpenBatch checks every opening against sessionID, as Open does, and
+// reports the first that fails.
+func OpenBatch(sessionID []byte, openings []Opening) error {
+	for i, o := range openings {
+		if err := Open(sessionID, o.Party, o.Commitment, o.Message, o.Nonce); err != nil {
+			return fmt.Errorf("opening %d: %w", i, err)
+		}
+	}
+	return nil
+}
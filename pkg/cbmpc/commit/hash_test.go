@@ -0,0 +1,70 @@
+//go:build cgo && !windows
+
+package commit_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/commit"
+)
+
+func TestHashCommit(t *testing.T) {
+	sid := []byte("session")
+	com, nonce, err := commit.Commit(sid, "alice", []byte("message"))
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if len(nonce) != commit.NonceSize {
+		t.Fatalf("nonce is %d bytes, want %d", len(nonce), commit.NonceSize)
+	}
+	if err := commit.Open(sid, "alice", com, []byte("message"), nonce); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	badNonce := append([]byte(nil), nonce...)
+	badNonce[0] ^= 1
+	tests := map[string]error{
+		"wrong message": commit.Open(sid, "alice", com, []byte("massage"), nonce),
+		"wrong session": commit.Open([]byte("other"), "alice", com, []byte("message"), nonce),
+		"wrong party":   commit.Open(sid, "bob", com, []byte("message"), nonce),
+		"wrong nonce":   commit.Open(sid, "alice", com, []byte("message"), badNonce),
+		"short nonce":   commit.Open(sid, "alice", com, []byte("message"), nonce[:16]),
+	}
+	for name, err := range tests {
+		if !errors.Is(err, commit.ErrMismatch) {
+			t.Errorf("%s: error = %v, want ErrMismatch", name, err)
+		}
+	}
+
+	// The nonce hides the message.
+	com2, _, err := commit.Commit(sid, "alice", []byte("message"))
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if string(com) == string(com2) {
+		t.Fatal("two commitments to the same message are equal")
+	}
+}
+
+func TestHashOpenBatch(t *testing.T) {
+	sid := []byte("session")
+	var openings []commit.Opening
+	for _, party := range []string{"alice", "bob", "carol"} {
+		msg := []byte("share of " + party)
+		com, nonce, err := commit.Commit(sid, party, msg)
+		if err != nil {
+			t.Fatalf("Commit failed: %v", err)
+		}
+		openings = append(openings, commit.Opening{Party: party, Commitment: com, Message: msg, Nonce: nonce})
+	}
+	if err := commit.OpenBatch(sid, openings); err != nil {
+		t.Fatalf("OpenBatch failed: %v", err)
+	}
+
+	openings[1].Message = []byte("x")
+	err := commit.OpenBatch(sid, openings)
+	if !errors.Is(err, commit.ErrMismatch) || err.Error() != "opening 1: "+commit.ErrMismatch.Error() {
+		t.Fatalf("OpenBatch error = %v, want ErrMismatch at opening 1", err)
+	}
+}
@@ -0,0 +1,219 @@
+package commit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+)
+
+// pedersenDST is the domain separation tag H is hashed to the curve with.
+const pedersenDST = "cbmpc-go/commit/pedersen-h"
+
+// Pedersen computes Pedersen commitments m*G + r*H over one curve, where G
+// is the curve generator and H a second generator derived by hashing, so
+// that nobody knows its discrete logarithm. Pedersen commitments are
+// perfectly hiding, computationally binding and additively homomorphic:
+// the sum of two commitments commits to the sum of their messages.
+//
+// The curve arithmetic runs in the native library. A Pedersen must be freed
+// with Free when no longer needed.
+type Pedersen struct {
+	c curve.Curve
+	h *curve.Point
+}
+
+// PedersenOpening is a Pedersen commitment with its claimed message and
+// randomness.
+type PedersenOpening struct {
+	Commitment *curve.Point
+	M, R       *curve.Scalar
+}
+
+// NewPedersen returns Pedersen commitments over c. P-256, P-384, P-521 and
+// secp256k1 are supported.
+//
+// H is curve.HashToPoint(c, nil, []byte("cbmpc-go/commit/pedersen-h")), the
+// RFC 9380 hash to curve of an empty message under that tag. Every party
+// derives the same H, and anyone can check it with H.
+func NewPedersen(c curve.Curve) (*Pedersen, error) {
+	switch c {
+	case curve.P256, curve.P384, curve.P521, curve.Secp256k1:
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedCurve, c)
+	}
+	h, err := curve.HashToPoint(c, nil, []byte(pedersenDST))
+	if err != nil {
+		return nil, err
+	}
+	return &Pedersen{c: c, h: h}, nil
+}
+
+// Curve returns the curve of the commitments.
+func (p *Pedersen) Curve() curve.Curve { return p.c }
+
+// H returns the compressed encoding of the second generator.
+func (p *Pedersen) H() ([]byte, error) { return p.h.Bytes() }
+
+// Free releases the second generator.
+func (p *Pedersen) Free() {
+	if p != nil {
+		p.h.Free()
+	}
+}
+
+// Commit commits to m with fresh randomness r and returns the commitment and
+// r. The caller must free both.
+func (p *Pedersen) Commit(m *curve.Scalar) (*curve.Point, *curve.Scalar, error) {
+	r, err := curve.RandomScalar(p.c)
+	if err != nil {
+		return nil, nil, err
+	}
+	com, err := p.CommitWith(m, r)
+	if err != nil {
+		r.Free()
+		return nil, nil, err
+	}
+	return com, r, nil
+}
+
+// CommitWith returns the commitment m*G + r*H. The caller must free it.
+func (p *Pedersen) CommitWith(m, r *curve.Scalar) (*curve.Point, error) {
+	if m == nil || r == nil {
+		return nil, errors.New("nil scalar")
+	}
+	mg, err := curve.MulGenerator(p.c, m)
+	if err != nil {
+		return nil, err
+	}
+	defer mg.Free()
+	rh, err := p.h.Mul(r)
+	if err != nil {
+		return nil, err
+	}
+	defer rh.Free()
+	return mg.Add(rh)
+}
+
+// Open checks that com opens to m with randomness r.
+func (p *Pedersen) Open(com *curve.Point, m, r *curve.Scalar) error {
+	if com == nil {
+		return errors.New("nil commitment")
+	}
+	want, err := p.CommitWith(m, r)
+	if err != nil {
+		return err
+	}
+	defer want.Free()
+	return equalPoints(com, want)
+}
+
+// OpenBatch checks every opening, as Open does, and reports the first that
+// fails. It checks a random linear combination of the openings, which costs
+// one commitment computation plus one scalar multiplication per opening, and
+// falls back to checking openings one by one only when the combination does
+// not match.
+func (p *Pedersen) OpenBatch(openings []PedersenOpening) error {
+	if len(openings) == 0 {
+		return nil
+	}
+	for i, o := range openings {
+		if o.Commitment == nil || o.M == nil || o.R == nil {
+			return fmt.Errorf("opening %d: incomplete", i)
+		}
+	}
+	if p.combinedOpen(openings) == nil {
+		return nil
+	}
+	for i, o := range openings {
+		if err := p.Open(o.Commitment, o.M, o.R); err != nil {
+			return fmt.Errorf("opening %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// combinedOpen checks that sum(rho_i*C_i) opens to sum(rho_i*m_i) with
+// randomness sum(rho_i*r_i) for random rho_i. A bad opening passes with
+// probability about 1/q.
+func (p *Pedersen) combinedOpen(openings []PedersenOpening) error {
+	var sumC *curve.Point
+	var sumM, sumR *curve.Scalar
+	defer func() {
+		sumC.Free()
+		sumM.Free()
+		sumR.Free()
+	}()
+
+	for _, o := range openings {
+		rho, err := curve.RandomScalar(p.c)
+		if err != nil {
+			return err
+		}
+		c, err := o.Commitment.Mul(rho)
+		if err != nil {
+			rho.Free()
+			return err
+		}
+		m, err := rho.Mul(o.M, p.c)
+		if err != nil {
+			rho.Free()
+			c.Free()
+			return err
+		}
+		r, err := rho.Mul(o.R, p.c)
+		rho.Free()
+		if err != nil {
+			c.Free()
+			m.Free()
+			return err
+		}
+		if sumC == nil {
+			sumC, sumM, sumR = c, m, r
+			continue
+		}
+		if sumC, err = addPoint(sumC, c); err != nil {
+			m.Free()
+			r.Free()
+			return err
+		}
+		if sumM, err = addScalar(sumM, m, p.c); err != nil {
+			r.Free()
+			return err
+		}
+		if sumR, err = addScalar(sumR, r, p.c); err != nil {
+			return err
+		}
+	}
+	return p.Open(sumC, sumM, sumR)
+}
+
+// addPoint returns a+b and frees a and b.
+func addPoint(a, b *curve.Point) (*curve.Point, error) {
+	defer a.Free()
+	defer b.Free()
+	return a.Add(b)
+}
+
+// addScalar returns a+b and frees a and b.
+func addScalar(a, b *curve.Scalar, c curve.Curve) (*curve.Scalar, error) {
+	defer a.Free()
+	defer b.Free()
+	return a.Add(b, c)
+}
+
+func equalPoints(a, b *curve.Point) error {
+	ab, err := a.Bytes()
+	if err != nil {
+		return err
+	}
+	bb, err := b.Bytes()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(ab, bb) {
+		return ErrMismatch
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+//go:build cgo && !windows
+
+package commit_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/commit"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+)
+
+func TestPedersen(t *testing.T) {
+	for _, c := range []curve.Curve{curve.P256, curve.P384, curve.P521, curve.Secp256k1} {
+		t.Run(c.String(), func(t *testing.T) {
+			ped, err := commit.NewPedersen(c)
+			if err != nil {
+				t.Fatalf("NewPedersen failed: %v", err)
+			}
+			defer ped.Free()
+
+			// H is deterministic.
+			other, err := commit.NewPedersen(c)
+			if err != nil {
+				t.Fatalf("NewPedersen failed: %v", err)
+			}
+			defer other.Free()
+			h1, err := ped.H()
+			if err != nil {
+				t.Fatalf("H failed: %v", err)
+			}
+			h2, _ := other.H()
+			if string(h1) != string(h2) {
+				t.Fatal("H differs between instances")
+			}
+			want, err := curve.HashToPoint(c, nil, []byte("cbmpc-go/commit/pedersen-h"))
+			if err != nil {
+				t.Fatalf("HashToPoint failed: %v", err)
+			}
+			defer want.Free()
+			if wb, _ := want.Bytes(); string(h1) != string(wb) {
+				t.Fatal("H is not the hash to curve of the Pedersen tag")
+			}
+
+			m, err := curve.RandomScalar(c)
+			if err != nil {
+				t.Fatalf("RandomScalar failed: %v", err)
+			}
+			defer m.Free()
+			com, r, err := ped.Commit(m)
+			if err != nil {
+				t.Fatalf("Commit failed: %v", err)
+			}
+			defer com.Free()
+			defer r.Free()
+			if err := ped.Open(com, m, r); err != nil {
+				t.Fatalf("Open failed: %v", err)
+			}
+			if err := ped.Open(com, r, m); !errors.Is(err, commit.ErrMismatch) {
+				t.Fatalf("Open with swapped scalars: error = %v, want ErrMismatch", err)
+			}
+		})
+	}
+}
+
+// TestPedersenHomomorphic tests that the sum of two commitments opens to the
+// sum of their messages and randomness.
+func TestPedersenHomomorphic(t *testing.T) {
+	c := curve.Secp256k1
+	ped, err := commit.NewPedersen(c)
+	if err != nil {
+		t.Fatalf("NewPedersen failed: %v", err)
+	}
+	defer ped.Free()
+
+	m1, _ := curve.RandomScalar(c)
+	m2, _ := curve.RandomScalar(c)
+	c1, r1, err := ped.Commit(m1)
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	c2, r2, err := ped.Commit(m2)
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	sum, err := c1.Add(c2)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	m, _ := m1.Add(m2, c)
+	r, _ := r1.Add(r2, c)
+	if err := ped.Open(sum, m, r); err != nil {
+		t.Fatalf("Open of the sum failed: %v", err)
+	}
+}
+
+func TestPedersenOpenBatch(t *testing.T) {
+	c := curve.P256
+	ped, err := commit.NewPedersen(c)
+	if err != nil {
+		t.Fatalf("NewPedersen failed: %v", err)
+	}
+	defer ped.Free()
+
+	openings := make([]commit.PedersenOpening, 5)
+	for i := range openings {
+		m, err := curve.RandomScalar(c)
+		if err != nil {
+			t.Fatalf("RandomScalar failed: %v", err)
+		}
+		com, r, err := ped.Commit(m)
+		if err != nil {
+			t.Fatalf("Commit failed: %v", err)
+		}
+		openings[i] = commit.PedersenOpening{Commitment: com, M: m, R: r}
+	}
+	if err := ped.OpenBatch(openings); err != nil {
+		t.Fatalf("OpenBatch failed: %v", err)
+	}
+
+	openings[3].M = openings[2].M
+	err = ped.OpenBatch(openings)
+	if !errors.Is(err, commit.ErrMismatch) || !strings.HasPrefix(err.Error(), "opening 3:") {
+		t.Fatalf("OpenBatch error = %v, want ErrMismatch at opening 3", err)
+	}
+}
+
+func TestPedersenUnsupportedCurve(t *testing.T) {
+	if _, err := commit.NewPedersen(curve.Ed25519); !errors.Is(err, commit.ErrUnsupportedCurve) {
+		t.Fatalf("error = %v, want ErrUnsupportedCurve", err)
+	}
+}
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+)
+
+// Version is the schema version understood by this package.
+const Version = 1
+
+// Transport kinds.
+const (
+	TransportTLS  = "tls"
+	TransportMock = "mock"
+)
+
+// KEM types.
+const (
+	KEMRSA = "rsa"
+)
+
+// Config is the deployment configuration of one MPC cluster.
+type Config struct {
+	// Version must equal Version.
+	Version int `json:"version" yaml:"version"`
+	// Self names the local party. It is optional in shared cluster files and
+	// is usually injected per node by templating.
+	Self string `json:"self,omitempty" yaml:"self,omitempty"`
+	// Curve is one of "secp256k1", "p256", "p384", "p521" or "ed25519".
+	Curve string `json:"curve" yaml:"curve"`
+	// Threshold is the number of parties required to sign. Zero means all
+	// parties (n-of-n).
+	Threshold int `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+	// Parties lists every party; the index in this list is its RoleID.
+	Parties []Party `json:"parties" yaml:"parties"`
+	// Transport configures how parties reach each other.
+	Transport Transport `json:"transport" yaml:"transport"`
+	// Timeouts bounds protocol runs. Unset fields take their defaults.
+	Timeouts Timeouts `json:"timeouts" yaml:"timeouts,omitempty"`
+	// KEM configures the key encapsulation used for PVE backups. Optional.
+	KEM *KEM `json:"kem,omitempty" yaml:"kem,omitempty"`
+	// Policies maps a policy name to an access structure in the format
+	// accepted by accessstructure.FromJSON. Optional.
+	Policies map[string]Policy `json:"policies,omitempty" yaml:"policies,omitempty"`
+}
+
+// Party describes a single party in the cluster.
+type Party struct {
+	Name string `json:"name" yaml:"name"`
+	// Address is "host:port". It may be omitted when Transport.Discovery is
+	// set, which then resolves it by Name.
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	// Cert and Key are paths to the party's PEM certificate and private key.
+	// Required for the tls transport.
+	Cert string `json:"cert,omitempty" yaml:"cert,omitempty"`
+	Key  string `json:"key,omitempty" yaml:"key,omitempty"`
+}
+
+// Transport configures the network between parties.
+type Transport struct {
+	// Kind is "tls" or "mock".
+	Kind string `json:"kind" yaml:"kind"`
+	// CACert is the path to the PEM CA bundle. Required for tls.
+	CACert string `json:"ca_cert,omitempty" yaml:"ca_cert,omitempty"`
+	// DialTimeout bounds connection setup, e.g. "10s". Zero selects the
+	// transport default.
+	DialTimeout Duration `json:"dial_timeout,omitempty" yaml:"dial_timeout,omitempty"`
+	// Discovery resolves the addresses of parties that have none. Optional.
+	Discovery *Discovery `json:"discovery,omitempty" yaml:"discovery,omitempty"`
+}
+
+// Default timeouts, applied by ApplyDefaults.
+const (
+	DefaultJobTimeout   = 2 * time.Minute
+	DefaultRoundTimeout = 30 * time.Second
+)
+
+// Timeouts bounds protocol runs.
+type Timeouts struct {
+	// Job bounds a whole protocol run, from connecting to the result.
+	// Defaults to DefaultJobTimeout.
+	Job Duration `json:"job,omitempty" yaml:"job,omitempty"`
+	// Round bounds the wait for each round's messages; see
+	// cbmpc.JobOptions.RoundTimeout. Defaults to DefaultRoundTimeout.
+	Round Duration `json:"round,omitempty" yaml:"round,omitempty"`
+}
+
+// Discovery kinds.
+const (
+	DiscoveryDNSSD  = "dns-sd"
+	DiscoveryConsul = "consul"
+)
+
+// Discovery configures how party addresses are looked up by name.
+type Discovery struct {
+	// Kind is "dns-sd" or "consul".
+	Kind string `json:"kind" yaml:"kind"`
+	// Domain is the DNS-SD domain. Required for dns-sd.
+	Domain string `json:"domain,omitempty" yaml:"domain,omitempty"`
+	// Service is the DNS-SD service type or the Consul service name.
+	// Defaults to DefaultService.
+	Service string `json:"service,omitempty" yaml:"service,omitempty"`
+	// Address is the Consul HTTP API URL. Empty selects the local agent.
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	// Datacenter is the Consul datacenter. Empty selects the agent's.
+	Datacenter string `json:"datacenter,omitempty" yaml:"datacenter,omitempty"`
+	// HealthCheck skips resolved addresses that do not accept a TCP
+	// connection.
+	HealthCheck bool `json:"health_check,omitempty" yaml:"health_check,omitempty"`
+}
+
+// KEM configures the key encapsulation mechanism used for PVE.
+type KEM struct {
+	// Type is "rsa".
+	Type string `json:"type" yaml:"type"`
+	// Bits is the RSA modulus size: 2048, 3072 or 4096. Defaults to
+	// DefaultRSABits.
+	Bits int `json:"bits,omitempty" yaml:"bits,omitempty"`
+}
+
+// DefaultService is the discovery service name used when none is set.
+const DefaultService = "cbmpc"
+
+// DefaultRSABits is the RSA modulus size used when kem.bits is not set.
+const DefaultRSABits = 3072
+
+// ApplyDefaults fills in unset optional fields: the timeouts, the discovery
+// service name and the RSA KEM size. Parse, ParseYAML and Load call it before
+// Validate; call it on configurations built in code.
+func (c *Config) ApplyDefaults() {
+	if c.Timeouts.Job == 0 {
+		c.Timeouts.Job = Duration(DefaultJobTimeout)
+	}
+	if c.Timeouts.Round == 0 {
+		c.Timeouts.Round = Duration(DefaultRoundTimeout)
+	}
+	if d := c.Transport.Discovery; d != nil && d.Service == "" {
+		d.Service = DefaultService
+	}
+	if c.KEM != nil && c.KEM.Type == KEMRSA && c.KEM.Bits == 0 {
+		c.KEM.Bits = DefaultRSABits
+	}
+}
+
+// Duration is a time.Duration written as a Go duration string ("30s").
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	return d.parse(s)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (d Duration) MarshalYAML() (any, error) {
+	return time.Duration(d).String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	var s string
+	if err := node.Decode(&s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	return d.parse(s)
+}
+
+func (d *Duration) parse(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Policy is an access structure embedded in a configuration file.
+type Policy struct {
+	Expr ac.Expr
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p Policy) MarshalJSON() ([]byte, error) {
+	return ac.ToJSON(p.Expr)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Policy) UnmarshalJSON(data []byte) error {
+	expr, err := ac.FromJSON(data)
+	if err != nil {
+		return err
+	}
+	p.Expr = expr
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (p *Policy) UnmarshalYAML(node *yaml.Node) error {
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	expr, err := ac.FromYAML(data)
+	if err != nil {
+		return err
+	}
+	p.Expr = expr
+	return nil
+}
+
+// Parse decodes a JSON configuration, applies defaults and validates it.
+// Unknown fields are rejected.
+func Parse(data []byte) (*Config, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	if dec.More() {
+		return nil, errors.New("parse config: trailing data after document")
+	}
+	cfg.ApplyDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// ParseYAML decodes a YAML configuration, applies defaults and validates it.
+// The schema is identical to Parse.
+func ParseYAML(data []byte) (*Config, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	cfg.ApplyDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Load reads a configuration file, choosing the format from its extension
+// (.json, .yaml or .yml), expands environment variables in it with ExpandEnv,
+// and parses it.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	return parseFile(path, data)
+}
+
+func parseFile(path string, data []byte) (*Config, error) {
+	data, err := ExpandEnv(data, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return Parse(data)
+	case ".yaml", ".yml":
+		return ParseYAML(data)
+	default:
+		return nil, fmt.Errorf("unsupported config extension %q (want .json, .yaml or .yml)", filepath.Ext(path))
+	}
+}
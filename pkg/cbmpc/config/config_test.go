@@ -0,0 +1,329 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+)
+
+func TestLoadYAML(t *testing.T) {
+	cfg, err := Load("testdata/cluster.yaml")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.CurveID() != curve.Secp256k1 {
+		t.Errorf("CurveID = %v, want secp256k1", cfg.CurveID())
+	}
+	if got := cfg.Names(); !reflect.DeepEqual(got, []string{"alice", "bob", "carol"}) {
+		t.Errorf("Names = %v", got)
+	}
+	if role, ok := cfg.Role("bob"); !ok || role != 1 {
+		t.Errorf("Role(bob) = %d, %v", role, ok)
+	}
+	if cfg.Quorum() != 2 {
+		t.Errorf("Quorum = %d, want 2", cfg.Quorum())
+	}
+	if time.Duration(cfg.Transport.DialTimeout) != 10*time.Second {
+		t.Errorf("DialTimeout = %v", time.Duration(cfg.Transport.DialTimeout))
+	}
+	backup, ok := cfg.Policy("backup")
+	if !ok {
+		t.Fatal("missing backup policy")
+	}
+	want := ac.Threshold(2, ac.Leaf("custodian1"), ac.Leaf("custodian2"), ac.Leaf("custodian3"))
+	if !reflect.DeepEqual(backup, want) {
+		t.Errorf("backup policy = %#v", backup)
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	cfg, err := Load("testdata/cluster.json")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.CurveID() != curve.Ed25519 {
+		t.Errorf("CurveID = %v, want ed25519", cfg.CurveID())
+	}
+	if cfg.Quorum() != 2 {
+		t.Errorf("Quorum = %d, want all parties", cfg.Quorum())
+	}
+}
+
+func TestLoadUnsupportedExtension(t *testing.T) {
+	if _, err := Load("testdata/cluster.toml"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	cfg, err := Load("testdata/cluster.yaml")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	back, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if !reflect.DeepEqual(back, cfg) {
+		t.Fatalf("round trip mismatch:\n got  %+v\n want %+v", back, cfg)
+	}
+}
+
+func validConfig() *Config {
+	return &Config{
+		Version:   Version,
+		Curve:     "p256",
+		Threshold: 2,
+		Parties: []Party{
+			{Name: "a", Address: "a:1", Cert: "a.pem", Key: "a.key"},
+			{Name: "b", Address: "b:1", Cert: "b.pem", Key: "b.key"},
+		},
+		Transport: Transport{Kind: TransportTLS, CACert: "ca.pem"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		mutate func(*Config)
+		want   string
+	}{
+		"version":        {func(c *Config) { c.Version = 2 }, "version:"},
+		"curve":          {func(c *Config) { c.Curve = "p224" }, "curve:"},
+		"one party":      {func(c *Config) { c.Parties = c.Parties[:1]; c.Threshold = 1 }, "at least 2 parties"},
+		"empty name":     {func(c *Config) { c.Parties[1].Name = "" }, "parties[1].name"},
+		"duplicate name": {func(c *Config) { c.Parties[1].Name = "a" }, "duplicate name"},
+		"bad address":    {func(c *Config) { c.Parties[0].Address = "nohost" }, "parties[0].address"},
+		"bad port":       {func(c *Config) { c.Parties[0].Address = "a:99999" }, "invalid port"},
+		"dup address":    {func(c *Config) { c.Parties[1].Address = "a:1" }, "duplicate address"},
+		"missing cert":   {func(c *Config) { c.Parties[0].Cert = "" }, "parties[0].cert"},
+		"missing key":    {func(c *Config) { c.Parties[1].Key = "" }, "parties[1].key"},
+		"unknown self":   {func(c *Config) { c.Self = "z" }, "self:"},
+		"threshold":      {func(c *Config) { c.Threshold = 3 }, "threshold:"},
+		"missing ca":     {func(c *Config) { c.Transport.CACert = "" }, "transport.ca_cert"},
+		"no transport":   {func(c *Config) { c.Transport.Kind = "" }, "transport.kind"},
+		"bad transport":  {func(c *Config) { c.Transport.Kind = "quic" }, "unknown kind"},
+		"neg timeout":    {func(c *Config) { c.Transport.DialTimeout = -1 }, "dial_timeout"},
+		"kem bits":       {func(c *Config) { c.KEM = &KEM{Type: KEMRSA, Bits: 1024} }, "kem.bits"},
+		"kem type":       {func(c *Config) { c.KEM = &KEM{Type: "mlkem"} }, "kem.type"},
+		"empty policy":   {func(c *Config) { c.Policies = map[string]Policy{"backup": {}} }, "policies.backup"},
+		"discovery kind": {func(c *Config) { c.Transport.Discovery = &Discovery{Kind: "mdns"} }, "transport.discovery.kind"},
+		"dns-sd domain":  {func(c *Config) { c.Transport.Discovery = &Discovery{Kind: DiscoveryDNSSD} }, "transport.discovery.domain"},
+		"no address":     {func(c *Config) { c.Parties[0].Address = "" }, "parties[0].address"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := validConfig()
+			tc.mutate(cfg)
+			err := cfg.Validate()
+			if !errors.Is(err, ErrInvalid) {
+				t.Fatalf("expected ErrInvalid, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("error %q does not mention %q", err, tc.want)
+			}
+		})
+	}
+
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("valid config rejected: %v", err)
+	}
+	discovered := validConfig()
+	discovered.Parties[0].Address = ""
+	discovered.Transport.Discovery = &Discovery{Kind: DiscoveryConsul}
+	if err := discovered.Validate(); err != nil {
+		t.Fatalf("config without address under discovery rejected: %v", err)
+	}
+}
+
+func TestValidateReportsAllErrors(t *testing.T) {
+	cfg := validConfig()
+	cfg.Curve = "p224"
+	cfg.Threshold = 5
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "curve:") || !strings.Contains(err.Error(), "threshold:") {
+		t.Fatalf("expected both curve and threshold errors, got %v", err)
+	}
+}
+
+func TestParseRejectsUnknownFields(t *testing.T) {
+	if _, err := Parse([]byte(`{"version": 1, "curves": "p256"}`)); err == nil || !strings.Contains(err.Error(), "unknown field") {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+	if _, err := ParseYAML([]byte("version: 1\ncurves: p256\n")); err == nil {
+		t.Fatal("expected unknown field error from YAML")
+	}
+}
+
+func TestParseInvalidPolicy(t *testing.T) {
+	doc := `{"version": 1, "curve": "p256",
+		"parties": [{"name": "a", "address": "a:1"}, {"name": "b", "address": "b:1"}],
+		"transport": {"kind": "mock"},
+		"policies": {"backup": {"type": "threshold", "k": 5, "children": [{"type": "leaf", "name": "x"}]}}}`
+	if _, err := Parse([]byte(doc)); err == nil || !strings.Contains(err.Error(), "threshold k") {
+		t.Fatalf("expected policy error, got %v", err)
+	}
+}
+
+func TestParseInvalidDuration(t *testing.T) {
+	if _, err := ParseYAML([]byte("version: 1\ntransport:\n  kind: mock\n  dial_timeout: soon\n")); err == nil {
+		t.Fatal("expected duration error")
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	env := map[string]string{"HOST": "alice.mpc", "EMPTY": ""}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	got, err := ExpandEnv([]byte(`a: ${HOST}:7000 b: ${PORT:-7001} c: ${EMPTY} d: $$HOME e: $x`), lookup)
+	if err != nil {
+		t.Fatalf("ExpandEnv: %v", err)
+	}
+	if want := `a: alice.mpc:7000 b: 7001 c:  d: $HOME e: $x`; string(got) != want {
+		t.Errorf("ExpandEnv = %q, want %q", got, want)
+	}
+
+	_, err = ExpandEnv([]byte(`${A} ${B:-b} ${C}`), lookup)
+	if !errors.Is(err, ErrInvalid) || !strings.Contains(err.Error(), "A, C") {
+		t.Errorf("missing variables: err = %v", err)
+	}
+	for _, doc := range []string{`${HOST`, `${}`, `${1X}`} {
+		if _, err := ExpandEnv([]byte(doc), lookup); !errors.Is(err, ErrInvalid) {
+			t.Errorf("ExpandEnv(%q): err = %v, want ErrInvalid", doc, err)
+		}
+	}
+}
+
+func TestLoadExpandsEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cluster.yaml")
+	doc := "version: 1\ncurve: ${CURVE}\nthreshold: ${THRESHOLD:-2}\nparties: [{name: a}, {name: b}]\ntransport: {kind: mock}\n"
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CURVE", "p256")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Curve != "p256" || cfg.Threshold != 2 {
+		t.Errorf("Load = %+v", cfg)
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	cfg := validConfig()
+	cfg.KEM = &KEM{Type: KEMRSA}
+	cfg.Transport.Discovery = &Discovery{Kind: DiscoveryConsul}
+	cfg.Timeouts.Round = Duration(5 * time.Second)
+	cfg.ApplyDefaults()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if time.Duration(cfg.Timeouts.Job) != DefaultJobTimeout || time.Duration(cfg.Timeouts.Round) != 5*time.Second {
+		t.Errorf("Timeouts = %+v", cfg.Timeouts)
+	}
+	if cfg.KEM.Bits != DefaultRSABits || cfg.Transport.Discovery.Service != DefaultService {
+		t.Errorf("KEM = %+v, Discovery = %+v", cfg.KEM, cfg.Transport.Discovery)
+	}
+	if got := cfg.JobOptions().RoundTimeout; got != 5*time.Second {
+		t.Errorf("JobOptions().RoundTimeout = %v", got)
+	}
+}
+
+func TestSelfRole(t *testing.T) {
+	cfg := validConfig()
+	if _, err := cfg.SelfRole(); err == nil {
+		t.Error("SelfRole without self succeeded")
+	}
+	cfg.Self = "b"
+	if role, err := cfg.SelfRole(); err != nil || role != 1 {
+		t.Errorf("SelfRole = %d, %v", role, err)
+	}
+}
+
+func TestWatcherReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cluster.yaml")
+	write := func(threshold string) {
+		t.Helper()
+		doc := "version: 1\ncurve: p256\nthreshold: " + threshold + "\nparties: [{name: a}, {name: b}]\ntransport: {kind: mock}\n"
+		if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("1")
+	w, err := Watch(path, 0)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer w.Close()
+
+	var reloads, failures int
+	w.OnReload(func(old, cur *Config) {
+		reloads++
+		if old.Threshold != 1 || cur.Threshold != 2 {
+			t.Errorf("reload from %d to %d", old.Threshold, cur.Threshold)
+		}
+	})
+	w.OnError(func(error) { failures++ })
+
+	if err := w.Reload(); err != nil || reloads != 0 {
+		t.Fatalf("unchanged Reload: err = %v, reloads = %d", err, reloads)
+	}
+	write("7")
+	for range 2 {
+		if err := w.Reload(); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("invalid Reload: err = %v", err)
+		}
+	}
+	if failures != 1 || w.Current().Threshold != 1 {
+		t.Fatalf("failures = %d, threshold = %d", failures, w.Current().Threshold)
+	}
+	write("2")
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if reloads != 1 || w.Current().Threshold != 2 {
+		t.Fatalf("reloads = %d, threshold = %d", reloads, w.Current().Threshold)
+	}
+}
+
+func TestWatcherPolls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cluster.json")
+	doc := `{"version": 1, "curve": "p256", "parties": [{"name": "a"}, {"name": "b"}], "transport": {"kind": "mock"}}`
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	w, err := Watch(path, 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	reloaded := make(chan *Config, 1)
+	w.OnReload(func(_, cur *Config) { reloaded <- cur })
+	if err := os.WriteFile(path, []byte(strings.Replace(doc, "p256", "p384", 1)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case cfg := <-reloaded:
+		if cfg.Curve != "p384" {
+			t.Errorf("reloaded curve %q", cfg.Curve)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
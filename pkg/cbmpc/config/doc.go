@@ -0,0 +1,81 @@
+// Package config defines the deployment configuration schema for an MPC
+// cluster: parties, transport, signing threshold, KEM and access structure
+// policies.
+//
+// The schema is plain JSON or YAML so it can be rendered by infrastructure
+// tooling (Terraform templatefile, Helm values). Load, Parse and ParseYAML
+// reject unknown fields and run Validate, which reports every problem with the
+// path of the offending field. Running the loader in a plan or lint step makes
+// invalid configs fail before anything is deployed.
+//
+// # Example
+//
+//	version: 1
+//	self: alice
+//	curve: secp256k1
+//	threshold: 2
+//	parties:
+//	  - {name: alice, address: "alice.mpc:7000", cert: /etc/mpc/alice.pem, key: /etc/mpc/alice.key}
+//	  - {name: bob,   address: "bob.mpc:7000",   cert: /etc/mpc/bob.pem,   key: /etc/mpc/bob.key}
+//	  - {name: carol, address: "carol.mpc:7000", cert: /etc/mpc/carol.pem, key: /etc/mpc/carol.key}
+//	transport:
+//	  kind: tls
+//	  ca_cert: /etc/mpc/ca.pem
+//	  dial_timeout: 10s
+//	kem:
+//	  type: rsa
+//	  bits: 3072
+//	policies:
+//	  backup:
+//	    type: threshold
+//	    k: 2
+//	    children:
+//	      - {type: leaf, name: custodian1}
+//	      - {type: leaf, name: custodian2}
+//	      - {type: leaf, name: custodian3}
+//
+// Under transport, discovery looks up the addresses of parties listed
+// without one, so a shared file need not hard-code them:
+//
+//	transport:
+//	  kind: tls
+//	  ca_cert: /etc/mpc/ca.pem
+//	  discovery: {kind: consul, service: cbmpc, health_check: true}
+//
+// A party's index in parties is its RoleID; Names and Role convert between the
+// two, and SelfRole finds the local party's. Policies use the accessstructure
+// policy document format.
+//
+// # Environment and Defaults
+//
+// Load expands ${NAME} and ${NAME:-default} from the environment before
+// parsing (see ExpandEnv), so one file can serve every node:
+//
+//	self: ${MPC_SELF}
+//	transport:
+//	  kind: tls
+//	  ca_cert: ${MPC_CERTS:-/etc/mpc}/ca.pem
+//
+// Optional fields left unset take their defaults: timeouts.job and
+// timeouts.round, the discovery service name and the RSA KEM size. JobOptions
+// turns the round timeout into cbmpc.JobOptions, and TLS loads the local
+// party's certificate and the CA bundle for tlsnet.
+//
+// # Reloading
+//
+// Watch keeps a file loaded and reloads it when it changes, either by polling
+// or on Reload. OnReload hooks receive the old and new configuration; a file
+// that does not validate is reported to the OnError hooks and ignored:
+//
+//	w, err := config.Watch("/etc/mpc/cluster.yaml", 30*time.Second)
+//	if err != nil {
+//	    return err
+//	}
+//	defer w.Close()
+//	w.OnReload(func(old, cur *config.Config) {
+//	    log.Printf("round timeout now %v", time.Duration(cur.Timeouts.Round))
+//	})
+//
+// The package does not open connections; it only describes a deployment and
+// loads the files it names.
+package config
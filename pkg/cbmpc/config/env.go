@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ExpandEnv replaces ${NAME} and ${NAME:-default} in a configuration document
+// with the value of the environment variable NAME, or with default when NAME
+// is unset or empty. "$$" stands for a literal "$", and a "$" not followed by
+// "{" or "$" is kept as is. Every variable that is unset and has no default is
+// reported in one error.
+//
+// Values are substituted as text, before the document is parsed, so they can
+// fill numbers as well as strings; quote the reference when the value may
+// contain characters that are special in JSON or YAML.
+func ExpandEnv(data []byte, lookup func(string) (string, bool)) ([]byte, error) {
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	var (
+		out     bytes.Buffer
+		missing []string
+	)
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c != '$' || i+1 == len(data) {
+			out.WriteByte(c)
+			continue
+		}
+		switch data[i+1] {
+		case '$':
+			out.WriteByte('$')
+			i++
+			continue
+		case '{':
+		default:
+			out.WriteByte(c)
+			continue
+		}
+		end := bytes.IndexByte(data[i+2:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated ${ at offset %d", ErrInvalid, i)
+		}
+		ref := string(data[i+2 : i+2+end])
+		name, def, hasDefault := strings.Cut(ref, ":-")
+		if !validEnvName(name) {
+			return nil, fmt.Errorf("%w: invalid variable reference ${%s}", ErrInvalid, ref)
+		}
+		v, ok := lookup(name)
+		switch {
+		case ok && v != "":
+			out.WriteString(v)
+		case hasDefault:
+			out.WriteString(def)
+		case ok:
+			// Set but empty, with no default.
+		default:
+			missing = append(missing, name)
+		}
+		i += 2 + end
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: environment variables not set: %s", ErrInvalid, strings.Join(missing, ", "))
+	}
+	return out.Bytes(), nil
+}
+
+func validEnvName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		switch {
+		case c == '_', c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LoadCertPool reads a PEM CA bundle, such as Transport.CACert.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("CA bundle %s: no PEM certificates", path)
+	}
+	return pool, nil
+}
+
+// LoadKeyPair reads a PEM certificate and private key, such as a party's
+// Cert and Key.
+func LoadKeyPair(certPath, keyPath string) (tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(filepath.Clean(certPath), filepath.Clean(keyPath))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("load key pair: %w", err)
+	}
+	return cert, nil
+}
+
+// TLS loads the local party's certificate and the CA bundle for the tls
+// transport. Self must be set.
+func (c *Config) TLS() (tls.Certificate, *x509.CertPool, error) {
+	role, err := c.SelfRole()
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	me := c.Parties[role]
+	cert, err := LoadKeyPair(me.Cert, me.Key)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	pool, err := LoadCertPool(c.Transport.CACert)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	return cert, pool, nil
+}
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+)
+
+// ErrInvalid wraps every validation failure reported by Validate.
+var ErrInvalid = errors.New("invalid config")
+
+var curves = map[string]curve.Curve{
+	"secp256k1": curve.Secp256k1,
+	"p256":      curve.P256,
+	"p384":      curve.P384,
+	"p521":      curve.P521,
+	"ed25519":   curve.Ed25519,
+}
+
+// Validate checks the configuration and reports every problem found, each
+// prefixed with the path of the offending field, so templated deployments can
+// be fixed in one pass. The returned error wraps ErrInvalid.
+func (c *Config) Validate() error {
+	if c == nil {
+		return fmt.Errorf("%w: nil config", ErrInvalid)
+	}
+	var errs []string
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+
+	if c.Version != Version {
+		fail("version: unsupported version %d (want %d)", c.Version, Version)
+	}
+	if _, ok := curves[c.Curve]; !ok {
+		fail("curve: unknown curve %q (want one of %s)", c.Curve, strings.Join(curveNames(), ", "))
+	}
+
+	if len(c.Parties) < 2 {
+		fail("parties: need at least 2 parties (got %d)", len(c.Parties))
+	}
+	names := make(map[string]int, len(c.Parties))
+	addrs := make(map[string]int, len(c.Parties))
+	for i, p := range c.Parties {
+		at := "parties[" + strconv.Itoa(i) + "]"
+		if p.Name == "" {
+			fail("%s.name: must not be empty", at)
+		} else if j, dup := names[p.Name]; dup {
+			fail("%s.name: duplicate name %q (also parties[%d])", at, p.Name, j)
+		} else {
+			names[p.Name] = i
+		}
+
+		if c.Transport.Kind == TransportMock {
+			continue
+		}
+		if p.Address == "" && c.Transport.Discovery != nil {
+			// Resolved by discovery at connect time.
+		} else if _, port, err := net.SplitHostPort(p.Address); err != nil {
+			fail("%s.address: %v", at, err)
+		} else if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+			fail("%s.address: invalid port %q", at, port)
+		} else if j, dup := addrs[p.Address]; dup {
+			fail("%s.address: duplicate address %q (also parties[%d])", at, p.Address, j)
+		} else {
+			addrs[p.Address] = i
+		}
+		if c.Transport.Kind == TransportTLS {
+			if p.Cert == "" {
+				fail("%s.cert: required for tls transport", at)
+			}
+			if p.Key == "" {
+				fail("%s.key: required for tls transport", at)
+			}
+		}
+	}
+
+	if c.Self != "" {
+		if _, ok := names[c.Self]; !ok {
+			fail("self: %q is not a party", c.Self)
+		}
+	}
+	if c.Threshold < 0 || c.Threshold > len(c.Parties) {
+		fail("threshold: must be in [1, %d] or 0 for all parties (got %d)", len(c.Parties), c.Threshold)
+	}
+
+	switch c.Transport.Kind {
+	case TransportTLS:
+		if c.Transport.CACert == "" {
+			fail("transport.ca_cert: required for tls transport")
+		}
+	case TransportMock:
+		if c.Transport.CACert != "" {
+			fail("transport.ca_cert: not used by mock transport")
+		}
+	case "":
+		fail("transport.kind: required (want %q or %q)", TransportTLS, TransportMock)
+	default:
+		fail("transport.kind: unknown kind %q (want %q or %q)", c.Transport.Kind, TransportTLS, TransportMock)
+	}
+	if c.Transport.DialTimeout < 0 {
+		fail("transport.dial_timeout: must not be negative")
+	}
+	if c.Timeouts.Job < 0 {
+		fail("timeouts.job: must not be negative")
+	}
+	if c.Timeouts.Round < 0 {
+		fail("timeouts.round: must not be negative")
+	}
+	if d := c.Transport.Discovery; d != nil {
+		switch d.Kind {
+		case DiscoveryDNSSD:
+			if d.Domain == "" {
+				fail("transport.discovery.domain: required for dns-sd")
+			}
+			if d.Address != "" || d.Datacenter != "" {
+				fail("transport.discovery: address and datacenter are only used by consul")
+			}
+		case DiscoveryConsul:
+			if d.Domain != "" {
+				fail("transport.discovery.domain: only used by dns-sd")
+			}
+		default:
+			fail("transport.discovery.kind: unknown kind %q (want %q or %q)", d.Kind, DiscoveryDNSSD, DiscoveryConsul)
+		}
+		if c.Transport.Kind == TransportMock {
+			fail("transport.discovery: not used by mock transport")
+		}
+	}
+
+	if c.KEM != nil {
+		switch c.KEM.Type {
+		case KEMRSA:
+			if c.KEM.Bits != 2048 && c.KEM.Bits != 3072 && c.KEM.Bits != 4096 {
+				fail("kem.bits: rsa requires 2048, 3072 or 4096 (got %d)", c.KEM.Bits)
+			}
+		default:
+			fail("kem.type: unknown type %q (want %q)", c.KEM.Type, KEMRSA)
+		}
+	}
+
+	for _, name := range policyNames(c.Policies) {
+		if name == "" {
+			fail("policies: policy name must not be empty")
+		}
+		if c.Policies[name].Expr == nil {
+			fail("policies.%s: empty policy", name)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w:\n  %s", ErrInvalid, strings.Join(errs, "\n  "))
+}
+
+// CurveID returns the curve named by Curve, or curve.Unknown.
+func (c *Config) CurveID() curve.Curve {
+	return curves[c.Curve]
+}
+
+// Names returns party names in role order, the form expected by job
+// constructors.
+func (c *Config) Names() []string {
+	out := make([]string, len(c.Parties))
+	for i, p := range c.Parties {
+		out[i] = p.Name
+	}
+	return out
+}
+
+// Role returns the RoleID of the named party.
+func (c *Config) Role(name string) (cbmpc.RoleID, bool) {
+	for i, p := range c.Parties {
+		if p.Name == name {
+			return cbmpc.RoleID(i), true
+		}
+	}
+	return 0, false
+}
+
+// Quorum returns the effective signing threshold: Threshold, or the number of
+// parties when Threshold is zero.
+func (c *Config) Quorum() int {
+	if c.Threshold == 0 {
+		return len(c.Parties)
+	}
+	return c.Threshold
+}
+
+// SelfRole returns the RoleID of the local party named by Self.
+func (c *Config) SelfRole() (cbmpc.RoleID, error) {
+	if c.Self == "" {
+		return 0, errors.New("config: self is not set")
+	}
+	role, ok := c.Role(c.Self)
+	if !ok {
+		return 0, fmt.Errorf("config: self %q is not a party", c.Self)
+	}
+	return role, nil
+}
+
+// JobOptions returns the job options the configuration sets, currently the
+// round timeout.
+func (c *Config) JobOptions() cbmpc.JobOptions {
+	return cbmpc.JobOptions{RoundTimeout: time.Duration(c.Timeouts.Round)}
+}
+
+// Policy returns the named access structure expression.
+func (c *Config) Policy(name string) (ac.Expr, bool) {
+	p, ok := c.Policies[name]
+	return p.Expr, ok && p.Expr != nil
+}
+
+func curveNames() []string {
+	out := make([]string, 0, len(curves))
+	for name := range curves {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func policyNames(m map[string]Policy) []string {
+	out := make([]string, 0, len(m))
+	for name := range m {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
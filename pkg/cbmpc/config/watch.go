@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Watcher keeps a configuration file loaded and reloads it when it changes.
+// Hooks added with OnReload see every accepted change; a file that fails to
+// parse or validate is reported to the OnError hooks and the previous
+// configuration stays current, so a bad edit never takes a running node
+// down.
+//
+// Which changes a node can apply without a restart is up to the hooks: the
+// timeouts or the address of a peer usually can, the party list usually
+// cannot.
+type Watcher struct {
+	path string
+
+	reloadMu sync.Mutex // serializes Reload
+	mu       sync.Mutex
+	cur      *Config
+	raw      []byte
+	rejected []byte // last invalid contents, reported once
+	onReload []func(old, cur *Config)
+	onError  []func(error)
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Watch loads the configuration at path as Load does and returns a Watcher
+// for it. With a positive interval the Watcher checks the file that often
+// until Close; otherwise it reloads only when Reload is called, for example
+// on SIGHUP.
+func Watch(path string, interval time.Duration) (*Watcher, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	cfg, err := parseFile(path, data)
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{path: path, cur: cfg, raw: data, stop: make(chan struct{}), done: make(chan struct{})}
+	if interval <= 0 {
+		close(w.done)
+		return w, nil
+	}
+	go w.poll(interval)
+	return w, nil
+}
+
+// Current returns the configuration in effect. Treat it as read-only; a
+// reload replaces it rather than modifying it.
+func (w *Watcher) Current() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.cur
+}
+
+// OnReload adds a hook called with the previous and the new configuration
+// after each accepted change. Hooks run one at a time, in the order added.
+func (w *Watcher) OnReload(fn func(old, cur *Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onReload = append(w.onReload, fn)
+}
+
+// OnError adds a hook called when a changed file cannot be read or is
+// invalid.
+func (w *Watcher) OnError(fn func(error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onError = append(w.onError, fn)
+}
+
+// Reload reads the file now. It does nothing if the file is unchanged, and
+// reports an invalid file both to the OnError hooks and to the caller. An
+// invalid file is reported to the hooks once, not on every poll.
+func (w *Watcher) Reload() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	data, err := os.ReadFile(filepath.Clean(w.path))
+	if err != nil {
+		err = fmt.Errorf("read config: %w", err)
+		w.fail(err)
+		return err
+	}
+	w.mu.Lock()
+	same := bytes.Equal(data, w.raw)
+	seen := w.rejected != nil && bytes.Equal(data, w.rejected)
+	w.mu.Unlock()
+	if same {
+		return nil
+	}
+	cfg, err := parseFile(w.path, data)
+	if err != nil {
+		if !seen {
+			w.mu.Lock()
+			w.rejected = data
+			w.mu.Unlock()
+			w.fail(err)
+		}
+		return err
+	}
+
+	w.mu.Lock()
+	old := w.cur
+	w.cur, w.raw, w.rejected = cfg, data, nil
+	hooks := slices.Clone(w.onReload)
+	w.mu.Unlock()
+	for _, fn := range hooks {
+		fn(old, cfg)
+	}
+	return nil
+}
+
+func (w *Watcher) fail(err error) {
+	w.mu.Lock()
+	hooks := slices.Clone(w.onError)
+	w.mu.Unlock()
+	for _, fn := range hooks {
+		fn(err)
+	}
+}
+
+func (w *Watcher) poll(interval time.Duration) {
+	defer close(w.done)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-t.C:
+			_ = w.Reload()
+		}
+	}
+}
+
+// Close stops polling and waits for a reload in progress to finish.
+func (w *Watcher) Close() error {
+	if w == nil {
+		return errors.New("nil watcher")
+	}
+	w.closeOnce.Do(func() { close(w.stop) })
+	<-w.done
+	return nil
+}
@@ -13,6 +13,36 @@
 //   - Secp256k1 (Bitcoin curve)
 //   - Ed25519 (EdDSA curve)
 //
+// BLS12-381 is not supported: the upstream library has no pairing-friendly
+// curve (see docs/adr/0007-threshold-bls.md).
+//
+// # Hashing to Curves and Point Encodings
+//
+// HashToPoint hashes a message to a point with the RFC 9380 random-oracle
+// suite of the curve (HashToCurveSuite), for independent generators in custom
+// proofs. Point.Bytes returns the compressed encoding; Point.UncompressedBytes
+// and NewPointFromBytes also handle uncompressed SEC 1 points, and
+// CompressPoint and DecompressPoint convert between the two without creating
+// a Point:
+//
+//	h, err := curve.HashToPoint(curve.P256, msg, []byte("myapp-v1-generator"))
+//	defer h.Free()
+//	raw, err := h.UncompressedBytes() // 0x04 || x || y
+//
+// # X25519
+//
+// X25519KeyGen, X25519PublicKey and X25519 provide RFC 7748 key agreement,
+// for example to set up an encrypted channel between parties. X25519 keys are
+// not points on a Curve; the private key is a Scalar, freed like any other,
+// and public keys and shared secrets are 32-byte strings:
+//
+//	priv, pub, err := curve.X25519KeyGen()
+//	if err != nil {
+//	    return err
+//	}
+//	defer priv.Free()
+//	shared, err := curve.X25519(priv, peerPub)
+//
 // # Key Types
 //
 //   - Curve: Enum representing an elliptic curve
@@ -47,5 +77,11 @@
 //	commitment, err := curve.MakeElGamalCom(basePoint, x, r)
 //	defer commitment.Free()
 //
+//	// Combine commitments without exporting their points
+//	sum, err := commitment.Add(other)           // commits to x + y
+//	scaled, err := commitment.ScalarMul(s)      // commits to s * x
+//	fresh, err := commitment.Rerandomize(basePoint, r2)
+//	same := fresh.Equal(commitment)             // false: new randomness
+//
 // See cb-mpc/src/cbmpc/crypto/ for underlying cryptographic implementations.
 package curve
@@ -157,6 +157,95 @@ func (c *ECElGamalCom) PointR() (*Point, error) {
 	return p, nil
 }
 
+// Add returns the sum of two commitments on the same curve, which commits to
+// the sum of their messages under the sum of their randomness.
+// Returns a new ECElGamalCom that must be freed with Free() when no longer needed.
+func (c *ECElGamalCom) Add(other *ECElGamalCom) (*ECElGamalCom, error) {
+	if c == nil || c.ceccom == nil || other == nil || other.ceccom == nil {
+		return nil, errors.New("nil EC ElGamal commitment")
+	}
+
+	ceccom, err := backend.ECElGamalCommitmentAdd(c.ceccom, other.ceccom)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(other)
+
+	return newECElGamalCom(ceccom), nil
+}
+
+// ScalarMul multiplies the commitment by s, which commits to s times the
+// message under s times the randomness.
+// Returns a new ECElGamalCom that must be freed with Free() when no longer needed.
+func (c *ECElGamalCom) ScalarMul(s *Scalar) (*ECElGamalCom, error) {
+	if c == nil || c.ceccom == nil {
+		return nil, errors.New("nil EC ElGamal commitment")
+	}
+	if s == nil {
+		return nil, errors.New("nil scalar")
+	}
+
+	ceccom, err := backend.ECElGamalCommitmentScalarMul(c.ceccom, s.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(s)
+
+	return newECElGamalCom(ceccom), nil
+}
+
+// Rerandomize returns (L + r*G, R + r*P), which commits to the same message
+// under public key P with the randomness increased by r. Rerandomized
+// commitments cannot be linked to the original without r.
+// Returns a new ECElGamalCom that must be freed with Free() when no longer needed.
+func (c *ECElGamalCom) Rerandomize(p *Point, r *Scalar) (*ECElGamalCom, error) {
+	if c == nil || c.ceccom == nil {
+		return nil, errors.New("nil EC ElGamal commitment")
+	}
+	if p == nil || p.cpoint == nil {
+		return nil, errors.New("nil point P")
+	}
+	if r == nil {
+		return nil, errors.New("nil scalar")
+	}
+
+	ceccom, err := backend.ECElGamalCommitmentRerand(c.ceccom, p.cpoint, r.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(p)
+	runtime.KeepAlive(r)
+
+	return newECElGamalCom(ceccom), nil
+}
+
+// Equal reports whether both commitments have the same L and R points.
+// Nil or freed commitments are never equal.
+func (c *ECElGamalCom) Equal(other *ECElGamalCom) bool {
+	if c == nil || c.ceccom == nil || other == nil || other.ceccom == nil {
+		return false
+	}
+
+	equal, err := backend.ECElGamalCommitmentEqual(c.ceccom, other.ceccom)
+
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(other)
+
+	return err == nil && equal
+}
+
+func newECElGamalCom(ceccom backend.ECElGamalCommitment) *ECElGamalCom {
+	c := &ECElGamalCom{ceccom: ceccom}
+	runtime.SetFinalizer(c, (*ECElGamalCom).Free)
+	return c
+}
+
 // Free releases the resources associated with this EC ElGamal commitment.
 // This is called automatically by the garbage collector via finalizer,
 // but can be called explicitly for immediate cleanup.
@@ -544,3 +544,126 @@ func TestECElGamalComLoadInvalidCurve(t *testing.T) {
 
 	t.Logf("LoadECElGamalCom correctly rejected wrong curve: %v", err)
 }
+
+// TestECElGamalComArithmetic tests that Add, ScalarMul and Rerandomize act on
+// the committed message and randomness as expected.
+func TestECElGamalComArithmetic(t *testing.T) {
+	for _, c := range []curve.Curve{curve.P256, curve.Secp256k1} {
+		t.Run(c.String(), func(t *testing.T) {
+			random := func() *curve.Scalar {
+				s, err := curve.RandomScalar(c)
+				if err != nil {
+					t.Fatalf("RandomScalar failed: %v", err)
+				}
+				t.Cleanup(s.Free)
+				return s
+			}
+			commitTo := func(p *curve.Point, m, r *curve.Scalar) *curve.ECElGamalCom {
+				com, err := curve.MakeElGamalCom(p, m, r)
+				if err != nil {
+					t.Fatalf("MakeElGamalCom failed: %v", err)
+				}
+				t.Cleanup(com.Free)
+				return com
+			}
+			sum := func(a, b *curve.Scalar) *curve.Scalar {
+				s, err := a.Add(b, c)
+				if err != nil {
+					t.Fatalf("Scalar Add failed: %v", err)
+				}
+				return s
+			}
+
+			p, err := curve.MulGenerator(c, random())
+			if err != nil {
+				t.Fatalf("MulGenerator failed: %v", err)
+			}
+			defer p.Free()
+			m1, r1, m2, r2 := random(), random(), random(), random()
+			a, b := commitTo(p, m1, r1), commitTo(p, m2, r2)
+
+			if !a.Equal(commitTo(p, m1, r1)) {
+				t.Fatal("equal commitments compare unequal")
+			}
+			if a.Equal(b) {
+				t.Fatal("different commitments compare equal")
+			}
+
+			ab, err := a.Add(b)
+			if err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+			defer ab.Free()
+			if !ab.Equal(commitTo(p, sum(m1, m2), sum(r1, r2))) {
+				t.Fatal("A + B does not commit to m1 + m2 under r1 + r2")
+			}
+
+			s := random()
+			sa, err := a.ScalarMul(s)
+			if err != nil {
+				t.Fatalf("ScalarMul failed: %v", err)
+			}
+			defer sa.Free()
+			sm, _ := s.Mul(m1, c)
+			sr, _ := s.Mul(r1, c)
+			if !sa.Equal(commitTo(p, sm, sr)) {
+				t.Fatal("s * A does not commit to s * m1 under s * r1")
+			}
+
+			r := random()
+			ra, err := a.Rerandomize(p, r)
+			if err != nil {
+				t.Fatalf("Rerandomize failed: %v", err)
+			}
+			defer ra.Free()
+			if ra.Equal(a) {
+				t.Fatal("rerandomized commitment equals the original")
+			}
+			if !ra.Equal(commitTo(p, m1, sum(r1, r))) {
+				t.Fatal("rerandomized commitment does not commit to m1 under r1 + r")
+			}
+		})
+	}
+}
+
+// TestECElGamalComArithmeticErrors tests nil inputs and mixed curves.
+func TestECElGamalComArithmeticErrors(t *testing.T) {
+	newCom := func(c curve.Curve) *curve.ECElGamalCom {
+		s, err := curve.RandomScalar(c)
+		if err != nil {
+			t.Fatalf("RandomScalar failed: %v", err)
+		}
+		p, err := curve.MulGenerator(c, s)
+		if err != nil {
+			t.Fatalf("MulGenerator failed: %v", err)
+		}
+		com, err := curve.MakeElGamalCom(p, s, s)
+		if err != nil {
+			t.Fatalf("MakeElGamalCom failed: %v", err)
+		}
+		return com
+	}
+	p256, k1 := newCom(curve.P256), newCom(curve.Secp256k1)
+	defer p256.Free()
+	defer k1.Free()
+
+	var nilCom *curve.ECElGamalCom
+	if _, err := nilCom.Add(p256); err == nil {
+		t.Error("Add on nil commitment succeeded")
+	}
+	if _, err := p256.Add(nil); err == nil {
+		t.Error("Add of nil commitment succeeded")
+	}
+	if _, err := p256.Add(k1); err == nil {
+		t.Error("Add across curves succeeded")
+	}
+	if _, err := p256.ScalarMul(nil); err == nil {
+		t.Error("ScalarMul by nil scalar succeeded")
+	}
+	if _, err := p256.Rerandomize(nil, nil); err == nil {
+		t.Error("Rerandomize with nil point succeeded")
+	}
+	if nilCom.Equal(nilCom) || p256.Equal(nil) {
+		t.Error("nil commitments compare equal")
+	}
+}
@@ -0,0 +1,121 @@
+package curve
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// weierstrass holds the parameters of a short Weierstrass curve
+// y^2 = x^3 + a*x + b over GF(p), with field elements of size bytes.
+type weierstrass struct {
+	p, a, b *big.Int
+	size    int
+}
+
+var secp256k1P, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+
+func nist(params *elliptic.CurveParams) *weierstrass {
+	return &weierstrass{
+		p:    params.P,
+		a:    new(big.Int).Sub(params.P, big.NewInt(3)),
+		b:    params.B,
+		size: (params.BitSize + 7) / 8,
+	}
+}
+
+var weierstrassCurves = map[Curve]*weierstrass{
+	P256:      nist(elliptic.P256().Params()),
+	P384:      nist(elliptic.P384().Params()),
+	P521:      nist(elliptic.P521().Params()),
+	Secp256k1: {p: secp256k1P, a: new(big.Int), b: big.NewInt(7), size: 32},
+}
+
+func weierstrassCurve(c Curve) (*weierstrass, error) {
+	w, ok := weierstrassCurves[c]
+	if !ok {
+		return nil, fmt.Errorf("no SEC 1 point encoding for curve %v", c)
+	}
+	return w, nil
+}
+
+// rhs returns x^3 + a*x + b mod p.
+func (w *weierstrass) rhs(x *big.Int) *big.Int {
+	r := new(big.Int).Mul(x, x)
+	r.Add(r, w.a)
+	r.Mul(r, x)
+	r.Add(r, w.b)
+	return r.Mod(r, w.p)
+}
+
+func (w *weierstrass) onCurve(x, y *big.Int) bool {
+	if x.Sign() < 0 || x.Cmp(w.p) >= 0 || y.Sign() < 0 || y.Cmp(w.p) >= 0 {
+		return false
+	}
+	y2 := new(big.Int).Mul(y, y)
+	return y2.Mod(y2, w.p).Cmp(w.rhs(x)) == 0
+}
+
+func (w *weierstrass) compress(x, y *big.Int) []byte {
+	out := make([]byte, 1+w.size)
+	out[0] = 2 | byte(y.Bit(0))
+	x.FillBytes(out[1:])
+	return out
+}
+
+// CompressPoint converts an uncompressed SEC 1 point (0x04 || x || y) on a
+// Weierstrass curve to its compressed form (0x02 or 0x03 || x), the encoding
+// Point and the key types use. It fails for points not on the curve and for
+// Ed25519, which has no SEC 1 encoding.
+func CompressPoint(c Curve, uncompressed []byte) ([]byte, error) {
+	w, err := weierstrassCurve(c)
+	if err != nil {
+		return nil, err
+	}
+	if len(uncompressed) != 1+2*w.size || uncompressed[0] != 4 {
+		return nil, fmt.Errorf("invalid uncompressed %v point", c)
+	}
+	x := new(big.Int).SetBytes(uncompressed[1 : 1+w.size])
+	y := new(big.Int).SetBytes(uncompressed[1+w.size:])
+	if !w.onCurve(x, y) {
+		return nil, errors.New("point not on curve")
+	}
+	return w.compress(x, y), nil
+}
+
+// DecompressPoint converts a compressed SEC 1 point on a Weierstrass curve to
+// its uncompressed form (0x04 || x || y), for systems that exchange
+// uncompressed points. It fails for invalid points and for Ed25519.
+func DecompressPoint(c Curve, compressed []byte) ([]byte, error) {
+	w, err := weierstrassCurve(c)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressed) != 1+w.size || (compressed[0] != 2 && compressed[0] != 3) {
+		return nil, fmt.Errorf("invalid compressed %v point", c)
+	}
+	x := new(big.Int).SetBytes(compressed[1:])
+	if x.Cmp(w.p) >= 0 {
+		return nil, errors.New("point not on curve")
+	}
+	y := new(big.Int).ModSqrt(w.rhs(x), w.p)
+	if y == nil {
+		return nil, errors.New("point not on curve")
+	}
+	if y.Bit(0) != uint(compressed[0]&1) {
+		y.Sub(w.p, y)
+	}
+	out := make([]byte, 1+2*w.size)
+	out[0] = 4
+	x.FillBytes(out[1 : 1+w.size])
+	y.FillBytes(out[1+w.size:])
+	return out, nil
+}
+
+// isUncompressed reports whether b has the length and prefix of an
+// uncompressed SEC 1 point on c.
+func isUncompressed(c Curve, b []byte) bool {
+	w, ok := weierstrassCurves[c]
+	return ok && len(b) == 1+2*w.size && b[0] == 4
+}
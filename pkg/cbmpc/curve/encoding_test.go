@@ -0,0 +1,57 @@
+package curve_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+)
+
+// The RFC 9380 P256_XMD:SHA-256_SSWU_RO_ point for the empty message.
+const (
+	p256Compressed   = "032c15230b26dbc6fc9a37051158c95b79656e17a1a920b11394ca91c44247d3e4"
+	p256Uncompressed = "042c15230b26dbc6fc9a37051158c95b79656e17a1a920b11394ca91c44247d3e4" +
+		"8a7a74985cc5c776cdfe4b1f19884970453912e9d31528c060be9ab5c43e8415"
+)
+
+func TestPointCompression(t *testing.T) {
+	compressed, _ := hex.DecodeString(p256Compressed)
+	uncompressed, _ := hex.DecodeString(p256Uncompressed)
+
+	got, err := curve.DecompressPoint(curve.P256, compressed)
+	if err != nil {
+		t.Fatalf("DecompressPoint: %v", err)
+	}
+	if !bytes.Equal(got, uncompressed) {
+		t.Fatalf("DecompressPoint = %x, want %x", got, uncompressed)
+	}
+	got, err = curve.CompressPoint(curve.P256, uncompressed)
+	if err != nil {
+		t.Fatalf("CompressPoint: %v", err)
+	}
+	if !bytes.Equal(got, compressed) {
+		t.Fatalf("CompressPoint = %x, want %x", got, compressed)
+	}
+
+	offCurve := bytes.Clone(uncompressed)
+	offCurve[len(offCurve)-1] ^= 1
+	if _, err := curve.CompressPoint(curve.P256, offCurve); err == nil {
+		t.Fatal("CompressPoint accepted a point not on the curve")
+	}
+	if _, err := curve.DecompressPoint(curve.P384, compressed); err == nil {
+		t.Fatal("DecompressPoint accepted a P-256 point as P-384")
+	}
+	if _, err := curve.DecompressPoint(curve.Ed25519, make([]byte, 32)); err == nil {
+		t.Fatal("DecompressPoint accepted an Ed25519 point")
+	}
+}
+
+func TestHashToCurveSuite(t *testing.T) {
+	if got := curve.HashToCurveSuite(curve.Secp256k1); got != "secp256k1_XMD:SHA-256_SSWU_RO_" {
+		t.Fatalf("HashToCurveSuite(secp256k1) = %q", got)
+	}
+	if got := curve.HashToCurveSuite(curve.Unknown); got != "" {
+		t.Fatalf("HashToCurveSuite(Unknown) = %q", got)
+	}
+}
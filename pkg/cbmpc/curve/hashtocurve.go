@@ -0,0 +1,377 @@
+package curve
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+)
+
+// h2cSuite is an RFC 9380 hash-to-curve suite in its random-oracle (_RO_)
+// variant.
+type h2cSuite struct {
+	id   string
+	hash func() hash.Hash
+	// l is the number of bytes hashed into each field element.
+	l int
+	// z is the SSWU or Elligator 2 non-square constant.
+	z int64
+}
+
+var h2cSuites = map[Curve]h2cSuite{
+	P256:      {id: "P256_XMD:SHA-256_SSWU_RO_", hash: sha256.New, l: 48, z: -10},
+	P384:      {id: "P384_XMD:SHA-384_SSWU_RO_", hash: sha512.New384, l: 72, z: -12},
+	P521:      {id: "P521_XMD:SHA-512_SSWU_RO_", hash: sha512.New, l: 98, z: -4},
+	Secp256k1: {id: "secp256k1_XMD:SHA-256_SSWU_RO_", hash: sha256.New, l: 48, z: -11},
+	Ed25519:   {id: "edwards25519_XMD:SHA-512_ELL2_RO_", hash: sha512.New, l: 48, z: 2},
+}
+
+// HashToCurveSuite returns the RFC 9380 suite HashToPoint uses for c, for
+// example "P256_XMD:SHA-256_SSWU_RO_", or "" if c has none.
+func HashToCurveSuite(c Curve) string {
+	return h2cSuites[c].id
+}
+
+// hashToCurve hashes msg to a point on c with domain separation tag dst as
+// RFC 9380 specifies, and returns the point in the encoding NewPointFromBytes
+// takes. The arithmetic uses math/big and is not constant time, so msg
+// should not be secret.
+func hashToCurve(c Curve, msg, dst []byte) ([]byte, error) {
+	s, ok := h2cSuites[c]
+	if !ok {
+		return nil, fmt.Errorf("no hash-to-curve suite for curve %v", c)
+	}
+	if len(dst) == 0 {
+		return nil, errors.New("empty domain separation tag")
+	}
+	if c == Ed25519 {
+		u, err := hashToField(s, ed25519P, msg, dst)
+		if err != nil {
+			return nil, err
+		}
+		x0, y0 := elligator2Edwards(u[0])
+		x1, y1 := elligator2Edwards(u[1])
+		x, y := edwardsAdd(x0, y0, x1, y1)
+		for range 3 { // clear the cofactor 8
+			x, y = edwardsAdd(x, y, x, y)
+		}
+		return edwardsEncode(x, y), nil
+	}
+
+	w := weierstrassCurves[c]
+	u, err := hashToField(s, w.p, msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	x0, y0 := mapToCurve(c, w, s, u[0])
+	x1, y1 := mapToCurve(c, w, s, u[1])
+	x, y := w.add(x0, y0, x1, y1)
+	if x == nil {
+		return nil, errors.New("hash-to-curve produced the point at infinity")
+	}
+	return w.compress(x, y), nil
+}
+
+// expandMessageXMD is expand_message_xmd of RFC 9380, section 5.3.1.
+func expandMessageXMD(h func() hash.Hash, msg, dst []byte, n int) ([]byte, error) {
+	hh := h()
+	bLen, sLen := hh.Size(), hh.BlockSize()
+	ell := (n + bLen - 1) / bLen
+	if ell > 255 || n > 65535 {
+		return nil, errors.New("expand_message_xmd: requested length too large")
+	}
+	if len(dst) > 255 {
+		hh.Write([]byte("H2C-OVERSIZE-DST-"))
+		hh.Write(dst)
+		dst = hh.Sum(nil)
+		hh.Reset()
+	}
+	dstPrime := append(append([]byte(nil), dst...), byte(len(dst)))
+
+	hh.Write(make([]byte, sLen))
+	hh.Write(msg)
+	hh.Write([]byte{byte(n >> 8), byte(n), 0})
+	hh.Write(dstPrime)
+	b0 := hh.Sum(nil)
+
+	out := make([]byte, 0, ell*bLen)
+	prev := make([]byte, bLen)
+	for i := 1; i <= ell; i++ {
+		for j := range prev {
+			prev[j] ^= b0[j]
+		}
+		hh.Reset()
+		hh.Write(prev)
+		hh.Write([]byte{byte(i)})
+		hh.Write(dstPrime)
+		prev = hh.Sum(prev[:0])
+		out = append(out, prev...)
+	}
+	return out[:n], nil
+}
+
+// hashToField is hash_to_field of RFC 9380, section 5.2, with count 2.
+func hashToField(s h2cSuite, p *big.Int, msg, dst []byte) ([2]*big.Int, error) {
+	var u [2]*big.Int
+	uniform, err := expandMessageXMD(s.hash, msg, dst, 2*s.l)
+	if err != nil {
+		return u, err
+	}
+	for i := range u {
+		u[i] = new(big.Int).SetBytes(uniform[i*s.l : (i+1)*s.l])
+		u[i].Mod(u[i], p)
+	}
+	return u, nil
+}
+
+func isSquare(x, p *big.Int) bool {
+	return x.Sign() == 0 || big.Jacobi(x, p) == 1
+}
+
+// inv0 returns 1/x mod p, or 0 for x = 0.
+func inv0(x, p *big.Int) *big.Int {
+	if x.Sign() == 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).ModInverse(x, p)
+}
+
+func neg(x, p *big.Int) *big.Int {
+	r := new(big.Int).Neg(x)
+	return r.Mod(r, p)
+}
+
+// sswu is the simplified SWU map of RFC 9380, section 6.6.2, onto
+// y^2 = x^3 + a*x + b over GF(p).
+func sswu(p, a, b *big.Int, z int64, u *big.Int) (*big.Int, *big.Int) {
+	mod := func(x *big.Int) *big.Int { return x.Mod(x, p) }
+	Z := mod(big.NewInt(z))
+	u2 := mod(new(big.Int).Mul(u, u))
+	zu2 := mod(new(big.Int).Mul(Z, u2))
+	tv1 := mod(new(big.Int).Mul(zu2, zu2))
+	tv1 = inv0(mod(tv1.Add(tv1, zu2)), p)
+
+	var x1 *big.Int
+	if tv1.Sign() == 0 {
+		x1 = mod(new(big.Int).Mul(b, inv0(mod(new(big.Int).Mul(Z, a)), p)))
+	} else {
+		x1 = mod(new(big.Int).Mul(neg(b, p), inv0(a, p)))
+		x1 = mod(x1.Mul(x1, new(big.Int).Add(big.NewInt(1), tv1)))
+	}
+	g := func(x *big.Int) *big.Int {
+		r := new(big.Int).Mul(x, x)
+		r.Add(r, a)
+		r.Mul(r, x)
+		r.Add(r, b)
+		return mod(r)
+	}
+
+	x, gx := x1, g(x1)
+	if !isSquare(gx, p) {
+		x = mod(new(big.Int).Mul(zu2, x1))
+		gx = g(x)
+	}
+	y := new(big.Int).ModSqrt(gx, p)
+	if u.Bit(0) != y.Bit(0) {
+		y = neg(y, p)
+	}
+	return x, y
+}
+
+// secp256k1 maps through the 3-isogenous curve E' of RFC 9380, section 8.7.
+var (
+	secp256k1IsoA = hexInt("3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533")
+	secp256k1IsoB = big.NewInt(1771)
+	secp256k1Iso  = [4][]*big.Int{
+		{ // x numerator
+			hexInt("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa8c7"),
+			hexInt("07d3d4c80bc321d5b9f315cea7fd44c5d595d2fc0bf63b92dfff1044f17c6581"),
+			hexInt("534c328d23f234e6e2a413deca25caece4506144037c40314ecbd0b53d9dd262"),
+			hexInt("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa88c"),
+		},
+		{ // x denominator
+			hexInt("d35771193d94918a9ca34ccbb7b640dd86cd409542f8487d9fe6b745781eb49b"),
+			hexInt("edadc6f64383dc1df7c4b2d51b54225406d36b641f5e41bbc52a56612a8c6d14"),
+			big.NewInt(1),
+		},
+		{ // y numerator
+			hexInt("4bda12f684bda12f684bda12f684bda12f684bda12f684bda12f684b8e38e23c"),
+			hexInt("c75e0c32d5cb7c0fa9d0a54b12a0a6d5647ab046d686da6fdffc90fc201d71a3"),
+			hexInt("29a6194691f91a73715209ef6512e576722830a201be2018a765e85a9ecee931"),
+			hexInt("2f684bda12f684bda12f684bda12f684bda12f684bda12f684bda12f38e38d84"),
+		},
+		{ // y denominator
+			hexInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffff93b"),
+			hexInt("7a06534bb8bdb49fd5e9e6632722c2989467c1bfc8e8d978dfb425d2685c2573"),
+			hexInt("6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f"),
+			big.NewInt(1),
+		},
+	}
+)
+
+func hexInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("curve: bad constant " + s)
+	}
+	return n
+}
+
+// poly evaluates the polynomial with coefficients k (constant term first)
+// at x mod p.
+func poly(k []*big.Int, x, p *big.Int) *big.Int {
+	r := new(big.Int)
+	for i := len(k) - 1; i >= 0; i-- {
+		r.Mul(r, x)
+		r.Add(r, k[i])
+		r.Mod(r, p)
+	}
+	return r
+}
+
+func mapToCurve(c Curve, w *weierstrass, s h2cSuite, u *big.Int) (*big.Int, *big.Int) {
+	if c != Secp256k1 {
+		return sswu(w.p, w.a, w.b, s.z, u)
+	}
+	xp, yp := sswu(w.p, secp256k1IsoA, secp256k1IsoB, s.z, u)
+	xNum := poly(secp256k1Iso[0], xp, w.p)
+	xDen := poly(secp256k1Iso[1], xp, w.p)
+	yNum := poly(secp256k1Iso[2], xp, w.p)
+	yDen := poly(secp256k1Iso[3], xp, w.p)
+	x := new(big.Int).Mul(xNum, inv0(xDen, w.p))
+	y := new(big.Int).Mul(yNum, inv0(yDen, w.p))
+	y.Mul(y, yp)
+	return x.Mod(x, w.p), y.Mod(y, w.p)
+}
+
+// add returns the affine sum of two points, with nil for the point at
+// infinity.
+func (w *weierstrass) add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	p := w.p
+	var lambda *big.Int
+	if x1.Cmp(x2) == 0 {
+		if y1.Cmp(y2) != 0 || y1.Sign() == 0 {
+			return nil, nil
+		}
+		num := new(big.Int).Mul(x1, x1)
+		num.Mul(num, big.NewInt(3))
+		num.Add(num, w.a)
+		den := new(big.Int).Lsh(y1, 1)
+		lambda = num.Mul(num, new(big.Int).ModInverse(den.Mod(den, p), p))
+	} else {
+		num := new(big.Int).Sub(y2, y1)
+		den := new(big.Int).Sub(x2, x1)
+		lambda = num.Mul(num, new(big.Int).ModInverse(den.Mod(den, p), p))
+	}
+	lambda.Mod(lambda, p)
+	x3 := new(big.Int).Mul(lambda, lambda)
+	x3.Sub(x3, x1)
+	x3.Sub(x3, x2)
+	x3.Mod(x3, p)
+	y3 := new(big.Int).Sub(x1, x3)
+	y3.Mul(y3, lambda)
+	y3.Sub(y3, y1)
+	return x3, y3.Mod(y3, p)
+}
+
+var (
+	ed25519P = hexInt("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed")
+	// ed25519D is -121665/121666.
+	ed25519D = func() *big.Int {
+		d := new(big.Int).ModInverse(big.NewInt(121666), ed25519P)
+		d.Mul(d, big.NewInt(-121665))
+		return d.Mod(d, ed25519P)
+	}()
+	// ed25519MapC is sqrt(-486664) with sgn0 = 0, the constant of the
+	// rational map from curve25519 to edwards25519.
+	ed25519MapC = func() *big.Int {
+		c := new(big.Int).ModSqrt(neg(big.NewInt(486664), ed25519P), ed25519P)
+		if c.Bit(0) == 1 {
+			c = neg(c, ed25519P)
+		}
+		return c
+	}()
+)
+
+// elligator2Edwards maps u to edwards25519 with the Elligator 2 map onto
+// curve25519 (RFC 9380, section 6.7.1) followed by the rational map of
+// section 6.8.2.
+func elligator2Edwards(u *big.Int) (*big.Int, *big.Int) {
+	p := ed25519P
+	mod := func(x *big.Int) *big.Int { return x.Mod(x, p) }
+	J := big.NewInt(486662)
+	g := func(x *big.Int) *big.Int { // x^3 + J*x^2 + x
+		r := new(big.Int).Add(x, J)
+		r.Mul(r, x)
+		r.Add(r, big.NewInt(1))
+		r.Mul(r, x)
+		return mod(r)
+	}
+
+	den := new(big.Int).Mul(u, u)
+	den.Mul(den, big.NewInt(2))
+	den.Add(den, big.NewInt(1))
+	x1 := mod(new(big.Int).Mul(neg(J, p), inv0(mod(den), p)))
+	if x1.Sign() == 0 {
+		x1 = neg(J, p)
+	}
+	var s, t *big.Int
+	if gx1 := g(x1); isSquare(gx1, p) {
+		s, t = x1, new(big.Int).ModSqrt(gx1, p)
+		if t.Bit(0) == 0 {
+			t = neg(t, p)
+		}
+	} else {
+		s = mod(new(big.Int).Sub(neg(x1, p), J))
+		t = new(big.Int).ModSqrt(g(s), p)
+		if t.Bit(0) == 1 {
+			t = neg(t, p)
+		}
+	}
+
+	// (s, t) on curve25519 to (x, y) on edwards25519; the exceptional
+	// points map to the identity.
+	sp1 := mod(new(big.Int).Add(s, big.NewInt(1)))
+	if t.Sign() == 0 || sp1.Sign() == 0 {
+		return new(big.Int), big.NewInt(1)
+	}
+	x := new(big.Int).Mul(ed25519MapC, s)
+	x.Mul(x, new(big.Int).ModInverse(t, p))
+	y := new(big.Int).Sub(s, big.NewInt(1))
+	y.Mul(y, new(big.Int).ModInverse(sp1, p))
+	return mod(x), mod(y)
+}
+
+// edwardsAdd adds two points on edwards25519 (a = -1); the formulas are
+// complete, so doubling needs no special case.
+func edwardsAdd(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	p := ed25519P
+	x1y2 := new(big.Int).Mul(x1, y2)
+	y1x2 := new(big.Int).Mul(y1, x2)
+	y1y2 := new(big.Int).Mul(y1, y2)
+	x1x2 := new(big.Int).Mul(x1, x2)
+	dxy := new(big.Int).Mul(ed25519D, x1x2)
+	dxy.Mul(dxy, y1y2)
+	dxy.Mod(dxy, p)
+
+	xn := new(big.Int).Add(x1y2, y1x2)
+	xd := new(big.Int).Add(big.NewInt(1), dxy)
+	yn := new(big.Int).Add(y1y2, x1x2)
+	yd := new(big.Int).Sub(big.NewInt(1), dxy)
+	x := xn.Mul(xn, new(big.Int).ModInverse(xd.Mod(xd, p), p))
+	y := yn.Mul(yn, new(big.Int).ModInverse(yd.Mod(yd, p), p))
+	return x.Mod(x, p), y.Mod(y, p)
+}
+
+// edwardsEncode returns the 32-byte RFC 8032 encoding of (x, y).
+func edwardsEncode(x, y *big.Int) []byte {
+	out := make([]byte, 32)
+	y.FillBytes(out)
+	for i, j := 0, 31; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	out[31] |= byte(x.Bit(0)) << 7
+	return out
+}
@@ -0,0 +1,62 @@
+//go:build cgo && !windows
+
+package curve_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+)
+
+// TestHashToPoint checks HashToPoint against the empty-message vectors of
+// RFC 9380, appendix J.
+func TestHashToPoint(t *testing.T) {
+	tests := []struct {
+		curve curve.Curve
+		want  string
+	}{
+		{curve.P256, p256Compressed},
+		{curve.P384, "02eb9fe1b4f4e14e7140803c1d99d0a93cd823d2b024040f9c067a8eca1f5a2eeac9ad604973527a356f3fa3aeff0e4d83"},
+		{curve.P521, "0300fd767cebb2452030358d0e9cf907f525f50920c8f607889a6a35680727f64f4d66b161fafeb2654bea0d35086bec0a10b30b14adef3556ed9f7f1bc23cecc9c088"},
+		{curve.Secp256k1, "03c1cae290e291aee617ebaef1be6d73861479c48b841eaba9b7b5852ddfeb1346"},
+		{curve.Ed25519, "21dc15e10253796df23a7699c8a383ea624cce88c52431f6be220b1a56c8a609"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.curve.String(), func(t *testing.T) {
+			dst := "QUUX-V01-CS02-with-" + curve.HashToCurveSuite(tc.curve)
+			p, err := curve.HashToPoint(tc.curve, nil, []byte(dst))
+			if err != nil {
+				t.Fatalf("HashToPoint: %v", err)
+			}
+			defer p.Free()
+			got, err := p.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes: %v", err)
+			}
+			if hex.EncodeToString(got) != tc.want {
+				t.Fatalf("HashToPoint = %x, want %s", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := curve.HashToPoint(curve.P256, []byte("msg"), nil); err == nil {
+		t.Fatal("HashToPoint accepted an empty domain separation tag")
+	}
+}
+
+func TestPointUncompressedBytes(t *testing.T) {
+	uncompressed, _ := hex.DecodeString(p256Uncompressed)
+	p, err := curve.NewPointFromBytes(curve.P256, uncompressed)
+	if err != nil {
+		t.Fatalf("NewPointFromBytes(uncompressed): %v", err)
+	}
+	defer p.Free()
+	got, err := p.UncompressedBytes()
+	if err != nil {
+		t.Fatalf("UncompressedBytes: %v", err)
+	}
+	if hex.EncodeToString(got) != p256Uncompressed {
+		t.Fatalf("UncompressedBytes = %x", got)
+	}
+}
@@ -264,6 +264,65 @@ func TestScalarPointOperations(t *testing.T) {
 	t.Logf("Successfully computed scalar1 * (scalar2 * G), result has %d bytes", len(pointBytes))
 }
 
+// TestScalarArithmetic verifies Sub, Mul and Inverse against their inverses.
+func TestScalarArithmetic(t *testing.T) {
+	curves := []curve.Curve{
+		curve.P256,
+		curve.Secp256k1,
+		curve.Ed25519,
+	}
+
+	for _, c := range curves {
+		t.Run(c.String(), func(t *testing.T) {
+			a, err := curve.RandomScalar(c)
+			if err != nil {
+				t.Fatalf("RandomScalar failed: %v", err)
+			}
+			defer a.Free()
+			b, err := curve.RandomScalar(c)
+			if err != nil {
+				t.Fatalf("RandomScalar failed: %v", err)
+			}
+			defer b.Free()
+
+			// (a + b) - b == a
+			sum, err := a.Add(b, c)
+			if err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+			defer sum.Free()
+			diff, err := sum.Sub(b, c)
+			if err != nil {
+				t.Fatalf("Sub failed: %v", err)
+			}
+			defer diff.Free()
+			if !diff.Equal(a) {
+				t.Fatal("(a + b) - b != a")
+			}
+
+			// (a * b) * b^-1 == a
+			prod, err := a.Mul(b, c)
+			if err != nil {
+				t.Fatalf("Mul failed: %v", err)
+			}
+			defer prod.Free()
+			bInv, err := b.Inverse(c)
+			if err != nil {
+				t.Fatalf("Inverse failed: %v", err)
+			}
+			defer bInv.Free()
+			back, err := prod.Mul(bInv, c)
+			if err != nil {
+				t.Fatalf("Mul failed: %v", err)
+			}
+			defer back.Free()
+			if !back.Equal(a) {
+				t.Fatal("(a * b) * b^-1 != a")
+			}
+		})
+	}
+}
+
 // TestNewScalarFromBytesWithRandomScalar verifies that NewScalarFromBytes works with RandomScalar output.
 func TestNewScalarFromBytesWithRandomScalar(t *testing.T) {
 	c := curve.P256
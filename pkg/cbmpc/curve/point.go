@@ -32,11 +32,17 @@ type Point struct {
 
 // NewPointFromBytes creates a Point from compressed bytes.
 // The bytes should be in compressed format (33 bytes for 256-bit curves).
+// Uncompressed SEC 1 points (0x04 || x || y) are accepted too.
 func NewPointFromBytes(curve Curve, bytes []byte) (*Point, error) {
 	nid, err := backend.CurveToNID(backend.Curve(curve))
 	if err != nil {
 		return nil, err
 	}
+	if isUncompressed(curve, bytes) {
+		if bytes, err = CompressPoint(curve, bytes); err != nil {
+			return nil, err
+		}
+	}
 
 	cpoint, err := backend.ECCPointFromBytes(nid, bytes)
 	if err != nil {
@@ -69,6 +75,31 @@ func (p *Point) Bytes() ([]byte, error) {
 	return result, nil
 }
 
+// UncompressedBytes serializes the Point as an uncompressed SEC 1 point
+// (0x04 || x || y). Ed25519 points have no uncompressed form.
+func (p *Point) UncompressedBytes() ([]byte, error) {
+	b, err := p.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return DecompressPoint(p.Curve(), b)
+}
+
+// HashToPoint hashes msg to a point on c with the domain separation tag dst,
+// using the RFC 9380 random-oracle suite HashToCurveSuite(c) reports. No one
+// knows the discrete logarithm of the result, which makes it suitable as an
+// independent generator in custom proofs. dst must be non-empty and should
+// name the application and its use of the point. The hashing is not constant
+// time, so msg must not be secret.
+// Returns a Point that must be freed with Free() when no longer needed.
+func HashToPoint(c Curve, msg, dst []byte) (*Point, error) {
+	b, err := hashToCurve(c, msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return NewPointFromBytes(c, b)
+}
+
 // Curve returns the curve for this point.
 func (p *Point) Curve() Curve {
 	if p == nil || p.cpoint == nil {
@@ -230,3 +230,84 @@ func (s *Scalar) Add(other *Scalar, curve Curve) (*Scalar, error) {
 	runtime.SetFinalizer(result, (*Scalar).Free)
 	return result, nil
 }
+
+// Sub subtracts two scalars modulo curve order: result = (this - other) mod q.
+// Returns a new Scalar that must be freed with Free() when no longer needed.
+func (s *Scalar) Sub(other *Scalar, curve Curve) (*Scalar, error) {
+	if s == nil || len(s.Bytes) == 0 {
+		return nil, errors.New("nil scalar")
+	}
+	if other == nil || len(other.Bytes) == 0 {
+		return nil, errors.New("nil other scalar")
+	}
+
+	nid, err := backend.CurveToNID(backend.Curve(curve))
+	if err != nil {
+		return nil, err
+	}
+
+	resultBytes, err := backend.ScalarSub(s.Bytes, other.Bytes, nid)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(other)
+
+	result := &Scalar{Bytes: resultBytes}
+	runtime.SetFinalizer(result, (*Scalar).Free)
+	return result, nil
+}
+
+// Mul multiplies two scalars modulo curve order: result = (this * other) mod q.
+// Returns a new Scalar that must be freed with Free() when no longer needed.
+func (s *Scalar) Mul(other *Scalar, curve Curve) (*Scalar, error) {
+	if s == nil || len(s.Bytes) == 0 {
+		return nil, errors.New("nil scalar")
+	}
+	if other == nil || len(other.Bytes) == 0 {
+		return nil, errors.New("nil other scalar")
+	}
+
+	nid, err := backend.CurveToNID(backend.Curve(curve))
+	if err != nil {
+		return nil, err
+	}
+
+	resultBytes, err := backend.ScalarMul(s.Bytes, other.Bytes, nid)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(other)
+
+	result := &Scalar{Bytes: resultBytes}
+	runtime.SetFinalizer(result, (*Scalar).Free)
+	return result, nil
+}
+
+// Inverse returns the multiplicative inverse modulo curve order: result = this^-1 mod q.
+// Returns an error if the scalar is zero modulo q.
+// Returns a new Scalar that must be freed with Free() when no longer needed.
+func (s *Scalar) Inverse(curve Curve) (*Scalar, error) {
+	if s == nil || len(s.Bytes) == 0 {
+		return nil, errors.New("nil scalar")
+	}
+
+	nid, err := backend.CurveToNID(backend.Curve(curve))
+	if err != nil {
+		return nil, err
+	}
+
+	resultBytes, err := backend.ScalarInv(s.Bytes, nid)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime.KeepAlive(s)
+
+	result := &Scalar{Bytes: resultBytes}
+	runtime.SetFinalizer(result, (*Scalar).Free)
+	return result, nil
+}
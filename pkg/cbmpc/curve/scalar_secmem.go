@@ -0,0 +1,17 @@
+package curve
+
+import (
+	"errors"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
+
+// ProtectedBytes returns a copy of the scalar bytes in a secmem.Buffer.
+// Prefer it over CloneBytes when the scalar is secret (a witness, nonce or key
+// share). The caller must Destroy the buffer when done.
+func (s *Scalar) ProtectedBytes() (*secmem.Buffer, error) {
+	if s == nil || len(s.Bytes) == 0 {
+		return nil, errors.New("nil or freed scalar")
+	}
+	return secmem.Copy(s.Bytes)
+}
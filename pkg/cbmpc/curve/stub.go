@@ -157,6 +157,24 @@ func (s *Scalar) Add(other *Scalar, curve Curve) (*Scalar, error) {
 	return nil, errNotBuilt
 }
 
+// Sub subtracts two scalars modulo curve order.
+// This is a stub that returns an error for non-CGO builds.
+func (s *Scalar) Sub(other *Scalar, curve Curve) (*Scalar, error) {
+	return nil, errNotBuilt
+}
+
+// Mul multiplies two scalars modulo curve order.
+// This is a stub that returns an error for non-CGO builds.
+func (s *Scalar) Mul(other *Scalar, curve Curve) (*Scalar, error) {
+	return nil, errNotBuilt
+}
+
+// Inverse returns the multiplicative inverse modulo curve order.
+// This is a stub that returns an error for non-CGO builds.
+func (s *Scalar) Inverse(curve Curve) (*Scalar, error) {
+	return nil, errNotBuilt
+}
+
 // =====================
 // Point stub
 // =====================
@@ -178,6 +196,14 @@ func (p *Point) Curve() Curve {
 
 func (p *Point) Free() {}
 
+func (p *Point) UncompressedBytes() ([]byte, error) {
+	return nil, errNotBuilt
+}
+
+func HashToPoint(Curve, []byte, []byte) (*Point, error) {
+	return nil, errNotBuilt
+}
+
 // CPtr is a stub for non-CGO builds.
 func (p *Point) CPtr() backend.ECCPoint {
 	return nil
@@ -193,6 +219,11 @@ func (p *Point) Mul(*Scalar) (*Point, error) {
 	return nil, errNotBuilt
 }
 
+// Add is a stub for non-CGO builds.
+func (p *Point) Add(*Point) (*Point, error) {
+	return nil, errNotBuilt
+}
+
 // =====================
 // EC ElGamal Commitment stub
 // =====================
@@ -237,6 +268,30 @@ func (c *ECElGamalCom) PointR() (*Point, error) {
 	return nil, errNotBuilt
 }
 
+// Add adds two EC ElGamal commitments.
+// This is a stub that returns an error on non-CGO builds.
+func (c *ECElGamalCom) Add(*ECElGamalCom) (*ECElGamalCom, error) {
+	return nil, errNotBuilt
+}
+
+// ScalarMul multiplies an EC ElGamal commitment by a scalar.
+// This is a stub that returns an error on non-CGO builds.
+func (c *ECElGamalCom) ScalarMul(*Scalar) (*ECElGamalCom, error) {
+	return nil, errNotBuilt
+}
+
+// Rerandomize rerandomizes an EC ElGamal commitment.
+// This is a stub that returns an error on non-CGO builds.
+func (c *ECElGamalCom) Rerandomize(*Point, *Scalar) (*ECElGamalCom, error) {
+	return nil, errNotBuilt
+}
+
+// Equal compares two EC ElGamal commitments.
+// This is a stub that always returns false on non-CGO builds.
+func (c *ECElGamalCom) Equal(*ECElGamalCom) bool {
+	return false
+}
+
 // Free releases the resources associated with this EC ElGamal commitment.
 // This is a no-op on non-CGO builds.
 func (c *ECElGamalCom) Free() {}
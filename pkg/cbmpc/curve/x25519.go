@@ -0,0 +1,96 @@
+package curve
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"errors"
+	"runtime"
+)
+
+// X25519Size is the size of X25519 public keys and shared secrets.
+const X25519Size = 32
+
+// X25519 is not a Curve value: its public keys are Montgomery u-coordinates,
+// which do not fit Point, and no MPC protocol runs on it. Private keys are
+// Scalars holding the clamped 255-bit integer, big-endian like every other
+// Scalar; public keys and shared secrets are the 32-byte little-endian strings
+// of RFC 7748. These functions do not need the native library.
+
+// X25519KeyGen generates an X25519 key pair for ECDH. The private key is a
+// Scalar that must be freed with Free(); the public key is its 32-byte
+// u-coordinate.
+func X25519KeyGen() (*Scalar, []byte, error) {
+	le := make([]byte, X25519Size)
+	defer zeroizeBytes(le)
+	if _, err := rand.Read(le); err != nil {
+		return nil, nil, err
+	}
+	// Clamp now so the Scalar holds the integer that is actually used.
+	le[0] &= 248
+	le[31] &= 127
+	le[31] |= 64
+
+	priv := &Scalar{Bytes: reverse(le)}
+	runtime.SetFinalizer(priv, (*Scalar).Free)
+	pub, err := X25519PublicKey(priv)
+	if err != nil {
+		priv.Free()
+		return nil, nil, err
+	}
+	return priv, pub, nil
+}
+
+// X25519PublicKey returns the public key of an X25519 private key. Scalars
+// not produced by X25519KeyGen are clamped as RFC 7748 specifies.
+func X25519PublicKey(priv *Scalar) ([]byte, error) {
+	k, err := x25519PrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	return k.PublicKey().Bytes(), nil
+}
+
+// X25519 computes the shared secret between priv and the peer's public key.
+// It fails for a peer key of low order, whose shared secret would be zero
+// whatever priv is. Derive symmetric keys from the result with a KDF rather
+// than using it directly.
+func X25519(priv *Scalar, peer []byte) ([]byte, error) {
+	if len(peer) != X25519Size {
+		return nil, errors.New("invalid X25519 public key size")
+	}
+	k, err := x25519PrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := ecdh.X25519().NewPublicKey(peer)
+	if err != nil {
+		return nil, err
+	}
+	return k.ECDH(pub)
+}
+
+func x25519PrivateKey(priv *Scalar) (*ecdh.PrivateKey, error) {
+	if priv == nil || len(priv.Bytes) == 0 {
+		return nil, errors.New("nil scalar")
+	}
+	if len(priv.Bytes) > X25519Size {
+		return nil, errors.New("scalar too large for X25519")
+	}
+	be := make([]byte, X25519Size)
+	copy(be[X25519Size-len(priv.Bytes):], priv.Bytes)
+	runtime.KeepAlive(priv)
+	le := reverse(be)
+	zeroizeBytes(be)
+	defer zeroizeBytes(le)
+	return ecdh.X25519().NewPrivateKey(le)
+}
+
+// reverse returns a reversed copy of b, converting between the big-endian
+// Scalar encoding and the little-endian encoding of RFC 7748.
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i, v := range b {
+		out[len(b)-1-i] = v
+	}
+	return out
+}
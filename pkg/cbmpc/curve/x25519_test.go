@@ -0,0 +1,90 @@
+package curve_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+)
+
+// x25519Scalar turns an RFC 7748 little-endian private key into a Scalar.
+func x25519Scalar(t *testing.T, h string) *curve.Scalar {
+	t.Helper()
+	le, err := hex.DecodeString(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	be := make([]byte, len(le))
+	for i, b := range le {
+		be[len(le)-1-i] = b
+	}
+	return &curve.Scalar{Bytes: be}
+}
+
+// TestX25519RFC7748 checks the Diffie-Hellman vectors of RFC 7748, section 6.1.
+func TestX25519RFC7748(t *testing.T) {
+	alice := x25519Scalar(t, "77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	bob := x25519Scalar(t, "5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb")
+	alicePub, _ := hex.DecodeString("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a")
+	bobPub, _ := hex.DecodeString("de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f")
+	shared, _ := hex.DecodeString("4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742")
+
+	for name, tc := range map[string]struct {
+		priv      *curve.Scalar
+		pub, peer []byte
+	}{
+		"alice": {alice, alicePub, bobPub},
+		"bob":   {bob, bobPub, alicePub},
+	} {
+		pub, err := curve.X25519PublicKey(tc.priv)
+		if err != nil {
+			t.Fatalf("%s: X25519PublicKey: %v", name, err)
+		}
+		if !bytes.Equal(pub, tc.pub) {
+			t.Fatalf("%s: public key %x, want %x", name, pub, tc.pub)
+		}
+		got, err := curve.X25519(tc.priv, tc.peer)
+		if err != nil {
+			t.Fatalf("%s: X25519: %v", name, err)
+		}
+		if !bytes.Equal(got, shared) {
+			t.Fatalf("%s: shared secret %x, want %x", name, got, shared)
+		}
+	}
+}
+
+func TestX25519KeyGen(t *testing.T) {
+	a, aPub, err := curve.X25519KeyGen()
+	if err != nil {
+		t.Fatalf("X25519KeyGen: %v", err)
+	}
+	defer a.Free()
+	b, bPub, err := curve.X25519KeyGen()
+	if err != nil {
+		t.Fatalf("X25519KeyGen: %v", err)
+	}
+	defer b.Free()
+
+	ab, err := curve.X25519(a, bPub)
+	if err != nil {
+		t.Fatalf("X25519: %v", err)
+	}
+	ba, err := curve.X25519(b, aPub)
+	if err != nil {
+		t.Fatalf("X25519: %v", err)
+	}
+	if !bytes.Equal(ab, ba) {
+		t.Fatal("parties derived different shared secrets")
+	}
+
+	if _, err := curve.X25519(a, make([]byte, curve.X25519Size)); err == nil {
+		t.Fatal("X25519 accepted a low-order public key")
+	}
+	if _, err := curve.X25519(a, aPub[:31]); err == nil {
+		t.Fatal("X25519 accepted a short public key")
+	}
+	if _, err := curve.X25519PublicKey(nil); err == nil {
+		t.Fatal("X25519PublicKey accepted a nil scalar")
+	}
+}
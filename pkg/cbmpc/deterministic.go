@@ -0,0 +1,21 @@
+//go:build cbmpc_deterministic
+
+package cbmpc
+
+import "github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+
+// SetDeterministicNonces makes 2-party signing in this process deterministic:
+// every random value an ecdsa2p or schnorr2p signing protocol draws, from the
+// nonce shares to the session ID, comes from a stream keyed RFC 6979-style
+// with seed, the party's key share, the session ID given by the caller, and
+// the messages. Signing the same messages with the same key shares and seed
+// then produces the same transcript and signature, which protocol tests and
+// cross-version regression suites can compare against fixed expectations. A
+// nil seed restores the system RNG.
+//
+// It exists only in builds with the cbmpc_deterministic tag and must never be
+// used outside tests: a nonce that repeats across different messages, or is
+// known to anyone else, reveals the key.
+func SetDeterministicNonces(seed []byte) error {
+	return RemapError(backend.SetNonceSeed(seed))
+}
@@ -0,0 +1,44 @@
+package discovery
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/config"
+)
+
+// FromConfig returns the resolver described by a cluster configuration.
+// Addresses written in the configuration take precedence; the remaining
+// parties are looked up through cfg.Transport.Discovery, if set. The Consul
+// ACL token is read from the CONSUL_HTTP_TOKEN environment variable so it is
+// kept out of configuration files.
+func FromConfig(cfg *config.Config) (Resolver, error) {
+	static := make(Static)
+	for _, p := range cfg.Parties {
+		if p.Address != "" {
+			static[p.Name] = []string{p.Address}
+		}
+	}
+	d := cfg.Transport.Discovery
+	if d == nil {
+		return static, nil
+	}
+	var dynamic Resolver
+	switch d.Kind {
+	case config.DiscoveryDNSSD:
+		dynamic = DNSSD{Domain: d.Domain, Service: d.Service}
+	case config.DiscoveryConsul:
+		dynamic = Consul{
+			Address:    d.Address,
+			Service:    d.Service,
+			Datacenter: d.Datacenter,
+			Token:      os.Getenv("CONSUL_HTTP_TOKEN"),
+		}
+	default:
+		return nil, fmt.Errorf("discovery: unknown kind %q", d.Kind)
+	}
+	if d.HealthCheck {
+		dynamic = Checked{Resolver: dynamic}
+	}
+	return Chain{static, dynamic}, nil
+}
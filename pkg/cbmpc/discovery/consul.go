@@ -0,0 +1,103 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultConsulAddress is the local Consul agent's HTTP API.
+const DefaultConsulAddress = "http://127.0.0.1:8500"
+
+// Consul resolves parties from the Consul catalog through the agent's HTTP
+// API, returning only instances whose health checks are passing. Each party
+// registers an instance of Service tagged with its party name:
+//
+//	{"Name": "cbmpc", "Tags": ["alice"], "Port": 7000}
+type Consul struct {
+	// Address is the base URL of the HTTP API. Empty selects
+	// DefaultConsulAddress.
+	Address string
+	// Service is the service the parties register. Empty selects
+	// DefaultService.
+	Service string
+	// Datacenter to query. Empty queries the agent's datacenter.
+	Datacenter string
+	// Token is sent as the ACL token when not empty.
+	Token string
+	// Client performs the requests. Nil selects http.DefaultClient.
+	Client *http.Client
+}
+
+// consulEntry is the part of a /v1/health/service entry used here.
+type consulEntry struct {
+	Node struct {
+		Address string
+	}
+	Service struct {
+		Address string
+		Port    int
+	}
+}
+
+// Resolve implements Resolver.
+func (c Consul) Resolve(ctx context.Context, name string) ([]string, error) {
+	base := c.Address
+	if base == "" {
+		base = DefaultConsulAddress
+	}
+	service := c.Service
+	if service == "" {
+		service = DefaultService
+	}
+	q := url.Values{"passing": {"1"}, "tag": {name}}
+	if c.Datacenter != "" {
+		q.Set("dc", c.Datacenter)
+	}
+	u := strings.TrimSuffix(base, "/") + "/v1/health/service/" + url.PathEscape(service) + "?" + q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("discovery: consul request: %w", err)
+	}
+	if c.Token != "" {
+		req.Header.Set("X-Consul-Token", c.Token)
+	}
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("discovery: consul: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("discovery: consul: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var entries []consulEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("discovery: consul: decode response: %w", err)
+	}
+	addrs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		host := e.Service.Address
+		if host == "" {
+			host = e.Node.Address
+		}
+		if host == "" || e.Service.Port == 0 {
+			continue
+		}
+		addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(e.Service.Port)))
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("%w: %q (no passing %s instance)", ErrNotFound, name, service)
+	}
+	return addrs, nil
+}
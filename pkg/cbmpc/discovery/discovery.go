@@ -0,0 +1,127 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// ErrNotFound is returned when a resolver has no usable address for a party.
+var ErrNotFound = errors.New("discovery: party not found")
+
+// Resolver looks up the network addresses of a party by name. Addresses are
+// "host:port" strings in order of preference.
+type Resolver interface {
+	Resolve(ctx context.Context, name string) ([]string, error)
+}
+
+// Static is a fixed registry of party addresses.
+type Static map[string][]string
+
+// Resolve returns the registered addresses of name.
+func (s Static) Resolve(_ context.Context, name string) ([]string, error) {
+	addrs := s[name]
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+	return append([]string(nil), addrs...), nil
+}
+
+// Chain tries each resolver in turn and returns the first non-empty answer.
+// A resolver that does not know a party is skipped; any other error stops the
+// lookup, so a registry outage is not mistaken for an absent party.
+type Chain []Resolver
+
+// Resolve implements Resolver.
+func (c Chain) Resolve(ctx context.Context, name string) ([]string, error) {
+	for _, r := range c {
+		addrs, err := r.Resolve(ctx, name)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(addrs) > 0 {
+			return addrs, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrNotFound, name)
+}
+
+// DefaultProbeTimeout bounds a single health probe when Checked.Timeout is
+// zero.
+const DefaultProbeTimeout = 2 * time.Second
+
+// Checked filters the addresses returned by a resolver through a health
+// probe, keeping the order of those that pass.
+type Checked struct {
+	Resolver Resolver
+	// Probe checks one address. Nil selects TCPProbe.
+	Probe func(ctx context.Context, addr string) error
+	// Timeout bounds each probe. Zero selects DefaultProbeTimeout.
+	Timeout time.Duration
+}
+
+// Resolve implements Resolver. It fails with ErrNotFound, joined with the
+// probe errors, when no address passes.
+func (c Checked) Resolve(ctx context.Context, name string) ([]string, error) {
+	addrs, err := c.Resolver.Resolve(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	probe := c.Probe
+	if probe == nil {
+		probe = TCPProbe
+	}
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = DefaultProbeTimeout
+	}
+	var healthy []string
+	var errs []error
+	for _, addr := range addrs {
+		pctx, cancel := context.WithTimeout(ctx, timeout)
+		err := probe(pctx, addr)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", addr, err))
+			continue
+		}
+		healthy = append(healthy, addr)
+	}
+	if len(healthy) == 0 {
+		return nil, fmt.Errorf("%w: no healthy address for %q: %w", ErrNotFound, name, errors.Join(errs...))
+	}
+	return healthy, nil
+}
+
+// TCPProbe reports whether addr accepts a TCP connection.
+func TCPProbe(ctx context.Context, addr string) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// ResolveAll resolves every name and returns the preferred address of each,
+// in the order of names: the Addresses slice expected by transports such as
+// tlsnet.
+func ResolveAll(ctx context.Context, r Resolver, names []string) ([]string, error) {
+	out := make([]string, len(names))
+	for i, name := range names {
+		addrs, err := r.Resolve(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("%w: %q", ErrNotFound, name)
+		}
+		out[i] = addrs[0]
+	}
+	return out, nil
+}
@@ -0,0 +1,139 @@
+package discovery_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/config"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/discovery"
+)
+
+func TestStaticAndChain(t *testing.T) {
+	ctx := context.Background()
+	r := discovery.Chain{
+		discovery.Static{"alice": {"10.0.0.1:7000"}},
+		discovery.Static{"alice": {"10.9.9.9:7000"}, "bob": {"10.0.0.2:7000", "10.0.0.3:7000"}},
+	}
+	addrs, err := discovery.ResolveAll(ctx, r, []string{"bob", "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"10.0.0.2:7000", "10.0.0.1:7000"}; !reflect.DeepEqual(addrs, want) {
+		t.Fatalf("ResolveAll = %v, want %v", addrs, want)
+	}
+	if _, err := r.Resolve(ctx, "carol"); !errors.Is(err, discovery.ErrNotFound) {
+		t.Fatalf("Resolve(carol) err = %v, want ErrNotFound", err)
+	}
+
+	// A failing registry stops the chain rather than falling through.
+	broken := discovery.Consul{Address: "http://127.0.0.1:1"}
+	if _, err := (discovery.Chain{broken, r}).Resolve(ctx, "alice"); err == nil || errors.Is(err, discovery.ErrNotFound) {
+		t.Fatalf("err = %v, want registry error", err)
+	}
+}
+
+func TestChecked(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadAddr := dead.Addr().String()
+	_ = dead.Close()
+
+	ctx := context.Background()
+	r := discovery.Checked{Resolver: discovery.Static{"alice": {deadAddr, ln.Addr().String()}, "bob": {deadAddr}}}
+	addrs, err := r.Resolve(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{ln.Addr().String()}; !reflect.DeepEqual(addrs, want) {
+		t.Fatalf("Resolve = %v, want %v", addrs, want)
+	}
+	if _, err := r.Resolve(ctx, "bob"); !errors.Is(err, discovery.ErrNotFound) || !strings.Contains(err.Error(), deadAddr) {
+		t.Fatalf("Resolve(bob) err = %v, want ErrNotFound naming %s", err, deadAddr)
+	}
+}
+
+func TestConsul(t *testing.T) {
+	var gotQuery, gotToken string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/health/service/mpc" {
+			http.NotFound(w, r)
+			return
+		}
+		gotQuery, gotToken = r.URL.RawQuery, r.Header.Get("X-Consul-Token")
+		entries := []map[string]any{}
+		if r.URL.Query().Get("tag") == "alice" {
+			entries = append(entries,
+				map[string]any{"Node": map[string]any{"Address": "10.0.0.1"}, "Service": map[string]any{"Port": 7000}},
+				map[string]any{"Node": map[string]any{"Address": "10.0.0.1"}, "Service": map[string]any{"Address": "alice.mpc", "Port": 7001}},
+			)
+		}
+		_ = json.NewEncoder(w).Encode(entries)
+	}))
+	defer srv.Close()
+
+	c := discovery.Consul{Address: srv.URL, Service: "mpc", Datacenter: "dc2", Token: "secret"}
+	addrs, err := c.Resolve(context.Background(), "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"10.0.0.1:7000", "alice.mpc:7001"}; !reflect.DeepEqual(addrs, want) {
+		t.Fatalf("Resolve = %v, want %v", addrs, want)
+	}
+	if gotQuery != "dc=dc2&passing=1&tag=alice" || gotToken != "secret" {
+		t.Fatalf("query %q token %q", gotQuery, gotToken)
+	}
+	if _, err := c.Resolve(context.Background(), "bob"); !errors.Is(err, discovery.ErrNotFound) {
+		t.Fatalf("Resolve(bob) err = %v, want ErrNotFound", err)
+	}
+	if _, err := (discovery.Consul{Address: srv.URL, Service: "other"}).Resolve(context.Background(), "alice"); err == nil || errors.Is(err, discovery.ErrNotFound) {
+		t.Fatalf("err = %v, want HTTP error", err)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"Service": map[string]any{"Address": r.URL.Query().Get("tag") + ".mpc", "Port": 7000}},
+		})
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{
+		Parties: []config.Party{{Name: "alice", Address: "10.0.0.1:7000"}, {Name: "bob"}},
+		Transport: config.Transport{
+			Kind:      config.TransportTLS,
+			Discovery: &config.Discovery{Kind: config.DiscoveryConsul, Address: srv.URL},
+		},
+	}
+	r, err := discovery.FromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrs, err := discovery.ResolveAll(context.Background(), r, cfg.Names())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"10.0.0.1:7000", "bob.mpc:7000"}; !reflect.DeepEqual(addrs, want) {
+		t.Fatalf("ResolveAll = %v, want %v", addrs, want)
+	}
+}
+
+func TestDNSSDRequiresDomain(t *testing.T) {
+	if _, err := (discovery.DNSSD{}).Resolve(context.Background(), "alice"); err == nil {
+		t.Fatal("DNSSD resolved without a domain")
+	}
+}
@@ -0,0 +1,70 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// DefaultService is the DNS-SD service type and Consul service name used when
+// none is configured.
+const DefaultService = "cbmpc"
+
+// DNSSD resolves parties from DNS-based service discovery (RFC 6763). Each
+// party is a service instance whose SRV record is published at
+//
+//	<name>._<service>._tcp.<domain>
+//
+// and points at the host and port it listens on. Records are returned in SRV
+// priority and weight order.
+type DNSSD struct {
+	// Domain is the DNS-SD domain, e.g. "mpc.example.com".
+	Domain string
+	// Service is the service type without the leading underscore. Empty
+	// selects DefaultService.
+	Service string
+	// Resolver performs the lookups. Nil selects net.DefaultResolver.
+	Resolver *net.Resolver
+}
+
+// Resolve implements Resolver.
+func (d DNSSD) Resolve(ctx context.Context, name string) ([]string, error) {
+	if d.Domain == "" {
+		return nil, errors.New("discovery: DNS-SD domain required")
+	}
+	r := d.Resolver
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	fqdn := d.instance(name)
+	_, srvs, err := r.LookupSRV(ctx, "", "", fqdn)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, fmt.Errorf("%w: %q (no SRV record at %s)", ErrNotFound, name, fqdn)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("discovery: look up %s: %w", fqdn, err)
+	}
+	addrs := make([]string, 0, len(srvs))
+	for _, srv := range srvs {
+		if srv.Target == "." {
+			continue // RFC 2782: service decidedly not available
+		}
+		addrs = append(addrs, net.JoinHostPort(strings.TrimSuffix(srv.Target, "."), strconv.Itoa(int(srv.Port))))
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+	return addrs, nil
+}
+
+func (d DNSSD) instance(name string) string {
+	service := d.Service
+	if service == "" {
+		service = DefaultService
+	}
+	return name + "._" + service + "._tcp." + strings.TrimSuffix(d.Domain, ".")
+}
@@ -0,0 +1,26 @@
+// Package discovery resolves party names to network addresses, so cluster
+// configurations can name parties without hard-coding where they run.
+//
+// A Resolver returns the candidate "host:port" addresses of one party.
+// Static is a fixed registry, DNSSD reads SRV records published under
+// DNS-based service discovery, and Consul queries the Consul catalog for
+// instances with passing health checks. Chain combines resolvers, and Checked
+// drops addresses that fail a probe, by default a TCP connect:
+//
+//	r := discovery.Chain{
+//	    discovery.Static{"alice": {"10.0.0.1:7000"}},
+//	    discovery.Checked{Resolver: discovery.DNSSD{Domain: "mpc.example.com"}},
+//	}
+//	addrs, err := discovery.ResolveAll(ctx, r, []string{"alice", "bob", "carol"})
+//	if err != nil {
+//	    return err
+//	}
+//	t, err := tlsnet.New(tlsnet.Config{Names: names, Addresses: addrs, ...})
+//
+// FromConfig builds the resolver for a config.Config whose transport has a
+// discovery section.
+//
+// Discovery only decides where to connect. Peers are still authenticated by
+// the transport, so a poisoned registry can deny service but cannot
+// impersonate a party.
+package discovery
@@ -22,35 +22,68 @@
 //
 // # Example Usage
 //
-//	// Create a mock network for testing
 //	net := mocknet.New()
-//
-//	// Set up two-party job
-//	p1 := net.Ep2P(cbmpc.RoleID(cbmpc.RoleP1), cbmpc.RoleID(cbmpc.RoleP2))
-//	p2 := net.Ep2P(cbmpc.RoleID(cbmpc.RoleP2), cbmpc.RoleID(cbmpc.RoleP1))
 //	names := [2]string{"party1", "party2"}
+//	p1 := net.Ep2P(cbmpc.RoleID(cbmpc.RoleP1), cbmpc.RoleID(cbmpc.RoleP2))
 //
 //	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 //	defer cancel()
-//
 //	job1, _ := cbmpc.NewJob2PWithContext(ctx, p1, cbmpc.RoleP1, names)
 //	defer job1.Close()
+//	result1, _ := agreerandom.AgreeRandom(ctx, job1, 256) // P2 runs the same
 //
-//	job2, _ := cbmpc.NewJob2PWithContext(ctx, p2, cbmpc.RoleP2, names)
-//	defer job2.Close()
+// # Jobs
 //
-//	// Run protocol
-//	result1, _ := agreerandom.AgreeRandom(ctx, job1, 256)
-//	result2, _ := agreerandom.AgreeRandom(ctx, job2, 256)
+// A job runs one protocol at a time. Invoking a second protocol on a job while
+// one is running now fails with ErrJobBusy, where plain jobs used to let both
+// runs interleave their messages; share a job between goroutines with
+// NewSerializedJob2P or NewSerializedJobMP. JobOptions adds round timeouts and
+// progress callbacks, the WithHandshake constructors check peer versions,
+// SetSignPolicy lets every co-signer veto a signature, and AddOperationHook
+// and SetLogger observe each operation. Open configures these, and the native
+// entropy source, once per process. README.md covers each with examples,
+// along with resource scopes, key envelopes and deterministic test builds.
 //
 // # Subpackages
 //
 // Protocol implementations and support packages:
 //   - agreerandom - Agree Random protocols
-//   - ecdsa2p - 2-party ECDSA protocols
+//   - ecdsa2p - 2-party ECDSA protocols, including private key import
 //   - pve - Publicly Verifiable Encryption
-//   - curve - Public curve enum and utilities
+//   - pve/restore - Quorum restoration of PVE-AC backups with per-party errors
+//   - backup - Verifiable PVE-AC backups of key shares to custodian policies, with restore
+//   - ceremony - Checkpointed, resumable key ceremonies with signed, comparable transcripts
+//   - attestation - Signed reports of how a key was created, cross-checked across parties
+//   - secretsharing - Shamir secret sharing with Feldman commitments
+//   - commit - Hash and Pedersen commitments with batch opening
+//   - ot - Native oblivious transfer between the two parties of a job
+//   - migrate - Reshare ecdsa2p keys into threshold ecdsamp keys, keeping the public key
+//   - integrations/solana - Solana transaction signing with EdDSA keys
+//   - integrations/psbt - Bitcoin PSBT signing for P2WPKH and P2TR key-path inputs
+//   - integrations/x509 - X.509 certificates and CSRs signed through a crypto.Signer adapter
+//   - integrations/jose - JWS and JWT signing with ES256, ES384, ES512, ES256K and EdDSA
+//   - integrations/ssh - ssh.Signer for MPC-held SSH certificate authorities and host keys
+//   - integrations/cosmos - Cosmos SDK SIGN_MODE_DIRECT signing with secp256k1 ECDSA keys
+//   - integrations/webauthn - WebAuthn assertion signatures with P-256 ECDSA keys
+//   - integrations/ethereum - personal_sign and EIP-712 signing with recoverable secp256k1 signatures
+//   - bench - Latency and throughput measurements with percentiles
+//   - sigaudit - Nonce-reuse, duplicate and range checks over ECDSA signatures
+//   - signcache - Idempotent sign requests keyed by key, message and session
+//   - protoflow - Round structure of protocol runs for tooling and diffs
+//   - journal - Append-only job lifecycle journal for incident forensics
+//   - audit - Hash-chained audit log of key generation and signing operations
+//   - transcript - Per-party message transcripts with redaction hooks
+//   - replaynet - Transport that replays a transcript into one party
+//   - resumable - Transport that resumes a job after transient failures
+//   - quicnet - tlsnet over QUIC, in a separate module so quic-go stays opt-in
+//   - jobpool - Pool of established 2-party jobs for signing services
+//   - config - Deployment configuration schema, loader, validation, defaults and reload hooks
+//   - curve - Public curve enum and utilities, and X25519 key agreement
 //   - kem - KEM abstraction for PVE
+//   - kem/ecies - Deterministic EC KEM for PVE over P-256 and secp256k1
+//   - kem/hybrid - Combiner of two KEMs, e.g. classical and post-quantum
 //   - logging - Minimal logging facade (slog adapter)
-//   - mocknet - In-memory transport for tests and examples
+//   - secmem - Locked buffers and arenas, constant-time compare and strict export mode
+//   - mocknet - In-memory transport for tests and examples, with optional seeded deterministic scheduling
+//   - testvectors - Reference vectors and golden-file helpers for validating builds
 package cbmpc
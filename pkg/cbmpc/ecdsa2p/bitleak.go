@@ -0,0 +1,11 @@
+package ecdsa2p
+
+import "github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+
+// bitLeakError returns the *cbmpc.BitLeakError for global-abort signing with
+// key that failed with ErrBitLeak.
+func bitLeakError(protocol string, key *Key) error {
+	e := &cbmpc.BitLeakError{Protocol: protocol, MustRefresh: true}
+	e.Fingerprint, _ = key.Fingerprint()
+	return e
+}
@@ -0,0 +1,336 @@
+package ecdsa2p_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// run2P runs fn for both parties over a fresh job pair and fails on any error.
+func run2P(t *testing.T, net *mocknet.Net, fn func(partyID int, job *cbmpc.Job2P) error) {
+	t.Helper()
+	names := [2]string{"party1", "party2"}
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(partyID int) {
+			defer wg.Done()
+			role := cbmpc.RoleP1
+			if partyID == 1 {
+				role = cbmpc.RoleP2
+			}
+			transport := net.Ep2P(cbmpc.RoleID(partyID), cbmpc.RoleID(1-partyID))
+			job, err := cbmpc.NewJob2P(transport, role, names)
+			if err != nil {
+				errs[partyID] = err
+				return
+			}
+			defer func() {
+				_ = job.Close()
+			}()
+			errs[partyID] = fn(partyID, job)
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Party %d failed: %v", i, err)
+		}
+	}
+}
+
+// TestECDSA2PSignNISTCurves covers the CNSA curves end to end: DKG, sign,
+// DER parsing, raw (r || s) conversion and verification with crypto/ecdsa.
+func TestECDSA2PSignNISTCurves(t *testing.T) {
+	tests := []struct {
+		curve   cbmpc.Curve
+		digest  func([]byte) []byte
+		rawSize int
+	}{
+		{cbmpc.CurveP256, func(m []byte) []byte { h := sha256.Sum256(m); return h[:] }, 64},
+		{cbmpc.CurveP384, func(m []byte) []byte { h := sha512.Sum384(m); return h[:] }, 96},
+		{cbmpc.CurveP521, func(m []byte) []byte { h := sha512.Sum512(m); return h[:] }, 132},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.curve.String(), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			net := mocknet.New()
+
+			keys := make([]*ecdsa2p.Key, 2)
+			run2P(t, net, func(partyID int, job *cbmpc.Job2P) error {
+				result, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: tc.curve})
+				if err != nil {
+					return err
+				}
+				keys[partyID] = result.Key
+				return nil
+			})
+			defer func() {
+				for _, key := range keys {
+					if key != nil {
+						_ = key.Close()
+					}
+				}
+			}()
+
+			got, err := keys[0].Curve()
+			if err != nil {
+				t.Fatalf("Curve failed: %v", err)
+			}
+			if got != tc.curve {
+				t.Fatalf("Key curve = %v, want %v", got, tc.curve)
+			}
+			fp0, err := keys[0].Fingerprint()
+			if err != nil {
+				t.Fatalf("Fingerprint failed: %v", err)
+			}
+			if fp1, _ := keys[1].Fingerprint(); fp1 != fp0 {
+				t.Fatalf("parties' fingerprints differ: %s != %s", fp0, fp1)
+			}
+
+			messageHash := tc.digest([]byte("CNSA suite signing"))
+			signatures := make([][]byte, 2)
+			run2P(t, net, func(partyID int, job *cbmpc.Job2P) error {
+				result, err := ecdsa2p.Sign(ctx, job, &ecdsa2p.SignParams{Key: keys[partyID], Message: messageHash})
+				if err != nil {
+					return err
+				}
+				signatures[partyID] = result.Signature
+				return nil
+			})
+
+			pubKeyBytes, err := keys[0].PublicKey()
+			if err != nil {
+				t.Fatalf("Failed to get public key: %v", err)
+			}
+			valid, err := verifySignature(tc.curve, pubKeyBytes, messageHash, signatures[0])
+			if err != nil || !valid {
+				t.Fatalf("Signature verification failed (err=%v)", err)
+			}
+
+			raw, err := ecdsa2p.SignatureToRaw(tc.curve, signatures[0])
+			if err != nil {
+				t.Fatalf("SignatureToRaw failed: %v", err)
+			}
+			if len(raw) != tc.rawSize {
+				t.Fatalf("Raw signature length = %d, want %d", len(raw), tc.rawSize)
+			}
+			pub, err := keys[0].ECDSAPublicKey()
+			if err != nil {
+				t.Fatalf("ECDSAPublicKey failed: %v", err)
+			}
+			half := tc.rawSize / 2
+			r := new(big.Int).SetBytes(raw[:half])
+			s := new(big.Int).SetBytes(raw[half:])
+			if !ecdsa.Verify(pub, messageHash, r, s) {
+				t.Fatal("Raw signature verification failed")
+			}
+
+			compact := make([][]byte, 2)
+			run2P(t, net, func(partyID int, job *cbmpc.Job2P) error {
+				result, err := ecdsa2p.Sign(ctx, job, &ecdsa2p.SignParams{
+					Key:     keys[partyID],
+					Message: messageHash,
+					Format:  ecdsa2p.SigFormatCompact,
+				})
+				if err != nil {
+					return err
+				}
+				compact[partyID] = result.Signature
+				return nil
+			})
+			if len(compact[0]) != tc.rawSize {
+				t.Fatalf("Compact signature length = %d, want %d", len(compact[0]), tc.rawSize)
+			}
+			r = new(big.Int).SetBytes(compact[0][:half])
+			s = new(big.Int).SetBytes(compact[0][half:])
+			if s.Cmp(new(big.Int).Rsh(pub.Params().N, 1)) > 0 {
+				t.Fatal("Compact signature has high s")
+			}
+			if !ecdsa.Verify(pub, messageHash, r, s) {
+				t.Fatal("Compact signature verification failed")
+			}
+		})
+	}
+}
+
+func TestECDSA2PDKGRejectsEd25519(t *testing.T) {
+	net := mocknet.New()
+	job, err := cbmpc.NewJob2P(net.Ep2P(0, 1), cbmpc.RoleP1, [2]string{"party1", "party2"})
+	if err != nil {
+		t.Fatalf("NewJob2P failed: %v", err)
+	}
+	defer func() {
+		_ = job.Close()
+	}()
+	if _, err := ecdsa2p.DKG(context.Background(), job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveEd25519}); err == nil {
+		t.Fatal("expected error for Ed25519")
+	}
+}
+
+func TestECDSA2PSignRejectsOversizedHash(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	net := mocknet.New()
+
+	keys := make([]*ecdsa2p.Key, 2)
+	run2P(t, net, func(partyID int, job *cbmpc.Job2P) error {
+		result, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP384})
+		if err != nil {
+			return err
+		}
+		keys[partyID] = result.Key
+		return nil
+	})
+	defer func() {
+		for _, key := range keys {
+			_ = key.Close()
+		}
+	}()
+
+	// SHA-512 output is 64 bytes, larger than the 48-byte P-384 order.
+	hash := sha512.Sum512([]byte("too long"))
+	job, err := cbmpc.NewJob2P(net.Ep2P(0, 1), cbmpc.RoleP1, [2]string{"party1", "party2"})
+	if err != nil {
+		t.Fatalf("NewJob2P failed: %v", err)
+	}
+	defer func() {
+		_ = job.Close()
+	}()
+	if _, err := ecdsa2p.Sign(ctx, job, &ecdsa2p.SignParams{Key: keys[0], Message: hash[:]}); err == nil {
+		t.Fatal("expected error for 64-byte hash on P-384")
+	}
+}
+
+// TestRawSignatureP521 exercises the DER long form with a signature from the
+// standard library, independent of the native protocol.
+func TestRawSignatureP521(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	hash := sha512.Sum512([]byte("long form"))
+	der, err := ecdsa.SignASN1(rand.Reader, priv, hash[:])
+	if err != nil {
+		t.Fatalf("SignASN1 failed: %v", err)
+	}
+
+	r, s, err := ecdsa2p.ParseSignature(der)
+	if err != nil {
+		t.Fatalf("ParseSignature failed: %v", err)
+	}
+	if !ecdsa.Verify(&priv.PublicKey, hash[:], r, s) {
+		t.Fatal("parsed signature does not verify")
+	}
+
+	raw, err := ecdsa2p.SignatureToRaw(cbmpc.CurveP521, der)
+	if err != nil {
+		t.Fatalf("SignatureToRaw failed: %v", err)
+	}
+	if len(raw) != 132 {
+		t.Fatalf("raw length = %d, want 132", len(raw))
+	}
+	if new(big.Int).SetBytes(raw[:66]).Cmp(r) != 0 || new(big.Int).SetBytes(raw[66:]).Cmp(s) != 0 {
+		t.Fatal("raw signature does not match r || s")
+	}
+}
+
+// TestSignatureFormats round-trips standard library signatures through every
+// format and back to DER.
+func TestSignatureFormats(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	n := priv.Params().N
+	halfN := new(big.Int).Rsh(n, 1)
+	hash := sha256.Sum256([]byte("formats"))
+
+	for i := 0; i < 8; i++ {
+		der, err := ecdsa.SignASN1(rand.Reader, priv, hash[:])
+		if err != nil {
+			t.Fatalf("SignASN1 failed: %v", err)
+		}
+		_, s, err := ecdsa2p.ParseSignature(der)
+		if err != nil {
+			t.Fatalf("ParseSignature failed: %v", err)
+		}
+
+		for _, f := range []ecdsa2p.SigFormat{ecdsa2p.SigFormatDER, ecdsa2p.SigFormatRaw, ecdsa2p.SigFormatCompact} {
+			out, err := ecdsa2p.SignatureFromDER(cbmpc.CurveP256, der, f)
+			if err != nil {
+				t.Fatalf("SignatureFromDER(%v) failed: %v", f, err)
+			}
+			back := out
+			if f != ecdsa2p.SigFormatDER {
+				if len(out) != 64 {
+					t.Fatalf("%v length = %d, want 64", f, len(out))
+				}
+				if back, err = ecdsa2p.SignatureToDER(cbmpc.CurveP256, out); err != nil {
+					t.Fatalf("SignatureToDER(%v) failed: %v", f, err)
+				}
+			}
+			if !ecdsa.VerifyASN1(&priv.PublicKey, hash[:], back) {
+				t.Fatalf("%v signature does not verify", f)
+			}
+
+			_, gotS, err := ecdsa2p.ParseSignature(back)
+			if err != nil {
+				t.Fatalf("ParseSignature failed: %v", err)
+			}
+			switch f {
+			case ecdsa2p.SigFormatCompact:
+				if gotS.Cmp(halfN) > 0 {
+					t.Fatal("compact signature has high s")
+				}
+			default:
+				if gotS.Cmp(s) != 0 {
+					t.Fatalf("%v changed s", f)
+				}
+			}
+		}
+	}
+
+	if _, err := ecdsa2p.SignatureFromDER(cbmpc.CurveP256, []byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01}, ecdsa2p.SigFormat(9)); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+	if _, err := ecdsa2p.SignatureToDER(cbmpc.CurveP256, make([]byte, 63)); err == nil {
+		t.Fatal("expected error for short raw signature")
+	}
+	if _, err := ecdsa2p.SignatureToDER(cbmpc.CurveP256, make([]byte, 64)); err == nil {
+		t.Fatal("expected error for zero r and s")
+	}
+}
+
+func TestParseSignatureInvalid(t *testing.T) {
+	cases := map[string][]byte{
+		"empty":    nil,
+		"garbage":  {0x01, 0x02, 0x03},
+		"trailing": {0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01, 0x00},
+		"zero r":   {0x30, 0x06, 0x02, 0x01, 0x00, 0x02, 0x01, 0x01},
+	}
+	for name, der := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := ecdsa2p.ParseSignature(der); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+	if _, err := ecdsa2p.SignatureToRaw(cbmpc.CurveEd25519, []byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01}); err == nil {
+		t.Fatal("expected error for Ed25519")
+	}
+}
@@ -21,6 +21,30 @@
 //   - SignWithGlobalAbort: Signing with enhanced security checks
 //   - SignWithGlobalAbortBatch: Batch signing with enhanced security checks
 //   - Refresh: Refreshes a key share while preserving the public key
+//   - ImportPrivateKey: Splits an existing ECDSA private key into two shares
+//   - ExportPrivateKey: Reconstructs the private key with both parties' cooperation
+//
+// # Curves
+//
+// DKG accepts P-256, P-384, P-521 and secp256k1. For CNSA deployments use
+// P-384 with a SHA-384 message hash. The message hash passed to Sign may not
+// exceed the curve order size: 32 bytes for P-256 and secp256k1, 48 for P-384
+// and 66 for P-521 (a SHA-512 hash fits).
+//
+// # Signature Format
+//
+// Signatures are DER-encoded ASN.1 sequences of r and s and can be verified
+// with ecdsa.VerifyASN1 against Key.ECDSAPublicKey. SignParams.Format selects
+// the raw r || s form of JOSE and PKCS#11 or the low-s compact form of
+// Ethereum and Bitcoin instead. VerifySignatures checks many signatures in
+// one native call.
+//
+// # Bulk Signing
+//
+// SignMany splits a large batch of hashes over several jobs to the same peer
+// and signs the parts in parallel; SignStream signs hashes read from a
+// channel in fixed-size batches, with backpressure. README.md covers the
+// signature encodings and both bulk signing functions with examples.
 //
 // # Memory Management
 //
@@ -2,12 +2,17 @@ package ecdsa2p
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/pubkey"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
 )
 
 // Key represents a 2-party ECDSA key share.
@@ -29,11 +34,13 @@ type Key struct {
 	// The bindings layer uses *C.cbmpc_ecdsa2p_key (aliased as backend.ECDSA2PKey)
 	// The alias itself is a pointer type, so we store it directly (not as a pointer to it)
 	ckey backend.ECDSA2PKey
+	// created is when the key share was generated, zero if unknown.
+	created time.Time
 }
 
 // newKey creates a new Key from a C pointer and sets up a finalizer.
 func newKey(ckey backend.ECDSA2PKey) *Key {
-	k := &Key{ckey: ckey}
+	k := &Key{ckey: ckey, created: time.Now().UTC()}
 	runtime.SetFinalizer(k, func(key *Key) {
 		_ = key.Close()
 	})
@@ -53,7 +60,10 @@ func (k *Key) Close() error {
 }
 
 // Bytes returns the serialized key data for persistent storage or network transmission.
-// Returns a defensive copy to prevent external modification of internal key data.
+// The native serialization is wrapped in an envelope recording the protocol,
+// curve, role and creation time, which LoadKey checks.
+// In strict mode (secmem.SetStrict) it fails with secmem.ErrUnprotected; use
+// ProtectedBytes instead.
 //
 // SECURITY WARNING:
 // The returned bytes contain sensitive cryptographic key material.
@@ -76,27 +86,96 @@ func (k *Key) Close() error {
 //	}
 //	// Store encrypted bytes...
 func (k *Key) Bytes() ([]byte, error) {
+	if secmem.Strict() {
+		return nil, secmem.ErrUnprotected
+	}
+	if k == nil || k.ckey == nil {
+		return nil, errors.New("nil or closed key")
+	}
+	return k.serialize()
+}
+
+// ProtectedBytes is like Bytes but moves the serialized key into a
+// secmem.Buffer and wipes the intermediate copy. It is the export to use in
+// strict mode. Call Destroy on the buffer when done.
+func (k *Key) ProtectedBytes() (*secmem.Buffer, error) {
 	if k == nil || k.ckey == nil {
 		return nil, errors.New("nil or closed key")
 	}
-	data, err := backend.ECDSA2PKeySerialize(k.ckey)
+	data, err := k.serialize()
+	if err != nil {
+		return nil, err
+	}
+	return secmem.Move(data)
+}
+
+// serialize returns the native serialization of the key in its envelope.
+func (k *Key) serialize() ([]byte, error) {
+	meta, err := k.meta()
+	if err != nil {
+		return nil, err
+	}
+	share, err := backend.ECDSA2PKeySerialize(k.ckey)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
-	// Return a defensive copy to prevent mutation of internal state
-	result := make([]byte, len(data))
-	copy(result, data)
-	return result, nil
+	defer cbmpc.ZeroizeBytes(share)
+	return envelope.EncodeKey(meta, share)
 }
 
-// LoadKey deserializes a key from bytes.
+func (k *Key) meta() (envelope.KeyMeta, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return envelope.KeyMeta{}, err
+	}
+	role, err := backend.ECDSA2PKeyGetRole(k.ckey)
+	if err != nil {
+		return envelope.KeyMeta{}, cbmpc.RemapError(err)
+	}
+	return envelope.KeyMeta{
+		Protocol: envelope.KeyECDSA2P,
+		Curve:    uint8(curve),
+		Role:     envelope.Role2P(role),
+		Created:  k.created,
+	}, nil
+}
+
+// LoadKey deserializes a key from bytes written by Bytes or ProtectedBytes.
+// It fails with cbmpc.ErrKeyProtocolMismatch for another protocol's share and
+// cbmpc.ErrKeyVersion for a share written by a newer library. Bare native
+// serializations from earlier releases are still accepted; their Created
+// time is unknown.
 // The returned key must be freed with Close() when no longer needed.
 func LoadKey(data []byte) (*Key, error) {
-	ckey, err := backend.ECDSA2PKeyDeserialize(data)
+	meta, share, err := envelope.UnwrapKey(envelope.KeyECDSA2P, data)
+	if err != nil {
+		return nil, err
+	}
+	ckey, err := backend.ECDSA2PKeyDeserialize(share)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
-	return newKey(ckey), nil
+	k := newKey(ckey)
+	k.created = meta.Created
+	got, err := k.meta()
+	if err == nil {
+		err = meta.Check(got.Curve, got.Role)
+	}
+	if err != nil {
+		_ = k.Close()
+		return nil, err
+	}
+	return k, nil
+}
+
+// Created returns when the key share was generated by DKG, Refresh or
+// import, or the zero time if it was loaded from a share serialized before
+// keys recorded it.
+func (k *Key) Created() time.Time {
+	if k == nil {
+		return time.Time{}
+	}
+	return k.created
 }
 
 // PublicKey extracts the public key point Q from the key share.
@@ -128,6 +207,50 @@ func (k *Key) Curve() (cbmpc.Curve, error) {
 	return cbmpc.Curve(curve), nil
 }
 
+// Fingerprint returns the key's cbmpc.KeyFingerprint, a stable identifier
+// derived from its curve and public key. Every party's share of the key has
+// the same fingerprint, and operation logs and audit records use it too.
+func (k *Key) Fingerprint() (string, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return "", err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return "", err
+	}
+	return cbmpc.KeyFingerprint(curve, pub), nil
+}
+
+// Validate runs the native consistency checks on the key share: the curve is
+// known, the secret share is a nonzero scalar below the curve order and the
+// public key is a valid point. It returns cbmpc.ErrKeyInvalid for a share
+// that fails them. Keystores can call it after LoadKey to quarantine a
+// corrupted share before a signing protocol fails partway through.
+func (k *Key) Validate() error {
+	if k == nil || k.ckey == nil {
+		return errors.New("nil or closed key")
+	}
+	err := backend.ECDSA2PKeyValidate(k.ckey)
+	runtime.KeepAlive(k)
+	return cbmpc.RemapError(err)
+}
+
+// ECDSAPublicKey returns the public key as an *ecdsa.PublicKey, ready for
+// ecdsa.VerifyASN1. secp256k1 keys use btcec.S256() as their curve, since
+// crypto/elliptic does not provide one; crypto/x509 cannot encode them.
+func (k *Key) ECDSAPublicKey() (*ecdsa.PublicKey, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return pubkey.ECDSA(curve, pub)
+}
+
 // DKGParams contains parameters for 2-party ECDSA distributed key generation.
 type DKGParams struct {
 	Curve cbmpc.Curve
@@ -139,9 +262,10 @@ type DKGResult struct {
 }
 
 // DKG performs 2-party ECDSA distributed key generation.
+// Supported curves are P-256, P-384, P-521 and secp256k1.
 // The returned key must be freed with Close() when no longer needed.
 // See cb-mpc/src/cbmpc/protocol/ecdsa_2p.h for protocol details.
-func DKG(_ context.Context, j *cbmpc.Job2P, params *DKGParams) (*DKGResult, error) {
+func DKG(ctx context.Context, j *cbmpc.Job2P, params *DKGParams) (_ *DKGResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -149,11 +273,19 @@ func DKG(_ context.Context, j *cbmpc.Job2P, params *DKGParams) (*DKGResult, erro
 		return nil, errors.New("nil params")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := &cbmpc.Operation{Protocol: "ecdsa2p.DKG", Curve: params.Curve}
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
+	if !isECDSACurve(params.Curve) {
+		return nil, fmt.Errorf("unsupported curve for ECDSA: %v", params.Curve)
+	}
 	nid, err := backend.CurveToNID(backend.Curve(params.Curve))
 	if err != nil {
 		return nil, err
@@ -165,8 +297,14 @@ func DKG(_ context.Context, j *cbmpc.Job2P, params *DKGParams) (*DKGResult, erro
 	}
 	runtime.KeepAlive(j)
 
+	key := newKey(keyPtr)
+	op.PublicKey, _ = key.PublicKey()
+	if err := cbmpc.TrackInScope(ctx, key); err != nil {
+		return nil, err
+	}
+
 	return &DKGResult{
-		Key: newKey(keyPtr),
+		Key: key,
 	}, nil
 }
 
@@ -184,7 +322,7 @@ type RefreshResult struct {
 // The returned key must be freed with Close() when no longer needed.
 // The input key is not modified and remains valid.
 // See cb-mpc/src/cbmpc/protocol/ecdsa_2p.h for protocol details.
-func Refresh(_ context.Context, j *cbmpc.Job2P, params *RefreshParams) (*RefreshResult, error) {
+func Refresh(ctx context.Context, j *cbmpc.Job2P, params *RefreshParams) (_ *RefreshResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -195,10 +333,15 @@ func Refresh(_ context.Context, j *cbmpc.Job2P, params *RefreshParams) (*Refresh
 		return nil, errors.New("nil or closed key")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("ecdsa2p.Refresh", params.Key, nil)
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
 	newKeyCkey, err := backend.ECDSA2PRefresh(ptr, params.Key.ckey)
 	if err != nil {
@@ -207,8 +350,13 @@ func Refresh(_ context.Context, j *cbmpc.Job2P, params *RefreshParams) (*Refresh
 	runtime.KeepAlive(j)
 	runtime.KeepAlive(params.Key)
 
+	key := newKey(newKeyCkey)
+	if err := cbmpc.TrackInScope(ctx, key); err != nil {
+		return nil, err
+	}
+
 	return &RefreshResult{
-		NewKey: newKey(newKeyCkey),
+		NewKey: key,
 	}, nil
 }
 
@@ -219,14 +367,15 @@ type SignParams struct {
 	// Non-empty = resume session with the provided session ID
 	SessionID cbmpc.SessionID
 
-	Key     *Key   // Key share to sign with
-	Message []byte // Message hash to sign (must be pre-hashed, max size = curve order size)
+	Key     *Key      // Key share to sign with
+	Message []byte    // Message hash to sign (must be pre-hashed, max size = curve order size)
+	Format  SigFormat // Encoding of the returned signature (default DER)
 }
 
 // SignResult contains the output of 2-party ECDSA signing.
 type SignResult struct {
 	SessionID cbmpc.SessionID // Updated session ID for use in subsequent operations
-	Signature []byte          // ECDSA signature
+	Signature []byte          // ECDSA signature, encoded as SignParams.Format
 }
 
 // Sign performs 2-party ECDSA signing.
@@ -241,7 +390,7 @@ type SignResult struct {
 // The returned SessionID should be used for subsequent signing operations to maintain session continuity.
 //
 // See cb-mpc/src/cbmpc/protocol/ecdsa_2p.h for protocol details.
-func Sign(_ context.Context, j *cbmpc.Job2P, params *SignParams) (*SignResult, error) {
+func Sign(ctx context.Context, j *cbmpc.Job2P, params *SignParams) (_ *SignResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -265,21 +414,38 @@ func Sign(_ context.Context, j *cbmpc.Job2P, params *SignParams) (*SignResult, e
 		return nil, errors.New("message hash exceeds curve order size")
 	}
 
-	ptr, err := j.Ptr()
+	if err := checkFormat(params.Format); err != nil {
+		return nil, err
+	}
+
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("ecdsa2p.Sign", params.Key, [][]byte{params.Message})
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	if err := j.CheckSignPolicy(ctx, op.SignRequest()); err != nil {
+		return nil, err
+	}
 
 	newSID, sig, err := backend.ECDSA2PSign(ptr, params.Key.ckey, params.SessionID.Bytes(), params.Message)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
+	sigs := [][]byte{sig}
+	if err := formatSignatures(curve, params.Format, sigs); err != nil {
+		return nil, err
+	}
 	runtime.KeepAlive(j)
 	runtime.KeepAlive(params.Key)
 
 	return &SignResult{
 		SessionID: cbmpc.NewSessionID(newSID),
-		Signature: sig,
+		Signature: sigs[0],
 	}, nil
 }
 
@@ -290,14 +456,15 @@ type SignBatchParams struct {
 	// Non-empty = resume session with the provided session ID
 	SessionID cbmpc.SessionID
 
-	Key      *Key     // Key share to sign with
-	Messages [][]byte // Message hashes to sign (must be pre-hashed, max size = curve order size)
+	Key      *Key      // Key share to sign with
+	Messages [][]byte  // Message hashes to sign (must be pre-hashed, max size = curve order size)
+	Format   SigFormat // Encoding of the returned signatures (default DER)
 }
 
 // SignBatchResult contains the output of 2-party ECDSA batch signing.
 type SignBatchResult struct {
 	SessionID  cbmpc.SessionID // Updated session ID for use in subsequent operations
-	Signatures [][]byte        // ECDSA signatures (one per message)
+	Signatures [][]byte        // ECDSA signatures (one per message), encoded as SignBatchParams.Format
 }
 
 // SignBatch performs 2-party ECDSA batch signing.
@@ -309,7 +476,7 @@ type SignBatchResult struct {
 // The returned SessionID should be used for subsequent signing operations to maintain session continuity.
 //
 // See cb-mpc/src/cbmpc/protocol/ecdsa_2p.h for protocol details.
-func SignBatch(_ context.Context, j *cbmpc.Job2P, params *SignBatchParams) (*SignBatchResult, error) {
+func SignBatch(ctx context.Context, j *cbmpc.Job2P, params *SignBatchParams) (_ *SignBatchResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -340,15 +507,31 @@ func SignBatch(_ context.Context, j *cbmpc.Job2P, params *SignBatchParams) (*Sig
 		}
 	}
 
-	ptr, err := j.Ptr()
+	if err := checkFormat(params.Format); err != nil {
+		return nil, err
+	}
+
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("ecdsa2p.SignBatch", params.Key, params.Messages)
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	if err := j.CheckSignPolicy(ctx, op.SignRequest()); err != nil {
+		return nil, err
+	}
 
 	newSID, sigs, err := backend.ECDSA2PSignBatch(ptr, params.Key.ckey, params.SessionID.Bytes(), params.Messages)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
+	if err := formatSignatures(curve, params.Format, sigs); err != nil {
+		return nil, err
+	}
 	runtime.KeepAlive(j)
 	runtime.KeepAlive(params.Key)
 
@@ -359,7 +542,9 @@ func SignBatch(_ context.Context, j *cbmpc.Job2P, params *SignBatchParams) (*Sig
 }
 
 // SignWithGlobalAbort performs 2-party ECDSA signing with global abort mode.
-// Returns ErrBitLeak if signature verification fails (indicates potential key leak).
+// Returns a *cbmpc.BitLeakError, which matches ErrBitLeak, if signature verification
+// fails (indicates potential key leak). Only P1, which receives the signature,
+// verifies it: P2 returns normally, and must be told before the two refresh the key.
 //
 // Session ID semantics:
 //   - Empty SessionID (zero value): Library generates a fresh session ID
@@ -368,7 +553,15 @@ func SignBatch(_ context.Context, j *cbmpc.Job2P, params *SignBatchParams) (*Sig
 // The returned SessionID should be used for subsequent signing operations to maintain session continuity.
 //
 // See cb-mpc/src/cbmpc/protocol/ecdsa_2p.h for protocol details.
-func SignWithGlobalAbort(_ context.Context, j *cbmpc.Job2P, params *SignParams) (*SignResult, error) {
+func SignWithGlobalAbort(ctx context.Context, j *cbmpc.Job2P, params *SignParams) (*SignResult, error) {
+	res, err := signWithGlobalAbort(ctx, j, params)
+	if errors.Is(err, cbmpc.ErrBitLeak) {
+		return nil, bitLeakError("ecdsa2p.SignWithGlobalAbort", params.Key)
+	}
+	return res, err
+}
+
+func signWithGlobalAbort(ctx context.Context, j *cbmpc.Job2P, params *SignParams) (_ *SignResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -392,26 +585,44 @@ func SignWithGlobalAbort(_ context.Context, j *cbmpc.Job2P, params *SignParams)
 		return nil, errors.New("message hash exceeds curve order size")
 	}
 
-	ptr, err := j.Ptr()
+	if err := checkFormat(params.Format); err != nil {
+		return nil, err
+	}
+
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("ecdsa2p.SignWithGlobalAbort", params.Key, [][]byte{params.Message})
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	if err := j.CheckSignPolicy(ctx, op.SignRequest()); err != nil {
+		return nil, err
+	}
 
 	newSID, sig, err := backend.ECDSA2PSignWithGlobalAbort(ptr, params.Key.ckey, params.SessionID.Bytes(), params.Message)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
+	sigs := [][]byte{sig}
+	if err := formatSignatures(curve, params.Format, sigs); err != nil {
+		return nil, err
+	}
 	runtime.KeepAlive(j)
 	runtime.KeepAlive(params.Key)
 
 	return &SignResult{
 		SessionID: cbmpc.NewSessionID(newSID),
-		Signature: sig,
+		Signature: sigs[0],
 	}, nil
 }
 
 // SignWithGlobalAbortBatch performs 2-party ECDSA batch signing with global abort mode.
-// Returns ErrBitLeak if signature verification fails (indicates potential key leak).
+// Returns a *cbmpc.BitLeakError, which matches ErrBitLeak, if signature verification
+// fails (indicates potential key leak). As with SignWithGlobalAbort, only P1 sees it.
 //
 // Session ID semantics:
 //   - Empty SessionID (zero value): Library generates a fresh session ID
@@ -420,7 +631,15 @@ func SignWithGlobalAbort(_ context.Context, j *cbmpc.Job2P, params *SignParams)
 // The returned SessionID should be used for subsequent signing operations to maintain session continuity.
 //
 // See cb-mpc/src/cbmpc/protocol/ecdsa_2p.h for protocol details.
-func SignWithGlobalAbortBatch(_ context.Context, j *cbmpc.Job2P, params *SignBatchParams) (*SignBatchResult, error) {
+func SignWithGlobalAbortBatch(ctx context.Context, j *cbmpc.Job2P, params *SignBatchParams) (*SignBatchResult, error) {
+	res, err := signWithGlobalAbortBatch(ctx, j, params)
+	if errors.Is(err, cbmpc.ErrBitLeak) {
+		return nil, bitLeakError("ecdsa2p.SignWithGlobalAbortBatch", params.Key)
+	}
+	return res, err
+}
+
+func signWithGlobalAbortBatch(ctx context.Context, j *cbmpc.Job2P, params *SignBatchParams) (_ *SignBatchResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -451,15 +670,31 @@ func SignWithGlobalAbortBatch(_ context.Context, j *cbmpc.Job2P, params *SignBat
 		}
 	}
 
-	ptr, err := j.Ptr()
+	if err := checkFormat(params.Format); err != nil {
+		return nil, err
+	}
+
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("ecdsa2p.SignWithGlobalAbortBatch", params.Key, params.Messages)
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	if err := j.CheckSignPolicy(ctx, op.SignRequest()); err != nil {
+		return nil, err
+	}
 
 	newSID, sigs, err := backend.ECDSA2PSignWithGlobalAbortBatch(ptr, params.Key.ckey, params.SessionID.Bytes(), params.Messages)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
+	if err := formatSignatures(curve, params.Format, sigs); err != nil {
+		return nil, err
+	}
 	runtime.KeepAlive(j)
 	runtime.KeepAlive(params.Key)
 
@@ -468,3 +703,12 @@ func SignWithGlobalAbortBatch(_ context.Context, j *cbmpc.Job2P, params *SignBat
 		Signatures: sigs,
 	}, nil
 }
+
+// operation describes an invocation for the job's hooks and sign policy. The
+// key has already been validated, so lookup errors only leave fields empty.
+func operation(protocol string, key *Key, messages [][]byte) *cbmpc.Operation {
+	op := &cbmpc.Operation{Protocol: protocol, Messages: messages}
+	op.Curve, _ = key.Curve()
+	op.PublicKey, _ = key.PublicKey()
+	return op
+}
@@ -3,6 +3,8 @@ package ecdsa2p_test
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -409,3 +411,173 @@ func TestECDSA2PSignMaliciousP2(t *testing.T) {
 		}
 	}
 }
+
+// TestECDSA2PDKGCorruptedMessage tests that P2 aborts DKG when P1 sends a
+// corrupted message instead of an honest one.
+func TestECDSA2PDKGCorruptedMessage(t *testing.T) {
+	for _, round := range []int{0, 1} {
+		t.Run(fmt.Sprintf("round%d", round), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			net := mocknet.New()
+			names := [2]string{"party1", "party2"}
+			adv := mocknet.NewAdversary(net.Ep2P(cbmpc.RoleID(0), cbmpc.RoleID(1))).Corrupt(cbmpc.RoleID(1), round)
+			transports := []cbmpc.Transport{adv, net.Ep2P(cbmpc.RoleID(1), cbmpc.RoleID(0))}
+
+			var wg sync.WaitGroup
+			results := make([]*ecdsa2p.DKGResult, 2)
+			testErrors := make([]error, 2)
+			for i, role := range []cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2} {
+				wg.Add(1)
+				go func(i int, role cbmpc.Role) {
+					defer wg.Done()
+					job, err := cbmpc.NewJob2P(transports[i], role, names)
+					if err != nil {
+						testErrors[i] = err
+						return
+					}
+					defer func() { _ = job.Close() }()
+
+					// P1 stops once P2 aborts; bound its wait.
+					partyCtx, partyCancel := context.WithTimeout(ctx, time.Second)
+					defer partyCancel()
+					results[i], testErrors[i] = ecdsa2p.DKG(partyCtx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
+				}(i, role)
+			}
+			wg.Wait()
+
+			if len(adv.Interceptions()) == 0 {
+				t.Skipf("P1 sent fewer than %d messages", round+1)
+			}
+			if testErrors[1] == nil {
+				t.Fatal("P2 accepted a corrupted DKG message")
+			}
+			for _, r := range results {
+				if r != nil && r.Key != nil {
+					_ = r.Key.Close()
+				}
+			}
+		})
+	}
+}
+
+// TestECDSA2PDKGReplayedMessage tests that P2 aborts DKG when the network
+// delivers P1's first message twice.
+func TestECDSA2PDKGReplayedMessage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	names := [2]string{"party1", "party2"}
+	adv := mocknet.NewAdversary(net.Ep2P(cbmpc.RoleID(0), cbmpc.RoleID(1))).Replay(cbmpc.RoleID(1), 0)
+	transports := []cbmpc.Transport{adv, net.Ep2P(cbmpc.RoleID(1), cbmpc.RoleID(0))}
+
+	var wg sync.WaitGroup
+	results := make([]*ecdsa2p.DKGResult, 2)
+	testErrors := make([]error, 2)
+	for i, role := range []cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2} {
+		wg.Add(1)
+		go func(i int, role cbmpc.Role) {
+			defer wg.Done()
+			job, err := cbmpc.NewJob2P(transports[i], role, names)
+			if err != nil {
+				testErrors[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+
+			// P1 stops once P2 aborts; bound its wait.
+			partyCtx, partyCancel := context.WithTimeout(ctx, time.Second)
+			defer partyCancel()
+			results[i], testErrors[i] = ecdsa2p.DKG(partyCtx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
+		}(i, role)
+	}
+	wg.Wait()
+
+	if testErrors[1] == nil {
+		t.Fatal("P2 accepted a replayed DKG message")
+	}
+	for _, r := range results {
+		if r != nil && r.Key != nil {
+			_ = r.Key.Close()
+		}
+	}
+}
+
+// TestECDSA2PSignWithGlobalAbortBitLeak corrupts each message P2 sends P1
+// during global-abort signing in turn. Once the corruption reaches the
+// ciphertext P1 decrypts into the signature, P1 must fail with a
+// *BitLeakError asking for a refresh, while P2, which never verifies the
+// signature, returns normally instead of waiting on P1.
+func TestECDSA2PSignWithGlobalAbortBitLeak(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	keys := make([]*ecdsa2p.Key, 2)
+	run2P(t, mocknet.New(), func(partyID int, job *cbmpc.Job2P) error {
+		res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
+		if err != nil {
+			return err
+		}
+		keys[partyID] = res.Key
+		return nil
+	})
+	defer func() {
+		for _, k := range keys {
+			_ = k.Close()
+		}
+	}()
+	fingerprint, err := keys[0].Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messageHash := sha256.Sum256([]byte("bit leak"))
+	names := [2]string{"party1", "party2"}
+	leaked := false
+	for round := 0; round < 4 && !leaked; round++ {
+		net := mocknet.New()
+		adv := mocknet.NewAdversary(net.Ep2P(cbmpc.RoleID(1), cbmpc.RoleID(0))).Corrupt(cbmpc.RoleID(0), round)
+		transports := []cbmpc.Transport{net.Ep2P(cbmpc.RoleID(0), cbmpc.RoleID(1)), adv}
+
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i, role := range []cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2} {
+			wg.Add(1)
+			go func(i int, role cbmpc.Role) {
+				defer wg.Done()
+				job, err := cbmpc.NewJob2P(transports[i], role, names)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				defer func() { _ = job.Close() }()
+
+				partyCtx, partyCancel := context.WithTimeout(ctx, 5*time.Second)
+				defer partyCancel()
+				_, errs[i] = ecdsa2p.SignWithGlobalAbort(partyCtx, job, &ecdsa2p.SignParams{Key: keys[i], Message: messageHash[:]})
+			}(i, role)
+		}
+		wg.Wait()
+
+		if len(adv.Interceptions()) == 0 {
+			break
+		}
+		t.Logf("round %d: P1 err=%v, P2 err=%v", round, errs[0], errs[1])
+		var ble *cbmpc.BitLeakError
+		if !errors.As(errs[0], &ble) {
+			continue
+		}
+		leaked = true
+		if !ble.MustRefresh || ble.Fingerprint != fingerprint {
+			t.Errorf("BitLeakError = %+v, want MustRefresh and fingerprint %s", ble, fingerprint)
+		}
+		if errs[1] != nil {
+			t.Errorf("P2 failed with %v; only P1 verifies the signature", errs[1])
+		}
+	}
+	if !leaked {
+		t.Fatal("no corrupted message produced a bit leak at P1")
+	}
+}
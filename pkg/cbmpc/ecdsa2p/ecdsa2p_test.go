@@ -6,7 +6,6 @@ import (
 	"crypto/elliptic"
 	"crypto/sha256"
 	"encoding/hex"
-	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +16,7 @@ import (
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
 )
 
 // abbrevHex returns an abbreviated hex string showing first 2 and last 2 bytes.
@@ -74,26 +74,8 @@ func verifySignature(curve cbmpc.Curve, pubKeyBytes, messageHash, derSig []byte)
 	}
 	pubKey := &ecdsa.PublicKey{Curve: ellipticCurve, X: x, Y: y}
 
-	// Parse DER signature inline
-	// Format: 0x30 [total-len] 0x02 [R-len] [R] 0x02 [S-len] [S]
-	if len(derSig) < 8 || derSig[0] != 0x30 || derSig[2] != 0x02 {
-		return false, nil
-	}
-
-	rLen := int(derSig[3])
-	rBytes := derSig[4 : 4+rLen]
-	r := new(big.Int).SetBytes(rBytes)
-
-	sIndex := 4 + rLen
-	if sIndex+2 >= len(derSig) || derSig[sIndex] != 0x02 {
-		return false, nil
-	}
-
-	sLen := int(derSig[sIndex+1])
-	sBytes := derSig[sIndex+2 : sIndex+2+sLen]
-	s := new(big.Int).SetBytes(sBytes)
-
-	return ecdsa.Verify(pubKey, messageHash, r, s), nil
+	// VerifyASN1 handles the DER long-form length used by P-521 signatures.
+	return ecdsa.VerifyASN1(pubKey, messageHash, derSig), nil
 }
 
 func TestECDSA2PDKG(t *testing.T) {
@@ -105,6 +87,8 @@ func TestECDSA2PDKG(t *testing.T) {
 
 	curves := []cbmpc.Curve{
 		cbmpc.CurveP256,
+		cbmpc.CurveP384,
+		cbmpc.CurveP521,
 		cbmpc.CurveSecp256k1,
 	}
 
@@ -165,6 +149,25 @@ func TestECDSA2PDKG(t *testing.T) {
 				if len(keyBytes) == 0 {
 					t.Fatalf("Party %d got empty key", i)
 				}
+				if err := result.Key.Validate(); err != nil {
+					t.Fatalf("Party %d key failed validation: %v", i, err)
+				}
+
+				protected, err := result.Key.ProtectedBytes()
+				if err != nil {
+					t.Fatalf("Party %d failed to get protected key bytes: %v", i, err)
+				}
+				if !secmem.Equal(protected.Bytes(), keyBytes) {
+					t.Fatalf("Party %d protected bytes differ from Bytes", i)
+				}
+				protected.Destroy()
+
+				secmem.SetStrict(true)
+				_, err = result.Key.Bytes()
+				secmem.SetStrict(false)
+				if err != secmem.ErrUnprotected {
+					t.Fatalf("Party %d: Bytes in strict mode returned %v, want ErrUnprotected", i, err)
+				}
 			}
 
 			// Verify both parties have the same public key
@@ -0,0 +1,142 @@
+package ecdsa2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
+
+// ImportParams contains parameters for importing an existing ECDSA private
+// key into a 2-party key.
+type ImportParams struct {
+	Curve cbmpc.Curve
+
+	// Importer is the party that holds the existing private key. Both parties
+	// must pass the same value.
+	Importer cbmpc.Role
+
+	// PrivateKey is the big-endian private scalar. It must be set by the
+	// importer and left empty by the other party.
+	PrivateKey []byte
+
+	// PublicKey optionally pins the compressed public key the imported key
+	// must have. The non-importing party should set it to the address being
+	// migrated so it does not end up sharing a key it did not agree to.
+	PublicKey []byte
+}
+
+// ImportResult contains the output of ImportPrivateKey.
+type ImportResult struct {
+	Key *Key
+}
+
+// ImportPrivateKey splits an existing ECDSA private key into two key shares
+// without changing its public key, so a single-key wallet can move to 2-party
+// signing without rotating addresses.
+//
+// The parties run DKG and then move the importer's secret into the shares:
+// the non-importing party learns nothing about the private key, and the
+// resulting Key is indistinguishable from one produced by DKG. The importer
+// necessarily knew the whole key, so it must erase every copy of PrivateKey
+// once the import succeeds (cbmpc.ZeroizeBytes clears the slice passed here).
+// Run Refresh afterwards if the importer's old key material may have been
+// copied elsewhere; refresh re-randomizes both shares.
+//
+// The returned key must be freed with Close() when no longer needed.
+func ImportPrivateKey(ctx context.Context, j *cbmpc.Job2P, params *ImportParams) (_ *ImportResult, err error) {
+	if j == nil {
+		return nil, errors.New("nil job")
+	}
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if !isECDSACurve(params.Curve) {
+		return nil, fmt.Errorf("unsupported curve for ECDSA: %v", params.Curve)
+	}
+	if params.Importer != cbmpc.RoleP1 && params.Importer != cbmpc.RoleP2 {
+		return nil, fmt.Errorf("invalid importer role %d", params.Importer)
+	}
+	if len(params.PrivateKey) > params.Curve.MaxHashSize() {
+		return nil, errors.New("private key exceeds curve order size")
+	}
+	nid, err := backend.CurveToNID(backend.Curve(params.Curve))
+	if err != nil {
+		return nil, err
+	}
+
+	ptr, release, err := j.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	op := &cbmpc.Operation{Protocol: "ecdsa2p.ImportPrivateKey", Curve: params.Curve}
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	keyPtr, err := backend.ECDSA2PImport(ptr, nid, int(params.Importer), params.PrivateKey, params.PublicKey)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	runtime.KeepAlive(j)
+
+	key := newKey(keyPtr)
+	op.PublicKey, _ = key.PublicKey()
+	if err := cbmpc.TrackInScope(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &ImportResult{
+		Key: key,
+	}, nil
+}
+
+// ExportParams contains parameters for ExportPrivateKey.
+type ExportParams struct {
+	Key *Key
+}
+
+// ExportPrivateKey reconstructs the full private key from both shares and
+// returns it, left-padded to the curve order size, in a secmem.Buffer. Both
+// parties must run it with their shares of the same key, so one party alone
+// can never recover the key; each party receives the scalar.
+//
+// It exists to test ImportPrivateKey round trips and as a last-resort exit
+// from 2-party custody. Reconstructing the key defeats the purpose of
+// splitting it: do not call it in normal operation. Call Destroy on the buffer
+// when done.
+func ExportPrivateKey(ctx context.Context, j *cbmpc.Job2P, params *ExportParams) (_ *secmem.Buffer, err error) {
+	if j == nil {
+		return nil, errors.New("nil job")
+	}
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.Key == nil || params.Key.ckey == nil {
+		return nil, errors.New("nil or closed key")
+	}
+
+	ptr, release, err := j.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	op := operation("ecdsa2p.ExportPrivateKey", params.Key, nil)
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	x, err := backend.ECDSA2PExport(ptr, params.Key.ckey)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	runtime.KeepAlive(j)
+	runtime.KeepAlive(params.Key)
+
+	return secmem.Move(x)
+}
@@ -0,0 +1,174 @@
+package ecdsa2p_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// runPair runs fn for both parties over a fresh mocknet and returns their errors.
+func runPair(t *testing.T, fn func(party int, job *cbmpc.Job2P) error) [2]error {
+	t.Helper()
+	net := mocknet.New()
+	names := [2]string{"party1", "party2"}
+	var (
+		wg   sync.WaitGroup
+		errs [2]error
+	)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(party int) {
+			defer wg.Done()
+			role := cbmpc.RoleP1
+			if party == 1 {
+				role = cbmpc.RoleP2
+			}
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(party), cbmpc.RoleID(1-party)), role, names)
+			if err != nil {
+				errs[party] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			errs[party] = fn(party, job)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestImportExportPrivateKey(t *testing.T) {
+	for _, importer := range []cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2} {
+		t.Run(map[cbmpc.Role]string{cbmpc.RoleP1: "P1", cbmpc.RoleP2: "P2"}[importer], func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := priv.D.FillBytes(make([]byte, 32))
+			pub := elliptic.MarshalCompressed(elliptic.P256(), priv.X, priv.Y)
+
+			var keys [2]*ecdsa2p.Key
+			errs := runPair(t, func(party int, job *cbmpc.Job2P) error {
+				params := &ecdsa2p.ImportParams{Curve: cbmpc.CurveP256, Importer: importer}
+				if party == int(importer) {
+					params.PrivateKey = d
+				} else {
+					params.PublicKey = pub
+				}
+				res, err := ecdsa2p.ImportPrivateKey(ctx, job, params)
+				if err != nil {
+					return err
+				}
+				keys[party] = res.Key
+				return nil
+			})
+			for i, err := range errs {
+				if err != nil {
+					t.Fatalf("party %d import: %v", i, err)
+				}
+			}
+			defer keys[0].Close()
+			defer keys[1].Close()
+
+			for i, k := range keys {
+				got, err := k.PublicKey()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, pub) {
+					t.Fatalf("party %d public key %x, want %x", i, got, pub)
+				}
+			}
+
+			hash := sha256.Sum256([]byte("imported wallet"))
+			var sig []byte
+			errs = runPair(t, func(party int, job *cbmpc.Job2P) error {
+				res, err := ecdsa2p.Sign(ctx, job, &ecdsa2p.SignParams{Key: keys[party], Message: hash[:]})
+				if err == nil && party == 0 {
+					sig = res.Signature
+				}
+				return err
+			})
+			for i, err := range errs {
+				if err != nil {
+					t.Fatalf("party %d sign: %v", i, err)
+				}
+			}
+			if !ecdsa.VerifyASN1(&priv.PublicKey, hash[:], sig) {
+				t.Fatal("signature with imported key does not verify under the original public key")
+			}
+
+			var exported [2][]byte
+			errs = runPair(t, func(party int, job *cbmpc.Job2P) error {
+				buf, err := ecdsa2p.ExportPrivateKey(ctx, job, &ecdsa2p.ExportParams{Key: keys[party]})
+				if err != nil {
+					return err
+				}
+				defer buf.Destroy()
+				exported[party] = bytes.Clone(buf.Bytes())
+				return nil
+			})
+			for i, err := range errs {
+				if err != nil {
+					t.Fatalf("party %d export: %v", i, err)
+				}
+				if !bytes.Equal(exported[i], d) {
+					t.Fatalf("party %d exported a different private key", i)
+				}
+			}
+		})
+	}
+}
+
+func TestImportPrivateKeyRejectsWrongPublicKey(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := runPair(t, func(party int, job *cbmpc.Job2P) error {
+		params := &ecdsa2p.ImportParams{Curve: cbmpc.CurveP256, Importer: cbmpc.RoleP1}
+		if party == 0 {
+			params.PrivateKey = priv.D.FillBytes(make([]byte, 32))
+		} else {
+			params.PublicKey = elliptic.MarshalCompressed(elliptic.P256(), other.X, other.Y)
+		}
+		res, err := ecdsa2p.ImportPrivateKey(ctx, job, params)
+		if err == nil {
+			_ = res.Key.Close()
+		}
+		return err
+	})
+	if errs[1] == nil {
+		t.Fatal("party 2 accepted a key that does not match the pinned public key")
+	}
+}
+
+func TestImportPrivateKeyValidation(t *testing.T) {
+	ctx := context.Background()
+	if _, err := ecdsa2p.ImportPrivateKey(ctx, nil, &ecdsa2p.ImportParams{}); err == nil {
+		t.Fatal("expected error for nil job")
+	}
+	if _, err := ecdsa2p.ExportPrivateKey(ctx, nil, &ecdsa2p.ExportParams{}); err == nil {
+		t.Fatal("expected error for nil job")
+	}
+}
@@ -0,0 +1,198 @@
+package ecdsa2p
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// isECDSACurve reports whether c is a short Weierstrass curve usable with ECDSA.
+func isECDSACurve(c cbmpc.Curve) bool {
+	switch c {
+	case cbmpc.CurveP256, cbmpc.CurveP384, cbmpc.CurveP521, cbmpc.CurveSecp256k1:
+		return true
+	default:
+		return false
+	}
+}
+
+type derSignature struct {
+	R, S *big.Int
+}
+
+// ParseSignature decodes a DER-encoded ECDSA signature, as returned in
+// SignResult.Signature, into its r and s components.
+//
+// P-521 signatures are longer than 127 bytes and use the DER long-form
+// length; parsers that assume a single length byte will reject them.
+func ParseSignature(der []byte) (r, s *big.Int, err error) {
+	var sig derSignature
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid DER signature: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, nil, errors.New("trailing data after DER signature")
+	}
+	if sig.R == nil || sig.S == nil || sig.R.Sign() <= 0 || sig.S.Sign() <= 0 {
+		return nil, nil, errors.New("invalid DER signature: r and s must be positive")
+	}
+	return sig.R, sig.S, nil
+}
+
+// SigFormat selects the encoding of ECDSA signatures returned by signing.
+type SigFormat int
+
+const (
+	// SigFormatDER is an ASN.1 DER sequence of r and s, as used by Bitcoin
+	// transactions, X.509 and ecdsa.VerifyASN1. It is the default.
+	SigFormatDER SigFormat = iota
+	// SigFormatRaw is the fixed-width r || s form used by JOSE (ES256,
+	// ES384, ES512) and PKCS#11. Each half is left-padded to the curve's
+	// order size.
+	SigFormatRaw
+	// SigFormatCompact is SigFormatRaw with s normalized to the lower half of
+	// the curve order (s <= n/2), as Ethereum (EIP-2) and Bitcoin standardness
+	// rules (BIP-62) require. On secp256k1 it is the 64-byte compact form of
+	// libsecp256k1. Normalizing s keeps the signature valid.
+	SigFormatCompact
+)
+
+// String returns the name of the format.
+func (f SigFormat) String() string {
+	switch f {
+	case SigFormatDER:
+		return "DER"
+	case SigFormatRaw:
+		return "raw"
+	case SigFormatCompact:
+		return "compact"
+	default:
+		return fmt.Sprintf("SigFormat(%d)", int(f))
+	}
+}
+
+// SignatureFromDER converts a DER-encoded ECDSA signature, as returned by
+// the native protocol, into format f for curve c.
+func SignatureFromDER(c cbmpc.Curve, der []byte, f SigFormat) ([]byte, error) {
+	switch f {
+	case SigFormatDER:
+		if _, _, err := ParseSignature(der); err != nil {
+			return nil, err
+		}
+		return der, nil
+	case SigFormatRaw:
+		return SignatureToRaw(c, der)
+	case SigFormatCompact:
+		raw, err := SignatureToRaw(c, der)
+		if err != nil {
+			return nil, err
+		}
+		size := len(raw) / 2
+		n := curveOrder(c)
+		s := new(big.Int).SetBytes(raw[size:])
+		if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+			s.Sub(n, s).FillBytes(raw[size:])
+		}
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("unknown signature format: %v", f)
+	}
+}
+
+// SignatureToRaw converts a DER-encoded ECDSA signature into the fixed-width
+// r || s form used by JOSE (ES256, ES384, ES512) and PKCS#11. Each half is
+// left-padded to the curve's order size: 32 bytes for P-256 and secp256k1,
+// 48 bytes for P-384 and 66 bytes for P-521.
+func SignatureToRaw(c cbmpc.Curve, der []byte) ([]byte, error) {
+	if !isECDSACurve(c) {
+		return nil, fmt.Errorf("unsupported curve for ECDSA: %v", c)
+	}
+	r, s, err := ParseSignature(der)
+	if err != nil {
+		return nil, err
+	}
+	size := c.MaxHashSize()
+	if len(r.Bytes()) > size || len(s.Bytes()) > size {
+		return nil, fmt.Errorf("signature component exceeds %d bytes for %v", size, c)
+	}
+	out := make([]byte, 2*size)
+	r.FillBytes(out[:size])
+	s.FillBytes(out[size:])
+	return out, nil
+}
+
+// RawSignature converts a DER-encoded ECDSA signature into the fixed-width
+// r || s form.
+//
+// Deprecated: Use SignatureToRaw.
+func RawSignature(c cbmpc.Curve, der []byte) ([]byte, error) {
+	return SignatureToRaw(c, der)
+}
+
+// SignatureToDER converts a fixed-width r || s signature for curve c, in
+// SigFormatRaw or SigFormatCompact, back into DER.
+func SignatureToDER(c cbmpc.Curve, raw []byte) ([]byte, error) {
+	if !isECDSACurve(c) {
+		return nil, fmt.Errorf("unsupported curve for ECDSA: %v", c)
+	}
+	size := c.MaxHashSize()
+	if len(raw) != 2*size {
+		return nil, fmt.Errorf("raw signature must be %d bytes for %v, got %d", 2*size, c, len(raw))
+	}
+	sig := derSignature{
+		R: new(big.Int).SetBytes(raw[:size]),
+		S: new(big.Int).SetBytes(raw[size:]),
+	}
+	if sig.R.Sign() == 0 || sig.S.Sign() == 0 {
+		return nil, errors.New("invalid raw signature: r and s must be positive")
+	}
+	return asn1.Marshal(sig)
+}
+
+func checkFormat(f SigFormat) error {
+	if f < SigFormatDER || f > SigFormatCompact {
+		return fmt.Errorf("unknown signature format: %v", f)
+	}
+	return nil
+}
+
+// formatSignatures converts signatures returned by the native protocol into
+// format f. Empty signatures, returned to a party that does not receive the
+// output, are left as they are.
+func formatSignatures(c cbmpc.Curve, f SigFormat, sigs [][]byte) error {
+	if f == SigFormatDER {
+		return nil
+	}
+	for i, sig := range sigs {
+		if len(sig) == 0 {
+			continue
+		}
+		out, err := SignatureFromDER(c, sig, f)
+		if err != nil {
+			return err
+		}
+		sigs[i] = out
+	}
+	return nil
+}
+
+// curveOrder returns the group order of an ECDSA curve.
+func curveOrder(c cbmpc.Curve) *big.Int {
+	switch c {
+	case cbmpc.CurveP256:
+		return elliptic.P256().Params().N
+	case cbmpc.CurveP384:
+		return elliptic.P384().Params().N
+	case cbmpc.CurveP521:
+		return elliptic.P521().Params().N
+	default:
+		return btcec.S256().N
+	}
+}
@@ -0,0 +1,103 @@
+package ecdsa2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// SignManyParams contains parameters for SignMany.
+type SignManyParams struct {
+	Key      *Key      // Key share to sign with
+	Messages [][]byte  // Message hashes to sign (must be pre-hashed, max size = curve order size)
+	Format   SigFormat // Encoding of the returned signatures (default DER)
+}
+
+// SignManyResult contains the output of SignMany.
+type SignManyResult struct {
+	// Signatures holds one signature per message, in message order, for
+	// RoleP1. RoleP2 receives no signatures, as with SignBatch.
+	Signatures [][]byte
+}
+
+// SignMany signs a large batch of message hashes by splitting it over
+// several jobs to the same peer and running SignBatch on them in parallel,
+// each with a fresh session. Every job runs its own native protocol
+// instance, normally over its own connection, so the batch uses as many
+// cores on each side as there are jobs.
+//
+// Both parties must call SignMany with their jobs in the same order, the job
+// at index i on one side connected to the job at index i on the other, and
+// with the same messages. The messages are split into len(jobs) contiguous
+// chunks of nearly equal size; jobs left without messages are not used.
+//
+// If any chunk fails, SignMany returns an error naming each failed chunk and
+// no signatures. Each job must not be used by anything else until SignMany
+// returns.
+func SignMany(ctx context.Context, jobs []*cbmpc.Job2P, params *SignManyParams) (*SignManyResult, error) {
+	if len(jobs) == 0 {
+		return nil, errors.New("no jobs")
+	}
+	for i, j := range jobs {
+		if j == nil {
+			return nil, fmt.Errorf("nil job at index %d", i)
+		}
+		for _, other := range jobs[:i] {
+			if other == j {
+				return nil, fmt.Errorf("job at index %d is listed twice", i)
+			}
+		}
+	}
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.Key == nil || params.Key.ckey == nil {
+		return nil, errors.New("nil or closed key")
+	}
+	if len(params.Messages) == 0 {
+		return nil, errors.New("empty messages")
+	}
+
+	chunks := splitChunks(len(params.Messages), len(jobs))
+	results := make([]*SignBatchResult, len(chunks))
+	errs := make([]error, len(chunks))
+	var wg sync.WaitGroup
+	for i, c := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = SignBatch(ctx, jobs[i], &SignBatchParams{
+				Key:      params.Key,
+				Messages: params.Messages[c[0]:c[1]],
+				Format:   params.Format,
+			})
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("chunk %d (messages %d-%d): %w", i, c[0], c[1]-1, errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	var sigs [][]byte
+	for _, r := range results {
+		sigs = append(sigs, r.Signatures...)
+	}
+	return &SignManyResult{Signatures: sigs}, nil
+}
+
+// splitChunks divides n items into at most parts contiguous, non-empty
+// [start, end) ranges whose sizes differ by at most one.
+func splitChunks(n, parts int) [][2]int {
+	parts = min(parts, n)
+	chunks := make([][2]int, parts)
+	for i := range chunks {
+		chunks[i] = [2]int{i * n / parts, (i + 1) * n / parts}
+	}
+	return chunks
+}
@@ -0,0 +1,109 @@
+package ecdsa2p_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+func TestECDSA2PSignMany(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	names := [2]string{"party1", "party2"}
+	curve := cbmpc.CurveSecp256k1
+	const numJobs = 3
+
+	// One network, and so one pair of connected jobs, per parallel lane.
+	jobs := make([][]*cbmpc.Job2P, 2)
+	for range numJobs {
+		net := mocknet.New()
+		for i, role := range []cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2} {
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), role, names)
+			if err != nil {
+				t.Fatalf("NewJob2P: %v", err)
+			}
+			t.Cleanup(func() { _ = job.Close() })
+			jobs[i] = append(jobs[i], job)
+		}
+	}
+
+	var wg sync.WaitGroup
+	keys := make([]*ecdsa2p.Key, 2)
+	errs := make([]error, 2)
+	for i := range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := ecdsa2p.DKG(ctx, jobs[i][0], &ecdsa2p.DKGParams{Curve: curve})
+			if err == nil {
+				keys[i] = res.Key
+			}
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d DKG: %v", i, err)
+		}
+	}
+	defer func() {
+		for _, k := range keys {
+			_ = k.Close()
+		}
+	}()
+
+	// Seven messages over three jobs: chunks of 2, 2 and 3.
+	hashes := make([][]byte, 7)
+	for i := range hashes {
+		h := sha256.Sum256(fmt.Appendf(nil, "payout %d", i))
+		hashes[i] = h[:]
+	}
+	results := make([]*ecdsa2p.SignManyResult, 2)
+	for i := range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = ecdsa2p.SignMany(ctx, jobs[i], &ecdsa2p.SignManyParams{Key: keys[i], Messages: hashes})
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d SignMany: %v", i, err)
+		}
+	}
+
+	if len(results[0].Signatures) != len(hashes) {
+		t.Fatalf("P1 got %d signatures, want %d", len(results[0].Signatures), len(hashes))
+	}
+	if len(results[1].Signatures) != 0 {
+		t.Fatalf("P2 got %d signatures, want none", len(results[1].Signatures))
+	}
+	pub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, sig := range results[0].Signatures {
+		if ok, err := verifySignature(curve, pub, hashes[i], sig); err != nil || !ok {
+			t.Fatalf("signature %d does not verify: %v", i, err)
+		}
+	}
+}
+
+func TestECDSA2PSignManyValidation(t *testing.T) {
+	if _, err := ecdsa2p.SignMany(context.Background(), nil, &ecdsa2p.SignManyParams{}); err == nil {
+		t.Fatal("SignMany accepted no jobs")
+	}
+	if _, err := ecdsa2p.SignMany(context.Background(), []*cbmpc.Job2P{nil}, &ecdsa2p.SignManyParams{}); err == nil {
+		t.Fatal("SignMany accepted a nil job")
+	}
+}
@@ -0,0 +1,166 @@
+package ecdsa2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// DefaultStreamBatchSize is the batch size SignStream uses when
+// SignStreamParams.BatchSize is zero.
+const DefaultStreamBatchSize = 32
+
+// SignStreamParams contains parameters for SignStream.
+type SignStreamParams struct {
+	Key    *Key      // Key share to sign with
+	Format SigFormat // Encoding of the returned signatures (default DER)
+	// BatchSize is the number of hashes signed per protocol run. Both
+	// parties must use the same value. Zero selects DefaultStreamBatchSize.
+	BatchSize int
+}
+
+// StreamSignature is one result of SignStream.
+type StreamSignature struct {
+	Index     int    // position of the hash in the input stream
+	Hash      []byte // the hash signed
+	Signature []byte // encoded as SignStreamParams.Format; nil for RoleP2
+	Err       error  // set if the batch containing the hash failed
+}
+
+// SignStream signs the hashes received from in and emits a result for each
+// on the returned channel as its batch completes, for pipelines that
+// produce hashes over time.
+//
+// Hashes are grouped into batches of exactly BatchSize, except the last one
+// before in is closed, so both parties form the same batches without
+// coordinating; both must send the same hashes in the same order. Batch k
+// runs on jobs[k % len(jobs)], so at most len(jobs) batches are in flight.
+// When they all are, SignStream stops reading in, and it also stops while
+// the output is not being read, so a slow stage on either side slows the
+// producer instead of buffering without bound.
+//
+// Results of different jobs can arrive out of input order; Index gives the
+// position. The output channel is closed once in is closed and every batch
+// has completed. If a batch fails, its hashes are emitted with Err set and
+// the stream ends without reading the rest of in: the peer may have stopped
+// at a different point, so the jobs should not be reused. It also ends when
+// ctx is done.
+func SignStream(ctx context.Context, jobs []*cbmpc.Job2P, params *SignStreamParams, in <-chan []byte) (<-chan StreamSignature, error) {
+	if len(jobs) == 0 {
+		return nil, errors.New("no jobs")
+	}
+	for i, j := range jobs {
+		if j == nil {
+			return nil, fmt.Errorf("nil job at index %d", i)
+		}
+		for _, other := range jobs[:i] {
+			if other == j {
+				return nil, fmt.Errorf("job at index %d is listed twice", i)
+			}
+		}
+	}
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.Key == nil || params.Key.ckey == nil {
+		return nil, errors.New("nil or closed key")
+	}
+	if err := checkFormat(params.Format); err != nil {
+		return nil, err
+	}
+	if in == nil {
+		return nil, errors.New("nil input channel")
+	}
+	size := params.BatchSize
+	if size == 0 {
+		size = DefaultStreamBatchSize
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("invalid batch size %d", size)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	out := make(chan StreamSignature, size)
+	lanes := make([]chan streamBatch, len(jobs))
+	var wg sync.WaitGroup
+	for i, j := range jobs {
+		lanes[i] = make(chan streamBatch)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range lanes[i] {
+				if !signStreamBatch(ctx, j, params, b, out) {
+					cancel()
+				}
+			}
+		}()
+	}
+	go func() {
+		defer func() {
+			for _, lane := range lanes {
+				close(lane)
+			}
+			wg.Wait()
+			cancel()
+			close(out)
+		}()
+		next := 0
+		for k := 0; ; k++ {
+			b := streamBatch{start: next}
+			for len(b.hashes) < size {
+				select {
+				case h, ok := <-in:
+					if !ok {
+						if len(b.hashes) > 0 {
+							dispatch(ctx, lanes[k%len(lanes)], b)
+						}
+						return
+					}
+					b.hashes = append(b.hashes, h)
+				case <-ctx.Done():
+					return
+				}
+			}
+			if !dispatch(ctx, lanes[k%len(lanes)], b) {
+				return
+			}
+			next += len(b.hashes)
+		}
+	}()
+	return out, nil
+}
+
+type streamBatch struct {
+	start  int // index of hashes[0] in the stream
+	hashes [][]byte
+}
+
+func dispatch(ctx context.Context, lane chan<- streamBatch, b streamBatch) bool {
+	select {
+	case lane <- b:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// signStreamBatch signs one batch and emits its results. It reports whether
+// the batch succeeded.
+func signStreamBatch(ctx context.Context, j *cbmpc.Job2P, params *SignStreamParams, b streamBatch, out chan<- StreamSignature) bool {
+	res, err := SignBatch(ctx, j, &SignBatchParams{Key: params.Key, Messages: b.hashes, Format: params.Format})
+	for i, h := range b.hashes {
+		r := StreamSignature{Index: b.start + i, Hash: h, Err: err}
+		if err == nil && i < len(res.Signatures) {
+			r.Signature = res.Signatures[i]
+		}
+		select {
+		case out <- r:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return err == nil
+}
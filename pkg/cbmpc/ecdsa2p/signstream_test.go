@@ -0,0 +1,131 @@
+package ecdsa2p_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+func TestECDSA2PSignStream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	names := [2]string{"party1", "party2"}
+	curve := cbmpc.CurveSecp256k1
+	const numJobs = 2
+
+	jobs := make([][]*cbmpc.Job2P, 2)
+	for range numJobs {
+		net := mocknet.New()
+		for i, role := range []cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2} {
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), role, names)
+			if err != nil {
+				t.Fatalf("NewJob2P: %v", err)
+			}
+			t.Cleanup(func() { _ = job.Close() })
+			jobs[i] = append(jobs[i], job)
+		}
+	}
+
+	var wg sync.WaitGroup
+	keys := make([]*ecdsa2p.Key, 2)
+	errs := make([]error, 2)
+	for i := range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := ecdsa2p.DKG(ctx, jobs[i][0], &ecdsa2p.DKGParams{Curve: curve})
+			if err == nil {
+				keys[i] = res.Key
+			}
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d DKG: %v", i, err)
+		}
+	}
+	defer func() {
+		for _, k := range keys {
+			_ = k.Close()
+		}
+	}()
+
+	// Seven hashes in batches of three: two full batches and a final one.
+	hashes := make([][]byte, 7)
+	for i := range hashes {
+		h := sha256.Sum256(fmt.Appendf(nil, "event %d", i))
+		hashes[i] = h[:]
+	}
+	results := make([][]ecdsa2p.StreamSignature, 2)
+	for i := range 2 {
+		in := make(chan []byte)
+		out, err := ecdsa2p.SignStream(ctx, jobs[i], &ecdsa2p.SignStreamParams{Key: keys[i], BatchSize: 3}, in)
+		if err != nil {
+			t.Fatalf("party %d SignStream: %v", i, err)
+		}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			defer close(in)
+			for _, h := range hashes {
+				in <- h
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for r := range out {
+				results[i] = append(results[i], r)
+			}
+		}()
+	}
+	wg.Wait()
+
+	pub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, rs := range results {
+		if len(rs) != len(hashes) {
+			t.Fatalf("party %d got %d results, want %d", i, len(rs), len(hashes))
+		}
+		seen := make(map[int]bool)
+		for _, r := range rs {
+			if r.Err != nil {
+				t.Fatalf("party %d hash %d: %v", i, r.Index, r.Err)
+			}
+			if seen[r.Index] {
+				t.Fatalf("party %d got hash %d twice", i, r.Index)
+			}
+			seen[r.Index] = true
+			if i == 1 {
+				if r.Signature != nil {
+					t.Fatalf("P2 got a signature for hash %d", r.Index)
+				}
+				continue
+			}
+			if ok, err := verifySignature(curve, pub, hashes[r.Index], r.Signature); err != nil || !ok {
+				t.Fatalf("signature %d does not verify: %v", r.Index, err)
+			}
+		}
+	}
+}
+
+func TestECDSA2PSignStreamValidation(t *testing.T) {
+	in := make(chan []byte)
+	if _, err := ecdsa2p.SignStream(context.Background(), nil, &ecdsa2p.SignStreamParams{}, in); err == nil {
+		t.Fatal("SignStream accepted no jobs")
+	}
+	if _, err := ecdsa2p.SignStream(context.Background(), []*cbmpc.Job2P{nil}, &ecdsa2p.SignStreamParams{}, in); err == nil {
+		t.Fatal("SignStream accepted a nil job")
+	}
+}
@@ -0,0 +1,74 @@
+package ecdsa2p
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+// SignedMessage is a public key, message hash and signature to check with
+// VerifySignatures.
+type SignedMessage struct {
+	PublicKey []byte // Compressed or uncompressed public key, as returned by Key.PublicKey
+	Message   []byte // Message hash that was signed
+	Signature []byte // DER-encoded signature, as returned in SignResult.Signature
+}
+
+// VerifySignatures verifies ECDSA signatures on curve c in a single call into
+// the native library. It is meant for post-signing sanity checks in services
+// that sign at high volume, where one CGO round-trip per signature dominates.
+//
+// ECDSA has no batch verification equation, so each signature is still
+// checked on its own; the saving is in the call overhead.
+//
+// The returned slice has one entry per element of sigs: nil if that signature
+// verified, otherwise the reason it was rejected. One bad signature does not
+// stop the rest from being checked. The error return is reserved for failures
+// that affect the whole call, such as an empty batch or unsupported curve.
+func VerifySignatures(c cbmpc.Curve, sigs []SignedMessage) ([]error, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("empty signatures")
+	}
+	if !isECDSACurve(c) {
+		return nil, fmt.Errorf("unsupported curve for ECDSA: %v", c)
+	}
+	nid, err := backend.CurveToNID(backend.Curve(c))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]error, len(sigs))
+	idx := make([]int, 0, len(sigs))
+	pubKeys := make([][]byte, 0, len(sigs))
+	hashes := make([][]byte, 0, len(sigs))
+	raw := make([][]byte, 0, len(sigs))
+	for i, s := range sigs {
+		switch {
+		case len(s.PublicKey) == 0:
+			results[i] = errors.New("empty public key")
+		case len(s.Message) == 0:
+			results[i] = errors.New("empty message")
+		case len(s.Signature) == 0:
+			results[i] = errors.New("empty signature")
+		default:
+			idx = append(idx, i)
+			pubKeys = append(pubKeys, s.PublicKey)
+			hashes = append(hashes, s.Message)
+			raw = append(raw, s.Signature)
+		}
+	}
+	if len(idx) == 0 {
+		return results, nil
+	}
+
+	errs, err := backend.ECDSAVerifyMany(nid, pubKeys, hashes, raw)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	for j, i := range idx {
+		results[i] = cbmpc.RemapError(errs[j])
+	}
+	return results, nil
+}
@@ -0,0 +1,61 @@
+package ecdsa2p_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+)
+
+func TestVerifySignatures(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	pub := elliptic.MarshalCompressed(elliptic.P256(), priv.X, priv.Y)
+
+	sigs := make([]ecdsa2p.SignedMessage, 5)
+	for i := range sigs {
+		hash := sha256.Sum256(fmt.Appendf(nil, "message %d", i))
+		sig, err := ecdsa.SignASN1(rand.Reader, priv, hash[:])
+		if err != nil {
+			t.Fatalf("SignASN1 failed: %v", err)
+		}
+		sigs[i] = ecdsa2p.SignedMessage{PublicKey: pub, Message: hash[:], Signature: sig}
+	}
+	// Signature 1 is over a different message and signature 3 is missing.
+	sigs[1].Message = sigs[0].Message
+	sigs[3].Signature = nil
+
+	results, err := ecdsa2p.VerifySignatures(cbmpc.CurveP256, sigs)
+	if err != nil {
+		t.Fatalf("VerifySignatures failed: %v", err)
+	}
+	if len(results) != len(sigs) {
+		t.Fatalf("got %d results, want %d", len(results), len(sigs))
+	}
+	for i, err := range results {
+		bad := i == 1 || i == 3
+		if bad && err == nil {
+			t.Errorf("signature %d verified, want error", i)
+		}
+		if !bad && err != nil {
+			t.Errorf("signature %d: %v", i, err)
+		}
+	}
+}
+
+func TestVerifySignaturesErrors(t *testing.T) {
+	if _, err := ecdsa2p.VerifySignatures(cbmpc.CurveP256, nil); err == nil {
+		t.Error("VerifySignatures with no signatures should fail")
+	}
+	sigs := []ecdsa2p.SignedMessage{{PublicKey: []byte{1}, Message: []byte{1}, Signature: []byte{1}}}
+	if _, err := ecdsa2p.VerifySignatures(cbmpc.CurveEd25519, sigs); err == nil {
+		t.Error("VerifySignatures on Ed25519 should fail")
+	}
+}
@@ -0,0 +1,32 @@
+package ecdsamp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// bitLeakError returns the *cbmpc.BitLeakError for global-abort signing with
+// key that failed with ErrBitLeak, first refreshing the key if the job asks
+// for it. The refresh runs after the signing invocation has released the job.
+// A threshold key is not refreshed: ThresholdRefresh needs every holder of a
+// share, and a signing job may have only a quorum.
+func bitLeakError(ctx context.Context, j *cbmpc.JobMP, key *Key) error {
+	e := &cbmpc.BitLeakError{Protocol: "ecdsamp.SignWithGlobalAbort", MustRefresh: true}
+	e.Fingerprint, _ = key.Fingerprint()
+	if !j.RefreshOnBitLeak() {
+		return e
+	}
+	if key.structure != nil {
+		e.RefreshErr = errors.New("threshold key; refresh it with ThresholdRefresh")
+		return e
+	}
+	res, err := Refresh(ctx, j, &RefreshParams{Key: key})
+	if err != nil {
+		e.RefreshErr = err
+	} else {
+		e.RefreshedKey, e.MustRefresh = res.NewKey, false
+	}
+	return e
+}
@@ -1,23 +1,35 @@
 // Package ecdsamp provides multi-party ECDSA protocols with threshold signing.
 //
 // This package implements threshold ECDSA protocols that allow n parties to jointly
-// generate an ECDSA key and create signatures with a threshold of t+1 parties (where
-// t < n). The protocols support both key generation and signing with flexible threshold
+// generate an ECDSA key and create signatures with any Threshold of them (at most n).
+// The protocols support both key generation and signing with flexible threshold
 // parameters.
 //
 // # Threshold Signing
 //
 // Threshold ECDSA allows a subset of parties to cooperate to create signatures:
 //   - Key generation involves all n parties
-//   - Signing requires any t+1 parties (threshold)
+//   - Signing requires any Threshold parties, on a job of just those parties
 //   - The private key is never reconstructed on a single device
-//   - The scheme is secure as long as at most t parties are compromised
+//   - The scheme is secure as long as fewer than Threshold parties are compromised
 //
 // # Key Operations
 //
-//   - DKG: Distributed Key Generation for n parties with threshold t
-//   - Sign: Threshold signature generation (requires t+1 parties)
+//   - DKG: Distributed Key Generation for n parties with a Threshold
+//   - Sign: Threshold signature generation (requires Threshold parties)
+//   - SignWithGlobalAbort: Signing where every party aborts with ErrBitLeak if the signature fails to verify
 //   - Refresh: Key share refresh while preserving the public key
+//   - Reshare: Add or retire parties and change the threshold while preserving the public key
+//
+// # Jobs and Contexts
+//
+// A job runs one protocol at a time. A plain job, from cbmpc.NewJobMP or
+// cbmpc.NewJobMPWithContext, now returns cbmpc.ErrJobBusy to a protocol invoked
+// while another is running on it, where earlier releases let the two runs
+// interleave their messages. Share a job between goroutines with
+// cbmpc.NewSerializedJobMP instead. The ctx each function takes bounds that
+// wait, the sign-policy exchange and the operation hooks; the protocol run
+// itself stops when the job's own context is done.
 //
 // # Memory Management
 //
@@ -25,7 +37,7 @@
 //
 //	result, err := ecdsamp.DKG(ctx, job, &ecdsamp.DKGParams{
 //	    Curve:     cbmpc.CurveP256,
-//	    Threshold: 2,
+//	    Threshold: 3,
 //	})
 //	if err != nil {
 //	    return err
@@ -37,7 +49,7 @@
 //	// 3-of-5 threshold: 5 parties generate keys, any 3 can sign
 //	params := &ecdsamp.DKGParams{
 //	    Curve:     cbmpc.CurveP256,
-//	    Threshold: 2, // t=2 means 3 parties needed to sign (t+1)
+//	    Threshold: 3, // any 3 parties can sign
 //	}
 //
 //	// All 5 parties run DKG
@@ -45,7 +57,7 @@
 //	defer result1.Key.Close()
 //	// ... (parties 2-5 also run DKG)
 //
-//	// Any 3 parties can cooperate to sign
+//	// Any 3 parties can cooperate to sign, on a job of just those 3
 //	messageHash := sha256.Sum256([]byte("message to sign"))
 //	sig1, _ := ecdsamp.Sign(ctx, job1, &ecdsamp.SignParams{
 //	    Key:     result1.Key,
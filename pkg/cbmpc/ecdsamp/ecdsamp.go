@@ -2,12 +2,18 @@ package ecdsamp
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
+	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
 	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/pubkey"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
 )
 
 // Key represents a multi-party ECDSA key share.
@@ -29,11 +35,18 @@ type Key struct {
 	// The bindings layer uses *C.cbmpc_ecdsamp_key (aliased as backend.ECDSAMPKey)
 	// The alias itself is a pointer type, so we store it directly (not as a pointer to it)
 	ckey backend.ECDSAMPKey
+	// created is when the key share was generated, zero if unknown.
+	created time.Time
+	// quorum is the number of parties needed to sign, zero if every party is.
+	quorum int
+	// structure is the access structure of a threshold key's share, nil for
+	// an n-of-n key.
+	structure ac.AccessStructure
 }
 
 // newKey creates a new Key from a C pointer and sets up a finalizer.
 func newKey(ckey backend.ECDSAMPKey) *Key {
-	k := &Key{ckey: ckey}
+	k := &Key{ckey: ckey, created: time.Now().UTC()}
 	runtime.SetFinalizer(k, func(key *Key) {
 		_ = key.Close()
 	})
@@ -53,7 +66,10 @@ func (k *Key) Close() error {
 }
 
 // Bytes returns the serialized key data for persistent storage or network transmission.
-// Returns a defensive copy to prevent external modification of internal key data.
+// The native serialization is wrapped in an envelope recording the protocol,
+// curve, party name and creation time, which LoadKey checks.
+// In strict mode (secmem.SetStrict) it fails with secmem.ErrUnprotected; use
+// ProtectedBytes instead.
 //
 // SECURITY WARNING:
 // The returned bytes contain sensitive cryptographic key material.
@@ -76,27 +92,102 @@ func (k *Key) Close() error {
 //	}
 //	// Store encrypted bytes...
 func (k *Key) Bytes() ([]byte, error) {
+	if secmem.Strict() {
+		return nil, secmem.ErrUnprotected
+	}
 	if k == nil || k.ckey == nil {
 		return nil, errors.New("nil or closed key")
 	}
-	data, err := backend.ECDSAMPKeySerialize(k.ckey)
+	return k.serialize()
+}
+
+// ProtectedBytes is like Bytes but moves the serialized key into a
+// secmem.Buffer and wipes the intermediate copy. It is the export to use in
+// strict mode. Call Destroy on the buffer when done.
+func (k *Key) ProtectedBytes() (*secmem.Buffer, error) {
+	if k == nil || k.ckey == nil {
+		return nil, errors.New("nil or closed key")
+	}
+	data, err := k.serialize()
+	if err != nil {
+		return nil, err
+	}
+	return secmem.Move(data)
+}
+
+// serialize returns the native serialization of the key in its envelope.
+func (k *Key) serialize() ([]byte, error) {
+	meta, err := k.meta()
+	if err != nil {
+		return nil, err
+	}
+	share, err := backend.ECDSAMPKeySerialize(k.ckey)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
-	// Return a defensive copy to prevent mutation of internal state
-	result := make([]byte, len(data))
-	copy(result, data)
-	return result, nil
+	defer cbmpc.ZeroizeBytes(share)
+	return envelope.EncodeKey(meta, share)
 }
 
-// LoadKey deserializes a key from bytes.
+func (k *Key) meta() (envelope.KeyMeta, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return envelope.KeyMeta{}, err
+	}
+	name, err := backend.ECDSAMPKeyGetPartyName(k.ckey)
+	if err != nil {
+		return envelope.KeyMeta{}, cbmpc.RemapError(err)
+	}
+	return envelope.KeyMeta{
+		Protocol:        envelope.KeyECDSAMP,
+		Curve:           uint8(curve),
+		Role:            name,
+		Created:         k.created,
+		Quorum:          k.quorum,
+		AccessStructure: k.structure,
+	}, nil
+}
+
+// LoadKey deserializes a key from bytes written by Bytes or ProtectedBytes.
+// It fails with cbmpc.ErrKeyProtocolMismatch for another protocol's share,
+// including a schnorrmp share, and cbmpc.ErrKeyVersion for a share written
+// by a newer library. Bare native serializations from earlier releases are
+// still accepted; their Created time is unknown.
 // The returned key must be freed with Close() when no longer needed.
 func LoadKey(data []byte) (*Key, error) {
-	ckey, err := backend.ECDSAMPKeyDeserialize(data)
+	meta, share, err := envelope.UnwrapKey(envelope.KeyECDSAMP, data)
+	if err != nil {
+		return nil, err
+	}
+	ckey, err := backend.ECDSAMPKeyDeserialize(share)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
-	return newKey(ckey), nil
+	k := newKey(ckey)
+	k.created = meta.Created
+	k.quorum = meta.Quorum
+	if len(meta.AccessStructure) > 0 {
+		k.structure = append(ac.AccessStructure(nil), meta.AccessStructure...)
+	}
+	got, err := k.meta()
+	if err == nil {
+		err = meta.Check(got.Curve, got.Role)
+	}
+	if err != nil {
+		_ = k.Close()
+		return nil, err
+	}
+	return k, nil
+}
+
+// Created returns when the key share was generated by DKG, Refresh or
+// Reshare, or the zero time if it was loaded from a share serialized before
+// keys recorded it.
+func (k *Key) Created() time.Time {
+	if k == nil {
+		return time.Time{}
+	}
+	return k.created
 }
 
 // PublicKey extracts the public key point Q from the key share.
@@ -128,9 +219,59 @@ func (k *Key) Curve() (cbmpc.Curve, error) {
 	return cbmpc.Curve(curve), nil
 }
 
+// Fingerprint returns the key's cbmpc.KeyFingerprint, a stable identifier
+// derived from its curve and public key. Every party's share of the key has
+// the same fingerprint, and operation logs and audit records use it too.
+func (k *Key) Fingerprint() (string, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return "", err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return "", err
+	}
+	return cbmpc.KeyFingerprint(curve, pub), nil
+}
+
+// Validate runs the native consistency checks on the key share: the curve is
+// known, the secret share is a nonzero scalar below the curve order, the
+// public key and every party's public share are valid points, and the
+// party's own public share is its secret share times the generator. It
+// returns cbmpc.ErrKeyInvalid for a share that fails them, so a keystore can
+// set aside a corrupted share at load time rather than in the middle of a
+// signing protocol.
+func (k *Key) Validate() error {
+	if k == nil || k.ckey == nil {
+		return errors.New("nil or closed key")
+	}
+	err := backend.ECDSAMPKeyValidate(k.ckey)
+	runtime.KeepAlive(k)
+	return cbmpc.RemapError(err)
+}
+
+// ECDSAPublicKey returns the public key as an *ecdsa.PublicKey, ready for
+// ecdsa.VerifyASN1. secp256k1 keys use btcec.S256() as their curve, since
+// crypto/elliptic does not provide one; crypto/x509 cannot encode them.
+func (k *Key) ECDSAPublicKey() (*ecdsa.PublicKey, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return pubkey.ECDSA(curve, pub)
+}
+
 // DKGParams contains parameters for multi-party ECDSA distributed key generation.
 type DKGParams struct {
 	Curve cbmpc.Curve
+	// Threshold is the number of the job's n parties needed to sign with a
+	// threshold key, THRESHOLD[Threshold](names...); it must be at most n.
+	// Zero generates an n-of-n key.
+	Threshold int
 }
 
 // DKGResult contains the output of multi-party ECDSA distributed key generation.
@@ -142,35 +283,70 @@ type DKGResult struct {
 // DKG performs multi-party ECDSA distributed key generation.
 // The returned key must be freed with Close() when no longer needed.
 //
-// Context behavior: ctx is ignored; use cbmpc.NewJobMPWithContext to control cancellation.
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and is passed to the job's operation hooks and logger;
+// a cbmpc.ResourceScope it carries takes ownership of the returned key. The
+// protocol run itself stops only when the job's own context is done (see
+// cbmpc.NewJobMPWithContext).
 //
 // See cb-mpc/src/cbmpc/protocol/ecdsa_mp.h for protocol details.
-func DKG(_ context.Context, j *cbmpc.JobMP, params *DKGParams) (*DKGResult, error) {
+func DKG(ctx context.Context, j *cbmpc.JobMP, params *DKGParams) (_ *DKGResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
 	if params == nil {
 		return nil, errors.New("nil params")
 	}
+	names := j.Names()
+	if params.Threshold < 0 || params.Threshold > len(names) {
+		return nil, fmt.Errorf("threshold %d out of range [0,%d]", params.Threshold, len(names))
+	}
+	var structure ac.AccessStructure
+	if params.Threshold > 0 {
+		if structure, err = thresholdStructure(names, params.Threshold); err != nil {
+			return nil, err
+		}
+	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := &cbmpc.Operation{Protocol: "ecdsamp.DKG", Curve: params.Curve}
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
 	nid, err := backend.CurveToNID(backend.Curve(params.Curve))
 	if err != nil {
 		return nil, err
 	}
 
-	keyPtr, sid, err := backend.ECDSAMP_DKG(ptr, nid)
+	var keyPtr backend.ECDSAMPKey
+	var sid []byte
+	if params.Threshold > 0 {
+		keyPtr, sid, err = backend.ECDSAMPThresholdDKG(ptr, nid, []byte(structure), allParties(len(names)))
+	} else {
+		keyPtr, sid, err = backend.ECDSAMP_DKG(ptr, nid)
+	}
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
 	runtime.KeepAlive(j)
 
+	key := newKey(keyPtr)
+	if params.Threshold > 0 {
+		key.quorum = params.Threshold
+		key.structure = structure
+	}
+	op.PublicKey, _ = key.PublicKey()
+	if err := cbmpc.TrackInScope(ctx, key); err != nil {
+		return nil, err
+	}
+
 	return &DKGResult{
-		Key:       newKey(keyPtr),
+		Key:       key,
 		SessionID: cbmpc.NewSessionID(sid),
 	}, nil
 }
@@ -196,10 +372,14 @@ type RefreshResult struct {
 // - If params.SessionID is provided, it will be used and updated
 // - The updated/generated session ID is returned in RefreshResult.SessionID
 //
-// Context behavior: ctx is ignored; use cbmpc.NewJobMPWithContext to control cancellation.
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and is passed to the job's operation hooks and logger;
+// a cbmpc.ResourceScope it carries takes ownership of the returned key. The
+// protocol run itself stops only when the job's own context is done (see
+// cbmpc.NewJobMPWithContext).
 //
 // See cb-mpc/src/cbmpc/protocol/ecdsa_mp.h for protocol details.
-func Refresh(_ context.Context, j *cbmpc.JobMP, params *RefreshParams) (*RefreshResult, error) {
+func Refresh(ctx context.Context, j *cbmpc.JobMP, params *RefreshParams) (_ *RefreshResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -209,11 +389,19 @@ func Refresh(_ context.Context, j *cbmpc.JobMP, params *RefreshParams) (*Refresh
 	if params.Key == nil || params.Key.ckey == nil {
 		return nil, errors.New("nil or closed key")
 	}
+	if params.Key.structure != nil {
+		return nil, errors.New("threshold key; use ThresholdRefresh")
+	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("ecdsamp.Refresh", params.Key, nil)
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
 	newKeyCkey, newSid, err := backend.ECDSAMPRefresh(ptr, params.Key.ckey, params.SessionID.Bytes())
 	if err != nil {
@@ -222,8 +410,13 @@ func Refresh(_ context.Context, j *cbmpc.JobMP, params *RefreshParams) (*Refresh
 	runtime.KeepAlive(j)
 	runtime.KeepAlive(params.Key)
 
+	key := newKey(newKeyCkey)
+	if err := cbmpc.TrackInScope(ctx, key); err != nil {
+		return nil, err
+	}
+
 	return &RefreshResult{
-		NewKey:    newKey(newKeyCkey),
+		NewKey:    key,
 		SessionID: cbmpc.NewSessionID(newSid),
 	}, nil
 }
@@ -248,10 +441,100 @@ type SignResult struct {
 // Only the party with index matching SigReceiver will receive a non-empty signature.
 // All other parties will receive an empty signature.
 //
-// Context behavior: ctx is ignored; use cbmpc.NewJobMPWithContext to control cancellation.
+// A threshold key signs on a job of any parties that satisfy its access
+// structure, each turning its share into an additive share over them; a job
+// that does not satisfy it fails before any message is sent. An n-of-n key
+// signs on a job of all its parties.
+//
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and the sign-policy exchange (see
+// cbmpc.JobMP.SetSignPolicy), and is passed to the job's operation hooks and
+// logger. The protocol run itself stops only when the job's own context is
+// done (see cbmpc.NewJobMPWithContext).
+//
+// See cb-mpc/src/cbmpc/protocol/ecdsa_mp.h for protocol details.
+func Sign(ctx context.Context, j *cbmpc.JobMP, params *SignParams) (_ *SignResult, err error) {
+	if j == nil {
+		return nil, errors.New("nil job")
+	}
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.Key == nil || params.Key.ckey == nil {
+		return nil, errors.New("nil or closed key")
+	}
+	if len(params.Message) == 0 {
+		return nil, errors.New("empty message hash")
+	}
+
+	// Validate message hash size
+	curve, err := params.Key.Curve()
+	if err != nil {
+		return nil, err
+	}
+	maxSize := curve.MaxHashSize()
+	if maxSize > 0 && len(params.Message) > maxSize {
+		return nil, errors.New("message hash exceeds curve order size")
+	}
+
+	if err := checkQuorum(j, params.Key); err != nil {
+		return nil, err
+	}
+
+	ptr, release, err := j.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	op := operation("ecdsamp.Sign", params.Key, [][]byte{params.Message})
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	if err := j.CheckSignPolicy(ctx, op.SignRequest()); err != nil {
+		return nil, err
+	}
+
+	sig, err := backend.ECDSAMPSign(ptr, params.Key.ckey, params.Key.structure, params.Message, params.SigReceiver)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	runtime.KeepAlive(j)
+	runtime.KeepAlive(params.Key)
+
+	return &SignResult{
+		Signature: sig,
+	}, nil
+}
+
+// SignWithGlobalAbort performs multi-party ECDSA signing with global abort mode.
+// The party with index SigReceiver verifies the signature against the public key
+// and shares the result, so a failed verification returns a *cbmpc.BitLeakError,
+// which matches ErrBitLeak, on every party rather than only on the receiver
+// (indicates potential key leak). The key must then be refreshed with Refresh;
+// on a job created with cbmpc.JobOptions.RefreshOnBitLeak, every party does so
+// on the same job and returns the new share in the error's RefreshedKey.
+//
+// The message must be the hash of the actual message to sign.
+// The input key is not modified and remains valid.
+//
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and the sign-policy exchange (see
+// cbmpc.JobMP.SetSignPolicy), is passed to the job's operation hooks and
+// logger, and is used for the refresh RefreshOnBitLeak runs. The protocol run
+// itself stops only when the job's own context is done (see
+// cbmpc.NewJobMPWithContext).
 //
 // See cb-mpc/src/cbmpc/protocol/ecdsa_mp.h for protocol details.
-func Sign(_ context.Context, j *cbmpc.JobMP, params *SignParams) (*SignResult, error) {
+func SignWithGlobalAbort(ctx context.Context, j *cbmpc.JobMP, params *SignParams) (*SignResult, error) {
+	res, err := signWithGlobalAbort(ctx, j, params)
+	if errors.Is(err, cbmpc.ErrBitLeak) {
+		return nil, bitLeakError(ctx, j, params.Key)
+	}
+	return res, err
+}
+
+func signWithGlobalAbort(ctx context.Context, j *cbmpc.JobMP, params *SignParams) (_ *SignResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -275,12 +558,25 @@ func Sign(_ context.Context, j *cbmpc.JobMP, params *SignParams) (*SignResult, e
 		return nil, errors.New("message hash exceeds curve order size")
 	}
 
-	ptr, err := j.Ptr()
+	if err := checkQuorum(j, params.Key); err != nil {
+		return nil, err
+	}
+
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("ecdsamp.SignWithGlobalAbort", params.Key, [][]byte{params.Message})
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
-	sig, err := backend.ECDSAMPSign(ptr, params.Key.ckey, params.Message, params.SigReceiver)
+	if err := j.CheckSignPolicy(ctx, op.SignRequest()); err != nil {
+		return nil, err
+	}
+
+	sig, err := backend.ECDSAMPSignWithGlobalAbort(ptr, params.Key.ckey, params.Key.structure, params.Message, params.SigReceiver)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
@@ -312,10 +608,14 @@ type ThresholdDKGResult struct {
 // control structure. The access structure defines policies for secret sharing using combinations
 // of AND, OR, and Threshold gates.
 //
-// Context behavior: ctx is ignored; use cbmpc.NewJobMPWithContext to control cancellation.
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and is passed to the job's operation hooks and logger;
+// a cbmpc.ResourceScope it carries takes ownership of the returned key. The
+// protocol run itself stops only when the job's own context is done (see
+// cbmpc.NewJobMPWithContext).
 //
 // See cb-mpc/src/cbmpc/protocol/ecdsa_mp.h and cb-mpc/src/cbmpc/protocol/ec_dkg.h for protocol details.
-func ThresholdDKG(_ context.Context, j *cbmpc.JobMP, params *ThresholdDKGParams) (*ThresholdDKGResult, error) {
+func ThresholdDKG(ctx context.Context, j *cbmpc.JobMP, params *ThresholdDKGParams) (_ *ThresholdDKGResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -329,10 +629,15 @@ func ThresholdDKG(_ context.Context, j *cbmpc.JobMP, params *ThresholdDKGParams)
 		return nil, errors.New("empty quorum party indices")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := &cbmpc.Operation{Protocol: "ecdsamp.ThresholdDKG", Curve: params.Curve}
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
 	nid, err := backend.CurveToNID(backend.Curve(params.Curve))
 	if err != nil {
@@ -345,8 +650,15 @@ func ThresholdDKG(_ context.Context, j *cbmpc.JobMP, params *ThresholdDKGParams)
 	}
 	runtime.KeepAlive(j)
 
+	key := newKey(keyPtr)
+	key.structure = append(ac.AccessStructure(nil), params.AccessStructure...)
+	op.PublicKey, _ = key.PublicKey()
+	if err := cbmpc.TrackInScope(ctx, key); err != nil {
+		return nil, err
+	}
+
 	return &ThresholdDKGResult{
-		Key:       newKey(keyPtr),
+		Key:       key,
 		SessionID: cbmpc.NewSessionID(sid),
 	}, nil
 }
@@ -378,10 +690,14 @@ type ThresholdRefreshResult struct {
 // - If params.SessionID is provided, it will be used and updated
 // - The updated/generated session ID is returned in ThresholdRefreshResult.SessionID
 //
-// Context behavior: ctx is ignored; use cbmpc.NewJobMPWithContext to control cancellation.
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and is passed to the job's operation hooks and logger;
+// a cbmpc.ResourceScope it carries takes ownership of the returned key. The
+// protocol run itself stops only when the job's own context is done (see
+// cbmpc.NewJobMPWithContext).
 //
 // See cb-mpc/src/cbmpc/protocol/ecdsa_mp.h and cb-mpc/src/cbmpc/protocol/ec_dkg.h for protocol details.
-func ThresholdRefresh(_ context.Context, j *cbmpc.JobMP, params *ThresholdRefreshParams) (*ThresholdRefreshResult, error) {
+func ThresholdRefresh(ctx context.Context, j *cbmpc.JobMP, params *ThresholdRefreshParams) (_ *ThresholdRefreshResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -398,10 +714,15 @@ func ThresholdRefresh(_ context.Context, j *cbmpc.JobMP, params *ThresholdRefres
 		return nil, errors.New("empty quorum party indices")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("ecdsamp.ThresholdRefresh", params.Key, nil)
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
 	curve, err := params.Key.Curve()
 	if err != nil {
@@ -420,8 +741,25 @@ func ThresholdRefresh(_ context.Context, j *cbmpc.JobMP, params *ThresholdRefres
 	runtime.KeepAlive(j)
 	runtime.KeepAlive(params.Key)
 
+	refreshed := newKey(newKeyCkey)
+	refreshed.quorum = params.Key.quorum
+	refreshed.structure = append(ac.AccessStructure(nil), params.AccessStructure...)
+
+	if err := cbmpc.TrackInScope(ctx, refreshed); err != nil {
+		return nil, err
+	}
+
 	return &ThresholdRefreshResult{
-		NewKey:    newKey(newKeyCkey),
+		NewKey:    refreshed,
 		SessionID: cbmpc.NewSessionID(newSid),
 	}, nil
 }
+
+// operation describes an invocation for the job's hooks and sign policy. The
+// key has already been validated, so lookup errors only leave fields empty.
+func operation(protocol string, key *Key, messages [][]byte) *cbmpc.Operation {
+	op := &cbmpc.Operation{Protocol: protocol, Messages: messages}
+	op.Curve, _ = key.Curve()
+	op.PublicKey, _ = key.PublicKey()
+	return op
+}
@@ -0,0 +1,65 @@
+//go:build cgo && !windows
+
+package ecdsamp_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+func BenchmarkECDSAMPDKG(b *testing.B) {
+	for _, n := range []int{3, 64, 128} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+			defer cancel()
+
+			net := mocknet.New()
+			roles := make([]cbmpc.RoleID, n)
+			names := make([]string, n)
+			for i := range roles {
+				roles[i] = cbmpc.RoleID(i)
+				names[i] = fmt.Sprintf("party%d", i)
+			}
+			jobs := make([]*cbmpc.JobMP, n)
+			for i, self := range roles {
+				job, err := cbmpc.NewJobMPWithContext(ctx, net.EpMP(self, roles), self, names)
+				if err != nil {
+					b.Fatalf("NewJobMP role %d: %v", self, err)
+				}
+				jobs[i] = job
+				defer func() { _ = job.Close() }()
+			}
+
+			results := make([]*ecdsamp.DKGResult, n)
+			errs := make([]error, n)
+			b.ResetTimer()
+			for iter := 0; iter < b.N; iter++ {
+				var wg sync.WaitGroup
+				for i, job := range jobs {
+					wg.Add(1)
+					go func(i int, job *cbmpc.JobMP) {
+						defer wg.Done()
+						results[i], errs[i] = ecdsamp.DKG(ctx, job, &ecdsamp.DKGParams{Curve: cbmpc.CurveSecp256k1})
+					}(i, job)
+				}
+				wg.Wait()
+
+				b.StopTimer()
+				for i, err := range errs {
+					if err != nil {
+						b.Fatalf("DKG role %d: %v", i, err)
+					}
+					_ = results[i].Key.Close()
+				}
+				b.StartTimer()
+			}
+		})
+	}
+}
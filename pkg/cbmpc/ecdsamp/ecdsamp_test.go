@@ -6,6 +6,7 @@ import (
 	"crypto/elliptic"
 	"crypto/sha256"
 	"encoding/hex"
+	stderrors "errors"
 	"math/big"
 	"sync"
 	"testing"
@@ -17,6 +18,7 @@ import (
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorrmp"
 )
 
 // abbrevHex returns an abbreviated hex string showing first 2 and last 2 bytes.
@@ -96,6 +98,44 @@ func verifySignature(curve cbmpc.Curve, pubKeyBytes, messageHash, derSig []byte)
 	return ecdsa.Verify(pubKey, messageHash, r, s), nil
 }
 
+// signAs runs Sign on a job of the given parties, party i signing with
+// keys[i], and returns the signature party 0 receives.
+func signAs(ctx context.Context, t *testing.T, names []string, keys []*ecdsamp.Key, hash []byte) []byte {
+	t.Helper()
+	net := mocknet.New()
+	roles := make([]cbmpc.RoleID, len(names))
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+	}
+	sigs := make([][]byte, len(names))
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJobMP(net.EpMP(roles[i], roles), roles[i], names)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsamp.Sign(ctx, job, &ecdsamp.SignParams{Key: keys[i], Message: hash, SigReceiver: 0})
+			if err == nil {
+				sigs[i] = res.Signature
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %s Sign failed: %v", names[i], err)
+		}
+	}
+	return sigs[0]
+}
+
 func TestECDSAMPDKG(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -308,6 +348,9 @@ func TestECDSAMPKeySerializationRoundTrip(t *testing.T) {
 		defer func() {
 			_ = loadedKey.Close()
 		}()
+		if err := loadedKey.Validate(); err != nil {
+			t.Fatalf("Party %d: Loaded key failed validation: %v", i, err)
+		}
 
 		// Get public key after deserialization
 		pubKeyAfter, err := loadedKey.PublicKey()
@@ -334,6 +377,15 @@ func TestECDSAMPKeySerializationRoundTrip(t *testing.T) {
 			t.Fatalf("Party %d: Curve mismatch after round-trip: %s != %s",
 				i, curveBefore, curveAfter)
 		}
+		if !loadedKey.Created().Equal(key.Created().Truncate(time.Second)) {
+			t.Fatalf("Party %d: Created = %v, want %v", i, loadedKey.Created(), key.Created())
+		}
+
+		// A share of one protocol must not load as another's, even though
+		// ecdsamp and schnorrmp keys share a native type.
+		if _, err := schnorrmp.LoadKey(serialized); !stderrors.Is(err, cbmpc.ErrKeyProtocolMismatch) {
+			t.Fatalf("Party %d: schnorrmp.LoadKey of an ecdsamp share: %v", i, err)
+		}
 
 		t.Logf("Party %d: Serialization round-trip successful", i)
 	}
@@ -344,6 +396,9 @@ func TestECDSAMPKeySerializationRoundTrip(t *testing.T) {
 			_ = key.Close()
 		}
 	}
+	if err := keys[0].Validate(); err == nil {
+		t.Fatal("Validate accepted a closed key")
+	}
 }
 
 func TestECDSAMPRefresh(t *testing.T) {
@@ -844,3 +899,194 @@ func TestECDSAMPSignRefreshSign(t *testing.T) {
 		}
 	}
 }
+
+func TestECDSAMPSignWithGlobalAbort(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	curve := cbmpc.CurveP256
+	nParties := 3
+	sigReceiver := 1
+
+	roles := make([]cbmpc.RoleID, nParties)
+	names := make([]string, nParties)
+	for i := 0; i < nParties; i++ {
+		roles[i] = cbmpc.RoleID(i)
+		names[i] = "party" + string(rune('0'+i))
+	}
+	jobs := make([]*cbmpc.JobMP, nParties)
+	for i := range jobs {
+		job, err := cbmpc.NewJobMP(net.EpMP(roles[i], roles), roles[i], names)
+		if err != nil {
+			t.Fatalf("NewJobMP: %v", err)
+		}
+		defer func() { _ = job.Close() }()
+		jobs[i] = job
+	}
+
+	var wg sync.WaitGroup
+	keys := make([]*ecdsamp.Key, nParties)
+	errors := make([]error, nParties)
+	for i := 0; i < nParties; i++ {
+		wg.Add(1)
+		go func(partyID int) {
+			defer wg.Done()
+			result, err := ecdsamp.DKG(ctx, jobs[partyID], &ecdsamp.DKGParams{Curve: curve})
+			if err != nil {
+				errors[partyID] = err
+				return
+			}
+			keys[partyID] = result.Key
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errors {
+		if err != nil {
+			t.Fatalf("Party %d DKG failed: %v", i, err)
+		}
+	}
+	defer func() {
+		for _, key := range keys {
+			_ = key.Close()
+		}
+	}()
+
+	messageHash := sha256.Sum256([]byte("Hello, global abort!"))
+	signatures := make([][]byte, nParties)
+	for i := 0; i < nParties; i++ {
+		wg.Add(1)
+		go func(partyID int) {
+			defer wg.Done()
+			result, err := ecdsamp.SignWithGlobalAbort(ctx, jobs[partyID], &ecdsamp.SignParams{
+				Key:         keys[partyID],
+				Message:     messageHash[:],
+				SigReceiver: sigReceiver,
+			})
+			if err != nil {
+				errors[partyID] = err
+				return
+			}
+			signatures[partyID] = result.Signature
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errors {
+		if err != nil {
+			t.Fatalf("Party %d SignWithGlobalAbort failed: %v", i, err)
+		}
+	}
+
+	for i := 0; i < nParties; i++ {
+		if i != sigReceiver && len(signatures[i]) != 0 {
+			t.Fatalf("Party %d should not receive signature, got: %x", i, signatures[i])
+		}
+	}
+	pubKeyBytes, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatalf("Failed to get public key: %v", err)
+	}
+	valid, err := verifySignature(curve, pubKeyBytes, messageHash[:], signatures[sigReceiver])
+	if err != nil || !valid {
+		t.Fatalf("Signature verification failed: %v", err)
+	}
+}
+
+// TestECDSAMPRefreshOnBitLeak corrupts each message party 1 sends the
+// signature receiver during global-abort signing in turn, on jobs created
+// with RefreshOnBitLeak. Once the corruption yields a signature that fails to
+// verify, every party must return a *BitLeakError carrying a refreshed share,
+// and the refreshed shares must sign for the original public key.
+func TestECDSAMPRefreshOnBitLeak(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	names := []string{"p0", "p1", "p2"}
+	roles := []cbmpc.RoleID{0, 1, 2}
+	net := mocknet.New()
+	keys := make([]*ecdsamp.Key, len(names))
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJobMP(net.EpMP(roles[i], roles), roles[i], names)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsamp.DKG(ctx, job, &ecdsamp.DKGParams{Curve: cbmpc.CurveP256})
+			if err == nil {
+				keys[i] = res.Key
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d DKG failed: %v", i, err)
+		}
+	}
+	defer func() {
+		for _, k := range keys {
+			_ = k.Close()
+		}
+	}()
+	pub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := sha256.Sum256([]byte("bit leak"))
+	for round := 0; ; round++ {
+		net := mocknet.New()
+		adv := mocknet.NewAdversary(net.EpMP(roles[1], roles)).Corrupt(roles[0], round)
+		transports := []cbmpc.Transport{net.EpMP(roles[0], roles), adv, net.EpMP(roles[2], roles)}
+		for i := range names {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				partyCtx, partyCancel := context.WithTimeout(ctx, 5*time.Second)
+				defer partyCancel()
+				job, err := cbmpc.NewJobMPWithOptions(partyCtx, transports[i], roles[i], names, cbmpc.JobOptions{RefreshOnBitLeak: true})
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				defer func() { _ = job.Close() }()
+				_, errs[i] = ecdsamp.SignWithGlobalAbort(partyCtx, job, &ecdsamp.SignParams{Key: keys[i], Message: hash[:], SigReceiver: 0})
+			}(i)
+		}
+		wg.Wait()
+		if len(adv.Interceptions()) == 0 {
+			t.Fatal("no corrupted message produced a bit leak")
+		}
+
+		refreshed := make([]*ecdsamp.Key, len(names))
+		for i, err := range errs {
+			var ble *cbmpc.BitLeakError
+			if stderrors.As(err, &ble) && ble.RefreshedKey != nil {
+				refreshed[i] = ble.RefreshedKey.(*ecdsamp.Key)
+				defer func() { _ = refreshed[i].Close() }()
+			}
+		}
+		if refreshed[0] == nil {
+			t.Logf("round %d: errors %v", round, errs)
+			continue
+		}
+		for i, err := range errs {
+			var ble *cbmpc.BitLeakError
+			if !stderrors.As(err, &ble) || ble.MustRefresh || ble.RefreshErr != nil || refreshed[i] == nil {
+				t.Fatalf("party %d: error = %v, want a refreshed key", i, err)
+			}
+		}
+		sig := signAs(ctx, t, names, refreshed, hash[:])
+		if ok, err := verifySignature(cbmpc.CurveP256, pub, hash[:], sig); err != nil || !ok {
+			t.Fatalf("signature with refreshed keys does not verify: %v", err)
+		}
+		return
+	}
+}
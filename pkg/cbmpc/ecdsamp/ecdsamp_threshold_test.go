@@ -3,7 +3,9 @@
 package ecdsamp_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"sync"
 	"testing"
 	"time"
@@ -616,3 +618,107 @@ func TestECDSAMPThresholdRefresh(t *testing.T) {
 		}
 	}
 }
+
+// TestECDSAMPDKGThreshold generates a 2-of-3 key with DKGParams.Threshold,
+// checks that the quorum and access structure survive serialization, and
+// signs with two of the three parties.
+func TestECDSAMPDKGThreshold(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	nParties := 3
+	roles := make([]cbmpc.RoleID, nParties)
+	names := make([]string, nParties)
+	for i := 0; i < nParties; i++ {
+		roles[i] = cbmpc.RoleID(i)
+		names[i] = "p" + string(rune('0'+i))
+	}
+	jobs := make([]*cbmpc.JobMP, nParties)
+	for i := range jobs {
+		job, err := cbmpc.NewJobMP(net.EpMP(roles[i], roles), roles[i], names)
+		if err != nil {
+			t.Fatalf("NewJobMP: %v", err)
+		}
+		defer func() { _ = job.Close() }()
+		jobs[i] = job
+	}
+
+	for _, threshold := range []int{-1, nParties + 1} {
+		if _, err := ecdsamp.DKG(ctx, jobs[0], &ecdsamp.DKGParams{Curve: cbmpc.CurveSecp256k1, Threshold: threshold}); err == nil {
+			t.Fatalf("DKG accepted threshold %d for %d parties", threshold, nParties)
+		}
+	}
+
+	var wg sync.WaitGroup
+	keys := make([]*ecdsamp.Key, nParties)
+	errors := make([]error, nParties)
+	for i := 0; i < nParties; i++ {
+		wg.Add(1)
+		go func(partyID int) {
+			defer wg.Done()
+			result, err := ecdsamp.DKG(ctx, jobs[partyID], &ecdsamp.DKGParams{Curve: cbmpc.CurveSecp256k1, Threshold: 2})
+			if err == nil {
+				keys[partyID] = result.Key
+			}
+			errors[partyID] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errors {
+		if err != nil {
+			t.Fatalf("Party %d threshold DKG failed: %v", i, err)
+		}
+	}
+	defer func() {
+		for _, key := range keys {
+			_ = key.Close()
+		}
+	}()
+
+	if q := keys[0].Quorum(); q != 2 {
+		t.Fatalf("Quorum() = %d, want 2", q)
+	}
+	data, err := keys[2].Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	loaded, err := ecdsamp.LoadKey(data)
+	if err != nil {
+		t.Fatalf("LoadKey: %v", err)
+	}
+	defer func() { _ = loaded.Close() }()
+	if q := loaded.Quorum(); q != 2 {
+		t.Fatalf("loaded Quorum() = %d, want 2", q)
+	}
+	if !bytes.Equal(loaded.AccessStructure(), keys[2].AccessStructure()) {
+		t.Fatal("loaded key lost its access structure")
+	}
+
+	if _, err := ecdsamp.Refresh(ctx, jobs[0], &ecdsamp.RefreshParams{Key: keys[0]}); err == nil {
+		t.Fatal("Refresh accepted a threshold key")
+	}
+
+	// p0 and p2 sign, p2 with its reloaded share.
+	pub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("2-of-3"))
+	sig := signAs(ctx, t, []string{"p0", "p2"}, []*ecdsamp.Key{keys[0], loaded}, hash[:])
+	if ok, err := verifySignature(cbmpc.CurveSecp256k1, pub, hash[:], sig); err != nil || !ok {
+		t.Fatalf("2-of-3 signature does not verify against the DKG public key: %v", err)
+	}
+
+	// A job whose parties do not satisfy the access structure fails before
+	// sending anything.
+	outsiders := []string{"p0", "q9"}
+	lone, err := cbmpc.NewJobMP(mocknet.New().EpMP(0, []cbmpc.RoleID{0, 1}), 0, outsiders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = lone.Close() }()
+	if _, err := ecdsamp.Sign(ctx, lone, &ecdsamp.SignParams{Key: keys[0], Message: hash[:]}); err == nil {
+		t.Fatalf("Sign accepted parties %q", outsiders)
+	}
+}
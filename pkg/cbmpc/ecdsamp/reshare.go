@@ -0,0 +1,267 @@
+package ecdsamp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/reshare"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secretsharing"
+)
+
+// ErrInconsistentDeal is wrapped by the error Reshare returns when a dealer's
+// shares do not verify or parties saw different dealings.
+var ErrInconsistentDeal = reshare.ErrInconsistentDeal
+
+const reshareTag = "cbmpc-ecdsamp-reshare/1"
+
+// ReshareParams contains parameters for Reshare.
+type ReshareParams struct {
+	// Self is the caller's role in the transport, an index into Names.
+	Self cbmpc.RoleID
+
+	// Names are every party taking part, old and new, indexed by RoleID.
+	Names []string
+
+	// Key is the caller's current key share. Parties that do not hold the
+	// current key (joining parties) leave it nil. It is not modified.
+	Key *Key
+
+	// OldNames are the parties of the current key. For threshold keys they
+	// must be in the order of the access structure's leaves, since that order
+	// fixes each party's share index. Old parties that are offline may be
+	// listed here without being in Names.
+	OldNames []string
+
+	// OldThreshold is the Threshold of keys under THRESHOLD[t](OldNames...),
+	// as produced by DKG, Reshare, migrate.ECDSA2PToMP or ThresholdDKG with
+	// that structure. It is 0 for n-of-n keys, in which case every old party
+	// must deal.
+	OldThreshold int
+
+	// Dealers are the roles of the old parties that deal their shares. For
+	// threshold keys at least OldThreshold are needed. Nil means every party
+	// in Names that is also in OldNames.
+	Dealers []cbmpc.RoleID
+
+	// NewParties are the roles that receive shares of the new key, in the
+	// order of the new access structure's leaves.
+	NewParties []cbmpc.RoleID
+
+	// Threshold is the number of new parties needed to sign with the new
+	// key, as in DKGParams.
+	Threshold int
+
+	// PublicKey optionally pins the public key being reshared. Joining
+	// parties should set it.
+	PublicKey []byte
+}
+
+// ReshareResult contains the output of Reshare.
+type ReshareResult struct {
+	// Key is the caller's share of the new key, or nil if the caller is not
+	// among NewParties. It must be freed with Close() when no longer needed.
+	Key *Key
+
+	// AccessStructure is THRESHOLD[Threshold](names of NewParties...).
+	AccessStructure ac.AccessStructure
+}
+
+// Reshare moves an ecdsamp key to a new set of parties and a new threshold
+// while preserving its public key, so nodes can join or be decommissioned
+// without changing addresses.
+//
+// The dealers turn their shares into additive shares of the private key (with
+// Lagrange coefficients for threshold keys) and deal them to NewParties with
+// verifiable secret sharing; see the migrate package documentation for the
+// checks every party performs. To add a party, list it in Names and
+// NewParties but not OldNames; to retire one, leave it out of NewParties.
+// Every party in Names must call Reshare with the same parameters apart from
+// Self and Key.
+//
+// Retired parties still hold shares of the old key, which remain valid until
+// every party discards them: delete old shares once all new parties have
+// stored theirs.
+func Reshare(ctx context.Context, t cbmpc.Transport, params *ReshareParams) (*ReshareResult, error) {
+	if t == nil {
+		return nil, cbmpc.ErrNilTransport
+	}
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if int(params.Self) >= len(params.Names) {
+		return nil, fmt.Errorf("%w: self role %d out of range [0,%d)", cbmpc.ErrBadPeers, params.Self, len(params.Names))
+	}
+	oldIndex := make(map[string]int, len(params.OldNames))
+	for i, name := range params.OldNames {
+		if _, dup := oldIndex[name]; dup {
+			return nil, fmt.Errorf("duplicate old party %q", name)
+		}
+		oldIndex[name] = i + 1
+	}
+	dealers := params.Dealers
+	if dealers == nil {
+		for i, name := range params.Names {
+			if _, ok := oldIndex[name]; ok {
+				dealers = append(dealers, cbmpc.RoleID(i))
+			}
+		}
+	}
+	indices := make([]int, len(dealers))
+	for i, d := range dealers {
+		if int(d) >= len(params.Names) {
+			return nil, fmt.Errorf("%w: dealer %d out of range [0,%d)", cbmpc.ErrBadPeers, d, len(params.Names))
+		}
+		idx, ok := oldIndex[params.Names[d]]
+		if !ok {
+			return nil, fmt.Errorf("dealer %q is not an old party", params.Names[d])
+		}
+		indices[i] = idx
+	}
+	switch {
+	case params.OldThreshold == 0 && len(dealers) != len(params.OldNames):
+		return nil, fmt.Errorf("every old party must deal an additive key (%d of %d)", len(dealers), len(params.OldNames))
+	case params.OldThreshold > 0 && len(dealers) < params.OldThreshold:
+		return nil, fmt.Errorf("need at least %d dealers (got %d)", params.OldThreshold, len(dealers))
+	case params.OldThreshold < 0:
+		return nil, fmt.Errorf("invalid old threshold %d", params.OldThreshold)
+	}
+	dealer := -1
+	for i, d := range dealers {
+		if d == params.Self {
+			dealer = i
+		}
+	}
+	if (dealer >= 0) != (params.Key != nil && params.Key.ckey != nil) {
+		return nil, errors.New("key must be set by dealers and only by dealers")
+	}
+
+	// weight returns the Lagrange coefficient of dealer i, or nil for
+	// additive keys.
+	weight := func(c cbmpc.Curve, i int) (*curve.Scalar, error) {
+		if params.OldThreshold == 0 {
+			return nil, nil
+		}
+		return secretsharing.LagrangeCoefficient(c, indices, indices[i])
+	}
+
+	rp := &reshare.Params{
+		Tag:        reshareTag,
+		Self:       params.Self,
+		Names:      params.Names,
+		Dealers:    dealers,
+		Recipients: params.NewParties,
+		Threshold:  params.Threshold,
+		Pin:        params.PublicKey,
+	}
+	if params.Key != nil && params.Key.ckey != nil {
+		// Old parties check each dealer's contribution against its public
+		// share recorded in their key.
+		rp.DealerPublicKey = func(c cbmpc.Curve, i int) ([]byte, error) {
+			qi, err := backend.ECDSAMPKeyGetPublicShare(params.Key.ckey, params.Names[dealers[i]])
+			runtime.KeepAlive(params.Key)
+			if err != nil {
+				return nil, cbmpc.RemapError(err)
+			}
+			lambda, err := weight(c, i)
+			if err != nil || lambda == nil {
+				return qi, err
+			}
+			defer lambda.Free()
+			p, err := curve.NewPointFromBytes(c, qi)
+			if err != nil {
+				return nil, err
+			}
+			defer p.Free()
+			weighted, err := p.Mul(lambda)
+			if err != nil {
+				return nil, err
+			}
+			defer weighted.Free()
+			return weighted.Bytes()
+		}
+	}
+	if dealer >= 0 {
+		var err error
+		if rp.Curve, err = params.Key.Curve(); err != nil {
+			return nil, err
+		}
+		if rp.PublicKey, err = params.Key.PublicKey(); err != nil {
+			return nil, err
+		}
+		if rp.Secret, err = dealerSecret(params.Key, rp.Curve, weight, dealer); err != nil {
+			return nil, err
+		}
+		defer rp.Secret.Free()
+	}
+
+	res, err := reshare.Run(ctx, t, rp)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Share.Free()
+
+	names := make([]string, len(params.NewParties))
+	leaves := make([]ac.Expr, len(params.NewParties))
+	for i, r := range params.NewParties {
+		names[i] = params.Names[r]
+		leaves[i] = ac.Leaf(names[i])
+	}
+	structure, err := ac.Compile(ac.Threshold(params.Threshold, leaves...))
+	if err != nil {
+		return nil, err
+	}
+	out := &ReshareResult{AccessStructure: structure}
+	if res.Share == nil {
+		return out, nil
+	}
+
+	x := res.Share.BytesPadded(res.Curve)
+	defer cbmpc.ZeroizeBytes(x)
+	nid, err := backend.CurveToNID(backend.Curve(res.Curve))
+	if err != nil {
+		return nil, err
+	}
+	ckey, err := backend.ECDSAMPKeyNew(nid, params.Names[params.Self], x, res.PublicKey, names, res.PublicShares)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	out.Key = newKey(ckey)
+	out.Key.quorum = params.Threshold
+	out.Key.structure = structure
+	if err := cbmpc.TrackInScope(ctx, out.Key); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// dealerSecret returns the dealer's additive share of the private key: its
+// key share, weighted by its Lagrange coefficient for threshold keys.
+func dealerSecret(key *Key, c cbmpc.Curve, weight func(cbmpc.Curve, int) (*curve.Scalar, error), i int) (*curve.Scalar, error) {
+	xb, err := backend.ECDSAMPKeyGetXShare(key.ckey)
+	runtime.KeepAlive(key)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	defer cbmpc.ZeroizeBytes(xb)
+	x, err := curve.NewScalarFromBytes(xb)
+	if err != nil {
+		return nil, err
+	}
+	lambda, err := weight(c, i)
+	if err != nil || lambda == nil {
+		if err != nil {
+			x.Free()
+			return nil, err
+		}
+		return x, nil
+	}
+	defer lambda.Free()
+	defer x.Free()
+	return x.Mul(lambda, c)
+}
@@ -0,0 +1,180 @@
+package ecdsamp_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// runReshare runs Reshare for every party in names; params(i) returns party
+// i's parameters.
+func runReshare(ctx context.Context, n int, params func(int) *ecdsamp.ReshareParams) ([]*ecdsamp.ReshareResult, []error) {
+	net := mocknet.New()
+	roles := make([]cbmpc.RoleID, n)
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+	}
+	results := make([]*ecdsamp.ReshareResult, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = ecdsamp.Reshare(ctx, net.EpMP(roles[i], roles), params(i))
+		}(i)
+	}
+	wg.Wait()
+	return results, errs
+}
+
+func TestECDSAMPReshare(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Additive 3-of-3 key from DKG among p0..p2.
+	oldNames := []string{"p0", "p1", "p2"}
+	roles := []cbmpc.RoleID{0, 1, 2}
+	net := mocknet.New()
+	var wg sync.WaitGroup
+	keys := make([]*ecdsamp.Key, 3)
+	errs := make([]error, 3)
+	for i := range oldNames {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJobMP(net.EpMP(roles[i], roles), roles[i], oldNames)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsamp.DKG(ctx, job, &ecdsamp.DKGParams{Curve: cbmpc.CurveSecp256k1})
+			if err == nil {
+				keys[i] = res.Key
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d DKG failed: %v", i, err)
+		}
+		defer keys[i].Close()
+	}
+	pub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Add p3 and retire p0: 2-of-{p1,p2,p3}.
+	names := []string{"p0", "p1", "p2", "p3"}
+	first, errs := runReshare(ctx, len(names), func(i int) *ecdsamp.ReshareParams {
+		p := &ecdsamp.ReshareParams{
+			Self:       cbmpc.RoleID(i),
+			Names:      names,
+			OldNames:   oldNames,
+			NewParties: []cbmpc.RoleID{1, 2, 3},
+			Threshold:  2,
+			PublicKey:  pub,
+		}
+		if i < 3 {
+			p.Key = keys[i]
+		}
+		return p
+	})
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d first reshare failed: %v", i, err)
+		}
+	}
+	if first[0].Key != nil {
+		t.Fatal("retired party received a key")
+	}
+	for i := 1; i < len(names); i++ {
+		defer first[i].Key.Close()
+		got, err := first[i].Key.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, pub) {
+			t.Fatalf("party %d public key %x, want %x", i, got, pub)
+		}
+	}
+
+	// With p2 offline, p1 and p3 move the key to 2-of-{p1,p3,p4}.
+	names = []string{"p1", "p3", "p4"}
+	holders := map[int]*ecdsamp.Key{0: first[1].Key, 1: first[3].Key}
+	second, errs := runReshare(ctx, len(names), func(i int) *ecdsamp.ReshareParams {
+		return &ecdsamp.ReshareParams{
+			Self:         cbmpc.RoleID(i),
+			Names:        names,
+			Key:          holders[i],
+			OldNames:     []string{"p1", "p2", "p3"},
+			OldThreshold: 2,
+			NewParties:   []cbmpc.RoleID{0, 1, 2},
+			Threshold:    2,
+			PublicKey:    pub,
+		}
+	})
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d second reshare failed: %v", i, err)
+		}
+	}
+	for i, res := range second {
+		defer res.Key.Close()
+		got, err := res.Key.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, pub) {
+			t.Fatalf("party %d public key %x, want %x", i, got, pub)
+		}
+	}
+
+	// Two of the three new parties sign with the reshared key.
+	hash := sha256.Sum256([]byte("reshared"))
+	sig := signAs(ctx, t, []string{"p3", "p4"}, []*ecdsamp.Key{second[1].Key, second[2].Key}, hash[:])
+	if ok, err := verifySignature(cbmpc.CurveSecp256k1, pub, hash[:], sig); err != nil || !ok {
+		t.Fatalf("reshared key's signature does not verify against the original public key: %v", err)
+	}
+}
+
+func TestECDSAMPReshareValidation(t *testing.T) {
+	ctx := context.Background()
+	ep := mocknet.New().EpMP(0, []cbmpc.RoleID{0, 1, 2})
+	names := []string{"a", "b", "c"}
+	all := []cbmpc.RoleID{0, 1, 2}
+	tests := []struct {
+		name   string
+		params *ecdsamp.ReshareParams
+	}{
+		{"nil params", nil},
+		{"self out of range", &ecdsamp.ReshareParams{Self: 3, Names: names, OldNames: names, NewParties: all, Threshold: 2}},
+		{"duplicate old party", &ecdsamp.ReshareParams{Names: names, OldNames: []string{"a", "a"}, NewParties: all, Threshold: 2}},
+		{"dealer not old party", &ecdsamp.ReshareParams{Names: names, OldNames: names[:2], Dealers: all, NewParties: all, Threshold: 2}},
+		{"additive key missing dealer", &ecdsamp.ReshareParams{Names: names, OldNames: []string{"a", "b", "z"}, NewParties: all, Threshold: 2}},
+		{"too few threshold dealers", &ecdsamp.ReshareParams{Names: names, OldNames: []string{"a", "y", "z"}, OldThreshold: 2, NewParties: all, Threshold: 2}},
+		{"dealer without key", &ecdsamp.ReshareParams{Names: names, OldNames: names, NewParties: all, Threshold: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ecdsamp.Reshare(ctx, ep, tt.params); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+	if _, err := ecdsamp.Reshare(ctx, nil, &ecdsamp.ReshareParams{}); !errors.Is(err, cbmpc.ErrNilTransport) {
+		t.Fatalf("nil transport error = %v", err)
+	}
+}
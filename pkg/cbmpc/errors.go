@@ -1,9 +1,14 @@
 package cbmpc
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"time"
 
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
 )
 
 // ErrNotBuilt indicates the native MPC bindings are not available in the
@@ -18,6 +23,86 @@ var ErrNotBuilt = errors.New("cbmpc: native bindings not built")
 // handling - the key should be refreshed before signing again.
 var ErrBitLeak = errors.New("bit leak detected in signature verification")
 
+// ErrKeyInvalid indicates a key share that failed Key.Validate: its share
+// does not match its public key, or a point or scalar in it is out of range.
+// The share is corrupt and should not be used to sign.
+var ErrKeyInvalid = errors.New("cbmpc: key share failed consistency checks")
+
+// BitLeakError is returned by global-abort signing when the signature fails
+// to verify, with what the caller needs to recover. It matches ErrBitLeak
+// under errors.Is.
+type BitLeakError struct {
+	// Protocol names the signing invocation, e.g. "ecdsa2p.SignWithGlobalAbort".
+	Protocol string
+	// Fingerprint is the KeyFingerprint of the key share that signed.
+	Fingerprint string
+	// MustRefresh reports that the key share may have leaked a bit and must
+	// be refreshed before it signs again. It is false only once the job has
+	// refreshed it, see JobOptions.RefreshOnBitLeak. The refresh is a
+	// protocol run of every party, and in two-party signing P2 does not
+	// verify the signature and does not know it failed, so the caller must
+	// tell it before starting the refresh.
+	MustRefresh bool
+	// RefreshedKey is the refreshed share, of the signing package's Key type,
+	// when the job refreshed the key after the failure; the caller owns it
+	// and must close it. RefreshErr is set if that refresh failed.
+	RefreshedKey io.Closer
+	RefreshErr   error
+}
+
+func (e *BitLeakError) Error() string {
+	msg := fmt.Sprintf("cbmpc: %s: %v (key %s)", e.Protocol, ErrBitLeak, e.Fingerprint)
+	switch {
+	case e.RefreshErr != nil:
+		msg += fmt.Sprintf("; refresh failed: %v", e.RefreshErr)
+	case e.RefreshedKey != nil:
+		msg += "; key refreshed"
+	}
+	return msg
+}
+
+func (e *BitLeakError) Unwrap() error { return ErrBitLeak }
+
+// ErrReplay indicates a message delivered twice, out of order, or after a
+// gap in the sender's sequence numbers. Transports return it from Receive and
+// ReceiveAll; the protocol aborts with the error.
+var ErrReplay = errors.New("cbmpc: replayed or out-of-order message")
+
+// RoundTimeoutError reports a peer that sent nothing for a whole round within
+// JobOptions.RoundTimeout. It matches context.DeadlineExceeded under
+// errors.Is.
+type RoundTimeoutError struct {
+	// Round is the 1-based index of the receive that timed out within the
+	// protocol invocation, as counted in Traffic.Rounds.
+	Round int
+	// Peer is the party whose message did not arrive.
+	Peer RoleID
+	// Timeout is the round timeout that elapsed.
+	Timeout time.Duration
+}
+
+func (e *RoundTimeoutError) Error() string {
+	return fmt.Sprintf("cbmpc: round %d: no message from party %d within %v", e.Round, e.Peer, e.Timeout)
+}
+
+func (e *RoundTimeoutError) Unwrap() error { return context.DeadlineExceeded }
+
+// Errors returned by LoadKey in the protocol packages for serialized key
+// shares whose envelope does not match. Shares serialized before keys carried
+// an envelope still load.
+var (
+	// ErrKeyMalformed indicates a truncated or inconsistent key envelope.
+	ErrKeyMalformed = envelope.ErrMalformed
+	// ErrKeyVersion indicates a key envelope written by a newer library.
+	ErrKeyVersion = envelope.ErrUnsupportedVersion
+	// ErrKeyProtocolMismatch indicates a share of another protocol's key,
+	// for example a schnorrmp share passed to ecdsamp.LoadKey.
+	ErrKeyProtocolMismatch = envelope.ErrTypeMismatch
+	// ErrKeyCurveMismatch indicates a share whose curve differs from the one
+	// its envelope records.
+	ErrKeyCurveMismatch = envelope.ErrCurveMismatch
+)
+
 // RemapError converts bindings layer errors to public API errors.
 // This is exported for use by protocol subpackages.
 func RemapError(err error) error {
@@ -33,5 +118,8 @@ func RemapError(err error) error {
 	if errors.Is(err, backend.ErrBitLeak) {
 		return ErrBitLeak
 	}
+	if errors.Is(err, backend.ErrKeyInvalid) {
+		return ErrKeyInvalid
+	}
 	return err
 }
@@ -0,0 +1,33 @@
+package cbmpc
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+)
+
+// KeyFingerprint returns the stable identifier of the key with the given
+// curve and public key: the hex SHA-256 of a version label, the curve name
+// and the compressed public key. Every party holding a share of the key, and
+// any system that knows only its public key, derives the same fingerprint,
+// so it can name the key across parties, logs, audit records and storage.
+//
+// The key types' Fingerprint methods, operation logs and audit records all
+// use this function.
+func KeyFingerprint(c Curve, publicKey []byte) string {
+	h := sha256.New()
+	for _, p := range [][]byte{[]byte("cbmpc/key-fingerprint/v1"), []byte(c.String()), publicKey} {
+		_ = binary.Write(h, binary.BigEndian, uint32(len(p)))
+		h.Write(p)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// KeyFingerprint returns the fingerprint of the key op used or produced, or
+// "" if its public key is unknown.
+func (op *Operation) KeyFingerprint() string {
+	if op == nil || len(op.PublicKey) == 0 {
+		return ""
+	}
+	return KeyFingerprint(op.Curve, op.PublicKey)
+}
@@ -0,0 +1,46 @@
+package cbmpc
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/logging"
+)
+
+func TestKeyFingerprint(t *testing.T) {
+	pub := []byte{2, 7, 7}
+	fp := KeyFingerprint(CurveSecp256k1, pub)
+	if len(fp) != 64 {
+		t.Fatalf("fingerprint length = %d, want 64", len(fp))
+	}
+	if fp != KeyFingerprint(CurveSecp256k1, []byte{2, 7, 7}) {
+		t.Error("fingerprint is not stable")
+	}
+	if fp == KeyFingerprint(CurveP256, pub) {
+		t.Error("fingerprint ignores the curve")
+	}
+	if fp == KeyFingerprint(CurveSecp256k1, []byte{2, 7, 8}) {
+		t.Error("fingerprint ignores the public key")
+	}
+
+	op := &Operation{Protocol: "test.Run", Curve: CurveSecp256k1, PublicKey: pub}
+	if op.KeyFingerprint() != fp {
+		t.Error("Operation.KeyFingerprint differs from KeyFingerprint")
+	}
+	if (&Operation{Curve: CurveSecp256k1}).KeyFingerprint() != "" {
+		t.Error("operation without a public key has a fingerprint")
+	}
+
+	// The fingerprint survives the default redaction deny-list.
+	var buf bytes.Buffer
+	h := logging.NewRedactingHandler(slog.NewTextHandler(&buf, nil), nil)
+	j := &Job2P{self: 0, names: [2]string{"p1", "p2"}, trace: &jobTrace{}}
+	j.SetLogger(logging.New(slog.New(h)))
+	j.BeginOperation(context.Background(), op)(nil)
+	if !strings.Contains(buf.String(), "fingerprint="+fp) {
+		t.Errorf("log is missing the key fingerprint:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,186 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+)
+
+// ResourceGroup creates points, scalars and commitments that it owns, and
+// carries a context through which protocols hand it the keys they return.
+// Closing the group, or the end of its context, frees all of them, which
+// replaces a defer Free or Close per object:
+//
+//	g := cbmpc.NewResourceGroup(ctx)
+//	defer g.Close()
+//
+//	res, err := ecdsa2p.DKG(g.Context(), job, params) // res.Key is owned by g
+//	if err != nil {
+//	    return err
+//	}
+//	k, err := g.RandomScalar(curve.P256)
+//	if err != nil {
+//	    return err
+//	}
+//	r, err := g.MulGenerator(curve.P256, k)
+//
+// A ResourceGroup is a ResourceScope, so objects created elsewhere can be
+// added with Track and handed back with Untrack. When ctx is done the group
+// stops creating objects, and its constructors return ErrScopeClosed. The
+// objects it owns are then freed as soon as no caller holds the group: the
+// group's own constructors hold it while they run, and other code that uses
+// group-owned objects after ctx may be done, such as a protocol run on a job
+// with a longer-lived context, takes a hold with Hold.
+type ResourceGroup struct {
+	*ResourceScope
+	ctx  context.Context
+	stop func() bool
+
+	mu      sync.Mutex
+	holds   int
+	expired bool  // ctx is done; free once holds drops to zero
+	err     error // error from freeing after ctx was done
+}
+
+// NewResourceGroup creates a group bound to ctx.
+func NewResourceGroup(ctx context.Context) *ResourceGroup {
+	s, ctx := NewResourceScope(ctx)
+	g := &ResourceGroup{ResourceScope: s, ctx: ctx}
+	g.stop = context.AfterFunc(ctx, g.expire)
+	return g
+}
+
+// Context returns the group's context. Keys returned by protocols run with
+// it belong to the group.
+func (g *ResourceGroup) Context() context.Context {
+	return g.ctx
+}
+
+// Hold keeps the group's objects from being freed when its context ends
+// until release is called, so they can be used safely in the meantime.
+// Close still frees them at once. Hold returns ErrScopeClosed if the group
+// is closed or its context is done.
+func (g *ResourceGroup) Hold() (release func(), err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.expired || g.Closed() {
+		return nil, ErrScopeClosed
+	}
+	g.holds++
+	return sync.OnceFunc(g.release), nil
+}
+
+func (g *ResourceGroup) release() {
+	g.mu.Lock()
+	g.holds--
+	free := g.expired && g.holds == 0
+	g.mu.Unlock()
+	if free {
+		g.free()
+	}
+}
+
+// expire runs when the group's context is done.
+func (g *ResourceGroup) expire() {
+	g.mu.Lock()
+	g.expired = true
+	free := g.holds == 0
+	g.mu.Unlock()
+	if free {
+		g.free()
+	}
+}
+
+func (g *ResourceGroup) free() {
+	err := g.ResourceScope.Close()
+	g.mu.Lock()
+	g.err = errors.Join(g.err, err)
+	g.mu.Unlock()
+}
+
+// Close frees every object the group owns, even while it is held, and
+// returns the joined errors from freeing them, including those freed when
+// its context ended. Closing an already closed group returns nil.
+func (g *ResourceGroup) Close() error {
+	g.stop()
+	err := g.ResourceScope.Close()
+	g.mu.Lock()
+	err, g.err = errors.Join(g.err, err), nil
+	g.mu.Unlock()
+	return err
+}
+
+// create runs f while holding g and tracks the object it returns in g.
+func create[T any](g *ResourceGroup, f func() (T, error)) (T, error) {
+	release, err := g.Hold()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer release()
+	v, err := f()
+	return Keep(g.ResourceScope, v, err)
+}
+
+// Point decodes a point as curve.NewPointFromBytes does.
+func (g *ResourceGroup) Point(c Curve, data []byte) (*curve.Point, error) {
+	return create(g, func() (*curve.Point, error) { return curve.NewPointFromBytes(c, data) })
+}
+
+// HashToPoint hashes msg to a point as curve.HashToPoint does.
+func (g *ResourceGroup) HashToPoint(c Curve, msg, dst []byte) (*curve.Point, error) {
+	return create(g, func() (*curve.Point, error) { return curve.HashToPoint(c, msg, dst) })
+}
+
+// Generator returns the generator of c.
+func (g *ResourceGroup) Generator(c Curve) (*curve.Point, error) {
+	return create(g, func() (*curve.Point, error) { return curve.Generator(c) })
+}
+
+// MulGenerator returns k*G on c.
+func (g *ResourceGroup) MulGenerator(c Curve, k *curve.Scalar) (*curve.Point, error) {
+	return create(g, func() (*curve.Point, error) { return curve.MulGenerator(c, k) })
+}
+
+// AddPoints returns p + q.
+func (g *ResourceGroup) AddPoints(p, q *curve.Point) (*curve.Point, error) {
+	return create(g, func() (*curve.Point, error) { return p.Add(q) })
+}
+
+// MulPoint returns k*p.
+func (g *ResourceGroup) MulPoint(p *curve.Point, k *curve.Scalar) (*curve.Point, error) {
+	return create(g, func() (*curve.Point, error) { return p.Mul(k) })
+}
+
+// Scalar decodes a big-endian scalar as curve.NewScalarFromBytes does.
+func (g *ResourceGroup) Scalar(data []byte) (*curve.Scalar, error) {
+	return create(g, func() (*curve.Scalar, error) { return curve.NewScalarFromBytes(data) })
+}
+
+// RandomScalar returns a random scalar for c.
+func (g *ResourceGroup) RandomScalar(c Curve) (*curve.Scalar, error) {
+	return create(g, func() (*curve.Scalar, error) { return curve.RandomScalar(c) })
+}
+
+// AddScalars returns a + b modulo the order of c.
+func (g *ResourceGroup) AddScalars(a, b *curve.Scalar, c Curve) (*curve.Scalar, error) {
+	return create(g, func() (*curve.Scalar, error) { return a.Add(b, c) })
+}
+
+// MulScalars returns a * b modulo the order of c.
+func (g *ResourceGroup) MulScalars(a, b *curve.Scalar, c Curve) (*curve.Scalar, error) {
+	return create(g, func() (*curve.Scalar, error) { return a.Mul(b, c) })
+}
+
+// Commitment returns the EC ElGamal commitment to m with randomness r under
+// public key p, as curve.MakeElGamalCom does.
+func (g *ResourceGroup) Commitment(p *curve.Point, m, r *curve.Scalar) (*curve.ECElGamalCom, error) {
+	return create(g, func() (*curve.ECElGamalCom, error) { return curve.MakeElGamalCom(p, m, r) })
+}
+
+// LoadCommitment decodes a commitment as curve.LoadECElGamalCom does.
+func (g *ResourceGroup) LoadCommitment(c Curve, data []byte) (*curve.ECElGamalCom, error) {
+	return create(g, func() (*curve.ECElGamalCom, error) { return curve.LoadECElGamalCom(c, data) })
+}
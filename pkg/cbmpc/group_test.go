@@ -0,0 +1,103 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResourceGroup(t *testing.T) {
+	g := NewResourceGroup(context.Background())
+	if ScopeFromContext(g.Context()) != g.ResourceScope {
+		t.Fatal("group context does not carry the group's scope")
+	}
+
+	key := &fakeFreer{}
+	if err := TrackInScope(g.Context(), key); err != nil {
+		t.Fatalf("TrackInScope: %v", err)
+	}
+	if _, err := g.AddPoints(nil, nil); err == nil {
+		t.Fatal("AddPoints(nil, nil) succeeded")
+	}
+	if g.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", g.Len())
+	}
+
+	if err := g.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !key.freed {
+		t.Fatal("key added through the group context not freed on Close")
+	}
+}
+
+type chanFreer chan struct{}
+
+func (f chanFreer) Free() { close(f) }
+
+func TestResourceGroupFreesOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := NewResourceGroup(ctx)
+	key := make(chanFreer)
+	if err := g.Track(key); err != nil {
+		t.Fatalf("Track: %v", err)
+	}
+
+	cancel()
+	select {
+	case <-key:
+	case <-time.After(5 * time.Second):
+		t.Fatal("object not freed after the group's context was cancelled")
+	}
+	if _, err := g.Hold(); !errors.Is(err, ErrScopeClosed) {
+		t.Fatalf("Hold after cancel: got %v, want ErrScopeClosed", err)
+	}
+	if err := g.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestResourceGroupHoldDefersFree(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := NewResourceGroup(ctx)
+	key := make(chanFreer)
+	if err := g.Track(key); err != nil {
+		t.Fatalf("Track: %v", err)
+	}
+	release, err := g.Hold()
+	if err != nil {
+		t.Fatalf("Hold: %v", err)
+	}
+
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		g.mu.Lock()
+		expired := g.expired
+		g.mu.Unlock()
+		if expired {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("group did not observe the cancelled context")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-key:
+		t.Fatal("object freed while the group was held")
+	default:
+	}
+
+	release()
+	release() // a second call is a no-op
+	select {
+	case <-key:
+	default:
+		t.Fatal("object not freed when the last hold was released")
+	}
+	if err := g.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package cbmpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Protocol feature flags advertised in the handshake by default. Applications
+// may advertise their own flags alongside these, for example to announce a
+// transport wrapper every party must use.
+const (
+	FeatureAgreeRandom = "agree-random"
+	FeatureECDSA2P     = "ecdsa-2p"
+	FeatureECDSAMP     = "ecdsa-mp"
+	FeatureSchnorr2P   = "schnorr-2p"
+	FeatureSchnorrMP   = "schnorr-mp"
+	FeaturePVE         = "pve"
+)
+
+// SupportedFeatures returns the feature flags this build of the wrapper
+// advertises, sorted.
+func SupportedFeatures() []string {
+	return []string{
+		FeatureAgreeRandom,
+		FeatureECDSA2P,
+		FeatureECDSAMP,
+		FeaturePVE,
+		FeatureSchnorr2P,
+		FeatureSchnorrMP,
+	}
+}
+
+// ErrIncompatiblePeer is wrapped by every HandshakeError.
+var ErrIncompatiblePeer = errors.New("incompatible peer")
+
+// HandshakeError reports why a peer failed the handshake.
+type HandshakeError struct {
+	Peer   RoleID
+	Reason string
+}
+
+func (e *HandshakeError) Error() string {
+	return fmt.Sprintf("%v: party %d: %s", ErrIncompatiblePeer, e.Peer, e.Reason)
+}
+
+func (e *HandshakeError) Unwrap() error { return ErrIncompatiblePeer }
+
+// HandshakeParams configures the handshake.
+type HandshakeParams struct {
+	// Features are the flags advertised to peers. Nil advertises
+	// SupportedFeatures().
+	Features []string
+	// Require lists flags every party must advertise. Each party checks both
+	// its own and its peers' requirements, so every party fails together.
+	Require []string
+}
+
+// PeerInfo is what a peer announced in the handshake.
+type PeerInfo struct {
+	Role     RoleID
+	Wrapper  string
+	Upstream string
+	Features []string
+}
+
+const helloMagic = "cbmpc-hello/1"
+
+type hello struct {
+	Magic    string   `json:"magic"`
+	Self     RoleID   `json:"self"`
+	Names    []byte   `json:"names"` // SHA-256 of the party names
+	Wrapper  string   `json:"wrapper"`
+	Upstream string   `json:"upstream"`
+	Features []string `json:"features"`
+	Require  []string `json:"require,omitempty"`
+}
+
+// Handshake exchanges versions and feature flags with every other party of
+// the job described by self and names, and fails with a *HandshakeError if a
+// peer is incompatible: a different upstream version, a different wrapper
+// major version (major and minor before v1), a different list of party names,
+// or a missing required feature. Run it over the job's transport before the
+// job is created, as NewJob2PWithHandshake and NewJobMPWithHandshake do; a
+// mismatch then fails immediately instead of as a native deserialization
+// error several rounds into the protocol.
+//
+// Every party must run the handshake, since it sends one message to and
+// receives one message from each peer. It blocks until all peers have sent
+// theirs.
+func Handshake(ctx context.Context, t Transport, self RoleID, names []string, p *HandshakeParams) ([]PeerInfo, error) {
+	if t == nil {
+		return nil, ErrNilTransport
+	}
+	if p == nil {
+		p = &HandshakeParams{}
+	}
+	if len(names) < 2 {
+		return nil, fmt.Errorf("%w: need at least 2 parties (got %d)", ErrBadPeers, len(names))
+	}
+	if int(self) >= len(names) {
+		return nil, fmt.Errorf("%w: self role %d out of range [0,%d)", ErrBadPeers, self, len(names))
+	}
+	features := p.Features
+	if features == nil {
+		features = SupportedFeatures()
+	}
+	for _, f := range p.Require {
+		if !slices.Contains(features, f) {
+			return nil, fmt.Errorf("required feature %q is not advertised locally", f)
+		}
+	}
+
+	local := hello{
+		Magic:    helloMagic,
+		Self:     self,
+		Names:    namesDigest(names),
+		Wrapper:  WrapperVersion(),
+		Upstream: UpstreamVersion(),
+		Features: features,
+		Require:  p.Require,
+	}
+	msg, err := json.Marshal(local)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]RoleID, 0, len(names)-1)
+	for i := range names {
+		if RoleID(i) != self {
+			peers = append(peers, RoleID(i))
+		}
+	}
+	for _, peer := range peers {
+		if err := t.Send(ctx, peer, msg); err != nil {
+			return nil, fmt.Errorf("handshake send to party %d: %w", peer, err)
+		}
+	}
+	msgs, err := t.ReceiveAll(ctx, peers)
+	if err != nil {
+		return nil, fmt.Errorf("handshake receive: %w", err)
+	}
+
+	infos := make([]PeerInfo, 0, len(peers))
+	for _, peer := range peers {
+		raw, ok := msgs[peer]
+		if !ok {
+			return nil, &HandshakeError{Peer: peer, Reason: "no handshake message received"}
+		}
+		var remote hello
+		if err := json.Unmarshal(raw, &remote); err != nil || remote.Magic != helloMagic {
+			return nil, &HandshakeError{Peer: peer, Reason: "peer did not send a handshake; it may run an older wrapper or skip the handshake"}
+		}
+		if reason := compatible(local, remote, peer); reason != "" {
+			return nil, &HandshakeError{Peer: peer, Reason: reason}
+		}
+		infos = append(infos, PeerInfo{
+			Role:     peer,
+			Wrapper:  remote.Wrapper,
+			Upstream: remote.Upstream,
+			Features: remote.Features,
+		})
+	}
+	return infos, nil
+}
+
+// compatible returns why remote cannot run a job with local, or "".
+func compatible(local, remote hello, peer RoleID) string {
+	switch {
+	case remote.Self != peer:
+		return fmt.Sprintf("peer claims role %d", remote.Self)
+	case !bytes.Equal(remote.Names, local.Names):
+		return "party names differ"
+	case remote.Upstream != local.Upstream:
+		return fmt.Sprintf("upstream version %q, local %q", remote.Upstream, local.Upstream)
+	case !sameRelease(remote.Wrapper, local.Wrapper):
+		return fmt.Sprintf("wrapper version %q, local %q", remote.Wrapper, local.Wrapper)
+	}
+	for _, f := range local.Require {
+		if !slices.Contains(remote.Features, f) {
+			return fmt.Sprintf("missing required feature %q", f)
+		}
+	}
+	for _, f := range remote.Require {
+		if !slices.Contains(local.Features, f) {
+			return fmt.Sprintf("requires feature %q, which is not advertised locally", f)
+		}
+	}
+	return ""
+}
+
+// sameRelease reports whether two wrapper versions share a major version, or
+// major and minor version before v1. Unparsable versions must match exactly.
+func sameRelease(a, b string) bool {
+	amaj, amin, aok := parseRelease(a)
+	bmaj, bmin, bok := parseRelease(b)
+	if !aok || !bok {
+		return a == b
+	}
+	if amaj != bmaj {
+		return false
+	}
+	return amaj != 0 || amin == bmin
+}
+
+func parseRelease(v string) (major, minor int, ok bool) {
+	parts := strings.SplitN(strings.TrimPrefix(v, "v"), ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+func namesDigest(names []string) []byte {
+	h := sha256.New()
+	var n [4]byte
+	for _, name := range names {
+		binary.BigEndian.PutUint32(n[:], uint32(len(name)))
+		h.Write(n[:])
+		h.Write([]byte(name))
+	}
+	return h.Sum(nil)
+}
+
+// NewJob2PWithHandshake runs Handshake over t and then constructs the job as
+// NewJob2PWithContext does. Both parties must use it.
+func NewJob2PWithHandshake(ctx context.Context, t Transport, self Role, names [2]string, p *HandshakeParams) (*Job2P, error) {
+	if t == nil {
+		return nil, ErrNilTransport
+	}
+	if !self.valid() {
+		return nil, fmt.Errorf("%w: role %d is not valid", ErrBadPeers, self)
+	}
+	if _, err := Handshake(ctx, t, self.roleID(), names[:], p); err != nil {
+		return nil, err
+	}
+	return NewJob2PWithContext(ctx, t, self, names)
+}
+
+// NewJobMPWithHandshake runs Handshake over t and then constructs the job as
+// NewJobMPWithContext does. Every party must use it.
+func NewJobMPWithHandshake(ctx context.Context, t Transport, self RoleID, names []string, p *HandshakeParams) (*JobMP, error) {
+	if _, err := Handshake(ctx, t, self, names, p); err != nil {
+		return nil, err
+	}
+	return NewJobMPWithContext(ctx, t, self, names)
+}
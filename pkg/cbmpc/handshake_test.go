@@ -0,0 +1,168 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// chanNet is a minimal in-memory network; mocknet cannot be imported here.
+type chanNet struct {
+	mu    sync.Mutex
+	boxes map[[2]RoleID]chan []byte
+}
+
+func (n *chanNet) box(from, to RoleID) chan []byte {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.boxes == nil {
+		n.boxes = make(map[[2]RoleID]chan []byte)
+	}
+	k := [2]RoleID{from, to}
+	if n.boxes[k] == nil {
+		n.boxes[k] = make(chan []byte, 16)
+	}
+	return n.boxes[k]
+}
+
+type chanEndpoint struct {
+	net  *chanNet
+	self RoleID
+}
+
+func (e chanEndpoint) Send(_ context.Context, to RoleID, msg []byte) error {
+	e.net.box(e.self, to) <- append([]byte(nil), msg...)
+	return nil
+}
+
+func (e chanEndpoint) Receive(ctx context.Context, from RoleID) ([]byte, error) {
+	select {
+	case msg := <-e.net.box(from, e.self):
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (e chanEndpoint) ReceiveAll(ctx context.Context, from []RoleID) (map[RoleID][]byte, error) {
+	out := make(map[RoleID][]byte, len(from))
+	for _, r := range from {
+		msg, err := e.Receive(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		out[r] = msg
+	}
+	return out, nil
+}
+
+// runHandshake runs one handshake per party concurrently and returns each
+// party's error.
+func runHandshake(t *testing.T, names [][]string, params []*HandshakeParams) []error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	net := &chanNet{}
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			infos, err := Handshake(ctx, chanEndpoint{net: net, self: RoleID(i)}, RoleID(i), names[i], params[i])
+			if err == nil && len(infos) != len(names[i])-1 {
+				err = errors.New("wrong number of peer infos")
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestHandshakeCompatible(t *testing.T) {
+	names := []string{"alice", "bob", "carol"}
+	errs := runHandshake(t, [][]string{names, names, names}, make([]*HandshakeParams, 3))
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d: %v", i, err)
+		}
+	}
+}
+
+func TestHandshakeMismatch(t *testing.T) {
+	names := []string{"alice", "bob"}
+	cases := map[string]struct {
+		names  [][]string
+		params []*HandshakeParams
+	}{
+		"names": {
+			names:  [][]string{names, {"alice", "eve"}},
+			params: make([]*HandshakeParams, 2),
+		},
+		"required feature": {
+			names:  [][]string{names, names},
+			params: []*HandshakeParams{{Features: []string{"x", "y"}, Require: []string{"y"}}, {Features: []string{"x"}}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			for i, err := range runHandshake(t, tc.names, tc.params) {
+				var herr *HandshakeError
+				if !errors.As(err, &herr) || !errors.Is(err, ErrIncompatiblePeer) {
+					t.Fatalf("party %d: got %v, want HandshakeError", i, err)
+				}
+				if herr.Peer != RoleID(1-i) {
+					t.Fatalf("party %d: blamed party %d", i, herr.Peer)
+				}
+			}
+		})
+	}
+}
+
+func TestHandshakeNoHello(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	net := &chanNet{}
+	_ = chanEndpoint{net: net, self: 1}.Send(ctx, 0, []byte{0x01, 0x02})
+	_, err := Handshake(ctx, chanEndpoint{net: net, self: 0}, 0, []string{"alice", "bob"}, nil)
+	if !errors.Is(err, ErrIncompatiblePeer) {
+		t.Fatalf("got %v, want ErrIncompatiblePeer", err)
+	}
+}
+
+func TestHandshakeInvalid(t *testing.T) {
+	ctx := context.Background()
+	if _, err := Handshake(ctx, nil, 0, []string{"a", "b"}, nil); !errors.Is(err, ErrNilTransport) {
+		t.Fatalf("nil transport: %v", err)
+	}
+	ep := chanEndpoint{net: &chanNet{}}
+	if _, err := Handshake(ctx, ep, 2, []string{"a", "b"}, nil); !errors.Is(err, ErrBadPeers) {
+		t.Fatalf("bad self: %v", err)
+	}
+	if _, err := Handshake(ctx, ep, 0, []string{"a", "b"}, &HandshakeParams{Features: []string{}, Require: []string{"x"}}); err == nil {
+		t.Fatal("expected error for unadvertised required feature")
+	}
+}
+
+func TestSameRelease(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"v1.2.0", "v1.5.3", true},
+		{"v1.2.0", "v2.0.0", false},
+		{"v0.3.1", "v0.3.9", true},
+		{"v0.3.1", "v0.4.0", false},
+		{"v0.0.0-in-progress", "v0.0.0-in-progress", true},
+		{"dev", "dev", true},
+		{"dev", "v1.0.0", false},
+	}
+	for _, tc := range cases {
+		if got := sameRelease(tc.a, tc.b); got != tc.want {
+			t.Errorf("sameRelease(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
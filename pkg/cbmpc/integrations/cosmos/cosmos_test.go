@@ -0,0 +1,200 @@
+package cosmos_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/integrations/cosmos"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// localSigner signs with an in-process secp256k1 key.
+type localSigner struct {
+	key  *btcec.PrivateKey
+	high bool // return the high-S form, as a careless signer might
+}
+
+func (s localSigner) PublicKey() ([]byte, error) { return s.key.PubKey().SerializeCompressed(), nil }
+
+func (s localSigner) SignHash(_ context.Context, hash []byte) ([]byte, error) {
+	der := btcecdsa.Sign(s.key, hash).Serialize()
+	sig, err := ecdsa2p.SignatureFromDER(cbmpc.CurveSecp256k1, der, ecdsa2p.SigFormatCompact)
+	if err != nil || !s.high {
+		return sig, err
+	}
+	sv := new(big.Int).SetBytes(sig[32:])
+	new(big.Int).Sub(btcec.S256().N, sv).FillBytes(sig[32:])
+	return sig, nil
+}
+
+func testDoc() *cosmos.SignDoc {
+	return &cosmos.SignDoc{
+		BodyBytes:     []byte("body"),
+		AuthInfoBytes: []byte("auth"),
+		ChainID:       "cosmoshub-4",
+		AccountNumber: 300,
+	}
+}
+
+func TestSignDocEncoding(t *testing.T) {
+	want, _ := hex.DecodeString("0a04626f6479" + "120461757468" + "1a0b636f736d6f736875622d34" + "20ac02")
+	doc := testDoc()
+	if got := doc.Marshal(); !bytes.Equal(got, want) {
+		t.Fatalf("Marshal() = %x, want %x", got, want)
+	}
+	parsed, err := cosmos.ParseSignDoc(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.ChainID != doc.ChainID || parsed.AccountNumber != doc.AccountNumber ||
+		!bytes.Equal(parsed.BodyBytes, doc.BodyBytes) || !bytes.Equal(parsed.AuthInfoBytes, doc.AuthInfoBytes) {
+		t.Fatalf("ParseSignDoc() = %+v", parsed)
+	}
+
+	// Account number zero is omitted, as gogoproto does.
+	doc.AccountNumber = 0
+	if got := doc.Marshal(); bytes.Contains(got, []byte{0x20}) {
+		t.Fatalf("zero account number encoded: %x", got)
+	}
+
+	for name, bad := range map[string]string{
+		"out of order":     "120461757468" + "0a04626f6479",
+		"non-minimal":      "0a04626f6479" + "20ac8200",
+		"unknown field":    "2a0100",
+		"truncated":        "0a05626f6479",
+		"wrong wire type":  "0804",
+		"explicit default": "2000",
+	} {
+		b, _ := hex.DecodeString(bad)
+		if _, err := cosmos.ParseSignDoc(b); !errors.Is(err, cosmos.ErrMalformed) {
+			t.Errorf("%s: error = %v", name, err)
+		}
+	}
+}
+
+func TestPubKeyAny(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := key.PubKey().SerializeCompressed()
+	got, err := cosmos.PubKeyAny(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append([]byte{0x0a, 0x1f}, cosmos.PubKeyTypeURL...)
+	want = append(want, 0x12, 0x23, 0x0a, 0x21)
+	want = append(want, pub...)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("PubKeyAny() = %x, want %x", got, want)
+	}
+	if _, err := cosmos.PubKeyAny(key.PubKey().SerializeUncompressed()); err == nil {
+		t.Fatal("expected error for an uncompressed key")
+	}
+}
+
+func TestTxRaw(t *testing.T) {
+	tx := &cosmos.TxRaw{BodyBytes: []byte{1}, AuthInfoBytes: []byte{2}, Signatures: [][]byte{{3}, {}}}
+	want := []byte{0x0a, 1, 1, 0x12, 1, 2, 0x1a, 1, 3, 0x1a, 0}
+	if got := tx.Marshal(); !bytes.Equal(got, want) {
+		t.Fatalf("Marshal() = %x, want %x", got, want)
+	}
+}
+
+func TestSignDirect(t *testing.T) {
+	ctx := context.Background()
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := key.PubKey().SerializeCompressed()
+	doc := testDoc()
+	sig, err := cosmos.SignDirect(ctx, localSigner{key: key}, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sig) != cosmos.SignatureSize || !cosmos.VerifySignature(pub, doc.Marshal(), sig) {
+		t.Fatalf("signature %x does not verify", sig)
+	}
+
+	// The digest is SHA-256 of the sign bytes.
+	hash := sha256.Sum256(doc.Marshal())
+	r, s := new(btcec.ModNScalar), new(btcec.ModNScalar)
+	r.SetByteSlice(sig[:32])
+	s.SetByteSlice(sig[32:])
+	if !btcecdsa.NewSignature(r, s).Verify(hash[:], key.PubKey()) {
+		t.Fatal("signature is not over SHA-256 of the sign bytes")
+	}
+
+	doc.AccountNumber++
+	if cosmos.VerifySignature(pub, doc.Marshal(), sig) {
+		t.Fatal("signature verified for another account number")
+	}
+
+	// cosmos-sdk rejects high-S signatures, so SignDirect must too.
+	if _, err := cosmos.SignDirect(ctx, localSigner{key: key, high: true}, testDoc()); !errors.Is(err, cosmos.ErrBadSignature) {
+		t.Fatalf("high-S error = %v", err)
+	}
+	if _, err := cosmos.SignDirect(ctx, localSigner{key: key}, &cosmos.SignDoc{}); err == nil {
+		t.Fatal("expected error for a sign doc without chain ID")
+	}
+}
+
+func TestECDSA2PSigner(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	names := [2]string{"p1", "p2"}
+	roles := [2]cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2}
+
+	var keys [2]*ecdsa2p.Key
+	var sigs [2][]byte
+	var errs [2]error
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), roles[i], names)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveSecp256k1})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			keys[i] = res.Key
+			sigs[i], errs[i] = cosmos.SignDirect(ctx, &cosmos.ECDSA2PSigner{Job: job, Key: res.Key}, testDoc())
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d failed: %v", i, err)
+		}
+		defer func() { _ = keys[i].Close() }()
+	}
+	pub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cosmos.VerifySignature(pub, testDoc().Marshal(), sigs[0]) {
+		t.Fatal("MPC signature does not verify")
+	}
+}
@@ -0,0 +1,35 @@
+// Package cosmos signs Cosmos SDK transactions in SIGN_MODE_DIRECT with
+// ECDSA keys on secp256k1 from the ecdsa2p and ecdsamp packages.
+//
+// SignDirect hashes the canonical SignDoc encoding with SHA-256, runs the
+// MPC signing protocol and returns the 64-byte r || s signature with low S
+// that cosmos-sdk's secp256k1 verification requires (it rejects high-S
+// signatures as malleable). PubKeyAny encodes the 33-byte compressed public
+// key for the SignerInfo of AuthInfo.
+//
+// The package does not build TxBody or AuthInfo messages; take them from a
+// wallet SDK, or parse the sign bytes it produces with ParseSignDoc.
+//
+// # Usage Example
+//
+//	doc, err := cosmos.ParseSignDoc(signBytes)
+//	if err != nil {
+//	    return err
+//	}
+//	signer := &cosmos.ECDSA2PSigner{Job: job, Key: key}
+//	sig, err := cosmos.SignDirect(ctx, signer, doc)
+//	if err != nil {
+//	    return err
+//	}
+//	// On P1, which receives the signature:
+//	tx := &cosmos.TxRaw{BodyBytes: doc.BodyBytes, AuthInfoBytes: doc.AuthInfoBytes, Signatures: [][]byte{sig}}
+//	raw := tx.Marshal()
+//
+// # Security Considerations
+//
+//   - Every party signs whatever SignDoc it is given. Decode the body and
+//     check the messages, fee and chain ID on every party before signing.
+//   - The account number and chain ID in the SignDoc bind the signature to
+//     one account on one chain; take them from a trusted node, not from the
+//     requester.
+package cosmos
@@ -0,0 +1,139 @@
+package cosmos
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// The few protobuf messages this package needs are encoded by hand, in the
+// canonical form cosmos-sdk's gogoproto marshaler produces: fields in number
+// order, zero values omitted.
+
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+// ErrMalformed is wrapped by errors from ParseSignDoc.
+var ErrMalformed = errors.New("cosmos: malformed encoding")
+
+func appendTag(b []byte, field, wire int) []byte {
+	return binary.AppendUvarint(b, uint64(field<<3|wire))
+}
+
+func appendBytesField(b []byte, field int, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = appendTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendVarintField(b []byte, field int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = appendTag(b, field, wireVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+// SignDoc is the cosmos.tx.v1beta1.SignDoc signed in SIGN_MODE_DIRECT.
+type SignDoc struct {
+	BodyBytes     []byte // Serialized TxBody
+	AuthInfoBytes []byte // Serialized AuthInfo
+	ChainID       string
+	AccountNumber uint64
+}
+
+// Marshal returns the canonical encoding of the SignDoc: the sign bytes.
+func (d *SignDoc) Marshal() []byte {
+	var b []byte
+	b = appendBytesField(b, 1, d.BodyBytes)
+	b = appendBytesField(b, 2, d.AuthInfoBytes)
+	b = appendBytesField(b, 3, []byte(d.ChainID))
+	return appendVarintField(b, 4, d.AccountNumber)
+}
+
+// ParseSignDoc parses sign bytes produced by a wallet SDK. Only the canonical
+// encoding is accepted, so the parsed SignDoc marshals back to b exactly and
+// what the parties inspect is what they sign.
+func ParseSignDoc(b []byte) (*SignDoc, error) {
+	d := &SignDoc{}
+	rest := b
+	for len(rest) > 0 {
+		tag, n := binary.Uvarint(rest)
+		if n <= 0 {
+			return nil, fmt.Errorf("%w: bad tag", ErrMalformed)
+		}
+		rest = rest[n:]
+		field, wire := int(tag>>3), int(tag&7)
+		switch {
+		case field == 4 && wire == wireVarint:
+			v, n := binary.Uvarint(rest)
+			if n <= 0 {
+				return nil, fmt.Errorf("%w: bad account number", ErrMalformed)
+			}
+			d.AccountNumber = v
+			rest = rest[n:]
+		case field >= 1 && field <= 3 && wire == wireBytes:
+			l, n := binary.Uvarint(rest)
+			if n <= 0 || l > uint64(len(rest)-n) {
+				return nil, fmt.Errorf("%w: bad length for field %d", ErrMalformed, field)
+			}
+			v := rest[n : n+int(l)]
+			rest = rest[n+int(l):]
+			switch field {
+			case 1:
+				d.BodyBytes = append([]byte(nil), v...)
+			case 2:
+				d.AuthInfoBytes = append([]byte(nil), v...)
+			case 3:
+				d.ChainID = string(v)
+			}
+		default:
+			return nil, fmt.Errorf("%w: unexpected field %d (wire type %d)", ErrMalformed, field, wire)
+		}
+	}
+	if !bytes.Equal(d.Marshal(), b) {
+		return nil, fmt.Errorf("%w: non-canonical sign doc", ErrMalformed)
+	}
+	return d, nil
+}
+
+// TxRaw is the cosmos.tx.v1beta1.TxRaw broadcast to a node.
+type TxRaw struct {
+	BodyBytes     []byte
+	AuthInfoBytes []byte
+	Signatures    [][]byte // One per SignerInfo in AuthInfo, in order
+}
+
+// Marshal returns the encoding of the TxRaw, ready for BroadcastTx.
+func (t *TxRaw) Marshal() []byte {
+	var b []byte
+	b = appendBytesField(b, 1, t.BodyBytes)
+	b = appendBytesField(b, 2, t.AuthInfoBytes)
+	for _, sig := range t.Signatures {
+		// Repeated bytes fields keep empty entries.
+		b = appendTag(b, 3, wireBytes)
+		b = binary.AppendUvarint(b, uint64(len(sig)))
+		b = append(b, sig...)
+	}
+	return b
+}
+
+// PubKeyTypeURL is the Any type URL of a secp256k1 public key.
+const PubKeyTypeURL = "/cosmos.crypto.secp256k1.PubKey"
+
+// PubKeyAny returns the google.protobuf.Any wrapping a 33-byte compressed
+// secp256k1 public key, for SignerInfo.public_key in AuthInfo.
+func PubKeyAny(pub []byte) ([]byte, error) {
+	if err := checkPubKey(pub); err != nil {
+		return nil, err
+	}
+	var b []byte
+	b = appendBytesField(b, 1, []byte(PubKeyTypeURL))
+	return appendBytesField(b, 2, appendBytesField(nil, 1, pub)), nil
+}
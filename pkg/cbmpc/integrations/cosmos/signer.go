@@ -0,0 +1,159 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+)
+
+const (
+	// PubKeySize is the size of a compressed secp256k1 public key.
+	PubKeySize = 33
+
+	// SignatureSize is the size of a Cosmos secp256k1 signature (r || s).
+	SignatureSize = 64
+)
+
+// ErrBadSignature is returned when the MPC protocol produces a signature that
+// does not verify against the key and sign bytes.
+var ErrBadSignature = errors.New("cosmos: signature does not verify")
+
+// Signer signs SHA-256 digests of sign bytes with an MPC key on secp256k1.
+type Signer interface {
+	// PublicKey returns the 33-byte compressed public key.
+	PublicKey() ([]byte, error)
+
+	// SignHash signs hash, returning a 64-byte r || s signature with low S.
+	// It returns a nil signature, and no error, on parties that do not
+	// receive the signature.
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+// ECDSA2PSigner signs with a 2-party ECDSA key. Only P1 receives signatures.
+type ECDSA2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *ecdsa2p.Key
+}
+
+// PublicKey implements Signer.
+func (s *ECDSA2PSigner) PublicKey() ([]byte, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.PublicKey()
+}
+
+// SignHash implements Signer.
+func (s *ECDSA2PSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	res, err := ecdsa2p.Sign(ctx, s.Job, &ecdsa2p.SignParams{Key: s.Key, Message: hash, Format: ecdsa2p.SigFormatCompact})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// ECDSAMPSigner signs with a multi-party ECDSA key. Only the party at index
+// SigReceiver receives signatures.
+type ECDSAMPSigner struct {
+	Job         *cbmpc.JobMP
+	Key         *ecdsamp.Key
+	SigReceiver int
+}
+
+// PublicKey implements Signer.
+func (s *ECDSAMPSigner) PublicKey() ([]byte, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.PublicKey()
+}
+
+// SignHash implements Signer.
+func (s *ECDSAMPSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	res, err := ecdsamp.Sign(ctx, s.Job, &ecdsamp.SignParams{Key: s.Key, Message: hash, SigReceiver: s.SigReceiver})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return ecdsa2p.SignatureFromDER(cbmpc.CurveSecp256k1, res.Signature, ecdsa2p.SigFormatCompact)
+}
+
+// SignDirect signs doc in SIGN_MODE_DIRECT and returns the 64-byte signature
+// for the signer's slot in TxRaw.Signatures. The signature is checked against
+// the public key before it is returned. Every party must call it with the
+// same SignDoc.
+//
+// Parties that do not receive the signature get a nil signature and no
+// error.
+func SignDirect(ctx context.Context, s Signer, doc *SignDoc) ([]byte, error) {
+	if s == nil {
+		return nil, errors.New("nil signer")
+	}
+	if doc == nil {
+		return nil, errors.New("nil sign doc")
+	}
+	if doc.ChainID == "" {
+		return nil, errors.New("sign doc has no chain ID")
+	}
+	pub, err := s.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	signBytes := doc.Marshal()
+	hash := sha256.Sum256(signBytes)
+	sig, err := s.SignHash(ctx, hash[:])
+	if err != nil || sig == nil {
+		return nil, err
+	}
+	if !VerifySignature(pub, signBytes, sig) {
+		return nil, ErrBadSignature
+	}
+	return sig, nil
+}
+
+// VerifySignature reports whether sig is a valid signature of signBytes
+// under the compressed public key pub, with the checks of cosmos-sdk's
+// secp256k1 PubKey.VerifySignature: a 64-byte r || s over the SHA-256 of
+// signBytes, with S in the lower half of the order.
+func VerifySignature(pub, signBytes, sig []byte) bool {
+	if len(sig) != SignatureSize || checkPubKey(pub) != nil {
+		return false
+	}
+	pk, err := btcec.ParsePubKey(pub)
+	if err != nil {
+		return false
+	}
+	var r, s btcec.ModNScalar
+	if r.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:]) || r.IsZero() || s.IsZero() {
+		return false
+	}
+	if s.IsOverHalfOrder() {
+		return false
+	}
+	hash := sha256.Sum256(signBytes)
+	return btcecdsa.NewSignature(&r, &s).Verify(hash[:], pk)
+}
+
+func checkPubKey(pub []byte) error {
+	if len(pub) != PubKeySize || (pub[0] != 2 && pub[0] != 3) {
+		return fmt.Errorf("public key must be %d-byte compressed secp256k1", PubKeySize)
+	}
+	return nil
+}
+
+func checkCurve(c cbmpc.Curve, err error) error {
+	if err != nil {
+		return err
+	}
+	if c != cbmpc.CurveSecp256k1 {
+		return fmt.Errorf("cosmos keys must be secp256k1 (got %s)", c)
+	}
+	return nil
+}
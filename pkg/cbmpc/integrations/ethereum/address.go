@@ -0,0 +1,72 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"golang.org/x/crypto/sha3"
+)
+
+// Keccak256 returns the Keccak-256 hash of the concatenated data, the hash
+// Ethereum uses everywhere (not the standardized SHA3-256).
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// Address is a 20-byte Ethereum account address.
+type Address [20]byte
+
+// PubKeyToAddress derives the address of a secp256k1 public key, compressed
+// or uncompressed, as returned by ecdsa2p.Key.PublicKey.
+func PubKeyToAddress(pub []byte) (Address, error) {
+	pk, err := btcec.ParsePubKey(pub)
+	if err != nil {
+		return Address{}, err
+	}
+	var a Address
+	copy(a[:], Keccak256(pk.SerializeUncompressed()[1:])[12:])
+	return a, nil
+}
+
+// ParseAddress parses a 0x-prefixed hex address. Mixed-case input must carry
+// a valid EIP-55 checksum; all-lowercase and all-uppercase input is accepted
+// as is.
+func ParseAddress(s string) (Address, error) {
+	var a Address
+	hexPart, ok := strings.CutPrefix(s, "0x")
+	if !ok || len(hexPart) != 40 {
+		return a, fmt.Errorf("invalid address %q", s)
+	}
+	b, err := hex.DecodeString(hexPart)
+	if err != nil {
+		return a, fmt.Errorf("invalid address %q", s)
+	}
+	copy(a[:], b)
+	if hexPart != strings.ToLower(hexPart) && hexPart != strings.ToUpper(hexPart) && a.Hex() != s {
+		return Address{}, errors.New("address checksum mismatch")
+	}
+	return a, nil
+}
+
+// Hex returns the address with its EIP-55 checksum.
+func (a Address) Hex() string {
+	lower := hex.EncodeToString(a[:])
+	hash := Keccak256([]byte(lower))
+	out := []byte(lower)
+	for i, c := range out {
+		if c >= 'a' && hash[i/2]>>(4*(1-uint(i)%2))&0xf >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// String returns a.Hex().
+func (a Address) String() string { return a.Hex() }
@@ -0,0 +1,41 @@
+// Package ethereum signs Ethereum messages with ECDSA keys on secp256k1 from
+// the ecdsa2p and ecdsamp packages.
+//
+// Most "signature does not match" reports from wallet integrators come from
+// signing the wrong digest: the raw message instead of the EIP-191 prefixed
+// hash, SHA-256 instead of Keccak-256, or a hand-rolled EIP-712 encoding. The
+// package computes the digests itself and returns signatures in the form
+// wallets produce:
+//
+//   - SignPersonalMessage: personal_sign (EIP-191 version 0x45) over the
+//     message itself.
+//   - SignTypedData: eth_signTypedData_v4 (EIP-712) over the typed data as
+//     the dapp sent it; parse it with ParseTypedData.
+//   - SignDigest: any other 32-byte digest.
+//
+// Signatures have low S (EIP-2) and carry the recovery ID, found by
+// recovering the signer's address, so Signature.Bytes returns the 65-byte
+// r || s || v that ecrecover and wallet libraries expect.
+//
+// # Usage Example
+//
+//	td, err := ethereum.ParseTypedData(request)
+//	if err != nil {
+//	    return err
+//	}
+//	signer := &ethereum.ECDSA2PSigner{Job: job, Key: key}
+//	sig, err := ethereum.SignTypedData(ctx, signer, td)
+//	if err != nil {
+//	    return err
+//	}
+//	// On P1, which receives the signature:
+//	out := "0x" + hex.EncodeToString(sig.Bytes())
+//
+// # Security Considerations
+//
+//   - Every party signs whatever it is given. Check the domain (chain ID and
+//     verifying contract) and the message on every party before signing;
+//     an EIP-712 permit can move tokens as surely as a transaction.
+//   - SignDigest signs opaque digests and should only be exposed for digests
+//     the parties compute themselves.
+package ethereum
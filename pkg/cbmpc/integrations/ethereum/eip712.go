@@ -0,0 +1,365 @@
+package ethereum
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TypedDataField is one member of an EIP-712 struct type.
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is the EIP-712 payload of eth_signTypedData_v4.
+//
+// Values in Domain and Message are what encoding/json produces: strings,
+// bools, numbers (json.Number or float64), []any and map[string]any.
+// Integers may also be decimal or 0x-prefixed hex strings, and bytes values
+// are 0x-prefixed hex strings.
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]any              `json:"domain"`
+	Message     map[string]any              `json:"message"`
+}
+
+const domainType = "EIP712Domain"
+
+// domainFields lists the EIP712Domain members in the order EIP-712 defines.
+var domainFields = []TypedDataField{
+	{"name", "string"},
+	{"version", "string"},
+	{"chainId", "uint256"},
+	{"verifyingContract", "address"},
+	{"salt", "bytes32"},
+}
+
+// ParseTypedData parses the JSON argument of eth_signTypedData_v4. Numbers
+// are kept exact.
+func ParseTypedData(b []byte) (*TypedData, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	td := &TypedData{}
+	if err := dec.Decode(td); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("trailing data after typed data")
+	}
+	return td, nil
+}
+
+// Hash returns the EIP-712 digest:
+// keccak256("\x19\x01" || domainSeparator || hashStruct(message)).
+func (td *TypedData) Hash() ([]byte, error) {
+	sep, err := td.DomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+	if td.PrimaryType == domainType {
+		return Keccak256([]byte{0x19, 0x01}, sep), nil
+	}
+	msg, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, err
+	}
+	return Keccak256([]byte{0x19, 0x01}, sep, msg), nil
+}
+
+// DomainSeparator returns hashStruct(domain). If Types has no EIP712Domain
+// entry, the type is made of the standard members present in Domain.
+func (td *TypedData) DomainSeparator() ([]byte, error) {
+	return td.HashStruct(domainType, td.Domain)
+}
+
+// HashStruct returns hashStruct(data) for struct type typ:
+// keccak256(typeHash || encodeData(data)). data must have exactly the
+// members of typ.
+func (td *TypedData) HashStruct(typ string, data map[string]any) ([]byte, error) {
+	fields, ok := td.fields(typ)
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", typ)
+	}
+	encType, err := td.EncodeType(typ)
+	if err != nil {
+		return nil, err
+	}
+	for name := range data {
+		if !hasField(fields, name) {
+			return nil, fmt.Errorf("%s has no member %q", typ, name)
+		}
+	}
+	enc := Keccak256([]byte(encType))
+	for _, f := range fields {
+		v, ok := data[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s.%s is missing", typ, f.Name)
+		}
+		word, err := td.encodeValue(f.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typ, f.Name, err)
+		}
+		enc = append(enc, word...)
+	}
+	return Keccak256(enc), nil
+}
+
+// EncodeType returns encodeType(typ): the type followed by the struct types
+// it references, sorted by name, e.g.
+// "Mail(Person from,Person to,string contents)Person(string name,address wallet)".
+func (td *TypedData) EncodeType(typ string) (string, error) {
+	deps := map[string]bool{}
+	if err := td.deps(typ, deps); err != nil {
+		return "", err
+	}
+	delete(deps, typ)
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range append([]string{typ}, names...) {
+		fields, _ := td.fields(name)
+		sb.WriteString(name)
+		sb.WriteByte('(')
+		for i, f := range fields {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(f.Type + " " + f.Name)
+		}
+		sb.WriteByte(')')
+	}
+	return sb.String(), nil
+}
+
+func (td *TypedData) deps(typ string, found map[string]bool) error {
+	if found[typ] {
+		return nil
+	}
+	fields, ok := td.fields(typ)
+	if !ok {
+		return fmt.Errorf("unknown type %q", typ)
+	}
+	found[typ] = true
+	for _, f := range fields {
+		base := baseType(f.Type)
+		if _, ok := td.fields(base); ok {
+			if err := td.deps(base, found); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fields returns the members of struct type typ.
+func (td *TypedData) fields(typ string) ([]TypedDataField, bool) {
+	if fields, ok := td.Types[typ]; ok {
+		return fields, true
+	}
+	if typ != domainType {
+		return nil, false
+	}
+	var fields []TypedDataField
+	for _, f := range domainFields {
+		if _, ok := td.Domain[f.Name]; ok {
+			fields = append(fields, f)
+		}
+	}
+	return fields, true
+}
+
+// encodeValue returns the 32-byte encoding of v as type typ.
+func (td *TypedData) encodeValue(typ string, v any) ([]byte, error) {
+	if strings.HasSuffix(typ, "]") {
+		i := strings.LastIndexByte(typ, '[')
+		if i < 0 {
+			return nil, fmt.Errorf("invalid type %q", typ)
+		}
+		elems, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected array for %s", typ)
+		}
+		if n := typ[i+1 : len(typ)-1]; n != "" {
+			size, err := strconv.Atoi(n)
+			if err != nil || size != len(elems) {
+				return nil, fmt.Errorf("expected %s elements for %s, got %d", n, typ, len(elems))
+			}
+		}
+		var enc []byte
+		for _, e := range elems {
+			word, err := td.encodeValue(typ[:i], e)
+			if err != nil {
+				return nil, err
+			}
+			enc = append(enc, word...)
+		}
+		return Keccak256(enc), nil
+	}
+	if _, ok := td.fields(typ); ok {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected object for %s", typ)
+		}
+		return td.HashStruct(typ, m)
+	}
+
+	word := make([]byte, 32)
+	switch {
+	case typ == "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, errors.New("expected string")
+		}
+		return Keccak256([]byte(s)), nil
+	case typ == "bytes":
+		b, err := hexBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		return Keccak256(b), nil
+	case typ == "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, errors.New("expected bool")
+		}
+		if b {
+			word[31] = 1
+		}
+		return word, nil
+	case typ == "address":
+		s, ok := v.(string)
+		if !ok {
+			return nil, errors.New("expected address string")
+		}
+		a, err := ParseAddress(s)
+		if err != nil {
+			return nil, err
+		}
+		copy(word[12:], a[:])
+		return word, nil
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := strconv.Atoi(typ[len("bytes"):])
+		if err != nil || n < 1 || n > 32 {
+			return nil, fmt.Errorf("invalid type %q", typ)
+		}
+		b, err := hexBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != n {
+			return nil, fmt.Errorf("expected %d bytes, got %d", n, len(b))
+		}
+		copy(word, b)
+		return word, nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		signed := strings.HasPrefix(typ, "int")
+		bits := 256
+		if n := strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"); n != "" {
+			var err error
+			if bits, err = strconv.Atoi(n); err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+				return nil, fmt.Errorf("invalid type %q", typ)
+			}
+		}
+		x, err := toBigInt(v)
+		if err != nil {
+			return nil, err
+		}
+		lo, hi := new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(bits))
+		if signed {
+			hi.Rsh(hi, 1)
+			lo.Neg(hi)
+		}
+		if x.Cmp(lo) < 0 || x.Cmp(hi) >= 0 {
+			return nil, fmt.Errorf("%s out of range for %s", x, typ)
+		}
+		if x.Sign() < 0 {
+			x = new(big.Int).Add(x, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		x.FillBytes(word)
+		return word, nil
+	}
+	return nil, fmt.Errorf("unknown type %q", typ)
+}
+
+func baseType(typ string) string {
+	for strings.HasSuffix(typ, "]") {
+		i := strings.LastIndexByte(typ, '[')
+		if i < 0 {
+			break
+		}
+		typ = typ[:i]
+	}
+	return typ
+}
+
+func hasField(fields []TypedDataField, name string) bool {
+	for _, f := range fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hexBytes(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		h, ok := strings.CutPrefix(v, "0x")
+		if !ok {
+			return nil, errors.New("expected 0x-prefixed hex")
+		}
+		return hex.DecodeString(h)
+	}
+	return nil, errors.New("expected 0x-prefixed hex")
+}
+
+func toBigInt(v any) (*big.Int, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return parseInt(string(v))
+	case string:
+		return parseInt(v)
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return nil, fmt.Errorf("number %v is not an exact integer; pass it as a string", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	}
+	return nil, fmt.Errorf("expected integer, got %T", v)
+}
+
+func parseInt(s string) (*big.Int, error) {
+	x, ok := new(big.Int), false
+	if h, isHex := strings.CutPrefix(s, "0x"); isHex {
+		_, ok = x.SetString(h, 16)
+	} else {
+		_, ok = x.SetString(s, 10)
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	return x, nil
+}
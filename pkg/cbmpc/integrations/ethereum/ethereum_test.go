@@ -0,0 +1,322 @@
+package ethereum_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/integrations/ethereum"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// localSigner signs with an in-process secp256k1 key.
+type localSigner struct{ key *btcec.PrivateKey }
+
+func (s localSigner) PublicKey() ([]byte, error) { return s.key.PubKey().SerializeCompressed(), nil }
+
+func (s localSigner) SignHash(_ context.Context, hash []byte) ([]byte, error) {
+	der := btcecdsa.Sign(s.key, hash).Serialize()
+	return ecdsa2p.SignatureFromDER(cbmpc.CurveSecp256k1, der, ecdsa2p.SigFormatCompact)
+}
+
+func unhex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// mailTypedData is the example from EIP-712.
+const mailTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func cowKey(t *testing.T) *btcec.PrivateKey {
+	t.Helper()
+	key, _ := btcec.PrivKeyFromBytes(ethereum.Keccak256([]byte("cow")))
+	return key
+}
+
+func TestEIP712Mail(t *testing.T) {
+	td, err := ethereum.ParseTypedData([]byte(mailTypedData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encType, err := td.EncodeType("Mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encType != "Mail(Person from,Person to,string contents)Person(string name,address wallet)" {
+		t.Fatalf("EncodeType() = %s", encType)
+	}
+	sep, err := td.DomainSeparator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := unhex(t, "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"); !bytes.Equal(sep, want) {
+		t.Fatalf("DomainSeparator() = %x", sep)
+	}
+	msg, err := td.HashStruct("Mail", td.Message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := unhex(t, "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"); !bytes.Equal(msg, want) {
+		t.Fatalf("HashStruct(Mail) = %x", msg)
+	}
+	digest, err := td.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := unhex(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"); !bytes.Equal(digest, want) {
+		t.Fatalf("Hash() = %x", digest)
+	}
+
+	// Without an EIP712Domain type, the domain type comes from its members.
+	delete(td.Types, "EIP712Domain")
+	if again, err := td.Hash(); err != nil || !bytes.Equal(again, digest) {
+		t.Fatalf("Hash() without EIP712Domain = %x, %v", again, err)
+	}
+
+	sig, err := ethereum.SignTypedData(context.Background(), localSigner{cowKey(t)}, td)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := unhex(t, "4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+"1c")
+	if !bytes.Equal(sig.Bytes(), want) {
+		t.Fatalf("signature = %x\nwant        %x", sig.Bytes(), want)
+	}
+}
+
+func TestEIP712Rejects(t *testing.T) {
+	for name, mutate := range map[string]func(td *ethereum.TypedData){
+		"missing member": func(td *ethereum.TypedData) { delete(td.Message, "contents") },
+		"extra member":   func(td *ethereum.TypedData) { td.Message["bcc"] = "Eve" },
+		"bad address": func(td *ethereum.TypedData) {
+			td.Message["to"].(map[string]any)["wallet"] = "0xbbbbBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"
+		},
+		"unknown type": func(td *ethereum.TypedData) { td.PrimaryType = "Letter" },
+		"out of range": func(td *ethereum.TypedData) { td.Domain["chainId"] = "-1" },
+	} {
+		td, err := ethereum.ParseTypedData([]byte(mailTypedData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mutate(td)
+		if _, err := td.Hash(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestEIP712Types(t *testing.T) {
+	td, err := ethereum.ParseTypedData([]byte(`{
+	  "types": {
+	    "EIP712Domain": [{"name": "chainId", "type": "uint256"}],
+	    "Order": [
+	      {"name": "amount", "type": "int64"},
+	      {"name": "ids", "type": "uint8[2]"},
+	      {"name": "data", "type": "bytes"},
+	      {"name": "tag", "type": "bytes4"},
+	      {"name": "ok", "type": "bool"}
+	    ]
+	  },
+	  "primaryType": "Order",
+	  "domain": {"chainId": "0x89"},
+	  "message": {"amount": -5, "ids": [1, "0x02"], "data": "0xdeadbeef", "tag": "0x01020304", "ok": true}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := td.HashStruct("Order", td.Message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Build the expected encoding by hand.
+	word := func(x *big.Int) []byte {
+		if x.Sign() < 0 {
+			x = new(big.Int).Add(x, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return x.FillBytes(make([]byte, 32))
+	}
+	tag := make([]byte, 32)
+	copy(tag, []byte{1, 2, 3, 4})
+	enc := ethereum.Keccak256([]byte("Order(int64 amount,uint8[2] ids,bytes data,bytes4 tag,bool ok)"))
+	enc = append(enc, word(big.NewInt(-5))...)
+	enc = append(enc, ethereum.Keccak256(word(big.NewInt(1)), word(big.NewInt(2)))...)
+	enc = append(enc, ethereum.Keccak256([]byte{0xde, 0xad, 0xbe, 0xef})...)
+	enc = append(enc, tag...)
+	enc = append(enc, word(big.NewInt(1))...)
+	if want := ethereum.Keccak256(enc); !bytes.Equal(got, want) {
+		t.Fatalf("HashStruct(Order) = %x, want %x", got, want)
+	}
+
+	td.Message["ids"] = []any{1}
+	if _, err := td.HashStruct("Order", td.Message); err == nil {
+		t.Fatal("expected error for a fixed-size array of the wrong length")
+	}
+	td.Message["ids"] = []any{1, 256}
+	if _, err := td.HashStruct("Order", td.Message); err == nil {
+		t.Fatal("expected error for a uint8 out of range")
+	}
+}
+
+func TestPersonalSign(t *testing.T) {
+	got := ethereum.PersonalMessageHash([]byte("hello"))
+	if want := unhex(t, "50b2c43fd39106bafbba0da34fc430e1f91e3c96ea2acee2bc34119f92b37750"); !bytes.Equal(got, want) {
+		t.Fatalf("PersonalMessageHash(hello) = %x", got)
+	}
+
+	key := cowKey(t)
+	sig, err := ethereum.SignPersonalMessage(context.Background(), localSigner{key}, []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ethereum.ParseSignature(sig.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ethereum.RecoverAddress(got, parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.Hex() != "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826" {
+		t.Fatalf("recovered %s", addr)
+	}
+}
+
+func TestAddress(t *testing.T) {
+	key := cowKey(t)
+	addr, err := ethereum.PubKeyToAddress(key.PubKey().SerializeCompressed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.Hex() != "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826" {
+		t.Fatalf("PubKeyToAddress() = %s", addr)
+	}
+	for _, s := range []string{
+		"0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+		"0xcd2a3d9f938e13cd947ec05abc7fe734df8dd826",
+	} {
+		if a, err := ethereum.ParseAddress(s); err != nil || a != addr {
+			t.Fatalf("ParseAddress(%s) = %s, %v", s, a, err)
+		}
+	}
+	if _, err := ethereum.ParseAddress("0xcD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"); err == nil {
+		t.Fatal("expected checksum error")
+	}
+}
+
+func TestRecoverRejectsHighS(t *testing.T) {
+	key := cowKey(t)
+	digest := ethereum.PersonalMessageHash([]byte("hello"))
+	sig, err := ethereum.SignDigest(context.Background(), localSigner{key}, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := new(big.Int).SetBytes(sig.S[:])
+	new(big.Int).Sub(btcec.S256().N, s).FillBytes(sig.S[:])
+	sig.V ^= 1
+	if _, err := ethereum.RecoverAddress(digest, sig); err == nil {
+		t.Fatal("expected error for high S")
+	}
+	if _, err := ethereum.SignDigest(context.Background(), localSigner{key}, digest[:31]); err == nil {
+		t.Fatal("expected error for a short digest")
+	}
+	if _, err := ethereum.ParseSignature(append(sig.Bytes()[:64], 29)); err == nil {
+		t.Fatal("expected error for v = 29")
+	}
+}
+
+func TestECDSA2PSigner(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	names := [2]string{"p1", "p2"}
+	roles := [2]cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2}
+
+	var keys [2]*ecdsa2p.Key
+	var sigs [2]*ethereum.Signature
+	var errs [2]error
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), roles[i], names)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveSecp256k1})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			keys[i] = res.Key
+			sigs[i], errs[i] = ethereum.SignPersonalMessage(ctx, &ethereum.ECDSA2PSigner{Job: job, Key: res.Key}, []byte("hello"))
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d failed: %v", i, err)
+		}
+		defer func() { _ = keys[i].Close() }()
+	}
+	pub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ethereum.PubKeyToAddress(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ethereum.RecoverAddress(ethereum.PersonalMessageHash([]byte("hello")), sigs[0])
+	if err != nil || got != want {
+		t.Fatalf("recovered %s, %v; want %s", got, err, want)
+	}
+}
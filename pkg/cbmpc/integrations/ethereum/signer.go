@@ -0,0 +1,212 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+)
+
+// SignatureSize is the size of a signature in r || s || v form.
+const SignatureSize = 65
+
+// ErrBadSignature is returned when the MPC protocol produces a signature that
+// does not recover to the signer's address.
+var ErrBadSignature = errors.New("ethereum: signature does not recover to the signer")
+
+// Signer signs 32-byte digests with an MPC key on secp256k1.
+type Signer interface {
+	// PublicKey returns the 33-byte compressed public key.
+	PublicKey() ([]byte, error)
+
+	// SignHash signs hash, returning a 64-byte r || s signature with low S.
+	// It returns a nil signature, and no error, on parties that do not
+	// receive the signature.
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+// ECDSA2PSigner signs with a 2-party ECDSA key. Only P1 receives signatures.
+type ECDSA2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *ecdsa2p.Key
+}
+
+// PublicKey implements Signer.
+func (s *ECDSA2PSigner) PublicKey() ([]byte, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.PublicKey()
+}
+
+// SignHash implements Signer.
+func (s *ECDSA2PSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	res, err := ecdsa2p.Sign(ctx, s.Job, &ecdsa2p.SignParams{Key: s.Key, Message: hash, Format: ecdsa2p.SigFormatCompact})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// ECDSAMPSigner signs with a multi-party ECDSA key. Only the party at index
+// SigReceiver receives signatures.
+type ECDSAMPSigner struct {
+	Job         *cbmpc.JobMP
+	Key         *ecdsamp.Key
+	SigReceiver int
+}
+
+// PublicKey implements Signer.
+func (s *ECDSAMPSigner) PublicKey() ([]byte, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.PublicKey()
+}
+
+// SignHash implements Signer.
+func (s *ECDSAMPSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	res, err := ecdsamp.Sign(ctx, s.Job, &ecdsamp.SignParams{Key: s.Key, Message: hash, SigReceiver: s.SigReceiver})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return ecdsa2p.SignatureFromDER(cbmpc.CurveSecp256k1, res.Signature, ecdsa2p.SigFormatCompact)
+}
+
+// Signature is a recoverable secp256k1 signature.
+type Signature struct {
+	R, S [32]byte
+	V    byte // Recovery ID, 0 or 1
+}
+
+// Bytes returns the 65-byte r || s || v form with v = 27 + recovery ID, as
+// personal_sign and eth_signTypedData_v4 return and ecrecover expects.
+func (sig *Signature) Bytes() []byte {
+	out := make([]byte, 0, SignatureSize)
+	out = append(out, sig.R[:]...)
+	out = append(out, sig.S[:]...)
+	return append(out, 27+sig.V)
+}
+
+// ParseSignature parses a 65-byte r || s || v signature with v in {0, 1} or
+// {27, 28}.
+func ParseSignature(b []byte) (*Signature, error) {
+	if len(b) != SignatureSize {
+		return nil, fmt.Errorf("signature must be %d bytes (got %d)", SignatureSize, len(b))
+	}
+	v := b[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, fmt.Errorf("invalid recovery value %d", b[64])
+	}
+	sig := &Signature{V: v}
+	copy(sig.R[:], b[:32])
+	copy(sig.S[:], b[32:64])
+	return sig, nil
+}
+
+// RecoverAddress returns the address whose key produced sig over digest, as
+// the ecrecover precompile does. High-S signatures are rejected (EIP-2).
+func RecoverAddress(digest []byte, sig *Signature) (Address, error) {
+	if len(digest) != 32 {
+		return Address{}, errors.New("digest must be 32 bytes")
+	}
+	var s btcec.ModNScalar
+	if s.SetByteSlice(sig.S[:]) || s.IsOverHalfOrder() {
+		return Address{}, errors.New("signature S is not in the lower half of the order")
+	}
+	compact := make([]byte, 0, 65)
+	compact = append(compact, 27+sig.V)
+	compact = append(compact, sig.R[:]...)
+	compact = append(compact, sig.S[:]...)
+	pub, _, err := btcecdsa.RecoverCompact(compact, digest)
+	if err != nil {
+		return Address{}, err
+	}
+	return PubKeyToAddress(pub.SerializeCompressed())
+}
+
+// SignDigest signs a 32-byte digest and computes the recovery ID by
+// recovering the signer's address from the signature. Every party must call
+// it with the same digest.
+//
+// Parties that do not receive the signature get a nil Signature and no
+// error.
+func SignDigest(ctx context.Context, s Signer, digest []byte) (*Signature, error) {
+	if s == nil {
+		return nil, errors.New("nil signer")
+	}
+	if len(digest) != 32 {
+		return nil, errors.New("digest must be 32 bytes")
+	}
+	pub, err := s.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	addr, err := PubKeyToAddress(pub)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := s.SignHash(ctx, digest)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	if len(raw) != 64 {
+		return nil, fmt.Errorf("signature must be 64 bytes (got %d)", len(raw))
+	}
+	sig := &Signature{}
+	copy(sig.R[:], raw[:32])
+	copy(sig.S[:], raw[32:])
+	for v := byte(0); v < 2; v++ {
+		sig.V = v
+		if got, err := RecoverAddress(digest, sig); err == nil && got == addr {
+			return sig, nil
+		}
+	}
+	return nil, ErrBadSignature
+}
+
+// SignPersonalMessage signs message as personal_sign does, over
+// PersonalMessageHash(message).
+func SignPersonalMessage(ctx context.Context, s Signer, message []byte) (*Signature, error) {
+	return SignDigest(ctx, s, PersonalMessageHash(message))
+}
+
+// SignTypedData signs EIP-712 typed data as eth_signTypedData_v4 does, over
+// td.Hash().
+func SignTypedData(ctx context.Context, s Signer, td *TypedData) (*Signature, error) {
+	if td == nil {
+		return nil, errors.New("nil typed data")
+	}
+	digest, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return SignDigest(ctx, s, digest)
+}
+
+// PersonalMessageHash returns the EIP-191 version 0x45 digest of message:
+// keccak256("\x19Ethereum Signed Message:\n" || len(message) || message),
+// with the length in decimal. Pass the message itself, not its hash.
+func PersonalMessageHash(message []byte) []byte {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
+	return Keccak256([]byte(prefix), message)
+}
+
+func checkCurve(c cbmpc.Curve, err error) error {
+	if err != nil {
+		return err
+	}
+	if c != cbmpc.CurveSecp256k1 {
+		return fmt.Errorf("ethereum keys must be secp256k1 (got %s)", c)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// Package jose signs JSON Web Signatures and JSON Web Tokens with MPC keys,
+// so an auth service's token-signing key never exists in one place.
+//
+// The signers pick the JWS algorithm from the key's curve and return
+// signatures in the encoding JWS requires (raw r || s for ECDSA rather than
+// the DER the protocols produce):
+//
+//   - ES256, ES384, ES512 and ES256K with ecdsa2p and ecdsamp keys on
+//     P-256, P-384, P-521 and secp256k1 (ECDSA2PSigner, ECDSAMPSigner).
+//   - EdDSA with schnorr2p and schnorrmp keys on Ed25519 (Schnorr2PSigner,
+//     SchnorrMPSigner).
+//
+// SignCompact and SignJWT produce compact tokens directly. SigningMethod
+// plugs a signer into github.com/golang-jwt/jwt/v5. Tokens verify with any
+// JOSE library; PublicJWK publishes the key in a JWKS document.
+//
+// # Usage Example
+//
+//	signer := &jose.ECDSA2PSigner{Job: job, Key: key}
+//	claims := map[string]any{"sub": "user-1", "iat": iat, "exp": iat + 3600}
+//	token, err := jose.SignJWT(ctx, signer, claims, kid)
+//	if err != nil {
+//	    return err
+//	}
+//	// On P1, which receives the signature, token is the signed JWT.
+//
+// With go-jose, implement its OpaqueSigner around a Signer: Public returns
+// the key from PublicKey, Algs returns Alg, and SignPayload calls Sign.
+//
+// # Security Considerations
+//
+//   - Every party signs whatever claims it is given. Check the subject,
+//     audience and lifetime on every party before signing.
+//   - Claims must encode identically on every party; fix timestamps and
+//     token IDs before signing.
+package jose
@@ -0,0 +1,241 @@
+package jose_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/integrations/jose"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// localSigner signs with an in-process key. Without receive set, it behaves
+// like a party that does not receive the signature.
+type localSigner struct {
+	key     crypto.Signer
+	receive bool
+}
+
+func (s localSigner) PublicKey() (crypto.PublicKey, error) { return s.key.Public(), nil }
+
+func (s localSigner) Alg() (string, error) {
+	if _, ok := s.key.(ed25519.PrivateKey); ok {
+		return jose.EdDSA, nil
+	}
+	return jose.ES256, nil
+}
+
+func (s localSigner) Sign(_ context.Context, input []byte) ([]byte, error) {
+	if !s.receive {
+		return nil, nil
+	}
+	switch k := s.key.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(k, input), nil
+	case *ecdsa.PrivateKey:
+		h := sha256.Sum256(input)
+		r, sig, err := ecdsa.Sign(rand.Reader, k, h[:])
+		if err != nil {
+			return nil, err
+		}
+		out := make([]byte, 64)
+		r.FillBytes(out[:32])
+		sig.FillBytes(out[32:])
+		return out, nil
+	}
+	return nil, errors.New("unsupported key")
+}
+
+func rfc8037Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	d, err := base64.RawURLEncoding.DecodeString("nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ed25519.NewKeyFromSeed(d)
+}
+
+// TestRFC8037 checks the Ed25519 signing and thumbprint examples of RFC 8037
+// appendix A.
+func TestRFC8037(t *testing.T) {
+	key := rfc8037Key(t)
+	jwk, err := jose.PublicJWK(key.Public(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jwk.X != "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo" {
+		t.Fatalf("x = %s", jwk.X)
+	}
+	if tp, err := jwk.Thumbprint(); err != nil || tp != "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k" {
+		t.Fatalf("Thumbprint() = %s, %v", tp, err)
+	}
+
+	token, err := jose.SignCompact(context.Background(), localSigner{key: key, receive: true}, []byte("Example of Ed25519 signing"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = "eyJhbGciOiJFZERTQSJ9.RXhhbXBsZSBvZiBFZDI1NTE5IHNpZ25pbmc." +
+		"hgyY0il_MGCjP0JzlnLWG1PPOt7-09PGcvMg3AIbQR6dWbhijcNR4ki4iylGjg5BhVsPt9g7sVvpAr_MuM0KAg"
+	if token != want {
+		t.Fatalf("token = %s\nwant   %s", token, want)
+	}
+}
+
+func TestSignJWT(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := map[string]any{"sub": "user-1", "iat": 1700000000, "exp": 1700003600}
+	token, err := jose.SignJWT(ctx, localSigner{key: key, receive: true}, claims, "k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(header) != `{"alg":"ES256","kid":"k1","typ":"JWT"}` {
+		t.Fatalf("header = %s", header)
+	}
+	payload, err := jose.Verify(token, &key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(payload, &got); err != nil || got["sub"] != "user-1" {
+		t.Fatalf("payload = %s, %v", payload, err)
+	}
+
+	if token, err := jose.SignJWT(ctx, localSigner{key: key}, claims, ""); token != "" || err != nil {
+		t.Fatalf("SignJWT on non-receiver = %q, %v", token, err)
+	}
+	if _, err := jose.SignCompact(ctx, localSigner{key: key, receive: true}, nil, &jose.Header{Extra: map[string]any{"alg": "none"}}); err == nil {
+		t.Fatal(`expected error for a header setting "alg"`)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	ctx := context.Background()
+	key := rfc8037Key(t)
+	pub := key.Public()
+	token, err := jose.SignCompact(ctx, localSigner{key: key, receive: true}, []byte("payload"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(token, ".")
+
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	if _, err := jose.Verify(none+"."+parts[1]+".", pub); err == nil {
+		t.Fatal(`expected error for alg "none"`)
+	}
+	tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte("other")) + "." + parts[2]
+	if _, err := jose.Verify(tampered, pub); !errors.Is(err, jose.ErrInvalidSignature) {
+		t.Fatalf("tampered payload error = %v", err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jose.Verify(token, &other.PublicKey); err == nil {
+		t.Fatal("expected error for a key of another algorithm")
+	}
+}
+
+func TestSigningMethod(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := jose.NewSigningMethod(ctx, localSigner{key: key, receive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Alg() != jose.ES256 {
+		t.Fatalf("Alg() = %s", m.Alg())
+	}
+	sig, err := m.Sign("header.payload", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Verify("header.payload", sig, &key.PublicKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Verify("header.other", sig, nil); !errors.Is(err, jose.ErrInvalidSignature) {
+		t.Fatalf("Verify of other input error = %v", err)
+	}
+
+	nr, err := jose.NewSigningMethod(ctx, localSigner{key: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nr.Sign("header.payload", nil); !errors.Is(err, jose.ErrNoSignature) {
+		t.Fatalf("Sign on non-receiver error = %v", err)
+	}
+}
+
+func TestECDSA2PSigner(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	names := [2]string{"p1", "p2"}
+	roles := [2]cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2}
+
+	var keys [2]*ecdsa2p.Key
+	var tokens [2]string
+	var errs [2]error
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), roles[i], names)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP384})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			keys[i] = res.Key
+			signer := &jose.ECDSA2PSigner{Job: job, Key: res.Key}
+			tokens[i], errs[i] = jose.SignJWT(ctx, signer, map[string]any{"sub": "svc"}, "")
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d failed: %v", i, err)
+		}
+		defer func() { _ = keys[i].Close() }()
+	}
+	pub, err := keys[0].ECDSAPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jose.Verify(tokens[0], pub); err != nil {
+		t.Fatal(err)
+	}
+	if tokens[1] != "" {
+		t.Fatal("P2 received a token")
+	}
+}
@@ -0,0 +1,75 @@
+package jose
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+)
+
+// JWK is a public JSON Web Key (RFC 7517), for publishing MPC keys in a JWKS
+// document.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+}
+
+// PublicJWK returns the JWK of pub, an *ecdsa.PublicKey or
+// ed25519.PublicKey, with "alg" set and "use" set to "sig".
+func PublicJWK(pub crypto.PublicKey, kid string) (*JWK, error) {
+	alg, err := algForKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	jwk := &JWK{Kid: kid, Alg: alg, Use: "sig"}
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		params := pub.Curve.Params()
+		size := (params.BitSize + 7) / 8
+		x, y := make([]byte, size), make([]byte, size)
+		pub.X.FillBytes(x)
+		pub.Y.FillBytes(y)
+		jwk.Kty, jwk.Crv = "EC", params.Name
+		jwk.X, jwk.Y = b64.EncodeToString(x), b64.EncodeToString(y)
+	case ed25519.PublicKey:
+		jwk.Kty, jwk.Crv = "OKP", "Ed25519"
+		jwk.X = b64.EncodeToString(pub)
+	}
+	return jwk, nil
+}
+
+// Thumbprint returns the RFC 7638 SHA-256 thumbprint of the key, base64url
+// encoded. It is a stable choice for "kid".
+func (j *JWK) Thumbprint() (string, error) {
+	var members any
+	switch j.Kty {
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{j.Crv, j.Kty, j.X, j.Y}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{j.Crv, j.Kty, j.X}
+	default:
+		return "", fmt.Errorf("unsupported key type %q", j.Kty)
+	}
+	raw, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return b64.EncodeToString(sum[:]), nil
+}
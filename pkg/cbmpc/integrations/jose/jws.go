@@ -0,0 +1,179 @@
+package jose
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidSignature is returned by Verify when a token's signature does not
+// verify under the given key.
+var ErrInvalidSignature = errors.New("jose: invalid signature")
+
+var b64 = base64.RawURLEncoding
+
+// Header holds the JWS protected header fields other than "alg", which is
+// always set from the signer.
+type Header struct {
+	KeyID       string         // "kid"
+	Type        string         // "typ", e.g. "JWT"
+	ContentType string         // "cty"
+	Extra       map[string]any // Other header parameters; must not set "alg"
+}
+
+func (h *Header) encode(alg string) (string, error) {
+	fields := map[string]any{}
+	for k, v := range h.Extra {
+		fields[k] = v
+	}
+	if _, ok := fields["alg"]; ok {
+		return "", errors.New(`header must not set "alg"`)
+	}
+	fields["alg"] = alg
+	for k, v := range map[string]string{"kid": h.KeyID, "typ": h.Type, "cty": h.ContentType} {
+		if v != "" {
+			fields[k] = v
+		}
+	}
+	// encoding/json sorts map keys, so every party encodes the same header.
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return b64.EncodeToString(raw), nil
+}
+
+// SignCompact signs payload and returns the JWS in compact serialization
+// (RFC 7515). header may be nil. Every party must call it with the same
+// payload and header.
+//
+// Parties that do not receive the signature get an empty string and no
+// error.
+func SignCompact(ctx context.Context, s Signer, payload []byte, header *Header) (string, error) {
+	if s == nil {
+		return "", errors.New("nil signer")
+	}
+	if header == nil {
+		header = &Header{}
+	}
+	alg, err := s.Alg()
+	if err != nil {
+		return "", err
+	}
+	h, err := header.encode(alg)
+	if err != nil {
+		return "", err
+	}
+	signingInput := h + "." + b64.EncodeToString(payload)
+	sig, err := s.Sign(ctx, []byte(signingInput))
+	if err != nil || sig == nil {
+		return "", err
+	}
+	return signingInput + "." + b64.EncodeToString(sig), nil
+}
+
+// SignJWT encodes claims as JSON and signs them as a JWT with "typ" set to
+// "JWT" and "kid" set to keyID, if not empty. Every party must call it with
+// claims that encode identically: fix "iat", "exp" and "jti" before signing
+// rather than letting each party fill them in.
+//
+// Parties that do not receive the signature get an empty string and no
+// error.
+func SignJWT(ctx context.Context, s Signer, claims any, keyID string) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	return SignCompact(ctx, s, payload, &Header{KeyID: keyID, Type: "JWT"})
+}
+
+// Verify checks a compact JWS against pub and returns its payload. The
+// header's "alg" must be the algorithm of pub; tokens with any other
+// algorithm, including "none", are rejected.
+func Verify(token string, pub crypto.PublicKey) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token must have three parts")
+	}
+	rawHeader, err := b64.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	payload, err := b64.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("payload: %w", err)
+	}
+	sig, err := b64.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	if err := verify(header.Alg, pub, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// verify checks a JWS signature made with alg over signingInput.
+func verify(alg string, pub crypto.PublicKey, signingInput, sig []byte) error {
+	want, err := algForKey(pub)
+	if err != nil {
+		return err
+	}
+	if alg != want {
+		return fmt.Errorf("algorithm %q does not match %s key", alg, want)
+	}
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().N.BitLen() + 7) / 8
+		if len(sig) != 2*size {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest(alg, signingInput), r, s) {
+			return ErrInvalidSignature
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pub, signingInput, sig) {
+			return ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+// algForKey returns the JWS algorithm for a public key.
+func algForKey(pub crypto.PublicKey) (string, error) {
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		switch pub.Curve.Params().Name {
+		case "P-256":
+			return ES256, nil
+		case "P-384":
+			return ES384, nil
+		case "P-521":
+			return ES512, nil
+		case "secp256k1":
+			return ES256K, nil
+		}
+		return "", fmt.Errorf("unsupported curve %s", pub.Curve.Params().Name)
+	case ed25519.PublicKey:
+		if len(pub) != ed25519.PublicKeySize {
+			return "", errors.New("invalid Ed25519 public key")
+		}
+		return EdDSA, nil
+	}
+	return "", fmt.Errorf("unsupported public key type %T", pub)
+}
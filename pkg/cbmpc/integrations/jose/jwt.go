@@ -0,0 +1,66 @@
+package jose
+
+import (
+	"context"
+	"crypto"
+	"errors"
+)
+
+// SigningMethod signs with an MPC key through the signing method interface
+// of github.com/golang-jwt/jwt/v5:
+//
+//	method, err := jose.NewSigningMethod(ctx, signer)
+//	token := jwt.NewWithClaims(method, claims)
+//	signed, err := token.SignedString(nil)
+//
+// The key argument of Sign is ignored. MPC signatures are ordinary ES256,
+// ES384, ES512 and EdDSA signatures, so verifiers use the library's built-in
+// methods with the public key; only ES256K needs this type to verify.
+type SigningMethod struct {
+	ctx context.Context
+	s   Signer
+	alg string
+	pub crypto.PublicKey
+}
+
+// NewSigningMethod returns a SigningMethod for s. crypto and JWT libraries
+// have no context argument, so ctx is used for every signature.
+func NewSigningMethod(ctx context.Context, s Signer) (*SigningMethod, error) {
+	if s == nil {
+		return nil, errors.New("nil signer")
+	}
+	alg, err := s.Alg()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := s.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return &SigningMethod{ctx: ctx, s: s, alg: alg, pub: pub}, nil
+}
+
+// Alg returns the JWS "alg" value.
+func (m *SigningMethod) Alg() string { return m.alg }
+
+// Sign signs signingString and returns the raw JWS signature. Parties that
+// do not receive the signature get ErrNoSignature.
+func (m *SigningMethod) Sign(signingString string, _ any) ([]byte, error) {
+	sig, err := m.s.Sign(m.ctx, []byte(signingString))
+	if err != nil {
+		return nil, err
+	}
+	if sig == nil {
+		return nil, ErrNoSignature
+	}
+	return sig, nil
+}
+
+// Verify checks sig over signingString against key, or against the MPC
+// public key if key is nil.
+func (m *SigningMethod) Verify(signingString string, sig []byte, key any) error {
+	if key == nil {
+		key = m.pub
+	}
+	return verify(m.alg, key, []byte(signingString), sig)
+}
@@ -0,0 +1,212 @@
+package jose
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorr2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorrmp"
+)
+
+// JWS algorithms (RFC 7518, RFC 8037 and RFC 8812) produced by the signers.
+const (
+	ES256  = "ES256"
+	ES384  = "ES384"
+	ES512  = "ES512"
+	ES256K = "ES256K"
+	EdDSA  = "EdDSA"
+)
+
+// ErrNoSignature is returned when a party takes part in signing but does not
+// receive the signature.
+var ErrNoSignature = errors.New("jose: party does not receive the signature")
+
+// Signer produces JWS signatures with an MPC key.
+type Signer interface {
+	// PublicKey returns the key as an *ecdsa.PublicKey or ed25519.PublicKey.
+	PublicKey() (crypto.PublicKey, error)
+
+	// Alg returns the JWS "alg" value for the key.
+	Alg() (string, error)
+
+	// Sign signs the JWS signing input (the encoded header and payload joined
+	// by a dot) and returns the JWS signature: raw r || s for ECDSA, R || S
+	// for EdDSA. It returns a nil signature, and no error, on parties that
+	// take part in signing but do not receive the signature.
+	Sign(ctx context.Context, signingInput []byte) ([]byte, error)
+}
+
+// ECDSA2PSigner signs with a 2-party ECDSA key. Only P1 receives signatures.
+type ECDSA2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *ecdsa2p.Key
+}
+
+// PublicKey implements Signer.
+func (s *ECDSA2PSigner) PublicKey() (crypto.PublicKey, error) {
+	if _, err := s.Alg(); err != nil {
+		return nil, err
+	}
+	return s.Key.ECDSAPublicKey()
+}
+
+// Alg implements Signer.
+func (s *ECDSA2PSigner) Alg() (string, error) { return algForCurve(s.Key.Curve()) }
+
+// Sign implements Signer.
+func (s *ECDSA2PSigner) Sign(ctx context.Context, signingInput []byte) ([]byte, error) {
+	alg, err := s.Alg()
+	if err != nil {
+		return nil, err
+	}
+	res, err := ecdsa2p.Sign(ctx, s.Job, &ecdsa2p.SignParams{
+		Key:     s.Key,
+		Message: digest(alg, signingInput),
+		Format:  ecdsa2p.SigFormatRaw,
+	})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// ECDSAMPSigner signs with a multi-party ECDSA key. Only the party at index
+// SigReceiver receives signatures.
+type ECDSAMPSigner struct {
+	Job         *cbmpc.JobMP
+	Key         *ecdsamp.Key
+	SigReceiver int
+}
+
+// PublicKey implements Signer.
+func (s *ECDSAMPSigner) PublicKey() (crypto.PublicKey, error) {
+	if _, err := s.Alg(); err != nil {
+		return nil, err
+	}
+	return s.Key.ECDSAPublicKey()
+}
+
+// Alg implements Signer.
+func (s *ECDSAMPSigner) Alg() (string, error) { return algForCurve(s.Key.Curve()) }
+
+// Sign implements Signer.
+func (s *ECDSAMPSigner) Sign(ctx context.Context, signingInput []byte) ([]byte, error) {
+	alg, err := s.Alg()
+	if err != nil {
+		return nil, err
+	}
+	res, err := ecdsamp.Sign(ctx, s.Job, &ecdsamp.SignParams{
+		Key:         s.Key,
+		Message:     digest(alg, signingInput),
+		SigReceiver: s.SigReceiver,
+	})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	curve, err := s.Key.Curve()
+	if err != nil {
+		return nil, err
+	}
+	return ecdsa2p.SignatureToRaw(curve, res.Signature)
+}
+
+// Schnorr2PSigner signs with a 2-party EdDSA key.
+type Schnorr2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *schnorr2p.Key
+}
+
+// PublicKey implements Signer.
+func (s *Schnorr2PSigner) PublicKey() (crypto.PublicKey, error) {
+	if _, err := s.Alg(); err != nil {
+		return nil, err
+	}
+	return s.Key.Ed25519PublicKey()
+}
+
+// Alg implements Signer.
+func (s *Schnorr2PSigner) Alg() (string, error) { return algForCurve(s.Key.Curve()) }
+
+// Sign implements Signer.
+func (s *Schnorr2PSigner) Sign(ctx context.Context, signingInput []byte) ([]byte, error) {
+	res, err := schnorr2p.Sign(ctx, s.Job, &schnorr2p.SignParams{Key: s.Key, Message: signingInput, Variant: schnorr2p.VariantEdDSA})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// SchnorrMPSigner signs with a multi-party EdDSA key. Only the party at
+// index SigReceiver receives signatures.
+type SchnorrMPSigner struct {
+	Job         *cbmpc.JobMP
+	Key         *schnorrmp.Key
+	SigReceiver int
+}
+
+// PublicKey implements Signer.
+func (s *SchnorrMPSigner) PublicKey() (crypto.PublicKey, error) {
+	if _, err := s.Alg(); err != nil {
+		return nil, err
+	}
+	return s.Key.Ed25519PublicKey()
+}
+
+// Alg implements Signer.
+func (s *SchnorrMPSigner) Alg() (string, error) { return algForCurve(s.Key.Curve()) }
+
+// Sign implements Signer.
+func (s *SchnorrMPSigner) Sign(ctx context.Context, signingInput []byte) ([]byte, error) {
+	res, err := schnorrmp.Sign(ctx, s.Job, &schnorrmp.SignParams{
+		Key:         s.Key,
+		Message:     signingInput,
+		SigReceiver: s.SigReceiver,
+		Variant:     schnorrmp.VariantEdDSA,
+	})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// algForCurve maps a key's curve to its JWS algorithm.
+func algForCurve(c cbmpc.Curve, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	switch c {
+	case cbmpc.CurveP256:
+		return ES256, nil
+	case cbmpc.CurveP384:
+		return ES384, nil
+	case cbmpc.CurveP521:
+		return ES512, nil
+	case cbmpc.CurveSecp256k1:
+		return ES256K, nil
+	case cbmpc.CurveEd25519:
+		return EdDSA, nil
+	}
+	return "", fmt.Errorf("no JWS algorithm for %s keys", c)
+}
+
+// digest hashes the signing input with the hash of an ECDSA algorithm.
+func digest(alg string, signingInput []byte) []byte {
+	switch alg {
+	case ES384:
+		h := sha512.Sum384(signingInput)
+		return h[:]
+	case ES512:
+		h := sha512.Sum512(signingInput)
+		return h[:]
+	default:
+		h := sha256.Sum256(signingInput)
+		return h[:]
+	}
+}
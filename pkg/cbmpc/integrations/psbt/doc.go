@@ -0,0 +1,45 @@
+// Package psbt signs Bitcoin PSBTs (BIP 174) with MPC keys on secp256k1.
+//
+// Sign finds the inputs a key controls, computes each input's signature hash
+// from the packet, runs one MPC signing protocol per input and writes the
+// final witness, so callers never compute or pass raw sighashes:
+//
+//   - P2WPKH inputs are signed with an ecdsa2p key (ECDSA2PSigner) over the
+//     BIP 143 sighash. Signatures are re-encoded with low S.
+//   - P2TR key-path inputs are signed with a schnorr2p or schnorrmp BIP 340
+//     key (Schnorr2PSigner, SchnorrMPSigner) over the BIP 341 sighash.
+//
+// The input's PSBT_IN_SIGHASH_TYPE is honored; without it, P2WPKH inputs use
+// SIGHASH_ALL and P2TR inputs SIGHASH_DEFAULT.
+//
+// # Usage Example
+//
+//	p, err := psbt.ParseBase64(unsigned)
+//	if err != nil {
+//	    return err
+//	}
+//	signer := &psbt.ECDSA2PSigner{Job: job, Key: key}
+//	if _, err := psbt.Sign(ctx, p, signer); err != nil {
+//	    return err
+//	}
+//	// On P1, which receives the signatures:
+//	tx, err := p.Extract()
+//	raw := tx.Serialize()
+//
+// # Limitations
+//
+//   - Taproot outputs must use the MPC key itself as the output key
+//     (P2TRScript); BIP 86 and script-path tweaks are not applied, since the
+//     MPC protocols cannot sign for a tweaked key.
+//   - Only version 0 PSBTs are supported. Other script types (P2PKH, P2SH,
+//     P2WSH) are left for other signers and never touched.
+//
+// # Security Considerations
+//
+//   - Sighashes commit to amounts from the UTXO fields of the packet. Check
+//     the outputs and fee before signing; a P2WPKH input described only by a
+//     witness UTXO can misstate its amount (include the non-witness UTXO
+//     from a trusted source to rule that out).
+//   - Every party signs whatever packet it is given; all parties should
+//     validate it independently.
+package psbt
@@ -0,0 +1,341 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var magic = []byte("psbt\xff")
+
+// Key types from BIP 174 and BIP 371 that this package interprets. All other
+// key-value pairs are kept as Unknowns and written back unchanged.
+const (
+	globalUnsignedTx = 0x00
+	globalVersion    = 0xfb
+
+	inNonWitnessUTXO     = 0x00
+	inWitnessUTXO        = 0x01
+	inPartialSig         = 0x02
+	inSighashType        = 0x03
+	inRedeemScript       = 0x04
+	inWitnessScript      = 0x05
+	inBIP32Derivation    = 0x06
+	inFinalScriptSig     = 0x07
+	inFinalScriptWitness = 0x08
+	inTapKeySig          = 0x13
+	inTapScriptSig       = 0x14
+	inTapLeafScript      = 0x15
+	inTapBIP32Derivation = 0x16
+	inTapInternalKey     = 0x17
+	inTapMerkleRoot      = 0x18
+)
+
+// Unknown is a key-value pair this package does not interpret.
+type Unknown struct {
+	Key   []byte
+	Value []byte
+}
+
+// PartialSig is an ECDSA signature (DER plus sighash byte) by PubKey.
+type PartialSig struct {
+	PubKey    []byte
+	Signature []byte
+}
+
+// Input holds the per-input fields of a PSBT.
+type Input struct {
+	NonWitnessUTXO     *Tx
+	WitnessUTXO        *TxOut
+	PartialSigs        []PartialSig
+	SighashType        *SigHashType
+	FinalScriptSig     []byte
+	FinalScriptWitness [][]byte
+	TapKeySig          []byte
+	TapInternalKey     []byte
+	TapMerkleRoot      []byte
+	Unknowns           []Unknown
+}
+
+// Finalized reports whether the input has its final scriptSig or witness.
+func (in *Input) Finalized() bool {
+	return in.FinalScriptSig != nil || in.FinalScriptWitness != nil
+}
+
+// Output holds the per-output fields of a PSBT. This package does not
+// interpret any of them.
+type Output struct {
+	Unknowns []Unknown
+}
+
+// Packet is a version 0 partially signed Bitcoin transaction (BIP 174).
+type Packet struct {
+	UnsignedTx *Tx
+	Inputs     []*Input
+	Outputs    []*Output
+	Unknowns   []Unknown
+}
+
+// Parse decodes a binary PSBT.
+func Parse(data []byte) (*Packet, error) {
+	if !bytes.HasPrefix(data, magic) {
+		return nil, fmt.Errorf("%w: missing magic bytes", ErrMalformed)
+	}
+	r := &reader{b: data[len(magic):]}
+	p := &Packet{}
+
+	global, err := r.kvMap()
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range global {
+		switch {
+		case len(kv.Key) == 1 && kv.Key[0] == globalUnsignedTx:
+			if p.UnsignedTx, err = ParseTx(kv.Value); err != nil {
+				return nil, err
+			}
+		case len(kv.Key) == 1 && kv.Key[0] == globalVersion:
+			if len(kv.Value) != 4 || binary.LittleEndian.Uint32(kv.Value) != 0 {
+				return nil, errors.New("psbt: only version 0 is supported")
+			}
+			p.Unknowns = append(p.Unknowns, kv)
+		default:
+			p.Unknowns = append(p.Unknowns, kv)
+		}
+	}
+	if p.UnsignedTx == nil {
+		return nil, fmt.Errorf("%w: missing unsigned transaction", ErrMalformed)
+	}
+	for _, in := range p.UnsignedTx.TxIn {
+		if len(in.SignatureScript) != 0 || len(in.Witness) != 0 {
+			return nil, fmt.Errorf("%w: unsigned transaction has signatures", ErrMalformed)
+		}
+	}
+
+	for range p.UnsignedTx.TxIn {
+		kvs, err := r.kvMap()
+		if err != nil {
+			return nil, err
+		}
+		in, err := parseInput(kvs)
+		if err != nil {
+			return nil, err
+		}
+		p.Inputs = append(p.Inputs, in)
+	}
+	for range p.UnsignedTx.TxOut {
+		kvs, err := r.kvMap()
+		if err != nil {
+			return nil, err
+		}
+		p.Outputs = append(p.Outputs, &Output{Unknowns: kvs})
+	}
+	if len(r.b) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrMalformed, len(r.b))
+	}
+	return p, nil
+}
+
+// ParseBase64 decodes a base64 PSBT, the form wallets and bitcoind exchange.
+func ParseBase64(s string) (*Packet, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	return Parse(data)
+}
+
+func parseInput(kvs []Unknown) (*Input, error) {
+	in := &Input{}
+	for _, kv := range kvs {
+		typ, keyData := kv.Key[0], kv.Key[1:]
+		single := len(keyData) == 0
+		var err error
+		switch {
+		case typ == inNonWitnessUTXO && single:
+			in.NonWitnessUTXO, err = ParseTx(kv.Value)
+		case typ == inWitnessUTXO && single:
+			r := &reader{b: kv.Value}
+			var v []byte
+			if v, err = r.take(8); err == nil {
+				out := &TxOut{Value: int64(binary.LittleEndian.Uint64(v))}
+				if out.PkScript, err = r.varBytes(); err == nil && len(r.b) != 0 {
+					err = fmt.Errorf("%w: trailing bytes in witness UTXO", ErrMalformed)
+				}
+				in.WitnessUTXO = out
+			}
+		case typ == inPartialSig:
+			in.PartialSigs = append(in.PartialSigs, PartialSig{PubKey: keyData, Signature: kv.Value})
+		case typ == inSighashType && single:
+			if len(kv.Value) != 4 {
+				err = fmt.Errorf("%w: sighash type must be 4 bytes", ErrMalformed)
+				break
+			}
+			st := SigHashType(binary.LittleEndian.Uint32(kv.Value))
+			in.SighashType = &st
+		case typ == inFinalScriptSig && single:
+			in.FinalScriptSig = kv.Value
+		case typ == inFinalScriptWitness && single:
+			r := &reader{b: kv.Value}
+			var n int
+			if n, err = r.count(1); err == nil {
+				in.FinalScriptWitness = [][]byte{}
+				for i := 0; i < n && err == nil; i++ {
+					var item []byte
+					item, err = r.varBytes()
+					in.FinalScriptWitness = append(in.FinalScriptWitness, item)
+				}
+				if err == nil && len(r.b) != 0 {
+					err = fmt.Errorf("%w: trailing bytes in final witness", ErrMalformed)
+				}
+			}
+		case typ == inTapKeySig && single:
+			in.TapKeySig = kv.Value
+		case typ == inTapInternalKey && single:
+			in.TapInternalKey = kv.Value
+		case typ == inTapMerkleRoot && single:
+			in.TapMerkleRoot = kv.Value
+		default:
+			in.Unknowns = append(in.Unknowns, kv)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return in, nil
+}
+
+// Serialize encodes the packet in binary form.
+func (p *Packet) Serialize() ([]byte, error) {
+	if p.UnsignedTx == nil {
+		return nil, errors.New("psbt: missing unsigned transaction")
+	}
+	if len(p.Inputs) != len(p.UnsignedTx.TxIn) || len(p.Outputs) != len(p.UnsignedTx.TxOut) {
+		return nil, errors.New("psbt: input or output count does not match the transaction")
+	}
+	b := append([]byte{}, magic...)
+	b = appendKV(b, []byte{globalUnsignedTx}, p.UnsignedTx.serialize(false))
+	b = appendUnknowns(b, p.Unknowns)
+	b = append(b, 0x00)
+
+	for _, in := range p.Inputs {
+		if in.NonWitnessUTXO != nil {
+			b = appendKV(b, []byte{inNonWitnessUTXO}, in.NonWitnessUTXO.Serialize())
+		}
+		if in.WitnessUTXO != nil {
+			b = appendKV(b, []byte{inWitnessUTXO}, appendTxOut(nil, in.WitnessUTXO))
+		}
+		for _, ps := range in.PartialSigs {
+			b = appendKV(b, append([]byte{inPartialSig}, ps.PubKey...), ps.Signature)
+		}
+		if in.SighashType != nil {
+			b = appendKV(b, []byte{inSighashType}, binary.LittleEndian.AppendUint32(nil, uint32(*in.SighashType)))
+		}
+		if in.FinalScriptSig != nil {
+			b = appendKV(b, []byte{inFinalScriptSig}, in.FinalScriptSig)
+		}
+		if in.FinalScriptWitness != nil {
+			b = appendKV(b, []byte{inFinalScriptWitness}, appendWitness(nil, in.FinalScriptWitness))
+		}
+		if in.TapKeySig != nil {
+			b = appendKV(b, []byte{inTapKeySig}, in.TapKeySig)
+		}
+		if in.TapInternalKey != nil {
+			b = appendKV(b, []byte{inTapInternalKey}, in.TapInternalKey)
+		}
+		if in.TapMerkleRoot != nil {
+			b = appendKV(b, []byte{inTapMerkleRoot}, in.TapMerkleRoot)
+		}
+		b = appendUnknowns(b, in.Unknowns)
+		b = append(b, 0x00)
+	}
+	for _, out := range p.Outputs {
+		b = appendUnknowns(b, out.Unknowns)
+		b = append(b, 0x00)
+	}
+	return b, nil
+}
+
+// Base64 encodes the packet in base64.
+func (p *Packet) Base64() (string, error) {
+	b, err := p.Serialize()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Extract returns the signed transaction; broadcast tx.Serialize(). Every
+// input must be finalized.
+func (p *Packet) Extract() (*Tx, error) {
+	tx := &Tx{Version: p.UnsignedTx.Version, LockTime: p.UnsignedTx.LockTime, TxOut: p.UnsignedTx.TxOut}
+	for i, in := range p.Inputs {
+		if !in.Finalized() {
+			return nil, fmt.Errorf("psbt: input %d is not finalized", i)
+		}
+		txIn := *p.UnsignedTx.TxIn[i]
+		txIn.SignatureScript = in.FinalScriptSig
+		txIn.Witness = in.FinalScriptWitness
+		tx.TxIn = append(tx.TxIn, &txIn)
+	}
+	return tx, nil
+}
+
+// finalize sets the final witness of input i and clears the fields a
+// finalizer must remove (BIP 174), keeping UTXOs and unrelated unknowns.
+func (p *Packet) finalize(i int, witness [][]byte) {
+	in := p.Inputs[i]
+	in.FinalScriptWitness = witness
+	in.PartialSigs = nil
+	in.SighashType = nil
+	in.TapKeySig = nil
+	in.TapInternalKey = nil
+	in.TapMerkleRoot = nil
+	kept := in.Unknowns[:0]
+	for _, kv := range in.Unknowns {
+		switch kv.Key[0] {
+		case inRedeemScript, inWitnessScript, inBIP32Derivation, inTapScriptSig, inTapLeafScript, inTapBIP32Derivation:
+		default:
+			kept = append(kept, kv)
+		}
+	}
+	in.Unknowns = kept
+}
+
+func appendKV(b, key, value []byte) []byte {
+	return appendVarBytes(appendVarBytes(b, key), value)
+}
+
+func appendUnknowns(b []byte, kvs []Unknown) []byte {
+	for _, kv := range kvs {
+		b = appendKV(b, kv.Key, kv.Value)
+	}
+	return b
+}
+
+// kvMap reads one key-value map up to its 0x00 separator, rejecting
+// duplicate keys.
+func (r *reader) kvMap() ([]Unknown, error) {
+	var kvs []Unknown
+	seen := map[string]bool{}
+	for {
+		key, err := r.varBytes()
+		if err != nil {
+			return nil, err
+		}
+		if len(key) == 0 {
+			return kvs, nil
+		}
+		if seen[string(key)] {
+			return nil, fmt.Errorf("%w: duplicate key %x", ErrMalformed, key)
+		}
+		seen[string(key)] = true
+		value, err := r.varBytes()
+		if err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, Unknown{Key: key, Value: value})
+	}
+}
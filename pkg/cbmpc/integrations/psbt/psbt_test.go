@@ -0,0 +1,271 @@
+package psbt_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	btcschnorr "github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/integrations/psbt"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// localSigner signs with an in-process key and records the hashes it signed.
+type localSigner struct {
+	key    *btcec.PrivateKey
+	scheme psbt.Scheme
+	hashes [][]byte
+}
+
+func (s *localSigner) PublicKey() ([]byte, error) { return s.key.PubKey().SerializeCompressed(), nil }
+func (s *localSigner) Scheme() psbt.Scheme        { return s.scheme }
+
+func (s *localSigner) SignHash(_ context.Context, hash []byte) ([]byte, error) {
+	s.hashes = append(s.hashes, hash)
+	if s.scheme == psbt.SchemeSchnorr {
+		sig, err := btcschnorr.Sign(s.key, hash)
+		if err != nil {
+			return nil, err
+		}
+		return sig.Serialize(), nil
+	}
+	return btcecdsa.Sign(s.key, hash).Serialize(), nil
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// newPacket wraps tx in a packet with empty input and output maps.
+func newPacket(tx *psbt.Tx) *psbt.Packet {
+	p := &psbt.Packet{UnsignedTx: tx}
+	for range tx.TxIn {
+		p.Inputs = append(p.Inputs, &psbt.Input{})
+	}
+	for range tx.TxOut {
+		p.Outputs = append(p.Outputs, &psbt.Output{})
+	}
+	return p
+}
+
+// TestSignP2WPKHBIP143 uses the native P2WPKH example from BIP 143.
+func TestSignP2WPKHBIP143(t *testing.T) {
+	tx, err := psbt.ParseTx(mustHex(t, "0100000002fff7f7881a8099afa6940d42d1e7f6362bec38171ea3edf433541db4e4ad969f0000000000eeffffffef51e1b804cc89d182d279655c3aa89e815b1b309fe287d9b2b55d57b90ec68a0100000000ffffffff02202cb206000000001976a9148280b37df378db99f66f85c95a783a76ac7a6d5988ac9093510d000000001976a9143bde42dbee7e4dbe6a21b2d50ce2f0167faa815988ac11000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newPacket(tx)
+	p.Inputs[1].WitnessUTXO = &psbt.TxOut{Value: 600000000, PkScript: mustHex(t, "00141d0f172a0ecb48aee1be1f2687d2963ae33f71a1")}
+
+	key, _ := btcec.PrivKeyFromBytes(mustHex(t, "619c335025c7f4012e556c2a58b2506e30b8511b53ade95ea316fd8c3286feb9"))
+	s := &localSigner{key: key, scheme: psbt.SchemeECDSA}
+	signed, err := psbt.Sign(context.Background(), p, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signed) != 1 || signed[0] != 1 {
+		t.Fatalf("signed inputs %v, want [1]", signed)
+	}
+	if got, want := hex.EncodeToString(s.hashes[0]), "c37af31116d1b27caf68aae9e3ac82f1477929014d5b917657d0eb49478cb670"; got != want {
+		t.Fatalf("sighash %s, want %s", got, want)
+	}
+
+	w := p.Inputs[1].FinalScriptWitness
+	if len(w) != 2 || w[0][len(w[0])-1] != byte(psbt.SigHashAll) {
+		t.Fatalf("unexpected witness %x", w)
+	}
+	if !bytes.Equal(w[1], key.PubKey().SerializeCompressed()) {
+		t.Fatal("witness does not carry the public key")
+	}
+	if _, err := p.Extract(); err == nil {
+		t.Fatal("extracted a transaction with an unfinalized input")
+	}
+}
+
+func TestSignTaproot(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := psbt.P2TRScript(key.PubKey().SerializeCompressed()[1:])
+	tx := &psbt.Tx{
+		Version: 2,
+		TxIn: []*psbt.TxIn{
+			{PreviousOutPoint: psbt.OutPoint{Hash: [32]byte{1}, Index: 0}, Sequence: 0xfffffffd},
+			{PreviousOutPoint: psbt.OutPoint{Hash: [32]byte{2}, Index: 3}, Sequence: 0xfffffffd},
+		},
+		TxOut: []*psbt.TxOut{{Value: 15000, PkScript: script}},
+	}
+	p := newPacket(tx)
+	p.Inputs[0].WitnessUTXO = &psbt.TxOut{Value: 10000, PkScript: script}
+	p.Inputs[1].WitnessUTXO = &psbt.TxOut{Value: 6000, PkScript: script}
+	all := psbt.SigHashAll
+	p.Inputs[1].SighashType = &all
+
+	hashes := make([][]byte, 2)
+	for i := range hashes {
+		if hashes[i], err = p.SigHash(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if bytes.Equal(hashes[0], hashes[1]) {
+		t.Fatal("inputs share a sighash")
+	}
+
+	s := &localSigner{key: key, scheme: psbt.SchemeSchnorr}
+	signed, err := psbt.Sign(context.Background(), p, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signed) != 2 {
+		t.Fatalf("signed inputs %v, want both", signed)
+	}
+	for i, in := range p.Inputs {
+		if len(in.FinalScriptWitness) != 1 {
+			t.Fatalf("input %d witness %x", i, in.FinalScriptWitness)
+		}
+		sig := in.FinalScriptWitness[0]
+		if want := 64 + i; len(sig) != want {
+			t.Fatalf("input %d signature is %d bytes, want %d", i, len(sig), want)
+		}
+		parsed, err := btcschnorr.ParseSignature(sig[:64])
+		if err != nil || !parsed.Verify(hashes[i], key.PubKey()) {
+			t.Fatalf("input %d signature does not verify", i)
+		}
+		if in.SighashType != nil {
+			t.Fatalf("input %d not cleared after finalizing", i)
+		}
+	}
+
+	signedTx, err := p.Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := psbt.ParseTx(signedTx.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.TxID() != tx.TxID() {
+		t.Fatal("witness changed the transaction ID")
+	}
+
+	// Signing again skips finalized inputs.
+	if signed, err := psbt.Sign(context.Background(), p, s); err != nil || len(signed) != 0 {
+		t.Fatalf("re-sign = %v, %v", signed, err)
+	}
+}
+
+func TestPacketRoundTrip(t *testing.T) {
+	tx := &psbt.Tx{
+		Version: 2,
+		TxIn:    []*psbt.TxIn{{PreviousOutPoint: psbt.OutPoint{Hash: [32]byte{7}}, Sequence: 0xffffffff}},
+		TxOut:   []*psbt.TxOut{{Value: 1, PkScript: []byte{0x6a}}},
+	}
+	p := newPacket(tx)
+	p.Unknowns = []psbt.Unknown{{Key: []byte{0xfc, 0x01}, Value: []byte("proprietary")}}
+	p.Inputs[0].WitnessUTXO = &psbt.TxOut{Value: 2, PkScript: []byte{0x51}}
+	p.Inputs[0].Unknowns = []psbt.Unknown{{Key: []byte{0x06, 0x02}, Value: []byte{1, 2, 3, 4}}}
+	p.Outputs[0].Unknowns = []psbt.Unknown{{Key: []byte{0x02}, Value: []byte{9}}}
+
+	enc, err := p.Base64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := psbt.ParseBase64(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := parsed.Base64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != enc {
+		t.Fatal("packet did not round-trip")
+	}
+	if parsed.Inputs[0].WitnessUTXO.Value != 2 || len(parsed.Outputs[0].Unknowns) != 1 {
+		t.Fatal("fields lost in round trip")
+	}
+
+	raw, _ := p.Serialize()
+	for name, data := range map[string][]byte{
+		"no magic":  raw[1:],
+		"truncated": raw[:len(raw)-1],
+		"trailing":  append(append([]byte{}, raw...), 0),
+	} {
+		if _, err := psbt.Parse(data); !errors.Is(err, psbt.ErrMalformed) {
+			t.Errorf("%s: error = %v, want ErrMalformed", name, err)
+		}
+	}
+}
+
+func TestSignECDSA2P(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	names := [2]string{"p1", "p2"}
+	roles := [2]cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2}
+	var (
+		wg      sync.WaitGroup
+		packets [2]*psbt.Packet
+		errs    [2]error
+	)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), roles[i], names)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveSecp256k1})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer res.Key.Close()
+			signer := &psbt.ECDSA2PSigner{Job: job, Key: res.Key}
+			pk, err := signer.PublicKey()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			tx := &psbt.Tx{
+				Version: 2,
+				TxIn:    []*psbt.TxIn{{PreviousOutPoint: psbt.OutPoint{Hash: [32]byte{3}}, Sequence: 0xffffffff}},
+				TxOut:   []*psbt.TxOut{{Value: 900, PkScript: []byte{0x6a}}},
+			}
+			packets[i] = newPacket(tx)
+			packets[i].Inputs[0].WitnessUTXO = &psbt.TxOut{Value: 1000, PkScript: psbt.P2WPKHScript(pk)}
+			_, errs[i] = psbt.Sign(ctx, packets[i], signer)
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d failed: %v", i, err)
+		}
+	}
+	if !packets[0].Inputs[0].Finalized() {
+		t.Fatal("P1 did not finalize the input")
+	}
+	if _, err := packets[0].Extract(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,258 @@
+package psbt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // HASH160 is defined with RIPEMD-160.
+)
+
+// SigHashType selects which parts of the transaction a signature commits to.
+type SigHashType uint32
+
+// Signature hash types.
+const (
+	SigHashDefault      SigHashType = 0x00 // taproot only: like SigHashAll, 64-byte signature
+	SigHashAll          SigHashType = 0x01
+	SigHashNone         SigHashType = 0x02
+	SigHashSingle       SigHashType = 0x03
+	SigHashAnyOneCanPay SigHashType = 0x80
+)
+
+// ScriptType is the kind of output an input spends.
+type ScriptType int
+
+// Script types this package can sign.
+const (
+	ScriptUnknown ScriptType = iota
+	ScriptP2WPKH             // OP_0 <20-byte key hash>, ECDSA (BIP 143)
+	ScriptP2TR               // OP_1 <32-byte output key>, key-path Schnorr (BIP 341)
+)
+
+// hash160 returns RIPEMD160(SHA256(data)), the hash in P2WPKH scripts.
+func hash160(data []byte) []byte {
+	s := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(s[:])
+	return h.Sum(nil)
+}
+
+// P2WPKHScript returns the output script paying to a compressed public key.
+func P2WPKHScript(pubKey []byte) []byte {
+	return append([]byte{0x00, 0x14}, hash160(pubKey)...)
+}
+
+// P2TRScript returns the output script paying to a 32-byte x-only output key.
+// MPC keys are used as the output key directly, without a BIP 341 tweak.
+func P2TRScript(xOnlyKey []byte) []byte {
+	return append([]byte{0x51, 0x20}, xOnlyKey...)
+}
+
+func scriptType(script []byte) ScriptType {
+	switch {
+	case len(script) == 22 && script[0] == 0x00 && script[1] == 0x14:
+		return ScriptP2WPKH
+	case len(script) == 34 && script[0] == 0x51 && script[1] == 0x20:
+		return ScriptP2TR
+	}
+	return ScriptUnknown
+}
+
+// PrevOut returns the output spent by input i, from its witness UTXO or, if
+// absent, from its non-witness UTXO after checking that transaction's ID.
+func (p *Packet) PrevOut(i int) (*TxOut, error) {
+	if i < 0 || i >= len(p.Inputs) {
+		return nil, fmt.Errorf("psbt: input %d out of range", i)
+	}
+	in := p.Inputs[i]
+	if in.WitnessUTXO != nil {
+		return in.WitnessUTXO, nil
+	}
+	if in.NonWitnessUTXO == nil {
+		return nil, fmt.Errorf("psbt: input %d has no UTXO", i)
+	}
+	op := p.UnsignedTx.TxIn[i].PreviousOutPoint
+	if in.NonWitnessUTXO.TxID() != op.Hash {
+		return nil, fmt.Errorf("psbt: input %d UTXO does not match its outpoint", i)
+	}
+	if int(op.Index) >= len(in.NonWitnessUTXO.TxOut) {
+		return nil, fmt.Errorf("psbt: input %d outpoint index out of range", i)
+	}
+	return in.NonWitnessUTXO.TxOut[op.Index], nil
+}
+
+// ScriptType returns the kind of output input i spends.
+func (p *Packet) ScriptType(i int) (ScriptType, error) {
+	out, err := p.PrevOut(i)
+	if err != nil {
+		return ScriptUnknown, err
+	}
+	return scriptType(out.PkScript), nil
+}
+
+// sigHashType returns the sighash type requested for input i, defaulting to
+// SigHashAll for segwit v0 and SigHashDefault for taproot.
+func (p *Packet) sigHashType(i int, st ScriptType) (SigHashType, error) {
+	in := p.Inputs[i]
+	if in.SighashType == nil {
+		if st == ScriptP2TR {
+			return SigHashDefault, nil
+		}
+		return SigHashAll, nil
+	}
+	t := *in.SighashType
+	switch base := t &^ SigHashAnyOneCanPay; {
+	case t == SigHashDefault && st == ScriptP2TR:
+	case base >= SigHashAll && base <= SigHashSingle:
+	default:
+		return 0, fmt.Errorf("psbt: input %d has unsupported sighash type %#x", i, uint32(t))
+	}
+	return t, nil
+}
+
+// SigHash returns the hash a signature for input i must sign, computed from
+// the packet per BIP 143 (P2WPKH) or BIP 341 (P2TR key path) with the
+// input's sighash type. It is what Sign passes to the MPC protocol, exposed
+// so callers and policies can audit it.
+func (p *Packet) SigHash(i int) ([]byte, error) {
+	st, err := p.ScriptType(i)
+	if err != nil {
+		return nil, err
+	}
+	t, err := p.sigHashType(i, st)
+	if err != nil {
+		return nil, err
+	}
+	switch st {
+	case ScriptP2WPKH:
+		return p.sigHashV0(i, t)
+	case ScriptP2TR:
+		return p.sigHashTaproot(i, t)
+	}
+	return nil, fmt.Errorf("psbt: input %d spends an unsupported script type", i)
+}
+
+// sigHashV0 implements BIP 143 for a P2WPKH input.
+func (p *Packet) sigHashV0(i int, t SigHashType) ([]byte, error) {
+	tx := p.UnsignedTx
+	prev, err := p.PrevOut(i)
+	if err != nil {
+		return nil, err
+	}
+	base := t &^ SigHashAnyOneCanPay
+	anyoneCanPay := t&SigHashAnyOneCanPay != 0
+
+	var hashPrevouts, hashSequence, hashOutputs [32]byte
+	if !anyoneCanPay {
+		var b []byte
+		for _, in := range tx.TxIn {
+			b = appendOutPoint(b, in.PreviousOutPoint)
+		}
+		hashPrevouts = doubleSHA256(b)
+	}
+	if !anyoneCanPay && base != SigHashSingle && base != SigHashNone {
+		var b []byte
+		for _, in := range tx.TxIn {
+			b = binary.LittleEndian.AppendUint32(b, in.Sequence)
+		}
+		hashSequence = doubleSHA256(b)
+	}
+	switch {
+	case base != SigHashSingle && base != SigHashNone:
+		var b []byte
+		for _, out := range tx.TxOut {
+			b = appendTxOut(b, out)
+		}
+		hashOutputs = doubleSHA256(b)
+	case base == SigHashSingle && i < len(tx.TxOut):
+		hashOutputs = doubleSHA256(appendTxOut(nil, tx.TxOut[i]))
+	}
+
+	// The script code of P2WPKH is the matching P2PKH script.
+	scriptCode := append(append([]byte{0x76, 0xa9, 0x14}, prev.PkScript[2:]...), 0x88, 0xac)
+
+	b := binary.LittleEndian.AppendUint32(nil, uint32(tx.Version))
+	b = append(b, hashPrevouts[:]...)
+	b = append(b, hashSequence[:]...)
+	b = appendOutPoint(b, tx.TxIn[i].PreviousOutPoint)
+	b = appendVarBytes(b, scriptCode)
+	b = binary.LittleEndian.AppendUint64(b, uint64(prev.Value))
+	b = binary.LittleEndian.AppendUint32(b, tx.TxIn[i].Sequence)
+	b = append(b, hashOutputs[:]...)
+	b = binary.LittleEndian.AppendUint32(b, tx.LockTime)
+	b = binary.LittleEndian.AppendUint32(b, uint32(t))
+	h := doubleSHA256(b)
+	return h[:], nil
+}
+
+// sigHashTaproot implements BIP 341 for a key-path spend without annex.
+func (p *Packet) sigHashTaproot(i int, t SigHashType) ([]byte, error) {
+	tx := p.UnsignedTx
+	base := t &^ SigHashAnyOneCanPay
+	anyoneCanPay := t&SigHashAnyOneCanPay != 0
+	if base == SigHashSingle && i >= len(tx.TxOut) {
+		return nil, errors.New("psbt: SIGHASH_SINGLE without a matching output")
+	}
+
+	b := []byte{0x00, byte(t)} // epoch, hash type
+	b = binary.LittleEndian.AppendUint32(b, uint32(tx.Version))
+	b = binary.LittleEndian.AppendUint32(b, tx.LockTime)
+	if !anyoneCanPay {
+		var prevouts, amounts, scripts, sequences []byte
+		for j, in := range tx.TxIn {
+			prev, err := p.PrevOut(j)
+			if err != nil {
+				return nil, fmt.Errorf("taproot signing needs every input's UTXO: %w", err)
+			}
+			prevouts = appendOutPoint(prevouts, in.PreviousOutPoint)
+			amounts = binary.LittleEndian.AppendUint64(amounts, uint64(prev.Value))
+			scripts = appendVarBytes(scripts, prev.PkScript)
+			sequences = binary.LittleEndian.AppendUint32(sequences, in.Sequence)
+		}
+		for _, part := range [][]byte{prevouts, amounts, scripts, sequences} {
+			h := sha256.Sum256(part)
+			b = append(b, h[:]...)
+		}
+	}
+	if base != SigHashNone && base != SigHashSingle {
+		var outs []byte
+		for _, out := range tx.TxOut {
+			outs = appendTxOut(outs, out)
+		}
+		h := sha256.Sum256(outs)
+		b = append(b, h[:]...)
+	}
+	b = append(b, 0x00) // spend type: key path, no annex
+	if anyoneCanPay {
+		prev, err := p.PrevOut(i)
+		if err != nil {
+			return nil, err
+		}
+		b = appendOutPoint(b, tx.TxIn[i].PreviousOutPoint)
+		b = binary.LittleEndian.AppendUint64(b, uint64(prev.Value))
+		b = appendVarBytes(b, prev.PkScript)
+		b = binary.LittleEndian.AppendUint32(b, tx.TxIn[i].Sequence)
+	} else {
+		b = binary.LittleEndian.AppendUint32(b, uint32(i))
+	}
+	if base == SigHashSingle {
+		h := sha256.Sum256(appendTxOut(nil, tx.TxOut[i]))
+		b = append(b, h[:]...)
+	}
+	return taggedHash("TapSighash", b), nil
+}
+
+func appendOutPoint(b []byte, op OutPoint) []byte {
+	b = append(b, op.Hash[:]...)
+	return binary.LittleEndian.AppendUint32(b, op.Index)
+}
+
+// taggedHash is the BIP 340 tagged hash SHA256(SHA256(tag) || SHA256(tag) || msg).
+func taggedHash(tag string, msg []byte) []byte {
+	th := sha256.Sum256([]byte(tag))
+	h := sha256.Sum256(bytes.Join([][]byte{th[:], th[:], msg}, nil))
+	return h[:]
+}
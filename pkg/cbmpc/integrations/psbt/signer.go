@@ -0,0 +1,228 @@
+package psbt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	btcschnorr "github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorr2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorrmp"
+)
+
+// ErrBadSignature is returned when the MPC protocol produces a signature that
+// does not verify against the key and sighash; the packet is left unchanged.
+var ErrBadSignature = errors.New("psbt: signature does not verify")
+
+// Scheme is the signature scheme of a Signer.
+type Scheme int
+
+// Signature schemes.
+const (
+	SchemeECDSA   Scheme = iota // signs P2WPKH inputs
+	SchemeSchnorr               // BIP 340, signs P2TR key-path inputs
+)
+
+// Signer signs 32-byte sighashes with an MPC key on secp256k1.
+type Signer interface {
+	// PublicKey returns the 33-byte compressed public key.
+	PublicKey() ([]byte, error)
+
+	// Scheme returns the signature scheme.
+	Scheme() Scheme
+
+	// SignHash signs hash, returning a DER signature for SchemeECDSA or a
+	// 64-byte signature for SchemeSchnorr. It returns a nil signature, and no
+	// error, on parties that do not receive the signature.
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+// ECDSA2PSigner signs with a 2-party ECDSA key. Only P1 receives signatures.
+type ECDSA2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *ecdsa2p.Key
+}
+
+// PublicKey implements Signer.
+func (s *ECDSA2PSigner) PublicKey() ([]byte, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.PublicKey()
+}
+
+// Scheme implements Signer.
+func (s *ECDSA2PSigner) Scheme() Scheme { return SchemeECDSA }
+
+// SignHash implements Signer.
+func (s *ECDSA2PSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	res, err := ecdsa2p.Sign(ctx, s.Job, &ecdsa2p.SignParams{Key: s.Key, Message: hash})
+	if err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// Schnorr2PSigner signs with a 2-party BIP 340 key. Only P1 receives
+// signatures.
+type Schnorr2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *schnorr2p.Key
+}
+
+// PublicKey implements Signer.
+func (s *Schnorr2PSigner) PublicKey() ([]byte, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.PublicKey()
+}
+
+// Scheme implements Signer.
+func (s *Schnorr2PSigner) Scheme() Scheme { return SchemeSchnorr }
+
+// SignHash implements Signer.
+func (s *Schnorr2PSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	res, err := schnorr2p.Sign(ctx, s.Job, &schnorr2p.SignParams{Key: s.Key, Message: hash, Variant: schnorr2p.VariantBIP340})
+	if err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// SchnorrMPSigner signs with a multi-party BIP 340 key. Only the party at
+// index SigReceiver receives signatures.
+type SchnorrMPSigner struct {
+	Job         *cbmpc.JobMP
+	Key         *schnorrmp.Key
+	SigReceiver int
+}
+
+// PublicKey implements Signer.
+func (s *SchnorrMPSigner) PublicKey() ([]byte, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.PublicKey()
+}
+
+// Scheme implements Signer.
+func (s *SchnorrMPSigner) Scheme() Scheme { return SchemeSchnorr }
+
+// SignHash implements Signer.
+func (s *SchnorrMPSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	res, err := schnorrmp.Sign(ctx, s.Job, &schnorrmp.SignParams{
+		Key:         s.Key,
+		Message:     hash,
+		SigReceiver: s.SigReceiver,
+		Variant:     schnorrmp.VariantBIP340,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// Sign signs and finalizes every input of p that s controls: P2WPKH inputs
+// paying to s's key for SchemeECDSA, P2TR key-path inputs whose output key is
+// s's x-only key for SchemeSchnorr. It computes each sighash itself (see
+// SigHash), runs one signing protocol per input in input order, verifies the
+// signature, and writes the final witness. It returns the indices of the
+// inputs it signed.
+//
+// Every MPC party must call Sign with the same packet so the protocols line
+// up. Parties that do not receive signatures get the same indices back but
+// an unchanged packet.
+func Sign(ctx context.Context, p *Packet, s Signer) ([]int, error) {
+	if p == nil || p.UnsignedTx == nil {
+		return nil, errors.New("psbt: nil packet")
+	}
+	if s == nil {
+		return nil, errors.New("psbt: nil signer")
+	}
+	pk, err := s.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := btcec.ParsePubKey(pk)
+	if err != nil {
+		return nil, fmt.Errorf("psbt: invalid public key: %w", err)
+	}
+	pk = pub.SerializeCompressed()
+
+	var want []byte
+	var wantType ScriptType
+	switch s.Scheme() {
+	case SchemeECDSA:
+		want, wantType = P2WPKHScript(pk), ScriptP2WPKH
+	case SchemeSchnorr:
+		want, wantType = P2TRScript(pk[1:]), ScriptP2TR
+	default:
+		return nil, fmt.Errorf("psbt: unknown scheme %d", s.Scheme())
+	}
+
+	var signed []int
+	for i, in := range p.Inputs {
+		if in.Finalized() {
+			continue
+		}
+		prev, err := p.PrevOut(i)
+		if err != nil || !bytes.Equal(prev.PkScript, want) {
+			continue
+		}
+		hash, err := p.SigHash(i)
+		if err != nil {
+			return signed, err
+		}
+		t, err := p.sigHashType(i, wantType)
+		if err != nil {
+			return signed, err
+		}
+		sig, err := s.SignHash(ctx, hash)
+		if err != nil {
+			return signed, fmt.Errorf("psbt: signing input %d: %w", i, err)
+		}
+		signed = append(signed, i)
+		if sig == nil {
+			continue
+		}
+
+		switch wantType {
+		case ScriptP2WPKH:
+			parsed, err := btcecdsa.ParseDERSignature(sig)
+			if err != nil || !parsed.Verify(hash, pub) {
+				return signed, fmt.Errorf("%w: input %d", ErrBadSignature, i)
+			}
+			// Serialize re-encodes with low S, which relay policy requires.
+			final := append(parsed.Serialize(), byte(t))
+			p.finalize(i, [][]byte{final, pk})
+		case ScriptP2TR:
+			parsed, err := btcschnorr.ParseSignature(sig)
+			if err != nil || !parsed.Verify(hash, pub) {
+				return signed, fmt.Errorf("%w: input %d", ErrBadSignature, i)
+			}
+			final := append([]byte{}, sig...)
+			if t != SigHashDefault {
+				final = append(final, byte(t))
+			}
+			p.finalize(i, [][]byte{final})
+		}
+	}
+	return signed, nil
+}
+
+func checkCurve(c cbmpc.Curve, err error) error {
+	if err != nil {
+		return err
+	}
+	if c != cbmpc.CurveSecp256k1 {
+		return fmt.Errorf("bitcoin keys must be secp256k1 (got %s)", c)
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package psbt
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrMalformed is wrapped by errors from Parse and transaction decoding.
+var ErrMalformed = errors.New("psbt: malformed encoding")
+
+// OutPoint identifies a previous transaction output.
+type OutPoint struct {
+	Hash  [32]byte // txid in internal (little-endian) byte order
+	Index uint32
+}
+
+// TxIn is a transaction input.
+type TxIn struct {
+	PreviousOutPoint OutPoint
+	SignatureScript  []byte
+	Witness          [][]byte
+	Sequence         uint32
+}
+
+// TxOut is a transaction output.
+type TxOut struct {
+	Value    int64
+	PkScript []byte
+}
+
+// Tx is a Bitcoin transaction.
+type Tx struct {
+	Version  int32
+	TxIn     []*TxIn
+	TxOut    []*TxOut
+	LockTime uint32
+}
+
+// hasWitness reports whether any input carries witness data.
+func (tx *Tx) hasWitness() bool {
+	for _, in := range tx.TxIn {
+		if len(in.Witness) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Serialize encodes tx, with witness data if any input has some.
+func (tx *Tx) Serialize() []byte {
+	return tx.serialize(tx.hasWitness())
+}
+
+// TxID returns the transaction hash in internal byte order (reverse it for
+// display).
+func (tx *Tx) TxID() [32]byte {
+	return doubleSHA256(tx.serialize(false))
+}
+
+func (tx *Tx) serialize(witness bool) []byte {
+	b := binary.LittleEndian.AppendUint32(nil, uint32(tx.Version))
+	if witness {
+		b = append(b, 0x00, 0x01)
+	}
+	b = appendVarInt(b, uint64(len(tx.TxIn)))
+	for _, in := range tx.TxIn {
+		b = append(b, in.PreviousOutPoint.Hash[:]...)
+		b = binary.LittleEndian.AppendUint32(b, in.PreviousOutPoint.Index)
+		b = appendVarBytes(b, in.SignatureScript)
+		b = binary.LittleEndian.AppendUint32(b, in.Sequence)
+	}
+	b = appendVarInt(b, uint64(len(tx.TxOut)))
+	for _, out := range tx.TxOut {
+		b = appendTxOut(b, out)
+	}
+	if witness {
+		for _, in := range tx.TxIn {
+			b = appendWitness(b, in.Witness)
+		}
+	}
+	return binary.LittleEndian.AppendUint32(b, tx.LockTime)
+}
+
+// ParseTx decodes a transaction in either the legacy or the segwit encoding.
+func ParseTx(data []byte) (*Tx, error) {
+	r := &reader{b: data}
+	tx, err := r.tx()
+	if err != nil {
+		return nil, err
+	}
+	if len(r.b) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes after transaction", ErrMalformed, len(r.b))
+	}
+	return tx, nil
+}
+
+func appendTxOut(b []byte, out *TxOut) []byte {
+	b = binary.LittleEndian.AppendUint64(b, uint64(out.Value))
+	return appendVarBytes(b, out.PkScript)
+}
+
+func appendWitness(b []byte, w [][]byte) []byte {
+	b = appendVarInt(b, uint64(len(w)))
+	for _, item := range w {
+		b = appendVarBytes(b, item)
+	}
+	return b
+}
+
+func appendVarInt(b []byte, n uint64) []byte {
+	switch {
+	case n < 0xfd:
+		return append(b, byte(n))
+	case n <= 0xffff:
+		return binary.LittleEndian.AppendUint16(append(b, 0xfd), uint16(n))
+	case n <= 0xffffffff:
+		return binary.LittleEndian.AppendUint32(append(b, 0xfe), uint32(n))
+	default:
+		return binary.LittleEndian.AppendUint64(append(b, 0xff), n)
+	}
+}
+
+func appendVarBytes(b, data []byte) []byte {
+	return append(appendVarInt(b, uint64(len(data))), data...)
+}
+
+func doubleSHA256(b []byte) [32]byte {
+	h := sha256.Sum256(b)
+	return sha256.Sum256(h[:])
+}
+
+// reader decodes Bitcoin wire encodings.
+type reader struct{ b []byte }
+
+func (r *reader) take(n uint64) ([]byte, error) {
+	if n > uint64(len(r.b)) {
+		return nil, fmt.Errorf("%w: unexpected end of data", ErrMalformed)
+	}
+	out := r.b[:n]
+	r.b = r.b[n:]
+	return out, nil
+}
+
+func (r *reader) uint32() (uint32, error) {
+	b, err := r.take(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (r *reader) varInt() (uint64, error) {
+	b, err := r.take(1)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	var min uint64
+	switch b[0] {
+	case 0xfd:
+		v, err := r.take(2)
+		if err != nil {
+			return 0, err
+		}
+		n, min = uint64(binary.LittleEndian.Uint16(v)), 0xfd
+	case 0xfe:
+		v, err := r.take(4)
+		if err != nil {
+			return 0, err
+		}
+		n, min = uint64(binary.LittleEndian.Uint32(v)), 0x10000
+	case 0xff:
+		v, err := r.take(8)
+		if err != nil {
+			return 0, err
+		}
+		n, min = binary.LittleEndian.Uint64(v), 0x100000000
+	default:
+		return uint64(b[0]), nil
+	}
+	if n < min {
+		return 0, fmt.Errorf("%w: non-canonical varint", ErrMalformed)
+	}
+	return n, nil
+}
+
+func (r *reader) varBytes() ([]byte, error) {
+	n, err := r.varInt()
+	if err != nil {
+		return nil, err
+	}
+	b, err := r.take(n)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{}, b...), nil
+}
+
+// count reads a varint element count, bounded by the remaining data so a
+// corrupt count cannot trigger a huge allocation.
+func (r *reader) count(minSize int) (int, error) {
+	n, err := r.varInt()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(r.b)/minSize) {
+		return 0, fmt.Errorf("%w: count %d exceeds remaining data", ErrMalformed, n)
+	}
+	return int(n), nil
+}
+
+func (r *reader) tx() (*Tx, error) {
+	v, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	tx := &Tx{Version: int32(v)}
+	witness := false
+	if len(r.b) >= 2 && r.b[0] == 0x00 && r.b[1] == 0x01 {
+		witness = true
+		r.b = r.b[2:]
+	}
+
+	nIn, err := r.count(41)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < nIn; i++ {
+		in := &TxIn{}
+		h, err := r.take(32)
+		if err != nil {
+			return nil, err
+		}
+		copy(in.PreviousOutPoint.Hash[:], h)
+		if in.PreviousOutPoint.Index, err = r.uint32(); err != nil {
+			return nil, err
+		}
+		if in.SignatureScript, err = r.varBytes(); err != nil {
+			return nil, err
+		}
+		if in.Sequence, err = r.uint32(); err != nil {
+			return nil, err
+		}
+		tx.TxIn = append(tx.TxIn, in)
+	}
+
+	nOut, err := r.count(9)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < nOut; i++ {
+		v, err := r.take(8)
+		if err != nil {
+			return nil, err
+		}
+		out := &TxOut{Value: int64(binary.LittleEndian.Uint64(v))}
+		if out.PkScript, err = r.varBytes(); err != nil {
+			return nil, err
+		}
+		tx.TxOut = append(tx.TxOut, out)
+	}
+
+	if witness {
+		for _, in := range tx.TxIn {
+			n, err := r.count(1)
+			if err != nil {
+				return nil, err
+			}
+			for j := 0; j < n; j++ {
+				item, err := r.varBytes()
+				if err != nil {
+					return nil, err
+				}
+				in.Witness = append(in.Witness, item)
+			}
+		}
+	}
+	if tx.LockTime, err = r.uint32(); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
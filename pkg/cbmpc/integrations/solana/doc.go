@@ -0,0 +1,30 @@
+// Package solana signs Solana transactions with EdDSA keys from the schnorr2p
+// and schnorrmp packages.
+//
+// It covers what a custody service needs around the MPC signature: the wire
+// format of legacy and version 0 messages and transactions, 64-byte signature
+// placement, and base58 addresses. It does not build instructions or talk to
+// an RPC node; construct the Message (or parse one produced by a wallet SDK)
+// and submit the serialized transaction yourself.
+//
+// # Usage Example
+//
+//	// Every party parses the same unsigned message, e.g. from a wallet SDK.
+//	msg, err := solana.ParseMessage(unsigned)
+//	if err != nil {
+//	    return err
+//	}
+//	tx := solana.NewTransaction(msg)
+//	signer := &solana.SchnorrMPSigner{Job: job, Key: key, SigReceiver: 0}
+//	if err := solana.SignTransaction(ctx, signer, tx); err != nil {
+//	    return err
+//	}
+//	// On the receiver:
+//	raw, err := tx.MarshalBinary()
+//
+// # Security Considerations
+//
+//   - Every party signs whatever message it is given. Parse and check the
+//     message (program IDs, amounts, destination) before signing.
+//   - Keys must be on cbmpc.CurveEd25519; the Schnorr variant is always EdDSA.
+package solana
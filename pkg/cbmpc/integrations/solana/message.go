@@ -0,0 +1,416 @@
+package solana
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+)
+
+const (
+	// PublicKeySize is the size of a Solana public key (an Ed25519 point).
+	PublicKeySize = 32
+
+	// SignatureSize is the size of a Solana signature (R || S).
+	SignatureSize = 64
+
+	// versionPrefix marks a versioned message; legacy messages start with the
+	// header instead, whose first byte is below 0x80.
+	versionPrefix = 0x80
+)
+
+// ErrMalformed is wrapped by errors from ParseMessage and ParseTransaction.
+var ErrMalformed = errors.New("solana: malformed encoding")
+
+// PublicKey is a Solana account address.
+type PublicKey [PublicKeySize]byte
+
+// ParsePublicKey parses a base58 address.
+func ParsePublicKey(s string) (PublicKey, error) {
+	b, err := DecodeBase58(s)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return PublicKeyFromBytes(b)
+}
+
+// PublicKeyFromBytes converts a 32-byte Ed25519 public key, as returned by
+// schnorr2p.Key.PublicKey and schnorrmp.Key.PublicKey, to a PublicKey.
+func PublicKeyFromBytes(b []byte) (PublicKey, error) {
+	var pk PublicKey
+	if len(b) != PublicKeySize {
+		return pk, fmt.Errorf("public key must be %d bytes (got %d)", PublicKeySize, len(b))
+	}
+	copy(pk[:], b)
+	return pk, nil
+}
+
+// String returns the base58 address.
+func (pk PublicKey) String() string { return EncodeBase58(pk[:]) }
+
+// Signature is an Ed25519 signature in the 64-byte R || S form Solana uses.
+type Signature [SignatureSize]byte
+
+// SignatureFromBytes converts a 64-byte Ed25519 signature to a Signature.
+func SignatureFromBytes(b []byte) (Signature, error) {
+	var sig Signature
+	if len(b) != SignatureSize {
+		return sig, fmt.Errorf("signature must be %d bytes (got %d)", SignatureSize, len(b))
+	}
+	copy(sig[:], b)
+	return sig, nil
+}
+
+// String returns the base58 signature, which is also the transaction ID when
+// it is the first signature of a transaction.
+func (s Signature) String() string { return EncodeBase58(s[:]) }
+
+// Hash is a recent blockhash.
+type Hash [32]byte
+
+// String returns the base58 hash.
+func (h Hash) String() string { return EncodeBase58(h[:]) }
+
+// MessageHeader counts the signing and read-only accounts of a message.
+type MessageHeader struct {
+	NumRequiredSignatures       uint8
+	NumReadonlySignedAccounts   uint8
+	NumReadonlyUnsignedAccounts uint8
+}
+
+// CompiledInstruction is an instruction whose accounts are indices into the
+// message's account keys.
+type CompiledInstruction struct {
+	ProgramIDIndex uint8
+	Accounts       []uint8
+	Data           []byte
+}
+
+// AddressTableLookup loads additional accounts from an address lookup table
+// in a version 0 message.
+type AddressTableLookup struct {
+	AccountKey      PublicKey
+	WritableIndexes []uint8
+	ReadonlyIndexes []uint8
+}
+
+// Message is the part of a transaction that is signed.
+type Message struct {
+	// Versioned selects the version 0 format; false is a legacy message.
+	Versioned bool
+
+	Header              MessageHeader
+	AccountKeys         []PublicKey
+	RecentBlockhash     Hash
+	Instructions        []CompiledInstruction
+	AddressTableLookups []AddressTableLookup // version 0 only
+}
+
+// Signers returns the accounts that must sign the message, in signature order.
+func (m *Message) Signers() []PublicKey {
+	n := int(m.Header.NumRequiredSignatures)
+	if n > len(m.AccountKeys) {
+		n = len(m.AccountKeys)
+	}
+	return m.AccountKeys[:n]
+}
+
+// MarshalBinary returns the wire encoding of the message: the bytes that are
+// signed.
+func (m *Message) MarshalBinary() ([]byte, error) {
+	if int(m.Header.NumRequiredSignatures) > len(m.AccountKeys) {
+		return nil, errors.New("more required signatures than account keys")
+	}
+	if !m.Versioned && len(m.AddressTableLookups) > 0 {
+		return nil, errors.New("address table lookups need a versioned message")
+	}
+	var b []byte
+	if m.Versioned {
+		b = append(b, versionPrefix)
+	}
+	b = append(b, m.Header.NumRequiredSignatures, m.Header.NumReadonlySignedAccounts, m.Header.NumReadonlyUnsignedAccounts)
+
+	var err error
+	if b, err = appendLength(b, len(m.AccountKeys)); err != nil {
+		return nil, err
+	}
+	for _, k := range m.AccountKeys {
+		b = append(b, k[:]...)
+	}
+	b = append(b, m.RecentBlockhash[:]...)
+
+	if b, err = appendLength(b, len(m.Instructions)); err != nil {
+		return nil, err
+	}
+	for _, ix := range m.Instructions {
+		b = append(b, ix.ProgramIDIndex)
+		if b, err = appendBytes(b, ix.Accounts); err != nil {
+			return nil, err
+		}
+		if b, err = appendBytes(b, ix.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.Versioned {
+		if b, err = appendLength(b, len(m.AddressTableLookups)); err != nil {
+			return nil, err
+		}
+		for _, l := range m.AddressTableLookups {
+			b = append(b, l.AccountKey[:]...)
+			if b, err = appendBytes(b, l.WritableIndexes); err != nil {
+				return nil, err
+			}
+			if b, err = appendBytes(b, l.ReadonlyIndexes); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+// ParseMessage decodes a legacy or version 0 message.
+func ParseMessage(data []byte) (*Message, error) {
+	r := &reader{b: data}
+	m, err := r.message()
+	if err != nil {
+		return nil, err
+	}
+	if len(r.b) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrMalformed, len(r.b))
+	}
+	return m, nil
+}
+
+// Transaction is a message with one signature per required signer.
+type Transaction struct {
+	Signatures []Signature
+	Message    Message
+}
+
+// NewTransaction returns an unsigned transaction for m, with zeroed
+// signatures for every required signer.
+func NewTransaction(m *Message) *Transaction {
+	return &Transaction{
+		Signatures: make([]Signature, m.Header.NumRequiredSignatures),
+		Message:    *m,
+	}
+}
+
+// MarshalBinary returns the wire encoding of the transaction, as submitted
+// to sendTransaction (before base58 or base64 encoding).
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	msg, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if len(tx.Signatures) != int(tx.Message.Header.NumRequiredSignatures) {
+		return nil, fmt.Errorf("transaction has %d signatures, message requires %d", len(tx.Signatures), tx.Message.Header.NumRequiredSignatures)
+	}
+	b, err := appendLength(nil, len(tx.Signatures))
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range tx.Signatures {
+		b = append(b, s[:]...)
+	}
+	return append(b, msg...), nil
+}
+
+// ParseTransaction decodes a wire-encoded transaction.
+func ParseTransaction(data []byte) (*Transaction, error) {
+	r := &reader{b: data}
+	n, err := r.length()
+	if err != nil {
+		return nil, err
+	}
+	tx := &Transaction{Signatures: make([]Signature, n)}
+	for i := range tx.Signatures {
+		b, err := r.take(SignatureSize)
+		if err != nil {
+			return nil, err
+		}
+		copy(tx.Signatures[i][:], b)
+	}
+	m, err := r.message()
+	if err != nil {
+		return nil, err
+	}
+	if len(r.b) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrMalformed, len(r.b))
+	}
+	if n != int(m.Header.NumRequiredSignatures) {
+		return nil, fmt.Errorf("%w: %d signatures for %d required signers", ErrMalformed, n, m.Header.NumRequiredSignatures)
+	}
+	tx.Message = *m
+	return tx, nil
+}
+
+// ID returns the first signature, which identifies the transaction.
+func (tx *Transaction) ID() (Signature, error) {
+	if len(tx.Signatures) == 0 {
+		return Signature{}, errors.New("transaction has no signatures")
+	}
+	return tx.Signatures[0], nil
+}
+
+// Verify checks every signature against its signer with crypto/ed25519.
+func (tx *Transaction) Verify() error {
+	msg, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	signers := tx.Message.Signers()
+	if len(tx.Signatures) != len(signers) {
+		return fmt.Errorf("transaction has %d signatures for %d signers", len(tx.Signatures), len(signers))
+	}
+	for i, pk := range signers {
+		if !ed25519.Verify(pk[:], msg, tx.Signatures[i][:]) {
+			return fmt.Errorf("invalid signature for %s", pk)
+		}
+	}
+	return nil
+}
+
+// appendLength appends n in Solana's compact-u16 encoding.
+func appendLength(b []byte, n int) ([]byte, error) {
+	if n < 0 || n > 0xffff {
+		return nil, fmt.Errorf("length %d does not fit in compact-u16", n)
+	}
+	for {
+		c := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(b, c), nil
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+func appendBytes(b, data []byte) ([]byte, error) {
+	b, err := appendLength(b, len(data))
+	if err != nil {
+		return nil, err
+	}
+	return append(b, data...), nil
+}
+
+// reader decodes the wire format.
+type reader struct{ b []byte }
+
+func (r *reader) take(n int) ([]byte, error) {
+	if n > len(r.b) {
+		return nil, fmt.Errorf("%w: unexpected end of data", ErrMalformed)
+	}
+	out := r.b[:n]
+	r.b = r.b[n:]
+	return out, nil
+}
+
+func (r *reader) length() (int, error) {
+	n := 0
+	for i := 0; i < 3; i++ {
+		c, err := r.take(1)
+		if err != nil {
+			return 0, err
+		}
+		n |= int(c[0]&0x7f) << (7 * i)
+		if c[0]&0x80 == 0 {
+			if n > 0xffff || (i > 0 && c[0] == 0) {
+				return 0, fmt.Errorf("%w: invalid compact-u16", ErrMalformed)
+			}
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: invalid compact-u16", ErrMalformed)
+}
+
+func (r *reader) bytes() ([]byte, error) {
+	n, err := r.length()
+	if err != nil {
+		return nil, err
+	}
+	b, err := r.take(n)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{}, b...), nil
+}
+
+func (r *reader) message() (*Message, error) {
+	m := &Message{}
+	if len(r.b) > 0 && r.b[0]&versionPrefix != 0 {
+		if v := r.b[0] &^ versionPrefix; v != 0 {
+			return nil, fmt.Errorf("%w: unsupported message version %d", ErrMalformed, v)
+		}
+		m.Versioned = true
+		r.b = r.b[1:]
+	}
+	h, err := r.take(3)
+	if err != nil {
+		return nil, err
+	}
+	m.Header = MessageHeader{h[0], h[1], h[2]}
+
+	n, err := r.length()
+	if err != nil {
+		return nil, err
+	}
+	m.AccountKeys = make([]PublicKey, n)
+	for i := range m.AccountKeys {
+		k, err := r.take(PublicKeySize)
+		if err != nil {
+			return nil, err
+		}
+		copy(m.AccountKeys[i][:], k)
+	}
+	if int(m.Header.NumRequiredSignatures) > n {
+		return nil, fmt.Errorf("%w: more required signatures than account keys", ErrMalformed)
+	}
+	bh, err := r.take(len(m.RecentBlockhash))
+	if err != nil {
+		return nil, err
+	}
+	copy(m.RecentBlockhash[:], bh)
+
+	if n, err = r.length(); err != nil {
+		return nil, err
+	}
+	m.Instructions = make([]CompiledInstruction, n)
+	for i := range m.Instructions {
+		p, err := r.take(1)
+		if err != nil {
+			return nil, err
+		}
+		ix := CompiledInstruction{ProgramIDIndex: p[0]}
+		if ix.Accounts, err = r.bytes(); err != nil {
+			return nil, err
+		}
+		if ix.Data, err = r.bytes(); err != nil {
+			return nil, err
+		}
+		m.Instructions[i] = ix
+	}
+
+	if m.Versioned {
+		if n, err = r.length(); err != nil {
+			return nil, err
+		}
+		m.AddressTableLookups = make([]AddressTableLookup, n)
+		for i := range m.AddressTableLookups {
+			k, err := r.take(PublicKeySize)
+			if err != nil {
+				return nil, err
+			}
+			l := AddressTableLookup{}
+			copy(l.AccountKey[:], k)
+			if l.WritableIndexes, err = r.bytes(); err != nil {
+				return nil, err
+			}
+			if l.ReadonlyIndexes, err = r.bytes(); err != nil {
+				return nil, err
+			}
+			m.AddressTableLookups[i] = l
+		}
+	}
+	return m, nil
+}
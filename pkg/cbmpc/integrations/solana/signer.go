@@ -0,0 +1,143 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorr2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorrmp"
+)
+
+// ErrNotSigner is returned by SignTransaction when the signer's public key is
+// not among the message's required signers.
+var ErrNotSigner = errors.New("solana: key is not a required signer")
+
+// Signer produces Ed25519 signatures over serialized messages.
+type Signer interface {
+	// PublicKey returns the signer's address.
+	PublicKey() (PublicKey, error)
+
+	// Sign signs message. It returns a nil signature, and no error, on
+	// parties that take part in signing but do not receive the signature.
+	Sign(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// Schnorr2PSigner signs with a 2-party EdDSA key. Both parties must call
+// SignTransaction with the same transaction; both receive the signature.
+type Schnorr2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *schnorr2p.Key
+}
+
+// PublicKey implements Signer.
+func (s *Schnorr2PSigner) PublicKey() (PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return PublicKey{}, err
+	}
+	pub, err := s.Key.PublicKey()
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return PublicKeyFromBytes(pub)
+}
+
+// Sign implements Signer.
+func (s *Schnorr2PSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	res, err := schnorr2p.Sign(ctx, s.Job, &schnorr2p.SignParams{Key: s.Key, Message: message, Variant: schnorr2p.VariantEdDSA})
+	if err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// SchnorrMPSigner signs with a multi-party EdDSA key. Every party must call
+// SignTransaction with the same transaction and SigReceiver; only the party
+// at index SigReceiver receives the signature.
+type SchnorrMPSigner struct {
+	Job         *cbmpc.JobMP
+	Key         *schnorrmp.Key
+	SigReceiver int
+}
+
+// PublicKey implements Signer.
+func (s *SchnorrMPSigner) PublicKey() (PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return PublicKey{}, err
+	}
+	pub, err := s.Key.PublicKey()
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return PublicKeyFromBytes(pub)
+}
+
+// Sign implements Signer.
+func (s *SchnorrMPSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	res, err := schnorrmp.Sign(ctx, s.Job, &schnorrmp.SignParams{
+		Key:         s.Key,
+		Message:     message,
+		SigReceiver: s.SigReceiver,
+		Variant:     schnorrmp.VariantEdDSA,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// SignTransaction signs tx.Message with s and stores the signature in the
+// slot of s's address. It returns ErrNotSigner if that address is not a
+// required signer. Parties that do not receive the signature (see
+// SchnorrMPSigner) leave tx unchanged.
+func SignTransaction(ctx context.Context, s Signer, tx *Transaction) error {
+	if s == nil {
+		return errors.New("nil signer")
+	}
+	if tx == nil {
+		return errors.New("nil transaction")
+	}
+	pk, err := s.PublicKey()
+	if err != nil {
+		return err
+	}
+	slot := -1
+	for i, k := range tx.Message.Signers() {
+		if k == pk {
+			slot = i
+			break
+		}
+	}
+	if slot < 0 {
+		return fmt.Errorf("%w: %s", ErrNotSigner, pk)
+	}
+	if len(tx.Signatures) != int(tx.Message.Header.NumRequiredSignatures) {
+		return fmt.Errorf("transaction has %d signatures, message requires %d", len(tx.Signatures), tx.Message.Header.NumRequiredSignatures)
+	}
+	msg, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	sig, err := s.Sign(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if sig == nil {
+		return nil
+	}
+	if tx.Signatures[slot], err = SignatureFromBytes(sig); err != nil {
+		return err
+	}
+	return nil
+}
+
+func checkCurve(c cbmpc.Curve, err error) error {
+	if err != nil {
+		return err
+	}
+	if c != cbmpc.CurveEd25519 {
+		return fmt.Errorf("solana keys must be Ed25519 (got %s)", c)
+	}
+	return nil
+}
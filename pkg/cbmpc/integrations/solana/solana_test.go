@@ -0,0 +1,214 @@
+package solana_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/integrations/solana"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorr2p"
+)
+
+// localSigner signs with an in-process ed25519 key.
+type localSigner struct{ key ed25519.PrivateKey }
+
+func (s localSigner) PublicKey() (solana.PublicKey, error) {
+	return solana.PublicKeyFromBytes(s.key.Public().(ed25519.PublicKey))
+}
+
+func (s localSigner) Sign(_ context.Context, msg []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, msg), nil
+}
+
+// transferMessage builds a System Program transfer from -> to.
+func transferMessage(from, to solana.PublicKey, lamports uint64) *solana.Message {
+	data := make([]byte, 12)
+	binary.LittleEndian.PutUint32(data, 2) // Transfer
+	binary.LittleEndian.PutUint64(data[4:], lamports)
+	return &solana.Message{
+		Header:          solana.MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 1},
+		AccountKeys:     []solana.PublicKey{from, to, {}}, // all zeros: System Program
+		RecentBlockhash: solana.Hash{1, 2, 3},
+		Instructions:    []solana.CompiledInstruction{{ProgramIDIndex: 2, Accounts: []uint8{0, 1}, Data: data}},
+	}
+}
+
+func TestBase58(t *testing.T) {
+	vectors := []struct {
+		data []byte
+		enc  string
+	}{
+		{nil, ""},
+		{[]byte{0}, "1"},
+		{[]byte{0, 0, 1}, "112"},
+		{[]byte("Hello World!"), "2NEpo7TZRRrLZSi2U"},
+		{make([]byte, 32), "11111111111111111111111111111111"},
+	}
+	for _, v := range vectors {
+		if got := solana.EncodeBase58(v.data); got != v.enc {
+			t.Fatalf("EncodeBase58(%x) = %q, want %q", v.data, got, v.enc)
+		}
+		got, err := solana.DecodeBase58(v.enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, v.data) {
+			t.Fatalf("DecodeBase58(%q) = %x, want %x", v.enc, got, v.data)
+		}
+	}
+	if _, err := solana.DecodeBase58("0OIl"); err == nil {
+		t.Fatal("expected error for invalid characters")
+	}
+
+	pk, err := solana.ParsePublicKey("11111111111111111111111111111111")
+	if err != nil || pk != (solana.PublicKey{}) {
+		t.Fatalf("ParsePublicKey(system program) = %v, %v", pk, err)
+	}
+	if _, err := solana.ParsePublicKey("2NEpo7TZRRrLZSi2U"); err == nil {
+		t.Fatal("expected error for a short public key")
+	}
+}
+
+func TestMessageRoundTrip(t *testing.T) {
+	legacy := transferMessage(solana.PublicKey{9}, solana.PublicKey{8}, 5000)
+	v0 := transferMessage(solana.PublicKey{9}, solana.PublicKey{8}, 5000)
+	v0.Versioned = true
+	v0.AddressTableLookups = []solana.AddressTableLookup{{AccountKey: solana.PublicKey{7}, WritableIndexes: []uint8{1}, ReadonlyIndexes: []uint8{0, 2}}}
+
+	for name, m := range map[string]*solana.Message{"legacy": legacy, "v0": v0} {
+		t.Run(name, func(t *testing.T) {
+			b, err := m.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := solana.ParseMessage(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			again, err := got.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(again, b) {
+				t.Fatal("message did not round-trip")
+			}
+			if got.Versioned != m.Versioned {
+				t.Fatal("version lost")
+			}
+			if _, err := solana.ParseMessage(append(b, 0)); !errors.Is(err, solana.ErrMalformed) {
+				t.Fatalf("trailing byte error = %v", err)
+			}
+			if _, err := solana.ParseMessage(b[:len(b)-1]); !errors.Is(err, solana.ErrMalformed) {
+				t.Fatalf("truncated error = %v", err)
+			}
+		})
+	}
+
+	b, _ := legacy.MarshalBinary()
+	if want := []byte{1, 0, 1, 3}; !bytes.Equal(b[:4], want) {
+		t.Fatalf("legacy message starts %x, want %x", b[:4], want)
+	}
+	b, _ = v0.MarshalBinary()
+	if b[0] != 0x80 {
+		t.Fatalf("v0 message starts %x, want 80", b[0])
+	}
+}
+
+func TestSignTransaction(t *testing.T) {
+	ctx := context.Background()
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := localSigner{priv}
+	from, _ := s.PublicKey()
+
+	tx := solana.NewTransaction(transferMessage(from, solana.PublicKey{8}, 1))
+	if err := tx.Verify(); err == nil {
+		t.Fatal("unsigned transaction verified")
+	}
+	if err := solana.SignTransaction(ctx, s, tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := solana.ParseTransaction(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := parsed.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	id, _ := parsed.ID()
+	if got, _ := solana.DecodeBase58(id.String()); !bytes.Equal(got, tx.Signatures[0][:]) {
+		t.Fatal("transaction ID does not round-trip through base58")
+	}
+
+	other := solana.NewTransaction(transferMessage(solana.PublicKey{9}, from, 1))
+	if err := solana.SignTransaction(ctx, s, other); !errors.Is(err, solana.ErrNotSigner) {
+		t.Fatalf("non-signer error = %v", err)
+	}
+}
+
+func TestSchnorr2PSigner(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	names := [2]string{"p1", "p2"}
+	roles := [2]cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2}
+
+	var keys [2]*schnorr2p.Key
+	var txs [2]*solana.Transaction
+	var errs [2]error
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), roles[i], names)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := schnorr2p.DKG(ctx, job, &schnorr2p.DKGParams{Curve: cbmpc.CurveEd25519})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			keys[i] = res.Key
+			signer := &solana.Schnorr2PSigner{Job: job, Key: res.Key}
+			from, err := signer.PublicKey()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			txs[i] = solana.NewTransaction(transferMessage(from, solana.PublicKey{8}, 1_000_000))
+			errs[i] = solana.SignTransaction(ctx, signer, txs[i])
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d failed: %v", i, err)
+		}
+		defer func() { _ = keys[i].Close() }()
+	}
+	if err := txs[0].Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,43 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// NonceSize is the size of an SSH certificate nonce.
+const NonceSize = 32
+
+// SignCertificate signs cert with the MPC certificate authority key behind
+// s, setting cert.SignatureKey and cert.Signature.
+//
+// Every party must call it with the same certificate, including the same
+// cert.Nonce: ssh.Certificate.SignCert would otherwise draw a random nonce on
+// each party, and the parties would sign different data. Agree on the nonce
+// the way the rest of the certificate is agreed on, or derive it from the
+// request.
+//
+// Parties that do not receive the signature leave cert.Signature nil and get
+// no error.
+func SignCertificate(ctx context.Context, s Signer, cert *gossh.Certificate) error {
+	if cert == nil {
+		return errors.New("nil certificate")
+	}
+	if len(cert.Nonce) != NonceSize {
+		return errors.New("certificate nonce must be set to 32 bytes")
+	}
+	authority, err := NewSigner(ctx, s)
+	if err != nil {
+		return err
+	}
+	nonce := append([]byte(nil), cert.Nonce...)
+	err = cert.SignCert(bytes.NewReader(nonce), authority)
+	if errors.Is(err, ErrNoSignature) {
+		cert.Signature = nil
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,40 @@
+// Package ssh signs with MPC keys through golang.org/x/crypto/ssh, for SSH
+// certificate authorities and host keys whose private key never exists in
+// one place.
+//
+// NewSigner adapts an MPC key to ssh.Signer; SignCertificate signs user and
+// host certificates with it on every party at once:
+//
+//   - ECDSA keys from ecdsa2p and ecdsamp (ECDSA2PSigner, ECDSAMPSigner) on
+//     P-256, P-384 or P-521, as ecdsa-sha2-nistp256, -nistp384 or -nistp521.
+//   - EdDSA keys from schnorr2p and schnorrmp (Schnorr2PSigner,
+//     SchnorrMPSigner) on Ed25519, as ssh-ed25519.
+//
+// # Usage Example
+//
+//	// Every party builds the same certificate from a checked signing request.
+//	cert := &ssh.Certificate{
+//	    Key:             userKey,
+//	    Nonce:           nonce, // agreed by all parties
+//	    CertType:        ssh.UserCert,
+//	    KeyId:           "alice",
+//	    ValidPrincipals: []string{"alice"},
+//	    ValidAfter:      after,
+//	    ValidBefore:     before,
+//	}
+//	signer := &mpcssh.Schnorr2PSigner{Job: job, Key: caKey}
+//	if err := mpcssh.SignCertificate(ctx, signer, cert); err != nil {
+//	    return err
+//	}
+//	authorized := ssh.MarshalAuthorizedKey(cert)
+//
+// # Security Considerations
+//
+//   - Every party signs whatever certificate it is given. Check the key,
+//     principals, validity and critical options on every party before
+//     signing.
+//   - As a host key, the signer is called once per connection with the
+//     session's exchange hash. The other parties must receive that hash from
+//     the host and cannot check what it commits to; run them as a co-signing
+//     service that only the host can reach.
+package ssh
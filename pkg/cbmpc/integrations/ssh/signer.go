@@ -0,0 +1,190 @@
+package ssh
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorr2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorrmp"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// ErrNoSignature is returned by the ssh.Signer from NewSigner on parties that
+// take part in signing but do not receive the signature.
+var ErrNoSignature = errors.New("ssh: party does not receive the signature")
+
+// Signer produces signatures with an MPC key.
+type Signer interface {
+	// Public returns the key as an *ecdsa.PublicKey or ed25519.PublicKey.
+	Public() (crypto.PublicKey, error)
+
+	// Sign signs data: a digest for ECDSA keys, the full message for Ed25519
+	// keys. ECDSA signatures are ASN.1 DER encoded. It returns a nil
+	// signature, and no error, on parties that take part in signing but do
+	// not receive the signature.
+	Sign(ctx context.Context, data []byte) ([]byte, error)
+}
+
+// ECDSA2PSigner signs with a 2-party ECDSA key. Only P1 receives signatures.
+type ECDSA2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *ecdsa2p.Key
+}
+
+// Public implements Signer.
+func (s *ECDSA2PSigner) Public() (crypto.PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.ECDSAPublicKey()
+}
+
+// Sign implements Signer.
+func (s *ECDSA2PSigner) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	res, err := ecdsa2p.Sign(ctx, s.Job, &ecdsa2p.SignParams{Key: s.Key, Message: digest})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// ECDSAMPSigner signs with a multi-party ECDSA key. Only the party at index
+// SigReceiver receives signatures.
+type ECDSAMPSigner struct {
+	Job         *cbmpc.JobMP
+	Key         *ecdsamp.Key
+	SigReceiver int
+}
+
+// Public implements Signer.
+func (s *ECDSAMPSigner) Public() (crypto.PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.ECDSAPublicKey()
+}
+
+// Sign implements Signer.
+func (s *ECDSAMPSigner) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	res, err := ecdsamp.Sign(ctx, s.Job, &ecdsamp.SignParams{Key: s.Key, Message: digest, SigReceiver: s.SigReceiver})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// Schnorr2PSigner signs with a 2-party Ed25519 key.
+type Schnorr2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *schnorr2p.Key
+}
+
+// Public implements Signer.
+func (s *Schnorr2PSigner) Public() (crypto.PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.Ed25519PublicKey()
+}
+
+// Sign implements Signer.
+func (s *Schnorr2PSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	res, err := schnorr2p.Sign(ctx, s.Job, &schnorr2p.SignParams{Key: s.Key, Message: message, Variant: schnorr2p.VariantEdDSA})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// SchnorrMPSigner signs with a multi-party Ed25519 key. Only the party at
+// index SigReceiver receives signatures.
+type SchnorrMPSigner struct {
+	Job         *cbmpc.JobMP
+	Key         *schnorrmp.Key
+	SigReceiver int
+}
+
+// Public implements Signer.
+func (s *SchnorrMPSigner) Public() (crypto.PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.Ed25519PublicKey()
+}
+
+// Sign implements Signer.
+func (s *SchnorrMPSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	res, err := schnorrmp.Sign(ctx, s.Job, &schnorrmp.SignParams{
+		Key:         s.Key,
+		Message:     message,
+		SigReceiver: s.SigReceiver,
+		Variant:     schnorrmp.VariantEdDSA,
+	})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// NewSigner adapts s to golang.org/x/crypto/ssh.Signer, for use as a host
+// key (ssh.ServerConfig.AddHostKey), a client key (ssh.PublicKeys) or a
+// certificate authority (SignCertificate). ssh.Signer has no context, so ctx
+// is used for every signature.
+//
+// Every party must make the same Sign calls in the same order. Parties that
+// do not receive the signature get ErrNoSignature from Sign.
+func NewSigner(ctx context.Context, s Signer) (gossh.Signer, error) {
+	if s == nil {
+		return nil, errors.New("nil signer")
+	}
+	pub, err := s.Public()
+	if err != nil {
+		return nil, err
+	}
+	switch pub.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+	return gossh.NewSignerFromSigner(&cryptoSigner{ctx: ctx, s: s, pub: pub})
+}
+
+// cryptoSigner is the crypto.Signer that gossh.NewSignerFromSigner wraps;
+// it hashes ECDSA input and converts DER signatures to the SSH encoding.
+type cryptoSigner struct {
+	ctx context.Context
+	s   Signer
+	pub crypto.PublicKey
+}
+
+func (c *cryptoSigner) Public() crypto.PublicKey { return c.pub }
+
+func (c *cryptoSigner) Sign(_ io.Reader, data []byte, _ crypto.SignerOpts) ([]byte, error) {
+	sig, err := c.s.Sign(c.ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	if sig == nil {
+		return nil, ErrNoSignature
+	}
+	return sig, nil
+}
+
+func checkCurve(c cbmpc.Curve, err error) error {
+	if err != nil {
+		return err
+	}
+	switch c {
+	case cbmpc.CurveP256, cbmpc.CurveP384, cbmpc.CurveP521, cbmpc.CurveEd25519:
+		return nil
+	}
+	return fmt.Errorf("SSH does not support %s keys", c)
+}
@@ -0,0 +1,197 @@
+package ssh_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	mpcssh "github.com/coinbase/cb-mpc-go/pkg/cbmpc/integrations/ssh"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorr2p"
+	"golang.org/x/crypto/ssh"
+)
+
+// localSigner signs with an in-process key. Without receive set, it behaves
+// like a party that does not receive the signature.
+type localSigner struct {
+	key     crypto.Signer
+	receive bool
+}
+
+func (s localSigner) Public() (crypto.PublicKey, error) { return s.key.Public(), nil }
+
+func (s localSigner) Sign(_ context.Context, data []byte) ([]byte, error) {
+	if !s.receive {
+		return nil, nil
+	}
+	if k, ok := s.key.(*ecdsa.PrivateKey); ok {
+		return ecdsa.SignASN1(rand.Reader, k, data)
+	}
+	return s.key.Sign(rand.Reader, data, crypto.Hash(0))
+}
+
+func userCert(t *testing.T) *ssh.Certificate {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ssh.Certificate{
+		Key:             key,
+		Nonce:           bytes.Repeat([]byte{7}, mpcssh.NonceSize),
+		Serial:          1,
+		CertType:        ssh.UserCert,
+		KeyId:           "alice",
+		ValidPrincipals: []string{"alice"},
+		ValidAfter:      1700000000,
+		ValidBefore:     1700003600,
+	}
+}
+
+func TestSignCertificate(t *testing.T) {
+	ctx := context.Background()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, key := range map[string]crypto.Signer{"ecdsa": ecKey, "ed25519": edKey} {
+		t.Run(name, func(t *testing.T) {
+			cert := userCert(t)
+			nonce := append([]byte(nil), cert.Nonce...)
+			if err := mpcssh.SignCertificate(ctx, localSigner{key: key, receive: true}, cert); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(cert.Nonce, nonce) {
+				t.Fatal("nonce changed")
+			}
+			checker := &ssh.CertChecker{
+				IsUserAuthority: func(auth ssh.PublicKey) bool {
+					return bytes.Equal(auth.Marshal(), cert.SignatureKey.Marshal())
+				},
+				Clock: func() time.Time { return time.Unix(1700000100, 0) },
+			}
+			if err := checker.CheckCert("alice", cert); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNonReceiver(t *testing.T) {
+	ctx := context.Background()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := userCert(t)
+	if err := mpcssh.SignCertificate(ctx, localSigner{key: key}, cert); err != nil {
+		t.Fatal(err)
+	}
+	if cert.Signature != nil {
+		t.Fatal("non-receiver got a signature")
+	}
+
+	signer, err := mpcssh.NewSigner(ctx, localSigner{key: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.Sign(rand.Reader, []byte("data")); !errors.Is(err, mpcssh.ErrNoSignature) {
+		t.Fatalf("Sign on non-receiver error = %v", err)
+	}
+
+	cert.Nonce = nil
+	if err := mpcssh.SignCertificate(ctx, localSigner{key: key, receive: true}, cert); err == nil {
+		t.Fatal("expected error for a certificate without a nonce")
+	}
+}
+
+func TestNewSigner(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := mpcssh.NewSigner(ctx, localSigner{key: key, receive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := signer.PublicKey().Type(); got != ssh.KeyAlgoECDSA256 {
+		t.Fatalf("key type = %s", got)
+	}
+	sig, err := signer.Sign(rand.Reader, []byte("exchange hash"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signer.PublicKey().Verify([]byte("exchange hash"), sig); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mpcssh.NewSigner(ctx, nil); err == nil {
+		t.Fatal("expected error for a nil signer")
+	}
+}
+
+func TestSchnorr2PCertificateAuthority(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	names := [2]string{"p1", "p2"}
+	roles := [2]cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2}
+
+	var keys [2]*schnorr2p.Key
+	certs := [2]*ssh.Certificate{userCert(t), nil}
+	c := *certs[0]
+	certs[1] = &c
+	var errs [2]error
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), roles[i], names)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := schnorr2p.DKG(ctx, job, &schnorr2p.DKGParams{Curve: cbmpc.CurveEd25519})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			keys[i] = res.Key
+			errs[i] = mpcssh.SignCertificate(ctx, &mpcssh.Schnorr2PSigner{Job: job, Key: res.Key}, certs[i])
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d failed: %v", i, err)
+		}
+		defer func() { _ = keys[i].Close() }()
+	}
+	checker := &ssh.CertChecker{
+		IsUserAuthority: func(auth ssh.PublicKey) bool { return true },
+		Clock:           func() time.Time { return time.Unix(1700000100, 0) },
+	}
+	if err := checker.CheckCert("alice", certs[0]); err != nil {
+		t.Fatal(err)
+	}
+}
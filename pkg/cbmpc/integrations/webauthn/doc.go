@@ -0,0 +1,34 @@
+// Package webauthn produces WebAuthn (FIDO2) assertion signatures with P-256
+// ECDSA keys from the ecdsa2p and ecdsamp packages, so a passkey-style
+// backend can hold credentials as MPC key shares.
+//
+// An assertion signature is an ES256 signature, ASN.1 DER encoded, over
+// SHA-256(authenticatorData || SHA-256(clientDataJSON)). SignAssertion builds
+// that digest, runs the MPC signing protocol and checks the result the way a
+// relying party will. NewAuthenticatorData and ClientDataJSON build the two
+// inputs for backends that act as the authenticator and client; COSEKey
+// encodes the credential public key for registration.
+//
+// Only P-256 (secp256r1) credentials are supported; ES256 is the one
+// algorithm every relying party accepts.
+//
+// # Usage Example
+//
+//	authData := webauthn.NewAuthenticatorData("example.com",
+//	    webauthn.FlagUserPresent|webauthn.FlagUserVerified, 0).Marshal()
+//	clientData := webauthn.ClientDataJSON(webauthn.TypeGet, challenge, "https://example.com", false)
+//	signer := &webauthn.ECDSA2PSigner{Job: job, Key: key}
+//	assertion, err := webauthn.SignAssertion(ctx, signer, authData, clientData)
+//	if err != nil {
+//	    return err
+//	}
+//	// On P1, which receives the signature, assertion is non-nil.
+//
+// # Security Considerations
+//
+//   - Every party signs whatever it is given. Check the RP ID hash, origin,
+//     challenge and user verification flag on every party before signing;
+//     signing for the wrong origin defeats phishing resistance.
+//   - A signature counter of 0 tells relying parties the authenticator does
+//     not count. If you count, the parties must agree on the value.
+package webauthn
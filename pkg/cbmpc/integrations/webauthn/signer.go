@@ -0,0 +1,136 @@
+package webauthn
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+)
+
+// ErrBadSignature is returned when an assertion signature does not verify
+// against the credential public key.
+var ErrBadSignature = errors.New("webauthn: signature does not verify")
+
+// Signer signs SHA-256 digests with an MPC key on P-256.
+type Signer interface {
+	// PublicKey returns the credential public key.
+	PublicKey() (*ecdsa.PublicKey, error)
+
+	// SignHash signs hash and returns an ASN.1 DER signature. It returns a
+	// nil signature, and no error, on parties that do not receive the
+	// signature.
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+// ECDSA2PSigner signs with a 2-party ECDSA key. Only P1 receives signatures.
+type ECDSA2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *ecdsa2p.Key
+}
+
+// PublicKey implements Signer.
+func (s *ECDSA2PSigner) PublicKey() (*ecdsa.PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.ECDSAPublicKey()
+}
+
+// SignHash implements Signer.
+func (s *ECDSA2PSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	res, err := ecdsa2p.Sign(ctx, s.Job, &ecdsa2p.SignParams{Key: s.Key, Message: hash})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// ECDSAMPSigner signs with a multi-party ECDSA key. Only the party at index
+// SigReceiver receives signatures.
+type ECDSAMPSigner struct {
+	Job         *cbmpc.JobMP
+	Key         *ecdsamp.Key
+	SigReceiver int
+}
+
+// PublicKey implements Signer.
+func (s *ECDSAMPSigner) PublicKey() (*ecdsa.PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.ECDSAPublicKey()
+}
+
+// SignHash implements Signer.
+func (s *ECDSAMPSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	res, err := ecdsamp.Sign(ctx, s.Job, &ecdsamp.SignParams{Key: s.Key, Message: hash, SigReceiver: s.SigReceiver})
+	if err != nil || len(res.Signature) == 0 {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// Assertion is the part of an authenticator assertion response a backend
+// returns to the relying party, alongside the credential ID and user handle.
+type Assertion struct {
+	AuthenticatorData []byte
+	ClientDataJSON    []byte
+	Signature         []byte // ASN.1 DER ECDSA signature (ES256)
+}
+
+// SignAssertion signs an assertion over authData and clientDataJSON. The
+// signature is checked against the credential public key before it is
+// returned. Every party must call it with the same authenticator data and
+// client data.
+//
+// Parties that do not receive the signature get a nil Assertion and no
+// error.
+func SignAssertion(ctx context.Context, s Signer, authData, clientDataJSON []byte) (*Assertion, error) {
+	if s == nil {
+		return nil, errors.New("nil signer")
+	}
+	if _, err := ParseAuthenticatorData(authData); err != nil {
+		return nil, err
+	}
+	if len(clientDataJSON) == 0 {
+		return nil, errors.New("empty client data")
+	}
+	pub, err := s.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.SignHash(ctx, SignedData(authData, clientDataJSON))
+	if err != nil || sig == nil {
+		return nil, err
+	}
+	if err := VerifyAssertion(pub, authData, clientDataJSON, sig); err != nil {
+		return nil, err
+	}
+	return &Assertion{
+		AuthenticatorData: append([]byte(nil), authData...),
+		ClientDataJSON:    append([]byte(nil), clientDataJSON...),
+		Signature:         sig,
+	}, nil
+}
+
+func checkPublicKey(pub *ecdsa.PublicKey) error {
+	if pub == nil || pub.Curve != elliptic.P256() {
+		return errors.New("credential keys must be P-256")
+	}
+	return nil
+}
+
+func checkCurve(c cbmpc.Curve, err error) error {
+	if err != nil {
+		return err
+	}
+	if c != cbmpc.CurveP256 {
+		return fmt.Errorf("WebAuthn credential keys must be P-256 (got %s)", c)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Authenticator data flags.
+const (
+	FlagUserPresent    byte = 0x01 // UP
+	FlagUserVerified   byte = 0x04 // UV
+	FlagBackupEligible byte = 0x08 // BE
+	FlagBackupState    byte = 0x10 // BS
+)
+
+// Client data types.
+const (
+	TypeGet    = "webauthn.get"
+	TypeCreate = "webauthn.create"
+)
+
+// AlgES256 is the COSE algorithm identifier of ECDSA with SHA-256 on P-256.
+const AlgES256 = -7
+
+// AuthenticatorData is the authenticator data of an assertion. Assertions
+// carry no attested credential data; extensions are not supported.
+type AuthenticatorData struct {
+	RPIDHash  [32]byte
+	Flags     byte
+	SignCount uint32
+}
+
+// NewAuthenticatorData returns authenticator data for rpID with the given
+// flags and signature counter.
+func NewAuthenticatorData(rpID string, flags byte, signCount uint32) *AuthenticatorData {
+	return &AuthenticatorData{RPIDHash: sha256.Sum256([]byte(rpID)), Flags: flags, SignCount: signCount}
+}
+
+// Marshal returns the 37-byte encoding: rpIdHash || flags || signCount.
+func (a *AuthenticatorData) Marshal() []byte {
+	b := make([]byte, 0, 37)
+	b = append(b, a.RPIDHash[:]...)
+	b = append(b, a.Flags)
+	return binary.BigEndian.AppendUint32(b, a.SignCount)
+}
+
+// ParseAuthenticatorData parses authenticator data without attested
+// credential data or extensions.
+func ParseAuthenticatorData(b []byte) (*AuthenticatorData, error) {
+	if len(b) != 37 {
+		return nil, fmt.Errorf("authenticator data must be 37 bytes (got %d)", len(b))
+	}
+	a := &AuthenticatorData{Flags: b[32], SignCount: binary.BigEndian.Uint32(b[33:])}
+	copy(a.RPIDHash[:], b[:32])
+	return a, nil
+}
+
+// ClientDataJSON returns the client data JSON in the serialization the
+// WebAuthn specification defines for clients (section 5.8.1.1), so relying
+// parties that use the limited verification algorithm accept it.
+func ClientDataJSON(typ string, challenge []byte, origin string, crossOrigin bool) []byte {
+	var sb strings.Builder
+	sb.WriteString(`{"type":`)
+	ccdString(&sb, typ)
+	sb.WriteString(`,"challenge":`)
+	ccdString(&sb, base64.RawURLEncoding.EncodeToString(challenge))
+	sb.WriteString(`,"origin":`)
+	ccdString(&sb, origin)
+	if crossOrigin {
+		sb.WriteString(`,"crossOrigin":true}`)
+	} else {
+		sb.WriteString(`,"crossOrigin":false}`)
+	}
+	return []byte(sb.String())
+}
+
+// ccdString writes s as a JSON string the way CCDToString does: only quotes,
+// backslashes and control characters are escaped.
+func ccdString(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r < 0x20:
+			fmt.Fprintf(sb, `\u%04x`, r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+}
+
+// SignedData returns the digest an assertion signature covers:
+// SHA-256(authenticatorData || SHA-256(clientDataJSON)).
+func SignedData(authData, clientDataJSON []byte) []byte {
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	h := sha256.New()
+	h.Write(authData)
+	h.Write(clientDataHash[:])
+	return h.Sum(nil)
+}
+
+// VerifyAssertion checks an assertion signature as a relying party does.
+func VerifyAssertion(pub *ecdsa.PublicKey, authData, clientDataJSON, sig []byte) error {
+	if pub == nil {
+		return errors.New("nil public key")
+	}
+	if !ecdsa.VerifyASN1(pub, SignedData(authData, clientDataJSON), sig) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// COSEKey returns the COSE_Key encoding of a P-256 public key with algorithm
+// ES256, as stored in the attested credential data at registration.
+func COSEKey(pub *ecdsa.PublicKey) ([]byte, error) {
+	if err := checkPublicKey(pub); err != nil {
+		return nil, err
+	}
+	// {1: 2 (EC2), 3: -7 (ES256), -1: 1 (P-256), -2: x, -3: y}
+	b := []byte{0xa5, 0x01, 0x02, 0x03, 0x26, 0x20, 0x01, 0x21, 0x58, 0x20}
+	b = append(b, pub.X.FillBytes(make([]byte, 32))...)
+	b = append(b, 0x22, 0x58, 0x20)
+	return append(b, pub.Y.FillBytes(make([]byte, 32))...), nil
+}
@@ -0,0 +1,175 @@
+package webauthn_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/integrations/webauthn"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// localSigner signs with an in-process P-256 key.
+type localSigner struct {
+	key     *ecdsa.PrivateKey
+	receive bool
+}
+
+func (s localSigner) PublicKey() (*ecdsa.PublicKey, error) { return &s.key.PublicKey, nil }
+
+func (s localSigner) SignHash(_ context.Context, hash []byte) ([]byte, error) {
+	if !s.receive {
+		return nil, nil
+	}
+	return ecdsa.SignASN1(rand.Reader, s.key, hash)
+}
+
+func TestAuthenticatorData(t *testing.T) {
+	a := webauthn.NewAuthenticatorData("example.com", webauthn.FlagUserPresent|webauthn.FlagUserVerified, 258)
+	b := a.Marshal()
+	rp := sha256.Sum256([]byte("example.com"))
+	want := append(rp[:], 0x05, 0, 0, 1, 2)
+	if !bytes.Equal(b, want) {
+		t.Fatalf("Marshal() = %x, want %x", b, want)
+	}
+	parsed, err := webauthn.ParseAuthenticatorData(b)
+	if err != nil || *parsed != *a {
+		t.Fatalf("ParseAuthenticatorData() = %+v, %v", parsed, err)
+	}
+	if _, err := webauthn.ParseAuthenticatorData(b[:36]); err == nil {
+		t.Fatal("expected error for short authenticator data")
+	}
+}
+
+func TestClientDataJSON(t *testing.T) {
+	got := webauthn.ClientDataJSON(webauthn.TypeGet, []byte{0xfb, 0xff}, "https://example.com", false)
+	want := `{"type":"webauthn.get","challenge":"-_8","origin":"https://example.com","crossOrigin":false}`
+	if string(got) != want {
+		t.Fatalf("ClientDataJSON() = %s\nwant %s", got, want)
+	}
+
+	// Escaping follows CCDToString and still parses as JSON.
+	got = webauthn.ClientDataJSON(webauthn.TypeCreate, nil, "https://a\"b\\c\n<&>", true)
+	want = `{"type":"webauthn.create","challenge":"","origin":"https://a\"b\\c\u000a<&>","crossOrigin":true}`
+	if string(got) != want {
+		t.Fatalf("ClientDataJSON() = %s\nwant %s", got, want)
+	}
+	var v struct{ Origin string }
+	if err := json.Unmarshal(got, &v); err != nil || v.Origin != "https://a\"b\\c\n<&>" {
+		t.Fatalf("round trip = %q, %v", v.Origin, err)
+	}
+}
+
+func TestCOSEKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cose, err := webauthn.COSEKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cose) != 77 || cose[0] != 0xa5 {
+		t.Fatalf("COSEKey() = %x", cose)
+	}
+	if !bytes.Equal(cose[10:42], key.X.FillBytes(make([]byte, 32))) || !bytes.Equal(cose[45:], key.Y.FillBytes(make([]byte, 32))) {
+		t.Fatal("COSEKey coordinates mismatch")
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := webauthn.COSEKey(&other.PublicKey); err == nil {
+		t.Fatal("expected error for a P-384 key")
+	}
+}
+
+func TestSignAssertion(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authData := webauthn.NewAuthenticatorData("example.com", webauthn.FlagUserPresent, 0).Marshal()
+	clientData := webauthn.ClientDataJSON(webauthn.TypeGet, []byte("challenge"), "https://example.com", false)
+
+	a, err := webauthn.SignAssertion(ctx, localSigner{key: key, receive: true}, authData, clientData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := webauthn.VerifyAssertion(&key.PublicKey, a.AuthenticatorData, a.ClientDataJSON, a.Signature); err != nil {
+		t.Fatal(err)
+	}
+	// Relying parties hash the client data themselves; signing it raw is a
+	// common mistake this guards against.
+	h := sha256.Sum256(append(append([]byte(nil), authData...), clientData...))
+	if ecdsa.VerifyASN1(&key.PublicKey, h[:], a.Signature) {
+		t.Fatal("signature covers unhashed client data")
+	}
+	other := webauthn.ClientDataJSON(webauthn.TypeGet, []byte("other"), "https://example.com", false)
+	if err := webauthn.VerifyAssertion(&key.PublicKey, authData, other, a.Signature); !errors.Is(err, webauthn.ErrBadSignature) {
+		t.Fatalf("other client data error = %v", err)
+	}
+
+	if a, err := webauthn.SignAssertion(ctx, localSigner{key: key}, authData, clientData); a != nil || err != nil {
+		t.Fatalf("SignAssertion on non-receiver = %v, %v", a, err)
+	}
+	if _, err := webauthn.SignAssertion(ctx, localSigner{key: key, receive: true}, authData[:10], clientData); err == nil {
+		t.Fatal("expected error for malformed authenticator data")
+	}
+}
+
+func TestECDSA2PSigner(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	names := [2]string{"p1", "p2"}
+	roles := [2]cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2}
+	authData := webauthn.NewAuthenticatorData("example.com", webauthn.FlagUserPresent, 0).Marshal()
+	clientData := webauthn.ClientDataJSON(webauthn.TypeGet, []byte("challenge"), "https://example.com", false)
+
+	var keys [2]*ecdsa2p.Key
+	var assertions [2]*webauthn.Assertion
+	var errs [2]error
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), roles[i], names)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			keys[i] = res.Key
+			assertions[i], errs[i] = webauthn.SignAssertion(ctx, &webauthn.ECDSA2PSigner{Job: job, Key: res.Key}, authData, clientData)
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d failed: %v", i, err)
+		}
+		defer func() { _ = keys[i].Close() }()
+	}
+	if assertions[0] == nil {
+		t.Fatal("P1 received no assertion")
+	}
+}
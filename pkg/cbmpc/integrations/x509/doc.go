@@ -0,0 +1,36 @@
+// Package x509 issues X.509 certificates and certificate signing requests
+// with MPC keys, so a certificate authority's private key never exists in
+// one place.
+//
+// NewCryptoSigner adapts an MPC key to crypto.Signer; CreateCertificate and
+// CreateCertificateRequest wrap the crypto/x509 functions of the same name
+// with the checks needed to run them on every party at once:
+//
+//   - ECDSA keys from ecdsa2p and ecdsamp (ECDSA2PSigner, ECDSAMPSigner) on
+//     P-256, P-384 or P-521. crypto/x509 picks SHA-256, SHA-384 or SHA-512
+//     to match the curve.
+//   - EdDSA keys from schnorr2p and schnorrmp (Schnorr2PSigner,
+//     SchnorrMPSigner) on Ed25519.
+//
+// secp256k1 keys are rejected: crypto/x509 cannot encode them.
+//
+// # Usage Example
+//
+//	// Every party builds the same template, e.g. from a signed issuance request.
+//	signer := &x509.ECDSA2PSigner{Job: job, Key: caKey}
+//	der, err := x509.CreateCertificate(ctx, signer, leafTemplate, caCert, csr.PublicKey)
+//	if err != nil {
+//	    return err
+//	}
+//	// On P1, which receives the signature:
+//	cert, err := stdx509.ParseCertificate(der)
+//
+// # Security Considerations
+//
+//   - Every party signs whatever template it is given. Check the subject,
+//     key usages, validity and CA constraints on every party before signing;
+//     an unchecked template lets one party issue an intermediate CA.
+//   - Templates must be identical on every party, including the serial
+//     number and validity period; parties that disagree are not signing the
+//     same certificate.
+package x509
@@ -0,0 +1,209 @@
+package x509
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorr2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorrmp"
+)
+
+// ErrNoSignature is returned by the crypto.Signer from NewCryptoSigner on
+// parties that take part in signing but do not receive the signature.
+var ErrNoSignature = errors.New("x509: party does not receive the signature")
+
+// Signer produces signatures with an MPC key.
+type Signer interface {
+	// Public returns the key as an *ecdsa.PublicKey or ed25519.PublicKey.
+	Public() (crypto.PublicKey, error)
+
+	// Sign signs data: a digest for ECDSA keys, the full message for Ed25519
+	// keys. ECDSA signatures are ASN.1 DER encoded. It returns a nil
+	// signature, and no error, on parties that take part in signing but do
+	// not receive the signature.
+	Sign(ctx context.Context, data []byte) ([]byte, error)
+}
+
+// ECDSA2PSigner signs with a 2-party ECDSA key. Only P1 receives signatures.
+type ECDSA2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *ecdsa2p.Key
+}
+
+// Public implements Signer.
+func (s *ECDSA2PSigner) Public() (crypto.PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.ECDSAPublicKey()
+}
+
+// Sign implements Signer.
+func (s *ECDSA2PSigner) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	res, err := ecdsa2p.Sign(ctx, s.Job, &ecdsa2p.SignParams{Key: s.Key, Message: digest})
+	if err != nil {
+		return nil, err
+	}
+	return nonEmpty(res.Signature), nil
+}
+
+// ECDSAMPSigner signs with a multi-party ECDSA key. Only the party at index
+// SigReceiver receives signatures.
+type ECDSAMPSigner struct {
+	Job         *cbmpc.JobMP
+	Key         *ecdsamp.Key
+	SigReceiver int
+}
+
+// Public implements Signer.
+func (s *ECDSAMPSigner) Public() (crypto.PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.ECDSAPublicKey()
+}
+
+// Sign implements Signer.
+func (s *ECDSAMPSigner) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	res, err := ecdsamp.Sign(ctx, s.Job, &ecdsamp.SignParams{Key: s.Key, Message: digest, SigReceiver: s.SigReceiver})
+	if err != nil {
+		return nil, err
+	}
+	return nonEmpty(res.Signature), nil
+}
+
+// Schnorr2PSigner signs with a 2-party EdDSA key.
+type Schnorr2PSigner struct {
+	Job *cbmpc.Job2P
+	Key *schnorr2p.Key
+}
+
+// Public implements Signer.
+func (s *Schnorr2PSigner) Public() (crypto.PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.Ed25519PublicKey()
+}
+
+// Sign implements Signer.
+func (s *Schnorr2PSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	res, err := schnorr2p.Sign(ctx, s.Job, &schnorr2p.SignParams{Key: s.Key, Message: message, Variant: schnorr2p.VariantEdDSA})
+	if err != nil {
+		return nil, err
+	}
+	return nonEmpty(res.Signature), nil
+}
+
+// SchnorrMPSigner signs with a multi-party EdDSA key. Only the party at
+// index SigReceiver receives signatures.
+type SchnorrMPSigner struct {
+	Job         *cbmpc.JobMP
+	Key         *schnorrmp.Key
+	SigReceiver int
+}
+
+// Public implements Signer.
+func (s *SchnorrMPSigner) Public() (crypto.PublicKey, error) {
+	if err := checkCurve(s.Key.Curve()); err != nil {
+		return nil, err
+	}
+	return s.Key.Ed25519PublicKey()
+}
+
+// Sign implements Signer.
+func (s *SchnorrMPSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	res, err := schnorrmp.Sign(ctx, s.Job, &schnorrmp.SignParams{
+		Key:         s.Key,
+		Message:     message,
+		SigReceiver: s.SigReceiver,
+		Variant:     schnorrmp.VariantEdDSA,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nonEmpty(res.Signature), nil
+}
+
+// NewCryptoSigner adapts s to crypto.Signer, so it can be passed to
+// crypto/x509, crypto/tls or any other standard library API that takes a
+// private key. crypto.Signer has no context, so ctx is used for every
+// signature; its rand argument is ignored, since the MPC protocol draws its
+// own randomness.
+//
+// Every party must make the same Sign calls in the same order. Parties that
+// do not receive the signature get ErrNoSignature from Sign.
+func NewCryptoSigner(ctx context.Context, s Signer) (crypto.Signer, error) {
+	if s == nil {
+		return nil, errors.New("nil signer")
+	}
+	pub, err := s.Public()
+	if err != nil {
+		return nil, err
+	}
+	switch pub.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+	return &cryptoSigner{ctx: ctx, s: s, pub: pub}, nil
+}
+
+type cryptoSigner struct {
+	ctx context.Context
+	s   Signer
+	pub crypto.PublicKey
+}
+
+func (c *cryptoSigner) Public() crypto.PublicKey { return c.pub }
+
+func (c *cryptoSigner) Sign(_ io.Reader, data []byte, opts crypto.SignerOpts) ([]byte, error) {
+	h := opts.HashFunc()
+	switch c.pub.(type) {
+	case *ecdsa.PublicKey:
+		if h == 0 {
+			return nil, errors.New("ECDSA signing requires a digest")
+		}
+		if len(data) != h.Size() {
+			return nil, fmt.Errorf("digest is %d bytes, %v needs %d", len(data), h, h.Size())
+		}
+	case ed25519.PublicKey:
+		if h != 0 {
+			return nil, errors.New("pre-hashed Ed25519 (Ed25519ph) is not supported")
+		}
+	}
+	sig, err := c.s.Sign(c.ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	if sig == nil {
+		return nil, ErrNoSignature
+	}
+	return sig, nil
+}
+
+func nonEmpty(sig []byte) []byte {
+	if len(sig) == 0 {
+		return nil
+	}
+	return sig
+}
+
+func checkCurve(c cbmpc.Curve, err error) error {
+	if err != nil {
+		return err
+	}
+	switch c {
+	case cbmpc.CurveP256, cbmpc.CurveP384, cbmpc.CurveP521, cbmpc.CurveEd25519:
+		return nil
+	}
+	return fmt.Errorf("crypto/x509 does not support %s keys", c)
+}
@@ -0,0 +1,59 @@
+package x509
+
+import (
+	"context"
+	"crypto/rand"
+	stdx509 "crypto/x509"
+	"errors"
+)
+
+// CreateCertificateRequest creates a DER-encoded PKCS #10 certificate signing
+// request for the MPC key behind s, as crypto/x509.CreateCertificateRequest
+// does for a local key. Every party must call it with the same template.
+//
+// Parties that do not receive the signature get a nil CSR and no error.
+func CreateCertificateRequest(ctx context.Context, s Signer, template *stdx509.CertificateRequest) ([]byte, error) {
+	if template == nil {
+		return nil, errors.New("nil template")
+	}
+	priv, err := NewCryptoSigner(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	csr, err := stdx509.CreateCertificateRequest(rand.Reader, template, priv)
+	if errors.Is(err, ErrNoSignature) {
+		return nil, nil
+	}
+	return csr, err
+}
+
+// CreateCertificate issues a DER-encoded certificate for pub, signed by the
+// MPC key behind s, as crypto/x509.CreateCertificate does for a local key.
+// parent is the issuing certificate, parsed from the DER the receiving party
+// published, since fields crypto/x509 fills in (such as the subject key ID)
+// are copied into the child. Pass template itself, with pub set to s's
+// public key, for a self-signed CA certificate.
+//
+// Every party must call it with the same template, parent and pub, so that
+// all of them sign the same TBSCertificate. template.SerialNumber must be
+// set: crypto/x509 would otherwise pick a random serial on each party.
+//
+// Parties that do not receive the signature get a nil certificate and no
+// error.
+func CreateCertificate(ctx context.Context, s Signer, template, parent *stdx509.Certificate, pub any) ([]byte, error) {
+	if template == nil || parent == nil {
+		return nil, errors.New("nil template or parent")
+	}
+	if template.SerialNumber == nil {
+		return nil, errors.New("template has no serial number")
+	}
+	priv, err := NewCryptoSigner(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := stdx509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	if errors.Is(err, ErrNoSignature) {
+		return nil, nil
+	}
+	return cert, err
+}
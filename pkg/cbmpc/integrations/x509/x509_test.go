@@ -0,0 +1,236 @@
+package x509_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/integrations/x509"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// localSigner signs with an in-process key. Without receive set, it behaves
+// like a party that does not receive the signature.
+type localSigner struct {
+	key     crypto.Signer
+	receive bool
+}
+
+func (s localSigner) Public() (crypto.PublicKey, error) { return s.key.Public(), nil }
+
+func (s localSigner) Sign(_ context.Context, data []byte) ([]byte, error) {
+	if !s.receive {
+		return nil, nil
+	}
+	var opts crypto.SignerOpts = crypto.Hash(0)
+	if _, ok := s.key.(*ecdsa.PrivateKey); ok {
+		opts = crypto.SHA256
+	}
+	return s.key.Sign(rand.Reader, data, opts)
+}
+
+func caTemplate() *stdx509.Certificate {
+	return &stdx509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "MPC Root CA"},
+		NotBefore:             time.Unix(1700000000, 0),
+		NotAfter:              time.Unix(1700000000, 0).AddDate(10, 0, 0),
+		KeyUsage:              stdx509.KeyUsageCertSign | stdx509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+}
+
+func TestIssueChain(t *testing.T) {
+	ctx := context.Background()
+	for name, newKey := range map[string]func() (crypto.Signer, error){
+		"ecdsa": func() (crypto.Signer, error) { return ecdsa.GenerateKey(elliptic.P256(), rand.Reader) },
+		"ed25519": func() (crypto.Signer, error) {
+			_, k, err := ed25519.GenerateKey(rand.Reader)
+			return k, err
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			caKey, err := newKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			ca := localSigner{key: caKey, receive: true}
+			tmpl := caTemplate()
+			der, err := x509.CreateCertificate(ctx, ca, tmpl, tmpl, caKey.Public())
+			if err != nil {
+				t.Fatal(err)
+			}
+			caCert, err := stdx509.ParseCertificate(der)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			leafKey, err := newKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			csrDER, err := x509.CreateCertificateRequest(ctx, localSigner{key: leafKey, receive: true},
+				&stdx509.CertificateRequest{Subject: pkix.Name{CommonName: "svc"}, DNSNames: []string{"svc.example"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			csr, err := stdx509.ParseCertificateRequest(csrDER)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := csr.CheckSignature(); err != nil {
+				t.Fatal(err)
+			}
+
+			leafTmpl := &stdx509.Certificate{
+				SerialNumber: big.NewInt(2),
+				Subject:      csr.Subject,
+				DNSNames:     csr.DNSNames,
+				NotBefore:    caCert.NotBefore,
+				NotAfter:     caCert.NotBefore.AddDate(1, 0, 0),
+				KeyUsage:     stdx509.KeyUsageDigitalSignature,
+				ExtKeyUsage:  []stdx509.ExtKeyUsage{stdx509.ExtKeyUsageServerAuth},
+			}
+			leafDER, err := x509.CreateCertificate(ctx, ca, leafTmpl, caCert, csr.PublicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			leaf, err := stdx509.ParseCertificate(leafDER)
+			if err != nil {
+				t.Fatal(err)
+			}
+			roots := stdx509.NewCertPool()
+			roots.AddCert(caCert)
+			if _, err := leaf.Verify(stdx509.VerifyOptions{
+				DNSName:     "svc.example",
+				Roots:       roots,
+				CurrentTime: leaf.NotBefore.Add(time.Hour),
+			}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNonReceiver(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := localSigner{key: key}
+	tmpl := caTemplate()
+	if der, err := x509.CreateCertificate(ctx, s, tmpl, tmpl, key.Public()); der != nil || err != nil {
+		t.Fatalf("CreateCertificate on non-receiver = %x, %v", der, err)
+	}
+	if der, err := x509.CreateCertificateRequest(ctx, s, &stdx509.CertificateRequest{}); der != nil || err != nil {
+		t.Fatalf("CreateCertificateRequest on non-receiver = %x, %v", der, err)
+	}
+
+	priv, err := x509.NewCryptoSigner(ctx, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("msg"))
+	if _, err := priv.Sign(rand.Reader, digest[:], crypto.SHA256); !errors.Is(err, x509.ErrNoSignature) {
+		t.Fatalf("Sign on non-receiver error = %v", err)
+	}
+}
+
+func TestCryptoSignerChecks(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, err := x509.NewCryptoSigner(ctx, localSigner{key: key, receive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := priv.Sign(rand.Reader, []byte("not a digest"), crypto.Hash(0)); err == nil {
+		t.Fatal("expected error for unhashed ECDSA input")
+	}
+	if _, err := priv.Sign(rand.Reader, make([]byte, 20), crypto.SHA256); err == nil {
+		t.Fatal("expected error for a digest of the wrong size")
+	}
+
+	tmpl := caTemplate()
+	tmpl.SerialNumber = nil
+	if _, err := x509.CreateCertificate(ctx, localSigner{key: key, receive: true}, tmpl, tmpl, key.Public()); err == nil {
+		t.Fatal("expected error for a template without a serial number")
+	}
+	if _, err := x509.NewCryptoSigner(ctx, nil); err == nil {
+		t.Fatal("expected error for a nil signer")
+	}
+}
+
+func TestECDSA2PCertificateAuthority(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	names := [2]string{"p1", "p2"}
+	roles := [2]cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2}
+
+	var keys [2]*ecdsa2p.Key
+	var certs [2][]byte
+	var errs [2]error
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), roles[i], names)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			keys[i] = res.Key
+			signer := &x509.ECDSA2PSigner{Job: job, Key: res.Key}
+			pub, err := signer.Public()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			tmpl := caTemplate()
+			certs[i], errs[i] = x509.CreateCertificate(ctx, signer, tmpl, tmpl, pub)
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d failed: %v", i, err)
+		}
+		defer func() { _ = keys[i].Close() }()
+	}
+	if certs[1] != nil {
+		t.Fatal("P2 received a certificate")
+	}
+	cert, err := stdx509.ParseCertificate(certs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cert.CheckSignatureFrom(cert); err != nil {
+		t.Fatal(err)
+	}
+}
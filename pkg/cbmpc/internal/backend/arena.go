@@ -0,0 +1,56 @@
+package backend
+
+import "unsafe"
+
+// maxPooledArena bounds the buffer an arena keeps between calls, so one very
+// large batch does not hold its memory for the life of the process.
+const maxPooledArena = 1 << 20
+
+// packSlices returns the concatenation of slices, which total bytes long. When
+// the slices already lie back to back in one backing array, as when a caller
+// cuts a batch out of a single buffer, it returns a view of that array and
+// copied is false. Otherwise it copies them into buf, growing it if needed.
+func packSlices(buf []byte, slices [][]byte, total int) (data []byte, copied bool) {
+	if total == 0 {
+		return nil, false
+	}
+	if view, ok := contiguous(slices, total); ok {
+		return view, false
+	}
+	if cap(buf) < total {
+		buf = make([]byte, total)
+	}
+	data = buf[:total]
+	off := 0
+	for _, s := range slices {
+		off += copy(data[off:], s)
+	}
+	return data, true
+}
+
+// contiguous reports whether the non-empty slices are consecutive regions of
+// one backing array and, if so, returns the region they cover. Requiring the
+// first slice's capacity to span the whole region rules out adjacent but
+// distinct allocations.
+func contiguous(slices [][]byte, total int) ([]byte, bool) {
+	var (
+		first []byte
+		next  unsafe.Pointer
+	)
+	for _, s := range slices {
+		if len(s) == 0 {
+			continue
+		}
+		p := unsafe.Pointer(unsafe.SliceData(s))
+		if first == nil {
+			first = s
+		} else if p != next {
+			return nil, false
+		}
+		next = unsafe.Add(p, len(s))
+	}
+	if first == nil || cap(first) < total {
+		return nil, false
+	}
+	return first[:total], true
+}
@@ -0,0 +1,51 @@
+package backend
+
+import (
+	"bytes"
+	"testing"
+	"unsafe"
+)
+
+func TestPackSlicesZeroCopy(t *testing.T) {
+	buf := []byte("aaaabbbbcccc")
+	slices := [][]byte{buf[0:4], buf[4:8], {}, buf[8:12]}
+	data, copied := packSlices(nil, slices, 12)
+	if copied {
+		t.Fatal("contiguous slices were copied")
+	}
+	if unsafe.SliceData(data) != unsafe.SliceData(buf) || !bytes.Equal(data, buf) {
+		t.Fatal("zero-copy view does not alias the backing array")
+	}
+}
+
+func TestPackSlicesCopies(t *testing.T) {
+	cases := map[string][][]byte{
+		"separate":  {[]byte("aaaa"), []byte("bbbb")},
+		"reordered": {[]byte("aaaabbbb")[4:], []byte("aaaabbbb")[:4]},
+	}
+	// A slice capped at its length cannot vouch for the bytes after it.
+	backing := []byte("aaaabbbb")
+	cases["capped"] = [][]byte{backing[0:4:4], backing[4:8]}
+
+	for name, slices := range cases {
+		t.Run(name, func(t *testing.T) {
+			buf := make([]byte, 0, 4)
+			data, copied := packSlices(buf, slices, 8)
+			if !copied {
+				t.Fatal("non-contiguous slices were not copied")
+			}
+			if !bytes.Equal(data, bytes.Join(slices, nil)) {
+				t.Fatalf("packed %q", data)
+			}
+		})
+	}
+
+	buf := make([]byte, 0, 16)
+	data, _ := packSlices(buf, [][]byte{[]byte("ab"), []byte("cd")}, 4)
+	if unsafe.SliceData(data) != unsafe.SliceData(buf) {
+		t.Fatal("buffer with enough capacity was not reused")
+	}
+	if data, copied := packSlices(nil, [][]byte{{}, {}}, 0); data != nil || copied {
+		t.Fatal("empty input should produce no data")
+	}
+}
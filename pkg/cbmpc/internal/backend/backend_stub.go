@@ -66,5 +66,13 @@ var ErrNotBuilt = errors.New("cbmpc/internal/bindings: native bindings not built
 // key leak and the key should be considered compromised.
 var ErrBitLeak = errors.New("bit leak detected in signature verification")
 
+// ErrKeyInvalid is returned when a key share fails the native consistency
+// checks, for example a share that does not match its public key.
+var ErrKeyInvalid = errors.New("key share failed consistency checks")
+
+// ErrCommitMismatch is returned when a hash commitment does not open to the
+// claimed message.
+var ErrCommitMismatch = errors.New("commitment does not match opening")
+
 // Version returns the version string from the native library, or empty if not available.
 func Version() string { return "" }
@@ -41,8 +41,10 @@ type transport interface {
 // handle is an opaque reference to a registered Go object that can be passed to C code.
 type handle uintptr
 
+// The registry is read on every transport callback, so lookups take a shared
+// lock; large committees otherwise serialize all parties on this mutex.
 var (
-	mu   sync.Mutex
+	mu   sync.RWMutex
 	next handle = 1
 	reg         = map[handle]any{}
 )
@@ -69,9 +71,9 @@ func get(ptr unsafe.Pointer) (any, bool) {
 		return nil, false
 	}
 	h := handle(uintptr(ptr))
-	mu.Lock()
+	mu.RLock()
 	v, ok := reg[h]
-	mu.Unlock()
+	mu.RUnlock()
 	return v, ok
 }
 
@@ -10,6 +10,7 @@ package backend
 import "C"
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"runtime"
@@ -76,6 +77,62 @@ func MultiPairwiseAgreeRandom(cj unsafe.Pointer, bitlen int) ([][]byte, error) {
 	return cmemsToGoByteSlices(out), nil
 }
 
+// OTSend is a C binding wrapper for the sending side of the native oblivious
+// transfer (base OT followed by OT extension): one OT per pair (x0[i], x1[i]).
+func OTSend(cj unsafe.Pointer, sid []byte, x0, x1 [][]byte) error {
+	if cj == nil {
+		return errors.New("nil job")
+	}
+	if len(sid) == 0 {
+		return errors.New("empty session ID")
+	}
+	if len(x0) == 0 || len(x0) != len(x1) {
+		return errors.New("mismatched message pairs")
+	}
+
+	// Copy the session ID into C-allocated memory and pin the messages for
+	// the duration of the protocol
+	sidMem := allocCmem(sid)
+	defer freeCmem(sidMem)
+	x0Mem, x0Arena := pinCmems(x0)
+	defer x0Arena.release()
+	x1Mem, x1Arena := pinCmems(x1)
+	defer x1Arena.release()
+
+	rc := C.cbmpc_ot_send((*C.cbmpc_job2p)(cj), sidMem, x0Mem, x1Mem)
+	if rc != 0 {
+		return formatNativeErr("ot_send", rc)
+	}
+	return nil
+}
+
+// OTReceive is a C binding wrapper for the receiving side of the native
+// oblivious transfer. choices holds count bits, least significant bit first;
+// it returns the count chosen messages, each msgLen bytes.
+func OTReceive(cj unsafe.Pointer, sid, choices []byte, count, msgLen int) ([][]byte, error) {
+	if cj == nil {
+		return nil, errors.New("nil job")
+	}
+	if len(sid) == 0 {
+		return nil, errors.New("empty session ID")
+	}
+	if count <= 0 || len(choices) != (count+7)/8 {
+		return nil, errors.New("invalid choices")
+	}
+
+	sidMem := allocCmem(sid)
+	defer freeCmem(sidMem)
+	choicesMem := allocCmem(choices)
+	defer freeCmem(choicesMem)
+
+	var out C.cmems_t
+	rc := C.cbmpc_ot_receive((*C.cbmpc_job2p)(cj), sidMem, choicesMem, C.int(count), C.int(msgLen), &out)
+	if rc != 0 {
+		return nil, formatNativeErr("ot_receive", rc)
+	}
+	return cmemsToGoByteSlices(out), nil
+}
+
 // ECDSA2PDKG is a C binding wrapper for 2-party ECDSA distributed key generation.
 func ECDSA2PDKG(cj unsafe.Pointer, curveNID int) (ECDSA2PKey, error) {
 	if cj == nil {
@@ -107,6 +164,85 @@ func ECDSA2PRefresh(cj unsafe.Pointer, key ECDSA2PKey) (ECDSA2PKey, error) {
 	return newKey, nil
 }
 
+// ECDSA2PImport is a C binding wrapper for importing an existing private key
+// into a 2-party ECDSA key. importer is 0 for P1 and 1 for P2; only the
+// importer passes x. expectedQ may be nil.
+func ECDSA2PImport(cj unsafe.Pointer, curveNID, importer int, x, expectedQ []byte) (ECDSA2PKey, error) {
+	if cj == nil {
+		return nil, errors.New("nil job")
+	}
+
+	// Copy inputs into C-allocated memory to avoid aliasing Go memory during CGO call
+	xMem := allocCmem(x)
+	defer freeCmem(xMem)
+	qMem := allocCmem(expectedQ)
+	defer freeCmem(qMem)
+
+	var key ECDSA2PKey
+	rc := C.cbmpc_ecdsa2p_import((*C.cbmpc_job2p)(cj), C.int(curveNID), C.int(importer), xMem, qMem, &key)
+	if rc != 0 {
+		return nil, formatNativeErr("ecdsa2p_import", rc)
+	}
+	return key, nil
+}
+
+// ECDSA2PExport is a C binding wrapper that reconstructs the private key of a
+// 2-party ECDSA key. Both parties receive the scalar.
+func ECDSA2PExport(cj unsafe.Pointer, key ECDSA2PKey) ([]byte, error) {
+	if cj == nil {
+		return nil, errors.New("nil job")
+	}
+	if key == nil {
+		return nil, errors.New("nil key")
+	}
+
+	var out C.cmem_t
+	rc := C.cbmpc_ecdsa2p_export((*C.cbmpc_job2p)(cj), key, &out)
+	if rc != 0 {
+		return nil, formatNativeErr("ecdsa2p_export", rc)
+	}
+	return cmemToGoBytes(out), nil
+}
+
+// ECDSAVerifyMany verifies independent ECDSA signatures on one curve in a
+// single native call. The returned slice holds one entry per signature: nil
+// if it verified, otherwise the verification error. The error return reports
+// malformed input only.
+func ECDSAVerifyMany(curveNID int, pubKeys, hashes, sigs [][]byte) ([]error, error) {
+	n := len(sigs)
+	if n == 0 {
+		return nil, errors.New("empty signatures")
+	}
+	if len(pubKeys) != n || len(hashes) != n {
+		return nil, errors.New("public keys, hashes and signatures count mismatch")
+	}
+
+	pubKeysMem, pubKeysArena := pinCmems(pubKeys)
+	defer pubKeysArena.release()
+	hashesMem, hashesArena := pinCmems(hashes)
+	defer hashesArena.release()
+	sigsMem, sigsArena := pinCmems(sigs)
+	defer sigsArena.release()
+
+	results := make([]C.int, n)
+	rc := C.cbmpc_ecdsa_verify_many(C.int(curveNID), pubKeysMem, hashesMem, sigsMem, C.int(n), &results[0])
+	if rc != 0 {
+		return nil, formatNativeErr("ecdsa_verify_many", rc)
+	}
+	return verifyManyResults("ecdsa_verify", results), nil
+}
+
+// verifyManyResults converts per-item native results into errors.
+func verifyManyResults(op string, results []C.int) []error {
+	errs := make([]error, len(results))
+	for i, r := range results {
+		if r != 0 {
+			errs[i] = formatNativeErr(op, r)
+		}
+	}
+	return errs
+}
+
 // ECDSA2PSign is a C binding wrapper for 2-party ECDSA signing.
 func ECDSA2PSign(cj unsafe.Pointer, key ECDSA2PKey, sidIn, msg []byte) ([]byte, []byte, error) {
 	if cj == nil {
@@ -146,11 +282,11 @@ func ECDSA2PSignBatch(cj unsafe.Pointer, key ECDSA2PKey, sidIn []byte, msgs [][]
 		return nil, nil, errors.New("empty messages")
 	}
 
-	// Copy session ID and messages into C-allocated memory to avoid aliasing Go memory during CGO call
+	// Copy the session ID into C-allocated memory; the messages are pinned for the duration of the call
 	sidMem := allocCmem(sidIn)
 	defer freeCmem(sidMem)
-	msgsMem := goBytesSliceToCmems(msgs)
-	defer freeCmems(msgsMem)
+	msgsMem, msgsArena := pinCmems(msgs)
+	defer msgsArena.release()
 
 	var sidOut C.cmem_t
 	var sigsOut C.cmems_t
@@ -206,11 +342,11 @@ func ECDSA2PSignWithGlobalAbortBatch(cj unsafe.Pointer, key ECDSA2PKey, sidIn []
 		return nil, nil, errors.New("empty messages")
 	}
 
-	// Copy session ID and messages into C-allocated memory to avoid aliasing Go memory during CGO call
+	// Copy the session ID into C-allocated memory; the messages are pinned for the duration of the call
 	sidMem := allocCmem(sidIn)
 	defer freeCmem(sidMem)
-	msgsMem := goBytesSliceToCmems(msgs)
-	defer freeCmems(msgsMem)
+	msgsMem, msgsArena := pinCmems(msgs)
+	defer msgsArena.release()
 
 	var sidOut C.cmem_t
 	var sigsOut C.cmems_t
@@ -420,8 +556,8 @@ func PVEBatchEncrypt(k KEM, ekBytes, label []byte, curveNID int, xScalarsBytes [
 
 	ekMem := goBytesToCmem(ekBytes)
 	labelMem := goBytesToCmem(label)
-	xScalarsMem := goBytesSliceToCmems(xScalarsBytes)
-	defer freeCmems(xScalarsMem)
+	xScalarsMem, xScalarsArena := pinCmems(xScalarsBytes)
+	defer xScalarsArena.release()
 
 	var out C.cmem_t
 	rc := C.cbmpc_pve_batch_encrypt(ekMem, labelMem, C.int(curveNID), xScalarsMem, &out)
@@ -697,6 +833,48 @@ func UCDLVerify(proof []byte, qPoint ECCPoint, sessionID []byte, aux uint64) err
 	return nil
 }
 
+// UCDLVerifyMany verifies independent UC_DL proofs in a single native call.
+// The returned slice holds one entry per proof: nil if it verified, otherwise
+// the verification error. The error return reports malformed input only.
+func UCDLVerifyMany(proofs [][]byte, qPoints []ECCPoint, sessionIDs [][]byte, auxs []uint64) ([]error, error) {
+	n := len(proofs)
+	if n == 0 {
+		return nil, errors.New("empty proofs")
+	}
+	if len(qPoints) != n || len(sessionIDs) != n || len(auxs) != n {
+		return nil, errors.New("proofs, Q points, session IDs and aux count mismatch")
+	}
+
+	cPoints := make([]C.cbmpc_ecc_point, n)
+	cAuxs := make([]C.uint64_t, n)
+	for i := range proofs {
+		if qPoints[i] == nil {
+			return nil, errors.New("nil point in Q points array")
+		}
+		cPoints[i] = qPoints[i]
+		cAuxs[i] = C.uint64_t(auxs[i])
+	}
+
+	proofsMem, proofsArena := pinCmems(proofs)
+	defer proofsArena.release()
+	sessionIDsMem, sessionIDsArena := pinCmems(sessionIDs)
+	defer sessionIDsArena.release()
+
+	results := make([]C.int, n)
+	rc := C.cbmpc_uc_dl_verify_many(proofsMem, &cPoints[0], sessionIDsMem, &cAuxs[0], C.int(n), &results[0])
+	if rc != 0 {
+		return nil, formatNativeErr("uc_dl_verify_many", rc)
+	}
+
+	errs := make([]error, n)
+	for i, r := range results {
+		if r != 0 {
+			errs[i] = formatNativeErr("uc_dl_verify", r)
+		}
+	}
+	return errs, nil
+}
+
 // =====================
 // ZK Proof Operations - UC_Batch_DL
 // =====================
@@ -726,8 +904,8 @@ func UCBatchDLProve(qPoints []ECCPoint, wScalarsBytes [][]byte, sessionID []byte
 		cPoints[i] = p
 	}
 
-	wScalarsMem := goBytesSliceToCmems(wScalarsBytes)
-	defer freeCmems(wScalarsMem)
+	wScalarsMem, wScalarsArena := pinCmems(wScalarsBytes)
+	defer wScalarsArena.release()
 	sessionIDMem := goBytesToCmem(sessionID)
 
 	var out C.cmem_t
@@ -1157,7 +1335,10 @@ func ECDSAMPRefresh(cj unsafe.Pointer, key ECDSAMPKey, sidIn []byte) (ECDSAMPKey
 }
 
 // ECDSAMPSign is a C binding wrapper for multi-party ECDSA signing.
-func ECDSAMPSign(cj unsafe.Pointer, key ECDSAMPKey, msg []byte, sigReceiver int) ([]byte, error) {
+// acBytes is the serialized access structure of a threshold key, which is
+// converted to an additive share over the job's parties; it is empty for an
+// n-of-n key.
+func ECDSAMPSign(cj unsafe.Pointer, key ECDSAMPKey, acBytes, msg []byte, sigReceiver int) ([]byte, error) {
 	if cj == nil {
 		return nil, errors.New("nil job")
 	}
@@ -1168,12 +1349,16 @@ func ECDSAMPSign(cj unsafe.Pointer, key ECDSAMPKey, msg []byte, sigReceiver int)
 		return nil, errors.New("empty message")
 	}
 
+	// Copy the access structure into C-allocated memory for the signing operation
+	acMem := allocCmem(acBytes)
+	defer freeCmem(acMem)
+
 	// Copy message into C-allocated memory for the signing operation
 	msgMem := allocCmem(msg)
 	defer freeCmem(msgMem)
 
 	var sigOut C.cmem_t
-	rc := C.cbmpc_ecdsamp_sign((*C.cbmpc_jobmp)(cj), key, msgMem, C.int(sigReceiver), &sigOut)
+	rc := C.cbmpc_ecdsamp_sign((*C.cbmpc_jobmp)(cj), key, acMem, msgMem, C.int(sigReceiver), &sigOut)
 	if rc != 0 {
 		return nil, formatNativeErr("ecdsamp_sign", rc)
 	}
@@ -1181,6 +1366,40 @@ func ECDSAMPSign(cj unsafe.Pointer, key ECDSAMPKey, msg []byte, sigReceiver int)
 	return cmemToGoBytes(sigOut), nil
 }
 
+// ECDSAMPSignWithGlobalAbort signs a message with an ECDSA MP key using global abort mode.
+// Returns ErrBitLeak on every party if the receiver's signature verification fails.
+// acBytes is as for ECDSAMPSign.
+func ECDSAMPSignWithGlobalAbort(cj unsafe.Pointer, key ECDSAMPKey, acBytes, msg []byte, sigReceiver int) ([]byte, error) {
+	if cj == nil {
+		return nil, errors.New("nil job")
+	}
+	if key == nil {
+		return nil, errors.New("nil key")
+	}
+	if len(msg) == 0 {
+		return nil, errors.New("empty message")
+	}
+
+	// Copy the access structure into C-allocated memory for the signing operation
+	acMem := allocCmem(acBytes)
+	defer freeCmem(acMem)
+
+	// Copy message into C-allocated memory for the signing operation
+	msgMem := allocCmem(msg)
+	defer freeCmem(msgMem)
+
+	var sigOut C.cmem_t
+	rc := C.cbmpc_ecdsamp_sign_with_global_abort((*C.cbmpc_jobmp)(cj), key, acMem, msgMem, C.int(sigReceiver), &sigOut)
+	if rc != 0 {
+		if C.uint(rc) == C.uint(E_ECDSA_2P_BIT_LEAK) {
+			return nil, ErrBitLeak
+		}
+		return nil, formatNativeErr("ecdsamp_sign_with_global_abort", rc)
+	}
+
+	return cmemToGoBytes(sigOut), nil
+}
+
 // ECDSAMPThresholdDKG is a C binding wrapper for multi-party ECDSA threshold distributed key generation.
 func ECDSAMPThresholdDKG(cj unsafe.Pointer, curveNID int, acBytes []byte, quorumPartyIndices []int) (ECDSAMPKey, []byte, error) {
 	if cj == nil {
@@ -1270,6 +1489,23 @@ func Schnorr2PDKG(cj unsafe.Pointer, curveNID int) (Schnorr2PKey, error) {
 	return key, nil
 }
 
+// Schnorr2PRefresh is a C binding wrapper for 2-party Schnorr key refresh.
+func Schnorr2PRefresh(cj unsafe.Pointer, key Schnorr2PKey) (Schnorr2PKey, error) {
+	if cj == nil {
+		return nil, errors.New("nil job")
+	}
+	if key == nil {
+		return nil, errors.New("nil key")
+	}
+
+	var newKey Schnorr2PKey
+	rc := C.cbmpc_schnorr2p_refresh((*C.cbmpc_job2p)(cj), key, &newKey)
+	if rc != 0 {
+		return nil, formatNativeErr("schnorr2p_refresh", rc)
+	}
+	return newKey, nil
+}
+
 // Schnorr2PKeyFree frees a Schnorr 2P key.
 func Schnorr2PKeyFree(key Schnorr2PKey) {
 	if key != nil {
@@ -1339,6 +1575,40 @@ func Schnorr2PKeyGetCurve(key Schnorr2PKey) (int, error) {
 	return int(curveNID), nil
 }
 
+// Schnorr2PKeyGetRole returns the role of a Schnorr 2P key share: 0 for P1, 1
+// for P2.
+func Schnorr2PKeyGetRole(key Schnorr2PKey) (int, error) {
+	if key == nil {
+		return 0, errors.New("nil key")
+	}
+
+	var role C.int
+	rc := C.cbmpc_schnorr2p_key_get_role(key, &role)
+	if rc != 0 {
+		return 0, formatNativeErr("schnorr2p_key_get_role", rc)
+	}
+
+	return int(role), nil
+}
+
+// Schnorr2PKeyValidate runs the native consistency checks on a Schnorr 2P
+// key share. It returns ErrKeyInvalid if a check fails.
+func Schnorr2PKeyValidate(key Schnorr2PKey) error {
+	if key == nil {
+		return errors.New("nil key")
+	}
+
+	rc := C.cbmpc_schnorr2p_key_validate(key)
+	if rc != 0 {
+		if rc == C.CBMPC_E_CRYPTO {
+			return ErrKeyInvalid
+		}
+		return formatNativeErr("schnorr2p_key_validate", rc)
+	}
+
+	return nil
+}
+
 // SchnorrVariant represents Schnorr signature variant (EdDSA or BIP340).
 type SchnorrVariant int
 
@@ -1386,9 +1656,9 @@ func Schnorr2PSignBatch(cj unsafe.Pointer, key Schnorr2PKey, msgs [][]byte, vari
 		return nil, errors.New("empty messages")
 	}
 
-	// Copy messages into C-allocated memory to avoid aliasing Go memory during CGO call
-	msgsMem := goBytesSliceToCmems(msgs)
-	defer freeCmems(msgsMem)
+	// Pin the messages for the duration of the call instead of copying them to C memory
+	msgsMem, msgsArena := pinCmems(msgs)
+	defer msgsArena.release()
 
 	var sigsOut C.cmems_t
 	rc := C.cbmpc_schnorr2p_sign_batch((*C.cbmpc_job2p)(cj), key, msgsMem, C.int(variant), &sigsOut)
@@ -1399,6 +1669,32 @@ func Schnorr2PSignBatch(cj unsafe.Pointer, key Schnorr2PKey, msgs [][]byte, vari
 	return cmemsToGoByteSlices(sigsOut), nil
 }
 
+// SchnorrVerifyMany verifies independent Schnorr signatures of one variant
+// in a single native call; see ECDSAVerifyMany.
+func SchnorrVerifyMany(curveNID int, variant SchnorrVariant, pubKeys, msgs, sigs [][]byte) ([]error, error) {
+	n := len(sigs)
+	if n == 0 {
+		return nil, errors.New("empty signatures")
+	}
+	if len(pubKeys) != n || len(msgs) != n {
+		return nil, errors.New("public keys, messages and signatures count mismatch")
+	}
+
+	pubKeysMem, pubKeysArena := pinCmems(pubKeys)
+	defer pubKeysArena.release()
+	msgsMem, msgsArena := pinCmems(msgs)
+	defer msgsArena.release()
+	sigsMem, sigsArena := pinCmems(sigs)
+	defer sigsArena.release()
+
+	results := make([]C.int, n)
+	rc := C.cbmpc_schnorr_verify_many(C.int(curveNID), C.int(variant), pubKeysMem, msgsMem, sigsMem, C.int(n), &results[0])
+	if rc != 0 {
+		return nil, formatNativeErr("schnorr_verify_many", rc)
+	}
+	return verifyManyResults("schnorr_verify", results), nil
+}
+
 // =====================
 // Schnorr MP Protocols
 // =====================
@@ -1446,7 +1742,8 @@ func SchnorrMPRefresh(cj unsafe.Pointer, key ECDSAMPKey, sidIn []byte) (ECDSAMPK
 
 // SchnorrMPSign is a C binding wrapper for multi-party Schnorr signing.
 // Only the party with party_idx == sig_receiver will receive the final signature.
-func SchnorrMPSign(cj unsafe.Pointer, key ECDSAMPKey, msg []byte, sigReceiver int, variant SchnorrVariant) ([]byte, error) {
+// acBytes is as for ECDSAMPSign.
+func SchnorrMPSign(cj unsafe.Pointer, key ECDSAMPKey, acBytes, msg []byte, sigReceiver int, variant SchnorrVariant) ([]byte, error) {
 	if cj == nil {
 		return nil, errors.New("nil job")
 	}
@@ -1457,12 +1754,16 @@ func SchnorrMPSign(cj unsafe.Pointer, key ECDSAMPKey, msg []byte, sigReceiver in
 		return nil, errors.New("empty message")
 	}
 
+	// Copy the access structure into C-allocated memory for the signing operation
+	acMem := allocCmem(acBytes)
+	defer freeCmem(acMem)
+
 	// Copy message into C-allocated memory for the signing operation
 	msgMem := allocCmem(msg)
 	defer freeCmem(msgMem)
 
 	var sigOut C.cmem_t
-	rc := C.cbmpc_schnorrmp_sign((*C.cbmpc_jobmp)(cj), key, msgMem, C.int(sigReceiver), C.int(variant), &sigOut)
+	rc := C.cbmpc_schnorrmp_sign((*C.cbmpc_jobmp)(cj), key, acMem, msgMem, C.int(sigReceiver), C.int(variant), &sigOut)
 	if rc != 0 {
 		return nil, formatNativeErr("schnorrmp_sign", rc)
 	}
@@ -1472,7 +1773,8 @@ func SchnorrMPSign(cj unsafe.Pointer, key ECDSAMPKey, msg []byte, sigReceiver in
 
 // SchnorrMPSignBatch signs multiple messages with a Schnorr MP key (batch mode).
 // Only the party with party_idx == sig_receiver will receive the final signatures.
-func SchnorrMPSignBatch(cj unsafe.Pointer, key ECDSAMPKey, msgs [][]byte, sigReceiver int, variant SchnorrVariant) ([][]byte, error) {
+// acBytes is as for ECDSAMPSign.
+func SchnorrMPSignBatch(cj unsafe.Pointer, key ECDSAMPKey, acBytes []byte, msgs [][]byte, sigReceiver int, variant SchnorrVariant) ([][]byte, error) {
 	if cj == nil {
 		return nil, errors.New("nil job")
 	}
@@ -1483,12 +1785,16 @@ func SchnorrMPSignBatch(cj unsafe.Pointer, key ECDSAMPKey, msgs [][]byte, sigRec
 		return nil, errors.New("empty messages")
 	}
 
-	// Copy messages into C-allocated memory to avoid aliasing Go memory during CGO call
-	msgsMem := goBytesSliceToCmems(msgs)
-	defer freeCmems(msgsMem)
+	// Copy the access structure into C-allocated memory for the signing operation
+	acMem := allocCmem(acBytes)
+	defer freeCmem(acMem)
+
+	// Pin the messages for the duration of the call instead of copying them to C memory
+	msgsMem, msgsArena := pinCmems(msgs)
+	defer msgsArena.release()
 
 	var sigsOut C.cmems_t
-	rc := C.cbmpc_schnorrmp_sign_batch((*C.cbmpc_jobmp)(cj), key, msgsMem, C.int(sigReceiver), C.int(variant), &sigsOut)
+	rc := C.cbmpc_schnorrmp_sign_batch((*C.cbmpc_jobmp)(cj), key, acMem, msgsMem, C.int(sigReceiver), C.int(variant), &sigsOut)
 	if rc != 0 {
 		return nil, formatNativeErr("schnorrmp_sign_batch", rc)
 	}
@@ -1669,6 +1975,65 @@ func ScalarAdd(scalarABytes, scalarBBytes []byte, curveNID int) ([]byte, error)
 	return cmemToGoBytes(resultOut), nil
 }
 
+// ScalarSub subtracts two scalars modulo curve order: result = (scalarA - scalarB) mod q.
+// Returns result scalar bytes in big-endian format.
+func ScalarSub(scalarABytes, scalarBBytes []byte, curveNID int) ([]byte, error) {
+	if len(scalarABytes) == 0 {
+		return nil, errors.New("empty scalarA")
+	}
+	if len(scalarBBytes) == 0 {
+		return nil, errors.New("empty scalarB")
+	}
+
+	scalarAMem := goBytesToCmem(scalarABytes)
+	scalarBMem := goBytesToCmem(scalarBBytes)
+
+	var resultOut C.cmem_t
+	rc := C.cbmpc_scalar_sub(scalarAMem, scalarBMem, C.int(curveNID), &resultOut)
+	if rc != 0 {
+		return nil, formatNativeErr("scalar_sub", rc)
+	}
+	return cmemToGoBytes(resultOut), nil
+}
+
+// ScalarMul multiplies two scalars modulo curve order: result = (scalarA * scalarB) mod q.
+// Returns result scalar bytes in big-endian format.
+func ScalarMul(scalarABytes, scalarBBytes []byte, curveNID int) ([]byte, error) {
+	if len(scalarABytes) == 0 {
+		return nil, errors.New("empty scalarA")
+	}
+	if len(scalarBBytes) == 0 {
+		return nil, errors.New("empty scalarB")
+	}
+
+	scalarAMem := goBytesToCmem(scalarABytes)
+	scalarBMem := goBytesToCmem(scalarBBytes)
+
+	var resultOut C.cmem_t
+	rc := C.cbmpc_scalar_mul(scalarAMem, scalarBMem, C.int(curveNID), &resultOut)
+	if rc != 0 {
+		return nil, formatNativeErr("scalar_mul", rc)
+	}
+	return cmemToGoBytes(resultOut), nil
+}
+
+// ScalarInv inverts a scalar modulo curve order: result = scalar^-1 mod q.
+// Returns result scalar bytes in big-endian format.
+func ScalarInv(scalarBytes []byte, curveNID int) ([]byte, error) {
+	if len(scalarBytes) == 0 {
+		return nil, errors.New("empty scalar")
+	}
+
+	scalarMem := goBytesToCmem(scalarBytes)
+
+	var resultOut C.cmem_t
+	rc := C.cbmpc_scalar_inv(scalarMem, C.int(curveNID), &resultOut)
+	if rc != 0 {
+		return nil, formatNativeErr("scalar_inv", rc)
+	}
+	return cmemToGoBytes(resultOut), nil
+}
+
 // =====================
 // ZK Proof Operations - Valid_Paillier
 // =====================
@@ -1945,6 +2310,155 @@ func PaillierRangeExpSlackVerify(proof []byte, paillier Paillier, q, c, sessionI
 	return nil
 }
 
+// PDLProve creates a PDL proof that the Paillier ciphertext c encrypts the
+// discrete log of qPoint.
+// The paillier parameter must have a private key.
+func PDLProve(paillier Paillier, c []byte, qPoint ECCPoint, x, r, sessionID []byte, aux uint64) ([]byte, error) {
+	if paillier == nil {
+		return nil, errors.New("nil paillier")
+	}
+	if len(c) == 0 {
+		return nil, errors.New("empty ciphertext")
+	}
+	if qPoint == nil {
+		return nil, errors.New("nil point")
+	}
+	if len(x) == 0 {
+		return nil, errors.New("empty plaintext")
+	}
+	if len(r) == 0 {
+		return nil, errors.New("empty randomness")
+	}
+	if len(sessionID) == 0 {
+		return nil, errors.New("empty session ID")
+	}
+
+	cMem := goBytesToCmem(c)
+	xMem := goBytesToCmem(x)
+	rMem := goBytesToCmem(r)
+	sessionIDMem := goBytesToCmem(sessionID)
+
+	var out C.cmem_t
+	rc := C.cbmpc_pdl_prove(paillier, cMem, qPoint, xMem, rMem, sessionIDMem, C.uint64_t(aux), &out)
+	if rc != 0 {
+		return nil, formatNativeErr("pdl_prove", rc)
+	}
+
+	return cmemToGoBytes(out), nil
+}
+
+// PDLVerify verifies a PDL proof.
+// The proof parameter should be serialized proof bytes.
+func PDLVerify(proof []byte, paillier Paillier, c []byte, qPoint ECCPoint, sessionID []byte, aux uint64) error {
+	if len(proof) == 0 {
+		return errors.New("empty proof")
+	}
+	if paillier == nil {
+		return errors.New("nil paillier")
+	}
+	if len(c) == 0 {
+		return errors.New("empty ciphertext")
+	}
+	if qPoint == nil {
+		return errors.New("nil point")
+	}
+	if len(sessionID) == 0 {
+		return errors.New("empty session ID")
+	}
+
+	proofMem := goBytesToCmem(proof)
+	cMem := goBytesToCmem(c)
+	sessionIDMem := goBytesToCmem(sessionID)
+
+	rc := C.cbmpc_pdl_verify(proofMem, paillier, cMem, qPoint, sessionIDMem, C.uint64_t(aux))
+	if rc != 0 {
+		return formatNativeErr("pdl_verify", rc)
+	}
+
+	return nil
+}
+
+// PedersenCommit commits to x using the library's fixed Pedersen parameters.
+// Returns the commitment and the randomness needed to open it.
+func PedersenCommit(x []byte) (commitment, randomness []byte, err error) {
+	if len(x) == 0 {
+		return nil, nil, errors.New("empty value")
+	}
+
+	xMem := goBytesToCmem(x)
+	var cOut, rOut C.cmem_t
+	rc := C.cbmpc_pedersen_commit(xMem, &cOut, &rOut)
+	if rc != 0 {
+		return nil, nil, formatNativeErr("pedersen_commit", rc)
+	}
+
+	return cmemToGoBytes(cOut), cmemToGoBytes(rOut), nil
+}
+
+// RangePedersenProve creates a Range_Pedersen proof that commitment c opens to
+// a value in [0, q).
+// Returns the serialized proof bytes.
+func RangePedersenProve(q, c, x, r, sessionID []byte, aux uint64) ([]byte, error) {
+	if len(q) == 0 {
+		return nil, errors.New("empty bound q")
+	}
+	if len(c) == 0 {
+		return nil, errors.New("empty commitment")
+	}
+	if len(x) == 0 {
+		return nil, errors.New("empty value")
+	}
+	if len(r) == 0 {
+		return nil, errors.New("empty randomness")
+	}
+	if len(sessionID) == 0 {
+		return nil, errors.New("empty session ID")
+	}
+
+	qMem := goBytesToCmem(q)
+	cMem := goBytesToCmem(c)
+	xMem := goBytesToCmem(x)
+	rMem := goBytesToCmem(r)
+	sessionIDMem := goBytesToCmem(sessionID)
+
+	var out C.cmem_t
+	rc := C.cbmpc_range_pedersen_prove(qMem, cMem, xMem, rMem, sessionIDMem, C.uint64_t(aux), &out)
+	if rc != 0 {
+		return nil, formatNativeErr("range_pedersen_prove", rc)
+	}
+
+	return cmemToGoBytes(out), nil
+}
+
+// RangePedersenVerify verifies a Range_Pedersen proof.
+// The proof parameter should be serialized proof bytes.
+func RangePedersenVerify(proof, q, c, sessionID []byte, aux uint64) error {
+	if len(proof) == 0 {
+		return errors.New("empty proof")
+	}
+	if len(q) == 0 {
+		return errors.New("empty bound q")
+	}
+	if len(c) == 0 {
+		return errors.New("empty commitment")
+	}
+	if len(sessionID) == 0 {
+		return errors.New("empty session ID")
+	}
+
+	proofMem := goBytesToCmem(proof)
+	qMem := goBytesToCmem(q)
+	cMem := goBytesToCmem(c)
+	sessionIDMem := goBytesToCmem(sessionID)
+
+	rc := C.cbmpc_range_pedersen_verify(proofMem, qMem, cMem, sessionIDMem, C.uint64_t(aux))
+	if rc != 0 {
+		return formatNativeErr("range_pedersen_verify", rc)
+	}
+
+	return nil
+}
+
 // =====================
 // Access Control (AC) Builder Operations
 // =====================
@@ -2109,6 +2623,101 @@ func ACListLeafPaths(acBytes []byte) ([]string, error) {
 	return paths, nil
 }
 
+// AC node kinds reported by ACDescribe.
+const (
+	ACKindLeaf      = int(C.CBMPC_AC_LEAF)
+	ACKindAnd       = int(C.CBMPC_AC_AND)
+	ACKindOr        = int(C.CBMPC_AC_OR)
+	ACKindThreshold = int(C.CBMPC_AC_THRESHOLD)
+)
+
+// ACNodeInfo describes one node of a serialized AC tree.
+type ACNodeInfo struct {
+	Path      string
+	Kind      int
+	Threshold int
+	Children  int
+}
+
+// ACDescribe returns the nodes of an AC structure in pre-order.
+func ACDescribe(acBytes []byte) ([]ACNodeInfo, error) {
+	if len(acBytes) == 0 {
+		return nil, errors.New("empty AC bytes")
+	}
+
+	acMem := goBytesToCmem(acBytes)
+	var pathsOut C.cmems_t
+	var shapeOut C.cmem_t
+	rc := C.cbmpc_ac_describe(acMem, &pathsOut, &shapeOut)
+	if rc != 0 {
+		return nil, formatNativeErr("ac_describe", rc)
+	}
+
+	paths := cmemsToGoByteSlices(pathsOut)
+	shape := cmemToGoBytes(shapeOut)
+	if len(shape) != 12*len(paths) {
+		return nil, errors.New("ac_describe: inconsistent output")
+	}
+	nodes := make([]ACNodeInfo, len(paths))
+	for i, p := range paths {
+		row := shape[12*i:]
+		nodes[i] = ACNodeInfo{
+			Path:      string(p),
+			Kind:      int(binary.LittleEndian.Uint32(row[0:4])),
+			Threshold: int(binary.LittleEndian.Uint32(row[4:8])),
+			Children:  int(binary.LittleEndian.Uint32(row[8:12])),
+		}
+	}
+	return nodes, nil
+}
+
+// =====================
+// Secret Sharing
+// =====================
+
+// SSShareThreshold deals secretBytes into n Shamir shares for party indices
+// 1..n with the given threshold. It returns the share values in index order
+// and the polynomial coefficients a_0..a_{threshold-1}, with a_0 the secret.
+func SSShareThreshold(curveNID int, secretBytes []byte, threshold, n int) (shares, coeffs [][]byte, err error) {
+	if len(secretBytes) == 0 {
+		return nil, nil, errors.New("empty secret")
+	}
+	if threshold < 1 || n < threshold {
+		return nil, nil, errors.New("invalid threshold")
+	}
+
+	secretMem := goBytesToCmem(secretBytes)
+	var sharesOut, coeffsOut C.cmems_t
+	rc := C.cbmpc_ss_share_threshold(C.int(curveNID), secretMem, C.int(threshold), C.int(n), &sharesOut, &coeffsOut)
+	if rc != 0 {
+		return nil, nil, formatNativeErr("ss_share_threshold", rc)
+	}
+	return cmemsToGoByteSlices(sharesOut), cmemsToGoByteSlices(coeffsOut), nil
+}
+
+// SSLagrangeInterpolate interpolates f(0) from the shares at the party
+// indices pids. shares and pids are parallel lists of big-endian scalars.
+func SSLagrangeInterpolate(curveNID int, shares, pids [][]byte) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares")
+	}
+	if len(shares) != len(pids) {
+		return nil, errors.New("shares and pids length mismatch")
+	}
+
+	sharesMem := goBytesSliceToCmems(shares)
+	defer freeCmems(sharesMem)
+	pidsMem := goBytesSliceToCmems(pids)
+	defer freeCmems(pidsMem)
+
+	var out C.cmem_t
+	rc := C.cbmpc_ss_lagrange_interpolate(C.int(curveNID), sharesMem, pidsMem, &out)
+	if rc != 0 {
+		return nil, formatNativeErr("ss_lagrange_interpolate", rc)
+	}
+	return cmemToGoBytes(out), nil
+}
+
 // ACNodeFree frees an AC node (and its entire subtree).
 func ACNodeFree(node ACNode) {
 	if node != nil {
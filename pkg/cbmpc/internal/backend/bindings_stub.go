@@ -52,6 +52,14 @@ func MultiPairwiseAgreeRandom(unsafe.Pointer, int) ([][]byte, error) {
 	return nil, ErrNotBuilt
 }
 
+func OTSend(unsafe.Pointer, []byte, [][]byte, [][]byte) error {
+	return ErrNotBuilt
+}
+
+func OTReceive(unsafe.Pointer, []byte, []byte, int, int) ([][]byte, error) {
+	return nil, ErrNotBuilt
+}
+
 // ECDSA2PKey is a stub type for non-CGO builds
 type ECDSA2PKey = unsafe.Pointer
 
@@ -65,6 +73,14 @@ func ECDSA2PKeyGetCurve(ECDSA2PKey) (Curve, error) {
 	return Unknown, ErrNotBuilt
 }
 
+func ECDSA2PKeyGetRole(ECDSA2PKey) (int, error) {
+	return 0, ErrNotBuilt
+}
+
+func ECDSA2PKeyValidate(ECDSA2PKey) error {
+	return ErrNotBuilt
+}
+
 func ECDSA2PKeySerialize(ECDSA2PKey) ([]byte, error) {
 	return nil, ErrNotBuilt
 }
@@ -73,6 +89,10 @@ func ECDSA2PKeyDeserialize([]byte) (ECDSA2PKey, error) {
 	return nil, ErrNotBuilt
 }
 
+func ECDSA2PKeyGetXShare(ECDSA2PKey) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
 func ECDSA2PDKG(unsafe.Pointer, int) (ECDSA2PKey, error) {
 	return nil, ErrNotBuilt
 }
@@ -81,6 +101,18 @@ func ECDSA2PRefresh(unsafe.Pointer, ECDSA2PKey) (ECDSA2PKey, error) {
 	return nil, ErrNotBuilt
 }
 
+func ECDSA2PImport(unsafe.Pointer, int, int, []byte, []byte) (ECDSA2PKey, error) {
+	return nil, ErrNotBuilt
+}
+
+func ECDSA2PExport(unsafe.Pointer, ECDSA2PKey) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
+func ECDSAVerifyMany(int, [][]byte, [][]byte, [][]byte) ([]error, error) {
+	return nil, ErrNotBuilt
+}
+
 func ECDSA2PSign(unsafe.Pointer, ECDSA2PKey, []byte, []byte) ([]byte, []byte, error) {
 	return nil, nil, ErrNotBuilt
 }
@@ -140,6 +172,14 @@ func ECCPointGetCurve(ECCPoint) Curve {
 	return Unknown
 }
 
+func Commit([]byte, string, []byte) ([]byte, []byte, error) {
+	return nil, nil, ErrNotBuilt
+}
+
+func CommitOpen([]byte, string, []byte, []byte, []byte) error {
+	return ErrNotBuilt
+}
+
 // ECElGamalCommitment is a stub type for non-CGO builds
 type ECElGamalCommitment = unsafe.Pointer
 
@@ -169,6 +209,22 @@ func ECElGamalCommitmentMake(ECCPoint, []byte, []byte) (ECElGamalCommitment, err
 	return nil, ErrNotBuilt
 }
 
+func ECElGamalCommitmentAdd(ECElGamalCommitment, ECElGamalCommitment) (ECElGamalCommitment, error) {
+	return nil, ErrNotBuilt
+}
+
+func ECElGamalCommitmentScalarMul(ECElGamalCommitment, []byte) (ECElGamalCommitment, error) {
+	return nil, ErrNotBuilt
+}
+
+func ECElGamalCommitmentRerand(ECElGamalCommitment, ECCPoint, []byte) (ECElGamalCommitment, error) {
+	return nil, ErrNotBuilt
+}
+
+func ECElGamalCommitmentEqual(ECElGamalCommitment, ECElGamalCommitment) (bool, error) {
+	return false, ErrNotBuilt
+}
+
 func PVEGetQPoint([]byte) (ECCPoint, error) {
 	return nil, ErrNotBuilt
 }
@@ -193,6 +249,10 @@ func UCDLVerify([]byte, ECCPoint, []byte, uint64) error {
 	return ErrNotBuilt
 }
 
+func UCDLVerifyMany([][]byte, []ECCPoint, [][]byte, []uint64) ([]error, error) {
+	return nil, ErrNotBuilt
+}
+
 func UCBatchDLProve([]ECCPoint, [][]byte, []byte, uint64) ([]byte, error) {
 	return nil, ErrNotBuilt
 }
@@ -254,6 +314,10 @@ func ECDSAMPKeyGetCurve(ECDSAMPKey) (Curve, error) {
 	return Unknown, ErrNotBuilt
 }
 
+func ECDSAMPKeyGetPartyName(ECDSAMPKey) (string, error) {
+	return "", ErrNotBuilt
+}
+
 func ECDSAMPKeySerialize(ECDSAMPKey) ([]byte, error) {
 	return nil, ErrNotBuilt
 }
@@ -262,6 +326,26 @@ func ECDSAMPKeyDeserialize([]byte) (ECDSAMPKey, error) {
 	return nil, ErrNotBuilt
 }
 
+func ECDSAMPKeyGetXShare(ECDSAMPKey) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
+func ECDSAMPKeyGetPublicShare(ECDSAMPKey, string) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
+func ECDSAMPKeyValidate(ECDSAMPKey) error {
+	return ErrNotBuilt
+}
+
+func ECDSAMPKeyNew(int, string, []byte, []byte, []string, [][]byte) (ECDSAMPKey, error) {
+	return nil, ErrNotBuilt
+}
+
+func ECDSAMPKeyTweak(ECDSAMPKey, []byte) (ECDSAMPKey, error) {
+	return nil, ErrNotBuilt
+}
+
 func ECDSAMP_DKG(unsafe.Pointer, int) (ECDSAMPKey, []byte, error) {
 	return nil, nil, ErrNotBuilt
 }
@@ -270,7 +354,11 @@ func ECDSAMPRefresh(unsafe.Pointer, ECDSAMPKey, []byte) (ECDSAMPKey, []byte, err
 	return nil, nil, ErrNotBuilt
 }
 
-func ECDSAMPSign(unsafe.Pointer, ECDSAMPKey, []byte, int) ([]byte, error) {
+func ECDSAMPSign(unsafe.Pointer, ECDSAMPKey, []byte, []byte, int) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
+func ECDSAMPSignWithGlobalAbort(unsafe.Pointer, ECDSAMPKey, []byte, []byte, int) ([]byte, error) {
 	return nil, ErrNotBuilt
 }
 
@@ -289,6 +377,10 @@ func Schnorr2PDKG(unsafe.Pointer, int) (Schnorr2PKey, error) {
 	return nil, ErrNotBuilt
 }
 
+func Schnorr2PRefresh(unsafe.Pointer, Schnorr2PKey) (Schnorr2PKey, error) {
+	return nil, ErrNotBuilt
+}
+
 func Schnorr2PKeyFree(Schnorr2PKey) {}
 
 func Schnorr2PKeySerialize(Schnorr2PKey) ([]byte, error) {
@@ -307,6 +399,14 @@ func Schnorr2PKeyGetCurve(Schnorr2PKey) (int, error) {
 	return 0, ErrNotBuilt
 }
 
+func Schnorr2PKeyGetRole(Schnorr2PKey) (int, error) {
+	return 0, ErrNotBuilt
+}
+
+func Schnorr2PKeyValidate(Schnorr2PKey) error {
+	return ErrNotBuilt
+}
+
 // SchnorrVariant is a stub type for non-CGO builds
 type SchnorrVariant int
 
@@ -323,6 +423,10 @@ func Schnorr2PSignBatch(unsafe.Pointer, Schnorr2PKey, [][]byte, SchnorrVariant)
 	return nil, ErrNotBuilt
 }
 
+func SchnorrVerifyMany(int, SchnorrVariant, [][]byte, [][]byte, [][]byte) ([]error, error) {
+	return nil, ErrNotBuilt
+}
+
 func SchnorrMPDKG(unsafe.Pointer, int) (ECDSAMPKey, []byte, error) {
 	return nil, nil, ErrNotBuilt
 }
@@ -331,11 +435,11 @@ func SchnorrMPRefresh(unsafe.Pointer, ECDSAMPKey, []byte) (ECDSAMPKey, []byte, e
 	return nil, nil, ErrNotBuilt
 }
 
-func SchnorrMPSign(unsafe.Pointer, ECDSAMPKey, []byte, int, SchnorrVariant) ([]byte, error) {
+func SchnorrMPSign(unsafe.Pointer, ECDSAMPKey, []byte, []byte, int, SchnorrVariant) ([]byte, error) {
 	return nil, ErrNotBuilt
 }
 
-func SchnorrMPSignBatch(unsafe.Pointer, ECDSAMPKey, [][]byte, int, SchnorrVariant) ([][]byte, error) {
+func SchnorrMPSignBatch(unsafe.Pointer, ECDSAMPKey, []byte, [][]byte, int, SchnorrVariant) ([][]byte, error) {
 	return nil, ErrNotBuilt
 }
 
@@ -372,6 +476,18 @@ func ScalarAdd([]byte, []byte, int) ([]byte, error) {
 	return nil, ErrNotBuilt
 }
 
+func ScalarSub([]byte, []byte, int) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
+func ScalarMul([]byte, []byte, int) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
+func ScalarInv([]byte, int) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
 // Paillier is a stub type for non-CGO builds
 type Paillier = unsafe.Pointer
 
@@ -379,6 +495,10 @@ func PaillierGenerate() (Paillier, error) {
 	return nil, ErrNotBuilt
 }
 
+func PaillierGenerateWithBits(int) (Paillier, error) {
+	return nil, ErrNotBuilt
+}
+
 func PaillierCreatePub([]byte) (Paillier, error) {
 	return nil, ErrNotBuilt
 }
@@ -409,6 +529,14 @@ func PaillierAddCiphers(Paillier, []byte, []byte) ([]byte, error) {
 	return nil, ErrNotBuilt
 }
 
+func PaillierSubCiphers(Paillier, []byte, []byte) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
+func PaillierRerandomize(Paillier, []byte) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
 func PaillierMulScalar(Paillier, []byte, []byte) ([]byte, error) {
 	return nil, ErrNotBuilt
 }
@@ -466,6 +594,33 @@ func ACListLeafPaths([]byte) ([]string, error) {
 
 func ACNodeFree(ACNode) {}
 
+const (
+	ACKindLeaf = iota + 1
+	ACKindAnd
+	ACKindOr
+	ACKindThreshold
+)
+
+type ACNodeInfo struct {
+	Path      string
+	Kind      int
+	Threshold int
+	Children  int
+}
+
+func ACDescribe([]byte) ([]ACNodeInfo, error) {
+	return nil, ErrNotBuilt
+}
+
+// Secret sharing stubs
+func SSShareThreshold(int, []byte, int, int) ([][]byte, [][]byte, error) {
+	return nil, nil, ErrNotBuilt
+}
+
+func SSLagrangeInterpolate(int, [][]byte, [][]byte) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
 // PVE-AC stubs
 func PVEACEncrypt(KEM, []byte, map[string][]byte, []byte, int, [][]byte) ([]byte, error) {
 	return nil, ErrNotBuilt
@@ -520,3 +675,23 @@ func PaillierRangeExpSlackProve(Paillier, []byte, []byte, []byte, []byte, []byte
 func PaillierRangeExpSlackVerify([]byte, Paillier, []byte, []byte, []byte, uint64) error {
 	return ErrNotBuilt
 }
+
+func PDLProve(Paillier, []byte, ECCPoint, []byte, []byte, []byte, uint64) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
+func PDLVerify([]byte, Paillier, []byte, ECCPoint, []byte, uint64) error {
+	return ErrNotBuilt
+}
+
+func PedersenCommit([]byte) ([]byte, []byte, error) {
+	return nil, nil, ErrNotBuilt
+}
+
+func RangePedersenProve([]byte, []byte, []byte, []byte, []byte, uint64) ([]byte, error) {
+	return nil, ErrNotBuilt
+}
+
+func RangePedersenVerify([]byte, []byte, []byte, []byte, uint64) error {
+	return ErrNotBuilt
+}
@@ -13,6 +13,7 @@ import "C"
 
 import (
 	"errors"
+	"runtime"
 	"sync"
 	"unsafe"
 )
@@ -168,6 +169,71 @@ func freeCmems(cmems C.cmems_t) {
 	}
 }
 
+// cmemsArena is reusable Go storage backing one cmems_t passed to C. Its
+// memory is pinned for the duration of the call, so C may read it without a
+// malloc and copy per batch.
+type cmemsArena struct {
+	pinner runtime.Pinner
+	data   []byte
+	sizes  []C.int
+	copied bool
+}
+
+var cmemsArenas = sync.Pool{New: func() any { return new(cmemsArena) }}
+
+// pinCmems converts a Go [][]byte slice to a C.cmems_t backed by pinned Go
+// memory from a pool. When the slices are consecutive regions of one buffer
+// the data is passed without copying; otherwise it is packed into the
+// arena's reused buffer. Always pair with defer arena.release().
+//
+// Used for batch inputs (signing thousands of hashes, batch proofs and PVE)
+// where goBytesSliceToCmems would malloc and copy the whole batch per call.
+// The memory stays valid until release, so it is safe for multi-round
+// protocols provided the C side does not retain pointers after returning.
+func pinCmems(slices [][]byte) (C.cmems_t, *cmemsArena) {
+	a := cmemsArenas.Get().(*cmemsArena)
+	var cmems C.cmems_t
+	if len(slices) == 0 {
+		return cmems, a
+	}
+
+	total := 0
+	a.sizes = a.sizes[:0]
+	for _, s := range slices {
+		a.sizes = append(a.sizes, C.int(len(s)))
+		total += len(s)
+	}
+	var data []byte
+	data, a.copied = packSlices(a.data, slices, total)
+	if a.copied {
+		a.data = data[:cap(data)]
+	}
+
+	if total > 0 {
+		a.pinner.Pin(&data[0])
+		cmems.data = (*C.uint8_t)(unsafe.Pointer(&data[0]))
+	}
+	a.pinner.Pin(&a.sizes[0])
+	cmems.sizes = &a.sizes[0]
+	cmems.count = C.int(len(slices))
+	return cmems, a
+}
+
+// release unpins the arena, zeroes any copied data (batches may hold secret
+// scalars) and returns the arena to the pool.
+func (a *cmemsArena) release() {
+	a.pinner.Unpin()
+	if a.copied {
+		clear(a.data)
+		a.copied = false
+	}
+	if cap(a.data) > maxPooledArena {
+		a.data = nil
+	}
+	a.sizes = a.sizes[:0]
+	cmemsArenas.Put(a)
+}
+
 // allocCmem allocates C memory and copies Go bytes into it.
 // The caller is responsible for freeing this memory with freeCmem.
 //
@@ -244,6 +310,38 @@ func ECDSA2PKeyGetCurve(key ECDSA2PKey) (Curve, error) {
 	return Curve(curveInt), nil
 }
 
+// ECDSA2PKeyGetRole returns the role of an ECDSA 2P key share: 0 for P1, 1
+// for P2.
+func ECDSA2PKeyGetRole(key ECDSA2PKey) (int, error) {
+	if key == nil {
+		return 0, errors.New("nil key")
+	}
+
+	var role C.int
+	rc := C.cbmpc_ecdsa2p_key_get_role(key, &role)
+	if rc != 0 {
+		return 0, formatNativeErr("ecdsa2p_key_get_role", rc)
+	}
+	return int(role), nil
+}
+
+// ECDSA2PKeyValidate runs the native consistency checks on an ECDSA 2P key
+// share. It returns ErrKeyInvalid if a check fails.
+func ECDSA2PKeyValidate(key ECDSA2PKey) error {
+	if key == nil {
+		return errors.New("nil key")
+	}
+
+	rc := C.cbmpc_ecdsa2p_key_validate(key)
+	if rc != 0 {
+		if rc == C.CBMPC_E_CRYPTO {
+			return ErrKeyInvalid
+		}
+		return formatNativeErr("ecdsa2p_key_validate", rc)
+	}
+	return nil
+}
+
 // ECDSA2PKeySerialize serializes an ECDSA 2P key to bytes.
 func ECDSA2PKeySerialize(key ECDSA2PKey) ([]byte, error) {
 	if key == nil {
@@ -273,6 +371,21 @@ func ECDSA2PKeyDeserialize(data []byte) (ECDSA2PKey, error) {
 	return key, nil
 }
 
+// ECDSA2PKeyGetXShare returns the additive private share of an ECDSA 2P key,
+// padded to the curve order size. The result is secret key material.
+func ECDSA2PKeyGetXShare(key ECDSA2PKey) ([]byte, error) {
+	if key == nil {
+		return nil, errors.New("nil key")
+	}
+
+	var out C.cmem_t
+	rc := C.cbmpc_ecdsa2p_key_get_x_share(key, &out)
+	if rc != 0 {
+		return nil, errors.New("failed to get key share")
+	}
+	return cmemToGoBytes(out), nil
+}
+
 // =====================
 // ECDSA MP Key bridging
 // =====================
@@ -317,6 +430,21 @@ func ECDSAMPKeyGetCurve(key ECDSAMPKey) (Curve, error) {
 	return Curve(curveInt), nil
 }
 
+// ECDSAMPKeyGetPartyName returns the name of the party holding an ECDSA MP key
+// share.
+func ECDSAMPKeyGetPartyName(key ECDSAMPKey) (string, error) {
+	if key == nil {
+		return "", errors.New("nil key")
+	}
+
+	var out C.cmem_t
+	rc := C.cbmpc_ecdsamp_key_get_party_name(key, &out)
+	if rc != 0 {
+		return "", formatNativeErr("ecdsamp_key_get_party_name", rc)
+	}
+	return string(cmemToGoBytes(out)), nil
+}
+
 // ECDSAMPKeySerialize serializes an ECDSA MP key to bytes.
 func ECDSAMPKeySerialize(key ECDSAMPKey) ([]byte, error) {
 	if key == nil {
@@ -346,6 +474,97 @@ func ECDSAMPKeyDeserialize(data []byte) (ECDSAMPKey, error) {
 	return key, nil
 }
 
+// ECDSAMPKeyGetXShare returns the private share of an ECDSA MP key, padded
+// to the curve order size. The result is secret key material.
+func ECDSAMPKeyGetXShare(key ECDSAMPKey) ([]byte, error) {
+	if key == nil {
+		return nil, errors.New("nil key")
+	}
+
+	var out C.cmem_t
+	rc := C.cbmpc_ecdsamp_key_get_x_share(key, &out)
+	if rc != 0 {
+		return nil, errors.New("failed to get key share")
+	}
+	return cmemToGoBytes(out), nil
+}
+
+// ECDSAMPKeyGetPublicShare returns the public share of partyName recorded in
+// an ECDSA MP key.
+func ECDSAMPKeyGetPublicShare(key ECDSAMPKey, partyName string) ([]byte, error) {
+	if key == nil {
+		return nil, errors.New("nil key")
+	}
+
+	var out C.cmem_t
+	rc := C.cbmpc_ecdsamp_key_get_public_share(key, goBytesToCmem([]byte(partyName)), &out)
+	if rc != 0 {
+		return nil, formatNativeErr("ecdsamp_key_get_public_share", rc)
+	}
+	return cmemToGoBytes(out), nil
+}
+
+// ECDSAMPKeyValidate runs the native consistency checks on an ECDSA MP key
+// share, which schnorrmp keys share. It returns ErrKeyInvalid if a check
+// fails.
+func ECDSAMPKeyValidate(key ECDSAMPKey) error {
+	if key == nil {
+		return errors.New("nil key")
+	}
+
+	rc := C.cbmpc_ecdsamp_key_validate(key)
+	if rc != 0 {
+		if rc == C.CBMPC_E_CRYPTO {
+			return ErrKeyInvalid
+		}
+		return formatNativeErr("ecdsamp_key_validate", rc)
+	}
+	return nil
+}
+
+// ECDSAMPKeyNew builds an ECDSA MP key for partyName from its share xShare,
+// the public key q and every party's public share (names[i] -> qis[i]).
+func ECDSAMPKeyNew(curveNID int, partyName string, xShare, q []byte, names []string, qis [][]byte) (ECDSAMPKey, error) {
+	if len(names) != len(qis) {
+		return nil, errors.New("names and public shares differ in length")
+	}
+	nameBytes := make([][]byte, len(names))
+	for i, n := range names {
+		nameBytes[i] = []byte(n)
+	}
+
+	nameMem := goBytesToCmem([]byte(partyName))
+	xMem := allocCmem(xShare)
+	defer freeCmem(xMem)
+	qMem := goBytesToCmem(q)
+	namesMem := goBytesSliceToCmems(nameBytes)
+	defer freeCmems(namesMem)
+	qisMem := goBytesSliceToCmems(qis)
+	defer freeCmems(qisMem)
+
+	var key ECDSAMPKey
+	rc := C.cbmpc_ecdsamp_key_new(C.int(curveNID), nameMem, xMem, qMem, namesMem, qisMem, &key)
+	if rc != 0 {
+		return nil, formatNativeErr("ecdsamp_key_new", rc)
+	}
+	return key, nil
+}
+
+// ECDSAMPKeyTweak returns a copy of key shifted by the public scalar tweak,
+// sharing x + tweak under Q + tweak*G.
+func ECDSAMPKeyTweak(key ECDSAMPKey, tweak []byte) (ECDSAMPKey, error) {
+	if key == nil {
+		return nil, errors.New("nil key")
+	}
+
+	var out ECDSAMPKey
+	rc := C.cbmpc_ecdsamp_key_tweak(key, goBytesToCmem(tweak), &out)
+	if rc != 0 {
+		return nil, formatNativeErr("ecdsamp_key_tweak", rc)
+	}
+	return out, nil
+}
+
 // =====================
 // Scalar bridging (bn_t)
 // =====================
@@ -472,6 +691,51 @@ func ECCPointGetCurve(point ECCPoint) Curve {
 	return Curve(C.cbmpc_ecc_point_get_curve(point))
 }
 
+// =====================
+// Hash commitment bridging
+// =====================
+
+// Commit makes a native hash commitment to msg, bound to sid and to the
+// party named party. It returns the commitment and its random opening.
+func Commit(sid []byte, party string, msg []byte) (com, rand []byte, err error) {
+	if len(sid) == 0 {
+		return nil, nil, errors.New("empty session ID")
+	}
+	if party == "" {
+		return nil, nil, errors.New("empty party name")
+	}
+
+	var comOut, randOut C.cmem_t
+	rc := C.cbmpc_commit(goBytesToCmem(sid), goBytesToCmem([]byte(party)), goBytesToCmem(msg), &comOut, &randOut)
+	if rc != 0 {
+		return nil, nil, formatNativeErr("commit", rc)
+	}
+	return cmemToGoBytes(comOut), cmemToGoBytes(randOut), nil
+}
+
+// CommitOpen checks that com, made by Commit with sid and party, opens to
+// msg with rand. It returns ErrCommitMismatch if it does not.
+func CommitOpen(sid []byte, party string, com, msg, rand []byte) error {
+	if len(sid) == 0 {
+		return errors.New("empty session ID")
+	}
+	if party == "" {
+		return errors.New("empty party name")
+	}
+	if len(com) == 0 || len(rand) == 0 {
+		return errors.New("empty commitment or opening")
+	}
+
+	rc := C.cbmpc_commit_open(goBytesToCmem(sid), goBytesToCmem([]byte(party)), goBytesToCmem(com), goBytesToCmem(msg), goBytesToCmem(rand))
+	if rc != 0 {
+		if rc == C.CBMPC_E_CRYPTO {
+			return ErrCommitMismatch
+		}
+		return formatNativeErr("commit_open", rc)
+	}
+	return nil
+}
+
 // =====================
 // EC ElGamal Commitment bridging
 // =====================
@@ -590,26 +854,96 @@ func ECElGamalCommitmentMake(p ECCPoint, m, r []byte) (ECElGamalCommitment, erro
 	return commitment, nil
 }
 
+// ECElGamalCommitmentAdd adds two EC ElGamal commitments on the same curve.
+// Returns a commitment that must be freed with ECElGamalCommitmentFree.
+func ECElGamalCommitmentAdd(a, b ECElGamalCommitment) (ECElGamalCommitment, error) {
+	if a == nil || b == nil {
+		return nil, errors.New("nil commitment")
+	}
+
+	var commitment ECElGamalCommitment
+	rc := C.cbmpc_ec_elgamal_commitment_add(a, b, &commitment)
+	if rc != 0 {
+		return nil, formatNativeErr("ec_elgamal_commitment_add", rc)
+	}
+
+	return commitment, nil
+}
+
+// ECElGamalCommitmentScalarMul multiplies an EC ElGamal commitment by a scalar.
+// Returns a commitment that must be freed with ECElGamalCommitmentFree.
+func ECElGamalCommitmentScalarMul(commitment ECElGamalCommitment, s []byte) (ECElGamalCommitment, error) {
+	if commitment == nil {
+		return nil, errors.New("nil commitment")
+	}
+	if len(s) == 0 {
+		return nil, errors.New("empty scalar")
+	}
+
+	sMem := goBytesToCmem(s)
+
+	var result ECElGamalCommitment
+	rc := C.cbmpc_ec_elgamal_commitment_scalar_mul(commitment, sMem, &result)
+	if rc != 0 {
+		return nil, formatNativeErr("ec_elgamal_commitment_scalar_mul", rc)
+	}
+
+	return result, nil
+}
+
+// ECElGamalCommitmentRerand rerandomizes an EC ElGamal commitment under the
+// public key point p with randomness r.
+// Returns a commitment that must be freed with ECElGamalCommitmentFree.
+func ECElGamalCommitmentRerand(commitment ECElGamalCommitment, p ECCPoint, r []byte) (ECElGamalCommitment, error) {
+	if commitment == nil {
+		return nil, errors.New("nil commitment")
+	}
+	if p == nil {
+		return nil, errors.New("nil point P")
+	}
+	if len(r) == 0 {
+		return nil, errors.New("empty scalar")
+	}
+
+	rMem := goBytesToCmem(r)
+
+	var result ECElGamalCommitment
+	rc := C.cbmpc_ec_elgamal_commitment_rerand(commitment, p, rMem, &result)
+	if rc != 0 {
+		return nil, formatNativeErr("ec_elgamal_commitment_rerand", rc)
+	}
+
+	return result, nil
+}
+
+// ECElGamalCommitmentEqual reports whether two EC ElGamal commitments have
+// the same points.
+func ECElGamalCommitmentEqual(a, b ECElGamalCommitment) (bool, error) {
+	if a == nil || b == nil {
+		return false, errors.New("nil commitment")
+	}
+
+	var equal C.int
+	rc := C.cbmpc_ec_elgamal_commitment_equal(a, b, &equal)
+	if rc != 0 {
+		return false, formatNativeErr("ec_elgamal_commitment_equal", rc)
+	}
+
+	return equal == 1, nil
+}
+
 // =====================
 // Generic handle registry for opaque Go objects
 // =====================
 
 // handleRegistry stores Go objects that need to be passed through C as opaque handles.
 // This allows us to pass handles through C++ without violating CGO pointer rules.
-var (
-	handleRegistryMu sync.RWMutex
-	handleRegistry   = make(map[uint64]any)
-	nextHandleID     = uint64(0xDEADBEEF0000) // Start high to avoid looking like valid pointers
-)
+// It is touched on every PVE and KEM call, so it is sharded; see handleTable.
+var handleRegistry = newHandleTable()
 
 // registerHandle stores a Go object and returns a CGO-safe handle ID.
 func registerHandle(obj any) unsafe.Pointer {
-	handleRegistryMu.Lock()
-	defer handleRegistryMu.Unlock()
-
-	id := nextHandleID
-	nextHandleID++
-	handleRegistry[id] = obj
+	id := handleRegistry.register(obj)
 
 	//nolint:govet // Converting uintptr to unsafe.Pointer is intentional for CGO handle passing
 	return unsafe.Pointer(uintptr(id))
@@ -620,14 +954,7 @@ func lookupHandle(handle unsafe.Pointer) (any, bool) {
 	if handle == nil {
 		return nil, false
 	}
-
-	id := uint64(uintptr(handle))
-
-	handleRegistryMu.RLock()
-	defer handleRegistryMu.RUnlock()
-
-	obj, exists := handleRegistry[id]
-	return obj, exists
+	return handleRegistry.lookup(uint64(uintptr(handle)))
 }
 
 // freeHandle removes a Go object from the registry.
@@ -635,13 +962,7 @@ func freeHandle(handle unsafe.Pointer) {
 	if handle == nil {
 		return
 	}
-
-	id := uint64(uintptr(handle))
-
-	handleRegistryMu.Lock()
-	defer handleRegistryMu.Unlock()
-
-	delete(handleRegistry, id)
+	handleRegistry.free(uint64(uintptr(handle)))
 }
 
 // RegisterHandle stores a Go object and returns a CGO-safe handle.
@@ -673,6 +994,18 @@ func PaillierGenerate() (Paillier, error) {
 	return paillier, nil
 }
 
+// PaillierGenerateWithBits generates a new Paillier keypair with a modulus of
+// the given size (2048, 3072 or 4096 bits).
+// Returns a Paillier instance that must be freed with PaillierFree.
+func PaillierGenerateWithBits(bits int) (Paillier, error) {
+	var paillier Paillier
+	rc := C.cbmpc_paillier_generate_with_bits(C.int(bits), &paillier)
+	if rc != 0 {
+		return nil, formatNativeErr("paillier_generate_with_bits", rc)
+	}
+	return paillier, nil
+}
+
 // PaillierCreatePub creates a Paillier instance from a public key (modulus n only).
 // Returns a Paillier instance that must be freed with PaillierFree.
 func PaillierCreatePub(n []byte) (Paillier, error) {
@@ -792,6 +1125,43 @@ func PaillierAddCiphers(paillier Paillier, c1, c2 []byte) ([]byte, error) {
 	return cmemToGoBytes(out), nil
 }
 
+// PaillierSubCiphers subtracts Paillier ciphertext c2 from c1 homomorphically.
+func PaillierSubCiphers(paillier Paillier, c1, c2 []byte) ([]byte, error) {
+	if paillier == nil {
+		return nil, errors.New("nil paillier")
+	}
+	if len(c1) == 0 || len(c2) == 0 {
+		return nil, errors.New("empty ciphertext")
+	}
+
+	c1Mem := goBytesToCmem(c1)
+	c2Mem := goBytesToCmem(c2)
+	var out C.cmem_t
+	rc := C.cbmpc_paillier_sub_ciphers(paillier, c1Mem, c2Mem, &out)
+	if rc != 0 {
+		return nil, formatNativeErr("paillier_sub_ciphers", rc)
+	}
+	return cmemToGoBytes(out), nil
+}
+
+// PaillierRerandomize returns a fresh ciphertext of the same plaintext.
+func PaillierRerandomize(paillier Paillier, ciphertext []byte) ([]byte, error) {
+	if paillier == nil {
+		return nil, errors.New("nil paillier")
+	}
+	if len(ciphertext) == 0 {
+		return nil, errors.New("empty ciphertext")
+	}
+
+	ctMem := goBytesToCmem(ciphertext)
+	var out C.cmem_t
+	rc := C.cbmpc_paillier_rerandomize(paillier, ctMem, &out)
+	if rc != 0 {
+		return nil, formatNativeErr("paillier_rerandomize", rc)
+	}
+	return cmemToGoBytes(out), nil
+}
+
 // PaillierMulScalar multiplies a Paillier ciphertext by a scalar homomorphically.
 func PaillierMulScalar(paillier Paillier, ciphertext, scalar []byte) ([]byte, error) {
 	if paillier == nil {
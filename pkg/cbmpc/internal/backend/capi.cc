@@ -7,17 +7,20 @@
 #include <vector>
 
 #include "capi.h"
+#include "detnonce.h"
 
 #include "cbmpc/core/buf.h"
 #include "cbmpc/core/convert.h"
 #include "cbmpc/core/error.h"
 #include "cbmpc/crypto/base_ecc.h"
 #include "cbmpc/crypto/base_pki.h"
+#include "cbmpc/crypto/commitment.h"
 #include "cbmpc/crypto/elgamal.h"
 #include "cbmpc/protocol/agree_random.h"
 #include "cbmpc/protocol/ecdsa_2p.h"
 #include "cbmpc/protocol/ecdsa_mp.h"
 #include "cbmpc/protocol/mpc_job.h"
+#include "cbmpc/protocol/ot.h"
 #include "cbmpc/protocol/pve.h"
 #include "cbmpc/protocol/pve_ac.h"
 #include "cbmpc/protocol/pve_batch.h"
@@ -27,6 +30,7 @@
 #include "cbmpc/zk/zk_ec.h"
 #include "cbmpc/zk/zk_elgamal_com.h"
 #include "cbmpc/zk/zk_paillier.h"
+#include "cbmpc/zk/zk_pedersen.h"
 
 namespace {
 
@@ -34,6 +38,7 @@ using coinbase::buf_t;
 using coinbase::mem_t;
 using coinbase::mpc::job_2p_t;
 using coinbase::mpc::job_mp_t;
+using coinbase::mpc::party_t;
 
 // Allocate and copy data to a new cmem_t that the caller owns.
 // The caller is responsible for freeing this memory.
@@ -129,6 +134,32 @@ struct go_jobmp {
   std::vector<cbmpc_role_id> roles;
 };
 
+// Set out to the key share a party signs with on job. Without an access
+// structure the key is n-of-n and is used as is. Otherwise it holds a share
+// under that structure, which is converted to an additive share over the
+// job's parties; the conversion fails when they do not satisfy it.
+static error_t signing_share(job_mp_t &job, const coinbase::mpc::ecdsampc::key_t &key, cmem_t ac_bytes,
+                             coinbase::mpc::ecdsampc::key_t &out) {
+  if (!ac_bytes.data || ac_bytes.size <= 0) {
+    out = key;
+    return SUCCESS;
+  }
+
+  coinbase::crypto::ss::ac_owned_t ac;
+  error_t rv = coinbase::deser(mem_t(ac_bytes.data, ac_bytes.size), ac);
+  if (rv != SUCCESS) return rv;
+  ac.G = key.Q.get_curve().generator();
+
+  // The job's parties are the quorum.
+  coinbase::mpc::party_set_t quorum_party_set;
+  for (int i = 0; i < job.get_n_parties(); i++) {
+    quorum_party_set.add(i);
+  }
+
+  auto share = key;
+  return share.to_additive_share(job.get_party_idx(), ac, job.get_n_parties(), quorum_party_set, out);
+}
+
 }  // namespace
 
 extern "C" {
@@ -181,6 +212,91 @@ int cbmpc_multi_pairwise_agree_random(cbmpc_jobmp *j, int bitlen, cmems_t *out)
   return 0;
 }
 
+// Oblivious transfer
+//
+// Both functions run ot_protocol_pvw_ctx_t, base OT followed by OT extension,
+// between the two parties of the job: the sender sends msg1 and msg3 and the
+// receiver msg2, in whichever direction their roles in the job give.
+
+// Copy every message of msgs, which must all be msg_len bytes, into out.
+static error_t cmems_to_ot_messages(cmems_t msgs, int msg_len, std::vector<buf_t> &out) {
+  out.reserve(static_cast<size_t>(msgs.count));
+  size_t offset = 0;
+  for (int i = 0; i < msgs.count; ++i) {
+    if (msgs.sizes[i] != msg_len) return E_BADARG;
+    out.emplace_back(msgs.data + offset, msg_len);
+    offset += msg_len;
+  }
+  return SUCCESS;
+}
+
+static void zeroize_ot_messages(std::vector<buf_t> &msgs) {
+  for (auto &m : msgs) coinbase::secure_bzero(m.data(), m.size());
+}
+
+int cbmpc_ot_send(cbmpc_job2p *j, cmem_t sid, cmems_t x0, cmems_t x1) {
+  auto wrapper = reinterpret_cast<go_job2p *>(j);
+  if (!wrapper || !wrapper->job || !sid.data || sid.size <= 0) return E_BADARG;
+  if (x0.count <= 0 || x0.count != x1.count || !x0.data || !x0.sizes || !x1.data || !x1.sizes) return E_BADARG;
+  const int msg_len = x0.sizes[0];
+  if (msg_len <= 0) return E_BADARG;
+
+  std::vector<buf_t> m0, m1;
+  error_t rv = cmems_to_ot_messages(x0, msg_len, m0);
+  if (rv == SUCCESS) rv = cmems_to_ot_messages(x1, msg_len, m1);
+  if (rv != SUCCESS) {
+    zeroize_ot_messages(m0);
+    zeroize_ot_messages(m1);
+    return rv;
+  }
+
+  auto &job = *wrapper->job;
+  const bool sender_is_p1 = job.is_p1();
+  coinbase::mpc::ot_protocol_pvw_ctx_t ot;
+  ot.base.sid = buf_t(sid.data, sid.size);
+
+  rv = ot.step1_S2R();
+  if (rv == SUCCESS) rv = sender_is_p1 ? job.p1_to_p2(ot.msg1()) : job.p2_to_p1(ot.msg1());
+  if (rv == SUCCESS) rv = sender_is_p1 ? job.p2_to_p1(ot.msg2()) : job.p1_to_p2(ot.msg2());
+  if (rv == SUCCESS) rv = ot.step3_S2R(m0, m1);
+  if (rv == SUCCESS) rv = sender_is_p1 ? job.p1_to_p2(ot.msg3()) : job.p2_to_p1(ot.msg3());
+  zeroize_ot_messages(m0);
+  zeroize_ot_messages(m1);
+  return rv;
+}
+
+int cbmpc_ot_receive(cbmpc_job2p *j, cmem_t sid, cmem_t choices, int count, int msg_len, cmems_t *out) {
+  auto wrapper = reinterpret_cast<go_job2p *>(j);
+  if (!wrapper || !wrapper->job || !sid.data || sid.size <= 0 || !out) return E_BADARG;
+  if (count <= 0 || msg_len <= 0 || !choices.data || choices.size != (count + 7) / 8) return E_BADARG;
+
+  coinbase::bits_t r(count);
+  for (int i = 0; i < count; ++i) {
+    r.set(i, (choices.data[i / 8] >> (i % 8)) & 1);
+  }
+
+  auto &job = *wrapper->job;
+  const bool sender_is_p1 = !job.is_p1();
+  coinbase::mpc::ot_protocol_pvw_ctx_t ot;
+  ot.base.sid = buf_t(sid.data, sid.size);
+
+  error_t rv = sender_is_p1 ? job.p1_to_p2(ot.msg1()) : job.p2_to_p1(ot.msg1());
+  if (rv != SUCCESS) return rv;
+  rv = ot.step2_R2S(r, msg_len * 8);
+  if (rv != SUCCESS) return rv;
+  rv = sender_is_p1 ? job.p2_to_p1(ot.msg2()) : job.p1_to_p2(ot.msg2());
+  if (rv != SUCCESS) return rv;
+  rv = sender_is_p1 ? job.p1_to_p2(ot.msg3()) : job.p2_to_p1(ot.msg3());
+  if (rv != SUCCESS) return rv;
+
+  std::vector<buf_t> x;
+  rv = ot.output_R(count, x);
+  if (rv != SUCCESS) return rv;
+  *out = alloc_and_copy_vector(x);
+  zeroize_ot_messages(x);
+  return 0;
+}
+
 // Helper function to find ecurve_t by NID
 static inline coinbase::crypto::ecurve_t find_curve_by_nid(int nid) {
   return coinbase::crypto::ecurve_t::find(nid);
@@ -266,6 +382,7 @@ int cbmpc_ecdsa2p_sign(cbmpc_job2p *j, cmem_t sid_in, const cbmpc_ecdsa2p_key *k
   // Sign
   buf_t signature;
   mem_t msg_mem(msg.data, msg.size);
+  cbmpc_detnonce::scope_t nonces(*signing_key, {msg_mem}, sid);
   error_t rv = coinbase::mpc::ecdsa2pc::sign(*wrapper->job, sid, *signing_key, msg_mem, signature);
   if (rv != SUCCESS) return rv;
 
@@ -315,6 +432,7 @@ int cbmpc_ecdsa2p_sign_batch(cbmpc_job2p *j, cmem_t sid_in, const cbmpc_ecdsa2p_
 
   // Sign batch
   std::vector<buf_t> signatures;
+  cbmpc_detnonce::scope_t nonces(*signing_key, msg_vec, sid);
   error_t rv = coinbase::mpc::ecdsa2pc::sign_batch(*wrapper->job, sid, *signing_key, msg_vec, signatures);
   if (rv != SUCCESS) return rv;
 
@@ -346,6 +464,7 @@ int cbmpc_ecdsa2p_sign_with_global_abort(cbmpc_job2p *j, cmem_t sid_in, const cb
   // Sign with global abort
   buf_t signature;
   mem_t msg_mem(msg.data, msg.size);
+  cbmpc_detnonce::scope_t nonces(*signing_key, {msg_mem}, sid);
   error_t rv = coinbase::mpc::ecdsa2pc::sign_with_global_abort(*wrapper->job, sid, *signing_key, msg_mem, signature);
   if (rv != SUCCESS) return rv;
 
@@ -395,6 +514,7 @@ int cbmpc_ecdsa2p_sign_with_global_abort_batch(cbmpc_job2p *j, cmem_t sid_in, co
 
   // Sign batch with global abort
   std::vector<buf_t> signatures;
+  cbmpc_detnonce::scope_t nonces(*signing_key, msg_vec, sid);
   error_t rv = coinbase::mpc::ecdsa2pc::sign_with_global_abort_batch(*wrapper->job, sid, *signing_key, msg_vec, signatures);
   if (rv != SUCCESS) return rv;
 
@@ -409,6 +529,151 @@ int cbmpc_ecdsa2p_sign_with_global_abort_batch(cbmpc_job2p *j, cmem_t sid_in, co
   return 0;
 }
 
+// ECDSA 2P Import
+//
+// The parties first run DKG, giving P1 a random share r1 (with c_key = Enc(r1)) and
+// P2 a random share r2. P1 keeps r1 and c_key unchanged and P2's share is replaced
+// by x - r1, so the Paillier material stays valid and Q becomes x*G. If P1 imports,
+// it sends x - r1, which is uniform to P2. If P2 imports, P1 sends r1, which P2 checks
+// against Q - r2*G; the importer already holds x, so it learns nothing new.
+int cbmpc_ecdsa2p_import(cbmpc_job2p *j, int curve_nid, int importer, cmem_t x, cmem_t expected_Q, cbmpc_ecdsa2p_key **key_out) {
+  auto wrapper = reinterpret_cast<go_job2p *>(j);
+  if (!wrapper || !wrapper->job || !key_out || (importer != 0 && importer != 1)) return E_BADARG;
+
+  auto curve = find_curve_by_nid(curve_nid);
+  if (!curve) return E_BADARG;
+  const party_t importer_party = importer == 0 ? party_t::p1 : party_t::p2;
+  const bool is_importer = wrapper->job->is_p1() == (importer_party == party_t::p1);
+  if (is_importer != (x.data && x.size > 0)) return E_BADARG;
+
+  const auto &q = curve.order();
+  coinbase::crypto::bn_t x_bn;
+  if (is_importer) {
+    x_bn = coinbase::crypto::bn_t::from_bin(mem_t(x.data, x.size));
+    if (x_bn <= 0 || x_bn >= q) return E_BADARG;
+  }
+  coinbase::crypto::ecc_point_t expected;
+  const bool has_expected = expected_Q.data && expected_Q.size > 0;
+  if (has_expected && expected.from_bin(curve, mem_t(expected_Q.data, expected_Q.size)) != SUCCESS) return E_BADARG;
+
+  auto key = std::make_unique<coinbase::mpc::ecdsa2pc::key_t>();
+  error_t rv = coinbase::mpc::ecdsa2pc::dkg(*wrapper->job, curve, *key);
+  if (rv != SUCCESS) return rv;
+
+  // Q1 = r1*G, which P2 can compute from its own share.
+  coinbase::crypto::ecc_point_t Q1 = key->Q;
+  if (key->role == party_t::p2) Q1 = key->Q - curve.mul_to_generator(key->x_share);
+
+  coinbase::crypto::bn_t d;
+  coinbase::crypto::ecc_point_t X;
+  if (importer_party == party_t::p1) {
+    if (key->role == party_t::p1) {
+      MODULO(q) d = x_bn - key->x_share;
+      X = curve.mul_to_generator(x_bn);
+    }
+    rv = wrapper->job->p1_to_p2(d, X);
+    if (rv != SUCCESS) return rv;
+    if (key->role == party_t::p2) {
+      if (curve.mul_to_generator(d) + Q1 != X) return E_CRYPTO;
+      key->x_share = d;
+    }
+  } else {
+    if (key->role == party_t::p1) d = key->x_share;
+    rv = wrapper->job->p1_to_p2(d);
+    if (rv != SUCCESS) return rv;
+    if (key->role == party_t::p2) {
+      if (curve.mul_to_generator(d) != Q1) return E_CRYPTO;
+      MODULO(q) key->x_share = x_bn - d;
+      X = curve.mul_to_generator(x_bn);
+    }
+    rv = wrapper->job->p2_to_p1(X);
+    if (rv != SUCCESS) return rv;
+  }
+  d = 0;
+  x_bn = 0;
+
+  if (has_expected && X != expected) return E_CRYPTO;
+  key->Q = X;
+
+  auto key_wrapper = new cbmpc_ecdsa2p_key;
+  key_wrapper->opaque = key.release();
+  *key_out = key_wrapper;
+  return 0;
+}
+
+// ECDSA 2P Export
+int cbmpc_ecdsa2p_export(cbmpc_job2p *j, const cbmpc_ecdsa2p_key *key, cmem_t *x_out) {
+  auto wrapper = reinterpret_cast<go_job2p *>(j);
+  if (!wrapper || !wrapper->job || !key || !key->opaque || !x_out) return E_BADARG;
+
+  const auto *k = static_cast<const coinbase::mpc::ecdsa2pc::key_t *>(key->opaque);
+  const auto &q = k->curve.order();
+
+  coinbase::crypto::bn_t x1, x2;
+  if (k->role == party_t::p1) x1 = k->x_share;
+  else x2 = k->x_share;
+  error_t rv = wrapper->job->p1_to_p2(x1);
+  if (rv != SUCCESS) return rv;
+  rv = wrapper->job->p2_to_p1(x2);
+  if (rv != SUCCESS) return rv;
+
+  coinbase::crypto::bn_t x;
+  MODULO(q) x = x1 + x2;
+  x1 = 0;
+  x2 = 0;
+  if (k->curve.mul_to_generator(x) != k->Q) return E_CRYPTO;
+
+  buf_t x_bin = x.to_bin(q.get_bin_size());
+  x = 0;
+  *x_out = alloc_and_copy(x_bin.data(), static_cast<size_t>(x_bin.size()));
+  coinbase::secure_bzero(x_bin.data(), x_bin.size());
+  if (!x_out->data) return E_BADARG;
+  return 0;
+}
+
+// Split a cmems_t into count views. Returns false if the layout does not match count.
+static bool split_cmems(cmems_t in, int count, std::vector<mem_t> &out) {
+  if (in.count != count || !in.sizes) return false;
+  out.clear();
+  out.reserve(count);
+  size_t offset = 0;
+  for (int i = 0; i < count; ++i) {
+    int size = in.sizes[i];
+    if (size < 0) return false;
+    out.emplace_back(size > 0 ? in.data + offset : nullptr, size);
+    offset += size;
+  }
+  return true;
+}
+
+// Verify many ECDSA signatures
+int cbmpc_ecdsa_verify_many(int curve_nid, cmems_t pub_keys, cmems_t hashes, cmems_t sigs, int count, int *results_out) {
+  if (count <= 0 || !results_out) return E_BADARG;
+
+  auto curve = find_curve_by_nid(curve_nid);
+  if (!curve) return E_BADARG;
+
+  std::vector<mem_t> pub_vec, hash_vec, sig_vec;
+  if (!split_cmems(pub_keys, count, pub_vec) || !split_cmems(hashes, count, hash_vec) ||
+      !split_cmems(sigs, count, sig_vec)) {
+    return E_BADARG;
+  }
+
+  // The native library has no combined ECDSA verification equation; each
+  // signature is checked on its own, and a bad one does not stop the rest.
+  for (int i = 0; i < count; ++i) {
+    if (pub_vec[i].size == 0 || hash_vec[i].size == 0 || sig_vec[i].size == 0) {
+      results_out[i] = E_BADARG;
+      continue;
+    }
+    coinbase::crypto::ecc_point_t Q;
+    error_t rv = Q.from_oct(curve, pub_vec[i]);
+    if (rv == SUCCESS) rv = coinbase::crypto::ecc_pub_key_t(Q).verify(hash_vec[i], sig_vec[i]);
+    results_out[i] = rv;
+  }
+  return 0;
+}
+
 // ============================================================
 // ECDSA MP protocols
 // ============================================================
@@ -465,20 +730,63 @@ int cbmpc_ecdsamp_refresh(cbmpc_jobmp *j, cmem_t sid_in, const cbmpc_ecdsamp_key
 }
 
 // ECDSA MP Sign
-int cbmpc_ecdsamp_sign(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t msg, int sig_receiver, cmem_t *sig_out) {
+int cbmpc_ecdsamp_sign(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t ac_bytes, cmem_t msg, int sig_receiver,
+                       cmem_t *sig_out) {
   auto wrapper = reinterpret_cast<go_jobmp *>(j);
   if (!wrapper || !wrapper->job || !key || !key->opaque ||
       !msg.data || msg.size <= 0 || !sig_out) return E_BADARG;
 
-  // Copy the key so we can pass a mutable reference to sign
-  auto signing_key_copy = *static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque);
+  // Copy the key, converted to an additive share for a threshold key, so we
+  // can pass a mutable reference to sign
+  coinbase::mpc::ecdsampc::key_t signing_key_copy;
+  error_t rv = signing_share(*wrapper->job, *static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque),
+                             ac_bytes, signing_key_copy);
+  if (rv != SUCCESS) return rv;
+
+  // Sign
+  buf_t signature;
+  mem_t msg_mem(msg.data, msg.size);
+  rv = coinbase::mpc::ecdsampc::sign(*wrapper->job, signing_key_copy, msg_mem, sig_receiver, signature);
+  if (rv != SUCCESS) return rv;
+
+  // Copy output (signature may be empty for non-receiver parties)
+  *sig_out = alloc_and_copy(signature.data(), static_cast<size_t>(signature.size()));
+  return 0;
+}
+
+// ECDSA MP Sign with global abort
+int cbmpc_ecdsamp_sign_with_global_abort(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t ac_bytes, cmem_t msg,
+                                         int sig_receiver, cmem_t *sig_out) {
+  auto wrapper = reinterpret_cast<go_jobmp *>(j);
+  if (!wrapper || !wrapper->job || !key || !key->opaque ||
+      !msg.data || msg.size <= 0 || !sig_out) return E_BADARG;
+
+  // Copy the key, converted to an additive share for a threshold key, so we
+  // can pass a mutable reference to sign
+  coinbase::mpc::ecdsampc::key_t signing_key_copy;
+  error_t rv = signing_share(*wrapper->job, *static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque),
+                             ac_bytes, signing_key_copy);
+  if (rv != SUCCESS) return rv;
 
   // Sign
   buf_t signature;
   mem_t msg_mem(msg.data, msg.size);
-  error_t rv = coinbase::mpc::ecdsampc::sign(*wrapper->job, signing_key_copy, msg_mem, sig_receiver, signature);
+  rv = coinbase::mpc::ecdsampc::sign(*wrapper->job, signing_key_copy, msg_mem, sig_receiver, signature);
   if (rv != SUCCESS) return rv;
 
+  // Only the receiver holds the signature, so it verifies it and broadcasts
+  // the result; every party then aborts together when it does not verify.
+  // The library has no n-party bit-leak code, so the 2P one is reused.
+  uint32_t verified = 1;
+  if (wrapper->job->get_party_idx() == sig_receiver &&
+      coinbase::crypto::ecc_pub_key_t(signing_key_copy.Q).verify(msg_mem, signature) != SUCCESS) {
+    verified = 0;
+  }
+  auto verdict = wrapper->job->uniform_msg<uint32_t>(verified);
+  rv = wrapper->job->plain_broadcast(verdict);
+  if (rv != SUCCESS) return rv;
+  if (verdict.received(sig_receiver) != 1) return E_ECDSA_2P_BIT_LEAK;
+
   // Copy output (signature may be empty for non-receiver parties)
   *sig_out = alloc_and_copy(signature.data(), static_cast<size_t>(signature.size()));
   return 0;
@@ -1060,6 +1368,78 @@ int cbmpc_scalar_add(cmem_t scalar_a_bytes, cmem_t scalar_b_bytes, int curve_nid
   return 0;
 }
 
+int cbmpc_scalar_sub(cmem_t scalar_a_bytes, cmem_t scalar_b_bytes, int curve_nid, cmem_t *result_out) {
+  if (!scalar_a_bytes.data || scalar_a_bytes.size <= 0 ||
+      !scalar_b_bytes.data || scalar_b_bytes.size <= 0 || !result_out) {
+    return E_BADARG;
+  }
+
+  auto curve = find_curve_by_nid(curve_nid);
+  if (!curve) return E_BADARG;
+
+  coinbase::crypto::bn_t scalar_a = coinbase::crypto::bn_t::from_bin(mem_t(scalar_a_bytes.data, scalar_a_bytes.size));
+  coinbase::crypto::bn_t scalar_b = coinbase::crypto::bn_t::from_bin(mem_t(scalar_b_bytes.data, scalar_b_bytes.size));
+
+  // Subtract scalars modulo curve order: result = (scalar_a - scalar_b) mod q
+  const auto& q = curve.order();
+  coinbase::crypto::bn_t result;
+  MODULO(q) {
+    result = scalar_a - scalar_b;
+  }
+
+  buf_t result_bytes = result.to_bin();
+  *result_out = alloc_and_copy(result_bytes.data(), static_cast<size_t>(result_bytes.size()));
+
+  return 0;
+}
+
+int cbmpc_scalar_mul(cmem_t scalar_a_bytes, cmem_t scalar_b_bytes, int curve_nid, cmem_t *result_out) {
+  if (!scalar_a_bytes.data || scalar_a_bytes.size <= 0 ||
+      !scalar_b_bytes.data || scalar_b_bytes.size <= 0 || !result_out) {
+    return E_BADARG;
+  }
+
+  auto curve = find_curve_by_nid(curve_nid);
+  if (!curve) return E_BADARG;
+
+  coinbase::crypto::bn_t scalar_a = coinbase::crypto::bn_t::from_bin(mem_t(scalar_a_bytes.data, scalar_a_bytes.size));
+  coinbase::crypto::bn_t scalar_b = coinbase::crypto::bn_t::from_bin(mem_t(scalar_b_bytes.data, scalar_b_bytes.size));
+
+  // Multiply scalars modulo curve order: result = (scalar_a * scalar_b) mod q
+  const auto& q = curve.order();
+  coinbase::crypto::bn_t result;
+  MODULO(q) {
+    result = scalar_a * scalar_b;
+  }
+
+  buf_t result_bytes = result.to_bin();
+  *result_out = alloc_and_copy(result_bytes.data(), static_cast<size_t>(result_bytes.size()));
+
+  return 0;
+}
+
+int cbmpc_scalar_inv(cmem_t scalar_bytes, int curve_nid, cmem_t *result_out) {
+  if (!scalar_bytes.data || scalar_bytes.size <= 0 || !result_out) {
+    return E_BADARG;
+  }
+
+  auto curve = find_curve_by_nid(curve_nid);
+  if (!curve) return E_BADARG;
+
+  coinbase::crypto::bn_t scalar = coinbase::crypto::bn_t::from_bin(mem_t(scalar_bytes.data, scalar_bytes.size));
+
+  // Invert modulo curve order: result = scalar^-1 mod q
+  const auto& q = curve.order();
+  coinbase::crypto::bn_t reduced = scalar % q;
+  if (reduced == 0) return E_BADARG;
+  coinbase::crypto::bn_t result = q.inv(reduced);
+
+  buf_t result_bytes = result.to_bin();
+  *result_out = alloc_and_copy(result_bytes.data(), static_cast<size_t>(result_bytes.size()));
+
+  return 0;
+}
+
 // PVE operations using ecc_point_t directly
 int cbmpc_pve_get_Q_point(cmem_t pve_ct, cbmpc_ecc_point *Q_point_out) {
   if (!pve_ct.data || pve_ct.size <= 0 || !Q_point_out) {
@@ -1118,6 +1498,36 @@ const void *cbmpc_get_kem_tls(void) {
   return g_cbmpc_kem_tls;
 }
 
+// =====================
+// Hash Commitment Operations
+// =====================
+
+int cbmpc_commit(cmem_t sid, cmem_t party_name, cmem_t msg, cmem_t *com_out, cmem_t *rand_out) {
+  if (!sid.data || sid.size <= 0 || !party_name.data || party_name.size <= 0 || !com_out || !rand_out) {
+    return E_BADARG;
+  }
+  if (msg.size < 0 || (msg.size > 0 && !msg.data)) return E_BADARG;
+
+  const auto pid = coinbase::crypto::pid_from_name(std::string(reinterpret_cast<const char*>(party_name.data), party_name.size));
+  coinbase::crypto::commitment_t com(buf_t(sid.data, sid.size), pid);
+  com.gen(mem_t(msg.data, msg.size));
+
+  *com_out = alloc_and_copy(com.msg.data(), static_cast<size_t>(com.msg.size()));
+  *rand_out = alloc_and_copy(com.rand.data(), static_cast<size_t>(com.rand.size()));
+  return 0;
+}
+
+int cbmpc_commit_open(cmem_t sid, cmem_t party_name, cmem_t com, cmem_t msg, cmem_t rand) {
+  if (!sid.data || sid.size <= 0 || !party_name.data || party_name.size <= 0) return E_BADARG;
+  if (!com.data || com.size <= 0 || !rand.data || rand.size <= 0) return E_BADARG;
+  if (msg.size < 0 || (msg.size > 0 && !msg.data)) return E_BADARG;
+
+  const auto pid = coinbase::crypto::pid_from_name(std::string(reinterpret_cast<const char*>(party_name.data), party_name.size));
+  coinbase::crypto::commitment_t c(buf_t(sid.data, sid.size), pid);
+  c.set(buf_t(rand.data, rand.size), buf_t(com.data, com.size));
+  return c.open(mem_t(msg.data, msg.size));
+}
+
 // =====================
 // EC ElGamal Commitment Operations
 // =====================
@@ -1235,6 +1645,71 @@ int cbmpc_ec_elgamal_commitment_make(cbmpc_ecc_point P, cmem_t m, cmem_t r, cbmp
   return 0;
 }
 
+// Add two EC ElGamal commitments
+int cbmpc_ec_elgamal_commitment_add(cbmpc_ec_elgamal_commitment A, cbmpc_ec_elgamal_commitment B, cbmpc_ec_elgamal_commitment *commitment_out) {
+  if (!A || !B || !commitment_out) {
+    return E_BADARG;
+  }
+
+  const auto* a = reinterpret_cast<const coinbase::crypto::ec_elgamal_commitment_t*>(A);
+  const auto* b = reinterpret_cast<const coinbase::crypto::ec_elgamal_commitment_t*>(B);
+  if (a->L.get_curve() != b->L.get_curve()) return E_BADARG;
+
+  auto commitment = std::make_unique<coinbase::crypto::ec_elgamal_commitment_t>(a->L + b->L, a->R + b->R);
+
+  *commitment_out = reinterpret_cast<cbmpc_ec_elgamal_commitment>(commitment.release());
+  return 0;
+}
+
+// Multiply an EC ElGamal commitment by a scalar
+int cbmpc_ec_elgamal_commitment_scalar_mul(cbmpc_ec_elgamal_commitment commitment, cmem_t s, cbmpc_ec_elgamal_commitment *commitment_out) {
+  if (!commitment || !s.data || s.size <= 0 || !commitment_out) {
+    return E_BADARG;
+  }
+
+  const auto* c = reinterpret_cast<const coinbase::crypto::ec_elgamal_commitment_t*>(commitment);
+  coinbase::crypto::bn_t s_bn = coinbase::crypto::bn_t::from_bin(mem_t(s.data, s.size));
+
+  auto result = std::make_unique<coinbase::crypto::ec_elgamal_commitment_t>(s_bn * c->L, s_bn * c->R);
+
+  *commitment_out = reinterpret_cast<cbmpc_ec_elgamal_commitment>(result.release());
+  return 0;
+}
+
+// Rerandomize an EC ElGamal commitment
+int cbmpc_ec_elgamal_commitment_rerand(cbmpc_ec_elgamal_commitment commitment, cbmpc_ecc_point P, cmem_t r, cbmpc_ec_elgamal_commitment *commitment_out) {
+  if (!commitment || !P || !r.data || r.size <= 0 || !commitment_out) {
+    return E_BADARG;
+  }
+
+  const auto* c = reinterpret_cast<const coinbase::crypto::ec_elgamal_commitment_t*>(commitment);
+  const auto* P_point = reinterpret_cast<const coinbase::crypto::ecc_point_t*>(P);
+  auto curve = c->L.get_curve();
+  if (P_point->get_curve() != curve) return E_BADARG;
+
+  coinbase::crypto::bn_t r_bn = coinbase::crypto::bn_t::from_bin(mem_t(r.data, r.size));
+
+  auto result = std::make_unique<coinbase::crypto::ec_elgamal_commitment_t>(
+    c->L + curve.mul_to_generator(r_bn), c->R + r_bn * (*P_point)
+  );
+
+  *commitment_out = reinterpret_cast<cbmpc_ec_elgamal_commitment>(result.release());
+  return 0;
+}
+
+// Compare two EC ElGamal commitments
+int cbmpc_ec_elgamal_commitment_equal(cbmpc_ec_elgamal_commitment A, cbmpc_ec_elgamal_commitment B, int *equal_out) {
+  if (!A || !B || !equal_out) {
+    return E_BADARG;
+  }
+
+  const auto* a = reinterpret_cast<const coinbase::crypto::ec_elgamal_commitment_t*>(A);
+  const auto* b = reinterpret_cast<const coinbase::crypto::ec_elgamal_commitment_t*>(B);
+
+  *equal_out = (a->L == b->L && a->R == b->R) ? 1 : 0;
+  return 0;
+}
+
 // =====================
 // ZK Proof Operations - UC_DL
 // =====================
@@ -1279,6 +1754,43 @@ int cbmpc_uc_dl_verify(cmem_t proof_bytes, cbmpc_ecc_point Q_point, cmem_t sessi
   return rv;
 }
 
+// UC_DL Verify Many
+int cbmpc_uc_dl_verify_many(cmems_t proofs, cbmpc_ecc_point *Q_points, cmems_t session_ids, const uint64_t *auxs, int count,
+                            int *results_out) {
+  if (count <= 0 || !Q_points || !auxs || !results_out || proofs.count != count || !proofs.data || !proofs.sizes ||
+      session_ids.count != count || !session_ids.data || !session_ids.sizes) {
+    return E_BADARG;
+  }
+
+  size_t proof_offset = 0;
+  size_t sid_offset = 0;
+  for (int i = 0; i < count; ++i) {
+    int proof_size = proofs.sizes[i];
+    int sid_size = session_ids.sizes[i];
+    if (proof_size < 0 || sid_size < 0) return E_BADARG;
+    mem_t proof_mem(proofs.data + proof_offset, proof_size);
+    mem_t sid_mem(session_ids.data + sid_offset, sid_size);
+    proof_offset += proof_size;
+    sid_offset += sid_size;
+
+    if (!Q_points[i] || proof_size == 0 || sid_size == 0) {
+      results_out[i] = E_BADARG;
+      continue;
+    }
+
+    // Deserialize and verify each proof independently; a bad proof does not
+    // stop the rest of the batch.
+    coinbase::zk::uc_dl_t proof;
+    error_t rv = coinbase::deser(proof_mem, proof);
+    if (rv == SUCCESS) {
+      const auto* Q = reinterpret_cast<const coinbase::crypto::ecc_point_t*>(Q_points[i]);
+      rv = proof.verify(*Q, sid_mem, auxs[i]);
+    }
+    results_out[i] = rv;
+  }
+  return 0;
+}
+
 // =====================
 // ZK Proof Operations - UC_Batch_DL
 // =====================
@@ -1445,6 +1957,23 @@ int cbmpc_schnorr2p_dkg(cbmpc_job2p *j, int curve_nid, cbmpc_schnorr2p_key **key
   return 0;
 }
 
+// Refresh a Schnorr 2P key
+int cbmpc_schnorr2p_refresh(cbmpc_job2p *j, const cbmpc_schnorr2p_key *key_in, cbmpc_schnorr2p_key **key_out) {
+  auto wrapper = reinterpret_cast<go_job2p *>(j);
+  if (!wrapper || !wrapper->job || !key_in || !key_in->opaque || !key_out) return E_BADARG;
+
+  const auto *old_key = static_cast<const coinbase::mpc::eckey::key_share_2p_t *>(key_in->opaque);
+
+  auto new_key = std::make_unique<coinbase::mpc::eckey::key_share_2p_t>();
+  error_t rv = coinbase::mpc::eckey::key_share_2p_t::refresh(*wrapper->job, *old_key, *new_key);
+  if (rv != SUCCESS) return rv;
+
+  auto key_wrapper = new cbmpc_schnorr2p_key;
+  key_wrapper->opaque = new_key.release();
+  *key_out = key_wrapper;
+  return 0;
+}
+
 // Free a Schnorr 2P key
 void cbmpc_schnorr2p_key_free(cbmpc_schnorr2p_key *key) {
   if (key && key->opaque) {
@@ -1548,6 +2077,29 @@ int cbmpc_schnorr2p_key_get_curve(const cbmpc_schnorr2p_key *key, int *curve_nid
   return 0;
 }
 
+// Get role from Schnorr 2P key
+int cbmpc_schnorr2p_key_get_role(const cbmpc_schnorr2p_key *key, int *role_out) {
+  if (!key || !key->opaque || !role_out) return E_BADARG;
+
+  const auto* cpp_key = static_cast<const coinbase::mpc::eckey::key_share_2p_t*>(key->opaque);
+  *role_out = cpp_key->role == party_t::p1 ? 0 : 1;
+
+  return 0;
+}
+
+// Check a Schnorr 2P key share for internal consistency
+int cbmpc_schnorr2p_key_validate(const cbmpc_schnorr2p_key *key) {
+  if (!key || !key->opaque) return E_BADARG;
+
+  const auto* cpp_key = static_cast<const coinbase::mpc::eckey::key_share_2p_t*>(key->opaque);
+  if (!cpp_key->curve) return E_CRYPTO;
+  const auto& q = cpp_key->curve.order();
+  if (cpp_key->x_share <= 0 || cpp_key->x_share >= q) return E_CRYPTO;
+  if (cpp_key->Q.is_infinity() || cpp_key->curve.check(cpp_key->Q) != SUCCESS) return E_CRYPTO;
+
+  return 0;
+}
+
 // Schnorr 2P Sign
 int cbmpc_schnorr2p_sign(cbmpc_job2p *j, const cbmpc_schnorr2p_key *key, cmem_t msg, int variant, cmem_t *sig_out) {
   auto wrapper = reinterpret_cast<go_job2p *>(j);
@@ -1564,6 +2116,7 @@ int cbmpc_schnorr2p_sign(cbmpc_job2p *j, const cbmpc_schnorr2p_key *key, cmem_t
   // Sign
   buf_t signature;
   mem_t msg_mem(msg.data, msg.size);
+  cbmpc_detnonce::scope_t nonces(signing_key_copy, {msg_mem});
   error_t rv = coinbase::mpc::schnorr2p::sign(*wrapper->job, signing_key_copy, msg_mem, signature, cpp_variant);
   if (rv != SUCCESS) return rv;
 
@@ -1605,6 +2158,7 @@ int cbmpc_schnorr2p_sign_batch(cbmpc_job2p *j, const cbmpc_schnorr2p_key *key, c
 
   // Sign batch
   std::vector<buf_t> signatures;
+  cbmpc_detnonce::scope_t nonces(signing_key_copy, msg_vec);
   error_t rv = coinbase::mpc::schnorr2p::sign_batch(*wrapper->job, signing_key_copy, msg_vec, signatures, cpp_variant);
   if (rv != SUCCESS) return rv;
 
@@ -1616,6 +2170,52 @@ int cbmpc_schnorr2p_sign_batch(cbmpc_job2p *j, const cbmpc_schnorr2p_key *key, c
   return 0;
 }
 
+// Verify many Schnorr signatures
+int cbmpc_schnorr_verify_many(int curve_nid, int variant, cmems_t pub_keys, cmems_t msgs, cmems_t sigs, int count,
+                              int *results_out) {
+  if (count <= 0 || !results_out) return E_BADARG;
+  if (variant != CBMPC_SCHNORR_VARIANT_EDDSA && variant != CBMPC_SCHNORR_VARIANT_BIP340) return E_BADARG;
+
+  auto curve = find_curve_by_nid(curve_nid);
+  if (!curve) return E_BADARG;
+
+  std::vector<mem_t> pub_vec, msg_vec, sig_vec;
+  if (!split_cmems(pub_keys, count, pub_vec) || !split_cmems(msgs, count, msg_vec) ||
+      !split_cmems(sigs, count, sig_vec)) {
+    return E_BADARG;
+  }
+
+  for (int i = 0; i < count; ++i) {
+    if (pub_vec[i].size == 0 || sig_vec[i].size == 0) {
+      results_out[i] = E_BADARG;
+      continue;
+    }
+    coinbase::crypto::ecc_point_t Q;
+    error_t rv;
+    if (variant == CBMPC_SCHNORR_VARIANT_BIP340) {
+      if (msg_vec[i].size != 32) {
+        results_out[i] = E_BADARG;
+        continue;
+      }
+      // BIP340 keys are x-only; lift them to the point with an even y-coordinate.
+      if (pub_vec[i].size == 32) {
+        buf_t compressed(33);
+        compressed[0] = 0x02;
+        memcpy(compressed.data() + 1, pub_vec[i].data, 32);
+        rv = Q.from_oct(curve, compressed);
+      } else {
+        rv = Q.from_oct(curve, pub_vec[i]);
+      }
+      if (rv == SUCCESS) rv = coinbase::crypto::bip340::verify(Q, msg_vec[i], sig_vec[i]);
+    } else {
+      rv = Q.from_oct(curve, pub_vec[i]);
+      if (rv == SUCCESS) rv = coinbase::crypto::ecc_pub_key_t(Q).verify(msg_vec[i], sig_vec[i]);
+    }
+    results_out[i] = rv;
+  }
+  return 0;
+}
+
 // ============================================================
 // Schnorr MP protocols
 // ============================================================
@@ -1684,13 +2284,18 @@ int cbmpc_schnorrmp_refresh(cbmpc_jobmp *j, cmem_t sid_in, const cbmpc_ecdsamp_k
 }
 
 // Schnorr MP Sign
-int cbmpc_schnorrmp_sign(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t msg, int sig_receiver, int variant, cmem_t *sig_out) {
+int cbmpc_schnorrmp_sign(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t ac_bytes, cmem_t msg, int sig_receiver,
+                         int variant, cmem_t *sig_out) {
   auto wrapper = reinterpret_cast<go_jobmp *>(j);
   if (!wrapper || !wrapper->job || !key || !key->opaque ||
       !msg.data || msg.size <= 0 || !sig_out) return E_BADARG;
 
-  // Copy the key so we can pass a mutable reference to sign
-  auto signing_key_copy = *static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque);
+  // Copy the key, converted to an additive share for a threshold key, so we
+  // can pass a mutable reference to sign
+  coinbase::mpc::ecdsampc::key_t signing_key_copy;
+  error_t rv = signing_share(*wrapper->job, *static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque),
+                             ac_bytes, signing_key_copy);
+  if (rv != SUCCESS) return rv;
 
   // Convert variant
   auto cpp_variant = int_to_schnorrmp_variant(variant);
@@ -1698,7 +2303,7 @@ int cbmpc_schnorrmp_sign(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t ms
   // Sign
   buf_t signature;
   mem_t msg_mem(msg.data, msg.size);
-  error_t rv = coinbase::mpc::schnorrmp::sign(*wrapper->job, signing_key_copy, msg_mem, sig_receiver, signature, cpp_variant);
+  rv = coinbase::mpc::schnorrmp::sign(*wrapper->job, signing_key_copy, msg_mem, sig_receiver, signature, cpp_variant);
   if (rv != SUCCESS) return rv;
 
   // Copy output (signature may be empty for non-receiver parties)
@@ -1707,13 +2312,18 @@ int cbmpc_schnorrmp_sign(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t ms
 }
 
 // Schnorr MP Sign Batch
-int cbmpc_schnorrmp_sign_batch(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmems_t msgs, int sig_receiver, int variant, cmems_t *sigs_out) {
+int cbmpc_schnorrmp_sign_batch(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t ac_bytes, cmems_t msgs, int sig_receiver,
+                               int variant, cmems_t *sigs_out) {
   auto wrapper = reinterpret_cast<go_jobmp *>(j);
   if (!wrapper || !wrapper->job || !key || !key->opaque || !sigs_out) return E_BADARG;
   if (msgs.count <= 0 || !msgs.data || !msgs.sizes) return E_BADARG;
 
-  // Copy the key so we can pass a mutable reference to sign_batch
-  auto signing_key_copy = *static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque);
+  // Copy the key, converted to an additive share for a threshold key, so we
+  // can pass a mutable reference to sign_batch
+  coinbase::mpc::ecdsampc::key_t signing_key_copy;
+  error_t rv = signing_share(*wrapper->job, *static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque),
+                             ac_bytes, signing_key_copy);
+  if (rv != SUCCESS) return rv;
 
   // Convert variant
   auto cpp_variant = int_to_schnorrmp_variant(variant);
@@ -1734,7 +2344,7 @@ int cbmpc_schnorrmp_sign_batch(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cme
 
   // Sign batch
   std::vector<buf_t> signatures;
-  error_t rv = coinbase::mpc::schnorrmp::sign_batch(*wrapper->job, signing_key_copy, msg_vec, sig_receiver, signatures, cpp_variant);
+  rv = coinbase::mpc::schnorrmp::sign_batch(*wrapper->job, signing_key_copy, msg_vec, sig_receiver, signatures, cpp_variant);
   if (rv != SUCCESS) return rv;
 
   // Copy outputs (signatures may be empty for non-receiver parties)
@@ -2301,6 +2911,134 @@ int cbmpc_paillier_range_exp_slack_verify(cmem_t proof_bytes, cbmpc_paillier pai
   return rv;
 }
 
+// =====================
+// ZK Proof Operations - PDL
+// =====================
+
+// PDL Prove
+int cbmpc_pdl_prove(cbmpc_paillier paillier, cmem_t c, cbmpc_ecc_point Q_point, cmem_t x, cmem_t r, cmem_t session_id, uint64_t aux, cmem_t *proof_out) {
+  if (!paillier || !c.data || c.size <= 0 || !Q_point ||
+      !x.data || x.size <= 0 || !r.data || r.size <= 0 ||
+      !session_id.data || session_id.size <= 0 || !proof_out) {
+    return E_BADARG;
+  }
+
+  const auto* p = static_cast<const coinbase::crypto::paillier_t*>(paillier);
+
+  // Verify that the paillier instance has a private key (required for proving)
+  if (!p->has_private_key()) {
+    return E_BADARG;
+  }
+
+  const auto* Q = reinterpret_cast<const coinbase::crypto::ecc_point_t*>(Q_point);
+
+  // Deserialize parameters from bytes
+  coinbase::crypto::bn_t c_bn = coinbase::crypto::bn_t::from_bin(mem_t(c.data, c.size));
+  coinbase::crypto::bn_t x_bn = coinbase::crypto::bn_t::from_bin(mem_t(x.data, x.size));
+  coinbase::crypto::bn_t r_bn = coinbase::crypto::bn_t::from_bin(mem_t(r.data, r.size));
+
+  // Create proof
+  coinbase::zk::pdl_t proof;
+  proof.prove(c_bn, *p, *Q, x_bn, r_bn, mem_t(session_id.data, session_id.size), aux);
+
+  // Serialize proof to bytes and return
+  buf_t serialized = coinbase::ser(proof);
+  *proof_out = alloc_and_copy(serialized.data(), static_cast<size_t>(serialized.size()));
+
+  return 0;
+}
+
+// PDL Verify
+int cbmpc_pdl_verify(cmem_t proof_bytes, cbmpc_paillier paillier, cmem_t c, cbmpc_ecc_point Q_point, cmem_t session_id, uint64_t aux) {
+  if (!proof_bytes.data || proof_bytes.size <= 0 || !paillier ||
+      !c.data || c.size <= 0 || !Q_point ||
+      !session_id.data || session_id.size <= 0) {
+    return E_BADARG;
+  }
+
+  // Deserialize proof from bytes
+  coinbase::zk::pdl_t proof;
+  error_t rv = coinbase::deser(mem_t(proof_bytes.data, proof_bytes.size), proof);
+  if (rv != SUCCESS) return rv;
+
+  const auto* p = static_cast<const coinbase::crypto::paillier_t*>(paillier);
+  const auto* Q = reinterpret_cast<const coinbase::crypto::ecc_point_t*>(Q_point);
+
+  coinbase::crypto::bn_t c_bn = coinbase::crypto::bn_t::from_bin(mem_t(c.data, c.size));
+
+  // Verify (public key only is sufficient for verification)
+  rv = proof.verify(c_bn, *p, *Q, mem_t(session_id.data, session_id.size), aux);
+  return rv;
+}
+
+// =====================
+// ZK Proof Operations - Range_Pedersen
+// =====================
+
+// Pedersen commitment over the library's unknown-order group
+int cbmpc_pedersen_commit(cmem_t x, cmem_t *c_out, cmem_t *r_out) {
+  if (!x.data || x.size <= 0 || !c_out || !r_out) {
+    return E_BADARG;
+  }
+
+  const auto& params = coinbase::crypto::pedersen_commitment_params_t::get();
+  coinbase::crypto::bn_t x_bn = coinbase::crypto::bn_t::from_bin(mem_t(x.data, x.size));
+  coinbase::crypto::bn_t r_bn = coinbase::crypto::bn_t::rand(params.N);
+  coinbase::crypto::bn_t c_bn = params.commit(x_bn, r_bn);
+
+  buf_t c_bin = c_bn.to_bin();
+  buf_t r_bin = r_bn.to_bin();
+  *c_out = alloc_and_copy(c_bin.data(), static_cast<size_t>(c_bin.size()));
+  *r_out = alloc_and_copy(r_bin.data(), static_cast<size_t>(r_bin.size()));
+  if ((!c_out->data && c_bin.size() > 0) || (!r_out->data && r_bin.size() > 0)) return E_BADARG;
+
+  return 0;
+}
+
+// Range_Pedersen Prove
+int cbmpc_range_pedersen_prove(cmem_t q, cmem_t c, cmem_t x, cmem_t r, cmem_t session_id, uint64_t aux, cmem_t *proof_out) {
+  if (!q.data || q.size <= 0 || !c.data || c.size <= 0 || !x.data || x.size <= 0 ||
+      !r.data || r.size <= 0 || !session_id.data || session_id.size <= 0 || !proof_out) {
+    return E_BADARG;
+  }
+
+  // Deserialize parameters from bytes
+  coinbase::crypto::bn_t q_bn = coinbase::crypto::bn_t::from_bin(mem_t(q.data, q.size));
+  coinbase::crypto::bn_t c_bn = coinbase::crypto::bn_t::from_bin(mem_t(c.data, c.size));
+  coinbase::crypto::bn_t x_bn = coinbase::crypto::bn_t::from_bin(mem_t(x.data, x.size));
+  coinbase::crypto::bn_t r_bn = coinbase::crypto::bn_t::from_bin(mem_t(r.data, r.size));
+
+  // Create proof
+  coinbase::zk::range_pedersen_t proof;
+  proof.prove(q_bn, c_bn, x_bn, r_bn, mem_t(session_id.data, session_id.size), aux);
+
+  // Serialize proof to bytes and return
+  buf_t serialized = coinbase::ser(proof);
+  *proof_out = alloc_and_copy(serialized.data(), static_cast<size_t>(serialized.size()));
+
+  return 0;
+}
+
+// Range_Pedersen Verify
+int cbmpc_range_pedersen_verify(cmem_t proof_bytes, cmem_t q, cmem_t c, cmem_t session_id, uint64_t aux) {
+  if (!proof_bytes.data || proof_bytes.size <= 0 || !q.data || q.size <= 0 ||
+      !c.data || c.size <= 0 || !session_id.data || session_id.size <= 0) {
+    return E_BADARG;
+  }
+
+  // Deserialize proof from bytes
+  coinbase::zk::range_pedersen_t proof;
+  error_t rv = coinbase::deser(mem_t(proof_bytes.data, proof_bytes.size), proof);
+  if (rv != SUCCESS) return rv;
+
+  // Deserialize parameters from bytes
+  coinbase::crypto::bn_t q_bn = coinbase::crypto::bn_t::from_bin(mem_t(q.data, q.size));
+  coinbase::crypto::bn_t c_bn = coinbase::crypto::bn_t::from_bin(mem_t(c.data, c.size));
+
+  rv = proof.verify(q_bn, c_bn, mem_t(session_id.data, session_id.size), aux);
+  return rv;
+}
+
 // =====================
 // Access Control (AC) Builder Operations
 // =====================
@@ -2499,6 +3237,131 @@ int cbmpc_ac_list_leaf_paths(cmem_t ac_bytes, cmems_t *paths_out) {
   return 0;
 }
 
+static int ac_kind(coinbase::crypto::ss::node_e type) {
+  switch (type) {
+    case coinbase::crypto::ss::node_e::LEAF: return CBMPC_AC_LEAF;
+    case coinbase::crypto::ss::node_e::AND: return CBMPC_AC_AND;
+    case coinbase::crypto::ss::node_e::OR: return CBMPC_AC_OR;
+    case coinbase::crypto::ss::node_e::THRESHOLD: return CBMPC_AC_THRESHOLD;
+    default: return 0;
+  }
+}
+
+static void put_u32_le(std::vector<uint8_t> &out, uint32_t v) {
+  out.push_back(static_cast<uint8_t>(v));
+  out.push_back(static_cast<uint8_t>(v >> 8));
+  out.push_back(static_cast<uint8_t>(v >> 16));
+  out.push_back(static_cast<uint8_t>(v >> 24));
+}
+
+static error_t describe_ac_node(const coinbase::crypto::ss::node_t *node, std::vector<buf_t> &paths, std::vector<uint8_t> &shape) {
+  int kind = ac_kind(node->type);
+  if (kind == 0) return E_BADARG;
+
+  std::string path = node->get_path();
+  paths.emplace_back(reinterpret_cast<const uint8_t*>(path.c_str()), path.size());
+  put_u32_le(shape, static_cast<uint32_t>(kind));
+  put_u32_le(shape, kind == CBMPC_AC_THRESHOLD ? static_cast<uint32_t>(node->threshold) : 0);
+  put_u32_le(shape, static_cast<uint32_t>(node->children.size()));
+
+  for (const auto *child : node->children) {
+    error_t rv = describe_ac_node(child, paths, shape);
+    if (rv != SUCCESS) return rv;
+  }
+  return SUCCESS;
+}
+
+// Describe an AC tree in pre-order
+int cbmpc_ac_describe(cmem_t ac_bytes, cmems_t *paths_out, cmem_t *shape_out) {
+  if (!ac_bytes.data || ac_bytes.size <= 0 || !paths_out || !shape_out) {
+    return E_BADARG;
+  }
+
+  // Deserialize AC
+  coinbase::crypto::ss::ac_owned_t ac;
+  error_t rv = coinbase::deser(mem_t(ac_bytes.data, ac_bytes.size), ac);
+  if (rv != SUCCESS) return rv;
+  if (!ac.root) return E_BADARG;
+
+  std::vector<buf_t> paths;
+  std::vector<uint8_t> shape;
+  rv = describe_ac_node(ac.root, paths, shape);
+  if (rv != SUCCESS) return rv;
+
+  *paths_out = alloc_and_copy_vector(paths);
+  *shape_out = alloc_and_copy(shape.data(), shape.size());
+  return 0;
+}
+
+static std::vector<coinbase::crypto::bn_t> cmems_to_bns(cmems_t v) {
+  std::vector<coinbase::crypto::bn_t> out;
+  out.reserve(static_cast<size_t>(v.count));
+  size_t offset = 0;
+  for (int i = 0; i < v.count; ++i) {
+    out.push_back(coinbase::crypto::bn_t::from_bin(mem_t(v.data + offset, v.sizes[i])));
+    offset += v.sizes[i];
+  }
+  return out;
+}
+
+int cbmpc_ss_share_threshold(int curve_nid, cmem_t secret, int threshold, int n, cmems_t *shares_out, cmems_t *coeffs_out) {
+  if (!secret.data || secret.size <= 0 || threshold < 1 || n < threshold || !shares_out || !coeffs_out) {
+    return E_BADARG;
+  }
+
+  auto curve = find_curve_by_nid(curve_nid);
+  if (!curve) return E_BADARG;
+  const auto& q = curve.order();
+
+  coinbase::crypto::bn_t a = coinbase::crypto::bn_t::from_bin(mem_t(secret.data, secret.size));
+  std::vector<coinbase::crypto::bn_t> pids;
+  pids.reserve(static_cast<size_t>(n));
+  for (int i = 1; i <= n; ++i) {
+    pids.emplace_back(i);
+  }
+
+  auto [shares, coeffs] = coinbase::crypto::ss::share_threshold(q, a, threshold, n, pids);
+  if (static_cast<int>(shares.size()) != n || static_cast<int>(coeffs.size()) != threshold) {
+    return E_GENERAL;
+  }
+
+  std::vector<buf_t> share_bytes;
+  share_bytes.reserve(shares.size());
+  for (const auto& s : shares) {
+    share_bytes.push_back(s.to_bin(q.get_bin_size()));
+  }
+  std::vector<buf_t> coeff_bytes;
+  coeff_bytes.reserve(coeffs.size());
+  for (const auto& b : coeffs) {
+    coeff_bytes.push_back(b.to_bin(q.get_bin_size()));
+  }
+
+  *shares_out = alloc_and_copy_vector(share_bytes);
+  *coeffs_out = alloc_and_copy_vector(coeff_bytes);
+  return 0;
+}
+
+int cbmpc_ss_lagrange_interpolate(int curve_nid, cmems_t shares, cmems_t pids, cmem_t *secret_out) {
+  if (!shares.data || shares.count <= 0 || !shares.sizes ||
+      !pids.data || pids.count <= 0 || !pids.sizes ||
+      shares.count != pids.count || !secret_out) {
+    return E_BADARG;
+  }
+
+  auto curve = find_curve_by_nid(curve_nid);
+  if (!curve) return E_BADARG;
+  const auto& q = curve.order();
+
+  std::vector<coinbase::crypto::bn_t> share_vec = cmems_to_bns(shares);
+  std::vector<coinbase::crypto::bn_t> pid_vec = cmems_to_bns(pids);
+
+  coinbase::crypto::bn_t secret = coinbase::crypto::ss::lagrange_interpolate(coinbase::crypto::bn_t(0), share_vec, pid_vec, q);
+
+  buf_t secret_bytes = secret.to_bin(q.get_bin_size());
+  *secret_out = alloc_and_copy(secret_bytes.data(), static_cast<size_t>(secret_bytes.size()));
+  return 0;
+}
+
 // Free an AC node
 void cbmpc_ac_node_free(cbmpc_ac_node node) {
   if (node) {
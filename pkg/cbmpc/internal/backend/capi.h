@@ -23,6 +23,16 @@ int cbmpc_multi_agree_random(cbmpc_jobmp *j, int bitlen, cmem_t *out);
 int cbmpc_weak_multi_agree_random(cbmpc_jobmp *j, int bitlen, cmem_t *out);
 int cbmpc_multi_pairwise_agree_random(cbmpc_jobmp *j, int bitlen, cmems_t *out);
 
+// Oblivious transfer (native base OT followed by OT extension)
+// The sender calls cbmpc_ot_send and its peer cbmpc_ot_receive with the same sid.
+
+// Send one OT per pair (x0[i], x1[i]); every message must have the same non-zero size.
+int cbmpc_ot_send(cbmpc_job2p *j, cmem_t sid, cmems_t x0, cmems_t x1);
+
+// Receive one OT per choice bit and return the chosen messages, each msg_len bytes.
+// choices: count bits packed least significant bit first.
+int cbmpc_ot_receive(cbmpc_job2p *j, cmem_t sid, cmem_t choices, int count, int msg_len, cmems_t *out);
+
 // ECDSA 2P protocols
 // All functions return a key that must be freed with cbmpc_ecdsa2p_key_free.
 
@@ -46,6 +56,25 @@ int cbmpc_ecdsa2p_sign_with_global_abort(cbmpc_job2p *j, cmem_t sid_in, const cb
 // Returns E_ECDSA_2P_BIT_LEAK if signature verification fails (indicates potential key leak).
 int cbmpc_ecdsa2p_sign_with_global_abort_batch(cbmpc_job2p *j, cmem_t sid_in, const cbmpc_ecdsa2p_key *key, cmems_t msgs, cmem_t *sid_out, cmems_t *sigs_out);
 
+// Import an existing ECDSA private key into a 2P key without changing its public key.
+// importer is 0 for P1 and 1 for P2; the importer passes the big-endian scalar in x and
+// the other party passes an empty x. expected_Q, if non-empty, is the compressed public
+// key the caller expects the resulting key to have.
+int cbmpc_ecdsa2p_import(cbmpc_job2p *j, int curve_nid, int importer, cmem_t x, cmem_t expected_Q, cbmpc_ecdsa2p_key **key_out);
+
+// Reconstruct the private key of an ECDSA 2P key. Both parties must run it; each
+// receives the big-endian scalar in x_out.
+int cbmpc_ecdsa2p_export(cbmpc_job2p *j, const cbmpc_ecdsa2p_key *key, cmem_t *x_out);
+
+// Verify many ECDSA signatures in one call
+// curve_nid: curve of every public key
+// pub_keys: count public key encodings (compressed or uncompressed)
+// hashes: count message hashes; sigs: count DER-encoded signatures
+// results_out: caller-allocated array of count ints; results_out[i] receives the
+//              verification result of signature i (0 on success)
+// Returns 0 if every signature was processed, regardless of individual results.
+int cbmpc_ecdsa_verify_many(int curve_nid, cmems_t pub_keys, cmems_t hashes, cmems_t sigs, int count, int *results_out);
+
 // ECDSA MP protocols
 // All functions return a key that must be freed with cbmpc_ecdsamp_key_free.
 
@@ -59,7 +88,18 @@ int cbmpc_ecdsamp_refresh(cbmpc_jobmp *j, cmem_t sid_in, const cbmpc_ecdsamp_key
 
 // Sign a message with an ECDSA MP key.
 // Only the party with party_idx == sig_receiver will receive the final signature.
-int cbmpc_ecdsamp_sign(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t msg, int sig_receiver, cmem_t *sig_out);
+// ac_bytes: serialized access structure of a threshold key, empty for an
+// n-of-n key. A threshold key is converted to an additive share over the
+// job's parties, which must satisfy the access structure.
+int cbmpc_ecdsamp_sign(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t ac_bytes, cmem_t msg, int sig_receiver,
+                       cmem_t *sig_out);
+
+// Sign a message with an ECDSA MP key using global abort mode.
+// The receiver verifies the signature and broadcasts the result; every party
+// returns E_ECDSA_2P_BIT_LEAK if verification fails (indicates potential key leak).
+// ac_bytes is as for cbmpc_ecdsamp_sign.
+int cbmpc_ecdsamp_sign_with_global_abort(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t ac_bytes, cmem_t msg,
+                                         int sig_receiver, cmem_t *sig_out);
 
 // Perform multi-party ECDSA threshold DKG with access control.
 // ac_bytes: serialized access control structure
@@ -155,6 +195,18 @@ int cbmpc_ecc_point_add(cbmpc_ecc_point point_a, cbmpc_ecc_point point_b, cbmpc_
 // Returns result scalar bytes (big-endian).
 int cbmpc_scalar_add(cmem_t scalar_a_bytes, cmem_t scalar_b_bytes, int curve_nid, cmem_t *result_out);
 
+// Subtract two scalars: result = scalar_a - scalar_b (mod curve_order)
+// Returns result scalar bytes (big-endian).
+int cbmpc_scalar_sub(cmem_t scalar_a_bytes, cmem_t scalar_b_bytes, int curve_nid, cmem_t *result_out);
+
+// Multiply two scalars: result = scalar_a * scalar_b (mod curve_order)
+// Returns result scalar bytes (big-endian).
+int cbmpc_scalar_mul(cmem_t scalar_a_bytes, cmem_t scalar_b_bytes, int curve_nid, cmem_t *result_out);
+
+// Invert a scalar: result = scalar^-1 (mod curve_order)
+// Returns E_BADARG if the scalar is zero modulo the curve order.
+int cbmpc_scalar_inv(cmem_t scalar_bytes, int curve_nid, cmem_t *result_out);
+
 // PVE operations using ecc_point_t directly (more efficient)
 // Extract public key Q from a PVE ciphertext as an ecc_point_t.
 // Returns a borrowed reference - do NOT free the returned point.
@@ -213,6 +265,31 @@ int cbmpc_ac_to_string(cmem_t ac_bytes, cmem_t *str_out);
 // Returns cmems_t containing leaf path strings (UTF-8).
 int cbmpc_ac_list_leaf_paths(cmem_t ac_bytes, cmems_t *paths_out);
 
+// AC node kinds reported by cbmpc_ac_describe.
+#define CBMPC_AC_LEAF 1
+#define CBMPC_AC_AND 2
+#define CBMPC_AC_OR 3
+#define CBMPC_AC_THRESHOLD 4
+
+// Describe an AC tree in pre-order.
+// ac_bytes: serialized AC bytes.
+// paths_out: one entry per node holding its path (the root path is empty).
+// shape_out: three little-endian uint32 values per node:
+//   kind (CBMPC_AC_*), threshold (0 unless THRESHOLD), child count.
+int cbmpc_ac_describe(cmem_t ac_bytes, cmems_t *paths_out, cmem_t *shape_out);
+
+// Shamir secret sharing (crypto::ss)
+// Deal secret into n shares for party indices 1..n with threshold t.
+// secret: big-endian scalar bytes.
+// shares_out: share values f(1)..f(n), big-endian.
+// coeffs_out: polynomial coefficients a_0..a_{t-1}, big-endian, a_0 = secret.
+int cbmpc_ss_share_threshold(int curve_nid, cmem_t secret, int threshold, int n, cmems_t *shares_out, cmems_t *coeffs_out);
+
+// Interpolate f(0) from shares at the given party indices.
+// shares, pids: parallel lists of big-endian scalars; pids must be distinct and non-zero.
+// Returns the secret scalar bytes (big-endian).
+int cbmpc_ss_lagrange_interpolate(int curve_nid, cmems_t shares, cmems_t pids, cmem_t *secret_out);
+
 // Free an AC node (and its entire subtree if it's a parent node).
 void cbmpc_ac_node_free(cbmpc_ac_node node);
 
@@ -281,6 +358,9 @@ const void *cbmpc_get_kem_tls(void);
 // Perform 2-party Schnorr distributed key generation.
 int cbmpc_schnorr2p_dkg(cbmpc_job2p *j, int curve_nid, cbmpc_schnorr2p_key **key_out);
 
+// Refresh a 2-party Schnorr key, keeping its public key.
+int cbmpc_schnorr2p_refresh(cbmpc_job2p *j, const cbmpc_schnorr2p_key *key_in, cbmpc_schnorr2p_key **key_out);
+
 // Free a Schnorr 2P key.
 void cbmpc_schnorr2p_key_free(cbmpc_schnorr2p_key *key);
 
@@ -296,6 +376,14 @@ int cbmpc_schnorr2p_key_get_public_key(const cbmpc_schnorr2p_key *key, cmem_t *o
 // Get the curve NID from a Schnorr 2P key.
 int cbmpc_schnorr2p_key_get_curve(const cbmpc_schnorr2p_key *key, int *curve_nid_out);
 
+// Get the role of a Schnorr 2P key share: 0 for P1, 1 for P2.
+int cbmpc_schnorr2p_key_get_role(const cbmpc_schnorr2p_key *key, int *role_out);
+
+// Check a Schnorr 2P key share for internal consistency: the curve is known,
+// the share is in [1, q) and Q is a valid point other than infinity.
+// Returns E_CRYPTO if a check fails.
+int cbmpc_schnorr2p_key_validate(const cbmpc_schnorr2p_key *key);
+
 // Sign a message with a Schnorr 2P key.
 // variant: CBMPC_SCHNORR_VARIANT_EDDSA or CBMPC_SCHNORR_VARIANT_BIP340
 int cbmpc_schnorr2p_sign(cbmpc_job2p *j, const cbmpc_schnorr2p_key *key, cmem_t msg, int variant, cmem_t *sig_out);
@@ -304,6 +392,15 @@ int cbmpc_schnorr2p_sign(cbmpc_job2p *j, const cbmpc_schnorr2p_key *key, cmem_t
 // variant: CBMPC_SCHNORR_VARIANT_EDDSA or CBMPC_SCHNORR_VARIANT_BIP340
 int cbmpc_schnorr2p_sign_batch(cbmpc_job2p *j, const cbmpc_schnorr2p_key *key, cmems_t msgs, int variant, cmems_t *sigs_out);
 
+// Verify many Schnorr signatures in one call
+// curve_nid: curve of every public key; variant: CBMPC_SCHNORR_VARIANT_EDDSA or CBMPC_SCHNORR_VARIANT_BIP340
+// pub_keys: count public key encodings (compressed for EdDSA, compressed or x-only for BIP340)
+// msgs: count messages (raw for EdDSA, 32-byte hashes for BIP340); sigs: count signatures
+// results_out: caller-allocated array of count ints; results_out[i] receives the
+//              verification result of signature i (0 on success)
+// Returns 0 if every signature was processed, regardless of individual results.
+int cbmpc_schnorr_verify_many(int curve_nid, int variant, cmems_t pub_keys, cmems_t msgs, cmems_t sigs, int count, int *results_out);
+
 // Schnorr MP protocols
 // Schnorr MP uses the same key type as ECDSA MP (eckey::key_share_mp_t).
 
@@ -320,12 +417,16 @@ int cbmpc_schnorrmp_refresh(cbmpc_jobmp *j, cmem_t sid_in, const cbmpc_ecdsamp_k
 // Sign a message with a Schnorr MP key.
 // Only the party with party_idx == sig_receiver will receive the final signature.
 // variant: CBMPC_SCHNORR_VARIANT_EDDSA or CBMPC_SCHNORR_VARIANT_BIP340
-int cbmpc_schnorrmp_sign(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t msg, int sig_receiver, int variant, cmem_t *sig_out);
+// ac_bytes is as for cbmpc_ecdsamp_sign.
+int cbmpc_schnorrmp_sign(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t ac_bytes, cmem_t msg, int sig_receiver,
+                         int variant, cmem_t *sig_out);
 
 // Sign multiple messages with a Schnorr MP key (batch mode).
 // Only the party with party_idx == sig_receiver will receive the final signatures.
 // variant: CBMPC_SCHNORR_VARIANT_EDDSA or CBMPC_SCHNORR_VARIANT_BIP340
-int cbmpc_schnorrmp_sign_batch(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmems_t msgs, int sig_receiver, int variant, cmems_t *sigs_out);
+// ac_bytes is as for cbmpc_ecdsamp_sign.
+int cbmpc_schnorrmp_sign_batch(cbmpc_jobmp *j, const cbmpc_ecdsamp_key *key, cmem_t ac_bytes, cmems_t msgs,
+                               int sig_receiver, int variant, cmems_t *sigs_out);
 
 // Perform multi-party Schnorr threshold DKG with access control.
 // Uses coinbase::mpc::schnorrmp::threshold_dkg wrapper.
@@ -343,6 +444,18 @@ int cbmpc_schnorrmp_threshold_dkg(cbmpc_jobmp *j, int curve_nid, cmem_t ac_bytes
 // sid_out: output session ID (updated or newly generated)
 int cbmpc_schnorrmp_threshold_refresh(cbmpc_jobmp *j, int curve_nid, cmem_t ac_bytes, const int *quorum_party_indices, int quorum_count, cmem_t sid_in, const cbmpc_ecdsamp_key *key_in, cmem_t *sid_out, cbmpc_ecdsamp_key **key_out);
 
+// Hash commitments (coinbase::crypto::commitment_t)
+// A commitment is bound to a session ID and to the committing party, whose
+// pid is derived from its name as the native protocols derive it.
+
+// Commit to msg. com_out receives the commitment and rand_out the random
+// opening; both must be freed by the caller.
+int cbmpc_commit(cmem_t sid, cmem_t party_name, cmem_t msg, cmem_t *com_out, cmem_t *rand_out);
+
+// Check that com, made by party_name under sid, opens to msg with rand.
+// Returns 0 if it does.
+int cbmpc_commit_open(cmem_t sid, cmem_t party_name, cmem_t com, cmem_t msg, cmem_t rand);
+
 // EC ElGamal Commitment operations (coinbase::crypto namespace)
 // Opaque pointer to ec_elgamal_commitment_t (C++ type).
 typedef void* cbmpc_ec_elgamal_commitment;
@@ -371,6 +484,20 @@ void cbmpc_ec_elgamal_commitment_free(cbmpc_ec_elgamal_commitment commitment);
 // Returns a pointer to ec_elgamal_commitment_t that must be freed with cbmpc_ec_elgamal_commitment_free.
 int cbmpc_ec_elgamal_commitment_make(cbmpc_ecc_point P, cmem_t m, cmem_t r, cbmpc_ec_elgamal_commitment *commitment_out);
 
+// Add two EC ElGamal commitments on the same curve: (A.L + B.L, A.R + B.R).
+// The result commits to the sum of the messages under the sum of the randomness.
+int cbmpc_ec_elgamal_commitment_add(cbmpc_ec_elgamal_commitment A, cbmpc_ec_elgamal_commitment B, cbmpc_ec_elgamal_commitment *commitment_out);
+
+// Multiply an EC ElGamal commitment by a scalar: (s*L, s*R).
+int cbmpc_ec_elgamal_commitment_scalar_mul(cbmpc_ec_elgamal_commitment commitment, cmem_t s, cbmpc_ec_elgamal_commitment *commitment_out);
+
+// Rerandomize an EC ElGamal commitment under public key P with fresh randomness r:
+// (L + r*G, R + r*P). The result commits to the same message.
+int cbmpc_ec_elgamal_commitment_rerand(cbmpc_ec_elgamal_commitment commitment, cbmpc_ecc_point P, cmem_t r, cbmpc_ec_elgamal_commitment *commitment_out);
+
+// Compare two EC ElGamal commitments. Sets *equal_out to 1 if both points match, 0 otherwise.
+int cbmpc_ec_elgamal_commitment_equal(cbmpc_ec_elgamal_commitment A, cbmpc_ec_elgamal_commitment B, int *equal_out);
+
 // ZK proof operations (coinbase::zk namespace)
 // UC_DL proof - universally composable discrete log proof
 
@@ -385,6 +512,14 @@ int cbmpc_uc_dl_prove(cbmpc_ecc_point Q_point, cmem_t w, cmem_t session_id, uint
 // Q_point: the public key point to verify against
 int cbmpc_uc_dl_verify(cmem_t proof, cbmpc_ecc_point Q_point, cmem_t session_id, uint64_t aux);
 
+// Verify many independent UC_DL proofs in one call
+// proofs, session_ids: count serialized proofs and their session identifiers
+// Q_points, auxs: count point handles and auxiliary values
+// results_out: caller-allocated array of count ints; results_out[i] receives the
+//              verification result of proof i (0 on success)
+// Returns 0 if every proof was processed, regardless of individual results.
+int cbmpc_uc_dl_verify_many(cmems_t proofs, cbmpc_ecc_point *Q_points, cmems_t session_ids, const uint64_t *auxs, int count, int *results_out);
+
 // UC_Batch_DL proof - batch universally composable discrete log proof
 // Proves knowledge of multiple discrete logarithms Q[i] = w[i]*G
 
@@ -572,6 +707,54 @@ int cbmpc_paillier_range_exp_slack_prove(cbmpc_paillier paillier, cmem_t q, cmem
 // aux: auxiliary data (must match the one used in Prove)
 int cbmpc_paillier_range_exp_slack_verify(cmem_t proof, cbmpc_paillier paillier, cmem_t q, cmem_t c, cmem_t session_id, uint64_t aux);
 
+// PDL proof - proves a Paillier ciphertext encrypts the discrete log of an EC point
+// This proves that c = Enc(x; r) under the Paillier key and Q = x*G, binding the
+// ciphertext to the point as done for the key share in 2-party ECDSA.
+
+// Create PDL proof
+// paillier: the Paillier instance (must have private key for proving)
+// c: the ciphertext (as bytes)
+// Q_point: the EC point Q = x*G
+// x: the plaintext value (witness)
+// r: the randomness used to encrypt (witness)
+// session_id: session identifier for security, aux: auxiliary data
+// Returns serialized proof bytes.
+int cbmpc_pdl_prove(cbmpc_paillier paillier, cmem_t c, cbmpc_ecc_point Q_point, cmem_t x, cmem_t r, cmem_t session_id, uint64_t aux, cmem_t *proof_out);
+
+// Verify a PDL proof
+// proof: serialized proof bytes
+// paillier: the Paillier instance (public key only)
+// c: the ciphertext to verify
+// Q_point: the EC point claimed to be x*G for the plaintext x of c
+// session_id: session identifier (must match the one used in Prove)
+// aux: auxiliary data (must match the one used in Prove)
+int cbmpc_pdl_verify(cmem_t proof, cbmpc_paillier paillier, cmem_t c, cbmpc_ecc_point Q_point, cmem_t session_id, uint64_t aux);
+
+// Range_Pedersen proof - proves a Pedersen commitment opens to a value in [0, q)
+// Commitments use the library's fixed unknown-order Pedersen parameters (g, h, N):
+// c = g^x * h^r mod N.
+
+// Create a Pedersen commitment to x with fresh randomness
+// x: the value to commit to (as bytes)
+// Returns the commitment c and randomness r; r is the opening and must be kept secret.
+int cbmpc_pedersen_commit(cmem_t x, cmem_t *c_out, cmem_t *r_out);
+
+// Create Range_Pedersen proof
+// q: the exclusive upper bound of the range (as bytes)
+// c: the commitment (as bytes)
+// x, r: the committed value and randomness (witness)
+// session_id: session identifier for security, aux: auxiliary data
+// Returns serialized proof bytes.
+int cbmpc_range_pedersen_prove(cmem_t q, cmem_t c, cmem_t x, cmem_t r, cmem_t session_id, uint64_t aux, cmem_t *proof_out);
+
+// Verify a Range_Pedersen proof
+// proof: serialized proof bytes
+// q: the exclusive upper bound (must match the one used in Prove)
+// c: the commitment to verify
+// session_id: session identifier (must match the one used in Prove)
+// aux: auxiliary data (must match the one used in Prove)
+int cbmpc_range_pedersen_verify(cmem_t proof, cmem_t q, cmem_t c, cmem_t session_id, uint64_t aux);
+
 #ifdef __cplusplus
 }
 #endif
@@ -0,0 +1,76 @@
+//go:build cgo && !windows
+
+package backend
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"testing"
+)
+
+func hashes(n int) [][]byte {
+	out := make([][]byte, n)
+	for i := range out {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], uint64(i))
+		h := sha256.Sum256(b[:])
+		out[i] = h[:]
+	}
+	return out
+}
+
+// BenchmarkCmems compares the per-call cost of passing a batch of 32-byte
+// hashes to C with malloc and copy against the pooled, pinned arena, for both
+// separately allocated hashes and hashes cut from one buffer.
+func BenchmarkCmems(b *testing.B) {
+	for _, n := range []int{16, 1000, 10000} {
+		separate := hashes(n)
+		flat := make([]byte, 0, 32*n)
+		for _, h := range separate {
+			flat = append(flat, h...)
+		}
+		contiguous := make([][]byte, n)
+		for i := range contiguous {
+			contiguous[i] = flat[32*i : 32*(i+1)]
+		}
+
+		b.Run(fmt.Sprintf("malloc/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				freeCmems(goBytesSliceToCmems(separate))
+			}
+		})
+		b.Run(fmt.Sprintf("arena/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, a := pinCmems(separate)
+				a.release()
+			}
+		})
+		b.Run(fmt.Sprintf("arena-zerocopy/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, a := pinCmems(contiguous)
+				a.release()
+			}
+		})
+	}
+}
+
+func TestPinCmems(t *testing.T) {
+	in := hashes(3)
+	m, a := pinCmems(in)
+	if int(m.count) != 3 || m.data == nil || m.sizes == nil {
+		t.Fatalf("cmems = %+v", m)
+	}
+	if !a.copied {
+		t.Fatal("separately allocated hashes should be copied")
+	}
+	a.release()
+	for _, b := range a.data {
+		if b != 0 {
+			t.Fatal("arena data not zeroed on release")
+		}
+	}
+}
@@ -282,6 +282,27 @@ int cbmpc_ecdsa2p_key_get_curve(const cbmpc_ecdsa2p_key *key, int *curve) {
   return 0;
 }
 
+// Get the role of an ECDSA 2P key share (0 for P1, 1 for P2)
+int cbmpc_ecdsa2p_key_get_role(const cbmpc_ecdsa2p_key *key, int *role) {
+  if (!key || !key->opaque || !role) return E_BADARG;
+
+  const auto *k = static_cast<const coinbase::mpc::ecdsa2pc::key_t *>(key->opaque);
+  *role = k->role == coinbase::mpc::party_t::p1 ? 0 : 1;
+  return 0;
+}
+
+// Check an ECDSA 2P key share for internal consistency
+int cbmpc_ecdsa2p_key_validate(const cbmpc_ecdsa2p_key *key) {
+  if (!key || !key->opaque) return E_BADARG;
+
+  const auto *k = static_cast<const coinbase::mpc::ecdsa2pc::key_t *>(key->opaque);
+  if (!k->curve) return E_CRYPTO;
+  const auto &q = k->curve.order();
+  if (k->x_share <= 0 || k->x_share >= q) return E_CRYPTO;
+  if (k->Q.is_infinity() || k->curve.check(k->Q) != SUCCESS) return E_CRYPTO;
+  return 0;
+}
+
 // Serialize an ECDSA 2P key
 int cbmpc_ecdsa2p_key_serialize(const cbmpc_ecdsa2p_key *key, cmem_t *out) {
   if (!key || !key->opaque || !out) return E_BADARG;
@@ -310,6 +331,19 @@ int cbmpc_ecdsa2p_key_deserialize(cmem_t serialized, cbmpc_ecdsa2p_key **key) {
   return 0;
 }
 
+// Get the additive share of an ECDSA 2P key
+int cbmpc_ecdsa2p_key_get_x_share(const cbmpc_ecdsa2p_key *key, cmem_t *out) {
+  if (!key || !key->opaque || !out) return E_BADARG;
+
+  const auto *k = static_cast<const coinbase::mpc::ecdsa2pc::key_t *>(key->opaque);
+  buf_t x_bin = k->x_share.to_bin(k->curve.order().get_bin_size());
+  *out = alloc_and_copy(x_bin.data(), static_cast<size_t>(x_bin.size()));
+  coinbase::secure_bzero(x_bin.data(), x_bin.size());
+  if (!out->data) return E_BADARG;
+
+  return 0;
+}
+
 // ============================================================
 // ECDSA MP key management functions
 // ============================================================
@@ -343,6 +377,18 @@ int cbmpc_ecdsamp_key_get_curve(const cbmpc_ecdsamp_key *key, int *curve) {
   return 0;
 }
 
+// Get the party name of an ECDSA MP key share
+int cbmpc_ecdsamp_key_get_party_name(const cbmpc_ecdsamp_key *key, cmem_t *out) {
+  if (!key || !key->opaque || !out) return E_BADARG;
+
+  const auto *k = static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque);
+  const std::string &name = k->party_name;
+  *out = alloc_and_copy(reinterpret_cast<const uint8_t *>(name.data()), name.size());
+  if (!out->data && name.size() > 0) return E_BADARG;
+
+  return 0;
+}
+
 // Serialize an ECDSA MP key
 int cbmpc_ecdsamp_key_serialize(const cbmpc_ecdsamp_key *key, cmem_t *out) {
   if (!key || !key->opaque || !out) return E_BADARG;
@@ -371,6 +417,114 @@ int cbmpc_ecdsamp_key_deserialize(cmem_t serialized, cbmpc_ecdsamp_key **key) {
   return 0;
 }
 
+// Get the private share of an ECDSA MP key
+int cbmpc_ecdsamp_key_get_x_share(const cbmpc_ecdsamp_key *key, cmem_t *out) {
+  if (!key || !key->opaque || !out) return E_BADARG;
+
+  const auto *k = static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque);
+  buf_t x_bin = k->x_share.to_bin(k->curve.order().get_bin_size());
+  *out = alloc_and_copy(x_bin.data(), static_cast<size_t>(x_bin.size()));
+  coinbase::secure_bzero(x_bin.data(), x_bin.size());
+  if (!out->data) return E_BADARG;
+
+  return 0;
+}
+
+// Get a party's public share from an ECDSA MP key
+int cbmpc_ecdsamp_key_get_public_share(const cbmpc_ecdsamp_key *key, cmem_t party_name, cmem_t *out) {
+  if (!key || !key->opaque || !party_name.data || party_name.size <= 0 || !out) return E_BADARG;
+
+  const auto *k = static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque);
+  coinbase::crypto::pname_t pname(reinterpret_cast<const char*>(party_name.data), party_name.size);
+  auto it = k->Qis.find(pname);
+  if (it == k->Qis.end()) return E_NOT_FOUND;
+  buf_t point = it->second.to_compressed_bin();
+  *out = alloc_and_copy(point.data(), static_cast<size_t>(point.size()));
+  if (!out->data && point.size() > 0) return E_BADARG;
+
+  return 0;
+}
+
+// Check an ECDSA MP key share for internal consistency
+int cbmpc_ecdsamp_key_validate(const cbmpc_ecdsamp_key *key) {
+  if (!key || !key->opaque) return E_BADARG;
+
+  const auto *k = static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque);
+  if (!k->curve) return E_CRYPTO;
+  const auto &q = k->curve.order();
+  if (k->x_share <= 0 || k->x_share >= q) return E_CRYPTO;
+  if (k->Q.is_infinity() || k->curve.check(k->Q) != SUCCESS) return E_CRYPTO;
+  for (const auto &[name, Qi] : k->Qis) {
+    if (Qi.is_infinity() || k->curve.check(Qi) != SUCCESS) return E_CRYPTO;
+  }
+  auto it = k->Qis.find(k->party_name);
+  if (it == k->Qis.end()) return E_CRYPTO;
+  if (it->second != k->curve.mul_to_generator(k->x_share)) return E_CRYPTO;
+  return 0;
+}
+
+// Build an ECDSA MP key from its parts
+int cbmpc_ecdsamp_key_new(int curve_nid, cmem_t party_name, cmem_t x_share, cmem_t Q, cmems_t names, cmems_t Qis, cbmpc_ecdsamp_key **key) {
+  if (!party_name.data || party_name.size <= 0 || !x_share.data || x_share.size <= 0 ||
+      !Q.data || Q.size <= 0 || !key) return E_BADARG;
+  if (names.count <= 0 || names.count != Qis.count || !names.data || !names.sizes || !Qis.data || !Qis.sizes) return E_BADARG;
+
+  auto k = std::make_unique<coinbase::mpc::ecdsampc::key_t>();
+  k->curve = find_curve_by_nid(curve_nid);
+  if (!k->curve) return E_BADARG;
+  k->party_name = coinbase::crypto::pname_t(reinterpret_cast<const char*>(party_name.data), party_name.size);
+  k->x_share = coinbase::crypto::bn_t::from_bin(mem_t(x_share.data, x_share.size));
+  if (k->Q.from_bin(k->curve, mem_t(Q.data, Q.size)) != SUCCESS) return E_BADARG;
+
+  size_t name_off = 0, point_off = 0;
+  for (int i = 0; i < names.count; i++) {
+    coinbase::crypto::pname_t pname(reinterpret_cast<const char*>(names.data + name_off), names.sizes[i]);
+    name_off += names.sizes[i];
+    coinbase::crypto::ecc_point_t Qi;
+    if (Qi.from_bin(k->curve, mem_t(Qis.data + point_off, Qis.sizes[i])) != SUCCESS) return E_BADARG;
+    point_off += Qis.sizes[i];
+    k->Qis[pname] = Qi;
+  }
+  // The share must match the party's own public share.
+  auto self = k->Qis.find(k->party_name);
+  if (self == k->Qis.end()) return E_BADARG;
+  if (k->curve.mul_to_generator(k->x_share) != self->second) return E_CRYPTO;
+
+  auto wrapper = new cbmpc_ecdsamp_key;
+  wrapper->opaque = k.release();
+  *key = wrapper;
+  return 0;
+}
+
+// Shift an ECDSA MP key by a public tweak
+int cbmpc_ecdsamp_key_tweak(const cbmpc_ecdsamp_key *key, cmem_t tweak, cbmpc_ecdsamp_key **key_out) {
+  if (!key || !key->opaque || !tweak.data || tweak.size <= 0 || !key_out) return E_BADARG;
+
+  const auto *k = static_cast<const coinbase::mpc::ecdsampc::key_t *>(key->opaque);
+  const auto &q = k->curve.order();
+  coinbase::crypto::bn_t t = coinbase::crypto::bn_t::from_bin(mem_t(tweak.data, tweak.size));
+  if (t >= q) return E_BADARG;
+  if (k->Qis.empty()) return E_BADARG;
+  coinbase::crypto::ecc_point_t T = k->curve.mul_to_generator(t);
+
+  coinbase::crypto::ecc_point_t sum = k->curve.infinity();
+  for (const auto &[name, Qi] : k->Qis) sum += Qi;
+  const bool additive = sum == k->Q;
+
+  auto out = std::make_unique<coinbase::mpc::ecdsampc::key_t>(*k);
+  out->Q += T;
+  for (auto &[name, Qi] : out->Qis) {
+    if (additive && name != out->Qis.begin()->first) continue;
+    Qi += T;
+    if (name == out->party_name) MODULO(q) out->x_share = out->x_share + t;
+  }
+
+  auto wrapper = new cbmpc_ecdsamp_key;
+  wrapper->opaque = out.release();
+  *key_out = wrapper;
+  return 0;
+}
+
 // ============================================================
 // Paillier cryptosystem management functions
 // ============================================================
@@ -385,6 +539,26 @@ int cbmpc_paillier_generate(cbmpc_paillier *paillier_out) {
   return 0;
 }
 
+// Generate Paillier keypair with a caller-selected modulus size
+int cbmpc_paillier_generate_with_bits(int bits, cbmpc_paillier *paillier_out) {
+  if (!paillier_out) return E_BADARG;
+  if (bits != 2048 && bits != 3072 && bits != 4096) return E_BADARG;
+
+  // Mirrors paillier_t::generate(), which fixes the size at 2048 bits:
+  // two safe primes of half the size, retried until N has exactly `bits` bits.
+  coinbase::crypto::bn_t p, q, N;
+  do {
+    p = coinbase::crypto::bn_t::generate_prime(bits / 2, true);
+    q = coinbase::crypto::bn_t::generate_prime(bits / 2, true);
+    N = p * q;
+  } while (p == q || N.get_bits_count() != bits);
+
+  auto paillier = std::make_unique<coinbase::crypto::paillier_t>();
+  paillier->create_prv(N, p, q);
+  *paillier_out = paillier.release();
+  return 0;
+}
+
 // Create Paillier instance from public key
 int cbmpc_paillier_create_pub(cmem_t N, cbmpc_paillier *paillier_out) {
   if (!N.data || N.size <= 0 || !paillier_out) return E_BADARG;
@@ -485,6 +659,39 @@ int cbmpc_paillier_add_ciphers(cbmpc_paillier paillier, cmem_t c1, cmem_t c2, cm
   return 0;
 }
 
+// Subtract two Paillier ciphertexts homomorphically
+int cbmpc_paillier_sub_ciphers(cbmpc_paillier paillier, cmem_t c1, cmem_t c2, cmem_t *result_out) {
+  if (!paillier || !c1.data || c1.size <= 0 || !c2.data || c2.size <= 0 || !result_out) {
+    return E_BADARG;
+  }
+
+  const auto *p = static_cast<const coinbase::crypto::paillier_t *>(paillier);
+  coinbase::crypto::bn_t ct1 = coinbase::crypto::bn_t::from_bin(mem_t(c1.data, c1.size));
+  coinbase::crypto::bn_t ct2 = coinbase::crypto::bn_t::from_bin(mem_t(c2.data, c2.size));
+  coinbase::crypto::bn_t result = p->sub_ciphers(ct1, ct2);
+  buf_t result_bin = result.to_bin();
+  *result_out = alloc_and_copy(result_bin.data(), static_cast<size_t>(result_bin.size()));
+  if (!result_out->data && result_bin.size() > 0) return E_BADARG;
+
+  return 0;
+}
+
+// Rerandomize a Paillier ciphertext
+int cbmpc_paillier_rerandomize(cbmpc_paillier paillier, cmem_t ciphertext, cmem_t *result_out) {
+  if (!paillier || !ciphertext.data || ciphertext.size <= 0 || !result_out) {
+    return E_BADARG;
+  }
+
+  const auto *p = static_cast<const coinbase::crypto::paillier_t *>(paillier);
+  coinbase::crypto::bn_t ct = coinbase::crypto::bn_t::from_bin(mem_t(ciphertext.data, ciphertext.size));
+  coinbase::crypto::bn_t result = p->rerand(ct);
+  buf_t result_bin = result.to_bin();
+  *result_out = alloc_and_copy(result_bin.data(), static_cast<size_t>(result_bin.size()));
+  if (!result_out->data && result_bin.size() > 0) return E_BADARG;
+
+  return 0;
+}
+
 // Multiply a ciphertext by a scalar homomorphically
 int cbmpc_paillier_mul_scalar(cbmpc_paillier paillier, cmem_t ciphertext, cmem_t scalar, cmem_t *result_out) {
   if (!paillier || !ciphertext.data || ciphertext.size <= 0 || !scalar.data || scalar.size <= 0 || !result_out) {
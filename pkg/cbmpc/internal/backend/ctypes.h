@@ -26,6 +26,14 @@ int cbmpc_ecdsa2p_key_get_public_key(const cbmpc_ecdsa2p_key *key, cmem_t *out);
 // Get the curve from an ECDSA 2P key (returns curve enum value, not NID).
 int cbmpc_ecdsa2p_key_get_curve(const cbmpc_ecdsa2p_key *key, int *curve);
 
+// Get the role of an ECDSA 2P key share: 0 for P1, 1 for P2.
+int cbmpc_ecdsa2p_key_get_role(const cbmpc_ecdsa2p_key *key, int *role);
+
+// Check an ECDSA 2P key share for internal consistency: the curve is known,
+// the share is in [1, q) and Q is a valid point other than infinity.
+// Returns E_CRYPTO if a check fails.
+int cbmpc_ecdsa2p_key_validate(const cbmpc_ecdsa2p_key *key);
+
 // Serialize an ECDSA 2P key to bytes for persistent storage or network transmission.
 // The returned cmem_t is allocated and must be freed by the caller.
 int cbmpc_ecdsa2p_key_serialize(const cbmpc_ecdsa2p_key *key, cmem_t *out);
@@ -34,6 +42,11 @@ int cbmpc_ecdsa2p_key_serialize(const cbmpc_ecdsa2p_key *key, cmem_t *out);
 // The returned key must be freed with cbmpc_ecdsa2p_key_free.
 int cbmpc_ecdsa2p_key_deserialize(cmem_t serialized, cbmpc_ecdsa2p_key **key);
 
+// Get the additive private share x_i of an ECDSA 2P key (big-endian, padded to the
+// curve order size). The returned cmem_t holds secret material and must be freed
+// by the caller.
+int cbmpc_ecdsa2p_key_get_x_share(const cbmpc_ecdsa2p_key *key, cmem_t *out);
+
 // ECDSA MP key - opaque handle to C++ key_t object
 // Memory management: Keys returned by cbmpc_ecdsamp_* functions must be freed with cbmpc_ecdsamp_key_free.
 typedef struct cbmpc_ecdsamp_key {
@@ -50,6 +63,10 @@ int cbmpc_ecdsamp_key_get_public_key(const cbmpc_ecdsamp_key *key, cmem_t *out);
 // Get the curve from an ECDSA MP key (returns curve enum value, not NID).
 int cbmpc_ecdsamp_key_get_curve(const cbmpc_ecdsamp_key *key, int *curve);
 
+// Get the name of the party holding an ECDSA MP key share.
+// The returned cmem_t is allocated and must be freed by the caller.
+int cbmpc_ecdsamp_key_get_party_name(const cbmpc_ecdsamp_key *key, cmem_t *out);
+
 // Serialize an ECDSA MP key to bytes for persistent storage or network transmission.
 // The returned cmem_t is allocated and must be freed by the caller.
 int cbmpc_ecdsamp_key_serialize(const cbmpc_ecdsamp_key *key, cmem_t *out);
@@ -58,6 +75,34 @@ int cbmpc_ecdsamp_key_serialize(const cbmpc_ecdsamp_key *key, cmem_t *out);
 // The returned key must be freed with cbmpc_ecdsamp_key_free.
 int cbmpc_ecdsamp_key_deserialize(cmem_t serialized, cbmpc_ecdsamp_key **key);
 
+// Get the private share of an ECDSA MP key (big-endian, padded to the curve order
+// size). The returned cmem_t holds secret material and must be freed by the caller.
+int cbmpc_ecdsamp_key_get_x_share(const cbmpc_ecdsamp_key *key, cmem_t *out);
+
+// Get the public share Q_i of the named party from an ECDSA MP key (compressed
+// point). Returns E_NOT_FOUND if the key has no share for party_name.
+int cbmpc_ecdsamp_key_get_public_share(const cbmpc_ecdsamp_key *key, cmem_t party_name, cmem_t *out);
+
+// Check an ECDSA MP key share for internal consistency: the curve is known,
+// the share is in [1, q), Q and every Q_i are valid points other than
+// infinity, and the party's own Q_i is x_share*G. Returns E_CRYPTO if a check
+// fails.
+int cbmpc_ecdsamp_key_validate(const cbmpc_ecdsamp_key *key);
+
+// Build an ECDSA MP key from its parts: the party's share, the public key Q, and
+// every party's public share (names[i] -> Qis[i], compressed points). Used when
+// shares are produced outside the native protocols, e.g. when migrating keys.
+// The returned key must be freed with cbmpc_ecdsamp_key_free.
+int cbmpc_ecdsamp_key_new(int curve_nid, cmem_t party_name, cmem_t x_share, cmem_t Q, cmems_t names, cmems_t Qis, cbmpc_ecdsamp_key **key);
+
+// Shift an ECDSA MP key by a public tweak t (big-endian, less than the curve
+// order): the result shares x + t under the public key Q + t*G. Additive keys
+// (public shares summing to Q) add t to the share of the party whose name sorts
+// first; threshold keys add t to every share. Every party applying the same
+// tweak ends up with consistent shares without communicating.
+// The returned key must be freed with cbmpc_ecdsamp_key_free.
+int cbmpc_ecdsamp_key_tweak(const cbmpc_ecdsamp_key *key, cmem_t tweak, cbmpc_ecdsamp_key **key_out);
+
 // Schnorr 2P key - opaque handle to C++ key_t object (eckey::key_share_2p_t)
 // Memory management: Keys returned by cbmpc_schnorr2p_* functions must be freed with cbmpc_schnorr2p_key_free.
 typedef struct cbmpc_schnorr2p_key {
@@ -72,6 +117,11 @@ typedef void *cbmpc_paillier;
 // The returned paillier instance must be freed with cbmpc_paillier_free.
 int cbmpc_paillier_generate(cbmpc_paillier *paillier_out);
 
+// Generate a new Paillier keypair with a modulus of exactly `bits` bits.
+// bits must be 2048, 3072 or 4096; other sizes return E_BADARG.
+// The returned paillier instance must be freed with cbmpc_paillier_free.
+int cbmpc_paillier_generate_with_bits(int bits, cbmpc_paillier *paillier_out);
+
 // Create a Paillier instance from a public key (modulus N only).
 // The returned paillier instance must be freed with cbmpc_paillier_free.
 int cbmpc_paillier_create_pub(cmem_t N, cbmpc_paillier *paillier_out);
@@ -103,6 +153,15 @@ int cbmpc_paillier_decrypt(cbmpc_paillier paillier, cmem_t ciphertext, cmem_t *p
 // The returned cmem_t is allocated and must be freed by the caller.
 int cbmpc_paillier_add_ciphers(cbmpc_paillier paillier, cmem_t c1, cmem_t c2, cmem_t *result_out);
 
+// Subtract Paillier ciphertext c2 from c1 homomorphically.
+// The returned cmem_t is allocated and must be freed by the caller.
+int cbmpc_paillier_sub_ciphers(cbmpc_paillier paillier, cmem_t c1, cmem_t c2, cmem_t *result_out);
+
+// Rerandomize a Paillier ciphertext so it decrypts to the same plaintext but is
+// unlinkable to the input.
+// The returned cmem_t is allocated and must be freed by the caller.
+int cbmpc_paillier_rerandomize(cbmpc_paillier paillier, cmem_t ciphertext, cmem_t *result_out);
+
 // Multiply a Paillier ciphertext by a scalar homomorphically.
 // The returned cmem_t is allocated and must be freed by the caller.
 int cbmpc_paillier_mul_scalar(cbmpc_paillier paillier, cmem_t ciphertext, cmem_t scalar, cmem_t *result_out);
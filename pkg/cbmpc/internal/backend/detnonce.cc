@@ -0,0 +1,141 @@
+#include "detnonce.h"
+
+#ifdef CBMPC_DETERMINISTIC_NONCES
+
+// RAND_METHOD is deprecated in OpenSSL 3 but remains the only way to replace
+// the RNG that RAND_bytes and BN_rand draw from.
+#define OPENSSL_SUPPRESS_DEPRECATED
+
+#include <openssl/evp.h>
+#include <openssl/hmac.h>
+#include <openssl/rand.h>
+
+#include <algorithm>
+#include <cstring>
+#include <mutex>
+
+#include "cbmpc/core/error.h"
+
+namespace {
+
+using coinbase::buf_t;
+using coinbase::mem_t;
+
+constexpr char kDomain[] = "cbmpc-go deterministic nonce v1";
+
+std::mutex seed_mu;
+buf_t seed;
+const RAND_METHOD *fallback = nullptr;
+
+// stream is the HMAC-SHA256 counter-mode stream of the signing call running
+// on this thread. Native protocol calls run on one OS thread from start to
+// finish, transport callbacks included, so a thread-local stream belongs to
+// exactly one party's call.
+struct stream_t {
+  bool active = false;
+  uint8_t key[32];
+  uint64_t counter = 0;
+  uint8_t block[32];
+  size_t used = sizeof(block);
+};
+thread_local stream_t stream;
+
+void refill() {
+  uint8_t ctr[8];
+  for (int i = 0; i < 8; i++) ctr[i] = uint8_t(stream.counter >> (56 - 8 * i));
+  stream.counter++;
+  unsigned int n = 0;
+  HMAC(EVP_sha256(), stream.key, sizeof(stream.key), ctr, sizeof(ctr), stream.block, &n);
+  stream.used = 0;
+}
+
+int det_bytes(unsigned char *out, int n) {
+  if (!stream.active) return fallback->bytes(out, n);
+  while (n > 0) {
+    if (stream.used == sizeof(stream.block)) refill();
+    size_t take = std::min(sizeof(stream.block) - stream.used, size_t(n));
+    std::memcpy(out, stream.block + stream.used, take);
+    stream.used += take;
+    out += take;
+    n -= int(take);
+  }
+  return 1;
+}
+
+int det_seed(const void *buf, int n) { return fallback->seed ? fallback->seed(buf, n) : 1; }
+int det_add(const void *buf, int n, double entropy) { return fallback->add ? fallback->add(buf, n, entropy) : 1; }
+int det_status() { return fallback->status ? fallback->status() : 1; }
+
+RAND_METHOD det_method = {det_seed, det_bytes, nullptr, det_add, det_bytes, det_status};
+
+}  // namespace
+
+extern "C" int cbmpc_test_set_nonce_seed(cmem_t s) {
+  std::lock_guard<std::mutex> lock(seed_mu);
+  if (s.data && s.size > 0) {
+    seed = buf_t(s.data, s.size);
+  } else {
+    coinbase::secure_bzero(seed.data(), seed.size());
+    seed = buf_t();
+  }
+  if (!fallback) {
+    fallback = RAND_get_rand_method();
+    if (!fallback || RAND_set_rand_method(&det_method) != 1) {
+      fallback = nullptr;
+      return E_CRYPTO;
+    }
+  }
+  return 0;
+}
+
+namespace cbmpc_detnonce {
+
+// begin keys this thread's stream with
+//
+//	HMAC-SHA256(seed, domain || role || len(x) || x || len(sid) || sid || n || (len(m) || m)*)
+//
+// so that the stream, like an RFC 6979 nonce, is a function of the secret
+// share and the messages and differs between the two parties.
+void begin(int role, mem_t x_share, const std::vector<mem_t> &msgs, mem_t sid) {
+  buf_t s;
+  {
+    std::lock_guard<std::mutex> lock(seed_mu);
+    s = seed;
+  }
+  if (s.size() == 0 || !fallback) return;
+
+  buf_t data;
+  auto put_len = [&data](size_t n) {
+    uint8_t b[4] = {uint8_t(n >> 24), uint8_t(n >> 16), uint8_t(n >> 8), uint8_t(n)};
+    data += mem_t(b, 4);
+  };
+  data += mem_t(reinterpret_cast<const uint8_t *>(kDomain), int(sizeof(kDomain) - 1));
+  uint8_t r = uint8_t(role);
+  data += mem_t(&r, 1);
+  put_len(x_share.size);
+  data += x_share;
+  put_len(sid.size);
+  data += sid;
+  put_len(msgs.size());
+  for (const auto &m : msgs) {
+    put_len(m.size);
+    data += m;
+  }
+
+  unsigned int n = 0;
+  HMAC(EVP_sha256(), s.data(), s.size(), data.data(), data.size(), stream.key, &n);
+  coinbase::secure_bzero(data.data(), data.size());
+  stream.counter = 0;
+  stream.used = sizeof(stream.block);
+  stream.active = true;
+}
+
+void end() {
+  coinbase::secure_bzero(stream.key, sizeof(stream.key));
+  coinbase::secure_bzero(stream.block, sizeof(stream.block));
+  stream.active = false;
+}
+
+}  // namespace cbmpc_detnonce
+
+#endif  // CBMPC_DETERMINISTIC_NONCES
@@ -0,0 +1,23 @@
+//go:build cgo && !windows && cbmpc_deterministic
+
+package backend
+
+/*
+#cgo CFLAGS: -DCBMPC_DETERMINISTIC_NONCES
+#cgo CXXFLAGS: -DCBMPC_DETERMINISTIC_NONCES
+#include "detnonce.h"
+*/
+import "C"
+
+// SetNonceSeed sets the process-wide seed from which 2P signing derives its
+// randomness in deterministic builds. An empty seed turns it off.
+func SetNonceSeed(seed []byte) error {
+	seedMem := allocCmem(seed)
+	defer freeCmem(seedMem)
+
+	rc := C.cbmpc_test_set_nonce_seed(seedMem)
+	if rc != 0 {
+		return formatNativeErr("test_set_nonce_seed", rc)
+	}
+	return nil
+}
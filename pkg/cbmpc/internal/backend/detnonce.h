@@ -0,0 +1,67 @@
+#pragma once
+#include <stddef.h>
+#include <stdint.h>
+
+#include "cbmpc/core/cmem.h"
+
+// Deterministic nonces for test builds.
+//
+// Compiled with CBMPC_DETERMINISTIC_NONCES (the cbmpc_deterministic Go build
+// tag), 2P signing draws every random value - nonce shares, session IDs, proof
+// randomness - from a stream derived RFC6979-style from a process-wide seed,
+// the party's key share and the messages, so repeated runs produce identical
+// transcripts and signatures. Without the define none of this exists and
+// signing uses the OpenSSL RNG as usual.
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+#ifdef CBMPC_DETERMINISTIC_NONCES
+// Set the process-wide seed of deterministic signing. An empty seed turns it
+// off again.
+int cbmpc_test_set_nonce_seed(cmem_t seed);
+#endif
+
+#ifdef __cplusplus
+}  // extern "C"
+
+#include <vector>
+
+#include "cbmpc/core/buf.h"
+#include "cbmpc/protocol/mpc_job.h"
+
+namespace cbmpc_detnonce {
+
+#ifdef CBMPC_DETERMINISTIC_NONCES
+void begin(int role, coinbase::mem_t x_share, const std::vector<coinbase::mem_t> &msgs, coinbase::mem_t sid);
+void end();
+#endif
+
+// scope_t makes the OpenSSL RNG of the calling thread deterministic for the
+// lifetime of the scope when a seed is set. It compiles to nothing in release
+// builds.
+class scope_t {
+ public:
+  template <typename KEY>
+  scope_t(const KEY &key, const std::vector<coinbase::mem_t> &msgs, coinbase::mem_t sid = coinbase::mem_t()) {
+#ifdef CBMPC_DETERMINISTIC_NONCES
+    coinbase::buf_t x = key.x_share.to_bin();
+    begin(key.role == coinbase::mpc::party_t::p1 ? 0 : 1, x, msgs, sid);
+#else
+    (void)key;
+    (void)msgs;
+    (void)sid;
+#endif
+  }
+  ~scope_t() {
+#ifdef CBMPC_DETERMINISTIC_NONCES
+    end();
+#endif
+  }
+  scope_t(const scope_t &) = delete;
+  scope_t &operator=(const scope_t &) = delete;
+};
+
+}  // namespace cbmpc_detnonce
+#endif
@@ -0,0 +1,7 @@
+//go:build cbmpc_deterministic && (!cgo || windows)
+
+package backend
+
+func SetNonceSeed([]byte) error {
+	return ErrNotBuilt
+}
@@ -0,0 +1,47 @@
+#include "entropy.h"
+
+// RAND_METHOD is deprecated in OpenSSL 3 but remains the only way to replace
+// the RNG that RAND_bytes and BN_rand draw from.
+#define OPENSSL_SUPPRESS_DEPRECATED
+
+#include <openssl/rand.h>
+
+#include <mutex>
+
+#include "cbmpc/core/error.h"
+
+// Implemented in Go (entropy.go): fills out with n bytes from the source and
+// returns 1, or returns 0 if the source fails.
+extern "C" int cbmpc_go_entropy(unsigned char *out, int n);
+
+namespace {
+
+std::mutex mu;
+const RAND_METHOD *previous = nullptr;
+bool installed = false;
+
+int go_bytes(unsigned char *out, int n) { return cbmpc_go_entropy(out, n); }
+int go_seed(const void *, int) { return 1; }
+int go_add(const void *, int, double) { return 1; }
+int go_status() { return 1; }
+
+RAND_METHOD go_method = {go_seed, go_bytes, nullptr, go_add, go_bytes, go_status};
+
+}  // namespace
+
+extern "C" int cbmpc_set_entropy_source(int on) {
+  std::lock_guard<std::mutex> lock(mu);
+  if (bool(on) == installed) return 0;
+  if (on) {
+    previous = RAND_get_rand_method();
+    if (!previous || RAND_set_rand_method(&go_method) != 1) {
+      previous = nullptr;
+      return E_CRYPTO;
+    }
+  } else {
+    if (RAND_set_rand_method(previous) != 1) return E_CRYPTO;
+    previous = nullptr;
+  }
+  installed = bool(on);
+  return 0;
+}
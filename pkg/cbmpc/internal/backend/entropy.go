@@ -0,0 +1,54 @@
+//go:build cgo && !windows
+
+package backend
+
+/*
+#include "entropy.h"
+*/
+import "C"
+
+import (
+	"io"
+	"sync"
+	"unsafe"
+)
+
+// entropy is the source installed by SetEntropySource. Reads hold mu, so the
+// source is never read concurrently.
+var entropy struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+// SetEntropySource makes r the source of every random byte native code draws
+// through OpenSSL, process-wide. A nil r restores the RNG that was in place
+// before. A read error from r fails the native call that drew the bytes.
+func SetEntropySource(r io.Reader) error {
+	entropy.mu.Lock()
+	defer entropy.mu.Unlock()
+	on := 0
+	if r != nil {
+		on = 1
+	}
+	if rc := C.cbmpc_set_entropy_source(C.int(on)); rc != 0 {
+		return formatNativeErr("set_entropy_source", rc)
+	}
+	entropy.r = r
+	return nil
+}
+
+//export cbmpc_go_entropy
+func cbmpc_go_entropy(out *C.uchar, n C.int) C.int {
+	if out == nil || n < 0 {
+		return 0
+	}
+	entropy.mu.Lock()
+	defer entropy.mu.Unlock()
+	if entropy.r == nil {
+		return 0
+	}
+	if _, err := io.ReadFull(entropy.r, unsafe.Slice((*byte)(unsafe.Pointer(out)), int(n))); err != nil {
+		return 0
+	}
+	return 1
+}
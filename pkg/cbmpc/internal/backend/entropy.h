@@ -0,0 +1,19 @@
+#pragma once
+
+// Go-supplied entropy.
+//
+// cbmpc_set_entropy_source(1) replaces the OpenSSL RNG that RAND_bytes,
+// RAND_priv_bytes and BN_rand draw from, process-wide, with one that reads
+// from the source set by SetEntropySource in Go. cbmpc_set_entropy_source(0)
+// restores the RNG that was in place before. It is not meant for the
+// deterministic test build, whose RNG it would replace.
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+int cbmpc_set_entropy_source(int on);
+
+#ifdef __cplusplus
+}  // extern "C"
+#endif
@@ -0,0 +1,12 @@
+//go:build !cgo || windows
+
+package backend
+
+import "io"
+
+func SetEntropySource(r io.Reader) error {
+	if r == nil {
+		return nil
+	}
+	return ErrNotBuilt
+}
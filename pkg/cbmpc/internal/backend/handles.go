@@ -0,0 +1,74 @@
+package backend
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// handleShards is the number of independently locked registry shards. It is a
+// power of two so the shard index is a mask of the handle ID.
+const handleShards = 64
+
+// handleShard is one lock-protected slice of the registry, padded to its own
+// cache line so neighbouring shards do not contend through false sharing.
+type handleShard struct {
+	mu sync.RWMutex
+	m  map[uint64]any
+	_  [64]byte
+}
+
+// handleTable maps handle IDs to Go objects. Consecutive IDs land in
+// different shards, so concurrent PVE and KEM calls rarely share a lock.
+type handleTable struct {
+	next   atomic.Uint64
+	shards [handleShards]handleShard
+}
+
+func newHandleTable() *handleTable {
+	t := &handleTable{}
+	t.next.Store(0xDEADBEEF0000) // Start high to avoid looking like valid pointers
+	for i := range t.shards {
+		t.shards[i].m = make(map[uint64]any)
+	}
+	return t
+}
+
+func (t *handleTable) shard(id uint64) *handleShard {
+	return &t.shards[id&(handleShards-1)]
+}
+
+func (t *handleTable) register(obj any) uint64 {
+	id := t.next.Add(1)
+	s := t.shard(id)
+	s.mu.Lock()
+	s.m[id] = obj
+	s.mu.Unlock()
+	return id
+}
+
+func (t *handleTable) lookup(id uint64) (any, bool) {
+	s := t.shard(id)
+	s.mu.RLock()
+	obj, exists := s.m[id]
+	s.mu.RUnlock()
+	return obj, exists
+}
+
+func (t *handleTable) free(id uint64) {
+	s := t.shard(id)
+	s.mu.Lock()
+	delete(s.m, id)
+	s.mu.Unlock()
+}
+
+// len returns the number of registered handles.
+func (t *handleTable) len() int {
+	n := 0
+	for i := range t.shards {
+		s := &t.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
@@ -0,0 +1,91 @@
+// Package envelope implements the versioned wire format shared by the zk and
+// pve proof envelopes and by serialized key shares.
+//
+// Layout (big-endian):
+//
+//	magic    [4]byte "CBMP"
+//	format   uint8   envelope format, currently 1
+//	domain   uint8   owning package (zk, pve, key)
+//	type     uint8   package-specific payload type
+//	curve    uint8   curve enum value, 0 if not curve-specific
+//	version  uint16  payload format version
+//	length   uint32  payload length
+//	payload  []byte
+package envelope
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Domains.
+const (
+	DomainZK  uint8 = 1
+	DomainPVE uint8 = 2
+	DomainKey uint8 = 3
+)
+
+const (
+	format     = 1
+	headerSize = 4 + 1 + 1 + 1 + 1 + 2 + 4
+)
+
+var magic = [4]byte{'C', 'B', 'M', 'P'}
+
+var (
+	ErrMalformed          = errors.New("malformed envelope")
+	ErrUnsupportedVersion = errors.New("unsupported envelope version")
+	ErrTypeMismatch       = errors.New("envelope type mismatch")
+	ErrCurveMismatch      = errors.New("envelope curve mismatch")
+)
+
+// Header describes an envelope payload.
+type Header struct {
+	Domain  uint8
+	Type    uint8
+	Curve   uint8
+	Version uint16
+}
+
+// Encode returns h and payload in envelope form.
+func Encode(h Header, payload []byte) ([]byte, error) {
+	if uint64(len(payload)) > 1<<32-1 {
+		return nil, errors.New("payload too large")
+	}
+	out := make([]byte, headerSize+len(payload))
+	copy(out, magic[:])
+	out[4] = format
+	out[5] = h.Domain
+	out[6] = h.Type
+	out[7] = h.Curve
+	binary.BigEndian.PutUint16(out[8:], h.Version)
+	binary.BigEndian.PutUint32(out[10:], uint32(len(payload)))
+	copy(out[headerSize:], payload)
+	return out, nil
+}
+
+// Decode parses an envelope written by Encode for the given domain. The
+// returned payload aliases data.
+func Decode(domain uint8, data []byte) (Header, []byte, error) {
+	if len(data) < headerSize || [4]byte(data[:4]) != magic {
+		return Header{}, nil, fmt.Errorf("%w: missing header", ErrMalformed)
+	}
+	if data[4] != format {
+		return Header{}, nil, fmt.Errorf("%w: envelope format %d", ErrUnsupportedVersion, data[4])
+	}
+	h := Header{
+		Domain:  data[5],
+		Type:    data[6],
+		Curve:   data[7],
+		Version: binary.BigEndian.Uint16(data[8:]),
+	}
+	if h.Domain != domain {
+		return Header{}, nil, fmt.Errorf("%w: wrong domain %d", ErrMalformed, h.Domain)
+	}
+	n := binary.BigEndian.Uint32(data[10:])
+	if uint64(len(data)-headerSize) != uint64(n) {
+		return Header{}, nil, fmt.Errorf("%w: payload length %d, have %d bytes", ErrMalformed, n, len(data)-headerSize)
+	}
+	return h, data[headerSize:], nil
+}
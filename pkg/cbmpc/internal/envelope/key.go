@@ -0,0 +1,195 @@
+package envelope
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Key protocols, the Type of a DomainKey envelope.
+const (
+	KeyECDSA2P   uint8 = 1
+	KeyECDSAMP   uint8 = 2
+	KeySchnorr2P uint8 = 3
+	KeySchnorrMP uint8 = 4
+)
+
+// Payload formats of DomainKey envelopes. Version 1 is
+//
+//	roleLen  uint16
+//	role     []byte
+//	created  int64   Unix seconds, 0 if unknown
+//	share    []byte  native key serialization
+//
+// and version 2 adds the signing quorum of threshold keys after created:
+//
+//	quorum   uint16
+//
+// Version 3 follows the quorum with the access structure the share is held
+// under, which signing needs to turn the share into an additive one:
+//
+//	acLen    uint32
+//	ac       []byte  serialized access structure
+//
+// Keys without a quorum are still written as version 1, and keys with a
+// quorum but no access structure as version 2, so earlier releases keep
+// loading them.
+const (
+	keyVersion       = 1
+	keyVersionQuorum = 2
+	keyVersionAccess = 3
+)
+
+// KeyProtocolName returns the name of a key protocol.
+func KeyProtocolName(p uint8) string {
+	switch p {
+	case KeyECDSA2P:
+		return "ecdsa2p"
+	case KeyECDSAMP:
+		return "ecdsamp"
+	case KeySchnorr2P:
+		return "schnorr2p"
+	case KeySchnorrMP:
+		return "schnorrmp"
+	default:
+		return fmt.Sprintf("protocol(%d)", p)
+	}
+}
+
+// KeyMeta describes a serialized key share.
+type KeyMeta struct {
+	Protocol uint8
+	Curve    uint8
+	// Role is "p1" or "p2" for 2-party keys and the party name for
+	// multi-party keys.
+	Role string
+	// Created is the key's creation time, zero if unknown.
+	Created time.Time
+	// Quorum is the number of parties needed to sign with a threshold key,
+	// zero for keys that need every party.
+	Quorum int
+	// AccessStructure is the serialized access structure of a threshold
+	// key, nil for keys that need every party.
+	AccessStructure []byte
+}
+
+// Role2P returns the KeyMeta role of a 2-party key share: "p1" for role 0,
+// "p2" otherwise.
+func Role2P(role int) string {
+	if role == 0 {
+		return "p1"
+	}
+	return "p2"
+}
+
+// Check reports whether a key share loaded from an envelope described by m
+// has the curve and role the envelope claims. It accepts anything for the
+// zero KeyMeta of a bare share.
+func (m KeyMeta) Check(curve uint8, role string) error {
+	if m.Protocol == 0 {
+		return nil
+	}
+	if m.Curve != curve {
+		return fmt.Errorf("%w: envelope says curve %d, share has %d", ErrCurveMismatch, m.Curve, curve)
+	}
+	if m.Role != role {
+		return fmt.Errorf("%w: envelope says role %q, share has %q", ErrMalformed, m.Role, role)
+	}
+	return nil
+}
+
+// EncodeKey wraps the native serialization of a key share described by m.
+func EncodeKey(m KeyMeta, share []byte) ([]byte, error) {
+	if len(m.Role) > 1<<16-1 {
+		return nil, fmt.Errorf("role too long: %d bytes", len(m.Role))
+	}
+	if m.Quorum < 0 || m.Quorum > 1<<16-1 {
+		return nil, fmt.Errorf("invalid quorum %d", m.Quorum)
+	}
+	if uint64(len(m.AccessStructure)) > 1<<32-1 {
+		return nil, fmt.Errorf("access structure too long: %d bytes", len(m.AccessStructure))
+	}
+	var created int64
+	if !m.Created.IsZero() {
+		created = m.Created.Unix()
+	}
+	version := uint16(keyVersion)
+	payload := make([]byte, 0, 2+len(m.Role)+8+2+4+len(m.AccessStructure)+len(share))
+	payload = binary.BigEndian.AppendUint16(payload, uint16(len(m.Role)))
+	payload = append(payload, m.Role...)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(created))
+	if m.Quorum != 0 || len(m.AccessStructure) != 0 {
+		version = keyVersionQuorum
+		payload = binary.BigEndian.AppendUint16(payload, uint16(m.Quorum))
+	}
+	if len(m.AccessStructure) != 0 {
+		version = keyVersionAccess
+		payload = binary.BigEndian.AppendUint32(payload, uint32(len(m.AccessStructure)))
+		payload = append(payload, m.AccessStructure...)
+	}
+	payload = append(payload, share...)
+	out, err := Encode(Header{Domain: DomainKey, Type: m.Protocol, Curve: m.Curve, Version: version}, payload)
+	clear(payload)
+	return out, err
+}
+
+// DecodeKey parses a key share written by EncodeKey and checks that it
+// belongs to protocol. The returned share aliases data.
+func DecodeKey(protocol uint8, data []byte) (KeyMeta, []byte, error) {
+	h, payload, err := Decode(DomainKey, data)
+	if err != nil {
+		return KeyMeta{}, nil, err
+	}
+	if h.Type != protocol {
+		return KeyMeta{}, nil, fmt.Errorf("%w: %s key share, want %s",
+			ErrTypeMismatch, KeyProtocolName(h.Type), KeyProtocolName(protocol))
+	}
+	if h.Version < keyVersion || h.Version > keyVersionAccess {
+		return KeyMeta{}, nil, fmt.Errorf("%w: key share version %d", ErrUnsupportedVersion, h.Version)
+	}
+	if len(payload) < 2 {
+		return KeyMeta{}, nil, fmt.Errorf("%w: truncated key share", ErrMalformed)
+	}
+	n := int(binary.BigEndian.Uint16(payload))
+	if len(payload) < 2+n+8 {
+		return KeyMeta{}, nil, fmt.Errorf("%w: truncated key share", ErrMalformed)
+	}
+	m := KeyMeta{Protocol: h.Type, Curve: h.Curve, Role: string(payload[2 : 2+n])}
+	if created := int64(binary.BigEndian.Uint64(payload[2+n:])); created != 0 {
+		m.Created = time.Unix(created, 0).UTC()
+	}
+	share := payload[2+n+8:]
+	if h.Version >= keyVersionQuorum {
+		if len(share) < 2 {
+			return KeyMeta{}, nil, fmt.Errorf("%w: truncated key share", ErrMalformed)
+		}
+		m.Quorum = int(binary.BigEndian.Uint16(share))
+		share = share[2:]
+	}
+	if h.Version >= keyVersionAccess {
+		if len(share) < 4 {
+			return KeyMeta{}, nil, fmt.Errorf("%w: truncated key share", ErrMalformed)
+		}
+		n := binary.BigEndian.Uint32(share)
+		if uint64(len(share)-4) < uint64(n) {
+			return KeyMeta{}, nil, fmt.Errorf("%w: truncated key share", ErrMalformed)
+		}
+		m.AccessStructure = share[4 : 4+n]
+		share = share[4+n:]
+	}
+	if len(share) == 0 {
+		return KeyMeta{}, nil, fmt.Errorf("%w: empty key share", ErrMalformed)
+	}
+	return m, share, nil
+}
+
+// UnwrapKey is DecodeKey, except that data without an envelope header is
+// returned unchanged with a zero KeyMeta: shares serialized before keys
+// carried an envelope are bare native serializations.
+func UnwrapKey(protocol uint8, data []byte) (KeyMeta, []byte, error) {
+	if len(data) < len(magic) || !bytes.Equal(data[:len(magic)], magic[:]) {
+		return KeyMeta{}, data, nil
+	}
+	return DecodeKey(protocol, data)
+}
@@ -0,0 +1,131 @@
+package envelope_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+)
+
+func TestKeyRoundTrip(t *testing.T) {
+	share := []byte{0xde, 0xad, 0xbe, 0xef}
+	meta := envelope.KeyMeta{
+		Protocol: envelope.KeyECDSAMP,
+		Curve:    3,
+		Role:     "alice",
+		Created:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	data, err := envelope.EncodeKey(meta, share)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, gotShare, err := envelope.UnwrapKey(envelope.KeyECDSAMP, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, meta) {
+		t.Fatalf("meta = %+v, want %+v", got, meta)
+	}
+	if !bytes.Equal(gotShare, share) {
+		t.Fatalf("share = %x, want %x", gotShare, share)
+	}
+	if err := got.Check(3, "alice"); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if err := got.Check(4, "alice"); !errors.Is(err, envelope.ErrCurveMismatch) {
+		t.Errorf("curve mismatch: %v", err)
+	}
+	if err := got.Check(3, "bob"); !errors.Is(err, envelope.ErrMalformed) {
+		t.Errorf("role mismatch: %v", err)
+	}
+}
+
+func TestKeyMismatches(t *testing.T) {
+	data, err := envelope.EncodeKey(envelope.KeyMeta{Protocol: envelope.KeySchnorrMP, Role: "p"}, []byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = envelope.DecodeKey(envelope.KeyECDSAMP, data)
+	if !errors.Is(err, envelope.ErrTypeMismatch) {
+		t.Errorf("protocol mismatch: %v", err)
+	}
+
+	newer := append([]byte(nil), data...)
+	newer[9] = 4 // payload version, low byte
+	if _, _, err := envelope.DecodeKey(envelope.KeySchnorrMP, newer); !errors.Is(err, envelope.ErrUnsupportedVersion) {
+		t.Errorf("newer payload version: %v", err)
+	}
+
+	for _, n := range []int{1, 3} {
+		trunc := append([]byte(nil), data[:len(data)-n]...)
+		trunc[13] -= byte(n) // payload length, low byte
+		if _, _, err := envelope.DecodeKey(envelope.KeySchnorrMP, trunc); !errors.Is(err, envelope.ErrMalformed) {
+			t.Errorf("truncated by %d: %v", n, err)
+		}
+	}
+}
+
+func TestUnwrapBareKey(t *testing.T) {
+	bare := []byte{0x00, 0x01, 0x02}
+	meta, share, err := envelope.UnwrapKey(envelope.KeyECDSA2P, bare)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(meta, envelope.KeyMeta{}) || !bytes.Equal(share, bare) {
+		t.Fatalf("UnwrapKey(bare) = %+v, %x", meta, share)
+	}
+	if err := meta.Check(1, "p1"); err != nil {
+		t.Fatalf("Check on a bare share: %v", err)
+	}
+}
+
+func TestKeyQuorumRoundTrip(t *testing.T) {
+	share := []byte{0xde, 0xad, 0xbe, 0xef}
+	meta := envelope.KeyMeta{Protocol: envelope.KeySchnorrMP, Curve: 3, Role: "bob", Quorum: 3}
+	data, err := envelope.EncodeKey(meta, share)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[9] != 2 {
+		t.Fatalf("payload version = %d, want 2 for a key with a quorum", data[9])
+	}
+	got, gotShare, err := envelope.DecodeKey(envelope.KeySchnorrMP, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, meta) || !bytes.Equal(gotShare, share) {
+		t.Fatalf("DecodeKey = %+v, %x; want %+v, %x", got, gotShare, meta, share)
+	}
+
+	plain, err := envelope.EncodeKey(envelope.KeyMeta{Protocol: envelope.KeySchnorrMP, Role: "bob"}, share)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain[9] != 1 {
+		t.Fatalf("payload version = %d, want 1 for a key without a quorum", plain[9])
+	}
+}
+
+func TestKeyAccessStructureRoundTrip(t *testing.T) {
+	share := []byte{0xde, 0xad, 0xbe, 0xef}
+	meta := envelope.KeyMeta{Protocol: envelope.KeyECDSAMP, Curve: 1, Role: "carol", Quorum: 2, AccessStructure: []byte{1, 2, 3, 4, 5}}
+	data, err := envelope.EncodeKey(meta, share)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[9] != 3 {
+		t.Fatalf("payload version = %d, want 3 for a key with an access structure", data[9])
+	}
+	got, gotShare, err := envelope.DecodeKey(envelope.KeyECDSAMP, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, meta) || !bytes.Equal(gotShare, share) {
+		t.Fatalf("DecodeKey = %+v, %x; want %+v, %x", got, gotShare, meta, share)
+	}
+}
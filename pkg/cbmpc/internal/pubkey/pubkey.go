@@ -0,0 +1,58 @@
+// Package pubkey converts the public key encodings returned by the native
+// library into crypto/ecdsa and crypto/ed25519 keys.
+package pubkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// ECDSA parses a compressed SEC 1 point on c, as returned by the key types'
+// PublicKey methods. Keys on
+// secp256k1, which crypto/elliptic does not provide, use btcec.S256() as
+// their curve; crypto/ecdsa verifies signatures with them, more slowly than
+// with the built-in curves.
+func ECDSA(c cbmpc.Curve, b []byte) (*ecdsa.PublicKey, error) {
+	if c == cbmpc.CurveSecp256k1 {
+		pk, err := btcec.ParsePubKey(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secp256k1 public key: %w", err)
+		}
+		return pk.ToECDSA(), nil
+	}
+
+	var ec elliptic.Curve
+	switch c {
+	case cbmpc.CurveP256:
+		ec = elliptic.P256()
+	case cbmpc.CurveP384:
+		ec = elliptic.P384()
+	case cbmpc.CurveP521:
+		ec = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("not an ECDSA curve: %v", c)
+	}
+	x, y := elliptic.UnmarshalCompressed(ec, b)
+	if x == nil {
+		return nil, fmt.Errorf("invalid %v public key", c)
+	}
+	return &ecdsa.PublicKey{Curve: ec, X: x, Y: y}, nil
+}
+
+// Ed25519 returns the 32-byte Ed25519 encoding b as an ed25519.PublicKey.
+func Ed25519(c cbmpc.Curve, b []byte) (ed25519.PublicKey, error) {
+	if c != cbmpc.CurveEd25519 {
+		return nil, fmt.Errorf("not an Ed25519 key: %v", c)
+	}
+	if len(b) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid Ed25519 public key length")
+	}
+	return ed25519.PublicKey(append([]byte(nil), b...)), nil
+}
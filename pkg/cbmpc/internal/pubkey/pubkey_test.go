@@ -0,0 +1,83 @@
+package pubkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+func TestECDSA(t *testing.T) {
+	hash := sha256.Sum256([]byte("pubkey"))
+	for _, c := range []cbmpc.Curve{cbmpc.CurveP256, cbmpc.CurveP384, cbmpc.CurveP521} {
+		var ec elliptic.Curve
+		switch c {
+		case cbmpc.CurveP256:
+			ec = elliptic.P256()
+		case cbmpc.CurveP384:
+			ec = elliptic.P384()
+		default:
+			ec = elliptic.P521()
+		}
+		priv, err := ecdsa.GenerateKey(ec, rand.Reader)
+		if err != nil {
+			t.Fatalf("GenerateKey failed: %v", err)
+		}
+		pub, err := ECDSA(c, elliptic.MarshalCompressed(ec, priv.X, priv.Y))
+		if err != nil {
+			t.Fatalf("%v: ECDSA failed: %v", c, err)
+		}
+		if !pub.Equal(&priv.PublicKey) {
+			t.Fatalf("%v: parsed key differs", c)
+		}
+	}
+
+	priv, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey failed: %v", err)
+	}
+	pub, err := ECDSA(cbmpc.CurveSecp256k1, priv.PubKey().SerializeCompressed())
+	if err != nil {
+		t.Fatalf("secp256k1: ECDSA failed: %v", err)
+	}
+	sig, err := ecdsa.SignASN1(rand.Reader, priv.ToECDSA(), hash[:])
+	if err != nil {
+		t.Fatalf("SignASN1 failed: %v", err)
+	}
+	if !ecdsa.VerifyASN1(pub, hash[:], sig) {
+		t.Fatal("secp256k1 signature does not verify with parsed key")
+	}
+
+	if _, err := ECDSA(cbmpc.CurveP256, []byte{0x02, 0x01}); err == nil {
+		t.Error("expected error for truncated point")
+	}
+	if _, err := ECDSA(cbmpc.CurveEd25519, make([]byte, 32)); err == nil {
+		t.Error("expected error for Ed25519")
+	}
+}
+
+func TestEd25519(t *testing.T) {
+	want, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	got, err := Ed25519(cbmpc.CurveEd25519, want)
+	if err != nil {
+		t.Fatalf("Ed25519 failed: %v", err)
+	}
+	if !got.Equal(want) {
+		t.Fatal("parsed key differs")
+	}
+	if _, err := Ed25519(cbmpc.CurveEd25519, want[:31]); err == nil {
+		t.Error("expected error for short key")
+	}
+	if _, err := Ed25519(cbmpc.CurveSecp256k1, want); err == nil {
+		t.Error("expected error for secp256k1")
+	}
+}
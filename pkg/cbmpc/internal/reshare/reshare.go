@@ -0,0 +1,393 @@
+// Package reshare implements dealer-based resharing of an EC private key, the
+// core shared by migrate.ECDSA2PToMP and ecdsamp.Reshare.
+//
+// Each dealer holds an additive share of the private key x, that is the
+// dealers' secrets sum to x. Every dealer Shamir-shares its secret to the
+// recipients with a Feldman commitment and sends each participant the
+// commitment, plus its share if it is a recipient. Participants then exchange
+// a digest of every commitment they received, so no one accepts a share of a
+// polynomial the others never saw. Each recipient checks its shares against
+// the commitments, checks that the committed secrets sum to the public key,
+// and adds its shares. Recipient i (0-based position in Recipients) ends up
+// with f(i+1) of a degree Threshold-1 polynomial with f(0) = x.
+package reshare
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secretsharing"
+)
+
+// ErrInconsistentDeal is wrapped by the error returned when a dealing does not
+// verify or participants saw different dealings.
+var ErrInconsistentDeal = errors.New("inconsistent dealing")
+
+// Params configures one resharing run.
+type Params struct {
+	// Tag separates protocols built on this package in messages and digests.
+	Tag string
+
+	Self  cbmpc.RoleID
+	Names []string // every participant, by RoleID
+
+	Dealers    []cbmpc.RoleID
+	Recipients []cbmpc.RoleID // recipient i receives the share at index i+1
+	Threshold  int
+
+	// Dealers set Curve, PublicKey and Secret; other participants leave them
+	// zero.
+	Curve     cbmpc.Curve
+	PublicKey []byte
+	Secret    *curve.Scalar
+
+	// Pin optionally fixes the public key every participant must see.
+	Pin []byte
+
+	// DealerPublicKey optionally returns the expected Secret*G of Dealers[i]
+	// given the curve, or nil if the caller cannot tell.
+	DealerPublicKey func(c cbmpc.Curve, i int) ([]byte, error)
+}
+
+// Result is what a participant learns.
+type Result struct {
+	Curve     cbmpc.Curve
+	PublicKey []byte
+
+	// Index is the caller's position in Recipients, or -1.
+	Index int
+	// Share is the caller's new share, nil unless it is a recipient. The
+	// caller must free it.
+	Share *curve.Scalar
+	// PublicShares are share*G for every recipient, in Recipients order.
+	PublicShares [][]byte
+}
+
+// deal is what a dealer sends to each other participant.
+type deal struct {
+	Tag        string      `json:"tag"`
+	Curve      cbmpc.Curve `json:"curve"`
+	PublicKey  []byte      `json:"public_key"`
+	Commitment [][]byte    `json:"commitment"`
+	Share      []byte      `json:"share,omitempty"`
+}
+
+// Run executes the resharing for the calling participant. Every participant
+// must call it with the same Tag, Names, Dealers, Recipients and Threshold.
+func Run(ctx context.Context, t cbmpc.Transport, p *Params) (*Result, error) {
+	if t == nil {
+		return nil, cbmpc.ErrNilTransport
+	}
+	if p == nil {
+		return nil, errors.New("nil params")
+	}
+	n := len(p.Names)
+	if n < 2 {
+		return nil, fmt.Errorf("%w: need at least 2 parties (got %d)", cbmpc.ErrBadPeers, n)
+	}
+	if int(p.Self) >= n {
+		return nil, fmt.Errorf("%w: self role %d out of range [0,%d)", cbmpc.ErrBadPeers, p.Self, n)
+	}
+	dealer, err := position("dealer", p.Dealers, p.Self, n)
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := position("recipient", p.Recipients, p.Self, n)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Dealers) == 0 {
+		return nil, errors.New("no dealers")
+	}
+	if p.Threshold < 2 || p.Threshold > len(p.Recipients) {
+		return nil, fmt.Errorf("threshold must be in [2,%d] (got %d)", len(p.Recipients), p.Threshold)
+	}
+	participants := make(map[cbmpc.RoleID]bool, n)
+	for _, r := range append(append([]cbmpc.RoleID{}, p.Dealers...), p.Recipients...) {
+		participants[r] = true
+	}
+	if len(participants) != n {
+		return nil, fmt.Errorf("%w: every party must be a dealer or a recipient", cbmpc.ErrBadPeers)
+	}
+	if (dealer >= 0) != (p.Secret != nil) {
+		return nil, errors.New("secret must be set by dealers and only by dealers")
+	}
+
+	var peers []cbmpc.RoleID
+	for j := range p.Names {
+		if cbmpc.RoleID(j) != p.Self {
+			peers = append(peers, cbmpc.RoleID(j))
+		}
+	}
+
+	// Round 1: dealers send their dealings.
+	deals := make([]*deal, len(p.Dealers))
+	defer func() {
+		for _, d := range deals {
+			if d != nil {
+				cbmpc.ZeroizeBytes(d.Share)
+			}
+		}
+	}()
+	if dealer >= 0 {
+		own, err := dealTo(ctx, t, p, peers)
+		if err != nil {
+			return nil, err
+		}
+		deals[dealer] = own
+	}
+	var from []cbmpc.RoleID
+	for i, d := range p.Dealers {
+		if i != dealer {
+			from = append(from, d)
+		}
+	}
+	if len(from) > 0 {
+		msgs, err := t.ReceiveAll(ctx, from)
+		if err != nil {
+			return nil, fmt.Errorf("reshare receive: %w", err)
+		}
+		for i, d := range p.Dealers {
+			if i == dealer {
+				continue
+			}
+			v := new(deal)
+			raw := msgs[d]
+			err := json.Unmarshal(raw, v)
+			cbmpc.ZeroizeBytes(raw)
+			if err != nil || v.Tag != p.Tag {
+				return nil, fmt.Errorf("%w: dealer %d sent a malformed dealing", ErrInconsistentDeal, d)
+			}
+			if (recipient >= 0) != (len(v.Share) > 0) {
+				return nil, fmt.Errorf("%w: dealer %d sent a malformed dealing", ErrInconsistentDeal, d)
+			}
+			deals[i] = v
+		}
+	}
+	for i, d := range deals {
+		if d.Curve != deals[0].Curve || !bytes.Equal(d.PublicKey, deals[0].PublicKey) {
+			return nil, fmt.Errorf("%w: dealers %d and %d disagree on the key", ErrInconsistentDeal, p.Dealers[0], p.Dealers[i])
+		}
+	}
+	if p.Pin != nil && !bytes.Equal(deals[0].PublicKey, p.Pin) {
+		return nil, fmt.Errorf("%w: dealers are resharing public key %x, want %x", ErrInconsistentDeal, deals[0].PublicKey, p.Pin)
+	}
+
+	// Round 2: confirm every participant saw the same commitments.
+	sum := digest(p.Tag, deals)
+	for _, peer := range peers {
+		if err := t.Send(ctx, peer, sum); err != nil {
+			return nil, fmt.Errorf("reshare send to party %d: %w", peer, err)
+		}
+	}
+	echoes, err := t.ReceiveAll(ctx, peers)
+	if err != nil {
+		return nil, fmt.Errorf("reshare receive: %w", err)
+	}
+	for _, peer := range peers {
+		if !bytes.Equal(echoes[peer], sum) {
+			return nil, fmt.Errorf("%w: party %d saw different commitments", ErrInconsistentDeal, peer)
+		}
+	}
+
+	return combine(deals, p, recipient)
+}
+
+// position validates roles and returns self's position in it, or -1.
+func position(what string, roles []cbmpc.RoleID, self cbmpc.RoleID, n int) (int, error) {
+	pos := -1
+	seen := make(map[cbmpc.RoleID]bool, len(roles))
+	for i, r := range roles {
+		if int(r) >= n {
+			return -1, fmt.Errorf("%w: %s %d out of range [0,%d)", cbmpc.ErrBadPeers, what, r, n)
+		}
+		if seen[r] {
+			return -1, fmt.Errorf("%w: duplicate %s %d", cbmpc.ErrBadPeers, what, r)
+		}
+		seen[r] = true
+		if r == self {
+			pos = i
+		}
+	}
+	return pos, nil
+}
+
+// dealTo splits the caller's secret, sends every peer its dealing and returns
+// the caller's own.
+func dealTo(ctx context.Context, t cbmpc.Transport, p *Params, peers []cbmpc.RoleID) (*deal, error) {
+	res, err := secretsharing.Split(&secretsharing.SplitParams{
+		Curve:     p.Curve,
+		Secret:    p.Secret,
+		Threshold: p.Threshold,
+		Parties:   len(p.Recipients),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Free()
+	commitment, err := res.Commitment.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	shareFor := func(r cbmpc.RoleID) []byte {
+		for i, rr := range p.Recipients {
+			if rr == r {
+				return res.Shares[i].Value.BytesPadded(p.Curve)
+			}
+		}
+		return nil
+	}
+
+	for _, peer := range peers {
+		d := deal{Tag: p.Tag, Curve: p.Curve, PublicKey: p.PublicKey, Commitment: commitment, Share: shareFor(peer)}
+		msg, err := json.Marshal(d)
+		cbmpc.ZeroizeBytes(d.Share)
+		if err != nil {
+			return nil, err
+		}
+		err = t.Send(ctx, peer, msg)
+		cbmpc.ZeroizeBytes(msg)
+		if err != nil {
+			return nil, fmt.Errorf("reshare send to party %d: %w", peer, err)
+		}
+	}
+	return &deal{Tag: p.Tag, Curve: p.Curve, PublicKey: p.PublicKey, Commitment: commitment, Share: shareFor(p.Self)}, nil
+}
+
+// digest hashes everything about the dealings that must be the same at every
+// participant.
+func digest(tag string, deals []*deal) []byte {
+	h := sha256.New()
+	writeBytes(h, []byte(tag))
+	for _, d := range deals {
+		fmt.Fprintf(h, "%d:%d:", d.Curve, len(d.Commitment))
+		writeBytes(h, d.PublicKey)
+		for _, pt := range d.Commitment {
+			writeBytes(h, pt)
+		}
+	}
+	return h.Sum(nil)
+}
+
+func writeBytes(w io.Writer, b []byte) {
+	fmt.Fprintf(w, "%d:", len(b))
+	_, _ = w.Write(b)
+}
+
+// combine verifies the dealings and, for a recipient, adds its shares.
+func combine(deals []*deal, p *Params, recipient int) (*Result, error) {
+	c := deals[0].Curve
+	commitments := make([]*secretsharing.Commitment, len(deals))
+	defer func() {
+		for _, cm := range commitments {
+			cm.Free()
+		}
+	}()
+	for i, d := range deals {
+		cm, err := secretsharing.LoadCommitment(c, d.Commitment)
+		if err != nil {
+			return nil, fmt.Errorf("%w: dealer %d: %v", ErrInconsistentDeal, p.Dealers[i], err)
+		}
+		commitments[i] = cm
+		if cm.Threshold() != p.Threshold {
+			return nil, fmt.Errorf("%w: dealer %d dealt threshold %d, want %d", ErrInconsistentDeal, p.Dealers[i], cm.Threshold(), p.Threshold)
+		}
+		if p.DealerPublicKey == nil {
+			continue
+		}
+		want, err := p.DealerPublicKey(c, i)
+		if err != nil {
+			return nil, err
+		}
+		if want == nil {
+			continue
+		}
+		got, err := sumPoints(commitments[i:i+1], (*secretsharing.Commitment).PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(got, want) {
+			return nil, fmt.Errorf("%w: dealer %d dealt a secret that is not its share", ErrInconsistentDeal, p.Dealers[i])
+		}
+	}
+
+	// The committed secrets must add up to the public key.
+	q, err := sumPoints(commitments, (*secretsharing.Commitment).PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(q, deals[0].PublicKey) {
+		return nil, fmt.Errorf("%w: dealt secrets do not add up to the public key", ErrInconsistentDeal)
+	}
+
+	res := &Result{Curve: c, PublicKey: q, Index: recipient, PublicShares: make([][]byte, len(p.Recipients))}
+	for k := range p.Recipients {
+		res.PublicShares[k], err = sumPoints(commitments, func(cm *secretsharing.Commitment) (*curve.Point, error) {
+			return cm.PublicShare(k + 1)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if recipient < 0 {
+		return res, nil
+	}
+
+	var sum *curve.Scalar
+	for i, d := range deals {
+		s, err := curve.NewScalarFromBytes(d.Share)
+		if err != nil {
+			sum.Free()
+			return nil, fmt.Errorf("%w: dealer %d: %v", ErrInconsistentDeal, p.Dealers[i], err)
+		}
+		if err := secretsharing.VerifyShare(commitments[i], secretsharing.Share{Index: recipient + 1, Value: s}); err != nil {
+			s.Free()
+			sum.Free()
+			return nil, fmt.Errorf("%w: dealer %d: %v", ErrInconsistentDeal, p.Dealers[i], err)
+		}
+		if sum == nil {
+			sum = s
+			continue
+		}
+		next, err := sum.Add(s, c)
+		sum.Free()
+		s.Free()
+		if err != nil {
+			return nil, err
+		}
+		sum = next
+	}
+	res.Share = sum
+	return res, nil
+}
+
+// sumPoints returns the compressed sum of point(cm) over cms.
+func sumPoints(cms []*secretsharing.Commitment, point func(*secretsharing.Commitment) (*curve.Point, error)) ([]byte, error) {
+	var acc *curve.Point
+	defer func() { acc.Free() }()
+	for _, cm := range cms {
+		pt, err := point(cm)
+		if err != nil {
+			return nil, err
+		}
+		if acc == nil {
+			acc = pt
+			continue
+		}
+		next, err := acc.Add(pt)
+		pt.Free()
+		if err != nil {
+			return nil, err
+		}
+		acc.Free()
+		acc = next
+	}
+	return acc.Bytes()
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"slices"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
@@ -23,6 +25,14 @@ type Job2P struct {
 	hptr      uintptr
 	cancel    context.CancelFunc
 	closeOnce sync.Once
+	guard     invocationGuard
+	transport Transport
+	self      RoleID
+	names     [2]string
+	policy    SignPolicy
+	hooks     []OperationHook
+	trace     *jobTrace
+	release   func() // set by a Library, called on Close
 }
 
 type JobMP struct {
@@ -30,6 +40,37 @@ type JobMP struct {
 	hptr      uintptr
 	cancel    context.CancelFunc
 	closeOnce sync.Once
+	guard     invocationGuard
+	transport Transport
+	self      RoleID
+	names     []string
+	policy    SignPolicy
+	hooks     []OperationHook
+	trace     *jobTrace
+	release   func() // set by a Library, called on Close
+
+	refreshOnBitLeak bool
+}
+
+// JobOptions tunes how a job drives its transport.
+type JobOptions struct {
+	// RoundTimeout bounds the wait for each round's messages, separately
+	// from the deadline of the context the job was created with. A peer that
+	// stays silent for a whole round fails the protocol with a
+	// *RoundTimeoutError naming the round and the peer, instead of the run
+	// waiting for the overall deadline. Zero disables it.
+	RoundTimeout time.Duration
+	// OnRound, if set, is called for every message the job sends or
+	// receives, so that UIs and orchestrators can show how far a protocol
+	// run has got. See RoundFunc.
+	OnRound RoundFunc
+	// RefreshOnBitLeak makes multi-party global-abort signing refresh an
+	// n-of-n key on the same job when it fails with a bit leak, and return
+	// the new share in the *BitLeakError. Every party must set it, since the
+	// refresh is a protocol run of its own; every party learns of the leak,
+	// so all of them start it. Two-party jobs ignore it, because only P1
+	// verifies the signature there.
+	RefreshOnBitLeak bool
 }
 
 // transportAdapter bridges the public RoleID-based Transport interface with
@@ -37,16 +78,25 @@ type JobMP struct {
 // the exported API idiomatic while avoiding a dependency cycle between pkg and
 // internal/bindings.
 type transportAdapter struct {
-	inner Transport
-	ctx   context.Context
+	inner        Transport
+	ctx          context.Context
+	trace        *jobTrace
+	roundTimeout time.Duration
 }
 
 func (a transportAdapter) Send(_ context.Context, to uint32, msg []byte) error {
-	return a.inner.Send(a.ctx, RoleID(to), msg)
+	err := a.inner.Send(a.ctx, RoleID(to), msg)
+	a.trace.send(a.ctx, RoleID(to), len(msg), err)
+	return err
 }
 
 func (a transportAdapter) Receive(_ context.Context, from uint32) ([]byte, error) {
-	return a.inner.Receive(a.ctx, RoleID(from))
+	ctx, cancel := a.roundContext()
+	defer cancel()
+	msg, err := a.inner.Receive(ctx, RoleID(from))
+	err = a.roundError(ctx, RoleID(from), err)
+	a.trace.receive(a.ctx, []RoleID{RoleID(from)}, len(msg), err)
+	return msg, err
 }
 
 func (a transportAdapter) ReceiveAll(_ context.Context, from []uint32) (map[uint32][]byte, error) {
@@ -54,7 +104,12 @@ func (a transportAdapter) ReceiveAll(_ context.Context, from []uint32) (map[uint
 	for i, r := range from {
 		roles[i] = RoleID(r)
 	}
-	batch, err := a.inner.ReceiveAll(a.ctx, roles)
+	batch, err := a.receiveAll(roles)
+	n := 0
+	for _, data := range batch {
+		n += len(data)
+	}
+	a.trace.receive(a.ctx, roles, n, err)
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +120,46 @@ func (a transportAdapter) ReceiveAll(_ context.Context, from []uint32) (map[uint
 	return out, nil
 }
 
+// receiveAll receives one round. With a round timeout it receives from each
+// peer in turn under the round's deadline, so that a timeout names the peer
+// whose message is missing.
+func (a transportAdapter) receiveAll(roles []RoleID) (map[RoleID][]byte, error) {
+	if a.roundTimeout <= 0 {
+		return a.inner.ReceiveAll(a.ctx, roles)
+	}
+	ctx, cancel := a.roundContext()
+	defer cancel()
+	out := make(map[RoleID][]byte, len(roles))
+	for _, role := range roles {
+		if _, dup := out[role]; dup {
+			return nil, fmt.Errorf("duplicate role %d in receive_all", role)
+		}
+		msg, err := a.inner.Receive(ctx, role)
+		if err != nil {
+			return nil, a.roundError(ctx, role, err)
+		}
+		out[role] = msg
+	}
+	return out, nil
+}
+
+// roundContext returns the context for one round's receive.
+func (a transportAdapter) roundContext() (context.Context, context.CancelFunc) {
+	if a.roundTimeout <= 0 {
+		return a.ctx, func() {}
+	}
+	return context.WithTimeout(a.ctx, a.roundTimeout)
+}
+
+// roundError turns a receive that failed because the round deadline passed,
+// rather than the job's own context, into a RoundTimeoutError.
+func (a transportAdapter) roundError(ctx context.Context, from RoleID, err error) error {
+	if err == nil || a.roundTimeout <= 0 || a.ctx.Err() != nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return &RoundTimeoutError{Round: int(a.trace.rounds.Load()) + 1, Peer: from, Timeout: a.roundTimeout}
+}
+
 // NewJob2P constructs a 2-party job using the provided transport, role, and
 // party names. Names must be stable, unique identifiers for each participant.
 // This variant uses a background context; see NewJob2PWithContext to provide
@@ -77,6 +172,12 @@ func NewJob2P(t Transport, self Role, names [2]string) (*Job2P, error) {
 // context derived from ctx is used for all transport operations and will be
 // canceled during Close() to promptly unblock pending receives.
 func NewJob2PWithContext(ctx context.Context, t Transport, self Role, names [2]string) (*Job2P, error) {
+	return NewJob2PWithOptions(ctx, t, self, names, JobOptions{})
+}
+
+// NewJob2PWithOptions constructs a 2-party job as NewJob2PWithContext does,
+// tuned by opts.
+func NewJob2PWithOptions(ctx context.Context, t Transport, self Role, names [2]string, opts JobOptions) (*Job2P, error) {
 	if t == nil {
 		return nil, ErrNilTransport
 	}
@@ -91,14 +192,15 @@ func NewJob2PWithContext(ctx context.Context, t Transport, self Role, names [2]s
 	}
 
 	jobCtx, cancel := context.WithCancel(ctx)
-	adapter := transportAdapter{inner: t, ctx: jobCtx}
+	trace := &jobTrace{onRound: opts.OnRound}
+	adapter := transportAdapter{inner: t, ctx: jobCtx, trace: trace, roundTimeout: opts.RoundTimeout}
 	cjob, h, err := backend.NewJob2P(adapter, uint32(self.roleID()), []string{names[0], names[1]})
 	if err != nil {
 		cancel()
 		return nil, RemapError(err)
 	}
 
-	j := &Job2P{cptr: cjob, hptr: h, cancel: cancel}
+	j := &Job2P{cptr: cjob, hptr: h, cancel: cancel, transport: t, self: self.roleID(), names: names, trace: trace}
 	runtime.SetFinalizer(j, func(j *Job2P) { _ = j.Close() })
 	return j, nil
 }
@@ -116,6 +218,9 @@ func (j *Job2P) Close() error {
 		j.cptr = nil
 		j.hptr = 0
 		j.cancel = nil
+		if j.release != nil {
+			j.release()
+		}
 	})
 	return nil
 }
@@ -132,6 +237,12 @@ func NewJobMP(t Transport, self RoleID, names []string) (*JobMP, error) {
 // context derived from ctx is used for all transport operations and will be
 // canceled during Close() to promptly unblock pending receives.
 func NewJobMPWithContext(ctx context.Context, t Transport, self RoleID, names []string) (*JobMP, error) {
+	return NewJobMPWithOptions(ctx, t, self, names, JobOptions{})
+}
+
+// NewJobMPWithOptions constructs an n-party job as NewJobMPWithContext does,
+// tuned by opts.
+func NewJobMPWithOptions(ctx context.Context, t Transport, self RoleID, names []string, opts JobOptions) (*JobMP, error) {
 	if t == nil {
 		return nil, ErrNilTransport
 	}
@@ -155,14 +266,15 @@ func NewJobMPWithContext(ctx context.Context, t Transport, self RoleID, names []
 	}
 
 	jobCtx, cancel := context.WithCancel(ctx)
-	adapter := transportAdapter{inner: t, ctx: jobCtx}
+	trace := &jobTrace{onRound: opts.OnRound}
+	adapter := transportAdapter{inner: t, ctx: jobCtx, trace: trace, roundTimeout: opts.RoundTimeout}
 	cjob, h, err := backend.NewJobMP(adapter, uint32(self), names)
 	if err != nil {
 		cancel()
 		return nil, RemapError(err)
 	}
 
-	j := &JobMP{cptr: cjob, hptr: h, cancel: cancel}
+	j := &JobMP{cptr: cjob, hptr: h, cancel: cancel, transport: t, self: self, names: slices.Clone(names), trace: trace, refreshOnBitLeak: opts.RefreshOnBitLeak}
 	runtime.SetFinalizer(j, func(j *JobMP) { _ = j.Close() })
 	return j, nil
 }
@@ -180,6 +292,9 @@ func (j *JobMP) Close() error {
 		j.cptr = nil
 		j.hptr = 0
 		j.cancel = nil
+		if j.release != nil {
+			j.release()
+		}
 	})
 	return nil
 }
@@ -193,6 +308,33 @@ func (j *Job2P) Ptr() (unsafe.Pointer, error) {
 	return j.cptr, nil
 }
 
+// Transport returns the job's transport, this party's role and the peer's
+// role. Callers should hold the job (see Acquire) while they use the
+// transport, so that their messages do not interleave with a native protocol.
+// This is exported for use by protocol subpackages.
+func (j *Job2P) Transport() (t Transport, self, peer RoleID, err error) {
+	if j == nil || j.cptr == nil {
+		return nil, 0, 0, ErrJobClosed
+	}
+	return j.transport, j.self, 1 - j.self, nil
+}
+
+// Names returns a copy of the job's party names, indexed by RoleID.
+// This is exported for use by protocol subpackages.
+func (j *JobMP) Names() []string {
+	if j == nil {
+		return nil
+	}
+	return slices.Clone(j.names)
+}
+
+// RefreshOnBitLeak reports whether the job was created with
+// JobOptions.RefreshOnBitLeak.
+// This is exported for use by protocol subpackages.
+func (j *JobMP) RefreshOnBitLeak() bool {
+	return j != nil && j.refreshOnBitLeak
+}
+
 // Ptr returns the unsafe pointer to the underlying C job.
 // This is exported for use by protocol subpackages.
 func (j *JobMP) Ptr() (unsafe.Pointer, error) {
@@ -0,0 +1,35 @@
+// Package jobpool keeps established 2-party jobs ready for signing services.
+//
+// Creating a job means dialing the peer, completing a TLS handshake and
+// optionally a version handshake, which can take longer than the signature
+// itself. A Pool creates jobs ahead of time and leases one per request:
+//
+//	pool, err := jobpool.NewPool(ctx, jobpool.Config{
+//	    New: func(ctx context.Context) (*cbmpc.Job2P, io.Closer, error) {
+//	        conn, err := dialPeer(ctx) // one connection per pooled job
+//	        if err != nil {
+//	            return nil, nil, err
+//	        }
+//	        job, err := cbmpc.NewJob2PWithContext(ctx, conn, cbmpc.RoleP1, names)
+//	        return job, conn, err
+//	    },
+//	    Size: 16,
+//	    Warm: 4,
+//	})
+//
+//	lease, err := pool.Get(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	res, err := ecdsa2p.Sign(ctx, lease.Job(), params)
+//	lease.Release(err)
+//
+// A lease gives the caller exclusive use of its job until Release. A job whose
+// protocol run failed is discarded, since its peer may be left mid-session,
+// and the pool creates a replacement on demand.
+//
+// Each pooled job has its own connection, and the peer must run the same
+// protocol on its end of that connection. The peer typically serves every
+// accepted connection with a loop that creates the job once and then runs
+// one protocol per request received on it.
+package jobpool
@@ -0,0 +1,199 @@
+package jobpool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// ErrPoolClosed is returned by Get after Close.
+var ErrPoolClosed = errors.New("job pool closed")
+
+// Factory establishes a transport to the peer and creates a job over it. The
+// returned closer, which may be nil, releases the transport when the job is
+// discarded.
+type Factory func(ctx context.Context) (*cbmpc.Job2P, io.Closer, error)
+
+// Config configures a Pool.
+type Config struct {
+	// New creates the pooled jobs.
+	New Factory
+	// Size is the maximum number of jobs, idle or leased.
+	Size int
+	// Warm is the number of jobs created by NewPool before it returns. It must
+	// not exceed Size.
+	Warm int
+}
+
+// Stats is a snapshot of pool usage.
+type Stats struct {
+	Idle      int
+	InUse     int
+	Created   uint64
+	Discarded uint64
+}
+
+type entry struct {
+	job    *cbmpc.Job2P
+	closer io.Closer
+}
+
+func (e *entry) close() {
+	_ = e.job.Close()
+	if e.closer != nil {
+		_ = e.closer.Close()
+	}
+}
+
+// Pool keeps established 2-party jobs for reuse across requests. A Pool is
+// safe for concurrent use.
+type Pool struct {
+	newJob Factory
+	slots  chan struct{} // one token per job, idle or leased
+
+	mu        sync.Mutex
+	idle      []*entry
+	inUse     int
+	created   uint64
+	discarded uint64
+	closed    bool
+}
+
+// NewPool returns a pool and creates cfg.Warm jobs in it.
+func NewPool(ctx context.Context, cfg Config) (*Pool, error) {
+	if cfg.New == nil {
+		return nil, errors.New("nil factory")
+	}
+	if cfg.Size <= 0 {
+		return nil, errors.New("size must be positive")
+	}
+	if cfg.Warm < 0 || cfg.Warm > cfg.Size {
+		return nil, errors.New("warm must be between 0 and size")
+	}
+	p := &Pool{newJob: cfg.New, slots: make(chan struct{}, cfg.Size)}
+	for i := 0; i < cfg.Warm; i++ {
+		e, err := p.create(ctx)
+		if err != nil {
+			_ = p.Close()
+			return nil, err
+		}
+		p.idle = append(p.idle, e)
+	}
+	return p, nil
+}
+
+func (p *Pool) create(ctx context.Context) (*entry, error) {
+	job, closer, err := p.newJob(ctx)
+	if err != nil {
+		if closer != nil {
+			_ = closer.Close()
+		}
+		return nil, err
+	}
+	if job == nil {
+		if closer != nil {
+			_ = closer.Close()
+		}
+		return nil, errors.New("factory returned nil job")
+	}
+	p.mu.Lock()
+	p.created++
+	p.mu.Unlock()
+	return &entry{job: job, closer: closer}, nil
+}
+
+// Get leases a job, waiting while all Size jobs are leased. It reuses the
+// most recently returned idle job and otherwise creates one with the factory.
+func (p *Pool) Get(ctx context.Context) (*Lease, error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		<-p.slots
+		return nil, ErrPoolClosed
+	}
+	var e *entry
+	if n := len(p.idle); n > 0 {
+		e = p.idle[n-1]
+		p.idle = p.idle[:n-1]
+	}
+	p.inUse++
+	p.mu.Unlock()
+
+	if e == nil {
+		var err error
+		if e, err = p.create(ctx); err != nil {
+			p.mu.Lock()
+			p.inUse--
+			p.mu.Unlock()
+			<-p.slots
+			return nil, err
+		}
+	}
+	return &Lease{p: p, e: e}, nil
+}
+
+// Stats returns current pool usage.
+func (p *Pool) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Stats{Idle: len(p.idle), InUse: p.inUse, Created: p.created, Discarded: p.discarded}
+}
+
+// Close closes all idle jobs. Leased jobs are closed when released. Get fails
+// with ErrPoolClosed afterwards.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+	for _, e := range idle {
+		e.close()
+	}
+	return nil
+}
+
+func (p *Pool) put(e *entry, discard bool) {
+	p.mu.Lock()
+	p.inUse--
+	if discard || p.closed {
+		p.discarded++
+		p.mu.Unlock()
+		e.close()
+	} else {
+		p.idle = append(p.idle, e)
+		p.mu.Unlock()
+	}
+	<-p.slots
+}
+
+// Lease is a job checked out of a Pool.
+type Lease struct {
+	p    *Pool
+	e    *entry
+	once sync.Once
+}
+
+// Job returns the leased job.
+func (l *Lease) Job() *cbmpc.Job2P { return l.e.job }
+
+// Release returns the job to the pool. Pass the error of the protocol run on
+// the job: after a failed run the peer's session state is unknown, so the job
+// is closed and a fresh one is created on a later Get. Only the first call
+// has an effect.
+func (l *Lease) Release(err error) {
+	l.once.Do(func() { l.p.put(l.e, err != nil) })
+}
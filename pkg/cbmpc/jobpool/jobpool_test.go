@@ -0,0 +1,151 @@
+package jobpool_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/jobpool"
+)
+
+type countCloser struct{ n *atomic.Int32 }
+
+func (c countCloser) Close() error { c.n.Add(1); return nil }
+
+// factory hands out placeholder jobs; the pool never runs protocols itself.
+func factory(created, closed *atomic.Int32) jobpool.Factory {
+	return func(context.Context) (*cbmpc.Job2P, io.Closer, error) {
+		created.Add(1)
+		return &cbmpc.Job2P{}, countCloser{closed}, nil
+	}
+}
+
+func TestPoolReuse(t *testing.T) {
+	ctx := context.Background()
+	var created, closed atomic.Int32
+	pool, err := jobpool.NewPool(ctx, jobpool.Config{New: factory(&created, &closed), Size: 2, Warm: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Load() != 1 {
+		t.Fatalf("warm created %d jobs, want 1", created.Load())
+	}
+
+	l1, err := pool.Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := l1.Job()
+	l1.Release(nil)
+	l1.Release(errors.New("ignored")) // only the first Release counts
+
+	l2, err := pool.Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l2.Job() != job {
+		t.Fatal("idle job was not reused")
+	}
+	l2.Release(errors.New("sign failed"))
+	if closed.Load() != 1 {
+		t.Fatalf("failed job not closed")
+	}
+
+	l3, err := pool.Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l3.Job() == job || created.Load() != 2 {
+		t.Fatal("discarded job was not replaced")
+	}
+	if s := pool.Stats(); s.InUse != 1 || s.Idle != 0 || s.Created != 2 || s.Discarded != 1 {
+		t.Fatalf("stats = %+v", s)
+	}
+	l3.Release(nil)
+
+	if err := pool.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if closed.Load() != 2 {
+		t.Fatalf("idle job not closed on Close")
+	}
+	if _, err := pool.Get(ctx); !errors.Is(err, jobpool.ErrPoolClosed) {
+		t.Fatalf("Get after Close = %v", err)
+	}
+}
+
+func TestPoolBlocksAtSize(t *testing.T) {
+	ctx := context.Background()
+	var created, closed atomic.Int32
+	pool, err := jobpool.NewPool(ctx, jobpool.Config{New: factory(&created, &closed), Size: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	a, _ := pool.Get(ctx)
+	b, _ := pool.Get(ctx)
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Get(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get on exhausted pool = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		l, err := pool.Get(ctx)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if l.Job() != a.Job() {
+			t.Error("waiter did not receive the released job")
+		}
+		l.Release(nil)
+	}()
+	a.Release(nil)
+	wg.Wait()
+	b.Release(nil)
+	if created.Load() != 2 {
+		t.Fatalf("created %d jobs, want 2", created.Load())
+	}
+}
+
+func TestPoolFactoryError(t *testing.T) {
+	ctx := context.Background()
+	boom := errors.New("dial failed")
+	fail := func(context.Context) (*cbmpc.Job2P, io.Closer, error) { return nil, nil, boom }
+	if _, err := jobpool.NewPool(ctx, jobpool.Config{New: fail, Size: 1, Warm: 1}); !errors.Is(err, boom) {
+		t.Fatalf("NewPool = %v", err)
+	}
+	pool, err := jobpool.NewPool(ctx, jobpool.Config{New: fail, Size: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ { // a failed create must free its slot
+		if _, err := pool.Get(ctx); !errors.Is(err, boom) {
+			t.Fatalf("Get = %v", err)
+		}
+	}
+}
+
+func TestNewPoolInvalid(t *testing.T) {
+	var c atomic.Int32
+	f := factory(&c, &c)
+	for name, cfg := range map[string]jobpool.Config{
+		"nil factory": {Size: 1},
+		"zero size":   {New: f},
+		"warm > size": {New: f, Size: 1, Warm: 2},
+	} {
+		if _, err := jobpool.NewPool(context.Background(), cfg); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Package journal records a local, append-only log of job lifecycle events
+// for post-incident forensics.
+//
+// Each party journals when a job is created, a digest of every message it
+// sends and receives tagged with a round number, and whether the job
+// completed or aborted, along with an error class. After an incident, reading
+// the journals of all parties shows exactly how far each one progressed and
+// where they diverged:
+//
+//	j, err := journal.Open("/var/lib/mpc/journal.jsonl")
+//	if err != nil {
+//	    return err
+//	}
+//	defer j.Close()
+//
+//	jl, err := j.Begin(requestID, "ecdsa2p.Sign", cbmpc.RoleID(cbmpc.RoleP1), transport)
+//	if err != nil {
+//	    return err
+//	}
+//	job, err := cbmpc.NewJob2PWithContext(ctx, jl, cbmpc.RoleP1, names)
+//	// ...
+//	res, err := ecdsa2p.Sign(ctx, job, params)
+//	_ = jl.Finish(err)
+//
+// Events are written as JSON lines. Open syncs the file after every event.
+// Read decodes a journal and tolerates a torn final line. Summarize reduces it
+// to one JobSummary per job; jobs still StateRunning were in flight when the
+// journal stopped.
+//
+// Payloads are never written, only their SHA-256 digest and size. Digests of
+// one party's sent events match the peer's received events for the same
+// message, which lets journals from different parties be lined up.
+//
+// Journaling is optional and adds a write (and, with Open, an fsync) per
+// message; leave the transport unwrapped when it is not wanted.
+package journal
@@ -0,0 +1,399 @@
+package journal
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// EventType is the kind of a journal event.
+type EventType string
+
+const (
+	EventCreated        EventType = "created"
+	EventSent           EventType = "sent"
+	EventReceived       EventType = "received"
+	EventTransportError EventType = "transport_error"
+	EventCompleted      EventType = "completed"
+	EventAborted        EventType = "aborted"
+)
+
+// Error classes recorded on aborted and transport_error events.
+const (
+	ClassCanceled  = "canceled"
+	ClassTimeout   = "timeout"
+	ClassJobClosed = "job_closed"
+	ClassBitLeak   = "bit_leak"
+	ClassNotBuilt  = "not_built"
+	ClassTransport = "transport"
+	ClassProtocol  = "protocol"
+)
+
+// Event is one line of the journal. Message payloads are never written; sent
+// and received events carry the SHA-256 digest and size of the payload so
+// parties' journals can be matched against each other.
+type Event struct {
+	Time       time.Time     `json:"time"`
+	Job        string        `json:"job"`
+	Type       EventType     `json:"type"`
+	Protocol   string        `json:"protocol,omitempty"`
+	Self       cbmpc.RoleID  `json:"self"`
+	Round      int           `json:"round,omitempty"`
+	Peer       *cbmpc.RoleID `json:"peer,omitempty"`
+	Digest     string        `json:"digest,omitempty"`
+	Size       int           `json:"size,omitempty"`
+	ErrorClass string        `json:"error_class,omitempty"`
+}
+
+// Journal appends job lifecycle events to a local log, one JSON object per
+// line. A Journal is safe for concurrent use by many jobs.
+type Journal struct {
+	mu     sync.Mutex
+	w      io.Writer
+	file   *os.File // set by Open; synced after every event
+	now    func() time.Time
+	closed bool
+}
+
+// New returns a Journal writing to w. Writes are not synced; use Open for a
+// durable file-backed journal.
+func New(w io.Writer) (*Journal, error) {
+	if w == nil {
+		return nil, errors.New("nil writer")
+	}
+	return &Journal{w: w, now: time.Now}, nil
+}
+
+// Open opens or creates the journal file at path for appending. Every event
+// is synced to disk before the call that produced it returns, so the journal
+// survives a crash of the process.
+func Open(path string) (*Journal, error) {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open journal: %w", err)
+	}
+	return &Journal{w: f, file: f, now: time.Now}, nil
+}
+
+// Close closes the underlying file if the journal was created by Open. Later
+// writes fail.
+func (j *Journal) Close() error {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return nil
+	}
+	j.closed = true
+	if j.file != nil {
+		return j.file.Close()
+	}
+	return nil
+}
+
+func (j *Journal) write(e Event) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return errors.New("journal closed")
+	}
+	e.Time = j.now().UTC()
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := j.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write journal: %w", err)
+	}
+	if j.file != nil {
+		if err := j.file.Sync(); err != nil {
+			return fmt.Errorf("sync journal: %w", err)
+		}
+	}
+	return nil
+}
+
+// Begin records that a job was created and returns a transport wrapper that
+// journals every message the job exchanges. Pass the returned JobLog to the
+// job constructor in place of t and call Finish with the protocol's result.
+func (j *Journal) Begin(jobID, protocol string, self cbmpc.RoleID, t cbmpc.Transport) (*JobLog, error) {
+	if j == nil {
+		return nil, errors.New("nil journal")
+	}
+	if t == nil {
+		return nil, cbmpc.ErrNilTransport
+	}
+	if jobID == "" {
+		return nil, errors.New("empty job ID")
+	}
+	if err := j.write(Event{Job: jobID, Type: EventCreated, Protocol: protocol, Self: self}); err != nil {
+		return nil, err
+	}
+	return &JobLog{j: j, inner: t, id: jobID, self: self, round: 1}, nil
+}
+
+// JobLog is a cbmpc.Transport that journals the messages of a single job.
+//
+// Rounds are numbered locally: the job starts in round 1 and a send that
+// follows a receive starts the next round. A sent event is written before the
+// message is handed to the wrapped transport, so the journal shows what the
+// party attempted even if the process dies mid-send. A received event is
+// written once the message has arrived.
+type JobLog struct {
+	j     *Journal
+	inner cbmpc.Transport
+	id    string
+	self  cbmpc.RoleID
+
+	mu           sync.Mutex
+	round        int
+	received     bool // a receive happened since the last round change
+	transportErr bool
+	finished     bool
+}
+
+// Send journals the message and forwards it to the wrapped transport.
+func (l *JobLog) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	l.mu.Lock()
+	if l.received {
+		l.round++
+		l.received = false
+	}
+	round := l.round
+	l.mu.Unlock()
+
+	if err := l.j.write(l.message(EventSent, round, to, msg)); err != nil {
+		return err
+	}
+	if err := l.inner.Send(ctx, to, msg); err != nil {
+		l.failed(round, &to, err)
+		return err
+	}
+	return nil
+}
+
+// Receive forwards to the wrapped transport and journals the message.
+func (l *JobLog) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
+	round := l.markReceive()
+	msg, err := l.inner.Receive(ctx, from)
+	if err != nil {
+		l.failed(round, &from, err)
+		return nil, err
+	}
+	if err := l.j.write(l.message(EventReceived, round, from, msg)); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// ReceiveAll forwards to the wrapped transport and journals one received
+// event per message, in role order.
+func (l *JobLog) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	round := l.markReceive()
+	msgs, err := l.inner.ReceiveAll(ctx, from)
+	if err != nil {
+		l.failed(round, nil, err)
+		return nil, err
+	}
+	roles := make([]cbmpc.RoleID, 0, len(msgs))
+	for role := range msgs {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(a, b int) bool { return roles[a] < roles[b] })
+	for _, role := range roles {
+		if err := l.j.write(l.message(EventReceived, round, role, msgs[role])); err != nil {
+			return nil, err
+		}
+	}
+	return msgs, nil
+}
+
+// Finish records that the job completed (err == nil) or aborted, with the
+// class of err. Only the first call has an effect.
+func (l *JobLog) Finish(err error) error {
+	l.mu.Lock()
+	if l.finished {
+		l.mu.Unlock()
+		return nil
+	}
+	l.finished = true
+	round, transportErr := l.round, l.transportErr
+	l.mu.Unlock()
+
+	e := Event{Job: l.id, Type: EventCompleted, Self: l.self, Round: round}
+	if err != nil {
+		e.Type = EventAborted
+		e.ErrorClass = classify(err, transportErr)
+	}
+	return l.j.write(e)
+}
+
+func (l *JobLog) markReceive() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.received = true
+	return l.round
+}
+
+func (l *JobLog) message(t EventType, round int, peer cbmpc.RoleID, msg []byte) Event {
+	sum := sha256.Sum256(msg)
+	return Event{
+		Job:    l.id,
+		Type:   t,
+		Self:   l.self,
+		Round:  round,
+		Peer:   &peer,
+		Digest: hex.EncodeToString(sum[:]),
+		Size:   len(msg),
+	}
+}
+
+// failed records a transport failure. Journal write errors are dropped so the
+// transport error reaches the caller unchanged.
+func (l *JobLog) failed(round int, peer *cbmpc.RoleID, err error) {
+	l.mu.Lock()
+	l.transportErr = true
+	l.mu.Unlock()
+	_ = l.j.write(Event{
+		Job:        l.id,
+		Type:       EventTransportError,
+		Self:       l.self,
+		Round:      round,
+		Peer:       peer,
+		ErrorClass: classify(err, true),
+	})
+}
+
+// ClassifyError returns the error class recorded for err.
+func ClassifyError(err error) string {
+	return classify(err, false)
+}
+
+// classify maps err to an error class. The native library reports transport
+// failures as generic error codes, so sawTransportErr marks jobs whose
+// transport failed and that would otherwise be classified as protocol errors.
+func classify(err error, sawTransportErr bool) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.Canceled):
+		return ClassCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ClassTimeout
+	case errors.Is(err, cbmpc.ErrJobClosed):
+		return ClassJobClosed
+	case errors.Is(err, cbmpc.ErrBitLeak):
+		return ClassBitLeak
+	case errors.Is(err, cbmpc.ErrNotBuilt):
+		return ClassNotBuilt
+	case sawTransportErr:
+		return ClassTransport
+	default:
+		return ClassProtocol
+	}
+}
+
+var _ cbmpc.Transport = (*JobLog)(nil)
+
+// ErrTruncated is returned by Read when the journal ends in a partial line,
+// typically because the process died mid-write. The events before it are
+// still returned.
+var ErrTruncated = errors.New("journal ends in a partial line")
+
+// Read decodes every event in a journal.
+func Read(r io.Reader) ([]Event, error) {
+	br := bufio.NewReader(r)
+	var events []Event
+	for n := 1; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) != 0 {
+				return events, ErrTruncated
+			}
+			return events, nil
+		}
+		if err != nil {
+			return events, err
+		}
+		var e Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			return events, fmt.Errorf("journal line %d: %w", n, err)
+		}
+		events = append(events, e)
+	}
+}
+
+// JobState is the last known state of a job.
+type JobState string
+
+const (
+	StateRunning   JobState = "running"
+	StateCompleted JobState = "completed"
+	StateAborted   JobState = "aborted"
+)
+
+// JobSummary describes how far a job progressed according to the journal.
+type JobSummary struct {
+	Job        string
+	Protocol   string
+	Self       cbmpc.RoleID
+	State      JobState
+	Round      int // last round reached
+	Sent       int
+	Received   int
+	ErrorClass string // set for aborted jobs and jobs whose transport failed
+	Started    time.Time
+	Last       time.Time // time of the job's last event
+}
+
+// Summarize folds events into one summary per job, in order of first
+// appearance. Jobs with no completed or aborted event are StateRunning; in a
+// journal read after a crash, these are the jobs that were in flight.
+func Summarize(events []Event) []JobSummary {
+	var out []JobSummary
+	index := make(map[string]int)
+	for _, e := range events {
+		i, ok := index[e.Job]
+		if !ok {
+			i = len(out)
+			index[e.Job] = i
+			out = append(out, JobSummary{Job: e.Job, Self: e.Self, State: StateRunning, Started: e.Time})
+		}
+		s := &out[i]
+		s.Last = e.Time
+		if e.Round > s.Round {
+			s.Round = e.Round
+		}
+		switch e.Type {
+		case EventCreated:
+			s.Protocol = e.Protocol
+			s.Started = e.Time
+		case EventSent:
+			s.Sent++
+		case EventReceived:
+			s.Received++
+		case EventTransportError:
+			s.ErrorClass = e.ErrorClass
+		case EventCompleted:
+			s.State = StateCompleted
+		case EventAborted:
+			s.State = StateAborted
+			s.ErrorClass = e.ErrorClass
+		}
+	}
+	return out
+}
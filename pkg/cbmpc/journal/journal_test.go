@@ -0,0 +1,230 @@
+package journal_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/journal"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// runPingPong runs a two-round exchange between roles 0 and 1: each sends to
+// the other, then 0 sends a final message that 1 receives.
+func runPingPong(t *testing.T, j *journal.Journal) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, self := range []cbmpc.RoleID{0, 1} {
+		peer := 1 - self
+		jl, err := j.Begin(fmt.Sprintf("job-%d", self), "pingpong", self, net.Ep2P(self, peer))
+		if err != nil {
+			t.Fatalf("Begin: %v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := func() error {
+				if err := jl.Send(ctx, peer, []byte{byte(self)}); err != nil {
+					return err
+				}
+				if _, err := jl.Receive(ctx, peer); err != nil {
+					return err
+				}
+				if self == 0 {
+					return jl.Send(ctx, peer, []byte("done"))
+				}
+				_, err := jl.ReceiveAll(ctx, []cbmpc.RoleID{peer})
+				return err
+			}()
+			if ferr := jl.Finish(err); ferr != nil {
+				errs <- ferr
+			}
+			if err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestJournalRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	j, err := journal.New(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runPingPong(t, j)
+
+	events, err := journal.Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(events) != 2+3+3+2 {
+		t.Fatalf("got %d events, want 10", len(events))
+	}
+
+	sent := make(map[string]bool)
+	for _, e := range events {
+		if e.Type == journal.EventSent {
+			sent[e.Digest] = true
+		}
+	}
+	for _, e := range events {
+		if e.Type == journal.EventReceived && !sent[e.Digest] {
+			t.Errorf("received digest %s has no matching sent event", e.Digest)
+		}
+	}
+
+	sums := journal.Summarize(events)
+	if len(sums) != 2 {
+		t.Fatalf("got %d summaries, want 2", len(sums))
+	}
+	want := map[string]journal.JobSummary{
+		"job-0": {Round: 2, Sent: 2, Received: 1},
+		"job-1": {Round: 1, Sent: 1, Received: 2},
+	}
+	for _, s := range sums {
+		w := want[s.Job]
+		if s.State != journal.StateCompleted || s.Protocol != "pingpong" {
+			t.Errorf("%s: state %s protocol %q", s.Job, s.State, s.Protocol)
+		}
+		if s.Round != w.Round || s.Sent != w.Sent || s.Received != w.Received {
+			t.Errorf("%s: round %d sent %d received %d, want %d/%d/%d",
+				s.Job, s.Round, s.Sent, s.Received, w.Round, w.Sent, w.Received)
+		}
+	}
+}
+
+func TestJournalAbortClasses(t *testing.T) {
+	var buf bytes.Buffer
+	j, _ := journal.New(&buf)
+	net := mocknet.New()
+
+	// A receive that times out marks the job's abort as a transport failure.
+	jl, err := j.Begin("stuck", "p", 0, net.Ep2P(0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := jl.Receive(ctx, 1); err == nil {
+		t.Fatal("expected receive to fail")
+	}
+	_ = jl.Finish(errors.New("native error"))
+
+	// A protocol error without a transport failure.
+	jl2, _ := j.Begin("bad", "p", 0, net.Ep2P(0, 1))
+	_ = jl2.Finish(errors.New("verification failed"))
+	_ = jl2.Finish(nil) // ignored
+
+	// A job that never finished.
+	if _, err := j.Begin("inflight", "p", 0, net.Ep2P(0, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := journal.Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]journal.JobSummary)
+	for _, s := range journal.Summarize(events) {
+		got[s.Job] = s
+	}
+	if s := got["stuck"]; s.State != journal.StateAborted || s.ErrorClass != journal.ClassTransport {
+		t.Errorf("stuck: %s/%s", s.State, s.ErrorClass)
+	}
+	if s := got["bad"]; s.State != journal.StateAborted || s.ErrorClass != journal.ClassProtocol {
+		t.Errorf("bad: %s/%s", s.State, s.ErrorClass)
+	}
+	if s := got["inflight"]; s.State != journal.StateRunning {
+		t.Errorf("inflight: %s", s.State)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	cases := map[error]string{
+		nil:                      "",
+		context.Canceled:         journal.ClassCanceled,
+		context.DeadlineExceeded: journal.ClassTimeout,
+		cbmpc.ErrJobClosed:       journal.ClassJobClosed,
+		cbmpc.ErrBitLeak:         journal.ClassBitLeak,
+		cbmpc.ErrNotBuilt:        journal.ClassNotBuilt,
+		errors.New("other"):      journal.ClassProtocol,
+	}
+	for err, want := range cases {
+		if got := journal.ClassifyError(fmt.Errorf("wrapped: %w", err)); err != nil && got != want {
+			t.Errorf("ClassifyError(%v) = %q, want %q", err, got, want)
+		}
+	}
+	if journal.ClassifyError(nil) != "" {
+		t.Error("ClassifyError(nil) should be empty")
+	}
+}
+
+func TestOpenAppendsAndReadTolerantOfTornLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	for i := 0; i < 2; i++ {
+		j, err := journal.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jl, err := j.Begin(fmt.Sprintf("job-%d", i), "p", 0, mocknet.New().Ep2P(0, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := jl.Finish(nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := j.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := journal.Read(bytes.NewReader(data))
+	if err != nil || len(events) != 4 {
+		t.Fatalf("Read: %d events, err %v", len(events), err)
+	}
+
+	torn := append(data, []byte(`{"job":"job-2","ty`)...)
+	events, err = journal.Read(bytes.NewReader(torn))
+	if !errors.Is(err, journal.ErrTruncated) || len(events) != 4 {
+		t.Fatalf("torn Read: %d events, err %v", len(events), err)
+	}
+}
+
+func TestBeginValidation(t *testing.T) {
+	j, _ := journal.New(&bytes.Buffer{})
+	if _, err := j.Begin("x", "p", 0, nil); !errors.Is(err, cbmpc.ErrNilTransport) {
+		t.Errorf("nil transport: %v", err)
+	}
+	if _, err := j.Begin("", "p", 0, mocknet.New().Ep2P(0, 1)); err == nil {
+		t.Error("empty job ID should fail")
+	}
+	if _, err := journal.New(nil); err == nil {
+		t.Error("nil writer should fail")
+	}
+	_ = j.Close()
+	if _, err := j.Begin("x", "p", 0, mocknet.New().Ep2P(0, 1)); err == nil {
+		t.Error("Begin after Close should fail")
+	}
+}
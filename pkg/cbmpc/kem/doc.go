@@ -22,6 +22,9 @@
 //
 // Currently supported:
 //   - rsa: Deterministic RSA-OAEP (2048/3072/4096-bit)
+//   - ecies: Deterministic ECIES over P-256 or secp256k1, with 65-byte keys
+//     and ciphertexts
+//   - hybrid: Combiner of two KEMs, secure while either component is
 //
 // # Why Determinism?
 //
@@ -67,5 +70,18 @@
 //	pveInstance, _ := pve.New(kem)
 //	// ... use pveInstance for Encrypt/Verify/Decrypt
 //
+// # Validating Keys
+//
+// Before encrypting to a key received from someone else, check it with
+// ValidateEK. It rejects malformed keys, key types no KEM here accepts, and
+// weak RSA keys (small moduli, small or even exponents), so that no PVE
+// ciphertext is anchored to them. Fingerprint gives the hex SHA-256 to match
+// keys against an inventory:
+//
+//	if err := kem.ValidateEK(ek); err != nil {
+//	    return err // errors.Is(err, kem.ErrWeakEK) etc.
+//	}
+//	log.Printf("backing up to key %s", kem.Fingerprint(ek))
+//
 // See pkg/cbmpc/kem/README.md for detailed security documentation.
 package kem
@@ -0,0 +1,22 @@
+// Package ecies provides a DETERMINISTIC ECIES-style KEM for PVE over P-256
+// or secp256k1.
+//
+// It is a lighter-weight alternative to the RSA KEM in pkg/cbmpc/kem/rsa:
+// public keys and ciphertexts are 65-byte uncompressed points instead of
+// several hundred bytes, and encapsulation costs two scalar multiplications
+// instead of an RSA operation, which shrinks PVE ciphertexts and speeds up
+// encryption and verification.
+//
+// As with every KEM in pkg/cbmpc/kem, the ephemeral key is derived from the
+// caller's seed rho rather than drawn at random, which PVE requires and which
+// makes the KEM unsafe for general-purpose encryption.
+//
+//	kem, _ := ecies.New(cbmpc.CurveSecp256k1)
+//	skRef, ek, _ := kem.Generate()
+//	dk, _ := kem.NewPrivateKeyHandle(skRef)
+//	defer kem.FreePrivateKeyHandle(dk)
+//	pveInstance, _ := pve.New(kem)
+//
+// The KEM's curve is independent of the curve of the scalars PVE encrypts.
+// See pkg/cbmpc/kem/README.md for the construction.
+package ecies
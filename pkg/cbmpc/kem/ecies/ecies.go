@@ -0,0 +1,456 @@
+package ecies
+
+import (
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
+
+// Typed errors for handle and input validation.
+var (
+	// ErrInvalidHandleType indicates the handle is not an ECIES private key handle.
+	ErrInvalidHandleType = errors.New("invalid handle type: expected ECIES private key handle")
+
+	// ErrAlgorithmMismatch indicates the handle is for a different curve than this KEM.
+	ErrAlgorithmMismatch = errors.New("algorithm mismatch: handle is for a different ECIES curve")
+
+	// ErrPublicKeyHashMismatch indicates the public key hash doesn't match.
+	ErrPublicKeyHashMismatch = errors.New("public key hash mismatch")
+
+	// ErrUnsupportedCurve indicates New was given a curve other than P-256 or secp256k1.
+	ErrUnsupportedCurve = errors.New("unsupported curve: ECIES KEM supports P-256 and secp256k1")
+
+	// ErrInvalidPoint indicates a public key or ciphertext is not an
+	// uncompressed SEC1 point on the KEM's curve.
+	ErrInvalidPoint = errors.New("invalid point: expected uncompressed SEC1 encoding on the KEM curve")
+)
+
+const (
+	// ScalarSize is the size in bytes of a private key reference.
+	ScalarSize = 32
+
+	// PointSize is the size in bytes of a public key and of a ciphertext: an
+	// uncompressed SEC1 point.
+	PointSize = 65
+
+	// SharedSecretSize is the size in bytes of the shared secret.
+	SharedSecretSize = 32
+)
+
+// KEM is a DETERMINISTIC ECIES-style KEM for PVE (Publicly Verifiable
+// Encryption) over P-256 or secp256k1.
+//
+// SECURITY WARNING: This is NOT a general-purpose randomized KEM!
+//
+// Like the RSA KEM, it replaces the ephemeral randomness of ECIES with a
+// deterministic derivation from the seed rho, which is REQUIRED for PVE's
+// verifiability and UNSUITABLE for general public-key encryption.
+//
+// Construction, with H = SHA-256 and ekHash = H(ek):
+//   - Ephemeral scalar e: HKDF(rho || ekHash) expanded with a counter and
+//     rejection-sampled into [1, n-1]
+//   - Ciphertext: E = e*G, uncompressed (65 bytes)
+//   - Shared secret: HKDF(x(e*P)) with a per-curve salt and info E || ek
+//
+// Key security properties:
+//   - DETERMINISTIC: Same (ek, rho) always produces the same ciphertext
+//   - DOMAIN-SEPARATED: Different keys with same rho produce different ciphertexts
+//   - KEY-BOUND: The shared secret is derived over both E and ek
+//
+// Compared with RSA-3072, keys and ciphertexts are 65 bytes instead of 384
+// and encapsulation needs one fixed-base and one variable-base scalar
+// multiplication. Security rests on the elliptic-curve Diffie-Hellman
+// problem on the chosen curve.
+//
+// DO NOT use this for general-purpose encryption! Only use within PVE protocol context.
+type KEM struct {
+	curve cbmpc.Curve
+	ops   curveOps
+	// boundEKHash optionally binds this KEM instance to a specific public key
+	// (by SHA-256 hash) for additional misuse resistance during decapsulation.
+	boundEKHash    [32]byte
+	hasBoundEKHash bool
+}
+
+// New creates a new DETERMINISTIC ECIES KEM for PVE over c, which must be
+// cbmpc.CurveP256 or cbmpc.CurveSecp256k1.
+func New(c cbmpc.Curve) (*KEM, error) {
+	var ops curveOps
+	switch c {
+	case cbmpc.CurveP256:
+		ops = p256Ops{}
+	case cbmpc.CurveSecp256k1:
+		ops = secp256k1Ops{}
+	default:
+		return nil, ErrUnsupportedCurve
+	}
+	return &KEM{curve: c, ops: ops}, nil
+}
+
+// Curve returns the curve of the KEM.
+func (k *KEM) Curve() cbmpc.Curve { return k.curve }
+
+// BindPublicKeyHash restricts Decapsulate to use only a handle whose public key
+// hash matches the provided 32-byte SHA-256 hash. Passing an incorrectly sized
+// hash is ignored (no binding applied).
+func (k *KEM) BindPublicKeyHash(h []byte) {
+	if len(h) != 32 {
+		return
+	}
+	copy(k.boundEKHash[:], h)
+	k.hasBoundEKHash = true
+}
+
+// BindPublicKey restricts Decapsulate to a specific public key by hashing it
+// with SHA-256 and calling BindPublicKeyHash.
+func (k *KEM) BindPublicKey(ek []byte) {
+	k.boundEKHash = sha256.Sum256(ek)
+	k.hasBoundEKHash = true
+}
+
+// privateKeyHandle represents a handle to an ECIES private key.
+// The scalar is held in a secmem.Buffer and is wiped on free.
+type privateKeyHandle struct {
+	mu          sync.RWMutex
+	algorithmID string         // e.g. "ecies-p256"
+	pubKeyHash  [32]byte       // SHA-256 hash of public key
+	key         *secmem.Buffer // 32-byte big-endian scalar
+	publicKey   []byte         // uncompressed SEC1 point
+}
+
+// Generate generates a new key pair.
+// Returns:
+//   - skRef: Private key reference (32-byte big-endian scalar)
+//   - ek: Public key (uncompressed SEC1 point)
+//   - err: Any error that occurred
+//
+// In strict mode (secmem.SetStrict) Generate fails with secmem.ErrUnprotected;
+// use GenerateProtected.
+func (k *KEM) Generate() (skRef []byte, ek []byte, err error) {
+	if secmem.Strict() {
+		return nil, nil, secmem.ErrUnprotected
+	}
+	return k.generate()
+}
+
+// GenerateProtected is like Generate but returns the private key reference in
+// a secmem.Buffer. The caller must Destroy it when done.
+func (k *KEM) GenerateProtected() (skRef *secmem.Buffer, ek []byte, err error) {
+	sk, ek, err := k.generate()
+	if err != nil {
+		return nil, nil, err
+	}
+	skRef, err = secmem.Move(sk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return skRef, ek, nil
+}
+
+func (k *KEM) generate() (skRef []byte, ek []byte, err error) {
+	sk := make([]byte, ScalarSize)
+	for {
+		if _, err := rand.Read(sk); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate ECIES key: %w", err)
+		}
+		ek, err = k.ops.publicKey(sk)
+		if err == nil {
+			return sk, ek, nil
+		}
+	}
+}
+
+// Encapsulate generates a ciphertext and shared secret for the given public key.
+//
+// Parameters:
+//   - ek: Public key as an uncompressed SEC1 point
+//   - rho: 32-byte seed for deterministic encapsulation
+//
+// Returns:
+//   - ct: Ciphertext (the ephemeral point E, 65 bytes)
+//   - ss: Shared secret (32 bytes)
+//   - err: Any error that occurred
+func (k *KEM) Encapsulate(ek []byte, rho [32]byte) (ct, ss []byte, err error) {
+	if err := k.ops.checkPoint(ek); err != nil {
+		return nil, nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	ekHash := sha256.Sum256(ek)
+
+	e, err := k.ephemeral(rho, ekHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer secmem.Zero(e)
+
+	ct, err = k.ops.publicKey(e)
+	if err != nil {
+		return nil, nil, err
+	}
+	z, err := k.ops.sharedX(e, ek)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer secmem.Zero(z)
+
+	ss, err = k.deriveSecret(z, ct, ek)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ct, ss, nil
+}
+
+// ephemeral derives the ephemeral scalar from rho and the public key hash by
+// rejection sampling, so that the same rho with different keys yields
+// unrelated scalars.
+func (k *KEM) ephemeral(rho [32]byte, ekHash [32]byte) ([]byte, error) {
+	ikm := make([]byte, 0, 64)
+	ikm = append(ikm, rho[:]...)
+	ikm = append(ikm, ekHash[:]...)
+	defer secmem.Zero(ikm)
+
+	prk, err := hkdf.Extract(sha256.New, ikm, []byte("cbmpc-pve-ecies-hkdf"))
+	if err != nil {
+		return nil, err
+	}
+	defer secmem.Zero(prk)
+
+	info := []byte("cbmpc-pve-ecies:" + k.ops.name() + ":ephemeral:\x00")
+	for ctr := 0; ctr < 256; ctr++ {
+		info[len(info)-1] = byte(ctr)
+		e, err := hkdf.Expand(sha256.New, prk, string(info), ScalarSize)
+		if err != nil {
+			return nil, err
+		}
+		if k.ops.validScalar(e) {
+			return e, nil
+		}
+		secmem.Zero(e)
+	}
+	// Each candidate is rejected with probability below 2^-32.
+	return nil, errors.New("failed to derive ephemeral scalar")
+}
+
+func (k *KEM) deriveSecret(z, ct, ek []byte) ([]byte, error) {
+	info := make([]byte, 0, len(ct)+len(ek))
+	info = append(info, ct...)
+	info = append(info, ek...)
+	return hkdf.Key(sha256.New, z, []byte("cbmpc/pve/ecies:"+k.ops.name()), string(info), SharedSecretSize)
+}
+
+// Decapsulate recovers the shared secret from a ciphertext using the private key.
+//
+// Parameters:
+//   - skHandle: Private key handle from NewPrivateKeyHandle
+//   - ct: Ciphertext to decrypt
+//
+// Returns:
+//   - ss: Shared secret (32 bytes)
+//   - err: Any error that occurred
+func (k *KEM) Decapsulate(skHandle any, ct []byte) (ss []byte, err error) {
+	handle, ok := skHandle.(*privateKeyHandle)
+	if !ok {
+		return nil, ErrInvalidHandleType
+	}
+
+	handle.mu.RLock()
+	algorithmID := handle.algorithmID
+	pubKeyHash := handle.pubKeyHash
+	if handle.key == nil {
+		handle.mu.RUnlock()
+		return nil, errors.New("private key handle has been freed")
+	}
+	sk := make([]byte, handle.key.Len())
+	copy(sk, handle.key.Bytes())
+	publicKey := append([]byte(nil), handle.publicKey...)
+	handle.mu.RUnlock()
+	defer secmem.Zero(sk)
+
+	if algorithmID != k.algorithmID() {
+		return nil, fmt.Errorf("%w: got %q, want %q", ErrAlgorithmMismatch, algorithmID, k.algorithmID())
+	}
+	if sha256.Sum256(publicKey) != pubKeyHash {
+		return nil, ErrPublicKeyHashMismatch
+	}
+	if k.hasBoundEKHash && pubKeyHash != k.boundEKHash {
+		return nil, ErrPublicKeyHashMismatch
+	}
+
+	if err := k.ops.checkPoint(ct); err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	z, err := k.ops.sharedX(sk, ct)
+	if err != nil {
+		return nil, fmt.Errorf("ECIES decapsulation failed: %w", err)
+	}
+	defer secmem.Zero(z)
+	return k.deriveSecret(z, ct, publicKey)
+}
+
+// DerivePub derives the public key from a private key reference.
+//
+// Parameters:
+//   - skRef: Private key reference (32-byte big-endian scalar)
+//
+// Returns:
+//   - Public key as an uncompressed SEC1 point
+//   - Any error that occurred
+func (k *KEM) DerivePub(skRef []byte) ([]byte, error) {
+	return k.ops.publicKey(skRef)
+}
+
+// NewPrivateKeyHandle creates a handle to a private key.
+// The scalar is held in a secmem.Buffer and wiped on free.
+func (k *KEM) NewPrivateKeyHandle(skRef []byte) (any, error) {
+	publicKey, err := k.ops.publicKey(skRef)
+	if err != nil {
+		return nil, err
+	}
+	key, err := secmem.Copy(skRef)
+	if err != nil {
+		return nil, err
+	}
+	return &privateKeyHandle{
+		algorithmID: k.algorithmID(),
+		pubKeyHash:  sha256.Sum256(publicKey),
+		key:         key,
+		publicKey:   publicKey,
+	}, nil
+}
+
+// FreePrivateKeyHandle securely frees a private key handle.
+// This zeroizes the private key material from memory.
+func (k *KEM) FreePrivateKeyHandle(handle any) error {
+	h, ok := handle.(*privateKeyHandle)
+	if !ok {
+		return ErrInvalidHandleType
+	}
+	h.mu.Lock()
+	if h.key != nil {
+		h.key.Destroy()
+	}
+	h.key = nil
+	h.publicKey = nil
+	h.mu.Unlock()
+	return nil
+}
+
+func (k *KEM) algorithmID() string { return "ecies-" + k.ops.name() }
+
+// curveOps is the elliptic-curve arithmetic the KEM needs. Scalars are
+// 32-byte big-endian and points uncompressed SEC1.
+type curveOps interface {
+	name() string
+	validScalar(s []byte) bool
+	checkPoint(p []byte) error
+	publicKey(s []byte) ([]byte, error)
+	sharedX(s, p []byte) ([]byte, error)
+}
+
+type p256Ops struct{}
+
+func (p256Ops) name() string { return "p256" }
+
+func (p256Ops) validScalar(s []byte) bool {
+	_, err := ecdh.P256().NewPrivateKey(s)
+	return err == nil
+}
+
+func (p256Ops) checkPoint(p []byte) error {
+	if _, err := ecdh.P256().NewPublicKey(p); err != nil {
+		return ErrInvalidPoint
+	}
+	return nil
+}
+
+func (p256Ops) publicKey(s []byte) ([]byte, error) {
+	sk, err := ecdh.P256().NewPrivateKey(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid P-256 private key: %w", err)
+	}
+	return sk.PublicKey().Bytes(), nil
+}
+
+func (p256Ops) sharedX(s, p []byte) ([]byte, error) {
+	sk, err := ecdh.P256().NewPrivateKey(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid P-256 private key: %w", err)
+	}
+	pk, err := ecdh.P256().NewPublicKey(p)
+	if err != nil {
+		return nil, ErrInvalidPoint
+	}
+	return sk.ECDH(pk)
+}
+
+type secp256k1Ops struct{}
+
+func (secp256k1Ops) name() string { return "secp256k1" }
+
+func (secp256k1Ops) scalar(s []byte) (*btcec.ModNScalar, bool) {
+	if len(s) != ScalarSize {
+		return nil, false
+	}
+	var x btcec.ModNScalar
+	if overflow := x.SetByteSlice(s); overflow || x.IsZero() {
+		x.Zero()
+		return nil, false
+	}
+	return &x, true
+}
+
+func (o secp256k1Ops) validScalar(s []byte) bool {
+	x, ok := o.scalar(s)
+	if ok {
+		x.Zero()
+	}
+	return ok
+}
+
+func (secp256k1Ops) point(p []byte) (*btcec.PublicKey, error) {
+	if len(p) != PointSize || p[0] != 0x04 {
+		return nil, ErrInvalidPoint
+	}
+	pk, err := btcec.ParsePubKey(p)
+	if err != nil {
+		return nil, ErrInvalidPoint
+	}
+	return pk, nil
+}
+
+func (o secp256k1Ops) checkPoint(p []byte) error {
+	_, err := o.point(p)
+	return err
+}
+
+func (o secp256k1Ops) publicKey(s []byte) ([]byte, error) {
+	x, ok := o.scalar(s)
+	if !ok {
+		return nil, errors.New("invalid secp256k1 private key")
+	}
+	sk := btcec.PrivKeyFromScalar(x)
+	defer sk.Zero()
+	return sk.PubKey().SerializeUncompressed(), nil
+}
+
+func (o secp256k1Ops) sharedX(s, p []byte) ([]byte, error) {
+	x, ok := o.scalar(s)
+	if !ok {
+		return nil, errors.New("invalid secp256k1 private key")
+	}
+	sk := btcec.PrivKeyFromScalar(x)
+	defer sk.Zero()
+	pk, err := o.point(p)
+	if err != nil {
+		return nil, err
+	}
+	return btcec.GenerateSharedSecret(sk, pk), nil
+}
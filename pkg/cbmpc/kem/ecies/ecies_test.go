@@ -0,0 +1,203 @@
+package ecies_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/ecies"
+)
+
+var curves = []struct {
+	name  string
+	curve cbmpc.Curve
+}{
+	{"P256", cbmpc.CurveP256},
+	{"secp256k1", cbmpc.CurveSecp256k1},
+}
+
+func newKeyPair(t *testing.T, kem *ecies.KEM) (handle any, ek []byte) {
+	t.Helper()
+	skRef, ek, err := kem.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	handle, err = kem.NewPrivateKeyHandle(skRef)
+	if err != nil {
+		t.Fatalf("NewPrivateKeyHandle failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := kem.FreePrivateKeyHandle(handle); err != nil {
+			t.Errorf("FreePrivateKeyHandle failed: %v", err)
+		}
+	})
+	return handle, ek
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, c := range curves {
+		t.Run(c.name, func(t *testing.T) {
+			kem, err := ecies.New(c.curve)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			handle, ek := newKeyPair(t, kem)
+			if len(ek) != ecies.PointSize {
+				t.Fatalf("public key is %d bytes, want %d", len(ek), ecies.PointSize)
+			}
+
+			var rho [32]byte
+			copy(rho[:], "test-rho-12345678901234567890123")
+			ct, ss, err := kem.Encapsulate(ek, rho)
+			if err != nil {
+				t.Fatalf("Encapsulate failed: %v", err)
+			}
+			if len(ct) != ecies.PointSize || len(ss) != ecies.SharedSecretSize {
+				t.Fatalf("ct is %d bytes and ss %d bytes", len(ct), len(ss))
+			}
+			got, err := kem.Decapsulate(handle, ct)
+			if err != nil {
+				t.Fatalf("Decapsulate failed: %v", err)
+			}
+			if !bytes.Equal(got, ss) {
+				t.Fatal("decapsulated shared secret does not match")
+			}
+		})
+	}
+}
+
+func TestDeterminism(t *testing.T) {
+	for _, c := range curves {
+		t.Run(c.name, func(t *testing.T) {
+			kem, err := ecies.New(c.curve)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			_, ek1 := newKeyPair(t, kem)
+			_, ek2 := newKeyPair(t, kem)
+
+			var rho, rho2 [32]byte
+			copy(rho[:], "deterministic-seed-1234567890123")
+			copy(rho2[:], "deterministic-seed-1234567890124")
+			ct1, ss1, _ := kem.Encapsulate(ek1, rho)
+			ct2, ss2, _ := kem.Encapsulate(ek1, rho)
+			if !bytes.Equal(ct1, ct2) || !bytes.Equal(ss1, ss2) {
+				t.Fatal("same (ek, rho) produced different outputs")
+			}
+			if ct3, _, _ := kem.Encapsulate(ek1, rho2); bytes.Equal(ct1, ct3) {
+				t.Fatal("different rho produced the same ciphertext")
+			}
+			if ct4, _, _ := kem.Encapsulate(ek2, rho); bytes.Equal(ct1, ct4) {
+				t.Fatal("different keys with the same rho produced the same ciphertext")
+			}
+		})
+	}
+}
+
+func TestDerivePub(t *testing.T) {
+	for _, c := range curves {
+		t.Run(c.name, func(t *testing.T) {
+			kem, err := ecies.New(c.curve)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			skRef, ek, err := kem.Generate()
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			got, err := kem.DerivePub(skRef)
+			if err != nil {
+				t.Fatalf("DerivePub failed: %v", err)
+			}
+			if !bytes.Equal(got, ek) {
+				t.Fatal("DerivePub does not match the generated public key")
+			}
+			if _, err := kem.DerivePub(make([]byte, ecies.ScalarSize)); err == nil {
+				t.Fatal("DerivePub accepted a zero scalar")
+			}
+		})
+	}
+}
+
+func TestValidation(t *testing.T) {
+	p256, err := ecies.New(cbmpc.CurveP256)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	k1, err := ecies.New(cbmpc.CurveSecp256k1)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, err := ecies.New(cbmpc.CurveEd25519); !errors.Is(err, ecies.ErrUnsupportedCurve) {
+		t.Fatalf("New(Ed25519) error = %v, want ErrUnsupportedCurve", err)
+	}
+
+	p256Handle, p256EK := newKeyPair(t, p256)
+	_, k1EK := newKeyPair(t, k1)
+	var rho [32]byte
+
+	t.Run("public key on the wrong curve", func(t *testing.T) {
+		if _, _, err := p256.Encapsulate(k1EK, rho); !errors.Is(err, ecies.ErrInvalidPoint) {
+			t.Fatalf("Encapsulate error = %v, want ErrInvalidPoint", err)
+		}
+	})
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		ct, _, err := p256.Encapsulate(p256EK, rho)
+		if err != nil {
+			t.Fatalf("Encapsulate failed: %v", err)
+		}
+		ct[len(ct)-1] ^= 1
+		if _, err := p256.Decapsulate(p256Handle, ct); !errors.Is(err, ecies.ErrInvalidPoint) {
+			t.Fatalf("Decapsulate error = %v, want ErrInvalidPoint", err)
+		}
+	})
+
+	t.Run("handle for another curve", func(t *testing.T) {
+		ct, _, err := k1.Encapsulate(k1EK, rho)
+		if err != nil {
+			t.Fatalf("Encapsulate failed: %v", err)
+		}
+		if _, err := k1.Decapsulate(p256Handle, ct); !errors.Is(err, ecies.ErrAlgorithmMismatch) {
+			t.Fatalf("Decapsulate error = %v, want ErrAlgorithmMismatch", err)
+		}
+	})
+
+	t.Run("invalid handle type", func(t *testing.T) {
+		if _, err := p256.Decapsulate("not a handle", nil); !errors.Is(err, ecies.ErrInvalidHandleType) {
+			t.Fatalf("Decapsulate error = %v, want ErrInvalidHandleType", err)
+		}
+	})
+
+	t.Run("bound public key", func(t *testing.T) {
+		bound, _ := ecies.New(cbmpc.CurveP256)
+		bound.BindPublicKey(k1EK)
+		ct, _, err := p256.Encapsulate(p256EK, rho)
+		if err != nil {
+			t.Fatalf("Encapsulate failed: %v", err)
+		}
+		if _, err := bound.Decapsulate(p256Handle, ct); !errors.Is(err, ecies.ErrPublicKeyHashMismatch) {
+			t.Fatalf("Decapsulate error = %v, want ErrPublicKeyHashMismatch", err)
+		}
+	})
+}
+
+func BenchmarkEncapsulate(b *testing.B) {
+	for _, c := range curves {
+		b.Run(c.name, func(b *testing.B) {
+			kem, _ := ecies.New(c.curve)
+			_, ek, err := kem.Generate()
+			if err != nil {
+				b.Fatalf("Generate failed: %v", err)
+			}
+			var rho [32]byte
+			for i := 0; i < b.N; i++ {
+				rho[0] = byte(i)
+				if _, _, err := kem.Encapsulate(ek, rho); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+//go:build cgo && !windows
+
+package ecies_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/ecies"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+)
+
+// TestPVERoundTrip tests that the ECIES KEM works as the KEM of PVE.
+func TestPVERoundTrip(t *testing.T) {
+	for _, c := range curves {
+		t.Run(c.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			kem, err := ecies.New(c.curve)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			handle, ek := newKeyPair(t, kem)
+			pveInstance, err := pve.New(kem)
+			if err != nil {
+				t.Fatalf("Failed to create PVE instance: %v", err)
+			}
+
+			x, err := curve.RandomScalar(c.curve)
+			if err != nil {
+				t.Fatalf("Failed to create scalar: %v", err)
+			}
+			defer x.Free()
+
+			label := []byte("ecies-pve")
+			enc, err := pveInstance.Encrypt(ctx, &pve.EncryptParams{EK: ek, Label: label, Curve: c.curve, X: x})
+			if err != nil {
+				t.Fatalf("Encrypt failed: %v", err)
+			}
+			q, err := enc.Ciphertext.Q()
+			if err != nil {
+				t.Fatalf("Q failed: %v", err)
+			}
+			defer q.Free()
+			if err := pveInstance.Verify(ctx, &pve.VerifyParams{EK: ek, Ciphertext: enc.Ciphertext, Q: q, Label: label}); err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+
+			dec, err := pveInstance.Decrypt(ctx, &pve.DecryptParams{DK: handle, EK: ek, Ciphertext: enc.Ciphertext, Label: label, Curve: c.curve})
+			if err != nil {
+				t.Fatalf("Decrypt failed: %v", err)
+			}
+			defer dec.X.Free()
+			if !dec.X.Equal(x) {
+				t.Fatal("decrypted scalar does not match")
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+// Package hybrid combines two deterministic KEMs into one for PVE, so that
+// backups stay confidential as long as either primitive holds.
+//
+// The intended use is pairing a classical KEM with a post-quantum one, so
+// that long-lived backups survive a future break of RSA or elliptic-curve
+// cryptography, and equally a flaw in the newer scheme. Any Component works,
+// including a KEM backed by an HSM:
+//
+//	k, _ := hybrid.New(rsaKEM, pqKEM)
+//	skRef, ek, _ := k.Generate()
+//	dk, _ := k.NewPrivateKeyHandle(skRef)
+//	defer k.FreePrivateKeyHandle(dk)
+//	pveInstance, _ := pve.New(k)
+//
+// Each component gets its own seed derived from rho, and the shared secrets
+// are combined with a KDF over both secrets, ciphertexts and public keys; see
+// KEM for the construction. Post-quantum KEMs must, like every KEM used with
+// PVE, encapsulate deterministically from the seed they are given; the
+// standard library's crypto/mlkem does not expose that, so a post-quantum
+// component has to come from an implementation that does.
+package hybrid
@@ -0,0 +1,254 @@
+package hybrid
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
+
+var (
+	// ErrInvalidHandleType indicates the handle is not a hybrid private key handle.
+	ErrInvalidHandleType = errors.New("invalid handle type: expected hybrid private key handle")
+
+	// ErrMalformed indicates a public key, private key reference or ciphertext
+	// is not a valid encoding of two component values.
+	ErrMalformed = errors.New("malformed hybrid encoding")
+)
+
+// SharedSecretSize is the size in bytes of the combined shared secret.
+const SharedSecretSize = 32
+
+// Component is a deterministic KEM that manages its own keys, such as
+// *rsa.KEM or *ecies.KEM.
+type Component interface {
+	kem.KEM
+	Generate() (skRef []byte, ek []byte, err error)
+	NewPrivateKeyHandle(skRef []byte) (any, error)
+	FreePrivateKeyHandle(handle any) error
+}
+
+// KEM combines two deterministic KEMs so that the combined shared secret
+// stays secret as long as either component is secure, for example a
+// classical KEM with a post-quantum one.
+//
+// Public keys, private key references and ciphertexts are the two
+// components' values, each prefixed with its 4-byte big-endian length. The
+// combined shared secret follows the KDF combiner construction, hashing both
+// shared secrets together with both ciphertexts and both public keys:
+//
+//	rho_i = HMAC-SHA256(rho, "cbmpc/pve/hybrid:" || i)
+//	ss    = HKDF-SHA256(ss_1 || ss_2, salt = "cbmpc/pve/hybrid-kem",
+//	                    info = ct_1 || ct_2 || ek_1 || ek_2)
+//
+// where the info fields are length-prefixed. Binding the ciphertexts and keys
+// keeps the combination secure against chosen-ciphertext attacks when only
+// one component is.
+//
+// Like its components, KEM is DETERMINISTIC and only safe within PVE.
+type KEM struct {
+	first, second Component
+}
+
+// New returns a KEM combining first and second.
+func New(first, second Component) (*KEM, error) {
+	if first == nil || second == nil {
+		return nil, errors.New("hybrid: nil component KEM")
+	}
+	return &KEM{first: first, second: second}, nil
+}
+
+// privateKeyHandle holds the components' handles and public keys.
+type privateKeyHandle struct {
+	first, second     any
+	firstEK, secondEK []byte
+}
+
+// Generate generates a key pair for each component.
+// Returns:
+//   - skRef: Both components' private key references
+//   - ek: Both components' public keys
+//   - err: Any error that occurred
+func (k *KEM) Generate() (skRef []byte, ek []byte, err error) {
+	sk1, ek1, err := k.first.Generate()
+	if err != nil {
+		return nil, nil, fmt.Errorf("hybrid: first component: %w", err)
+	}
+	defer secmem.Zero(sk1)
+	sk2, ek2, err := k.second.Generate()
+	if err != nil {
+		return nil, nil, fmt.Errorf("hybrid: second component: %w", err)
+	}
+	defer secmem.Zero(sk2)
+	return join(sk1, sk2), join(ek1, ek2), nil
+}
+
+// Encapsulate encapsulates to both components with seeds derived from rho
+// and combines the shared secrets.
+//
+// Returns:
+//   - ct: Both components' ciphertexts
+//   - ss: Combined shared secret (32 bytes)
+//   - err: Any error that occurred
+func (k *KEM) Encapsulate(ek []byte, rho [32]byte) (ct, ss []byte, err error) {
+	ek1, ek2, err := split(ek)
+	if err != nil {
+		return nil, nil, err
+	}
+	rho1, rho2 := deriveRho(rho, 1), deriveRho(rho, 2)
+	defer secmem.Zero(rho1[:])
+	defer secmem.Zero(rho2[:])
+
+	ct1, ss1, err := k.first.Encapsulate(ek1, rho1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("hybrid: first component: %w", err)
+	}
+	defer secmem.Zero(ss1)
+	ct2, ss2, err := k.second.Encapsulate(ek2, rho2)
+	if err != nil {
+		return nil, nil, fmt.Errorf("hybrid: second component: %w", err)
+	}
+	defer secmem.Zero(ss2)
+
+	ss, err = combine(ss1, ss2, ct1, ct2, ek1, ek2)
+	if err != nil {
+		return nil, nil, err
+	}
+	return join(ct1, ct2), ss, nil
+}
+
+// Decapsulate decapsulates both components and combines the shared secrets.
+// It fails if either component fails.
+func (k *KEM) Decapsulate(skHandle any, ct []byte) (ss []byte, err error) {
+	h, ok := skHandle.(*privateKeyHandle)
+	if !ok {
+		return nil, ErrInvalidHandleType
+	}
+	ct1, ct2, err := split(ct)
+	if err != nil {
+		return nil, err
+	}
+	ss1, err := k.first.Decapsulate(h.first, ct1)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid: first component: %w", err)
+	}
+	defer secmem.Zero(ss1)
+	ss2, err := k.second.Decapsulate(h.second, ct2)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid: second component: %w", err)
+	}
+	defer secmem.Zero(ss2)
+	return combine(ss1, ss2, ct1, ct2, h.firstEK, h.secondEK)
+}
+
+// DerivePub derives both components' public keys from a hybrid private key
+// reference.
+func (k *KEM) DerivePub(skRef []byte) ([]byte, error) {
+	sk1, sk2, err := split(skRef)
+	if err != nil {
+		return nil, err
+	}
+	ek1, err := k.first.DerivePub(sk1)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid: first component: %w", err)
+	}
+	ek2, err := k.second.DerivePub(sk2)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid: second component: %w", err)
+	}
+	return join(ek1, ek2), nil
+}
+
+// NewPrivateKeyHandle creates a handle holding a handle for each component.
+func (k *KEM) NewPrivateKeyHandle(skRef []byte) (any, error) {
+	sk1, sk2, err := split(skRef)
+	if err != nil {
+		return nil, err
+	}
+	ek1, err := k.first.DerivePub(sk1)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid: first component: %w", err)
+	}
+	ek2, err := k.second.DerivePub(sk2)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid: second component: %w", err)
+	}
+	h1, err := k.first.NewPrivateKeyHandle(sk1)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid: first component: %w", err)
+	}
+	h2, err := k.second.NewPrivateKeyHandle(sk2)
+	if err != nil {
+		_ = k.first.FreePrivateKeyHandle(h1)
+		return nil, fmt.Errorf("hybrid: second component: %w", err)
+	}
+	return &privateKeyHandle{first: h1, second: h2, firstEK: ek1, secondEK: ek2}, nil
+}
+
+// FreePrivateKeyHandle frees both components' handles.
+func (k *KEM) FreePrivateKeyHandle(handle any) error {
+	h, ok := handle.(*privateKeyHandle)
+	if !ok {
+		return ErrInvalidHandleType
+	}
+	return errors.Join(k.first.FreePrivateKeyHandle(h.first), k.second.FreePrivateKeyHandle(h.second))
+}
+
+// deriveRho derives the seed of component i, so that the components never
+// share randomness.
+func deriveRho(rho [32]byte, i byte) [32]byte {
+	mac := hmac.New(sha256.New, rho[:])
+	mac.Write([]byte("cbmpc/pve/hybrid:"))
+	mac.Write([]byte{i})
+	var out [32]byte
+	copy(out[:], mac.Sum(nil))
+	return out
+}
+
+func combine(ss1, ss2, ct1, ct2, ek1, ek2 []byte) ([]byte, error) {
+	secret := make([]byte, 0, len(ss1)+len(ss2))
+	secret = append(secret, ss1...)
+	secret = append(secret, ss2...)
+	defer secmem.Zero(secret)
+	info := join(ct1, ct2)
+	info = append(info, join(ek1, ek2)...)
+	return hkdf.Key(sha256.New, secret, []byte("cbmpc/pve/hybrid-kem"), string(info), SharedSecretSize)
+}
+
+// join encodes a and b, each prefixed with its 4-byte big-endian length.
+func join(a, b []byte) []byte {
+	out := make([]byte, 0, 8+len(a)+len(b))
+	out = binary.BigEndian.AppendUint32(out, uint32(len(a)))
+	out = append(out, a...)
+	out = binary.BigEndian.AppendUint32(out, uint32(len(b)))
+	return append(out, b...)
+}
+
+// split decodes the output of join.
+func split(in []byte) (a, b []byte, err error) {
+	a, rest, ok := next(in)
+	if !ok {
+		return nil, nil, ErrMalformed
+	}
+	b, rest, ok = next(rest)
+	if !ok || len(rest) != 0 {
+		return nil, nil, ErrMalformed
+	}
+	return a, b, nil
+}
+
+func next(in []byte) (field, rest []byte, ok bool) {
+	if len(in) < 4 {
+		return nil, nil, false
+	}
+	n := binary.BigEndian.Uint32(in)
+	if uint64(n) > uint64(len(in)-4) {
+		return nil, nil, false
+	}
+	return in[4 : 4+n], in[4+n:], true
+}
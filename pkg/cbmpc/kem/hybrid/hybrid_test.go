@@ -0,0 +1,144 @@
+package hybrid_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/ecies"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/hybrid"
+)
+
+func newKEM(t *testing.T) *hybrid.KEM {
+	t.Helper()
+	first, err := ecies.New(cbmpc.CurveP256)
+	if err != nil {
+		t.Fatalf("ecies.New failed: %v", err)
+	}
+	second, err := ecies.New(cbmpc.CurveSecp256k1)
+	if err != nil {
+		t.Fatalf("ecies.New failed: %v", err)
+	}
+	k, err := hybrid.New(first, second)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return k
+}
+
+func TestRoundTrip(t *testing.T) {
+	k := newKEM(t)
+	skRef, ek, err := k.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	derived, err := k.DerivePub(skRef)
+	if err != nil {
+		t.Fatalf("DerivePub failed: %v", err)
+	}
+	if !bytes.Equal(derived, ek) {
+		t.Fatal("DerivePub does not match the generated public key")
+	}
+	handle, err := k.NewPrivateKeyHandle(skRef)
+	if err != nil {
+		t.Fatalf("NewPrivateKeyHandle failed: %v", err)
+	}
+	defer func() {
+		if err := k.FreePrivateKeyHandle(handle); err != nil {
+			t.Errorf("FreePrivateKeyHandle failed: %v", err)
+		}
+	}()
+
+	var rho [32]byte
+	copy(rho[:], "test-rho-12345678901234567890123")
+	ct, ss, err := k.Encapsulate(ek, rho)
+	if err != nil {
+		t.Fatalf("Encapsulate failed: %v", err)
+	}
+	if len(ss) != hybrid.SharedSecretSize {
+		t.Fatalf("shared secret is %d bytes", len(ss))
+	}
+	got, err := k.Decapsulate(handle, ct)
+	if err != nil {
+		t.Fatalf("Decapsulate failed: %v", err)
+	}
+	if !bytes.Equal(got, ss) {
+		t.Fatal("decapsulated shared secret does not match")
+	}
+
+	ct2, ss2, err := k.Encapsulate(ek, rho)
+	if err != nil {
+		t.Fatalf("Encapsulate failed: %v", err)
+	}
+	if !bytes.Equal(ct, ct2) || !bytes.Equal(ss, ss2) {
+		t.Fatal("same (ek, rho) produced different outputs")
+	}
+}
+
+// TestComponentBinding tests that each component's ciphertext and key are
+// bound into the combined shared secret.
+func TestComponentBinding(t *testing.T) {
+	k := newKEM(t)
+	_, ek, err := k.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	_, other, err := k.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	var rho [32]byte
+	ct, ss, err := k.Encapsulate(ek, rho)
+	if err != nil {
+		t.Fatalf("Encapsulate failed: %v", err)
+	}
+
+	// Same first component key, different second one
+	mixed := append(append([]byte(nil), ek[:4+ecies.PointSize]...), other[4+ecies.PointSize:]...)
+	mixedCT, mixedSS, err := k.Encapsulate(mixed, rho)
+	if err != nil {
+		t.Fatalf("Encapsulate failed: %v", err)
+	}
+	if !bytes.Equal(ct[:4+ecies.PointSize], mixedCT[:4+ecies.PointSize]) {
+		t.Fatal("first component ciphertext depends on the second key")
+	}
+	if bytes.Equal(ss, mixedSS) {
+		t.Fatal("combined secret does not depend on the second component")
+	}
+}
+
+func TestMalformed(t *testing.T) {
+	k := newKEM(t)
+	skRef, ek, err := k.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	handle, err := k.NewPrivateKeyHandle(skRef)
+	if err != nil {
+		t.Fatalf("NewPrivateKeyHandle failed: %v", err)
+	}
+	defer k.FreePrivateKeyHandle(handle)
+
+	var rho [32]byte
+	ct, _, err := k.Encapsulate(ek, rho)
+	if err != nil {
+		t.Fatalf("Encapsulate failed: %v", err)
+	}
+	for name, in := range map[string][]byte{
+		"empty":     nil,
+		"truncated": ct[:len(ct)-1],
+		"trailing":  append(append([]byte(nil), ct...), 0),
+		"overlong":  {0xff, 0xff, 0xff, 0xff},
+	} {
+		if _, err := k.Decapsulate(handle, in); !errors.Is(err, hybrid.ErrMalformed) {
+			t.Errorf("%s: Decapsulate error = %v, want ErrMalformed", name, err)
+		}
+	}
+	if _, _, err := k.Encapsulate(ek[:10], rho); !errors.Is(err, hybrid.ErrMalformed) {
+		t.Errorf("Encapsulate error = %v, want ErrMalformed", err)
+	}
+	if _, err := k.Decapsulate("not a handle", ct); !errors.Is(err, hybrid.ErrInvalidHandleType) {
+		t.Errorf("Decapsulate error = %v, want ErrInvalidHandleType", err)
+	}
+}
@@ -0,0 +1,149 @@
+package rsa
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
+
+// ErrNotRSAKey indicates that imported key material parsed but is not an RSA key.
+var ErrNotRSAKey = errors.New("not an RSA key")
+
+// FromPrivateKeyPEM imports an existing RSA private key, such as an escrow
+// key, for use with PVE. The PEM block may be a "PRIVATE KEY" (PKCS#8) or an
+// "RSA PRIVATE KEY" (PKCS#1); encrypted PEM blocks must be decrypted first.
+//
+// Returns:
+//   - k: A KEM sized to the key
+//   - skRef: Private key reference (PKCS#8 DER format), as from Generate
+//   - ek: Public key (PKIX DER format)
+//   - err: Any error that occurred
+func FromPrivateKeyPEM(data []byte) (k *KEM, skRef []byte, ek []byte, err error) {
+	block, err := decodePEM(data, "PRIVATE KEY", "RSA PRIVATE KEY")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer secmem.Zero(block.Bytes)
+	return FromPrivateKeyDER(block.Bytes)
+}
+
+// FromPrivateKeyDER is like FromPrivateKeyPEM for a DER-encoded PKCS#8 or
+// PKCS#1 private key.
+func FromPrivateKeyDER(der []byte) (k *KEM, skRef []byte, ek []byte, err error) {
+	var privateKey *rsa.PrivateKey
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		var ok bool
+		if privateKey, ok = key.(*rsa.PrivateKey); !ok {
+			return nil, nil, nil, fmt.Errorf("%w: got %T", ErrNotRSAKey, key)
+		}
+	} else if privateKey, err = x509.ParsePKCS1PrivateKey(der); err != nil {
+		return nil, nil, nil, errors.New("failed to parse private key: expected PKCS#8 or PKCS#1 DER")
+	}
+	if err := privateKey.Validate(); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid RSA private key: %w", err)
+	}
+
+	k, err = New(privateKey.Size() * 8)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	skRef, err = x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	ek, err = x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		secmem.Zero(skRef)
+		return nil, nil, nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	return k, skRef, ek, nil
+}
+
+// FromPublicKeyPEM imports an existing RSA public key to encrypt or verify
+// PVE backups under. The PEM block may be a "PUBLIC KEY" (PKIX) or an
+// "RSA PUBLIC KEY" (PKCS#1).
+//
+// Returns:
+//   - k: A KEM sized to the key
+//   - ek: Public key (PKIX DER format)
+//   - err: Any error that occurred
+func FromPublicKeyPEM(data []byte) (k *KEM, ek []byte, err error) {
+	block, err := decodePEM(data, "PUBLIC KEY", "RSA PUBLIC KEY")
+	if err != nil {
+		return nil, nil, err
+	}
+	return FromPublicKeyDER(block.Bytes)
+}
+
+// FromPublicKeyDER is like FromPublicKeyPEM for a DER-encoded PKIX or PKCS#1
+// public key.
+func FromPublicKeyDER(der []byte) (k *KEM, ek []byte, err error) {
+	publicKey, err := parsePublicKey(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	k, err = New(publicKey.Size() * 8)
+	if err != nil {
+		return nil, nil, err
+	}
+	ek, err = x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	return k, ek, nil
+}
+
+// Fingerprint returns the hex SHA-256 of a public key's PKIX DER encoding,
+// for matching keys against an inventory. It accepts PKIX or PKCS#1 DER and
+// gives the same fingerprint for both encodings of a key. The underlying hash
+// is the one BindPublicKeyHash expects.
+func Fingerprint(ek []byte) (string, error) {
+	publicKey, err := parsePublicKey(ek)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func parsePublicKey(der []byte) (*rsa.PublicKey, error) {
+	if key, err := x509.ParsePKIXPublicKey(der); err == nil {
+		publicKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: got %T", ErrNotRSAKey, key)
+		}
+		return publicKey, nil
+	}
+	publicKey, err := x509.ParsePKCS1PublicKey(der)
+	if err != nil {
+		return nil, errors.New("failed to parse public key: expected PKIX or PKCS#1 DER")
+	}
+	return publicKey, nil
+}
+
+// decodePEM returns the first PEM block in data, which must have one of types.
+func decodePEM(data []byte, types ...string) (*pem.Block, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	for _, t := range types {
+		if block.Type == t {
+			return block, nil
+		}
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		return nil, errors.New("encrypted PEM private keys are not supported; decrypt the key first")
+	}
+	return nil, fmt.Errorf("unexpected PEM block type %q", block.Type)
+}
@@ -0,0 +1,128 @@
+//go:build cgo && !windows
+
+package rsa_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	stdrsa "crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/rsa"
+)
+
+// TestImportPrivateKey tests that imported PKCS#8 and PKCS#1 keys decapsulate
+// what their public keys encapsulate.
+func TestImportPrivateKey(t *testing.T) {
+	key, err := stdrsa.GenerateKey(rand.Reader, 3072)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	pkix, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+
+	for name, data := range map[string][]byte{
+		"PKCS#8": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+		"PKCS#1": pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			kem, skRef, ek, err := rsa.FromPrivateKeyPEM(data)
+			if err != nil {
+				t.Fatalf("FromPrivateKeyPEM failed: %v", err)
+			}
+			if !bytes.Equal(ek, pkix) {
+				t.Fatal("imported public key does not match")
+			}
+			handle, err := kem.NewPrivateKeyHandle(skRef)
+			if err != nil {
+				t.Fatalf("NewPrivateKeyHandle failed: %v", err)
+			}
+			defer kem.FreePrivateKeyHandle(handle)
+
+			// Encapsulate with a KEM imported from the public key alone
+			pubKEM, pubEK, err := rsa.FromPublicKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}))
+			if err != nil {
+				t.Fatalf("FromPublicKeyPEM failed: %v", err)
+			}
+			var rho [32]byte
+			ct, ss, err := pubKEM.Encapsulate(pubEK, rho)
+			if err != nil {
+				t.Fatalf("Encapsulate failed: %v", err)
+			}
+			got, err := kem.Decapsulate(handle, ct)
+			if err != nil {
+				t.Fatalf("Decapsulate failed: %v", err)
+			}
+			if !bytes.Equal(got, ss) {
+				t.Fatal("shared secrets differ")
+			}
+		})
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	ecDER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	if _, _, _, err := rsa.FromPrivateKeyDER(ecDER); !errors.Is(err, rsa.ErrNotRSAKey) {
+		t.Errorf("FromPrivateKeyDER(EC key) error = %v, want ErrNotRSAKey", err)
+	}
+	if _, _, _, err := rsa.FromPrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte{1}})); err == nil {
+		t.Error("FromPrivateKeyPEM accepted an encrypted key")
+	}
+	if _, _, err := rsa.FromPublicKeyPEM([]byte("not PEM")); err == nil {
+		t.Error("FromPublicKeyPEM accepted input without a PEM block")
+	}
+
+	small, err := stdrsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	if _, _, err := rsa.FromPublicKeyDER(x509.MarshalPKCS1PublicKey(&small.PublicKey)); err == nil {
+		t.Error("FromPublicKeyDER accepted a 1024-bit key")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	key, err := stdrsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	pkix, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+	sum := sha256.Sum256(pkix)
+	want := hex.EncodeToString(sum[:])
+
+	for name, der := range map[string][]byte{
+		"PKIX":   pkix,
+		"PKCS#1": x509.MarshalPKCS1PublicKey(&key.PublicKey),
+	} {
+		got, err := rsa.Fingerprint(der)
+		if err != nil {
+			t.Fatalf("%s: Fingerprint failed: %v", name, err)
+		}
+		if got != want {
+			t.Errorf("%s: Fingerprint = %s, want %s", name, got, want)
+		}
+	}
+}
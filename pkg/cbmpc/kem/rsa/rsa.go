@@ -12,6 +12,8 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
 )
 
 // Typed errors for handle validation.
@@ -101,7 +103,8 @@ func (k *KEM) BindPublicKey(ek []byte) {
 }
 
 // privateKeyHandle represents a handle to an RSA private key.
-// Private key material is held in DER form and zeroized on free.
+// Private key material is held in DER form in a secmem.Buffer and is wiped
+// on free.
 //
 // The handle includes algorithm metadata to prevent misuse:
 //   - algorithmID identifies the algorithm family and key size (e.g., "rsa-oaep-2048")
@@ -109,11 +112,11 @@ func (k *KEM) BindPublicKey(ek []byte) {
 //   - pubKeyHash is SHA-256 of the public key for integrity checking
 type privateKeyHandle struct {
 	mu          sync.RWMutex
-	algorithmID string   // Algorithm family and key size (e.g., "rsa-oaep-2048")
-	keySize     int      // Modulus size in bytes
-	pubKeyHash  [32]byte // SHA-256 hash of public key
-	keyDER      []byte   // PKCS#8 DER encoding
-	publicKey   []byte   // PKIX DER encoding
+	algorithmID string         // Algorithm family and key size (e.g., "rsa-oaep-2048")
+	keySize     int            // Modulus size in bytes
+	pubKeyHash  [32]byte       // SHA-256 hash of public key
+	keyDER      *secmem.Buffer // PKCS#8 DER encoding
+	publicKey   []byte         // PKIX DER encoding
 }
 
 // Generate generates a new RSA key pair.
@@ -121,7 +124,31 @@ type privateKeyHandle struct {
 //   - skRef: Private key reference (PKCS#8 DER format)
 //   - ek: Public key (PKIX DER format)
 //   - err: Any error that occurred
+//
+// In strict mode (secmem.SetStrict) Generate fails with secmem.ErrUnprotected;
+// use GenerateProtected.
 func (k *KEM) Generate() (skRef []byte, ek []byte, err error) {
+	if secmem.Strict() {
+		return nil, nil, secmem.ErrUnprotected
+	}
+	return k.generate()
+}
+
+// GenerateProtected is like Generate but returns the private key reference in
+// a secmem.Buffer. The caller must Destroy it when done.
+func (k *KEM) GenerateProtected() (skRef *secmem.Buffer, ek []byte, err error) {
+	der, ek, err := k.generate()
+	if err != nil {
+		return nil, nil, err
+	}
+	skRef, err = secmem.Move(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	return skRef, ek, nil
+}
+
+func (k *KEM) generate() (skRef []byte, ek []byte, err error) {
 	// Generate RSA key pair
 	privateKey, err := rsa.GenerateKey(rand.Reader, k.keySize)
 	if err != nil {
@@ -228,8 +255,8 @@ func (k *KEM) Decapsulate(skHandle any, ct []byte) (ss []byte, err error) {
 	algorithmID := handle.algorithmID
 	keySize := handle.keySize
 	pubKeyHash := handle.pubKeyHash
-	keyDER := make([]byte, len(handle.keyDER))
-	copy(keyDER, handle.keyDER)
+	keyDER := make([]byte, handle.keyDER.Len())
+	copy(keyDER, handle.keyDER.Bytes())
 	publicKey := make([]byte, len(handle.publicKey))
 	copy(publicKey, handle.publicKey)
 	handle.mu.RUnlock()
@@ -324,7 +351,8 @@ func (k *KEM) DerivePub(skRef []byte) ([]byte, error) {
 }
 
 // NewPrivateKeyHandle creates a handle to a private key.
-// Private key material is held in DER form and zeroized on free.
+// Private key material is held in DER form in a secmem.Buffer and wiped on
+// free.
 //
 // Parameters:
 //   - skRef: Private key reference in PKCS#8 DER format
@@ -363,15 +391,19 @@ func (k *KEM) NewPrivateKeyHandle(skRef []byte) (any, error) {
 	// Compute public key hash for integrity checking
 	pubKeyHash := sha256.Sum256(publicKey)
 
+	keyDER, err := secmem.Copy(skRef)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create handle with DER-encoded key and metadata
 	handle := &privateKeyHandle{
 		algorithmID: algorithmID,
 		keySize:     keySize,
 		pubKeyHash:  pubKeyHash,
-		keyDER:      make([]byte, len(skRef)),
+		keyDER:      keyDER,
 		publicKey:   publicKey,
 	}
-	copy(handle.keyDER, skRef)
 
 	return handle, nil
 }
@@ -386,7 +418,7 @@ func (k *KEM) FreePrivateKeyHandle(handle any) error {
 
 	// Zeroize private key material
 	h.mu.Lock()
-	zeroizeBytes(h.keyDER)
+	h.keyDER.Destroy()
 	h.keyDER = nil
 	h.publicKey = nil
 	h.mu.Unlock()
@@ -2,7 +2,11 @@
 
 package rsa
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
 
 // KEM stub implementation for non-CGO builds.
 type KEM struct{}
@@ -15,6 +19,10 @@ func (k *KEM) Generate() (skRef []byte, ek []byte, err error) {
 	return nil, nil, errors.New("RSA KEM requires CGO")
 }
 
+func (k *KEM) GenerateProtected() (skRef *secmem.Buffer, ek []byte, err error) {
+	return nil, nil, errors.New("RSA KEM requires CGO")
+}
+
 func (k *KEM) Encapsulate(ek []byte, rho [32]byte) (ct, ss []byte, err error) {
 	return nil, nil, errors.New("RSA KEM requires CGO")
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/rsa"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
 )
 
 // TestHandleValidation tests that Decapsulate properly validates handle metadata.
@@ -488,3 +489,50 @@ func TestBoundEKHash(t *testing.T) {
 		t.Errorf("Expected ErrPublicKeyHashMismatch, got: %v", err)
 	}
 }
+
+// TestGenerateProtected checks the protected key export and strict mode.
+func TestGenerateProtected(t *testing.T) {
+	kem, err := rsa.New(2048)
+	if err != nil {
+		t.Fatalf("Failed to create KEM: %v", err)
+	}
+
+	skRef, ek, err := kem.GenerateProtected()
+	if err != nil {
+		t.Fatalf("GenerateProtected failed: %v", err)
+	}
+	defer skRef.Destroy()
+
+	pub, err := kem.DerivePub(skRef.Bytes())
+	if err != nil {
+		t.Fatalf("DerivePub failed: %v", err)
+	}
+	if !secmem.Equal(pub, ek) {
+		t.Fatal("derived public key does not match")
+	}
+
+	handle, err := kem.NewPrivateKeyHandle(skRef.Bytes())
+	if err != nil {
+		t.Fatalf("NewPrivateKeyHandle failed: %v", err)
+	}
+	defer func() { _ = kem.FreePrivateKeyHandle(handle) }()
+
+	var rho [32]byte
+	ct, ss, err := kem.Encapsulate(ek, rho)
+	if err != nil {
+		t.Fatalf("Encapsulate failed: %v", err)
+	}
+	got, err := kem.Decapsulate(handle, ct)
+	if err != nil {
+		t.Fatalf("Decapsulate failed: %v", err)
+	}
+	if !secmem.Equal(got, ss) {
+		t.Fatal("shared secret mismatch")
+	}
+
+	secmem.SetStrict(true)
+	defer secmem.SetStrict(false)
+	if _, _, err := kem.Generate(); !errors.Is(err, secmem.ErrUnprotected) {
+		t.Fatalf("Generate in strict mode returned %v, want ErrUnprotected", err)
+	}
+}
@@ -0,0 +1,129 @@
+package kem
+
+import (
+	"crypto/ecdh"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+var (
+	// ErrMalformedEK indicates an encryption key that does not decode.
+	ErrMalformedEK = errors.New("kem: malformed encryption key")
+
+	// ErrUnsupportedEK indicates a well-formed key of a type no KEM in this
+	// module accepts.
+	ErrUnsupportedEK = errors.New("kem: unsupported encryption key type")
+
+	// ErrWeakEK indicates a key that decodes but is too weak to back up to.
+	ErrWeakEK = errors.New("kem: weak encryption key")
+)
+
+const (
+	// MinRSABits is the smallest RSA modulus ValidateEK accepts.
+	MinRSABits = 2048
+
+	// minRSAExponent is the smallest public exponent ValidateEK accepts.
+	// Small exponents such as 3 are safe with OAEP in theory but are a common
+	// sign of keys generated by weak tooling.
+	minRSAExponent = 65537
+)
+
+// Fingerprint returns the hex SHA-256 of an encryption key. For RSA keys in
+// PKIX DER this equals rsa.Fingerprint, and the underlying hash is the one
+// the KEMs' BindPublicKeyHash methods expect.
+func Fingerprint(ek []byte) string {
+	sum := sha256.Sum256(ek)
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateEK checks that ek is a well-formed encryption key of one of the
+// module's KEMs and is strong enough to anchor PVE backups:
+//   - RSA (kem/rsa): PKIX DER, a modulus of at least MinRSABits bits, and
+//     an odd public exponent of at least 65537
+//   - ECIES (kem/ecies): an uncompressed point on P-256 or secp256k1
+//   - Hybrid (kem/hybrid): two length-prefixed keys, each valid on its own
+//
+// Errors wrap ErrMalformedEK, ErrUnsupportedEK or ErrWeakEK. Verifiers can
+// call ValidateEK on keys received from other parties before encrypting to
+// them; it does not prove that anyone holds the private key.
+func ValidateEK(ek []byte) error {
+	if len(ek) == 0 {
+		return fmt.Errorf("%w: empty", ErrMalformedEK)
+	}
+	switch ek[0] {
+	case 0x30: // DER SEQUENCE
+		return validatePKIX(ek)
+	case 0x04: // uncompressed SEC1 point
+		return validatePoint(ek)
+	case 0x00: // length prefix of a hybrid key
+		first, second, ok := splitHybrid(ek)
+		if !ok {
+			return fmt.Errorf("%w: bad hybrid encoding", ErrMalformedEK)
+		}
+		if err := ValidateEK(first); err != nil {
+			return fmt.Errorf("first component: %w", err)
+		}
+		if err := ValidateEK(second); err != nil {
+			return fmt.Errorf("second component: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unrecognized encoding", ErrMalformedEK)
+}
+
+func validatePKIX(ek []byte) error {
+	pub, err := x509.ParsePKIXPublicKey(ek)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedEK, err)
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnsupportedEK, pub)
+	}
+	if bits := rsaPub.N.BitLen(); bits < MinRSABits {
+		return fmt.Errorf("%w: %d-bit RSA modulus, need at least %d", ErrWeakEK, bits, MinRSABits)
+	}
+	if rsaPub.N.Bit(0) == 0 {
+		return fmt.Errorf("%w: even RSA modulus", ErrWeakEK)
+	}
+	if rsaPub.E < minRSAExponent || rsaPub.E%2 == 0 {
+		return fmt.Errorf("%w: RSA public exponent %d", ErrWeakEK, rsaPub.E)
+	}
+	return nil
+}
+
+func validatePoint(ek []byte) error {
+	if len(ek) != 65 {
+		return fmt.Errorf("%w: %d-byte point, want 65", ErrMalformedEK, len(ek))
+	}
+	if _, err := ecdh.P256().NewPublicKey(ek); err == nil {
+		return nil
+	}
+	if _, err := btcec.ParsePubKey(ek); err == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: point is not on P-256 or secp256k1", ErrMalformedEK)
+}
+
+// splitHybrid decodes the two length-prefixed keys of a kem/hybrid key.
+func splitHybrid(ek []byte) (first, second []byte, ok bool) {
+	var parts [2][]byte
+	for i := range parts {
+		if len(ek) < 4 {
+			return nil, nil, false
+		}
+		n := binary.BigEndian.Uint32(ek)
+		if uint64(n) > uint64(len(ek)-4) {
+			return nil, nil, false
+		}
+		parts[i], ek = ek[4:4+n], ek[4+n:]
+	}
+	return parts[0], parts[1], len(ek) == 0
+}
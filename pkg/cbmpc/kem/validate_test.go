@@ -0,0 +1,108 @@
+package kem_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/ecies"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/hybrid"
+)
+
+func pkix(t *testing.T, pub any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey failed: %v", err)
+	}
+	return der
+}
+
+func TestValidateEK(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate EC key: %v", err)
+	}
+
+	p256, _ := ecies.New(cbmpc.CurveP256)
+	_, p256EK, err := p256.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	k1, _ := ecies.New(cbmpc.CurveSecp256k1)
+	_, k1EK, err := k1.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	hk, _ := hybrid.New(p256, k1)
+	_, hybridEK, err := hk.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	offCurve := append([]byte(nil), p256EK...)
+	offCurve[64] ^= 1
+	badHybrid := append([]byte(nil), hybridEK...)
+	badHybrid[len(badHybrid)-1] ^= 1
+	smallExp := pkix(t, &rsa.PublicKey{N: rsaKey.N, E: 3})
+	evenN := pkix(t, &rsa.PublicKey{N: new(big.Int).Add(rsaKey.N, big.NewInt(1)), E: 65537})
+
+	tests := []struct {
+		name string
+		ek   []byte
+		want error
+	}{
+		{"RSA", pkix(t, &rsaKey.PublicKey), nil},
+		{"ECIES P-256", p256EK, nil},
+		{"ECIES secp256k1", k1EK, nil},
+		{"hybrid", hybridEK, nil},
+		{"empty", nil, kem.ErrMalformedEK},
+		{"garbage", []byte{0x30, 1, 2}, kem.ErrMalformedEK},
+		{"point off curve", offCurve, kem.ErrMalformedEK},
+		{"truncated point", p256EK[:33], kem.ErrMalformedEK},
+		{"hybrid with bad component", badHybrid, kem.ErrMalformedEK},
+		{"EC PKIX key", pkix(t, &ecKey.PublicKey), kem.ErrUnsupportedEK},
+		{"1024-bit RSA", pkix(t, &smallKey.PublicKey), kem.ErrWeakEK},
+		{"RSA exponent 3", smallExp, kem.ErrWeakEK},
+		{"even RSA modulus", evenN, kem.ErrWeakEK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := kem.ValidateEK(tt.ek)
+			if tt.want == nil && err != nil {
+				t.Fatalf("ValidateEK failed: %v", err)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ValidateEK error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := kem.Fingerprint([]byte("ek-a"))
+	if len(a) != 64 {
+		t.Fatalf("fingerprint %q is not 64 hex digits", a)
+	}
+	if a == kem.Fingerprint([]byte("ek-b")) {
+		t.Fatal("different keys have the same fingerprint")
+	}
+	if a != kem.Fingerprint([]byte("ek-a")) {
+		t.Fatal("fingerprint is not stable")
+	}
+}
@@ -0,0 +1,248 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/logging"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
+
+var (
+	// ErrLibraryOpen is returned by Open while another Library is open.
+	ErrLibraryOpen = errors.New("cbmpc: library already open")
+	// ErrLibraryClosed is returned when a closed Library creates a job.
+	ErrLibraryClosed = errors.New("cbmpc: library closed")
+)
+
+// LibraryConfig is the process-wide setup applied by Open.
+//
+// Entropy is installed in the native library; the other settings are applied
+// on the Go side, and the following are out of scope:
+//   - Native logging: the native library does not log. Its failures reach Go
+//     as errors, which Logger sees through the jobs.
+//   - Native zeroization: native secrets are cleared by the library's own
+//     buffers whatever StrictSecrets says; StrictSecrets and Arena govern
+//     only what is exported to Go.
+type LibraryConfig struct {
+	// Entropy, if set, is the source of every random byte native code draws
+	// while the Library is open: nonces, key shares, session IDs and proof
+	// randomness. It replaces the OpenSSL RNG for the whole process, so
+	// other users of OpenSSL in the process draw from it too, and reads are
+	// serialized. A read error fails the protocol that needed the bytes. It
+	// requires native bindings.
+	Entropy io.Reader
+	// StrictSecrets turns on secmem strict mode while the Library is open,
+	// so that key shares and other secrets leave the library only in
+	// zeroizing secmem Buffers. See secmem.SetStrict.
+	StrictSecrets bool
+	// Arena, if set, is the secmem arena ProtectedBytes exports allocate
+	// from while the Library is open. See secmem.SetArena.
+	Arena *secmem.Arena
+	// MaxJobs bounds the number of jobs open at once; zero means no bound.
+	// A running protocol holds an OS thread in native code for each blocked
+	// transport call, so the bound also caps the threads the library uses.
+	// Job constructors wait for a free slot until their context is done.
+	MaxJobs int
+	// Logger, if set, is given to every job with SetLogger.
+	Logger logging.Logger
+	// Hooks are added to every job with AddOperationHook, for example an
+	// audit log's hook.
+	Hooks []OperationHook
+	// JobOptions are the options every job is created with.
+	JobOptions JobOptions
+}
+
+// openLibrary is the Library currently open, if any.
+var openLibrary atomic.Pointer[Library]
+
+// Library is the open library: the process-wide settings of a
+// LibraryConfig, and a factory for jobs that use them. Jobs created through a
+// Library are tracked until closed, and closing the Library closes those
+// still open.
+type Library struct {
+	cfg   LibraryConfig
+	slots chan struct{}
+
+	prevStrict bool
+	prevArena  *secmem.Arena
+
+	mu     sync.Mutex
+	closed bool
+	jobs   map[*libraryJob]struct{}
+}
+
+// libraryJob is a job created through a Library.
+type libraryJob struct{ close func() error }
+
+// Open applies cfg and returns the Library. Only one Library can be open at a
+// time, since the settings are process-wide; Close restores the settings
+// that were in effect before Open.
+//
+// Jobs created with the package-level constructors are unaffected by the
+// Library's defaults and limits, though they see its secmem settings.
+func Open(cfg LibraryConfig) (*Library, error) {
+	if cfg.MaxJobs < 0 {
+		return nil, fmt.Errorf("cbmpc: MaxJobs must not be negative (got %d)", cfg.MaxJobs)
+	}
+	l := &Library{cfg: cfg, jobs: make(map[*libraryJob]struct{})}
+	if cfg.MaxJobs > 0 {
+		l.slots = make(chan struct{}, cfg.MaxJobs)
+	}
+	if !openLibrary.CompareAndSwap(nil, l) {
+		return nil, ErrLibraryOpen
+	}
+	if cfg.Entropy != nil {
+		if err := backend.SetEntropySource(cfg.Entropy); err != nil {
+			openLibrary.CompareAndSwap(l, nil)
+			return nil, RemapError(err)
+		}
+	}
+	l.prevStrict, l.prevArena = secmem.Strict(), secmem.DefaultArena()
+	if cfg.StrictSecrets {
+		secmem.SetStrict(true)
+	}
+	if cfg.Arena != nil {
+		secmem.SetArena(cfg.Arena)
+	}
+	return l, nil
+}
+
+// Close closes every job created through l that is still open, restores the
+// process-wide settings Open changed, and makes l refuse new jobs. Protocols
+// running on the closed jobs fail.
+func (l *Library) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	jobs := l.jobs
+	l.jobs = nil
+	l.mu.Unlock()
+
+	var errs []error
+	for j := range jobs {
+		errs = append(errs, j.close())
+	}
+	if l.cfg.Entropy != nil {
+		if err := backend.SetEntropySource(nil); err != nil {
+			errs = append(errs, RemapError(err))
+		}
+	}
+	secmem.SetStrict(l.prevStrict)
+	secmem.SetArena(l.prevArena)
+	openLibrary.CompareAndSwap(l, nil)
+	return errors.Join(errs...)
+}
+
+// OpenJobs returns the number of jobs created through l that are not yet
+// closed.
+func (l *Library) OpenJobs() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.jobs)
+}
+
+// NewJob2P creates a 2-party job as NewJob2PWithOptions does, with the
+// Library's job options, logger and hooks.
+func (l *Library) NewJob2P(ctx context.Context, t Transport, self Role, names [2]string) (*Job2P, error) {
+	release, err := l.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	j, err := NewJob2PWithOptions(ctx, t, self, names, l.cfg.JobOptions)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if l.cfg.Logger != nil {
+		j.SetLogger(l.cfg.Logger)
+	}
+	for _, h := range l.cfg.Hooks {
+		j.AddOperationHook(h)
+	}
+	if err := l.track(j.Close, &j.release, release); err != nil {
+		_ = j.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+// NewJobMP creates an n-party job as NewJobMPWithOptions does, with the
+// Library's job options, logger and hooks.
+func (l *Library) NewJobMP(ctx context.Context, t Transport, self RoleID, names []string) (*JobMP, error) {
+	release, err := l.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	j, err := NewJobMPWithOptions(ctx, t, self, names, l.cfg.JobOptions)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if l.cfg.Logger != nil {
+		j.SetLogger(l.cfg.Logger)
+	}
+	for _, h := range l.cfg.Hooks {
+		j.AddOperationHook(h)
+	}
+	if err := l.track(j.Close, &j.release, release); err != nil {
+		_ = j.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+// acquire takes a job slot, waiting for one when MaxJobs are open, and
+// returns the function that gives it back.
+func (l *Library) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return nil, errors.New("cbmpc: nil library")
+	}
+	l.mu.Lock()
+	closed := l.closed
+	l.mu.Unlock()
+	if closed {
+		return nil, ErrLibraryClosed
+	}
+	if l.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// track registers a new job and sets *hook, which the job's Close calls, to
+// untrack it and free its slot.
+func (l *Library) track(closeJob func() error, hook *func(), release func()) error {
+	lj := &libraryJob{close: closeJob}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		// The job's Close frees the slot through the hook set below.
+		*hook = release
+		return ErrLibraryClosed
+	}
+	l.jobs[lj] = struct{}{}
+	*hook = func() {
+		l.mu.Lock()
+		delete(l.jobs, lj)
+		l.mu.Unlock()
+		release()
+	}
+	return nil
+}
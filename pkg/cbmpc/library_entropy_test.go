@@ -0,0 +1,58 @@
+//go:build cgo && !windows
+
+package cbmpc
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+type countingReader struct{ n atomic.Int64 }
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r.n.Add(1))
+	}
+	return len(p), nil
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("no entropy") }
+
+func TestLibraryEntropy(t *testing.T) {
+	const p256 = 415
+	src := &countingReader{}
+	l, err := Open(LibraryConfig{Entropy: src})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := backend.CurveRandomScalar(p256); err != nil {
+		t.Fatal(err)
+	}
+	drawn := src.n.Load()
+	if drawn == 0 {
+		t.Fatal("native randomness did not come from LibraryConfig.Entropy")
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := backend.CurveRandomScalar(p256); err != nil {
+		t.Fatal(err)
+	}
+	if src.n.Load() != drawn {
+		t.Fatal("source still read after Close")
+	}
+
+	l, err = Open(LibraryConfig{Entropy: failingReader{}})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = l.Close() }()
+	if _, err := backend.CurveRandomScalar(p256); err == nil {
+		t.Fatal("random scalar drawn from a failing source")
+	}
+}
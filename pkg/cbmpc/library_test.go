@@ -0,0 +1,96 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
+
+// fakeJob registers a job without a native session with l, as the Library's
+// constructors do after creating one.
+func fakeJob(t *testing.T, ctx context.Context, l *Library) (*Job2P, error) {
+	t.Helper()
+	release, err := l.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	j := &Job2P{trace: &jobTrace{}}
+	if err := l.track(j.Close, &j.release, release); err != nil {
+		_ = j.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+func TestOpenAppliesAndRestoresSettings(t *testing.T) {
+	if secmem.Strict() {
+		t.Skip("strict mode already on")
+	}
+	l, err := Open(LibraryConfig{StrictSecrets: true})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !secmem.Strict() {
+		t.Error("strict mode not enabled")
+	}
+	if _, err := Open(LibraryConfig{}); !errors.Is(err, ErrLibraryOpen) {
+		t.Errorf("second Open: err = %v, want ErrLibraryOpen", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if secmem.Strict() {
+		t.Error("strict mode not restored")
+	}
+	if _, err := l.NewJob2P(context.Background(), chanEndpoint{net: &chanNet{}}, RoleP1, [2]string{"a", "b"}); !errors.Is(err, ErrLibraryClosed) {
+		t.Errorf("NewJob2P after Close: err = %v, want ErrLibraryClosed", err)
+	}
+
+	l, err = Open(LibraryConfig{})
+	if err != nil {
+		t.Fatalf("Open after Close: %v", err)
+	}
+	_ = l.Close()
+	if _, err := Open(LibraryConfig{MaxJobs: -1}); err == nil {
+		t.Error("Open with negative MaxJobs succeeded")
+	}
+}
+
+func TestLibraryJobLimit(t *testing.T) {
+	l, err := Open(LibraryConfig{MaxJobs: 1})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer l.Close()
+
+	j1, err := fakeJob(t, context.Background(), l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := fakeJob(t, ctx, l); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second job: err = %v, want DeadlineExceeded", err)
+	}
+	if l.OpenJobs() != 1 {
+		t.Errorf("OpenJobs = %d, want 1", l.OpenJobs())
+	}
+
+	_ = j1.Close()
+	j2, err := fakeJob(t, context.Background(), l)
+	if err != nil {
+		t.Fatalf("job after Close: %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if l.OpenJobs() != 0 || len(l.slots) != 0 {
+		t.Errorf("after Close: %d jobs open, %d slots taken", l.OpenJobs(), len(l.slots))
+	}
+	if _, err := j2.Ptr(); !errors.Is(err, ErrJobClosed) {
+		t.Errorf("job after library Close: err = %v, want ErrJobClosed", err)
+	}
+}
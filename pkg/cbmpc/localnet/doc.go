@@ -0,0 +1,28 @@
+// Package localnet routes protocol messages between many jobs in one
+// process, for services that host several logical parties: development
+// sandboxes, simulations and test harnesses running many sessions at once.
+//
+// A Router holds any number of sessions, each with its own set of parties.
+// Every party joins a session and gets an Endpoint, which is a
+// cbmpc.Transport:
+//
+//	router := localnet.NewRouter(localnet.Config{QueueLimit: 16, MaxBytes: 8 << 20})
+//	p0, err := router.Join(sessionID, 0, 2)
+//	if err != nil {
+//	    return err
+//	}
+//	defer p0.Close()
+//	job, err := cbmpc.NewJob2P(p0, cbmpc.RoleP1, names)
+//
+// Unlike mocknet, whose mailboxes grow without limit, the router bounds its
+// memory. Each ordered pair has at most Config.QueueLimit undelivered
+// messages, and all sessions together at most Config.MaxBytes of them. Send
+// blocks while either limit is reached, which slows a runaway sender
+// instead of letting it exhaust memory. Messages are handed over under a
+// lock with no goroutine per pair or per session.
+//
+// Closing an endpoint releases the messages queued for it and makes peers
+// waiting on it fail with ErrPeerGone, so a party that aborts does not
+// leave the others blocked. A session is removed once all its joined
+// endpoints are closed.
+package localnet
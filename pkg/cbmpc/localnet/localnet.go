@@ -0,0 +1,347 @@
+package localnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+var (
+	// ErrClosed is returned by calls on a closed Endpoint.
+	ErrClosed = errors.New("localnet: endpoint closed")
+	// ErrPeerGone is returned when the peer closed its endpoint: by Send,
+	// and by Receive once every message the peer sent has been received.
+	ErrPeerGone = errors.New("localnet: peer left the session")
+	// ErrTooLarge is returned by Send for a message larger than the
+	// router's byte budget, which could never be buffered.
+	ErrTooLarge = errors.New("localnet: message exceeds router byte budget")
+)
+
+// Defaults for Config fields left zero.
+const (
+	DefaultQueueLimit = 64
+	DefaultMaxBytes   = 64 << 20
+)
+
+// Config bounds the memory a Router holds for undelivered messages.
+type Config struct {
+	// QueueLimit is the number of undelivered messages each ordered pair of
+	// parties may have. Zero selects DefaultQueueLimit.
+	QueueLimit int
+	// MaxBytes is the total size of undelivered messages across all
+	// sessions. Zero selects DefaultMaxBytes.
+	MaxBytes int64
+}
+
+// Router carries messages between the parties of any number of sessions
+// hosted in one process. Send copies the message into the recipient's queue
+// and returns, or blocks while that queue or the router's byte budget is
+// full; no goroutines run on the router's behalf. A Router is safe for
+// concurrent use.
+type Router struct {
+	queueLimit int
+	maxBytes   int64
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	bytes    int64
+	freed    chan struct{} // closed and replaced when bytes decreases
+}
+
+// NewRouter returns an empty router.
+func NewRouter(cfg Config) *Router {
+	if cfg.QueueLimit <= 0 {
+		cfg.QueueLimit = DefaultQueueLimit
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultMaxBytes
+	}
+	return &Router{
+		queueLimit: cfg.QueueLimit,
+		maxBytes:   cfg.MaxBytes,
+		sessions:   make(map[string]*session),
+		freed:      make(chan struct{}),
+	}
+}
+
+// Stats is a snapshot of router usage.
+type Stats struct {
+	Sessions int
+	Bytes    int64 // size of undelivered messages
+}
+
+// Stats reports the open sessions and buffered bytes.
+func (r *Router) Stats() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Stats{Sessions: len(r.sessions), Bytes: r.bytes}
+}
+
+type session struct {
+	id      string
+	parties int
+	boxes   []*mailbox // boxes[from*parties+to]
+	joined  []bool
+	live    int
+}
+
+// mailbox is the bounded FIFO queue for one ordered pair of parties.
+type mailbox struct {
+	mu      sync.Mutex
+	queue   [][]byte
+	size    int64 // total length of queue
+	from    bool  // sender has left
+	to      bool  // receiver has left
+	changed chan struct{}
+}
+
+// changedLocked wakes everyone waiting on the mailbox. Callers hold m.mu.
+func (m *mailbox) changedLocked() {
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+// Join creates the endpoint of party self in the named session of parties
+// parties, creating the session on first use. Each role joins once; the
+// session ends when all joined endpoints are closed, after which the ID may
+// be used again.
+func (r *Router) Join(sessionID string, self cbmpc.RoleID, parties int) (*Endpoint, error) {
+	if sessionID == "" {
+		return nil, errors.New("empty session ID")
+	}
+	if parties < 2 {
+		return nil, fmt.Errorf("localnet: need at least 2 parties (got %d)", parties)
+	}
+	if int64(self) >= int64(parties) {
+		return nil, fmt.Errorf("localnet: role %d out of range for %d parties", self, parties)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[sessionID]
+	if !ok {
+		s = &session{id: sessionID, parties: parties, boxes: make([]*mailbox, parties*parties), joined: make([]bool, parties)}
+		for i := range s.boxes {
+			s.boxes[i] = &mailbox{changed: make(chan struct{})}
+		}
+		r.sessions[sessionID] = s
+	}
+	if s.parties != parties {
+		return nil, fmt.Errorf("localnet: session %q has %d parties, not %d", sessionID, s.parties, parties)
+	}
+	if s.joined[self] {
+		return nil, fmt.Errorf("localnet: role %d already joined session %q", self, sessionID)
+	}
+	s.joined[self] = true
+	s.live++
+	return &Endpoint{r: r, s: s, self: self, done: make(chan struct{})}, nil
+}
+
+// reserve accounts for n more buffered bytes if the budget allows it.
+// Otherwise it returns a channel closed when bytes are next freed.
+func (r *Router) reserve(n int64) (bool, <-chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.bytes+n > r.maxBytes {
+		return false, r.freed
+	}
+	r.bytes += n
+	return true, nil
+}
+
+func (r *Router) release(n int64) {
+	if n == 0 {
+		return
+	}
+	r.mu.Lock()
+	r.bytes -= n
+	close(r.freed)
+	r.freed = make(chan struct{})
+	r.mu.Unlock()
+}
+
+// Endpoint is one party's cbmpc.Transport within a session.
+type Endpoint struct {
+	r    *Router
+	s    *session
+	self cbmpc.RoleID
+
+	once sync.Once
+	done chan struct{}
+}
+
+var _ cbmpc.Transport = (*Endpoint)(nil)
+
+// Self returns the endpoint's role.
+func (e *Endpoint) Self() cbmpc.RoleID { return e.self }
+
+func (e *Endpoint) box(from, to cbmpc.RoleID) (*mailbox, error) {
+	if from == to {
+		return nil, errors.New("localnet: message to self")
+	}
+	peer := from
+	if from == e.self {
+		peer = to
+	}
+	if int64(peer) >= int64(e.s.parties) {
+		return nil, fmt.Errorf("localnet: unknown peer %d", peer)
+	}
+	return e.s.boxes[int(from)*e.s.parties+int(to)], nil
+}
+
+// Send queues a copy of msg for to. It blocks while the pair's queue or the
+// router's byte budget is full, until space is freed or ctx is done.
+func (e *Endpoint) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	m, err := e.box(e.self, to)
+	if err != nil {
+		return err
+	}
+	n := int64(len(msg))
+	if n > e.r.maxBytes {
+		return fmt.Errorf("%w: %d bytes", ErrTooLarge, n)
+	}
+	for {
+		select {
+		case <-e.done:
+			return ErrClosed
+		default:
+		}
+		m.mu.Lock()
+		if m.to {
+			m.mu.Unlock()
+			return ErrPeerGone
+		}
+		wait := m.changed
+		if len(m.queue) < e.r.queueLimit {
+			ok, freed := e.r.reserve(n)
+			if ok {
+				m.queue = append(m.queue, append([]byte(nil), msg...))
+				m.size += n
+				m.changedLocked()
+				m.mu.Unlock()
+				return nil
+			}
+			m.mu.Unlock()
+			select {
+			case <-freed:
+			case <-e.done:
+				return ErrClosed
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+		m.mu.Unlock()
+		select {
+		case <-wait:
+		case <-e.done:
+			return ErrClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (e *Endpoint) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
+	m, err := e.box(from, e.self)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		select {
+		case <-e.done:
+			return nil, ErrClosed
+		default:
+		}
+		m.mu.Lock()
+		if len(m.queue) > 0 {
+			msg := m.queue[0]
+			m.queue[0] = nil
+			m.queue = m.queue[1:]
+			n := int64(len(msg))
+			m.size -= n
+			m.changedLocked()
+			m.mu.Unlock()
+			e.r.release(n)
+			return msg, nil
+		}
+		if m.from {
+			m.mu.Unlock()
+			return nil, ErrPeerGone
+		}
+		wait := m.changed
+		m.mu.Unlock()
+		select {
+		case <-wait:
+		case <-e.done:
+			return nil, ErrClosed
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (e *Endpoint) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	out := make(map[cbmpc.RoleID][]byte, len(from))
+	for _, role := range from {
+		if _, dup := out[role]; dup {
+			return nil, errors.New("localnet: duplicate role in receive_all")
+		}
+		msg, err := e.Receive(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		out[role] = msg
+	}
+	return out, nil
+}
+
+// Close leaves the session. Messages queued for this endpoint are dropped
+// and their memory released; messages it sent stay receivable by their
+// recipients. Peers blocked on this endpoint fail with ErrPeerGone.
+func (e *Endpoint) Close() error {
+	e.once.Do(func() {
+		close(e.done)
+		s := e.s
+		var freed int64
+		for p := range s.parties {
+			peer := cbmpc.RoleID(p) // #nosec G115 -- p < parties, a valid role
+			if peer == e.self {
+				continue
+			}
+			in := s.boxes[int(peer)*s.parties+int(e.self)]
+			in.mu.Lock()
+			in.to = true
+			freed += in.size
+			in.queue, in.size = nil, 0
+			in.changedLocked()
+			in.mu.Unlock()
+
+			out := s.boxes[int(e.self)*s.parties+int(peer)]
+			out.mu.Lock()
+			out.from = true
+			out.changedLocked()
+			out.mu.Unlock()
+		}
+		e.r.mu.Lock()
+		s.live--
+		ended := s.live == 0
+		if ended && e.r.sessions[s.id] == s {
+			delete(e.r.sessions, s.id)
+		}
+		e.r.mu.Unlock()
+		if ended {
+			// Release what was sent to roles that never joined.
+			for _, m := range s.boxes {
+				m.mu.Lock()
+				freed += m.size
+				m.queue, m.size = nil, 0
+				m.mu.Unlock()
+			}
+		}
+		e.r.release(freed)
+	})
+	return nil
+}
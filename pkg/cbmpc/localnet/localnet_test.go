@@ -0,0 +1,186 @@
+package localnet_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/localnet"
+)
+
+func join(t *testing.T, r *localnet.Router, session string, n int) []*localnet.Endpoint {
+	t.Helper()
+	eps := make([]*localnet.Endpoint, n)
+	for i := range eps {
+		ep, err := r.Join(session, cbmpc.RoleID(i), n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = ep.Close() })
+		eps[i] = ep
+	}
+	return eps
+}
+
+func TestSessions(t *testing.T) {
+	r := localnet.NewRouter(localnet.Config{QueueLimit: 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Many sessions run at once; with a queue of one message per pair,
+	// senders depend on receivers to make progress.
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for s := range 10 {
+		eps := join(t, r, fmt.Sprintf("s%d", s), 3)
+		for i, ep := range eps {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for round := range 5 {
+					var from []cbmpc.RoleID
+					for j := range eps {
+						if j == i {
+							continue
+						}
+						from = append(from, cbmpc.RoleID(j))
+						if err := ep.Send(ctx, cbmpc.RoleID(j), fmt.Appendf(nil, "%d:%d:%d", s, round, i)); err != nil {
+							errs <- err
+							return
+						}
+					}
+					got, err := ep.ReceiveAll(ctx, from)
+					if err != nil {
+						errs <- err
+						return
+					}
+					for _, j := range from {
+						if want := fmt.Sprintf("%d:%d:%d", s, round, j); string(got[j]) != want {
+							errs <- fmt.Errorf("got %q, want %q", got[j], want)
+							return
+						}
+					}
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+	if st := r.Stats(); st.Sessions != 10 || st.Bytes != 0 {
+		t.Fatalf("stats %+v", st)
+	}
+}
+
+func TestBackpressure(t *testing.T) {
+	r := localnet.NewRouter(localnet.Config{QueueLimit: 2, MaxBytes: 10})
+	eps := join(t, r, "a", 2)
+	other := join(t, r, "b", 2)
+	ctx := context.Background()
+
+	short := func() context.Context {
+		c, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		t.Cleanup(cancel)
+		return c
+	}
+	for range 2 {
+		if err := eps[0].Send(ctx, 1, []byte("x")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := eps[0].Send(short(), 1, []byte("x")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("send to full queue: err = %v, want to block", err)
+	}
+
+	// The byte budget is shared by all sessions.
+	if err := other[0].Send(ctx, 1, []byte("12345678")); err != nil {
+		t.Fatal(err)
+	}
+	if err := other[1].Send(short(), 0, []byte("1")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("send over byte budget: err = %v, want to block", err)
+	}
+	if err := other[1].Send(ctx, 0, make([]byte, 11)); !errors.Is(err, localnet.ErrTooLarge) {
+		t.Fatalf("oversized send: err = %v", err)
+	}
+
+	// Receiving in another session frees budget for a blocked sender.
+	done := make(chan error, 1)
+	go func() { done <- eps[1].Send(ctx, 0, []byte("12")) }()
+	time.Sleep(10 * time.Millisecond)
+	if _, err := other[1].Receive(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	r := localnet.NewRouter(localnet.Config{})
+	eps := join(t, r, "s", 3)
+	ctx := context.Background()
+	if err := eps[0].Send(ctx, 1, []byte("sent before leaving")); err != nil {
+		t.Fatal(err)
+	}
+	if err := eps[1].Send(ctx, 0, []byte("never read")); err != nil {
+		t.Fatal(err)
+	}
+
+	blocked := make(chan error, 1)
+	go func() {
+		_, err := eps[2].Receive(ctx, 0)
+		blocked <- err
+	}()
+	_ = eps[0].Close()
+	if err := <-blocked; !errors.Is(err, localnet.ErrPeerGone) {
+		t.Fatalf("Receive from closed peer: err = %v", err)
+	}
+	if msg, err := eps[1].Receive(ctx, 0); err != nil || string(msg) != "sent before leaving" {
+		t.Fatalf("Receive queued message = %q, %v", msg, err)
+	}
+	if _, err := eps[1].Receive(ctx, 0); !errors.Is(err, localnet.ErrPeerGone) {
+		t.Fatalf("Receive after drain: err = %v", err)
+	}
+	if err := eps[1].Send(ctx, 0, []byte("x")); !errors.Is(err, localnet.ErrPeerGone) {
+		t.Fatalf("Send to closed peer: err = %v", err)
+	}
+	if err := eps[0].Send(ctx, 1, []byte("x")); !errors.Is(err, localnet.ErrClosed) {
+		t.Fatalf("Send on closed endpoint: err = %v", err)
+	}
+	if st := r.Stats(); st.Bytes != 0 {
+		t.Fatalf("bytes after close = %d", st.Bytes)
+	}
+
+	_ = eps[1].Close()
+	_ = eps[2].Close()
+	if st := r.Stats(); st.Sessions != 0 {
+		t.Fatalf("sessions after close = %d", st.Sessions)
+	}
+	if _, err := r.Join("s", 0, 3); err != nil {
+		t.Fatalf("rejoin ended session: %v", err)
+	}
+}
+
+func TestJoinValidation(t *testing.T) {
+	r := localnet.NewRouter(localnet.Config{})
+	if _, err := r.Join("s", 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	for name, join := range map[string]func() error{
+		"duplicate role": func() error { _, err := r.Join("s", 0, 2); return err },
+		"party count":    func() error { _, err := r.Join("s", 1, 3); return err },
+		"role range":     func() error { _, err := r.Join("t", 2, 2); return err },
+		"one party":      func() error { _, err := r.Join("t", 0, 1); return err },
+		"empty session":  func() error { _, err := r.Join("", 0, 2); return err },
+	} {
+		if join() == nil {
+			t.Errorf("%s: Join succeeded", name)
+		}
+	}
+}
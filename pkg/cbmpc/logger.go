@@ -0,0 +1,145 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/logging"
+)
+
+// Traffic counts the messages one party exchanged during an operation. A
+// round is one receive from the native protocol, from one or several peers.
+type Traffic struct {
+	Rounds           int
+	MessagesSent     int
+	MessagesReceived int
+	BytesSent        int64
+	BytesReceived    int64
+}
+
+// jobTrace is shared by a job and its transport adapter. It holds the job's
+// logger, counts the traffic of the running protocol and keeps its first
+// transport error; the counters are updated from native callbacks and so are
+// atomic.
+type jobTrace struct {
+	logger logging.Logger
+
+	rounds, sent, received   atomic.Int64
+	bytesSent, bytesReceived atomic.Int64
+
+	mu      sync.Mutex
+	failure error
+
+	onRound  RoundFunc
+	key      roundKey // protocol being run, for round estimates
+	estimate int
+}
+
+func (t *jobTrace) reset() {
+	t.rounds.Store(0)
+	t.sent.Store(0)
+	t.received.Store(0)
+	t.bytesSent.Store(0)
+	t.bytesReceived.Store(0)
+	t.mu.Lock()
+	t.failure = nil
+	t.mu.Unlock()
+}
+
+// fail records the first transport error of the running protocol. The
+// native library only learns that a callback failed, so this is the only
+// record of why.
+func (t *jobTrace) fail(err error) {
+	t.mu.Lock()
+	if t.failure == nil {
+		t.failure = err
+	}
+	t.mu.Unlock()
+}
+
+// surface adds the recorded transport error to the error a protocol
+// invocation returned, so callers can match it with errors.Is and errors.As.
+func (t *jobTrace) surface(errp *error) {
+	if errp == nil || *errp == nil {
+		return
+	}
+	t.mu.Lock()
+	failure := t.failure
+	t.mu.Unlock()
+	if failure != nil && !errors.Is(*errp, failure) {
+		*errp = fmt.Errorf("%w: %w", failure, *errp)
+	}
+}
+
+func (t *jobTrace) traffic() Traffic {
+	return Traffic{
+		Rounds:           int(t.rounds.Load()),
+		MessagesSent:     int(t.sent.Load()),
+		MessagesReceived: int(t.received.Load()),
+		BytesSent:        t.bytesSent.Load(),
+		BytesReceived:    t.bytesReceived.Load(),
+	}
+}
+
+func (t *jobTrace) send(ctx context.Context, to RoleID, n int, err error) {
+	if err != nil {
+		t.fail(err)
+		if t.logger != nil {
+			t.logger.Warn(ctx, "cbmpc: send failed", "to", to, "bytes", n, "error", err)
+		}
+		return
+	}
+	t.sent.Add(1)
+	t.bytesSent.Add(int64(n))
+	t.progress(t.rounds.Load()+1, DirectionSend, to)
+	if t.logger != nil {
+		t.logger.Debug(ctx, "cbmpc: sent", "to", to, "bytes", n)
+	}
+}
+
+func (t *jobTrace) receive(ctx context.Context, from []RoleID, n int, err error) {
+	if err != nil {
+		t.fail(err)
+		if t.logger != nil {
+			t.logger.Warn(ctx, "cbmpc: receive failed", "from", from, "error", err)
+		}
+		return
+	}
+	round := t.rounds.Add(1)
+	t.received.Add(int64(len(from)))
+	t.bytesReceived.Add(int64(n))
+	for _, peer := range from {
+		t.progress(round, DirectionReceive, peer)
+	}
+	if t.logger != nil {
+		t.logger.Debug(ctx, "cbmpc: received", "round", round, "from", from, "bytes", n)
+	}
+}
+
+// SetLogger makes the job log through l: every message sent and received at
+// debug level, and every DKG, refresh, signing and key import or export at
+// info level when it finishes (warn if it fails), with its duration and
+// traffic. Message contents are never logged. Call it before the job is used;
+// a nil l turns logging off.
+func (j *Job2P) SetLogger(l logging.Logger) {
+	if j != nil {
+		j.trace.logger = withSelf(l, j.self)
+	}
+}
+
+// SetLogger is the n-party counterpart of Job2P.SetLogger.
+func (j *JobMP) SetLogger(l logging.Logger) {
+	if j != nil {
+		j.trace.logger = withSelf(l, j.self)
+	}
+}
+
+func withSelf(l logging.Logger, self RoleID) logging.Logger {
+	if l == nil {
+		return nil
+	}
+	return l.With("self", self)
+}
@@ -0,0 +1,63 @@
+package cbmpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/logging"
+)
+
+func TestJobLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	// A job without a native session: only the Go-side tracing is exercised.
+	trace := &jobTrace{}
+	j := &Job2P{self: 0, names: [2]string{"p1", "p2"}, trace: trace}
+	j.SetLogger(logger)
+
+	net := &chanNet{}
+	adapter := transportAdapter{inner: chanEndpoint{net: net, self: 0}, ctx: context.Background(), trace: trace}
+	peer := chanEndpoint{net: net, self: 1}
+
+	var got *Operation
+	j.AddOperationHook(func(_ context.Context, op *Operation) { got = op })
+	done := j.BeginOperation(context.Background(), &Operation{Protocol: "test.Run"})
+	if err := adapter.Send(context.Background(), 1, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	_ = peer.Send(context.Background(), 0, []byte("hi"))
+	_ = peer.Send(context.Background(), 0, []byte("again"))
+	if _, err := adapter.Receive(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := adapter.ReceiveAll(context.Background(), []uint32{1}); err != nil {
+		t.Fatal(err)
+	}
+	err := errors.New("boom")
+	done(&err)
+
+	want := Traffic{Rounds: 2, MessagesSent: 1, MessagesReceived: 2, BytesSent: 5, BytesReceived: 7}
+	if got == nil || got.Traffic != want {
+		t.Fatalf("traffic = %+v, want %+v", got, want)
+	}
+	out := buf.String()
+	for _, s := range []string{
+		`msg="cbmpc: protocol started" self=0 protocol=test.Run`,
+		`msg="cbmpc: sent" self=0 to=1 bytes=5`,
+		`msg="cbmpc: received" self=0 round=2 from=[1] bytes=5`,
+		`msg="cbmpc: protocol failed" self=0 protocol=test.Run`,
+		`rounds=2 messages_sent=1 bytes_sent=5 messages_received=2 bytes_received=7 error=boom`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("log is missing %q:\n%s", s, out)
+		}
+	}
+	if strings.Contains(out, "hello") {
+		t.Error("message contents were logged")
+	}
+}
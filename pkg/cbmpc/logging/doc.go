@@ -46,10 +46,26 @@
 //	// Get the redaction placeholder
 //	placeholder := logging.Placeholder() // Returns "[redacted]"
 //
+// Redacted relies on every call site remembering to use it. To protect
+// against code that forgets, wrap the slog handler with NewRedactingHandler,
+// which replaces any attribute whose key contains a deny-listed word ("key",
+// "scalar", "share", "seed", ... by default), including inside groups and
+// attributes added with With:
+//
+//	h := logging.NewRedactingHandler(slog.NewJSONHandler(os.Stderr, nil), &logging.RedactOptions{
+//	    Allow: []string{"public_key"},
+//	})
+//	logger := logging.New(slog.New(h))
+//	logger.Info(ctx, "refreshed", "x_share", share) // Logs: x_share="[redacted]"
+//
 // # Usage in MPC Code
 //
-// Loggers can be passed to MPC protocol implementations for debugging
-// and observability:
+// Attach a logger to a job with SetLogger; the job then logs every message it
+// exchanges at debug level and every protocol it runs at info level:
+//
+//	job.SetLogger(logging.New(nil))
+//
+// Application code can log alongside it:
 //
 //	logger := logging.New(nil)
 //	logger.Info(ctx, "starting DKG", "curve", "P256", "parties", 2)
@@ -62,19 +78,9 @@
 //
 // # Custom Implementations
 //
-// Applications can provide custom Logger implementations:
-//
-//	type customLogger struct {
-//	    // ... your fields
-//	}
-//
-//	func (l *customLogger) Debug(ctx context.Context, msg string, args ...any) {
-//	    // Custom debug logic
-//	}
-//	// ... implement other methods
-//
-//	logger := &customLogger{}
-//	// Use logger with MPC protocols
+// Any type with the Logger methods can be passed to SetLogger, for example
+// to route protocol events into an existing logging system or to capture
+// them in tests.
 //
 // # Security Considerations
 //
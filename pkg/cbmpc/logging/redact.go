@@ -0,0 +1,130 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// DefaultDenyList is the deny-list used by NewRedactingHandler when none is
+// configured.
+var DefaultDenyList = []string{"key", "scalar", "share", "seed", "secret", "private", "password", "mnemonic"}
+
+// RedactOptions configures NewRedactingHandler.
+type RedactOptions struct {
+	// Deny lists the substrings that mark an attribute key as sensitive.
+	// Matching is case-insensitive. Nil uses DefaultDenyList.
+	Deny []string
+	// Allow lists attribute keys that are never redacted even if they match
+	// Deny, such as "public_key". Matching is case-insensitive and exact.
+	Allow []string
+}
+
+// NewRedactingHandler wraps h so that every attribute whose key contains a
+// deny-listed substring has its value replaced with Placeholder(), whether it
+// is passed to a log call, added with With, or nested in a group. A group
+// whose name matches is redacted as a whole. Values are resolved first, so a
+// slog.LogValuer cannot smuggle a sensitive group past the check.
+//
+// Matching is by substring, so "key" also redacts "key_id" and "public_key";
+// list such keys in Allow. Erring towards redaction is deliberate: the
+// handler protects against code that logs secrets by accident, and a
+// redacted identifier is cheaper than a leaked key share.
+func NewRedactingHandler(h slog.Handler, opts *RedactOptions) slog.Handler {
+	if opts == nil {
+		opts = &RedactOptions{}
+	}
+	deny := opts.Deny
+	if deny == nil {
+		deny = DefaultDenyList
+	}
+	r := &redactor{}
+	for _, d := range deny {
+		if d != "" {
+			r.deny = append(r.deny, strings.ToLower(d))
+		}
+	}
+	for _, a := range opts.Allow {
+		r.allow = append(r.allow, strings.ToLower(a))
+	}
+	return &redactingHandler{inner: h, r: r}
+}
+
+type redactor struct {
+	deny  []string
+	allow []string
+}
+
+func (r *redactor) sensitive(key string) bool {
+	key = strings.ToLower(key)
+	if slices.Contains(r.allow, key) {
+		return false
+	}
+	for _, d := range r.deny {
+		if strings.Contains(key, d) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *redactor) attr(a slog.Attr) slog.Attr {
+	if r.sensitive(a.Key) {
+		return slog.String(a.Key, redactedPlaceholder)
+	}
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		return a
+	}
+	group := a.Value.Group()
+	out := make([]slog.Attr, len(group))
+	for i, g := range group {
+		out[i] = r.attr(g)
+	}
+	return slog.Attr{Key: a.Key, Value: slog.GroupValue(out...)}
+}
+
+type redactingHandler struct {
+	inner slog.Handler
+	r     *redactor
+	// redactAll is set once WithGroup opens a sensitive group: everything
+	// logged inside it is redacted.
+	redactAll bool
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, rec slog.Record) error {
+	out := slog.NewRecord(rec.Time, rec.Level, rec.Message, rec.PC)
+	rec.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(h.redact(a))
+		return true
+	})
+	return h.inner.Handle(ctx, out)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		out[i] = h.redact(a)
+	}
+	return &redactingHandler{inner: h.inner.WithAttrs(out), r: h.r, redactAll: h.redactAll}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{
+		inner:     h.inner.WithGroup(name),
+		r:         h.r,
+		redactAll: h.redactAll || h.r.sensitive(name),
+	}
+}
+
+func (h *redactingHandler) redact(a slog.Attr) slog.Attr {
+	if h.redactAll {
+		return slog.String(a.Key, redactedPlaceholder)
+	}
+	return h.r.attr(a)
+}
@@ -0,0 +1,56 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/logging"
+)
+
+type secretValuer struct{}
+
+func (secretValuer) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("seed", "0xdeadbeef"), slog.Int("bits", 256))
+}
+
+func TestRedactingHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := logging.NewRedactingHandler(slog.NewTextHandler(&buf, nil), &logging.RedactOptions{Allow: []string{"public_key"}})
+	log := logging.New(slog.New(h)).With("x_share", "s1")
+
+	log.Info(context.Background(), "dkg done",
+		"public_key", "02abcd",
+		"PrivateKey", "k1",
+		"party", "p1",
+		slog.Group("state", "Scalar", "k2", "round", 3),
+		"material", secretValuer{},
+	)
+	slog.New(h).WithGroup("secrets").Info("nested", "n", "k3")
+
+	out := buf.String()
+	for _, leak := range []string{"s1", "k1", "k2", "k3", "deadbeef"} {
+		if strings.Contains(out, leak) {
+			t.Errorf("output leaks %q:\n%s", leak, out)
+		}
+	}
+	for _, kept := range []string{"public_key=02abcd", "party=p1", "state.round=3", "material.bits=256"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("output is missing %q:\n%s", kept, out)
+		}
+	}
+	if got := strings.Count(out, logging.Placeholder()); got != 5 {
+		t.Errorf("got %d redactions, want 5:\n%s", got, out)
+	}
+}
+
+func TestRedactingHandlerCustomDenyList(t *testing.T) {
+	var buf bytes.Buffer
+	h := logging.NewRedactingHandler(slog.NewTextHandler(&buf, nil), &logging.RedactOptions{Deny: []string{"token"}})
+	slog.New(h).Info("m", "api_token", "t1", "key", "kept")
+	if out := buf.String(); strings.Contains(out, "t1") || !strings.Contains(out, "key=kept") {
+		t.Fatalf("unexpected output %s", out)
+	}
+}
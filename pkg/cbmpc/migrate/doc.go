@@ -0,0 +1,50 @@
+// Package migrate converts keys between protocol families without changing
+// their public key, so deployments can change their custody model without
+// moving funds or rotating on-chain addresses.
+//
+// # Key Operations
+//
+//   - ECDSA2PToMP: Reshares an ecdsa2p key into a t-of-n threshold ecdsamp key
+//
+// # Protocol
+//
+// The two holders of an ecdsa2p key each hold an additive share x_i of the
+// private key x = x_1 + x_2. ECDSA2PToMP has each holder deal its share to the
+// n new parties with Shamir secret sharing and Feldman commitments (see the
+// secretsharing package). Every new party checks its two shares against the
+// commitments, checks that the committed secrets add up to the ecdsa2p public
+// key, and confirms with every other party that all of them saw the same
+// commitments before it adds the shares. The result is a share of x under the
+// access structure THRESHOLD[t](names...), the same form ecdsamp.ThresholdDKG
+// produces, so ecdsamp.ThresholdRefresh works on it.
+//
+// No party ever holds x. A holder that deals inconsistent shares is detected
+// and the migration fails for everyone; it cannot bias the resulting key,
+// which is pinned to the ecdsa2p public key.
+//
+// # Usage Example
+//
+//	// Every new party runs ECDSA2PToMP over one multi-party transport.
+//	// Parties 0 and 1 hold the P1 and P2 shares of the 2-party key.
+//	res, err := migrate.ECDSA2PToMP(ctx, transport, &migrate.ECDSA2PToMPParams{
+//	    Self:      self,
+//	    Names:     []string{"a", "b", "c", "d", "e"},
+//	    Holders:   [2]cbmpc.RoleID{0, 1},
+//	    Key:       key2p, // nil on parties 2..4
+//	    Threshold: 3,
+//	    PublicKey: address, // pins the expected key on non-holders
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer res.Key.Close()
+//
+// # Security Considerations
+//
+//   - Run the migration over an authenticated, confidential transport: each
+//     holder sends every party a secret share.
+//   - Set PublicKey on parties that do not hold the old key, so they only
+//     accept shares of the key they expect.
+//   - Delete the ecdsa2p key shares once every party has stored its new
+//     share; until then, the old 2-of-2 key still signs.
+package migrate
@@ -0,0 +1,195 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/reshare"
+)
+
+// ErrInconsistentDeal is wrapped by the error returned when a holder's
+// dealing does not verify or parties saw different dealings.
+var ErrInconsistentDeal = reshare.ErrInconsistentDeal
+
+// ECDSA2PToMPParams contains parameters for ECDSA2PToMP.
+type ECDSA2PToMPParams struct {
+	// Self is the caller's role in the transport, an index into Names.
+	Self cbmpc.RoleID
+
+	// Names are the new parties, indexed by RoleID. They become the leaves
+	// of the threshold access structure and the party names of the new keys.
+	Names []string
+
+	// Holders are the roles holding the P1 and P2 shares of the ecdsa2p key.
+	// They must be distinct members of Names.
+	Holders [2]cbmpc.RoleID
+
+	// Key is the caller's ecdsa2p key share. Holders must set it; every other
+	// party must leave it nil. It is not modified.
+	Key *ecdsa2p.Key
+
+	// Threshold is the number of parties required to use the new key. It
+	// must be at least 2 and at most len(Names).
+	Threshold int
+
+	// PublicKey optionally pins the compressed public key being migrated.
+	PublicKey []byte
+}
+
+// ECDSA2PToMPResult contains the output of ECDSA2PToMP.
+type ECDSA2PToMPResult struct {
+	// Key is the caller's share of the migrated key. It must be freed with
+	// Close() when no longer needed.
+	Key *ecdsamp.Key
+
+	// AccessStructure is THRESHOLD[Threshold](Names...), for use with
+	// ecdsamp.ThresholdRefresh.
+	AccessStructure accessstructure.AccessStructure
+}
+
+const tag = "cbmpc-migrate-ecdsa2p-mp/1"
+
+// ECDSA2PToMP reshares an ecdsa2p key into a Threshold-of-len(Names)
+// ecdsamp key with the same public key. Every new party, including the two
+// holders, must call it with the same Names, Holders and Threshold over a
+// transport connecting all of them. See the package documentation for the
+// protocol.
+func ECDSA2PToMP(ctx context.Context, t cbmpc.Transport, params *ECDSA2PToMPParams) (*ECDSA2PToMPResult, error) {
+	if t == nil {
+		return nil, cbmpc.ErrNilTransport
+	}
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	n := len(params.Names)
+	if n < 2 {
+		return nil, fmt.Errorf("%w: need at least 2 parties (got %d)", cbmpc.ErrBadPeers, n)
+	}
+	if int(params.Self) >= n {
+		return nil, fmt.Errorf("%w: self role %d out of range [0,%d)", cbmpc.ErrBadPeers, params.Self, n)
+	}
+	h0, h1 := params.Holders[0], params.Holders[1]
+	if h0 == h1 || int(h0) >= n || int(h1) >= n {
+		return nil, fmt.Errorf("%w: holders %d and %d must be distinct parties", cbmpc.ErrBadPeers, h0, h1)
+	}
+	if params.Threshold < 2 || params.Threshold > n {
+		return nil, fmt.Errorf("threshold must be in [2,%d] (got %d)", n, params.Threshold)
+	}
+	holder := params.Self == params.Holders[0] || params.Self == params.Holders[1]
+	if holder != (params.Key != nil) {
+		return nil, errors.New("key must be set by holders and only by holders")
+	}
+
+	rp := &reshare.Params{
+		Tag:       tag,
+		Self:      params.Self,
+		Names:     params.Names,
+		Dealers:   params.Holders[:],
+		Threshold: params.Threshold,
+		Pin:       params.PublicKey,
+	}
+	for i := range params.Names {
+		rp.Recipients = append(rp.Recipients, cbmpc.RoleID(i))
+	}
+	if holder {
+		var err error
+		if rp.Curve, err = params.Key.Curve(); err != nil {
+			return nil, err
+		}
+		if rp.PublicKey, err = params.Key.PublicKey(); err != nil {
+			return nil, err
+		}
+		if rp.Secret, err = keyShare(params.Key); err != nil {
+			return nil, err
+		}
+		defer rp.Secret.Free()
+	}
+	res, err := reshare.Run(ctx, t, rp)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Share.Free()
+
+	leaves := make([]accessstructure.Expr, n)
+	for i, name := range params.Names {
+		leaves[i] = accessstructure.Leaf(name)
+	}
+	ac, err := accessstructure.Compile(accessstructure.Threshold(params.Threshold, leaves...))
+	if err != nil {
+		return nil, err
+	}
+	key, err := newMPKey(res, params.Names, params.Names[params.Self], params.Threshold, ac)
+	if err != nil {
+		return nil, err
+	}
+	return &ECDSA2PToMPResult{Key: key, AccessStructure: ac}, nil
+}
+
+// keyShare extracts the additive private share from an ecdsa2p key.
+func keyShare(key *ecdsa2p.Key) (*curve.Scalar, error) {
+	data, err := key.ProtectedBytes()
+	if err != nil {
+		return nil, err
+	}
+	defer data.Destroy()
+	_, share, err := envelope.UnwrapKey(envelope.KeyECDSA2P, data.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	ckey, err := backend.ECDSA2PKeyDeserialize(share)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	defer backend.ECDSA2PKeyFree(ckey)
+
+	x, err := backend.ECDSA2PKeyGetXShare(ckey)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	defer cbmpc.ZeroizeBytes(x)
+	return curve.NewScalarFromBytes(x)
+}
+
+// newMPKey assembles the caller's ecdsamp key from the resharing result. The
+// key records its quorum and access structure, which Sign uses to turn the
+// share into an additive one.
+func newMPKey(res *reshare.Result, names []string, self string, quorum int, ac accessstructure.AccessStructure) (*ecdsamp.Key, error) {
+	x := res.Share.BytesPadded(res.Curve)
+	defer cbmpc.ZeroizeBytes(x)
+	nid, err := backend.CurveToNID(backend.Curve(res.Curve))
+	if err != nil {
+		return nil, err
+	}
+	ckey, err := backend.ECDSAMPKeyNew(nid, self, x, res.PublicKey, names, res.PublicShares)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	defer backend.ECDSAMPKeyFree(ckey)
+	data, err := backend.ECDSAMPKeySerialize(ckey)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	defer cbmpc.ZeroizeBytes(data)
+	wrapped, err := envelope.EncodeKey(envelope.KeyMeta{
+		Protocol:        envelope.KeyECDSAMP,
+		Curve:           uint8(res.Curve),
+		Role:            self,
+		Created:         time.Now().UTC(),
+		Quorum:          quorum,
+		AccessStructure: ac,
+	}, data)
+	if err != nil {
+		return nil, err
+	}
+	defer cbmpc.ZeroizeBytes(wrapped)
+	return ecdsamp.LoadKey(wrapped)
+}
@@ -0,0 +1,271 @@
+package migrate_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsamp"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/migrate"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+)
+
+// dkg2p runs ecdsa2p.DKG and returns both key shares.
+func dkg2p(t *testing.T, ctx context.Context) [2]*ecdsa2p.Key {
+	t.Helper()
+	net := mocknet.New()
+	var (
+		wg   sync.WaitGroup
+		keys [2]*ecdsa2p.Key
+		errs [2]error
+	)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(party int) {
+			defer wg.Done()
+			role := cbmpc.RoleP1
+			if party == 1 {
+				role = cbmpc.RoleP2
+			}
+			job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(party), cbmpc.RoleID(1-party)), role, [2]string{"old1", "old2"})
+			if err != nil {
+				errs[party] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveSecp256k1})
+			if err == nil {
+				keys[party] = res.Key
+			}
+			errs[party] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d DKG failed: %v", i, err)
+		}
+	}
+	t.Cleanup(func() {
+		_ = keys[0].Close()
+		_ = keys[1].Close()
+	})
+	return keys
+}
+
+// runMigration runs ECDSA2PToMP for every party; params(i) returns party i's
+// parameters.
+func runMigration(ctx context.Context, n int, params func(int) *migrate.ECDSA2PToMPParams) ([]*migrate.ECDSA2PToMPResult, []error) {
+	net := mocknet.New()
+	roles := make([]cbmpc.RoleID, n)
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+	}
+	results := make([]*migrate.ECDSA2PToMPResult, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = migrate.ECDSA2PToMP(ctx, net.EpMP(roles[i], roles), params(i))
+		}(i)
+	}
+	wg.Wait()
+	return results, errs
+}
+
+func TestECDSA2PToMP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	keys := dkg2p(t, ctx)
+	pub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{"p0", "p1", "p2", "p3", "p4"}
+	results, errs := runMigration(ctx, len(names), func(i int) *migrate.ECDSA2PToMPParams {
+		p := &migrate.ECDSA2PToMPParams{
+			Self:      cbmpc.RoleID(i),
+			Names:     names,
+			Holders:   [2]cbmpc.RoleID{1, 3},
+			Threshold: 3,
+			PublicKey: pub,
+		}
+		switch i {
+		case 1:
+			p.Key = keys[0]
+		case 3:
+			p.Key = keys[1]
+		}
+		return p
+	})
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d migration failed: %v", i, err)
+		}
+	}
+	for i, res := range results {
+		defer res.Key.Close()
+		got, err := res.Key.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, pub) {
+			t.Fatalf("party %d public key %x, want %x", i, got, pub)
+		}
+		if !bytes.Equal(res.AccessStructure, results[0].AccessStructure) {
+			t.Fatalf("party %d got a different access structure", i)
+		}
+	}
+
+	// The migrated shares are ordinary threshold keys: a quorum can refresh them.
+	quorum := []int{0, 2, 4}
+	roles := make([]cbmpc.RoleID, len(names))
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+	}
+	net := mocknet.New()
+	var wg sync.WaitGroup
+	refreshErrs := make([]error, len(names))
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJobMP(net.EpMP(roles[i], roles), roles[i], names)
+			if err != nil {
+				refreshErrs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsamp.ThresholdRefresh(ctx, job, &ecdsamp.ThresholdRefreshParams{
+				Key:                results[i].Key,
+				AccessStructure:    results[i].AccessStructure,
+				QuorumPartyIndices: quorum,
+			})
+			if err != nil {
+				refreshErrs[i] = err
+				return
+			}
+			defer res.NewKey.Close()
+			got, err := res.NewKey.PublicKey()
+			if err == nil && !bytes.Equal(got, pub) {
+				err = errors.New("refresh changed the public key")
+			}
+			refreshErrs[i] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range refreshErrs {
+		if err != nil {
+			t.Fatalf("party %d threshold refresh failed: %v", i, err)
+		}
+	}
+
+	// They sign too: p0, p2 and p4 sign on a job of their own, and the
+	// signature verifies against the ecdsa2p public key.
+	signers := []string{names[0], names[2], names[4]}
+	signerRoles := []cbmpc.RoleID{0, 1, 2}
+	hash := sha256.Sum256([]byte("migrated"))
+	signNet := mocknet.New()
+	sigs := make([][]byte, len(quorum))
+	signErrs := make([]error, len(quorum))
+	for i, p := range quorum {
+		wg.Add(1)
+		go func(i, p int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJobMP(signNet.EpMP(signerRoles[i], signerRoles), signerRoles[i], signers)
+			if err != nil {
+				signErrs[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			res, err := ecdsamp.Sign(ctx, job, &ecdsamp.SignParams{Key: results[p].Key, Message: hash[:], SigReceiver: 0})
+			if err == nil {
+				sigs[i] = res.Signature
+			}
+			signErrs[i] = err
+		}(i, p)
+	}
+	wg.Wait()
+	for i, err := range signErrs {
+		if err != nil {
+			t.Fatalf("party %s Sign failed: %v", signers[i], err)
+		}
+	}
+	ecPub, err := results[0].Key.ECDSAPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ecdsa.VerifyASN1(ecPub, hash[:], sigs[0]) {
+		t.Fatal("migrated key's signature does not verify against the ecdsa2p public key")
+	}
+}
+
+func TestECDSA2PToMPRejectsWrongPublicKey(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	keys := dkg2p(t, ctx)
+	other := dkg2p(t, ctx)
+	wrong, err := other[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{"p0", "p1", "p2"}
+	_, errs := runMigration(ctx, len(names), func(i int) *migrate.ECDSA2PToMPParams {
+		p := &migrate.ECDSA2PToMPParams{
+			Self:      cbmpc.RoleID(i),
+			Names:     names,
+			Holders:   [2]cbmpc.RoleID{0, 1},
+			Threshold: 2,
+		}
+		if i < 2 {
+			p.Key = keys[i]
+		} else {
+			p.PublicKey = wrong
+		}
+		return p
+	})
+	if !errors.Is(errs[2], migrate.ErrInconsistentDeal) {
+		t.Fatalf("party 2 error = %v, want ErrInconsistentDeal", errs[2])
+	}
+}
+
+func TestECDSA2PToMPValidation(t *testing.T) {
+	ctx := context.Background()
+	ep := mocknet.New().EpMP(0, []cbmpc.RoleID{0, 1, 2})
+	names := []string{"a", "b", "c"}
+	tests := []struct {
+		name   string
+		params *migrate.ECDSA2PToMPParams
+	}{
+		{"nil params", nil},
+		{"too few parties", &migrate.ECDSA2PToMPParams{Names: names[:1], Holders: [2]cbmpc.RoleID{0, 1}, Threshold: 2}},
+		{"same holders", &migrate.ECDSA2PToMPParams{Names: names, Holders: [2]cbmpc.RoleID{1, 1}, Threshold: 2}},
+		{"holder out of range", &migrate.ECDSA2PToMPParams{Names: names, Holders: [2]cbmpc.RoleID{1, 3}, Threshold: 2}},
+		{"threshold 1", &migrate.ECDSA2PToMPParams{Names: names, Holders: [2]cbmpc.RoleID{1, 2}, Threshold: 1}},
+		{"threshold above n", &migrate.ECDSA2PToMPParams{Names: names, Holders: [2]cbmpc.RoleID{1, 2}, Threshold: 4}},
+		{"holder without key", &migrate.ECDSA2PToMPParams{Names: names, Holders: [2]cbmpc.RoleID{0, 1}, Threshold: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := migrate.ECDSA2PToMP(ctx, ep, tt.params); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+	if _, err := migrate.ECDSA2PToMP(ctx, nil, &migrate.ECDSA2PToMPParams{}); !errors.Is(err, cbmpc.ErrNilTransport) {
+		t.Fatalf("nil transport error = %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package mocknet
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// AnyPeer and AnyRound widen an Adversary rule to every recipient or to every
+// message sent to the recipient.
+const (
+	AnyPeer  = cbmpc.RoleID(math.MaxUint32)
+	AnyRound = -1
+)
+
+// Action names what an Adversary did to an outgoing message.
+type Action string
+
+const (
+	ActionDrop       Action = "drop"
+	ActionCorrupt    Action = "corrupt"
+	ActionSubstitute Action = "substitute"
+	ActionTamper     Action = "tamper"
+	ActionReplay     Action = "replay"
+)
+
+// Interception records one message an Adversary acted on.
+type Interception struct {
+	To     cbmpc.RoleID
+	Round  int
+	Action Action
+}
+
+type adversaryRule struct {
+	to     cbmpc.RoleID
+	round  int
+	action Action
+	apply  func(msg []byte) []byte // nil result drops the message
+	replay bool                    // deliver the message twice
+}
+
+// Adversary wraps the transport of one party and rewrites the messages it
+// sends, so tests can check that the honest parties abort when a peer
+// misbehaves. Rules select messages by recipient and round, where round is
+// the zero-based index of the message among those the party has sent to that
+// recipient; the first matching rule in the order they were added applies.
+// Messages no rule matches, and everything the party receives, pass through
+// unchanged.
+//
+//	adv := mocknet.NewAdversary(net.Ep2P(0, 1)).Corrupt(1, 0)
+//	job, _ := cbmpc.NewJob2P(adv, cbmpc.RoleP1, names)
+//
+// A dropped message is never delivered, so the recipient fails only when its
+// context expires: give honest parties a deadline.
+type Adversary struct {
+	inner cbmpc.Transport
+
+	mu    sync.Mutex
+	rules []adversaryRule
+	sent  map[cbmpc.RoleID]int
+	log   []Interception
+}
+
+// NewAdversary returns an Adversary sending through t. With no rules added it
+// behaves exactly like t.
+func NewAdversary(t cbmpc.Transport) *Adversary {
+	return &Adversary{inner: t, sent: make(map[cbmpc.RoleID]int)}
+}
+
+// Drop discards the matching messages.
+func (a *Adversary) Drop(to cbmpc.RoleID, round int) *Adversary {
+	return a.add(to, round, ActionDrop, func([]byte) []byte { return nil })
+}
+
+// Corrupt flips every bit of the middle byte of the matching messages, which
+// leaves their framing intact so the corruption reaches the protocol's own
+// checks rather than failing to parse.
+func (a *Adversary) Corrupt(to cbmpc.RoleID, round int) *Adversary {
+	return a.add(to, round, ActionCorrupt, func(msg []byte) []byte {
+		if len(msg) > 0 {
+			msg[len(msg)/2] ^= 0xff
+		}
+		return msg
+	})
+}
+
+// Substitute replaces the matching messages with msg, such as a message
+// recorded from another session.
+func (a *Adversary) Substitute(to cbmpc.RoleID, round int, msg []byte) *Adversary {
+	msg = append([]byte(nil), msg...)
+	return a.add(to, round, ActionSubstitute, func([]byte) []byte { return append([]byte(nil), msg...) })
+}
+
+// Tamper passes a copy of each matching message to f and sends what f
+// returns instead; returning nil drops the message.
+func (a *Adversary) Tamper(to cbmpc.RoleID, round int, f func(msg []byte) []byte) *Adversary {
+	return a.add(to, round, ActionTamper, f)
+}
+
+// Replay delivers the matching messages twice. When the Adversary wraps a
+// mocknet endpoint the copy keeps the original's sequence number, as a broker
+// redelivering a message would, and the recipient's receive fails with
+// cbmpc.ErrReplay. Over other transports the copy is sent as a new message.
+func (a *Adversary) Replay(to cbmpc.RoleID, round int) *Adversary {
+	return a.addRule(adversaryRule{to: to, round: round, action: ActionReplay, apply: func(msg []byte) []byte { return msg }, replay: true})
+}
+
+func (a *Adversary) add(to cbmpc.RoleID, round int, action Action, f func([]byte) []byte) *Adversary {
+	return a.addRule(adversaryRule{to: to, round: round, action: action, apply: f})
+}
+
+func (a *Adversary) addRule(r adversaryRule) *Adversary {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.rules = append(a.rules, r)
+	return a
+}
+
+// Interceptions returns the messages the Adversary has acted on, in order.
+// Tests use it to confirm that a rule actually fired.
+func (a *Adversary) Interceptions() []Interception {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]Interception(nil), a.log...)
+}
+
+func (a *Adversary) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	a.mu.Lock()
+	round := a.sent[to]
+	a.sent[to]++
+	var rule *adversaryRule
+	for i := range a.rules {
+		r := &a.rules[i]
+		if (r.to == AnyPeer || r.to == to) && (r.round == AnyRound || r.round == round) {
+			rule = r
+			a.log = append(a.log, Interception{To: to, Round: round, Action: r.action})
+			break
+		}
+	}
+	a.mu.Unlock()
+
+	if rule != nil {
+		cp := make([]byte, len(msg))
+		copy(cp, msg)
+		if msg = rule.apply(cp); msg == nil {
+			return nil
+		}
+	}
+	if err := a.inner.Send(ctx, to, msg); err != nil || rule == nil || !rule.replay {
+		return err
+	}
+	if r, ok := a.inner.(interface{ redeliver(cbmpc.RoleID) error }); ok {
+		return r.redeliver(to)
+	}
+	return a.inner.Send(ctx, to, msg)
+}
+
+func (a *Adversary) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
+	return a.inner.Receive(ctx, from)
+}
+
+func (a *Adversary) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	return a.inner.ReceiveAll(ctx, from)
+}
+
+// Done forwards to the wrapped endpoint, so an Adversary can stand in for a
+// party on a deterministic network.
+func (a *Adversary) Done() {
+	if d, ok := a.inner.(interface{ Done() }); ok {
+		d.Done()
+	}
+}
+
+var _ cbmpc.Transport = (*Adversary)(nil)
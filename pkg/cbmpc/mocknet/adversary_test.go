@@ -0,0 +1,186 @@
+package mocknet
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// sealed appends a SHA-256 checksum to payload; openSealed rejects messages
+// whose checksum does not match, standing in for a protocol's own checks.
+func sealed(payload []byte) []byte {
+	sum := sha256.Sum256(payload)
+	return append(append([]byte(nil), payload...), sum[:]...)
+}
+
+func openSealed(msg []byte) ([]byte, error) {
+	if len(msg) < sha256.Size {
+		return nil, errors.New("short message")
+	}
+	payload := msg[:len(msg)-sha256.Size]
+	if sum := sha256.Sum256(payload); !bytes.Equal(sum[:], msg[len(payload):]) {
+		return nil, errors.New("checksum mismatch")
+	}
+	return payload, nil
+}
+
+// exchange has the adversarial party 0 send rounds sealed messages to party
+// 1 and returns what party 1 accepted, stopping at the first rejection.
+func exchange(ctx context.Context, adv *Adversary, honest cbmpc.Transport, rounds int) ([][]byte, error) {
+	for r := 0; r < rounds; r++ {
+		if err := adv.Send(ctx, 1, sealed([]byte{byte(r)})); err != nil {
+			return nil, err
+		}
+	}
+	var got [][]byte
+	for r := 0; r < rounds; r++ {
+		msg, err := honest.Receive(ctx, 0)
+		if err != nil {
+			return got, err
+		}
+		payload, err := openSealed(msg)
+		if err != nil {
+			return got, fmt.Errorf("round %d: %w", r, err)
+		}
+		got = append(got, payload)
+	}
+	return got, nil
+}
+
+func TestAdversaryPassThrough(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	net := New()
+	adv := NewAdversary(net.Ep2P(0, 1))
+	got, err := exchange(ctx, adv, net.Ep2P(1, 0), 3)
+	if err != nil || len(got) != 3 {
+		t.Fatalf("exchange = %v, %v", got, err)
+	}
+	if n := len(adv.Interceptions()); n != 0 {
+		t.Fatalf("%d interceptions without rules", n)
+	}
+}
+
+func TestAdversaryCorrupt(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	net := New()
+	adv := NewAdversary(net.Ep2P(0, 1)).Corrupt(1, 1)
+	got, err := exchange(ctx, adv, net.Ep2P(1, 0), 3)
+	if err == nil || len(got) != 1 {
+		t.Fatalf("honest party accepted corrupted round: %v, %v", got, err)
+	}
+	want := []Interception{{To: 1, Round: 1, Action: ActionCorrupt}}
+	if i := adv.Interceptions(); !slices.Equal(i, want) {
+		t.Fatalf("interceptions = %v, want %v", i, want)
+	}
+}
+
+func TestAdversaryDrop(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	net := New()
+	adv := NewAdversary(net.Ep2P(0, 1)).Drop(1, AnyRound)
+	if _, err := exchange(ctx, adv, net.Ep2P(1, 0), 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want deadline exceeded", err)
+	}
+	if n := len(adv.Interceptions()); n != 2 {
+		t.Fatalf("%d interceptions, want 2", n)
+	}
+}
+
+func TestAdversarySubstituteAndTamper(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	net := New()
+	adv := NewAdversary(net.Ep2P(0, 1)).
+		Substitute(1, 0, sealed([]byte{9})).
+		Tamper(AnyPeer, 2, func(msg []byte) []byte { return sealed([]byte{7}) }).
+		Corrupt(AnyPeer, AnyRound) // shadowed by the rules above for rounds 0 and 2
+	honest := net.Ep2P(1, 0)
+
+	for r := 0; r < 3; r++ {
+		if err := adv.Send(ctx, 1, sealed([]byte{byte(r)})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var got []byte
+	for r := 0; r < 3; r++ {
+		msg, err := honest.Receive(ctx, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if payload, err := openSealed(msg); err == nil {
+			got = append(got, payload...)
+		} else {
+			got = append(got, 0xee)
+		}
+	}
+	if want := []byte{9, 0xee, 7}; !bytes.Equal(got, want) {
+		t.Fatalf("received %x, want %x", got, want)
+	}
+}
+
+func TestAdversaryRoundsPerPeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	net := New()
+	roles := []cbmpc.RoleID{0, 1, 2}
+	adv := NewAdversary(net.EpMP(0, roles)).Corrupt(AnyPeer, 0)
+	for _, to := range []cbmpc.RoleID{1, 2, 1} {
+		if err := adv.Send(ctx, to, sealed([]byte{1})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []Interception{
+		{To: 1, Round: 0, Action: ActionCorrupt},
+		{To: 2, Round: 0, Action: ActionCorrupt},
+	}
+	if i := adv.Interceptions(); !slices.Equal(i, want) {
+		t.Fatalf("interceptions = %v, want %v", i, want)
+	}
+}
+
+func TestAdversaryReplay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	net := New()
+	roles := []cbmpc.RoleID{0, 1, 2}
+	adv := NewAdversary(net.EpMP(0, roles)).Replay(1, 0)
+	if err := adv.Send(ctx, 1, sealed([]byte{0})); err != nil {
+		t.Fatal(err)
+	}
+	if err := adv.Send(ctx, 2, sealed([]byte{0})); err != nil {
+		t.Fatal(err)
+	}
+
+	// The replayed copy passes the checksum; only its sequence number
+	// gives it away.
+	p1 := net.EpMP(1, roles)
+	if _, err := p1.Receive(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p1.ReceiveAll(ctx, []cbmpc.RoleID{0}); !errors.Is(err, cbmpc.ErrReplay) {
+		t.Fatalf("replayed message: err = %v, want ErrReplay", err)
+	}
+	if _, err := net.EpMP(2, roles).Receive(ctx, 0); err != nil {
+		t.Fatalf("other peer: %v", err)
+	}
+	want := []Interception{{To: 1, Round: 0, Action: ActionReplay}}
+	if i := adv.Interceptions(); !slices.Equal(i, want) {
+		t.Fatalf("interceptions = %v, want %v", i, want)
+	}
+}
@@ -0,0 +1,164 @@
+package mocknet
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// ErrDeadlock is returned by receives on a deterministic network when every
+// party is waiting and none of them has a message to receive.
+var ErrDeadlock = errors.New("mocknet: deadlock, every party is waiting for a message that was never sent")
+
+// NewDeterministic returns a network that runs its parties one at a time and
+// lets seed decide which one proceeds whenever several could. Parties run
+// freely until they block in Receive or ReceiveAll; once every party is
+// blocked or done, the network picks one party whose messages have all
+// arrived, using a PRNG seeded with seed, and resumes it. The resulting
+// interleaving depends only on the seed and on what each party sends, so a
+// test failure that depends on scheduling reproduces exactly with the same
+// seed.
+//
+// The network must know when a party will make no further calls: every party
+// must call Done on its endpoint when it finishes, typically with defer, or
+// the other parties wait for it forever. Create one endpoint per party before
+// any party starts. Schedule reports the order in which parties were resumed.
+func NewDeterministic(seed uint64) *Net {
+	n := New()
+	n.sched = &scheduler{
+		rng:     rand.New(rand.NewPCG(seed, seed)),
+		parties: make(map[cbmpc.RoleID]*party),
+	}
+	return n
+}
+
+// Schedule returns the roles of the parties a deterministic network resumed,
+// in order. It returns nil for networks created with New.
+func (n *Net) Schedule() []cbmpc.RoleID {
+	if n.sched == nil {
+		return nil
+	}
+	n.sched.mu.Lock()
+	defer n.sched.mu.Unlock()
+	return slices.Clone(n.sched.log)
+}
+
+type partyState int
+
+const (
+	partyRunning partyState = iota
+	partyWaiting
+	partyDone
+)
+
+type party struct {
+	state partyState
+	from  []cbmpc.RoleID // peers a waiting party receives from
+	in    map[cbmpc.RoleID]*mailbox
+	wake  chan error
+}
+
+// scheduler admits one running party at a time on a deterministic network.
+type scheduler struct {
+	mu      sync.Mutex
+	rng     *rand.Rand
+	parties map[cbmpc.RoleID]*party
+	log     []cbmpc.RoleID
+}
+
+func (s *scheduler) register(e *endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.parties[e.self] = &party{in: e.in, wake: make(chan error, 1)}
+}
+
+// receive blocks until the scheduler resumes the party, then takes one
+// message from each of roles, which must be sorted.
+func (s *scheduler) receive(ctx context.Context, self cbmpc.RoleID, roles []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	s.mu.Lock()
+	p := s.parties[self]
+	p.state, p.from = partyWaiting, roles
+	s.step()
+	s.mu.Unlock()
+
+	var err error
+	select {
+	case err = <-p.wake:
+	case <-ctx.Done():
+		s.mu.Lock()
+		if p.state == partyWaiting {
+			// Not resumed yet: leave the schedule without taking a turn.
+			p.state = partyRunning
+			s.mu.Unlock()
+			return nil, ctx.Err()
+		}
+		s.mu.Unlock()
+		err = <-p.wake
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[cbmpc.RoleID][]byte, len(roles))
+	for _, r := range roles {
+		msg, _, err := p.in[r].tryPop()
+		if err != nil {
+			return nil, err
+		}
+		out[r] = msg
+	}
+	return out, nil
+}
+
+func (s *scheduler) done(self cbmpc.RoleID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p := s.parties[self]; p != nil && p.state != partyDone {
+		p.state = partyDone
+		s.step()
+	}
+}
+
+// step resumes one party if none is running. Callers hold s.mu.
+func (s *scheduler) step() {
+	roles := slices.Sorted(maps.Keys(s.parties))
+	var ready, waiting []cbmpc.RoleID
+	for _, r := range roles {
+		p := s.parties[r]
+		switch p.state {
+		case partyRunning:
+			return
+		case partyWaiting:
+			waiting = append(waiting, r)
+			if p.deliverable() {
+				ready = append(ready, r)
+			}
+		}
+	}
+	if len(ready) == 0 {
+		for _, r := range waiting {
+			p := s.parties[r]
+			p.state = partyRunning
+			p.wake <- ErrDeadlock
+		}
+		return
+	}
+	r := ready[s.rng.IntN(len(ready))]
+	p := s.parties[r]
+	p.state = partyRunning
+	s.log = append(s.log, r)
+	p.wake <- nil
+}
+
+func (p *party) deliverable() bool {
+	for _, r := range p.from {
+		if p.in[r].len() == 0 {
+			return false
+		}
+	}
+	return true
+}
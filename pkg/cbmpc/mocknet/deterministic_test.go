@@ -0,0 +1,147 @@
+package mocknet
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// gossip runs three rounds among n parties on a deterministic network: each
+// party sends to every peer, then receives from one peer at a time. It
+// returns the global order in which parties completed receives.
+func gossip(t *testing.T, seed uint64, n int) ([]cbmpc.RoleID, []cbmpc.RoleID) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	net := NewDeterministic(seed)
+	roles := make([]cbmpc.RoleID, n)
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+	}
+	eps := make([]*EndpointMP, n)
+	for i := range eps {
+		eps[i] = net.EpMP(roles[i], roles)
+	}
+
+	var (
+		mu    sync.Mutex
+		order []cbmpc.RoleID
+		wg    sync.WaitGroup
+	)
+	for i := range eps {
+		wg.Add(1)
+		go func(self cbmpc.RoleID) {
+			defer wg.Done()
+			ep := eps[self]
+			defer ep.Done()
+			for round := 0; round < 3; round++ {
+				for _, peer := range roles {
+					if peer != self {
+						if err := ep.Send(ctx, peer, []byte{byte(round)}); err != nil {
+							t.Error(err)
+							return
+						}
+					}
+				}
+				for _, peer := range roles {
+					if peer == self {
+						continue
+					}
+					msg, err := ep.Receive(ctx, peer)
+					if err != nil || msg[0] != byte(round) {
+						t.Errorf("party %d round %d: %v %v", self, round, msg, err)
+						return
+					}
+					mu.Lock()
+					order = append(order, self)
+					mu.Unlock()
+				}
+			}
+		}(roles[i])
+	}
+	wg.Wait()
+	return order, net.Schedule()
+}
+
+func TestDeterministicReplaysSchedule(t *testing.T) {
+	order, schedule := gossip(t, 42, 4)
+	for i := 0; i < 5; i++ {
+		again, againSchedule := gossip(t, 42, 4)
+		if !slices.Equal(order, again) || !slices.Equal(schedule, againSchedule) {
+			t.Fatalf("seed 42 produced different runs:\n%v\n%v", order, again)
+		}
+	}
+
+	differs := false
+	for seed := uint64(0); seed < 8 && !differs; seed++ {
+		other, _ := gossip(t, seed, 4)
+		differs = !slices.Equal(order, other)
+	}
+	if !differs {
+		t.Fatal("every seed produced the same interleaving")
+	}
+}
+
+func TestDeterministicDeadlock(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	net := NewDeterministic(1)
+	p1 := net.Ep2P(0, 1)
+	p2 := net.Ep2P(1, 0)
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i, ep := range []*Endpoint2P{p1, p2} {
+		wg.Add(1)
+		go func(i int, ep *Endpoint2P) {
+			defer wg.Done()
+			defer ep.Done()
+			_, errs[i] = ep.Receive(ctx, cbmpc.RoleID(1-i))
+		}(i, ep)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if !errors.Is(err, ErrDeadlock) {
+			t.Errorf("party %d: error = %v, want ErrDeadlock", i, err)
+		}
+	}
+}
+
+func TestDeterministicReceiveAll(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	net := NewDeterministic(7)
+	roles := []cbmpc.RoleID{0, 1, 2}
+	eps := []*EndpointMP{net.EpMP(0, roles), net.EpMP(1, roles), net.EpMP(2, roles)}
+	var got map[cbmpc.RoleID][]byte
+	var wg sync.WaitGroup
+	for i, ep := range eps {
+		wg.Add(1)
+		go func(self cbmpc.RoleID, ep *EndpointMP) {
+			defer wg.Done()
+			defer ep.Done()
+			if self != 0 {
+				_ = ep.Send(ctx, 0, []byte{byte(self)})
+				return
+			}
+			var err error
+			if got, err = ep.ReceiveAll(ctx, []cbmpc.RoleID{2, 1}); err != nil {
+				t.Error(err)
+			}
+		}(cbmpc.RoleID(i), ep)
+	}
+	wg.Wait()
+	if len(got) != 2 || got[1][0] != 1 || got[2][0] != 2 {
+		t.Fatalf("ReceiveAll = %v", got)
+	}
+	if s := net.Schedule(); !slices.Equal(s, []cbmpc.RoleID{0}) {
+		t.Fatalf("schedule = %v, want [0]", s)
+	}
+}
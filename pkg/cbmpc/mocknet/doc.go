@@ -7,83 +7,71 @@
 //
 // # Features
 //
-//   - Sequenced message delivery (guarantees message ordering)
+//   - Sequenced message delivery (guarantees message ordering); a message
+//     delivered twice or out of order fails the receive with cbmpc.ErrReplay
 //   - Support for both 2-party and multi-party protocols
 //   - Context-based cancellation support
 //   - Thread-safe concurrent operations
+//   - One FIFO mailbox per directed pair, resolved when the endpoint is
+//     created, so committees of 100+ parties do not contend on shared locks
 //   - No external dependencies (pure Go)
 //
 // # Usage
 //
-// Create a network and endpoints for each party:
+// Create a network and endpoints for each party, then jobs on them:
 //
-//	import (
-//	    "github.com/coinbase/cb-mpc-go/pkg/cbmpc"
-//	    "github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
-//	)
-//
-//	// Create mock network
 //	net := mocknet.New()
 //
 //	// Two-party setup
 //	ep1 := net.Ep2P(cbmpc.RoleID(0), cbmpc.RoleID(1)) // Party 0 communicates with Party 1
 //	ep2 := net.Ep2P(cbmpc.RoleID(1), cbmpc.RoleID(0)) // Party 1 communicates with Party 0
-//
-//	// Multi-party setup (3 parties)
-//	allParties := []cbmpc.RoleID{0, 1, 2}
-//	ep1 := net.EpMP(cbmpc.RoleID(0), allParties)
-//	ep2 := net.EpMP(cbmpc.RoleID(1), allParties)
-//	ep3 := net.EpMP(cbmpc.RoleID(2), allParties)
-//
-// # Creating Jobs
-//
-// Use mocknet endpoints to create Job2P or JobMP instances:
-//
-//	// Two-party job
 //	job1, _ := cbmpc.NewJob2PWithContext(ctx, ep1, cbmpc.RoleP1, [2]string{"party1", "party2"})
+//	job2, _ := cbmpc.NewJob2PWithContext(ctx, ep2, cbmpc.RoleP2, [2]string{"party1", "party2"})
 //	defer job1.Close()
+//	defer job2.Close()
 //
-//	// Multi-party job (threshold t=1, 3 parties total)
-//	jobMP, _ := cbmpc.NewJobMPWithContext(ctx, epMP, cbmpc.RoleID(0), 1, 3, []string{"p0", "p1", "p2"})
+//	// Multi-party setup (3 parties)
+//	allParties := []cbmpc.RoleID{0, 1, 2}
+//	epMP := net.EpMP(cbmpc.RoleID(0), allParties)
+//	jobMP, _ := cbmpc.NewJobMPWithContext(ctx, epMP, cbmpc.RoleID(0), []string{"p0", "p1", "p2"})
 //	defer jobMP.Close()
 //
 // # Running Protocols
 //
-// Run protocol operations concurrently using goroutines:
+// Run each party in its own goroutine, as peers run in their own processes:
 //
 //	var wg sync.WaitGroup
-//	wg.Add(2)
-//
-//	go func() {
-//	    defer wg.Done()
-//	    result1, _ := ecdsa2p.DKG(ctx, job1, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
-//	    defer result1.Key.Close()
-//	}()
-//
-//	go func() {
-//	    defer wg.Done()
-//	    result2, _ := ecdsa2p.DKG(ctx, job2, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
-//	    defer result2.Key.Close()
-//	}()
-//
+//	for _, job := range []*cbmpc.Job2P{job1, job2} {
+//	    wg.Add(1)
+//	    go func() {
+//	        defer wg.Done()
+//	        res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: cbmpc.CurveP256})
+//	        // check err, defer res.Key.Close()
+//	    }()
+//	}
 //	wg.Wait()
 //
 // # Testing Tips
 //
 //   - Always use context.WithTimeout to prevent test hangs
-//   - Run parties in separate goroutines to simulate concurrent execution
-//   - Use sync.WaitGroup to coordinate protocol completion
-//   - Check for errors from both parties (protocol failures should be symmetric)
+//   - Check for errors from every party (protocol failures should be symmetric)
+//   - NewDeterministic schedules parties from a seed, so a failure that
+//     depends on goroutine interleaving can be replayed from the logged seed
+//   - Adversary drops, corrupts, substitutes or replays chosen messages one
+//     party sends, so tests can check that the honest parties abort
+//
+// README.md shows deterministic scheduling and adversarial tests with
+// examples.
 //
 // # Limitations
 //
 // Mocknet is designed for testing and examples only:
 //   - No encryption or authentication
 //   - No network latency simulation
-//   - No packet loss or reordering
+//   - No packet loss; delivery order varies only under NewDeterministic
 //   - Not suitable for production use
 //
 // For production deployments, implement cbmpc.Transport using actual network
-// protocols (e.g., TLS, gRPC, WebSocket). See examples/tlsnet for a TLS-based
+// protocols (e.g., TLS, gRPC, WebSocket). See pkg/cbmpc/tlsnet for a TLS-based
 // transport implementation.
 package mocknet
@@ -11,178 +11,224 @@ import (
 )
 
 type Net struct {
-	mu sync.Mutex
-	q  map[queueKey]chan []byte
+	mu    sync.RWMutex
+	boxes map[pairKey]*mailbox
+	sched *scheduler // set by NewDeterministic
 }
 
-func New() *Net { return &Net{q: make(map[queueKey]chan []byte)} }
+func New() *Net { return &Net{boxes: make(map[pairKey]*mailbox)} }
 
-type queueKey struct {
+type pairKey struct {
 	from cbmpc.RoleID
 	to   cbmpc.RoleID
-	seq  uint64
 }
 
-type endpointCore struct {
-	net  *Net
-	self cbmpc.RoleID
-
-	mu        sync.Mutex
-	sendSeq   map[cbmpc.RoleID]uint64
-	recvSeq   map[cbmpc.RoleID]uint64
-	sendLocks map[cbmpc.RoleID]*sync.Mutex
-	recvLocks map[cbmpc.RoleID]*sync.Mutex
+// mailbox is an unbounded FIFO queue for one directed pair of parties.
+// Ordering per pair is all the native library relies on, so a single queue
+// replaces per-sequence slots and keeps the shared Net map off the hot path.
+//
+// Each message carries the sequence number the mailbox assigned when it was
+// sent, and the receiving side rejects any message that is not the next one,
+// as the cbmpc.Transport contract requires of real transports.
+type mailbox struct {
+	from  cbmpc.RoleID
+	mu    sync.Mutex
+	queue []frame
+	sent  uint64 // sequence number of the next message pushed
+	next  uint64 // sequence number the receiver expects
+	last  frame  // most recently pushed message, for redeliver
+	ready chan struct{}
 }
 
-func newEndpointCore(n *Net, self cbmpc.RoleID) *endpointCore {
-	return &endpointCore{
-		net:       n,
-		self:      self,
-		sendSeq:   make(map[cbmpc.RoleID]uint64),
-		recvSeq:   make(map[cbmpc.RoleID]uint64),
-		sendLocks: make(map[cbmpc.RoleID]*sync.Mutex),
-		recvLocks: make(map[cbmpc.RoleID]*sync.Mutex),
-	}
+type frame struct {
+	seq uint64
+	msg []byte
 }
 
-func (c *endpointCore) key(from, to cbmpc.RoleID, seq uint64) queueKey {
-	return queueKey{from: from, to: to, seq: seq}
+func newMailbox(from cbmpc.RoleID) *mailbox {
+	return &mailbox{from: from, ready: make(chan struct{}, 1)}
 }
 
-func (c *endpointCore) sendLock(role cbmpc.RoleID) *sync.Mutex {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	lock := c.sendLocks[role]
-	if lock == nil {
-		lock = &sync.Mutex{}
-		c.sendLocks[role] = lock
-	}
-	return lock
+func (m *mailbox) push(msg []byte) {
+	m.mu.Lock()
+	m.last = frame{seq: m.sent, msg: msg}
+	m.sent++
+	m.queue = append(m.queue, m.last)
+	m.mu.Unlock()
+	m.signal()
 }
 
-func (c *endpointCore) recvLock(role cbmpc.RoleID) *sync.Mutex {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	lock := c.recvLocks[role]
-	if lock == nil {
-		lock = &sync.Mutex{}
-		c.recvLocks[role] = lock
+// redeliver queues the most recent message again under its original sequence
+// number, as a broker with at-least-once delivery might.
+func (m *mailbox) redeliver() {
+	m.mu.Lock()
+	if m.sent == 0 {
+		m.mu.Unlock()
+		return
 	}
-	return lock
+	m.queue = append(m.queue, frame{seq: m.last.seq, msg: append([]byte(nil), m.last.msg...)})
+	m.mu.Unlock()
+	m.signal()
 }
 
-func (c *endpointCore) currentSendSeq(role cbmpc.RoleID) uint64 {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	return c.sendSeq[role]
-}
+func (m *mailbox) pop(ctx context.Context) ([]byte, error) {
+	for {
+		m.mu.Lock()
+		if len(m.queue) > 0 {
+			msg, err := m.take()
+			more := len(m.queue) > 0
+			m.mu.Unlock()
+			if more {
+				// Pass the wakeup on in case another receiver is waiting.
+				m.signal()
+			}
+			return msg, err
+		}
+		m.mu.Unlock()
 
-func (c *endpointCore) advanceSendSeq(role cbmpc.RoleID) {
-	c.mu.Lock()
-	c.sendSeq[role]++
-	c.mu.Unlock()
+		select {
+		case <-m.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
 }
 
-func (c *endpointCore) currentRecvSeq(role cbmpc.RoleID) uint64 {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	return c.recvSeq[role]
+// take removes the head of the queue and checks its sequence number. Callers
+// hold m.mu and have checked that the queue is not empty.
+func (m *mailbox) take() ([]byte, error) {
+	f := m.queue[0]
+	m.queue[0] = frame{}
+	m.queue = m.queue[1:]
+	if len(m.queue) == 0 {
+		m.queue = nil
+	}
+	if f.seq != m.next {
+		return nil, fmt.Errorf("mocknet: %w: message %d from %d, expected %d", cbmpc.ErrReplay, f.seq, m.from, m.next)
+	}
+	m.next++
+	return f.msg, nil
 }
 
-func (c *endpointCore) advanceRecvSeq(role cbmpc.RoleID) {
-	c.mu.Lock()
-	c.recvSeq[role]++
-	c.mu.Unlock()
+func (m *mailbox) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.queue)
 }
 
-func (n *Net) slot(key queueKey) chan []byte {
-	n.mu.Lock()
-	defer n.mu.Unlock()
-	ch := n.q[key]
-	if ch == nil {
-		ch = make(chan []byte, 1)
-		n.q[key] = ch
+func (m *mailbox) tryPop() ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.queue) == 0 {
+		return nil, false, nil
 	}
-	return ch
+	msg, err := m.take()
+	return msg, true, err
 }
 
-func (n *Net) deliver(ctx context.Context, key queueKey, payload []byte) error {
-	ch := n.slot(key)
-	msg := append([]byte(nil), payload...)
+func (m *mailbox) signal() {
 	select {
-	case ch <- msg:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
+	case m.ready <- struct{}{}:
+	default:
 	}
 }
 
-func (n *Net) await(ctx context.Context, key queueKey) ([]byte, error) {
-	ch := n.slot(key)
-	select {
-	case msg := <-ch:
-		n.mu.Lock()
-		delete(n.q, key)
-		n.mu.Unlock()
-		return msg, nil
-	case <-ctx.Done():
-		return nil, ctx.Err()
+// mailbox returns the queue for messages from -> to, creating it on first use.
+func (n *Net) mailbox(from, to cbmpc.RoleID) *mailbox {
+	key := pairKey{from: from, to: to}
+	n.mu.RLock()
+	box := n.boxes[key]
+	n.mu.RUnlock()
+	if box != nil {
+		return box
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if box = n.boxes[key]; box == nil {
+		box = newMailbox(from)
+		n.boxes[key] = box
 	}
+	return box
 }
 
+// endpoint resolves its inbound and outbound mailboxes once at construction,
+// so Send and Receive take only the lock of the pair they touch.
 type endpoint struct {
-	core  *endpointCore
-	peers map[cbmpc.RoleID]struct{}
+	self  cbmpc.RoleID
+	out   map[cbmpc.RoleID]*mailbox
+	in    map[cbmpc.RoleID]*mailbox
+	sched *scheduler
 }
 
 func newEndpoint(n *Net, self cbmpc.RoleID, peers []cbmpc.RoleID) *endpoint {
-	peerSet := make(map[cbmpc.RoleID]struct{}, len(peers))
+	e := &endpoint{
+		self: self,
+		out:  make(map[cbmpc.RoleID]*mailbox, len(peers)),
+		in:   make(map[cbmpc.RoleID]*mailbox, len(peers)),
+	}
 	for _, p := range peers {
 		if p == self {
 			continue
 		}
-		peerSet[p] = struct{}{}
+		e.out[p] = n.mailbox(self, p)
+		e.in[p] = n.mailbox(p, self)
+	}
+	if n.sched != nil {
+		e.sched = n.sched
+		e.sched.register(e)
+	}
+	return e
+}
+
+// Done tells a deterministic network that the party will make no further
+// calls, so the remaining parties can be scheduled. It is a no-op on networks
+// created with New.
+func (e *endpoint) Done() {
+	if e.sched != nil {
+		e.sched.done(e.self)
 	}
-	return &endpoint{core: newEndpointCore(n, self), peers: peerSet}
 }
 
 func (e *endpoint) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
-	if to == e.core.self {
+	if to == e.self {
 		return errors.New("mocknet: send to self")
 	}
-	if _, ok := e.peers[to]; !ok {
+	box, ok := e.out[to]
+	if !ok {
 		return fmt.Errorf("mocknet: unknown peer %d", to)
 	}
-	lock := e.core.sendLock(to)
-	lock.Lock()
-	defer lock.Unlock()
-
-	seq := e.core.currentSendSeq(to)
-	if err := e.core.net.deliver(ctx, e.core.key(e.core.self, to, seq), msg); err != nil {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
-	e.core.advanceSendSeq(to)
+	box.push(append([]byte(nil), msg...))
+	return nil
+}
+
+// redeliver queues the last message sent to to a second time under its
+// original sequence number.
+func (e *endpoint) redeliver(to cbmpc.RoleID) error {
+	box, ok := e.out[to]
+	if !ok {
+		return fmt.Errorf("mocknet: unknown peer %d", to)
+	}
+	box.redeliver()
 	return nil
 }
 
 func (e *endpoint) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
-	if from == e.core.self {
+	if from == e.self {
 		return nil, errors.New("mocknet: receive from self")
 	}
-	if _, ok := e.peers[from]; !ok {
+	box, ok := e.in[from]
+	if !ok {
 		return nil, fmt.Errorf("mocknet: unknown peer %d", from)
 	}
-	lock := e.core.recvLock(from)
-	lock.Lock()
-	defer lock.Unlock()
-
-	seq := e.core.currentRecvSeq(from)
-	msg, err := e.core.net.await(ctx, e.core.key(from, e.core.self, seq))
-	if err != nil {
-		return nil, err
+	if e.sched != nil {
+		msgs, err := e.sched.receive(ctx, e.self, []cbmpc.RoleID{from})
+		return msgs[from], err
 	}
-	e.core.advanceRecvSeq(from)
-	return msg, nil
+	return box.pop(ctx)
 }
 
 func (e *endpoint) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
@@ -190,31 +236,17 @@ func (e *endpoint) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbm
 	if err != nil {
 		return nil, err
 	}
-	if len(roles) == 0 {
-		return map[cbmpc.RoleID][]byte{}, nil
-	}
 
-	locks := make([]*sync.Mutex, len(roles))
-	for i, role := range roles {
-		lock := e.core.recvLock(role)
-		lock.Lock()
-		locks[i] = lock
+	if e.sched != nil {
+		return e.sched.receive(ctx, e.self, roles)
 	}
-	defer func() {
-		for _, lock := range locks {
-			lock.Unlock()
-		}
-	}()
-
 	out := make(map[cbmpc.RoleID][]byte, len(roles))
 	for _, role := range roles {
-		seq := e.core.currentRecvSeq(role)
-		msg, err := e.core.net.await(ctx, e.core.key(role, e.core.self, seq))
+		msg, err := e.in[role].pop(ctx)
 		if err != nil {
 			return nil, err
 		}
 		out[role] = msg
-		e.core.advanceRecvSeq(role)
 	}
 	return out, nil
 }
@@ -222,10 +254,10 @@ func (e *endpoint) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbm
 func (e *endpoint) normalizeRoles(from []cbmpc.RoleID) ([]cbmpc.RoleID, error) {
 	uniq := make(map[cbmpc.RoleID]struct{}, len(from))
 	for _, role := range from {
-		if role == e.core.self {
+		if role == e.self {
 			return nil, errors.New("mocknet: receive from self")
 		}
-		if _, ok := e.peers[role]; !ok {
+		if _, ok := e.in[role]; !ok {
 			return nil, fmt.Errorf("mocknet: unknown peer %d", role)
 		}
 		if _, ok := uniq[role]; ok {
@@ -2,6 +2,7 @@ package mocknet
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -182,3 +183,122 @@ func TestReceiveErrors(t *testing.T) {
 		t.Fatalf("expected duplicate error in ReceiveAll")
 	}
 }
+
+func TestConcurrentReceiversSamePeer(t *testing.T) {
+	net := New()
+	tx := net.Ep2P(0, 1)
+	rx := net.Ep2P(1, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	const msgs = 64
+	var wg sync.WaitGroup
+	got := make(chan byte, msgs)
+	for i := 0; i < msgs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			msg, err := rx.Receive(ctx, 0)
+			if err != nil {
+				t.Errorf("receive: %v", err)
+				return
+			}
+			got <- msg[0]
+		}()
+	}
+	for i := 0; i < msgs; i++ {
+		if err := tx.Send(ctx, 1, []byte{byte(i)}); err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+	wg.Wait()
+	close(got)
+
+	seen := make(map[byte]bool, msgs)
+	for b := range got {
+		seen[b] = true
+	}
+	if len(seen) != msgs {
+		t.Fatalf("expected %d distinct messages, got %d", msgs, len(seen))
+	}
+}
+
+func TestReceiveCancelled(t *testing.T) {
+	net := New()
+	ep := net.Ep2P(0, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ep.Receive(ctx, 1); err == nil {
+		t.Fatal("expected error from cancelled receive")
+	}
+	if err := ep.Send(ctx, 1, []byte{1}); err == nil {
+		t.Fatal("expected error from cancelled send")
+	}
+}
+
+// allToAll runs rounds of every party broadcasting to every other party and
+// collecting the round with ReceiveAll, the dominant pattern in MP protocols.
+func allToAll(tb testing.TB, n, rounds int) {
+	tb.Helper()
+	net := New()
+	roles := make([]cbmpc.RoleID, n)
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+	}
+	eps := make([]*EndpointMP, n)
+	for i, self := range roles {
+		eps[i] = net.EpMP(self, roles)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	msg := make([]byte, 64)
+	var wg sync.WaitGroup
+	for i, ep := range eps {
+		wg.Add(1)
+		go func(self cbmpc.RoleID, ep *EndpointMP) {
+			defer wg.Done()
+			peers := make([]cbmpc.RoleID, 0, n-1)
+			for _, r := range roles {
+				if r != self {
+					peers = append(peers, r)
+				}
+			}
+			for r := 0; r < rounds; r++ {
+				for _, p := range peers {
+					if err := ep.Send(ctx, p, msg); err != nil {
+						tb.Errorf("send %d->%d: %v", self, p, err)
+						return
+					}
+				}
+				batch, err := ep.ReceiveAll(ctx, peers)
+				if err != nil {
+					tb.Errorf("receiveAll %d: %v", self, err)
+					return
+				}
+				if len(batch) != len(peers) {
+					tb.Errorf("receiveAll %d: got %d messages", self, len(batch))
+					return
+				}
+			}
+		}(roles[i], ep)
+	}
+	wg.Wait()
+}
+
+func TestNetEpMPLargeCommittee(t *testing.T) {
+	allToAll(t, 128, 3)
+}
+
+func BenchmarkAllToAll(b *testing.B) {
+	for _, n := range []int{16, 64, 128} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				allToAll(b, n, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Package natsnet provides a cbmpc.Transport over NATS JetStream, for
+// deployments where parties cannot open TCP connections to each other and
+// instead reach a shared broker.
+//
+// Each ordered pair of parties exchanges messages on its own subject,
+// "<prefix>.<session>.<from>.<to>", read through a durable consumer. The
+// stream persists every message until the receiving party acknowledges it,
+// so a party that disconnects from the broker picks up where it left off.
+// JetStream delivers at least once; the transport numbers the messages to
+// each peer and drops redeliveries, holding messages that arrive ahead of a
+// redelivered one, so the protocol sees each message exactly once and in
+// order.
+//
+// The module does not depend on a NATS client. New takes a Stream, which a
+// nats.go JetStream handle satisfies through the small adapter shown in the
+// Stream documentation:
+//
+//	js, _ := jetstream.New(nc)
+//	stream, _ := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+//	    Name:     "CBMPC",
+//	    Subjects: []string{"cbmpc.>"},
+//	})
+//	t, err := natsnet.New(natsnet.Config{
+//	    Stream:    jsStream{js, stream},
+//	    Self:      0,
+//	    Names:     []string{"p0", "p1"},
+//	    SessionID: sid,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer t.Close()
+//	job, err := cbmpc.NewJob2P(t, cbmpc.RoleP1, [2]string{"p0", "p1"})
+//
+// Use a fresh SessionID for every protocol run: the sequence numbers restart
+// with each Transport, and the session keeps runs on one stream apart.
+//
+// The broker sees every message. Wrap the transport with securenet to keep
+// message contents from it and to detect tampering.
+package natsnet
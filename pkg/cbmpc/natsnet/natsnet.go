@@ -0,0 +1,383 @@
+package natsnet
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// ErrClosed is returned by calls on a closed Transport.
+var ErrClosed = errors.New("natsnet: transport closed")
+
+// DefaultPrefix is the first subject token when Config.Prefix is empty.
+const DefaultPrefix = "cbmpc"
+
+// maxPending bounds the messages held per peer while an earlier one is
+// missing, so a broker that never redelivers it cannot grow memory without
+// limit.
+const maxPending = 1024
+
+// Msg is a message delivered by a Stream. It must be acknowledged once the
+// transport has taken it; unacknowledged messages are redelivered.
+type Msg interface {
+	Data() []byte
+	Ack() error
+}
+
+// Stream is the subset of a JetStream context the transport uses. The
+// stream behind it must capture the transport's subjects ("cbmpc.>" with the
+// default prefix) and persist messages until they are acknowledged.
+//
+// A jetstream.Msg from github.com/nats-io/nats.go/jetstream satisfies Msg,
+// and a JetStream stream handle is adapted with:
+//
+//	type jsStream struct {
+//	    js     jetstream.JetStream
+//	    stream jetstream.Stream
+//	}
+//
+//	func (s jsStream) Publish(ctx context.Context, subject string, data []byte) error {
+//	    _, err := s.js.Publish(ctx, subject, data)
+//	    return err
+//	}
+//
+//	func (s jsStream) Consume(subject, durable string, handle func(natsnet.Msg)) (func(), error) {
+//	    c, err := s.stream.CreateOrUpdateConsumer(context.Background(), jetstream.ConsumerConfig{
+//	        Durable:       durable,
+//	        FilterSubject: subject,
+//	        AckPolicy:     jetstream.AckExplicitPolicy,
+//	        DeliverPolicy: jetstream.DeliverAllPolicy,
+//	    })
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    cc, err := c.Consume(func(m jetstream.Msg) { handle(m) })
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return cc.Stop, nil
+//	}
+type Stream interface {
+	// Publish stores data on subject and returns once the server has
+	// acknowledged storing it.
+	Publish(ctx context.Context, subject string, data []byte) error
+	// Consume delivers the messages on subject to handle through the
+	// durable consumer named durable, starting with the oldest message it
+	// has not acknowledged, until stop is called. Delivery is at least
+	// once: a message may be delivered again, and redeliveries may arrive
+	// out of order.
+	Consume(subject, durable string, handle func(Msg)) (stop func(), err error)
+}
+
+// Config configures a Transport.
+type Config struct {
+	// Stream carries the messages.
+	Stream Stream
+	// Self is this party's index in Names.
+	Self int
+	// Names are the party names, in role order. Each must be a single
+	// subject token: no '.', '*', '>' or whitespace.
+	Names []string
+	// SessionID scopes the subjects and consumers of one protocol run, so
+	// runs sharing a stream do not see each other's messages. All parties
+	// must use the same value, and it must be a single subject token.
+	SessionID string
+	// Prefix is the first subject token. Empty selects DefaultPrefix.
+	Prefix string
+}
+
+// Transport is a cbmpc.Transport over a NATS JetStream stream. Each ordered
+// pair of parties has its own subject,
+//
+//	<prefix>.<session>.<from>.<to>
+//
+// and its own durable consumer. Messages carry a sequence number, so the
+// receiver delivers each message once and in order however often and in
+// whatever order the broker delivers it.
+type Transport struct {
+	stream Stream
+	self   cbmpc.RoleID
+	peers  map[cbmpc.RoleID]*peer
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   sync.Once
+}
+
+type peer struct {
+	subject string // messages to this peer
+
+	sendMu  sync.Mutex
+	sendSeq uint64
+
+	stop  func()
+	ready chan struct{}
+
+	mu       sync.Mutex
+	recvNext uint64            // sequence number of the next message to queue
+	pending  map[uint64][]byte // received ahead of recvNext
+	inbox    [][]byte
+	err      error
+}
+
+// New subscribes to the subjects of messages addressed to this party and
+// returns the transport.
+func New(cfg Config) (*Transport, error) {
+	if cfg.Stream == nil {
+		return nil, errors.New("natsnet: stream required")
+	}
+	if len(cfg.Names) < 2 {
+		return nil, errors.New("natsnet: at least two parties required")
+	}
+	if len(cfg.Names) > math.MaxUint32 {
+		return nil, fmt.Errorf("natsnet: too many parties (%d) for 32-bit role IDs", len(cfg.Names))
+	}
+	if cfg.Self < 0 || cfg.Self >= len(cfg.Names) {
+		return nil, fmt.Errorf("natsnet: invalid self index %d", cfg.Self)
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = DefaultPrefix
+	}
+	if err := checkToken("session ID", cfg.SessionID); err != nil {
+		return nil, err
+	}
+	if err := checkToken("prefix", cfg.Prefix); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(cfg.Names))
+	for _, name := range cfg.Names {
+		if err := checkToken("party name", name); err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("natsnet: duplicate party name %q", name)
+		}
+		seen[name] = true
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Transport{
+		stream: cfg.Stream,
+		self:   cbmpc.RoleID(cfg.Self), // #nosec G115 -- bounded by the MaxUint32 check above
+		peers:  make(map[cbmpc.RoleID]*peer, len(cfg.Names)-1),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	self := cfg.Names[cfg.Self]
+	subject := func(from, to string) string {
+		return cfg.Prefix + "." + cfg.SessionID + "." + from + "." + to
+	}
+	for i, name := range cfg.Names {
+		if i == cfg.Self {
+			continue
+		}
+		p := &peer{
+			subject: subject(self, name),
+			ready:   make(chan struct{}, 1),
+			pending: make(map[uint64][]byte),
+		}
+		t.peers[cbmpc.RoleID(i)] = p // #nosec G115 -- bounded by the MaxUint32 check above
+		// Consumer names cannot contain '.', so join the tokens with '_'.
+		durable := strings.Join([]string{cfg.Prefix, cfg.SessionID, name, self}, "_")
+		stop, err := cfg.Stream.Consume(subject(name, self), durable, p.deliver)
+		if err != nil {
+			_ = t.Close()
+			return nil, fmt.Errorf("natsnet: consume from %q: %w", name, err)
+		}
+		p.mu.Lock()
+		p.stop = stop
+		p.mu.Unlock()
+	}
+	return t, nil
+}
+
+// checkToken rejects values that are not a single NATS subject token.
+func checkToken(what, s string) error {
+	if s == "" {
+		return fmt.Errorf("natsnet: empty %s", what)
+	}
+	if strings.ContainsAny(s, ".*> \t\r\n") {
+		return fmt.Errorf("natsnet: %s %q is not a single subject token", what, s)
+	}
+	return nil
+}
+
+// Send publishes msg to the subject of the pair (self, to) and returns once
+// the stream has stored it.
+func (t *Transport) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	if to == t.self {
+		return errors.New("natsnet: send to self")
+	}
+	p, err := t.getPeer(to)
+	if err != nil {
+		return err
+	}
+	if t.ctx.Err() != nil {
+		return ErrClosed
+	}
+	// Hold the lock across Publish so messages are stored in sequence order.
+	p.sendMu.Lock()
+	defer p.sendMu.Unlock()
+	frame := make([]byte, 8+len(msg))
+	binary.BigEndian.PutUint64(frame, p.sendSeq)
+	copy(frame[8:], msg)
+	if err := t.stream.Publish(ctx, p.subject, frame); err != nil {
+		return fmt.Errorf("natsnet: publish: %w", err)
+	}
+	p.sendSeq++
+	return nil
+}
+
+func (t *Transport) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
+	if from == t.self {
+		return nil, errors.New("natsnet: receive from self")
+	}
+	p, err := t.getPeer(from)
+	if err != nil {
+		return nil, err
+	}
+	return p.receive(ctx, t.ctx)
+}
+
+func (t *Transport) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	uniq := make(map[cbmpc.RoleID]struct{}, len(from))
+	for _, role := range from {
+		if role == t.self {
+			return nil, errors.New("natsnet: receive_all includes self")
+		}
+		if _, err := t.getPeer(role); err != nil {
+			return nil, err
+		}
+		if _, exists := uniq[role]; exists {
+			return nil, errors.New("natsnet: duplicate role in receive_all")
+		}
+		uniq[role] = struct{}{}
+	}
+
+	out := make(map[cbmpc.RoleID][]byte, len(from))
+	for _, role := range from {
+		msg, err := t.peers[role].receive(ctx, t.ctx)
+		if err != nil {
+			return nil, err
+		}
+		out[role] = msg
+	}
+	return out, nil
+}
+
+// Close stops consuming. Messages still in the stream stay there; the
+// durable consumers keep their position, so a Transport created again with
+// the same configuration does not see the messages already received.
+func (t *Transport) Close() error {
+	t.once.Do(func() {
+		t.cancel()
+		for _, p := range t.peers {
+			p.mu.Lock()
+			stop := p.stop
+			p.stop = nil
+			p.mu.Unlock()
+			if stop != nil {
+				stop()
+			}
+		}
+	})
+	return nil
+}
+
+func (t *Transport) getPeer(id cbmpc.RoleID) (*peer, error) {
+	p, ok := t.peers[id]
+	if !ok {
+		return nil, fmt.Errorf("natsnet: unknown peer %d", id)
+	}
+	return p, nil
+}
+
+// deliver handles one delivery from the broker. It acknowledges the message
+// only once it is held, so a crash before then leaves it in the stream.
+func (p *peer) deliver(m Msg) {
+	p.mu.Lock()
+	wake := p.acceptLocked(m.Data())
+	p.mu.Unlock()
+	if wake {
+		notify(p.ready)
+	}
+	_ = m.Ack()
+}
+
+// acceptLocked records a delivered frame and reports whether a receiver has
+// something new to see. A redelivered message is dropped; one that arrives
+// ahead of a missing message is held until the gap is filled.
+func (p *peer) acceptLocked(frame []byte) bool {
+	if p.err != nil {
+		return false
+	}
+	if len(frame) < 8 {
+		p.err = errors.New("natsnet: malformed message")
+		return true
+	}
+	seq := binary.BigEndian.Uint64(frame)
+	if _, held := p.pending[seq]; seq < p.recvNext || held {
+		return false
+	}
+	if len(p.pending) >= maxPending {
+		p.err = fmt.Errorf("%w: message %d still missing after %d later ones", cbmpc.ErrReplay, p.recvNext, maxPending)
+		return true
+	}
+	p.pending[seq] = append([]byte(nil), frame[8:]...)
+	n := len(p.inbox)
+	for {
+		msg, ok := p.pending[p.recvNext]
+		if !ok {
+			break
+		}
+		delete(p.pending, p.recvNext)
+		p.inbox = append(p.inbox, msg)
+		p.recvNext++
+	}
+	return len(p.inbox) > n
+}
+
+// notify wakes one waiting receiver without blocking.
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+func (p *peer) receive(ctx, closed context.Context) ([]byte, error) {
+	for {
+		p.mu.Lock()
+		if len(p.inbox) > 0 {
+			msg := p.inbox[0]
+			p.inbox[0] = nil
+			p.inbox = p.inbox[1:]
+			more := len(p.inbox) > 0
+			p.mu.Unlock()
+			if more {
+				// Pass the wakeup on in case another receiver is waiting.
+				notify(p.ready)
+			}
+			return msg, nil
+		}
+		err := p.err
+		p.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case <-p.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-closed.Done():
+			return nil, ErrClosed
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package natsnet_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/natsnet"
+)
+
+// memStream is an in-memory Stream. It delivers every message dup+1 times.
+type memStream struct {
+	dup int
+
+	mu   sync.Mutex
+	log  map[string][][]byte
+	subs map[string]func(natsnet.Msg)
+	acks int
+}
+
+func newMemStream(dup int) *memStream {
+	return &memStream{dup: dup, log: make(map[string][][]byte), subs: make(map[string]func(natsnet.Msg))}
+}
+
+type memMsg struct {
+	s    *memStream
+	data []byte
+}
+
+func (m memMsg) Data() []byte { return m.data }
+
+func (m memMsg) Ack() error {
+	m.s.mu.Lock()
+	m.s.acks++
+	m.s.mu.Unlock()
+	return nil
+}
+
+func (s *memStream) Publish(_ context.Context, subject string, data []byte) error {
+	data = append([]byte(nil), data...)
+	s.mu.Lock()
+	s.log[subject] = append(s.log[subject], data)
+	handle := s.subs[subject]
+	s.mu.Unlock()
+	for range s.dup + 1 {
+		if handle != nil {
+			handle(memMsg{s, data})
+		}
+	}
+	return nil
+}
+
+func (s *memStream) Consume(subject, _ string, handle func(natsnet.Msg)) (func(), error) {
+	s.mu.Lock()
+	s.subs[subject] = handle
+	backlog := s.log[subject]
+	s.mu.Unlock()
+	for _, data := range backlog {
+		handle(memMsg{s, data})
+	}
+	return func() {
+		s.mu.Lock()
+		delete(s.subs, subject)
+		s.mu.Unlock()
+	}, nil
+}
+
+func (s *memStream) handler(subject string) func(natsnet.Msg) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.subs[subject]
+}
+
+func parties(t *testing.T, s natsnet.Stream, n int) []*natsnet.Transport {
+	t.Helper()
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("p%d", i)
+	}
+	ts := make([]*natsnet.Transport, n)
+	for i := range ts {
+		tr, err := natsnet.New(natsnet.Config{Stream: s, Self: i, Names: names, SessionID: "s1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = tr.Close() })
+		ts[i] = tr
+	}
+	return ts
+}
+
+func TestExchangeWithRedelivery(t *testing.T) {
+	s := newMemStream(2)
+	ts := parties(t, s, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for round := range 3 {
+		for i, tr := range ts {
+			for j := range ts {
+				if i != j {
+					if err := tr.Send(ctx, cbmpc.RoleID(j), fmt.Appendf(nil, "%d:%d->%d", round, i, j)); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+		}
+		for j, tr := range ts {
+			var from []cbmpc.RoleID
+			for i := range ts {
+				if i != j {
+					from = append(from, cbmpc.RoleID(i))
+				}
+			}
+			got, err := tr.ReceiveAll(ctx, from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, i := range from {
+				if want := fmt.Sprintf("%d:%d->%d", round, i, j); string(got[i]) != want {
+					t.Fatalf("party %d got %q, want %q", j, got[i], want)
+				}
+			}
+		}
+	}
+	// Nothing left over: every redelivery was dropped.
+	short, cancelShort := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShort()
+	if _, err := ts[1].Receive(short, 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Receive after exchange: err = %v, want deadline", err)
+	}
+	if s.acks != 3*6*3 {
+		t.Fatalf("acks = %d, want every delivery acknowledged", s.acks)
+	}
+}
+
+func TestOutOfOrderRedelivery(t *testing.T) {
+	s := newMemStream(0)
+	ts := parties(t, s, 2)
+	handle := s.handler("cbmpc.s1.p0.p1")
+	frame := func(seq uint64, msg string) natsnet.Msg {
+		return memMsg{s, append(binary.BigEndian.AppendUint64(nil, seq), msg...)}
+	}
+	handle(frame(1, "b"))
+	handle(frame(2, "c"))
+	handle(frame(1, "b"))
+	handle(frame(0, "a"))
+	handle(frame(0, "a"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for _, want := range []string{"a", "b", "c"} {
+		got, err := ts[1].Receive(ctx, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestClose(t *testing.T) {
+	ts := parties(t, newMemStream(0), 2)
+	done := make(chan error, 1)
+	go func() {
+		_, err := ts[0].Receive(context.Background(), 1)
+		done <- err
+	}()
+	_ = ts[0].Close()
+	if err := <-done; !errors.Is(err, natsnet.ErrClosed) {
+		t.Fatalf("Receive after Close: err = %v, want ErrClosed", err)
+	}
+	if err := ts[0].Send(context.Background(), 1, []byte("x")); !errors.Is(err, natsnet.ErrClosed) {
+		t.Fatalf("Send after Close: err = %v, want ErrClosed", err)
+	}
+}
+
+func TestConfigValidation(t *testing.T) {
+	s := newMemStream(0)
+	for name, cfg := range map[string]natsnet.Config{
+		"no stream":    {Self: 0, Names: []string{"a", "b"}, SessionID: "s"},
+		"one party":    {Stream: s, Self: 0, Names: []string{"a"}, SessionID: "s"},
+		"bad self":     {Stream: s, Self: 2, Names: []string{"a", "b"}, SessionID: "s"},
+		"no session":   {Stream: s, Self: 0, Names: []string{"a", "b"}},
+		"dot session":  {Stream: s, Self: 0, Names: []string{"a", "b"}, SessionID: "s.1"},
+		"wildcard":     {Stream: s, Self: 0, Names: []string{"a", "*"}, SessionID: "s"},
+		"duplicate":    {Stream: s, Self: 0, Names: []string{"a", "a"}, SessionID: "s"},
+		"space prefix": {Stream: s, Self: 0, Names: []string{"a", "b"}, SessionID: "s", Prefix: "my app"},
+	} {
+		if _, err := natsnet.New(cfg); err == nil {
+			t.Errorf("%s: New accepted %+v", name, cfg)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package cbmpc
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Operation describes one protocol invocation on a job. Protocol subpackages
+// fill in what the invocation uses; the job adds the party information and
+// timing before passing it to the job's operation hooks.
+type Operation struct {
+	// Protocol names the invocation, e.g. "ecdsa2p.DKG" or "schnorrmp.Sign".
+	Protocol string
+	// Self and Parties identify the local party and the job's party names,
+	// indexed by RoleID.
+	Self    RoleID
+	Parties []string
+	// Curve and PublicKey identify the key used or, for DKGs, produced. They
+	// are empty if unknown.
+	Curve     Curve
+	PublicKey []byte
+	// Messages are the values signed, one per signature; nil for protocols
+	// that do not sign.
+	Messages [][]byte
+	// Start, Duration, Traffic and Err are set when the invocation returns.
+	Start    time.Time
+	Duration time.Duration
+	Traffic  Traffic
+	Err      error
+}
+
+// SignRequest returns the policy request for a signing operation.
+func (op *Operation) SignRequest() *SignRequest {
+	return &SignRequest{Protocol: op.Protocol, Curve: op.Curve, PublicKey: op.PublicKey, Messages: op.Messages}
+}
+
+// OperationHook is called when a protocol invocation on a job returns, with
+// its outcome in op.Err. Hooks run on the calling goroutine before the job is
+// released, so they must not invoke protocols on the same job. Hooks must not
+// retain or modify op.Messages.
+type OperationHook func(ctx context.Context, op *Operation)
+
+// AddOperationHook registers h to observe every DKG, refresh, signing and key
+// import or export invocation on the job. Call it before the job is used.
+func (j *Job2P) AddOperationHook(h OperationHook) {
+	if j != nil && h != nil {
+		j.hooks = append(j.hooks, h)
+	}
+}
+
+// BeginOperation starts timing op and returns the function that reports it
+// to the job's hooks; protocol subpackages defer it with a pointer to the
+// invocation's named error result after Acquire. If the invocation failed
+// after a transport error, such as a RoundTimeoutError, the function wraps
+// the result with that error, which the native library cannot pass on.
+// This is exported for use by protocol subpackages.
+func (j *Job2P) BeginOperation(ctx context.Context, op *Operation) func(errp *error) {
+	if j == nil {
+		return func(*error) {}
+	}
+	return beginOperation(ctx, j.hooks, j.trace, op, j.self, j.names[:])
+}
+
+// AddOperationHook is the n-party counterpart of Job2P.AddOperationHook.
+func (j *JobMP) AddOperationHook(h OperationHook) {
+	if j != nil && h != nil {
+		j.hooks = append(j.hooks, h)
+	}
+}
+
+// BeginOperation starts timing op; see Job2P.BeginOperation.
+// This is exported for use by protocol subpackages.
+func (j *JobMP) BeginOperation(ctx context.Context, op *Operation) func(errp *error) {
+	if j == nil {
+		return func(*error) {}
+	}
+	return beginOperation(ctx, j.hooks, j.trace, op, j.self, j.names)
+}
+
+func beginOperation(ctx context.Context, hooks []OperationHook, trace *jobTrace, op *Operation, self RoleID, names []string) func(*error) {
+	trace.reset()
+	if op != nil {
+		trace.start(op.Protocol, self, len(names))
+	}
+	finish := func(errp *error) {
+		trace.surface(errp)
+		trace.finish(errp)
+	}
+	if op == nil || (len(hooks) == 0 && trace.logger == nil) {
+		return finish
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	op.Self = self
+	op.Parties = slices.Clone(names)
+	op.Start = time.Now()
+	if trace.logger != nil {
+		trace.logger.Debug(ctx, "cbmpc: protocol started", operationAttrs(op)...)
+	}
+	return func(errp *error) {
+		finish(errp)
+		var err error
+		if errp != nil {
+			err = *errp
+		}
+		op.Duration = time.Since(op.Start)
+		op.Traffic = trace.traffic()
+		op.Err = err
+		if l := trace.logger; l != nil {
+			args := append(operationAttrs(op),
+				"duration", op.Duration,
+				"rounds", op.Traffic.Rounds,
+				"messages_sent", op.Traffic.MessagesSent,
+				"bytes_sent", op.Traffic.BytesSent,
+				"messages_received", op.Traffic.MessagesReceived,
+				"bytes_received", op.Traffic.BytesReceived,
+			)
+			if err != nil {
+				l.Warn(ctx, "cbmpc: protocol failed", append(args, "error", err)...)
+			} else {
+				l.Info(ctx, "cbmpc: protocol finished", args...)
+			}
+		}
+		for _, h := range hooks {
+			h(ctx, op)
+		}
+	}
+}
+
+// operationAttrs returns the log attributes naming op and its key. The
+// fingerprint attribute avoids "key" in its name so that redacting handlers
+// with the default deny-list keep it.
+func operationAttrs(op *Operation) []any {
+	args := []any{"protocol", op.Protocol}
+	if fp := op.KeyFingerprint(); fp != "" {
+		args = append(args, "fingerprint", fp)
+	}
+	return args
+}
@@ -0,0 +1,24 @@
+// Package ot provides 1-out-of-2 oblivious transfer between the two parties
+// of a job, for building custom secure-computation steps (for example private
+// set membership) on the same transport as the library's protocols.
+//
+// In an oblivious transfer the sender holds pairs of messages and the
+// receiver holds one choice bit per pair; the receiver learns the chosen
+// message of each pair and nothing about the other, and the sender learns
+// nothing about the choices.
+//
+// Send and Receive run the native library's OT protocol over a Job2P: PVW
+// base OTs followed by OT extension, so a batch of any size costs a fixed
+// number of public-key operations.
+//
+//	// Sender
+//	err := ot.Send(ctx, job, sessionID, pairs)
+//
+//	// Receiver
+//	msgs, err := ot.Receive(ctx, job, sessionID, choices, msgLen)
+//
+// Either party may send. Both calls hold the job for their duration, so OT
+// messages never interleave with another protocol run.
+//
+// See cb-mpc/src/cbmpc/protocol/ot.h for protocol details.
+package ot
@@ -0,0 +1,109 @@
+package ot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+// Send runs one OT per pair as the sender over a 2-party job; the peer must
+// call Receive with the same session ID, one choice per pair and the pairs'
+// message length. Every message must have the same, non-zero length.
+//
+// It is a Go wrapper for coinbase::mpc::ot_protocol_pvw_ctx_t.
+// See cb-mpc/src/cbmpc/protocol/ot.h for protocol details.
+func Send(ctx context.Context, j *cbmpc.Job2P, sessionID []byte, pairs [][2][]byte) error {
+	if j == nil {
+		return errors.New("nil job")
+	}
+	if len(sessionID) == 0 {
+		return errors.New("empty session ID")
+	}
+	if _, err := checkPairs(pairs); err != nil {
+		return err
+	}
+	x0 := make([][]byte, len(pairs))
+	x1 := make([][]byte, len(pairs))
+	for i, p := range pairs {
+		x0[i], x1[i] = p[0], p[1]
+	}
+
+	ptr, release, err := j.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	err = backend.OTSend(ptr, sessionID, x0, x1)
+	runtime.KeepAlive(j)
+	return cbmpc.RemapError(err)
+}
+
+// Receive runs one OT per choice as the receiver over a 2-party job and
+// returns the chosen messages, each msgLen bytes long; see Send.
+func Receive(ctx context.Context, j *cbmpc.Job2P, sessionID []byte, choices []bool, msgLen int) ([][]byte, error) {
+	if j == nil {
+		return nil, errors.New("nil job")
+	}
+	if len(sessionID) == 0 {
+		return nil, errors.New("empty session ID")
+	}
+	if err := checkChoices(choices, msgLen); err != nil {
+		return nil, err
+	}
+	bits := make([]byte, (len(choices)+7)/8)
+	for i, c := range choices {
+		if c {
+			bits[i/8] |= 1 << (i % 8)
+		}
+	}
+	defer clear(bits)
+
+	ptr, release, err := j.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	msgs, err := backend.OTReceive(ptr, sessionID, bits, len(choices), msgLen)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	runtime.KeepAlive(j)
+	if len(msgs) != len(choices) {
+		return nil, fmt.Errorf("received %d messages, want %d", len(msgs), len(choices))
+	}
+	return msgs, nil
+}
+
+// checkPairs checks that pairs is non-empty and that every message has the
+// same, non-zero length, and returns that length.
+func checkPairs(pairs [][2][]byte) (int, error) {
+	if len(pairs) == 0 {
+		return 0, errors.New("no message pairs")
+	}
+	l := len(pairs[0][0])
+	if l == 0 {
+		return 0, errors.New("empty message")
+	}
+	for i, p := range pairs {
+		if len(p[0]) != l || len(p[1]) != l {
+			return 0, fmt.Errorf("message pair %d: all messages must be %d bytes", i, l)
+		}
+	}
+	return l, nil
+}
+
+func checkChoices(choices []bool, msgLen int) error {
+	if len(choices) == 0 {
+		return errors.New("no choices")
+	}
+	if msgLen <= 0 {
+		return errors.New("message length must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+//go:build cgo && !windows
+
+package ot_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ot"
+)
+
+func randomPairs(t *testing.T, n, l int) ([][2][]byte, []bool) {
+	t.Helper()
+	pairs := make([][2][]byte, n)
+	choices := make([]bool, n)
+	bits := make([]byte, n)
+	if _, err := rand.Read(bits); err != nil {
+		t.Fatal(err)
+	}
+	for i := range pairs {
+		for b := range 2 {
+			pairs[i][b] = make([]byte, l)
+			if _, err := rand.Read(pairs[i][b]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		choices[i] = bits[i]&1 == 1
+	}
+	return pairs, choices
+}
+
+func checkChosen(t *testing.T, pairs [][2][]byte, choices []bool, got [][]byte) {
+	t.Helper()
+	if len(got) != len(choices) {
+		t.Fatalf("got %d messages, want %d", len(got), len(choices))
+	}
+	for i, c := range choices {
+		want := pairs[i][0]
+		if c {
+			want = pairs[i][1]
+		}
+		if !bytes.Equal(got[i], want) {
+			t.Fatalf("OT %d: receiver got the wrong message", i)
+		}
+	}
+}
+
+// jobs returns the two parties' jobs on a fresh mocknet.
+func jobs(t *testing.T) (*cbmpc.Job2P, *cbmpc.Job2P) {
+	t.Helper()
+	net := mocknet.New()
+	names := [2]string{"p1", "p2"}
+	p1, p2 := cbmpc.RoleID(cbmpc.RoleP1), cbmpc.RoleID(cbmpc.RoleP2)
+	job1, err := cbmpc.NewJob2P(net.Ep2P(p1, p2), cbmpc.RoleP1, names)
+	if err != nil {
+		t.Fatalf("NewJob2P p1: %v", err)
+	}
+	t.Cleanup(func() { _ = job1.Close() })
+	job2, err := cbmpc.NewJob2P(net.Ep2P(p2, p1), cbmpc.RoleP2, names)
+	if err != nil {
+		t.Fatalf("NewJob2P p2: %v", err)
+	}
+	t.Cleanup(func() { _ = job2.Close() })
+	return job1, job2
+}
+
+func TestOT(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Either party can send.
+	for _, tc := range []struct {
+		name   string
+		n, l   int
+		p2Send bool
+	}{
+		{"one", 1, 16, false},
+		{"batch", 1000, 100, false},
+		{"p2 sends", 300, 8, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			job1, job2 := jobs(t)
+			sender, receiver := job1, job2
+			if tc.p2Send {
+				sender, receiver = job2, job1
+			}
+			pairs, choices := randomPairs(t, tc.n, tc.l)
+
+			var wg sync.WaitGroup
+			var sendErr error
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sendErr = ot.Send(ctx, sender, []byte("ot-test"), pairs)
+			}()
+			got, err := ot.Receive(ctx, receiver, []byte("ot-test"), choices, tc.l)
+			wg.Wait()
+			if err != nil {
+				t.Fatalf("Receive failed: %v", err)
+			}
+			if sendErr != nil {
+				t.Fatalf("Send failed: %v", sendErr)
+			}
+			checkChosen(t, pairs, choices, got)
+		})
+	}
+}
+
+func TestInvalidParams(t *testing.T) {
+	ctx := context.Background()
+	job1, _ := jobs(t)
+	sid := []byte("sid")
+	pairs, choices := randomPairs(t, 2, 8)
+
+	if err := ot.Send(ctx, nil, sid, pairs); err == nil {
+		t.Error("Send with a nil job succeeded")
+	}
+	if err := ot.Send(ctx, job1, nil, pairs); err == nil {
+		t.Error("Send with an empty session ID succeeded")
+	}
+	if err := ot.Send(ctx, job1, sid, nil); err == nil {
+		t.Error("Send with no pairs succeeded")
+	}
+	uneven := [][2][]byte{{make([]byte, 8), make([]byte, 9)}}
+	if err := ot.Send(ctx, job1, sid, uneven); err == nil {
+		t.Error("Send with uneven messages succeeded")
+	}
+	if _, err := ot.Receive(ctx, job1, sid, nil, 8); err == nil {
+		t.Error("Receive with no choices succeeded")
+	}
+	if _, err := ot.Receive(ctx, job1, sid, choices, 0); err == nil {
+		t.Error("Receive with zero message length succeeded")
+	}
+}
@@ -7,11 +7,15 @@
 // # Key Operations
 //
 //   - Generate(): Create a new keypair (2048-bit modulus)
+//   - GenerateWithBits(): Create a new keypair with a 2048, 3072 or 4096-bit modulus
+//   - ModulusBits(): Report the size of the modulus
 //   - FromPublicKey(): Create from modulus N (public key only)
 //   - FromPrivateKey(): Create from N, p, q (full private key)
 //   - Encrypt(): Encrypt plaintext to ciphertext
 //   - Decrypt(): Decrypt ciphertext to plaintext (requires private key)
 //   - AddCiphers(): Homomorphically add two ciphertexts (E(a) + E(b) = E(a+b))
+//   - SubCiphers(): Homomorphically subtract two ciphertexts (E(a) - E(b) = E(a-b))
+//   - Rerandomize(): Produce an unlinkable ciphertext of the same plaintext
 //   - MulScalar(): Homomorphically multiply ciphertext by scalar (E(a) * k = E(a*k))
 //   - VerifyCipher(): Verify that a ciphertext is well-formed
 //   - Serialize()/Deserialize(): Save and load keys
@@ -25,9 +29,13 @@
 //
 // The Paillier cryptosystem supports:
 //   - Additive homomorphism: E(m1) * E(m2) = E(m1 + m2)
+//   - Subtraction: E(m1) * E(m2)^-1 = E(m1 - m2)
 //   - Scalar multiplication: E(m)^k = E(k * m)
 //
-// These properties are exposed via AddCiphers() and MulScalar() methods.
+// These properties are exposed via AddCiphers(), SubCiphers() and MulScalar()
+// methods. Results of homomorphic operations are deterministic functions of
+// their inputs; pass them through Rerandomize() before sharing them if the
+// recipient must not be able to link them to the inputs.
 //
 // # Usage Example
 //
@@ -0,0 +1,28 @@
+package paillier
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Supported modulus sizes for GenerateWithBits.
+const (
+	Bits2048 = 2048
+	Bits3072 = 3072
+	Bits4096 = 4096
+)
+
+// ErrUnsafeModulusSize is returned by GenerateWithBits for sizes other than
+// 2048, 3072 or 4096 bits.
+var ErrUnsafeModulusSize = errors.New("unsupported paillier modulus size")
+
+// checkModulusBits rejects modulus sizes below the 2048-bit floor and any size
+// the native key generator does not support.
+func checkModulusBits(bits int) error {
+	switch bits {
+	case Bits2048, Bits3072, Bits4096:
+		return nil
+	default:
+		return fmt.Errorf("%w: %d bits (want %d, %d or %d)", ErrUnsafeModulusSize, bits, Bits2048, Bits3072, Bits4096)
+	}
+}
@@ -0,0 +1,19 @@
+package paillier
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckModulusBits(t *testing.T) {
+	for _, bits := range []int{Bits2048, Bits3072, Bits4096} {
+		if err := checkModulusBits(bits); err != nil {
+			t.Errorf("checkModulusBits(%d): %v", bits, err)
+		}
+	}
+	for _, bits := range []int{-1, 0, 512, 1024, 2047, 2560, 8192} {
+		if err := checkModulusBits(bits); !errors.Is(err, ErrUnsafeModulusSize) {
+			t.Errorf("checkModulusBits(%d): expected ErrUnsafeModulusSize, got %v", bits, err)
+		}
+	}
+}
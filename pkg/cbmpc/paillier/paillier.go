@@ -4,6 +4,7 @@ package paillier
 
 import (
 	"errors"
+	"math/big"
 	"runtime"
 
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
@@ -14,7 +15,7 @@ import (
 // The key is stored as an opaque C++ object handle.
 //
 // A Paillier instance can be created in three ways:
-//   - Generate(): Creates a new keypair (has both public and private key)
+//   - Generate() / GenerateWithBits(): Creates a new keypair (has both public and private key)
 //   - FromPublicKey(): Creates from modulus N only (public key only, can encrypt/verify)
 //   - FromPrivateKey(): Creates from N, p, q (has private key, can decrypt)
 //
@@ -36,6 +37,24 @@ func Generate() (*Paillier, error) {
 	return p, nil
 }
 
+// GenerateWithBits creates a new Paillier keypair whose modulus N is exactly
+// bits long. bits must be 2048, 3072 or 4096; any other size returns
+// ErrUnsafeModulusSize. Larger moduli are considerably slower to generate.
+// See cb-mpc/src/cbmpc/crypto/base_paillier.h for implementation details.
+func GenerateWithBits(bits int) (*Paillier, error) {
+	if err := checkModulusBits(bits); err != nil {
+		return nil, err
+	}
+	handle, err := backend.PaillierGenerateWithBits(bits)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+
+	p := &Paillier{handle: handle}
+	runtime.SetFinalizer(p, (*Paillier).Close)
+	return p, nil
+}
+
 // FromPublicKey creates a Paillier instance from a public key (modulus n).
 // The returned instance can encrypt and verify ciphertexts but cannot decrypt.
 // See cb-mpc/src/cbmpc/crypto/base_paillier.h for implementation details.
@@ -95,6 +114,15 @@ func (p *Paillier) GetN() ([]byte, error) {
 	return n, nil
 }
 
+// ModulusBits returns the bit length of the modulus N.
+func (p *Paillier) ModulusBits() (int, error) {
+	n, err := p.GetN()
+	if err != nil {
+		return 0, err
+	}
+	return new(big.Int).SetBytes(n).BitLen(), nil
+}
+
 // Encrypt encrypts a plaintext value using the Paillier cryptosystem.
 // The plaintext must be less than the modulus N.
 // See cb-mpc/src/cbmpc/crypto/base_paillier.h for implementation details.
@@ -140,6 +168,37 @@ func (p *Paillier) AddCiphers(c1, c2 []byte) ([]byte, error) {
 	return result, nil
 }
 
+// SubCiphers homomorphically subtracts c2 from c1.
+// Result decrypts to plaintext1 - plaintext2 (mod N).
+// See cb-mpc/src/cbmpc/crypto/base_paillier.h for implementation details.
+func (p *Paillier) SubCiphers(c1, c2 []byte) ([]byte, error) {
+	if p.handle == nil {
+		return nil, errors.New("nil or closed paillier")
+	}
+	result, err := backend.PaillierSubCiphers(p.handle, c1, c2)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	runtime.KeepAlive(p)
+	return result, nil
+}
+
+// Rerandomize returns a new ciphertext that decrypts to the same plaintext as
+// ciphertext but cannot be linked to it without the private key. Only the
+// public key is required.
+// See cb-mpc/src/cbmpc/crypto/base_paillier.h for implementation details.
+func (p *Paillier) Rerandomize(ciphertext []byte) ([]byte, error) {
+	if p.handle == nil {
+		return nil, errors.New("nil or closed paillier")
+	}
+	result, err := backend.PaillierRerandomize(p.handle, ciphertext)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	runtime.KeepAlive(p)
+	return result, nil
+}
+
 // MulScalar homomorphically multiplies a Paillier ciphertext by a scalar.
 // Result decrypts to plaintext * scalar (mod N).
 // See cb-mpc/src/cbmpc/crypto/base_paillier.h for implementation details.
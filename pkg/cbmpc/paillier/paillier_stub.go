@@ -12,6 +12,11 @@ func Generate() (*Paillier, error) {
 	return nil, backend.ErrNotBuilt
 }
 
+// GenerateWithBits is a stub that returns ErrNotBuilt.
+func GenerateWithBits(int) (*Paillier, error) {
+	return nil, backend.ErrNotBuilt
+}
+
 // FromPublicKey is a stub that returns ErrNotBuilt.
 func FromPublicKey([]byte) (*Paillier, error) {
 	return nil, backend.ErrNotBuilt
@@ -35,6 +40,11 @@ func (p *Paillier) GetN() ([]byte, error) {
 	return nil, backend.ErrNotBuilt
 }
 
+// ModulusBits is a stub that returns ErrNotBuilt.
+func (p *Paillier) ModulusBits() (int, error) {
+	return 0, backend.ErrNotBuilt
+}
+
 // Encrypt is a stub that returns ErrNotBuilt.
 func (p *Paillier) Encrypt([]byte) ([]byte, error) {
 	return nil, backend.ErrNotBuilt
@@ -50,6 +60,16 @@ func (p *Paillier) AddCiphers([]byte, []byte) ([]byte, error) {
 	return nil, backend.ErrNotBuilt
 }
 
+// SubCiphers is a stub that returns ErrNotBuilt.
+func (p *Paillier) SubCiphers([]byte, []byte) ([]byte, error) {
+	return nil, backend.ErrNotBuilt
+}
+
+// Rerandomize is a stub that returns ErrNotBuilt.
+func (p *Paillier) Rerandomize([]byte) ([]byte, error) {
+	return nil, backend.ErrNotBuilt
+}
+
 // MulScalar is a stub that returns ErrNotBuilt.
 func (p *Paillier) MulScalar([]byte, []byte) ([]byte, error) {
 	return nil, backend.ErrNotBuilt
@@ -3,6 +3,9 @@
 package paillier_test
 
 import (
+	"bytes"
+	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/paillier"
@@ -153,6 +156,97 @@ func TestPaillierAddCiphers(t *testing.T) {
 	}
 }
 
+func TestPaillierSubCiphers(t *testing.T) {
+	p, err := paillier.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	defer p.Close()
+
+	c1, err := p.Encrypt([]byte{0x08})
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	c2, err := p.Encrypt([]byte{0x05})
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	// Homomorphically subtract: E(8) - E(5) = E(3)
+	cDiff, err := p.SubCiphers(c1, c2)
+	if err != nil {
+		t.Fatalf("SubCiphers failed: %v", err)
+	}
+	decrypted, err := p.Decrypt(cDiff)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if got := new(big.Int).SetBytes(decrypted); got.Int64() != 0x03 {
+		t.Errorf("Decrypted difference mismatch: expected 0x03, got 0x%x", got)
+	}
+
+	// Negative results wrap modulo N: E(5) - E(8) = E(N - 3)
+	cNeg, err := p.SubCiphers(c2, c1)
+	if err != nil {
+		t.Fatalf("SubCiphers failed: %v", err)
+	}
+	decrypted, err = p.Decrypt(cNeg)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	nBytes, err := p.GetN()
+	if err != nil {
+		t.Fatalf("GetN failed: %v", err)
+	}
+	want := new(big.Int).Sub(new(big.Int).SetBytes(nBytes), big.NewInt(3))
+	if got := new(big.Int).SetBytes(decrypted); got.Cmp(want) != 0 {
+		t.Errorf("Decrypted negative difference mismatch: expected N-3, got %x", got)
+	}
+}
+
+func TestPaillierRerandomize(t *testing.T) {
+	priv, err := paillier.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	defer priv.Close()
+
+	nBytes, err := priv.GetN()
+	if err != nil {
+		t.Fatalf("GetN failed: %v", err)
+	}
+	pub, err := paillier.FromPublicKey(nBytes)
+	if err != nil {
+		t.Fatalf("FromPublicKey failed: %v", err)
+	}
+	defer pub.Close()
+
+	c, err := pub.Encrypt([]byte{0x2a})
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	// Rerandomization only needs the public key.
+	r, err := pub.Rerandomize(c)
+	if err != nil {
+		t.Fatalf("Rerandomize failed: %v", err)
+	}
+	if bytes.Equal(r, c) {
+		t.Error("Rerandomized ciphertext should differ from the input")
+	}
+	if err := pub.VerifyCipher(r); err != nil {
+		t.Errorf("Rerandomized ciphertext failed verification: %v", err)
+	}
+
+	decrypted, err := priv.Decrypt(r)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if got := new(big.Int).SetBytes(decrypted); got.Int64() != 0x2a {
+		t.Errorf("Decrypted rerandomized value mismatch: expected 0x2a, got 0x%x", got)
+	}
+}
+
 func TestPaillierMulScalar(t *testing.T) {
 	p, err := paillier.Generate()
 	if err != nil {
@@ -345,3 +439,44 @@ func TestPaillierClose(t *testing.T) {
 	// Close again should be safe
 	p.Close()
 }
+
+func TestPaillierGenerateWithBits(t *testing.T) {
+	bitSizes := []int{paillier.Bits3072}
+	if !testing.Short() {
+		bitSizes = append(bitSizes, paillier.Bits4096)
+	}
+	for _, bits := range bitSizes {
+		p, err := paillier.GenerateWithBits(bits)
+		if err != nil {
+			t.Fatalf("GenerateWithBits(%d) failed: %v", bits, err)
+		}
+		got, err := p.ModulusBits()
+		if err != nil {
+			t.Fatalf("ModulusBits failed: %v", err)
+		}
+		if got != bits {
+			t.Errorf("Expected %d-bit modulus, got %d", bits, got)
+		}
+
+		c, err := p.Encrypt([]byte{0x2a})
+		if err != nil {
+			t.Fatalf("Encrypt failed: %v", err)
+		}
+		m, err := p.Decrypt(c)
+		if err != nil {
+			t.Fatalf("Decrypt failed: %v", err)
+		}
+		if new(big.Int).SetBytes(m).Int64() != 0x2a {
+			t.Errorf("Round trip mismatch at %d bits: got %x", bits, m)
+		}
+		p.Close()
+	}
+}
+
+func TestPaillierGenerateWithBitsRejectsUnsafe(t *testing.T) {
+	for _, bits := range []int{0, 1024, 2047, 8192} {
+		if _, err := paillier.GenerateWithBits(bits); !errors.Is(err, paillier.ErrUnsafeModulusSize) {
+			t.Errorf("GenerateWithBits(%d): expected ErrUnsafeModulusSize, got %v", bits, err)
+		}
+	}
+}
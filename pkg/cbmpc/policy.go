@@ -0,0 +1,235 @@
+package cbmpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrPolicyRejected is wrapped by every PolicyError.
+var ErrPolicyRejected = errors.New("sign request rejected by policy")
+
+// PolicyError reports which party's SignPolicy rejected a signing request.
+// Every party of the job receives the same error, so the protocol aborts on
+// all of them before any signing message is sent.
+type PolicyError struct {
+	Party  RoleID
+	Reason string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("%v: party %d: %s", ErrPolicyRejected, e.Party, e.Reason)
+}
+
+func (e *PolicyError) Unwrap() error { return ErrPolicyRejected }
+
+// SignRequest describes a signing operation about to run. Protocol
+// subpackages fill it in; policies must not modify it.
+type SignRequest struct {
+	// Protocol names the operation, e.g. "ecdsa2p.Sign" or "schnorrmp.SignBatch".
+	Protocol string
+	// Curve and PublicKey identify the key being used.
+	Curve     Curve
+	PublicKey []byte
+	// Messages are the values passed to the protocol, one per signature:
+	// message hashes for ECDSA, raw messages for Schnorr.
+	Messages [][]byte
+	// Metadata is the local caller's context attached with WithSignMetadata.
+	// It is not sent to peers, so each party sees only its own.
+	Metadata map[string]string
+}
+
+// SignPolicy decides whether the local party takes part in a signing request.
+// A nil error allows it; any error rejects it, and its message is sent to the
+// other parties as the rejection reason.
+type SignPolicy interface {
+	Allow(ctx context.Context, req *SignRequest) error
+}
+
+// SignPolicyFunc adapts a function to SignPolicy.
+type SignPolicyFunc func(ctx context.Context, req *SignRequest) error
+
+// Allow implements SignPolicy.
+func (f SignPolicyFunc) Allow(ctx context.Context, req *SignRequest) error { return f(ctx, req) }
+
+type signMetadataKey struct{}
+
+// WithSignMetadata returns a context carrying md, which signing calls made
+// with it pass to the local SignPolicy in SignRequest.Metadata. Entries are
+// merged over any metadata already in ctx.
+func WithSignMetadata(ctx context.Context, md map[string]string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	merged := maps.Clone(SignMetadataFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(md))
+	}
+	maps.Copy(merged, md)
+	return context.WithValue(ctx, signMetadataKey{}, merged)
+}
+
+// SignMetadataFromContext returns the metadata attached by WithSignMetadata,
+// or nil if there is none. The returned map must not be modified.
+func SignMetadataFromContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	md, _ := ctx.Value(signMetadataKey{}).(map[string]string)
+	return md
+}
+
+const verdictMagic = "cbmpc-policy/1"
+
+type verdict struct {
+	Magic   string `json:"magic"`
+	Self    RoleID `json:"self"`
+	Request []byte `json:"request"` // SHA-256 of protocol, public key and messages
+	Reason  string `json:"reason,omitempty"`
+	Reject  bool   `json:"reject,omitempty"`
+}
+
+// RunSignPolicy evaluates p on req and exchanges the verdict with the other
+// parties of an n-party job over t. It returns a *PolicyError naming the
+// lowest-numbered party that rejected, or that is about to sign a different
+// request than the local party, and nil if every party allowed the same
+// request. Every party must call it with a policy, in the same position of
+// the job's protocol sequence, since it sends one message to and receives
+// one message from each peer.
+//
+// Jobs with a policy set by SetSignPolicy run it automatically at the start
+// of every signing protocol; call it directly only for protocols driven
+// outside a Job.
+func RunSignPolicy(ctx context.Context, t Transport, self RoleID, n int, p SignPolicy, req *SignRequest) error {
+	if t == nil {
+		return ErrNilTransport
+	}
+	if p == nil {
+		return errors.New("nil sign policy")
+	}
+	if req == nil {
+		return errors.New("nil sign request")
+	}
+	if n < 2 || int(self) >= n {
+		return fmt.Errorf("%w: self role %d out of range [0,%d)", ErrBadPeers, self, n)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if req.Metadata == nil {
+		req.Metadata = SignMetadataFromContext(ctx)
+	}
+
+	local := verdict{Magic: verdictMagic, Self: self, Request: requestDigest(req)}
+	if err := p.Allow(ctx, req); err != nil {
+		local.Reject, local.Reason = true, err.Error()
+	}
+	msg, err := json.Marshal(local)
+	if err != nil {
+		return err
+	}
+
+	peers := make([]RoleID, 0, n-1)
+	for i := 0; i < n; i++ {
+		if RoleID(i) != self {
+			peers = append(peers, RoleID(i))
+		}
+	}
+	for _, peer := range peers {
+		if err := t.Send(ctx, peer, msg); err != nil {
+			return fmt.Errorf("sign policy send to party %d: %w", peer, err)
+		}
+	}
+	msgs, err := t.ReceiveAll(ctx, peers)
+	if err != nil {
+		return fmt.Errorf("sign policy receive: %w", err)
+	}
+
+	verdicts := map[RoleID]verdict{self: local}
+	for _, peer := range peers {
+		var remote verdict
+		if err := json.Unmarshal(msgs[peer], &remote); err != nil || remote.Magic != verdictMagic || remote.Self != peer {
+			return &PolicyError{Party: peer, Reason: "peer did not send a policy verdict; every party must set a sign policy"}
+		}
+		verdicts[peer] = remote
+	}
+	for _, r := range slices.Sorted(maps.Keys(verdicts)) {
+		v := verdicts[r]
+		switch {
+		case v.Reject:
+			return &PolicyError{Party: r, Reason: v.Reason}
+		case !bytes.Equal(v.Request, local.Request):
+			return &PolicyError{Party: r, Reason: "peer is signing a different request"}
+		}
+	}
+	return nil
+}
+
+// requestDigest binds the parts of req every party must agree on; Metadata
+// is local and excluded.
+func requestDigest(req *SignRequest) []byte {
+	h := sha256.New()
+	var n [4]byte
+	write := func(b []byte) {
+		binary.BigEndian.PutUint32(n[:], uint32(len(b)))
+		h.Write(n[:])
+		h.Write(b)
+	}
+	write([]byte(req.Protocol))
+	write([]byte(req.Curve.String()))
+	write(req.PublicKey)
+	for _, m := range req.Messages {
+		write(m)
+	}
+	return h.Sum(nil)
+}
+
+// SetSignPolicy makes every signing protocol run on the job first evaluate p
+// and exchange verdicts with the peer (see RunSignPolicy); if either party
+// rejects, the protocol returns a *PolicyError without signing. Both parties
+// must set a policy, or neither. Call it before the job is used; a nil p
+// removes the policy.
+func (j *Job2P) SetSignPolicy(p SignPolicy) {
+	if j != nil {
+		j.policy = p
+	}
+}
+
+// CheckSignPolicy runs the job's sign policy on req, if one is set. Protocol
+// subpackages call it after Acquire and before signing.
+// This is exported for use by protocol subpackages.
+func (j *Job2P) CheckSignPolicy(ctx context.Context, req *SignRequest) error {
+	if j == nil {
+		return ErrJobClosed
+	}
+	if j.policy == nil {
+		return nil
+	}
+	return RunSignPolicy(ctx, j.transport, j.self, 2, j.policy, req)
+}
+
+// SetSignPolicy is the n-party counterpart of Job2P.SetSignPolicy. Every
+// party must set a policy, or none.
+func (j *JobMP) SetSignPolicy(p SignPolicy) {
+	if j != nil {
+		j.policy = p
+	}
+}
+
+// CheckSignPolicy runs the job's sign policy on req; see Job2P.CheckSignPolicy.
+// This is exported for use by protocol subpackages.
+func (j *JobMP) CheckSignPolicy(ctx context.Context, req *SignRequest) error {
+	if j == nil {
+		return ErrJobClosed
+	}
+	if j.policy == nil {
+		return nil
+	}
+	return RunSignPolicy(ctx, j.transport, j.self, len(j.names), j.policy, req)
+}
@@ -0,0 +1,121 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// runPolicy runs RunSignPolicy for every party concurrently and returns each
+// party's error.
+func runPolicy(t *testing.T, policies []SignPolicy, reqs []*SignRequest) []error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	net := &chanNet{}
+	errs := make([]error, len(policies))
+	var wg sync.WaitGroup
+	for i := range policies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = RunSignPolicy(ctx, chanEndpoint{net: net, self: RoleID(i)}, RoleID(i), len(policies), policies[i], reqs[i])
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+func request(msg string) *SignRequest {
+	return &SignRequest{Protocol: "ecdsamp.Sign", PublicKey: []byte{2, 1}, Messages: [][]byte{[]byte(msg)}}
+}
+
+var allowAll = SignPolicyFunc(func(context.Context, *SignRequest) error { return nil })
+
+// allowList allows only the listed messages.
+func allowList(msgs ...string) SignPolicy {
+	return SignPolicyFunc(func(_ context.Context, req *SignRequest) error {
+		for _, m := range req.Messages {
+			found := false
+			for _, ok := range msgs {
+				found = found || string(m) == ok
+			}
+			if !found {
+				return errors.New("message not on allow-list")
+			}
+		}
+		return nil
+	})
+}
+
+func TestSignPolicyAllows(t *testing.T) {
+	policies := []SignPolicy{allowAll, allowList("pay alice"), allowAll}
+	reqs := []*SignRequest{request("pay alice"), request("pay alice"), request("pay alice")}
+	for i, err := range runPolicy(t, policies, reqs) {
+		if err != nil {
+			t.Fatalf("party %d: %v", i, err)
+		}
+	}
+}
+
+func TestSignPolicyRejectAbortsEveryParty(t *testing.T) {
+	policies := []SignPolicy{allowAll, allowList("pay alice"), allowAll}
+	reqs := []*SignRequest{request("pay mallory"), request("pay mallory"), request("pay mallory")}
+	for i, err := range runPolicy(t, policies, reqs) {
+		var pe *PolicyError
+		if !errors.As(err, &pe) || !errors.Is(err, ErrPolicyRejected) {
+			t.Fatalf("party %d: error = %v, want *PolicyError", i, err)
+		}
+		if pe.Party != 1 || !strings.Contains(pe.Reason, "allow-list") {
+			t.Fatalf("party %d: got %+v", i, pe)
+		}
+	}
+}
+
+func TestSignPolicyDetectsDifferentRequests(t *testing.T) {
+	policies := []SignPolicy{allowAll, allowAll}
+	reqs := []*SignRequest{request("pay alice"), request("pay mallory")}
+	for i, err := range runPolicy(t, policies, reqs) {
+		var pe *PolicyError
+		if !errors.As(err, &pe) || pe.Party != RoleID(1-i) {
+			t.Fatalf("party %d: error = %v, want rejection naming party %d", i, err, 1-i)
+		}
+	}
+}
+
+func TestSignPolicyMetadata(t *testing.T) {
+	ctx := WithSignMetadata(context.Background(), map[string]string{"ticket": "T-1", "user": "a"})
+	ctx = WithSignMetadata(ctx, map[string]string{"user": "b"})
+	var got map[string]string
+	p := SignPolicyFunc(func(_ context.Context, req *SignRequest) error {
+		got = req.Metadata
+		return nil
+	})
+
+	net := &chanNet{}
+	go func() {
+		_ = RunSignPolicy(context.Background(), chanEndpoint{net: net, self: 1}, 1, 2, allowAll, request("m"))
+	}()
+	if err := RunSignPolicy(ctx, chanEndpoint{net: net, self: 0}, 0, 2, p, request("m")); err != nil {
+		t.Fatal(err)
+	}
+	if got["ticket"] != "T-1" || got["user"] != "b" {
+		t.Fatalf("metadata = %v", got)
+	}
+}
+
+func TestSignPolicyPeerWithoutPolicy(t *testing.T) {
+	net := &chanNet{}
+	go func() {
+		// A peer that skips the policy round starts the protocol instead.
+		_ = chanEndpoint{net: net, self: 1}.Send(context.Background(), 0, []byte{0x01, 0x02})
+	}()
+	err := RunSignPolicy(context.Background(), chanEndpoint{net: net, self: 0}, 0, 2, allowAll, request("m"))
+	var pe *PolicyError
+	if !errors.As(err, &pe) || pe.Party != 1 {
+		t.Fatalf("error = %v, want rejection naming party 1", err)
+	}
+}
@@ -0,0 +1,65 @@
+package cbmpc
+
+import "sync"
+
+// Direction tells whether a progress event is for a message sent or
+// received.
+type Direction uint8
+
+const (
+	DirectionSend Direction = iota
+	DirectionReceive
+)
+
+func (d Direction) String() string {
+	if d == DirectionSend {
+		return "send"
+	}
+	return "receive"
+}
+
+// RoundFunc receives progress events from a running protocol, one per
+// message sent to or received from peer. round is the 1-based round being
+// worked on, counted as in Traffic.Rounds, and totalEstimated is the number
+// of rounds the same protocol took the last time this process ran it with the
+// same party count and role, or 0 before the first run completes.
+//
+// It is called from the goroutines that drive the transport, possibly
+// concurrently, so it must be safe for concurrent use, return quickly and
+// not use the job.
+type RoundFunc func(round, totalEstimated int, direction Direction, peer RoleID)
+
+// roundKey identifies runs expected to take the same number of rounds.
+type roundKey struct {
+	protocol string
+	parties  int
+	self     RoleID
+}
+
+// roundCounts holds the rounds of the last successful run of each protocol,
+// shared by all jobs in the process.
+var roundCounts sync.Map // roundKey -> int
+
+// start prepares progress reporting for one protocol invocation.
+func (t *jobTrace) start(protocol string, self RoleID, parties int) {
+	t.key = roundKey{protocol: protocol, parties: parties, self: self}
+	t.estimate = 0
+	if n, ok := roundCounts.Load(t.key); ok {
+		t.estimate = n.(int)
+	}
+}
+
+// finish records the round count of a successful invocation for later
+// estimates.
+func (t *jobTrace) finish(errp *error) {
+	if t.key.protocol == "" || (errp != nil && *errp != nil) {
+		return
+	}
+	roundCounts.Store(t.key, int(t.rounds.Load()))
+}
+
+func (t *jobTrace) progress(round int64, d Direction, peer RoleID) {
+	if t.onRound != nil {
+		t.onRound(int(round), t.estimate, d, peer)
+	}
+}
@@ -0,0 +1,65 @@
+package cbmpc
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type progressEvent struct {
+	round, total int
+	dir          Direction
+	peer         RoleID
+}
+
+func TestOnRound(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []progressEvent
+	)
+	trace := &jobTrace{onRound: func(round, total int, dir Direction, peer RoleID) {
+		mu.Lock()
+		events = append(events, progressEvent{round, total, dir, peer})
+		mu.Unlock()
+	}}
+	j := &JobMP{self: 0, names: []string{"p0", "p1", "p2"}, trace: trace}
+	net := &chanNet{}
+	adapter := transportAdapter{inner: chanEndpoint{net: net, self: 0}, ctx: context.Background(), trace: trace}
+	peers := []chanEndpoint{{net: net, self: 1}, {net: net, self: 2}}
+
+	run := func() {
+		var err error
+		done := j.BeginOperation(context.Background(), &Operation{Protocol: "test.Progress"})
+		defer done(&err)
+		for range 2 {
+			if err = adapter.Send(context.Background(), 1, []byte("m")); err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range peers {
+				_ = p.Send(context.Background(), 0, []byte("m"))
+			}
+			if _, err = adapter.ReceiveAll(context.Background(), []uint32{1, 2}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	run()
+	want := []progressEvent{
+		{1, 0, DirectionSend, 1}, {1, 0, DirectionReceive, 1}, {1, 0, DirectionReceive, 2},
+		{2, 0, DirectionSend, 1}, {2, 0, DirectionReceive, 1}, {2, 0, DirectionReceive, 2},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("first run events = %v, want %v", events, want)
+	}
+
+	// The second run of the protocol estimates its length from the first.
+	events = nil
+	run()
+	for _, e := range events {
+		if e.total != 2 {
+			t.Fatalf("second run event %+v, want total 2", e)
+		}
+	}
+}
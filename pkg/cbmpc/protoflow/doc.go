@@ -0,0 +1,35 @@
+// Package protoflow captures the round and message structure of cb-mpc
+// protocols from real runs.
+//
+// The protocol flows are driven by the native library, so rather than keep a
+// hand-written description that can drift from upstream, protoflow records the
+// transport calls each party makes and derives the flow from them. The result
+// is machine-readable (JSON tags on every type) and can be rendered as a
+// Mermaid sequence diagram. Diffing flows across releases shows when a cb-mpc
+// bump adds rounds or changes who talks to whom, which affects firewall rules
+// and per-round timeouts.
+//
+// # Usage
+//
+// Wrap each party's transport in a Recorder, run the protocol, then Build:
+//
+//	net := mocknet.New()
+//	rec1 := protoflow.NewRecorder(net.Ep2P(0, 1), 0)
+//	rec2 := protoflow.NewRecorder(net.Ep2P(1, 0), 1)
+//
+//	job1, _ := cbmpc.NewJob2PWithContext(ctx, rec1, cbmpc.RoleP1, names)
+//	job2, _ := cbmpc.NewJob2PWithContext(ctx, rec2, cbmpc.RoleP2, names)
+//	// ... run ecdsa2p.DKG on both jobs concurrently ...
+//
+//	flow, err := protoflow.Build("ecdsa2p.DKG", rec1, rec2)
+//	out, _ := json.MarshalIndent(flow.WithoutSizes(), "", "  ")
+//
+// Rounds are numbered by causal depth: a message belongs to round r+1 when
+// the latest message its sender had received before sending it was in round
+// r. Each Party lists the send/receive steps of that role's state machine.
+//
+// Recorders never retain payloads, only peer roles, ordering and sizes.
+//
+// See examples/protocol-flows for a program that emits flows for the bundled
+// protocols.
+package protoflow
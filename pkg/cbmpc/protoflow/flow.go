@@ -0,0 +1,223 @@
+package protoflow
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// Action is what a party does in a step.
+type Action string
+
+const (
+	ActionSend    Action = "send"
+	ActionReceive Action = "receive"
+)
+
+// Message is a single point-to-point message in a flow.
+type Message struct {
+	From  cbmpc.RoleID `json:"from"`
+	To    cbmpc.RoleID `json:"to"`
+	Bytes int          `json:"bytes,omitempty"`
+}
+
+// Round groups messages by causal depth: a message is in round 1 if its sender
+// had not received anything before sending it, and in round r+1 if the latest
+// round the sender had received from was r.
+type Round struct {
+	Number   int       `json:"number"`
+	Messages []Message `json:"messages"`
+}
+
+// Step is one state of a party's state machine: sending to, or waiting on, a
+// set of peers. Consecutive sends in the same round are merged into one step.
+type Step struct {
+	Action Action         `json:"action"`
+	Round  int            `json:"round"`
+	Peers  []cbmpc.RoleID `json:"peers"`
+}
+
+// Party is the sequence of steps one role went through.
+type Party struct {
+	Role  cbmpc.RoleID `json:"role"`
+	Steps []Step       `json:"steps"`
+}
+
+// Flow is the machine-readable round structure of one protocol run.
+type Flow struct {
+	Protocol string  `json:"protocol"`
+	Rounds   []Round `json:"rounds"`
+	Parties  []Party `json:"parties"`
+}
+
+// Build reconstructs the round structure of a protocol run from the
+// recorders of every participating party. Messages between a pair of parties
+// are matched in FIFO order, which is the ordering the native library
+// requires from every Transport.
+func Build(protocol string, recorders ...*Recorder) (*Flow, error) {
+	if len(recorders) == 0 {
+		return nil, errors.New("no recorders")
+	}
+
+	type pairKey struct{ from, to cbmpc.RoleID }
+	type party struct {
+		role   cbmpc.RoleID
+		events []event
+		pos    int
+		depth  int
+		steps  []Step
+	}
+
+	parties := make([]*party, len(recorders))
+	seen := make(map[cbmpc.RoleID]bool, len(recorders))
+	for i, r := range recorders {
+		if r == nil {
+			return nil, errors.New("nil recorder")
+		}
+		if seen[r.self] {
+			return nil, fmt.Errorf("duplicate recorder for role %d", r.self)
+		}
+		seen[r.self] = true
+		parties[i] = &party{role: r.self, events: r.snapshot()}
+	}
+	sort.Slice(parties, func(i, j int) bool { return parties[i].role < parties[j].role })
+
+	// inflight holds the round number of each sent but not yet matched message.
+	inflight := make(map[pairKey][]int)
+	rounds := make(map[int][]Message)
+
+	for {
+		progressed := false
+		done := true
+		for _, p := range parties {
+			for p.pos < len(p.events) {
+				e := p.events[p.pos]
+				if e.kind == eventSend {
+					round := p.depth + 1
+					to := e.peers[0]
+					if !seen[to] {
+						return nil, fmt.Errorf("role %d sent to unrecorded role %d", p.role, to)
+					}
+					inflight[pairKey{p.role, to}] = append(inflight[pairKey{p.role, to}], round)
+					rounds[round] = append(rounds[round], Message{From: p.role, To: to, Bytes: e.sizes[0]})
+					if n := len(p.steps); n > 0 && p.steps[n-1].Action == ActionSend && p.steps[n-1].Round == round {
+						p.steps[n-1].Peers = append(p.steps[n-1].Peers, to)
+					} else {
+						p.steps = append(p.steps, Step{Action: ActionSend, Round: round, Peers: []cbmpc.RoleID{to}})
+					}
+				} else {
+					ready := true
+					for _, from := range e.peers {
+						if len(inflight[pairKey{from, p.role}]) == 0 {
+							ready = false
+							break
+						}
+					}
+					if !ready {
+						break
+					}
+					round := 0
+					for _, from := range e.peers {
+						k := pairKey{from, p.role}
+						if r := inflight[k][0]; r > round {
+							round = r
+						}
+						inflight[k] = inflight[k][1:]
+					}
+					if round > p.depth {
+						p.depth = round
+					}
+					p.steps = append(p.steps, Step{Action: ActionReceive, Round: round, Peers: append([]cbmpc.RoleID(nil), e.peers...)})
+				}
+				p.pos++
+				progressed = true
+			}
+			if p.pos < len(p.events) {
+				done = false
+			}
+		}
+		if done {
+			break
+		}
+		if !progressed {
+			return nil, errors.New("recorded events are inconsistent: a receive has no matching send")
+		}
+	}
+	for k, pending := range inflight {
+		if len(pending) != 0 {
+			return nil, fmt.Errorf("%d message(s) from role %d to role %d were never received", len(pending), k.from, k.to)
+		}
+	}
+
+	f := &Flow{Protocol: protocol}
+	for n := 1; n <= len(rounds); n++ {
+		msgs := rounds[n]
+		sort.SliceStable(msgs, func(i, j int) bool {
+			if msgs[i].From != msgs[j].From {
+				return msgs[i].From < msgs[j].From
+			}
+			return msgs[i].To < msgs[j].To
+		})
+		f.Rounds = append(f.Rounds, Round{Number: n, Messages: msgs})
+	}
+	for _, p := range parties {
+		f.Parties = append(f.Parties, Party{Role: p.role, Steps: p.steps})
+	}
+	return f, nil
+}
+
+// WithoutSizes returns a copy of f with message sizes cleared. Sizes of
+// encoded proofs and signatures vary slightly between runs; dropping them
+// keeps diffs across releases focused on structural changes.
+func (f *Flow) WithoutSizes() *Flow {
+	out := &Flow{Protocol: f.Protocol, Parties: f.Parties}
+	for _, r := range f.Rounds {
+		msgs := make([]Message, len(r.Messages))
+		for i, m := range r.Messages {
+			msgs[i] = Message{From: m.From, To: m.To}
+		}
+		out.Rounds = append(out.Rounds, Round{Number: r.Number, Messages: msgs})
+	}
+	return out
+}
+
+// Mermaid renders f as a Mermaid sequence diagram. names optionally maps role
+// IDs to display names; roles without a name are shown as P<role>.
+func (f *Flow) Mermaid(names map[cbmpc.RoleID]string) string {
+	label := func(role cbmpc.RoleID) string {
+		if name, ok := names[role]; ok && name != "" {
+			return name
+		}
+		return fmt.Sprintf("P%d", role)
+	}
+
+	var b strings.Builder
+	b.WriteString("sequenceDiagram\n")
+	if f.Protocol != "" {
+		fmt.Fprintf(&b, "    title %s\n", f.Protocol)
+	}
+	for _, p := range f.Parties {
+		fmt.Fprintf(&b, "    participant %s\n", label(p.Role))
+	}
+	span := ""
+	if n := len(f.Parties); n > 0 {
+		span = label(f.Parties[0].Role)
+		if n > 1 {
+			span += "," + label(f.Parties[n-1].Role)
+		}
+	}
+	for _, r := range f.Rounds {
+		fmt.Fprintf(&b, "    Note over %s: round %d\n", span, r.Number)
+		for _, m := range r.Messages {
+			if m.Bytes > 0 {
+				fmt.Fprintf(&b, "    %s->>%s: %d B\n", label(m.From), label(m.To), m.Bytes)
+			} else {
+				fmt.Fprintf(&b, "    %s->>%s: msg\n", label(m.From), label(m.To))
+			}
+		}
+	}
+	return b.String()
+}
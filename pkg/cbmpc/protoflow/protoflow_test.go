@@ -0,0 +1,170 @@
+package protoflow_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/protoflow"
+)
+
+// runToyProtocol runs a 3-party exchange over mocknet: every party broadcasts
+// a commitment and collects the others', then party 0 sends a request to
+// party 1, which answers.
+func runToyProtocol(t *testing.T) []*protoflow.Recorder {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	roles := []cbmpc.RoleID{0, 1, 2}
+	net := mocknet.New()
+	recs := make([]*protoflow.Recorder, len(roles))
+	for i, role := range roles {
+		recs[i] = protoflow.NewRecorder(net.EpMP(role, roles), role)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(roles))
+	for i, role := range roles {
+		wg.Add(1)
+		go func(rec *protoflow.Recorder, self cbmpc.RoleID) {
+			defer wg.Done()
+			var others []cbmpc.RoleID
+			for _, r := range roles {
+				if r != self {
+					others = append(others, r)
+					if err := rec.Send(ctx, r, make([]byte, 32)); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}
+			if _, err := rec.ReceiveAll(ctx, others); err != nil {
+				errs <- err
+				return
+			}
+			switch self {
+			case 0:
+				if err := rec.Send(ctx, 1, make([]byte, 8)); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := rec.Receive(ctx, 1); err != nil {
+					errs <- err
+				}
+			case 1:
+				if _, err := rec.Receive(ctx, 0); err != nil {
+					errs <- err
+					return
+				}
+				if err := rec.Send(ctx, 0, make([]byte, 64)); err != nil {
+					errs <- err
+				}
+			}
+		}(recs[i], role)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("toy protocol failed: %v", err)
+	}
+	return recs
+}
+
+func TestBuildRounds(t *testing.T) {
+	recs := runToyProtocol(t)
+
+	flow, err := protoflow.Build("toy", recs...)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(flow.Rounds) != 3 {
+		t.Fatalf("expected 3 rounds, got %d: %+v", len(flow.Rounds), flow.Rounds)
+	}
+	if got := len(flow.Rounds[0].Messages); got != 6 {
+		t.Fatalf("expected 6 broadcast messages in round 1, got %d", got)
+	}
+	r2 := flow.Rounds[1].Messages
+	if len(r2) != 1 || r2[0].From != 0 || r2[0].To != 1 || r2[0].Bytes != 8 {
+		t.Fatalf("unexpected round 2: %+v", r2)
+	}
+	r3 := flow.Rounds[2].Messages
+	if len(r3) != 1 || r3[0].From != 1 || r3[0].To != 0 || r3[0].Bytes != 64 {
+		t.Fatalf("unexpected round 3: %+v", r3)
+	}
+
+	// Party 0: broadcast, collect, request, wait for answer.
+	steps := flow.Parties[0].Steps
+	wantActions := []protoflow.Action{protoflow.ActionSend, protoflow.ActionReceive, protoflow.ActionSend, protoflow.ActionReceive}
+	if len(steps) != len(wantActions) {
+		t.Fatalf("expected %d steps for party 0, got %+v", len(wantActions), steps)
+	}
+	for i, s := range steps {
+		if s.Action != wantActions[i] {
+			t.Fatalf("step %d: expected %s, got %s", i, wantActions[i], s.Action)
+		}
+	}
+	if len(steps[0].Peers) != 2 {
+		t.Fatalf("expected broadcast step to list both peers, got %v", steps[0].Peers)
+	}
+	// Party 2 only takes part in the first round.
+	if got := len(flow.Parties[2].Steps); got != 2 {
+		t.Fatalf("expected 2 steps for party 2, got %d", got)
+	}
+}
+
+func TestFlowJSONWithoutSizes(t *testing.T) {
+	flow, err := protoflow.Build("toy", runToyProtocol(t)...)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	a, err := json.Marshal(flow.WithoutSizes())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(a), "bytes") {
+		t.Fatalf("sizes not stripped: %s", a)
+	}
+
+	again, err := protoflow.Build("toy", runToyProtocol(t)...)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	b, _ := json.Marshal(again.WithoutSizes())
+	if string(a) != string(b) {
+		t.Fatalf("flow is not stable across runs:\n%s\n%s", a, b)
+	}
+}
+
+func TestMermaid(t *testing.T) {
+	flow, err := protoflow.Build("toy", runToyProtocol(t)...)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	out := flow.Mermaid(map[cbmpc.RoleID]string{0: "alice"})
+	for _, want := range []string{"sequenceDiagram", "participant alice", "participant P1", "round 3", "P1->>alice: 64 B"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("diagram missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	if _, err := protoflow.Build("none"); err == nil {
+		t.Fatal("expected error without recorders")
+	}
+
+	recs := runToyProtocol(t)
+	// Dropping party 2 leaves its sends unrecorded and its messages to others unmatched.
+	if _, err := protoflow.Build("partial", recs[0], recs[1]); err == nil {
+		t.Fatal("expected error for incomplete recorder set")
+	}
+	if _, err := protoflow.Build("dup", recs[0], recs[0], recs[1]); err == nil {
+		t.Fatal("expected error for duplicate recorder")
+	}
+}
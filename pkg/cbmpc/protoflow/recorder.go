@@ -0,0 +1,104 @@
+package protoflow
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+type eventKind int
+
+const (
+	eventSend eventKind = iota
+	eventReceive
+)
+
+// event is a single completed transport call observed by a Recorder.
+type event struct {
+	kind  eventKind
+	peers []cbmpc.RoleID // destination for sends, sources for receives
+	sizes []int          // payload size per peer
+}
+
+// Recorder wraps a cbmpc.Transport and records the shape of every message a
+// party sends and receives: peer roles, ordering and payload sizes. Payload
+// contents are never retained.
+//
+// Wrap each party's transport in a Recorder, run the protocol, then pass all
+// recorders to Build.
+type Recorder struct {
+	inner cbmpc.Transport
+	self  cbmpc.RoleID
+
+	mu     sync.Mutex
+	events []event
+}
+
+// NewRecorder returns a Recorder that forwards to t on behalf of self.
+func NewRecorder(t cbmpc.Transport, self cbmpc.RoleID) *Recorder {
+	return &Recorder{inner: t, self: self}
+}
+
+// Self returns the role this recorder observes.
+func (r *Recorder) Self() cbmpc.RoleID { return r.self }
+
+// Reset discards recorded events so the recorder can capture another run.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+// Send forwards to the wrapped transport and records the message on success.
+func (r *Recorder) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	if err := r.inner.Send(ctx, to, msg); err != nil {
+		return err
+	}
+	r.record(event{kind: eventSend, peers: []cbmpc.RoleID{to}, sizes: []int{len(msg)}})
+	return nil
+}
+
+// Receive forwards to the wrapped transport and records the message on success.
+func (r *Recorder) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
+	msg, err := r.inner.Receive(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	r.record(event{kind: eventReceive, peers: []cbmpc.RoleID{from}, sizes: []int{len(msg)}})
+	return msg, nil
+}
+
+// ReceiveAll forwards to the wrapped transport and records the batch on success.
+func (r *Recorder) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	msgs, err := r.inner.ReceiveAll(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	peers := make([]cbmpc.RoleID, 0, len(msgs))
+	for role := range msgs {
+		peers = append(peers, role)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+	sizes := make([]int, len(peers))
+	for i, role := range peers {
+		sizes[i] = len(msgs[role])
+	}
+	r.record(event{kind: eventReceive, peers: peers, sizes: sizes})
+	return msgs, nil
+}
+
+func (r *Recorder) record(e event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *Recorder) snapshot() []event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]event(nil), r.events...)
+}
+
+var _ cbmpc.Transport = (*Recorder)(nil)
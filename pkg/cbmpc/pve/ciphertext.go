@@ -0,0 +1,192 @@
+package pve
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+// Ciphertext represents a publicly verifiable encryption ciphertext.
+//
+// Encrypt returns a tagged ciphertext: a short header recording the format
+// version, curve and label, followed by the ciphertext produced by the native
+// library under the KEM. The header lets storage layers index and sanity-check
+// ciphertexts with Version, Curve and Label without calling into the native
+// library. Untagged ciphertexts written by earlier versions (Version 0) are
+// still accepted by every operation; Label falls back to the native library
+// for them and Curve reports ErrUntagged.
+type Ciphertext []byte
+
+// CiphertextVersion is the tagged ciphertext format written by Encrypt.
+const CiphertextVersion = 1
+
+// ciphertextMagic starts every tagged ciphertext and tells it apart from an
+// untagged one, whose native serialization starts with the encoding of Q.
+var ciphertextMagic = []byte("cbpv")
+
+// ciphertextHeaderSize is the size of the fixed part of the header: magic,
+// version (uint16), curve (uint8) and label length (uint32).
+const ciphertextHeaderSize = 4 + 2 + 1 + 4
+
+var (
+	// ErrMalformedCiphertext is returned for tagged ciphertexts whose header
+	// cannot be parsed.
+	ErrMalformedCiphertext = errors.New("pve: malformed ciphertext")
+	// ErrUntagged is returned by accessors that need the header of a
+	// ciphertext written before ciphertexts were tagged.
+	ErrUntagged = errors.New("pve: untagged ciphertext")
+)
+
+type ciphertextHeader struct {
+	version uint16
+	curve   cbmpc.Curve
+	label   []byte
+	body    []byte // the native ciphertext
+}
+
+// newCiphertext tags a native ciphertext.
+func newCiphertext(c cbmpc.Curve, label, native []byte) (Ciphertext, error) {
+	if c < 0 || c > 255 {
+		return nil, fmt.Errorf("invalid curve %d", int(c))
+	}
+	out := make([]byte, 0, ciphertextHeaderSize+len(label)+len(native))
+	out = append(out, ciphertextMagic...)
+	out = binary.BigEndian.AppendUint16(out, CiphertextVersion)
+	out = append(out, byte(c))
+	out = binary.BigEndian.AppendUint32(out, uint32(len(label)))
+	out = append(out, label...)
+	return append(out, native...), nil
+}
+
+// header parses the tag. It returns nil for untagged ciphertexts.
+func (ct Ciphertext) header() (*ciphertextHeader, error) {
+	if !bytes.HasPrefix(ct, ciphertextMagic) {
+		return nil, nil
+	}
+	if len(ct) < ciphertextHeaderSize {
+		return nil, fmt.Errorf("%w: short header", ErrMalformedCiphertext)
+	}
+	h := &ciphertextHeader{
+		version: binary.BigEndian.Uint16(ct[4:]),
+		curve:   cbmpc.Curve(ct[6]),
+	}
+	if h.version != CiphertextVersion {
+		return nil, fmt.Errorf("%w: format v%d, this library reads v%d", ErrMalformedCiphertext, h.version, CiphertextVersion)
+	}
+	n := binary.BigEndian.Uint32(ct[7:])
+	rest := ct[ciphertextHeaderSize:]
+	if uint64(n) > uint64(len(rest)) {
+		return nil, fmt.Errorf("%w: label overruns ciphertext", ErrMalformedCiphertext)
+	}
+	h.label, h.body = rest[:n], rest[n:]
+	if len(h.body) == 0 {
+		return nil, fmt.Errorf("%w: missing KEM ciphertext", ErrMalformedCiphertext)
+	}
+	return h, nil
+}
+
+// Version returns the ciphertext format version: CiphertextVersion for
+// ciphertexts returned by Encrypt and 0 for untagged ones.
+func (ct Ciphertext) Version() (int, error) {
+	h, err := ct.header()
+	if err != nil || h == nil {
+		return 0, err
+	}
+	return int(h.version), nil
+}
+
+// Curve returns the curve of the encrypted scalar.
+func (ct Ciphertext) Curve() (cbmpc.Curve, error) {
+	h, err := ct.header()
+	if err != nil {
+		return cbmpc.CurveUnknown, err
+	}
+	if h == nil {
+		return cbmpc.CurveUnknown, ErrUntagged
+	}
+	return h.curve, nil
+}
+
+// Label extracts the label from the ciphertext. The result aliases ct for
+// tagged ciphertexts.
+// See cb-mpc/src/cbmpc/protocol/pve.h for protocol details.
+func (ct Ciphertext) Label() ([]byte, error) {
+	if len(ct) == 0 {
+		return nil, errors.New("empty ciphertext")
+	}
+	h, err := ct.header()
+	if err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return backend.PVEGetLabel(ct)
+	}
+	return h.label, nil
+}
+
+// KEMCiphertext returns the ciphertext produced by the native library under
+// the KEM, without the tag; for untagged ciphertexts it returns ct itself.
+// This is what Verify and Decrypt pass to the native library.
+func (ct Ciphertext) KEMCiphertext() ([]byte, error) {
+	if len(ct) == 0 {
+		return nil, errors.New("empty ciphertext")
+	}
+	h, err := ct.header()
+	if err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return ct, nil
+	}
+	return h.body, nil
+}
+
+// Size returns the encoded size of the ciphertext in bytes.
+func (ct Ciphertext) Size() int { return len(ct) }
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns a copy of
+// the ciphertext after checking that its header, if any, is well-formed.
+func (ct Ciphertext) MarshalBinary() ([]byte, error) {
+	if _, err := ct.header(); err != nil {
+		return nil, err
+	}
+	return bytes.Clone([]byte(ct)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It accepts tagged
+// and untagged ciphertexts, checks the header of tagged ones, and copies
+// data.
+func (ct *Ciphertext) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty ciphertext")
+	}
+	if _, err := Ciphertext(data).header(); err != nil {
+		return err
+	}
+	*ct = bytes.Clone(data)
+	return nil
+}
+
+// checkLabel returns the native ciphertext after checking, for tagged
+// ciphertexts, that the tag records label. The check only gives an early,
+// clearer error; the native library binds the label cryptographically.
+func (ct Ciphertext) checkLabel(label []byte) ([]byte, error) {
+	if len(ct) == 0 {
+		return nil, errors.New("empty ciphertext")
+	}
+	h, err := ct.header()
+	if err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return ct, nil
+	}
+	if !bytes.Equal(h.label, label) {
+		return nil, errors.New("pve: ciphertext label does not match")
+	}
+	return h.body, nil
+}
@@ -0,0 +1,99 @@
+package pve_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = pve.Ciphertext(nil)
+	_ encoding.BinaryUnmarshaler = (*pve.Ciphertext)(nil)
+)
+
+// tagged builds a tagged ciphertext by hand, as Encrypt would.
+func tagged(version uint16, c cbmpc.Curve, label, native []byte) []byte {
+	out := []byte("cbpv")
+	out = binary.BigEndian.AppendUint16(out, version)
+	out = append(out, byte(c))
+	out = binary.BigEndian.AppendUint32(out, uint32(len(label)))
+	out = append(out, label...)
+	return append(out, native...)
+}
+
+func TestCiphertextAccessors(t *testing.T) {
+	native := []byte{0x02, 0xaa, 0xbb, 0xcc}
+	ct := pve.Ciphertext(tagged(pve.CiphertextVersion, cbmpc.CurveSecp256k1, []byte("backup-1"), native))
+
+	if v, err := ct.Version(); err != nil || v != pve.CiphertextVersion {
+		t.Fatalf("Version() = %d, %v", v, err)
+	}
+	if c, err := ct.Curve(); err != nil || c != cbmpc.CurveSecp256k1 {
+		t.Fatalf("Curve() = %v, %v", c, err)
+	}
+	if l, err := ct.Label(); err != nil || string(l) != "backup-1" {
+		t.Fatalf("Label() = %q, %v", l, err)
+	}
+	if k, err := ct.KEMCiphertext(); err != nil || !bytes.Equal(k, native) {
+		t.Fatalf("KEMCiphertext() = %x, %v", k, err)
+	}
+	if ct.Size() != len(ct) {
+		t.Fatalf("Size() = %d, want %d", ct.Size(), len(ct))
+	}
+
+	data, err := ct.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back pve.Ciphertext
+	if err := back.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-1] ^= 1
+	if !bytes.Equal(back, ct) {
+		t.Fatal("UnmarshalBinary did not copy its input")
+	}
+}
+
+func TestCiphertextUntagged(t *testing.T) {
+	ct := pve.Ciphertext{0x02, 0xaa, 0xbb}
+	if v, err := ct.Version(); err != nil || v != 0 {
+		t.Fatalf("Version() = %d, %v; want 0", v, err)
+	}
+	if _, err := ct.Curve(); !errors.Is(err, pve.ErrUntagged) {
+		t.Fatalf("Curve() error = %v, want ErrUntagged", err)
+	}
+	if k, err := ct.KEMCiphertext(); err != nil || !bytes.Equal(k, ct) {
+		t.Fatalf("KEMCiphertext() = %x, %v", k, err)
+	}
+	var back pve.Ciphertext
+	if err := back.UnmarshalBinary(ct); err != nil {
+		t.Fatalf("UnmarshalBinary rejected an untagged ciphertext: %v", err)
+	}
+}
+
+func TestCiphertextMalformed(t *testing.T) {
+	good := tagged(pve.CiphertextVersion, cbmpc.CurveP256, []byte("l"), []byte{1})
+	cases := map[string][]byte{
+		"short header":   good[:8],
+		"label overruns": tagged(pve.CiphertextVersion, cbmpc.CurveP256, []byte("label"), nil)[:14],
+		"no body":        tagged(pve.CiphertextVersion, cbmpc.CurveP256, []byte("l"), nil),
+		"future version": tagged(pve.CiphertextVersion+1, cbmpc.CurveP256, []byte("l"), []byte{1}),
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			var ct pve.Ciphertext
+			if err := ct.UnmarshalBinary(data); !errors.Is(err, pve.ErrMalformedCiphertext) {
+				t.Fatalf("UnmarshalBinary error = %v, want ErrMalformedCiphertext", err)
+			}
+			if _, err := pve.Ciphertext(data).Label(); !errors.Is(err, pve.ErrMalformedCiphertext) {
+				t.Fatalf("Label error = %v, want ErrMalformedCiphertext", err)
+			}
+		})
+	}
+}
@@ -20,15 +20,28 @@
 //
 // # Key Operations
 //
-// Single-scalar operations (Ciphertext has Q() and Label() getters):
-//   - Encrypt: Creates a PVE ciphertext with proof
-//   - Verify: Verifies a PVE ciphertext against a commitment
-//   - Decrypt: Decrypts a PVE ciphertext to recover the scalar
+// Single-scalar operations (Ciphertext has Q(), Label(), Curve() and other
+// getters):
+//   - Encrypt, Verify, Decrypt: Create, check and open a PVE ciphertext
+//   - Rotate: Re-encrypt a ciphertext to a new key, checking both ends
 //
 // Batch operations (BatchCiphertext does NOT have Q() or Label() getters):
-//   - BatchEncrypt: Creates a batch PVE ciphertext for multiple scalars
-//   - BatchVerify: Verifies a batch ciphertext against multiple commitments
-//   - BatchDecrypt: Decrypts a batch ciphertext to recover multiple scalars
+//   - BatchEncrypt, BatchVerify, BatchDecrypt: One ciphertext and one proof
+//     for many scalars
+//
+// Marshal and Unmarshal wrap any ciphertext in a versioned envelope for
+// storage, and the pve/restore subpackage restores PVE-AC ciphertexts from a
+// quorum's decryption shares. README.md shows each with an example.
+//
+// # Ciphertext Format
+//
+// Encrypt returns a tagged Ciphertext: a header with the format version,
+// curve and label, followed by the native ciphertext under the KEM. Storage
+// layers can index and validate backups in pure Go with Version, Curve,
+// Label, KEMCiphertext and Size, and Ciphertext implements
+// encoding.BinaryMarshaler and BinaryUnmarshaler, which reject malformed
+// headers. Untagged ciphertexts from earlier versions remain valid inputs.
+// Only Q needs the native library.
 //
 // # KEM Requirements
 //
@@ -37,7 +50,8 @@
 //   - Provide domain separation (different keys → different ciphertexts)
 //   - Implement the cbmpc.KEM interface
 //
-// See pkg/cbmpc/kem for available KEM implementations.
+// See pkg/cbmpc/kem for available KEM implementations: kem/rsa, and kem/ecies
+// for smaller ciphertexts and faster encryption.
 //
 // # Security Properties
 //
@@ -49,40 +63,20 @@
 //
 // # Usage Example
 //
-//	import (
-//	    "github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
-//	    "github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/rsa"
-//	    "github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
-//	)
-//
-//	// Create KEM and PVE instance
 //	kem, _ := rsa.New(2048)
 //	_, ek, _ := kem.Generate()
 //	pveInstance, _ := pve.New(kem)
 //
-//	// Encrypt a scalar value
-//	x, _ := curve.NewScalarFromString("123456789")
-//	defer x.Free()
-//
 //	result, _ := pveInstance.Encrypt(ctx, &pve.EncryptParams{
-//	    EK:    ek,
-//	    Label: []byte("my-label"),
-//	    Curve: cbmpc.CurveP256,
-//	    X:     x,
+//	    EK: ek, Label: []byte("my-label"), Curve: cbmpc.CurveP256, X: x,
 //	})
-//
-//	// Extract commitment Q for verification
 //	Q, _ := result.Ciphertext.Q()
 //	defer Q.Free()
 //
-//	// Anyone can verify the ciphertext
+//	// Anyone can verify the ciphertext; nil means it is valid.
 //	err := pveInstance.Verify(ctx, &pve.VerifyParams{
-//	    EK:         ek,
-//	    Ciphertext: result.Ciphertext,
-//	    Q:          Q,
-//	    Label:      []byte("my-label"),
+//	    EK: ek, Ciphertext: result.Ciphertext, Q: Q, Label: []byte("my-label"),
 //	})
-//	// err == nil means verification succeeded
 //
 // See cb-mpc/src/cbmpc/protocol/pve.h for protocol implementation details.
 package pve
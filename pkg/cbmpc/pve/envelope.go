@@ -0,0 +1,116 @@
+package pve
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+)
+
+// CiphertextType identifies the ciphertext carried by an envelope. The values
+// are part of the stored format and must never be renumbered.
+type CiphertextType uint8
+
+const (
+	CiphertextSingle CiphertextType = 1 // Ciphertext
+	CiphertextBatch  CiphertextType = 2 // BatchCiphertext
+	CiphertextAC     CiphertextType = 3 // ACCiphertext
+)
+
+// ciphertextVersions holds the payload format version written for each
+// ciphertext type. Bump an entry when the native serialization changes.
+var ciphertextVersions = map[CiphertextType]uint16{
+	CiphertextSingle: 1,
+	CiphertextBatch:  1,
+	CiphertextAC:     1,
+}
+
+// String returns a short name for the ciphertext type.
+func (t CiphertextType) String() string {
+	switch t {
+	case CiphertextSingle:
+		return "single"
+	case CiphertextBatch:
+		return "batch"
+	case CiphertextAC:
+		return "ac"
+	default:
+		return fmt.Sprintf("CiphertextType(%d)", uint8(t))
+	}
+}
+
+// Envelope errors. Unmarshal wraps one of these so callers can tell a
+// ciphertext stored by a newer library or of a different kind apart from a
+// corrupted one.
+var (
+	ErrEnvelopeMalformed      = envelope.ErrMalformed
+	ErrEnvelopeVersion        = envelope.ErrUnsupportedVersion
+	ErrCiphertextTypeMismatch = envelope.ErrTypeMismatch
+	ErrCurveMismatch          = envelope.ErrCurveMismatch
+)
+
+// Envelope is a decoded ciphertext envelope.
+type Envelope struct {
+	Type    CiphertextType
+	Curve   cbmpc.Curve
+	Version uint16 // Payload format version
+	Payload []byte // The raw ciphertext, as returned by Encrypt
+}
+
+// Marshal wraps a ciphertext in a versioned envelope recording its type and
+// curve, for backups that must survive library upgrades.
+func Marshal(t CiphertextType, c cbmpc.Curve, ct []byte) ([]byte, error) {
+	v, ok := ciphertextVersions[t]
+	if !ok {
+		return nil, fmt.Errorf("unknown ciphertext type %d", uint8(t))
+	}
+	if c < 0 || c > 255 {
+		return nil, fmt.Errorf("invalid curve %d", int(c))
+	}
+	if len(ct) == 0 {
+		return nil, errors.New("empty ciphertext")
+	}
+	return envelope.Encode(envelope.Header{
+		Domain:  envelope.DomainPVE,
+		Type:    uint8(t),
+		Curve:   uint8(c),
+		Version: v,
+	}, ct)
+}
+
+// ParseEnvelope decodes an envelope written by Marshal without checking what
+// it contains. The payload aliases data.
+func ParseEnvelope(data []byte) (*Envelope, error) {
+	h, payload, err := envelope.Decode(envelope.DomainPVE, data)
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		Type:    CiphertextType(h.Type),
+		Curve:   cbmpc.Curve(h.Curve),
+		Version: h.Version,
+		Payload: payload,
+	}, nil
+}
+
+// Unmarshal decodes an envelope written by Marshal and returns the ciphertext
+// after checking that it has type t and curve c in a format this library
+// understands. Convert the result to the matching ciphertext type before
+// calling Verify or Decrypt.
+func Unmarshal(data []byte, t CiphertextType, c cbmpc.Curve) ([]byte, error) {
+	env, err := ParseEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	if env.Type != t {
+		return nil, fmt.Errorf("%w: have %s, want %s", ErrCiphertextTypeMismatch, env.Type, t)
+	}
+	if env.Curve != c {
+		return nil, fmt.Errorf("%w: have %s, want %s", ErrCurveMismatch, env.Curve, c)
+	}
+	if want := ciphertextVersions[t]; env.Version != want {
+		return nil, fmt.Errorf("%w: %s ciphertext format v%d, this library reads v%d", ErrEnvelopeVersion, t, env.Version, want)
+	}
+	return append([]byte(nil), env.Payload...), nil
+}
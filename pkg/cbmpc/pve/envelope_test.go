@@ -0,0 +1,46 @@
+package pve_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/zk"
+)
+
+func TestEnvelope(t *testing.T) {
+	ct := []byte("ciphertext bytes")
+	data, err := pve.Marshal(pve.CiphertextBatch, cbmpc.CurveP256, ct)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := pve.Unmarshal(data, pve.CiphertextBatch, cbmpc.CurveP256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, ct) {
+		t.Fatalf("payload = %q, want %q", got, ct)
+	}
+
+	if _, err := pve.Unmarshal(data, pve.CiphertextSingle, cbmpc.CurveP256); !errors.Is(err, pve.ErrCiphertextTypeMismatch) {
+		t.Errorf("type mismatch: %v", err)
+	}
+	if _, err := pve.Unmarshal(data, pve.CiphertextBatch, cbmpc.CurveSecp256k1); !errors.Is(err, pve.ErrCurveMismatch) {
+		t.Errorf("curve mismatch: %v", err)
+	}
+	if _, err := pve.Unmarshal(ct, pve.CiphertextBatch, cbmpc.CurveP256); !errors.Is(err, pve.ErrEnvelopeMalformed) {
+		t.Errorf("raw ciphertext: %v", err)
+	}
+
+	// A zk envelope is not a pve envelope.
+	proof, err := zk.Marshal(zk.ProofDL, cbmpc.CurveP256, []byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pve.ParseEnvelope(proof); !errors.Is(err, pve.ErrEnvelopeMalformed) {
+		t.Errorf("zk envelope: %v", err)
+	}
+}
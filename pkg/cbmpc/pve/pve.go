@@ -5,6 +5,7 @@ package pve
 import (
 	"context"
 	"errors"
+	"fmt"
 	"runtime"
 
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
@@ -27,17 +28,15 @@ func New(kem cbmpc.KEM) (*PVE, error) {
 	return &PVE{kem: kem}, nil
 }
 
-// Ciphertext represents a publicly verifiable encryption ciphertext.
-type Ciphertext []byte
-
 // Q extracts the public key point Q from the ciphertext.
 // See cb-mpc/src/cbmpc/protocol/pve.h for protocol details.
 func (ct Ciphertext) Q() (*cbmpc.CurvePoint, error) {
-	if len(ct) == 0 {
-		return nil, errors.New("empty ciphertext")
+	native, err := ct.KEMCiphertext()
+	if err != nil {
+		return nil, err
 	}
 
-	cpoint, err := backend.PVEGetQPoint(ct)
+	cpoint, err := backend.PVEGetQPoint(native)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
@@ -45,15 +44,6 @@ func (ct Ciphertext) Q() (*cbmpc.CurvePoint, error) {
 	return curve.NewPointFromBackend(cpoint), nil
 }
 
-// Label extracts the label from the ciphertext.
-// See cb-mpc/src/cbmpc/protocol/pve.h for protocol details.
-func (ct Ciphertext) Label() ([]byte, error) {
-	if len(ct) == 0 {
-		return nil, errors.New("empty ciphertext")
-	}
-	return backend.PVEGetLabel(ct)
-}
-
 // EncryptParams contains parameters for PVE encryption.
 type EncryptParams struct {
 	// EK is the public encryption key bytes (serialized).
@@ -101,9 +91,11 @@ func (pve *PVE) Encrypt(_ context.Context, params *EncryptParams) (*EncryptResul
 		return nil, cbmpc.RemapError(err)
 	}
 
-	return &EncryptResult{
-		Ciphertext: Ciphertext(ctBytes),
-	}, nil
+	ct, err := newCiphertext(params.Curve, params.Label, ctBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptResult{Ciphertext: ct}, nil
 }
 
 // VerifyParams contains parameters for PVE verification.
@@ -134,7 +126,11 @@ func (pve *PVE) Verify(_ context.Context, params *VerifyParams) error {
 		return errors.New("nil Q")
 	}
 
-	err := backend.PVEVerifyWithPoint(pve.kem, params.EK, params.Ciphertext, params.Q.CPtr(), params.Label)
+	native, err := params.Ciphertext.checkLabel(params.Label)
+	if err != nil {
+		return err
+	}
+	err = backend.PVEVerifyWithPoint(pve.kem, params.EK, native, params.Q.CPtr(), params.Label)
 	if err != nil {
 		return cbmpc.RemapError(err)
 	}
@@ -185,12 +181,19 @@ func (pve *PVE) Decrypt(_ context.Context, params *DecryptParams) (*DecryptResul
 	if err != nil {
 		return nil, err
 	}
+	native, err := params.Ciphertext.checkLabel(params.Label)
+	if err != nil {
+		return nil, err
+	}
+	if c, err := params.Ciphertext.Curve(); err == nil && c != params.Curve {
+		return nil, fmt.Errorf("pve: ciphertext is for curve %s, not %s", c, params.Curve)
+	}
 
 	// Register the DK handle so it can be safely passed through C
 	dkHandle := backend.RegisterHandle(params.DK)
 	defer backend.FreeHandle(dkHandle)
 
-	xBytes, err := backend.PVEDecrypt(pve.kem, dkHandle, params.EK, params.Ciphertext, params.Label, nid)
+	xBytes, err := backend.PVEDecrypt(pve.kem, dkHandle, params.EK, native, params.Label, nid)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
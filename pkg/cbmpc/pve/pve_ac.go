@@ -22,7 +22,9 @@ type ACEncryptParams struct {
 	AC ac.AccessStructure
 
 	// PathToEK maps party path names to their encryption keys.
-	// Path names must match those used in the AC structure.
+	// Path names must match those used in the AC structure. A map that does not
+	// cover exactly the structure's leaf paths is rejected with an
+	// *accessstructure.PathMismatchError.
 	PathToEK map[string][]byte
 
 	// Label is the encryption label.
@@ -56,6 +58,9 @@ func (pve *PVE) ACEncrypt(ctx context.Context, p *ACEncryptParams) (*ACEncryptRe
 	if len(p.PathToEK) == 0 {
 		return nil, errors.New("empty PathToEK map")
 	}
+	if err := p.AC.CheckPathToEK(p.PathToEK); err != nil {
+		return nil, err
+	}
 
 	nid, err := backend.CurveToNID(backend.Curve(p.Curve))
 	if err != nil {
@@ -117,6 +122,9 @@ func (pve *PVE) ACVerify(ctx context.Context, p *ACVerifyParams) error {
 	if len(p.PathToEK) == 0 {
 		return errors.New("empty PathToEK map")
 	}
+	if err := p.AC.CheckPathToEK(p.PathToEK); err != nil {
+		return err
+	}
 	if len(p.Ciphertext) == 0 {
 		return errors.New("empty ciphertext")
 	}
@@ -264,6 +272,11 @@ func (pve *PVE) ACAggregateToRestoreRow(ctx context.Context, p *ACAggregateToRes
 	if len(p.Ciphertext) == 0 {
 		return nil, errors.New("empty ciphertext")
 	}
+	if p.AllPathToEK != nil {
+		if err := p.AC.CheckPathToEK(p.AllPathToEK); err != nil {
+			return nil, err
+		}
+	}
 
 	scalarsBytes, err := backend.PVEACAggregateToRestoreRow(
 		pve.kem,
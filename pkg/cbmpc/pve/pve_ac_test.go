@@ -4,6 +4,8 @@ package pve_test
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -447,3 +449,55 @@ func TestPVEACDecryptAggregate(t *testing.T) {
 
 	t.Log("All scalars restored correctly!")
 }
+
+// TestPVEACEncryptPathMismatch checks that a PathToEK map that does not match
+// the structure's leaves is rejected with the offending paths.
+func TestPVEACEncryptPathMismatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	kem := testkem.NewToyRSAKEM(2048)
+	pveInstance, err := pve.New(kem)
+	if err != nil {
+		t.Fatalf("Failed to create PVE instance: %v", err)
+	}
+
+	structure, err := ac.Compile(ac.And(ac.Leaf("alice"), ac.Leaf("bob")))
+	if err != nil {
+		t.Fatalf("Failed to compile AC: %v", err)
+	}
+	paths, err := structure.LeafPaths()
+	if err != nil {
+		t.Fatalf("LeafPaths failed: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("got %d leaf paths, want 2", len(paths))
+	}
+
+	_, ek, err := kem.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	pathToEK := map[string][]byte{paths[0]: ek, "/mallory": ek}
+
+	x, err := curve.NewScalarFromString("42")
+	if err != nil {
+		t.Fatalf("Failed to create scalar: %v", err)
+	}
+	defer x.Free()
+
+	_, err = pveInstance.ACEncrypt(ctx, &pve.ACEncryptParams{
+		AC:       structure,
+		PathToEK: pathToEK,
+		Label:    []byte("test-ac-label"),
+		Curve:    cbmpc.CurveP256,
+		Scalars:  [][]byte{x.Bytes},
+	})
+	var mismatch *ac.PathMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("ACEncrypt returned %v, want *PathMismatchError", err)
+	}
+	if !reflect.DeepEqual(mismatch.Missing, []string{paths[1]}) || !reflect.DeepEqual(mismatch.Extra, []string{"/mallory"}) {
+		t.Fatalf("unexpected mismatch %+v", mismatch)
+	}
+}
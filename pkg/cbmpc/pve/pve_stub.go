@@ -7,6 +7,7 @@ import (
 	"errors"
 
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
 )
 
@@ -17,16 +18,10 @@ func New(cbmpc.KEM) (*PVE, error) {
 	return nil, errors.New("PVE requires CGO")
 }
 
-type Ciphertext []byte
-
 func (ct Ciphertext) Q() (*cbmpc.CurvePoint, error) {
 	return nil, errors.New("PVE requires CGO")
 }
 
-func (ct Ciphertext) Label() ([]byte, error) {
-	return nil, errors.New("PVE requires CGO")
-}
-
 type EncryptParams struct {
 	EK    []byte
 	Label []byte
@@ -115,6 +110,25 @@ func (pve *PVE) BatchDecrypt(_ context.Context, params *BatchDecryptParams) (*Ba
 	return nil, errors.New("PVE requires CGO")
 }
 
+type RotateParams struct {
+	DK         any
+	EK         []byte
+	NewEK      []byte
+	Ciphertext Ciphertext
+	Label      []byte
+	NewLabel   []byte
+	Target     *PVE
+}
+
+type RotateResult struct {
+	Ciphertext Ciphertext
+	Q          []byte
+}
+
+func (pve *PVE) Rotate(_ context.Context, params *RotateParams) (*RotateResult, error) {
+	return nil, errors.New("PVE requires CGO")
+}
+
 // AC-based PVE operations
 
 type ACCiphertext []byte
@@ -124,7 +138,7 @@ func (ct ACCiphertext) Bytes() []byte {
 }
 
 type ACEncryptParams struct {
-	AC       []byte
+	AC       ac.AccessStructure
 	PathToEK map[string][]byte
 	Label    []byte
 	Curve    cbmpc.Curve
@@ -138,3 +152,49 @@ type ACEncryptResult struct {
 func (pve *PVE) ACEncrypt(_ context.Context, params *ACEncryptParams) (*ACEncryptResult, error) {
 	return nil, errors.New("PVE requires CGO")
 }
+
+type ACVerifyParams struct {
+	AC         ac.AccessStructure
+	PathToEK   map[string][]byte
+	Ciphertext ACCiphertext
+	QPoints    []*cbmpc.CurvePoint
+	Label      []byte
+}
+
+func (pve *PVE) ACVerify(_ context.Context, params *ACVerifyParams) error {
+	return errors.New("PVE requires CGO")
+}
+
+type ACPartyDecryptRowParams struct {
+	AC         ac.AccessStructure
+	RowIndex   int
+	Path       string
+	DK         any
+	Ciphertext ACCiphertext
+	Label      []byte
+}
+
+type ACPartyDecryptRowResult struct {
+	Share []byte
+}
+
+func (pve *PVE) ACPartyDecryptRow(_ context.Context, params *ACPartyDecryptRowParams) (*ACPartyDecryptRowResult, error) {
+	return nil, errors.New("PVE requires CGO")
+}
+
+type ACAggregateToRestoreRowParams struct {
+	AC                ac.AccessStructure
+	RowIndex          int
+	Label             []byte
+	QuorumPathToShare map[string][]byte
+	Ciphertext        ACCiphertext
+	AllPathToEK       map[string][]byte
+}
+
+type ACAggregateToRestoreRowResult struct {
+	Scalars [][]byte
+}
+
+func (pve *PVE) ACAggregateToRestoreRow(_ context.Context, params *ACAggregateToRestoreRowParams) (*ACAggregateToRestoreRowResult, error) {
+	return nil, errors.New("PVE requires CGO")
+}
@@ -82,6 +82,14 @@ func TestPVEEncryptDecrypt(t *testing.T) {
 		t.Fatalf("Label mismatch: got %q, want %q", extractedLabel, label)
 	}
 
+	// The tag records the version and curve without a native call
+	if v, err := ct.Version(); err != nil || v != pve.CiphertextVersion {
+		t.Fatalf("Version() = %d, %v; want %d", v, err, pve.CiphertextVersion)
+	}
+	if c, err := ct.Curve(); err != nil || c != crv {
+		t.Fatalf("Curve() = %v, %v; want %v", c, err, crv)
+	}
+
 	// Verify
 	err = pveInstance.Verify(ctx, &pve.VerifyParams{
 		EK:         ek,
@@ -93,6 +101,21 @@ func TestPVEEncryptDecrypt(t *testing.T) {
 		t.Fatalf("Verify failed: %v", err)
 	}
 
+	// The untagged native ciphertext written by earlier versions still verifies
+	native, err := ct.KEMCiphertext()
+	if err != nil {
+		t.Fatalf("KEMCiphertext failed: %v", err)
+	}
+	err = pveInstance.Verify(ctx, &pve.VerifyParams{
+		EK:         ek,
+		Ciphertext: pve.Ciphertext(native),
+		Q:          Q,
+		Label:      label,
+	})
+	if err != nil {
+		t.Fatalf("Verify of untagged ciphertext failed: %v", err)
+	}
+
 	// Decrypt
 	decryptResult, err := pveInstance.Decrypt(ctx, &pve.DecryptParams{
 		DK:         dkHandle,
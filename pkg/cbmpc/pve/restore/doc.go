@@ -0,0 +1,35 @@
+// Package restore coordinates the restoration of a PVE-AC backup from the
+// decryption shares of a quorum.
+//
+// Each party decrypts its share of a ciphertext row with
+// pve.ACPartyDecryptRow on its own machine, holding its own decryption key,
+// and hands the share to a coordinator out of band. The coordinator needs no
+// private keys: Coordinator checks each share as it is added, reports whether
+// the shares received satisfy the access structure, and aggregates them into
+// the encrypted scalars.
+//
+// Errors name the party responsible. Add returns a *PartyError for shares
+// from unknown paths, empty shares and conflicting resubmissions. If
+// aggregation fails, Restore retries without each party whose share is not
+// needed for the quorum, and blames the parties whose exclusion succeeds:
+//
+//	c, _ := restore.New(restore.Config{
+//	    PVE: pveInstance, AC: structure, Ciphertext: ct, Label: label,
+//	    Curve: cbmpc.CurveP256, QPoints: qs,
+//	})
+//	for path, share := range collected {
+//	    if err := c.Add(path, share); err != nil {
+//	        log.Print(err) // e.g. "party /mallory: restore: party is not in the access structure"
+//	    }
+//	}
+//	scalars, err := c.Restore(ctx)
+//	var pe *restore.PartyError
+//	if errors.As(err, &pe) {
+//	    c.Remove(pe.Path) // and retry Restore
+//	}
+//
+// Attribution needs more shares than a minimal quorum: with exactly a
+// quorum, a bad share cannot be told apart from a good one and Restore
+// returns ErrUnattributed. Setting Config.QPoints lets Restore also catch
+// shares that aggregate to wrong scalars rather than fail outright.
+package restore
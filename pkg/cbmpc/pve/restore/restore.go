@@ -0,0 +1,265 @@
+package restore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+)
+
+var (
+	// ErrUnknownParty is returned for a share from a path that is not a leaf
+	// of the access structure.
+	ErrUnknownParty = errors.New("restore: party is not in the access structure")
+	// ErrDuplicateShare is returned when a party submits a second share.
+	ErrDuplicateShare = errors.New("restore: duplicate share")
+	// ErrEmptyShare is returned for an empty share.
+	ErrEmptyShare = errors.New("restore: empty share")
+	// ErrNoQuorum is returned by Restore when the parties that submitted
+	// shares do not satisfy the access structure.
+	ErrNoQuorum = errors.New("restore: shares do not satisfy the access structure")
+	// ErrBadShare marks a party whose share prevents restoration: leaving it
+	// out restores the scalars.
+	ErrBadShare = errors.New("restore: bad share")
+	// ErrUnattributed is returned when aggregation fails and no single
+	// party's share can be blamed, for example because the received shares
+	// form a minimal quorum or several shares are bad.
+	ErrUnattributed = errors.New("restore: aggregation failed and no single share is to blame")
+)
+
+// PartyError attributes an error to the party at Path.
+type PartyError struct {
+	Path string
+	Err  error
+}
+
+func (e *PartyError) Error() string { return fmt.Sprintf("party %s: %v", e.Path, e.Err) }
+
+func (e *PartyError) Unwrap() error { return e.Err }
+
+// Config describes the ciphertext to restore.
+type Config struct {
+	// PVE is the instance, with the KEM the ciphertext was encrypted under.
+	PVE *pve.PVE
+
+	// AC is the access structure the ciphertext was encrypted to.
+	AC ac.AccessStructure
+
+	// Ciphertext and Label are the PVE-AC ciphertext and its label.
+	Ciphertext pve.ACCiphertext
+	Label      []byte
+
+	// Curve is the curve of the encrypted scalars.
+	Curve cbmpc.Curve
+
+	// RowIndex is the row the parties decrypted with ACPartyDecryptRow.
+	RowIndex int
+
+	// PathToEK, if set, holds every party's encryption key and makes
+	// aggregation verify the ciphertext.
+	PathToEK map[string][]byte
+
+	// QPoints, if set, are the public points of the encrypted scalars, in
+	// order. Restore checks every restored scalar against them, which also
+	// lets it attribute shares that aggregate to wrong values.
+	QPoints []*cbmpc.CurvePoint
+}
+
+// Coordinator collects the decryption shares of a quorum, gathered out of
+// band, and restores the scalars of a PVE-AC ciphertext. Add checks each share
+// as it arrives; Restore aggregates them and, if that fails, finds which
+// party's share is to blame. A Coordinator is not safe for concurrent use.
+type Coordinator struct {
+	cfg    Config
+	leaves map[string]bool // full leaf paths, with the leading "/"
+	shares map[string][]byte
+}
+
+// New returns a Coordinator for cfg.
+func New(cfg Config) (*Coordinator, error) {
+	if cfg.PVE == nil {
+		return nil, errors.New("restore: nil PVE")
+	}
+	if len(cfg.Ciphertext) == 0 {
+		return nil, errors.New("restore: empty ciphertext")
+	}
+	paths, err := cfg.AC.LeafPaths()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.PathToEK != nil {
+		if err := cfg.AC.CheckPathToEK(cfg.PathToEK); err != nil {
+			return nil, err
+		}
+	}
+	leaves := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		leaves[fullPath(p)] = true
+	}
+	return &Coordinator{cfg: cfg, leaves: leaves, shares: make(map[string][]byte)}, nil
+}
+
+// fullPath returns p with a leading "/", the form LeafPaths uses; shareKey
+// returns it without, the form the native aggregation expects.
+func fullPath(p string) string { return "/" + strings.TrimPrefix(p, "/") }
+
+func shareKey(p string) string { return strings.TrimPrefix(p, "/") }
+
+// Add records the share the party at path produced with ACPartyDecryptRow.
+// Paths may be given with or without the leading "/". It returns a
+// *PartyError wrapping ErrUnknownParty, ErrDuplicateShare or ErrEmptyShare
+// if the share cannot be used.
+func (c *Coordinator) Add(path string, share []byte) error {
+	full := fullPath(path)
+	switch {
+	case !c.leaves[full]:
+		return &PartyError{Path: full, Err: ErrUnknownParty}
+	case len(share) == 0:
+		return &PartyError{Path: full, Err: ErrEmptyShare}
+	}
+	if old, ok := c.shares[full]; ok {
+		if bytes.Equal(old, share) {
+			return nil
+		}
+		return &PartyError{Path: full, Err: ErrDuplicateShare}
+	}
+	c.shares[full] = bytes.Clone(share)
+	return nil
+}
+
+// Remove discards the share of the party at path, typically one Restore
+// blamed, so that Restore can be retried with the others.
+func (c *Coordinator) Remove(path string) {
+	delete(c.shares, fullPath(path))
+}
+
+// Received returns the sorted paths of the parties whose shares were added.
+func (c *Coordinator) Received() []string {
+	paths := make([]string, 0, len(c.shares))
+	for p := range c.shares {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// Satisfied reports whether the received shares satisfy the access
+// structure.
+func (c *Coordinator) Satisfied() bool {
+	return c.cfg.AC.IsSatisfiedBy(c.Received())
+}
+
+// Restore aggregates the received shares and returns the restored scalars,
+// which the caller must Free. If aggregation fails, or the result does not
+// match QPoints, Restore retries without each party in turn and returns the
+// parties whose exclusion succeeds as *PartyError values wrapping ErrBadShare,
+// joined with errors.Join. If no single party is to blame it returns an error
+// wrapping ErrUnattributed and the aggregation error.
+func (c *Coordinator) Restore(ctx context.Context) ([]*curve.Scalar, error) {
+	received := c.Received()
+	if !c.cfg.AC.IsSatisfiedBy(received) {
+		return nil, fmt.Errorf("%w: have %s", ErrNoQuorum, strings.Join(received, ", "))
+	}
+	scalars, err := c.aggregate(ctx, received)
+	if err == nil {
+		return scalars, nil
+	}
+	if ctx.Err() != nil {
+		return nil, err
+	}
+
+	var blamed []error
+	for i, p := range received {
+		rest := slices.Delete(slices.Clone(received), i, i+1)
+		if !c.cfg.AC.IsSatisfiedBy(rest) {
+			continue
+		}
+		s, restErr := c.aggregate(ctx, rest)
+		if restErr != nil {
+			continue
+		}
+		freeAll(s)
+		blamed = append(blamed, &PartyError{Path: p, Err: ErrBadShare})
+	}
+	if len(blamed) == 0 {
+		return nil, fmt.Errorf("%w: %w", ErrUnattributed, err)
+	}
+	return nil, errors.Join(blamed...)
+}
+
+// aggregate restores the scalars from the shares of paths and checks them
+// against QPoints.
+func (c *Coordinator) aggregate(ctx context.Context, paths []string) ([]*curve.Scalar, error) {
+	quorum := make(map[string][]byte, len(paths))
+	for _, p := range paths {
+		quorum[shareKey(p)] = c.shares[p]
+	}
+	res, err := c.cfg.PVE.ACAggregateToRestoreRow(ctx, &pve.ACAggregateToRestoreRowParams{
+		AC:                c.cfg.AC,
+		RowIndex:          c.cfg.RowIndex,
+		Label:             c.cfg.Label,
+		QuorumPathToShare: quorum,
+		Ciphertext:        c.cfg.Ciphertext,
+		AllPathToEK:       c.cfg.PathToEK,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scalars := make([]*curve.Scalar, 0, len(res.Scalars))
+	for _, raw := range res.Scalars {
+		s, err := curve.NewScalarFromBytes(raw)
+		cbmpc.ZeroizeBytes(raw)
+		if err != nil {
+			freeAll(scalars)
+			return nil, err
+		}
+		scalars = append(scalars, s)
+	}
+	if err := c.checkQ(scalars); err != nil {
+		freeAll(scalars)
+		return nil, err
+	}
+	return scalars, nil
+}
+
+func (c *Coordinator) checkQ(scalars []*curve.Scalar) error {
+	if c.cfg.QPoints == nil {
+		return nil
+	}
+	if len(scalars) != len(c.cfg.QPoints) {
+		return fmt.Errorf("restore: restored %d scalars, have %d public points", len(scalars), len(c.cfg.QPoints))
+	}
+	for i, s := range scalars {
+		q, err := curve.MulGenerator(c.cfg.Curve, s)
+		if err != nil {
+			return err
+		}
+		got, err := q.Bytes()
+		q.Free()
+		if err != nil {
+			return err
+		}
+		want, err := c.cfg.QPoints[i].Bytes()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(got, want) {
+			return fmt.Errorf("restore: scalar %d does not match its public point", i)
+		}
+	}
+	return nil
+}
+
+func freeAll(scalars []*curve.Scalar) {
+	for _, s := range scalars {
+		s.Free()
+	}
+}
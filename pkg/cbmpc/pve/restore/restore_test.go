@@ -0,0 +1,256 @@
+//go:build cgo && !windows
+
+package restore_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/testkem"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve/restore"
+)
+
+type fixture struct {
+	cfg     restore.Config
+	shares  map[string][]byte // by full path
+	scalars []*curve.Scalar
+}
+
+// newFixture encrypts two scalars to a 2-of-3 structure and has every party
+// decrypt its share of row 0.
+func newFixture(t *testing.T, ctx context.Context) *fixture {
+	t.Helper()
+	kem := testkem.NewToyRSAKEM(2048)
+	pveInstance, err := pve.New(kem)
+	if err != nil {
+		t.Fatalf("Failed to create PVE instance: %v", err)
+	}
+	structure, err := ac.Compile(ac.Threshold(2, ac.Leaf("alice"), ac.Leaf("bob"), ac.Leaf("charlie")))
+	if err != nil {
+		t.Fatalf("Failed to compile AC: %v", err)
+	}
+	paths, err := structure.LeafPaths()
+	if err != nil {
+		t.Fatalf("Failed to list leaf paths: %v", err)
+	}
+
+	pathToEK := make(map[string][]byte)
+	pathToDK := make(map[string]any)
+	for _, path := range paths {
+		sk, ek, err := kem.Generate()
+		if err != nil {
+			t.Fatalf("Failed to generate key pair for %s: %v", path, err)
+		}
+		dk, err := kem.NewPrivateKeyHandle(sk)
+		if err != nil {
+			t.Fatalf("Failed to create key handle for %s: %v", path, err)
+		}
+		t.Cleanup(func() { kem.FreePrivateKeyHandle(dk) })
+		pathToEK[path] = ek
+		pathToDK[path] = dk
+	}
+
+	crv := cbmpc.CurveP256
+	f := &fixture{shares: make(map[string][]byte)}
+	raw := make([][]byte, 2)
+	for i := range raw {
+		x, err := curve.RandomScalar(crv)
+		if err != nil {
+			t.Fatalf("Failed to create scalar: %v", err)
+		}
+		t.Cleanup(x.Free)
+		f.scalars = append(f.scalars, x)
+		raw[i] = x.BytesPadded(crv)
+
+		q, err := curve.MulGenerator(crv, x)
+		if err != nil {
+			t.Fatalf("MulGenerator failed: %v", err)
+		}
+		t.Cleanup(q.Free)
+		f.cfg.QPoints = append(f.cfg.QPoints, q)
+	}
+
+	label := []byte("restore-test")
+	enc, err := pveInstance.ACEncrypt(ctx, &pve.ACEncryptParams{
+		AC: structure, PathToEK: pathToEK, Label: label, Curve: crv, Scalars: raw,
+	})
+	if err != nil {
+		t.Fatalf("ACEncrypt failed: %v", err)
+	}
+
+	for _, path := range paths {
+		res, err := pveInstance.ACPartyDecryptRow(ctx, &pve.ACPartyDecryptRowParams{
+			AC:         structure,
+			Path:       strings.TrimPrefix(path, "/"),
+			DK:         pathToDK[path],
+			Ciphertext: enc.Ciphertext,
+			Label:      label,
+		})
+		if err != nil {
+			t.Fatalf("ACPartyDecryptRow failed for %s: %v", path, err)
+		}
+		f.shares[path] = res.Share
+	}
+
+	f.cfg.PVE = pveInstance
+	f.cfg.AC = structure
+	f.cfg.Ciphertext = enc.Ciphertext
+	f.cfg.Label = label
+	f.cfg.Curve = crv
+	return f
+}
+
+func (f *fixture) check(t *testing.T, got []*curve.Scalar) {
+	t.Helper()
+	if len(got) != len(f.scalars) {
+		t.Fatalf("restored %d scalars, want %d", len(got), len(f.scalars))
+	}
+	for i := range got {
+		if !got[i].Equal(f.scalars[i]) {
+			t.Errorf("scalar %d does not match", i)
+		}
+	}
+}
+
+func TestRestore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	f := newFixture(t, ctx)
+
+	c, err := restore.New(f.cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := c.Add("/alice", f.shares["/alice"]); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if c.Satisfied() {
+		t.Fatal("one share satisfies a 2-of-3 structure")
+	}
+	if _, err := c.Restore(ctx); !errors.Is(err, restore.ErrNoQuorum) {
+		t.Fatalf("Restore error = %v, want ErrNoQuorum", err)
+	}
+
+	// Paths are accepted without the leading "/"
+	if err := c.Add("bob", f.shares["/bob"]); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	got, err := c.Restore(ctx)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	defer func() {
+		for _, s := range got {
+			s.Free()
+		}
+	}()
+	f.check(t, got)
+}
+
+func TestRestoreAdd(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	f := newFixture(t, ctx)
+
+	c, err := restore.New(f.cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	tests := []struct {
+		path  string
+		share []byte
+		want  error
+	}{
+		{"/mallory", f.shares["/alice"], restore.ErrUnknownParty},
+		{"/alice", nil, restore.ErrEmptyShare},
+		{"/alice", f.shares["/alice"], nil},
+		{"/alice", f.shares["/alice"], nil},
+		{"/alice", f.shares["/bob"], restore.ErrDuplicateShare},
+	}
+	for _, tt := range tests {
+		err := c.Add(tt.path, tt.share)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Add(%s) error = %v, want %v", tt.path, err, tt.want)
+		}
+		var pe *restore.PartyError
+		if tt.want != nil && (!errors.As(err, &pe) || pe.Path != tt.path) {
+			t.Errorf("Add(%s) error %v is not attributed to %s", tt.path, err, tt.path)
+		}
+	}
+	if got := c.Received(); len(got) != 1 || got[0] != "/alice" {
+		t.Fatalf("Received = %v", got)
+	}
+}
+
+// TestRestoreBadShare tests that a corrupted share is attributed to its party
+// when the other shares still form a quorum.
+func TestRestoreBadShare(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	f := newFixture(t, ctx)
+
+	c, err := restore.New(f.cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	bad := append([]byte(nil), f.shares["/charlie"]...)
+	bad[len(bad)/2] ^= 0xff
+	for path, share := range map[string][]byte{"/alice": f.shares["/alice"], "/bob": f.shares["/bob"], "/charlie": bad} {
+		if err := c.Add(path, share); err != nil {
+			t.Fatalf("Add(%s) failed: %v", path, err)
+		}
+	}
+
+	_, err = c.Restore(ctx)
+	if !errors.Is(err, restore.ErrBadShare) {
+		t.Fatalf("Restore error = %v, want ErrBadShare", err)
+	}
+	var pe *restore.PartyError
+	if !errors.As(err, &pe) || pe.Path != "/charlie" {
+		t.Fatalf("Restore error %v is not attributed to /charlie", err)
+	}
+
+	c.Remove(pe.Path)
+	got, err := c.Restore(ctx)
+	if err != nil {
+		t.Fatalf("Restore after Remove failed: %v", err)
+	}
+	defer func() {
+		for _, s := range got {
+			s.Free()
+		}
+	}()
+	f.check(t, got)
+}
+
+// TestRestoreUnattributed tests that a bad share in a minimal quorum is
+// reported without blaming an honest party.
+func TestRestoreUnattributed(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	f := newFixture(t, ctx)
+
+	c, err := restore.New(f.cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	bad := append([]byte(nil), f.shares["/bob"]...)
+	bad[len(bad)/2] ^= 0xff
+	if err := c.Add("/alice", f.shares["/alice"]); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := c.Add("/bob", bad); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := c.Restore(ctx); !errors.Is(err, restore.ErrUnattributed) {
+		t.Fatalf("Restore error = %v, want ErrUnattributed", err)
+	}
+}
@@ -0,0 +1,118 @@
+//go:build cgo && !windows
+
+package pve
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// RotateParams contains parameters for re-encrypting a ciphertext to a new
+// encryption key.
+type RotateParams struct {
+	// DK is the private decryption key of the ciphertext's current key, as
+	// for DecryptParams.
+	DK any
+
+	// EK is the public encryption key the ciphertext is currently under.
+	EK []byte
+
+	// NewEK is the public encryption key to re-encrypt to.
+	NewEK []byte
+
+	// Ciphertext is the ciphertext to rotate.
+	Ciphertext Ciphertext
+
+	// Label is the ciphertext's label. NewLabel, if set, labels the new
+	// ciphertext instead, e.g. to record the rotation date.
+	Label    []byte
+	NewLabel []byte
+
+	// Target encrypts under NewEK. If nil, the PVE instance Rotate is called
+	// on is used, which suits rotating to a new key of the same KEM.
+	Target *PVE
+}
+
+// RotateResult contains the result of a rotation.
+type RotateResult struct {
+	// Ciphertext is the new ciphertext under NewEK.
+	Ciphertext Ciphertext
+
+	// Q is the encoded public point x*G shared by the old and new
+	// ciphertexts. Auditors can compare it with the old ciphertext's Q to
+	// confirm that the same secret was carried over.
+	Q []byte
+}
+
+// Rotate moves a backup to a new encryption key in one call: it verifies the
+// ciphertext, decrypts it, encrypts the scalar under NewEK, and checks that
+// the new ciphertext commits to the same Q and verifies under NewEK before
+// returning it. The decrypted scalar is freed before Rotate returns.
+//
+// The curve is read from the ciphertext tag, so untagged ciphertexts from
+// earlier versions must be re-encrypted with Decrypt and Encrypt instead.
+func (pve *PVE) Rotate(ctx context.Context, params *RotateParams) (*RotateResult, error) {
+	if pve == nil {
+		return nil, errors.New("nil PVE instance")
+	}
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if len(params.NewEK) == 0 {
+		return nil, errors.New("empty new encryption key")
+	}
+	target := params.Target
+	if target == nil {
+		target = pve
+	}
+	newLabel := params.NewLabel
+	if newLabel == nil {
+		newLabel = params.Label
+	}
+	c, err := params.Ciphertext.Curve()
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := params.Ciphertext.Q()
+	if err != nil {
+		return nil, err
+	}
+	defer q.Free()
+	oldQ, err := q.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if err := pve.Verify(ctx, &VerifyParams{EK: params.EK, Ciphertext: params.Ciphertext, Q: q, Label: params.Label}); err != nil {
+		return nil, fmt.Errorf("pve: verify ciphertext before rotation: %w", err)
+	}
+
+	dec, err := pve.Decrypt(ctx, &DecryptParams{DK: params.DK, EK: params.EK, Ciphertext: params.Ciphertext, Label: params.Label, Curve: c})
+	if err != nil {
+		return nil, err
+	}
+	defer dec.X.Free()
+
+	enc, err := target.Encrypt(ctx, &EncryptParams{EK: params.NewEK, Label: newLabel, Curve: c, X: dec.X})
+	if err != nil {
+		return nil, err
+	}
+	newQPoint, err := enc.Ciphertext.Q()
+	if err != nil {
+		return nil, err
+	}
+	defer newQPoint.Free()
+	newQ, err := newQPoint.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(oldQ, newQ) {
+		return nil, errors.New("pve: rotated ciphertext commits to a different Q")
+	}
+	if err := target.Verify(ctx, &VerifyParams{EK: params.NewEK, Ciphertext: enc.Ciphertext, Q: q, Label: newLabel}); err != nil {
+		return nil, fmt.Errorf("pve: verify rotated ciphertext: %w", err)
+	}
+	return &RotateResult{Ciphertext: enc.Ciphertext, Q: oldQ}, nil
+}
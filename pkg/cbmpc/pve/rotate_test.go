@@ -0,0 +1,122 @@
+package pve_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/testkem"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+)
+
+// TestPVERotate tests that a ciphertext re-encrypted to a new key keeps its Q
+// and decrypts under the new key only.
+func TestPVERotate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	kem := testkem.NewToyRSAKEM(2048)
+	pveInstance, err := pve.New(kem)
+	if err != nil {
+		t.Fatalf("Failed to create PVE instance: %v", err)
+	}
+
+	oldSK, oldEK, err := kem.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate old key pair: %v", err)
+	}
+	oldDK, err := kem.NewPrivateKeyHandle(oldSK)
+	if err != nil {
+		t.Fatalf("Failed to create old key handle: %v", err)
+	}
+	defer kem.FreePrivateKeyHandle(oldDK)
+
+	newSK, newEK, err := kem.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate new key pair: %v", err)
+	}
+	newDK, err := kem.NewPrivateKeyHandle(newSK)
+	if err != nil {
+		t.Fatalf("Failed to create new key handle: %v", err)
+	}
+	defer kem.FreePrivateKeyHandle(newDK)
+
+	crv := cbmpc.CurveSecp256k1
+	x, err := curve.RandomScalar(crv)
+	if err != nil {
+		t.Fatalf("Failed to create scalar: %v", err)
+	}
+	defer x.Free()
+
+	enc, err := pveInstance.Encrypt(ctx, &pve.EncryptParams{EK: oldEK, Label: []byte("backup"), Curve: crv, X: x})
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	rotated, err := pveInstance.Rotate(ctx, &pve.RotateParams{
+		DK:         oldDK,
+		EK:         oldEK,
+		NewEK:      newEK,
+		Ciphertext: enc.Ciphertext,
+		Label:      []byte("backup"),
+		NewLabel:   []byte("backup-rotated"),
+	})
+	if err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+
+	oldQ, err := enc.Ciphertext.Q()
+	if err != nil {
+		t.Fatalf("Q failed: %v", err)
+	}
+	defer oldQ.Free()
+	oldQBytes, err := oldQ.Bytes()
+	if err != nil {
+		t.Fatalf("Q bytes failed: %v", err)
+	}
+	if !bytes.Equal(rotated.Q, oldQBytes) {
+		t.Fatal("rotation changed Q")
+	}
+	if label, _ := rotated.Ciphertext.Label(); string(label) != "backup-rotated" {
+		t.Fatalf("rotated label = %q", label)
+	}
+
+	dec, err := pveInstance.Decrypt(ctx, &pve.DecryptParams{
+		DK:         newDK,
+		EK:         newEK,
+		Ciphertext: rotated.Ciphertext,
+		Label:      []byte("backup-rotated"),
+		Curve:      crv,
+	})
+	if err != nil {
+		t.Fatalf("Decrypt with new key failed: %v", err)
+	}
+	defer dec.X.Free()
+	if !dec.X.Equal(x) {
+		t.Fatal("rotated ciphertext decrypts to a different scalar")
+	}
+
+	if _, err := pveInstance.Decrypt(ctx, &pve.DecryptParams{
+		DK:         oldDK,
+		EK:         oldEK,
+		Ciphertext: rotated.Ciphertext,
+		Label:      []byte("backup-rotated"),
+		Curve:      crv,
+	}); err == nil {
+		t.Fatal("old key decrypted the rotated ciphertext")
+	}
+
+	// Rotating with the wrong key fails before anything is re-encrypted
+	if _, err := pveInstance.Rotate(ctx, &pve.RotateParams{
+		DK:         newDK,
+		EK:         oldEK,
+		NewEK:      newEK,
+		Ciphertext: enc.Ciphertext,
+		Label:      []byte("backup"),
+	}); err == nil {
+		t.Fatal("Rotate succeeded with the wrong decryption key")
+	}
+}
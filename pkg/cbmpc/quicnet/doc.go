@@ -0,0 +1,30 @@
+// Package quicnet runs the tlsnet transport over QUIC, for mobile and edge
+// co-signers on networks that drop and change addresses often.
+//
+// It is a separate Go module so that only the deployments that use it depend
+// on quic-go. Network implements tlsnet.Network, and New is tlsnet.New with
+// that network:
+//
+//	t, err := quicnet.New(tlsnet.Config{
+//	    Self:        0,
+//	    Names:       []string{"p0", "p1"},
+//	    Addresses:   []string{"10.0.0.1:8443", "10.0.0.2:8443"},
+//	    Certificate: cert,
+//	    RootCAs:     roots,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer t.Close()
+//	job, err := cbmpc.NewJob2P(t, cbmpc.RoleP1, [2]string{"p0", "p1"})
+//
+// Addresses are UDP. Everything above the connection is tlsnet's: parties
+// authenticate with mutual TLS 1.3 by name or SPIFFE ID, messages are
+// numbered and acknowledged per peer, and a dropped connection is dialed
+// again and the unacknowledged messages resent. QUIC adds a handshake of one
+// round trip and keeps a connection alive when the network path to a party
+// changes, such as after NAT rebinding, so fewer outages reach the redial.
+//
+// 0-RTT is never enabled: early data can be replayed by the network, and
+// protocol messages must not be.
+package quicnet
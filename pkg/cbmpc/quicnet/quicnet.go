@@ -0,0 +1,178 @@
+package quicnet
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/tlsnet"
+)
+
+// ALPN is the application protocol negotiated on every connection.
+const ALPN = "cbmpc"
+
+// Application error codes sent when a connection is closed.
+const (
+	codeClosed   quic.ApplicationErrorCode = 0
+	codeNoStream quic.ApplicationErrorCode = 1
+)
+
+// Network is a tlsnet.Network over QUIC. Each connection carries one
+// bidirectional stream, opened by the dialing party, on which the tlsnet
+// framing runs.
+type Network struct {
+	// Config tunes the QUIC connections. Nil uses the quic-go defaults.
+	// Allow0RTT is always turned off.
+	Config *quic.Config
+}
+
+// New returns a tlsnet.Transport whose connections run over QUIC. A
+// cfg.Network of type Network is kept; any other is replaced with Network{}.
+func New(cfg tlsnet.Config) (*tlsnet.Transport, error) {
+	if _, ok := cfg.Network.(Network); !ok {
+		cfg.Network = Network{}
+	}
+	return tlsnet.New(cfg)
+}
+
+// Listen listens for QUIC connections on the UDP address addr.
+func (n Network) Listen(addr string, cfg *tls.Config) (net.Listener, error) {
+	ln, err := quic.ListenAddr(addr, withALPN(cfg), n.quicConfig())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &listener{ln: ln, conns: make(chan net.Conn), ctx: ctx, cancel: cancel}
+	go l.run()
+	return l, nil
+}
+
+// Dial connects to addr and opens the connection's stream.
+func (n Network) Dial(ctx context.Context, addr string, cfg *tls.Config) (net.Conn, error) {
+	qc, err := quic.DialAddr(ctx, addr, withALPN(cfg), n.quicConfig())
+	if err != nil {
+		return nil, err
+	}
+	s, err := qc.OpenStreamSync(ctx)
+	if err != nil {
+		_ = qc.CloseWithError(codeNoStream, "open stream")
+		return nil, err
+	}
+	return &conn{Stream: s, qc: qc}, nil
+}
+
+func (n Network) quicConfig() *quic.Config {
+	var c quic.Config
+	if n.Config != nil {
+		c = *n.Config.Clone()
+	}
+	// Early data can be replayed by the network.
+	c.Allow0RTT = false
+	return &c
+}
+
+func withALPN(cfg *tls.Config) *tls.Config {
+	cfg = cfg.Clone()
+	if !slices.Contains(cfg.NextProtos, ALPN) {
+		cfg.NextProtos = append(cfg.NextProtos, ALPN)
+	}
+	return cfg
+}
+
+// conn is the stream of a QUIC connection seen as a net.Conn. Closing it
+// closes the connection.
+type conn struct {
+	quic.Stream
+	qc quic.Connection
+}
+
+func (c *conn) LocalAddr() net.Addr  { return c.qc.LocalAddr() }
+func (c *conn) RemoteAddr() net.Addr { return c.qc.RemoteAddr() }
+
+// ConnectionState returns the TLS state of the connection, from which
+// tlsnet authenticates the peer.
+func (c *conn) ConnectionState() tls.ConnectionState {
+	return c.qc.ConnectionState().TLS
+}
+
+func (c *conn) Close() error {
+	return c.qc.CloseWithError(codeClosed, "")
+}
+
+// listener accepts QUIC connections and hands out the stream each peer
+// opens. Connections whose stream does not arrive within
+// tlsnet.DefaultDialTimeout are closed.
+type listener struct {
+	ln    *quic.Listener
+	conns chan net.Conn
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu  sync.Mutex
+	err error
+}
+
+func (l *listener) run() {
+	for {
+		qc, err := l.ln.Accept(l.ctx)
+		if err != nil {
+			l.mu.Lock()
+			if l.err == nil {
+				l.err = err
+			}
+			l.mu.Unlock()
+			l.cancel()
+			return
+		}
+		go l.accept(qc)
+	}
+}
+
+func (l *listener) accept(qc quic.Connection) {
+	ctx, cancel := context.WithTimeout(l.ctx, tlsnet.DefaultDialTimeout)
+	defer cancel()
+	s, err := qc.AcceptStream(ctx)
+	if err != nil {
+		_ = qc.CloseWithError(codeNoStream, "no stream")
+		return
+	}
+	select {
+	case l.conns <- &conn{Stream: s, qc: qc}:
+	case <-l.ctx.Done():
+		_ = qc.CloseWithError(codeClosed, "")
+	}
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.err == nil || errors.Is(l.err, context.Canceled) {
+			return nil, net.ErrClosed
+		}
+		return nil, l.err
+	}
+}
+
+func (l *listener) Close() error {
+	l.mu.Lock()
+	if l.err == nil {
+		l.err = net.ErrClosed
+	}
+	l.mu.Unlock()
+	l.cancel()
+	return l.ln.Close()
+}
+
+func (l *listener) Addr() net.Addr {
+	return l.ln.Addr()
+}
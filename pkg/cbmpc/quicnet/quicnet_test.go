@@ -0,0 +1,117 @@
+package quicnet_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/quicnet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/tlsnet"
+)
+
+func freeAddrs(t *testing.T, n int) []string {
+	t.Helper()
+	addrs := make([]string, n)
+	for i := range addrs {
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrs[i] = pc.LocalAddr().String()
+		_ = pc.Close()
+	}
+	return addrs
+}
+
+func cluster(t *testing.T, n int) []*tlsnet.Transport {
+	t.Helper()
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("p%d", i)
+	}
+	// GenerateCertificates only writes below the working directory.
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := tlsnet.GenerateCertificates(names, ".", tlsnet.CertOptions{KeyBits: 2048, IncludeLocalhost: true}); err != nil {
+		t.Fatal(err)
+	}
+	ca, err := os.ReadFile(filepath.Join(dir, "rootCA.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(ca)
+
+	addrs := freeAddrs(t, n)
+	ts := make([]*tlsnet.Transport, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cert, err := tls.LoadX509KeyPair(filepath.Join(dir, names[i]+"-cert.pem"), filepath.Join(dir, names[i]+"-key.pem"))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			ts[i], errs[i] = quicnet.New(tlsnet.Config{Self: i, Names: names, Addresses: addrs, Certificate: cert, RootCAs: roots})
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d: %v", i, err)
+		}
+	}
+	t.Cleanup(func() {
+		for _, tr := range ts {
+			_ = tr.Close()
+		}
+	})
+	return ts
+}
+
+func TestExchange(t *testing.T) {
+	ts := cluster(t, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for round := range 3 {
+		for i, tr := range ts {
+			for j := range ts {
+				if i != j {
+					if err := tr.Send(ctx, cbmpc.RoleID(j), fmt.Appendf(nil, "%d:%d->%d", round, i, j)); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+		}
+		for j, tr := range ts {
+			for i := range ts {
+				if i == j {
+					continue
+				}
+				got, err := tr.Receive(ctx, cbmpc.RoleID(i))
+				if err != nil {
+					t.Fatalf("round %d party %d: %v", round, j, err)
+				}
+				if want := fmt.Sprintf("%d:%d->%d", round, i, j); string(got) != want {
+					t.Fatalf("party %d got %q, want %q", j, got, want)
+				}
+			}
+		}
+	}
+	for _, st := range ts[0].Status() {
+		if !st.Connected {
+			t.Fatalf("status %+v", st)
+		}
+	}
+}
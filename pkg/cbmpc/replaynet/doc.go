@@ -0,0 +1,25 @@
+// Package replaynet replays a recorded transcript into a single party.
+//
+// A Transport built from a transcript.Transcript answers the party's receives
+// with the recorded messages and checks that the party's sends and receives
+// happen in the recorded order. The first call that does not match fails with
+// ErrUnexpected, naming the step and what the transcript expected. That
+// pinpoints where a party's control flow left the original run, for example
+// after a library upgrade:
+//
+//	tr, err := transcript.Parse(data)
+//	if err != nil {
+//	    return err
+//	}
+//	net, _ := replaynet.New(tr)
+//	job, _ := cbmpc.NewJob2PWithContext(ctx, net, cbmpc.Role(tr.Self), names)
+//	_, err = ecdsa2p.Sign(ctx, job, params) // same key and inputs as the original run
+//	fmt.Println(err, net.Remaining(), net.Divergences())
+//
+// Protocols draw fresh randomness, so the replayed party's sends differ from
+// the recorded ones, and a peer message that depends on them (a challenge, a
+// decommitment check) can make the replay fail verification where the
+// original run succeeded. Divergences lists the sends that differed. Replay is
+// most useful for failures in message parsing and flow, and for reproducing
+// the exact input that made a party abort.
+package replaynet
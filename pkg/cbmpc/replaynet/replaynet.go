@@ -0,0 +1,150 @@
+package replaynet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/transcript"
+)
+
+var (
+	// ErrUnexpected is returned when the replayed party performs a transport
+	// call that does not match the next transcript entry.
+	ErrUnexpected = errors.New("replaynet: call does not match transcript")
+	// ErrExhausted is returned when the replayed party goes past the end of
+	// the transcript.
+	ErrExhausted = errors.New("replaynet: transcript exhausted")
+	// ErrRedacted is returned when a received message is needed but its
+	// payload was redacted at recording time.
+	ErrRedacted = errors.New("replaynet: received payload was redacted")
+)
+
+// Divergence records a sent message whose payload differs from the recorded
+// one. Protocols draw fresh randomness, so divergences are expected; they show
+// where the replay stopped reproducing the original run.
+type Divergence struct {
+	Step int // index into the transcript entries
+	Peer cbmpc.RoleID
+}
+
+// Transport is a cbmpc.Transport that plays back the received messages of a
+// transcript to the party that recorded it. Sends are checked against the
+// transcript order and otherwise discarded. Pass it to a job constructor with
+// the role and names of the original run.
+type Transport struct {
+	t *transcript.Transcript
+
+	mu          sync.Mutex
+	pos         int
+	divergences []Divergence
+}
+
+// New returns a Transport replaying tr.
+func New(tr *transcript.Transcript) (*Transport, error) {
+	if tr == nil {
+		return nil, errors.New("nil transcript")
+	}
+	return &Transport{t: tr}, nil
+}
+
+// Self returns the role of the recorded party.
+func (r *Transport) Self() cbmpc.RoleID { return r.t.Self }
+
+// Send checks that the transcript expects a send to the given peer next and
+// notes a Divergence if the payload differs from the recorded one.
+func (r *Transport) Send(_ context.Context, to cbmpc.RoleID, msg []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, err := r.next(transcript.Sent, to)
+	if err != nil {
+		return err
+	}
+	if !e.Redacted && !bytes.Equal(e.Payload, msg) {
+		r.divergences = append(r.divergences, Divergence{Step: r.pos, Peer: to})
+	}
+	r.pos++
+	return nil
+}
+
+// Receive returns the next recorded message if it was received from the given
+// peer.
+func (r *Transport) Receive(_ context.Context, from cbmpc.RoleID) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, err := r.next(transcript.Received, from)
+	if err != nil {
+		return nil, err
+	}
+	if e.Redacted {
+		return nil, fmt.Errorf("%w: step %d from %d", ErrRedacted, r.pos, from)
+	}
+	r.pos++
+	return append([]byte(nil), e.Payload...), nil
+}
+
+// ReceiveAll returns the next len(from) recorded messages, which must have
+// been received from exactly the requested roles.
+func (r *Transport) ReceiveAll(_ context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	roles := append([]cbmpc.RoleID(nil), from...)
+	sort.Slice(roles, func(i, j int) bool { return roles[i] < roles[j] })
+
+	out := make(map[cbmpc.RoleID][]byte, len(roles))
+	for i, role := range roles {
+		step := r.pos + i
+		if step >= len(r.t.Entries) {
+			return nil, fmt.Errorf("%w at step %d: receive from %d", ErrExhausted, step, role)
+		}
+		e := r.t.Entries[step]
+		if e.Dir != transcript.Received || e.Peer != role {
+			return nil, fmt.Errorf("%w at step %d: receive from %d, transcript has %s %d", ErrUnexpected, step, role, verb(e.Dir), e.Peer)
+		}
+		if e.Redacted {
+			return nil, fmt.Errorf("%w: step %d from %d", ErrRedacted, step, role)
+		}
+		out[role] = append([]byte(nil), e.Payload...)
+	}
+	r.pos += len(roles)
+	return out, nil
+}
+
+// Remaining returns the number of transcript entries not yet replayed. It is
+// zero after a replay that followed the original run to the end.
+func (r *Transport) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.t.Entries) - r.pos
+}
+
+// Divergences returns the sends whose payload differed from the transcript.
+func (r *Transport) Divergences() []Divergence {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Divergence(nil), r.divergences...)
+}
+
+func (r *Transport) next(dir transcript.Direction, peer cbmpc.RoleID) (transcript.Entry, error) {
+	if r.pos >= len(r.t.Entries) {
+		return transcript.Entry{}, fmt.Errorf("%w at step %d: %s %d", ErrExhausted, r.pos, verb(dir), peer)
+	}
+	e := r.t.Entries[r.pos]
+	if e.Dir != dir || e.Peer != peer {
+		return transcript.Entry{}, fmt.Errorf("%w at step %d: %s %d, transcript has %s %d", ErrUnexpected, r.pos, verb(dir), peer, verb(e.Dir), e.Peer)
+	}
+	return e, nil
+}
+
+func verb(dir transcript.Direction) string {
+	if dir == transcript.Sent {
+		return "send to"
+	}
+	return "receive from"
+}
+
+var _ cbmpc.Transport = (*Transport)(nil)
@@ -0,0 +1,106 @@
+package replaynet_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/replaynet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/transcript"
+)
+
+// party0 sends a value to party 1, then expects the value plus one back.
+func party0(ctx context.Context, t cbmpc.Transport, v byte) (byte, error) {
+	if err := t.Send(ctx, 1, []byte{v}); err != nil {
+		return 0, err
+	}
+	msg, err := t.Receive(ctx, 1)
+	if err != nil {
+		return 0, err
+	}
+	if msg[0] != v+1 {
+		return 0, errors.New("bad reply")
+	}
+	return msg[0], nil
+}
+
+func record(t *testing.T, redact transcript.Redactor) *transcript.Transcript {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	rec, err := transcript.NewRecorder(net.Ep2P(0, 1), 0, "toy", redact)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer := net.Ep2P(1, 0)
+	go func() {
+		msg, err := peer.Receive(ctx, 0)
+		if err == nil {
+			_ = peer.Send(ctx, 0, []byte{msg[0] + 1})
+		}
+	}()
+	if _, err := party0(ctx, rec, 41); err != nil {
+		t.Fatal(err)
+	}
+	return rec.Transcript()
+}
+
+func TestReplay(t *testing.T) {
+	ctx := context.Background()
+	tr := record(t, nil)
+
+	net, err := replaynet.New(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := party0(ctx, net, 41)
+	if err != nil || got != 42 {
+		t.Fatalf("replay = %d, %v", got, err)
+	}
+	if net.Remaining() != 0 || len(net.Divergences()) != 0 {
+		t.Fatalf("remaining %d, divergences %v", net.Remaining(), net.Divergences())
+	}
+
+	// A different send payload is a divergence, not an error, and the replay
+	// reproduces the recorded reply.
+	net, _ = replaynet.New(tr)
+	if _, err := party0(ctx, net, 7); err == nil {
+		t.Fatal("expected the recorded reply to be rejected")
+	}
+	if d := net.Divergences(); len(d) != 1 || d[0].Step != 0 || d[0].Peer != 1 {
+		t.Fatalf("divergences = %+v", d)
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	ctx := context.Background()
+	tr := record(t, transcript.RedactSent)
+
+	net, _ := replaynet.New(tr)
+	if _, err := net.Receive(ctx, 1); !errors.Is(err, replaynet.ErrUnexpected) {
+		t.Fatalf("out-of-order receive: %v", err)
+	}
+	if err := net.Send(ctx, 2, nil); !errors.Is(err, replaynet.ErrUnexpected) {
+		t.Fatalf("wrong peer: %v", err)
+	}
+	if err := net.Send(ctx, 1, []byte{0}); err != nil {
+		t.Fatalf("redacted send should match any payload: %v", err)
+	}
+	if _, err := net.ReceiveAll(ctx, []cbmpc.RoleID{1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := net.Receive(ctx, 1); !errors.Is(err, replaynet.ErrExhausted) {
+		t.Fatalf("past end: %v", err)
+	}
+
+	net, _ = replaynet.New(record(t, transcript.RedactAll))
+	_ = net.Send(ctx, 1, []byte{41})
+	if _, err := net.Receive(ctx, 1); !errors.Is(err, replaynet.ErrRedacted) {
+		t.Fatalf("redacted receive: %v", err)
+	}
+}
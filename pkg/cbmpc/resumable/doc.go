@@ -0,0 +1,35 @@
+// Package resumable keeps a job alive across transient transport failures.
+//
+// A long multi-party DKG over a WAN aborts on any connection blip, because the
+// native protocol treats every transport error as fatal. resumable.Transport
+// sits between the job and the real transport and hides such failures: it
+// numbers and buffers every outgoing message until the peer acknowledges it,
+// and when the underlying transport returns a retryable error it calls the
+// Connector for a fresh connection, tells each peer how far it has received,
+// and retransmits whatever the peers are missing. Receivers drop duplicates
+// and deliver messages in order, so the job resumes from the last completed
+// round as if nothing had happened:
+//
+//	net, err := resumable.New(ctx, resumable.Config{
+//	    Connect: func(ctx context.Context) (cbmpc.Transport, error) {
+//	        return dialAll(ctx, peers) // e.g. a new tlsnet transport
+//	    },
+//	    Peers:       []cbmpc.RoleID{1, 2},
+//	    MaxAttempts: 10,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	job, _ := cbmpc.NewJobMPWithContext(ctx, net, self, names)
+//
+// Every party of the job must use a resumable.Transport, since the frames it
+// exchanges carry sequence numbers and acknowledgements the job never sees.
+// Use a new Transport for each job. Set Config.Logger to log each failure and
+// reconnect attempt.
+//
+// The native round state lives in C++ and cannot be saved, so resumption works
+// at the message layer within one process: a process that dies mid-protocol
+// cannot resume the job and must start over. The transport must also report
+// failures; a message lost without an error on either side is only recovered
+// at the next reconnect.
+package resumable
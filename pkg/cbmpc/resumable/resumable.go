@@ -0,0 +1,362 @@
+package resumable
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/logging"
+)
+
+// ErrResumeFailed is returned when the transport could not be re-established
+// within Config.MaxAttempts. It wraps the last connection error.
+var ErrResumeFailed = errors.New("resumable: could not resume session")
+
+// Connector returns a transport connected to every peer. It is called once by
+// New and again after each retryable failure; it should discard any previous
+// connection.
+type Connector func(ctx context.Context) (cbmpc.Transport, error)
+
+// Config configures a resumable Transport.
+type Config struct {
+	// Connect establishes (or re-establishes) the underlying transport.
+	Connect Connector
+	// Peers lists every other party this party exchanges messages with.
+	Peers []cbmpc.RoleID
+	// IsRetryable reports whether a transport error is transient. Nil treats
+	// every error as transient unless the caller's context is done.
+	IsRetryable func(error) bool
+	// MaxAttempts bounds the connection attempts made for one failure. Zero
+	// selects 5.
+	MaxAttempts int
+	// Backoff is the delay before the second attempt, doubled for each further
+	// attempt up to 5s. Zero selects 200ms.
+	Backoff time.Duration
+	// Logger, if set, receives a warning for every transport failure and
+	// reconnect attempt, and an info event when the session resumes.
+	Logger logging.Logger
+}
+
+const (
+	defaultMaxAttempts = 5
+	defaultBackoff     = 200 * time.Millisecond
+	maxBackoff         = 5 * time.Second
+)
+
+// Frame types on the underlying transport.
+const (
+	frameData   byte = 1
+	frameResume byte = 2
+)
+
+const frameHeader = 1 + 8 + 8 // type, seq, ack
+
+// Transport is a cbmpc.Transport that survives transient failures of the
+// underlying transport by reconnecting and retransmitting unacknowledged
+// messages. Use one Transport per job.
+type Transport struct {
+	cfg Config
+
+	mu         sync.Mutex // guards inner and gen; held while reconnecting
+	inner      cbmpc.Transport
+	gen        int
+	reconnects int
+
+	peers map[cbmpc.RoleID]*peerState
+}
+
+// peerState is the buffered round state for one peer.
+type peerState struct {
+	mu       sync.Mutex
+	nextSend uint64
+	unacked  []sentMsg // ordered by seq
+	nextRecv uint64
+	pending  map[uint64][]byte // received out of order
+}
+
+type sentMsg struct {
+	seq     uint64
+	payload []byte
+}
+
+// New connects with cfg.Connect and returns a Transport over the result.
+func New(ctx context.Context, cfg Config) (*Transport, error) {
+	if cfg.Connect == nil {
+		return nil, errors.New("nil connector")
+	}
+	if len(cfg.Peers) == 0 {
+		return nil, errors.New("no peers")
+	}
+	if cfg.MaxAttempts < 0 || cfg.Backoff < 0 {
+		return nil, errors.New("MaxAttempts and Backoff must not be negative")
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.Backoff == 0 {
+		cfg.Backoff = defaultBackoff
+	}
+	t := &Transport{cfg: cfg, peers: make(map[cbmpc.RoleID]*peerState, len(cfg.Peers))}
+	for _, p := range cfg.Peers {
+		if _, dup := t.peers[p]; dup {
+			return nil, fmt.Errorf("duplicate peer %d", p)
+		}
+		t.peers[p] = &peerState{pending: make(map[uint64][]byte)}
+	}
+	inner, err := cfg.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if inner == nil {
+		return nil, cbmpc.ErrNilTransport
+	}
+	t.inner = inner
+	return t, nil
+}
+
+// Reconnects returns how many times the session has been resumed.
+func (t *Transport) Reconnects() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.reconnects
+}
+
+// Send buffers msg until the peer acknowledges it and delivers it, resuming
+// the session if the underlying transport fails.
+func (t *Transport) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	ps, err := t.peer(to)
+	if err != nil {
+		return err
+	}
+	ps.mu.Lock()
+	seq := ps.nextSend
+	ps.nextSend++
+	ps.unacked = append(ps.unacked, sentMsg{seq: seq, payload: append([]byte(nil), msg...)})
+	frame := encodeFrame(frameData, seq, ps.nextRecv, msg)
+	ps.mu.Unlock()
+
+	inner, gen := t.current()
+	if err := inner.Send(ctx, to, frame); err != nil {
+		// A successful resume retransmits every unacknowledged message,
+		// including this one.
+		return t.recover(ctx, gen, err)
+	}
+	return nil
+}
+
+// Receive returns the next message from the peer in order, exactly once.
+func (t *Transport) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
+	ps, err := t.peer(from)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if msg, ok := ps.take(); ok {
+			return msg, nil
+		}
+		inner, gen := t.current()
+		raw, err := inner.Receive(ctx, from)
+		if err != nil {
+			if rerr := t.recover(ctx, gen, err); rerr != nil {
+				return nil, rerr
+			}
+			continue
+		}
+		if err := t.handle(ctx, from, ps, raw); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ReceiveAll receives one message from each requested peer.
+func (t *Transport) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	out := make(map[cbmpc.RoleID][]byte, len(from))
+	for _, role := range from {
+		msg, err := t.Receive(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		out[role] = msg
+	}
+	return out, nil
+}
+
+func (t *Transport) peer(role cbmpc.RoleID) (*peerState, error) {
+	ps, ok := t.peers[role]
+	if !ok {
+		return nil, fmt.Errorf("unknown peer %d", role)
+	}
+	return ps, nil
+}
+
+func (t *Transport) current() (cbmpc.Transport, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.inner, t.gen
+}
+
+// take pops the next in-order message, if it has arrived.
+func (ps *peerState) take() ([]byte, bool) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	msg, ok := ps.pending[ps.nextRecv]
+	if !ok {
+		return nil, false
+	}
+	delete(ps.pending, ps.nextRecv)
+	ps.nextRecv++
+	return msg, true
+}
+
+// ack drops buffered messages the peer has received.
+func (ps *peerState) ack(next uint64) {
+	i := sort.Search(len(ps.unacked), func(i int) bool { return ps.unacked[i].seq >= next })
+	if i > 0 {
+		ps.unacked = append([]sentMsg(nil), ps.unacked[i:]...)
+	}
+}
+
+func (t *Transport) handle(ctx context.Context, from cbmpc.RoleID, ps *peerState, raw []byte) error {
+	typ, seq, ack, payload, err := decodeFrame(raw)
+	if err != nil {
+		return fmt.Errorf("peer %d: %w", from, err)
+	}
+	ps.mu.Lock()
+	ps.ack(ack)
+	switch typ {
+	case frameData:
+		if seq >= ps.nextRecv {
+			if _, dup := ps.pending[seq]; !dup {
+				ps.pending[seq] = append([]byte(nil), payload...)
+			}
+		}
+		ps.mu.Unlock()
+		return nil
+	default: // frameResume: the peer reconnected and needs our unacked messages.
+		frames := ps.retransmitFrames()
+		ps.mu.Unlock()
+		inner, gen := t.current()
+		for _, f := range frames {
+			if err := inner.Send(ctx, from, f); err != nil {
+				return t.recover(ctx, gen, err)
+			}
+		}
+		return nil
+	}
+}
+
+func (ps *peerState) retransmitFrames() [][]byte {
+	frames := make([][]byte, len(ps.unacked))
+	for i, m := range ps.unacked {
+		frames[i] = encodeFrame(frameData, m.seq, ps.nextRecv, m.payload)
+	}
+	return frames
+}
+
+// recover resumes the session after err unless err is permanent. gen is the
+// generation of the transport that failed; if another goroutine has already
+// resumed past it, recover returns at once.
+func (t *Transport) recover(ctx context.Context, gen int, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+	if t.cfg.IsRetryable != nil && !t.cfg.IsRetryable(err) {
+		return err
+	}
+	t.logger().Warn(ctx, "resumable: transport failed, reconnecting", "error", err)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.gen != gen {
+		return nil
+	}
+
+	lastErr := err
+	delay := t.cfg.Backoff
+	for attempt := 0; attempt < t.cfg.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			delay = min(2*delay, maxBackoff)
+		}
+		inner, cerr := t.cfg.Connect(ctx)
+		if cerr == nil && inner == nil {
+			cerr = cbmpc.ErrNilTransport
+		}
+		if cerr == nil {
+			cerr = t.resync(ctx, inner)
+		}
+		if cerr != nil {
+			lastErr = cerr
+			t.logger().Warn(ctx, "resumable: reconnect attempt failed", "attempt", attempt+1, "max_attempts", t.cfg.MaxAttempts, "error", cerr)
+			continue
+		}
+		t.inner = inner
+		t.gen++
+		t.reconnects++
+		t.logger().Info(ctx, "resumable: session resumed", "attempt", attempt+1, "reconnects", t.reconnects)
+		return nil
+	}
+	return fmt.Errorf("%w after %d attempts: %w", ErrResumeFailed, t.cfg.MaxAttempts, lastErr)
+}
+
+func (t *Transport) logger() logging.Logger {
+	if t.cfg.Logger == nil {
+		return nopLogger{}
+	}
+	return t.cfg.Logger
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(context.Context, string, ...any) {}
+func (nopLogger) Info(context.Context, string, ...any)  {}
+func (nopLogger) Warn(context.Context, string, ...any)  {}
+func (nopLogger) Error(context.Context, string, ...any) {}
+func (n nopLogger) With(...any) logging.Logger          { return n }
+
+// resync tells every peer what we have received and retransmits what they
+// have not acknowledged. Duplicates are discarded by the receiver.
+func (t *Transport) resync(ctx context.Context, inner cbmpc.Transport) error {
+	for role, ps := range t.peers {
+		ps.mu.Lock()
+		frames := append([][]byte{encodeFrame(frameResume, 0, ps.nextRecv, nil)}, ps.retransmitFrames()...)
+		ps.mu.Unlock()
+		for _, f := range frames {
+			if err := inner.Send(ctx, role, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func encodeFrame(typ byte, seq, ack uint64, payload []byte) []byte {
+	out := make([]byte, frameHeader+len(payload))
+	out[0] = typ
+	binary.BigEndian.PutUint64(out[1:], seq)
+	binary.BigEndian.PutUint64(out[9:], ack)
+	copy(out[frameHeader:], payload)
+	return out
+}
+
+func decodeFrame(raw []byte) (typ byte, seq, ack uint64, payload []byte, err error) {
+	if len(raw) < frameHeader {
+		return 0, 0, 0, nil, errors.New("short frame")
+	}
+	typ = raw[0]
+	if typ != frameData && typ != frameResume {
+		return 0, 0, 0, nil, fmt.Errorf("unknown frame type %d", typ)
+	}
+	return typ, binary.BigEndian.Uint64(raw[1:]), binary.BigEndian.Uint64(raw[9:]), raw[frameHeader:], nil
+}
+
+var _ cbmpc.Transport = (*Transport)(nil)
@@ -0,0 +1,200 @@
+package resumable_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/resumable"
+)
+
+var errBlip = errors.New("connection reset")
+
+// flaky drops the sends and fails the receives whose 1-based counts are listed.
+type flaky struct {
+	inner     cbmpc.Transport
+	failSends map[int]bool
+	failRecvs map[int]bool
+	failAll   bool
+
+	mu           sync.Mutex
+	sends, recvs int
+}
+
+func (f *flaky) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	f.mu.Lock()
+	f.sends++
+	fail := f.failAll || f.failSends[f.sends]
+	f.mu.Unlock()
+	if fail {
+		return errBlip
+	}
+	return f.inner.Send(ctx, to, msg)
+}
+
+func (f *flaky) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
+	f.mu.Lock()
+	f.recvs++
+	fail := f.failAll || f.failRecvs[f.recvs]
+	f.mu.Unlock()
+	if fail {
+		return nil, errBlip
+	}
+	return f.inner.Receive(ctx, from)
+}
+
+func (f *flaky) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	return f.inner.ReceiveAll(ctx, from)
+}
+
+func others(n int, self cbmpc.RoleID) []cbmpc.RoleID {
+	var out []cbmpc.RoleID
+	for i := 0; i < n; i++ {
+		if cbmpc.RoleID(i) != self {
+			out = append(out, cbmpc.RoleID(i))
+		}
+	}
+	return out
+}
+
+// allToAll runs rounds in which every party sends to and receives from every
+// other party, checking that each message arrives once and in order.
+func allToAll(ctx context.Context, self cbmpc.RoleID, t cbmpc.Transport, peers []cbmpc.RoleID, rounds int) error {
+	for r := 0; r < rounds; r++ {
+		for _, p := range peers {
+			if err := t.Send(ctx, p, []byte(fmt.Sprintf("%d:%d->%d", r, self, p))); err != nil {
+				return err
+			}
+		}
+		msgs, err := t.ReceiveAll(ctx, peers)
+		if err != nil {
+			return err
+		}
+		for _, p := range peers {
+			if want := fmt.Sprintf("%d:%d->%d", r, p, self); string(msgs[p]) != want {
+				return fmt.Errorf("round %d from %d: got %q, want %q", r, p, msgs[p], want)
+			}
+		}
+	}
+	return nil
+}
+
+func TestResumeAfterBlips(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	const n, rounds = 3, 20
+	net := mocknet.New()
+	transports := make([]*resumable.Transport, n)
+	for i := 0; i < n; i++ {
+		self := cbmpc.RoleID(i)
+		link := &flaky{
+			inner:     net.EpMP(self, others(n, self)),
+			failSends: map[int]bool{3 + i: true, 20 + 3*i: true},
+			failRecvs: map[int]bool{5 + 2*i: true, 30: true, 31: true},
+		}
+		tr, err := resumable.New(ctx, resumable.Config{
+			Connect: func(context.Context) (cbmpc.Transport, error) { return link, nil },
+			Peers:   others(n, self),
+			Backoff: time.Millisecond,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		transports[i] = tr
+	}
+
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			self := cbmpc.RoleID(i)
+			errs[i] = allToAll(ctx, self, transports[i], others(n, self), rounds)
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d: %v", i, err)
+		}
+		if transports[i].Reconnects() == 0 {
+			t.Fatalf("party %d never reconnected", i)
+		}
+	}
+}
+
+func TestPermanentError(t *testing.T) {
+	ctx := context.Background()
+	net := mocknet.New()
+	link := &flaky{inner: net.Ep2P(0, 1), failAll: true}
+	connects := 0
+	tr, err := resumable.New(ctx, resumable.Config{
+		Connect: func(context.Context) (cbmpc.Transport, error) {
+			connects++
+			return link, nil
+		},
+		Peers:       []cbmpc.RoleID{1},
+		IsRetryable: func(err error) bool { return !errors.Is(err, errBlip) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Send(ctx, 1, []byte("x")); !errors.Is(err, errBlip) {
+		t.Fatalf("Send = %v, want %v", err, errBlip)
+	}
+	if connects != 1 {
+		t.Fatalf("connected %d times, want 1", connects)
+	}
+}
+
+func TestResumeFailed(t *testing.T) {
+	ctx := context.Background()
+	net := mocknet.New()
+	first := true
+	tr, err := resumable.New(ctx, resumable.Config{
+		Connect: func(context.Context) (cbmpc.Transport, error) {
+			if first {
+				first = false
+				return &flaky{inner: net.Ep2P(0, 1), failAll: true}, nil
+			}
+			return nil, errors.New("peer unreachable")
+		},
+		Peers:       []cbmpc.RoleID{1},
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Send(ctx, 1, []byte("x")); !errors.Is(err, resumable.ErrResumeFailed) {
+		t.Fatalf("Send = %v, want ErrResumeFailed", err)
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	ctx := context.Background()
+	connect := func(context.Context) (cbmpc.Transport, error) { return mocknet.New().Ep2P(0, 1), nil }
+	cases := map[string]resumable.Config{
+		"nil connector":  {Peers: []cbmpc.RoleID{1}},
+		"no peers":       {Connect: connect},
+		"duplicate peer": {Connect: connect, Peers: []cbmpc.RoleID{1, 1}},
+		"negative":       {Connect: connect, Peers: []cbmpc.RoleID{1}, MaxAttempts: -1},
+		"nil transport": {Peers: []cbmpc.RoleID{1}, Connect: func(context.Context) (cbmpc.Transport, error) {
+			return nil, nil
+		}},
+	}
+	for name, cfg := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := resumable.New(ctx, cfg); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRoundTimeout(t *testing.T) {
+	net := &chanNet{}
+	trace := &jobTrace{}
+	j := &JobMP{self: 0, names: []string{"p0", "p1", "p2"}, trace: trace}
+	adapter := transportAdapter{inner: chanEndpoint{net: net, self: 0}, ctx: context.Background(), trace: trace, roundTimeout: 20 * time.Millisecond}
+	p1 := chanEndpoint{net: net, self: 1}
+
+	done := j.BeginOperation(context.Background(), &Operation{Protocol: "test.Run"})
+	_ = p1.Send(context.Background(), 0, []byte("round 1"))
+	if _, err := adapter.Receive(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	// Party 1 answers round 2 but party 2 stays silent.
+	_ = p1.Send(context.Background(), 0, []byte("round 2"))
+	start := time.Now()
+	_, err := adapter.ReceiveAll(context.Background(), []uint32{1, 2})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("round timeout took %v", elapsed)
+	}
+	var rte *RoundTimeoutError
+	if !errors.As(err, &rte) || rte.Round != 2 || rte.Peer != 2 {
+		t.Fatalf("err = %v, want round 2 timeout from party 2", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("RoundTimeoutError does not match context.DeadlineExceeded")
+	}
+
+	// The native library reports only that the callback failed; the job
+	// puts the round timeout back into the error the protocol returns.
+	err = errors.New("native: receive failed")
+	done(&err)
+	if !errors.As(err, &rte) || rte.Peer != 2 {
+		t.Fatalf("protocol error = %v, want it to carry the round timeout", err)
+	}
+}
+
+func TestRoundTimeoutLeavesJobDeadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	adapter := transportAdapter{inner: chanEndpoint{net: &chanNet{}, self: 0}, ctx: ctx, trace: &jobTrace{}, roundTimeout: time.Minute}
+	_, err := adapter.Receive(context.Background(), 1)
+	var rte *RoundTimeoutError
+	if !errors.Is(err, context.Canceled) || errors.As(err, &rte) {
+		t.Fatalf("err = %v, want the job's cancellation", err)
+	}
+}
@@ -17,8 +17,13 @@
 // # Key Operations
 //
 //   - DKG: Distributed Key Generation
+//   - Refresh: Key share refresh while preserving the public key
 //   - Sign: Generate a Schnorr signature
 //   - SignBatch: Generate multiple Schnorr signatures efficiently
+//   - VerifySignatures: Verify many signatures of one variant in a single native call
+//
+// Key.Ed25519PublicKey returns an Ed25519 public key as an ed25519.PublicKey
+// for use with ed25519.Verify.
 //
 // # Security Properties
 //
@@ -2,11 +2,16 @@ package schnorr2p
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"runtime"
+	"time"
 
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/pubkey"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
 )
 
 // Key represents a 2-party Schnorr key share (wraps eckey::key_share_2p_t).
@@ -17,6 +22,8 @@ import (
 // - Use Close() to securely free the key when done
 type Key struct {
 	ckey backend.Schnorr2PKey
+	// created is when the key share was generated, zero if unknown.
+	created time.Time
 }
 
 // Close frees the underlying C++ key resources.
@@ -33,27 +40,83 @@ func (k *Key) Close() error {
 }
 
 // Bytes serializes the key to bytes for persistent storage or network transmission.
-// Returns a defensive copy to prevent external modification of internal key data.
+// The native serialization is wrapped in an envelope recording the protocol,
+// curve, role and creation time, which LoadKey checks.
+// In strict mode (secmem.SetStrict) it fails with secmem.ErrUnprotected; use
+// ProtectedBytes instead.
 //
 // SECURITY WARNING: The returned bytes contain the private key share.
 // - Zeroize with cbmpc.ZeroizeBytes immediately after use
 // - Never log, print, or transmit over insecure channels
 // - Encrypt before storing or transmitting
 func (k *Key) Bytes() ([]byte, error) {
+	if secmem.Strict() {
+		return nil, secmem.ErrUnprotected
+	}
 	if k == nil {
 		return nil, errors.New("nil key")
 	}
 	if k.ckey == nil {
 		return nil, errors.New("key is closed")
 	}
-	data, err := backend.Schnorr2PKeySerialize(k.ckey)
+	return k.serialize()
+}
+
+// ProtectedBytes is like Bytes but moves the serialized key into a
+// secmem.Buffer and wipes the intermediate copy. It is the export to use in
+// strict mode. Call Destroy on the buffer when done.
+func (k *Key) ProtectedBytes() (*secmem.Buffer, error) {
+	if k == nil {
+		return nil, errors.New("nil key")
+	}
+	if k.ckey == nil {
+		return nil, errors.New("key is closed")
+	}
+	data, err := k.serialize()
+	if err != nil {
+		return nil, err
+	}
+	return secmem.Move(data)
+}
+
+// serialize returns the native serialization of the key in its envelope.
+func (k *Key) serialize() ([]byte, error) {
+	meta, err := k.meta()
+	if err != nil {
+		return nil, err
+	}
+	share, err := backend.Schnorr2PKeySerialize(k.ckey)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
-	// Return a defensive copy to prevent mutation of internal state
-	result := make([]byte, len(data))
-	copy(result, data)
-	return result, nil
+	defer cbmpc.ZeroizeBytes(share)
+	return envelope.EncodeKey(meta, share)
+}
+
+func (k *Key) meta() (envelope.KeyMeta, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return envelope.KeyMeta{}, err
+	}
+	role, err := backend.Schnorr2PKeyGetRole(k.ckey)
+	if err != nil {
+		return envelope.KeyMeta{}, cbmpc.RemapError(err)
+	}
+	return envelope.KeyMeta{
+		Protocol: envelope.KeySchnorr2P,
+		Curve:    uint8(curve),
+		Role:     envelope.Role2P(role),
+		Created:  k.created,
+	}, nil
+}
+
+// Created returns when the key share was generated by DKG, or the zero time
+// if it was loaded from a share serialized before keys recorded it.
+func (k *Key) Created() time.Time {
+	if k == nil {
+		return time.Time{}
+	}
+	return k.created
 }
 
 // PublicKey returns the public key point Q in compressed format.
@@ -93,17 +156,75 @@ func (k *Key) Curve() (cbmpc.Curve, error) {
 	return cbmpc.Curve(curve), nil
 }
 
-// LoadKey deserializes a Schnorr 2P key from bytes.
+// Fingerprint returns the key's cbmpc.KeyFingerprint, a stable identifier
+// derived from its curve and public key. Every party's share of the key has
+// the same fingerprint, and operation logs and audit records use it too.
+func (k *Key) Fingerprint() (string, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return "", err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return "", err
+	}
+	return cbmpc.KeyFingerprint(curve, pub), nil
+}
+
+// Validate checks the key share for corruption the same way as
+// ecdsa2p.Key.Validate: known curve, a secret share in [1, q) and a valid
+// public key. It returns cbmpc.ErrKeyInvalid if a check fails.
+func (k *Key) Validate() error {
+	if k == nil || k.ckey == nil {
+		return errors.New("nil or closed key")
+	}
+	err := backend.Schnorr2PKeyValidate(k.ckey)
+	runtime.KeepAlive(k)
+	return cbmpc.RemapError(err)
+}
+
+// Ed25519PublicKey returns the public key of an Ed25519 key as an
+// ed25519.PublicKey, ready for ed25519.Verify. It fails for keys on other
+// curves.
+func (k *Key) Ed25519PublicKey() (ed25519.PublicKey, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return pubkey.Ed25519(curve, pub)
+}
+
+// LoadKey deserializes a Schnorr 2P key from bytes written by Bytes or
+// ProtectedBytes. It fails with cbmpc.ErrKeyProtocolMismatch for another
+// protocol's share and cbmpc.ErrKeyVersion for a share written by a newer
+// library. Bare native serializations from earlier releases are still
+// accepted; their Created time is unknown.
 //
 // SECURITY WARNING: The input bytes contain the private key share.
 // - Zeroize with cbmpc.ZeroizeBytes immediately after calling LoadKey
 func LoadKey(serialized []byte) (*Key, error) {
-	ckey, err := backend.Schnorr2PKeyDeserialize(serialized)
+	meta, share, err := envelope.UnwrapKey(envelope.KeySchnorr2P, serialized)
+	if err != nil {
+		return nil, err
+	}
+	ckey, err := backend.Schnorr2PKeyDeserialize(share)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
-	key := &Key{ckey: ckey}
+	key := &Key{ckey: ckey, created: meta.Created}
 	runtime.SetFinalizer(key, (*Key).Close)
+	got, err := key.meta()
+	if err == nil {
+		err = meta.Check(got.Curve, got.Role)
+	}
+	if err != nil {
+		_ = key.Close()
+		return nil, err
+	}
 	return key, nil
 }
 
@@ -142,7 +263,7 @@ type DKGResult struct {
 // DKG performs 2-party Schnorr distributed key generation.
 //
 // See cb-mpc/src/cbmpc/protocol/ec_dkg.h for protocol details.
-func DKG(_ context.Context, j *cbmpc.Job2P, params *DKGParams) (*DKGResult, error) {
+func DKG(ctx context.Context, j *cbmpc.Job2P, params *DKGParams) (_ *DKGResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -150,10 +271,15 @@ func DKG(_ context.Context, j *cbmpc.Job2P, params *DKGParams) (*DKGResult, erro
 		return nil, errors.New("nil params")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := &cbmpc.Operation{Protocol: "schnorr2p.DKG", Curve: params.Curve}
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
 	nid, err := backend.CurveToNID(backend.Curve(params.Curve))
 	if err != nil {
@@ -166,14 +292,74 @@ func DKG(_ context.Context, j *cbmpc.Job2P, params *DKGParams) (*DKGResult, erro
 	}
 	runtime.KeepAlive(j)
 
-	key := &Key{ckey: ckey}
+	key := &Key{ckey: ckey, created: time.Now().UTC()}
 	runtime.SetFinalizer(key, (*Key).Close)
+	op.PublicKey, _ = key.PublicKey()
+	if err := cbmpc.TrackInScope(ctx, key); err != nil {
+		return nil, err
+	}
 
 	return &DKGResult{
 		Key: key,
 	}, nil
 }
 
+// RefreshParams contains parameters for 2-party Schnorr key refresh.
+type RefreshParams struct {
+	Key *Key
+}
+
+// RefreshResult contains the output of 2-party Schnorr key refresh.
+type RefreshResult struct {
+	NewKey *Key
+}
+
+// Refresh performs 2-party Schnorr key refresh: both parties get new shares of
+// the same private key, so the public key and earlier signatures stay valid
+// while shares stolen before the refresh become useless.
+// The returned key must be freed with Close() when no longer needed.
+// The input key is not modified and remains valid.
+//
+// See cb-mpc/src/cbmpc/protocol/ec_dkg.h for protocol details.
+func Refresh(ctx context.Context, j *cbmpc.Job2P, params *RefreshParams) (_ *RefreshResult, err error) {
+	if j == nil {
+		return nil, errors.New("nil job")
+	}
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.Key == nil || params.Key.ckey == nil {
+		return nil, errors.New("nil or closed key")
+	}
+
+	ptr, release, err := j.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	op := operation("schnorr2p.Refresh", params.Key, nil)
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	ckey, err := backend.Schnorr2PRefresh(ptr, params.Key.ckey)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	runtime.KeepAlive(j)
+	runtime.KeepAlive(params.Key)
+
+	key := &Key{ckey: ckey, created: time.Now().UTC()}
+	runtime.SetFinalizer(key, (*Key).Close)
+	if err := cbmpc.TrackInScope(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &RefreshResult{
+		NewKey: key,
+	}, nil
+}
+
 // SignParams contains parameters for 2-party Schnorr signing.
 type SignParams struct {
 	Key     *Key    // Key share to sign with
@@ -193,7 +379,7 @@ type SignResult struct {
 //   - BIP340 (secp256k1): Message must be pre-hashed to exactly 32 bytes
 //
 // See cb-mpc/src/cbmpc/protocol/schnorr_2p.h for protocol details.
-func Sign(_ context.Context, j *cbmpc.Job2P, params *SignParams) (*SignResult, error) {
+func Sign(ctx context.Context, j *cbmpc.Job2P, params *SignParams) (_ *SignResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -212,10 +398,19 @@ func Sign(_ context.Context, j *cbmpc.Job2P, params *SignParams) (*SignResult, e
 		return nil, errors.New("BIP340 variant requires exactly 32-byte pre-hashed message")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("schnorr2p.Sign", params.Key, [][]byte{params.Message})
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	if err := j.CheckSignPolicy(ctx, op.SignRequest()); err != nil {
+		return nil, err
+	}
 
 	// Use the opaque C key pointer directly (no serialization/deserialization)
 	sig, err := backend.Schnorr2PSign(ptr, params.Key.ckey, params.Message, backend.SchnorrVariant(params.Variant))
@@ -249,7 +444,7 @@ type SignBatchResult struct {
 //   - BIP340 (secp256k1): Messages must be pre-hashed to exactly 32 bytes each
 //
 // See cb-mpc/src/cbmpc/protocol/schnorr_2p.h for protocol details.
-func SignBatch(_ context.Context, j *cbmpc.Job2P, params *SignBatchParams) (*SignBatchResult, error) {
+func SignBatch(ctx context.Context, j *cbmpc.Job2P, params *SignBatchParams) (_ *SignBatchResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -272,10 +467,19 @@ func SignBatch(_ context.Context, j *cbmpc.Job2P, params *SignBatchParams) (*Sig
 		}
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("schnorr2p.SignBatch", params.Key, params.Messages)
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	if err := j.CheckSignPolicy(ctx, op.SignRequest()); err != nil {
+		return nil, err
+	}
 
 	// Use the opaque C key pointer directly (no serialization/deserialization)
 	sigs, err := backend.Schnorr2PSignBatch(ptr, params.Key.ckey, params.Messages, backend.SchnorrVariant(params.Variant))
@@ -289,3 +493,12 @@ func SignBatch(_ context.Context, j *cbmpc.Job2P, params *SignBatchParams) (*Sig
 		Signatures: sigs,
 	}, nil
 }
+
+// operation describes an invocation for the job's hooks and sign policy. The
+// key has already been validated, so lookup errors only leave fields empty.
+func operation(protocol string, key *Key, messages [][]byte) *cbmpc.Operation {
+	op := &cbmpc.Operation{Protocol: protocol, Messages: messages}
+	op.Curve, _ = key.Curve()
+	op.PublicKey, _ = key.PublicKey()
+	return op
+}
@@ -147,10 +147,14 @@ func TestSchnorr2PSignEdDSA(t *testing.T) {
 	}
 
 	// Verify the signature using Ed25519 verification
-	if len(pubKey0) != ed25519.PublicKeySize {
-		t.Fatalf("Expected public key length %d, got %d", ed25519.PublicKeySize, len(pubKey0))
+	edPub, err := keys[0].Ed25519PublicKey()
+	if err != nil {
+		t.Fatalf("Ed25519PublicKey failed: %v", err)
+	}
+	if !edPub.Equal(ed25519.PublicKey(pubKey0)) {
+		t.Fatal("Ed25519PublicKey does not match PublicKey")
 	}
-	if !ed25519.Verify(ed25519.PublicKey(pubKey0), message, signatures[0]) {
+	if !ed25519.Verify(edPub, message, signatures[0]) {
 		t.Fatal("Ed25519 signature verification failed")
 	}
 
@@ -710,3 +714,102 @@ func TestSchnorr2PSignWithRandomMessage(t *testing.T) {
 
 	t.Log("Successfully signed and verified random message")
 }
+
+// TestSchnorr2PRefreshEdDSA tests that refreshed Schnorr 2P shares keep the
+// public key and still sign.
+func TestSchnorr2PRefreshEdDSA(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	names := [2]string{"party1", "party2"}
+
+	var jobs [2]*cbmpc.Job2P
+	for i, role := range []cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2} {
+		job, err := cbmpc.NewJob2P(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), role, names)
+		if err != nil {
+			t.Fatalf("NewJob2P: %v", err)
+		}
+		defer func() { _ = job.Close() }()
+		jobs[i] = job
+	}
+	run := func(fn func(partyID int) error) {
+		t.Helper()
+		var wg sync.WaitGroup
+		var errs [2]error
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(partyID int) {
+				defer wg.Done()
+				errs[partyID] = fn(partyID)
+			}(i)
+		}
+		wg.Wait()
+		for i, err := range errs {
+			if err != nil {
+				t.Fatalf("Party %d failed: %v", i, err)
+			}
+		}
+	}
+
+	var keys, refreshed [2]*schnorr2p.Key
+	run(func(partyID int) error {
+		result, err := schnorr2p.DKG(ctx, jobs[partyID], &schnorr2p.DKGParams{Curve: cbmpc.CurveEd25519})
+		if err == nil {
+			keys[partyID] = result.Key
+		}
+		return err
+	})
+	defer func() { _ = keys[0].Close(); _ = keys[1].Close() }()
+	run(func(partyID int) error {
+		result, err := schnorr2p.Refresh(ctx, jobs[partyID], &schnorr2p.RefreshParams{Key: keys[partyID]})
+		if err == nil {
+			refreshed[partyID] = result.NewKey
+		}
+		return err
+	})
+	defer func() { _ = refreshed[0].Close(); _ = refreshed[1].Close() }()
+
+	oldPub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey failed: %v", err)
+	}
+	for i, key := range refreshed {
+		pub, err := key.PublicKey()
+		if err != nil {
+			t.Fatalf("Party %d PublicKey failed: %v", i, err)
+		}
+		if string(pub) != string(oldPub) {
+			t.Fatalf("Party %d public key changed by refresh", i)
+		}
+		if err := key.Validate(); err != nil {
+			t.Fatalf("Party %d refreshed key failed validation: %v", i, err)
+		}
+	}
+
+	message := []byte("Hello after refresh!")
+	var signature []byte
+	run(func(partyID int) error {
+		result, err := schnorr2p.Sign(ctx, jobs[partyID], &schnorr2p.SignParams{
+			Key:     refreshed[partyID],
+			Message: message,
+			Variant: schnorr2p.VariantEdDSA,
+		})
+		if err == nil && partyID == 0 {
+			signature = result.Signature
+		}
+		return err
+	})
+	if !ed25519.Verify(ed25519.PublicKey(oldPub), message, signature) {
+		t.Fatal("Ed25519 signature with refreshed key failed verification")
+	}
+}
+
+func TestSchnorr2PRefreshValidation(t *testing.T) {
+	if _, err := schnorr2p.Refresh(context.Background(), nil, &schnorr2p.RefreshParams{}); err == nil {
+		t.Fatal("Refresh accepted a nil job")
+	}
+	if err := (*schnorr2p.Key)(nil).Validate(); err == nil {
+		t.Fatal("Validate accepted a nil key")
+	}
+}
@@ -0,0 +1,79 @@
+package schnorr2p
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+// SignedMessage is a public key, message and signature to check with
+// VerifySignatures.
+type SignedMessage struct {
+	PublicKey []byte // Public key, as returned by Key.PublicKey (BIP340 also accepts 32-byte x-only keys)
+	Message   []byte // Message that was signed (raw for EdDSA, 32-byte hash for BIP340)
+	Signature []byte // Signature, as returned in SignResult.Signature
+}
+
+// VerifySignatures verifies Schnorr signatures of one variant in a single
+// call into the native library: EdDSA signatures over Ed25519 or BIP340
+// signatures over secp256k1. It is meant for post-signing sanity checks in
+// services that sign at high volume, where one CGO round-trip per signature
+// dominates.
+//
+// The returned slice has one entry per element of sigs: nil if that signature
+// verified, otherwise the reason it was rejected. One bad signature does not
+// stop the rest from being checked. The error return is reserved for failures
+// that affect the whole call, such as an empty batch or unknown variant.
+func VerifySignatures(variant Variant, sigs []SignedMessage) ([]error, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("empty signatures")
+	}
+	var c cbmpc.Curve
+	switch variant {
+	case VariantEdDSA:
+		c = cbmpc.CurveEd25519
+	case VariantBIP340:
+		c = cbmpc.CurveSecp256k1
+	default:
+		return nil, fmt.Errorf("unknown Schnorr variant: %v", variant)
+	}
+	nid, err := backend.CurveToNID(backend.Curve(c))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]error, len(sigs))
+	idx := make([]int, 0, len(sigs))
+	pubKeys := make([][]byte, 0, len(sigs))
+	msgs := make([][]byte, 0, len(sigs))
+	raw := make([][]byte, 0, len(sigs))
+	for i, s := range sigs {
+		switch {
+		case len(s.PublicKey) == 0:
+			results[i] = errors.New("empty public key")
+		case variant == VariantBIP340 && len(s.Message) != 32:
+			results[i] = fmt.Errorf("BIP340 message must be 32 bytes, got %d", len(s.Message))
+		case len(s.Signature) == 0:
+			results[i] = errors.New("empty signature")
+		default:
+			idx = append(idx, i)
+			pubKeys = append(pubKeys, s.PublicKey)
+			msgs = append(msgs, s.Message)
+			raw = append(raw, s.Signature)
+		}
+	}
+	if len(idx) == 0 {
+		return results, nil
+	}
+
+	errs, err := backend.SchnorrVerifyMany(nid, backend.SchnorrVariant(variant), pubKeys, msgs, raw)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	for j, i := range idx {
+		results[i] = cbmpc.RemapError(errs[j])
+	}
+	return results, nil
+}
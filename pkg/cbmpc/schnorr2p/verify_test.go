@@ -0,0 +1,94 @@
+package schnorr2p_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcschnorr "github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorr2p"
+)
+
+func checkVerifyResults(t *testing.T, results []error, n int, bad ...int) {
+	t.Helper()
+	if len(results) != n {
+		t.Fatalf("got %d results, want %d", len(results), n)
+	}
+	isBad := make(map[int]bool)
+	for _, i := range bad {
+		isBad[i] = true
+	}
+	for i, err := range results {
+		if isBad[i] && err == nil {
+			t.Errorf("signature %d verified, want error", i)
+		}
+		if !isBad[i] && err != nil {
+			t.Errorf("signature %d: %v", i, err)
+		}
+	}
+}
+
+func TestVerifySignaturesEdDSA(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	sigs := make([]schnorr2p.SignedMessage, 4)
+	for i := range sigs {
+		msg := fmt.Appendf(nil, "message %d", i)
+		sigs[i] = schnorr2p.SignedMessage{PublicKey: pub, Message: msg, Signature: ed25519.Sign(priv, msg)}
+	}
+	sigs[2].Signature[0] ^= 1
+
+	results, err := schnorr2p.VerifySignatures(schnorr2p.VariantEdDSA, sigs)
+	if err != nil {
+		t.Fatalf("VerifySignatures failed: %v", err)
+	}
+	checkVerifyResults(t, results, len(sigs), 2)
+}
+
+func TestVerifySignaturesBIP340(t *testing.T) {
+	priv, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey failed: %v", err)
+	}
+
+	sigs := make([]schnorr2p.SignedMessage, 4)
+	for i := range sigs {
+		hash := sha256.Sum256(fmt.Appendf(nil, "message %d", i))
+		sig, err := btcschnorr.Sign(priv, hash[:])
+		if err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+		sigs[i] = schnorr2p.SignedMessage{
+			PublicKey: priv.PubKey().SerializeCompressed(),
+			Message:   hash[:],
+			Signature: sig.Serialize(),
+		}
+	}
+	// x-only keys are accepted too.
+	sigs[1].PublicKey = btcschnorr.SerializePubKey(priv.PubKey())
+	// Signature 3 is over a short message.
+	sigs[3].Message = sigs[3].Message[:31]
+
+	results, err := schnorr2p.VerifySignatures(schnorr2p.VariantBIP340, sigs)
+	if err != nil {
+		t.Fatalf("VerifySignatures failed: %v", err)
+	}
+	checkVerifyResults(t, results, len(sigs), 3)
+}
+
+func TestVerifySignaturesErrors(t *testing.T) {
+	if _, err := schnorr2p.VerifySignatures(schnorr2p.VariantEdDSA, nil); err == nil {
+		t.Error("VerifySignatures with no signatures should fail")
+	}
+	sigs := []schnorr2p.SignedMessage{{PublicKey: []byte{1}, Message: []byte{1}, Signature: []byte{1}}}
+	if _, err := schnorr2p.VerifySignatures(schnorr2p.Variant(99), sigs); err == nil {
+		t.Error("VerifySignatures with unknown variant should fail")
+	}
+}
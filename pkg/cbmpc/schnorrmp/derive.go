@@ -0,0 +1,204 @@
+package schnorrmp
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+// HardenedOffset is the first hardened child index. Hardened derivation needs
+// the whole private key and is not supported for MPC keys.
+const HardenedOffset = 1 << 31
+
+// ErrHardenedPath is returned for paths with hardened components.
+var ErrHardenedPath = errors.New("hardened derivation is not supported for MPC keys")
+
+// ErrInvalidChild is returned in the negligible case that a child index yields
+// a zero tweak; callers should skip to the next index, as in BIP32.
+var ErrInvalidChild = errors.New("invalid child index")
+
+// ed25519Order is the order l of the Ed25519 base point.
+var ed25519Order, _ = new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
+
+const chainCodeKey = "cbmpc ed25519 mpc chain code"
+
+// ChainCode is the 32-byte chain code extending a public key for derivation.
+type ChainCode [32]byte
+
+// DefaultChainCode returns the chain code HMAC-SHA512("cbmpc ed25519 mpc chain
+// code", publicKey)[:32]. Anyone who knows the public key can derive the child
+// public keys, so accounts derived with it are linkable; use a chain code the
+// parties agree on privately (e.g. with agreerandom after DKG) to avoid that.
+func DefaultChainCode(publicKey []byte) ChainCode {
+	mac := hmac.New(sha512.New, []byte(chainCodeKey))
+	mac.Write(publicKey)
+	var cc ChainCode
+	copy(cc[:], mac.Sum(nil))
+	return cc
+}
+
+// ExtendedPublicKey is an Ed25519 public key with its chain code.
+type ExtendedPublicKey struct {
+	PublicKey []byte
+	ChainCode ChainCode
+}
+
+// ParsePath parses a derivation path such as "m/44/501/0/0". Hardened
+// components ("0'" or "0h") are rejected with ErrHardenedPath.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("path %q must start with \"m\"", path)
+	}
+	out := make([]uint32, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		if strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h") || strings.HasSuffix(p, "H") {
+			return nil, fmt.Errorf("%w: %q", ErrHardenedPath, path)
+		}
+		i, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path component %q: %w", p, err)
+		}
+		if i >= HardenedOffset {
+			return nil, fmt.Errorf("%w: %q", ErrHardenedPath, path)
+		}
+		out = append(out, uint32(i))
+	}
+	return out, nil
+}
+
+// DerivePublicKey derives the child public key and chain code at path from
+// xpub without any key share, for watch-only wallets. It matches the public
+// key of the key Derive returns for the same path.
+//
+// Each step computes I = HMAC-SHA512(chain code, 0x02 || A || ser32(i)),
+// where A is the 32-byte parent public key; the tweak is IL (big-endian)
+// mod l, the child public key is A + tweak*G and the child chain code is IR.
+// This follows the public-derivation shape of SLIP-0010/BIP32, which define
+// only hardened derivation for Ed25519.
+func DerivePublicKey(xpub ExtendedPublicKey, path []uint32) (ExtendedPublicKey, error) {
+	_, child, err := deriveTweak(xpub, path)
+	return child, err
+}
+
+// DeriveParams contains parameters for Derive.
+type DeriveParams struct {
+	Key       *Key      // Ed25519 key share to derive from; not modified
+	ChainCode ChainCode // chain code of Key, the same at every party
+	Path      []uint32  // non-hardened child indices
+}
+
+// DeriveResult contains the output of Derive.
+type DeriveResult struct {
+	// Key is the caller's share of the child key. It must be freed with
+	// Close() when no longer needed.
+	Key *Key
+
+	// ChainCode is the child chain code, for deriving further.
+	ChainCode ChainCode
+}
+
+// Derive returns the caller's share of the child key at params.Path, so one
+// DKG can back many accounts. It runs locally: every party that derives the
+// same path from the same chain code gets a share of the same child key,
+// which signs with Sign like the parent. Derivation adds a public tweak to
+// the key, so anyone holding a child private key and the extended public key
+// can recover the parent private key; this is also true of non-hardened BIP32.
+func Derive(params *DeriveParams) (*DeriveResult, error) {
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.Key == nil || params.Key.ckey == nil {
+		return nil, errors.New("nil or closed key")
+	}
+	c, err := params.Key.Curve()
+	if err != nil {
+		return nil, err
+	}
+	if c != cbmpc.CurveEd25519 {
+		return nil, fmt.Errorf("derivation is only defined for Ed25519 keys (got %s)", c)
+	}
+	pub, err := params.Key.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	tweak, child, err := deriveTweak(ExtendedPublicKey{PublicKey: pub, ChainCode: params.ChainCode}, params.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	ckey, err := backend.ECDSAMPKeyTweak(params.Key.ckey, tweak.FillBytes(make([]byte, 32)))
+	runtime.KeepAlive(params.Key)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	return &DeriveResult{Key: newKey(ckey), ChainCode: child.ChainCode}, nil
+}
+
+// deriveTweak walks path from xpub and returns the total tweak (mod l)
+// together with the child extended public key.
+func deriveTweak(xpub ExtendedPublicKey, path []uint32) (*big.Int, ExtendedPublicKey, error) {
+	total := new(big.Int)
+	cur := ExtendedPublicKey{PublicKey: append([]byte(nil), xpub.PublicKey...), ChainCode: xpub.ChainCode}
+	for _, index := range path {
+		if index >= HardenedOffset {
+			return nil, ExtendedPublicKey{}, ErrHardenedPath
+		}
+		mac := hmac.New(sha512.New, cur.ChainCode[:])
+		mac.Write([]byte{0x02})
+		mac.Write(cur.PublicKey)
+		var ser [4]byte
+		binary.BigEndian.PutUint32(ser[:], index)
+		mac.Write(ser[:])
+		sum := mac.Sum(nil)
+
+		t := new(big.Int).SetBytes(sum[:32])
+		t.Mod(t, ed25519Order)
+		if t.Sign() == 0 {
+			return nil, ExtendedPublicKey{}, fmt.Errorf("%w: %d", ErrInvalidChild, index)
+		}
+		pub, err := addTweak(cur.PublicKey, t)
+		if err != nil {
+			return nil, ExtendedPublicKey{}, err
+		}
+		total.Add(total, t).Mod(total, ed25519Order)
+		cur.PublicKey = pub
+		copy(cur.ChainCode[:], sum[32:])
+	}
+	return total, cur, nil
+}
+
+// addTweak returns pub + t*G on Ed25519.
+func addTweak(pub []byte, t *big.Int) ([]byte, error) {
+	s, err := curve.NewScalarFromBytes(t.FillBytes(make([]byte, 32)))
+	if err != nil {
+		return nil, err
+	}
+	defer s.Free()
+	tg, err := curve.MulGenerator(curve.Ed25519, s)
+	if err != nil {
+		return nil, err
+	}
+	defer tg.Free()
+	p, err := curve.NewPointFromBytes(curve.Ed25519, pub)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Free()
+	sumPoint, err := p.Add(tg)
+	if err != nil {
+		return nil, err
+	}
+	defer sumPoint.Free()
+	return sumPoint.Bytes()
+}
@@ -0,0 +1,153 @@
+package schnorrmp_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/schnorrmp"
+)
+
+func TestParsePath(t *testing.T) {
+	got, err := schnorrmp.ParsePath("m/44/501/0/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []uint32{44, 501, 0, 0}
+	if len(got) != len(want) {
+		t.Fatalf("ParsePath = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ParsePath = %v, want %v", got, want)
+		}
+	}
+	if got, err := schnorrmp.ParsePath("m"); err != nil || len(got) != 0 {
+		t.Fatalf("ParsePath(m) = %v, %v", got, err)
+	}
+
+	for _, path := range []string{"m/44'/501'", "m/0h", "m/2147483648"} {
+		if _, err := schnorrmp.ParsePath(path); !errors.Is(err, schnorrmp.ErrHardenedPath) {
+			t.Fatalf("ParsePath(%q) error = %v, want ErrHardenedPath", path, err)
+		}
+	}
+	for _, path := range []string{"", "44/0", "m/", "m/x", "m/-1"} {
+		if _, err := schnorrmp.ParsePath(path); err == nil {
+			t.Fatalf("ParsePath(%q) succeeded", path)
+		}
+	}
+}
+
+func TestSchnorrMPDerive(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	roles := []cbmpc.RoleID{0, 1, 2}
+	names := []string{"p1", "p2", "p3"}
+
+	var keys [3]*schnorrmp.Key
+	var errs [3]error
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(partyID int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJobMP(net.EpMP(roles[partyID], roles), roles[partyID], names)
+			if err != nil {
+				errs[partyID] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			result, err := schnorrmp.DKG(ctx, job, &schnorrmp.DKGParams{Curve: cbmpc.CurveEd25519})
+			if err == nil {
+				keys[partyID] = result.Key
+			}
+			errs[partyID] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Party %d DKG failed: %v", i, err)
+		}
+		defer func() { _ = keys[i].Close() }()
+	}
+
+	pub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	xpub := schnorrmp.ExtendedPublicKey{PublicKey: pub, ChainCode: schnorrmp.DefaultChainCode(pub)}
+	path, err := schnorrmp.ParsePath("m/44/501/7/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := schnorrmp.DerivePublicKey(xpub, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(want.PublicKey, pub) {
+		t.Fatal("derived public key equals the parent")
+	}
+
+	var children [3]*schnorrmp.Key
+	for i, key := range keys {
+		res, err := schnorrmp.Derive(&schnorrmp.DeriveParams{Key: key, ChainCode: xpub.ChainCode, Path: path})
+		if err != nil {
+			t.Fatalf("Party %d derive failed: %v", i, err)
+		}
+		defer func() { _ = res.Key.Close() }()
+		children[i] = res.Key
+		got, err := res.Key.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want.PublicKey) {
+			t.Fatalf("Party %d child public key %x, want %x", i, got, want.PublicKey)
+		}
+		if res.ChainCode != want.ChainCode {
+			t.Fatalf("Party %d child chain code differs", i)
+		}
+	}
+
+	// The derived shares sign for the child public key.
+	message := []byte("derived account")
+	var sigs [3][]byte
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(partyID int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJobMP(net.EpMP(roles[partyID], roles), roles[partyID], names)
+			if err != nil {
+				errs[partyID] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			result, err := schnorrmp.Sign(ctx, job, &schnorrmp.SignParams{
+				Key:     children[partyID],
+				Message: message,
+				Variant: schnorrmp.VariantEdDSA,
+			})
+			if err == nil {
+				sigs[partyID] = result.Signature
+			}
+			errs[partyID] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Party %d signing failed: %v", i, err)
+		}
+	}
+	if !ed25519.Verify(ed25519.PublicKey(want.PublicKey), message, sigs[0]) {
+		t.Fatal("signature does not verify under the derived public key")
+	}
+}
@@ -2,16 +2,16 @@
 //
 // This package implements threshold Schnorr signature protocols supporting both
 // EdDSA (Ed25519) and BIP340 (Bitcoin Schnorr) schemes. The protocols allow n
-// parties to jointly generate a Schnorr key with threshold t, where any t+1
-// parties can cooperate to create signatures.
+// parties to jointly generate a Schnorr key with a Threshold, where any
+// Threshold parties can cooperate to create signatures.
 //
 // # Threshold Signing
 //
 // Threshold Schnorr allows a subset of parties to cooperate to create signatures:
 //   - Key generation involves all n parties
-//   - Signing requires any t+1 parties (threshold)
+//   - Signing requires any Threshold parties, on a job of just those parties
 //   - The private key is never reconstructed on a single device
-//   - Secure as long as at most t parties are compromised
+//   - Secure as long as fewer than Threshold parties are compromised
 //
 // # Supported Variants
 //
@@ -20,10 +20,30 @@
 //
 // # Key Operations
 //
-//   - DKG: Distributed Key Generation for n parties with threshold t
+//   - DKG: Distributed Key Generation for n parties with a Threshold
 //   - Sign: Threshold Schnorr signature generation
 //   - SignBatch: Batch threshold signing for multiple messages
 //   - Refresh: Key share refresh while preserving the public key
+//   - Derive: Non-hardened child key derivation for Ed25519 keys
+//
+// # Key Derivation
+//
+// SLIP-0010 defines only hardened derivation for Ed25519, which needs the
+// whole private key. Derive instead uses a non-hardened, BIP32-style scheme
+// (see DerivePublicKey) in which each party adds the same public tweak to its
+// share locally, so one DKG can serve many Solana or Cosmos accounts without
+// another protocol run. Paths must not contain hardened components, and the
+// derived addresses differ from those of SLIP-0010 wallets for the same path.
+//
+// # Jobs and Contexts
+//
+// A job runs one protocol at a time. A plain job, from cbmpc.NewJobMP or
+// cbmpc.NewJobMPWithContext, now returns cbmpc.ErrJobBusy to a protocol invoked
+// while another is running on it, where earlier releases let the two runs
+// interleave their messages. Share a job between goroutines with
+// cbmpc.NewSerializedJobMP instead. The ctx each function takes bounds that
+// wait, the sign-policy exchange and the operation hooks; the protocol run
+// itself stops when the job's own context is done.
 //
 // # Memory Management
 //
@@ -31,7 +51,7 @@
 //
 //	result, err := schnorrmp.DKG(ctx, job, &schnorrmp.DKGParams{
 //	    Curve:     cbmpc.CurveEd25519,
-//	    Threshold: 2, // Requires 3 parties to sign (t+1)
+//	    Threshold: 3, // Requires 3 parties to sign
 //	})
 //	if err != nil {
 //	    return err
@@ -43,13 +63,13 @@
 //	// 3-of-5 threshold EdDSA: 5 parties generate keys, any 3 can sign
 //	params := &schnorrmp.DKGParams{
 //	    Curve:     cbmpc.CurveEd25519,
-//	    Threshold: 2, // t=2 means 3 parties needed (t+1)
+//	    Threshold: 3, // any 3 parties can sign
 //	}
 //
 //	result, _ := schnorrmp.DKG(ctx, job1, params)
 //	defer result.Key.Close()
 //
-//	// Any 3 parties cooperate to sign
+//	// Any 3 parties cooperate to sign, on a job of just those 3
 //	message := []byte("message to sign")
 //	sig, _ := schnorrmp.Sign(ctx, job1, &schnorrmp.SignParams{
 //	    Key:     result.Key,
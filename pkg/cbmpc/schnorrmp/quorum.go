@@ -0,0 +1,80 @@
+package schnorrmp
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+)
+
+// Quorum returns the number of parties needed to sign with a threshold key:
+// the Threshold it was generated with. It is zero for n-of-n keys, which
+// every party signs with, and for keys from ThresholdDKG, whose access
+// structure need not be a threshold.
+func (k *Key) Quorum() int {
+	if k == nil {
+		return 0
+	}
+	return k.quorum
+}
+
+// AccessStructure returns the access structure a threshold key's share is
+// held under, or nil for an n-of-n key. Any set of parties that satisfies it
+// can sign: Sign turns each party's share into an additive share over the
+// job's parties.
+func (k *Key) AccessStructure() ac.AccessStructure {
+	if k == nil || k.structure == nil {
+		return nil
+	}
+	return append(ac.AccessStructure(nil), k.structure...)
+}
+
+// checkQuorum fails a signing protocol before any message is sent if the
+// job's parties cannot sign with the key: fewer than its quorum, or not
+// satisfying its access structure.
+func checkQuorum(j *cbmpc.JobMP, key *Key) error {
+	names := j.Names()
+	if n := len(names); key.quorum > 0 && n < key.quorum {
+		return fmt.Errorf("job has %d parties, fewer than the key's quorum of %d", n, key.quorum)
+	}
+	if key.structure == nil {
+		return nil
+	}
+	leaves, err := key.structure.LeafPaths()
+	if err != nil {
+		return err
+	}
+	in := make(map[string]bool, len(names))
+	for _, name := range names {
+		in[name] = true
+	}
+	var signers []string
+	for _, leaf := range leaves {
+		if in[path.Base(leaf)] {
+			signers = append(signers, leaf)
+		}
+	}
+	if !key.structure.IsSatisfiedBy(signers) {
+		return fmt.Errorf("job parties %q do not satisfy the key's access structure", names)
+	}
+	return nil
+}
+
+// thresholdStructure returns THRESHOLD[quorum](names...).
+func thresholdStructure(names []string, quorum int) (ac.AccessStructure, error) {
+	leaves := make([]ac.Expr, len(names))
+	for i, name := range names {
+		leaves[i] = ac.Leaf(name)
+	}
+	return ac.Compile(ac.Threshold(quorum, leaves...))
+}
+
+// allParties returns the indices of n parties.
+func allParties(n int) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
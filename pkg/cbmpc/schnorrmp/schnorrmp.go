@@ -2,12 +2,18 @@ package schnorrmp
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
+	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
 	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/pubkey"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
 )
 
 // Key represents a multi-party Schnorr key share.
@@ -33,11 +39,18 @@ type Key struct {
 	// ckey stores the C pointer as returned from bindings layer
 	// Currently uses backend.ECDSAMPKey but treated as opaque
 	ckey backend.ECDSAMPKey
+	// created is when the key share was generated, zero if unknown.
+	created time.Time
+	// quorum is the number of parties needed to sign, zero if every party is.
+	quorum int
+	// structure is the access structure of a threshold key's share, nil for
+	// an n-of-n key.
+	structure ac.AccessStructure
 }
 
 // newKey creates a new Key from a C pointer and sets up a finalizer.
 func newKey(ckey backend.ECDSAMPKey) *Key {
-	k := &Key{ckey: ckey}
+	k := &Key{ckey: ckey, created: time.Now().UTC()}
 	runtime.SetFinalizer(k, func(key *Key) {
 		_ = key.Close()
 	})
@@ -57,7 +70,10 @@ func (k *Key) Close() error {
 }
 
 // Bytes returns the serialized key data for persistent storage or network transmission.
-// Returns a defensive copy to prevent external modification of internal key data.
+// The native serialization is wrapped in an envelope recording the protocol,
+// curve, party name and creation time, which LoadKey checks.
+// In strict mode (secmem.SetStrict) it fails with secmem.ErrUnprotected; use
+// ProtectedBytes instead.
 //
 // SECURITY WARNING:
 // The returned bytes contain sensitive cryptographic key material.
@@ -80,27 +96,102 @@ func (k *Key) Close() error {
 //	}
 //	// Store encrypted bytes...
 func (k *Key) Bytes() ([]byte, error) {
+	if secmem.Strict() {
+		return nil, secmem.ErrUnprotected
+	}
+	if k == nil || k.ckey == nil {
+		return nil, errors.New("nil or closed key")
+	}
+	return k.serialize()
+}
+
+// ProtectedBytes is like Bytes but moves the serialized key into a
+// secmem.Buffer and wipes the intermediate copy. It is the export to use in
+// strict mode. Call Destroy on the buffer when done.
+func (k *Key) ProtectedBytes() (*secmem.Buffer, error) {
 	if k == nil || k.ckey == nil {
 		return nil, errors.New("nil or closed key")
 	}
-	data, err := backend.ECDSAMPKeySerialize(k.ckey)
+	data, err := k.serialize()
+	if err != nil {
+		return nil, err
+	}
+	return secmem.Move(data)
+}
+
+// serialize returns the native serialization of the key in its envelope.
+func (k *Key) serialize() ([]byte, error) {
+	meta, err := k.meta()
+	if err != nil {
+		return nil, err
+	}
+	share, err := backend.ECDSAMPKeySerialize(k.ckey)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
-	// Return a defensive copy to prevent mutation of internal state
-	result := make([]byte, len(data))
-	copy(result, data)
-	return result, nil
+	defer cbmpc.ZeroizeBytes(share)
+	return envelope.EncodeKey(meta, share)
+}
+
+func (k *Key) meta() (envelope.KeyMeta, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return envelope.KeyMeta{}, err
+	}
+	name, err := backend.ECDSAMPKeyGetPartyName(k.ckey)
+	if err != nil {
+		return envelope.KeyMeta{}, cbmpc.RemapError(err)
+	}
+	return envelope.KeyMeta{
+		Protocol:        envelope.KeySchnorrMP,
+		Curve:           uint8(curve),
+		Role:            name,
+		Created:         k.created,
+		Quorum:          k.quorum,
+		AccessStructure: k.structure,
+	}, nil
 }
 
-// LoadKey deserializes a key from bytes.
+// LoadKey deserializes a key from bytes written by Bytes or ProtectedBytes.
+// It fails with cbmpc.ErrKeyProtocolMismatch for another protocol's share,
+// including a ecdsamp share, and cbmpc.ErrKeyVersion for a share written
+// by a newer library. Bare native serializations from earlier releases are
+// still accepted; their Created time is unknown.
 // The returned key must be freed with Close() when no longer needed.
 func LoadKey(data []byte) (*Key, error) {
-	ckey, err := backend.ECDSAMPKeyDeserialize(data)
+	meta, share, err := envelope.UnwrapKey(envelope.KeySchnorrMP, data)
+	if err != nil {
+		return nil, err
+	}
+	ckey, err := backend.ECDSAMPKeyDeserialize(share)
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
-	return newKey(ckey), nil
+	k := newKey(ckey)
+	k.created = meta.Created
+	k.quorum = meta.Quorum
+	if len(meta.AccessStructure) > 0 {
+		k.structure = append(ac.AccessStructure(nil), meta.AccessStructure...)
+	}
+	got, err := k.meta()
+	if err == nil {
+		err = meta.Check(got.Curve, got.Role)
+	}
+	if err != nil {
+		_ = k.Close()
+		return nil, err
+	}
+	return k, nil
+}
+
+// Created returns when the key share was generated by DKG, Refresh or
+// Derive, or the zero time if it was loaded from a share serialized before
+// keys recorded it.
+func (k *Key) Created() time.Time {
+	if k == nil {
+		return time.Time{}
+	}
+	return k.created
 }
 
 // PublicKey extracts the public key point Q from the key share.
@@ -132,6 +223,48 @@ func (k *Key) Curve() (cbmpc.Curve, error) {
 	return cbmpc.Curve(curve), nil
 }
 
+// Fingerprint returns the key's cbmpc.KeyFingerprint, a stable identifier
+// derived from its curve and public key. Every party's share of the key has
+// the same fingerprint, and operation logs and audit records use it too.
+func (k *Key) Fingerprint() (string, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return "", err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return "", err
+	}
+	return cbmpc.KeyFingerprint(curve, pub), nil
+}
+
+// Validate checks the key share for corruption the same way as
+// ecdsamp.Key.Validate, including that the party's own public share matches
+// its secret share. It returns cbmpc.ErrKeyInvalid if a check fails.
+func (k *Key) Validate() error {
+	if k == nil || k.ckey == nil {
+		return errors.New("nil or closed key")
+	}
+	err := backend.ECDSAMPKeyValidate(k.ckey)
+	runtime.KeepAlive(k)
+	return cbmpc.RemapError(err)
+}
+
+// Ed25519PublicKey returns the public key of an Ed25519 key as an
+// ed25519.PublicKey, ready for ed25519.Verify. It fails for keys on other
+// curves.
+func (k *Key) Ed25519PublicKey() (ed25519.PublicKey, error) {
+	curve, err := k.Curve()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return pubkey.Ed25519(curve, pub)
+}
+
 // Variant represents a Schnorr signature variant.
 type Variant int
 
@@ -156,6 +289,10 @@ func (v Variant) String() string {
 // DKGParams contains parameters for multi-party Schnorr distributed key generation.
 type DKGParams struct {
 	Curve cbmpc.Curve
+	// Threshold is the number of the job's n parties needed to sign with a
+	// threshold key, THRESHOLD[Threshold](names...); it must be at most n.
+	// Zero generates an n-of-n key.
+	Threshold int
 }
 
 // DKGResult contains the output of multi-party Schnorr distributed key generation.
@@ -167,36 +304,70 @@ type DKGResult struct {
 // DKG performs multi-party Schnorr distributed key generation.
 // The returned key must be freed with Close() when no longer needed.
 //
-// Context behavior: ctx is ignored; use cbmpc.NewJobMPWithContext to control cancellation.
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and is passed to the job's operation hooks and logger;
+// a cbmpc.ResourceScope it carries takes ownership of the returned key. The
+// protocol run itself stops only when the job's own context is done (see
+// cbmpc.NewJobMPWithContext).
 //
 // See cb-mpc/src/cbmpc/protocol/schnorr_mp.h for protocol details.
-func DKG(_ context.Context, j *cbmpc.JobMP, params *DKGParams) (*DKGResult, error) {
+func DKG(ctx context.Context, j *cbmpc.JobMP, params *DKGParams) (_ *DKGResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
 	if params == nil {
 		return nil, errors.New("nil params")
 	}
+	names := j.Names()
+	if params.Threshold < 0 || params.Threshold > len(names) {
+		return nil, fmt.Errorf("threshold %d out of range [0,%d]", params.Threshold, len(names))
+	}
+	var structure ac.AccessStructure
+	if params.Threshold > 0 {
+		if structure, err = thresholdStructure(names, params.Threshold); err != nil {
+			return nil, err
+		}
+	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := &cbmpc.Operation{Protocol: "schnorrmp.DKG", Curve: params.Curve}
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
 	nid, err := backend.CurveToNID(backend.Curve(params.Curve))
 	if err != nil {
 		return nil, err
 	}
 
-	// Use Schnorr MP specific DKG wrapper
-	keyPtr, sid, err := backend.SchnorrMPDKG(ptr, nid)
+	var keyPtr backend.ECDSAMPKey
+	var sid []byte
+	if params.Threshold > 0 {
+		keyPtr, sid, err = backend.SchnorrMPThresholdDKG(ptr, nid, []byte(structure), allParties(len(names)))
+	} else {
+		keyPtr, sid, err = backend.SchnorrMPDKG(ptr, nid)
+	}
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
 	runtime.KeepAlive(j)
 
+	key := newKey(keyPtr)
+	if params.Threshold > 0 {
+		key.quorum = params.Threshold
+		key.structure = structure
+	}
+	op.PublicKey, _ = key.PublicKey()
+	if err := cbmpc.TrackInScope(ctx, key); err != nil {
+		return nil, err
+	}
+
 	return &DKGResult{
-		Key:       newKey(keyPtr),
+		Key:       key,
 		SessionID: cbmpc.NewSessionID(sid),
 	}, nil
 }
@@ -222,10 +393,14 @@ type RefreshResult struct {
 // - If params.SessionID is provided, it will be used and updated
 // - The updated/generated session ID is returned in RefreshResult.SessionID
 //
-// Context behavior: ctx is ignored; use cbmpc.NewJobMPWithContext to control cancellation.
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and is passed to the job's operation hooks and logger;
+// a cbmpc.ResourceScope it carries takes ownership of the returned key. The
+// protocol run itself stops only when the job's own context is done (see
+// cbmpc.NewJobMPWithContext).
 //
 // See cb-mpc/src/cbmpc/protocol/schnorr_mp.h for protocol details.
-func Refresh(_ context.Context, j *cbmpc.JobMP, params *RefreshParams) (*RefreshResult, error) {
+func Refresh(ctx context.Context, j *cbmpc.JobMP, params *RefreshParams) (_ *RefreshResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -235,11 +410,19 @@ func Refresh(_ context.Context, j *cbmpc.JobMP, params *RefreshParams) (*Refresh
 	if params.Key == nil || params.Key.ckey == nil {
 		return nil, errors.New("nil or closed key")
 	}
+	if params.Key.structure != nil {
+		return nil, errors.New("threshold key; use ThresholdRefresh")
+	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("schnorrmp.Refresh", params.Key, nil)
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
 	// Use Schnorr MP specific refresh wrapper
 	newKeyCkey, newSid, err := backend.SchnorrMPRefresh(ptr, params.Key.ckey, params.SessionID.Bytes())
@@ -249,8 +432,13 @@ func Refresh(_ context.Context, j *cbmpc.JobMP, params *RefreshParams) (*Refresh
 	runtime.KeepAlive(j)
 	runtime.KeepAlive(params.Key)
 
+	key := newKey(newKeyCkey)
+	if err := cbmpc.TrackInScope(ctx, key); err != nil {
+		return nil, err
+	}
+
 	return &RefreshResult{
-		NewKey:    newKey(newKeyCkey),
+		NewKey:    key,
 		SessionID: cbmpc.NewSessionID(newSid),
 	}, nil
 }
@@ -277,10 +465,19 @@ type SignResult struct {
 // Only the party with party_idx == SigReceiver will receive the final signature.
 // Other parties will receive an empty signature.
 //
-// Context behavior: ctx is ignored; use cbmpc.NewJobMPWithContext to control cancellation.
+// A threshold key signs on a job of any parties that satisfy its access
+// structure, each turning its share into an additive share over them; a job
+// that does not satisfy it fails before any message is sent. An n-of-n key
+// signs on a job of all its parties.
+//
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and the sign-policy exchange (see
+// cbmpc.JobMP.SetSignPolicy), and is passed to the job's operation hooks and
+// logger. The protocol run itself stops only when the job's own context is
+// done (see cbmpc.NewJobMPWithContext).
 //
 // See cb-mpc/src/cbmpc/protocol/schnorr_mp.h for protocol details.
-func Sign(_ context.Context, j *cbmpc.JobMP, params *SignParams) (*SignResult, error) {
+func Sign(ctx context.Context, j *cbmpc.JobMP, params *SignParams) (_ *SignResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -299,12 +496,25 @@ func Sign(_ context.Context, j *cbmpc.JobMP, params *SignParams) (*SignResult, e
 		return nil, errors.New("BIP340 variant requires exactly 32-byte pre-hashed message")
 	}
 
-	ptr, err := j.Ptr()
+	if err := checkQuorum(j, params.Key); err != nil {
+		return nil, err
+	}
+
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("schnorrmp.Sign", params.Key, [][]byte{params.Message})
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	if err := j.CheckSignPolicy(ctx, op.SignRequest()); err != nil {
+		return nil, err
+	}
 
-	sig, err := backend.SchnorrMPSign(ptr, params.Key.ckey, params.Message, params.SigReceiver, backend.SchnorrVariant(params.Variant))
+	sig, err := backend.SchnorrMPSign(ptr, params.Key.ckey, params.Key.structure, params.Message, params.SigReceiver, backend.SchnorrVariant(params.Variant))
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
@@ -338,10 +548,14 @@ type SignBatchResult struct {
 // Only the party with party_idx == SigReceiver will receive the final signatures.
 // Other parties will receive empty signatures.
 //
-// Context behavior: ctx is ignored; use cbmpc.NewJobMPWithContext to control cancellation.
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and the sign-policy exchange (see
+// cbmpc.JobMP.SetSignPolicy), and is passed to the job's operation hooks and
+// logger. The protocol run itself stops only when the job's own context is
+// done (see cbmpc.NewJobMPWithContext).
 //
 // See cb-mpc/src/cbmpc/protocol/schnorr_mp.h for protocol details.
-func SignBatch(_ context.Context, j *cbmpc.JobMP, params *SignBatchParams) (*SignBatchResult, error) {
+func SignBatch(ctx context.Context, j *cbmpc.JobMP, params *SignBatchParams) (_ *SignBatchResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -364,12 +578,25 @@ func SignBatch(_ context.Context, j *cbmpc.JobMP, params *SignBatchParams) (*Sig
 		}
 	}
 
-	ptr, err := j.Ptr()
+	if err := checkQuorum(j, params.Key); err != nil {
+		return nil, err
+	}
+
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("schnorrmp.SignBatch", params.Key, params.Messages)
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
+
+	if err := j.CheckSignPolicy(ctx, op.SignRequest()); err != nil {
+		return nil, err
+	}
 
-	sigs, err := backend.SchnorrMPSignBatch(ptr, params.Key.ckey, params.Messages, params.SigReceiver, backend.SchnorrVariant(params.Variant))
+	sigs, err := backend.SchnorrMPSignBatch(ptr, params.Key.ckey, params.Key.structure, params.Messages, params.SigReceiver, backend.SchnorrVariant(params.Variant))
 	if err != nil {
 		return nil, cbmpc.RemapError(err)
 	}
@@ -401,10 +628,14 @@ type ThresholdDKGResult struct {
 // control structure. The access structure defines policies for secret sharing using combinations
 // of AND, OR, and Threshold gates.
 //
-// Context behavior: ctx is ignored; use cbmpc.NewJobMPWithContext to control cancellation.
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and is passed to the job's operation hooks and logger;
+// a cbmpc.ResourceScope it carries takes ownership of the returned key. The
+// protocol run itself stops only when the job's own context is done (see
+// cbmpc.NewJobMPWithContext).
 //
 // See cb-mpc/src/cbmpc/protocol/schnorr_mp.h for protocol details.
-func ThresholdDKG(_ context.Context, j *cbmpc.JobMP, params *ThresholdDKGParams) (*ThresholdDKGResult, error) {
+func ThresholdDKG(ctx context.Context, j *cbmpc.JobMP, params *ThresholdDKGParams) (_ *ThresholdDKGResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -418,10 +649,15 @@ func ThresholdDKG(_ context.Context, j *cbmpc.JobMP, params *ThresholdDKGParams)
 		return nil, errors.New("empty quorum party indices")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := &cbmpc.Operation{Protocol: "schnorrmp.ThresholdDKG", Curve: params.Curve}
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
 	nid, err := backend.CurveToNID(backend.Curve(params.Curve))
 	if err != nil {
@@ -434,8 +670,15 @@ func ThresholdDKG(_ context.Context, j *cbmpc.JobMP, params *ThresholdDKGParams)
 	}
 	runtime.KeepAlive(j)
 
+	key := newKey(keyPtr)
+	key.structure = append(ac.AccessStructure(nil), params.AccessStructure...)
+	op.PublicKey, _ = key.PublicKey()
+	if err := cbmpc.TrackInScope(ctx, key); err != nil {
+		return nil, err
+	}
+
 	return &ThresholdDKGResult{
-		Key:       newKey(keyPtr),
+		Key:       key,
 		SessionID: cbmpc.NewSessionID(sid),
 	}, nil
 }
@@ -467,10 +710,14 @@ type ThresholdRefreshResult struct {
 // - If params.SessionID is provided, it will be used and updated
 // - The updated/generated session ID is returned in ThresholdRefreshResult.SessionID
 //
-// Context behavior: ctx is ignored; use cbmpc.NewJobMPWithContext to control cancellation.
+// Context behavior: ctx bounds the wait for a serialized job (see
+// cbmpc.JobMP.Acquire) and is passed to the job's operation hooks and logger;
+// a cbmpc.ResourceScope it carries takes ownership of the returned key. The
+// protocol run itself stops only when the job's own context is done (see
+// cbmpc.NewJobMPWithContext).
 //
 // See cb-mpc/src/cbmpc/protocol/schnorr_mp.h for protocol details.
-func ThresholdRefresh(_ context.Context, j *cbmpc.JobMP, params *ThresholdRefreshParams) (*ThresholdRefreshResult, error) {
+func ThresholdRefresh(ctx context.Context, j *cbmpc.JobMP, params *ThresholdRefreshParams) (_ *ThresholdRefreshResult, err error) {
 	if j == nil {
 		return nil, errors.New("nil job")
 	}
@@ -487,10 +734,15 @@ func ThresholdRefresh(_ context.Context, j *cbmpc.JobMP, params *ThresholdRefres
 		return nil, errors.New("empty quorum party indices")
 	}
 
-	ptr, err := j.Ptr()
+	ptr, release, err := j.Acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	op := operation("schnorrmp.ThresholdRefresh", params.Key, nil)
+	done := j.BeginOperation(ctx, op)
+	defer done(&err)
 
 	curve, err := params.Key.Curve()
 	if err != nil {
@@ -509,8 +761,25 @@ func ThresholdRefresh(_ context.Context, j *cbmpc.JobMP, params *ThresholdRefres
 	runtime.KeepAlive(j)
 	runtime.KeepAlive(params.Key)
 
+	refreshed := newKey(newKeyCkey)
+	refreshed.quorum = params.Key.quorum
+	refreshed.structure = append(ac.AccessStructure(nil), params.AccessStructure...)
+
+	if err := cbmpc.TrackInScope(ctx, refreshed); err != nil {
+		return nil, err
+	}
+
 	return &ThresholdRefreshResult{
-		NewKey:    newKey(newKeyCkey),
+		NewKey:    refreshed,
 		SessionID: cbmpc.NewSessionID(newSid),
 	}, nil
 }
+
+// operation describes an invocation for the job's hooks and sign policy. The
+// key has already been validated, so lookup errors only leave fields empty.
+func operation(protocol string, key *Key, messages [][]byte) *cbmpc.Operation {
+	op := &cbmpc.Operation{Protocol: protocol, Messages: messages}
+	op.Curve, _ = key.Curve()
+	op.PublicKey, _ = key.PublicKey()
+	return op
+}
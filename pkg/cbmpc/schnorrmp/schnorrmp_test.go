@@ -66,6 +66,9 @@ func TestSchnorrMPSignEdDSA(t *testing.T) {
 			t.Fatalf("Party %d key is nil", i)
 		}
 		defer func() { _ = keys[i].Close() }()
+		if err := keys[i].Validate(); err != nil {
+			t.Fatalf("Party %d key failed validation: %v", i, err)
+		}
 	}
 
 	// Verify all parties have the same public key
@@ -4,6 +4,7 @@ package schnorrmp_test
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/hex"
 	"sync"
 	"testing"
@@ -626,3 +627,107 @@ func abbrevHex(data []byte) string {
 	}
 	return hex.EncodeToString(data[:2]) + "..." + hex.EncodeToString(data[len(data)-2:])
 }
+
+// TestSchnorrMPDKGThreshold generates a 3-of-4 threshold key with
+// DKGParams.Threshold, signs with three of the four parties, and checks that
+// Sign refuses a smaller job.
+func TestSchnorrMPDKGThreshold(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	nParties := 4
+	roles := make([]cbmpc.RoleID, nParties)
+	names := make([]string, nParties)
+	for i := 0; i < nParties; i++ {
+		roles[i] = cbmpc.RoleID(i)
+		names[i] = "p" + string(rune('0'+i))
+	}
+
+	var wg sync.WaitGroup
+	keys := make([]*schnorrmp.Key, nParties)
+	errors := make([]error, nParties)
+	for i := 0; i < nParties; i++ {
+		wg.Add(1)
+		go func(partyID int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJobMP(net.EpMP(roles[partyID], roles), roles[partyID], names)
+			if err != nil {
+				errors[partyID] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			result, err := schnorrmp.DKG(ctx, job, &schnorrmp.DKGParams{Curve: cbmpc.CurveEd25519, Threshold: 3})
+			if err == nil {
+				keys[partyID] = result.Key
+			}
+			errors[partyID] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errors {
+		if err != nil {
+			t.Fatalf("Party %d threshold DKG failed: %v", i, err)
+		}
+	}
+	defer func() {
+		for _, key := range keys {
+			_ = key.Close()
+		}
+	}()
+	if q := keys[0].Quorum(); q != 3 {
+		t.Fatalf("Quorum() = %d, want 3", q)
+	}
+	pub, err := keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// p0, p1 and p3 sign on a job of their own.
+	signers := []int{0, 1, 3}
+	signerNames := make([]string, len(signers))
+	signerRoles := make([]cbmpc.RoleID, len(signers))
+	for i, p := range signers {
+		signerNames[i] = names[p]
+		signerRoles[i] = cbmpc.RoleID(i)
+	}
+	message := []byte("3-of-4")
+	signNet := mocknet.New()
+	sigs := make([][]byte, len(signers))
+	for i, p := range signers {
+		wg.Add(1)
+		go func(i, p int) {
+			defer wg.Done()
+			job, err := cbmpc.NewJobMP(signNet.EpMP(signerRoles[i], signerRoles), signerRoles[i], signerNames)
+			if err != nil {
+				errors[i] = err
+				return
+			}
+			defer func() { _ = job.Close() }()
+			result, err := schnorrmp.Sign(ctx, job, &schnorrmp.SignParams{Key: keys[p], Message: message, SigReceiver: 0, Variant: schnorrmp.VariantEdDSA})
+			if err == nil {
+				sigs[i] = result.Signature
+			}
+			errors[i] = err
+		}(i, p)
+	}
+	wg.Wait()
+	for i := range signers {
+		if errors[i] != nil {
+			t.Fatalf("Party %s Sign failed: %v", signerNames[i], errors[i])
+		}
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pub), message, sigs[0]) {
+		t.Fatal("3-of-4 signature does not verify against the DKG public key")
+	}
+
+	pair := []cbmpc.RoleID{0, 1}
+	small, err := cbmpc.NewJobMP(mocknet.New().EpMP(0, pair), 0, names[:2])
+	if err != nil {
+		t.Fatalf("NewJobMP: %v", err)
+	}
+	defer func() { _ = small.Close() }()
+	if _, err := schnorrmp.Sign(ctx, small, &schnorrmp.SignParams{Key: keys[0], Message: []byte("msg"), Variant: schnorrmp.VariantEdDSA}); err == nil {
+		t.Fatal("Sign accepted a job smaller than the key's quorum")
+	}
+}
@@ -0,0 +1,210 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// ErrScopeClosed is returned by ResourceScope.Track after the scope has been
+// closed or its context is done. The object passed to Track has already been
+// freed when it is returned.
+var ErrScopeClosed = errors.New("resource scope closed")
+
+// ResourceScope owns a set of native objects (points, scalars, commitments,
+// keys, jobs) and frees all of them together. It is meant for request-scoped
+// code such as server handlers, where a single missed defer otherwise leaks
+// C++ memory until the finalizer happens to run:
+//
+//	scope, ctx := cbmpc.NewResourceScope(r.Context())
+//	defer scope.Close()
+//
+//	res, err := ecdsa2p.DKG(ctx, job, params) // res.Key is owned by scope
+//	if err != nil {
+//	    return err
+//	}
+//
+// Protocols that return keys register them with the scope carried by their
+// context; other objects are added with Track. Any object with a Close()
+// error, Close() or Free() method can be tracked. Objects are freed in
+// reverse order of tracking, and only by Close: when the scope's context is
+// done the scope stops accepting objects, but what it already owns stays
+// valid until Close, since the handler may still be using it.
+//
+// A ResourceScope is safe for concurrent use.
+type ResourceScope struct {
+	ctx    context.Context
+	mu     sync.Mutex
+	items  []scopeItem
+	closed bool
+}
+
+type scopeItem struct {
+	obj     any
+	release func() error
+}
+
+type scopeKey struct{}
+
+// NewResourceScope creates a scope bound to ctx and returns a derived context
+// carrying it, retrievable with ScopeFromContext. Once ctx is done, Track
+// refuses new objects; Close must still be called to free the tracked ones.
+func NewResourceScope(ctx context.Context) (*ResourceScope, context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	s := &ResourceScope{ctx: ctx}
+	return s, context.WithValue(ctx, scopeKey{}, s)
+}
+
+// ScopeFromContext returns the scope attached to ctx by NewResourceScope, or
+// nil if there is none.
+func ScopeFromContext(ctx context.Context) *ResourceScope {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(scopeKey{}).(*ResourceScope)
+	return s
+}
+
+// TrackInScope adds r to the scope carried by ctx, if any, and does nothing
+// otherwise. Constructors that take a context call it on the objects they
+// return. If the scope no longer accepts objects, r is freed and
+// ErrScopeClosed is returned.
+func TrackInScope(ctx context.Context, r any) error {
+	s := ScopeFromContext(ctx)
+	if s == nil {
+		return nil
+	}
+	return s.Track(r)
+}
+
+// Track adds r to the scope so that it is freed on Close. r must have a
+// Close() error, Close() or Free() method. If the scope is already closed or
+// its context is done, r is freed immediately and ErrScopeClosed is returned.
+func (s *ResourceScope) Track(r any) error {
+	if s == nil {
+		return errors.New("nil resource scope")
+	}
+	release, err := releaser(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.closed || s.ctx.Err() != nil {
+		s.mu.Unlock()
+		_ = release()
+		return ErrScopeClosed
+	}
+	s.items = append(s.items, scopeItem{obj: r, release: release})
+	s.mu.Unlock()
+	return nil
+}
+
+// Untrack removes r from the scope without freeing it, for an object that
+// must outlive the scope, such as a key created in a request and kept in a
+// cache. The caller becomes responsible for freeing r. Untrack reports
+// whether r was tracked.
+func (s *ResourceScope) Untrack(r any) bool {
+	if s == nil || r == nil || !reflect.TypeOf(r).Comparable() {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.items) - 1; i >= 0; i-- {
+		if s.items[i].obj == r {
+			s.items = slices.Delete(s.items, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+// Keep tracks the result of a constructor in s, folding the constructor's
+// error check and Track into one step:
+//
+//	pt, err := curve.NewPointFromBytes(c, data)
+//	if pt, err = cbmpc.Keep(scope, pt, err); err != nil {
+//	    return err
+//	}
+//
+// If err is non-nil, v is returned untracked along with err. If tracking
+// fails, v has been freed (or was never freeable) and the zero T is returned
+// with the error.
+func Keep[T any](s *ResourceScope, v T, err error) (T, error) {
+	if err != nil {
+		return v, err
+	}
+	if err := s.Track(v); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// Len returns the number of objects currently owned by the scope. It is zero
+// once the scope is closed.
+func (s *ResourceScope) Len() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// Closed reports whether the scope has been closed.
+func (s *ResourceScope) Closed() bool {
+	if s == nil {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// Close frees every tracked object in reverse order of tracking and returns
+// the joined errors from Close() error methods. Closing an already closed
+// scope is a no-op that returns nil.
+func (s *ResourceScope) Close() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	items := s.items
+	s.items = nil
+	s.mu.Unlock()
+
+	var errs []error
+	for i := len(items) - 1; i >= 0; i-- {
+		if err := items[i].release(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// releaser adapts the cleanup method of r to a uniform signature.
+func releaser(r any) (func() error, error) {
+	switch v := r.(type) {
+	case nil:
+		return nil, errors.New("nil resource")
+	case interface{ Close() error }:
+		return v.Close, nil
+	case interface{ Close() }:
+		return func() error { v.Close(); return nil }, nil
+	case interface{ Free() }:
+		return func() error { v.Free(); return nil }, nil
+	default:
+		return nil, fmt.Errorf("type %T has no Close or Free method", r)
+	}
+}
@@ -0,0 +1,171 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeResource struct {
+	name  string
+	order *[]string
+	err   error
+}
+
+func (f *fakeResource) Close() error {
+	*f.order = append(*f.order, f.name)
+	return f.err
+}
+
+type fakeFreer struct{ freed bool }
+
+func (f *fakeFreer) Free() { f.freed = true }
+
+func TestResourceScopeClose(t *testing.T) {
+	scope, _ := NewResourceScope(context.Background())
+
+	var order []string
+	boom := errors.New("boom")
+	freer := &fakeFreer{}
+	for _, r := range []any{
+		&fakeResource{name: "a", order: &order},
+		freer,
+		&fakeResource{name: "b", order: &order, err: boom},
+	} {
+		if err := scope.Track(r); err != nil {
+			t.Fatalf("Track: %v", err)
+		}
+	}
+	if got := scope.Len(); got != 3 {
+		t.Fatalf("Len = %d, want 3", got)
+	}
+
+	if err := scope.Close(); !errors.Is(err, boom) {
+		t.Fatalf("Close error = %v, want %v", err, boom)
+	}
+	if len(order) != 2 || order[0] != "b" || order[1] != "a" {
+		t.Fatalf("close order = %v, want [b a]", order)
+	}
+	if !freer.freed {
+		t.Fatal("Free was not called")
+	}
+	if !scope.Closed() || scope.Len() != 0 {
+		t.Fatal("scope should be closed and empty")
+	}
+	if err := scope.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+}
+
+func TestResourceScopeTrackAfterClose(t *testing.T) {
+	scope, _ := NewResourceScope(context.Background())
+	_ = scope.Close()
+
+	freer := &fakeFreer{}
+	if err := scope.Track(freer); !errors.Is(err, ErrScopeClosed) {
+		t.Fatalf("Track after close = %v, want ErrScopeClosed", err)
+	}
+	if !freer.freed {
+		t.Fatal("resource tracked after close should be freed immediately")
+	}
+}
+
+func TestResourceScopeTrackInvalid(t *testing.T) {
+	scope, _ := NewResourceScope(context.Background())
+	defer scope.Close()
+
+	if err := scope.Track(nil); err == nil {
+		t.Fatal("expected error for nil resource")
+	}
+	if err := scope.Track(42); err == nil {
+		t.Fatal("expected error for type without Close or Free")
+	}
+	var nilScope *ResourceScope
+	if err := nilScope.Track(&fakeFreer{}); err == nil {
+		t.Fatal("expected error for nil scope")
+	}
+}
+
+func TestKeep(t *testing.T) {
+	scope, _ := NewResourceScope(context.Background())
+	f, err := Keep(scope, &fakeFreer{}, nil)
+	if err != nil || f == nil {
+		t.Fatalf("Keep = %v, %v", f, err)
+	}
+	if scope.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", scope.Len())
+	}
+
+	boom := errors.New("boom")
+	if _, err := Keep(scope, (*fakeFreer)(nil), boom); err != boom {
+		t.Fatalf("Keep passed through %v, want %v", err, boom)
+	}
+	if scope.Len() != 1 {
+		t.Fatal("failed constructor result was tracked")
+	}
+
+	_ = scope.Close()
+	if !f.freed {
+		t.Fatal("kept resource not freed on Close")
+	}
+	late, err := Keep(scope, &fakeFreer{}, nil)
+	if !errors.Is(err, ErrScopeClosed) || late != nil {
+		t.Fatalf("Keep after close = %v, %v", late, err)
+	}
+}
+
+func TestResourceScopeContextCancel(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	scope, ctx := NewResourceScope(parent)
+	if ScopeFromContext(ctx) != scope {
+		t.Fatal("ScopeFromContext did not return the scope")
+	}
+	if ScopeFromContext(context.Background()) != nil {
+		t.Fatal("expected nil scope for plain context")
+	}
+
+	live := &fakeFreer{}
+	if err := scope.Track(live); err != nil {
+		t.Fatalf("Track: %v", err)
+	}
+	cancel()
+
+	late := &fakeFreer{}
+	if err := scope.Track(late); !errors.Is(err, ErrScopeClosed) {
+		t.Fatalf("Track after cancel = %v, want ErrScopeClosed", err)
+	}
+	if !late.freed {
+		t.Fatal("resource refused after cancel should be freed")
+	}
+	if live.freed || scope.Closed() || scope.Len() != 1 {
+		t.Fatal("cancellation must not free tracked resources")
+	}
+	_ = scope.Close()
+	if !live.freed {
+		t.Fatal("tracked resource not freed on Close")
+	}
+}
+
+func TestTrackInScope(t *testing.T) {
+	if err := TrackInScope(context.Background(), &fakeFreer{}); err != nil {
+		t.Fatalf("TrackInScope without scope: %v", err)
+	}
+
+	scope, ctx := NewResourceScope(context.Background())
+	kept, owned := &fakeFreer{}, &fakeFreer{}
+	for _, f := range []*fakeFreer{kept, owned} {
+		if err := TrackInScope(ctx, f); err != nil {
+			t.Fatalf("TrackInScope: %v", err)
+		}
+	}
+	if !scope.Untrack(kept) {
+		t.Fatal("Untrack did not find a tracked resource")
+	}
+	if scope.Untrack(kept) || scope.Untrack(&fakeFreer{}) {
+		t.Fatal("Untrack found an untracked resource")
+	}
+	_ = scope.Close()
+	if kept.freed || !owned.freed {
+		t.Fatalf("after Close: kept freed = %v, owned freed = %v", kept.freed, owned.freed)
+	}
+}
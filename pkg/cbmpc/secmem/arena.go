@@ -0,0 +1,190 @@
+package secmem
+
+import (
+	"errors"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrArenaFull is returned by Arena.New when no free region is large enough.
+var ErrArenaFull = errors.New("secmem: arena full")
+
+// arenaAlign is the granularity of arena slots.
+const arenaAlign = 16
+
+type span struct{ off, n int }
+
+// Arena is one locked mapping from which many Buffers are carved.
+//
+// Every standalone Buffer occupies at least one page of locked memory, and
+// the default RLIMIT_MEMLOCK on Linux is often 64 KiB, so a process holding a
+// few dozen key shares exhausts it and later buffers silently go unlocked. An
+// Arena locks and excludes from core dumps a single region once, and hands out
+// Buffers from it:
+//
+//	arena, err := secmem.NewArena(64 << 10)
+//	if err != nil {
+//	    return err
+//	}
+//	defer arena.Destroy()
+//	secmem.SetArena(arena) // ProtectedBytes and friends now allocate here
+//
+// Destroying a Buffer wipes its slot and returns it to the arena. Destroying
+// the arena wipes and unmaps everything; its Buffers then report nil Bytes.
+// An Arena is safe for concurrent use.
+type Arena struct {
+	mu        sync.Mutex
+	mem       []byte
+	mapped    bool
+	locked    bool
+	nodump    bool
+	free      []span // sorted by offset, coalesced
+	destroyed atomic.Bool
+}
+
+// NewArena maps and locks an arena of at least size bytes.
+func NewArena(size int) (*Arena, error) {
+	if size <= 0 {
+		return nil, errors.New("secmem: arena size must be positive")
+	}
+	mem, mapped, err := alloc(size)
+	if err != nil {
+		return nil, err
+	}
+	a := &Arena{mem: mem, mapped: mapped, free: []span{{0, len(mem)}}}
+	a.locked = lock(mem)
+	if mapped {
+		a.nodump = nodump(mem)
+	}
+	runtime.SetFinalizer(a, (*Arena).Destroy)
+	return a, nil
+}
+
+// New returns a zero-filled Buffer of n bytes carved from the arena.
+func (a *Arena) New(n int) (*Buffer, error) {
+	if n < 0 {
+		return nil, errors.New("secmem: negative size")
+	}
+	size := max((n+arenaAlign-1)/arenaAlign*arenaAlign, arenaAlign)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.destroyed.Load() {
+		return nil, ErrDestroyed
+	}
+	for i, f := range a.free {
+		if f.n < size {
+			continue
+		}
+		slot := span{f.off, size}
+		if f.n == size {
+			a.free = append(a.free[:i], a.free[i+1:]...)
+		} else {
+			a.free[i] = span{f.off + size, f.n - size}
+		}
+		b := &Buffer{
+			mem:    a.mem[slot.off : slot.off+size : slot.off+size],
+			n:      n,
+			locked: a.locked,
+			nodump: a.nodump,
+			arena:  a,
+			slot:   slot,
+		}
+		runtime.SetFinalizer(b, (*Buffer).Destroy)
+		return b, nil
+	}
+	return nil, ErrArenaFull
+}
+
+// Copy returns a Buffer in the arena holding a copy of src.
+func (a *Arena) Copy(src []byte) (*Buffer, error) {
+	b, err := a.New(len(src))
+	if err != nil {
+		return nil, err
+	}
+	copy(b.mem, src)
+	return b, nil
+}
+
+// Move returns a Buffer in the arena holding the contents of src and zeroes
+// src.
+func (a *Arena) Move(src []byte) (*Buffer, error) {
+	b, err := a.Copy(src)
+	Zero(src)
+	return b, err
+}
+
+// release wipes a slot and returns it to the free list.
+func (a *Arena) release(s span) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.destroyed.Load() {
+		return
+	}
+	Zero(a.mem[s.off : s.off+s.n])
+	i := sort.Search(len(a.free), func(i int) bool { return a.free[i].off > s.off })
+	a.free = append(a.free, span{})
+	copy(a.free[i+1:], a.free[i:])
+	a.free[i] = s
+	// Coalesce with the following and preceding free spans.
+	if i+1 < len(a.free) && a.free[i].off+a.free[i].n == a.free[i+1].off {
+		a.free[i].n += a.free[i+1].n
+		a.free = append(a.free[:i+1], a.free[i+2:]...)
+	}
+	if i > 0 && a.free[i-1].off+a.free[i-1].n == a.free[i].off {
+		a.free[i-1].n += a.free[i].n
+		a.free = append(a.free[:i], a.free[i+1:]...)
+	}
+}
+
+// Available returns the number of free bytes, which may be fragmented.
+func (a *Arena) Available() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	n := 0
+	for _, f := range a.free {
+		n += f.n
+	}
+	return n
+}
+
+// Locked reports whether the arena memory is locked against swapping.
+func (a *Arena) Locked() bool { return a.locked }
+
+// NoDump reports whether the arena memory is excluded from core dumps.
+func (a *Arena) NoDump() bool { return a.nodump }
+
+// Destroy wipes and releases the arena and every Buffer carved from it. It is
+// safe to call more than once.
+func (a *Arena) Destroy() {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.destroyed.Swap(true) {
+		return
+	}
+	runtime.SetFinalizer(a, nil)
+	defaultArena.CompareAndSwap(a, nil)
+	Zero(a.mem)
+	if a.locked {
+		unlock(a.mem)
+	}
+	free(a.mem, a.mapped)
+	a.mem = nil
+	a.free = nil
+}
+
+var defaultArena atomic.Pointer[Arena]
+
+// SetArena makes New, Copy and Move, and through them every ProtectedBytes
+// export, allocate from a. When a is full they fall back to a dedicated
+// mapping. SetArena(nil) restores dedicated mappings. Destroying the arena
+// also unsets it.
+func SetArena(a *Arena) { defaultArena.Store(a) }
+
+// DefaultArena returns the arena set by SetArena, or nil.
+func DefaultArena() *Arena { return defaultArena.Load() }
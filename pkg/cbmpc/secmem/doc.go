@@ -0,0 +1,38 @@
+// Package secmem provides protected buffers and helpers for secret material.
+//
+// cbmpc.ZeroizeBytes wipes a slice once the caller is done with it, but the
+// secret still lives on the Go heap until then: it can be swapped to disk,
+// copied by the runtime, or left behind by a missed defer. A Buffer instead
+// holds the secret in its own memory mapping, locked against swapping where
+// the OS allows it, and wiped and unmapped by Destroy (or by a finalizer if
+// Destroy is forgotten):
+//
+//	buf, err := key.ProtectedBytes()
+//	if err != nil {
+//	    return err
+//	}
+//	defer buf.Destroy()
+//	ciphertext := seal(buf.Bytes())
+//
+// Move copies a secret into a Buffer and wipes the source, so that only the
+// protected copy remains. Equal compares in constant time.
+//
+// # Strict Mode
+//
+// Key types in the protocol packages (ecdsa2p, ecdsamp, schnorr2p,
+// schnorrmp), curve.Scalar and the RSA KEM offer ProtectedBytes or
+// GenerateProtected next to their plain []byte exports. SetStrict(true) makes
+// the plain exports fail with ErrUnprotected, so that a deployment can require
+// every secret to leave the library in a Buffer. Set it once at startup.
+//
+// Memory locking is best effort. Locked reports whether it succeeded; raise
+// RLIMIT_MEMLOCK if it does not. On Linux the memory is also excluded from
+// core dumps (MADV_DONTDUMP), which NoDump reports. On platforms without mmap,
+// buffers live on the Go heap and are only wiped.
+//
+// # Arenas
+//
+// Each Buffer locks at least a page, so services holding many shares should
+// create one Arena at startup and install it with SetArena. Buffers are then
+// carved from that single locked, non-dumpable region.
+package secmem
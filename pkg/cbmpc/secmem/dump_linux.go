@@ -0,0 +1,12 @@
+package secmem
+
+import "syscall"
+
+// madvDontDump is MADV_DONTDUMP from <sys/mman.h>; package syscall does not
+// define it.
+const madvDontDump = 0x10
+
+// nodump excludes mem from core dumps.
+func nodump(mem []byte) bool {
+	return syscall.Madvise(mem, madvDontDump) == nil
+}
@@ -0,0 +1,13 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package secmem
+
+// On other platforms buffers live on the Go heap and are not locked. They are
+// still wiped on Destroy.
+func alloc(n int) ([]byte, bool, error) { return make([]byte, n), false, nil }
+
+func free([]byte, bool) {}
+
+func lock([]byte) bool { return false }
+
+func unlock([]byte) {}
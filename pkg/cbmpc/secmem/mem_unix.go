@@ -0,0 +1,41 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package secmem
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// alloc maps fresh anonymous pages so secrets never share a page with other
+// heap objects and the Go runtime never copies them.
+func alloc(n int) ([]byte, bool, error) {
+	if n == 0 {
+		return []byte{}, false, nil
+	}
+	page := os.Getpagesize()
+	size := (n + page - 1) / page * page
+	mem, err := syscall.Mmap(-1, 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return nil, false, fmt.Errorf("secmem: mmap: %w", err)
+	}
+	return mem, true, nil
+}
+
+func free(mem []byte, mapped bool) {
+	if mapped {
+		_ = syscall.Munmap(mem)
+	}
+}
+
+func lock(mem []byte) bool {
+	if len(mem) == 0 {
+		return false
+	}
+	return syscall.Mlock(mem) == nil
+}
+
+func unlock(mem []byte) {
+	_ = syscall.Munlock(mem)
+}
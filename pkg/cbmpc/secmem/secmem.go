@@ -0,0 +1,194 @@
+package secmem
+
+import (
+	"crypto/subtle"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrUnprotected is returned by raw secret exports such as Key.Bytes when
+// strict mode is enabled. Use the corresponding ProtectedBytes method instead.
+var ErrUnprotected = errors.New("secmem: unprotected export of secret material disabled; use ProtectedBytes")
+
+// ErrDestroyed is returned when a destroyed Buffer is used.
+var ErrDestroyed = errors.New("secmem: buffer destroyed")
+
+var strict atomic.Bool
+
+// SetStrict enables or disables strict mode. In strict mode every API that
+// would hand secret material back as a plain []byte (key Bytes, KEM Generate)
+// fails with ErrUnprotected, so all secrets leave the library in Buffers.
+// Strict mode is off by default and is meant to be set once at startup.
+func SetStrict(on bool) { strict.Store(on) }
+
+// Strict reports whether strict mode is enabled.
+func Strict() bool { return strict.Load() }
+
+// Buffer holds secret bytes outside the Go heap where the platform allows it.
+// The memory is locked against swapping when possible and wiped on Destroy.
+// A Buffer must not be copied.
+//
+// Bytes returns a view of the memory, not a copy. The view must not be used
+// after Destroy; on platforms where the memory is unmapped, doing so crashes
+// the process rather than reading stale secrets.
+type Buffer struct {
+	mu        sync.RWMutex
+	mem       []byte // whole allocation, page-rounded where mapped
+	n         int
+	locked    bool
+	nodump    bool
+	mapped    bool
+	destroyed bool
+
+	arena *Arena // non-nil when mem is a slot carved from an Arena
+	slot  span
+}
+
+// New returns a zero-filled Buffer of n bytes. When a default arena is set
+// (see SetArena) and has room, the buffer is carved from it; otherwise it gets
+// its own mapping.
+func New(n int) (*Buffer, error) {
+	if n < 0 {
+		return nil, errors.New("secmem: negative size")
+	}
+	if a := defaultArena.Load(); a != nil {
+		if b, err := a.New(n); err == nil {
+			return b, nil
+		}
+	}
+	mem, mapped, err := alloc(n)
+	if err != nil {
+		return nil, err
+	}
+	b := &Buffer{mem: mem, n: n, mapped: mapped}
+	b.locked = lock(mem)
+	if mapped {
+		b.nodump = nodump(mem)
+	}
+	runtime.SetFinalizer(b, (*Buffer).Destroy)
+	return b, nil
+}
+
+// Copy returns a Buffer holding a copy of src. src is left unchanged.
+func Copy(src []byte) (*Buffer, error) {
+	b, err := New(len(src))
+	if err != nil {
+		return nil, err
+	}
+	copy(b.mem, src)
+	return b, nil
+}
+
+// Move returns a Buffer holding the contents of src and zeroes src, so the
+// secret exists only in protected memory afterwards.
+func Move(src []byte) (*Buffer, error) {
+	b, err := Copy(src)
+	Zero(src)
+	return b, err
+}
+
+// Bytes returns the buffer contents. It returns nil after Destroy.
+func (b *Buffer) Bytes() []byte {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.destroyed || (b.arena != nil && b.arena.destroyed.Load()) {
+		return nil
+	}
+	return b.mem[:b.n:b.n]
+}
+
+// Len returns the size of the secret in bytes.
+func (b *Buffer) Len() int {
+	if b == nil {
+		return 0
+	}
+	return b.n
+}
+
+// Locked reports whether the memory is locked against swapping. Locking is
+// best effort: it fails, for example, when RLIMIT_MEMLOCK is exhausted.
+func (b *Buffer) Locked() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.locked
+}
+
+// NoDump reports whether the memory is excluded from core dumps. This is
+// supported on Linux only.
+func (b *Buffer) NoDump() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.nodump
+}
+
+// Destroyed reports whether Destroy has been called.
+func (b *Buffer) Destroyed() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.destroyed
+}
+
+// Equal reports in constant time whether b and other hold the same bytes.
+// Destroyed buffers are never equal.
+func (b *Buffer) Equal(other *Buffer) bool {
+	x, y := b.Bytes(), other.Bytes()
+	if x == nil || y == nil {
+		return false
+	}
+	return Equal(x, y)
+}
+
+// Destroy wipes and releases the buffer. It is safe to call more than once.
+func (b *Buffer) Destroy() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.destroyed {
+		return
+	}
+	b.destroyed = true
+	runtime.SetFinalizer(b, nil)
+	if b.arena != nil {
+		b.arena.release(b.slot)
+		b.mem = nil
+		b.locked = false
+		return
+	}
+	Zero(b.mem)
+	if b.locked {
+		unlock(b.mem)
+	}
+	free(b.mem, b.mapped)
+	b.mem = nil
+	b.locked = false
+}
+
+// Zero overwrites buf with zeros in a way the compiler cannot elide.
+func Zero(buf []byte) {
+	for i := range buf {
+		buf[i] = 0
+	}
+	runtime.KeepAlive(buf)
+}
+
+// Equal reports in constant time whether a and b are equal. The time taken
+// depends on the lengths but not on the contents.
+func Equal(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
@@ -0,0 +1,205 @@
+package secmem_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
+)
+
+func TestMoveWipesSource(t *testing.T) {
+	src := []byte("super secret key share")
+	want := append([]byte(nil), src...)
+
+	buf, err := secmem.Move(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buf.Destroy()
+
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("buffer = %q, want %q", buf.Bytes(), want)
+	}
+	if buf.Len() != len(want) {
+		t.Fatalf("Len = %d, want %d", buf.Len(), len(want))
+	}
+	if !bytes.Equal(src, make([]byte, len(src))) {
+		t.Fatal("Move did not wipe the source")
+	}
+}
+
+func TestCopyLeavesSource(t *testing.T) {
+	src := []byte{1, 2, 3}
+	buf, err := secmem.Copy(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buf.Destroy()
+	if !bytes.Equal(src, []byte{1, 2, 3}) {
+		t.Fatal("Copy modified the source")
+	}
+	buf.Bytes()[0] = 9
+	if src[0] != 1 {
+		t.Fatal("buffer aliases the source")
+	}
+}
+
+func TestDestroy(t *testing.T) {
+	buf, err := secmem.Copy([]byte("x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Destroy()
+	buf.Destroy()
+	if !buf.Destroyed() || buf.Bytes() != nil || buf.Locked() {
+		t.Fatal("destroyed buffer still exposes memory")
+	}
+	if buf.Equal(buf) {
+		t.Fatal("destroyed buffers must not compare equal")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a, _ := secmem.Copy([]byte("abc"))
+	b, _ := secmem.Copy([]byte("abc"))
+	c, _ := secmem.Copy([]byte("abd"))
+	defer a.Destroy()
+	defer b.Destroy()
+	defer c.Destroy()
+
+	if !a.Equal(b) || a.Equal(c) {
+		t.Fatal("Buffer.Equal mismatch")
+	}
+	if !secmem.Equal([]byte("x"), []byte("x")) || secmem.Equal([]byte("x"), []byte("xy")) {
+		t.Fatal("Equal mismatch")
+	}
+}
+
+func TestEmptyAndLarge(t *testing.T) {
+	empty, err := secmem.New(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty.Len() != 0 || len(empty.Bytes()) != 0 {
+		t.Fatal("empty buffer not empty")
+	}
+	empty.Destroy()
+
+	big, err := secmem.New(3*4096 + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer big.Destroy()
+	if len(big.Bytes()) != 3*4096+1 || cap(big.Bytes()) != 3*4096+1 {
+		t.Fatal("view must be limited to the requested size")
+	}
+
+	if _, err := secmem.New(-1); err == nil {
+		t.Fatal("negative size should fail")
+	}
+}
+
+func TestStrict(t *testing.T) {
+	defer secmem.SetStrict(false)
+	if secmem.Strict() {
+		t.Fatal("strict mode must be off by default")
+	}
+	secmem.SetStrict(true)
+	if !secmem.Strict() {
+		t.Fatal("SetStrict(true) not observed")
+	}
+}
+
+func TestArena(t *testing.T) {
+	arena, err := secmem.NewArena(4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer arena.Destroy()
+	total := arena.Available()
+
+	a, err := arena.Copy([]byte("share a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := []byte("share b")
+	b, err := arena.Move(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(a.Bytes()) != "share a" || string(b.Bytes()) != "share b" {
+		t.Fatalf("arena buffers = %q, %q", a.Bytes(), b.Bytes())
+	}
+	if !bytes.Equal(src, make([]byte, len(src))) {
+		t.Fatal("Move did not wipe the source")
+	}
+	if a.Locked() != arena.Locked() || a.NoDump() != arena.NoDump() {
+		t.Fatal("buffer does not report the arena's protection")
+	}
+
+	view := a.Bytes()
+	a.Destroy()
+	b.Destroy()
+	if arena.Available() != total {
+		t.Fatalf("Available = %d after freeing everything, want %d", arena.Available(), total)
+	}
+	if !bytes.Equal(view, make([]byte, len(view))) {
+		t.Fatal("destroyed slot not wiped")
+	}
+
+	if _, err := arena.New(total + 1); err != secmem.ErrArenaFull {
+		t.Fatalf("oversized New = %v, want ErrArenaFull", err)
+	}
+	whole, err := arena.New(total)
+	if err != nil {
+		t.Fatalf("coalesced arena cannot satisfy a full-size request: %v", err)
+	}
+	whole.Destroy()
+}
+
+func TestArenaDestroy(t *testing.T) {
+	arena, err := secmem.NewArena(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, err := arena.Copy([]byte("x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	arena.Destroy()
+	arena.Destroy()
+	if buf.Bytes() != nil {
+		t.Fatal("buffer still readable after its arena was destroyed")
+	}
+	buf.Destroy()
+	if _, err := arena.New(1); err != secmem.ErrDestroyed {
+		t.Fatalf("New on destroyed arena = %v", err)
+	}
+}
+
+func TestSetArena(t *testing.T) {
+	arena, err := secmem.NewArena(64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secmem.SetArena(arena)
+	defer secmem.SetArena(nil)
+	defer arena.Destroy()
+
+	before := arena.Available()
+	buf, err := secmem.Copy([]byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buf.Destroy()
+	if arena.Available() >= before {
+		t.Fatal("Copy did not allocate from the default arena")
+	}
+
+	// A request the arena cannot hold falls back to a dedicated mapping.
+	big, err := secmem.New(8192)
+	if err != nil {
+		t.Fatal(err)
+	}
+	big.Destroy()
+}
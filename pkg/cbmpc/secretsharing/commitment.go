@@ -0,0 +1,151 @@
+package secretsharing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+)
+
+// Commitment is a Feldman commitment to a dealing polynomial: one point
+// A_k = a_k*G per coefficient. A_0 is the public key of the shared secret.
+//
+// Commitment owns native point resources and must be freed with Free().
+type Commitment struct {
+	curve  curve.Curve
+	points []*curve.Point
+}
+
+// commit computes A_k = coeffs[k]*G for every coefficient.
+func commit(c curve.Curve, coeffs []*curve.Scalar) (*Commitment, error) {
+	cm := &Commitment{curve: c, points: make([]*curve.Point, 0, len(coeffs))}
+	for _, a := range coeffs {
+		p, err := curve.MulGenerator(c, a)
+		if err != nil {
+			cm.Free()
+			return nil, err
+		}
+		cm.points = append(cm.points, p)
+	}
+	return cm, nil
+}
+
+// LoadCommitment reconstructs a Commitment from the compressed points
+// returned by Bytes.
+func LoadCommitment(c curve.Curve, points [][]byte) (*Commitment, error) {
+	if len(points) == 0 {
+		return nil, errors.New("empty commitment")
+	}
+	cm := &Commitment{curve: c, points: make([]*curve.Point, 0, len(points))}
+	for i, b := range points {
+		p, err := curve.NewPointFromBytes(c, b)
+		if err != nil {
+			cm.Free()
+			return nil, fmt.Errorf("commitment point %d: %w", i, err)
+		}
+		cm.points = append(cm.points, p)
+	}
+	return cm, nil
+}
+
+// Curve returns the curve the commitment is defined over.
+func (cm *Commitment) Curve() curve.Curve {
+	if cm == nil {
+		return curve.Unknown
+	}
+	return cm.curve
+}
+
+// Threshold returns the reconstruction threshold (number of coefficients).
+func (cm *Commitment) Threshold() int {
+	if cm == nil {
+		return 0
+	}
+	return len(cm.points)
+}
+
+// PublicKey returns a copy of A_0 = secret*G.
+// The returned Point must be freed with Free() when no longer needed.
+func (cm *Commitment) PublicKey() (*curve.Point, error) {
+	if cm == nil || len(cm.points) == 0 {
+		return nil, errors.New("nil commitment")
+	}
+	return clonePoint(cm.curve, cm.points[0])
+}
+
+// PublicShare returns f(index)*G, the public counterpart of the share dealt to
+// index. The returned Point must be freed with Free() when no longer needed.
+func (cm *Commitment) PublicShare(index int) (*curve.Point, error) {
+	if cm == nil || len(cm.points) == 0 {
+		return nil, errors.New("nil commitment")
+	}
+	if index < 1 {
+		return nil, fmt.Errorf("index must be >= 1 (got %d)", index)
+	}
+	return cm.eval(index)
+}
+
+// Bytes serializes each commitment point in compressed form, ordered by
+// coefficient degree.
+func (cm *Commitment) Bytes() ([][]byte, error) {
+	if cm == nil || len(cm.points) == 0 {
+		return nil, errors.New("nil commitment")
+	}
+	out := make([][]byte, len(cm.points))
+	for i, p := range cm.points {
+		b, err := p.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+// Free releases the native point resources.
+func (cm *Commitment) Free() {
+	if cm == nil {
+		return
+	}
+	for _, p := range cm.points {
+		p.Free()
+	}
+	cm.points = nil
+}
+
+// eval computes sum_k A_k * x^k using Horner's rule.
+func (cm *Commitment) eval(x int) (*curve.Point, error) {
+	xs, err := indexScalar(x)
+	if err != nil {
+		return nil, err
+	}
+	defer xs.Free()
+
+	last := len(cm.points) - 1
+	acc, err := clonePoint(cm.curve, cm.points[last])
+	if err != nil {
+		return nil, err
+	}
+	for k := last - 1; k >= 0; k-- {
+		scaled, err := acc.Mul(xs)
+		acc.Free()
+		if err != nil {
+			return nil, err
+		}
+		acc, err = scaled.Add(cm.points[k])
+		scaled.Free()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return acc, nil
+}
+
+// clonePoint returns an independently owned copy of p.
+func clonePoint(c curve.Curve, p *curve.Point) (*curve.Point, error) {
+	b, err := p.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return curve.NewPointFromBytes(c, b)
+}
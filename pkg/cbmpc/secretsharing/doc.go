@@ -0,0 +1,62 @@
+// Package secretsharing provides Shamir secret sharing with Feldman verifiable
+// commitments over the supported curves.
+//
+// Shares live in the scalar field of the chosen curve, which is the same field
+// used by the threshold nodes of access structures (see the accessstructure
+// package). This makes it possible to build custom backup and recovery flows
+// whose shares are interchangeable with the ones produced by the native
+// protocols.
+//
+// # Key Operations
+//
+//   - Split: Deal a secret into n shares with threshold t, plus a Feldman commitment
+//   - Combine: Reconstruct the secret from t or more shares (Lagrange interpolation)
+//   - VerifyShare: Check a share against the Feldman commitment
+//   - LagrangeCoefficient: Weight that turns a Shamir share into an additive share
+//   - LoadCommitment / Commitment.Bytes: Serialize commitments for distribution
+//
+// # Memory Management
+//
+// Shares hold secret scalars and commitments hold native points; both must be
+// freed when no longer needed:
+//
+//	res, err := secretsharing.Split(&secretsharing.SplitParams{
+//	    Curve:     curve.Secp256k1,
+//	    Secret:    secret,
+//	    Threshold: 2,
+//	    Parties:   3,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer res.Free()
+//
+// # Usage Example
+//
+//	// Each recipient verifies its share against the broadcast commitment
+//	for _, share := range res.Shares {
+//	    if err := secretsharing.VerifyShare(res.Commitment, share); err != nil {
+//	        return err
+//	    }
+//	}
+//
+//	// Any two shares reconstruct the secret
+//	secret, err := secretsharing.Combine(curve.Secp256k1, res.Shares[:2])
+//	if err != nil {
+//	    return err
+//	}
+//	defer secret.Free()
+//
+// # Security Considerations
+//
+//   - Combine does not know the threshold: fewer than t shares yield an
+//     unrelated scalar. Compare secret*G with Commitment.PublicKey() to detect it.
+//   - Party indices must be distinct and non-zero; index 0 is the secret.
+//   - Dealing and interpolation run in the native library.
+//
+// Split and Combine call crypto::ss::share_threshold and
+// crypto::ss::lagrange_interpolate from cb-mpc/src/cbmpc/crypto/secret_sharing.h,
+// the same code that shares and reconstructs the threshold nodes of access
+// structures; only the Feldman commitment is assembled in Go, from native
+// point operations.
+package secretsharing
@@ -0,0 +1,256 @@
+package secretsharing
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+// ErrInvalidShare is returned by VerifyShare when a share is not consistent
+// with the Feldman commitment.
+var ErrInvalidShare = errors.New("secretsharing: share does not match commitment")
+
+// Share is a single Shamir share: the dealing polynomial evaluated at the
+// party index X. Indices start at 1; index 0 is the secret itself.
+type Share struct {
+	Index int           // Party index (x-coordinate), must be >= 1
+	Value *curve.Scalar // Polynomial evaluation f(Index) mod q
+}
+
+// Free zeroizes the share value.
+func (s *Share) Free() {
+	if s == nil {
+		return
+	}
+	s.Value.Free()
+}
+
+// SplitParams contains parameters for splitting a secret.
+type SplitParams struct {
+	Curve     curve.Curve   // Curve whose order defines the share field
+	Secret    *curve.Scalar // Secret to share
+	Threshold int           // Number of shares required to reconstruct (t)
+	Parties   int           // Number of shares to produce (n)
+}
+
+// SplitResult contains the output of Split.
+type SplitResult struct {
+	Shares     []Share     // Shares for indices 1..n, in order
+	Commitment *Commitment // Feldman commitment to the dealing polynomial
+}
+
+// Free zeroizes all shares and releases the commitment.
+func (r *SplitResult) Free() {
+	if r == nil {
+		return
+	}
+	for i := range r.Shares {
+		r.Shares[i].Free()
+	}
+	r.Commitment.Free()
+}
+
+// Split deals secret into n Shamir shares with reconstruction threshold t and
+// returns Feldman commitments A_k = a_k*G to the polynomial coefficients so
+// each recipient can check its share with VerifyShare. The dealing itself is
+// done by the native crypto::ss::share_threshold.
+//
+// Shares are ordered by index: Shares[i].Index == i+1. The commitment's
+// PublicKey equals Secret*G.
+func Split(params *SplitParams) (*SplitResult, error) {
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.Secret == nil {
+		return nil, errors.New("nil secret")
+	}
+	if params.Threshold < 1 {
+		return nil, fmt.Errorf("threshold must be >= 1 (got %d)", params.Threshold)
+	}
+	if params.Parties < params.Threshold {
+		return nil, fmt.Errorf("parties (%d) must be >= threshold (%d)", params.Parties, params.Threshold)
+	}
+
+	c := params.Curve
+	nid, err := backend.CurveToNID(backend.Curve(c))
+	if err != nil {
+		return nil, err
+	}
+	shareBytes, coeffBytes, err := backend.SSShareThreshold(nid, params.Secret.Bytes, params.Threshold, params.Parties)
+	defer clearAll(shareBytes)
+	defer clearAll(coeffBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(shareBytes) != params.Parties || len(coeffBytes) != params.Threshold {
+		return nil, errors.New("share_threshold: inconsistent output")
+	}
+
+	coeffs := make([]*curve.Scalar, 0, len(coeffBytes))
+	defer func() {
+		for _, a := range coeffs {
+			a.Free()
+		}
+	}()
+	for _, b := range coeffBytes {
+		a, err := curve.NewScalarFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		coeffs = append(coeffs, a)
+	}
+
+	commitment, err := commit(c, coeffs)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]Share, 0, params.Parties)
+	for i, b := range shareBytes {
+		y, err := curve.NewScalarFromBytes(b)
+		if err != nil {
+			for j := range shares {
+				shares[j].Free()
+			}
+			commitment.Free()
+			return nil, err
+		}
+		shares = append(shares, Share{Index: i + 1, Value: y})
+	}
+
+	return &SplitResult{Shares: shares, Commitment: commitment}, nil
+}
+
+// Combine reconstructs the secret from at least threshold shares with the
+// native crypto::ss::lagrange_interpolate at zero. Supplying fewer shares
+// than the threshold returns an unrelated value; use VerifyShare and the
+// commitment's PublicKey to detect this.
+func Combine(c curve.Curve, shares []Share) (*curve.Scalar, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares")
+	}
+	seen := make(map[int]struct{}, len(shares))
+	values := make([][]byte, len(shares))
+	pids := make([][]byte, len(shares))
+	for i, s := range shares {
+		if s.Index < 1 {
+			return nil, fmt.Errorf("share %d: index must be >= 1 (got %d)", i, s.Index)
+		}
+		if s.Value == nil {
+			return nil, fmt.Errorf("share %d: nil value", i)
+		}
+		if _, dup := seen[s.Index]; dup {
+			return nil, fmt.Errorf("duplicate share index %d", s.Index)
+		}
+		seen[s.Index] = struct{}{}
+		values[i] = s.Value.Bytes
+		pids[i] = indexBytes(s.Index)
+	}
+	return interpolate(c, values, pids)
+}
+
+// LagrangeCoefficient returns the weight of the share at index when the
+// secret is interpolated from the shares at indices: secret = sum of
+// LagrangeCoefficient(c, indices, i) * f(i). Resharing protocols use it to
+// turn a Shamir share into an additive share of the secret among indices.
+func LagrangeCoefficient(c curve.Curve, indices []int, index int) (*curve.Scalar, error) {
+	found := false
+	seen := make(map[int]struct{}, len(indices))
+	values := make([][]byte, len(indices))
+	pids := make([][]byte, len(indices))
+	for i, x := range indices {
+		if x < 1 {
+			return nil, fmt.Errorf("index must be >= 1 (got %d)", x)
+		}
+		if _, dup := seen[x]; dup {
+			return nil, fmt.Errorf("duplicate index %d", x)
+		}
+		seen[x] = struct{}{}
+		// Interpolating the indicator of index yields its coefficient.
+		values[i] = []byte{0}
+		if x == index {
+			values[i] = []byte{1}
+			found = true
+		}
+		pids[i] = indexBytes(x)
+	}
+	if !found {
+		return nil, fmt.Errorf("index %d is not among the interpolation indices", index)
+	}
+	return interpolate(c, values, pids)
+}
+
+// VerifyShare checks share.Value*G == sum_k A_k * Index^k against the
+// Feldman commitment. Returns ErrInvalidShare on mismatch.
+func VerifyShare(commitment *Commitment, share Share) error {
+	if commitment == nil || len(commitment.points) == 0 {
+		return errors.New("nil commitment")
+	}
+	if share.Index < 1 {
+		return fmt.Errorf("index must be >= 1 (got %d)", share.Index)
+	}
+	if share.Value == nil {
+		return errors.New("nil share value")
+	}
+
+	c := commitment.curve
+	lhs, err := curve.MulGenerator(c, share.Value)
+	if err != nil {
+		return err
+	}
+	defer lhs.Free()
+
+	rhs, err := commitment.eval(share.Index)
+	if err != nil {
+		return err
+	}
+	defer rhs.Free()
+
+	lhsBytes, err := lhs.Bytes()
+	if err != nil {
+		return err
+	}
+	rhsBytes, err := rhs.Bytes()
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(lhsBytes, rhsBytes) != 1 {
+		return ErrInvalidShare
+	}
+	return nil
+}
+
+// interpolate evaluates at zero the polynomial through (pids[i], values[i]).
+func interpolate(c curve.Curve, values, pids [][]byte) (*curve.Scalar, error) {
+	nid, err := backend.CurveToNID(backend.Curve(c))
+	if err != nil {
+		return nil, err
+	}
+	out, err := backend.SSLagrangeInterpolate(nid, values, pids)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(out)
+	return curve.NewScalarFromBytes(out)
+}
+
+// indexBytes encodes a party index as a big-endian scalar.
+func indexBytes(i int) []byte {
+	return big.NewInt(int64(i)).Bytes()
+}
+
+// indexScalar converts a party index into a scalar.
+func indexScalar(i int) (*curve.Scalar, error) {
+	return curve.NewScalarFromBytes(indexBytes(i))
+}
+
+// clearAll zeroizes secret byte slices returned by the backend.
+func clearAll(bufs [][]byte) {
+	for _, b := range bufs {
+		clear(b)
+	}
+}
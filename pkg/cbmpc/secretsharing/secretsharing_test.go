@@ -0,0 +1,246 @@
+//go:build cgo && !windows
+
+package secretsharing_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/secretsharing"
+)
+
+func split(t *testing.T, c curve.Curve, threshold, parties int) (*curve.Scalar, *secretsharing.SplitResult) {
+	t.Helper()
+	secret, err := curve.RandomScalar(c)
+	if err != nil {
+		t.Fatalf("RandomScalar failed: %v", err)
+	}
+	t.Cleanup(secret.Free)
+
+	res, err := secretsharing.Split(&secretsharing.SplitParams{
+		Curve:     c,
+		Secret:    secret,
+		Threshold: threshold,
+		Parties:   parties,
+	})
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	t.Cleanup(res.Free)
+	return secret, res
+}
+
+func TestSplitCombine(t *testing.T) {
+	curves := []curve.Curve{curve.P256, curve.Secp256k1, curve.Ed25519}
+
+	for _, c := range curves {
+		t.Run(c.String(), func(t *testing.T) {
+			secret, res := split(t, c, 3, 5)
+
+			if len(res.Shares) != 5 {
+				t.Fatalf("expected 5 shares, got %d", len(res.Shares))
+			}
+			if res.Commitment.Threshold() != 3 {
+				t.Fatalf("expected threshold 3, got %d", res.Commitment.Threshold())
+			}
+
+			// Every 3-subset reconstructs the secret.
+			subsets := [][]int{{0, 1, 2}, {0, 2, 4}, {1, 3, 4}, {4, 3, 2}}
+			for _, idx := range subsets {
+				shares := make([]secretsharing.Share, len(idx))
+				for i, j := range idx {
+					shares[i] = res.Shares[j]
+				}
+				got, err := secretsharing.Combine(c, shares)
+				if err != nil {
+					t.Fatalf("Combine(%v) failed: %v", idx, err)
+				}
+				if !got.Equal(secret) {
+					t.Fatalf("Combine(%v) did not reconstruct the secret", idx)
+				}
+				got.Free()
+			}
+
+			// All shares also reconstruct the secret.
+			got, err := secretsharing.Combine(c, res.Shares)
+			if err != nil {
+				t.Fatalf("Combine(all) failed: %v", err)
+			}
+			defer got.Free()
+			if !got.Equal(secret) {
+				t.Fatal("Combine(all) did not reconstruct the secret")
+			}
+		})
+	}
+}
+
+func TestCombineBelowThreshold(t *testing.T) {
+	c := curve.P256
+	secret, res := split(t, c, 3, 5)
+
+	got, err := secretsharing.Combine(c, res.Shares[:2])
+	if err != nil {
+		t.Fatalf("Combine failed: %v", err)
+	}
+	defer got.Free()
+	if got.Equal(secret) {
+		t.Fatal("two shares must not reconstruct a 3-of-5 secret")
+	}
+}
+
+func TestVerifyShare(t *testing.T) {
+	c := curve.Secp256k1
+	secret, res := split(t, c, 2, 3)
+
+	for _, share := range res.Shares {
+		if err := secretsharing.VerifyShare(res.Commitment, share); err != nil {
+			t.Fatalf("VerifyShare(%d) failed: %v", share.Index, err)
+		}
+	}
+
+	// A share presented at the wrong index must be rejected.
+	moved := secretsharing.Share{Index: 2, Value: res.Shares[0].Value}
+	if err := secretsharing.VerifyShare(res.Commitment, moved); !errors.Is(err, secretsharing.ErrInvalidShare) {
+		t.Fatalf("expected ErrInvalidShare, got %v", err)
+	}
+
+	// PublicKey commits to the secret.
+	pub, err := res.Commitment.PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey failed: %v", err)
+	}
+	defer pub.Free()
+	want, err := curve.MulGenerator(c, secret)
+	if err != nil {
+		t.Fatalf("MulGenerator failed: %v", err)
+	}
+	defer want.Free()
+	pubBytes, _ := pub.Bytes()
+	wantBytes, _ := want.Bytes()
+	if !bytes.Equal(pubBytes, wantBytes) {
+		t.Fatal("commitment public key does not match secret*G")
+	}
+}
+
+func TestLagrangeCoefficient(t *testing.T) {
+	c := curve.Secp256k1
+	secret, res := split(t, c, 3, 5)
+
+	// sum lambda_i * f(i) over any 3 indices recovers the secret.
+	indices := []int{2, 4, 5}
+	var sum *curve.Scalar
+	for _, i := range indices {
+		lambda, err := secretsharing.LagrangeCoefficient(c, indices, i)
+		if err != nil {
+			t.Fatalf("LagrangeCoefficient(%d) failed: %v", i, err)
+		}
+		term, err := res.Shares[i-1].Value.Mul(lambda, c)
+		lambda.Free()
+		if err != nil {
+			t.Fatalf("Mul failed: %v", err)
+		}
+		if sum == nil {
+			sum = term
+			continue
+		}
+		next, err := sum.Add(term, c)
+		sum.Free()
+		term.Free()
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		sum = next
+	}
+	defer sum.Free()
+	if !sum.Equal(secret) {
+		t.Fatal("weighted shares do not sum to the secret")
+	}
+
+	if _, err := secretsharing.LagrangeCoefficient(c, indices, 3); err == nil {
+		t.Fatal("expected error for an index outside the set")
+	}
+	if _, err := secretsharing.LagrangeCoefficient(c, []int{1, 1}, 1); err == nil {
+		t.Fatal("expected error for duplicate indices")
+	}
+}
+
+func TestPublicShare(t *testing.T) {
+	c := curve.P256
+	_, res := split(t, c, 2, 3)
+
+	for _, share := range res.Shares {
+		pub, err := res.Commitment.PublicShare(share.Index)
+		if err != nil {
+			t.Fatalf("PublicShare(%d) failed: %v", share.Index, err)
+		}
+		want, err := curve.MulGenerator(c, share.Value)
+		if err != nil {
+			t.Fatalf("MulGenerator failed: %v", err)
+		}
+		pubBytes, _ := pub.Bytes()
+		wantBytes, _ := want.Bytes()
+		pub.Free()
+		want.Free()
+		if !bytes.Equal(pubBytes, wantBytes) {
+			t.Fatalf("PublicShare(%d) does not match share*G", share.Index)
+		}
+	}
+	if _, err := res.Commitment.PublicShare(0); err == nil {
+		t.Fatal("expected error for index 0")
+	}
+}
+
+func TestCommitmentRoundTrip(t *testing.T) {
+	c := curve.P256
+	_, res := split(t, c, 3, 4)
+
+	points, err := res.Commitment.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	loaded, err := secretsharing.LoadCommitment(c, points)
+	if err != nil {
+		t.Fatalf("LoadCommitment failed: %v", err)
+	}
+	defer loaded.Free()
+
+	for _, share := range res.Shares {
+		if err := secretsharing.VerifyShare(loaded, share); err != nil {
+			t.Fatalf("VerifyShare against loaded commitment failed: %v", err)
+		}
+	}
+}
+
+func TestSplitInvalidParams(t *testing.T) {
+	secret, err := curve.RandomScalar(curve.P256)
+	if err != nil {
+		t.Fatalf("RandomScalar failed: %v", err)
+	}
+	defer secret.Free()
+
+	cases := map[string]*secretsharing.SplitParams{
+		"nil params":        nil,
+		"nil secret":        {Curve: curve.P256, Threshold: 2, Parties: 3},
+		"zero threshold":    {Curve: curve.P256, Secret: secret, Threshold: 0, Parties: 3},
+		"threshold > parts": {Curve: curve.P256, Secret: secret, Threshold: 4, Parties: 3},
+	}
+	for name, params := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := secretsharing.Split(params); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestCombineDuplicateIndex(t *testing.T) {
+	c := curve.P256
+	_, res := split(t, c, 2, 3)
+
+	shares := []secretsharing.Share{res.Shares[0], res.Shares[0]}
+	if _, err := secretsharing.Combine(c, shares); err == nil {
+		t.Fatal("expected error for duplicate share index")
+	}
+}
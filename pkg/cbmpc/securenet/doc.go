@@ -0,0 +1,33 @@
+// Package securenet encrypts and authenticates protocol messages end to end
+// between parties.
+//
+// Deployments that relay messages through a broker (a message queue, a
+// coordinator service) often cannot run mTLS between the parties themselves:
+// TLS ends at the broker, which sees and could alter every message. A
+// Transport from this package wraps the broker transport and seals each
+// message with AES-256-GCM under a key that only the sending and receiving
+// party hold, so the broker learns only message sizes and timing.
+//
+// Keys come from one of two places. Wrap takes a pairwise secret provisioned
+// out of band. Handshake derives fresh secrets per session from ephemeral
+// X25519 keys signed with each party's long-term Ed25519 identity, so a
+// broker cannot substitute its own keys:
+//
+//	sn, err := securenet.Handshake(ctx, &securenet.HandshakeParams{
+//	    Transport: brokerTransport,
+//	    Self:      self,
+//	    SessionID: sid,
+//	    Identity:  identityKey,
+//	    Peers:     peerIdentities,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	job, err := cbmpc.NewJob2P(sn, cbmpc.RoleP1, names)
+//
+// Every message from one party to another carries a sequence number bound
+// into its authentication tag together with the session ID and the
+// direction. A message that was modified, replayed, reordered, dropped (as
+// seen from the message after it) or taken from another session fails with
+// ErrAuth, which aborts the protocol.
+package securenet
@@ -0,0 +1,169 @@
+package securenet
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+const (
+	handshakeKey  byte = 1
+	handshakeSign byte = 2
+)
+
+// ErrHandshake indicates a peer whose handshake messages were malformed or
+// not signed by its identity key.
+var ErrHandshake = errors.New("securenet: handshake failed")
+
+// HandshakeParams configures Handshake.
+type HandshakeParams struct {
+	// Transport connects this party to every peer. It carries the handshake
+	// and then the sealed messages.
+	Transport cbmpc.Transport
+	// Self is this party's role.
+	Self cbmpc.RoleID
+	// SessionID must be unique per run and identical across parties.
+	SessionID []byte
+	// Identity is this party's long-term signing key.
+	Identity ed25519.PrivateKey
+	// Peers maps every other party's role to its identity public key.
+	Peers map[cbmpc.RoleID]ed25519.PublicKey
+}
+
+// Handshake runs an authenticated key exchange with every peer and returns a
+// Transport sealing messages under the resulting keys. Each pair of parties
+// exchanges ephemeral X25519 keys and signs both of them, with both roles and
+// the session ID, under its identity key, so a relay that can read and
+// rewrite messages can neither learn the keys nor impersonate a party. The
+// ephemeral keys are discarded, so recorded traffic stays confidential even if
+// identity keys later leak.
+//
+// Every party must call Handshake with the same session ID before the job
+// starts; it takes two rounds.
+func Handshake(ctx context.Context, params *HandshakeParams) (*Transport, error) {
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.Transport == nil {
+		return nil, cbmpc.ErrNilTransport
+	}
+	if len(params.SessionID) == 0 {
+		return nil, errors.New("empty session ID")
+	}
+	if len(params.Identity) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid identity key")
+	}
+	if len(params.Peers) == 0 {
+		return nil, errors.New("no peers")
+	}
+	peers := make([]cbmpc.RoleID, 0, len(params.Peers))
+	for role, pub := range params.Peers {
+		if role == params.Self {
+			return nil, fmt.Errorf("peer key for self (role %d)", role)
+		}
+		if len(pub) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid identity key for role %d", role)
+		}
+		peers = append(peers, role)
+	}
+	t, self := params.Transport, params.Self
+
+	eph, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	own := eph.PublicKey().Bytes()
+	theirs, err := handshakeRound(ctx, t, peers, handshakeKey, func(cbmpc.RoleID) []byte { return own })
+	if err != nil {
+		return nil, err
+	}
+	transcripts := make(map[cbmpc.RoleID][]byte, len(peers))
+	for _, role := range peers {
+		transcripts[role] = transcript(params.SessionID, self, own, role, theirs[role])
+	}
+
+	sigs, err := handshakeRound(ctx, t, peers, handshakeSign, func(role cbmpc.RoleID) []byte {
+		return ed25519.Sign(params.Identity, signedMessage(transcripts[role], self))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[cbmpc.RoleID][]byte, len(peers))
+	defer func() {
+		for _, s := range secrets {
+			clear(s)
+		}
+	}()
+	for _, role := range peers {
+		if !ed25519.Verify(params.Peers[role], signedMessage(transcripts[role], role), sigs[role]) {
+			return nil, fmt.Errorf("%w: bad signature from role %d", ErrHandshake, role)
+		}
+		pub, err := ecdh.X25519().NewPublicKey(theirs[role])
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad key from role %d", ErrHandshake, role)
+		}
+		shared, err := eph.ECDH(pub)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad key from role %d", ErrHandshake, role)
+		}
+		secrets[role], err = hkdf.Key(sha256.New, shared, transcripts[role], "cbmpc/securenet/secret", MinSecretSize)
+		clear(shared)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return Wrap(t, self, params.SessionID, secrets)
+}
+
+// handshakeRound sends msg(peer) to every peer and collects their messages
+// for the same round.
+func handshakeRound(ctx context.Context, t cbmpc.Transport, peers []cbmpc.RoleID, round byte, msg func(cbmpc.RoleID) []byte) (map[cbmpc.RoleID][]byte, error) {
+	for _, role := range peers {
+		if err := t.Send(ctx, role, append([]byte{round}, msg(role)...)); err != nil {
+			return nil, fmt.Errorf("handshake send to role %d: %w", role, err)
+		}
+	}
+	got, err := t.ReceiveAll(ctx, peers)
+	if err != nil {
+		return nil, fmt.Errorf("handshake receive: %w", err)
+	}
+	out := make(map[cbmpc.RoleID][]byte, len(peers))
+	for _, role := range peers {
+		m := got[role]
+		if len(m) == 0 || m[0] != round {
+			return nil, fmt.Errorf("%w: unexpected message from role %d", ErrHandshake, role)
+		}
+		out[role] = m[1:]
+	}
+	return out, nil
+}
+
+// transcript hashes the session ID and both parties' roles and ephemeral
+// keys, in role order so both parties compute the same value.
+func transcript(sid []byte, a cbmpc.RoleID, aKey []byte, b cbmpc.RoleID, bKey []byte) []byte {
+	if b < a {
+		a, aKey, b, bKey = b, bKey, a, aKey
+	}
+	h := sha256.New()
+	h.Write([]byte("cbmpc/securenet/handshake/v1"))
+	for _, p := range [][]byte{sid, binary.BigEndian.AppendUint32(nil, uint32(a)), aKey, binary.BigEndian.AppendUint32(nil, uint32(b)), bKey} {
+		_ = binary.Write(h, binary.BigEndian, uint32(len(p)))
+		h.Write(p)
+	}
+	return h.Sum(nil)
+}
+
+// signedMessage is what signer signs for a transcript. Including the signer
+// keeps a party's signature from being reflected back to it.
+func signedMessage(transcript []byte, signer cbmpc.RoleID) []byte {
+	return binary.BigEndian.AppendUint32(append([]byte("cbmpc/securenet/sig"), transcript...), uint32(signer))
+}
@@ -0,0 +1,244 @@
+package securenet
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// MinSecretSize is the minimum length of a pairwise secret passed to Wrap.
+const MinSecretSize = 32
+
+const (
+	frameVersion = 1
+	// headerSize is the version byte and the 8-byte sequence number.
+	headerSize = 1 + 8
+)
+
+var (
+	// ErrAuth indicates a message that failed authentication: it was
+	// modified, replayed, reordered, or sealed under another session.
+	ErrAuth = errors.New("securenet: message authentication failed")
+	// ErrUnknownPeer indicates a send to or receive from a role the
+	// transport has no key for.
+	ErrUnknownPeer = errors.New("securenet: no session key for peer")
+)
+
+// Transport is a cbmpc.Transport that encrypts and authenticates every
+// message with a key derived for the pair of parties and the direction.
+type Transport struct {
+	inner cbmpc.Transport
+	self  cbmpc.RoleID
+	sid   []byte
+	peers map[cbmpc.RoleID]*pair
+}
+
+// pair holds the keys and sequence numbers for one peer. Sends hold sendMu
+// and receives recvMu across the wrapped transport call, so messages are
+// sealed and opened in the order the wrapped transport carries them.
+type pair struct {
+	send, recv cipher.AEAD
+	sendAD     []byte
+	recvAD     []byte
+
+	sendMu  sync.Mutex
+	sendSeq uint64
+	recvMu  sync.Mutex
+	recvSeq uint64
+}
+
+// Wrap returns a Transport that seals messages between self and each peer
+// under keys derived from secrets[peer], a secret of at least MinSecretSize
+// bytes that only the two parties know. The peer must call Wrap with the same
+// secret for self and the same session ID.
+//
+// The secret can be provisioned out of band, or come from Handshake, which
+// derives it from authenticated ephemeral keys. Each secret must be used with
+// one session ID only: reusing a (secret, session ID) pair reuses AES-GCM
+// nonces.
+func Wrap(t cbmpc.Transport, self cbmpc.RoleID, sessionID []byte, secrets map[cbmpc.RoleID][]byte) (*Transport, error) {
+	if t == nil {
+		return nil, cbmpc.ErrNilTransport
+	}
+	if len(sessionID) == 0 {
+		return nil, errors.New("empty session ID")
+	}
+	if len(secrets) == 0 {
+		return nil, errors.New("no peers")
+	}
+	s := &Transport{
+		inner: t,
+		self:  self,
+		sid:   append([]byte(nil), sessionID...),
+		peers: make(map[cbmpc.RoleID]*pair, len(secrets)),
+	}
+	for peer, secret := range secrets {
+		if peer == self {
+			return nil, fmt.Errorf("secret for self (role %d)", self)
+		}
+		if len(secret) < MinSecretSize {
+			return nil, fmt.Errorf("secret for role %d: %d bytes, need at least %d", peer, len(secret), MinSecretSize)
+		}
+		p, err := s.newPair(peer, secret)
+		if err != nil {
+			return nil, err
+		}
+		s.peers[peer] = p
+	}
+	return s, nil
+}
+
+func (s *Transport) newPair(peer cbmpc.RoleID, secret []byte) (*pair, error) {
+	p := &pair{
+		sendAD: s.associatedData(s.self, peer),
+		recvAD: s.associatedData(peer, s.self),
+	}
+	var err error
+	if p.send, err = s.aead(secret, p.sendAD); err != nil {
+		return nil, err
+	}
+	if p.recv, err = s.aead(secret, p.recvAD); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// associatedData binds a message to the session and its direction.
+func (s *Transport) associatedData(from, to cbmpc.RoleID) []byte {
+	ad := make([]byte, 0, len("cbmpc/securenet/v1")+4+len(s.sid)+8)
+	ad = append(ad, "cbmpc/securenet/v1"...)
+	ad = binary.BigEndian.AppendUint32(ad, uint32(len(s.sid)))
+	ad = append(ad, s.sid...)
+	ad = binary.BigEndian.AppendUint32(ad, uint32(from))
+	return binary.BigEndian.AppendUint32(ad, uint32(to))
+}
+
+// aead derives the AES-256-GCM key for one direction.
+func (s *Transport) aead(secret, direction []byte) (cipher.AEAD, error) {
+	key, err := hkdf.Key(sha256.New, secret, s.sid, string(direction), 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	clear(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *Transport) pair(role cbmpc.RoleID) (*pair, error) {
+	p, ok := s.peers[role]
+	if !ok {
+		return nil, fmt.Errorf("%w: role %d", ErrUnknownPeer, role)
+	}
+	return p, nil
+}
+
+// Send seals msg for to and forwards it to the wrapped transport.
+func (s *Transport) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	p, err := s.pair(to)
+	if err != nil {
+		return err
+	}
+	p.sendMu.Lock()
+	defer p.sendMu.Unlock()
+	if p.sendSeq == ^uint64(0) {
+		return errors.New("securenet: sequence numbers exhausted")
+	}
+	frame := make([]byte, headerSize, headerSize+len(msg)+p.send.Overhead())
+	frame[0] = frameVersion
+	binary.BigEndian.PutUint64(frame[1:], p.sendSeq)
+	frame = p.send.Seal(frame, nonce(p.sendSeq), msg, p.sendAD)
+	// The sequence number is spent even if the send fails: the frame may
+	// have reached the peer, and its nonce must not seal another message.
+	p.sendSeq++
+	return s.inner.Send(ctx, to, frame)
+}
+
+// Receive reads the next message from from and opens it.
+func (s *Transport) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
+	p, err := s.pair(from)
+	if err != nil {
+		return nil, err
+	}
+	p.recvMu.Lock()
+	defer p.recvMu.Unlock()
+	frame, err := s.inner.Receive(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	return p.open(from, frame)
+}
+
+// ReceiveAll reads the next message from every role in from and opens them.
+func (s *Transport) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	// Lock in role order so concurrent calls cannot deadlock.
+	roles := slices.Clone(from)
+	slices.Sort(roles)
+	roles = slices.Compact(roles)
+	pairs := make([]*pair, len(roles))
+	for i, role := range roles {
+		p, err := s.pair(role)
+		if err != nil {
+			return nil, err
+		}
+		pairs[i] = p
+	}
+	for _, p := range pairs {
+		p.recvMu.Lock()
+		defer p.recvMu.Unlock()
+	}
+
+	frames, err := s.inner.ReceiveAll(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[cbmpc.RoleID][]byte, len(frames))
+	for i, role := range roles {
+		frame, ok := frames[role]
+		if !ok {
+			return nil, fmt.Errorf("securenet: no message from role %d", role)
+		}
+		msg, err := pairs[i].open(role, frame)
+		if err != nil {
+			return nil, err
+		}
+		out[role] = msg
+	}
+	return out, nil
+}
+
+// open authenticates and decrypts the next frame from a peer. The caller
+// holds p.recvMu.
+func (p *pair) open(from cbmpc.RoleID, frame []byte) ([]byte, error) {
+	if len(frame) < headerSize+p.recv.Overhead() || frame[0] != frameVersion {
+		return nil, fmt.Errorf("%w: malformed frame from role %d", ErrAuth, from)
+	}
+	if seq := binary.BigEndian.Uint64(frame[1:]); seq != p.recvSeq {
+		return nil, fmt.Errorf("%w: message %d from role %d, expected %d", ErrAuth, seq, from, p.recvSeq)
+	}
+	msg, err := p.recv.Open(nil, nonce(p.recvSeq), frame[headerSize:], p.recvAD)
+	if err != nil {
+		return nil, fmt.Errorf("%w: message %d from role %d", ErrAuth, p.recvSeq, from)
+	}
+	p.recvSeq++
+	return msg, nil
+}
+
+// nonce returns the GCM nonce for a sequence number. Each direction has its
+// own key, so sequence numbers never repeat under a key.
+func nonce(seq uint64) []byte {
+	n := make([]byte, 12)
+	binary.BigEndian.PutUint64(n[4:], seq)
+	return n
+}
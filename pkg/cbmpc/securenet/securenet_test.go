@@ -0,0 +1,262 @@
+package securenet_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/securenet"
+)
+
+var secret = bytes.Repeat([]byte{7}, securenet.MinSecretSize)
+
+// spy records the frames a party sends.
+type spy struct {
+	cbmpc.Transport
+	mu   sync.Mutex
+	sent [][]byte
+}
+
+func (s *spy) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	s.mu.Lock()
+	s.sent = append(s.sent, append([]byte(nil), msg...))
+	s.mu.Unlock()
+	return s.Transport.Send(ctx, to, msg)
+}
+
+func wrapPair(t *testing.T, t0, t1 cbmpc.Transport) (*securenet.Transport, *securenet.Transport) {
+	t.Helper()
+	a, err := securenet.Wrap(t0, 0, []byte("sid"), map[cbmpc.RoleID][]byte{1: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := securenet.Wrap(t1, 1, []byte("sid"), map[cbmpc.RoleID][]byte{0: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a, b
+}
+
+func TestWrap(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	net := mocknet.New()
+	sp := &spy{Transport: net.Ep2P(0, 1)}
+	a, b := wrapPair(t, sp, net.Ep2P(1, 0))
+
+	msgs := [][]byte{[]byte("first protocol message"), {}, []byte("third")}
+	for _, m := range msgs {
+		if err := a.Send(ctx, 1, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, want := range msgs {
+		got, err := b.Receive(ctx, 0)
+		if err != nil {
+			t.Fatalf("message %d: %v", i, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("message %d = %q, want %q", i, got, want)
+		}
+	}
+	if bytes.Contains(sp.sent[0], msgs[0]) {
+		t.Fatal("frame contains the plaintext")
+	}
+
+	if err := b.Send(ctx, 0, []byte("reply")); err != nil {
+		t.Fatal(err)
+	}
+	got, err := a.ReceiveAll(ctx, []cbmpc.RoleID{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got[1]) != "reply" {
+		t.Fatalf("ReceiveAll = %q", got[1])
+	}
+}
+
+func TestWrapRejectsTampering(t *testing.T) {
+	for name, adv := range map[string]func(*mocknet.Adversary, []byte) *mocknet.Adversary{
+		"corrupt": func(a *mocknet.Adversary, _ []byte) *mocknet.Adversary { return a.Corrupt(1, 1) },
+		"replay":  func(a *mocknet.Adversary, first []byte) *mocknet.Adversary { return a.Substitute(1, 1, first) },
+		"drop":    func(a *mocknet.Adversary, _ []byte) *mocknet.Adversary { return a.Drop(1, 1) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			net := mocknet.New()
+
+			// Seal the first message once to learn its frame for the replay.
+			sp := &spy{Transport: mocknet.New().Ep2P(0, 1)}
+			probe, _ := wrapPair(t, sp, mocknet.New().Ep2P(1, 0))
+			if err := probe.Send(ctx, 1, []byte("m0")); err != nil {
+				t.Fatal(err)
+			}
+
+			a, b := wrapPair(t, adv(mocknet.NewAdversary(net.Ep2P(0, 1)), sp.sent[0]), net.Ep2P(1, 0))
+			for _, m := range []string{"m0", "m1", "m2"} {
+				if err := a.Send(ctx, 1, []byte(m)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := b.Receive(ctx, 0); err != nil {
+				t.Fatalf("first message: %v", err)
+			}
+			if _, err := b.Receive(ctx, 0); !errors.Is(err, securenet.ErrAuth) {
+				t.Fatalf("second message: err = %v, want ErrAuth", err)
+			}
+		})
+	}
+}
+
+func TestWrapOtherSession(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	net := mocknet.New()
+	a, err := securenet.Wrap(net.Ep2P(0, 1), 0, []byte("sid-1"), map[cbmpc.RoleID][]byte{1: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := securenet.Wrap(net.Ep2P(1, 0), 1, []byte("sid-2"), map[cbmpc.RoleID][]byte{0: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Send(ctx, 1, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Receive(ctx, 0); !errors.Is(err, securenet.ErrAuth) {
+		t.Fatalf("err = %v, want ErrAuth", err)
+	}
+}
+
+func identities(t *testing.T, n int) ([]ed25519.PrivateKey, map[cbmpc.RoleID]ed25519.PublicKey) {
+	t.Helper()
+	privs := make([]ed25519.PrivateKey, n)
+	pubs := make(map[cbmpc.RoleID]ed25519.PublicKey, n)
+	for i := range privs {
+		pub, priv, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		privs[i], pubs[cbmpc.RoleID(i)] = priv, pub
+	}
+	return privs, pubs
+}
+
+// handshakeAll runs Handshake for every party and returns the transports and
+// errors by role.
+func handshakeAll(ctx context.Context, net *mocknet.Net, privs []ed25519.PrivateKey, pubs map[cbmpc.RoleID]ed25519.PublicKey) ([]*securenet.Transport, []error) {
+	n := len(privs)
+	roles := make([]cbmpc.RoleID, n)
+	for i := range roles {
+		roles[i] = cbmpc.RoleID(i)
+	}
+	out := make([]*securenet.Transport, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			peers := make(map[cbmpc.RoleID]ed25519.PublicKey)
+			for r, pub := range pubs {
+				if r != cbmpc.RoleID(i) {
+					peers[r] = pub
+				}
+			}
+			out[i], errs[i] = securenet.Handshake(ctx, &securenet.HandshakeParams{
+				Transport: net.EpMP(cbmpc.RoleID(i), roles),
+				Self:      cbmpc.RoleID(i),
+				SessionID: []byte("handshake-test"),
+				Identity:  privs[i],
+				Peers:     peers,
+			})
+		}()
+	}
+	wg.Wait()
+	return out, errs
+}
+
+func TestHandshake(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	privs, pubs := identities(t, 3)
+	ts, errs := handshakeAll(ctx, mocknet.New(), privs, pubs)
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d: %v", i, err)
+		}
+	}
+
+	for i, s := range ts {
+		for j := range ts {
+			if i != j {
+				if err := s.Send(ctx, cbmpc.RoleID(j), fmt.Appendf(nil, "%d->%d", i, j)); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+	for j, s := range ts {
+		var from []cbmpc.RoleID
+		for i := range ts {
+			if i != j {
+				from = append(from, cbmpc.RoleID(i))
+			}
+		}
+		got, err := s.ReceiveAll(ctx, from)
+		if err != nil {
+			t.Fatalf("party %d: %v", j, err)
+		}
+		for _, i := range from {
+			if want := fmt.Sprintf("%d->%d", i, j); string(got[i]) != want {
+				t.Fatalf("party %d got %q from %d, want %q", j, got[i], i, want)
+			}
+		}
+	}
+}
+
+func TestHandshakeWrongIdentity(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	privs, pubs := identities(t, 2)
+	// Party 0 signs with a key party 1 does not expect.
+	_, privs[0], _ = ed25519.GenerateKey(nil)
+	_, errs := handshakeAll(ctx, mocknet.New(), privs, pubs)
+	if !errors.Is(errs[1], securenet.ErrHandshake) {
+		t.Fatalf("party 1: err = %v, want ErrHandshake", errs[1])
+	}
+}
+
+func TestInvalidParams(t *testing.T) {
+	ep := mocknet.New().Ep2P(0, 1)
+	if _, err := securenet.Wrap(nil, 0, []byte("sid"), map[cbmpc.RoleID][]byte{1: secret}); err == nil {
+		t.Error("Wrap with nil transport succeeded")
+	}
+	if _, err := securenet.Wrap(ep, 0, nil, map[cbmpc.RoleID][]byte{1: secret}); err == nil {
+		t.Error("Wrap without session ID succeeded")
+	}
+	if _, err := securenet.Wrap(ep, 0, []byte("sid"), map[cbmpc.RoleID][]byte{1: secret[:16]}); err == nil {
+		t.Error("Wrap with a short secret succeeded")
+	}
+	if _, err := securenet.Wrap(ep, 0, []byte("sid"), map[cbmpc.RoleID][]byte{0: secret}); err == nil {
+		t.Error("Wrap with a secret for self succeeded")
+	}
+	s, err := securenet.Wrap(ep, 0, []byte("sid"), map[cbmpc.RoleID][]byte{1: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Send(context.Background(), 2, nil); !errors.Is(err, securenet.ErrUnknownPeer) {
+		t.Errorf("Send to unknown peer: %v", err)
+	}
+	if _, err := securenet.Handshake(context.Background(), &securenet.HandshakeParams{Transport: ep, SessionID: []byte("sid")}); err == nil {
+		t.Error("Handshake without identity succeeded")
+	}
+}
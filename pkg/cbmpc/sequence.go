@@ -0,0 +1,99 @@
+package cbmpc
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+)
+
+// seqHeaderSize is the length of the sequence number Sequenced prepends.
+const seqHeaderSize = 8
+
+// SequencedTransport numbers every message it sends to a peer and rejects
+// received messages that do not carry the next expected number. Create it
+// with Sequenced.
+type SequencedTransport struct {
+	inner Transport
+
+	mu   sync.Mutex
+	sent map[RoleID]uint64
+	next map[RoleID]uint64
+}
+
+// Sequenced wraps t so that each message carries a per-peer sequence number
+// and a replayed, reordered or skipped message fails with ErrReplay. Every
+// party must wrap its transport, since the number changes the messages on the
+// wire.
+//
+// The number is not authenticated: Sequenced catches brokers and retry logic
+// that deliver a message twice or out of order, not an attacker who can
+// rewrite messages. Pair it with an authenticated transport, or use
+// securenet, which authenticates its own sequence numbers.
+func Sequenced(t Transport) (*SequencedTransport, error) {
+	if t == nil {
+		return nil, ErrNilTransport
+	}
+	return &SequencedTransport{
+		inner: t,
+		sent:  make(map[RoleID]uint64),
+		next:  make(map[RoleID]uint64),
+	}, nil
+}
+
+// Send prefixes msg with the next sequence number for to.
+func (s *SequencedTransport) Send(ctx context.Context, to RoleID, msg []byte) error {
+	s.mu.Lock()
+	seq := s.sent[to]
+	s.sent[to]++
+	s.mu.Unlock()
+
+	frame := make([]byte, seqHeaderSize+len(msg))
+	binary.BigEndian.PutUint64(frame, seq)
+	copy(frame[seqHeaderSize:], msg)
+	return s.inner.Send(ctx, to, frame)
+}
+
+// Receive returns the next message from from, or ErrReplay if it is out of
+// sequence.
+func (s *SequencedTransport) Receive(ctx context.Context, from RoleID) ([]byte, error) {
+	frame, err := s.inner.Receive(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	return s.accept(from, frame)
+}
+
+// ReceiveAll returns the next message from each role in from, or ErrReplay
+// if any is out of sequence.
+func (s *SequencedTransport) ReceiveAll(ctx context.Context, from []RoleID) (map[RoleID][]byte, error) {
+	frames, err := s.inner.ReceiveAll(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[RoleID][]byte, len(frames))
+	for role, frame := range frames {
+		msg, err := s.accept(role, frame)
+		if err != nil {
+			return nil, err
+		}
+		out[role] = msg
+	}
+	return out, nil
+}
+
+func (s *SequencedTransport) accept(from RoleID, frame []byte) ([]byte, error) {
+	if len(frame) < seqHeaderSize {
+		return nil, fmt.Errorf("cbmpc: message from role %d has no sequence number", from)
+	}
+	seq := binary.BigEndian.Uint64(frame)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if want := s.next[from]; seq != want {
+		return nil, fmt.Errorf("%w: message %d from role %d, expected %d", ErrReplay, seq, from, want)
+	}
+	s.next[from]++
+	return frame[seqHeaderSize:], nil
+}
+
+var _ Transport = (*SequencedTransport)(nil)
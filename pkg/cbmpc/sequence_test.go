@@ -0,0 +1,58 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSequencedRejectsReplay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	net := &chanNet{}
+	s0, _ := Sequenced(chanEndpoint{net: net, self: 0})
+	s1, _ := Sequenced(chanEndpoint{net: net, self: 1})
+
+	if err := s0.Send(ctx, 1, []byte("first")); err != nil {
+		t.Fatal(err)
+	}
+	// Deliver the frame twice, as an at-least-once broker might.
+	box := net.box(0, 1)
+	frame := <-box
+	box <- frame
+	box <- frame
+
+	got, err := s1.ReceiveAll(ctx, []RoleID{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got[0]) != "first" {
+		t.Fatalf("got %q", got[0])
+	}
+	if _, err := s1.Receive(ctx, 0); !errors.Is(err, ErrReplay) {
+		t.Fatalf("replayed message: err = %v, want ErrReplay", err)
+	}
+}
+
+func TestSequencedRejectsGap(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	net := &chanNet{}
+	s0, _ := Sequenced(chanEndpoint{net: net, self: 0})
+	s1, _ := Sequenced(chanEndpoint{net: net, self: 1})
+
+	for _, m := range []string{"m0", "m1"} {
+		if err := s0.Send(ctx, 1, []byte(m)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-net.box(0, 1) // lost in transit
+	if _, err := s1.Receive(ctx, 0); !errors.Is(err, ErrReplay) {
+		t.Fatalf("message after a gap: err = %v, want ErrReplay", err)
+	}
+
+	if _, err := Sequenced(nil); !errors.Is(err, ErrNilTransport) {
+		t.Fatalf("Sequenced(nil): err = %v", err)
+	}
+}
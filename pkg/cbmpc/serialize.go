@@ -0,0 +1,107 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ErrJobBusy is returned when a protocol is invoked on a job that is already
+// running one. A job drives a single native session whose messages would
+// interleave, so a job created with NewJob2P or NewJobMP rejects concurrent
+// invocations; use NewSerializedJob2P or NewSerializedJobMP to queue them
+// instead.
+var ErrJobBusy = errors.New("job is already running a protocol")
+
+// invocationGuard admits one protocol invocation at a time. Plain jobs reject
+// a second invocation with ErrJobBusy; serialized jobs make it wait.
+type invocationGuard struct {
+	busy atomic.Bool
+	sem  chan struct{} // non-nil for serialized jobs
+}
+
+func (g *invocationGuard) acquire(ctx context.Context) (func(), error) {
+	if g.sem == nil {
+		if !g.busy.CompareAndSwap(false, true) {
+			return nil, ErrJobBusy
+		}
+		return sync.OnceFunc(func() { g.busy.Store(false) }), nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	select {
+	case g.sem <- struct{}{}:
+		return sync.OnceFunc(func() { <-g.sem }), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// NewSerializedJob2P constructs a 2-party job like NewJob2PWithContext whose
+// protocol invocations are serialized: a call made while another is running
+// waits for it to finish, or until the caller's context is done. Services can
+// share such a job between goroutines. Both parties must issue the same
+// protocols in the same order, so callers still need to agree on an order
+// with the peer; serialization only keeps one party's calls from
+// interleaving on the wire.
+func NewSerializedJob2P(ctx context.Context, t Transport, self Role, names [2]string) (*Job2P, error) {
+	j, err := NewJob2PWithContext(ctx, t, self, names)
+	if err != nil {
+		return nil, err
+	}
+	j.guard.sem = make(chan struct{}, 1)
+	return j, nil
+}
+
+// NewSerializedJobMP is the n-party counterpart of NewSerializedJob2P.
+func NewSerializedJobMP(ctx context.Context, t Transport, self RoleID, names []string) (*JobMP, error) {
+	j, err := NewJobMPWithContext(ctx, t, self, names)
+	if err != nil {
+		return nil, err
+	}
+	j.guard.sem = make(chan struct{}, 1)
+	return j, nil
+}
+
+// Acquire reserves the job for one protocol invocation and returns the native
+// job pointer. The caller must call release when the invocation returns.
+// Acquire fails with ErrJobBusy if a plain job is already in use, and waits
+// on a serialized job. Calling Acquire again on the same job before release,
+// for example from a transport callback, fails or deadlocks.
+// This is exported for use by protocol subpackages.
+func (j *Job2P) Acquire(ctx context.Context) (ptr unsafe.Pointer, release func(), err error) {
+	if j == nil {
+		return nil, nil, ErrJobClosed
+	}
+	release, err = j.guard.acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ptr, err = j.Ptr()
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	return ptr, release, nil
+}
+
+// Acquire reserves the job for one protocol invocation; see Job2P.Acquire.
+// This is exported for use by protocol subpackages.
+func (j *JobMP) Acquire(ctx context.Context) (ptr unsafe.Pointer, release func(), err error) {
+	if j == nil {
+		return nil, nil, ErrJobClosed
+	}
+	release, err = j.guard.acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ptr, err = j.Ptr()
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	return ptr, release, nil
+}
@@ -0,0 +1,82 @@
+package cbmpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInvocationGuardPlain(t *testing.T) {
+	var g invocationGuard
+	release, err := g.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.acquire(context.Background()); !errors.Is(err, ErrJobBusy) {
+		t.Fatalf("second acquire = %v, want ErrJobBusy", err)
+	}
+	release()
+	release() // idempotent
+	release, err = g.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	release()
+}
+
+func TestInvocationGuardSerialized(t *testing.T) {
+	g := invocationGuard{sem: make(chan struct{}, 1)}
+	release, err := g.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := g.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire while held = %v, want DeadlineExceeded", err)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		r, err := g.acquire(context.Background())
+		if err != nil {
+			t.Error(err)
+			close(acquired)
+			return
+		}
+		acquired <- r
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquired while held")
+	case <-time.After(10 * time.Millisecond):
+	}
+	release()
+	release() // must not free a slot held by the waiter
+	r, ok := <-acquired
+	if !ok {
+		t.FailNow()
+	}
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel2()
+	if _, err := g.acquire(ctx2); err == nil {
+		t.Fatal("double release admitted a second invocation")
+	}
+	r()
+}
+
+func TestAcquireClosedJob(t *testing.T) {
+	var nilJob *Job2P
+	if _, _, err := nilJob.Acquire(context.Background()); !errors.Is(err, ErrJobClosed) {
+		t.Fatalf("nil job: %v", err)
+	}
+	j := &JobMP{}
+	for i := 0; i < 2; i++ {
+		// The guard is released on failure, so the second call is not busy.
+		if _, _, err := j.Acquire(context.Background()); !errors.Is(err, ErrJobClosed) {
+			t.Fatalf("closed job, call %d: %v", i, err)
+		}
+	}
+}
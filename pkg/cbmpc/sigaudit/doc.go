@@ -0,0 +1,42 @@
+// Package sigaudit provides post-hoc safety checks over ECDSA signatures
+// produced by a single key, such as the outputs of ecdsa2p and ecdsamp.
+//
+// The checks only use public data (message hashes and DER signatures), so the
+// auditor can run alongside a production signer, over signing logs, or in an
+// offline forensics job without access to key shares.
+//
+// # Checks
+//
+//   - Nonce reuse: two signatures over different messages share r (critical;
+//     the private key is recoverable and must be rotated)
+//   - Duplicate signatures: the same message/signature pair recorded twice
+//   - Malleated signatures: the same message signed as (r, s) and (r, q-s)
+//   - Conflicting signatures: the same message and r with unrelated s, which
+//     one key cannot produce
+//   - Range: r and s must lie in [1, q-1]
+//
+// There is no check for biased nonces. r is the x-coordinate of kG, which is
+// uniform even when k is biased, so the signatures alone do not show the bias
+// that lattice attacks exploit.
+//
+// # Usage
+//
+//	auditor, err := sigaudit.NewAuditor(curve.P256)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	// After each signing operation
+//	for _, f := range auditor.Add(msgHash, result.Signature) {
+//	    if f.Severity == sigaudit.SeverityCritical {
+//	        alert(f)
+//	    }
+//	}
+//
+//	// Periodically
+//	if report := auditor.Report(); !report.OK() {
+//	    log.Printf("signature audit: %+v", report.Findings)
+//	}
+//
+// For one-shot audits over stored records use Audit.
+package sigaudit
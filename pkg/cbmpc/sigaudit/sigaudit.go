@@ -0,0 +1,297 @@
+package sigaudit
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+)
+
+// Kind classifies an audit finding.
+type Kind int
+
+const (
+	// KindMalformed means the signature is not a valid DER-encoded ECDSA signature.
+	KindMalformed Kind = iota
+	// KindOutOfRange means r or s is outside [1, q-1].
+	KindOutOfRange
+	// KindNonceReuse means two signatures over different messages share r. The
+	// private key can be recovered from such a pair; rotate the key.
+	KindNonceReuse
+	// KindDuplicateSignature means the same signature was recorded more than once.
+	KindDuplicateSignature
+	// KindMalleated means a signature was recorded both as (r, s) and as its
+	// malleated form (r, q-s) over the same message. Both verify, and nothing
+	// about the key is revealed.
+	KindMalleated
+	// KindConflicting means two signatures over the same message share r but
+	// have unrelated s values. One key and nonce cannot produce both, so at
+	// least one of them does not verify under the audited key.
+	KindConflicting
+)
+
+// String returns a human-readable name for the finding kind.
+func (k Kind) String() string {
+	switch k {
+	case KindMalformed:
+		return "malformed"
+	case KindOutOfRange:
+		return "out-of-range"
+	case KindNonceReuse:
+		return "nonce-reuse"
+	case KindDuplicateSignature:
+		return "duplicate-signature"
+	case KindMalleated:
+		return "malleated"
+	case KindConflicting:
+		return "conflicting"
+	default:
+		return "unknown"
+	}
+}
+
+// Severity ranks findings by urgency.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+// String returns a human-readable name for the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// Finding describes a single anomaly. Indices refer to the order in which
+// signatures were added to the Auditor.
+type Finding struct {
+	Kind     Kind
+	Severity Severity
+	Indices  []int
+	Detail   string
+}
+
+// Report is the result of an audit.
+type Report struct {
+	Curve      curve.Curve
+	Signatures int
+	Findings   []Finding
+}
+
+// OK reports whether the audit produced no warnings or critical findings.
+func (r *Report) OK() bool {
+	if r == nil {
+		return false
+	}
+	for _, f := range r.Findings {
+		if f.Severity >= SeverityWarning {
+			return false
+		}
+	}
+	return true
+}
+
+type seen struct {
+	index   int
+	message []byte
+	s       *big.Int
+}
+
+// Auditor accumulates ECDSA signatures produced by a single key and flags
+// nonce reuse and other anomalies. It only needs public data (message
+// hashes and signatures), so it can run next to a production signer as a
+// post-hoc safety monitor.
+//
+// Auditor is safe for concurrent use.
+type Auditor struct {
+	curve curve.Curve
+	order *big.Int
+
+	mu       sync.Mutex
+	count    int
+	byR      map[string][]seen
+	findings []Finding
+}
+
+// NewAuditor returns an Auditor for signatures on curve c. Only ECDSA curves
+// are supported (P-256, P-384, P-521, secp256k1).
+func NewAuditor(c curve.Curve) (*Auditor, error) {
+	order, err := curveOrder(c)
+	if err != nil {
+		return nil, err
+	}
+	return &Auditor{
+		curve: c,
+		order: order,
+		byR:   make(map[string][]seen),
+	}, nil
+}
+
+// Add records a DER-encoded signature over messageHash and returns the
+// findings it triggers.
+func (a *Auditor) Add(messageHash, derSig []byte) []Finding {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	idx := a.count
+	a.count++
+
+	r, s, err := parseDER(derSig)
+	if err != nil {
+		return a.record(Finding{
+			Kind:     KindMalformed,
+			Severity: SeverityWarning,
+			Indices:  []int{idx},
+			Detail:   err.Error(),
+		})
+	}
+	if !a.inRange(r) || !a.inRange(s) {
+		return a.record(Finding{
+			Kind:     KindOutOfRange,
+			Severity: SeverityCritical,
+			Indices:  []int{idx},
+			Detail:   "r or s outside [1, q-1]",
+		})
+	}
+
+	var out []Finding
+	key := string(r.Bytes())
+	for _, prev := range a.byR[key] {
+		if bytes.Equal(prev.message, messageHash) {
+			out = append(out, a.sameMessage(prev, idx, s))
+			continue
+		}
+		out = append(out, Finding{
+			Kind:     KindNonceReuse,
+			Severity: SeverityCritical,
+			Indices:  []int{prev.index, idx},
+			Detail:   "signatures share r; the private key is recoverable",
+		})
+	}
+	a.byR[key] = append(a.byR[key], seen{
+		index:   idx,
+		message: append([]byte(nil), messageHash...),
+		s:       s,
+	})
+	return a.record(out...)
+}
+
+// sameMessage classifies a signature over the same message and with the same
+// r as an earlier one. Such a pair reveals nothing about the key: the nonce
+// and the message are the same, so s can only differ by sign.
+func (a *Auditor) sameMessage(prev seen, idx int, s *big.Int) Finding {
+	indices := []int{prev.index, idx}
+	switch {
+	case prev.s.Cmp(s) == 0:
+		return Finding{
+			Kind:     KindDuplicateSignature,
+			Severity: SeverityInfo,
+			Indices:  indices,
+			Detail:   "identical message and signature recorded twice",
+		}
+	case new(big.Int).Add(prev.s, s).Cmp(a.order) == 0:
+		return Finding{
+			Kind:     KindMalleated,
+			Severity: SeverityInfo,
+			Indices:  indices,
+			Detail:   "signature recorded with s and with q-s",
+		}
+	default:
+		return Finding{
+			Kind:     KindConflicting,
+			Severity: SeverityWarning,
+			Indices:  indices,
+			Detail:   "same message and r with unrelated s; at least one signature is invalid",
+		}
+	}
+}
+
+// Report returns all findings so far.
+func (a *Auditor) Report() *Report {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	findings := append([]Finding(nil), a.findings...)
+	return &Report{Curve: a.curve, Signatures: a.count, Findings: findings}
+}
+
+// Record is a message hash and its DER-encoded ECDSA signature.
+type Record struct {
+	MessageHash []byte
+	Signature   []byte
+}
+
+// Audit runs every check over records with a new Auditor and returns its
+// report.
+func Audit(c curve.Curve, records []Record) (*Report, error) {
+	a, err := NewAuditor(c)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		a.Add(rec.MessageHash, rec.Signature)
+	}
+	return a.Report(), nil
+}
+
+func (a *Auditor) record(fs ...Finding) []Finding {
+	a.findings = append(a.findings, fs...)
+	return fs
+}
+
+func (a *Auditor) inRange(v *big.Int) bool {
+	return v.Sign() > 0 && v.Cmp(a.order) < 0
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+func parseDER(der []byte) (*big.Int, *big.Int, error) {
+	var sig ecdsaSignature
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid DER signature: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, nil, errors.New("trailing data after DER signature")
+	}
+	if sig.R == nil || sig.S == nil {
+		return nil, nil, errors.New("missing r or s")
+	}
+	return sig.R, sig.S, nil
+}
+
+// secp256k1Order is the group order n of secp256k1 (SEC 2, section 2.4.1).
+var secp256k1Order, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+
+func curveOrder(c curve.Curve) (*big.Int, error) {
+	switch c {
+	case curve.P256:
+		return elliptic.P256().Params().N, nil
+	case curve.P384:
+		return elliptic.P384().Params().N, nil
+	case curve.P521:
+		return elliptic.P521().Params().N, nil
+	case curve.Secp256k1:
+		return secp256k1Order, nil
+	default:
+		return nil, fmt.Errorf("unsupported curve for ECDSA audit: %v", c)
+	}
+}
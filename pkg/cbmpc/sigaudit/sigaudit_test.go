@@ -0,0 +1,151 @@
+package sigaudit_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/sigaudit"
+)
+
+func der(t *testing.T, r, s *big.Int) []byte {
+	t.Helper()
+	out, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	if err != nil {
+		t.Fatalf("asn1.Marshal failed: %v", err)
+	}
+	return out
+}
+
+func hash(msg string) []byte {
+	h := sha256.Sum256([]byte(msg))
+	return h[:]
+}
+
+func TestAuditCleanSignatures(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	var records []sigaudit.Record
+	for i := 0; i < 64; i++ {
+		h := hash(string(rune('a' + i)))
+		sig, err := ecdsa.SignASN1(rand.Reader, key, h)
+		if err != nil {
+			t.Fatalf("SignASN1 failed: %v", err)
+		}
+		records = append(records, sigaudit.Record{MessageHash: h, Signature: sig})
+	}
+
+	report, err := sigaudit.Audit(curve.P256, records)
+	if err != nil {
+		t.Fatalf("Audit failed: %v", err)
+	}
+	if !report.OK() || len(report.Findings) != 0 {
+		t.Fatalf("expected clean report, got %+v", report.Findings)
+	}
+	if report.Signatures != len(records) {
+		t.Fatalf("expected %d signatures, got %d", len(records), report.Signatures)
+	}
+}
+
+func TestAuditNonceReuse(t *testing.T) {
+	a, err := sigaudit.NewAuditor(curve.Secp256k1)
+	if err != nil {
+		t.Fatalf("NewAuditor failed: %v", err)
+	}
+
+	r := big.NewInt(0).Lsh(big.NewInt(1), 250)
+	if fs := a.Add(hash("one"), der(t, r, big.NewInt(11))); len(fs) != 0 {
+		t.Fatalf("unexpected findings on first signature: %+v", fs)
+	}
+	fs := a.Add(hash("two"), der(t, r, big.NewInt(12)))
+	if len(fs) != 1 || fs[0].Kind != sigaudit.KindNonceReuse || fs[0].Severity != sigaudit.SeverityCritical {
+		t.Fatalf("expected critical nonce reuse, got %+v", fs)
+	}
+	if fs[0].Indices[0] != 0 || fs[0].Indices[1] != 1 {
+		t.Fatalf("unexpected indices %v", fs[0].Indices)
+	}
+	if a.Report().OK() {
+		t.Fatal("report with nonce reuse must not be OK")
+	}
+}
+
+func TestAuditDuplicateSignature(t *testing.T) {
+	a, err := sigaudit.NewAuditor(curve.P256)
+	if err != nil {
+		t.Fatalf("NewAuditor failed: %v", err)
+	}
+
+	sig := der(t, big.NewInt(1234567), big.NewInt(7654321))
+	a.Add(hash("same"), sig)
+	fs := a.Add(hash("same"), sig)
+	if len(fs) != 1 || fs[0].Kind != sigaudit.KindDuplicateSignature {
+		t.Fatalf("expected duplicate signature finding, got %+v", fs)
+	}
+	if !a.Report().OK() {
+		t.Fatal("duplicate signatures alone should not fail the report")
+	}
+}
+
+func TestAuditMalformedAndOutOfRange(t *testing.T) {
+	a, err := sigaudit.NewAuditor(curve.P256)
+	if err != nil {
+		t.Fatalf("NewAuditor failed: %v", err)
+	}
+
+	fs := a.Add(hash("m"), []byte{0x30, 0x01})
+	if len(fs) != 1 || fs[0].Kind != sigaudit.KindMalformed {
+		t.Fatalf("expected malformed finding, got %+v", fs)
+	}
+
+	n := elliptic.P256().Params().N
+	fs = a.Add(hash("m"), der(t, n, big.NewInt(1)))
+	if len(fs) != 1 || fs[0].Kind != sigaudit.KindOutOfRange {
+		t.Fatalf("expected out-of-range finding, got %+v", fs)
+	}
+	fs = a.Add(hash("m"), der(t, big.NewInt(1), big.NewInt(0)))
+	if len(fs) != 1 || fs[0].Kind != sigaudit.KindOutOfRange {
+		t.Fatalf("expected out-of-range finding, got %+v", fs)
+	}
+}
+
+func TestAuditSameMessage(t *testing.T) {
+	a, err := sigaudit.NewAuditor(curve.P256)
+	if err != nil {
+		t.Fatalf("NewAuditor failed: %v", err)
+	}
+
+	n := elliptic.P256().Params().N
+	r, s := big.NewInt(1234567), big.NewInt(7654321)
+	a.Add(hash("same"), der(t, r, s))
+	fs := a.Add(hash("same"), der(t, r, new(big.Int).Sub(n, s)))
+	if len(fs) != 1 || fs[0].Kind != sigaudit.KindMalleated || fs[0].Severity != sigaudit.SeverityInfo {
+		t.Fatalf("expected malleation finding, got %+v", fs)
+	}
+	if !a.Report().OK() {
+		t.Fatal("a malleated signature alone should not fail the report")
+	}
+
+	b, err := sigaudit.NewAuditor(curve.P256)
+	if err != nil {
+		t.Fatalf("NewAuditor failed: %v", err)
+	}
+	b.Add(hash("same"), der(t, r, s))
+	fs = b.Add(hash("same"), der(t, r, big.NewInt(42)))
+	if len(fs) != 1 || fs[0].Kind != sigaudit.KindConflicting || fs[0].Severity != sigaudit.SeverityWarning {
+		t.Fatalf("expected conflicting signature finding, got %+v", fs)
+	}
+}
+
+func TestNewAuditorUnsupportedCurve(t *testing.T) {
+	if _, err := sigaudit.NewAuditor(curve.Ed25519); err == nil {
+		t.Fatal("expected error for Ed25519")
+	}
+}
@@ -0,0 +1,34 @@
+// Package signcache provides an optional idempotency layer for signing
+// requests.
+//
+// Clients retry. Without deduplication every retry of a sign request starts a
+// new MPC ceremony and produces a second, different signature over the same
+// payload. A Cache keyed by (key ID, message hash, session) returns the
+// signature produced by the first run instead:
+//
+//	store, _ := signcache.NewMemoryStore(10000, time.Hour)
+//	cache, _ := signcache.New(store)
+//
+//	req := signcache.Request{KeyID: keyID, MessageHash: hash, SessionID: requestID}
+//	sig, cached, err := cache.Do(ctx, req, func(ctx context.Context) ([]byte, error) {
+//	    res, err := ecdsa2p.Sign(ctx, job, &ecdsa2p.SignParams{Key: key, Message: hash})
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return res.Signature, nil
+//	})
+//
+// Concurrent identical requests are coalesced into one run. Errors are never
+// cached, and callers waiting on a run that fails receive its error.
+//
+// # Placement
+//
+// Every party must make the same cache decision: if one party answers from
+// its cache while the others start the protocol, they block waiting for it.
+// Consult the cache where ceremonies are initiated (the coordinator or API
+// front end), or give every party the same shared Store.
+//
+// SessionID scopes deduplication. Use a client-supplied idempotency token to
+// allow intentional re-signing of the same payload under a new token, or leave
+// it empty to sign each (key, message) pair at most once per cache lifetime.
+package signcache
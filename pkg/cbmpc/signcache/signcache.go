@@ -0,0 +1,218 @@
+package signcache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Request identifies a signing request for deduplication. Two requests with
+// the same key ID, message hash and session are the same request.
+type Request struct {
+	KeyID       string // Stable identifier of the signing key
+	MessageHash []byte // Pre-hashed message, as passed to Sign
+	SessionID   []byte // Caller-chosen session or idempotency token; may be empty
+}
+
+// Key returns the cache key for r: a hex SHA-256 digest over the
+// length-prefixed fields, so the raw message hash is never used as a store key.
+func (r Request) Key() string {
+	h := sha256.New()
+	h.Write([]byte("cbmpc/signcache/v1"))
+	for _, f := range [][]byte{[]byte(r.KeyID), r.MessageHash, r.SessionID} {
+		_ = binary.Write(h, binary.BigEndian, uint32(len(f)))
+		h.Write(f)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Store persists signatures by cache key. Implementations must be safe for
+// concurrent use. A shared store (e.g. Redis) lets retries that land on a
+// different node reuse the signature.
+type Store interface {
+	// Get returns the signature stored under key, or ok=false.
+	Get(ctx context.Context, key string) (sig []byte, ok bool, err error)
+	// Put stores sig under key.
+	Put(ctx context.Context, key string, sig []byte) error
+}
+
+// SignFunc runs the signing protocol and returns the signature.
+type SignFunc func(ctx context.Context) ([]byte, error)
+
+// Cache deduplicates signing requests. A request that matches a stored
+// signature returns it without running the protocol, and concurrent identical
+// requests within one process share a single protocol run. Failed runs are not
+// cached, so a retry after an error runs the protocol again.
+type Cache struct {
+	store Store
+
+	mu       sync.Mutex
+	inflight map[string]*call
+}
+
+type call struct {
+	done chan struct{}
+	sig  []byte
+	err  error
+}
+
+// New returns a Cache backed by store.
+func New(store Store) (*Cache, error) {
+	if store == nil {
+		return nil, errors.New("nil store")
+	}
+	return &Cache{store: store, inflight: make(map[string]*call)}, nil
+}
+
+// Do returns the signature for req, calling sign only if no signature is
+// stored and no identical request is in flight. cached reports whether the
+// signature came from the store or from another caller's run.
+func (c *Cache) Do(ctx context.Context, req Request, sign SignFunc) (sig []byte, cached bool, err error) {
+	if sign == nil {
+		return nil, false, errors.New("nil sign function")
+	}
+	if req.KeyID == "" {
+		return nil, false, errors.New("empty key ID")
+	}
+	if len(req.MessageHash) == 0 {
+		return nil, false, errors.New("empty message hash")
+	}
+	key := req.Key()
+
+	c.mu.Lock()
+	if cl, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-cl.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		if cl.err != nil {
+			return nil, false, cl.err
+		}
+		return append([]byte(nil), cl.sig...), true, nil
+	}
+	cl := &call{done: make(chan struct{})}
+	c.inflight[key] = cl
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.inflight, key)
+		c.mu.Unlock()
+		close(cl.done)
+	}()
+
+	stored, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		cl.err = err
+		return nil, false, err
+	}
+	if ok {
+		cl.sig = stored
+		return append([]byte(nil), stored...), true, nil
+	}
+
+	sig, err = sign(ctx)
+	if err != nil {
+		cl.err = err
+		return nil, false, err
+	}
+	if err := c.store.Put(ctx, key, sig); err != nil {
+		// The signature exists; hand it out but report that it was not
+		// recorded so the caller can decide whether retries are safe.
+		cl.sig = sig
+		return append([]byte(nil), sig...), false, err
+	}
+	cl.sig = sig
+	return append([]byte(nil), sig...), false, nil
+}
+
+// MemoryStore is an in-process Store with LRU eviction and an optional TTL.
+type MemoryStore struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	order *list.List // front = most recently used
+	items map[string]*list.Element
+}
+
+type memEntry struct {
+	key     string
+	sig     []byte
+	expires time.Time
+}
+
+// NewMemoryStore returns a store holding at most capacity signatures, each for
+// at most ttl. A zero ttl keeps entries until evicted.
+func NewMemoryStore(capacity int, ttl time.Duration) (*MemoryStore, error) {
+	if capacity <= 0 {
+		return nil, errors.New("capacity must be positive")
+	}
+	if ttl < 0 {
+		return nil, errors.New("ttl must not be negative")
+	}
+	return &MemoryStore{
+		capacity: capacity,
+		ttl:      ttl,
+		now:      time.Now,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}, nil
+}
+
+// Get implements Store.
+func (m *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	e := el.Value.(*memEntry)
+	if !e.expires.IsZero() && !m.now().Before(e.expires) {
+		m.order.Remove(el)
+		delete(m.items, key)
+		return nil, false, nil
+	}
+	m.order.MoveToFront(el)
+	return append([]byte(nil), e.sig...), true, nil
+}
+
+// Put implements Store.
+func (m *MemoryStore) Put(_ context.Context, key string, sig []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var expires time.Time
+	if m.ttl > 0 {
+		expires = m.now().Add(m.ttl)
+	}
+	e := &memEntry{key: key, sig: append([]byte(nil), sig...), expires: expires}
+	if el, ok := m.items[key]; ok {
+		el.Value = e
+		m.order.MoveToFront(el)
+		return nil
+	}
+	m.items[key] = m.order.PushFront(e)
+	for m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.items, oldest.Value.(*memEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of stored signatures, including expired entries that
+// have not been looked up since expiring.
+func (m *MemoryStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
@@ -0,0 +1,179 @@
+package signcache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCache(t *testing.T) (*Cache, *MemoryStore) {
+	t.Helper()
+	store, err := NewMemoryStore(16, 0)
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	cache, err := New(store)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return cache, store
+}
+
+func TestDoReturnsStoredSignature(t *testing.T) {
+	cache, _ := newCache(t)
+	ctx := context.Background()
+	req := Request{KeyID: "k1", MessageHash: []byte{1, 2, 3}}
+
+	var runs int
+	sign := func(context.Context) ([]byte, error) {
+		runs++
+		return []byte{byte(runs)}, nil
+	}
+
+	sig1, cached, err := cache.Do(ctx, req, sign)
+	if err != nil || cached {
+		t.Fatalf("first Do: sig=%x cached=%v err=%v", sig1, cached, err)
+	}
+	sig2, cached, err := cache.Do(ctx, req, sign)
+	if err != nil || !cached {
+		t.Fatalf("second Do: cached=%v err=%v", cached, err)
+	}
+	if !bytes.Equal(sig1, sig2) || runs != 1 {
+		t.Fatalf("expected one run and identical signatures, got runs=%d %x %x", runs, sig1, sig2)
+	}
+
+	// A different session is a different request.
+	req.SessionID = []byte("retry-token")
+	if _, cached, _ := cache.Do(ctx, req, sign); cached || runs != 2 {
+		t.Fatalf("new session should run the protocol (cached=%v runs=%d)", cached, runs)
+	}
+}
+
+func TestDoDoesNotCacheErrors(t *testing.T) {
+	cache, store := newCache(t)
+	ctx := context.Background()
+	req := Request{KeyID: "k1", MessageHash: []byte{1}}
+
+	boom := errors.New("boom")
+	if _, _, err := cache.Do(ctx, req, func(context.Context) ([]byte, error) { return nil, boom }); !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+	if store.Len() != 0 {
+		t.Fatal("failed run should not be stored")
+	}
+	sig, cached, err := cache.Do(ctx, req, func(context.Context) ([]byte, error) { return []byte{9}, nil })
+	if err != nil || cached || !bytes.Equal(sig, []byte{9}) {
+		t.Fatalf("retry after error: sig=%x cached=%v err=%v", sig, cached, err)
+	}
+}
+
+func TestDoCoalescesConcurrentRequests(t *testing.T) {
+	cache, _ := newCache(t)
+	ctx := context.Background()
+	req := Request{KeyID: "k1", MessageHash: []byte{7}}
+
+	var runs atomic.Int32
+	release := make(chan struct{})
+	sign := func(context.Context) ([]byte, error) {
+		runs.Add(1)
+		<-release
+		return []byte{42}, nil
+	}
+
+	const callers = 8
+	var wg sync.WaitGroup
+	sigs := make([][]byte, callers)
+	started := make(chan struct{}, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			started <- struct{}{}
+			sig, _, err := cache.Do(ctx, req, sign)
+			if err != nil {
+				t.Errorf("Do: %v", err)
+			}
+			sigs[i] = sig
+		}(i)
+	}
+	for i := 0; i < callers; i++ {
+		<-started
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if runs.Load() != 1 {
+		t.Fatalf("expected 1 protocol run, got %d", runs.Load())
+	}
+	for i, sig := range sigs {
+		if !bytes.Equal(sig, []byte{42}) {
+			t.Fatalf("caller %d got %x", i, sig)
+		}
+	}
+}
+
+func TestDoInvalid(t *testing.T) {
+	cache, _ := newCache(t)
+	ctx := context.Background()
+	ok := func(context.Context) ([]byte, error) { return []byte{1}, nil }
+	if _, _, err := cache.Do(ctx, Request{MessageHash: []byte{1}}, ok); err == nil {
+		t.Error("expected error for empty key ID")
+	}
+	if _, _, err := cache.Do(ctx, Request{KeyID: "k"}, ok); err == nil {
+		t.Error("expected error for empty message hash")
+	}
+	if _, _, err := cache.Do(ctx, Request{KeyID: "k", MessageHash: []byte{1}}, nil); err == nil {
+		t.Error("expected error for nil sign function")
+	}
+	if _, err := New(nil); err == nil {
+		t.Error("expected error for nil store")
+	}
+}
+
+func TestRequestKeyUnambiguous(t *testing.T) {
+	a := Request{KeyID: "ab", MessageHash: []byte("c")}
+	b := Request{KeyID: "a", MessageHash: []byte("bc")}
+	if a.Key() == b.Key() {
+		t.Fatal("field boundaries must be part of the key")
+	}
+}
+
+func TestMemoryStoreEvictionAndTTL(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewMemoryStore(2, time.Minute)
+	if err != nil {
+		t.Fatalf("NewMemoryStore: %v", err)
+	}
+	now := time.Unix(0, 0)
+	store.now = func() time.Time { return now }
+
+	_ = store.Put(ctx, "a", []byte{1})
+	_ = store.Put(ctx, "b", []byte{2})
+	if _, ok, _ := store.Get(ctx, "a"); !ok {
+		t.Fatal("a should be present")
+	}
+	_ = store.Put(ctx, "c", []byte{3}) // evicts b, the least recently used
+	if _, ok, _ := store.Get(ctx, "b"); ok {
+		t.Fatal("b should have been evicted")
+	}
+	if _, ok, _ := store.Get(ctx, "a"); !ok {
+		t.Fatal("a should survive eviction")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok, _ := store.Get(ctx, "c"); ok {
+		t.Fatal("c should have expired")
+	}
+
+	if _, err := NewMemoryStore(0, 0); err == nil {
+		t.Error("expected error for zero capacity")
+	}
+	if _, err := NewMemoryStore(1, -time.Second); err == nil {
+		t.Error("expected error for negative ttl")
+	}
+}
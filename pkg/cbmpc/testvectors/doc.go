@@ -0,0 +1,49 @@
+// Package testvectors ships reference inputs and outputs for the wrapper's
+// protocols, keyed by protocol and format version, and helpers to check a
+// build against them. Integrators wrapping the library in another language
+// or packaging their own native build can run the vectors to confirm their
+// outputs are accepted, and that tampered ones are rejected.
+//
+//	if err := testvectors.CheckAll(false); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Vector files are JSON and embedded in the package; All, Load and Parse
+// read them, and Set.Marshal writes them. Each Vector carries hex-encoded
+// byte fields and, for negative cases, Invalid. Check reports ErrMismatch if
+// this build's verdict differs from the vector and ErrUnsupported if it
+// cannot verify the protocol at all.
+//
+// # Protocols
+//
+// Signature vectors are verified in pure Go and apply to both the two-party
+// and multi-party protocols:
+//
+//   - ecdsa.Sign: public_key (compressed SEC1), message (hash) and signature
+//     (DER) on P-256, P-384, P-521 and secp256k1
+//   - schnorr.Sign: public_key, message and signature for EdDSA on Ed25519
+//     and BIP340 on secp256k1
+//
+// Vectors for the protocols below are produced and, except for DKG
+// transcripts, verified by the native library, so Check reports
+// ErrUnsupported for PVE and ZK vectors in a build without native bindings.
+// Their files are written to data by TestGenerate in a build with native
+// bindings:
+//
+//   - ecdsa2p.DKG: public_key and the unredacted JSON transcripts
+//     transcript_p1 and transcript_p2 recorded by package transcript, which
+//     must agree message for message
+//   - pve.Encrypt: ek (RSA KEM, PKIX DER), ciphertext, q and label
+//   - zk.DH: q, a, b, proof, session_id and aux (8 bytes, big-endian)
+//
+// New formats get a new version rather than changing an existing file, so a
+// downstream test pinned to a version keeps passing.
+//
+// # Golden Files
+//
+// Golden compares deterministic output, such as a serialized envelope,
+// against a file under testdata and regenerates it when CBMPC_UPDATE_GOLDEN
+// is set:
+//
+//	testvectors.Golden(t, "testdata/envelope.golden", envelope)
+package testvectors
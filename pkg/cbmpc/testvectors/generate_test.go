@@ -0,0 +1,232 @@
+//go:build cgo && !windows
+
+package testvectors_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/ecdsa2p"
+	rsakem "github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/rsa"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/testvectors"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/transcript"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/zk"
+)
+
+// generateEnv names the directory TestGenerate writes vector files to. The
+// vectors that need native bindings to produce are generated with
+//
+//	make testvectors
+const generateEnv = "CBMPC_GENERATE_TESTVECTORS"
+
+func TestGenerate(t *testing.T) {
+	dir := os.Getenv(generateEnv)
+	if dir == "" {
+		t.Skipf("set %s to generate vector files", generateEnv)
+	}
+	for name, gen := range map[string]func(*testing.T) *testvectors.Set{
+		"ecdsa2p-dkg-v1.json": generateECDSA2PDKG,
+		"pve-encrypt-v1.json": generatePVE,
+		"zk-dh-v1.json":       generateZKDH,
+	} {
+		raw, err := gen(t).Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), raw, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestNativeVectors fails while a protocol produced by the native library
+// has no committed vector file.
+func TestNativeVectors(t *testing.T) {
+	for _, protocol := range []string{testvectors.ProtocolECDSA2PDKG, testvectors.ProtocolPVE, testvectors.ProtocolZKDH} {
+		set, err := testvectors.Load(protocol, 1)
+		if err != nil {
+			t.Fatalf("%v; generate it with %s", err, generateEnv)
+		}
+		if len(set.Vectors) == 0 {
+			t.Fatalf("%s v1 has no vectors", protocol)
+		}
+	}
+}
+
+func generateECDSA2PDKG(t *testing.T) *testvectors.Set {
+	set := &testvectors.Set{
+		Protocol:    testvectors.ProtocolECDSA2PDKG,
+		Version:     1,
+		Description: "Two-party ECDSA DKG: compressed public key and both parties' unredacted transcripts.",
+	}
+	for _, c := range []cbmpc.Curve{cbmpc.CurveP256, cbmpc.CurveSecp256k1} {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		net := mocknet.New()
+		recs := make([]*transcript.Recorder, 2)
+		pubs := make([][]byte, 2)
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		for i, role := range []cbmpc.Role{cbmpc.RoleP1, cbmpc.RoleP2} {
+			wg.Add(1)
+			go func(i int, role cbmpc.Role) {
+				defer wg.Done()
+				recs[i], errs[i] = transcript.NewRecorder(net.Ep2P(cbmpc.RoleID(i), cbmpc.RoleID(1-i)), cbmpc.RoleID(i), "ecdsa2p.DKG", nil)
+				if errs[i] != nil {
+					return
+				}
+				job, err := cbmpc.NewJob2PWithContext(ctx, recs[i], role, [2]string{"p1", "p2"})
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				defer func() { _ = job.Close() }()
+				res, err := ecdsa2p.DKG(ctx, job, &ecdsa2p.DKGParams{Curve: c})
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				defer func() { _ = res.Key.Close() }()
+				pubs[i], errs[i] = res.Key.PublicKey()
+			}(i, role)
+		}
+		wg.Wait()
+		cancel()
+		for _, err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		fields := map[string]testvectors.Bytes{"public_key": pubs[0]}
+		for i, name := range []string{"transcript_p1", "transcript_p2"} {
+			raw, err := json.Marshal(recs[i].Transcript())
+			if err != nil {
+				t.Fatal(err)
+			}
+			fields[name] = raw
+		}
+		set.Vectors = append(set.Vectors, testvectors.Vector{Name: c.String(), Curve: c.String(), Fields: fields})
+	}
+	return set
+}
+
+func generatePVE(t *testing.T) *testvectors.Set {
+	kem, err := rsakem.New(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ek, err := kem.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := pve.New(kem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := &testvectors.Set{
+		Protocol:    testvectors.ProtocolPVE,
+		Version:     1,
+		Description: "PVE ciphertexts under a 2048-bit RSA KEM: PKIX DER encryption key, ciphertext, public point Q and label.",
+	}
+	for _, c := range []cbmpc.Curve{cbmpc.CurveP256, cbmpc.CurveSecp256k1, cbmpc.CurveEd25519} {
+		x, err := curve.RandomScalar(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, err := curve.MulGenerator(c, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		qBytes, err := q.Bytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		label := []byte("cb-mpc test vector " + c.String())
+		res, err := p.Encrypt(context.Background(), &pve.EncryptParams{EK: ek, Label: label, Curve: c, X: x})
+		if err != nil {
+			t.Fatal(err)
+		}
+		q.Free()
+		x.Free()
+		fields := map[string]testvectors.Bytes{"ek": ek, "ciphertext": res.Ciphertext, "q": qBytes, "label": label}
+		set.Vectors = append(set.Vectors, testvectors.Vector{Name: c.String(), Curve: c.String(), Fields: fields})
+
+		wrong := map[string]testvectors.Bytes{"label": []byte("wrong label")}
+		for k, v := range fields {
+			if _, ok := wrong[k]; !ok {
+				wrong[k] = v
+			}
+		}
+		set.Vectors = append(set.Vectors, testvectors.Vector{Name: c.String() + "-wrong-label", Curve: c.String(), Fields: wrong, Invalid: true})
+	}
+	return set
+}
+
+func generateZKDH(t *testing.T) *testvectors.Set {
+	set := &testvectors.Set{
+		Protocol:    testvectors.ProtocolZKDH,
+		Version:     1,
+		Description: "Diffie-Hellman proofs that A = w*G and B = w*Q: points, proof, session ID and aux (8 bytes, big-endian).",
+	}
+	for _, c := range []cbmpc.Curve{cbmpc.CurveP256, cbmpc.CurveSecp256k1} {
+		scalars := make([]*curve.Scalar, 2)
+		for i := range scalars {
+			s, err := curve.RandomScalar(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Free()
+			scalars[i] = s
+		}
+		q, err := curve.MulGenerator(c, scalars[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer q.Free()
+		a, err := curve.MulGenerator(c, scalars[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer a.Free()
+		b, err := q.Mul(scalars[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer b.Free()
+
+		sid := make([]byte, 32)
+		if _, err := rand.Read(sid); err != nil {
+			t.Fatal(err)
+		}
+		const aux = 1
+		proof, err := zk.ProveDH(&zk.DHProveParams{Q: q, A: a, B: b, Exponent: scalars[1], SessionID: cbmpc.NewSessionID(sid), Aux: aux})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fields := map[string]testvectors.Bytes{"proof": proof, "session_id": sid, "aux": binary.BigEndian.AppendUint64(nil, aux)}
+		for name, p := range map[string]*curve.Point{"q": q, "a": a, "b": b} {
+			if fields[name], err = p.Bytes(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		set.Vectors = append(set.Vectors, testvectors.Vector{Name: c.String(), Curve: c.String(), Fields: fields})
+
+		wrong := make(map[string]testvectors.Bytes, len(fields))
+		for k, v := range fields {
+			wrong[k] = v
+		}
+		wrong["aux"] = binary.BigEndian.AppendUint64(nil, aux+1)
+		set.Vectors = append(set.Vectors, testvectors.Vector{Name: c.String() + "-wrong-aux", Curve: c.String(), Fields: wrong, Invalid: true})
+	}
+	return set
+}
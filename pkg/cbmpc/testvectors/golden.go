@@ -0,0 +1,42 @@
+package testvectors
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// UpdateEnv names the environment variable that makes Golden rewrite golden
+// files instead of comparing against them.
+const UpdateEnv = "CBMPC_UPDATE_GOLDEN"
+
+// Golden compares got with the content of the golden file at path and fails
+// t if they differ. With UpdateEnv set to a non-empty value it writes got to
+// path instead, creating directories as needed, so that golden files can be
+// regenerated with
+//
+//	CBMPC_UPDATE_GOLDEN=1 go test ./...
+//
+// Golden is meant for wrapper outputs that are deterministic, such as
+// serialized keys and envelopes; use Check for randomized outputs like
+// signatures.
+func Golden(t testing.TB, path string, got []byte) {
+	t.Helper()
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("testvectors: reading golden file (set %s=1 to create it): %v", UpdateEnv, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("testvectors: output differs from %s (set %s=1 to update):\ngot:  %q\nwant: %q", path, UpdateEnv, got, want)
+	}
+}
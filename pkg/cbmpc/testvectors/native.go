@@ -0,0 +1,94 @@
+//go:build cgo && !windows
+
+package testvectors
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	rsakem "github.com/coinbase/cb-mpc-go/pkg/cbmpc/kem/rsa"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/zk"
+)
+
+func init() {
+	verifiers[ProtocolPVE] = verifyPVE
+	verifiers[ProtocolZKDH] = verifyZKDH
+}
+
+// verifyPVE checks a PVE ciphertext under the RSA KEM. Fields: ek (PKIX DER
+// RSA public key), ciphertext, q (the encrypted scalar's public point) and
+// label.
+func verifyPVE(v *Vector) error {
+	c, err := v.CurveID()
+	if err != nil {
+		return err
+	}
+	fields, err := v.fields("ek", "ciphertext", "q", "label")
+	if err != nil {
+		return err
+	}
+	pub, err := x509.ParsePKIXPublicKey(fields[0])
+	if err != nil {
+		return err
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("ek is not an RSA public key")
+	}
+	kem, err := rsakem.New(rsaPub.Size() * 8)
+	if err != nil {
+		return err
+	}
+	p, err := pve.New(kem)
+	if err != nil {
+		return err
+	}
+	q, err := curve.NewPointFromBytes(c, fields[2])
+	if err != nil {
+		return err
+	}
+	defer q.Free()
+	return p.Verify(context.Background(), &pve.VerifyParams{
+		EK:         fields[0],
+		Ciphertext: pve.Ciphertext(fields[1]),
+		Q:          q,
+		Label:      fields[3],
+	})
+}
+
+// verifyZKDH checks a Diffie-Hellman proof. Fields: q, a, b (points), proof,
+// session_id and aux (8 bytes, big-endian).
+func verifyZKDH(v *Vector) error {
+	c, err := v.CurveID()
+	if err != nil {
+		return err
+	}
+	fields, err := v.fields("q", "a", "b", "proof", "session_id", "aux")
+	if err != nil {
+		return err
+	}
+	if len(fields[5]) != 8 {
+		return errors.New("aux must be 8 bytes")
+	}
+	var points [3]*curve.Point
+	for i := range points {
+		if points[i], err = curve.NewPointFromBytes(c, fields[i]); err != nil {
+			return err
+		}
+		defer points[i].Free()
+	}
+	return zk.VerifyDH(&zk.DHVerifyParams{
+		Proof:     zk.DHProof(fields[3]),
+		Q:         points[0],
+		A:         points[1],
+		B:         points[2],
+		SessionID: cbmpc.NewSessionID(fields[4]),
+		Aux:       binary.BigEndian.Uint64(fields[5]),
+	})
+}
@@ -0,0 +1,179 @@
+package testvectors
+
+import (
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// Protocols with reference vectors. Signature vectors are shared by the
+// two-party and multi-party variants, whose outputs have the same format.
+const (
+	ProtocolECDSASign   = "ecdsa.Sign"
+	ProtocolSchnorrSign = "schnorr.Sign"
+	ProtocolECDSA2PDKG  = "ecdsa2p.DKG"
+	ProtocolPVE         = "pve.Encrypt"
+	ProtocolZKDH        = "zk.DH"
+)
+
+// ErrNotFound is returned by Load when no vectors exist for the requested
+// protocol and version.
+var ErrNotFound = errors.New("testvectors: no vectors for protocol and version")
+
+//go:embed data/*.json
+var data embed.FS
+
+// Set is the content of one vector file: every vector of one protocol at one
+// format version.
+type Set struct {
+	Protocol    string   `json:"protocol"`
+	Version     int      `json:"version"`
+	Description string   `json:"description,omitempty"`
+	Vectors     []Vector `json:"vectors"`
+}
+
+// Vector is one reference input/output pair. Fields holds the byte values the
+// protocol's verifier reads, hex-encoded in the file; the package
+// documentation lists the fields of each protocol. Invalid vectors must be
+// rejected by a correct build.
+type Vector struct {
+	Protocol string           `json:"-"`
+	Version  int              `json:"-"`
+	Name     string           `json:"name"`
+	Curve    string           `json:"curve,omitempty"`
+	Fields   map[string]Bytes `json:"fields"`
+	Invalid  bool             `json:"invalid,omitempty"`
+}
+
+// Bytes is a byte slice encoded as a hex string in JSON.
+type Bytes []byte
+
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(b))
+}
+
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// Field returns the named field, or an error naming the vector if it is
+// missing.
+func (v *Vector) Field(name string) ([]byte, error) {
+	b, ok := v.Fields[name]
+	if !ok {
+		return nil, fmt.Errorf("testvectors: %s: missing field %q", v, name)
+	}
+	return b, nil
+}
+
+func (v *Vector) fields(names ...string) ([][]byte, error) {
+	out := make([][]byte, len(names))
+	for i, name := range names {
+		b, err := v.Field(name)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+// CurveID returns the vector's curve, matched against the names returned by
+// cbmpc.Curve.String.
+func (v *Vector) CurveID() (cbmpc.Curve, error) {
+	for _, c := range []cbmpc.Curve{cbmpc.CurveP256, cbmpc.CurveP384, cbmpc.CurveP521, cbmpc.CurveSecp256k1, cbmpc.CurveEd25519} {
+		if strings.EqualFold(c.String(), v.Curve) {
+			return c, nil
+		}
+	}
+	return cbmpc.CurveUnknown, fmt.Errorf("testvectors: %s: unknown curve %q", v, v.Curve)
+}
+
+// String identifies the vector as protocol/vN/name.
+func (v *Vector) String() string {
+	return fmt.Sprintf("%s/v%d/%s", v.Protocol, v.Version, v.Name)
+}
+
+// All returns every shipped vector set, ordered by protocol and version.
+func All() ([]*Set, error) {
+	names, err := fs.Glob(data, "data/*.json")
+	if err != nil {
+		return nil, err
+	}
+	sets := make([]*Set, 0, len(names))
+	for _, name := range names {
+		raw, err := data.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		s, err := Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("testvectors: %s: %w", path.Base(name), err)
+		}
+		sets = append(sets, s)
+	}
+	slices.SortFunc(sets, func(a, b *Set) int {
+		if c := strings.Compare(a.Protocol, b.Protocol); c != 0 {
+			return c
+		}
+		return a.Version - b.Version
+	})
+	return sets, nil
+}
+
+// Load returns the vectors of one protocol at one format version.
+func Load(protocol string, version int) (*Set, error) {
+	sets, err := All()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sets {
+		if s.Protocol == protocol && s.Version == version {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s v%d", ErrNotFound, protocol, version)
+}
+
+// Parse decodes a vector set, such as one written by a generator or supplied
+// by another implementation.
+func Parse(raw []byte) (*Set, error) {
+	var s Set
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	if s.Protocol == "" || s.Version <= 0 {
+		return nil, errors.New("missing protocol or version")
+	}
+	for i := range s.Vectors {
+		s.Vectors[i].Protocol = s.Protocol
+		s.Vectors[i].Version = s.Version
+	}
+	return &s, nil
+}
+
+// Marshal encodes s in the format Parse and the embedded files use.
+func (s *Set) Marshal() ([]byte, error) {
+	raw, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(raw, '\n'), nil
+}
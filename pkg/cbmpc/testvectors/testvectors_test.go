@@ -0,0 +1,129 @@
+package testvectors_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/testvectors"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/transcript"
+)
+
+func TestShippedVectors(t *testing.T) {
+	sets, err := testvectors.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) == 0 {
+		t.Fatal("no vector sets shipped")
+	}
+	for _, s := range sets {
+		for i := range s.Vectors {
+			v := &s.Vectors[i]
+			t.Run(v.String(), func(t *testing.T) {
+				err := testvectors.Check(v)
+				if errors.Is(err, testvectors.ErrUnsupported) {
+					t.Skip(err)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	}
+	if err := testvectors.CheckAll(false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckDetectsMismatch(t *testing.T) {
+	set, err := testvectors.Load(testvectors.ProtocolECDSASign, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := set.Vectors[0]
+	sig := append(testvectors.Bytes(nil), v.Fields["signature"]...)
+	sig[len(sig)-1] ^= 1
+	v.Fields = map[string]testvectors.Bytes{
+		"public_key": v.Fields["public_key"],
+		"message":    v.Fields["message"],
+		"signature":  sig,
+	}
+	if err := testvectors.Check(&v); !errors.Is(err, testvectors.ErrMismatch) {
+		t.Fatalf("tampered signature: error = %v, want ErrMismatch", err)
+	}
+	v.Invalid = true
+	if err := testvectors.Check(&v); err != nil {
+		t.Fatalf("tampered signature marked invalid: %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	if _, err := testvectors.Load(testvectors.ProtocolECDSASign, 99); !errors.Is(err, testvectors.ErrNotFound) {
+		t.Fatalf("error = %v, want ErrNotFound", err)
+	}
+	set, err := testvectors.Load(testvectors.ProtocolSchnorrSign, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := set.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := testvectors.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Vectors) != len(set.Vectors) || again.Vectors[0].String() != set.Vectors[0].String() {
+		t.Fatalf("round trip changed the set: %v", again.Vectors[0])
+	}
+}
+
+func TestECDSA2PDKGTranscripts(t *testing.T) {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p1 := &transcript.Transcript{Version: 1, Self: 0, Entries: []transcript.Entry{
+		{Dir: transcript.Sent, Peer: 1, Size: 2, Payload: []byte("m1")},
+		{Dir: transcript.Received, Peer: 1, Size: 2, Payload: []byte("m2")},
+	}}
+	p2 := &transcript.Transcript{Version: 1, Self: 1, Entries: []transcript.Entry{
+		{Dir: transcript.Received, Peer: 0, Size: 2, Payload: []byte("m1")},
+		{Dir: transcript.Sent, Peer: 0, Size: 2, Payload: []byte("m2")},
+	}}
+	vector := func() *testvectors.Vector {
+		t1, _ := json.Marshal(p1)
+		t2, _ := json.Marshal(p2)
+		return &testvectors.Vector{
+			Protocol: testvectors.ProtocolECDSA2PDKG,
+			Version:  1,
+			Name:     "synthetic",
+			Curve:    "P-256",
+			Fields: map[string]testvectors.Bytes{
+				"public_key":    elliptic.MarshalCompressed(elliptic.P256(), k.X, k.Y),
+				"transcript_p1": t1,
+				"transcript_p2": t2,
+			},
+		}
+	}
+	if err := testvectors.Check(vector()); err != nil {
+		t.Fatal(err)
+	}
+	p2.Entries[0].Payload = []byte("m3")
+	if err := testvectors.Check(vector()); !errors.Is(err, testvectors.ErrMismatch) {
+		t.Fatalf("diverging transcripts: error = %v, want ErrMismatch", err)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "out.golden")
+	t.Setenv(testvectors.UpdateEnv, "1")
+	testvectors.Golden(t, path, []byte("hello"))
+	t.Setenv(testvectors.UpdateEnv, "")
+	testvectors.Golden(t, path, []byte("hello"))
+}
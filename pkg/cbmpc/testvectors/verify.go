@@ -0,0 +1,255 @@
+package testvectors
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/transcript"
+)
+
+var (
+	// ErrUnsupported is returned by Check for vectors this build cannot
+	// verify, such as PVE vectors in a build without native bindings.
+	ErrUnsupported = errors.New("testvectors: protocol not verifiable in this build")
+	// ErrMismatch is returned by Check when a valid vector is rejected or an
+	// invalid one accepted.
+	ErrMismatch = errors.New("testvectors: result does not match vector")
+)
+
+// verifiers accept a vector by returning nil. Native verifiers are added by
+// native.go in builds with bindings.
+var verifiers = map[string]func(*Vector) error{
+	ProtocolECDSASign:   verifyECDSA,
+	ProtocolSchnorrSign: verifySchnorr,
+	ProtocolECDSA2PDKG:  verifyECDSA2PDKG,
+}
+
+// Check verifies v with this build and reports whether the result matches
+// the vector: nil if a valid vector is accepted or an invalid one rejected.
+// Otherwise it returns an error wrapping ErrMismatch or ErrUnsupported.
+func Check(v *Vector) error {
+	verify, ok := verifiers[v.Protocol]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnsupported, v)
+	}
+	err := verify(v)
+	switch {
+	case errors.Is(err, ErrUnsupported):
+		return err
+	case err != nil && !v.Invalid:
+		return fmt.Errorf("%w: %s rejected: %v", ErrMismatch, v, err)
+	case err == nil && v.Invalid:
+		return fmt.Errorf("%w: %s is invalid but was accepted", ErrMismatch, v)
+	}
+	return nil
+}
+
+// CheckAll checks every shipped vector and joins the failures. Vectors this
+// build cannot verify are skipped unless strict is set.
+func CheckAll(strict bool) error {
+	sets, err := All()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, s := range sets {
+		for i := range s.Vectors {
+			err := Check(&s.Vectors[i])
+			if errors.Is(err, ErrUnsupported) && !strict {
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// verifyECDSA checks an ECDSA signature. Fields: public_key (compressed
+// SEC1), message (the hash that was signed) and signature (DER).
+func verifyECDSA(v *Vector) error {
+	c, err := v.CurveID()
+	if err != nil {
+		return err
+	}
+	pub, msg, sig, err := signatureFields(v)
+	if err != nil {
+		return err
+	}
+	if c == cbmpc.CurveSecp256k1 {
+		pk, err := btcec.ParsePubKey(pub)
+		if err != nil {
+			return err
+		}
+		s, err := btcecdsa.ParseDERSignature(sig)
+		if err != nil {
+			return err
+		}
+		if !s.Verify(msg, pk) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	ec := ellipticCurve(c)
+	if ec == nil {
+		return fmt.Errorf("curve %s does not support ECDSA", c)
+	}
+	x, y := elliptic.UnmarshalCompressed(ec, pub)
+	if x == nil {
+		return errors.New("invalid public key")
+	}
+	if !ecdsa.VerifyASN1(&ecdsa.PublicKey{Curve: ec, X: x, Y: y}, msg, sig) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// verifySchnorr checks an EdDSA signature on Ed25519 or a BIP340 signature
+// on secp256k1. Fields: public_key (32-byte Ed25519 or compressed SEC1),
+// message (raw for EdDSA, the 32-byte hash for BIP340) and signature.
+func verifySchnorr(v *Vector) error {
+	c, err := v.CurveID()
+	if err != nil {
+		return err
+	}
+	pub, msg, sig, err := signatureFields(v)
+	if err != nil {
+		return err
+	}
+	switch c {
+	case cbmpc.CurveEd25519:
+		if len(pub) != ed25519.PublicKeySize {
+			return errors.New("invalid public key")
+		}
+		if !ed25519.Verify(ed25519.PublicKey(pub), msg, sig) {
+			return errors.New("invalid signature")
+		}
+	case cbmpc.CurveSecp256k1:
+		pk, err := btcec.ParsePubKey(pub)
+		if err != nil {
+			return err
+		}
+		s, err := schnorr.ParseSignature(sig)
+		if err != nil {
+			return err
+		}
+		if !s.Verify(msg, pk) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return fmt.Errorf("curve %s does not support Schnorr", c)
+	}
+	return nil
+}
+
+// verifyECDSA2PDKG checks a two-party DKG run. Fields: public_key
+// (compressed SEC1) and transcript_p1 and transcript_p2, the JSON transcripts
+// recorded by package transcript. Each party must have received exactly what
+// the other sent, in order, and the public key must be a valid point.
+func verifyECDSA2PDKG(v *Vector) error {
+	c, err := v.CurveID()
+	if err != nil {
+		return err
+	}
+	pub, err := v.Field("public_key")
+	if err != nil {
+		return err
+	}
+	if err := checkPoint(c, pub); err != nil {
+		return err
+	}
+	var ts [2]*transcript.Transcript
+	for i, name := range []string{"transcript_p1", "transcript_p2"} {
+		raw, err := v.Field(name)
+		if err != nil {
+			return err
+		}
+		if ts[i], err = transcript.Parse(raw); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if err := matchTranscripts(ts[0], ts[1]); err != nil {
+		return err
+	}
+	return matchTranscripts(ts[1], ts[0])
+}
+
+// matchTranscripts checks that the messages from's party sent to to's party
+// are exactly those to's party received from it, in order.
+func matchTranscripts(from, to *transcript.Transcript) error {
+	var out, in [][]byte
+	for _, e := range from.Entries {
+		if e.Dir == transcript.Sent && e.Peer == to.Self {
+			if e.Redacted {
+				return fmt.Errorf("party %d transcript is redacted", from.Self)
+			}
+			out = append(out, e.Payload)
+		}
+	}
+	for _, e := range to.Entries {
+		if e.Dir == transcript.Received && e.Peer == from.Self {
+			if e.Redacted {
+				return fmt.Errorf("party %d transcript is redacted", to.Self)
+			}
+			in = append(in, e.Payload)
+		}
+	}
+	if len(out) != len(in) {
+		return fmt.Errorf("party %d sent %d messages to party %d, which received %d", from.Self, len(out), to.Self, len(in))
+	}
+	for i := range out {
+		if !bytes.Equal(out[i], in[i]) {
+			return fmt.Errorf("message %d from party %d to party %d differs", i, from.Self, to.Self)
+		}
+	}
+	return nil
+}
+
+func signatureFields(v *Vector) (pub, msg, sig []byte, err error) {
+	f, err := v.fields("public_key", "message", "signature")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return f[0], f[1], f[2], nil
+}
+
+func checkPoint(c cbmpc.Curve, pub []byte) error {
+	switch c {
+	case cbmpc.CurveSecp256k1:
+		_, err := btcec.ParsePubKey(pub)
+		return err
+	case cbmpc.CurveEd25519:
+		if len(pub) != ed25519.PublicKeySize {
+			return errors.New("invalid public key")
+		}
+		return nil
+	}
+	ec := ellipticCurve(c)
+	if ec == nil {
+		return fmt.Errorf("unsupported curve %s", c)
+	}
+	if x, _ := elliptic.UnmarshalCompressed(ec, pub); x == nil {
+		return errors.New("invalid public key")
+	}
+	return nil
+}
+
+func ellipticCurve(c cbmpc.Curve) elliptic.Curve {
+	switch c {
+	case cbmpc.CurveP256:
+		return elliptic.P256()
+	case cbmpc.CurveP384:
+		return elliptic.P384()
+	case cbmpc.CurveP521:
+		return elliptic.P521()
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Package tlsnet provides a cbmpc.Transport over mutual TLS (mTLS) between
+// the parties.
+//
+// Each party has a unique name (e.g. "p0", "p1"), used as the TLS server name
+// and carried in its certificate's Subject CommonName or a DNS SAN. When a
+// connection is established the dialing party announces its role, and the
+// accepting party checks that the client certificate names the party with
+// that role. A root CA given in Config.RootCAs signs every party certificate.
+// TLS 1.3 is the minimum version.
+//
+//	t, err := tlsnet.New(tlsnet.Config{
+//	    Self:        0,
+//	    Names:       []string{"p0", "p1"},
+//	    Addresses:   []string{"10.0.0.1:8443", "10.0.0.2:8443"},
+//	    Certificate: cert,
+//	    RootCAs:     roots,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer t.Close()
+//	job, err := cbmpc.NewJob2P(t, cbmpc.RoleP1, [2]string{"p0", "p1"})
+//
+// # Reconnection
+//
+// Messages are numbered per peer, and every frame acknowledges the messages
+// its sender has received. When a connection fails, or receives nothing for
+// Config.HeartbeatTimeout while idle connections exchange heartbeats, it is
+// dropped and the party listed first in Names dials again with exponential
+// backoff. On reconnecting, the parties exchange the number of the next
+// message each expects and resend what the other missed, so a protocol run
+// continues across the outage. Send queues messages while disconnected;
+// bound Receive with a context deadline so a peer that never returns fails
+// the run. A message that arrives out of sequence, or a peer that was
+// restarted and lost its place, fails with cbmpc.ErrReplay. Status reports
+// each connection for health checks.
+//
+// # Certificate Rotation
+//
+// Set Config.GetCertificate to serve a certificate that can change while the
+// transport runs. CertReloader loads a certificate and key from PEM files and
+// reloads them on SIGHUP or when the files change:
+//
+//	certs, err := tlsnet.NewCertReloader("p0-cert.pem", "p0-key.pem")
+//	if err != nil {
+//	    return err
+//	}
+//	certs.ReloadOnSIGHUP(ctx, func(err error) { log.Print(err) })
+//	certs.Watch(ctx, 30*time.Second, func(err error) { log.Print(err) })
+//	t, err := tlsnet.New(tlsnet.Config{..., GetCertificate: certs.GetCertificate})
+//
+// New connections present the reloaded certificate; established ones keep
+// the certificate they were opened with until they reconnect.
+//
+// # SPIFFE Identities
+//
+// Set Config.SPIFFE to authenticate parties by SPIFFE ID instead of
+// certificate names. Each party presents an X.509-SVID from the configured
+// source, and a peer is accepted only if its SVID chains to the source's
+// trust bundle and carries the SPIFFE ID mapped to its party name:
+//
+//	t, err := tlsnet.New(tlsnet.Config{
+//	    Self:      0,
+//	    Names:     []string{"p0", "p1"},
+//	    Addresses: []string{"10.0.0.1:8443", "10.0.0.2:8443"},
+//	    SPIFFE: &tlsnet.SPIFFEConfig{
+//	        Source: svids,
+//	        IDs: map[string]string{
+//	            "p0": "spiffe://example.org/mpc/p0",
+//	            "p1": "spiffe://example.org/mpc/p1",
+//	        },
+//	    },
+//	})
+//
+// The SVID and bundle are fetched for every connection, so rotation takes
+// effect from the next reconnect. SVIDFiles reads them from the files that
+// SPIRE's spiffe-helper keeps current. The module carries no Workload API
+// client; the SVIDSource documentation shows an adapter for go-spiffe's
+// workloadapi.X509Source, which watches the API for rotations.
+//
+// # Networks
+//
+// Connections run over TLS on TCP unless Config.Network supplies another
+// carrier. The separate quicnet module implements Network over QUIC, with
+// the same authentication, framing and reconnection.
+//
+// GenerateCertificates writes a demo CA and party certificates for local
+// runs; see examples/tlsnet/cmd/gen-certs. In production, issue certificates
+// with proper hostnames and lifetimes from your own CA.
+package tlsnet
@@ -0,0 +1,62 @@
+package tlsnet
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+)
+
+// Network carries the connections of a Transport. Listen and Dial secure
+// every connection with the TLS configuration they are given; the Transport
+// authenticates the peer from the connection's ConnectionState and runs its
+// framing, acknowledgements and resends on top.
+//
+// The connections Listen accepts and Dial returns must have a
+// ConnectionState() tls.ConnectionState method, as *tls.Conn has. A
+// connection that also has HandshakeContext(context.Context) error, such as
+// one accepted by tls.NewListener, is handshaken by the Transport; others
+// must be handshaken already. The quicnet module provides a Network over
+// QUIC.
+type Network interface {
+	Listen(addr string, cfg *tls.Config) (net.Listener, error)
+	Dial(ctx context.Context, addr string, cfg *tls.Config) (net.Conn, error)
+}
+
+// tcpNetwork is the default Network: TLS over TCP.
+type tcpNetwork struct{}
+
+func (tcpNetwork) Listen(addr string, cfg *tls.Config) (net.Listener, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return tls.NewListener(ln, cfg), nil
+}
+
+func (tcpNetwork) Dial(ctx context.Context, addr string, cfg *tls.Config) (net.Conn, error) {
+	d := tls.Dialer{Config: cfg}
+	return d.DialContext(ctx, "tcp", addr)
+}
+
+// secureConn is a connection that reports its TLS state.
+type secureConn interface {
+	net.Conn
+	ConnectionState() tls.ConnectionState
+}
+
+// handshake completes the TLS handshake of c if it has not run yet.
+func handshake(ctx context.Context, c net.Conn) (secureConn, error) {
+	sc, ok := c.(secureConn)
+	if !ok {
+		return nil, errors.New("tlsnet: connection has no TLS state")
+	}
+	if h, ok := c.(interface {
+		HandshakeContext(context.Context) error
+	}); ok {
+		if err := h.HandshakeContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return sc, nil
+}
@@ -0,0 +1,135 @@
+package tlsnet
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// CertReloader holds a certificate and key loaded from PEM files and loads
+// them again on request, so a rotated certificate is picked up without
+// restarting the process. Pass its GetCertificate method as
+// Config.GetCertificate; connections established after a reload present the
+// new certificate, and existing connections keep running.
+type CertReloader struct {
+	certFile, keyFile string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// NewCertReloader loads the certificate and key from certFile and keyFile.
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload loads the certificate and key files again. On failure the
+// previously loaded certificate stays in use.
+func (r *CertReloader) Reload() error {
+	modTime, err := r.filesModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("tlsnet: reload certificate: %w", err)
+	}
+	r.mu.Lock()
+	r.cert, r.modTime = &cert, modTime
+	r.mu.Unlock()
+	return nil
+}
+
+// GetCertificate returns the most recently loaded certificate.
+func (r *CertReloader) GetCertificate() (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// ReloadOnSIGHUP reloads the certificate whenever the process receives
+// SIGHUP, until ctx is done. Failed reloads are reported to onError, which
+// may be nil.
+func (r *CertReloader) ReloadOnSIGHUP(ctx context.Context, onError func(error)) {
+	onSIGHUP(ctx, r.Reload, onError)
+}
+
+// onSIGHUP calls reload whenever the process receives SIGHUP, until ctx is
+// done, and reports its errors to onError if not nil.
+func onSIGHUP(ctx context.Context, reload func() error, onError func(error)) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(sig)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sig:
+				if err := reload(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+// Watch checks the certificate and key files every interval and reloads
+// them when either has changed, until ctx is done. It polls modification
+// times rather than subscribing to file system events, which keeps the
+// package free of platform-specific dependencies; an interval of a few
+// seconds is ample for certificate rotation. Failed reloads are reported to
+// onError, which may be nil, and retried at the next check.
+func (r *CertReloader) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			modTime, err := r.filesModTime()
+			if err != nil {
+				if onError != nil {
+					onError(err)
+				}
+				continue
+			}
+			r.mu.RLock()
+			changed := !modTime.Equal(r.modTime)
+			r.mu.RUnlock()
+			if changed {
+				if err := r.Reload(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+// filesModTime returns the later modification time of the two files.
+func (r *CertReloader) filesModTime() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{r.certFile, r.keyFile} {
+		fi, err := os.Stat(name)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("tlsnet: %w", err)
+		}
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest, nil
+}
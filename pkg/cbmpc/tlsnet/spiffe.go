@@ -0,0 +1,264 @@
+package tlsnet
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// SPIFFEConfig switches a Transport from certificate names to SPIFFE
+// identities: parties present X.509-SVIDs, and each peer is authenticated by
+// the SPIFFE ID in its SVID's URI SAN instead of a DNS name or CommonName.
+type SPIFFEConfig struct {
+	// Source supplies this party's SVID and the trust bundle that peer SVIDs
+	// must chain to. Both are fetched for every connection, so rotation by
+	// the source takes effect from the next reconnect.
+	Source SVIDSource
+	// IDs maps every party name in Config.Names to the SPIFFE ID its SVID
+	// carries, e.g. "p0" -> "spiffe://example.org/mpc/p0". The names remain
+	// the party names the job uses.
+	IDs map[string]string
+}
+
+// SVIDSource supplies an X.509-SVID and trust bundle. Implementations must be
+// safe for concurrent use and may return different values over time to
+// follow rotation.
+//
+// SVIDFiles reads them from files kept current by an agent sidecar such as
+// spiffe-helper. To use the SPIFFE Workload API directly, adapt go-spiffe's
+// workloadapi.X509Source, which watches the API for rotations:
+//
+//	type workloadSVIDs struct {
+//	    src *workloadapi.X509Source
+//	    td  spiffeid.TrustDomain
+//	}
+//
+//	func (w workloadSVIDs) SVID() (*tls.Certificate, error) {
+//	    svid, err := w.src.GetX509SVID()
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    cert := &tls.Certificate{PrivateKey: svid.PrivateKey}
+//	    for _, c := range svid.Certificates {
+//	        cert.Certificate = append(cert.Certificate, c.Raw)
+//	    }
+//	    return cert, nil
+//	}
+//
+//	func (w workloadSVIDs) Bundle() (*x509.CertPool, error) {
+//	    b, err := w.src.GetX509BundleForTrustDomain(w.td)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    pool := x509.NewCertPool()
+//	    for _, c := range b.X509Authorities() {
+//	        pool.AddCert(c)
+//	    }
+//	    return pool, nil
+//	}
+type SVIDSource interface {
+	// SVID returns this party's SVID chain and private key.
+	SVID() (*tls.Certificate, error)
+	// Bundle returns the X.509 authorities of the trust domain.
+	Bundle() (*x509.CertPool, error)
+}
+
+// validate checks the ID mapping against names and that the local SVID
+// carries the ID mapped to self.
+func (c *SPIFFEConfig) validate(names []string, self int) error {
+	if c.Source == nil {
+		return errors.New("tlsnet: SPIFFE source required")
+	}
+	seen := make(map[string]string, len(names))
+	for _, name := range names {
+		id, ok := c.IDs[name]
+		if !ok {
+			return fmt.Errorf("tlsnet: no SPIFFE ID for party %q", name)
+		}
+		if _, err := parseSPIFFEID(id); err != nil {
+			return fmt.Errorf("tlsnet: party %q: %w", name, err)
+		}
+		if other, dup := seen[id]; dup {
+			return fmt.Errorf("tlsnet: parties %q and %q share SPIFFE ID %s", other, name, id)
+		}
+		seen[id] = name
+	}
+	if len(c.IDs) != len(names) {
+		return errors.New("tlsnet: SPIFFE ID for a name not in Names")
+	}
+	cert, err := c.Source.SVID()
+	if err != nil {
+		return fmt.Errorf("tlsnet: fetch SVID: %w", err)
+	}
+	if cert == nil || len(cert.Certificate) == 0 {
+		return errors.New("tlsnet: empty SVID")
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("tlsnet: parse SVID: %w", err)
+	}
+	id, err := svidID(leaf)
+	if err != nil {
+		return err
+	}
+	if want := c.IDs[names[self]]; id != want {
+		return fmt.Errorf("tlsnet: own SVID is %s, configured %s", id, want)
+	}
+	return nil
+}
+
+// verify checks that rawCerts is an SVID chain issued by the trust bundle
+// and, if want is not empty, that it carries the SPIFFE ID want. It returns
+// the SVID's SPIFFE ID.
+func (c *SPIFFEConfig) verify(rawCerts [][]byte, want string) (string, error) {
+	if len(rawCerts) == 0 {
+		return "", errors.New("tlsnet: peer sent no SVID")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return "", fmt.Errorf("tlsnet: parse peer SVID: %w", err)
+		}
+		certs[i] = cert
+	}
+	roots, err := c.Source.Bundle()
+	if err != nil {
+		return "", fmt.Errorf("tlsnet: fetch trust bundle: %w", err)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return "", fmt.Errorf("tlsnet: verify peer SVID: %w", err)
+	}
+	id, err := svidID(certs[0])
+	if err != nil {
+		return "", err
+	}
+	if want != "" && id != want {
+		return "", fmt.Errorf("tlsnet: peer SVID is %s, expected %s", id, want)
+	}
+	return id, nil
+}
+
+// svidID returns the SPIFFE ID of an X.509-SVID, which carries exactly one
+// URI SAN.
+func svidID(cert *x509.Certificate) (string, error) {
+	if len(cert.URIs) != 1 {
+		return "", fmt.Errorf("tlsnet: SVID has %d URI SANs, want 1", len(cert.URIs))
+	}
+	id := cert.URIs[0].String()
+	if _, err := parseSPIFFEID(id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// parseSPIFFEID checks that id is a SPIFFE ID: a spiffe URI with a trust
+// domain and no query, fragment or user info.
+func parseSPIFFEID(id string) (*url.URL, error) {
+	u, err := url.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SPIFFE ID %q: %w", id, err)
+	}
+	if u.Scheme != "spiffe" || u.Host == "" || u.Port() != "" || u.User != nil ||
+		u.RawQuery != "" || u.Fragment != "" || u.Opaque != "" {
+		return nil, fmt.Errorf("invalid SPIFFE ID %q", id)
+	}
+	return u, nil
+}
+
+// SVIDFiles is an SVIDSource backed by PEM files, in the layout SPIRE's
+// spiffe-helper writes: the SVID chain, its private key and the trust bundle.
+// Reload, ReloadOnSIGHUP and Watch pick up renewed files; configure the
+// helper's renew signal as SIGHUP to reload as soon as it writes them.
+type SVIDFiles struct {
+	svid       *CertReloader
+	bundleFile string
+
+	mu     sync.RWMutex
+	bundle *x509.CertPool
+}
+
+// NewSVIDFiles loads the SVID, key and bundle files.
+func NewSVIDFiles(svidFile, keyFile, bundleFile string) (*SVIDFiles, error) {
+	svid, err := NewCertReloader(svidFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	s := &SVIDFiles{svid: svid, bundleFile: bundleFile}
+	if err := s.reloadBundle(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SVID returns the most recently loaded SVID.
+func (s *SVIDFiles) SVID() (*tls.Certificate, error) { return s.svid.GetCertificate() }
+
+// Bundle returns the most recently loaded trust bundle.
+func (s *SVIDFiles) Bundle() (*x509.CertPool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bundle, nil
+}
+
+// Reload loads all three files again. On failure the previously loaded
+// values stay in use.
+func (s *SVIDFiles) Reload() error {
+	return errors.Join(s.reloadBundle(), s.svid.Reload())
+}
+
+// ReloadOnSIGHUP reloads the files whenever the process receives SIGHUP,
+// until ctx is done. Failed reloads are reported to onError, which may be
+// nil.
+func (s *SVIDFiles) ReloadOnSIGHUP(ctx context.Context, onError func(error)) {
+	onSIGHUP(ctx, s.Reload, onError)
+}
+
+// Watch reloads the files every interval, until ctx is done. Failed reloads
+// are reported to onError, which may be nil.
+func (s *SVIDFiles) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	s.svid.Watch(ctx, interval, onError)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.reloadBundle(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *SVIDFiles) reloadBundle() error {
+	data, err := os.ReadFile(s.bundleFile)
+	if err != nil {
+		return fmt.Errorf("tlsnet: read trust bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return fmt.Errorf("tlsnet: no certificates in trust bundle %s", s.bundleFile)
+	}
+	s.mu.Lock()
+	s.bundle = pool
+	s.mu.Unlock()
+	return nil
+}
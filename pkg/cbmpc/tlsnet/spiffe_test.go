@@ -0,0 +1,202 @@
+package tlsnet
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func issueSVID(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, id string, serial int64) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		URIs:         []*url.URL{u},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+type staticSVIDs struct {
+	svid   tls.Certificate
+	bundle *x509.CertPool
+}
+
+func (s staticSVIDs) SVID() (*tls.Certificate, error) { return &s.svid, nil }
+func (s staticSVIDs) Bundle() (*x509.CertPool, error) { return s.bundle, nil }
+
+var spiffeIDs = map[string]string{
+	"p0": "spiffe://example.org/mpc/p0",
+	"p1": "spiffe://example.org/mpc/p1",
+	"p2": "spiffe://example.org/mpc/p2",
+}
+
+// spiffeCluster starts parties that present SVIDs for the presented IDs.
+func spiffeCluster(t *testing.T, presented []string) ([]*Transport, []error) {
+	t.Helper()
+	names := []string{"p0", "p1", "p2"}[:len(presented)]
+	bundle, ca, caKey, _ := testPKI(t, nil)
+	addrs := freeAddrs(t, len(names))
+	ids := make(map[string]string)
+	for _, n := range names {
+		ids[n] = spiffeIDs[n]
+	}
+	ts := make([]*Transport, len(names))
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i := range names {
+		src := staticSVIDs{svid: issueSVID(t, ca, caKey, presented[i], int64(i+2)), bundle: bundle}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ts[i], errs[i] = New(Config{
+				Self:      i,
+				Names:     names,
+				Addresses: addrs,
+				SPIFFE:    &SPIFFEConfig{Source: src, IDs: ids},
+			})
+		}()
+	}
+	wg.Wait()
+	t.Cleanup(func() {
+		for _, tr := range ts {
+			if tr != nil {
+				_ = tr.Close()
+			}
+		}
+	})
+	return ts, errs
+}
+
+func TestSPIFFE(t *testing.T) {
+	ts, errs := spiffeCluster(t, []string{spiffeIDs["p0"], spiffeIDs["p1"], spiffeIDs["p2"]})
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d: %v", i, err)
+		}
+	}
+	exchange(t, ts, 0)
+}
+
+func TestSPIFFEWrongPeer(t *testing.T) {
+	// p1 holds a valid SVID from the trust domain, but not the ID p0 expects
+	// for it.
+	const mallory = "spiffe://example.org/mpc/mallory"
+	bundle, ca, caKey, _ := testPKI(t, nil)
+	names := []string{"p0", "p1"}
+	addrs := freeAddrs(t, 2)
+	configs := []Config{
+		{
+			Self: 0, Names: names, Addresses: addrs, ConnectTimeout: time.Second,
+			SPIFFE: &SPIFFEConfig{
+				Source: staticSVIDs{svid: issueSVID(t, ca, caKey, spiffeIDs["p0"], 2), bundle: bundle},
+				IDs:    map[string]string{"p0": spiffeIDs["p0"], "p1": spiffeIDs["p1"]},
+			},
+		},
+		{
+			Self: 1, Names: names, Addresses: addrs, ConnectTimeout: time.Second,
+			SPIFFE: &SPIFFEConfig{
+				Source: staticSVIDs{svid: issueSVID(t, ca, caKey, mallory, 3), bundle: bundle},
+				IDs:    map[string]string{"p0": spiffeIDs["p0"], "p1": mallory},
+			},
+		},
+	}
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range configs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var tr *Transport
+			if tr, errs[i] = New(configs[i]); tr != nil {
+				_ = tr.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	if errs[0] == nil || !strings.Contains(errs[0].Error(), "expected "+spiffeIDs["p1"]) {
+		t.Fatalf("p0: err = %v, want SPIFFE ID mismatch", errs[0])
+	}
+
+	// A party refuses to start with an SVID for another party's ID.
+	configs[1].SPIFFE.IDs = map[string]string{"p0": spiffeIDs["p0"], "p1": spiffeIDs["p1"]}
+	if _, err := New(configs[1]); err == nil || !strings.Contains(err.Error(), "own SVID") {
+		t.Fatalf("New with another party's SVID: err = %v", err)
+	}
+}
+
+func TestSPIFFEConfigValidation(t *testing.T) {
+	bundle, ca, caKey, _ := testPKI(t, nil)
+	src := staticSVIDs{svid: issueSVID(t, ca, caKey, spiffeIDs["p0"], 2), bundle: bundle}
+	names := []string{"p0", "p1"}
+	for name, ids := range map[string]map[string]string{
+		"missing":   {"p0": spiffeIDs["p0"]},
+		"duplicate": {"p0": spiffeIDs["p0"], "p1": spiffeIDs["p0"]},
+		"scheme":    {"p0": spiffeIDs["p0"], "p1": "https://example.org/p1"},
+		"extra":     {"p0": spiffeIDs["p0"], "p1": spiffeIDs["p1"], "p9": "spiffe://example.org/p9"},
+	} {
+		cfg := SPIFFEConfig{Source: src, IDs: ids}
+		if err := cfg.validate(names, 0); err == nil {
+			t.Errorf("%s: accepted %v", name, ids)
+		}
+	}
+}
+
+func TestSVIDFiles(t *testing.T) {
+	_, ca, caKey, _ := testPKI(t, nil)
+	dir := t.TempDir()
+	certFile, keyFile := writePEM(t, dir, issueSVID(t, ca, caKey, spiffeIDs["p0"], 2))
+	bundleFile := filepath.Join(dir, "bundle.pem")
+	if err := os.WriteFile(bundleFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	src, err := NewSVIDFiles(certFile, keyFile, bundleFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := SPIFFEConfig{Source: src, IDs: map[string]string{"p0": spiffeIDs["p0"], "p1": spiffeIDs["p1"]}}
+	if err := cfg.validate([]string{"p0", "p1"}, 0); err != nil {
+		t.Fatal(err)
+	}
+	peer := issueSVID(t, ca, caKey, spiffeIDs["p1"], 3)
+	if id, err := cfg.verify(peer.Certificate, spiffeIDs["p1"]); err != nil || id != spiffeIDs["p1"] {
+		t.Fatalf("verify = %q, %v", id, err)
+	}
+
+	// After rotation to a new trust domain CA the old SVIDs no longer verify.
+	_, ca2, _, _ := testPKI(t, nil)
+	if err := os.WriteFile(bundleFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca2.Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cfg.verify(peer.Certificate, ""); err == nil {
+		t.Fatal("SVID verified against a rotated bundle")
+	}
+}
@@ -0,0 +1,941 @@
+package tlsnet
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// Defaults for the zero values of the Config timing fields.
+const (
+	DefaultConnectTimeout    = 10 * time.Second
+	DefaultDialTimeout       = 5 * time.Second
+	DefaultHeartbeatInterval = 5 * time.Second
+	DefaultMaxBackoff        = 5 * time.Second
+)
+
+const minBackoff = 100 * time.Millisecond
+
+// ErrClosed is returned by calls on a closed Transport.
+var ErrClosed = errors.New("tlsnet: transport closed")
+
+// Config configures the TLS-backed transport between parties.
+type Config struct {
+	Self      int
+	Names     []string
+	Addresses []string
+
+	// Certificate is this party's certificate. It is ignored when
+	// GetCertificate is set.
+	Certificate tls.Certificate
+	// GetCertificate, if set, supplies the certificate for every new
+	// connection, so a rotated certificate takes effect from the next
+	// reconnect. CertReloader.GetCertificate fits.
+	GetCertificate func() (*tls.Certificate, error)
+	RootCAs        *x509.CertPool
+
+	// SPIFFE, if set, authenticates parties by SPIFFE ID from X.509-SVIDs
+	// instead of by name; Certificate, GetCertificate and RootCAs are then
+	// ignored.
+	SPIFFE *SPIFFEConfig
+
+	// ConnectTimeout bounds how long New waits for every peer to connect.
+	// Zero means DefaultConnectTimeout.
+	ConnectTimeout time.Duration
+	// DialTimeout bounds each connection attempt, including the TLS
+	// handshake. Zero means DefaultDialTimeout.
+	DialTimeout time.Duration
+	// PeerDialTimeouts overrides DialTimeout for the named peers, for
+	// example those reached over a slower link.
+	PeerDialTimeouts map[string]time.Duration
+	// HeartbeatInterval is how often each connection sends a heartbeat
+	// when idle. Zero means DefaultHeartbeatInterval.
+	HeartbeatInterval time.Duration
+	// HeartbeatTimeout is how long a connection may go without receiving
+	// anything before it is closed and re-established. Zero means three
+	// heartbeat intervals.
+	HeartbeatTimeout time.Duration
+	// MaxBackoff caps the delay between reconnection attempts, which
+	// doubles from 100ms after each failure. Zero means DefaultMaxBackoff.
+	MaxBackoff time.Duration
+
+	// Network carries the connections. Nil means TLS over TCP.
+	Network Network
+}
+
+func (c *Config) defaults() {
+	if c.ConnectTimeout <= 0 {
+		c.ConnectTimeout = DefaultConnectTimeout
+	}
+	if c.DialTimeout <= 0 {
+		c.DialTimeout = DefaultDialTimeout
+	}
+	if c.HeartbeatInterval <= 0 {
+		c.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+	if c.HeartbeatTimeout <= 0 {
+		c.HeartbeatTimeout = 3 * c.HeartbeatInterval
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = DefaultMaxBackoff
+	}
+	if c.Network == nil {
+		c.Network = tcpNetwork{}
+	}
+	if c.SPIFFE != nil {
+		c.GetCertificate = c.SPIFFE.Source.SVID
+	}
+	if c.GetCertificate == nil {
+		cert := c.Certificate
+		c.GetCertificate = func() (*tls.Certificate, error) { return &cert, nil }
+	}
+}
+
+// Transport implements cbmpc.Transport using long-lived mTLS connections
+// between parties. A connection that fails or stops answering heartbeats is
+// re-established with backoff, and messages the peer had not acknowledged
+// are sent again, so a protocol run survives connection loss as long as both
+// processes keep running.
+type Transport struct {
+	self  cbmpc.RoleID
+	names []string
+	cfg   Config
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// peers is fixed by New.
+	peers map[cbmpc.RoleID]*peer
+
+	serverTLS *tls.Config
+	listener  net.Listener
+	closeOnce sync.Once
+}
+
+// New listens on this party's address, connects to every other party and
+// returns a ready-to-use transport. Parties dial the peers listed after them
+// in Names and accept connections from those listed before.
+func New(cfg Config) (*Transport, error) {
+	if cfg.RootCAs == nil && cfg.SPIFFE == nil {
+		return nil, errors.New("tlsnet: root CA pool required")
+	}
+	if cfg.Self < 0 || cfg.Self >= len(cfg.Names) {
+		return nil, fmt.Errorf("tlsnet: invalid self index %d", cfg.Self)
+	}
+	if cfg.SPIFFE != nil {
+		if err := cfg.SPIFFE.validate(cfg.Names, cfg.Self); err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.Names) != len(cfg.Addresses) {
+		return nil, errors.New("tlsnet: names/addresses length mismatch")
+	}
+	if len(cfg.Names) < 2 {
+		return nil, errors.New("tlsnet: at least two parties required")
+	}
+	if len(cfg.Names) > math.MaxUint32 {
+		return nil, fmt.Errorf("tlsnet: too many parties (%d) for 32-bit role IDs", len(cfg.Names))
+	}
+	for name := range cfg.PeerDialTimeouts {
+		if !slices.Contains(cfg.Names, name) {
+			return nil, fmt.Errorf("tlsnet: dial timeout for unknown peer %q", name)
+		}
+	}
+	cfg.defaults()
+
+	selfRole, err := roleIDFromIndex(cfg.Self)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Transport{
+		self:   selfRole,
+		names:  append([]string(nil), cfg.Names...),
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+		peers:  make(map[cbmpc.RoleID]*peer, len(cfg.Names)-1),
+	}
+	for i, name := range cfg.Names {
+		if i == cfg.Self {
+			continue
+		}
+		id, err := roleIDFromIndex(i)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		timeout := cfg.DialTimeout
+		if d, ok := cfg.PeerDialTimeouts[name]; ok && d > 0 {
+			timeout = d
+		}
+		t.peers[id] = newPeer(t, id, name, cfg.Addresses[i], timeout, i > cfg.Self)
+	}
+
+	t.serverTLS = &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return t.cfg.GetCertificate() },
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+		ClientCAs:      cfg.RootCAs,
+		MinVersion:     tls.VersionTLS13,
+	}
+	if cfg.SPIFFE != nil {
+		// The client's role is known only after the handshake; accept checks
+		// its SPIFFE ID against the role it claims.
+		t.serverTLS.ClientAuth = tls.RequireAnyClientCert
+		t.serverTLS.ClientCAs = nil
+		t.serverTLS.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			_, err := cfg.SPIFFE.verify(rawCerts, "")
+			return err
+		}
+	}
+	ln, err := cfg.Network.Listen(cfg.Addresses[cfg.Self], t.serverTLS)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("tlsnet: listen: %w", err)
+	}
+	t.listener = ln
+	go t.acceptLoop()
+
+	for _, p := range t.peers {
+		if p.dials {
+			p.redial()
+		}
+	}
+
+	timer := time.NewTimer(cfg.ConnectTimeout)
+	defer timer.Stop()
+	for _, p := range t.peers {
+		select {
+		case <-p.connected:
+		case <-timer.C:
+			_ = t.Close()
+			return nil, fmt.Errorf("tlsnet: timeout waiting for peer %q: %w", p.name, p.lastError())
+		}
+	}
+	return t, nil
+}
+
+// PeerStatus describes the connection to one peer.
+type PeerStatus struct {
+	Name       string
+	Connected  bool
+	LastSeen   time.Time // last frame received, zero before the first
+	Reconnects int       // connections established after the first
+	Unacked    int       // messages sent but not yet acknowledged
+	LastError  error     // most recent connection failure, if any
+}
+
+// Status reports the state of the connection to every peer, in role order,
+// for health checks and metrics.
+func (t *Transport) Status() []PeerStatus {
+	out := make([]PeerStatus, 0, len(t.peers))
+	for i := range t.names {
+		p, ok := t.peers[cbmpc.RoleID(i)]
+		if !ok {
+			continue
+		}
+		p.mu.Lock()
+		out = append(out, PeerStatus{
+			Name:       p.name,
+			Connected:  p.link != nil,
+			LastSeen:   p.lastSeen,
+			Reconnects: max(p.links-1, 0),
+			Unacked:    len(p.unacked),
+			LastError:  p.lastErr,
+		})
+		p.mu.Unlock()
+	}
+	return out
+}
+
+// certHasName returns true if the certificate identity includes the provided name
+// either as Subject CommonName or as a DNS SAN entry.
+func certHasName(cert *x509.Certificate, name string) bool {
+	if cert.Subject.CommonName == name {
+		return true
+	}
+	for _, dns := range cert.DNSNames {
+		if dns == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Send queues msg for to and returns without waiting for it to be written.
+// Messages queued while the connection is down are sent once it is back.
+func (t *Transport) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	if to == t.self {
+		return errors.New("tlsnet: send to self")
+	}
+	p, err := t.getPeer(to)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if t.ctx.Err() != nil {
+		return ErrClosed
+	}
+	return p.send(msg)
+}
+
+func (t *Transport) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
+	if from == t.self {
+		return nil, errors.New("tlsnet: receive from self")
+	}
+	p, err := t.getPeer(from)
+	if err != nil {
+		return nil, err
+	}
+	return p.receive(ctx)
+}
+
+func (t *Transport) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	uniq := make(map[cbmpc.RoleID]struct{}, len(from))
+	for _, role := range from {
+		if role == t.self {
+			return nil, errors.New("tlsnet: receive_all includes self")
+		}
+		if _, err := t.getPeer(role); err != nil {
+			return nil, err
+		}
+		if _, exists := uniq[role]; exists {
+			return nil, errors.New("tlsnet: duplicate role in receive_all")
+		}
+		uniq[role] = struct{}{}
+	}
+
+	out := make(map[cbmpc.RoleID][]byte, len(from))
+	for _, role := range from {
+		p, _ := t.getPeer(role)
+		msg, err := p.receive(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out[role] = msg
+	}
+	return out, nil
+}
+
+// Close terminates the transport and underlying connections.
+func (t *Transport) Close() error {
+	t.closeOnce.Do(func() {
+		t.cancel()
+		if t.listener != nil {
+			_ = t.listener.Close()
+		}
+		for _, p := range t.peers {
+			p.fail(ErrClosed)
+		}
+	})
+	return nil
+}
+
+func (t *Transport) getPeer(id cbmpc.RoleID) (*peer, error) {
+	p, ok := t.peers[id]
+	if !ok {
+		return nil, fmt.Errorf("tlsnet: unknown peer %d", id)
+	}
+	return p, nil
+}
+
+func (t *Transport) clientTLS(p *peer) *tls.Config {
+	cfg := &tls.Config{
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return t.cfg.GetCertificate()
+		},
+		RootCAs:    t.cfg.RootCAs,
+		ServerName: p.name,
+		MinVersion: tls.VersionTLS13,
+	}
+	if s := t.cfg.SPIFFE; s != nil {
+		// SVIDs carry no DNS names: replace hostname verification with a
+		// check of the SVID chain and the peer's SPIFFE ID.
+		want := s.IDs[p.name]
+		cfg.RootCAs = nil
+		cfg.InsecureSkipVerify = true // #nosec G402 -- verified by VerifyPeerCertificate
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			_, err := s.verify(rawCerts, want)
+			return err
+		}
+	}
+	return cfg
+}
+
+// checkIdentity checks that the client certificate of an accepted connection
+// belongs to p.
+func (t *Transport) checkIdentity(p *peer, state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("tlsnet: missing peer certificate")
+	}
+	leaf := state.PeerCertificates[0]
+	if s := t.cfg.SPIFFE; s != nil {
+		id, err := svidID(leaf)
+		if err != nil {
+			return err
+		}
+		if want := s.IDs[p.name]; id != want {
+			return fmt.Errorf("tlsnet: peer SVID is %s, expected %s for %q", id, want, p.name)
+		}
+		return nil
+	}
+	if !certHasName(leaf, p.name) {
+		return fmt.Errorf("tlsnet: peer certificate identity mismatch: expected %q", p.name)
+	}
+	return nil
+}
+
+// acceptLoop accepts connections from the peers that dial this party, for the
+// initial connection and every reconnect, until the transport is closed.
+func (t *Transport) acceptLoop() {
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			if t.ctx.Err() != nil {
+				return
+			}
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				continue
+			}
+			// Transient failures such as running out of file descriptors:
+			// wait instead of spinning.
+			select {
+			case <-t.ctx.Done():
+				return
+			case <-time.After(minBackoff):
+			}
+			continue
+		}
+		go t.accept(conn)
+	}
+}
+
+// accept authenticates an inbound connection and hands it to its peer.
+func (t *Transport) accept(raw net.Conn) {
+	deadline := time.Now().Add(t.cfg.DialTimeout)
+	_ = raw.SetDeadline(deadline)
+	ctx, cancel := context.WithDeadline(t.ctx, deadline)
+	defer cancel()
+	conn, err := handshake(ctx, raw)
+	if err != nil {
+		_ = raw.Close()
+		return
+	}
+	id, peerNext, err := readHello(conn)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	p, ok := t.peers[cbmpc.RoleID(id)]
+	if !ok || p.dials {
+		_ = conn.Close()
+		return
+	}
+	// Bind claimed peer ID to certificate identity.
+	if err := t.checkIdentity(p, conn.ConnectionState()); err != nil {
+		p.setLastError(err)
+		_ = conn.Close()
+		return
+	}
+	next := p.detach()
+	if err := writeNext(conn, next); err != nil {
+		p.setLastError(err)
+		_ = conn.Close()
+		return
+	}
+	_ = conn.SetDeadline(time.Time{})
+	p.attach(conn, peerNext)
+}
+
+// dial connects to a peer this party dials and hands the connection to it.
+func (t *Transport) dial(p *peer) error {
+	ctx, cancel := context.WithTimeout(t.ctx, p.dialTimeout)
+	defer cancel()
+	conn, err := t.cfg.Network.Dial(ctx, p.addr, t.clientTLS(p))
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+	next := p.detach()
+	if err := writeHello(conn, uint32(t.self), next); err != nil {
+		return closeWithContextErr(conn, err)
+	}
+	peerNext, err := readNext(conn)
+	if err != nil {
+		return closeWithContextErr(conn, fmt.Errorf("read peer hello: %w", err))
+	}
+	_ = conn.SetDeadline(time.Time{})
+	p.attach(conn, peerNext)
+	return nil
+}
+
+// frame is one numbered message to a peer.
+type frame struct {
+	seq uint64
+	msg []byte
+}
+
+// peer holds the state of the link to one peer that outlives individual
+// connections: the messages sent but not acknowledged, the sequence numbers
+// in each direction and the messages received but not yet consumed.
+type peer struct {
+	t           *Transport
+	id          cbmpc.RoleID
+	name        string
+	addr        string
+	dialTimeout time.Duration
+	dials       bool // this party dials the peer; otherwise it accepts
+
+	connected chan struct{} // closed on the first connection
+	ready     chan struct{} // signals receivers that inbox or err changed
+
+	mu       sync.Mutex
+	link     *link // nil while disconnected
+	links    int   // connections established so far
+	dialing  bool
+	unacked  []frame
+	sendSeq  uint64 // sequence number of the next message sent
+	writeSeq uint64 // next sequence number to write on link
+	recvNext uint64 // sequence number of the next message expected
+	inbox    [][]byte
+	lastSeen time.Time
+	lastErr  error
+	err      error // permanent failure
+}
+
+// link is one connection to a peer.
+type link struct {
+	conn net.Conn
+	wake chan struct{} // signals the writer that messages are queued
+	done chan struct{} // closed when the link is dropped
+}
+
+func newPeer(t *Transport, id cbmpc.RoleID, name, addr string, dialTimeout time.Duration, dials bool) *peer {
+	return &peer{
+		t:           t,
+		id:          id,
+		name:        name,
+		addr:        addr,
+		dialTimeout: dialTimeout,
+		dials:       dials,
+		connected:   make(chan struct{}),
+		ready:       make(chan struct{}, 1),
+	}
+}
+
+func (p *peer) send(msg []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.unacked = append(p.unacked, frame{seq: p.sendSeq, msg: append([]byte(nil), msg...)})
+	p.sendSeq++
+	if p.link != nil {
+		notify(p.link.wake)
+	}
+	return nil
+}
+
+func (p *peer) receive(ctx context.Context) ([]byte, error) {
+	for {
+		p.mu.Lock()
+		if len(p.inbox) > 0 {
+			msg := p.inbox[0]
+			p.inbox[0] = nil
+			p.inbox = p.inbox[1:]
+			more := len(p.inbox) > 0
+			p.mu.Unlock()
+			if more {
+				// Pass the wakeup on in case another receiver is waiting.
+				notify(p.ready)
+			}
+			return msg, nil
+		}
+		err := p.err
+		p.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case <-p.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-p.t.ctx.Done():
+			return nil, ErrClosed
+		}
+	}
+}
+
+// detach drops the current connection, if any, and returns the sequence
+// number of the next message expected from the peer. Called while a new
+// connection is being set up, it guarantees that nothing arriving on the old
+// connection is accepted after the number is sent to the peer.
+func (p *peer) detach() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dropLocked(nil)
+	return p.recvNext
+}
+
+// attach makes conn the connection to the peer. peerNext is the sequence
+// number of the next message the peer expects; queued messages from there on
+// are sent again.
+func (p *peer) attach(conn net.Conn, peerNext uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		_ = conn.Close()
+		return
+	}
+	if err := p.ackLocked(peerNext); err != nil {
+		_ = conn.Close()
+		p.failLocked(err)
+		return
+	}
+	p.dropLocked(nil)
+	l := &link{conn: conn, wake: make(chan struct{}, 1), done: make(chan struct{})}
+	p.link = l
+	p.links++
+	p.writeSeq = peerNext
+	p.lastSeen = time.Now()
+	if p.links == 1 {
+		close(p.connected)
+	}
+	go p.readLoop(l)
+	go p.writeLoop(l)
+	notify(l.wake)
+}
+
+// ackLocked discards the messages the peer acknowledged by expecting next.
+func (p *peer) ackLocked(next uint64) error {
+	if next > p.sendSeq {
+		return fmt.Errorf("tlsnet: %w: peer %q expects message %d, only %d sent (was this party restarted?)", cbmpc.ErrReplay, p.name, next, p.sendSeq)
+	}
+	if next < p.sendSeq-uint64(len(p.unacked)) {
+		return fmt.Errorf("tlsnet: %w: peer %q expects message %d, already acknowledged (was the peer restarted?)", cbmpc.ErrReplay, p.name, next)
+	}
+	n := 0
+	for n < len(p.unacked) && p.unacked[n].seq < next {
+		p.unacked[n] = frame{}
+		n++
+	}
+	p.unacked = p.unacked[n:]
+	return nil
+}
+
+// drop closes l after a connection failure and, on the dialing side, starts
+// reconnecting. It does nothing if l is no longer the current link.
+func (p *peer) drop(l *link, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.link != l {
+		return
+	}
+	p.dropLocked(err)
+	if p.err == nil && p.dials {
+		p.redialLocked()
+	}
+}
+
+func (p *peer) dropLocked(err error) {
+	if err != nil {
+		p.lastErr = err
+	}
+	if p.link == nil {
+		return
+	}
+	close(p.link.done)
+	_ = p.link.conn.Close()
+	p.link = nil
+}
+
+// fail ends the link to the peer for good, failing pending and future calls
+// with err.
+func (p *peer) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failLocked(err)
+}
+
+func (p *peer) failLocked(err error) {
+	if p.err != nil {
+		return
+	}
+	p.err = err
+	p.dropLocked(nil)
+	notify(p.ready)
+}
+
+func (p *peer) setLastError(err error) {
+	p.mu.Lock()
+	p.lastErr = err
+	p.mu.Unlock()
+}
+
+func (p *peer) lastError() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.lastErr == nil {
+		return errors.New("no connection attempt completed")
+	}
+	return p.lastErr
+}
+
+func (p *peer) redial() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.redialLocked()
+}
+
+// redialLocked starts a goroutine that dials the peer with exponential
+// backoff until it connects or the transport is closed.
+func (p *peer) redialLocked() {
+	if p.dialing {
+		return
+	}
+	p.dialing = true
+	go func() {
+		backoff := minBackoff
+		for {
+			err := p.t.dial(p)
+			if err == nil || p.t.ctx.Err() != nil {
+				break
+			}
+			p.setLastError(err)
+			// Jitter keeps parties that lost their connections at the same
+			// moment from redialing in lockstep.
+			wait := time.Duration(rand.Int64N(int64(backoff))) + backoff/2
+			select {
+			case <-p.t.ctx.Done():
+			case <-time.After(wait):
+			}
+			if p.t.ctx.Err() != nil {
+				break
+			}
+			backoff = min(2*backoff, p.t.cfg.MaxBackoff)
+		}
+		p.mu.Lock()
+		p.dialing = false
+		p.mu.Unlock()
+	}()
+}
+
+// readLoop delivers the frames arriving on l until it fails.
+func (p *peer) readLoop(l *link) {
+	for {
+		kind, seq, ack, msg, err := readFrame(l.conn)
+		if err != nil {
+			p.drop(l, err)
+			return
+		}
+		if err := p.deliver(l, kind, seq, ack, msg); err != nil {
+			p.fail(err)
+			return
+		}
+	}
+}
+
+func (p *peer) deliver(l *link, kind byte, seq, ack uint64, msg []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.link != l {
+		return nil // superseded; the peer resends what we did not accept
+	}
+	p.lastSeen = time.Now()
+	if err := p.ackLocked(ack); err != nil {
+		return err
+	}
+	switch kind {
+	case frameHeartbeat:
+		return nil
+	case frameData:
+		if seq != p.recvNext {
+			return fmt.Errorf("tlsnet: %w: frame %d from peer %d, expected %d", cbmpc.ErrReplay, seq, p.id, p.recvNext)
+		}
+		p.recvNext++
+		p.inbox = append(p.inbox, msg)
+		notify(p.ready)
+		return nil
+	default:
+		return fmt.Errorf("tlsnet: unknown frame type %d from peer %d", kind, p.id)
+	}
+}
+
+// writeLoop writes queued messages to l, sends heartbeats and drops l when
+// the peer has been silent for longer than the heartbeat timeout.
+func (p *peer) writeLoop(l *link) {
+	ticker := time.NewTicker(p.t.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		heartbeat := false
+		select {
+		case <-l.done:
+			return
+		case <-l.wake:
+		case <-ticker.C:
+			heartbeat = true
+		}
+
+		p.mu.Lock()
+		if p.link != l {
+			p.mu.Unlock()
+			return
+		}
+		if heartbeat && time.Since(p.lastSeen) > p.t.cfg.HeartbeatTimeout {
+			p.dropLocked(fmt.Errorf("tlsnet: no heartbeat from peer %q for %v", p.name, p.t.cfg.HeartbeatTimeout))
+			if p.dials {
+				p.redialLocked()
+			}
+			p.mu.Unlock()
+			return
+		}
+		var pending []frame
+		for _, f := range p.unacked {
+			if f.seq >= p.writeSeq {
+				pending = append(pending, f)
+			}
+		}
+		p.writeSeq = p.sendSeq
+		ack := p.recvNext
+		p.mu.Unlock()
+
+		var err error
+		for _, f := range pending {
+			if err = writeFrame(l.conn, frameData, f.seq, ack, f.msg); err != nil {
+				break
+			}
+		}
+		if err == nil && len(pending) == 0 && heartbeat {
+			err = writeFrame(l.conn, frameHeartbeat, 0, ack, nil)
+		}
+		if err != nil {
+			p.drop(l, err)
+			return
+		}
+	}
+}
+
+func notify(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
+
+// Frame types.
+const (
+	frameData      byte = 0
+	frameHeartbeat byte = 1
+)
+
+// frameHeaderSize covers the payload length, the frame type, the sequence
+// number and the acknowledgement: the sequence number of the next message
+// the sender expects.
+const frameHeaderSize = 4 + 1 + 8 + 8
+
+func writeFrame(conn net.Conn, kind byte, seq, ack uint64, payload []byte) error {
+	size := len(payload)
+	if size < 0 || size > math.MaxUint32 {
+		return fmt.Errorf("tlsnet: frame too large (%d bytes)", size)
+	}
+	buf := make([]byte, frameHeaderSize, frameHeaderSize+size)
+	binary.BigEndian.PutUint32(buf[0:], uint32(size))
+	buf[4] = kind
+	binary.BigEndian.PutUint64(buf[5:], seq)
+	binary.BigEndian.PutUint64(buf[13:], ack)
+	_, err := conn.Write(append(buf, payload...))
+	return err
+}
+
+func readFrame(conn net.Conn) (kind byte, seq, ack uint64, payload []byte, err error) {
+	var hdr [frameHeaderSize]byte
+	if _, err := io.ReadFull(conn, hdr[:]); err != nil {
+		return 0, 0, 0, nil, err
+	}
+	n := binary.BigEndian.Uint32(hdr[0:])
+	kind = hdr[4]
+	seq = binary.BigEndian.Uint64(hdr[5:])
+	ack = binary.BigEndian.Uint64(hdr[13:])
+	if n == 0 {
+		return kind, seq, ack, []byte{}, nil
+	}
+	payload = make([]byte, n)
+	if _, err := io.ReadFull(conn, payload); err != nil {
+		return 0, 0, 0, nil, err
+	}
+	return kind, seq, ack, payload, nil
+}
+
+// writeHello sends the dialing party's role and the sequence number of the
+// next message it expects from the peer.
+func writeHello(conn net.Conn, id uint32, next uint64) error {
+	var buf [12]byte
+	binary.BigEndian.PutUint32(buf[:], id)
+	binary.BigEndian.PutUint64(buf[4:], next)
+	_, err := conn.Write(buf[:])
+	return err
+}
+
+func readHello(conn net.Conn) (uint32, uint64, error) {
+	var buf [12]byte
+	if _, err := io.ReadFull(conn, buf[:]); err != nil {
+		return 0, 0, err
+	}
+	return binary.BigEndian.Uint32(buf[:]), binary.BigEndian.Uint64(buf[4:]), nil
+}
+
+// writeNext sends the accepting party's reply to the hello: the sequence
+// number of the next message it expects.
+func writeNext(conn net.Conn, next uint64) error {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], next)
+	_, err := conn.Write(buf[:])
+	return err
+}
+
+func readNext(conn net.Conn) (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(conn, buf[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+func roleIDFromIndex(idx int) (cbmpc.RoleID, error) {
+	if idx < 0 {
+		return 0, fmt.Errorf("tlsnet: negative role index %d", idx)
+	}
+	if idx > math.MaxUint32 {
+		return 0, fmt.Errorf("tlsnet: role index %d exceeds 32-bit capacity", idx)
+	}
+	return cbmpc.RoleID(idx), nil
+}
+
+func closeWithContextErr(c io.Closer, base error) error {
+	if base == nil {
+		return c.Close()
+	}
+	if closeErr := c.Close(); closeErr != nil {
+		return fmt.Errorf("%w; close error: %v", base, closeErr)
+	}
+	return base
+}
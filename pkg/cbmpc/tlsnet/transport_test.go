@@ -0,0 +1,367 @@
+package tlsnet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// testPKI issues a CA and one certificate per name in memory.
+func testPKI(t *testing.T, names []string) (*x509.CertPool, *x509.Certificate, *ecdsa.PrivateKey, []tls.Certificate) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	certs := make([]tls.Certificate, len(names))
+	for i, name := range names {
+		certs[i] = issue(t, ca, caKey, name, int64(i+2))
+	}
+	return roots, ca, caKey, certs
+}
+
+func issue(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, name string, serial int64) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{name},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func freeAddrs(t *testing.T, n int) []string {
+	t.Helper()
+	addrs := make([]string, n)
+	for i := range addrs {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrs[i] = ln.Addr().String()
+		_ = ln.Close()
+	}
+	return addrs
+}
+
+// cluster starts n connected transports; configure may adjust each config.
+func cluster(t *testing.T, n int, configure func(i int, c *Config)) []*Transport {
+	t.Helper()
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("p%d", i)
+	}
+	roots, _, _, certs := testPKI(t, names)
+	addrs := freeAddrs(t, n)
+	ts := make([]*Transport, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cfg := Config{Self: i, Names: names, Addresses: addrs, Certificate: certs[i], RootCAs: roots}
+			if configure != nil {
+				configure(i, &cfg)
+			}
+			ts[i], errs[i] = New(cfg)
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("party %d: %v", i, err)
+		}
+	}
+	t.Cleanup(func() {
+		for _, tr := range ts {
+			_ = tr.Close()
+		}
+	})
+	return ts
+}
+
+// exchange has every party send one message to every other and checks what
+// each receives.
+func exchange(t *testing.T, ts []*Transport, round int) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for i, tr := range ts {
+		for j := range ts {
+			if i != j {
+				if err := tr.Send(ctx, cbmpc.RoleID(j), fmt.Appendf(nil, "%d:%d->%d", round, i, j)); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+	for j, tr := range ts {
+		var from []cbmpc.RoleID
+		for i := range ts {
+			if i != j {
+				from = append(from, cbmpc.RoleID(i))
+			}
+		}
+		got, err := tr.ReceiveAll(ctx, from)
+		if err != nil {
+			t.Fatalf("round %d party %d: %v", round, j, err)
+		}
+		for _, i := range from {
+			if want := fmt.Sprintf("%d:%d->%d", round, i, j); string(got[i]) != want {
+				t.Fatalf("party %d got %q, want %q", j, got[i], want)
+			}
+		}
+	}
+}
+
+func TestExchange(t *testing.T) {
+	ts := cluster(t, 3, nil)
+	for round := range 3 {
+		exchange(t, ts, round)
+	}
+	for _, st := range ts[0].Status() {
+		if !st.Connected || st.Reconnects != 0 {
+			t.Fatalf("status %+v", st)
+		}
+	}
+}
+
+// countingNetwork records the connections made through the default network.
+type countingNetwork struct {
+	tcpNetwork
+	mu             sync.Mutex
+	listens, dials int
+}
+
+func (n *countingNetwork) Listen(addr string, cfg *tls.Config) (net.Listener, error) {
+	n.mu.Lock()
+	n.listens++
+	n.mu.Unlock()
+	return n.tcpNetwork.Listen(addr, cfg)
+}
+
+func (n *countingNetwork) Dial(ctx context.Context, addr string, cfg *tls.Config) (net.Conn, error) {
+	n.mu.Lock()
+	n.dials++
+	n.mu.Unlock()
+	return n.tcpNetwork.Dial(ctx, addr, cfg)
+}
+
+func TestNetwork(t *testing.T) {
+	nets := make([]*countingNetwork, 3)
+	ts := cluster(t, 3, func(i int, c *Config) {
+		nets[i] = &countingNetwork{}
+		c.Network = nets[i]
+	})
+	exchange(t, ts, 0)
+	for i, n := range nets {
+		n.mu.Lock()
+		listens, dials := n.listens, n.dials
+		n.mu.Unlock()
+		// Party i dials the parties listed after it.
+		if listens != 1 || dials < 2-i {
+			t.Fatalf("party %d: %d listens, %d dials", i, listens, dials)
+		}
+	}
+}
+
+// kill closes the current connection between parties a and b under them.
+func kill(ts []*Transport, a, b int) {
+	p := ts[a].peers[cbmpc.RoleID(b)]
+	p.mu.Lock()
+	l := p.link
+	p.mu.Unlock()
+	if l != nil {
+		_ = l.conn.Close()
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	ts := cluster(t, 3, nil)
+	exchange(t, ts, 0)
+
+	// Messages sent right after the connection dies are written to the dead
+	// connection or queued, and must arrive once it is re-established.
+	kill(ts, 0, 1)
+	kill(ts, 2, 1)
+	exchange(t, ts, 1)
+	exchange(t, ts, 2)
+
+	for _, i := range []int{0, 2} {
+		if st := status(ts[i], "p1"); st.Reconnects == 0 {
+			t.Fatalf("party %d: no reconnect recorded: %+v", i, st)
+		}
+	}
+}
+
+func status(tr *Transport, name string) PeerStatus {
+	for _, st := range tr.Status() {
+		if st.Name == name {
+			return st
+		}
+	}
+	return PeerStatus{}
+}
+
+func TestHeartbeatTimeout(t *testing.T) {
+	ts := cluster(t, 2, func(_ int, c *Config) {
+		c.HeartbeatInterval = 20 * time.Millisecond
+		c.HeartbeatTimeout = 100 * time.Millisecond
+	})
+	exchange(t, ts, 0)
+
+	// Silence the link in one direction: party 1 stops writing. Party 0
+	// must notice and both must reconnect.
+	p := ts[1].peers[0]
+	p.mu.Lock()
+	stalled := p.link
+	close(stalled.done)
+	stalled.done = make(chan struct{}) // keep drop from closing it twice
+	p.mu.Unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for status(ts[0], "p1").Reconnects == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("stalled connection not replaced: %+v", status(ts[0], "p1"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := status(ts[0], "p1").LastError; err == nil || !strings.Contains(err.Error(), "heartbeat") {
+		t.Fatalf("last error = %v, want heartbeat timeout", err)
+	}
+	exchange(t, ts, 1)
+}
+
+func TestConnectTimeout(t *testing.T) {
+	names := []string{"p0", "p1"}
+	roots, _, _, certs := testPKI(t, names)
+	_, err := New(Config{
+		Self:             0,
+		Names:            names,
+		Addresses:        freeAddrs(t, 2),
+		Certificate:      certs[0],
+		RootCAs:          roots,
+		ConnectTimeout:   300 * time.Millisecond,
+		PeerDialTimeouts: map[string]time.Duration{"p1": 50 * time.Millisecond},
+	})
+	if err == nil || !strings.Contains(err.Error(), `"p1"`) {
+		t.Fatalf("err = %v, want timeout naming p1", err)
+	}
+
+	if _, err := New(Config{
+		Self: 0, Names: names, Addresses: freeAddrs(t, 2), RootCAs: roots,
+		PeerDialTimeouts: map[string]time.Duration{"p9": time.Second},
+	}); err == nil {
+		t.Fatal("New accepted a dial timeout for an unknown peer")
+	}
+}
+
+func writePEM(t *testing.T, dir string, cert tls.Certificate) (string, string) {
+	t.Helper()
+	keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestCertReloader(t *testing.T) {
+	_, ca, caKey, certs := testPKI(t, []string{"p0"})
+	dir := t.TempDir()
+	certFile, keyFile := writePEM(t, dir, certs[0])
+	r, err := NewCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 10)
+	r.Watch(ctx, 10*time.Millisecond, func(err error) { errs <- err })
+
+	rotated := issue(t, ca, caKey, "p0", 99)
+	writePEM(t, dir, rotated)
+	// Make the change visible on file systems with coarse timestamps.
+	later := time.Now().Add(time.Minute)
+	_ = os.Chtimes(certFile, later, later)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, _ := r.GetCertificate()
+		if string(got.Certificate[0]) == string(rotated.Certificate[0]) {
+			break
+		}
+		select {
+		case err := <-errs:
+			t.Fatal(err)
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("rotated certificate not loaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A broken file keeps the loaded certificate in place.
+	if err := os.WriteFile(certFile, []byte("garbage"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reload(); err == nil {
+		t.Fatal("Reload accepted a malformed certificate")
+	}
+	if got, _ := r.GetCertificate(); string(got.Certificate[0]) != string(rotated.Certificate[0]) {
+		t.Fatal("failed reload replaced the certificate")
+	}
+}
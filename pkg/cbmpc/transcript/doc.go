@@ -0,0 +1,29 @@
+// Package transcript records the messages one party exchanges during a job so
+// that protocol failures can be debugged offline.
+//
+// A Recorder wraps the party's transport and keeps every sent and received
+// message in order. The resulting Transcript is plain JSON. It can be attached
+// to a bug report and fed back into the same party with package replaynet:
+//
+//	rec, err := transcript.NewRecorder(transport, cbmpc.RoleID(cbmpc.RoleP1), "ecdsa2p.Sign", transcript.RedactSent)
+//	if err != nil {
+//	    return err
+//	}
+//	job, err := cbmpc.NewJob2PWithContext(ctx, rec, cbmpc.RoleP1, names)
+//	// ... run the protocol ...
+//	if err != nil {
+//	    data, _ := json.Marshal(rec.Transcript())
+//	    _ = os.WriteFile("failed-sign.json", data, 0o600)
+//	}
+//
+// # Redaction
+//
+// A Redactor chooses what is stored for each message. RedactSent keeps only
+// received payloads, which is all replay needs. RedactAll keeps only sizes and
+// directions. Transcripts never contain key shares, but protocol messages can
+// be linked to keys and messages, so store them like other operational data
+// of the signing service.
+//
+// Recording is opt-in and costs a copy of every message; leave the transport
+// unwrapped in production unless transcripts are needed.
+package transcript
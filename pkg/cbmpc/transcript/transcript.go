@@ -0,0 +1,178 @@
+package transcript
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+)
+
+// FormatVersion is the transcript format written by this package.
+const FormatVersion = 1
+
+// Direction is the direction of a recorded message relative to the recording
+// party.
+type Direction string
+
+const (
+	Sent     Direction = "send"
+	Received Direction = "receive"
+)
+
+// Entry is one message in a transcript.
+type Entry struct {
+	Dir      Direction    `json:"dir"`
+	Peer     cbmpc.RoleID `json:"peer"`
+	Size     int          `json:"size"`
+	Payload  []byte       `json:"payload,omitempty"`
+	Redacted bool         `json:"redacted,omitempty"`
+}
+
+// Transcript is the ordered list of messages one party sent and received
+// during a job.
+type Transcript struct {
+	Version int          `json:"version"`
+	Self    cbmpc.RoleID `json:"self"`
+	Label   string       `json:"label,omitempty"`
+	Entries []Entry      `json:"entries"`
+}
+
+// Parse decodes a transcript written with json.Marshal.
+func Parse(data []byte) (*Transcript, error) {
+	var t Transcript
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("parse transcript: %w", err)
+	}
+	if t.Version != FormatVersion {
+		return nil, fmt.Errorf("parse transcript: unsupported version %d (want %d)", t.Version, FormatVersion)
+	}
+	for i, e := range t.Entries {
+		if e.Dir != Sent && e.Dir != Received {
+			return nil, fmt.Errorf("parse transcript: entry %d: unknown direction %q", i, e.Dir)
+		}
+	}
+	return &t, nil
+}
+
+// Redactor decides what is stored for a message. It returns the payload to
+// record, which may be msg itself, a modified copy, or nil to record only the
+// message size. It must not modify msg.
+type Redactor func(dir Direction, peer cbmpc.RoleID, msg []byte) []byte
+
+// RedactSent records received messages in full and drops the payloads of
+// sent messages. The result can still be replayed with replaynet, since a
+// replayed party produces its own sends.
+func RedactSent(dir Direction, _ cbmpc.RoleID, msg []byte) []byte {
+	if dir == Sent {
+		return nil
+	}
+	return msg
+}
+
+// RedactAll records message sizes only. The result documents the flow but
+// cannot be replayed.
+func RedactAll(Direction, cbmpc.RoleID, []byte) []byte { return nil }
+
+// Recorder wraps a cbmpc.Transport and records every message the party sends
+// and receives. Pass it to NewJob2PWithContext or NewJobMPWithContext in place
+// of the transport, run the protocol, then save Transcript().
+//
+// Transcripts contain protocol messages. They are not secret key material,
+// but they may be linkable to keys and signed payloads. Use a Redactor to
+// limit what is kept.
+type Recorder struct {
+	inner  cbmpc.Transport
+	self   cbmpc.RoleID
+	label  string
+	redact Redactor
+
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewRecorder returns a Recorder that forwards to t on behalf of self. label
+// is stored in the transcript for humans, e.g. "ecdsa2p.Sign". A nil redact
+// records every payload in full.
+func NewRecorder(t cbmpc.Transport, self cbmpc.RoleID, label string, redact Redactor) (*Recorder, error) {
+	if t == nil {
+		return nil, cbmpc.ErrNilTransport
+	}
+	return &Recorder{inner: t, self: self, label: label, redact: redact}, nil
+}
+
+// Send forwards to the wrapped transport and records the message on success.
+func (r *Recorder) Send(ctx context.Context, to cbmpc.RoleID, msg []byte) error {
+	if err := r.inner.Send(ctx, to, msg); err != nil {
+		return err
+	}
+	r.record(Sent, to, msg)
+	return nil
+}
+
+// Receive forwards to the wrapped transport and records the message on success.
+func (r *Recorder) Receive(ctx context.Context, from cbmpc.RoleID) ([]byte, error) {
+	msg, err := r.inner.Receive(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	r.record(Received, from, msg)
+	return msg, nil
+}
+
+// ReceiveAll forwards to the wrapped transport and records one entry per
+// message, in role order.
+func (r *Recorder) ReceiveAll(ctx context.Context, from []cbmpc.RoleID) (map[cbmpc.RoleID][]byte, error) {
+	msgs, err := r.inner.ReceiveAll(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]cbmpc.RoleID, 0, len(msgs))
+	for role := range msgs {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i] < roles[j] })
+	for _, role := range roles {
+		r.record(Received, role, msgs[role])
+	}
+	return msgs, nil
+}
+
+// Transcript returns a copy of everything recorded so far.
+func (r *Recorder) Transcript() *Transcript {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]Entry, len(r.entries))
+	for i, e := range r.entries {
+		e.Payload = append([]byte(nil), e.Payload...)
+		entries[i] = e
+	}
+	return &Transcript{Version: FormatVersion, Self: r.self, Label: r.label, Entries: entries}
+}
+
+// Reset discards recorded messages so the recorder can capture another job.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+}
+
+func (r *Recorder) record(dir Direction, peer cbmpc.RoleID, msg []byte) {
+	e := Entry{Dir: dir, Peer: peer, Size: len(msg)}
+	payload := msg
+	if r.redact != nil {
+		payload = r.redact(dir, peer, msg)
+	}
+	if payload == nil {
+		e.Redacted = true
+	} else {
+		e.Payload = append([]byte(nil), payload...)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, e)
+}
+
+var _ cbmpc.Transport = (*Recorder)(nil)
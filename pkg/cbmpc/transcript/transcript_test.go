@@ -0,0 +1,85 @@
+package transcript_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/mocknet"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/transcript"
+)
+
+func TestRecorderRedaction(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	net := mocknet.New()
+	rec, err := transcript.NewRecorder(net.EpMP(0, []cbmpc.RoleID{0, 1, 2}), 0, "toy", transcript.RedactSent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer1 := net.EpMP(1, []cbmpc.RoleID{0, 1, 2})
+	peer2 := net.EpMP(2, []cbmpc.RoleID{0, 1, 2})
+
+	if err := rec.Send(ctx, 1, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := peer1.Receive(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	_ = peer2.Send(ctx, 0, []byte("from2"))
+	_ = peer1.Send(ctx, 0, []byte("from1"))
+	if _, err := rec.ReceiveAll(ctx, []cbmpc.RoleID{2, 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	tr := rec.Transcript()
+	want := []transcript.Entry{
+		{Dir: transcript.Sent, Peer: 1, Size: 5, Redacted: true},
+		{Dir: transcript.Received, Peer: 1, Size: 5, Payload: []byte("from1")},
+		{Dir: transcript.Received, Peer: 2, Size: 5, Payload: []byte("from2")},
+	}
+	if len(tr.Entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(tr.Entries), len(want))
+	}
+	for i, e := range tr.Entries {
+		w := want[i]
+		if e.Dir != w.Dir || e.Peer != w.Peer || e.Size != w.Size || e.Redacted != w.Redacted || string(e.Payload) != string(w.Payload) {
+			t.Errorf("entry %d = %+v, want %+v", i, e, w)
+		}
+	}
+
+	data, err := json.Marshal(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := transcript.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Self != 0 || parsed.Label != "toy" || len(parsed.Entries) != 3 || string(parsed.Entries[2].Payload) != "from2" {
+		t.Fatalf("round trip mismatch: %+v", parsed)
+	}
+
+	rec.Reset()
+	if n := len(rec.Transcript().Entries); n != 0 {
+		t.Fatalf("Reset left %d entries", n)
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for name, data := range map[string]string{
+		"version":   `{"version":2,"self":0,"entries":[]}`,
+		"direction": `{"version":1,"self":0,"entries":[{"dir":"sideways","peer":1,"size":0}]}`,
+		"json":      `{`,
+	} {
+		if _, err := transcript.Parse([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := transcript.NewRecorder(nil, 0, "", nil); err == nil {
+		t.Error("nil transport should fail")
+	}
+}
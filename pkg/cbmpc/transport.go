@@ -40,6 +40,16 @@ func (r Role) peer() RoleID {
 // calls, even in two-party settings. For ReceiveAll, the returned map MUST
 // contain exactly one entry per requested role; missing entries are treated as
 // an error in the bindings layer.
+//
+// Ordering: Messages between each ordered pair of parties MUST be delivered
+// exactly once and in the order they were sent. Protocol messages carry no
+// sequence numbers of their own, so a transport that redelivers a message
+// (an at-least-once broker, a retry after reconnect) makes the receiving party
+// treat it as the next round's message. Implementations number the messages
+// they send to each peer and fail Receive and ReceiveAll with ErrReplay when
+// a message arrives out of sequence; mocknet and tlsnet do this. A
+// transport that cannot number messages itself can be wrapped with
+// Sequenced.
 type Transport interface {
 	Send(ctx context.Context, to RoleID, msg []byte) error
 	Receive(ctx context.Context, from RoleID) ([]byte, error)
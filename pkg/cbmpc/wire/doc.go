@@ -0,0 +1,32 @@
+// Package wire defines language-neutral encodings of the artifacts the
+// library stores and exchanges, so that services written in other languages
+// (for example a Java coordinator in front of Go signers) can handle them
+// without reverse-engineering byte layouts.
+//
+// The schemas are in wire.proto, next to this file:
+//
+//   - KeyShare: a key share from Key.Bytes in ecdsa2p, ecdsamp, schnorr2p or
+//     schnorrmp (ParseKeyShare, KeyShare.Bytes).
+//   - Ciphertext: a PVE ciphertext envelope from pve.Marshal
+//     (ParseCiphertext, Ciphertext.Bytes).
+//   - Proof: a zero-knowledge proof envelope from zk.Marshal (ParseProof,
+//     Proof.Bytes).
+//   - PolicyNode: an access structure expression (PolicyFromExpr,
+//     PolicyNode.Expr).
+//
+// Each message type has MarshalProto and UnmarshalProto for the protocol
+// buffer binary encoding and implements json.Marshaler and json.Unmarshaler
+// with the proto3 JSON mapping, so code generated from wire.proto reads what
+// this package writes and the other way round. The encoders are hand-written;
+// the package has no protobuf dependency.
+//
+//	data, err := key.Bytes()
+//	share, err := wire.ParseKeyShare(data)
+//	msg, err := share.MarshalProto() // send to the coordinator
+//
+// The payloads themselves (Share, Payload) stay in the native library's
+// format; only the library can interpret them.
+//
+// KeyShare messages contain private key shares in every encoding. Encrypt
+// them at rest and in transit.
+package wire
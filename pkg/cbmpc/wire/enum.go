@@ -0,0 +1,79 @@
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// enum holds the names of a wire.proto enum, indexed by value.
+type enum []string
+
+var (
+	curveEnum = enum{
+		"CURVE_UNSPECIFIED",
+		"CURVE_P256",
+		"CURVE_P384",
+		"CURVE_P521",
+		"CURVE_SECP256K1",
+		"CURVE_ED25519",
+	}
+	keyProtocolEnum = enum{
+		"KEY_PROTOCOL_UNSPECIFIED",
+		"KEY_PROTOCOL_ECDSA2P",
+		"KEY_PROTOCOL_ECDSAMP",
+		"KEY_PROTOCOL_SCHNORR2P",
+		"KEY_PROTOCOL_SCHNORRMP",
+	}
+	ciphertextTypeEnum = enum{
+		"CIPHERTEXT_TYPE_UNSPECIFIED",
+		"CIPHERTEXT_TYPE_SINGLE",
+		"CIPHERTEXT_TYPE_BATCH",
+		"CIPHERTEXT_TYPE_AC",
+	}
+	proofTypeEnum = enum{
+		"PROOF_TYPE_UNSPECIFIED",
+		"PROOF_TYPE_DL",
+		"PROOF_TYPE_BATCH_DL",
+		"PROOF_TYPE_DH",
+		"PROOF_TYPE_ELGAMAL_COM",
+		"PROOF_TYPE_ELGAMAL_COM_PUB_SHARE_EQU",
+		"PROOF_TYPE_ELGAMAL_COM_MULT",
+		"PROOF_TYPE_UC_ELGAMAL_COM_MULT_PRIVATE_SCALAR",
+		"PROOF_TYPE_VALID_PAILLIER",
+		"PROOF_TYPE_PAILLIER_ZERO",
+		"PROOF_TYPE_TWO_PAILLIER_EQUAL",
+		"PROOF_TYPE_PAILLIER_RANGE_EXP_SLACK",
+		"PROOF_TYPE_RANGE",
+		"PROOF_TYPE_PAILLIER_EC",
+	}
+)
+
+// json returns the proto3 JSON form of v: its name, or its number if the
+// enum has no name for it.
+func (e enum) json(v int) json.RawMessage {
+	if v >= 0 && v < len(e) {
+		return json.RawMessage(`"` + e[v] + `"`)
+	}
+	return json.RawMessage(fmt.Sprint(v))
+}
+
+// parse reads a proto3 JSON enum value, a name or a number. A missing value
+// is the zero value.
+func (e enum) parse(raw json.RawMessage) (int, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, nil
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		if i := slices.Index(e, name); i >= 0 {
+			return i, nil
+		}
+		return 0, fmt.Errorf("%w: unknown enum value %q", ErrMalformed, name)
+	}
+	var v int32
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return 0, fmt.Errorf("%w: bad enum value %s", ErrMalformed, raw)
+	}
+	return int(v), nil
+}
@@ -0,0 +1,263 @@
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/zk"
+)
+
+// Ciphertext is a PVE ciphertext envelope, as written by pve.Marshal.
+type Ciphertext struct {
+	Type    pve.CiphertextType
+	Curve   cbmpc.Curve
+	Version uint16 // Payload format version
+	Payload []byte // The raw ciphertext, as returned by Encrypt
+}
+
+// ParseCiphertext decodes the output of pve.Marshal.
+func ParseCiphertext(data []byte) (*Ciphertext, error) {
+	env, err := pve.ParseEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Ciphertext{
+		Type:    env.Type,
+		Curve:   env.Curve,
+		Version: env.Version,
+		Payload: append([]byte(nil), env.Payload...),
+	}, nil
+}
+
+// Bytes returns the envelope pve.Unmarshal reads.
+func (c *Ciphertext) Bytes() ([]byte, error) {
+	return c.msg().bytes(envelope.DomainPVE)
+}
+
+// MarshalProto encodes c as a Ciphertext message of wire.proto.
+func (c *Ciphertext) MarshalProto() ([]byte, error) { return c.msg().proto() }
+
+// UnmarshalProto decodes a Ciphertext message of wire.proto into c.
+func (c *Ciphertext) UnmarshalProto(b []byte) error {
+	var m envelopeMsg
+	if err := m.parseProto(b); err != nil {
+		return err
+	}
+	c.set(m)
+	return nil
+}
+
+// MarshalJSON encodes c in the proto3 JSON form of a Ciphertext message.
+func (c Ciphertext) MarshalJSON() ([]byte, error) { return c.msg().json(ciphertextTypeEnum) }
+
+// UnmarshalJSON decodes the proto3 JSON form of a Ciphertext message into c.
+func (c *Ciphertext) UnmarshalJSON(data []byte) error {
+	var m envelopeMsg
+	if err := m.parseJSON(ciphertextTypeEnum, data); err != nil {
+		return err
+	}
+	c.set(m)
+	return nil
+}
+
+func (c *Ciphertext) msg() envelopeMsg {
+	return envelopeMsg{typ: int(c.Type), curve: c.Curve, version: c.Version, payload: c.Payload}
+}
+
+func (c *Ciphertext) set(m envelopeMsg) {
+	*c = Ciphertext{Type: pve.CiphertextType(m.typ), Curve: m.curve, Version: m.version, Payload: m.payload}
+}
+
+// Proof is a zero-knowledge proof envelope, as written by zk.Marshal.
+type Proof struct {
+	Type    zk.ProofType
+	Curve   cbmpc.Curve // CurveUnknown for proofs not tied to a curve
+	Version uint16      // Payload format version
+	Payload []byte      // The raw proof, as returned by the Prove function
+}
+
+// ParseProof decodes the output of zk.Marshal.
+func ParseProof(data []byte) (*Proof, error) {
+	env, err := zk.ParseEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{
+		Type:    env.Type,
+		Curve:   env.Curve,
+		Version: env.Version,
+		Payload: append([]byte(nil), env.Payload...),
+	}, nil
+}
+
+// Bytes returns the envelope zk.Unmarshal reads.
+func (p *Proof) Bytes() ([]byte, error) {
+	return p.msg().bytes(envelope.DomainZK)
+}
+
+// MarshalProto encodes p as a Proof message of wire.proto.
+func (p *Proof) MarshalProto() ([]byte, error) { return p.msg().proto() }
+
+// UnmarshalProto decodes a Proof message of wire.proto into p.
+func (p *Proof) UnmarshalProto(b []byte) error {
+	var m envelopeMsg
+	if err := m.parseProto(b); err != nil {
+		return err
+	}
+	p.set(m)
+	return nil
+}
+
+// MarshalJSON encodes p in the proto3 JSON form of a Proof message.
+func (p Proof) MarshalJSON() ([]byte, error) { return p.msg().json(proofTypeEnum) }
+
+// UnmarshalJSON decodes the proto3 JSON form of a Proof message into p.
+func (p *Proof) UnmarshalJSON(data []byte) error {
+	var m envelopeMsg
+	if err := m.parseJSON(proofTypeEnum, data); err != nil {
+		return err
+	}
+	p.set(m)
+	return nil
+}
+
+func (p *Proof) msg() envelopeMsg {
+	return envelopeMsg{typ: int(p.Type), curve: p.Curve, version: p.Version, payload: p.Payload}
+}
+
+func (p *Proof) set(m envelopeMsg) {
+	*p = Proof{Type: zk.ProofType(m.typ), Curve: m.curve, Version: m.version, Payload: m.payload}
+}
+
+// envelopeMsg holds the fields Ciphertext and Proof share, in the same order
+// and with the same field numbers in wire.proto.
+type envelopeMsg struct {
+	typ     int
+	curve   cbmpc.Curve
+	version uint16
+	payload []byte
+}
+
+func (m envelopeMsg) validate() error {
+	if m.typ <= 0 || m.typ > 255 {
+		return fmt.Errorf("%w: bad type %d", ErrMalformed, m.typ)
+	}
+	if m.curve < 0 || m.curve > 255 {
+		return fmt.Errorf("%w: invalid curve %d", ErrMalformed, int(m.curve))
+	}
+	if len(m.payload) == 0 {
+		return fmt.Errorf("%w: empty payload", ErrMalformed)
+	}
+	return nil
+}
+
+func (m envelopeMsg) bytes(domain uint8) ([]byte, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return envelope.Encode(envelope.Header{
+		Domain:  domain,
+		Type:    uint8(m.typ),
+		Curve:   uint8(m.curve),
+		Version: m.version,
+	}, m.payload)
+}
+
+func (m envelopeMsg) proto() ([]byte, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	var b []byte
+	b = appendUint(b, 1, uint64(m.typ))
+	b = appendUint(b, 2, uint64(m.curve))
+	b = appendUint(b, 3, uint64(m.version))
+	b = appendBytes(b, 4, m.payload)
+	return b, nil
+}
+
+func (m *envelopeMsg) parseProto(b []byte) error {
+	var out envelopeMsg
+	err := parseFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			v, err := f.uint32Value()
+			if err != nil {
+				return err
+			}
+			out.typ = int(v)
+		case 2:
+			c, err := curveValue(f)
+			if err != nil {
+				return err
+			}
+			out.curve = c
+		case 3:
+			v, err := f.uint32Value()
+			if err != nil {
+				return err
+			}
+			if v > 1<<16-1 {
+				return fmt.Errorf("%w: bad version %d", ErrMalformed, v)
+			}
+			out.version = uint16(v)
+		case 4:
+			v, err := f.bytesValue()
+			if err != nil {
+				return err
+			}
+			out.payload = v
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := out.validate(); err != nil {
+		return err
+	}
+	*m = out
+	return nil
+}
+
+type envelopeJSON struct {
+	Type    json.RawMessage `json:"type,omitempty"`
+	Curve   json.RawMessage `json:"curve,omitempty"`
+	Version uint16          `json:"version,omitempty"`
+	Payload []byte          `json:"payload,omitempty"`
+}
+
+func (m envelopeMsg) json(types enum) ([]byte, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelopeJSON{
+		Type:    enumField(types, m.typ),
+		Curve:   enumField(curveEnum, int(m.curve)),
+		Version: m.version,
+		Payload: m.payload,
+	})
+}
+
+func (m *envelopeMsg) parseJSON(types enum, data []byte) error {
+	var in envelopeJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	t, err := types.parse(in.Type)
+	if err != nil {
+		return err
+	}
+	c, err := curveEnum.parse(in.Curve)
+	if err != nil {
+		return err
+	}
+	out := envelopeMsg{typ: t, curve: cbmpc.Curve(c), version: in.Version, payload: in.Payload}
+	if err := out.validate(); err != nil {
+		return err
+	}
+	*m = out
+	return nil
+}
@@ -0,0 +1,261 @@
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+)
+
+// KeyProtocol identifies the protocol package a key share belongs to.
+type KeyProtocol uint8
+
+const (
+	KeyECDSA2P   = KeyProtocol(envelope.KeyECDSA2P)
+	KeyECDSAMP   = KeyProtocol(envelope.KeyECDSAMP)
+	KeySchnorr2P = KeyProtocol(envelope.KeySchnorr2P)
+	KeySchnorrMP = KeyProtocol(envelope.KeySchnorrMP)
+)
+
+// String returns the protocol package name, e.g. "ecdsa2p".
+func (p KeyProtocol) String() string { return envelope.KeyProtocolName(uint8(p)) }
+
+// KeyShare is a key share as serialized by Key.Bytes in the protocol
+// packages, split into its metadata and the native serialization.
+//
+// SECURITY WARNING: Share holds the private key share, in the protocol buffer
+// and JSON encodings too. Encrypt KeyShare messages at rest and in transit and
+// zeroize them after use.
+type KeyShare struct {
+	Protocol KeyProtocol
+	Curve    cbmpc.Curve
+	// Role is "p1" or "p2" for 2-party keys and the party name for
+	// multi-party keys.
+	Role    string
+	Created time.Time // zero if unknown
+	Share   []byte    // native serialization
+}
+
+// ParseKeyShare decodes the output of Key.Bytes or Key.ProtectedBytes.
+// Shares serialized before keys carried an envelope are rejected, since
+// their protocol is unknown; load them with the right package's LoadKey and
+// serialize them again.
+func ParseKeyShare(data []byte) (*KeyShare, error) {
+	h, _, err := envelope.Decode(envelope.DomainKey, data)
+	if err != nil {
+		return nil, err
+	}
+	meta, share, err := envelope.DecodeKey(h.Type, data)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyShare{
+		Protocol: KeyProtocol(meta.Protocol),
+		Curve:    cbmpc.Curve(meta.Curve),
+		Role:     meta.Role,
+		Created:  meta.Created,
+		Share:    append([]byte(nil), share...),
+	}, nil
+}
+
+// Bytes returns the key share in the form LoadKey of its protocol package
+// reads.
+func (k *KeyShare) Bytes() ([]byte, error) {
+	if err := k.validate(); err != nil {
+		return nil, err
+	}
+	return envelope.EncodeKey(envelope.KeyMeta{
+		Protocol: uint8(k.Protocol),
+		Curve:    uint8(k.Curve),
+		Role:     k.Role,
+		Created:  k.Created,
+	}, k.Share)
+}
+
+func (k *KeyShare) validate() error {
+	if k.Protocol == 0 {
+		return fmt.Errorf("%w: key share without protocol", ErrMalformed)
+	}
+	if k.Curve < 0 || k.Curve > 255 {
+		return fmt.Errorf("%w: invalid curve %d", ErrMalformed, int(k.Curve))
+	}
+	if len(k.Share) == 0 {
+		return fmt.Errorf("%w: empty key share", ErrMalformed)
+	}
+	return nil
+}
+
+// MarshalProto encodes k as a KeyShare message of wire.proto.
+func (k *KeyShare) MarshalProto() ([]byte, error) {
+	if err := k.validate(); err != nil {
+		return nil, err
+	}
+	var b []byte
+	b = appendUint(b, 1, uint64(k.Protocol))
+	b = appendUint(b, 2, uint64(k.Curve))
+	b = appendBytes(b, 3, []byte(k.Role))
+	if !k.Created.IsZero() {
+		b = appendMessage(b, 4, marshalTimestamp(k.Created))
+	}
+	b = appendBytes(b, 5, k.Share)
+	return b, nil
+}
+
+// UnmarshalProto decodes a KeyShare message of wire.proto into k.
+func (k *KeyShare) UnmarshalProto(b []byte) error {
+	var out KeyShare
+	err := parseFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			v, err := f.uint32Value()
+			if err != nil || v > 255 {
+				return fmt.Errorf("%w: bad protocol", ErrMalformed)
+			}
+			out.Protocol = KeyProtocol(v)
+		case 2:
+			c, err := curveValue(f)
+			if err != nil {
+				return err
+			}
+			out.Curve = c
+		case 3:
+			if err := f.expect(wireBytes); err != nil {
+				return err
+			}
+			out.Role = string(f.data)
+		case 4:
+			if err := f.expect(wireBytes); err != nil {
+				return err
+			}
+			t, err := unmarshalTimestamp(f.data)
+			if err != nil {
+				return err
+			}
+			out.Created = t
+		case 5:
+			v, err := f.bytesValue()
+			if err != nil {
+				return err
+			}
+			out.Share = v
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := out.validate(); err != nil {
+		return err
+	}
+	*k = out
+	return nil
+}
+
+type keyShareJSON struct {
+	Protocol json.RawMessage `json:"protocol,omitempty"`
+	Curve    json.RawMessage `json:"curve,omitempty"`
+	Role     string          `json:"role,omitempty"`
+	Created  *time.Time      `json:"created,omitempty"`
+	Share    []byte          `json:"share,omitempty"`
+}
+
+// MarshalJSON encodes k in the proto3 JSON form of a KeyShare message.
+func (k KeyShare) MarshalJSON() ([]byte, error) {
+	if err := k.validate(); err != nil {
+		return nil, err
+	}
+	out := keyShareJSON{
+		Protocol: enumField(keyProtocolEnum, int(k.Protocol)),
+		Curve:    enumField(curveEnum, int(k.Curve)),
+		Role:     k.Role,
+		Share:    k.Share,
+	}
+	if !k.Created.IsZero() {
+		t := k.Created.UTC()
+		out.Created = &t
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes the proto3 JSON form of a KeyShare message into k.
+func (k *KeyShare) UnmarshalJSON(data []byte) error {
+	var in keyShareJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	p, err := keyProtocolEnum.parse(in.Protocol)
+	if err != nil {
+		return err
+	}
+	if p < 0 || p > 255 {
+		return fmt.Errorf("%w: bad protocol", ErrMalformed)
+	}
+	c, err := curveEnum.parse(in.Curve)
+	if err != nil {
+		return err
+	}
+	out := KeyShare{Protocol: KeyProtocol(p), Curve: cbmpc.Curve(c), Role: in.Role, Share: in.Share}
+	if in.Created != nil {
+		out.Created = in.Created.UTC()
+	}
+	if err := out.validate(); err != nil {
+		return err
+	}
+	*k = out
+	return nil
+}
+
+// enumField is e.json(v), or nothing for the zero value, which proto3 JSON
+// omits.
+func enumField(e enum, v int) json.RawMessage {
+	if v == 0 {
+		return nil
+	}
+	return e.json(v)
+}
+
+func curveValue(f field) (cbmpc.Curve, error) {
+	v, err := f.uint32Value()
+	if err != nil {
+		return 0, err
+	}
+	if v > 255 {
+		return 0, fmt.Errorf("%w: bad curve %d", ErrMalformed, v)
+	}
+	return cbmpc.Curve(v), nil
+}
+
+// marshalTimestamp encodes t as a google.protobuf.Timestamp.
+func marshalTimestamp(t time.Time) []byte {
+	var b []byte
+	b = appendInt(b, 1, t.Unix())
+	b = appendInt(b, 2, int64(t.Nanosecond()))
+	return b
+}
+
+func unmarshalTimestamp(b []byte) (time.Time, error) {
+	var sec, nsec int64
+	err := parseFields(b, func(f field) error {
+		if f.num != 1 && f.num != 2 {
+			return nil
+		}
+		if err := f.expect(wireVarint); err != nil {
+			return err
+		}
+		if f.num == 1 {
+			sec = int64(f.v)
+		} else {
+			nsec = int64(int32(f.v))
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if nsec < 0 || nsec >= 1e9 {
+		return time.Time{}, fmt.Errorf("%w: bad timestamp", ErrMalformed)
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
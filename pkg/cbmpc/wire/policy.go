@@ -0,0 +1,112 @@
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+)
+
+// maxPolicyDepth bounds the nesting of decoded policies.
+const maxPolicyDepth = 64
+
+// PolicyNode is an access structure expression. Its JSON form is the policy
+// document accepted by accessstructure.FromJSON.
+type PolicyNode struct {
+	Type     string        `json:"type"` // "leaf", "and", "or", "threshold" or "weighted"
+	Name     string        `json:"name,omitempty"`
+	K        uint32        `json:"k,omitempty"`
+	Weight   uint32        `json:"weight,omitempty"` // children of a weighted gate; 0 means 1
+	Children []*PolicyNode `json:"children,omitempty"`
+}
+
+// PolicyFromExpr returns the wire form of an access structure expression.
+func PolicyFromExpr(e accessstructure.Expr) (*PolicyNode, error) {
+	data, err := accessstructure.ToJSON(e)
+	if err != nil {
+		return nil, err
+	}
+	var n PolicyNode
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// Expr returns the expression n describes, validated as
+// accessstructure.FromJSON does, ready for accessstructure.Compile.
+func (n *PolicyNode) Expr() (accessstructure.Expr, error) {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return nil, err
+	}
+	return accessstructure.FromJSON(data)
+}
+
+// MarshalProto encodes n as a PolicyNode message of wire.proto.
+func (n *PolicyNode) MarshalProto() ([]byte, error) {
+	return n.appendProto(nil, 0)
+}
+
+func (n *PolicyNode) appendProto(b []byte, depth int) ([]byte, error) {
+	if depth > maxPolicyDepth {
+		return nil, fmt.Errorf("policy nested more than %d levels", maxPolicyDepth)
+	}
+	b = appendBytes(b, 1, []byte(n.Type))
+	b = appendBytes(b, 2, []byte(n.Name))
+	b = appendUint(b, 3, uint64(n.K))
+	b = appendUint(b, 4, uint64(n.Weight))
+	for i, c := range n.Children {
+		if c == nil {
+			return nil, fmt.Errorf("child %d is nil", i)
+		}
+		child, err := c.appendProto(nil, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		b = appendMessage(b, 5, child)
+	}
+	return b, nil
+}
+
+// UnmarshalProto decodes a PolicyNode message of wire.proto into n. It only
+// checks the encoding; Expr checks the policy itself.
+func (n *PolicyNode) UnmarshalProto(b []byte) error {
+	return n.parseProto(b, 0)
+}
+
+func (n *PolicyNode) parseProto(b []byte, depth int) error {
+	if depth > maxPolicyDepth {
+		return fmt.Errorf("%w: policy nested more than %d levels", ErrMalformed, maxPolicyDepth)
+	}
+	var out PolicyNode
+	err := parseFields(b, func(f field) error {
+		var err error
+		switch f.num {
+		case 1:
+			if err = f.expect(wireBytes); err == nil {
+				out.Type = string(f.data)
+			}
+		case 2:
+			if err = f.expect(wireBytes); err == nil {
+				out.Name = string(f.data)
+			}
+		case 3:
+			out.K, err = f.uint32Value()
+		case 4:
+			out.Weight, err = f.uint32Value()
+		case 5:
+			if err = f.expect(wireBytes); err == nil {
+				child := new(PolicyNode)
+				err = child.parseProto(f.data, depth+1)
+				out.Children = append(out.Children, child)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	*n = out
+	return nil
+}
@@ -0,0 +1,154 @@
+package wire
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Protocol buffer wire types used by the messages in wire.proto.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// ErrMalformed is wrapped by the error returned when decoding a message
+// that is not valid protocol buffer or JSON encoding of it.
+var ErrMalformed = errors.New("wire: malformed message")
+
+func appendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+func appendTag(b []byte, num, typ int) []byte {
+	return appendVarint(b, uint64(num)<<3|uint64(typ))
+}
+
+// appendUint appends a varint field, omitting it when v is zero as proto3
+// does.
+func appendUint(b []byte, num int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	return appendVarint(appendTag(b, num, wireVarint), v)
+}
+
+// appendInt appends an int64 varint field, omitting it when v is zero.
+func appendInt(b []byte, num int, v int64) []byte {
+	return appendUint(b, num, uint64(v))
+}
+
+// appendBytes appends a length-delimited field, omitting it when v is empty.
+func appendBytes(b []byte, num int, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	return appendMessage(b, num, v)
+}
+
+// appendMessage appends a length-delimited field even when v is empty, as
+// an embedded message that is present.
+func appendMessage(b []byte, num int, v []byte) []byte {
+	b = appendTag(b, num, wireBytes)
+	b = appendVarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func consumeVarint(b []byte) (uint64, int, error) {
+	var v uint64
+	for i := 0; i < len(b) && i < 10; i++ {
+		v |= uint64(b[i]&0x7f) << (7 * i)
+		if b[i] < 0x80 {
+			return v, i + 1, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("%w: bad varint", ErrMalformed)
+}
+
+// field is one decoded field. v holds varint values, data the contents of
+// length-delimited fields.
+type field struct {
+	num  int
+	typ  int
+	v    uint64
+	data []byte
+}
+
+// parseFields calls fn for each field of the message b. Fields of wire types
+// fn does not expect are rejected by fn; unknown fields can simply be
+// ignored, which keeps older readers compatible with newer writers.
+func parseFields(b []byte, fn func(f field) error) error {
+	for len(b) > 0 {
+		tag, n, err := consumeVarint(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		f := field{num: int(tag >> 3), typ: int(tag & 7)}
+		if f.num <= 0 || f.num > 1<<29-1 {
+			return fmt.Errorf("%w: bad field number", ErrMalformed)
+		}
+		switch f.typ {
+		case wireVarint:
+			if f.v, n, err = consumeVarint(b); err != nil {
+				return err
+			}
+		case wireFixed64:
+			n = 8
+		case wireFixed32:
+			n = 4
+		case wireBytes:
+			l, m, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			if l > uint64(len(b)-m) {
+				return fmt.Errorf("%w: field %d truncated", ErrMalformed, f.num)
+			}
+			f.data = b[m : m+int(l)]
+			n = m + int(l)
+		default:
+			return fmt.Errorf("%w: unsupported wire type %d", ErrMalformed, f.typ)
+		}
+		if n > len(b) {
+			return fmt.Errorf("%w: field %d truncated", ErrMalformed, f.num)
+		}
+		b = b[n:]
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expect fails unless f has wire type typ.
+func (f field) expect(typ int) error {
+	if f.typ != typ {
+		return fmt.Errorf("%w: field %d has wire type %d, want %d", ErrMalformed, f.num, f.typ, typ)
+	}
+	return nil
+}
+
+// uint32Value returns a varint field that must fit in 32 bits.
+func (f field) uint32Value() (uint32, error) {
+	if err := f.expect(wireVarint); err != nil {
+		return 0, err
+	}
+	if f.v > 1<<32-1 {
+		return 0, fmt.Errorf("%w: field %d out of range", ErrMalformed, f.num)
+	}
+	return uint32(f.v), nil
+}
+
+// bytesValue returns a copy of a length-delimited field.
+func (f field) bytesValue() ([]byte, error) {
+	if err := f.expect(wireBytes); err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), f.data...), nil
+}
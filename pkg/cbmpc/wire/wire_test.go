@@ -0,0 +1,215 @@
+package wire_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	ac "github.com/coinbase/cb-mpc-go/pkg/cbmpc/accessstructure"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/pve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/wire"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/zk"
+)
+
+func TestKeyShare(t *testing.T) {
+	want := &wire.KeyShare{
+		Protocol: wire.KeySchnorrMP,
+		Curve:    cbmpc.CurveEd25519,
+		Role:     "alice",
+		Created:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Share:    []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+	data, err := want.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := wire.ParseKeyShare(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkKeyShare(t, "ParseKeyShare", got, want)
+
+	msg, err := got.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromProto wire.KeyShare
+	if err := fromProto.UnmarshalProto(msg); err != nil {
+		t.Fatal(err)
+	}
+	checkKeyShare(t, "proto", &fromProto, want)
+
+	js, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const wantJSON = `{"protocol":"KEY_PROTOCOL_SCHNORRMP","curve":"CURVE_ED25519","role":"alice","created":"2024-05-01T12:00:00Z","share":"3q2+7w=="}`
+	if string(js) != wantJSON {
+		t.Fatalf("JSON = %s, want %s", js, wantJSON)
+	}
+	var fromJSON wire.KeyShare
+	if err := json.Unmarshal(js, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	checkKeyShare(t, "JSON", &fromJSON, want)
+
+	if _, err := wire.ParseKeyShare([]byte{1, 2, 3}); err == nil {
+		t.Error("ParseKeyShare accepted a bare share")
+	}
+}
+
+func checkKeyShare(t *testing.T, name string, got, want *wire.KeyShare) {
+	t.Helper()
+	if got.Protocol != want.Protocol || got.Curve != want.Curve || got.Role != want.Role ||
+		!got.Created.Equal(want.Created) || !bytes.Equal(got.Share, want.Share) {
+		t.Fatalf("%s: got %+v, want %+v", name, got, want)
+	}
+}
+
+func TestProofEncoding(t *testing.T) {
+	data, err := zk.Marshal(zk.ProofDL, cbmpc.CurveSecp256k1, []byte{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := wire.ParseProof(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The encoding generated protobuf code produces for the same message.
+	msg, err := p.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hex.EncodeToString(msg), "08011004180122020102"; got != want {
+		t.Fatalf("proto = %s, want %s", got, want)
+	}
+
+	// Unknown fields from a newer schema are skipped.
+	var q wire.Proof
+	if err := q.UnmarshalProto(append(msg, 0x30, 0x07)); err != nil {
+		t.Fatal(err)
+	}
+	back, err := q.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(back, data) {
+		t.Fatalf("envelope = %x, want %x", back, data)
+	}
+	if _, err := zk.Unmarshal(back, zk.ProofDL, cbmpc.CurveSecp256k1); err != nil {
+		t.Fatalf("zk.Unmarshal: %v", err)
+	}
+
+	js, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"PROOF_TYPE_DL","curve":"CURVE_SECP256K1","version":1,"payload":"AQI="}`; string(js) != want {
+		t.Fatalf("JSON = %s, want %s", js, want)
+	}
+	// proto3 JSON also allows enum numbers.
+	var r wire.Proof
+	if err := json.Unmarshal([]byte(`{"type":1,"curve":"CURVE_SECP256K1","version":1,"payload":"AQI="}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Type != zk.ProofDL || r.Curve != cbmpc.CurveSecp256k1 {
+		t.Fatalf("JSON with enum numbers: %+v", r)
+	}
+}
+
+func TestCiphertextRoundTrip(t *testing.T) {
+	data, err := pve.Marshal(pve.CiphertextAC, cbmpc.CurveP256, []byte("ciphertext"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := wire.ParseCiphertext(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := c.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromProto wire.Ciphertext
+	if err := fromProto.UnmarshalProto(msg); err != nil {
+		t.Fatal(err)
+	}
+	js, err := json.Marshal(fromProto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON wire.Ciphertext
+	if err := json.Unmarshal(js, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	back, err := fromJSON.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pve.Unmarshal(back, pve.CiphertextAC, cbmpc.CurveP256); err != nil {
+		t.Fatalf("pve.Unmarshal: %v", err)
+	}
+}
+
+func TestPolicyRoundTrip(t *testing.T) {
+	expr := ac.Threshold(2,
+		ac.Leaf("alice"),
+		ac.And(ac.Leaf("bob"), ac.Leaf("carol")),
+		ac.Weighted(2, map[ac.Expr]int{ac.Leaf("dave"): 2, ac.Leaf("erin"): 1}),
+	)
+	n, err := wire.PolicyFromExpr(expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := n.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back wire.PolicyNode
+	if err := back.UnmarshalProto(msg); err != nil {
+		t.Fatal(err)
+	}
+	got, err := back.Expr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON, _ := ac.ToJSON(expr)
+	gotJSON, _ := ac.ToJSON(got)
+	if !bytes.Equal(gotJSON, wantJSON) {
+		t.Fatalf("policy after round trip:\n%s\nwant:\n%s", gotJSON, wantJSON)
+	}
+
+	// The JSON form is the policy document accepted by FromJSON.
+	js, err := json.Marshal(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ac.FromJSON(js); err != nil {
+		t.Fatalf("FromJSON: %v", err)
+	}
+}
+
+func TestMalformed(t *testing.T) {
+	bad := map[string][]byte{
+		"truncated varint": {0x08},
+		"truncated bytes":  {0x22, 0x05, 0x01},
+		"wrong wire type":  {0x0a, 0x01, 0x01, 0x22, 0x01, 0x01},
+		"empty payload":    {0x08, 0x01},
+	}
+	for name, msg := range bad {
+		var p wire.Proof
+		if err := p.UnmarshalProto(msg); !errors.Is(err, wire.ErrMalformed) {
+			t.Errorf("%s: err = %v, want ErrMalformed", name, err)
+		}
+	}
+
+	var k wire.KeyShare
+	if err := json.Unmarshal([]byte(`{"protocol":"KEY_PROTOCOL_NOPE","share":"AQ=="}`), &k); !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("unknown enum name: err = %v", err)
+	}
+}
@@ -1,6 +1,6 @@
 package cbmpc
 
-import "runtime"
+import "github.com/coinbase/cb-mpc-go/pkg/cbmpc/secmem"
 
 // ZeroizeBytes overwrites the provided slice with zeros and prevents compiler
 // dead store elimination using runtime.KeepAlive.
@@ -12,10 +12,10 @@ import "runtime"
 //
 // The underlying cb-mpc C++ library also performs its own secure zeroization
 // of internal buffers using OpenSSL's OPENSSL_cleanse or platform-specific APIs.
+//
+// For secrets that must stay in memory for a while, package secmem keeps them
+// in locked, separately mapped buffers instead of on the Go heap. ZeroizeBytes
+// is secmem.Zero, so both wipe the same way.
 func ZeroizeBytes(buf []byte) {
-	for i := range buf {
-		buf[i] = 0
-	}
-	// Prevent dead store elimination per golang/go#33325
-	runtime.KeepAlive(buf)
+	secmem.Zero(buf)
 }
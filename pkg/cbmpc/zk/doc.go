@@ -17,6 +17,12 @@
 //   - Paillier-Zero: Proves that a Paillier ciphertext encrypts zero
 //   - Two-Paillier-Equal: Proves two Paillier ciphertexts (under different keys) encrypt the same value
 //   - Paillier-Range-Exp-Slack: Proves a Paillier ciphertext encrypts a value in valid range with slack
+//   - Range: Proves a Pedersen commitment (CommitPedersen) opens to a value in [0, 2^n)
+//   - Paillier-EC (PDL): Proves a Paillier ciphertext encrypts the discrete log of an EC point
+//
+// Range proofs are over Pedersen commitments in the library's unknown-order
+// group, not over EC ElGamal commitments; the library has no range proof for
+// the latter.
 //
 // # Usage
 //
@@ -38,5 +44,24 @@
 //	    Aux:       partyID,
 //	})
 //
+// VerifyManyDL checks many independent UC-DL proofs in one native call and
+// returns a per-proof error slice. Use it when a verifier handles many proofs
+// at once, to pay the CGO overhead once instead of once per proof. UC-Batch-DL
+// is different: it is a single proof covering several points.
+//
+// # Storing Proofs
+//
+// Proofs are raw native serializations. Marshal wraps a proof in a versioned
+// envelope (proof type, curve, payload format version). Unmarshal rejects a
+// proof of the wrong type or curve, or one written in a format this library
+// does not read, with ErrProofTypeMismatch, ErrCurveMismatch or
+// ErrEnvelopeVersion. Without the envelope, these cases surface as native
+// deserialization failures:
+//
+//	stored, err := zk.Marshal(zk.ProofDL, cbmpc.CurveSecp256k1, proof)
+//	// ...
+//	raw, err := zk.Unmarshal(stored, zk.ProofDL, cbmpc.CurveSecp256k1)
+//	err = zk.VerifyDL(&zk.DLVerifyParams{Proof: raw, ...})
+//
 // See pkg/cbmpc/zk/README.md for detailed protocol documentation and examples.
 package zk
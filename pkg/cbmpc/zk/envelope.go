@@ -0,0 +1,156 @@
+package zk
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/envelope"
+)
+
+// ProofType identifies the statement carried by a proof envelope. The values
+// are part of the stored format and must never be renumbered.
+type ProofType uint8
+
+const (
+	ProofDL                            ProofType = 1
+	ProofBatchDL                       ProofType = 2
+	ProofDH                            ProofType = 3
+	ProofElGamalCom                    ProofType = 4
+	ProofElGamalComPubShareEqu         ProofType = 5
+	ProofElGamalComMult                ProofType = 6
+	ProofUCElGamalComMultPrivateScalar ProofType = 7
+	ProofValidPaillier                 ProofType = 8
+	ProofPaillierZero                  ProofType = 9
+	ProofTwoPaillierEqual              ProofType = 10
+	ProofPaillierRangeExpSlack         ProofType = 11
+	ProofRange                         ProofType = 12
+	ProofPaillierEC                    ProofType = 13
+)
+
+// proofVersions holds the payload format version written for each proof
+// type. Bump an entry when the native serialization of that proof changes.
+var proofVersions = map[ProofType]uint16{
+	ProofDL:                            1,
+	ProofBatchDL:                       1,
+	ProofDH:                            1,
+	ProofElGamalCom:                    1,
+	ProofElGamalComPubShareEqu:         1,
+	ProofElGamalComMult:                1,
+	ProofUCElGamalComMultPrivateScalar: 1,
+	ProofValidPaillier:                 1,
+	ProofPaillierZero:                  1,
+	ProofTwoPaillierEqual:              1,
+	ProofPaillierRangeExpSlack:         1,
+	ProofRange:                         1,
+	ProofPaillierEC:                    1,
+}
+
+// String returns the proof name used in the package documentation.
+func (t ProofType) String() string {
+	switch t {
+	case ProofDL:
+		return "UC-DL"
+	case ProofBatchDL:
+		return "UC-Batch-DL"
+	case ProofDH:
+		return "DH"
+	case ProofElGamalCom:
+		return "UC-ElGamal-Com"
+	case ProofElGamalComPubShareEqu:
+		return "ElGamal-Com-PubShare-Equ"
+	case ProofElGamalComMult:
+		return "ElGamal-Com-Mult"
+	case ProofUCElGamalComMultPrivateScalar:
+		return "UC-ElGamal-Com-Mult-Private-Scalar"
+	case ProofValidPaillier:
+		return "Valid-Paillier"
+	case ProofPaillierZero:
+		return "Paillier-Zero"
+	case ProofTwoPaillierEqual:
+		return "Two-Paillier-Equal"
+	case ProofPaillierRangeExpSlack:
+		return "Paillier-Range-Exp-Slack"
+	case ProofRange:
+		return "Range"
+	case ProofPaillierEC:
+		return "Paillier-EC"
+	default:
+		return fmt.Sprintf("ProofType(%d)", uint8(t))
+	}
+}
+
+// Envelope errors. Unmarshal wraps one of these so callers can tell a proof
+// stored by a newer library or for a different statement apart from a
+// corrupted one.
+var (
+	ErrEnvelopeMalformed = envelope.ErrMalformed
+	ErrEnvelopeVersion   = envelope.ErrUnsupportedVersion
+	ErrProofTypeMismatch = envelope.ErrTypeMismatch
+	ErrCurveMismatch     = envelope.ErrCurveMismatch
+)
+
+// Envelope is a decoded proof envelope.
+type Envelope struct {
+	Type    ProofType
+	Curve   cbmpc.Curve // CurveUnknown for proofs not tied to a curve (Paillier, Range)
+	Version uint16      // Payload format version
+	Payload []byte      // The raw proof, as returned by the Prove function
+}
+
+// Marshal wraps a proof in a versioned envelope recording its type and curve,
+// for storage that must survive library upgrades. Pass cbmpc.CurveUnknown for
+// proofs that are not tied to a curve.
+func Marshal(t ProofType, c cbmpc.Curve, proof []byte) ([]byte, error) {
+	v, ok := proofVersions[t]
+	if !ok {
+		return nil, fmt.Errorf("unknown proof type %d", uint8(t))
+	}
+	if c < 0 || c > 255 {
+		return nil, fmt.Errorf("invalid curve %d", int(c))
+	}
+	if len(proof) == 0 {
+		return nil, errors.New("empty proof")
+	}
+	return envelope.Encode(envelope.Header{
+		Domain:  envelope.DomainZK,
+		Type:    uint8(t),
+		Curve:   uint8(c),
+		Version: v,
+	}, proof)
+}
+
+// ParseEnvelope decodes an envelope written by Marshal without checking what
+// it contains. The payload aliases data.
+func ParseEnvelope(data []byte) (*Envelope, error) {
+	h, payload, err := envelope.Decode(envelope.DomainZK, data)
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		Type:    ProofType(h.Type),
+		Curve:   cbmpc.Curve(h.Curve),
+		Version: h.Version,
+		Payload: payload,
+	}, nil
+}
+
+// Unmarshal decodes an envelope written by Marshal and returns the proof after
+// checking that it is a proof of type t over curve c in a format this library
+// understands. The result can be passed to the matching Verify function.
+func Unmarshal(data []byte, t ProofType, c cbmpc.Curve) ([]byte, error) {
+	env, err := ParseEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	if env.Type != t {
+		return nil, fmt.Errorf("%w: have %s, want %s", ErrProofTypeMismatch, env.Type, t)
+	}
+	if env.Curve != c {
+		return nil, fmt.Errorf("%w: have %s, want %s", ErrCurveMismatch, env.Curve, c)
+	}
+	if want := proofVersions[t]; env.Version != want {
+		return nil, fmt.Errorf("%w: %s proof format v%d, this library reads v%d", ErrEnvelopeVersion, t, env.Version, want)
+	}
+	return append([]byte(nil), env.Payload...), nil
+}
@@ -0,0 +1,80 @@
+package zk_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/zk"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	proof := []byte{0xde, 0xad, 0xbe, 0xef}
+	data, err := zk.Marshal(zk.ProofDL, cbmpc.CurveSecp256k1, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := zk.ParseEnvelope(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.Type != zk.ProofDL || env.Curve != cbmpc.CurveSecp256k1 || env.Version != 1 {
+		t.Fatalf("unexpected envelope header %+v", env)
+	}
+
+	got, err := zk.Unmarshal(data, zk.ProofDL, cbmpc.CurveSecp256k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, proof) {
+		t.Fatalf("payload = %x, want %x", got, proof)
+	}
+	got[0] = 0
+	if data[len(data)-len(proof)] != 0xde {
+		t.Fatal("Unmarshal result aliases the input")
+	}
+}
+
+func TestEnvelopeMismatches(t *testing.T) {
+	data, err := zk.Marshal(zk.ProofRange, cbmpc.CurveUnknown, []byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := zk.Unmarshal(data, zk.ProofDL, cbmpc.CurveUnknown); !errors.Is(err, zk.ErrProofTypeMismatch) {
+		t.Errorf("type mismatch: %v", err)
+	}
+	if _, err := zk.Unmarshal(data, zk.ProofRange, cbmpc.CurveP256); !errors.Is(err, zk.ErrCurveMismatch) {
+		t.Errorf("curve mismatch: %v", err)
+	}
+
+	newer := append([]byte(nil), data...)
+	newer[9] = 2 // payload version, low byte
+	if _, err := zk.Unmarshal(newer, zk.ProofRange, cbmpc.CurveUnknown); !errors.Is(err, zk.ErrEnvelopeVersion) {
+		t.Errorf("newer payload version: %v", err)
+	}
+	newer = append([]byte(nil), data...)
+	newer[4] = 9 // envelope format
+	if _, err := zk.ParseEnvelope(newer); !errors.Is(err, zk.ErrEnvelopeVersion) {
+		t.Errorf("newer envelope format: %v", err)
+	}
+
+	for name, bad := range map[string][]byte{
+		"raw proof": {0x01, 0x02, 0x03},
+		"truncated": data[:len(data)-1],
+		"trailing":  append(append([]byte(nil), data...), 0),
+	} {
+		if _, err := zk.ParseEnvelope(bad); !errors.Is(err, zk.ErrEnvelopeMalformed) {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+
+	if _, err := zk.Marshal(zk.ProofType(200), cbmpc.CurveP256, []byte{1}); err == nil {
+		t.Error("unknown proof type should fail")
+	}
+	if _, err := zk.Marshal(zk.ProofDL, cbmpc.CurveP256, nil); err == nil {
+		t.Error("empty proof should fail")
+	}
+}
@@ -0,0 +1,132 @@
+//go:build cgo && !windows
+
+package zk
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/paillier"
+)
+
+// PaillierECProof represents a zero-knowledge proof that a Paillier ciphertext encrypts the
+// discrete logarithm of an EC point: c = Enc(x; r) and Q = x*G. This is the "PDL" proof that
+// 2-party ECDSA uses to bind the encrypted key share to its public share.
+//
+// PaillierECProof is a value type ([]byte) that can be safely copied, passed across goroutines,
+// and serialized without resource management concerns. There is no Close() method or finalizer.
+type PaillierECProof []byte
+
+// PaillierECProveParams contains parameters for PDL proof generation.
+type PaillierECProveParams struct {
+	Paillier  *paillier.Paillier // The Paillier key used for encryption (must have private key)
+	C         []byte             // The ciphertext of X
+	Q         *curve.Point       // The public point Q = X*G
+	X         *curve.Scalar      // The plaintext, the discrete log of Q (witness)
+	R         []byte             // The randomness used to create the ciphertext (witness)
+	SessionID cbmpc.SessionID    // Session identifier for security
+	Aux       uint64             // Auxiliary data (e.g., party identifier)
+}
+
+// ProvePaillierEC creates a PDL proof that C encrypts the discrete log of Q.
+// The Paillier instance must have a private key.
+// Returns the proof as bytes - no Close() required, safe to copy and serialize.
+// See cb-mpc/src/cbmpc/zk/zk_paillier.h for protocol details.
+func ProvePaillierEC(params *PaillierECProveParams) (PaillierECProof, error) {
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	if params.Paillier == nil {
+		return nil, errors.New("nil paillier")
+	}
+	if len(params.C) == 0 {
+		return nil, errors.New("empty ciphertext")
+	}
+	if params.Q == nil {
+		return nil, errors.New("nil point")
+	}
+	if params.X == nil {
+		return nil, errors.New("nil plaintext")
+	}
+	if len(params.R) == 0 {
+		return nil, errors.New("empty randomness")
+	}
+	if params.SessionID.IsEmpty() {
+		return nil, errors.New("empty session ID")
+	}
+
+	handle := params.Paillier.Handle()
+	if handle == nil {
+		return nil, errors.New("paillier has been closed")
+	}
+	if !params.Paillier.HasPrivateKey() {
+		return nil, errors.New("paillier must have private key to prove")
+	}
+	qPoint := params.Q.CPtr()
+	if qPoint == nil {
+		return nil, errors.New("point has been freed")
+	}
+
+	proofBytes, err := backend.PDLProve(handle, params.C, qPoint, params.X.Bytes, params.R, params.SessionID.Bytes(), params.Aux)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+
+	runtime.KeepAlive(params.Q)
+	runtime.KeepAlive(params.X)
+	return PaillierECProof(proofBytes), nil
+}
+
+// PaillierECVerifyParams contains parameters for PDL proof verification.
+type PaillierECVerifyParams struct {
+	Proof     PaillierECProof    // The proof to verify
+	Paillier  *paillier.Paillier // The Paillier key (can be public key only)
+	C         []byte             // The ciphertext
+	Q         *curve.Point       // The point whose discrete log C is claimed to encrypt
+	SessionID cbmpc.SessionID    // Session identifier (must match the one used in Prove)
+	Aux       uint64             // Auxiliary data (must match the one used in Prove)
+}
+
+// VerifyPaillierEC verifies a PDL proof.
+// The Paillier instance can be a public key only (no private key required for verification).
+// See cb-mpc/src/cbmpc/zk/zk_paillier.h for protocol details.
+func VerifyPaillierEC(params *PaillierECVerifyParams) error {
+	if params == nil {
+		return errors.New("nil params")
+	}
+	if len(params.Proof) == 0 {
+		return errors.New("empty proof")
+	}
+	if params.Paillier == nil {
+		return errors.New("nil paillier")
+	}
+	if len(params.C) == 0 {
+		return errors.New("empty ciphertext")
+	}
+	if params.Q == nil {
+		return errors.New("nil point")
+	}
+	if params.SessionID.IsEmpty() {
+		return errors.New("empty session ID")
+	}
+
+	handle := params.Paillier.Handle()
+	if handle == nil {
+		return errors.New("paillier has been closed")
+	}
+	qPoint := params.Q.CPtr()
+	if qPoint == nil {
+		return errors.New("point has been freed")
+	}
+
+	err := backend.PDLVerify([]byte(params.Proof), handle, params.C, qPoint, params.SessionID.Bytes(), params.Aux)
+	runtime.KeepAlive(params.Q)
+	if err != nil {
+		return cbmpc.RemapError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+//go:build cgo && !windows
+
+package zk_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/paillier"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/zk"
+)
+
+// encryptWithRandomness computes c = (1 + x*N) * r^N mod N^2 so the test
+// knows the randomness, which Paillier.Encrypt does not return.
+func encryptWithRandomness(t *testing.T, p *paillier.Paillier, x []byte) (c, r []byte) {
+	t.Helper()
+	nBytes, err := p.GetN()
+	if err != nil {
+		t.Fatalf("GetN failed: %v", err)
+	}
+	n := new(big.Int).SetBytes(nBytes)
+	n2 := new(big.Int).Mul(n, n)
+
+	rr, err := rand.Int(rand.Reader, n)
+	if err != nil {
+		t.Fatalf("failed to generate randomness: %v", err)
+	}
+	gm := new(big.Int).Mul(new(big.Int).SetBytes(x), n)
+	gm.Add(gm, big.NewInt(1))
+	cc := new(big.Int).Exp(rr, n, n2)
+	cc.Mul(cc, gm).Mod(cc, n2)
+	return cc.Bytes(), rr.Bytes()
+}
+
+func TestPaillierECProveVerify(t *testing.T) {
+	p, err := paillier.Generate()
+	if err != nil {
+		t.Fatalf("paillier.Generate failed: %v", err)
+	}
+	defer p.Close()
+
+	x, err := curve.RandomScalar(curve.Secp256k1)
+	if err != nil {
+		t.Fatalf("RandomScalar failed: %v", err)
+	}
+	defer x.Free()
+	q, err := curve.MulGenerator(curve.Secp256k1, x)
+	if err != nil {
+		t.Fatalf("MulGenerator failed: %v", err)
+	}
+	defer q.Free()
+
+	c, r := encryptWithRandomness(t, p, x.Bytes)
+	sessionID := cbmpc.NewSessionID([]byte("paillier-ec-test"))
+	aux := uint64(42)
+
+	proof, err := zk.ProvePaillierEC(&zk.PaillierECProveParams{
+		Paillier:  p,
+		C:         c,
+		Q:         q,
+		X:         x,
+		R:         r,
+		SessionID: sessionID,
+		Aux:       aux,
+	})
+	if err != nil {
+		t.Fatalf("ProvePaillierEC failed: %v", err)
+	}
+	if len(proof) == 0 {
+		t.Fatal("proof is empty")
+	}
+
+	n, err := p.GetN()
+	if err != nil {
+		t.Fatalf("GetN failed: %v", err)
+	}
+	pub, err := paillier.FromPublicKey(n)
+	if err != nil {
+		t.Fatalf("FromPublicKey failed: %v", err)
+	}
+	defer pub.Close()
+
+	verify := func(q *curve.Point, sid cbmpc.SessionID, aux uint64) error {
+		return zk.VerifyPaillierEC(&zk.PaillierECVerifyParams{
+			Proof:     proof,
+			Paillier:  pub,
+			C:         c,
+			Q:         q,
+			SessionID: sid,
+			Aux:       aux,
+		})
+	}
+	if err := verify(q, sessionID, aux); err != nil {
+		t.Fatalf("VerifyPaillierEC failed: %v", err)
+	}
+
+	// The proof must not verify against a different point.
+	g, err := curve.Generator(curve.Secp256k1)
+	if err != nil {
+		t.Fatalf("Generator failed: %v", err)
+	}
+	defer g.Free()
+	if err := verify(g, sessionID, aux); err == nil {
+		t.Error("VerifyPaillierEC should fail with wrong point")
+	}
+	if err := verify(q, cbmpc.NewSessionID([]byte("other-session")), aux); err == nil {
+		t.Error("VerifyPaillierEC should fail with wrong session ID")
+	}
+	if err := verify(q, sessionID, aux+1); err == nil {
+		t.Error("VerifyPaillierEC should fail with wrong aux")
+	}
+}
+
+func TestPaillierECNilChecks(t *testing.T) {
+	p, err := paillier.Generate()
+	if err != nil {
+		t.Fatalf("paillier.Generate failed: %v", err)
+	}
+	defer p.Close()
+
+	x, err := curve.RandomScalar(curve.Secp256k1)
+	if err != nil {
+		t.Fatalf("RandomScalar failed: %v", err)
+	}
+	defer x.Free()
+	q, err := curve.MulGenerator(curve.Secp256k1, x)
+	if err != nil {
+		t.Fatalf("MulGenerator failed: %v", err)
+	}
+	defer q.Free()
+	c, r := encryptWithRandomness(t, p, x.Bytes)
+	sessionID := cbmpc.NewSessionID([]byte("paillier-ec-test"))
+
+	if _, err := zk.ProvePaillierEC(nil); err == nil {
+		t.Error("ProvePaillierEC should fail with nil params")
+	}
+	if err := zk.VerifyPaillierEC(nil); err == nil {
+		t.Error("VerifyPaillierEC should fail with nil params")
+	}
+
+	bad := map[string]*zk.PaillierECProveParams{
+		"nil paillier":    {C: c, Q: q, X: x, R: r, SessionID: sessionID},
+		"empty cipher":    {Paillier: p, Q: q, X: x, R: r, SessionID: sessionID},
+		"nil point":       {Paillier: p, C: c, X: x, R: r, SessionID: sessionID},
+		"nil plaintext":   {Paillier: p, C: c, Q: q, R: r, SessionID: sessionID},
+		"empty random":    {Paillier: p, C: c, Q: q, X: x, SessionID: sessionID},
+		"empty sessionID": {Paillier: p, C: c, Q: q, X: x, R: r},
+	}
+	for name, params := range bad {
+		if _, err := zk.ProvePaillierEC(params); err == nil {
+			t.Errorf("ProvePaillierEC should fail with %s", name)
+		}
+	}
+
+	// Proving requires the private key.
+	n, err := p.GetN()
+	if err != nil {
+		t.Fatalf("GetN failed: %v", err)
+	}
+	pub, err := paillier.FromPublicKey(n)
+	if err != nil {
+		t.Fatalf("FromPublicKey failed: %v", err)
+	}
+	defer pub.Close()
+	if _, err := zk.ProvePaillierEC(&zk.PaillierECProveParams{
+		Paillier: pub, C: c, Q: q, X: x, R: r, SessionID: sessionID,
+	}); err == nil {
+		t.Error("ProvePaillierEC should fail without private key")
+	}
+
+	if err := zk.VerifyPaillierEC(&zk.PaillierECVerifyParams{
+		Paillier: pub, C: c, Q: q, SessionID: sessionID,
+	}); err == nil {
+		t.Error("VerifyPaillierEC should fail with empty proof")
+	}
+}
@@ -0,0 +1,148 @@
+//go:build cgo && !windows
+
+package zk
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
+)
+
+// MaxRangeBits is the largest bit length accepted by ProveRange and VerifyRange.
+const MaxRangeBits = 256
+
+// RangeProof represents a zero-knowledge proof that a Pedersen commitment
+// opens to a value in [0, 2^Bits).
+//
+// RangeProof is a value type ([]byte) that can be safely copied, passed across goroutines,
+// and serialized without resource management concerns. There is no Close() method or finalizer.
+type RangeProof []byte
+
+// CommitPedersen commits to value (big-endian) with fresh randomness using the
+// library's fixed unknown-order Pedersen parameters: C = g^value * h^r mod N.
+// The commitment is hiding and binding; randomness is the opening and must be
+// kept secret together with value.
+// See cb-mpc/src/cbmpc/zk/zk_pedersen.h for implementation details.
+func CommitPedersen(value []byte) (commitment, randomness []byte, err error) {
+	if len(value) == 0 {
+		return nil, nil, errors.New("empty value")
+	}
+	commitment, randomness, err = backend.PedersenCommit(value)
+	if err != nil {
+		return nil, nil, cbmpc.RemapError(err)
+	}
+	return commitment, randomness, nil
+}
+
+// RangeProveParams contains parameters for range proof generation.
+type RangeProveParams struct {
+	Bits       int             // Range is [0, 2^Bits); 1 <= Bits <= MaxRangeBits
+	Commitment []byte          // Pedersen commitment from CommitPedersen
+	Value      []byte          // The committed value (big-endian, must be < 2^Bits)
+	Randomness []byte          // The randomness returned by CommitPedersen
+	SessionID  cbmpc.SessionID // Session identifier for security
+	Aux        uint64          // Auxiliary data (e.g., party identifier)
+}
+
+// ProveRange creates a proof that Commitment opens to a value in [0, 2^Bits)
+// without revealing the value.
+//
+// The underlying proof has statistical slack: a verifier is convinced the value
+// lies in a range a small factor wider than [0, 2^Bits). Choose Bits with
+// headroom below any bound that matters, e.g. a 64-bit balance cap when the
+// ledger tolerates values up to 2^128.
+//
+// Returns the proof as bytes - no Close() required, safe to copy and serialize.
+// See cb-mpc/src/cbmpc/zk/zk_pedersen.h for protocol details.
+func ProveRange(params *RangeProveParams) (RangeProof, error) {
+	if params == nil {
+		return nil, errors.New("nil params")
+	}
+	bound, err := rangeBound(params.Bits)
+	if err != nil {
+		return nil, err
+	}
+	if len(params.Commitment) == 0 {
+		return nil, errors.New("empty commitment")
+	}
+	if len(params.Value) == 0 {
+		return nil, errors.New("empty value")
+	}
+	if len(params.Randomness) == 0 {
+		return nil, errors.New("empty randomness")
+	}
+	if params.SessionID.IsEmpty() {
+		return nil, errors.New("empty session ID")
+	}
+	if new(big.Int).SetBytes(params.Value).Cmp(bound) >= 0 {
+		return nil, fmt.Errorf("value out of range [0, 2^%d)", params.Bits)
+	}
+
+	proofBytes, err := backend.RangePedersenProve(
+		bound.Bytes(),
+		params.Commitment,
+		params.Value,
+		params.Randomness,
+		params.SessionID.Bytes(),
+		params.Aux,
+	)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+
+	return RangeProof(proofBytes), nil
+}
+
+// RangeVerifyParams contains parameters for range proof verification.
+type RangeVerifyParams struct {
+	Proof      RangeProof      // The proof to verify
+	Bits       int             // Must match the one used in Prove
+	Commitment []byte          // The commitment claimed to open to a value in range
+	SessionID  cbmpc.SessionID // Session identifier (must match the one used in Prove)
+	Aux        uint64          // Auxiliary data (must match the one used in Prove)
+}
+
+// VerifyRange verifies a range proof.
+// See cb-mpc/src/cbmpc/zk/zk_pedersen.h for protocol details.
+func VerifyRange(params *RangeVerifyParams) error {
+	if params == nil {
+		return errors.New("nil params")
+	}
+	if len(params.Proof) == 0 {
+		return errors.New("empty proof")
+	}
+	bound, err := rangeBound(params.Bits)
+	if err != nil {
+		return err
+	}
+	if len(params.Commitment) == 0 {
+		return errors.New("empty commitment")
+	}
+	if params.SessionID.IsEmpty() {
+		return errors.New("empty session ID")
+	}
+
+	err = backend.RangePedersenVerify(
+		[]byte(params.Proof),
+		bound.Bytes(),
+		params.Commitment,
+		params.SessionID.Bytes(),
+		params.Aux,
+	)
+	if err != nil {
+		return cbmpc.RemapError(err)
+	}
+
+	return nil
+}
+
+// rangeBound returns 2^bits after validating bits.
+func rangeBound(bits int) (*big.Int, error) {
+	if bits < 1 || bits > MaxRangeBits {
+		return nil, fmt.Errorf("range bits must be in [1, %d] (got %d)", MaxRangeBits, bits)
+	}
+	return new(big.Int).Lsh(big.NewInt(1), uint(bits)), nil
+}
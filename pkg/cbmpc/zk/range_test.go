@@ -0,0 +1,150 @@
+//go:build cgo && !windows
+
+package zk_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/zk"
+)
+
+func newRangeSessionID(t *testing.T) cbmpc.SessionID {
+	t.Helper()
+	sessionIDBytes := make([]byte, 32)
+	if _, err := rand.Read(sessionIDBytes); err != nil {
+		t.Fatalf("failed to generate session ID: %v", err)
+	}
+	return cbmpc.NewSessionID(sessionIDBytes)
+}
+
+func TestRangeProveVerify(t *testing.T) {
+	sessionID := newRangeSessionID(t)
+	aux := uint64(7)
+
+	// A 64-bit balance.
+	value := new(big.Int).SetUint64(1_000_000_007).Bytes()
+	commitment, randomness, err := zk.CommitPedersen(value)
+	if err != nil {
+		t.Fatalf("CommitPedersen failed: %v", err)
+	}
+
+	proof, err := zk.ProveRange(&zk.RangeProveParams{
+		Bits:       64,
+		Commitment: commitment,
+		Value:      value,
+		Randomness: randomness,
+		SessionID:  sessionID,
+		Aux:        aux,
+	})
+	if err != nil {
+		t.Fatalf("ProveRange failed: %v", err)
+	}
+
+	if err := zk.VerifyRange(&zk.RangeVerifyParams{
+		Proof:      proof,
+		Bits:       64,
+		Commitment: commitment,
+		SessionID:  sessionID,
+		Aux:        aux,
+	}); err != nil {
+		t.Fatalf("VerifyRange failed: %v", err)
+	}
+
+	// Wrong aux must fail.
+	if err := zk.VerifyRange(&zk.RangeVerifyParams{
+		Proof:      proof,
+		Bits:       64,
+		Commitment: commitment,
+		SessionID:  sessionID,
+		Aux:        aux + 1,
+	}); err == nil {
+		t.Error("VerifyRange should fail with wrong aux")
+	}
+
+	// A different commitment must fail.
+	other, _, err := zk.CommitPedersen(value)
+	if err != nil {
+		t.Fatalf("CommitPedersen failed: %v", err)
+	}
+	if err := zk.VerifyRange(&zk.RangeVerifyParams{
+		Proof:      proof,
+		Bits:       64,
+		Commitment: other,
+		SessionID:  sessionID,
+		Aux:        aux,
+	}); err == nil {
+		t.Error("VerifyRange should fail with a different commitment")
+	}
+}
+
+func TestRangeProveRejectsOutOfRange(t *testing.T) {
+	sessionID := newRangeSessionID(t)
+
+	// 2^8 is outside [0, 2^8).
+	value := []byte{0x01, 0x00}
+	commitment, randomness, err := zk.CommitPedersen(value)
+	if err != nil {
+		t.Fatalf("CommitPedersen failed: %v", err)
+	}
+	if _, err := zk.ProveRange(&zk.RangeProveParams{
+		Bits:       8,
+		Commitment: commitment,
+		Value:      value,
+		Randomness: randomness,
+		SessionID:  sessionID,
+	}); err == nil {
+		t.Error("ProveRange should reject a value outside the range")
+	}
+}
+
+func TestRangeNilChecks(t *testing.T) {
+	sessionID := newRangeSessionID(t)
+	value := []byte{0x2a}
+	commitment, randomness, err := zk.CommitPedersen(value)
+	if err != nil {
+		t.Fatalf("CommitPedersen failed: %v", err)
+	}
+
+	if _, err := zk.ProveRange(nil); err == nil {
+		t.Error("ProveRange should fail with nil params")
+	}
+	if err := zk.VerifyRange(nil); err == nil {
+		t.Error("VerifyRange should fail with nil params")
+	}
+	if _, _, err := zk.CommitPedersen(nil); err == nil {
+		t.Error("CommitPedersen should fail with empty value")
+	}
+
+	valid := zk.RangeProveParams{
+		Bits:       16,
+		Commitment: commitment,
+		Value:      value,
+		Randomness: randomness,
+		SessionID:  sessionID,
+	}
+	for name, mutate := range map[string]func(*zk.RangeProveParams){
+		"zero bits":        func(p *zk.RangeProveParams) { p.Bits = 0 },
+		"too many bits":    func(p *zk.RangeProveParams) { p.Bits = zk.MaxRangeBits + 1 },
+		"empty commitment": func(p *zk.RangeProveParams) { p.Commitment = nil },
+		"empty value":      func(p *zk.RangeProveParams) { p.Value = nil },
+		"empty randomness": func(p *zk.RangeProveParams) { p.Randomness = nil },
+		"empty session ID": func(p *zk.RangeProveParams) { p.SessionID = cbmpc.SessionID{} },
+	} {
+		params := valid
+		mutate(&params)
+		if _, err := zk.ProveRange(&params); err == nil {
+			t.Errorf("ProveRange should fail with %s", name)
+		}
+	}
+
+	if err := zk.VerifyRange(&zk.RangeVerifyParams{
+		Bits:       16,
+		Commitment: commitment,
+		SessionID:  sessionID,
+	}); err == nil {
+		t.Error("VerifyRange should fail with empty proof")
+	}
+}
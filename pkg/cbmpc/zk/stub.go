@@ -4,6 +4,7 @@ package zk
 
 import (
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc"
+	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/curve"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/internal/backend"
 	"github.com/coinbase/cb-mpc-go/pkg/cbmpc/paillier"
 )
@@ -155,3 +156,85 @@ type PaillierRangeExpSlackVerifyParams struct {
 func VerifyPaillierRangeExpSlack(*PaillierRangeExpSlackVerifyParams) error {
 	return backend.ErrNotBuilt
 }
+
+// =====================
+// PaillierEC ZK proof stubs
+// =====================
+
+// PaillierECProof represents a zero-knowledge proof that a Paillier ciphertext encrypts the discrete log of an EC point (stub).
+type PaillierECProof []byte
+
+// PaillierECProveParams contains parameters for PDL proof generation (stub).
+type PaillierECProveParams struct {
+	Paillier  *paillier.Paillier
+	C         []byte
+	Q         *curve.Point
+	X         *curve.Scalar
+	R         []byte
+	SessionID cbmpc.SessionID
+	Aux       uint64
+}
+
+// ProvePaillierEC is a stub that returns ErrNotBuilt.
+func ProvePaillierEC(*PaillierECProveParams) (PaillierECProof, error) {
+	return nil, backend.ErrNotBuilt
+}
+
+// PaillierECVerifyParams contains parameters for PDL proof verification (stub).
+type PaillierECVerifyParams struct {
+	Proof     PaillierECProof
+	Paillier  *paillier.Paillier
+	C         []byte
+	Q         *curve.Point
+	SessionID cbmpc.SessionID
+	Aux       uint64
+}
+
+// VerifyPaillierEC is a stub that returns ErrNotBuilt.
+func VerifyPaillierEC(*PaillierECVerifyParams) error {
+	return backend.ErrNotBuilt
+}
+
+// =====================
+// Range ZK proof stubs
+// =====================
+
+// MaxRangeBits is the largest bit length accepted by ProveRange and VerifyRange.
+const MaxRangeBits = 256
+
+// RangeProof represents a zero-knowledge proof that a Pedersen commitment opens to a value in range (stub).
+type RangeProof []byte
+
+// CommitPedersen is a stub that returns ErrNotBuilt.
+func CommitPedersen([]byte) ([]byte, []byte, error) {
+	return nil, nil, backend.ErrNotBuilt
+}
+
+// RangeProveParams contains parameters for range proof generation (stub).
+type RangeProveParams struct {
+	Bits       int
+	Commitment []byte
+	Value      []byte
+	Randomness []byte
+	SessionID  cbmpc.SessionID
+	Aux        uint64
+}
+
+// ProveRange is a stub that returns ErrNotBuilt.
+func ProveRange(*RangeProveParams) (RangeProof, error) {
+	return nil, backend.ErrNotBuilt
+}
+
+// RangeVerifyParams contains parameters for range proof verification (stub).
+type RangeVerifyParams struct {
+	Proof      RangeProof
+	Bits       int
+	Commitment []byte
+	SessionID  cbmpc.SessionID
+	Aux        uint64
+}
+
+// VerifyRange is a stub that returns ErrNotBuilt.
+func VerifyRange(*RangeVerifyParams) error {
+	return backend.ErrNotBuilt
+}
@@ -113,3 +113,61 @@ func VerifyDL(params *DLVerifyParams) error {
 	runtime.KeepAlive(params.Point)
 	return nil
 }
+
+// VerifyManyDL verifies independent UC_DL proofs, each with its own point,
+// session ID and aux, in a single call into the native library. It is
+// equivalent to calling VerifyDL on each element but avoids one CGO
+// round-trip per proof, which dominates for verifier services checking large
+// numbers of small proofs.
+//
+// The returned slice has one entry per element of params: nil if that proof
+// verified, otherwise the reason it was rejected. One bad proof does not stop
+// the rest from being checked. The error return is reserved for failures that
+// affect the whole call, such as an empty batch.
+// See cb-mpc/src/cbmpc/zk/zk_ec.h for protocol details.
+func VerifyManyDL(params []*DLVerifyParams) ([]error, error) {
+	if len(params) == 0 {
+		return nil, errors.New("empty params")
+	}
+
+	results := make([]error, len(params))
+	idx := make([]int, 0, len(params))
+	proofs := make([][]byte, 0, len(params))
+	points := make([]backend.ECCPoint, 0, len(params))
+	sessionIDs := make([][]byte, 0, len(params))
+	auxs := make([]uint64, 0, len(params))
+	for i, p := range params {
+		switch {
+		case p == nil:
+			results[i] = errors.New("nil params")
+		case len(p.Proof) == 0:
+			results[i] = errors.New("empty proof")
+		case p.Point == nil:
+			results[i] = errors.New("nil point")
+		case p.SessionID.IsEmpty():
+			results[i] = errors.New("empty session ID")
+		case p.Point.CPtr() == nil:
+			results[i] = errors.New("point has been freed")
+		default:
+			idx = append(idx, i)
+			proofs = append(proofs, []byte(p.Proof))
+			points = append(points, p.Point.CPtr())
+			sessionIDs = append(sessionIDs, p.SessionID.Bytes())
+			auxs = append(auxs, p.Aux)
+		}
+	}
+	if len(idx) == 0 {
+		return results, nil
+	}
+
+	errs, err := backend.UCDLVerifyMany(proofs, points, sessionIDs, auxs)
+	if err != nil {
+		return nil, cbmpc.RemapError(err)
+	}
+	for j, i := range idx {
+		results[i] = cbmpc.RemapError(errs[j])
+	}
+
+	runtime.KeepAlive(params)
+	return results, nil
+}
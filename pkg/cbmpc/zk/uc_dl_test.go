@@ -423,3 +423,73 @@ func TestDLProofValueSemantics(t *testing.T) {
 		t.Fatal("Verify with empty proof should return error")
 	}
 }
+
+// TestVerifyManyDL checks that a single batched call reports per-proof results
+// matching VerifyDL, including for malformed entries.
+func TestVerifyManyDL(t *testing.T) {
+	const n = 4
+	sessionIDBytes := make([]byte, 32)
+	if _, err := rand.Read(sessionIDBytes); err != nil {
+		t.Fatalf("failed to generate session ID: %v", err)
+	}
+	sessionID := cbmpc.NewSessionID(sessionIDBytes)
+
+	params := make([]*zk.DLVerifyParams, 0, n+3)
+	for i := 0; i < n; i++ {
+		exponent, err := curve.RandomScalar(curve.P256)
+		if err != nil {
+			t.Fatalf("failed to generate exponent: %v", err)
+		}
+		defer exponent.Free()
+		point, err := curve.MulGenerator(curve.P256, exponent)
+		if err != nil {
+			t.Fatalf("failed to compute point: %v", err)
+		}
+		defer point.Free()
+
+		proof, err := zk.ProveDL(&zk.DLProveParams{
+			Point:     point,
+			Exponent:  exponent,
+			SessionID: sessionID,
+			Aux:       uint64(i),
+		})
+		if err != nil {
+			t.Fatalf("Prove %d failed: %v", i, err)
+		}
+		params = append(params, &zk.DLVerifyParams{
+			Proof:     proof,
+			Point:     point,
+			SessionID: sessionID,
+			Aux:       uint64(i),
+		})
+	}
+
+	// Wrong aux, garbage proof bytes and a nil entry.
+	wrongAux := *params[0]
+	wrongAux.Aux = 99
+	garbage := *params[1]
+	garbage.Proof = zk.DLProof{0x01, 0x02, 0x03}
+	params = append(params, &wrongAux, &garbage, nil)
+
+	results, err := zk.VerifyManyDL(params)
+	if err != nil {
+		t.Fatalf("VerifyManyDL failed: %v", err)
+	}
+	if len(results) != len(params) {
+		t.Fatalf("got %d results, want %d", len(results), len(params))
+	}
+	for i := 0; i < n; i++ {
+		if results[i] != nil {
+			t.Errorf("proof %d: unexpected error: %v", i, results[i])
+		}
+	}
+	for i := n; i < len(params); i++ {
+		if results[i] == nil {
+			t.Errorf("proof %d: expected error", i)
+		}
+	}
+
+	if _, err := zk.VerifyManyDL(nil); err == nil {
+		t.Fatal("VerifyManyDL with no params should return error")
+	}
+}